@@ -17,6 +17,7 @@ import (
 	"github.com/jonesrussell/north-cloud/ai-observer/internal/insights"
 	anthprovider "github.com/jonesrussell/north-cloud/ai-observer/internal/provider/anthropic"
 	"github.com/jonesrussell/north-cloud/ai-observer/internal/scheduler"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
@@ -31,6 +32,8 @@ const (
 
 // Start initializes and runs the ai-observer service.
 func Start() error {
+	defer crash.Start("ai-observer")()
+
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("config: %w", err)
@@ -97,7 +100,7 @@ func Start() error {
 		logger.Int("drift_interval_seconds", cfg.Observer.Categories.DriftIntervalSeconds),
 	)
 
-	go sched.Run(ctx)
+	crash.Go(log, "scheduler", func() { sched.Run(ctx) })
 
 	// Start health HTTP server alongside the scheduler.
 	srv := infragin.NewServerBuilder(cfg.Service.Name, cfg.Service.Port).
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+const (
+	// defaultAPIKeyTTL and maxAPIKeyTTL bound how long an API key is valid.
+	defaultAPIKeyTTL = 365 * 24 * time.Hour
+	maxAPIKeyTTL     = 2 * 365 * 24 * time.Hour
+
+	// maxAPIKeyRateLimit caps the per-minute rate limit of an API key.
+	maxAPIKeyRateLimit = 10000
+	// maxAPIKeyDailyQuota caps the daily quota of an API key.
+	maxAPIKeyDailyQuota = 10_000_000
+	// maxAPIKeyIndexes caps the index patterns of an API key.
+	maxAPIKeyIndexes = 20
+)
+
+// IssueAPIKeyRequest represents a request for a search API key.
+type IssueAPIKeyRequest struct {
+	// Name identifies the key holder, e.g. "partner-news".
+	Name string `binding:"required,max=64" json:"name"`
+	// RateLimit is requests per minute; 0 uses the search service default.
+	RateLimit int `json:"rate_limit,omitempty"`
+	// DailyQuota is requests per UTC day; 0 uses the search service default.
+	DailyQuota int `json:"daily_quota,omitempty"`
+	// Indexes are the classified content index patterns the key may search;
+	// empty allows all of them.
+	Indexes []string `json:"indexes,omitempty"`
+	// TTL is a Go duration such as "720h"; empty is one year.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// IssueAPIKeyResponse represents an issued search API key. The key itself
+// is not stored anywhere: it is only shown once.
+type IssueAPIKeyResponse struct {
+	Key        string    `json:"key"`
+	KeyID      string    `json:"key_id"`
+	Name       string    `json:"name"`
+	RateLimit  int       `json:"rate_limit"`
+	DailyQuota int       `json:"daily_quota"`
+	Indexes    []string  `json:"indexes"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// IssueAPIKey issues a search API key with its rate limit, daily quota, and
+// allowed index patterns. The caller must hold a full-access token.
+func (h *AuthHandler) IssueAPIKey(c *gin.Context) {
+	claims, ok := infrajwt.GetClaims(c)
+	if !ok || !claims.HasFullAccess() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "a full-access token is required to issue API keys"})
+		return
+	}
+
+	var req IssueAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	ttl, message := validateAPIKeyRequest(&req)
+	if message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
+	key, keyID, err := h.jwtManager.GenerateAPIKey(&infrajwt.APIKeyClaims{
+		Claims:     infrajwt.Claims{Sub: req.Name},
+		RateLimit:  req.RateLimit,
+		DailyQuota: req.DailyQuota,
+		Indexes:    req.Indexes,
+	}, ttl)
+	if err != nil {
+		h.log.Error("Failed to generate API key", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate API key"})
+		return
+	}
+
+	h.log.Info("Issued search API key",
+		logger.String("key_id", keyID),
+		logger.String("name", req.Name),
+		logger.Int("rate_limit", req.RateLimit),
+		logger.Int("daily_quota", req.DailyQuota),
+		logger.String("indexes", strings.Join(req.Indexes, ",")),
+		logger.Duration("ttl", ttl),
+		logger.String("issued_by", claims.Sub),
+	)
+	c.JSON(http.StatusCreated, IssueAPIKeyResponse{
+		Key:        key,
+		KeyID:      keyID,
+		Name:       req.Name,
+		RateLimit:  req.RateLimit,
+		DailyQuota: req.DailyQuota,
+		Indexes:    req.Indexes,
+		ExpiresAt:  time.Now().Add(ttl).UTC(),
+	})
+}
+
+// validateAPIKeyRequest checks the limits and index patterns of req and
+// returns its TTL, or a message explaining why the request is invalid.
+func validateAPIKeyRequest(req *IssueAPIKeyRequest) (time.Duration, string) {
+	if req.RateLimit < 0 || req.RateLimit > maxAPIKeyRateLimit {
+		return 0, "rate_limit must be between 0 and 10000 requests per minute"
+	}
+	if req.DailyQuota < 0 || req.DailyQuota > maxAPIKeyDailyQuota {
+		return 0, "daily_quota must be between 0 and 10000000 requests per day"
+	}
+	if len(req.Indexes) > maxAPIKeyIndexes {
+		return 0, "at most 20 index patterns are allowed"
+	}
+	for _, pattern := range req.Indexes {
+		if !infrajwt.ValidAPIKeyIndex(pattern) {
+			return 0, "index pattern " + pattern + " must name classified content indexes (e.g. sudbury_*_classified_content)"
+		}
+	}
+
+	return parseKeyTTL(req.TTL)
+}
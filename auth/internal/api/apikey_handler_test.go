@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/api"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+func setupAPIKeyRouter(t *testing.T) (*gin.Engine, *auth.JWTManager) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{JWTSecret: tokenTestSecret, JWTExpiration: 24 * time.Hour},
+	}
+	jwtMgr := auth.NewJWTManager(tokenTestSecret, cfg.Auth.JWTExpiration)
+	handler := api.NewAuthHandler(cfg, jwtMgr, &mockLogger{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/api-keys", infrajwt.Middleware(tokenTestSecret), handler.IssueAPIKey)
+	return router, jwtMgr
+}
+
+func issueAPIKey(router *gin.Engine, bearer string, body map[string]any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/api-keys", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_IssueAPIKey(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupAPIKeyRouter(t)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	w := issueAPIKey(router, adminToken, map[string]any{
+		"name":        "partner-news",
+		"rate_limit":  120,
+		"daily_quota": 5000,
+		"indexes":     []string{"sudbury_*_classified_content"},
+		"ttl":         "720h",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("IssueAPIKey() status = %d, want %d, body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	var resp api.IssueAPIKeyResponse
+	if unmarshalErr := json.Unmarshal(w.Body.Bytes(), &resp); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal response: %v", unmarshalErr)
+	}
+	claims, err := infrajwt.ParseAPIKey(tokenTestSecret, resp.Key)
+	if err != nil {
+		t.Fatalf("ParseAPIKey() error = %v", err)
+	}
+	if claims.ID != resp.KeyID || claims.Sub != "partner-news" {
+		t.Errorf("claims = (%q, %q), want (%q, partner-news)", claims.ID, claims.Sub, resp.KeyID)
+	}
+	if claims.RateLimit != 120 || claims.DailyQuota != 5000 || len(claims.Indexes) != 1 {
+		t.Errorf("limits not carried by the key: %+v", claims)
+	}
+}
+
+func TestAuthHandler_IssueAPIKey_ReadTokenCannotIssue(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupAPIKeyRouter(t)
+	readToken, err := jwtMgr.GenerateScopedToken("dashboard", auth.ScopeRead, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken() error = %v", err)
+	}
+
+	if w := issueAPIKey(router, readToken, map[string]any{"name": "partner"}); w.Code != http.StatusForbidden {
+		t.Errorf("IssueAPIKey() status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestAuthHandler_IssueAPIKey_RejectsInvalidRequests(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupAPIKeyRouter(t)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	cases := map[string]map[string]any{
+		"missing name":       {"rate_limit": 10},
+		"negative rate":      {"name": "p", "rate_limit": -1},
+		"quota too large":    {"name": "p", "daily_quota": 20_000_000},
+		"raw content index":  {"name": "p", "indexes": []string{"sudbury_com_raw_content"}},
+		"multi-target index": {"name": "p", "indexes": []string{"a_classified_content,b_raw_content"}},
+		"ttl too long":       {"name": "p", "ttl": "20000h"},
+	}
+	for name, body := range cases {
+		if w := issueAPIKey(router, adminToken, body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -1,19 +1,34 @@
 package api
 
 import (
+	"crypto/subtle"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/north-cloud/auth/internal/auth"
 	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+	"github.com/jonesrussell/north-cloud/auth/internal/security"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	"github.com/jonesrussell/north-cloud/auth/internal/user"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
 // AuthHandler handles authentication requests.
 type AuthHandler struct {
-	config     *config.Config
-	jwtManager *auth.JWTManager
-	log        logger.Logger
+	config      *config.Config
+	jwtManager  *auth.JWTManager
+	guard       *security.Guard
+	serviceKeys *servicekey.Store
+	sessions    *session.Store
+	users       *user.Store
+	passwords   *password.Policy
+	log         logger.Logger
 }
 
 // NewAuthHandler creates a new auth handler.
@@ -25,15 +40,55 @@ func NewAuthHandler(cfg *config.Config, jwtManager *auth.JWTManager, log logger.
 	}
 }
 
+// WithSecurityGuard enables brute-force lockout and login anomaly detection.
+func (h *AuthHandler) WithSecurityGuard(guard *security.Guard) *AuthHandler {
+	h.guard = guard
+	return h
+}
+
+// WithServiceKeys enables service key management. Without it, the service
+// key endpoints answer 503.
+func (h *AuthHandler) WithServiceKeys(store *servicekey.Store) *AuthHandler {
+	h.serviceKeys = store
+	return h
+}
+
+// WithSessions enables refresh tokens: logins start a session and get a
+// short-lived access token. Without it, logins get a jwt_expiration token.
+func (h *AuthHandler) WithSessions(store *session.Store) *AuthHandler {
+	h.sessions = store
+	return h
+}
+
+// WithUsers enables invited accounts alongside the configured admin.
+// Without it, the invitation and user endpoints answer 503.
+func (h *AuthHandler) WithUsers(store *user.Store) *AuthHandler {
+	h.users = store
+	return h
+}
+
+// WithPasswordPolicy applies policy to the passwords invitees choose and
+// change to.
+func (h *AuthHandler) WithPasswordPolicy(policy *password.Policy) *AuthHandler {
+	h.passwords = policy
+	return h
+}
+
 // LoginRequest represents a login request.
 type LoginRequest struct {
+	// Username is the configured admin's username or an invited account's email.
 	Username string `binding:"required" json:"username"`
 	Password string `binding:"required" json:"password"`
+	// StepUpCode is only needed when a login is flagged as suspicious.
+	StepUpCode string `json:"step_up_code,omitempty"`
 }
 
-// LoginResponse represents a login response.
+// LoginResponse represents a login response. RefreshToken is empty when
+// sessions are unavailable; the token then lasts jwt_expiration.
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
 
 // Login handles login requests.
@@ -45,18 +100,42 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Validate credentials
-	if req.Username != h.config.Auth.Username || req.Password != h.config.Auth.Password {
+	var attempt *security.Attempt
+	if h.guard != nil {
+		attempt = h.guard.NewAttempt(req.Username, c.ClientIP(), c.Request.Header)
+		if retryAfter := h.guard.RetryAfter(attempt); retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many failed login attempts"})
+			return
+		}
+	}
+
+	subject, scope, ok := h.authenticate(c, req.Username, req.Password)
+	if !ok {
 		h.log.Info("Failed login attempt",
 			logger.String("username", req.Username),
 			logger.String("client_ip", c.ClientIP()),
 		)
+		if h.guard != nil {
+			h.guard.LoginFailed(attempt)
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtManager.GenerateToken()
+	if h.guard != nil {
+		var stepUpErr *security.StepUpError
+		if err := h.guard.LoginSucceeded(attempt, req.StepUpCode); errors.As(err, &stepUpErr) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":            stepUpErr.Error(),
+				"step_up_required": true,
+				"reasons":          stepUpErr.Reasons,
+			})
+			return
+		}
+	}
+
+	resp, err := h.issueLoginTokens(c, subject, scope)
 	if err != nil {
 		h.log.Error("Failed to generate token", logger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
@@ -65,7 +144,62 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	h.log.Info("Successful login",
 		logger.String("username", req.Username),
+		logger.String("subject", subject),
 		logger.String("client_ip", c.ClientIP()),
+		logger.Bool("refresh_token", resp.RefreshToken != ""),
 	)
-	c.JSON(http.StatusOK, LoginResponse{Token: token})
+	c.JSON(http.StatusOK, resp)
+}
+
+// authenticate checks the credentials against the configured admin, then
+// the invited accounts, and returns the subject and scope to issue tokens
+// for. The configured admin has full access; an account's scope follows
+// its role.
+func (h *AuthHandler) authenticate(c *gin.Context, username, pw string) (subject, scope string, ok bool) {
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(h.config.Auth.Username)) == 1
+	passwordMatch := subtle.ConstantTimeCompare([]byte(pw), []byte(h.config.Auth.Password)) == 1
+	if usernameMatch && passwordMatch {
+		return auth.DashboardSubject, "", true
+	}
+	if h.users == nil {
+		return "", "", false
+	}
+
+	account, err := h.users.Authenticate(c.Request.Context(), username, pw)
+	if err != nil {
+		if !errors.Is(err, user.ErrInvalidCredentials) {
+			h.log.Error("Failed to authenticate user", logger.Error(err))
+		}
+		return "", "", false
+	}
+	return account.Email, user.RoleScope(account.Role), true
+}
+
+// issueLoginTokens starts a session for subject and returns its access and
+// refresh tokens. If sessions are unavailable or the session cannot be
+// stored, it returns a jwt_expiration token alone, so logins keep working.
+func (h *AuthHandler) issueLoginTokens(c *gin.Context, subject, scope string) (*LoginResponse, error) {
+	if h.sessions != nil {
+		refreshToken, started, err := h.sessions.Create(c.Request.Context(), subject, scope, c.ClientIP())
+		if err == nil {
+			return h.sessionResponse(started, refreshToken)
+		}
+		h.log.Error("Failed to start session; issuing a token without refresh", logger.Error(err))
+	}
+
+	token, err := h.jwtManager.GenerateScopedToken(subject, scope, h.config.Auth.JWTExpiration)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResponse{Token: token, ExpiresAt: time.Now().Add(h.config.Auth.JWTExpiration).UTC()}, nil
+}
+
+// sessionResponse signs a new access token for a session.
+func (h *AuthHandler) sessionResponse(sess *session.Session, refreshToken string) (*LoginResponse, error) {
+	ttl := h.config.Auth.AccessTokenTTL
+	token, err := h.jwtManager.GenerateSessionToken(sess.Subject, sess.Scope, sess.ID, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResponse{Token: token, RefreshToken: refreshToken, ExpiresAt: time.Now().Add(ttl).UTC()}, nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/jonesrussell/north-cloud/auth/internal/api"
 	"github.com/jonesrussell/north-cloud/auth/internal/auth"
 	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/security"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -165,3 +166,83 @@ func TestAuthHandler_Login_MalformedRequest(t *testing.T) {
 		})
 	}
 }
+
+func newGuardedHandler(stepUpCode string) *api.AuthHandler {
+	cfg := &config.Config{
+		Auth: config.AuthConfig{
+			Username:      "admin",
+			Password:      "admin",
+			JWTSecret:     "test-secret-key-32-chars-minimum",
+			JWTExpiration: 24 * time.Hour,
+		},
+	}
+	guard := security.NewGuard(security.GuardConfig{
+		Detector: security.DetectorConfig{
+			MaxFailures:        2,
+			FailureWindow:      15 * time.Minute,
+			LockoutDuration:    15 * time.Minute,
+			SuspiciousFailures: 1,
+		},
+		StepUpCode: stepUpCode,
+	}, nil, &mockLogger{})
+
+	jwtMgr := auth.NewJWTManager(cfg.Auth.JWTSecret, cfg.Auth.JWTExpiration)
+	return api.NewAuthHandler(cfg, jwtMgr, &mockLogger{}).WithSecurityGuard(guard)
+}
+
+func postLogin(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_Login_LockoutAfterFailures(t *testing.T) {
+	t.Helper()
+
+	router := setupTestRouter(newGuardedHandler(""))
+	badLogin := `{"username": "admin", "password": "wrong"}`
+
+	for range 2 {
+		if w := postLogin(router, badLogin); w.Code != http.StatusUnauthorized {
+			t.Fatalf("Login() status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	}
+
+	// Even correct credentials are refused while the IP is locked out.
+	w := postLogin(router, `{"username": "admin", "password": "admin"}`)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Login() status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Login() expected Retry-After header")
+	}
+}
+
+func TestAuthHandler_Login_StepUpRequired(t *testing.T) {
+	t.Helper()
+
+	router := setupTestRouter(newGuardedHandler("123456"))
+
+	if w := postLogin(router, `{"username": "admin", "password": "wrong"}`); w.Code != http.StatusUnauthorized {
+		t.Fatalf("Login() status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+
+	w := postLogin(router, `{"username": "admin", "password": "admin"}`)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Login() status = %d, want %d, body: %s", w.Code, http.StatusForbidden, w.Body.String())
+	}
+	var response map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response["step_up_required"] != true {
+		t.Errorf("Login() expected step_up_required in response, got %v", response)
+	}
+
+	w = postLogin(router, `{"username": "admin", "password": "admin", "step_up_code": "123456"}`)
+	if w.Code != http.StatusOK {
+		t.Errorf("Login() with step-up code status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// IntrospectRequest carries the token to introspect, form-encoded as RFC
+// 7662 specifies or as JSON.
+type IntrospectRequest struct {
+	Token string `binding:"required" form:"token" json:"token"`
+
+	// TokenTypeHint is accepted and ignored: the token's format tells JWTs
+	// from service keys.
+	TokenTypeHint string `form:"token_type_hint" json:"token_type_hint"`
+}
+
+// Introspect tells services whether a token is active and what it grants
+// (RFC 7662), so they need neither the JWT secret nor the service key
+// store. It needs no token of its own, like VerifyServiceKey: the answer
+// only describes the token presented. Tokens of revoked sessions and
+// service keys are inactive, as are refresh tokens and anything malformed.
+func (h *AuthHandler) Introspect(c *gin.Context) {
+	var req IntrospectRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+
+	if infrajwt.IsServiceKey(req.Token) {
+		h.introspectServiceKey(c, req.Token)
+		return
+	}
+
+	claims, err := h.jwtManager.ValidateToken(req.Token)
+	if err != nil {
+		c.JSON(http.StatusOK, infrajwt.IntrospectionResponse{Active: false})
+		return
+	}
+
+	// Session-backed login tokens carry the session ID; search API keys
+	// carry their key ID, which has no session
+	if claims.ID != "" && claims.Scope != infrajwt.ScopeSearchAPI && h.sessions != nil {
+		active, activeErr := h.sessions.Active(c.Request.Context(), claims.ID)
+		if activeErr != nil {
+			h.log.Error("Failed to check session", logger.Error(activeErr), logger.String("session_id", claims.ID))
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "introspection unavailable"})
+			return
+		}
+		if !active {
+			c.JSON(http.StatusOK, infrajwt.IntrospectionResponse{Active: false})
+			return
+		}
+	}
+
+	response := infrajwt.IntrospectionResponse{
+		Active: true,
+		Scope:  claims.Scope,
+		Sub:    claims.Sub,
+		JTI:    claims.ID,
+	}
+	if claims.ExpiresAt != nil {
+		response.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		response.Iat = claims.IssuedAt.Unix()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// introspectServiceKey answers for a service key from the store.
+func (h *AuthHandler) introspectServiceKey(c *gin.Context, key string) {
+	if h.serviceKeys == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "service keys are unavailable"})
+		return
+	}
+
+	verified, err := h.serviceKeys.Verify(c.Request.Context(), key)
+	if errors.Is(err, servicekey.ErrInvalidKey) {
+		c.JSON(http.StatusOK, infrajwt.IntrospectionResponse{Active: false})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to verify service key", logger.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "introspection unavailable"})
+		return
+	}
+
+	c.JSON(http.StatusOK, infrajwt.IntrospectionResponse{
+		Active: true,
+		Scope:  verified.Scope,
+		Sub:    verified.Name,
+		Exp:    verified.ExpiresAt.Unix(),
+		JTI:    verified.ID,
+	})
+}
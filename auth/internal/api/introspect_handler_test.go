@@ -0,0 +1,157 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/api"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+// setupIntrospectRouter wires login, session revocation, service keys and
+// introspection as NewServer does.
+func setupIntrospectRouter(t *testing.T) (*gin.Engine, *auth.JWTManager) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{
+			Username:        "admin",
+			Password:        "password",
+			JWTSecret:       tokenTestSecret,
+			JWTExpiration:   24 * time.Hour,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 24 * time.Hour,
+		},
+	}
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	keys := servicekey.NewStore(client)
+
+	jwtMgr := auth.NewJWTManager(tokenTestSecret, cfg.Auth.JWTExpiration)
+	handler := api.NewAuthHandler(cfg, jwtMgr, &mockLogger{}).
+		WithSessions(session.NewStore(client, cfg.Auth.RefreshTokenTTL)).
+		WithServiceKeys(keys)
+	requireToken := infrajwt.Middleware(tokenTestSecret, infrajwt.WithServiceKeyVerifier(keys))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/login", handler.Login)
+	router.DELETE("/api/v1/auth/sessions", requireToken, handler.RevokeAllSessions)
+	router.POST("/api/v1/auth/service-keys", requireToken, handler.IssueServiceKey)
+	router.DELETE("/api/v1/auth/service-keys/:id", requireToken, handler.RevokeServiceKey)
+	router.POST("/api/v1/auth/introspect", handler.Introspect)
+	return router, jwtMgr
+}
+
+// introspect posts token form-encoded, as RFC 7662 clients do.
+func introspect(t *testing.T, router *gin.Engine, token string) infrajwt.IntrospectionResponse {
+	t.Helper()
+
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/introspect", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Introspect() status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var resp infrajwt.IntrospectionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode introspection response: %v", err)
+	}
+	return resp
+}
+
+func TestAuthHandler_IntrospectTokens(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupIntrospectRouter(t)
+	admin := login(t, router)
+
+	resp := introspect(t, router, admin.Token)
+	if !resp.Active || resp.Sub != auth.DashboardSubject || resp.Scope != "" || resp.JTI == "" || resp.Exp == 0 {
+		t.Errorf("Introspect(login token) = %+v, want an active full-access session token", resp)
+	}
+
+	scoped, err := jwtMgr.GenerateScopedToken("grafana", "indexes:read", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken() error = %v", err)
+	}
+	if resp = introspect(t, router, scoped); !resp.Active || resp.Sub != "grafana" || resp.Scope != "indexes:read" {
+		t.Errorf("Introspect(scoped) = %+v, want active with its scope", resp)
+	}
+
+	expired, err := jwtMgr.GenerateSessionToken("grafana", "", "0123456789abcdef", -time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateSessionToken() error = %v", err)
+	}
+	otherSecret, err := auth.NewJWTManager("another-secret", time.Hour).GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	for name, token := range map[string]string{
+		"expired":       expired,
+		"wrong secret":  otherSecret,
+		"malformed":     "not-a-token",
+		"refresh token": admin.RefreshToken,
+	} {
+		if resp = introspect(t, router, token); resp.Active || resp.Sub != "" {
+			t.Errorf("Introspect(%s) = %+v, want only inactive", name, resp)
+		}
+	}
+
+	// Revoking the session deactivates its access token at once
+	if code := serviceKeyRequest(router, http.MethodDelete, "/api/v1/auth/sessions", admin.Token, nil).Code; code != http.StatusOK {
+		t.Fatalf("RevokeAllSessions() status = %d, want %d", code, http.StatusOK)
+	}
+	if resp = introspect(t, router, admin.Token); resp.Active {
+		t.Errorf("Introspect(revoked session) = %+v, want inactive", resp)
+	}
+}
+
+func TestAuthHandler_IntrospectServiceKeys(t *testing.T) {
+	t.Helper()
+
+	router, _ := setupIntrospectRouter(t)
+	admin := login(t, router)
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys", admin.Token,
+		map[string]any{"name": "nc-http-proxy", "scopes": []string{"proxy:write"}})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("IssueServiceKey() status = %d, body: %s", w.Code, w.Body.String())
+	}
+	var issued api.IssueServiceKeyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &issued); err != nil {
+		t.Fatalf("failed to decode service key: %v", err)
+	}
+
+	resp := introspect(t, router, issued.Secret)
+	if !resp.Active || resp.Sub != "nc-http-proxy" || resp.Scope != "proxy:write" || resp.JTI != issued.ID {
+		t.Errorf("Introspect(service key) = %+v, want active with the key's name and scope", resp)
+	}
+
+	if code := serviceKeyRequest(router, http.MethodDelete, "/api/v1/auth/service-keys/"+issued.ID, admin.Token, nil).Code; code != http.StatusNoContent {
+		t.Fatalf("RevokeServiceKey() status = %d, want %d", code, http.StatusNoContent)
+	}
+	if resp = introspect(t, router, issued.Secret); resp.Active {
+		t.Errorf("Introspect(revoked key) = %+v, want inactive", resp)
+	}
+
+	// JSON bodies are accepted too; a missing token is a bad request
+	if code := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/introspect", "", map[string]string{}).Code; code != http.StatusBadRequest {
+		t.Errorf("Introspect(no token) status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
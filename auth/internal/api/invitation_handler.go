@@ -0,0 +1,286 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+	"github.com/jonesrussell/north-cloud/auth/internal/user"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// Invitation lifetimes.
+const (
+	defaultInvitationTTL = 72 * time.Hour
+	maxInvitationTTL     = 30 * 24 * time.Hour
+)
+
+// InviteRequest represents a request to invite a user.
+type InviteRequest struct {
+	Email string `binding:"required,email,max=254" json:"email"`
+	// Role is "viewer" (the default) or "admin".
+	Role string `json:"role,omitempty"`
+	// TTL is a Go duration such as "24h"; empty is 72h.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// InviteResponse represents a created invitation. The token is not
+// stored: it is shown once.
+type InviteResponse struct {
+	Invitation user.Invitation `json:"invitation"`
+	// Token is the invitation token to pass to /invitations/accept.
+	Token string `json:"token"`
+	// URL is the invitation link, when auth.invite_url is set.
+	URL string `json:"url,omitempty"`
+}
+
+// AcceptInvitationRequest carries an invitation token and the password the
+// invitee chose.
+type AcceptInvitationRequest struct {
+	Token    string `binding:"required" json:"token"`
+	Password string `binding:"required" json:"password"`
+}
+
+// Invite invites an email to create an account. The caller must hold full
+// access.
+func (h *AuthHandler) Invite(c *gin.Context) {
+	claims, ok := h.userAdmin(c, "a full-access token is required to invite users")
+	if !ok {
+		return
+	}
+
+	var req InviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	role := req.Role
+	if role == "" {
+		role = user.RoleViewer
+	}
+	if !user.ValidRole(role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role must be viewer or admin"})
+		return
+	}
+	ttl, message := parseInvitationTTL(req.TTL)
+	if message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
+	token, inv, err := h.users.Invite(c.Request.Context(), req.Email, role, claims.Sub, ttl)
+	if errors.Is(err, user.ErrExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": "a user with this email already exists"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to create invitation", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create invitation"})
+		return
+	}
+
+	h.log.Info("Invited user",
+		logger.String("invitation_id", inv.ID),
+		logger.String("email", inv.Email),
+		logger.String("role", role),
+		logger.Duration("ttl", ttl),
+		logger.String("invited_by", claims.Sub),
+	)
+	c.JSON(http.StatusCreated, InviteResponse{Invitation: *inv, Token: token, URL: h.invitationURL(token)})
+}
+
+// ListInvitations lists the invitations that have not expired, been
+// revoked, or been accepted.
+func (h *AuthHandler) ListInvitations(c *gin.Context) {
+	if _, ok := h.userAdmin(c, "a full-access token is required to list invitations"); !ok {
+		return
+	}
+
+	invitations, err := h.users.ListInvitations(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to list invitations", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list invitations"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations, "count": len(invitations)})
+}
+
+// RevokeInvitation revokes the invitation with the :id path parameter.
+func (h *AuthHandler) RevokeInvitation(c *gin.Context) {
+	claims, ok := h.userAdmin(c, "a full-access token is required to revoke invitations")
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	err := h.users.RevokeInvitation(c.Request.Context(), id)
+	if errors.Is(err, user.ErrInvitationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "invitation not found"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to revoke invitation", logger.Error(err), logger.String("invitation_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke invitation"})
+		return
+	}
+
+	h.log.Info("Revoked invitation",
+		logger.String("invitation_id", id),
+		logger.String("revoked_by", claims.Sub),
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// AcceptInvitation sets the invitee's password and creates their account,
+// pending until its first login. The token authenticates the request.
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	if h.users == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "user accounts are unavailable"})
+		return
+	}
+
+	var req AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	inv, err := h.users.VerifyInvitation(ctx, req.Token)
+	if errors.Is(err, user.ErrInvalidInvitation) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired invitation"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to verify invitation", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify invitation"})
+		return
+	}
+
+	if h.passwords != nil {
+		if policyErr := h.passwords.Check(ctx, inv.Email, req.Password); policyErr != nil {
+			var violation *password.Violation
+			if errors.As(policyErr, &violation) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": violation.Error(), "rule": violation.Rule})
+				return
+			}
+			h.log.Warn("Password policy check incomplete", logger.Error(policyErr))
+		}
+	}
+
+	created, err := h.users.AcceptInvitation(ctx, inv, req.Password)
+	if errors.Is(err, user.ErrExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": "a user with this email already exists"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to accept invitation", logger.Error(err), logger.String("invitation_id", inv.ID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to accept invitation"})
+		return
+	}
+	h.recordPassword(ctx, inv.Email, req.Password)
+
+	h.log.Info("Accepted invitation",
+		logger.String("invitation_id", inv.ID),
+		logger.String("email", created.Email),
+		logger.String("role", created.Role),
+		logger.String("client_ip", c.ClientIP()),
+	)
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListUsers lists the invited accounts. The configured admin is not listed.
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	if _, ok := h.userAdmin(c, "a full-access token is required to list users"); !ok {
+		return
+	}
+
+	users, err := h.users.List(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to list users", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list users"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"users": users, "count": len(users)})
+}
+
+// DeleteUser deletes the account with the :email path parameter and ends
+// its sessions. Its access tokens last until they expire.
+func (h *AuthHandler) DeleteUser(c *gin.Context) {
+	claims, ok := h.userAdmin(c, "a full-access token is required to delete users")
+	if !ok {
+		return
+	}
+
+	email := user.NormalizeEmail(c.Param("email"))
+	err := h.users.Delete(c.Request.Context(), email)
+	if errors.Is(err, user.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to delete user", logger.Error(err), logger.String("email", email))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete user"})
+		return
+	}
+
+	revoked := 0
+	if h.sessions != nil {
+		revoked, err = h.sessions.RevokeSubject(c.Request.Context(), email)
+		if err != nil {
+			h.log.Error("Failed to revoke sessions of deleted user", logger.Error(err), logger.String("email", email))
+		}
+	}
+
+	h.log.Info("Deleted user",
+		logger.String("email", email),
+		logger.Int("revoked_sessions", revoked),
+		logger.String("deleted_by", claims.Sub),
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// userAdmin returns the caller's claims if user accounts are available and
+// the caller has full access, and otherwise writes the error response.
+func (h *AuthHandler) userAdmin(c *gin.Context, forbidden string) (*infrajwt.Claims, bool) {
+	if h.users == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "user accounts are unavailable"})
+		return nil, false
+	}
+	claims, ok := infrajwt.GetClaims(c)
+	if !ok || !claims.HasFullAccess() {
+		c.JSON(http.StatusForbidden, gin.H{"error": forbidden})
+		return nil, false
+	}
+	return claims, true
+}
+
+// invitationURL returns the invitation link for token, or "" when
+// auth.invite_url is not set.
+func (h *AuthHandler) invitationURL(token string) string {
+	if h.config.Auth.InviteURL == "" {
+		return ""
+	}
+	return h.config.Auth.InviteURL + "?token=" + url.QueryEscape(token)
+}
+
+// parseInvitationTTL parses an invitation's TTL, or returns a message
+// explaining why it is invalid. Empty is 72h; the maximum is 30 days.
+func parseInvitationTTL(value string) (time.Duration, string) {
+	ttl := defaultInvitationTTL
+	if value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return 0, "ttl must be a positive duration such as 72h"
+		}
+		ttl = parsed
+	}
+	if ttl > maxInvitationTTL {
+		return 0, "ttl must not exceed " + maxInvitationTTL.String()
+	}
+	return ttl, ""
+}
@@ -0,0 +1,198 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/api"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	"github.com/jonesrussell/north-cloud/auth/internal/user"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+// setupInvitationRouter wires login and the invitation, user and password
+// routes as NewServer does, with sessions and a 12-character password
+// policy.
+func setupInvitationRouter(t *testing.T) (*gin.Engine, *auth.JWTManager) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{
+			Username:        "admin",
+			Password:        "password",
+			JWTSecret:       tokenTestSecret,
+			JWTExpiration:   24 * time.Hour,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 24 * time.Hour,
+			InviteURL:       "https://dashboard.example.com/accept-invite",
+		},
+		PasswordPolicy: config.PasswordPolicyConfig{MinLength: 12, HistorySize: 5},
+	}
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	jwtMgr := auth.NewJWTManager(tokenTestSecret, cfg.Auth.JWTExpiration)
+	handler := api.NewAuthHandler(cfg, jwtMgr, &mockLogger{}).
+		WithSessions(session.NewStore(client, cfg.Auth.RefreshTokenTTL)).
+		WithUsers(user.NewStore(client, []byte(tokenTestSecret))).
+		WithPasswordPolicy(api.NewPasswordPolicy(&cfg.PasswordPolicy, password.NewHistory(client, cfg.PasswordPolicy.HistorySize)))
+	requireToken := infrajwt.Middleware(tokenTestSecret)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/login", handler.Login)
+	router.GET("/api/v1/auth/sessions", requireToken, handler.ListSessions)
+	router.POST("/api/v1/auth/invitations", requireToken, handler.Invite)
+	router.GET("/api/v1/auth/invitations", requireToken, handler.ListInvitations)
+	router.DELETE("/api/v1/auth/invitations/:id", requireToken, handler.RevokeInvitation)
+	router.POST("/api/v1/auth/invitations/accept", handler.AcceptInvitation)
+	router.GET("/api/v1/auth/users", requireToken, handler.ListUsers)
+	router.DELETE("/api/v1/auth/users/:email", requireToken, handler.DeleteUser)
+	router.POST("/api/v1/auth/password",
+		infrajwt.Middleware(tokenTestSecret, infrajwt.WithReadRoutes("/api/v1/auth/password")), handler.ChangePassword)
+	return router, jwtMgr
+}
+
+func invite(t *testing.T, router *gin.Engine, bearer, email string) api.InviteResponse {
+	t.Helper()
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/invitations", bearer,
+		map[string]string{"email": email})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Invite() status = %d, want %d, body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var resp api.InviteResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode invite response: %v", err)
+	}
+	return resp
+}
+
+func TestAuthHandler_InvitationOnboarding(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupInvitationRouter(t)
+	admin := login(t, router)
+	invited := invite(t, router, admin.Token, "Ana@Example.com")
+	if invited.Invitation.Role != user.RoleViewer {
+		t.Errorf("Invite() role = %q, want %q by default", invited.Invitation.Role, user.RoleViewer)
+	}
+	if invited.URL != "https://dashboard.example.com/accept-invite?token="+invited.Token {
+		t.Errorf("Invite() url = %q, want the invite page with the token", invited.URL)
+	}
+
+	accept := func(pw string) int {
+		return serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/invitations/accept", "",
+			map[string]string{"token": invited.Token, "password": pw}).Code
+	}
+	if code := accept("too short"); code != http.StatusBadRequest {
+		t.Errorf("AcceptInvitation(short password) status = %d, want %d", code, http.StatusBadRequest)
+	}
+	if code := accept("correct horse battery"); code != http.StatusCreated {
+		t.Fatalf("AcceptInvitation() status = %d, want %d", code, http.StatusCreated)
+	}
+	if code := accept("correct horse battery"); code != http.StatusUnauthorized {
+		t.Errorf("AcceptInvitation() twice status = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	// The invitee logs in with their email and gets a read-only session
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/login", "",
+		map[string]string{"username": "ana@example.com", "password": "correct horse battery"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Login(invitee) status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var viewer api.LoginResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &viewer); err != nil {
+		t.Fatalf("failed to decode login response: %v", err)
+	}
+	claims, err := jwtMgr.ValidateToken(viewer.Token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Sub != "ana@example.com" || claims.Scope != auth.ScopeRead {
+		t.Errorf("invitee token sub = %q, scope = %q, want the email with read scope", claims.Sub, claims.Scope)
+	}
+	if code := serviceKeyRequest(router, http.MethodGet, "/api/v1/auth/users", viewer.Token, nil).Code; code != http.StatusForbidden {
+		t.Errorf("ListUsers() as a viewer status = %d, want %d", code, http.StatusForbidden)
+	}
+
+	w = serviceKeyRequest(router, http.MethodGet, "/api/v1/auth/users", admin.Token, nil)
+	var listed struct {
+		Users []user.User `json:"users"`
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &listed); err != nil || len(listed.Users) != 1 || listed.Users[0].Status != user.StatusActive {
+		t.Errorf("ListUsers() = %s, want the activated account", w.Body.String())
+	}
+
+	// Deleting the account ends its sessions and its logins
+	if code := serviceKeyRequest(router, http.MethodDelete, "/api/v1/auth/users/ana@example.com", admin.Token, nil).Code; code != http.StatusNoContent {
+		t.Fatalf("DeleteUser() status = %d, want %d", code, http.StatusNoContent)
+	}
+	w = serviceKeyRequest(router, http.MethodGet, "/api/v1/auth/sessions", admin.Token, nil)
+	var sessions struct {
+		Sessions []session.Session `json:"sessions"`
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &sessions); err != nil || len(sessions.Sessions) != 1 || sessions.Sessions[0].Subject != auth.DashboardSubject {
+		t.Errorf("ListSessions() after delete = %s, want only the admin's session", w.Body.String())
+	}
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/login", "",
+		map[string]string{"username": "ana@example.com", "password": "correct horse battery"})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Login(deleted) status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthHandler_InviteRejects(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupInvitationRouter(t)
+	admin := login(t, router)
+	readToken, err := jwtMgr.GenerateScopedToken("viewer", auth.ScopeRead, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		bearer string
+		body   map[string]string
+		want   int
+	}{
+		{"read-only caller", readToken, map[string]string{"email": "bo@example.com"}, http.StatusForbidden},
+		{"invalid email", admin.Token, map[string]string{"email": "not-an-email"}, http.StatusBadRequest},
+		{"unknown role", admin.Token, map[string]string{"email": "bo@example.com", "role": "owner"}, http.StatusBadRequest},
+		{"ttl over 30 days", admin.Token, map[string]string{"email": "bo@example.com", "ttl": "1000h"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/invitations", tt.bearer, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("Invite() status = %d, want %d, body: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	// A revoked invitation's link stops working
+	invited := invite(t, router, admin.Token, "bo@example.com")
+	path := "/api/v1/auth/invitations/" + invited.Invitation.ID
+	if code := serviceKeyRequest(router, http.MethodDelete, path, admin.Token, nil).Code; code != http.StatusNoContent {
+		t.Fatalf("RevokeInvitation() status = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := serviceKeyRequest(router, http.MethodDelete, path, admin.Token, nil).Code; code != http.StatusNotFound {
+		t.Errorf("RevokeInvitation() twice status = %d, want %d", code, http.StatusNotFound)
+	}
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/invitations/accept", "",
+		map[string]string{"token": invited.Token, "password": "correct horse battery"})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("AcceptInvitation(revoked) status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
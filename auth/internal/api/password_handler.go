@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+	"github.com/jonesrussell/north-cloud/auth/internal/security"
+	"github.com/jonesrussell/north-cloud/auth/internal/user"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// ChangePasswordRequest carries the caller's current password and the one
+// replacing it.
+type ChangePasswordRequest struct {
+	CurrentPassword string `binding:"required" json:"current_password"`
+	NewPassword     string `binding:"required" json:"new_password"`
+}
+
+// ChangePassword changes the password of the invited account the token was
+// issued to. The current password must match; a wrong one counts as a
+// failed login. The new password must meet the password policy, including
+// the history. The configured admin's password is set in AUTH_PASSWORD.
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	if h.users == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "user accounts are unavailable"})
+		return
+	}
+	claims, ok := infrajwt.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	if claims.Sub == auth.DashboardSubject {
+		c.JSON(http.StatusForbidden, gin.H{"error": "the configured admin's password is set in AUTH_PASSWORD"})
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	var attempt *security.Attempt
+	if h.guard != nil {
+		attempt = h.guard.NewAttempt(claims.Sub, c.ClientIP(), c.Request.Header)
+		if retryAfter := h.guard.RetryAfter(attempt); retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many failed login attempts"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	if err := h.users.VerifyPassword(ctx, claims.Sub, req.CurrentPassword); err != nil {
+		if !errors.Is(err, user.ErrInvalidCredentials) {
+			h.log.Error("Failed to verify password", logger.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to change password"})
+			return
+		}
+		if h.guard != nil {
+			h.guard.LoginFailed(attempt)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		return
+	}
+
+	if h.passwords != nil {
+		if policyErr := h.passwords.Check(ctx, claims.Sub, req.NewPassword); policyErr != nil {
+			var violation *password.Violation
+			if errors.As(policyErr, &violation) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": violation.Error(), "rule": violation.Rule})
+				return
+			}
+			h.log.Warn("Password policy check incomplete", logger.Error(policyErr))
+		}
+	}
+
+	err := h.users.SetPassword(ctx, claims.Sub, req.NewPassword)
+	if errors.Is(err, user.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to change password", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to change password"})
+		return
+	}
+	h.recordPassword(ctx, claims.Sub, req.NewPassword)
+
+	h.log.Info("Changed password",
+		logger.String("email", claims.Sub),
+		logger.String("client_ip", c.ClientIP()),
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// recordPassword adds a password that has just been stored to the history.
+// A failure is only logged, since the password is already in use.
+func (h *AuthHandler) recordPassword(ctx context.Context, subject, pw string) {
+	if h.passwords == nil {
+		return
+	}
+	if err := h.passwords.Record(ctx, subject, pw); err != nil {
+		h.log.Warn("Failed to record password history", logger.Error(err))
+	}
+}
@@ -0,0 +1,119 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+)
+
+func TestAuthHandler_ChangePassword(t *testing.T) {
+	t.Helper()
+
+	router, _ := setupInvitationRouter(t)
+	admin := login(t, router)
+	invited := invite(t, router, admin.Token, "ana@example.com")
+	if code := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/invitations/accept", "",
+		map[string]string{"token": invited.Token, "password": "correct horse battery"}).Code; code != http.StatusCreated {
+		t.Fatalf("AcceptInvitation() status = %d, want %d", code, http.StatusCreated)
+	}
+
+	loginAs := func(pw string) int {
+		return serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/login", "",
+			map[string]string{"username": "ana@example.com", "password": pw}).Code
+	}
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/login", "",
+		map[string]string{"username": "ana@example.com", "password": "correct horse battery"})
+	var viewer struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &viewer); err != nil || viewer.Token == "" {
+		t.Fatalf("Login(invitee) = %s, want a token", w.Body.String())
+	}
+
+	change := func(bearer, current, next string) int {
+		return serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/password", bearer,
+			map[string]string{"current_password": current, "new_password": next}).Code
+	}
+	tests := []struct {
+		name    string
+		bearer  string
+		current string
+		next    string
+		want    int
+	}{
+		{"configured admin", admin.Token, "password", "a much longer password", http.StatusForbidden},
+		{"wrong current password", viewer.Token, "wrong password", "tr0ub4dor and three", http.StatusUnauthorized},
+		{"new password too short", viewer.Token, "correct horse battery", "too short", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := change(tt.bearer, tt.current, tt.next); code != tt.want {
+				t.Errorf("ChangePassword() status = %d, want %d", code, tt.want)
+			}
+		})
+	}
+
+	// A viewer's read token may change its own password
+	if code := change(viewer.Token, "correct horse battery", "tr0ub4dor and three"); code != http.StatusNoContent {
+		t.Fatalf("ChangePassword() status = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := loginAs("correct horse battery"); code != http.StatusUnauthorized {
+		t.Errorf("Login(old password) status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := loginAs("tr0ub4dor and three"); code != http.StatusOK {
+		t.Errorf("Login(new password) status = %d, want %d", code, http.StatusOK)
+	}
+
+	// The previous password is in the history
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/password", viewer.Token,
+		map[string]string{"current_password": "tr0ub4dor and three", "new_password": "correct horse battery"})
+	var rejected struct {
+		Rule string `json:"rule"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &rejected); err != nil || w.Code != http.StatusBadRequest || rejected.Rule != password.RuleReused {
+		t.Errorf("ChangePassword(reused) = %d %s, want 400 for reuse", w.Code, w.Body.String())
+	}
+}
+
+func TestAuthHandler_FailedWriteSkipsHistory(t *testing.T) {
+	t.Helper()
+
+	router, _ := setupInvitationRouter(t)
+	admin := login(t, router)
+	first := invite(t, router, admin.Token, "ana@example.com")
+	second := invite(t, router, admin.Token, "ana@example.com")
+	accept := func(token, pw string) int {
+		return serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/invitations/accept", "",
+			map[string]string{"token": token, "password": pw}).Code
+	}
+	if code := accept(first.Token, "correct horse battery"); code != http.StatusCreated {
+		t.Fatalf("AcceptInvitation() status = %d, want %d", code, http.StatusCreated)
+	}
+	// The account exists now, so creating it again fails after the policy check
+	if code := accept(second.Token, "tr0ub4dor and three"); code != http.StatusConflict {
+		t.Fatalf("AcceptInvitation(second) status = %d, want %d", code, http.StatusConflict)
+	}
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/login", "",
+		map[string]string{"username": "ana@example.com", "password": "correct horse battery"})
+	var viewer struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &viewer); err != nil || viewer.Token == "" {
+		t.Fatalf("Login(invitee) = %s, want a token", w.Body.String())
+	}
+
+	change := func(current, next string) int {
+		return serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/password", viewer.Token,
+			map[string]string{"current_password": current, "new_password": next}).Code
+	}
+	if code := change("correct horse battery", "a third good password"); code != http.StatusNoContent {
+		t.Fatalf("ChangePassword() status = %d, want %d", code, http.StatusNoContent)
+	}
+	// The password that was never stored is not in the history
+	if code := change("a third good password", "tr0ub4dor and three"); code != http.StatusNoContent {
+		t.Errorf("ChangePassword(never stored) status = %d, want %d", code, http.StatusNoContent)
+	}
+}
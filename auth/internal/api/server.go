@@ -6,7 +6,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/north-cloud/auth/internal/auth"
 	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+	"github.com/jonesrussell/north-cloud/auth/internal/security"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	"github.com/jonesrussell/north-cloud/auth/internal/user"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -20,14 +26,40 @@ const (
 	serviceVersion = "1.0.0"
 )
 
+// Stores holds the Redis-backed stores. Any may be nil, in which case its
+// endpoints are unavailable; without PasswordHistory, invitees' passwords
+// are not checked for reuse.
+type Stores struct {
+	ServiceKeys     *servicekey.Store
+	Sessions        *session.Store
+	Users           *user.Store
+	PasswordHistory *password.History
+}
+
 // NewServer creates a new HTTP server using the infrastructure gin package.
-func NewServer(cfg *config.Config, log logger.Logger) (*infragin.Server, error) {
+func NewServer(cfg *config.Config, log logger.Logger, stores Stores) (*infragin.Server, error) {
 	// Create JWT manager
 	jwtConfig := cfg.GetJWTConfig()
 	jwtManager := auth.NewJWTManager(jwtConfig.Secret, jwtConfig.Expiration)
 
-	// Create auth handler
-	authHandler := NewAuthHandler(cfg, jwtManager, log)
+	// Create auth handler with brute-force and anomaly detection
+	authHandler := NewAuthHandler(cfg, jwtManager, log).
+		WithSecurityGuard(newSecurityGuard(&cfg.Security, log)).
+		WithServiceKeys(stores.ServiceKeys).
+		WithSessions(stores.Sessions).
+		WithUsers(stores.Users).
+		WithPasswordPolicy(NewPasswordPolicy(&cfg.PasswordPolicy, stores.PasswordHistory))
+
+	// Service keys are checked against the store here, not through AUTH_URL
+	var keyVerifier infrajwt.ServiceKeyVerifier
+	if stores.ServiceKeys != nil {
+		keyVerifier = stores.ServiceKeys
+	}
+	requireToken := infrajwt.Middleware(jwtConfig.Secret, infrajwt.WithServiceKeyVerifier(keyVerifier))
+	requirePasswordToken := infrajwt.Middleware(jwtConfig.Secret,
+		infrajwt.WithServiceKeyVerifier(keyVerifier),
+		infrajwt.WithReadRoutes("/api/v1/auth/password"),
+	)
 
 	// Build server using infrastructure gin package
 	server := infragin.NewServerBuilder(cfg.Service.Name, cfg.Service.Port).
@@ -41,12 +73,83 @@ func NewServer(cfg *config.Config, log logger.Logger) (*infragin.Server, error)
 		).
 		WithMetrics().
 		WithRoutes(func(router *gin.Engine) {
-			// Auth routes (no JWT protection - this IS the auth service)
+			// Auth routes (login is unprotected - this IS the auth service)
 			v1 := router.Group("/api/v1")
 			authGroup := v1.Group("/auth")
 			authGroup.POST("/login", authHandler.Login)
+			// Refresh and logout take the refresh token, as the access token may have expired
+			authGroup.POST("/refresh", authHandler.Refresh)
+			authGroup.POST("/logout", authHandler.Logout)
+			authGroup.GET("/sessions", requireToken, authHandler.ListSessions)
+			authGroup.DELETE("/sessions", requireToken, authHandler.RevokeAllSessions)
+			authGroup.DELETE("/sessions/:id", requireToken, authHandler.RevokeSession)
+			// Invitations; accept is open, as the invitation token authenticates it
+			authGroup.POST("/invitations", requireToken, authHandler.Invite)
+			authGroup.GET("/invitations", requireToken, authHandler.ListInvitations)
+			authGroup.DELETE("/invitations/:id", requireToken, authHandler.RevokeInvitation)
+			authGroup.POST("/invitations/accept", authHandler.AcceptInvitation)
+			authGroup.GET("/users", requireToken, authHandler.ListUsers)
+			authGroup.DELETE("/users/:email", requireToken, authHandler.DeleteUser)
+			// Any account changes its own password, so read tokens may use it too
+			authGroup.POST("/password", requirePasswordToken, authHandler.ChangePassword)
+			// Scoped tokens require a valid full-access token
+			authGroup.POST("/tokens", requireToken, authHandler.IssueToken)
+			// Search API keys, likewise issued to full-access token holders
+			authGroup.POST("/api-keys", requireToken, authHandler.IssueAPIKey)
+			// Service keys; verify is open, as other services call it with the key itself
+			authGroup.POST("/service-keys", requireToken, authHandler.IssueServiceKey)
+			authGroup.GET("/service-keys", requireToken, authHandler.ListServiceKeys)
+			authGroup.DELETE("/service-keys/:id", requireToken, authHandler.RevokeServiceKey)
+			authGroup.POST("/service-keys/verify", authHandler.VerifyServiceKey)
+			// RFC 7662 introspection; open for the same reason as verify
+			authGroup.POST("/introspect", authHandler.Introspect)
 		}).
 		Build()
 
 	return server, nil
 }
+
+// NewPasswordPolicy builds the password policy from config. A nil history
+// disables reuse prevention, as does a history size below one.
+func NewPasswordPolicy(cfg *config.PasswordPolicyConfig, history *password.History) *password.Policy {
+	policy := password.NewPolicy(cfg.MinLength)
+	if cfg.BreachCheck {
+		policy.WithBreachChecker(password.NewRangeChecker(cfg.BreachAPIURL, cfg.BreachTimeout))
+	}
+	if history != nil && cfg.HistorySize > 0 {
+		policy.WithHistory(history)
+	}
+	return policy
+}
+
+// newSecurityGuard builds the login guard and its notifiers from config.
+func newSecurityGuard(cfg *config.SecurityConfig, log logger.Logger) *security.Guard {
+	var notifiers security.MultiNotifier
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, security.NewWebhookNotifier(cfg.WebhookURL, cfg.NotifyTimeout))
+	}
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, security.NewSlackNotifier(cfg.SlackWebhookURL, cfg.NotifyTimeout))
+	}
+	var notifier security.Notifier
+	if len(notifiers) > 0 {
+		notifier = notifiers
+	}
+
+	return security.NewGuard(security.GuardConfig{
+		Detector: security.DetectorConfig{
+			MaxFailures:         cfg.MaxFailedLogins,
+			FailureWindow:       cfg.FailureWindow,
+			LockoutDuration:     cfg.LockoutDuration,
+			SuspiciousFailures:  cfg.SuspiciousFailures,
+			ImpossibleTravelKMH: cfg.ImpossibleTravelKMH,
+		},
+		Geo: security.HeaderGeoResolver{
+			CountryHeader:   cfg.CountryHeader,
+			LatitudeHeader:  cfg.LatitudeHeader,
+			LongitudeHeader: cfg.LongitudeHeader,
+		},
+		NotifyTimeout: cfg.NotifyTimeout,
+		StepUpCode:    cfg.StepUpCode,
+	}, notifier, log)
+}
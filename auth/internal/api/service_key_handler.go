@@ -0,0 +1,181 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// IssueServiceKeyRequest represents a request for a service key. Set
+// Scope or Scopes; neither gives a read-only key.
+type IssueServiceKeyRequest struct {
+	// Name identifies the service account holding the key, e.g. "mcp-north-cloud".
+	Name string `binding:"required,max=64" json:"name"`
+	// Scope is "read" (the default) or "admin".
+	Scope string `json:"scope,omitempty"`
+	// Scopes are explicit scopes such as "crawler:jobs:write" and "indexes:read",
+	// for least-privilege keys.
+	Scopes []string `json:"scopes,omitempty"`
+	// TTL is a Go duration such as "720h"; empty is one year.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// IssueServiceKeyResponse represents an issued service key. Only a hash of
+// the key is stored: it is shown once.
+type IssueServiceKeyResponse struct {
+	servicekey.Key
+
+	// Secret is the key to present, as a bearer token or in X-API-Key.
+	Secret string `json:"key"`
+}
+
+// VerifyServiceKeyRequest carries a service key to verify.
+type VerifyServiceKeyRequest struct {
+	Key string `binding:"required" json:"key"`
+}
+
+// IssueServiceKey issues a service key. The caller must hold full access.
+func (h *AuthHandler) IssueServiceKey(c *gin.Context) {
+	claims, ok := h.serviceKeyAdmin(c, "a full-access token is required to issue service keys")
+	if !ok {
+		return
+	}
+
+	var req IssueServiceKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	scope, message := resolveScopes(req.Scope, req.Scopes, auth.ScopeRead, true)
+	if message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+	ttl, message := parseKeyTTL(req.TTL)
+	if message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
+	key, created, err := h.serviceKeys.Create(c.Request.Context(), req.Name, scope, claims.Sub, ttl)
+	if err != nil {
+		h.log.Error("Failed to create service key", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create service key"})
+		return
+	}
+
+	h.log.Info("Issued service key",
+		logger.String("key_id", created.ID),
+		logger.String("name", created.Name),
+		logger.String("scope", scope),
+		logger.Duration("ttl", ttl),
+		logger.String("issued_by", claims.Sub),
+	)
+	c.JSON(http.StatusCreated, IssueServiceKeyResponse{Key: *created, Secret: key})
+}
+
+// ListServiceKeys lists the service keys that have not expired or been revoked.
+func (h *AuthHandler) ListServiceKeys(c *gin.Context) {
+	if _, ok := h.serviceKeyAdmin(c, "a full-access token is required to list service keys"); !ok {
+		return
+	}
+
+	keys, err := h.serviceKeys.List(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to list service keys", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list service keys"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"keys": keys, "count": len(keys)})
+}
+
+// RevokeServiceKey revokes the service key with the :id path parameter.
+func (h *AuthHandler) RevokeServiceKey(c *gin.Context) {
+	claims, ok := h.serviceKeyAdmin(c, "a full-access token is required to revoke service keys")
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	err := h.serviceKeys.Revoke(c.Request.Context(), id)
+	if errors.Is(err, servicekey.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "service key not found"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to revoke service key", logger.Error(err), logger.String("key_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke service key"})
+		return
+	}
+
+	h.log.Info("Revoked service key",
+		logger.String("key_id", id),
+		logger.String("revoked_by", claims.Sub),
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// VerifyServiceKey tells other services what a service key grants. It needs
+// no token: the key in the body is the credential being checked.
+func (h *AuthHandler) VerifyServiceKey(c *gin.Context) {
+	if h.serviceKeys == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "service keys are unavailable"})
+		return
+	}
+
+	var req VerifyServiceKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	key, err := h.serviceKeys.Verify(c.Request.Context(), req.Key)
+	if errors.Is(err, servicekey.ErrInvalidKey) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid service key"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to verify service key", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify service key"})
+		return
+	}
+	c.JSON(http.StatusOK, key.Response())
+}
+
+// serviceKeyAdmin returns the caller's claims if service keys are available
+// and the caller has full access, and otherwise writes the error response.
+func (h *AuthHandler) serviceKeyAdmin(c *gin.Context, forbidden string) (*infrajwt.Claims, bool) {
+	if h.serviceKeys == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "service keys are unavailable"})
+		return nil, false
+	}
+	claims, ok := infrajwt.GetClaims(c)
+	if !ok || !claims.HasFullAccess() {
+		c.JSON(http.StatusForbidden, gin.H{"error": forbidden})
+		return nil, false
+	}
+	return claims, true
+}
+
+// parseKeyTTL parses the TTL of a long-lived key, or returns a message
+// explaining why it is invalid. Empty is one year; the maximum is two.
+func parseKeyTTL(value string) (time.Duration, string) {
+	ttl := defaultAPIKeyTTL
+	if value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return 0, "ttl must be a positive duration such as 720h"
+		}
+		ttl = parsed
+	}
+	if ttl > maxAPIKeyTTL {
+		return 0, "ttl must not exceed " + maxAPIKeyTTL.String()
+	}
+	return ttl, ""
+}
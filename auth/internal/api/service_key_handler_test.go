@@ -0,0 +1,209 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/api"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+// setupServiceKeyRouter wires the service key routes as NewServer does; a
+// nil store leaves service keys unavailable.
+func setupServiceKeyRouter(t *testing.T, withStore bool) (*gin.Engine, *auth.JWTManager) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{JWTSecret: tokenTestSecret, JWTExpiration: 24 * time.Hour},
+	}
+	jwtMgr := auth.NewJWTManager(tokenTestSecret, cfg.Auth.JWTExpiration)
+	handler := api.NewAuthHandler(cfg, jwtMgr, &mockLogger{})
+
+	var verifier infrajwt.ServiceKeyVerifier
+	if withStore {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		store := servicekey.NewStore(client)
+		handler.WithServiceKeys(store)
+		verifier = store
+	}
+	requireToken := infrajwt.Middleware(tokenTestSecret, infrajwt.WithServiceKeyVerifier(verifier))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/service-keys", requireToken, handler.IssueServiceKey)
+	router.GET("/api/v1/auth/service-keys", requireToken, handler.ListServiceKeys)
+	router.DELETE("/api/v1/auth/service-keys/:id", requireToken, handler.RevokeServiceKey)
+	router.POST("/api/v1/auth/service-keys/verify", handler.VerifyServiceKey)
+	return router, jwtMgr
+}
+
+func serviceKeyRequest(router *gin.Engine, method, path, bearer string, body any) *httptest.ResponseRecorder {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_ServiceKeyLifecycle(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupServiceKeyRouter(t, true)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys", adminToken,
+		map[string]any{"name": "mcp-north-cloud", "scope": "admin", "ttl": "720h"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("IssueServiceKey() status = %d, want %d, body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var issued api.IssueServiceKeyResponse
+	if unmarshalErr := json.Unmarshal(w.Body.Bytes(), &issued); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal response: %v", unmarshalErr)
+	}
+	if !infrajwt.IsServiceKey(issued.Secret) || issued.Scope != auth.ScopeAdmin || issued.CreatedBy != "dashboard" {
+		t.Errorf("IssueServiceKey() = %+v", issued)
+	}
+
+	// Other services verify the key without a token
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys/verify", "", map[string]string{"key": issued.Secret})
+	var verified infrajwt.ServiceKeyResponse
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &verified) != nil {
+		t.Fatalf("VerifyServiceKey() status = %d, body: %s", w.Code, w.Body.String())
+	}
+	if verified.KeyID != issued.ID || verified.Name != "mcp-north-cloud" || verified.Scope != auth.ScopeAdmin {
+		t.Errorf("VerifyServiceKey() = %+v", verified)
+	}
+
+	// The key itself authenticates, here to list keys
+	w = serviceKeyRequest(router, http.MethodGet, "/api/v1/auth/service-keys", issued.Secret, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ListServiceKeys() status = %d, body: %s", w.Code, w.Body.String())
+	}
+	var listed struct {
+		Keys  []servicekey.Key `json:"keys"`
+		Count int              `json:"count"`
+	}
+	if unmarshalErr := json.Unmarshal(w.Body.Bytes(), &listed); unmarshalErr != nil || listed.Count != 1 {
+		t.Fatalf("ListServiceKeys() = %s", w.Body.String())
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte(issued.Secret)) || bytes.Contains(w.Body.Bytes(), []byte("secret_hash")) {
+		t.Error("ListServiceKeys() exposes key material")
+	}
+
+	w = serviceKeyRequest(router, http.MethodDelete, "/api/v1/auth/service-keys/"+issued.ID, adminToken, nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("RevokeServiceKey() status = %d, body: %s", w.Code, w.Body.String())
+	}
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys/verify", "", map[string]string{"key": issued.Secret})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("VerifyServiceKey() after revoke status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	w = serviceKeyRequest(router, http.MethodDelete, "/api/v1/auth/service-keys/"+issued.ID, adminToken, nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("second RevokeServiceKey() status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestAuthHandler_ServiceKeyExplicitScopes(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupServiceKeyRouter(t, true)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys", adminToken,
+		map[string]any{"name": "mcp-north-cloud", "scopes": []string{"indexes:read", "crawler:jobs:write"}})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("IssueServiceKey() status = %d, want %d, body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var issued api.IssueServiceKeyResponse
+	if unmarshalErr := json.Unmarshal(w.Body.Bytes(), &issued); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal response: %v", unmarshalErr)
+	}
+	if issued.Scope != "crawler:jobs:write indexes:read" {
+		t.Errorf("scope = %q, want the sorted explicit scopes", issued.Scope)
+	}
+
+	// Explicit scopes are not full access, so the key cannot manage keys
+	w = serviceKeyRequest(router, http.MethodGet, "/api/v1/auth/service-keys", issued.Secret, nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("ListServiceKeys() with a scoped key status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestAuthHandler_IssueServiceKey_Rejects(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupServiceKeyRouter(t, true)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	readToken, err := jwtMgr.GenerateScopedToken("grafana", auth.ScopeRead, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken() error = %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		bearer string
+		body   map[string]any
+		want   int
+	}{
+		{"read token", readToken, map[string]any{"name": "grafana"}, http.StatusForbidden},
+		{"missing name", adminToken, map[string]any{"scope": "read"}, http.StatusBadRequest},
+		{"unknown scope", adminToken, map[string]any{"name": "grafana", "scope": "search_api"}, http.StatusBadRequest},
+		{"unknown explicit scope", adminToken, map[string]any{"name": "mcp", "scopes": []string{"crawler:jobs:run"}}, http.StatusBadRequest},
+		{"ttl too long", adminToken, map[string]any{"name": "grafana", "ttl": "20000h"}, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys", tc.bearer, tc.body)
+		if w.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, tc.want)
+		}
+	}
+}
+
+func TestAuthHandler_ServiceKeysUnavailable(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupServiceKeyRouter(t, false)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys", adminToken, map[string]any{"name": "grafana"})
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("IssueServiceKey() status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/service-keys/verify", "",
+		map[string]string{"key": infrajwt.ServiceKeyPrefix + "0123456789abcdef_x"})
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("VerifyServiceKey() status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// RefreshRequest carries the refresh token to exchange or log out.
+type RefreshRequest struct {
+	RefreshToken string `binding:"required" json:"refresh_token"`
+}
+
+// Refresh exchanges a refresh token for a new access token and a new
+// refresh token. The old refresh token stops working; presenting it again
+// revokes the session.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	if h.sessions == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "refresh tokens are unavailable"})
+		return
+	}
+
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	refreshToken, refreshed, err := h.sessions.Refresh(c.Request.Context(), req.RefreshToken)
+	if errors.Is(err, session.ErrTokenReused) {
+		h.log.Warn("Refresh token reused; session revoked",
+			logger.String("client_ip", c.ClientIP()),
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+		return
+	}
+	if errors.Is(err, session.ErrInvalidToken) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to refresh session", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refresh session"})
+		return
+	}
+
+	resp, err := h.sessionResponse(refreshed, refreshToken)
+	if err != nil {
+		h.log.Error("Failed to generate token", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Logout ends the session behind a refresh token. It succeeds for tokens
+// that are already invalid, so clients can always log out.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if h.sessions == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "refresh tokens are unavailable"})
+		return
+	}
+
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.sessions.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		h.log.Error("Failed to log out", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log out"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListSessions lists the sessions that have not expired or been revoked.
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	if _, ok := h.sessionAdmin(c, "a full-access token is required to list sessions"); !ok {
+		return
+	}
+
+	sessions, err := h.sessions.List(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to list sessions", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sessions"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions, "count": len(sessions)})
+}
+
+// RevokeSession revokes the session with the :id path parameter. Its
+// refresh token stops working at once; its access token lasts until it
+// expires.
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	claims, ok := h.sessionAdmin(c, "a full-access token is required to revoke sessions")
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	err := h.sessions.Revoke(c.Request.Context(), id)
+	if errors.Is(err, session.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to revoke session", logger.Error(err), logger.String("session_id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke session"})
+		return
+	}
+
+	h.log.Info("Revoked session",
+		logger.String("session_id", id),
+		logger.String("revoked_by", claims.Sub),
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeAllSessions revokes every session, logging everyone out centrally.
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	claims, ok := h.sessionAdmin(c, "a full-access token is required to revoke sessions")
+	if !ok {
+		return
+	}
+
+	revoked, err := h.sessions.RevokeAll(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to revoke sessions", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke sessions"})
+		return
+	}
+
+	h.log.Info("Revoked all sessions",
+		logger.Int("revoked", revoked),
+		logger.String("revoked_by", claims.Sub),
+	)
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// sessionAdmin returns the caller's claims if sessions are available and
+// the caller has full access, and otherwise writes the error response.
+func (h *AuthHandler) sessionAdmin(c *gin.Context, forbidden string) (*infrajwt.Claims, bool) {
+	if h.sessions == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "refresh tokens are unavailable"})
+		return nil, false
+	}
+	claims, ok := infrajwt.GetClaims(c)
+	if !ok || !claims.HasFullAccess() {
+		c.JSON(http.StatusForbidden, gin.H{"error": forbidden})
+		return nil, false
+	}
+	return claims, true
+}
@@ -0,0 +1,174 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/api"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+// setupSessionRouter wires login and the session routes as NewServer does;
+// a nil store leaves refresh tokens unavailable.
+func setupSessionRouter(t *testing.T, withStore bool) *gin.Engine {
+	t.Helper()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{
+			Username:        "admin",
+			Password:        "password",
+			JWTSecret:       tokenTestSecret,
+			JWTExpiration:   24 * time.Hour,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 24 * time.Hour,
+		},
+	}
+	jwtMgr := auth.NewJWTManager(tokenTestSecret, cfg.Auth.JWTExpiration)
+	handler := api.NewAuthHandler(cfg, jwtMgr, &mockLogger{})
+	if withStore {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		handler.WithSessions(session.NewStore(client, cfg.Auth.RefreshTokenTTL))
+	}
+	requireToken := infrajwt.Middleware(tokenTestSecret)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/login", handler.Login)
+	router.POST("/api/v1/auth/refresh", handler.Refresh)
+	router.POST("/api/v1/auth/logout", handler.Logout)
+	router.GET("/api/v1/auth/sessions", requireToken, handler.ListSessions)
+	router.DELETE("/api/v1/auth/sessions", requireToken, handler.RevokeAllSessions)
+	router.DELETE("/api/v1/auth/sessions/:id", requireToken, handler.RevokeSession)
+	return router
+}
+
+func login(t *testing.T, router *gin.Engine) api.LoginResponse {
+	t.Helper()
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/login", "",
+		map[string]string{"username": "admin", "password": "password"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Login() status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var resp api.LoginResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode login response: %v", err)
+	}
+	return resp
+}
+
+func TestAuthHandler_RefreshRotates(t *testing.T) {
+	t.Helper()
+
+	router := setupSessionRouter(t, true)
+	first := login(t, router)
+	if first.RefreshToken == "" {
+		t.Fatal("Login() returned no refresh token")
+	}
+	if ttl := time.Until(first.ExpiresAt); ttl > 15*time.Minute || ttl < 14*time.Minute {
+		t.Errorf("access token expires in %v, want about 15m", ttl)
+	}
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/refresh", "",
+		map[string]string{"refresh_token": first.RefreshToken})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Refresh() status = %d, want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var second api.LoginResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &second); err != nil {
+		t.Fatalf("failed to decode refresh response: %v", err)
+	}
+	if second.Token == "" || second.RefreshToken == "" || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("Refresh() = %+v, want a new access token and a rotated refresh token", second)
+	}
+
+	// The rotated-out token is reuse: it fails and takes the session with it
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/refresh", "",
+		map[string]string{"refresh_token": first.RefreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Refresh(reused) status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/refresh", "",
+		map[string]string{"refresh_token": second.RefreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Refresh() after reuse status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthHandler_LogoutAndRevokeSessions(t *testing.T) {
+	t.Helper()
+
+	router := setupSessionRouter(t, true)
+	admin := login(t, router)
+	other := login(t, router)
+
+	w := serviceKeyRequest(router, http.MethodGet, "/api/v1/auth/sessions", admin.Token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ListSessions() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var listed struct {
+		Sessions []session.Session `json:"sessions"`
+		Count    int               `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("failed to decode list response: %v", err)
+	}
+	if listed.Count != 2 {
+		t.Fatalf("ListSessions() count = %d, want 2", listed.Count)
+	}
+
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/logout", "",
+		map[string]string{"refresh_token": other.RefreshToken})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Logout() status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/refresh", "",
+		map[string]string{"refresh_token": other.RefreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Refresh() after logout status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+
+	w = serviceKeyRequest(router, http.MethodDelete, "/api/v1/auth/sessions/0123456789abcdef", admin.Token, nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("RevokeSession(unknown) status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	w = serviceKeyRequest(router, http.MethodDelete, "/api/v1/auth/sessions", admin.Token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("RevokeAllSessions() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	w = serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/refresh", "",
+		map[string]string{"refresh_token": admin.RefreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Refresh() after revoke all status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthHandler_LoginWithoutSessionStore(t *testing.T) {
+	t.Helper()
+
+	router := setupSessionRouter(t, false)
+	resp := login(t, router)
+	if resp.RefreshToken != "" {
+		t.Errorf("Login() refresh token = %q, want none without a session store", resp.RefreshToken)
+	}
+	if ttl := time.Until(resp.ExpiresAt); ttl < 23*time.Hour {
+		t.Errorf("token expires in %v, want jwt_expiration", ttl)
+	}
+
+	w := serviceKeyRequest(router, http.MethodPost, "/api/v1/auth/refresh", "",
+		map[string]string{"refresh_token": "ncr_0123456789abcdef_secret"})
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Refresh() status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
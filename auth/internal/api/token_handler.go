@@ -0,0 +1,135 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// maxScopedTokenTTL caps the lifetime of issued read-only tokens.
+const maxScopedTokenTTL = 30 * 24 * time.Hour
+
+// maxScopes caps the explicit scopes on one token or service key.
+const maxScopes = 32
+
+// defaultScopedTokenSubject is used when the caller does not name the token holder.
+const defaultScopedTokenSubject = "dashboard-readonly"
+
+// IssueTokenRequest represents a request for a scoped token. Exactly one
+// of Scope and Scopes is required.
+type IssueTokenRequest struct {
+	// Scope is "read"; admin cannot be issued here.
+	Scope string `json:"scope,omitempty"`
+	// Scopes are explicit scopes such as "crawler:jobs:write" and "indexes:read".
+	Scopes []string `json:"scopes,omitempty"`
+	// TTL is a Go duration such as "24h"; empty uses the default JWT expiration.
+	TTL string `json:"ttl,omitempty"`
+	// Subject identifies the holder, e.g. "grafana".
+	Subject string `binding:"max=64" json:"subject,omitempty"`
+}
+
+// IssueTokenResponse represents an issued scoped token. Scope holds the
+// granted scopes separated by spaces.
+type IssueTokenResponse struct {
+	Token     string    `json:"token"`
+	Scope     string    `json:"scope"`
+	Subject   string    `json:"subject"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IssueToken issues a read-only token or one limited to explicit scopes.
+// The caller must hold a full-access token, so a scoped token cannot mint
+// further tokens.
+func (h *AuthHandler) IssueToken(c *gin.Context) {
+	claims, ok := infrajwt.GetClaims(c)
+	if !ok || !claims.HasFullAccess() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "a full-access token is required to issue tokens"})
+		return
+	}
+
+	var req IssueTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	scope, message := resolveScopes(req.Scope, req.Scopes, "", false)
+	if message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
+	ttl := h.config.Auth.JWTExpiration
+	if req.TTL != "" {
+		parsed, err := time.ParseDuration(req.TTL)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ttl must be a positive duration such as 24h"})
+			return
+		}
+		ttl = parsed
+	}
+	if ttl > maxScopedTokenTTL {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ttl must not exceed " + maxScopedTokenTTL.String()})
+		return
+	}
+
+	subject := req.Subject
+	if subject == "" {
+		subject = defaultScopedTokenSubject
+	}
+
+	token, err := h.jwtManager.GenerateScopedToken(subject, scope, ttl)
+	if err != nil {
+		h.log.Error("Failed to generate scoped token", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+		return
+	}
+
+	h.log.Info("Issued scoped token",
+		logger.String("subject", subject),
+		logger.String("scope", scope),
+		logger.Duration("ttl", ttl),
+		logger.String("issued_by", claims.Sub),
+	)
+	c.JSON(http.StatusCreated, IssueTokenResponse{
+		Token:     token,
+		Scope:     scope,
+		Subject:   subject,
+		ExpiresAt: time.Now().Add(ttl).UTC(),
+	})
+}
+
+// resolveScopes turns a requested scope or list of explicit scopes into a
+// scope claim, or returns a message explaining why they are invalid. An
+// empty request gets defaultScope; an empty defaultScope makes one required.
+func resolveScopes(scope string, scopes []string, defaultScope string, allowAdmin bool) (string, string) {
+	if scope != "" && len(scopes) > 0 {
+		return "", "set scope or scopes, not both"
+	}
+	if len(scopes) == 0 {
+		if scope == "" {
+			scope = defaultScope
+		}
+		if scope == "" {
+			return "", "scope or scopes is required"
+		}
+		scopes = []string{scope}
+	}
+	if len(scopes) > maxScopes {
+		return "", "at most " + strconv.Itoa(maxScopes) + " scopes are allowed"
+	}
+
+	for _, s := range scopes {
+		if !infrajwt.ValidScope(s) {
+			return "", "unknown scope " + strconv.Quote(s) + "; use read, admin, or <resource>:read|write"
+		}
+		if s == auth.ScopeAdmin && !allowAdmin {
+			return "", "the admin scope cannot be issued here"
+		}
+	}
+	return infrajwt.JoinScopes(scopes), ""
+}
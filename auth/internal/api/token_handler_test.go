@@ -0,0 +1,147 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/auth/internal/api"
+	"github.com/jonesrussell/north-cloud/auth/internal/auth"
+	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+const tokenTestSecret = "test-secret-key-32-chars-minimum"
+
+func setupTokenRouter(t *testing.T) (*gin.Engine, *auth.JWTManager) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{JWTSecret: tokenTestSecret, JWTExpiration: 24 * time.Hour},
+	}
+	jwtMgr := auth.NewJWTManager(tokenTestSecret, cfg.Auth.JWTExpiration)
+	handler := api.NewAuthHandler(cfg, jwtMgr, &mockLogger{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/auth/tokens", infrajwt.Middleware(tokenTestSecret), handler.IssueToken)
+	return router, jwtMgr
+}
+
+func issueToken(router *gin.Engine, bearer string, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/tokens", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_IssueToken_ReadScope(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupTokenRouter(t)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	w := issueToken(router, adminToken, map[string]string{"scope": "read", "ttl": "2h", "subject": "grafana"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("IssueToken() status = %d, want %d, body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	var resp api.IssueTokenResponse
+	if unmarshalErr := json.Unmarshal(w.Body.Bytes(), &resp); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal response: %v", unmarshalErr)
+	}
+	claims, err := jwtMgr.ValidateToken(resp.Token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Scope != auth.ScopeRead || claims.Sub != "grafana" {
+		t.Errorf("claims = (%q, %q), want (read, grafana)", claims.Scope, claims.Sub)
+	}
+}
+
+func TestAuthHandler_IssueToken_ExplicitScopes(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupTokenRouter(t)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	w := issueToken(router, adminToken, map[string]any{
+		"scopes": []string{"indexes:read", "crawler:jobs:write"}, "subject": "mcp-north-cloud",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("IssueToken() status = %d, want %d, body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	var resp api.IssueTokenResponse
+	if unmarshalErr := json.Unmarshal(w.Body.Bytes(), &resp); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal response: %v", unmarshalErr)
+	}
+	claims, err := jwtMgr.ValidateToken(resp.Token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Scope != "crawler:jobs:write indexes:read" || resp.Scope != claims.Scope {
+		t.Errorf("scope = %q (response %q), want the sorted explicit scopes", claims.Scope, resp.Scope)
+	}
+
+	cases := map[string]map[string]any{
+		"scope and scopes":   {"scope": "read", "scopes": []string{"indexes:read"}},
+		"no scope":           {"subject": "grafana"},
+		"unknown scope":      {"scopes": []string{"indexes:delete"}},
+		"admin among scopes": {"scopes": []string{"indexes:read", "admin"}},
+	}
+	for name, body := range cases {
+		if w := issueToken(router, adminToken, body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestAuthHandler_IssueToken_ReadTokenCannotMint(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupTokenRouter(t)
+	readToken, err := jwtMgr.GenerateScopedToken("dashboard", auth.ScopeRead, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken() error = %v", err)
+	}
+
+	w := issueToken(router, readToken, map[string]string{"scope": "read"})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("IssueToken() status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestAuthHandler_IssueToken_RejectsInvalidRequests(t *testing.T) {
+	t.Helper()
+
+	router, jwtMgr := setupTokenRouter(t)
+	adminToken, err := jwtMgr.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	cases := map[string]map[string]string{
+		"admin scope":  {"scope": "admin"},
+		"ttl too long": {"scope": "read", "ttl": "2000h"},
+		"bad ttl":      {"scope": "read", "ttl": "soon"},
+	}
+	for name, body := range cases {
+		if w := issueToken(router, adminToken, body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -5,11 +5,23 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 )
 
+// Token scopes. They mirror infrastructure/jwt, which enforces them in services.
+const (
+	ScopeAdmin = "admin"
+	ScopeRead  = "read"
+)
+
+// DashboardSubject is the subject of tokens issued to the configured admin
+// through the dashboard. Invited accounts use their email.
+const DashboardSubject = "dashboard"
+
 // Claims represents JWT claims
 type Claims struct {
-	Sub string `json:"sub"`
+	Sub   string `json:"sub"`
+	Scope string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -27,13 +39,43 @@ func NewJWTManager(secret string, expiration time.Duration) *JWTManager {
 	}
 }
 
-// GenerateToken generates a new JWT token
+// GenerateToken generates a new full-access JWT token
 func (m *JWTManager) GenerateToken() (string, error) {
+	return m.GenerateScopedToken(DashboardSubject, "", m.expiration)
+}
+
+// GenerateSessionToken generates a token for subject's login session,
+// limited to scope (none for full access), valid for ttl, and carrying the
+// session ID as jti.
+func (m *JWTManager) GenerateSessionToken(subject, scope, sessionID string, ttl time.Duration) (string, error) {
 	now := time.Now()
 	claims := &Claims{
-		Sub: "dashboard",
+		Sub:   subject,
+		Scope: scope,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(m.expiration)),
+			ID:        sessionID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+}
+
+// GenerateScopedToken generates a JWT token for subject limited to scope.
+// An empty scope produces a full-access token; ttl <= 0 uses the default expiration.
+func (m *JWTManager) GenerateScopedToken(subject, scope string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = m.expiration
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		Sub:   subject,
+		Scope: scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 		},
@@ -43,6 +85,12 @@ func (m *JWTManager) GenerateToken() (string, error) {
 	return token.SignedString(m.secret)
 }
 
+// GenerateAPIKey signs a search API key for claims, valid for ttl, and
+// returns it with its key ID.
+func (m *JWTManager) GenerateAPIKey(claims *infrajwt.APIKeyClaims, ttl time.Duration) (key, keyID string, err error) {
+	return infrajwt.NewAPIKey(string(m.secret), claims, ttl)
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func (m *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
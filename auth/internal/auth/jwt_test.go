@@ -81,6 +81,28 @@ func TestJWTManager_ValidateToken_Success(t *testing.T) {
 	}
 }
 
+func TestJWTManager_GenerateSessionToken(t *testing.T) {
+	t.Helper()
+
+	mgr := auth.NewJWTManager("test-secret-key-32-chars-minimum", 24*time.Hour)
+
+	token, err := mgr.GenerateSessionToken(auth.DashboardSubject, "", "0123456789abcdef", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateSessionToken() error = %v", err)
+	}
+	claims, err := mgr.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+
+	if claims.Sub != "dashboard" || claims.Scope != "" || claims.ID != "0123456789abcdef" {
+		t.Errorf("claims = (%q, %q, %q), want a full-access dashboard token for the session", claims.Sub, claims.Scope, claims.ID)
+	}
+	if ttl := time.Until(claims.ExpiresAt.Time); ttl > 15*time.Minute || ttl < 14*time.Minute {
+		t.Errorf("token expires in %v, want about 15m", ttl)
+	}
+}
+
 func TestJWTManager_ValidateToken_Expired(t *testing.T) {
 	t.Helper()
 
@@ -136,3 +158,25 @@ func TestJWTManager_ValidateToken_MalformedToken(t *testing.T) {
 		}
 	}
 }
+
+func TestJWTManager_GenerateScopedToken(t *testing.T) {
+	t.Helper()
+
+	mgr := auth.NewJWTManager("test-secret-key-32-chars-minimum", 24*time.Hour)
+
+	token, err := mgr.GenerateScopedToken("grafana", auth.ScopeRead, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken() error = %v", err)
+	}
+
+	claims, err := mgr.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Sub != "grafana" || claims.Scope != auth.ScopeRead {
+		t.Errorf("claims = (%q, %q), want (grafana, read)", claims.Sub, claims.Scope)
+	}
+	if remaining := time.Until(claims.ExpiresAt.Time); remaining > time.Hour {
+		t.Errorf("token expires in %v, want <= 1h", remaining)
+	}
+}
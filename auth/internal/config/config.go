@@ -15,15 +15,36 @@ const (
 	defaultPassword       = "admin"
 	defaultJWTSecret      = "change-me-in-production"
 	defaultJWTExpirationH = 24
+	defaultAccessTokenM   = 15
+	defaultRefreshTokenH  = 168
 	defaultLoggingLevel   = "info"
 	defaultLoggingFormat  = "json"
+	defaultRedisAddress   = "localhost:6379"
+
+	defaultMaxFailedLogins     = 5
+	defaultFailureWindowM      = 15
+	defaultLockoutDurationM    = 15
+	defaultSuspiciousFailures  = 3
+	defaultImpossibleTravelKMH = 900
+	defaultNotifyTimeoutS      = 10
+	defaultCountryHeader       = "CF-IPCountry"
+	defaultLatitudeHeader      = "CF-IPLatitude"
+	defaultLongitudeHeader     = "CF-IPLongitude"
+
+	defaultPasswordMinLength     = 12
+	defaultPasswordHistorySize   = 5
+	defaultPasswordBreachTimeout = 5
+	minPasswordMinLength         = 8
 )
 
 // Config holds the application configuration.
 type Config struct {
-	Service ServiceConfig `yaml:"service"`
-	Auth    AuthConfig    `yaml:"auth"`
-	Logging LoggingConfig `yaml:"logging"`
+	Service        ServiceConfig        `yaml:"service"`
+	Auth           AuthConfig           `yaml:"auth"`
+	Security       SecurityConfig       `yaml:"security"`
+	PasswordPolicy PasswordPolicyConfig `yaml:"password_policy"`
+	Redis          RedisConfig          `yaml:"redis"`
+	Logging        LoggingConfig        `yaml:"logging"`
 }
 
 // ServiceConfig holds service-level configuration.
@@ -33,12 +54,56 @@ type ServiceConfig struct {
 	Debug bool   `env:"APP_DEBUG" yaml:"debug"`
 }
 
-// AuthConfig holds authentication configuration.
+// AuthConfig holds authentication configuration. Logins get an access
+// token valid for AccessTokenTTL and a refresh token whose session lasts
+// RefreshTokenTTL; without the session store they get a JWTExpiration token.
+// InviteURL is the dashboard page invitation links point to.
 type AuthConfig struct {
-	Username      string        `env:"AUTH_USERNAME"   yaml:"username"`
-	Password      string        `env:"AUTH_PASSWORD"   yaml:"password"`
-	JWTSecret     string        `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
-	JWTExpiration time.Duration `yaml:"jwt_expiration"`
+	Username        string        `env:"AUTH_USERNAME"          yaml:"username"`
+	Password        string        `env:"AUTH_PASSWORD"          yaml:"password"`
+	JWTSecret       string        `env:"AUTH_JWT_SECRET"        yaml:"jwt_secret"`
+	JWTExpiration   time.Duration `yaml:"jwt_expiration"`
+	AccessTokenTTL  time.Duration `env:"AUTH_ACCESS_TOKEN_TTL"  yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `env:"AUTH_REFRESH_TOKEN_TTL" yaml:"refresh_token_ttl"`
+	InviteURL       string        `env:"AUTH_INVITE_URL"        yaml:"invite_url"`
+}
+
+// SecurityConfig holds brute-force protection, anomaly detection, and
+// security notification settings.
+type SecurityConfig struct {
+	MaxFailedLogins     int           `env:"AUTH_MAX_FAILED_LOGINS"          yaml:"max_failed_logins"`
+	FailureWindow       time.Duration `yaml:"failure_window"`
+	LockoutDuration     time.Duration `env:"AUTH_LOCKOUT_DURATION"           yaml:"lockout_duration"`
+	SuspiciousFailures  int           `yaml:"suspicious_failures"`
+	ImpossibleTravelKMH float64       `yaml:"impossible_travel_kmh"`
+	CountryHeader       string        `yaml:"country_header"`
+	LatitudeHeader      string        `yaml:"latitude_header"`
+	LongitudeHeader     string        `yaml:"longitude_header"`
+	WebhookURL          string        `env:"AUTH_SECURITY_WEBHOOK_URL"       yaml:"webhook_url"`
+	SlackWebhookURL     string        `env:"AUTH_SECURITY_SLACK_WEBHOOK_URL" yaml:"slack_webhook_url"`
+	NotifyTimeout       time.Duration `yaml:"notify_timeout"`
+	StepUpCode          string        `env:"AUTH_STEP_UP_CODE"               yaml:"step_up_code"`
+}
+
+// PasswordPolicyConfig holds the rules passwords must meet when they are
+// set. The breach check sends only a 5-character SHA-1 prefix to the range
+// API. A negative history size disables reuse prevention. A configured
+// admin password that violates the policy stops the service at startup
+// unless AllowWeakAdmin is set.
+type PasswordPolicyConfig struct {
+	MinLength      int           `env:"AUTH_PASSWORD_MIN_LENGTH"       yaml:"min_length"`
+	BreachCheck    bool          `env:"AUTH_PASSWORD_BREACH_CHECK"     yaml:"breach_check"`
+	BreachAPIURL   string        `yaml:"breach_api_url"`
+	BreachTimeout  time.Duration `yaml:"breach_timeout"`
+	HistorySize    int           `env:"AUTH_PASSWORD_HISTORY_SIZE"     yaml:"history_size"`
+	AllowWeakAdmin bool          `env:"AUTH_PASSWORD_ALLOW_WEAK_ADMIN" yaml:"allow_weak_admin"`
+}
+
+// RedisConfig holds the Redis connection that stores service keys.
+type RedisConfig struct {
+	Address  string `env:"REDIS_ADDRESS"  yaml:"address"`
+	Password string `env:"REDIS_PASSWORD" yaml:"password"`
+	DB       int    `yaml:"db"`
 }
 
 // LoggingConfig holds logging configuration.
@@ -72,6 +137,17 @@ func setDefaults(cfg *Config) {
 	if cfg.Auth.JWTExpiration == 0 {
 		cfg.Auth.JWTExpiration = defaultJWTExpirationH * time.Hour
 	}
+	if cfg.Auth.AccessTokenTTL == 0 {
+		cfg.Auth.AccessTokenTTL = defaultAccessTokenM * time.Minute
+	}
+	if cfg.Auth.RefreshTokenTTL == 0 {
+		cfg.Auth.RefreshTokenTTL = defaultRefreshTokenH * time.Hour
+	}
+	setSecurityDefaults(&cfg.Security)
+	setPasswordPolicyDefaults(&cfg.PasswordPolicy)
+	if cfg.Redis.Address == "" {
+		cfg.Redis.Address = defaultRedisAddress
+	}
 	if cfg.Logging.Level == "" {
 		cfg.Logging.Level = defaultLoggingLevel
 	}
@@ -80,6 +156,48 @@ func setDefaults(cfg *Config) {
 	}
 }
 
+func setSecurityDefaults(s *SecurityConfig) {
+	if s.MaxFailedLogins == 0 {
+		s.MaxFailedLogins = defaultMaxFailedLogins
+	}
+	if s.FailureWindow == 0 {
+		s.FailureWindow = defaultFailureWindowM * time.Minute
+	}
+	if s.LockoutDuration == 0 {
+		s.LockoutDuration = defaultLockoutDurationM * time.Minute
+	}
+	if s.SuspiciousFailures == 0 {
+		s.SuspiciousFailures = defaultSuspiciousFailures
+	}
+	if s.ImpossibleTravelKMH == 0 {
+		s.ImpossibleTravelKMH = defaultImpossibleTravelKMH
+	}
+	if s.CountryHeader == "" {
+		s.CountryHeader = defaultCountryHeader
+	}
+	if s.LatitudeHeader == "" {
+		s.LatitudeHeader = defaultLatitudeHeader
+	}
+	if s.LongitudeHeader == "" {
+		s.LongitudeHeader = defaultLongitudeHeader
+	}
+	if s.NotifyTimeout == 0 {
+		s.NotifyTimeout = defaultNotifyTimeoutS * time.Second
+	}
+}
+
+func setPasswordPolicyDefaults(p *PasswordPolicyConfig) {
+	if p.MinLength == 0 {
+		p.MinLength = defaultPasswordMinLength
+	}
+	if p.BreachTimeout == 0 {
+		p.BreachTimeout = defaultPasswordBreachTimeout * time.Second
+	}
+	if p.HistorySize == 0 {
+		p.HistorySize = defaultPasswordHistorySize
+	}
+}
+
 // Validate validates the configuration.
 func (c *Config) Validate() error {
 	if c.Auth.Username == "" {
@@ -95,6 +213,18 @@ func (c *Config) Validate() error {
 			Message: "must be set and not use default value in production",
 		}
 	}
+	if c.Auth.AccessTokenTTL <= 0 || c.Auth.AccessTokenTTL >= c.Auth.RefreshTokenTTL {
+		return &infraconfig.ValidationError{
+			Field:   "auth.access_token_ttl",
+			Message: "must be positive and shorter than auth.refresh_token_ttl",
+		}
+	}
+	if c.PasswordPolicy.MinLength < minPasswordMinLength {
+		return &infraconfig.ValidationError{
+			Field:   "password_policy.min_length",
+			Message: "must be at least " + strconv.Itoa(minPasswordMinLength),
+		}
+	}
 	if err := infraconfig.ValidatePort("service.port", c.Service.Port); err != nil {
 		return err
 	}
@@ -0,0 +1,74 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec // the range API is keyed by SHA-1; nothing is secured with it
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRangeAPIURL is the Have I Been Pwned Pwned Passwords range API.
+const DefaultRangeAPIURL = "https://api.pwnedpasswords.com/range/"
+
+// hashPrefixLength is how much of the SHA-1 hash leaves the service.
+const hashPrefixLength = 5
+
+// RangeChecker checks passwords against a Pwned Passwords range API using
+// k-anonymity: only the first five hex characters of the password's SHA-1
+// hash are sent, and the matching suffixes are compared locally.
+type RangeChecker struct {
+	apiURL string
+	client *http.Client
+}
+
+// NewRangeChecker creates a RangeChecker for the range API at apiURL
+// (DefaultRangeAPIURL if empty).
+func NewRangeChecker(apiURL string, timeout time.Duration) *RangeChecker {
+	if apiURL == "" {
+		apiURL = DefaultRangeAPIURL
+	}
+	if !strings.HasSuffix(apiURL, "/") {
+		apiURL += "/"
+	}
+	return &RangeChecker{apiURL: apiURL, client: &http.Client{Timeout: timeout}}
+}
+
+// Breached reports whether the password appears in the breach corpus.
+func (c *RangeChecker) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // see import
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:hashPrefixLength], hash[hashPrefixLength:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+prefix, http.NoBody)
+	if err != nil {
+		return false, fmt.Errorf("build range request: %w", err)
+	}
+	// Padding hides the real number of matches from anyone watching
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("query range API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("range API returned %s", resp.Status)
+	}
+
+	// Each line is "SUFFIX:COUNT"; padding entries have a count of 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		entry, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if ok && entry == suffix && count != "0" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("read range response: %w", err)
+	}
+	return false, nil
+}
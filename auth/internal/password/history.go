@@ -0,0 +1,62 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// historyPrefix keys each subject's list of bcrypt hashes, newest first.
+const historyPrefix = "auth:password_history:"
+
+// History remembers the bcrypt hashes of a subject's last size passwords
+// in Redis.
+type History struct {
+	client *redis.Client
+	size   int
+}
+
+// NewHistory creates a History keeping size passwords per subject.
+func NewHistory(client *redis.Client, size int) *History {
+	return &History{client: client, size: size}
+}
+
+// Find returns the position of password in the subject's history, 0 being
+// the current password, or -1 if it is not there.
+func (h *History) Find(ctx context.Context, subject, password string) (int, error) {
+	hashes, err := h.client.LRange(ctx, historyPrefix+subject, 0, int64(h.size)-1).Result()
+	if err != nil {
+		return -1, fmt.Errorf("read history: %w", err)
+	}
+	for i, hash := range hashes {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if err == nil {
+			return i, nil
+		}
+		if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return -1, fmt.Errorf("compare history entry: %w", err)
+		}
+	}
+	return -1, nil
+}
+
+// Record makes password the subject's current one, forgetting the oldest
+// beyond size.
+func (h *History) Record(ctx context.Context, subject, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+
+	key := historyPrefix + subject
+	pipe := h.client.TxPipeline()
+	pipe.LPush(ctx, key, hash)
+	pipe.LTrim(ctx, key, 0, int64(h.size)-1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record password: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Package password enforces the password policy: a minimum length, a
+// breach-list check, and no reuse of recent passwords.
+package password
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Policy rules, reported in Violation.Rule.
+const (
+	RuleLength   = "length"
+	RuleBreached = "breached"
+	RuleReused   = "reused"
+)
+
+// Violation is returned when a password breaks the policy.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+func (v *Violation) Error() string {
+	return "password policy: " + v.Message
+}
+
+// BreachChecker reports whether a password appears in known breaches.
+type BreachChecker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// Policy checks passwords as they are set. The breach check and history are
+// optional.
+type Policy struct {
+	minLength int
+	breaches  BreachChecker
+	history   *History
+}
+
+// NewPolicy creates a Policy requiring at least minLength characters.
+func NewPolicy(minLength int) *Policy {
+	return &Policy{minLength: minLength}
+}
+
+// WithBreachChecker rejects passwords found in known breaches.
+func (p *Policy) WithBreachChecker(checker BreachChecker) *Policy {
+	p.breaches = checker
+	return p
+}
+
+// WithHistory rejects passwords the subject used recently.
+func (p *Policy) WithHistory(history *History) *Policy {
+	p.history = history
+	return p
+}
+
+// Check checks a password being set for subject. Setting the current
+// password again is not reuse. It returns a *Violation if the password
+// breaks the policy; other errors mean a check could not run (the breach
+// API or history store is unreachable), and the remaining checks still
+// apply. Check does not change the history: call Record once the password
+// has been stored.
+func (p *Policy) Check(ctx context.Context, subject, password string) error {
+	if n := utf8.RuneCountInString(password); n < p.minLength {
+		return &Violation{
+			Rule:    RuleLength,
+			Message: fmt.Sprintf("must be at least %d characters, not %d", p.minLength, n),
+		}
+	}
+
+	var unchecked []error
+	if p.breaches != nil {
+		breached, err := p.breaches.Breached(ctx, password)
+		if err != nil {
+			unchecked = append(unchecked, fmt.Errorf("breach check: %w", err))
+		} else if breached {
+			return &Violation{Rule: RuleBreached, Message: "appears in a known data breach"}
+		}
+	}
+
+	if p.history != nil {
+		if err := p.checkHistory(ctx, subject, password); err != nil {
+			var violation *Violation
+			if errors.As(err, &violation) {
+				return err
+			}
+			unchecked = append(unchecked, err)
+		}
+	}
+	return errors.Join(unchecked...)
+}
+
+// Record makes password the subject's current one in the history, so it
+// counts as used from now on. Recording the current password again does
+// nothing. Without a history it does nothing.
+func (p *Policy) Record(ctx context.Context, subject, password string) error {
+	if p.history == nil {
+		return nil
+	}
+	position, err := p.history.Find(ctx, subject, password)
+	if err != nil {
+		return fmt.Errorf("password history: %w", err)
+	}
+	if position == 0 {
+		return nil
+	}
+	if err := p.history.Record(ctx, subject, password); err != nil {
+		return fmt.Errorf("password history: %w", err)
+	}
+	return nil
+}
+
+// checkHistory rejects a recently used password other than the current one.
+func (p *Policy) checkHistory(ctx context.Context, subject, password string) error {
+	position, err := p.history.Find(ctx, subject, password)
+	if err != nil {
+		return fmt.Errorf("password history: %w", err)
+	}
+	if position > 0 {
+		return &Violation{
+			Rule:    RuleReused,
+			Message: fmt.Sprintf("was used recently; the last %d passwords cannot be reused", p.history.size),
+		}
+	}
+	return nil
+}
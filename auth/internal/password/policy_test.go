@@ -0,0 +1,151 @@
+package password_test
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // mirrors the range API
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRangeServer serves a range API in which only breached appears, with
+// padding entries as the real API returns them.
+func newRangeServer(t *testing.T, breached string) (server *httptest.Server, prefixes *[]string) {
+	t.Helper()
+
+	sum := sha1.Sum([]byte(breached)) //nolint:gosec // see import
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	var seen []string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimPrefix(r.URL.Path, "/range/")
+		seen = append(seen, prefix)
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Error("range request without Add-Padding")
+		}
+		fmt.Fprintln(w, "0018A45C4D1DEF81644B54AB7F969B88D65:0")
+		if prefix == hash[:5] {
+			fmt.Fprintf(w, "%s:3861493\n", hash[5:])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &seen
+}
+
+func violationRule(t *testing.T, err error) string {
+	t.Helper()
+
+	var violation *password.Violation
+	if !errors.As(err, &violation) {
+		t.Fatalf("Check() error = %v, want a *Violation", err)
+	}
+	return violation.Rule
+}
+
+func TestPolicy_Length(t *testing.T) {
+	t.Helper()
+
+	policy := password.NewPolicy(12)
+	if rule := violationRule(t, policy.Check(context.Background(), "admin", "short")); rule != password.RuleLength {
+		t.Errorf("rule = %q, want %q", rule, password.RuleLength)
+	}
+	// Length counts characters, not bytes
+	if rule := violationRule(t, policy.Check(context.Background(), "admin", "ééééé")); rule != password.RuleLength {
+		t.Errorf("rule = %q, want %q", rule, password.RuleLength)
+	}
+	if err := policy.Check(context.Background(), "admin", "correct horse battery"); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+}
+
+func TestPolicy_Breached(t *testing.T) {
+	t.Helper()
+
+	server, prefixes := newRangeServer(t, "password1234")
+	policy := password.NewPolicy(8).WithBreachChecker(password.NewRangeChecker(server.URL+"/range", time.Second))
+
+	if rule := violationRule(t, policy.Check(context.Background(), "admin", "password1234")); rule != password.RuleBreached {
+		t.Errorf("rule = %q, want %q", rule, password.RuleBreached)
+	}
+	if err := policy.Check(context.Background(), "admin", "correct horse battery"); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	for _, prefix := range *prefixes {
+		if len(prefix) != 5 {
+			t.Errorf("range request sent %q, want only a 5-character hash prefix", prefix)
+		}
+	}
+}
+
+func TestPolicy_BreachCheckUnavailable(t *testing.T) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	policy := password.NewPolicy(8).WithBreachChecker(password.NewRangeChecker(server.URL, time.Second))
+
+	err := policy.Check(context.Background(), "admin", "correct horse battery")
+	var violation *password.Violation
+	if err == nil || errors.As(err, &violation) {
+		t.Errorf("Check() error = %v, want an unchecked error that is not a violation", err)
+	}
+}
+
+func TestPolicy_History(t *testing.T) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	policy := password.NewPolicy(8).WithHistory(password.NewHistory(client, 2))
+	ctx := context.Background()
+	set := func(subject, pw string) error {
+		if err := policy.Check(ctx, subject, pw); err != nil {
+			return err
+		}
+		return policy.Record(ctx, subject, pw)
+	}
+
+	for _, pw := range []string{"first password", "second password"} {
+		if err := set("admin", pw); err != nil {
+			t.Fatalf("set(%q) error = %v", pw, err)
+		}
+	}
+	// Setting the current password again, e.g. on every restart, is fine
+	if err := set("admin", "second password"); err != nil {
+		t.Errorf("set(current) error = %v", err)
+	}
+	if rule := violationRule(t, policy.Check(ctx, "admin", "first password")); rule != password.RuleReused {
+		t.Errorf("rule = %q, want %q", rule, password.RuleReused)
+	}
+	// History is per subject
+	if err := set("other", "first password"); err != nil {
+		t.Errorf("set() for another subject error = %v", err)
+	}
+
+	// A password that was checked but never stored is not in the history
+	if err := policy.Check(ctx, "admin", "unsaved password"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if err := policy.Check(ctx, "admin", "unsaved password"); err != nil {
+		t.Errorf("Check(unsaved) error = %v, want nil", err)
+	}
+
+	// Past the history size, the oldest password may be used again
+	if err := set("admin", "third password"); err != nil {
+		t.Fatalf("set() error = %v", err)
+	}
+	if err := set("admin", "first password"); err != nil {
+		t.Errorf("set(forgotten) error = %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package security
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// maxTrackedKeys triggers a sweep of expired state so sprayed usernames
+	// and IPs cannot grow memory without bound.
+	maxTrackedKeys = 10000
+	// maxKnownCountries caps the per-user country history.
+	maxKnownCountries = 50
+	// minTravelWindow avoids dividing by ~0 for near-simultaneous logins.
+	minTravelWindow = time.Minute
+
+	ipKeyPrefix   = "ip:"
+	userKeyPrefix = "user:"
+)
+
+// DetectorConfig holds brute-force and anomaly thresholds.
+type DetectorConfig struct {
+	// MaxFailures is the failed attempts within FailureWindow that lock out an IP.
+	MaxFailures int
+	// FailureWindow is how long failed attempts are remembered.
+	FailureWindow time.Duration
+	// LockoutDuration is how long a locked-out IP is refused.
+	LockoutDuration time.Duration
+	// SuspiciousFailures is how many recent failures make a success suspicious.
+	SuspiciousFailures int
+	// ImpossibleTravelKMH is the speed above which two logins are flagged.
+	ImpossibleTravelKMH float64
+}
+
+// loginHistory is what the detector remembers about a user's successful logins.
+type loginHistory struct {
+	countries map[string]bool
+	last      Location
+	lastAt    time.Time
+}
+
+// Detector tracks login attempts in memory. It is safe for concurrent use.
+// State does not survive a restart.
+type Detector struct {
+	cfg DetectorConfig
+
+	mu          sync.Mutex
+	failures    map[string][]time.Time
+	lockedUntil map[string]time.Time
+	history     map[string]*loginHistory
+}
+
+// NewDetector creates a login anomaly detector.
+func NewDetector(cfg DetectorConfig) *Detector {
+	return &Detector{
+		cfg:         cfg,
+		failures:    make(map[string][]time.Time),
+		lockedUntil: make(map[string]time.Time),
+		history:     make(map[string]*loginHistory),
+	}
+}
+
+// LockedUntil reports whether the client IP is locked out and until when.
+func (d *Detector) LockedUntil(clientIP string, now time.Time) (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	until, ok := d.lockedUntil[ipKeyPrefix+clientIP]
+	if !ok || !now.Before(until) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// RecordFailure records a failed login and returns any brute-force events.
+// Only IPs are locked out: locking a username would let anyone lock out the
+// real user, so username-wide bursts are reported but not blocked.
+func (d *Detector) RecordFailure(attempt *Attempt) []Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweepLocked(attempt.At)
+
+	var events []Event
+
+	ipKey := ipKeyPrefix + attempt.ClientIP
+	ipFailures := d.addFailureLocked(ipKey, attempt.At)
+	if d.cfg.MaxFailures > 0 && ipFailures >= d.cfg.MaxFailures {
+		if until, locked := d.lockedUntil[ipKey]; !locked || !attempt.At.Before(until) {
+			until := attempt.At.Add(d.cfg.LockoutDuration)
+			d.lockedUntil[ipKey] = until
+			events = append(events, attempt.event(EventBruteForce, map[string]any{
+				"failures":      ipFailures,
+				"window":        d.cfg.FailureWindow.String(),
+				"locked_until":  until,
+				"lockout_scope": "ip",
+			}))
+		}
+	}
+
+	userKey := userKeyPrefix + attempt.Username
+	userFailures := d.addFailureLocked(userKey, attempt.At)
+	// Report once, when the threshold is first crossed.
+	if d.cfg.MaxFailures > 0 && userFailures == d.cfg.MaxFailures && ipFailures < d.cfg.MaxFailures {
+		events = append(events, attempt.event(EventDistributedBruteForce, map[string]any{
+			"failures": userFailures,
+			"window":   d.cfg.FailureWindow.String(),
+		}))
+	}
+
+	return events
+}
+
+// AssessSuccess returns anomaly events for a successful login without
+// recording it, so a login refused for step-up does not become history.
+func (d *Detector) AssessSuccess(attempt *Attempt) []Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var events []Event
+
+	recent := len(d.recentFailuresLocked(userKeyPrefix+attempt.Username, attempt.At))
+	if d.cfg.SuspiciousFailures > 0 && recent >= d.cfg.SuspiciousFailures {
+		events = append(events, attempt.event(EventFailuresThenSuccess, map[string]any{
+			"recent_failures": recent,
+			"window":          d.cfg.FailureWindow.String(),
+		}))
+	}
+
+	hist, ok := d.history[attempt.Username]
+	if !ok {
+		return events
+	}
+
+	if attempt.Location.Country != "" && len(hist.countries) > 0 && !hist.countries[attempt.Location.Country] {
+		events = append(events, attempt.event(EventNewCountry, map[string]any{
+			"known_countries": sortedKeys(hist.countries),
+		}))
+	}
+
+	if d.cfg.ImpossibleTravelKMH > 0 && attempt.Location.HasCoords && hist.last.HasCoords {
+		distance := distanceKM(hist.last, attempt.Location)
+		elapsed := max(attempt.At.Sub(hist.lastAt), minTravelWindow)
+		speed := distance / elapsed.Hours()
+		if speed > d.cfg.ImpossibleTravelKMH {
+			events = append(events, attempt.event(EventImpossibleTravel, map[string]any{
+				"distance_km":      distance,
+				"elapsed":          attempt.At.Sub(hist.lastAt).String(),
+				"speed_kmh":        speed,
+				"previous_country": hist.last.Country,
+			}))
+		}
+	}
+
+	return events
+}
+
+// RecordSuccess clears failure state for the user and IP and remembers the
+// login location.
+func (d *Detector) RecordSuccess(attempt *Attempt) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.failures, userKeyPrefix+attempt.Username)
+	delete(d.failures, ipKeyPrefix+attempt.ClientIP)
+
+	hist, ok := d.history[attempt.Username]
+	if !ok {
+		hist = &loginHistory{countries: make(map[string]bool)}
+		d.history[attempt.Username] = hist
+	}
+	if attempt.Location.Country != "" && len(hist.countries) < maxKnownCountries {
+		hist.countries[attempt.Location.Country] = true
+	}
+	if attempt.Location.HasCoords {
+		hist.last = attempt.Location
+		hist.lastAt = attempt.At
+	}
+}
+
+// addFailureLocked appends a failure and returns the count inside the window.
+func (d *Detector) addFailureLocked(key string, at time.Time) int {
+	recent := append(d.recentFailuresLocked(key, at), at)
+	d.failures[key] = recent
+	return len(recent)
+}
+
+// recentFailuresLocked returns failures for key inside the window, dropping older ones.
+func (d *Detector) recentFailuresLocked(key string, now time.Time) []time.Time {
+	all := d.failures[key]
+	cutoff := now.Add(-d.cfg.FailureWindow)
+	recent := all[:0]
+	for _, at := range all {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	if len(recent) == 0 {
+		delete(d.failures, key)
+		return nil
+	}
+	d.failures[key] = recent
+	return recent
+}
+
+// sweepLocked drops expired failures and lockouts once tracking grows large.
+func (d *Detector) sweepLocked(now time.Time) {
+	if len(d.failures)+len(d.lockedUntil) < maxTrackedKeys {
+		return
+	}
+	for key := range d.failures {
+		d.recentFailuresLocked(key, now)
+	}
+	for key, until := range d.lockedUntil {
+		if !now.Before(until) {
+			delete(d.lockedUntil, key)
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
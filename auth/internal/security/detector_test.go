@@ -0,0 +1,131 @@
+package security_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/auth/internal/security"
+)
+
+var baseTime = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDetector() *security.Detector {
+	return security.NewDetector(security.DetectorConfig{
+		MaxFailures:         3,
+		FailureWindow:       15 * time.Minute,
+		LockoutDuration:     10 * time.Minute,
+		SuspiciousFailures:  2,
+		ImpossibleTravelKMH: 900,
+	})
+}
+
+func attemptAt(username, ip string, offset time.Duration, loc security.Location) *security.Attempt {
+	return &security.Attempt{Username: username, ClientIP: ip, Location: loc, At: baseTime.Add(offset)}
+}
+
+func hasEvent(events []security.Event, eventType security.EventType) bool {
+	for i := range events {
+		if events[i].Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDetector_LocksOutIPAfterMaxFailures(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDetector()
+	var events []security.Event
+	for i := range 3 {
+		events = d.RecordFailure(attemptAt("admin", "10.0.0.1", time.Duration(i)*time.Second, security.Location{}))
+	}
+
+	if !hasEvent(events, security.EventBruteForce) {
+		t.Fatalf("expected brute_force event on third failure, got %+v", events)
+	}
+	if _, locked := d.LockedUntil("10.0.0.1", baseTime.Add(time.Minute)); !locked {
+		t.Error("expected IP to be locked out")
+	}
+	if _, locked := d.LockedUntil("10.0.0.2", baseTime.Add(time.Minute)); locked {
+		t.Error("expected other IPs to be unaffected")
+	}
+	if _, locked := d.LockedUntil("10.0.0.1", baseTime.Add(11*time.Minute)); locked {
+		t.Error("expected lockout to expire")
+	}
+}
+
+func TestDetector_DistributedBruteForceDoesNotLock(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDetector()
+	var events []security.Event
+	for i, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		events = d.RecordFailure(attemptAt("admin", ip, time.Duration(i)*time.Second, security.Location{}))
+	}
+
+	if !hasEvent(events, security.EventDistributedBruteForce) {
+		t.Fatalf("expected distributed_brute_force event, got %+v", events)
+	}
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		if _, locked := d.LockedUntil(ip, baseTime.Add(time.Minute)); locked {
+			t.Errorf("expected %s not to be locked out", ip)
+		}
+	}
+}
+
+func TestDetector_FailuresThenSuccess(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDetector()
+	d.RecordFailure(attemptAt("admin", "10.0.0.1", 0, security.Location{}))
+	d.RecordFailure(attemptAt("admin", "10.0.0.2", time.Second, security.Location{}))
+
+	success := attemptAt("admin", "10.0.0.3", time.Minute, security.Location{})
+	if events := d.AssessSuccess(success); !hasEvent(events, security.EventFailuresThenSuccess) {
+		t.Fatalf("expected failures_then_success event, got %+v", events)
+	}
+
+	d.RecordSuccess(success)
+	if events := d.AssessSuccess(attemptAt("admin", "10.0.0.3", 2*time.Minute, security.Location{})); len(events) != 0 {
+		t.Errorf("expected success to clear failure state, got %+v", events)
+	}
+}
+
+func TestDetector_NewCountry(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDetector()
+	d.RecordSuccess(attemptAt("admin", "10.0.0.1", 0, security.Location{Country: "CA"}))
+
+	if events := d.AssessSuccess(attemptAt("admin", "10.0.0.1", time.Hour, security.Location{Country: "CA"})); len(events) != 0 {
+		t.Errorf("expected no events for known country, got %+v", events)
+	}
+	events := d.AssessSuccess(attemptAt("admin", "10.0.0.1", time.Hour, security.Location{Country: "RU"}))
+	if !hasEvent(events, security.EventNewCountry) {
+		t.Errorf("expected new_country event, got %+v", events)
+	}
+}
+
+func TestDetector_ImpossibleTravel(t *testing.T) {
+	t.Parallel()
+
+	toronto := security.Location{Country: "CA", Latitude: 43.65, Longitude: -79.38, HasCoords: true}
+	london := security.Location{Country: "GB", Latitude: 51.51, Longitude: -0.13, HasCoords: true}
+
+	d := newTestDetector()
+	d.RecordSuccess(attemptAt("admin", "10.0.0.1", 0, toronto))
+	d.RecordSuccess(attemptAt("admin", "10.0.0.1", 0, london))
+
+	// ~5700 km in one hour is well above 900 km/h.
+	events := d.AssessSuccess(attemptAt("admin", "10.0.0.2", time.Hour, toronto))
+	if !hasEvent(events, security.EventImpossibleTravel) {
+		t.Errorf("expected impossible_travel event, got %+v", events)
+	}
+
+	// The same trip over a day is plausible.
+	events = d.AssessSuccess(attemptAt("admin", "10.0.0.2", 24*time.Hour, toronto))
+	if hasEvent(events, security.EventImpossibleTravel) {
+		t.Errorf("expected no impossible_travel event after 24h, got %+v", events)
+	}
+}
@@ -0,0 +1,52 @@
+// Package security detects brute-force and anomalous login patterns and
+// emits security events to the log and to webhook/Slack notifiers.
+package security
+
+import "time"
+
+// EventType identifies a kind of security event.
+type EventType string
+
+const (
+	// EventBruteForce means one IP exceeded the failed login limit and was locked out.
+	EventBruteForce EventType = "brute_force"
+	// EventDistributedBruteForce means one username exceeded the failed login limit across IPs.
+	EventDistributedBruteForce EventType = "distributed_brute_force"
+	// EventFailuresThenSuccess means a login succeeded right after repeated failures.
+	EventFailuresThenSuccess EventType = "failures_then_success"
+	// EventNewCountry means a login succeeded from a country not seen for the user before.
+	EventNewCountry EventType = "new_country"
+	// EventImpossibleTravel means two successful logins are too far apart for the time between them.
+	EventImpossibleTravel EventType = "impossible_travel"
+	// EventStepUpRequired means a suspicious login was refused pending step-up verification.
+	EventStepUpRequired EventType = "step_up_required"
+)
+
+// Event is a single security event.
+type Event struct {
+	Type     EventType      `json:"type"`
+	Username string         `json:"username"`
+	ClientIP string         `json:"client_ip"`
+	Country  string         `json:"country,omitempty"`
+	Details  map[string]any `json:"details,omitempty"`
+	At       time.Time      `json:"at"`
+}
+
+// Attempt is one login attempt as seen by the detector.
+type Attempt struct {
+	Username string
+	ClientIP string
+	Location Location
+	At       time.Time
+}
+
+func (a *Attempt) event(eventType EventType, details map[string]any) Event {
+	return Event{
+		Type:     eventType,
+		Username: a.Username,
+		ClientIP: a.ClientIP,
+		Country:  a.Location.Country,
+		Details:  details,
+		At:       a.At,
+	}
+}
@@ -0,0 +1,66 @@
+package security
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKM is the mean Earth radius used for great-circle distances.
+const earthRadiusKM = 6371.0
+
+// unknownCountry is the placeholder some edge proxies send when geolocation fails.
+const unknownCountry = "XX"
+
+// Location is the approximate origin of a request.
+type Location struct {
+	Country   string
+	Latitude  float64
+	Longitude float64
+	HasCoords bool
+}
+
+// HeaderGeoResolver reads request geolocation from headers set by an edge
+// proxy (Cloudflare's CF-IPCountry / CF-IPLatitude / CF-IPLongitude by
+// default), so no GeoIP database is needed in the service.
+type HeaderGeoResolver struct {
+	CountryHeader   string
+	LatitudeHeader  string
+	LongitudeHeader string
+}
+
+// Locate returns the request location; fields are empty when headers are absent.
+func (r HeaderGeoResolver) Locate(header http.Header) Location {
+	var loc Location
+	if r.CountryHeader != "" {
+		country := strings.ToUpper(strings.TrimSpace(header.Get(r.CountryHeader)))
+		if country != unknownCountry {
+			loc.Country = country
+		}
+	}
+
+	if r.LatitudeHeader == "" || r.LongitudeHeader == "" {
+		return loc
+	}
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(header.Get(r.LatitudeHeader)), 64)
+	lon, lonErr := strconv.ParseFloat(strings.TrimSpace(header.Get(r.LongitudeHeader)), 64)
+	if latErr == nil && lonErr == nil {
+		loc.Latitude = lat
+		loc.Longitude = lon
+		loc.HasCoords = true
+	}
+	return loc
+}
+
+// distanceKM returns the haversine distance between two locations.
+func distanceKM(a, b Location) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKM * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,160 @@
+package security
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// defaultNotifyTimeout bounds a notification when GuardConfig leaves it unset.
+const defaultNotifyTimeout = 10 * time.Second
+
+// ErrStepUpRequired is returned when a suspicious login needs step-up verification.
+var ErrStepUpRequired = errors.New("step-up verification required")
+
+// StepUpError lists the anomalies that triggered a step-up requirement.
+type StepUpError struct {
+	Reasons []EventType
+}
+
+func (e *StepUpError) Error() string { return ErrStepUpRequired.Error() }
+
+// Unwrap lets callers match with errors.Is(err, ErrStepUpRequired).
+func (e *StepUpError) Unwrap() error { return ErrStepUpRequired }
+
+// Guard applies brute-force lockout and anomaly detection to logins and
+// emits the resulting security events.
+type Guard struct {
+	detector      *Detector
+	geo           HeaderGeoResolver
+	notifier      Notifier
+	notifyTimeout time.Duration
+	stepUpCode    string
+	log           logger.Logger
+	now           func() time.Time
+
+	pending sync.WaitGroup
+}
+
+// GuardConfig configures a Guard.
+type GuardConfig struct {
+	Detector      DetectorConfig
+	Geo           HeaderGeoResolver
+	NotifyTimeout time.Duration
+	// StepUpCode, when set, is required with logins flagged as anomalous.
+	StepUpCode string
+}
+
+// NewGuard creates a login guard. notifier may be nil to only log events.
+func NewGuard(cfg GuardConfig, notifier Notifier, log logger.Logger) *Guard {
+	if cfg.NotifyTimeout <= 0 {
+		cfg.NotifyTimeout = defaultNotifyTimeout
+	}
+	return &Guard{
+		detector:      NewDetector(cfg.Detector),
+		geo:           cfg.Geo,
+		notifier:      notifier,
+		notifyTimeout: cfg.NotifyTimeout,
+		stepUpCode:    cfg.StepUpCode,
+		log:           log,
+		now:           time.Now,
+	}
+}
+
+// NewAttempt builds an attempt from the request headers and client IP.
+func (g *Guard) NewAttempt(username, clientIP string, header http.Header) *Attempt {
+	return &Attempt{
+		Username: username,
+		ClientIP: clientIP,
+		Location: g.geo.Locate(header),
+		At:       g.now(),
+	}
+}
+
+// RetryAfter returns how long the attempt's IP remains locked out, or 0.
+func (g *Guard) RetryAfter(attempt *Attempt) time.Duration {
+	until, locked := g.detector.LockedUntil(attempt.ClientIP, attempt.At)
+	if !locked {
+		return 0
+	}
+	return until.Sub(attempt.At)
+}
+
+// LoginFailed records a failed login.
+func (g *Guard) LoginFailed(attempt *Attempt) {
+	g.emit(g.detector.RecordFailure(attempt))
+}
+
+// LoginSucceeded assesses a login with valid credentials. When anomalies are
+// found and a step-up code is configured, the login is refused with a
+// *StepUpError unless stepUpCode matches.
+func (g *Guard) LoginSucceeded(attempt *Attempt, stepUpCode string) error {
+	events := g.detector.AssessSuccess(attempt)
+
+	if len(events) > 0 && g.stepUpCode != "" {
+		if subtle.ConstantTimeCompare([]byte(stepUpCode), []byte(g.stepUpCode)) != 1 {
+			reasons := make([]EventType, 0, len(events))
+			for i := range events {
+				reasons = append(reasons, events[i].Type)
+			}
+			events = append(events, attempt.event(EventStepUpRequired, map[string]any{
+				"reasons":       reasons,
+				"code_supplied": stepUpCode != "",
+			}))
+			g.emit(events)
+			return &StepUpError{Reasons: reasons}
+		}
+		for i := range events {
+			if events[i].Details == nil {
+				events[i].Details = map[string]any{}
+			}
+			events[i].Details["step_up"] = "passed"
+		}
+	}
+
+	g.detector.RecordSuccess(attempt)
+	g.emit(events)
+	return nil
+}
+
+// Wait blocks until in-flight notifications finish. Used on shutdown and in tests.
+func (g *Guard) Wait() {
+	g.pending.Wait()
+}
+
+// emit logs events and sends them to the notifier in the background so a
+// slow webhook never delays a login response.
+func (g *Guard) emit(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	for i := range events {
+		g.log.Warn("Security event",
+			logger.String("event_type", string(events[i].Type)),
+			logger.String("username", events[i].Username),
+			logger.String("client_ip", events[i].ClientIP),
+			logger.String("country", events[i].Country),
+			logger.Any("details", events[i].Details),
+		)
+	}
+
+	if g.notifier == nil {
+		return
+	}
+
+	g.pending.Add(1)
+	go func() {
+		defer g.pending.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), g.notifyTimeout)
+		defer cancel()
+		if err := g.notifier.Notify(ctx, events); err != nil {
+			g.log.Error("Failed to send security event notification", logger.Error(err))
+		}
+	}()
+}
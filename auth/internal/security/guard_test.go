@@ -0,0 +1,112 @@
+package security_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/auth/internal/security"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+type noopLogger struct{}
+
+func (l *noopLogger) Debug(_ string, _ ...infralogger.Field)         {}
+func (l *noopLogger) Info(_ string, _ ...infralogger.Field)          {}
+func (l *noopLogger) Warn(_ string, _ ...infralogger.Field)          {}
+func (l *noopLogger) Error(_ string, _ ...infralogger.Field)         {}
+func (l *noopLogger) Fatal(_ string, _ ...infralogger.Field)         {}
+func (l *noopLogger) With(_ ...infralogger.Field) infralogger.Logger { return l }
+func (l *noopLogger) Sync() error                                    { return nil }
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []security.Event
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, events []security.Event) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, events...)
+	return nil
+}
+
+func (n *recordingNotifier) types() []security.EventType {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	types := make([]security.EventType, 0, len(n.events))
+	for i := range n.events {
+		types = append(types, n.events[i].Type)
+	}
+	return types
+}
+
+func newTestGuard(notifier security.Notifier, stepUpCode string) *security.Guard {
+	return security.NewGuard(security.GuardConfig{
+		Detector: security.DetectorConfig{
+			MaxFailures:        5,
+			FailureWindow:      15 * time.Minute,
+			LockoutDuration:    15 * time.Minute,
+			SuspiciousFailures: 2,
+		},
+		Geo:        security.HeaderGeoResolver{CountryHeader: "CF-IPCountry"},
+		StepUpCode: stepUpCode,
+	}, notifier, &noopLogger{})
+}
+
+func TestGuard_StepUpRequiredForSuspiciousLogin(t *testing.T) {
+	t.Parallel()
+
+	notifier := &recordingNotifier{}
+	guard := newTestGuard(notifier, "123456")
+
+	guard.LoginFailed(guard.NewAttempt("admin", "10.0.0.1", http.Header{}))
+	guard.LoginFailed(guard.NewAttempt("admin", "10.0.0.2", http.Header{}))
+
+	err := guard.LoginSucceeded(guard.NewAttempt("admin", "10.0.0.3", http.Header{}), "")
+	var stepUpErr *security.StepUpError
+	if !errors.As(err, &stepUpErr) || !errors.Is(err, security.ErrStepUpRequired) {
+		t.Fatalf("expected StepUpError, got %v", err)
+	}
+	if len(stepUpErr.Reasons) != 1 || stepUpErr.Reasons[0] != security.EventFailuresThenSuccess {
+		t.Errorf("unexpected reasons: %v", stepUpErr.Reasons)
+	}
+
+	if stepErr := guard.LoginSucceeded(guard.NewAttempt("admin", "10.0.0.3", http.Header{}), "123456"); stepErr != nil {
+		t.Fatalf("expected login with step-up code to pass, got %v", stepErr)
+	}
+
+	// Notifications are sent concurrently, so only membership is checked.
+	guard.Wait()
+	types := notifier.types()
+	if len(types) != 3 || !slices.Contains(types, security.EventStepUpRequired) {
+		t.Errorf("unexpected notified events: %v", types)
+	}
+}
+
+func TestGuard_NoStepUpCodeOnlyReports(t *testing.T) {
+	t.Parallel()
+
+	notifier := &recordingNotifier{}
+	guard := newTestGuard(notifier, "")
+
+	header := http.Header{}
+	header.Set("CF-IPCountry", "CA")
+	if err := guard.LoginSucceeded(guard.NewAttempt("admin", "10.0.0.1", header), ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	header.Set("CF-IPCountry", "BR")
+	if err := guard.LoginSucceeded(guard.NewAttempt("admin", "10.0.0.1", header), ""); err != nil {
+		t.Fatalf("expected anomaly to be reported without blocking, got %v", err)
+	}
+
+	guard.Wait()
+	if types := notifier.types(); len(types) != 1 || types[0] != security.EventNewCountry {
+		t.Errorf("unexpected notified events: %v", types)
+	}
+}
@@ -0,0 +1,124 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+)
+
+// maxErrorBodyBytes caps how much of a failed webhook response is echoed in errors.
+const maxErrorBodyBytes = 512
+
+// Notifier delivers security events to an external channel.
+type Notifier interface {
+	Notify(ctx context.Context, events []Event) error
+}
+
+// webhookPayload is the JSON body posted to generic webhooks.
+type webhookPayload struct {
+	Service string  `json:"service"`
+	Events  []Event `json:"events"`
+}
+
+// slackPayload is the JSON body accepted by Slack incoming webhooks.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// WebhookNotifier posts events as JSON to a generic webhook URL.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts JSON event batches to url.
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: timeout}),
+	}
+}
+
+// Notify implements Notifier.
+func (n *WebhookNotifier) Notify(ctx context.Context, events []Event) error {
+	return postJSON(ctx, n.httpClient, n.url, webhookPayload{Service: "auth", Events: events})
+}
+
+// SlackNotifier posts events as a text message to a Slack incoming webhook.
+type SlackNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewSlackNotifier creates a notifier for a Slack incoming webhook URL.
+func NewSlackNotifier(url string, timeout time.Duration) *SlackNotifier {
+	return &SlackNotifier{
+		url:        url,
+		httpClient: infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: timeout}),
+	}
+}
+
+// Notify implements Notifier.
+func (n *SlackNotifier) Notify(ctx context.Context, events []Event) error {
+	return postJSON(ctx, n.httpClient, n.url, slackPayload{Text: formatSlackText(events)})
+}
+
+func formatSlackText(events []Event) string {
+	var b strings.Builder
+	b.WriteString(":rotating_light: *Auth security events*")
+	for i := range events {
+		e := &events[i]
+		fmt.Fprintf(&b, "\n• `%s` user=%s ip=%s", e.Type, e.Username, e.ClientIP)
+		if e.Country != "" {
+			fmt.Fprintf(&b, " country=%s", e.Country)
+		}
+	}
+	return b.String()
+}
+
+// MultiNotifier fans events out to several notifiers, attempting all of them.
+type MultiNotifier []Notifier
+
+// Notify implements Notifier, joining the errors of failed notifiers.
+func (m MultiNotifier) Notify(ctx context.Context, events []Event) error {
+	errs := make([]error, 0, len(m))
+	for _, n := range m {
+		if err := n.Notify(ctx, events); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal security event payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+// Package servicekey issues, lists, revokes, and verifies service keys:
+// long-lived credentials for services and integrations that stand in for a
+// JWT, so holders never need the JWT secret. Keys are stored in Redis as a
+// SHA-256 hash of their secret; the key itself is only shown once.
+package servicekey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keys: one string per key record, and a set indexing their IDs.
+const (
+	keyRecordPrefix = "auth:service_keys:"
+	keyIndex        = "auth:service_keys"
+)
+
+const (
+	// idBytes and secretBytes are the random bytes in a key's ID and secret.
+	idBytes     = 8
+	secretBytes = 32
+)
+
+var (
+	// ErrNotFound is returned when revoking a key that does not exist or has expired.
+	ErrNotFound = errors.New("service key not found")
+	// ErrInvalidKey is returned for a key that is malformed, unknown,
+	// revoked, or expired. It is the middleware's ErrInvalidServiceKey.
+	ErrInvalidKey = infrajwt.ErrInvalidServiceKey
+)
+
+// Key describes an issued service key. It never holds the key itself.
+type Key struct {
+	ID        string    `json:"key_id"`
+	Name      string    `json:"name"`
+	Scope     string    `json:"scope"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// record is a Key as stored, with the hash of its secret.
+type record struct {
+	Key
+	SecretHash string `json:"secret_hash"`
+}
+
+// Store keeps service keys in Redis. Records expire with their keys, so
+// expired keys disappear without a cleanup job.
+type Store struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// NewStore creates a Store.
+func NewStore(client *redis.Client) *Store {
+	return &Store{client: client, now: time.Now}
+}
+
+// Create issues a key named name with scope, valid for ttl, and returns the
+// key, formatted as "nck_<id>_<secret>", with its description.
+func (s *Store) Create(ctx context.Context, name, scope, createdBy string, ttl time.Duration) (string, *Key, error) {
+	id := make([]byte, idBytes)
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, fmt.Errorf("generate service key id: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, fmt.Errorf("generate service key secret: %w", err)
+	}
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := s.now().UTC()
+	rec := record{
+		Key: Key{
+			ID:        hex.EncodeToString(id),
+			Name:      name,
+			Scope:     scope,
+			CreatedBy: createdBy,
+			CreatedAt: now,
+			ExpiresAt: now.Add(ttl),
+		},
+		SecretHash: hashSecret(encodedSecret),
+	}
+	payload, err := json.Marshal(&rec)
+	if err != nil {
+		return "", nil, fmt.Errorf("encode service key: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, keyRecordPrefix+rec.ID, payload, ttl)
+	pipe.SAdd(ctx, keyIndex, rec.ID)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return "", nil, fmt.Errorf("store service key: %w", err)
+	}
+
+	return infrajwt.ServiceKeyPrefix + rec.ID + "_" + encodedSecret, &rec.Key, nil
+}
+
+// List returns the keys that have not expired or been revoked, oldest
+// first. IDs of expired keys are dropped from the index as they are found.
+func (s *Store) List(ctx context.Context) ([]Key, error) {
+	ids, err := s.client.SMembers(ctx, keyIndex).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list service keys: %w", err)
+	}
+	if len(ids) == 0 {
+		return []Key{}, nil
+	}
+
+	recordKeys := make([]string, len(ids))
+	for i, id := range ids {
+		recordKeys[i] = keyRecordPrefix + id
+	}
+	values, err := s.client.MGet(ctx, recordKeys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("load service keys: %w", err)
+	}
+
+	keys := make([]Key, 0, len(values))
+	var expired []any
+	for i, value := range values {
+		payload, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+		var rec record
+		if decodeErr := json.Unmarshal([]byte(payload), &rec); decodeErr != nil {
+			return nil, fmt.Errorf("decode service key %s: %w", ids[i], decodeErr)
+		}
+		keys = append(keys, rec.Key)
+	}
+	if len(expired) > 0 {
+		if remErr := s.client.SRem(ctx, keyIndex, expired...).Err(); remErr != nil {
+			return nil, fmt.Errorf("prune service keys: %w", remErr)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b Key) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return keys, nil
+}
+
+// Revoke deletes the key with id; it stops working at once in auth, and in
+// other services when their verification cache expires.
+func (s *Store) Revoke(ctx context.Context, id string) error {
+	pipe := s.client.TxPipeline()
+	deleted := pipe.Del(ctx, keyRecordPrefix+id)
+	pipe.SRem(ctx, keyIndex, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("revoke service key: %w", err)
+	}
+	if deleted.Val() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Verify returns the description of key, or ErrInvalidKey.
+func (s *Store) Verify(ctx context.Context, key string) (*Key, error) {
+	id, secret, ok := parseKey(key)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+
+	payload, err := s.client.Get(ctx, keyRecordPrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load service key: %w", err)
+	}
+
+	var rec record
+	if decodeErr := json.Unmarshal(payload, &rec); decodeErr != nil {
+		return nil, fmt.Errorf("decode service key %s: %w", id, decodeErr)
+	}
+	if subtle.ConstantTimeCompare([]byte(rec.SecretHash), []byte(hashSecret(secret))) != 1 {
+		return nil, ErrInvalidKey
+	}
+	if !s.now().Before(rec.ExpiresAt) {
+		return nil, ErrInvalidKey
+	}
+	return &rec.Key, nil
+}
+
+// VerifyServiceKey implements infrajwt.ServiceKeyVerifier, so auth's own
+// middleware checks keys against the store directly.
+func (s *Store) VerifyServiceKey(ctx context.Context, key string) (*infrajwt.Claims, error) {
+	k, err := s.Verify(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	response := k.Response()
+	return response.Claims(), nil
+}
+
+// Response returns the verification response for the key.
+func (k *Key) Response() infrajwt.ServiceKeyResponse {
+	return infrajwt.ServiceKeyResponse{
+		KeyID:     k.ID,
+		Name:      k.Name,
+		Scope:     k.Scope,
+		ExpiresAt: k.ExpiresAt,
+	}
+}
+
+// parseKey splits "nck_<id>_<secret>" into its ID and secret.
+func parseKey(key string) (id, secret string, ok bool) {
+	rest, found := strings.CutPrefix(key, infrajwt.ServiceKeyPrefix)
+	if !found {
+		return "", "", false
+	}
+	id, secret, found = strings.Cut(rest, "_")
+	if !found || len(id) != hex.EncodedLen(idBytes) || secret == "" {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+// hashSecret returns the hex SHA-256 digest of a key secret.
+func hashSecret(secret string) string {
+	digest := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,126 @@
+package servicekey_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestStore(t *testing.T) (*servicekey.Store, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return servicekey.NewStore(client), mr
+}
+
+func TestStore_CreateAndVerify(t *testing.T) {
+	t.Helper()
+
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	key, created, err := store.Create(ctx, "grafana", infrajwt.ScopeRead, "dashboard", time.Hour)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !strings.HasPrefix(key, infrajwt.ServiceKeyPrefix+created.ID+"_") {
+		t.Errorf("key %q does not carry prefix and ID %s", key, created.ID)
+	}
+
+	verified, err := store.Verify(ctx, key)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if verified.Name != "grafana" || verified.Scope != infrajwt.ScopeRead || verified.CreatedBy != "dashboard" {
+		t.Errorf("Verify() = %+v", verified)
+	}
+
+	claims, err := store.VerifyServiceKey(ctx, key)
+	if err != nil {
+		t.Fatalf("VerifyServiceKey() error = %v", err)
+	}
+	if claims.Sub != "grafana" || claims.ID != created.ID || claims.HasFullAccess() {
+		t.Errorf("VerifyServiceKey() claims = %+v", claims)
+	}
+}
+
+func TestStore_VerifyRejects(t *testing.T) {
+	t.Helper()
+
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+	key, created, err := store.Create(ctx, "grafana", infrajwt.ScopeRead, "dashboard", time.Hour)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	cases := map[string]string{
+		"wrong secret":   infrajwt.ServiceKeyPrefix + created.ID + "_wrong",
+		"unknown id":     infrajwt.ServiceKeyPrefix + "0000000000000000_" + key[len(key)-10:],
+		"malformed id":   infrajwt.ServiceKeyPrefix + "xyz_secret",
+		"missing secret": infrajwt.ServiceKeyPrefix + created.ID,
+		"not a key":      "eyJhbGciOiJIUzI1NiJ9",
+	}
+	for name, candidate := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, verifyErr := store.Verify(ctx, candidate); !errors.Is(verifyErr, infrajwt.ErrInvalidServiceKey) {
+				t.Errorf("Verify() error = %v, want ErrInvalidServiceKey", verifyErr)
+			}
+		})
+	}
+}
+
+func TestStore_ListAndRevoke(t *testing.T) {
+	t.Helper()
+
+	store, mr := newTestStore(t)
+	ctx := context.Background()
+
+	first, firstKey, err := store.Create(ctx, "grafana", infrajwt.ScopeRead, "dashboard", time.Hour)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, _, err = store.Create(ctx, "mcp", infrajwt.ScopeAdmin, "dashboard", 2*time.Hour); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	keys, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("List() returned %d keys, want 2", len(keys))
+	}
+
+	if err = store.Revoke(ctx, firstKey.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if _, err = store.Verify(ctx, first); !errors.Is(err, infrajwt.ErrInvalidServiceKey) {
+		t.Errorf("Verify() after revoke error = %v, want ErrInvalidServiceKey", err)
+	}
+	if err = store.Revoke(ctx, firstKey.ID); !errors.Is(err, servicekey.ErrNotFound) {
+		t.Errorf("second Revoke() error = %v, want ErrNotFound", err)
+	}
+
+	// The remaining key expires with its record
+	mr.FastForward(3 * time.Hour)
+	keys, err = store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("List() after expiry returned %d keys, want 0", len(keys))
+	}
+	if members := mr.Exists("auth:service_keys"); members {
+		t.Error("expired key IDs were not pruned from the index")
+	}
+}
@@ -0,0 +1,400 @@
+// Package session keeps login sessions behind refresh tokens. A login
+// starts a session; each refresh rotates its refresh token and returns a
+// new short-lived access token, so revoking a session logs it out once its
+// current access token expires.
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RefreshTokenPrefix starts every refresh token.
+const RefreshTokenPrefix = "ncr_"
+
+// Redis keys: one string per session, and a set indexing their IDs.
+const (
+	sessionPrefix = "auth:sessions:"
+	sessionIndex  = "auth:sessions"
+)
+
+const (
+	// idBytes and secretBytes are the random bytes in a session ID and a
+	// refresh token secret.
+	idBytes     = 8
+	secretBytes = 32
+)
+
+var (
+	// ErrInvalidToken is returned for a refresh token that is malformed or
+	// whose session has ended.
+	ErrInvalidToken = errors.New("invalid refresh token")
+	// ErrTokenReused is returned when the refresh token the last refresh
+	// rotated out is presented again. The session is revoked, since either
+	// the token was stolen or its holder's copy was.
+	ErrTokenReused = errors.New("refresh token reused; session revoked")
+	// ErrNotFound is returned when revoking a session that does not exist.
+	ErrNotFound = errors.New("session not found")
+)
+
+// Session describes a login session. It never holds a refresh token.
+type Session struct {
+	ID          string    `json:"session_id"`
+	Subject     string    `json:"subject"`
+	Scope       string    `json:"scope,omitempty"`
+	ClientIP    string    `json:"client_ip"`
+	CreatedAt   time.Time `json:"created_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// record is a Session as stored, with the hashes of its current refresh
+// token and of the one the last refresh rotated out.
+type record struct {
+	Session
+	SecretHash         string `json:"secret_hash"`
+	PreviousSecretHash string `json:"previous_secret_hash,omitempty"`
+}
+
+// Store keeps sessions in Redis. A session lasts ttl from login however
+// often it is refreshed; its record expires with it.
+type Store struct {
+	client *redis.Client
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewStore creates a Store whose sessions last ttl.
+func NewStore(client *redis.Client, ttl time.Duration) *Store {
+	return &Store{client: client, ttl: ttl, now: time.Now}
+}
+
+// Create starts a session for subject, whose access tokens carry scope, and
+// returns its first refresh token.
+func (s *Store) Create(ctx context.Context, subject, scope, clientIP string) (string, *Session, error) {
+	id, err := randomHex(idBytes)
+	if err != nil {
+		return "", nil, fmt.Errorf("generate session id: %w", err)
+	}
+	secret, err := randomSecret()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := s.now().UTC()
+	rec := record{
+		Session: Session{
+			ID:          id,
+			Subject:     subject,
+			Scope:       scope,
+			ClientIP:    clientIP,
+			CreatedAt:   now,
+			RefreshedAt: now,
+			ExpiresAt:   now.Add(s.ttl),
+		},
+		SecretHash: hashSecret(secret),
+	}
+	payload, err := json.Marshal(&rec)
+	if err != nil {
+		return "", nil, fmt.Errorf("encode session: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, sessionPrefix+id, payload, s.ttl)
+	pipe.SAdd(ctx, sessionIndex, id)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return "", nil, fmt.Errorf("store session: %w", err)
+	}
+	return formatToken(id, secret), &rec.Session, nil
+}
+
+// Refresh rotates token: it returns a new refresh token for the session and
+// invalidates token. Presenting the token the last refresh rotated out
+// revokes the session and returns ErrTokenReused; any other wrong secret
+// returns ErrInvalidToken and leaves the session alone.
+func (s *Store) Refresh(ctx context.Context, token string) (string, *Session, error) {
+	id, secret, ok := parseToken(token)
+	if !ok {
+		return "", nil, ErrInvalidToken
+	}
+	newSecret, err := randomSecret()
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := sessionPrefix + id
+	var refreshed *Session
+	txErr := s.client.Watch(ctx, func(tx *redis.Tx) error {
+		rec, loadErr := load(ctx, tx, key)
+		if loadErr != nil {
+			return loadErr
+		}
+		current, previous := rec.matches(secret)
+		if !current {
+			if previous {
+				return ErrTokenReused
+			}
+			return ErrInvalidToken
+		}
+
+		remaining := rec.ExpiresAt.Sub(s.now())
+		if remaining <= 0 {
+			return ErrInvalidToken
+		}
+		rec.RefreshedAt = s.now().UTC()
+		rec.PreviousSecretHash = rec.SecretHash
+		rec.SecretHash = hashSecret(newSecret)
+		payload, encodeErr := json.Marshal(rec)
+		if encodeErr != nil {
+			return fmt.Errorf("encode session: %w", encodeErr)
+		}
+
+		_, pipeErr := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, payload, remaining)
+			return nil
+		})
+		refreshed = &rec.Session
+		return pipeErr
+	}, key)
+
+	switch {
+	case errors.Is(txErr, ErrTokenReused):
+		if revokeErr := s.Revoke(ctx, id); revokeErr != nil && !errors.Is(revokeErr, ErrNotFound) {
+			return "", nil, revokeErr
+		}
+		return "", nil, ErrTokenReused
+	case errors.Is(txErr, redis.TxFailedErr):
+		// Another refresh of the same token won the race, so this one is a reuse
+		return "", nil, ErrInvalidToken
+	case txErr != nil:
+		return "", nil, txErr
+	}
+	return formatToken(id, newSecret), refreshed, nil
+}
+
+// Logout ends the session of token. The secret must be the session's
+// current refresh token or the one the last refresh rotated out, so the
+// session ID alone (the jti of its access tokens) cannot end it. Unknown or
+// ended sessions and wrong secrets are ignored, so logging out twice is not
+// an error.
+func (s *Store) Logout(ctx context.Context, token string) error {
+	id, secret, ok := parseToken(token)
+	if !ok {
+		return ErrInvalidToken
+	}
+	rec, err := load(ctx, s.client, sessionPrefix+id)
+	if errors.Is(err, ErrInvalidToken) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current, previous := rec.matches(secret); !current && !previous {
+		return nil
+	}
+	if revokeErr := s.Revoke(ctx, id); revokeErr != nil && !errors.Is(revokeErr, ErrNotFound) {
+		return revokeErr
+	}
+	return nil
+}
+
+// List returns the active sessions, oldest first. IDs of expired sessions
+// are dropped from the index as they are found.
+func (s *Store) List(ctx context.Context) ([]Session, error) {
+	ids, err := s.client.SMembers(ctx, sessionIndex).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return []Session{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionPrefix + id
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("load sessions: %w", err)
+	}
+
+	sessions := make([]Session, 0, len(values))
+	var expired []any
+	for i, value := range values {
+		payload, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+		var rec record
+		if decodeErr := json.Unmarshal([]byte(payload), &rec); decodeErr != nil {
+			return nil, fmt.Errorf("decode session %s: %w", ids[i], decodeErr)
+		}
+		sessions = append(sessions, rec.Session)
+	}
+	if len(expired) > 0 {
+		if remErr := s.client.SRem(ctx, sessionIndex, expired...).Err(); remErr != nil {
+			return nil, fmt.Errorf("prune sessions: %w", remErr)
+		}
+	}
+
+	slices.SortFunc(sessions, func(a, b Session) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return sessions, nil
+}
+
+// Active reports whether the session with id has not expired or been revoked.
+func (s *Store) Active(ctx context.Context, id string) (bool, error) {
+	n, err := s.client.Exists(ctx, sessionPrefix+id).Result()
+	if err != nil {
+		return false, fmt.Errorf("check session: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Revoke ends the session with id.
+func (s *Store) Revoke(ctx context.Context, id string) error {
+	pipe := s.client.TxPipeline()
+	deleted := pipe.Del(ctx, sessionPrefix+id)
+	pipe.SRem(ctx, sessionIndex, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+	if deleted.Val() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RevokeAll ends every session and returns how many were active.
+func (s *Store) RevokeAll(ctx context.Context) (int, error) {
+	ids, err := s.client.SMembers(ctx, sessionIndex).Result()
+	if err != nil {
+		return 0, fmt.Errorf("list sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionPrefix + id
+	}
+	pipe := s.client.TxPipeline()
+	deleted := pipe.Del(ctx, keys...)
+	pipe.Del(ctx, sessionIndex)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("revoke sessions: %w", err)
+	}
+	return int(deleted.Val()), nil
+}
+
+// RevokeSubject ends every session of subject and returns how many there were.
+func (s *Store) RevokeSubject(ctx context.Context, subject string) (int, error) {
+	sessions, err := s.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	revoked := 0
+	for i := range sessions {
+		if sessions[i].Subject != subject {
+			continue
+		}
+		err := s.Revoke(ctx, sessions[i].ID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// matches reports, in constant time, whether secret is the record's current
+// refresh token secret or the one the last refresh rotated out.
+func (r *record) matches(secret string) (current, previous bool) {
+	presented := []byte(hashSecret(secret))
+	current = subtle.ConstantTimeCompare([]byte(r.SecretHash), presented) == 1
+	previous = r.PreviousSecretHash != "" &&
+		subtle.ConstantTimeCompare([]byte(r.PreviousSecretHash), presented) == 1
+	return current, previous
+}
+
+// load reads the session record at key, or returns ErrInvalidToken.
+func load(ctx context.Context, client redis.StringCmdable, key string) (*record, error) {
+	payload, err := client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load session: %w", err)
+	}
+	var rec record
+	if decodeErr := json.Unmarshal(payload, &rec); decodeErr != nil {
+		return nil, fmt.Errorf("decode session: %w", decodeErr)
+	}
+	return &rec, nil
+}
+
+// formatToken builds "ncr_<id>_<secret>".
+func formatToken(id, secret string) string {
+	return RefreshTokenPrefix + id + "_" + secret
+}
+
+// parseToken splits "ncr_<id>_<secret>" into its session ID and secret.
+func parseToken(token string) (id, secret string, ok bool) {
+	rest, found := strings.CutPrefix(token, RefreshTokenPrefix)
+	if !found {
+		return "", "", false
+	}
+	id, secret, found = strings.Cut(rest, "_")
+	if !found || len(id) != hex.EncodedLen(idBytes) || secret == "" {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+// randomHex returns n random bytes, hex encoded.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// randomSecret returns a new refresh token secret.
+func randomSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecret returns the hex SHA-256 digest of a refresh token secret.
+func hashSecret(secret string) string {
+	digest := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(digest[:])
+}
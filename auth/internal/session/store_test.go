@@ -0,0 +1,191 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestStore(t *testing.T) (*session.Store, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return session.NewStore(client, time.Hour), mr
+}
+
+func TestStore_RefreshRotates(t *testing.T) {
+	t.Helper()
+
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	first, created, err := store.Create(ctx, "dashboard", "", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !strings.HasPrefix(first, session.RefreshTokenPrefix+created.ID+"_") {
+		t.Errorf("token %q does not carry prefix and session ID %s", first, created.ID)
+	}
+
+	second, refreshed, err := store.Refresh(ctx, first)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if second == first || refreshed.ID != created.ID || !refreshed.ExpiresAt.Equal(created.ExpiresAt) {
+		t.Errorf("Refresh() = %q, %+v; want a new token for the same session and expiry", second, refreshed)
+	}
+
+	third, _, err := store.Refresh(ctx, second)
+	if err != nil {
+		t.Fatalf("second Refresh() error = %v", err)
+	}
+
+	// Replaying the rotated-out token revokes the session, including its latest token
+	if _, _, err = store.Refresh(ctx, second); !errors.Is(err, session.ErrTokenReused) {
+		t.Fatalf("Refresh(rotated) error = %v, want ErrTokenReused", err)
+	}
+	if _, _, err = store.Refresh(ctx, third); !errors.Is(err, session.ErrInvalidToken) {
+		t.Errorf("Refresh(latest) after reuse error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestStore_RefreshWrongSecretKeepsSession(t *testing.T) {
+	t.Helper()
+
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+	token, created, err := store.Create(ctx, "dashboard", "", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// A guessed secret is not a rotated-out token, so it must not log the holder out
+	forged := session.RefreshTokenPrefix + created.ID + "_guessed"
+	if _, _, err = store.Refresh(ctx, forged); !errors.Is(err, session.ErrInvalidToken) {
+		t.Fatalf("Refresh(wrong secret) error = %v, want ErrInvalidToken", err)
+	}
+	sessions, listErr := store.List(ctx)
+	if listErr != nil || len(sessions) != 1 || sessions[0].ID != created.ID {
+		t.Fatalf("List() = %v, %v; want the session kept", sessions, listErr)
+	}
+	if _, _, err = store.Refresh(ctx, token); err != nil {
+		t.Errorf("Refresh() after wrong secret error = %v, want nil", err)
+	}
+}
+
+func TestStore_RefreshRejects(t *testing.T) {
+	t.Helper()
+
+	store, mr := newTestStore(t)
+	ctx := context.Background()
+	token, _, err := store.Create(ctx, "dashboard", "", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	for _, candidate := range []string{"", "ncr_xyz_secret", "ncr_0000000000000000_secret", "nck_0000000000000000_secret"} {
+		if _, _, refreshErr := store.Refresh(ctx, candidate); !errors.Is(refreshErr, session.ErrInvalidToken) {
+			t.Errorf("Refresh(%q) error = %v, want ErrInvalidToken", candidate, refreshErr)
+		}
+	}
+
+	mr.FastForward(2 * time.Hour)
+	if _, _, err = store.Refresh(ctx, token); !errors.Is(err, session.ErrInvalidToken) {
+		t.Errorf("Refresh() after expiry error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestStore_LogoutAndRevoke(t *testing.T) {
+	t.Helper()
+
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+
+	laptop, _, err := store.Create(ctx, "dashboard", "", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	phone, phoneSession, err := store.Create(ctx, "dashboard", "", "198.51.100.2")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	tablet, _, err := store.Create(ctx, "dashboard", "", "192.0.2.10")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	sessions, err := store.List(ctx)
+	if err != nil || len(sessions) != 3 {
+		t.Fatalf("List() = %d sessions, %v; want 3", len(sessions), err)
+	}
+
+	if err = store.Logout(ctx, laptop); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if err = store.Logout(ctx, laptop); err != nil {
+		t.Errorf("second Logout() error = %v, want nil", err)
+	}
+	if _, _, err = store.Refresh(ctx, laptop); !errors.Is(err, session.ErrInvalidToken) {
+		t.Errorf("Refresh() after logout error = %v, want ErrInvalidToken", err)
+	}
+
+	if err = store.Revoke(ctx, phoneSession.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if err = store.Revoke(ctx, phoneSession.ID); !errors.Is(err, session.ErrNotFound) {
+		t.Errorf("second Revoke() error = %v, want ErrNotFound", err)
+	}
+	if _, _, err = store.Refresh(ctx, phone); !errors.Is(err, session.ErrInvalidToken) {
+		t.Errorf("Refresh() after revoke error = %v, want ErrInvalidToken", err)
+	}
+
+	revoked, err := store.RevokeAll(ctx)
+	if err != nil || revoked != 1 {
+		t.Fatalf("RevokeAll() = %d, %v; want 1", revoked, err)
+	}
+	if _, _, err = store.Refresh(ctx, tablet); !errors.Is(err, session.ErrInvalidToken) {
+		t.Errorf("Refresh() after RevokeAll error = %v, want ErrInvalidToken", err)
+	}
+	if sessions, err = store.List(ctx); err != nil || len(sessions) != 0 {
+		t.Errorf("List() after RevokeAll = %d sessions, %v; want 0", len(sessions), err)
+	}
+}
+
+func TestStore_LogoutChecksSecret(t *testing.T) {
+	t.Helper()
+
+	store, _ := newTestStore(t)
+	ctx := context.Background()
+	token, created, err := store.Create(ctx, "dashboard", "", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// The session ID is the jti of its access tokens, so it alone must not end the session
+	forged := session.RefreshTokenPrefix + created.ID + "_anything"
+	if err = store.Logout(ctx, forged); err != nil {
+		t.Fatalf("Logout(wrong secret) error = %v, want nil", err)
+	}
+	if active, activeErr := store.Active(ctx, created.ID); activeErr != nil || !active {
+		t.Fatalf("Active() after wrong secret = %v, %v; want the session kept", active, activeErr)
+	}
+
+	// The secret the last refresh rotated out still proves possession
+	if _, _, err = store.Refresh(ctx, token); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if err = store.Logout(ctx, token); err != nil {
+		t.Fatalf("Logout(rotated) error = %v", err)
+	}
+	if active, activeErr := store.Active(ctx, created.ID); activeErr != nil || active {
+		t.Errorf("Active() after Logout(rotated) = %v, %v; want revoked", active, activeErr)
+	}
+}
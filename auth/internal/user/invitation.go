@@ -0,0 +1,209 @@
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// InvitationTokenPrefix starts every invitation token.
+const InvitationTokenPrefix = "nci_"
+
+// Redis keys: one string per invitation, and a set indexing their IDs.
+const (
+	invitationPrefix = "auth:invitations:"
+	invitationIndex  = "auth:invitations"
+)
+
+// invitationIDBytes is the random bytes in an invitation ID.
+const invitationIDBytes = 8
+
+var (
+	// ErrInvalidInvitation is returned for an invitation token that is
+	// malformed, badly signed, revoked, used, or expired.
+	ErrInvalidInvitation = errors.New("invalid invitation")
+	// ErrInvitationNotFound is returned when revoking an invitation that
+	// does not exist or has expired.
+	ErrInvitationNotFound = errors.New("invitation not found")
+)
+
+// Invitation describes a pending invitation. It never holds the token.
+type Invitation struct {
+	ID        string    `json:"invitation_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Invite invites email with role for ttl and returns the token for the
+// invitation link, formatted as "nci_<id>_<signature>". It returns
+// ErrExists if the email already has an account.
+func (s *Store) Invite(ctx context.Context, email, role, createdBy string, ttl time.Duration) (string, *Invitation, error) {
+	email = NormalizeEmail(email)
+	exists, err := s.exists(ctx, email)
+	if err != nil {
+		return "", nil, err
+	}
+	if exists {
+		return "", nil, ErrExists
+	}
+
+	id := make([]byte, invitationIDBytes)
+	if _, err = rand.Read(id); err != nil {
+		return "", nil, fmt.Errorf("generate invitation id: %w", err)
+	}
+	now := s.now().UTC()
+	inv := Invitation{
+		ID:        hex.EncodeToString(id),
+		Email:     email,
+		Role:      role,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	payload, err := json.Marshal(&inv)
+	if err != nil {
+		return "", nil, fmt.Errorf("encode invitation: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, invitationPrefix+inv.ID, payload, ttl)
+	pipe.SAdd(ctx, invitationIndex, inv.ID)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return "", nil, fmt.Errorf("store invitation: %w", err)
+	}
+	return InvitationTokenPrefix + inv.ID + "_" + s.sign(&inv), &inv, nil
+}
+
+// VerifyInvitation returns the invitation behind token, or
+// ErrInvalidInvitation.
+func (s *Store) VerifyInvitation(ctx context.Context, token string) (*Invitation, error) {
+	rest, found := strings.CutPrefix(token, InvitationTokenPrefix)
+	if !found {
+		return nil, ErrInvalidInvitation
+	}
+	id, signature, found := strings.Cut(rest, "_")
+	if !found || len(id) != hex.EncodedLen(invitationIDBytes) {
+		return nil, ErrInvalidInvitation
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	payload, err := s.client.Get(ctx, invitationPrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load invitation: %w", err)
+	}
+	var inv Invitation
+	if decodeErr := json.Unmarshal(payload, &inv); decodeErr != nil {
+		return nil, fmt.Errorf("decode invitation %s: %w", id, decodeErr)
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(&inv))) || !s.now().Before(inv.ExpiresAt) {
+		return nil, ErrInvalidInvitation
+	}
+	return &inv, nil
+}
+
+// AcceptInvitation creates the invited account with password, pending
+// until its first login, and uses up the invitation. It returns ErrExists
+// if the email got an account meanwhile.
+func (s *Store) AcceptInvitation(ctx context.Context, inv *Invitation, password string) (*User, error) {
+	created, err := s.create(ctx, inv.Email, inv.Role, password, inv.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.RevokeInvitation(ctx, inv.ID); err != nil && !errors.Is(err, ErrInvitationNotFound) {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListInvitations returns the invitations that have not expired, been
+// revoked, or been accepted, oldest first.
+func (s *Store) ListInvitations(ctx context.Context) ([]Invitation, error) {
+	ids, err := s.client.SMembers(ctx, invitationIndex).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list invitations: %w", err)
+	}
+	if len(ids) == 0 {
+		return []Invitation{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = invitationPrefix + id
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("load invitations: %w", err)
+	}
+
+	invitations := make([]Invitation, 0, len(values))
+	var expired []any
+	for i, value := range values {
+		payload, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+		var inv Invitation
+		if decodeErr := json.Unmarshal([]byte(payload), &inv); decodeErr != nil {
+			return nil, fmt.Errorf("decode invitation %s: %w", ids[i], decodeErr)
+		}
+		invitations = append(invitations, inv)
+	}
+	if len(expired) > 0 {
+		if remErr := s.client.SRem(ctx, invitationIndex, expired...).Err(); remErr != nil {
+			return nil, fmt.Errorf("prune invitations: %w", remErr)
+		}
+	}
+
+	slices.SortFunc(invitations, func(a, b Invitation) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return invitations, nil
+}
+
+// RevokeInvitation deletes the invitation with id, so its link stops working.
+func (s *Store) RevokeInvitation(ctx context.Context, id string) error {
+	pipe := s.client.TxPipeline()
+	deleted := pipe.Del(ctx, invitationPrefix+id)
+	pipe.SRem(ctx, invitationIndex, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("revoke invitation: %w", err)
+	}
+	if deleted.Val() == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// sign returns the base64url HMAC-SHA256 of the invitation's ID, email,
+// role, and expiry, so a link cannot be forged or altered.
+func (s *Store) sign(inv *Invitation) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(strings.Join([]string{
+		"invitation", inv.ID, inv.Email, inv.Role, strconv.FormatInt(inv.ExpiresAt.Unix(), 10),
+	}, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,290 @@
+// Package user keeps the accounts onboarded by invitation, alongside the
+// single configured admin. An admin invites an email with a role; the
+// invitee sets a password through a signed link, and the account activates
+// on its first login. Accounts and invitations live in Redis; passwords
+// are stored as bcrypt hashes.
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Roles. A role decides the scope of the account's tokens.
+const (
+	// RoleAdmin has full access, like the configured admin.
+	RoleAdmin = "admin"
+	// RoleViewer may only read.
+	RoleViewer = "viewer"
+)
+
+// Account statuses.
+const (
+	// StatusPending accounts have set a password but not yet logged in.
+	StatusPending = "pending"
+	// StatusActive accounts have logged in at least once.
+	StatusActive = "active"
+)
+
+// Redis keys: one string per account, keyed by email, and a set indexing them.
+const (
+	userPrefix = "auth:users:"
+	userIndex  = "auth:users"
+)
+
+var (
+	// ErrInvalidCredentials is returned for an unknown email or wrong password.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrExists is returned when an account for the email already exists.
+	ErrExists = errors.New("user already exists")
+	// ErrNotFound is returned when deleting or updating an account that does not exist.
+	ErrNotFound = errors.New("user not found")
+)
+
+// ValidRole reports whether role is RoleAdmin or RoleViewer.
+func ValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleViewer
+}
+
+// RoleScope returns the token scope for role: none (full access) for
+// admins, "read" for viewers.
+func RoleScope(role string) string {
+	if role == RoleAdmin {
+		return ""
+	}
+	return "read"
+}
+
+// NormalizeEmail lowercases and trims an email, which identifies an account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// User describes an account. It never holds the password hash.
+type User struct {
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	InvitedBy   string     `json:"invited_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+}
+
+// userRecord is a User as stored, with the bcrypt hash of its password.
+type userRecord struct {
+	User
+	PasswordHash string `json:"password_hash"`
+}
+
+// Store keeps accounts and invitations in Redis. Invitation links are
+// signed with signingKey.
+type Store struct {
+	client     *redis.Client
+	signingKey []byte
+	now        func() time.Time
+}
+
+// NewStore creates a Store signing invitation links with signingKey.
+func NewStore(client *redis.Client, signingKey []byte) *Store {
+	return &Store{client: client, signingKey: signingKey, now: time.Now}
+}
+
+// Authenticate returns the account for email if password matches, and
+// activates a pending account. Otherwise it returns ErrInvalidCredentials.
+func (s *Store) Authenticate(ctx context.Context, email, password string) (*User, error) {
+	key := userPrefix + NormalizeEmail(email)
+	rec, err := s.loadUser(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(rec.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if rec.Status == StatusPending {
+		now := s.now().UTC()
+		rec.Status = StatusActive
+		rec.ActivatedAt = &now
+		payload, encodeErr := json.Marshal(rec)
+		if encodeErr != nil {
+			return nil, fmt.Errorf("encode user: %w", encodeErr)
+		}
+		// XX: an account deleted meanwhile stays deleted
+		if setErr := s.client.SetXX(ctx, key, payload, redis.KeepTTL).Err(); setErr != nil {
+			return nil, fmt.Errorf("activate user: %w", setErr)
+		}
+	}
+	return &rec.User, nil
+}
+
+// VerifyPassword returns ErrInvalidCredentials unless password is the
+// current password of the account for email. Unlike Authenticate, it does
+// not activate the account.
+func (s *Store) VerifyPassword(ctx context.Context, email, password string) error {
+	rec, err := s.loadUser(ctx, userPrefix+NormalizeEmail(email))
+	if errors.Is(err, ErrNotFound) {
+		return ErrInvalidCredentials
+	}
+	if err != nil {
+		return err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(rec.PasswordHash), []byte(password)) != nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// SetPassword replaces the password of the account for email, or returns
+// ErrNotFound. The caller applies the password policy first.
+func (s *Store) SetPassword(ctx context.Context, email, password string) error {
+	key := userPrefix + NormalizeEmail(email)
+	rec, err := s.loadUser(ctx, key)
+	if err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	rec.PasswordHash = string(hash)
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode user: %w", err)
+	}
+	// XX: an account deleted meanwhile stays deleted
+	updated, err := s.client.SetXX(ctx, key, payload, redis.KeepTTL).Result()
+	if err != nil {
+		return fmt.Errorf("set password: %w", err)
+	}
+	if !updated {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns every account, oldest first.
+func (s *Store) List(ctx context.Context) ([]User, error) {
+	emails, err := s.client.SMembers(ctx, userIndex).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	if len(emails) == 0 {
+		return []User{}, nil
+	}
+
+	keys := make([]string, len(emails))
+	for i, email := range emails {
+		keys[i] = userPrefix + email
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("load users: %w", err)
+	}
+
+	users := make([]User, 0, len(values))
+	for i, value := range values {
+		payload, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var rec userRecord
+		if decodeErr := json.Unmarshal([]byte(payload), &rec); decodeErr != nil {
+			return nil, fmt.Errorf("decode user %s: %w", emails[i], decodeErr)
+		}
+		users = append(users, rec.User)
+	}
+
+	slices.SortFunc(users, func(a, b User) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Email, b.Email)
+	})
+	return users, nil
+}
+
+// Delete removes the account for email. Its sessions must be revoked
+// separately.
+func (s *Store) Delete(ctx context.Context, email string) error {
+	email = NormalizeEmail(email)
+	pipe := s.client.TxPipeline()
+	deleted := pipe.Del(ctx, userPrefix+email)
+	pipe.SRem(ctx, userIndex, email)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+	if deleted.Val() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// create stores a pending account, or returns ErrExists.
+func (s *Store) create(ctx context.Context, email, role, password, invitedBy string) (*User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+	rec := userRecord{
+		User: User{
+			Email:     email,
+			Role:      role,
+			Status:    StatusPending,
+			InvitedBy: invitedBy,
+			CreatedAt: s.now().UTC(),
+		},
+		PasswordHash: string(hash),
+	}
+	payload, err := json.Marshal(&rec)
+	if err != nil {
+		return nil, fmt.Errorf("encode user: %w", err)
+	}
+
+	created, err := s.client.SetNX(ctx, userPrefix+email, payload, 0).Result()
+	if err != nil {
+		return nil, fmt.Errorf("store user: %w", err)
+	}
+	if !created {
+		return nil, ErrExists
+	}
+	if err := s.client.SAdd(ctx, userIndex, email).Err(); err != nil {
+		return nil, fmt.Errorf("index user: %w", err)
+	}
+	return &rec.User, nil
+}
+
+// exists reports whether an account for email exists.
+func (s *Store) exists(ctx context.Context, email string) (bool, error) {
+	n, err := s.client.Exists(ctx, userPrefix+email).Result()
+	if err != nil {
+		return false, fmt.Errorf("check user: %w", err)
+	}
+	return n > 0, nil
+}
+
+// loadUser reads the account stored at key, or returns ErrNotFound.
+func (s *Store) loadUser(ctx context.Context, key string) (*userRecord, error) {
+	payload, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load user: %w", err)
+	}
+	var rec userRecord
+	if decodeErr := json.Unmarshal(payload, &rec); decodeErr != nil {
+		return nil, fmt.Errorf("decode user: %w", decodeErr)
+	}
+	return &rec, nil
+}
@@ -0,0 +1,169 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jonesrussell/north-cloud/auth/internal/user"
+	"github.com/redis/go-redis/v9"
+)
+
+func newStore(t *testing.T) (*user.Store, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return user.NewStore(client, []byte("test-signing-key")), mr
+}
+
+func TestStore_InvitationOnboarding(t *testing.T) {
+	t.Helper()
+
+	store, _ := newStore(t)
+	ctx := context.Background()
+
+	token, inv, err := store.Invite(ctx, " Ana@Example.com ", user.RoleViewer, "dashboard", time.Hour)
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	if inv.Email != "ana@example.com" {
+		t.Errorf("Invite() email = %q, want it normalized", inv.Email)
+	}
+
+	verified, err := store.VerifyInvitation(ctx, token)
+	if err != nil {
+		t.Fatalf("VerifyInvitation() error = %v", err)
+	}
+	created, err := store.AcceptInvitation(ctx, verified, "correct horse battery")
+	if err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+	if created.Status != user.StatusPending || created.Role != user.RoleViewer {
+		t.Errorf("AcceptInvitation() = %+v, want a pending viewer", created)
+	}
+
+	// The invitation is used up
+	if _, err = store.VerifyInvitation(ctx, token); !errors.Is(err, user.ErrInvalidInvitation) {
+		t.Errorf("VerifyInvitation() after accept error = %v, want ErrInvalidInvitation", err)
+	}
+	if _, _, err = store.Invite(ctx, "ana@example.com", user.RoleAdmin, "dashboard", time.Hour); !errors.Is(err, user.ErrExists) {
+		t.Errorf("Invite() for an existing account error = %v, want ErrExists", err)
+	}
+
+	if _, err = store.Authenticate(ctx, "ana@example.com", "wrong password"); !errors.Is(err, user.ErrInvalidCredentials) {
+		t.Errorf("Authenticate(wrong password) error = %v, want ErrInvalidCredentials", err)
+	}
+	// The first login activates the account
+	loggedIn, err := store.Authenticate(ctx, "ANA@example.com", "correct horse battery")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if loggedIn.Status != user.StatusActive || loggedIn.ActivatedAt == nil {
+		t.Errorf("Authenticate() = %+v, want an activated account", loggedIn)
+	}
+	users, err := store.List(ctx)
+	if err != nil || len(users) != 1 || users[0].Status != user.StatusActive {
+		t.Errorf("List() = %+v, %v, want the active account", users, err)
+	}
+
+	if err = store.Delete(ctx, "ana@example.com"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = store.Authenticate(ctx, "ana@example.com", "correct horse battery"); !errors.Is(err, user.ErrInvalidCredentials) {
+		t.Errorf("Authenticate() after delete error = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestStore_VerifyInvitationRejects(t *testing.T) {
+	t.Helper()
+
+	store, mr := newStore(t)
+	ctx := context.Background()
+
+	token, inv, err := store.Invite(ctx, "ana@example.com", user.RoleViewer, "dashboard", time.Hour)
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+
+	forged := user.InvitationTokenPrefix + inv.ID + "_AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	for name, candidate := range map[string]string{
+		"forged signature": forged,
+		"malformed":        "nci_nothex_x",
+		"wrong prefix":     "nck_" + token[len(user.InvitationTokenPrefix):],
+	} {
+		if _, verifyErr := store.VerifyInvitation(ctx, candidate); !errors.Is(verifyErr, user.ErrInvalidInvitation) {
+			t.Errorf("%s: VerifyInvitation() error = %v, want ErrInvalidInvitation", name, verifyErr)
+		}
+	}
+
+	// Another store's signing key does not accept the link
+	otherClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = otherClient.Close() })
+	other := user.NewStore(otherClient, []byte("other-key"))
+	if _, err = other.VerifyInvitation(ctx, token); !errors.Is(err, user.ErrInvalidInvitation) {
+		t.Errorf("VerifyInvitation() with another key error = %v, want ErrInvalidInvitation", err)
+	}
+
+	if err = store.RevokeInvitation(ctx, inv.ID); err != nil {
+		t.Fatalf("RevokeInvitation() error = %v", err)
+	}
+	if _, err = store.VerifyInvitation(ctx, token); !errors.Is(err, user.ErrInvalidInvitation) {
+		t.Errorf("VerifyInvitation() after revoke error = %v, want ErrInvalidInvitation", err)
+	}
+
+	// Expired invitations disappear with their records
+	token, _, err = store.Invite(ctx, "bo@example.com", user.RoleViewer, "dashboard", time.Hour)
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	mr.FastForward(2 * time.Hour)
+	if _, err = store.VerifyInvitation(ctx, token); !errors.Is(err, user.ErrInvalidInvitation) {
+		t.Errorf("VerifyInvitation() after expiry error = %v, want ErrInvalidInvitation", err)
+	}
+	invitations, err := store.ListInvitations(ctx)
+	if err != nil || len(invitations) != 0 {
+		t.Errorf("ListInvitations() = %+v, %v, want none", invitations, err)
+	}
+}
+
+func TestStore_SetPassword(t *testing.T) {
+	t.Helper()
+
+	store, _ := newStore(t)
+	ctx := context.Background()
+
+	if err := store.SetPassword(ctx, "ana@example.com", "tr0ub4dor and three"); !errors.Is(err, user.ErrNotFound) {
+		t.Errorf("SetPassword(unknown) error = %v, want ErrNotFound", err)
+	}
+
+	token, _, err := store.Invite(ctx, "ana@example.com", user.RoleViewer, "dashboard", time.Hour)
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	inv, err := store.VerifyInvitation(ctx, token)
+	if err != nil {
+		t.Fatalf("VerifyInvitation() error = %v", err)
+	}
+	if _, err = store.AcceptInvitation(ctx, inv, "correct horse battery"); err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+
+	if err = store.VerifyPassword(ctx, "ana@example.com", "wrong password"); !errors.Is(err, user.ErrInvalidCredentials) {
+		t.Errorf("VerifyPassword(wrong password) error = %v, want ErrInvalidCredentials", err)
+	}
+	if err = store.SetPassword(ctx, "Ana@Example.com", "tr0ub4dor and three"); err != nil {
+		t.Fatalf("SetPassword() error = %v", err)
+	}
+	if err = store.VerifyPassword(ctx, "ana@example.com", "tr0ub4dor and three"); err != nil {
+		t.Errorf("VerifyPassword(new password) error = %v", err)
+	}
+	// Verifying does not activate the account; the first login does
+	users, err := store.List(ctx)
+	if err != nil || len(users) != 1 || users[0].Status != user.StatusPending {
+		t.Errorf("List() = %+v, %v, want the pending account", users, err)
+	}
+}
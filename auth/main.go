@@ -1,21 +1,36 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jonesrussell/north-cloud/auth/internal/api"
 	"github.com/jonesrussell/north-cloud/auth/internal/config"
+	"github.com/jonesrussell/north-cloud/auth/internal/password"
+	"github.com/jonesrussell/north-cloud/auth/internal/servicekey"
+	"github.com/jonesrussell/north-cloud/auth/internal/session"
+	"github.com/jonesrussell/north-cloud/auth/internal/user"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
+	infraredis "github.com/jonesrussell/north-cloud/infrastructure/redis"
+	"github.com/redis/go-redis/v9"
 )
 
+// passwordCheckTimeout bounds the startup password policy check.
+const passwordCheckTimeout = 15 * time.Second
+
 func main() {
 	os.Exit(run())
 }
 
 func run() int {
+	defer crash.Start("auth")()
+
 	// Start profiling server (if enabled)
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("auth"); pyroErr != nil {
@@ -73,6 +88,71 @@ func createLogger(cfg *config.Config) (logger.Logger, error) {
 	return log.With(logger.String("service", "auth")), nil
 }
 
+// connectRedis connects the Redis behind service keys, refresh tokens,
+// invited accounts and password history. Without Redis, logins issue
+// jwt_expiration tokens, only the configured admin can log in, the service
+// key, session and invitation endpoints are unavailable, and password reuse
+// is not checked, rather than the service failing to start.
+func connectRedis(cfg *config.Config, log logger.Logger) *redis.Client {
+	redisClient, err := infraredis.NewClient(infraredis.Config{
+		Address:  cfg.Redis.Address,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	if err != nil {
+		log.Warn("Service keys, refresh tokens, invitations and password history disabled: Redis unavailable", logger.Error(err))
+		return nil
+	}
+
+	log.Info("Service keys, refresh tokens and invitations enabled", logger.String("redis_address", cfg.Redis.Address))
+	return redisClient
+}
+
+// newStores builds the Redis-backed stores; a nil client leaves them unset.
+func newStores(cfg *config.Config, redisClient *redis.Client) api.Stores {
+	if redisClient == nil {
+		return api.Stores{}
+	}
+	return api.Stores{
+		ServiceKeys:     servicekey.NewStore(redisClient),
+		Sessions:        session.NewStore(redisClient, cfg.Auth.RefreshTokenTTL),
+		Users:           user.NewStore(redisClient, []byte(cfg.Auth.JWTSecret)),
+		PasswordHistory: password.NewHistory(redisClient, cfg.PasswordPolicy.HistorySize),
+	}
+}
+
+// checkPassword applies the password policy to the configured admin's
+// password, including the history when Redis is available, and records it
+// there once it is accepted. A violation stops the service unless
+// password_policy.allow_weak_admin is on, in which case it is logged as a
+// warning. A check that cannot run is only logged.
+func checkPassword(cfg *config.Config, log logger.Logger, history *password.History) error {
+	policy := api.NewPasswordPolicy(&cfg.PasswordPolicy, history)
+
+	ctx, cancel := context.WithTimeout(context.Background(), passwordCheckTimeout)
+	defer cancel()
+	err := policy.Check(ctx, cfg.Auth.Username, cfg.Auth.Password)
+
+	var violation *password.Violation
+	if err == nil || !errors.As(err, &violation) {
+		if err != nil {
+			log.Warn("Password policy check incomplete", logger.Error(err))
+		}
+		if recordErr := policy.Record(ctx, cfg.Auth.Username, cfg.Auth.Password); recordErr != nil {
+			log.Warn("Failed to record password history", logger.Error(recordErr))
+		}
+		return nil
+	}
+	if !cfg.PasswordPolicy.AllowWeakAdmin {
+		return fmt.Errorf("auth.password: %w", err)
+	}
+	log.Warn("Configured password violates the password policy; change AUTH_PASSWORD",
+		logger.String("rule", violation.Rule),
+		logger.Error(err),
+	)
+	return nil
+}
+
 // runServer creates and runs the HTTP server with graceful shutdown.
 func runServer(cfg *config.Config, log logger.Logger) int {
 	log.Info("Starting auth service",
@@ -81,7 +161,18 @@ func runServer(cfg *config.Config, log logger.Logger) int {
 		logger.Bool("debug", cfg.Service.Debug),
 	)
 
-	srv, srvErr := api.NewServer(cfg, log)
+	redisClient := connectRedis(cfg, log)
+	if redisClient != nil {
+		defer func() { _ = redisClient.Close() }()
+	}
+
+	stores := newStores(cfg, redisClient)
+	if pwErr := checkPassword(cfg, log, stores.PasswordHistory); pwErr != nil {
+		log.Error("Configured password rejected", logger.Error(pwErr))
+		return 1
+	}
+
+	srv, srvErr := api.NewServer(cfg, log, stores)
 	if srvErr != nil {
 		log.Error("Failed to create server", logger.Error(srvErr))
 		return 1
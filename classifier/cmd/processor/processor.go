@@ -21,6 +21,7 @@ import (
 	esclient "github.com/jonesrussell/north-cloud/infrastructure/elasticsearch"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/pipeline"
+	infraredis "github.com/jonesrussell/north-cloud/infrastructure/redis"
 )
 
 const (
@@ -56,6 +57,7 @@ type ProcessorConfig struct {
 	BatchSize         int
 	ConcurrentWorkers int
 	PipelineURL       string
+	OutputFields      []config.OutputFieldsRule
 }
 
 // LoadConfig loads configuration from config file with env var overrides
@@ -90,6 +92,7 @@ func LoadConfig() (*ProcessorConfig, *config.Config) {
 		BatchSize:         cfg.Service.BatchSize,
 		ConcurrentWorkers: cfg.Service.Concurrency,
 		PipelineURL:       cfg.Service.PipelineURL,
+		OutputFields:      cfg.Elasticsearch.OutputFields,
 	}, cfg
 }
 
@@ -117,7 +120,12 @@ func setupElasticsearch(cfg *ProcessorConfig, log infralogger.Logger) (*storage.
 		return nil, fmt.Errorf("failed to create Elasticsearch client: %w", err)
 	}
 
-	esStorage := storage.NewElasticsearchStorage(esClient)
+	outputFilter, err := storage.NewOutputFilter(cfg.OutputFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid elasticsearch.output_fields: %w", err)
+	}
+
+	esStorage := storage.NewElasticsearchStorage(esClient).WithOutputFilter(outputFilter)
 	if err = esStorage.TestConnection(ctx); err != nil {
 		return nil, fmt.Errorf("failed to verify Elasticsearch connection: %w", err)
 	}
@@ -387,6 +395,8 @@ func Start() error {
 		pollerConfig,
 		pipelineClient,
 	)
+	stopNotifier := setupClassifiedNotifier(poller, &fullCfg.Redis, log)
+	defer stopNotifier()
 
 	if err = poller.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start poller: %w", err)
@@ -472,8 +482,10 @@ func StartWithStop() (func(), error) {
 		pollerConfig,
 		pipelineClient,
 	)
+	stopNotifier := setupClassifiedNotifier(poller, &fullCfg.Redis, log)
 
 	if err = poller.Start(ctx); err != nil {
+		stopNotifier()
 		_ = db.Close()
 		return nil, fmt.Errorf("failed to start poller: %w", err)
 	}
@@ -484,9 +496,34 @@ func StartWithStop() (func(), error) {
 	stopFunc := func() {
 		log.Info("Stopping processor")
 		poller.Stop()
+		stopNotifier()
 		_ = db.Close()
 		log.Info("Processor stopped successfully")
 	}
 
 	return stopFunc, nil
 }
+
+// setupClassifiedNotifier announces indexed batches on the configured Redis
+// channel. Without a channel, or when Redis is unreachable, the processor runs
+// without announcing. The returned function closes the Redis connection.
+func setupClassifiedNotifier(poller *processor.Poller, cfg *config.RedisConfig, log infralogger.Logger) func() {
+	if cfg.ChannelClassified == "" {
+		return func() {}
+	}
+
+	client, err := infraredis.NewClient(infraredis.Config{
+		Address:  cfg.URL,
+		Password: cfg.Password,
+		DB:       cfg.Database,
+	})
+	if err != nil {
+		log.Warn("Classified batches will not be announced: Redis unavailable", infralogger.Error(err))
+		return func() {}
+	}
+
+	poller.WithClassifiedNotifier(storage.NewClassifiedNotifier(client, cfg.ChannelClassified))
+	log.Info("Announcing classified batches", infralogger.String("channel", cfg.ChannelClassified))
+
+	return func() { _ = client.Close() }
+}
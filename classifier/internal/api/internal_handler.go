@@ -139,3 +139,45 @@ func (h *Handler) InternalExtract(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// reputationPageSize is how many sources InternalSourceReputations reads per query.
+const reputationPageSize = 500
+
+// InternalSourceReputationsResponse maps source names to reputation scores (0-100).
+type InternalSourceReputationsResponse struct {
+	Sources     map[string]int `json:"sources"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
+
+// InternalSourceReputations handles GET /api/internal/v1/sources/reputation
+// It returns every source's reputation score in one response, for services
+// (search) that cache reputations instead of calling the classifier per request.
+func (h *Handler) InternalSourceReputations(c *gin.Context) {
+	scores := make(map[string]int)
+	filter := domain.SourceReputationListFilter{
+		Page:      1,
+		PageSize:  reputationPageSize,
+		SortBy:    "name",
+		SortOrder: "asc",
+	}
+	for {
+		sources, total, err := h.sourceReputationRepo.List(c.Request.Context(), filter)
+		if err != nil {
+			h.logger.Error("Failed to list source reputations", infralogger.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list source reputations"})
+			return
+		}
+		for _, source := range sources {
+			scores[source.SourceName] = source.ReputationScore
+		}
+		if len(sources) < filter.PageSize || len(scores) >= total {
+			break
+		}
+		filter.Page++
+	}
+
+	c.JSON(http.StatusOK, InternalSourceReputationsResponse{
+		Sources:     scores,
+		GeneratedAt: time.Now().UTC(),
+	})
+}
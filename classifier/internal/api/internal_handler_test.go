@@ -10,6 +10,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/north-cloud/classifier/internal/config"
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+	"github.com/jonesrussell/north-cloud/classifier/internal/testhelpers"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
 )
 
@@ -348,3 +350,33 @@ func TestInternalExtract_RouteRegisteredViaSetupRoutes(t *testing.T) {
 		t.Error("expected route /api/internal/v1/extract to be registered, got 404")
 	}
 }
+
+func TestInternalSourceReputations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := setupTestHandler()
+	sourceDB, ok := handler.sourceReputationRepo.(*testhelpers.MockSourceReputationDB)
+	if !ok {
+		t.Fatal("expected mock source reputation repository")
+	}
+	sourceDB.SetSource(&domain.SourceReputation{SourceName: "cbc", ReputationScore: 82})
+	sourceDB.SetSource(&domain.SourceReputation{SourceName: "spamblog", ReputationScore: 12})
+
+	router := gin.New()
+	SetupRoutes(router, handler, &config.Config{Auth: config.AuthConfig{InternalSecret: testInternalSecret}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/internal/v1/sources/reputation", http.NoBody)
+	req.Header.Set("X-Internal-Secret", testInternalSecret)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp InternalSourceReputationsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Sources["cbc"] != 82 || resp.Sources["spamblog"] != 12 || len(resp.Sources) != 2 {
+		t.Errorf("unexpected sources %v", resp.Sources)
+	}
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// replayIgnoredFields are result fields that differ between any two runs and
+// would hide the changes that matter.
+var replayIgnoredFields = map[string]struct{}{
+	"processing_time_ms": {},
+}
+
+// ReplayRequest is the optional body of a replay. An empty body replays with
+// the rules and classifier currently loaded.
+type ReplayRequest struct {
+	// Rules replaces the loaded rule set for this replay, e.g. an export of the
+	// rules as they stood when the document was classified.
+	Rules []domain.ClassificationRule `json:"rules"`
+	// ClassifierVersion pins the classifier version the caller expects. Only the
+	// running version can be replayed; other versions need that build deployed.
+	ClassifierVersion string `json:"classifier_version"`
+}
+
+// ReplaySnapshot is the classification output of one run, without the document body.
+type ReplaySnapshot struct {
+	ContentType          string                      `json:"content_type"`
+	ContentSubtype       string                      `json:"content_subtype,omitempty"`
+	QualityScore         int                         `json:"quality_score"`
+	Topics               []string                    `json:"topics"`
+	TopicScores          map[string]float64          `json:"topic_scores"`
+	SourceReputation     int                         `json:"source_reputation"`
+	SourceCategory       string                      `json:"source_category"`
+	ClassifierVersion    string                      `json:"classifier_version"`
+	ClassificationMethod string                      `json:"classification_method"`
+	ModelVersion         string                      `json:"model_version,omitempty"`
+	Confidence           float64                     `json:"confidence"`
+	Crime                *domain.CrimeResult         `json:"crime,omitempty"`
+	Mining               *domain.MiningResult        `json:"mining,omitempty"`
+	Coforge              *domain.CoforgeResult       `json:"coforge,omitempty"`
+	Entertainment        *domain.EntertainmentResult `json:"entertainment,omitempty"`
+	Indigenous           *domain.IndigenousResult    `json:"indigenous,omitempty"`
+	Location             *domain.LocationResult      `json:"location,omitempty"`
+	Locality             *domain.LocalityResult      `json:"locality,omitempty"`
+	Recipe               *domain.RecipeResult        `json:"recipe,omitempty"`
+	Job                  *domain.JobResult           `json:"job,omitempty"`
+	RFP                  *domain.RFPResult           `json:"rfp,omitempty"`
+	NeedSignal           *domain.NeedSignalResult    `json:"need_signal,omitempty"`
+	ICP                  *domain.ICPResult           `json:"icp,omitempty"`
+}
+
+// ReplayChange is one field whose value differs between the stored and replayed output.
+type ReplayChange struct {
+	Field    string `json:"field"`
+	Stored   any    `json:"stored"`
+	Replayed any    `json:"replayed"`
+}
+
+// ReplayResponse shows the stored and replayed classification side by side.
+type ReplayResponse struct {
+	ContentID     string          `json:"content_id"`
+	SourceName    string          `json:"source_name"`
+	RulesOverride bool            `json:"rules_override"`
+	Stored        *ReplaySnapshot `json:"stored"`
+	Replayed      *ReplaySnapshot `json:"replayed"`
+	Changes       []ReplayChange  `json:"changes"`
+}
+
+// ReplayDocument handles POST /api/v1/classify/replay/:content_id
+// Re-runs classification for a classified document from its stored raw content
+// and returns the stored and new output side by side. Nothing is written: the
+// classified index, classification history and source reputation are untouched.
+func (h *Handler) ReplayDocument(c *gin.Context) {
+	contentID := c.Param("content_id")
+	ctx := c.Request.Context()
+
+	var req ReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.ClassifierVersion != "" && req.ClassifierVersion != h.classifier.Version() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":              "requested classifier version is not running",
+			"requested_version":  req.ClassifierVersion,
+			"classifier_version": h.classifier.Version(),
+		})
+		return
+	}
+
+	existing, err := h.storage.GetClassifiedByID(ctx, contentID)
+	if err != nil {
+		h.logger.Warn("Classified document not found",
+			infralogger.String("content_id", contentID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+
+	raw, err := h.storage.GetRawContentByID(ctx, contentID, existing.SourceName)
+	if err != nil {
+		h.logger.Error("Failed to fetch raw content",
+			infralogger.String("content_id", contentID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch raw content"})
+		return
+	}
+
+	result, err := h.classifier.Replay(ctx, raw, req.Rules)
+	if err != nil {
+		h.logger.Error("Replay classification failed",
+			infralogger.String("content_id", contentID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Classification failed"})
+		return
+	}
+
+	stored := snapshotFromClassified(existing)
+	replayed := snapshotFromClassified(h.classifier.BuildClassifiedContent(raw, result))
+
+	c.JSON(http.StatusOK, ReplayResponse{
+		ContentID:     contentID,
+		SourceName:    existing.SourceName,
+		RulesOverride: req.Rules != nil,
+		Stored:        stored,
+		Replayed:      replayed,
+		Changes:       diffSnapshots(stored, replayed),
+	})
+}
+
+// snapshotFromClassified copies the classification output of a classified document.
+func snapshotFromClassified(cc *domain.ClassifiedContent) *ReplaySnapshot {
+	return &ReplaySnapshot{
+		ContentType:          cc.ContentType,
+		ContentSubtype:       cc.ContentSubtype,
+		QualityScore:         cc.QualityScore,
+		Topics:               cc.Topics,
+		TopicScores:          cc.TopicScores,
+		SourceReputation:     cc.SourceReputation,
+		SourceCategory:       cc.SourceCategory,
+		ClassifierVersion:    cc.ClassifierVersion,
+		ClassificationMethod: cc.ClassificationMethod,
+		ModelVersion:         cc.ModelVersion,
+		Confidence:           cc.Confidence,
+		Crime:                cc.Crime,
+		Mining:               cc.Mining,
+		Coforge:              cc.Coforge,
+		Entertainment:        cc.Entertainment,
+		Indigenous:           cc.Indigenous,
+		Location:             cc.Location,
+		Locality:             cc.Locality,
+		Recipe:               cc.Recipe,
+		Job:                  cc.Job,
+		RFP:                  cc.RFP,
+		NeedSignal:           cc.NeedSignal,
+		ICP:                  cc.ICP,
+	}
+}
+
+// diffSnapshots lists the fields that differ between two snapshots, using the
+// JSON field names. Nested results are compared field by field (e.g.
+// "crime.street_crime_relevance"); lists are compared as a whole.
+func diffSnapshots(stored, replayed *ReplaySnapshot) []ReplayChange {
+	storedFields := make(map[string]any)
+	replayedFields := make(map[string]any)
+	flattenJSON("", toJSONMap(stored), storedFields)
+	flattenJSON("", toJSONMap(replayed), replayedFields)
+
+	names := make([]string, 0, len(storedFields)+len(replayedFields))
+	for name := range storedFields {
+		names = append(names, name)
+	}
+	for name := range replayedFields {
+		if _, ok := storedFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make([]ReplayChange, 0)
+	for _, name := range names {
+		if !reflect.DeepEqual(storedFields[name], replayedFields[name]) {
+			changes = append(changes, ReplayChange{
+				Field:    name,
+				Stored:   storedFields[name],
+				Replayed: replayedFields[name],
+			})
+		}
+	}
+
+	return changes
+}
+
+// toJSONMap round-trips a value through JSON so it can be compared generically.
+func toJSONMap(v any) map[string]any {
+	out := make(map[string]any)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return out
+	}
+	_ = json.Unmarshal(data, &out)
+
+	return out
+}
+
+// flattenJSON writes every leaf of a decoded JSON object into out keyed by its dotted path.
+func flattenJSON(prefix string, obj map[string]any, out map[string]any) {
+	for key, value := range obj {
+		if _, ignored := replayIgnoredFields[key]; ignored {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flattenJSON(path, nested, out)
+			continue
+		}
+		out[path] = value
+	}
+}
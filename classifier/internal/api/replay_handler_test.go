@@ -0,0 +1,71 @@
+//nolint:testpackage // Testing internal API handlers requires same package access
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+)
+
+func TestReplayDocument_UnknownClassifierVersion(t *testing.T) {
+	handler := setupTestHandler()
+	router := setupRouter(handler)
+
+	body := bytes.NewBufferString(`{"classifier_version":"0.9.0"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/classify/replay/doc-1", body)
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	stored := &ReplaySnapshot{
+		ContentType:       domain.ContentTypeArticle,
+		QualityScore:      62,
+		Topics:            []string{"crime"},
+		TopicScores:       map[string]float64{"crime": 0.7},
+		ClassifierVersion: "1.0.0",
+		Crime:             &domain.CrimeResult{Relevance: "core_street_crime", ProcessingTimeMs: 12},
+	}
+	replayed := &ReplaySnapshot{
+		ContentType:       domain.ContentTypeArticle,
+		QualityScore:      62,
+		Topics:            []string{"crime", "local_news"},
+		TopicScores:       map[string]float64{"crime": 0.7, "local_news": 0.6},
+		ClassifierVersion: "1.0.0",
+		Crime:             &domain.CrimeResult{Relevance: "not_crime", ProcessingTimeMs: 40},
+	}
+
+	changes := diffSnapshots(stored, replayed)
+
+	want := []string{"crime.street_crime_relevance", "topic_scores.local_news", "topics"}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i, field := range want {
+		if changes[i].Field != field {
+			t.Errorf("change %d: expected %s, got %s", i, field, changes[i].Field)
+		}
+	}
+	if changes[0].Stored != "core_street_crime" || changes[0].Replayed != "not_crime" {
+		t.Errorf("unexpected crime change %+v", changes[0])
+	}
+	if changes[1].Stored != nil {
+		t.Errorf("expected new topic score to have no stored value, got %v", changes[1].Stored)
+	}
+}
+
+func TestDiffSnapshots_Identical(t *testing.T) {
+	snapshot := &ReplaySnapshot{ContentType: domain.ContentTypeArticle, Topics: []string{"crime"}}
+
+	if changes := diffSnapshots(snapshot, snapshot); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
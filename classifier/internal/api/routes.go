@@ -42,6 +42,7 @@ func SetupRoutesWithTelemetry(router *gin.Engine, handler *Handler, cfg *config.
 	classify.POST("", handler.Classify)                                  // POST /api/v1/classify
 	classify.POST("/batch", handler.ClassifyBatch)                       // POST /api/v1/classify/batch
 	classify.POST("/reclassify/:content_id", handler.ReclassifyDocument) // POST /api/v1/classify/reclassify/:content_id
+	classify.POST("/replay/:content_id", handler.ReplayDocument)         // POST /api/v1/classify/replay/:content_id
 	classify.GET("/:content_id", handler.GetClassificationResult)        // GET /api/v1/classify/:content_id
 
 	// Rules management endpoints
@@ -70,5 +71,6 @@ func SetupRoutesWithTelemetry(router *gin.Engine, handler *Handler, cfg *config.
 	if cfg != nil && cfg.Auth.InternalSecret != "" {
 		internal.Use(infragin.InternalAuthMiddleware(cfg.Auth.InternalSecret))
 	}
-	internal.POST("/extract", handler.InternalExtract) // POST /api/internal/v1/extract
+	internal.POST("/extract", handler.InternalExtract)                     // POST /api/internal/v1/extract
+	internal.GET("/sources/reputation", handler.InternalSourceReputations) // GET /api/internal/v1/sources/reputation
 }
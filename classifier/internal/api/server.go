@@ -14,13 +14,14 @@ import (
 // If the internal secret is not configured, the routes are NOT registered to prevent SSRF.
 func setupInternalRoutes(router *gin.Engine, handler *Handler, cfg *config.Config, infraLog infralogger.Logger) {
 	if cfg == nil || cfg.Auth.InternalSecret == "" {
-		infraLog.Warn("AUTH_INTERNAL_SECRET not configured: internal /extract endpoint will NOT be registered")
+		infraLog.Warn("AUTH_INTERNAL_SECRET not configured: internal endpoints will NOT be registered")
 		return
 	}
 
 	internal := router.Group("/api/internal/v1")
 	internal.Use(infragin.InternalAuthMiddleware(cfg.Auth.InternalSecret))
 	internal.POST("/extract", handler.InternalExtract)
+	internal.GET("/sources/reputation", handler.InternalSourceReputations)
 }
 
 // Default timeout values.
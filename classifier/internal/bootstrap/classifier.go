@@ -236,6 +236,10 @@ func createClassifierConfig(cfg *config.Config, logger infralogger.Logger) class
 		SectorAlignment:         sectorAlignment,
 		RoutingTable:            cfg.Classification.Routing,
 		MaxTopics:               cfg.Classification.Topic.MaxTopics,
+		Locality: classifier.LocalityConfig{
+			SourceRegions:     cfg.Classification.Locality.SourceRegions,
+			SalienceThreshold: cfg.Classification.Locality.SalienceThreshold,
+		},
 	}
 }
 
@@ -47,7 +47,14 @@ func SetupElasticsearch(cfg *config.Config, logger infralogger.Logger) *storage.
 		return nil
 	}
 
-	esStorage := storage.NewElasticsearchStorage(esClient)
+	outputFilter, err := storage.NewOutputFilter(cfg.Elasticsearch.OutputFields)
+	if err != nil {
+		logger.Error("Invalid elasticsearch.output_fields", infralogger.Error(err))
+		logger.Info("Re-classification endpoint will not be available")
+		return nil
+	}
+
+	esStorage := storage.NewElasticsearchStorage(esClient).WithOutputFilter(outputFilter)
 	if err = esStorage.TestConnection(context.Background()); err != nil {
 		logger.Warn("Failed to verify Elasticsearch connection", infralogger.Error(err))
 		logger.Info("Re-classification endpoint may not work correctly")
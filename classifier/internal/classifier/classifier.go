@@ -30,6 +30,7 @@ type Classifier struct {
 	entertainment       *EntertainmentClassifier
 	indigenous          *IndigenousClassifier
 	location            *LocationClassifier
+	locality            *LocalityScorer
 	recipeExtractor     *RecipeExtractor
 	jobExtractor        *JobExtractor
 	rfpExtractor        *RFPExtractor
@@ -59,6 +60,7 @@ type Config struct {
 	SectorAlignment         *SectorAlignmentExtractor // Optional: ICP segment matcher
 	RoutingTable            map[string][]string       // Optional: content-type routing (see ResolveSidecars)
 	MaxTopics               int                       // Maximum topics per item (default 5)
+	Locality                LocalityConfig            // Local news salience (source home regions, threshold)
 }
 
 // NewClassifier creates a new classifier with all strategies
@@ -109,6 +111,7 @@ func NewClassifier(
 		entertainment:       config.EntertainmentClassifier,
 		indigenous:          config.IndigenousClassifier,
 		location:            NewLocationClassifier(logger),
+		locality:            NewLocalityScorer(logger, config.Locality),
 		recipeExtractor:     config.RecipeExtractor,
 		jobExtractor:        config.JobExtractor,
 		rfpExtractor:        config.RFPExtractor,
@@ -151,6 +154,34 @@ func (c *Classifier) ResolveSidecars(contentType, subtype string) []string {
 
 // Classify performs full classification on raw content
 func (c *Classifier) Classify(ctx context.Context, raw *domain.RawContent) (*domain.ClassificationResult, error) {
+	return c.classify(ctx, raw, c.topic, false)
+}
+
+// Replay classifies raw content without writing anything: source reputation is
+// read but not created or updated. When rules is non-nil it replaces the loaded
+// topic rules for this call only. Optional ML sidecars answer with whatever
+// model they currently serve.
+func (c *Classifier) Replay(
+	ctx context.Context,
+	raw *domain.RawContent,
+	rules []domain.ClassificationRule,
+) (*domain.ClassificationResult, error) {
+	topic := c.topic
+	if rules != nil {
+		topic = NewTopicClassifier(c.logger, rules, c.topic.maxTopics)
+	}
+
+	return c.classify(ctx, raw, topic, true)
+}
+
+// classify runs every strategy using the given topic classifier. A dry run
+// leaves source reputation untouched.
+func (c *Classifier) classify(
+	ctx context.Context,
+	raw *domain.RawContent,
+	topic *TopicClassifier,
+	dryRun bool,
+) (*domain.ClassificationResult, error) {
 	startTime := time.Now()
 
 	c.logger.Debug("Starting classification",
@@ -172,13 +203,18 @@ func (c *Classifier) Classify(ctx context.Context, raw *domain.RawContent) (*dom
 	}
 
 	// 3. Topic Classification
-	topicResult, err := c.topic.Classify(ctx, raw)
+	topicResult, err := topic.Classify(ctx, raw)
 	if err != nil {
 		return nil, fmt.Errorf("topic classification failed: %w", err)
 	}
 
 	// 4. Source Reputation
-	sourceRepResult, err := c.sourceReputation.Score(ctx, raw.SourceName)
+	var sourceRepResult *SourceReputationResult
+	if dryRun {
+		sourceRepResult, err = c.sourceReputation.Peek(ctx, raw.SourceName)
+	} else {
+		sourceRepResult, err = c.sourceReputation.Score(ctx, raw.SourceName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("source reputation scoring failed: %w", err)
 	}
@@ -187,6 +223,9 @@ func (c *Classifier) Classify(ctx context.Context, raw *domain.RawContent) (*dom
 	crimeResult, miningResult, coforgeResult, entertainmentResult, indigenousResult, locationResult := c.classifyOptionalForPublishable(
 		ctx, raw, contentTypeResult.Type, contentTypeResult.Subtype)
 
+	// 5a. Locality salience — decides local_news for articles from where the story is, not keywords
+	localityResult := c.runLocality(raw, contentTypeResult.Type, topicResult)
+
 	// 5b. Structured extraction — recipes, jobs, and RFPs
 	recipeResult := c.runRecipeExtraction(ctx, raw, contentTypeResult.Type, topicResult.Topics)
 	jobResult := c.runJobExtraction(ctx, raw, contentTypeResult.Type, topicResult.Topics)
@@ -201,14 +240,16 @@ func (c *Classifier) Classify(ctx context.Context, raw *domain.RawContent) (*dom
 	}
 	icpResult := c.runSectorAlignment(ctx, raw, topicResult.Topics)
 
-	// Update source reputation if enabled
-	isSpam := qualityResult.TotalScore < spamThresholdScore // Spam threshold
-	if err = c.sourceReputation.UpdateAfterClassification(ctx, raw.SourceName, qualityResult.TotalScore, isSpam); err != nil {
-		c.logger.Warn("Failed to update source reputation",
-			infralogger.String("source_name", raw.SourceName),
-			infralogger.Error(err),
-		)
-		// Don't fail the whole classification if reputation update fails
+	// Update source reputation if enabled (never on replays, which would count the document twice)
+	if !dryRun {
+		isSpam := qualityResult.TotalScore < spamThresholdScore // Spam threshold
+		if err = c.sourceReputation.UpdateAfterClassification(ctx, raw.SourceName, qualityResult.TotalScore, isSpam); err != nil {
+			c.logger.Warn("Failed to update source reputation",
+				infralogger.String("source_name", raw.SourceName),
+				infralogger.Error(err),
+			)
+			// Don't fail the whole classification if reputation update fails
+		}
 	}
 
 	// Calculate overall confidence (average of all confidences)
@@ -241,6 +282,7 @@ func (c *Classifier) Classify(ctx context.Context, raw *domain.RawContent) (*dom
 		Entertainment:        entertainmentResult,
 		Indigenous:           indigenousResult,
 		Location:             locationResult,
+		Locality:             localityResult,
 		Recipe:               recipeResult,
 		Job:                  jobResult,
 		RFP:                  rfpResult,
@@ -306,6 +348,11 @@ func (c *Classifier) GetRules() []domain.ClassificationRule {
 	return c.topic.GetRules()
 }
 
+// Version returns the classifier version stamped on results
+func (c *Classifier) Version() string {
+	return c.version
+}
+
 // classifyOptionalForPublishable runs optional classifiers according to the declarative routing table.
 // ResolveSidecars(contentType, contentSubtype) determines which sidecars to run; runOptionalClassifiers runs only those.
 //
@@ -468,6 +515,29 @@ func (c *Classifier) runIndigenousOptional(
 	return aResult
 }
 
+// runLocality scores locality salience for articles and applies it to the
+// local_news topic. Other content types keep the keyword decision.
+func (c *Classifier) runLocality(
+	raw *domain.RawContent, contentType string, topics *TopicResult,
+) *domain.LocalityResult {
+	if c.locality == nil || contentType != domain.ContentTypeArticle {
+		return nil
+	}
+	result := c.locality.Score(raw)
+	c.locality.ApplyToTopics(result, topics)
+	if result != nil {
+		c.logger.Debug("Locality scored",
+			infralogger.String("content_id", raw.ID),
+			infralogger.String("source", raw.SourceName),
+			infralogger.String("region", result.Region),
+			infralogger.String("home_region", result.HomeRegion),
+			infralogger.Float64("salience", result.Salience),
+			infralogger.Bool("is_local", result.IsLocal),
+		)
+	}
+	return result
+}
+
 func (c *Classifier) runLocationOptional(
 	ctx context.Context, raw *domain.RawContent, run bool,
 ) *domain.LocationResult {
@@ -643,6 +713,7 @@ func (c *Classifier) BuildClassifiedContent(raw *domain.RawContent, result *doma
 		Entertainment:        result.Entertainment,
 		Indigenous:           result.Indigenous,
 		Location:             result.Location,
+		Locality:             result.Locality,
 		Recipe:               result.Recipe,
 		Job:                  result.Job,
 		RFP:                  result.RFP,
@@ -0,0 +1,211 @@
+// classifier/internal/classifier/locality.go
+package classifier
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/data"
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// localNewsTopic is the topic governed by locality salience.
+const localNewsTopic = "local_news"
+
+// Locality scoring constants.
+const (
+	// DefaultLocalitySalienceThreshold is the minimum salience for local news.
+	DefaultLocalitySalienceThreshold = 0.5
+	// homeProvinceCredit is the share of a home-province mention counted as local.
+	// Province-only stories ("Ontario announces…") should not clear the threshold.
+	homeProvinceCredit = 0.25
+	salienceDecimals   = 100
+)
+
+// LocalityConfig configures locality salience scoring.
+type LocalityConfig struct {
+	SourceRegions     map[string]string // source name -> home city
+	SalienceThreshold float64
+}
+
+// LocalityScorer decides whether content is local news for its source by
+// weighing detected locations against the source's home region. Mentions of
+// large metros count for less, so a Toronto wire story republished on a
+// Sudbury site is not mistaken for Sudbury news.
+type LocalityScorer struct {
+	location      *LocationClassifier
+	sourceRegions map[string]homeRegion
+	threshold     float64
+	log           infralogger.Logger
+}
+
+// homeRegion is a source's home city and its province code.
+type homeRegion struct {
+	city     string
+	province string
+}
+
+// NewLocalityScorer creates a locality scorer. Configured source regions are
+// normalized to canonical city slugs; unknown cities are dropped with a warning.
+func NewLocalityScorer(log infralogger.Logger, cfg LocalityConfig) *LocalityScorer {
+	threshold := cfg.SalienceThreshold
+	if threshold <= 0 {
+		threshold = DefaultLocalitySalienceThreshold
+	}
+
+	regions := make(map[string]homeRegion, len(cfg.SourceRegions))
+	for source, city := range cfg.SourceRegions {
+		region, ok := lookupHomeRegion(city)
+		if !ok {
+			log.Warn("Ignoring locality source region with unknown city",
+				infralogger.String("source_name", source),
+				infralogger.String("city", city),
+			)
+			continue
+		}
+		regions[source] = region
+	}
+
+	return &LocalityScorer{
+		location:      NewLocationClassifier(log),
+		sourceRegions: regions,
+		threshold:     threshold,
+		log:           log,
+	}
+}
+
+// HomeRegion returns the canonical home city for a source, from configuration
+// or inferred from city names embedded in the source name (e.g. "sudbury_com").
+func (ls *LocalityScorer) HomeRegion(sourceName string) string {
+	return ls.homeRegion(sourceName).city
+}
+
+func (ls *LocalityScorer) homeRegion(sourceName string) homeRegion {
+	if region, ok := ls.sourceRegions[sourceName]; ok {
+		return region
+	}
+
+	tokens := strings.FieldsFunc(strings.ToLower(sourceName), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	})
+	// Prefer two-word cities ("thunder bay") over their first word.
+	for i := range len(tokens) - 1 {
+		if region, ok := lookupHomeRegion(tokens[i] + " " + tokens[i+1]); ok {
+			return region
+		}
+	}
+	for _, token := range tokens {
+		if region, ok := lookupHomeRegion(token); ok {
+			return region
+		}
+	}
+
+	return homeRegion{}
+}
+
+// lookupHomeRegion resolves a city name to its canonical slug and province.
+func lookupHomeRegion(city string) (homeRegion, bool) {
+	province, ok := data.GetProvinceForCity(city)
+	if !ok {
+		return homeRegion{}, false
+	}
+	return homeRegion{city: data.NormalizeCityName(city), province: province}, true
+}
+
+// Score computes the locality of raw content relative to its source's home region.
+// Returns nil when the content mentions no Canadian city or province.
+func (ls *LocalityScorer) Score(raw *domain.RawContent) *domain.LocalityResult {
+	scores := ls.location.zoneScores(raw.Title, ls.location.extractLede(raw.RawText), raw.RawText)
+
+	home := ls.homeRegion(raw.SourceName)
+	homeCity, homeProvince := home.city, home.province
+
+	var total, homeScore float64
+	var topCity, topProvince *locationScore
+	var topCityScore float64
+	for _, key := range sortedScoreKeys(scores) {
+		s := scores[key]
+		switch s.entity.EntityType {
+		case EntityTypeCity:
+			weighted := s.score * data.PopulationWeight(s.entity.Normalized)
+			total += weighted
+			if homeCity != "" && s.entity.Normalized == homeCity {
+				homeScore += weighted
+			}
+			if topCity == nil || weighted > topCityScore {
+				topCity, topCityScore = s, weighted
+			}
+		case EntityTypeProvince:
+			total += s.score
+			if homeProvince != "" && s.entity.Normalized == homeProvince {
+				homeScore += s.score * homeProvinceCredit
+			}
+			if topProvince == nil || s.score > topProvince.score {
+				topProvince = s
+			}
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	result := &domain.LocalityResult{HomeRegion: homeCity}
+	switch {
+	case topCity != nil:
+		result.Region = topCity.entity.Normalized
+	case topProvince != nil:
+		result.Region = topProvince.entity.Normalized
+	}
+	if homeCity != "" {
+		result.Salience = math.Round(homeScore/total*salienceDecimals) / salienceDecimals
+		result.IsLocal = result.Salience >= ls.threshold
+	}
+
+	return result
+}
+
+// ApplyToTopics replaces the keyword local_news decision with the salience
+// decision when both a home region and content locations are known. Without
+// either, the keyword rule stands.
+func (ls *LocalityScorer) ApplyToTopics(locality *domain.LocalityResult, topics *TopicResult) {
+	if locality == nil || locality.HomeRegion == "" || topics == nil {
+		return
+	}
+
+	filtered := topics.Topics[:0]
+	for _, topic := range topics.Topics {
+		if topic != localNewsTopic {
+			filtered = append(filtered, topic)
+		}
+	}
+	topics.Topics = filtered
+	delete(topics.TopicScores, localNewsTopic)
+
+	if locality.IsLocal {
+		topics.Topics = append(topics.Topics, localNewsTopic)
+		if topics.TopicScores == nil {
+			topics.TopicScores = make(map[string]float64)
+		}
+		topics.TopicScores[localNewsTopic] = locality.Salience
+	}
+
+	topics.HighestTopic = ""
+	for _, topic := range topics.Topics {
+		if topics.HighestTopic == "" || topics.TopicScores[topic] > topics.TopicScores[topics.HighestTopic] {
+			topics.HighestTopic = topic
+		}
+	}
+}
+
+// sortedScoreKeys returns score keys in a stable order so ties resolve deterministically.
+func sortedScoreKeys(scores map[string]*locationScore) []string {
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
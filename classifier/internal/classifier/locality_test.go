@@ -0,0 +1,159 @@
+// classifier/internal/classifier/locality_test.go
+package classifier_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/classifier"
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+)
+
+func TestLocalityScorer_HomeRegion(t *testing.T) {
+	t.Helper()
+
+	ls := classifier.NewLocalityScorer(&mockLogger{}, classifier.LocalityConfig{
+		SourceRegions: map[string]string{"northern_news": "Timmins"},
+	})
+
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{source: "northern_news", want: "timmins"},
+		{source: "sudbury_com", want: "sudbury"},
+		{source: "www.thunder-bay-news.ca", want: "thunder-bay"},
+		{source: "national_wire", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			if got := ls.HomeRegion(tt.source); got != tt.want {
+				t.Errorf("HomeRegion(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalityScorer_Score(t *testing.T) {
+	t.Helper()
+
+	ls := classifier.NewLocalityScorer(&mockLogger{}, classifier.LocalityConfig{})
+
+	tests := []struct {
+		name        string
+		raw         *domain.RawContent
+		wantRegion  string
+		wantIsLocal bool
+	}{
+		{
+			name: "home city story is local",
+			raw: &domain.RawContent{
+				SourceName: "sudbury_com",
+				Title:      "Sudbury council approves new transit plan",
+				RawText:    "Sudbury city council voted Tuesday to expand bus service.\n\nThe plan covers Sudbury and outlying areas.",
+			},
+			wantRegion:  "sudbury",
+			wantIsLocal: true,
+		},
+		{
+			name: "Toronto wire story on a Sudbury site is not local",
+			raw: &domain.RawContent{
+				SourceName: "sudbury_com",
+				Title:      "Toronto police investigate downtown shooting",
+				RawText:    "Police in Toronto say one man was injured.\n\nToronto officers remain at the scene.",
+			},
+			wantRegion:  "toronto",
+			wantIsLocal: false,
+		},
+		{
+			name: "passing metro mention does not outweigh home city",
+			raw: &domain.RawContent{
+				SourceName: "sudbury_com",
+				Title:      "Sudbury hospital opens new wing",
+				RawText:    "The Sudbury hospital expansion was modelled on a Toronto facility.",
+			},
+			wantRegion:  "sudbury",
+			wantIsLocal: true,
+		},
+		{
+			name: "province-only story is not local",
+			raw: &domain.RawContent{
+				SourceName: "sudbury_com",
+				Title:      "Ontario announces new school funding",
+				RawText:    "The Ontario government announced funding on Monday.",
+			},
+			wantRegion:  "ON",
+			wantIsLocal: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ls.Score(tt.raw)
+			if result == nil {
+				t.Fatal("Score() returned nil")
+			}
+			if result.Region != tt.wantRegion {
+				t.Errorf("Region = %q, want %q", result.Region, tt.wantRegion)
+			}
+			if result.IsLocal != tt.wantIsLocal {
+				t.Errorf("IsLocal = %v (salience %.2f), want %v", result.IsLocal, result.Salience, tt.wantIsLocal)
+			}
+		})
+	}
+}
+
+func TestLocalityScorer_ScoreNoLocations(t *testing.T) {
+	t.Helper()
+
+	ls := classifier.NewLocalityScorer(&mockLogger{}, classifier.LocalityConfig{})
+	raw := &domain.RawContent{SourceName: "sudbury_com", Title: "Ten tips for better sleep", RawText: "Sleep matters."}
+
+	if result := ls.Score(raw); result != nil {
+		t.Errorf("Score() = %+v, want nil", result)
+	}
+}
+
+func TestLocalityScorer_ApplyToTopics(t *testing.T) {
+	t.Helper()
+
+	ls := classifier.NewLocalityScorer(&mockLogger{}, classifier.LocalityConfig{})
+
+	t.Run("removes keyword local_news when not local", func(t *testing.T) {
+		topics := &classifier.TopicResult{
+			Topics:       []string{"local_news", "crime"},
+			TopicScores:  map[string]float64{"local_news": 0.9, "crime": 0.6},
+			HighestTopic: "local_news",
+		}
+		ls.ApplyToTopics(&domain.LocalityResult{HomeRegion: "sudbury", Salience: 0.1}, topics)
+
+		if slices.Contains(topics.Topics, "local_news") {
+			t.Errorf("Topics = %v, want local_news removed", topics.Topics)
+		}
+		if topics.HighestTopic != "crime" {
+			t.Errorf("HighestTopic = %q, want crime", topics.HighestTopic)
+		}
+	})
+
+	t.Run("adds local_news when local", func(t *testing.T) {
+		topics := &classifier.TopicResult{Topics: []string{}, TopicScores: map[string]float64{}}
+		ls.ApplyToTopics(&domain.LocalityResult{HomeRegion: "sudbury", Salience: 0.8, IsLocal: true}, topics)
+
+		if !slices.Contains(topics.Topics, "local_news") {
+			t.Errorf("Topics = %v, want local_news", topics.Topics)
+		}
+		if topics.TopicScores["local_news"] != 0.8 {
+			t.Errorf("local_news score = %v, want 0.8", topics.TopicScores["local_news"])
+		}
+	})
+
+	t.Run("keeps keyword decision without home region", func(t *testing.T) {
+		topics := &classifier.TopicResult{Topics: []string{"local_news"}, TopicScores: map[string]float64{"local_news": 0.7}}
+		ls.ApplyToTopics(&domain.LocalityResult{Region: "toronto"}, topics)
+
+		if !slices.Contains(topics.Topics, "local_news") {
+			t.Errorf("Topics = %v, want local_news kept", topics.Topics)
+		}
+	})
+}
@@ -186,6 +186,11 @@ type locationScore struct {
 
 // scoreLocations determines the dominant location from headline, lede, and body.
 func (lc *LocationClassifier) scoreLocations(headline, lede, body string) *domain.LocationResult {
+	return lc.determineDominant(lc.zoneScores(headline, lede, body))
+}
+
+// zoneScores accumulates weighted entity scores across headline, lede, and body.
+func (lc *LocationClassifier) zoneScores(headline, lede, body string) map[string]*locationScore {
 	scores := make(map[string]*locationScore)
 
 	// Score headline entities (3.0x weight)
@@ -197,8 +202,7 @@ func (lc *LocationClassifier) scoreLocations(headline, lede, body string) *domai
 	// Score body entities (1.0x weight)
 	lc.scoreZone(body, BodyWeight, scores)
 
-	// Find dominant location
-	return lc.determineDominant(scores)
+	return scores
 }
 
 // scoreZone extracts entities from a text zone and adds weighted scores.
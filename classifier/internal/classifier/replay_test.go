@@ -0,0 +1,99 @@
+//nolint:testpackage // Testing internal classifier requires same package access
+package classifier
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+	"github.com/jonesrussell/north-cloud/classifier/internal/testhelpers"
+)
+
+func newReplayTestClassifier(db SourceReputationDB) *Classifier {
+	rules := []domain.ClassificationRule{{
+		ID:            1,
+		RuleType:      domain.RuleTypeTopic,
+		TopicName:     "crime",
+		Keywords:      []string{"police", "arrest", "charged"},
+		MinConfidence: 0.3,
+		Enabled:       true,
+	}}
+
+	return NewClassifier(&mockLogger{}, rules, db, Config{
+		Version: "1.0.0",
+		SourceReputationConfig: SourceReputationConfig{
+			DefaultScore:               50,
+			UpdateOnEachClassification: true,
+			SpamThreshold:              30,
+			MinArticlesForTrust:        10,
+			ReputationDecayRate:        0.1,
+		},
+	})
+}
+
+func replayTestContent() *domain.RawContent {
+	return &domain.RawContent{
+		ID:         "doc-1",
+		SourceName: "example.com",
+		Title:      "Police arrest suspect downtown",
+		RawText:    "Police said the suspect was charged after the arrest. Police continue to investigate.",
+		WordCount:  14,
+	}
+}
+
+func TestReplay_DoesNotWriteSourceReputation(t *testing.T) {
+	db := testhelpers.NewMockSourceReputationDB()
+	clf := newReplayTestClassifier(db)
+
+	result, err := clf.Replay(context.Background(), replayTestContent(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.SourceReputation != 50 {
+		t.Errorf("expected default reputation for an untracked source, got %d", result.SourceReputation)
+	}
+	if _, getErr := db.GetSource(context.Background(), "example.com"); getErr == nil {
+		t.Error("replay must not create a source reputation record")
+	}
+
+	if _, err = clf.Classify(context.Background(), replayTestContent()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source, err := db.GetSource(context.Background(), "example.com")
+	if err != nil || source.TotalArticles != 1 {
+		t.Fatalf("expected Classify to record one article, got %+v (err=%v)", source, err)
+	}
+
+	if _, err = clf.Replay(context.Background(), replayTestContent(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source, _ = db.GetSource(context.Background(), "example.com")
+	if source.TotalArticles != 1 {
+		t.Errorf("replay must not update the source record, total_articles = %d", source.TotalArticles)
+	}
+}
+
+func TestReplay_RulesOverride(t *testing.T) {
+	clf := newReplayTestClassifier(testhelpers.NewMockSourceReputationDB())
+
+	current, err := clf.Replay(context.Background(), replayTestContent(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(current.Topics, "crime") {
+		t.Fatalf("expected loaded rules to match crime, got %v", current.Topics)
+	}
+
+	replayed, err := clf.Replay(context.Background(), replayTestContent(), []domain.ClassificationRule{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if slices.Contains(replayed.Topics, "crime") {
+		t.Errorf("expected an empty rule set to match no topics, got %v", replayed.Topics)
+	}
+	if len(clf.GetRules()) != 1 {
+		t.Error("a rules override must not replace the loaded rules")
+	}
+}
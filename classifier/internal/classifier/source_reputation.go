@@ -83,6 +83,26 @@ func (s *SourceReputationScorer) Score(ctx context.Context, sourceName string) (
 		return nil, fmt.Errorf("failed to get source: %w", err)
 	}
 
+	return s.scoreRecord(sourceName, sourceRecord), nil
+}
+
+// Peek scores a source without creating its record. A source that cannot be
+// read scores as a new source.
+func (s *SourceReputationScorer) Peek(ctx context.Context, sourceName string) (*SourceReputationResult, error) {
+	sourceRecord, err := s.db.GetSource(ctx, sourceName)
+	if err != nil || sourceRecord == nil {
+		sourceRecord = &domain.SourceReputation{
+			SourceName:      sourceName,
+			Category:        domain.SourceCategoryUnknown,
+			ReputationScore: s.config.DefaultScore,
+		}
+	}
+
+	return s.scoreRecord(sourceName, sourceRecord), nil
+}
+
+// scoreRecord derives the reputation result from a source record.
+func (s *SourceReputationScorer) scoreRecord(sourceName string, sourceRecord *domain.SourceReputation) *SourceReputationResult {
 	// Calculate current reputation score
 	score := s.calculateReputationScore(sourceRecord)
 
@@ -104,7 +124,7 @@ func (s *SourceReputationScorer) Score(ctx context.Context, sourceName string) (
 		Score:    score,
 		Category: category,
 		Rank:     rank,
-	}, nil
+	}
 }
 
 // UpdateAfterClassification updates source reputation after classifying an article
@@ -37,6 +37,7 @@ const (
 	defaultQualityWeight             = 0.25
 	defaultReputationScore           = 50
 	defaultMaxTopics                 = 5
+	defaultLocalitySalienceThreshold = 0.5
 	defaultCrimeMLServiceURL         = "http://crime-ml:8076"
 	defaultCoforgeMLServiceURL       = "http://coforge-ml:8078"
 	defaultEntertainmentMLServiceURL = "http://entertainment-ml:8079"
@@ -96,6 +97,21 @@ type ElasticsearchConfig struct {
 	Timeout                 time.Duration `yaml:"timeout"`
 	RawContentSuffix        string        `yaml:"raw_content_suffix"`
 	ClassifiedContentSuffix string        `yaml:"classified_content_suffix"`
+	// OutputFields controls which fields each classified index stores; the first
+	// rule whose index pattern matches wins, and unmatched indexes keep every field.
+	OutputFields []OutputFieldsRule `yaml:"output_fields"`
+}
+
+// OutputFieldsRule whitelists the top-level fields written to matching classified
+// indexes. Fields outside the whitelist are dropped, or written to the index's
+// sidecar (<source>_classified_details) when Sidecar is true.
+type OutputFieldsRule struct {
+	// Index is a glob matched against the classified index name (e.g. "*_classified_content")
+	Index string `yaml:"index"`
+	// Fields is the whitelist; core identity/status fields are always kept
+	Fields []string `yaml:"fields"`
+	// Sidecar routes non-whitelisted fields to the sidecar index instead of dropping them
+	Sidecar bool `yaml:"sidecar"`
 }
 
 // RedisConfig holds Redis configuration.
@@ -106,7 +122,7 @@ type RedisConfig struct {
 	MaxRetries             int           `yaml:"max_retries"`
 	Timeout                time.Duration `yaml:"timeout"`
 	ChannelNewContent      string        `yaml:"channel_new_content"`
-	ChannelClassified      string        `yaml:"channel_classified"`
+	ChannelClassified      string        `env:"REDIS_CHANNEL_CLASSIFIED"  yaml:"channel_classified"` // batch announcements; empty disables
 	ClassificationCacheTTL time.Duration `yaml:"classification_cache_ttl"`
 }
 
@@ -148,6 +164,7 @@ type ClassificationConfig struct {
 	SectorAlignment  SectorAlignmentConfig      `yaml:"sector_alignment"`
 	DrillExtraction  DrillExtractionConfig      `yaml:"drill_extraction"`
 	QualityGate      QualityGateConfig          `yaml:"quality_gate"`
+	Locality         LocalityConfig             `yaml:"locality"`
 	// SidecarRegistry maps sidecar name (e.g. "crime", "mining") to enabled + URL.
 	// Built from Crime/Mining/... named configs when absent in YAML.
 	// NOTE: Currently populated by setClassificationDefaults but not yet consumed by the bootstrap
@@ -233,6 +250,14 @@ type QualityGateConfig struct {
 	Threshold int  `env:"CLASSIFIER_QUALITY_GATE_THRESHOLD" yaml:"threshold"`
 }
 
+// LocalityConfig holds local-news salience settings.
+type LocalityConfig struct {
+	// SourceRegions maps source name to home city (e.g. "mysudbury_ca": "sudbury").
+	// Sources not listed fall back to inferring a city from the source name.
+	SourceRegions     map[string]string `yaml:"source_regions"`
+	SalienceThreshold float64           `env:"LOCALITY_SALIENCE_THRESHOLD" yaml:"salience_threshold"`
+}
+
 // ContentTypeConfig holds content type detection settings.
 type ContentTypeConfig struct {
 	Enabled             bool    `yaml:"enabled"`
@@ -424,6 +449,9 @@ func setClassificationDefaults(c *ClassificationConfig) {
 		c.DrillExtraction.MaxBodyChars = 4000
 	}
 	// QualityGate defaults: disabled by default for safe rollout
+	if c.Locality.SalienceThreshold == 0 {
+		c.Locality.SalienceThreshold = defaultLocalitySalienceThreshold
+	}
 	if c.QualityGate.Threshold == 0 {
 		c.QualityGate.Threshold = defaultQualityGateThreshold
 	}
@@ -0,0 +1,40 @@
+// classifier/internal/data/city_population.go
+package data
+
+// Population tier weights. A mention of a large metro is weak evidence that a
+// story is about that place (datelines, "Toronto-based", provincial capitals),
+// so bigger cities count for less when measuring locality salience.
+const (
+	MetroPopulationWeight = 0.5  // CMA of 1M+ (and its core suburbs)
+	LargePopulationWeight = 0.75 // CMA of 250k–1M
+	SmallPopulationWeight = 1.0  // Everything else
+)
+
+// metroCities lists canonical slugs in a Census Metropolitan Area of 1M+ (2021 Census).
+var metroCities = map[string]bool{
+	"toronto": true, "mississauga": true, "brampton": true, "markham": true, "vaughan": true,
+	"montreal": true, "laval": true, "longueuil": true,
+	"vancouver": true, "surrey": true, "burnaby": true, "richmond": true,
+	"calgary": true, "edmonton": true, "ottawa": true,
+}
+
+// largeCities lists canonical slugs in a Census Metropolitan Area of 250k–1M (2021 Census).
+var largeCities = map[string]bool{
+	"winnipeg": true, "quebec-city": true, "hamilton": true, "kitchener": true, "waterloo": true,
+	"cambridge": true, "london": true, "halifax": true, "dartmouth": true, "st-catharines": true,
+	"niagara-falls": true, "windsor": true, "oshawa": true, "victoria": true, "saskatoon": true,
+	"regina": true, "sherbrooke": true, "st-johns": true, "barrie": true, "kelowna": true,
+	"abbotsford": true, "gatineau": true,
+}
+
+// PopulationWeight returns the salience weight for a canonical city slug.
+func PopulationWeight(canonical string) float64 {
+	switch {
+	case metroCities[canonical]:
+		return MetroPopulationWeight
+	case largeCities[canonical]:
+		return LargePopulationWeight
+	default:
+		return SmallPopulationWeight
+	}
+}
@@ -50,6 +50,9 @@ type ClassificationResult struct {
 	// Location detection (content-based)
 	Location *LocationResult `json:"location,omitempty"`
 
+	// Locality salience relative to the source's home region
+	Locality *LocalityResult `json:"locality,omitempty"`
+
 	// Recipe structured extraction (optional)
 	Recipe *RecipeResult `json:"recipe,omitempty"`
 
@@ -208,6 +211,9 @@ type ClassifiedContent struct {
 	// Location detection (content-based)
 	Location *LocationResult `json:"location,omitempty"`
 
+	// Locality salience relative to the source's home region
+	Locality *LocalityResult `json:"locality,omitempty"`
+
 	// Recipe structured extraction (optional)
 	Recipe *RecipeResult `json:"recipe,omitempty"`
 
@@ -289,6 +295,15 @@ type LocationResult struct {
 	Confidence  float64 `json:"confidence"`
 }
 
+// LocalityResult measures how much a story is about its source's home region.
+// Salience is the home region's share of population-weighted location mentions.
+type LocalityResult struct {
+	Region     string  `json:"region,omitempty"`      // Dominant city (or province) in the content
+	HomeRegion string  `json:"home_region,omitempty"` // Source's home city, when known
+	Salience   float64 `json:"salience"`              // 0.0-1.0
+	IsLocal    bool    `json:"is_local"`
+}
+
 // GetSpecificity returns the specificity level based on populated fields.
 func (l *LocationResult) GetSpecificity() string {
 	if l.City != "" {
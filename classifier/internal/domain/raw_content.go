@@ -30,6 +30,7 @@ type RawContent struct {
 	MetaDescription string `json:"meta_description,omitempty"`
 	MetaKeywords    string `json:"meta_keywords,omitempty"`
 	CanonicalURL    string `json:"canonical_url,omitempty"`
+	Language        string `json:"language,omitempty"` // primary subtag ("en", "fr") detected by the crawler
 
 	// Timestamps
 	CrawledAt     time.Time  `json:"crawled_at"`
@@ -71,3 +71,129 @@ func TestAddICPMigrationFile(t *testing.T) {
 		t.Errorf("migration icp.model_version.type = %v, want keyword", got)
 	}
 }
+
+func TestAddLocalityMigrationFile(t *testing.T) {
+	data, err := os.ReadFile("v016_add_locality.json")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var doc map[string]any
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		t.Fatalf("invalid JSON: %v", unmarshalErr)
+	}
+
+	props := doc["properties"].(map[string]any)
+	locality := props["locality"].(map[string]any)
+	localityProps := locality["properties"].(map[string]any)
+
+	// The migration must match the SSoT mapping used for new indexes.
+	m := NewClassifiedContentMapping()
+	s, err := m.GetJSON()
+	if err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	for field, wantType := range map[string]string{
+		"region":      "keyword",
+		"home_region": "keyword",
+		"salience":    "float",
+		"is_local":    "boolean",
+	} {
+		got := localityProps[field].(map[string]any)["type"]
+		if got != wantType {
+			t.Errorf("migration locality.%s.type = %v, want %s", field, got, wantType)
+		}
+		if !strings.Contains(s, `"`+field+`"`) {
+			t.Errorf("mapping missing locality field %s", field)
+		}
+	}
+}
+
+func TestAddLanguageMigrationFile(t *testing.T) {
+	data, err := os.ReadFile("v017_add_language.json")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var doc map[string]any
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		t.Fatalf("invalid JSON: %v", unmarshalErr)
+	}
+
+	props := doc["properties"].(map[string]any)
+	if got := props["language"].(map[string]any)["type"]; got != "keyword" {
+		t.Errorf("migration language.type = %v, want keyword", got)
+	}
+
+	// The French subfields come from the SSoT mapping, not the migration:
+	// they need the french_content analyzer, which only a new index has.
+	m := NewClassifiedContentMapping()
+	s, err := m.GetJSON()
+	if err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	for _, want := range []string{`"language"`, `"french_content"`} {
+		if !strings.Contains(s, want) {
+			t.Errorf("mapping missing %s", want)
+		}
+	}
+}
+
+func TestSearchSynonymsMigrationFiles(t *testing.T) {
+	m := NewClassifiedContentMapping()
+	s, err := m.GetJSON()
+	if err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	var index map[string]any
+	if unmarshalErr := json.Unmarshal([]byte(s), &index); unmarshalErr != nil {
+		t.Fatalf("invalid mapping JSON: %v", unmarshalErr)
+	}
+	analysis := index["settings"].(map[string]any)["analysis"].(map[string]any)
+	properties := index["mappings"].(map[string]any)["properties"].(map[string]any)
+
+	// The settings migration must add exactly the SSoT analyzers and filter
+	settings := readMigrationFile(t, "v018_search_synonyms_settings.json")["analysis"].(map[string]any)
+	for _, kind := range []string{"analyzer", "filter"} {
+		for name, def := range settings[kind].(map[string]any) {
+			want, _ := json.Marshal(analysis[kind].(map[string]any)[name])
+			got, _ := json.Marshal(def)
+			if string(got) != string(want) {
+				t.Errorf("settings migration %s %s = %s, want %s", kind, name, got, want)
+			}
+		}
+	}
+
+	// and the mapping migration the SSoT search analyzers
+	migration := readMigrationFile(t, "v018_search_synonyms_mapping.json")["properties"].(map[string]any)
+	for _, field := range []string{"title", "raw_text", "body"} {
+		got := migration[field].(map[string]any)
+		want := properties[field].(map[string]any)
+		for _, key := range []string{"type", "analyzer", "search_analyzer"} {
+			if got[key] != want[key] {
+				t.Errorf("mapping migration %s.%s = %v, want %v", field, key, got[key], want[key])
+			}
+		}
+		gotFr := got["fields"].(map[string]any)["fr"]
+		wantFr := want["fields"].(map[string]any)["fr"]
+		gotJSON, _ := json.Marshal(gotFr)
+		wantJSON, _ := json.Marshal(wantFr)
+		if string(gotJSON) != string(wantJSON) {
+			t.Errorf("mapping migration %s.fr = %s, want %s", field, gotJSON, wantJSON)
+		}
+	}
+}
+
+func readMigrationFile(t *testing.T, name string) map[string]any {
+	t.Helper()
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var doc map[string]any
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		t.Fatalf("%s: invalid JSON: %v", name, unmarshalErr)
+	}
+	return doc
+}
@@ -49,6 +49,12 @@ type DatabaseClient interface {
 	SaveClassificationHistoryBatch(ctx context.Context, histories []*domain.ClassificationHistory) error
 }
 
+// ClassifiedNotifier announces newly indexed classified content to other
+// services, e.g. so the search cache can drop stale responses
+type ClassifiedNotifier interface {
+	NotifyClassified(ctx context.Context, contents []*domain.ClassifiedContent) error
+}
+
 // Poller polls Elasticsearch for pending content and processes it
 type Poller struct {
 	esClient       ElasticsearchClient
@@ -56,6 +62,7 @@ type Poller struct {
 	batchProcessor *BatchProcessor
 	logger         infralogger.Logger
 	pipeline       *pipeline.Client
+	notifier       ClassifiedNotifier // nil if classified batches are not announced
 
 	qualityGateCfg config.QualityGateConfig
 
@@ -101,6 +108,12 @@ func NewPoller(
 	}
 }
 
+// WithClassifiedNotifier announces every indexed batch through notifier
+func (p *Poller) WithClassifiedNotifier(notifier ClassifiedNotifier) *Poller {
+	p.notifier = notifier
+	return p
+}
+
 // Start starts the poller
 func (p *Poller) Start(ctx context.Context) error {
 	if p.running {
@@ -258,6 +271,7 @@ func (p *Poller) indexResults(ctx context.Context, results []*ProcessResult) err
 	}
 
 	p.emitClassifiedEvents(ctx, classifiedContents)
+	p.notifyClassified(ctx, classifiedContents)
 
 	p.logger.Info("Successfully indexed classified content", infralogger.Int("count", len(classifiedContents)))
 
@@ -294,6 +308,21 @@ func (p *Poller) emitClassifiedEvents(ctx context.Context, contents []*domain.Cl
 	}
 }
 
+// notifyClassified announces an indexed batch. Subscribers treat the
+// announcement as a hint, so a failure is logged and not retried.
+func (p *Poller) notifyClassified(ctx context.Context, contents []*domain.ClassifiedContent) {
+	if p.notifier == nil || len(contents) == 0 {
+		return
+	}
+
+	if err := p.notifier.NotifyClassified(ctx, contents); err != nil {
+		p.logger.Warn("Failed to announce classified batch",
+			infralogger.Error(err),
+			infralogger.Int("count", len(contents)),
+		)
+	}
+}
+
 // validateURL validates and optionally truncates URLs to a reasonable length
 // This is defensive programming - the database column is now TEXT, but we want
 // to log warnings for extremely long URLs and prevent potential issues
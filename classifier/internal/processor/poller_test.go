@@ -2,9 +2,12 @@
 package processor
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -301,3 +304,41 @@ func TestPoller_validateURL_EmptyURL(t *testing.T) {
 		t.Errorf("expected no warning for empty URL, got %d warnings", len(logger.warnCalls))
 	}
 }
+
+// fakeNotifier records announced batches
+type fakeNotifier struct {
+	batches [][]*domain.ClassifiedContent
+	err     error
+}
+
+func (f *fakeNotifier) NotifyClassified(_ context.Context, contents []*domain.ClassifiedContent) error {
+	f.batches = append(f.batches, contents)
+	return f.err
+}
+
+func TestPoller_notifyClassified(t *testing.T) {
+	logger := newMockLoggerWithCalls()
+	notifier := &fakeNotifier{}
+	poller := (&Poller{logger: logger}).WithClassifiedNotifier(notifier)
+
+	contents := []*domain.ClassifiedContent{{RawContent: domain.RawContent{SourceName: "sudbury_star"}}}
+	poller.notifyClassified(context.Background(), contents)
+	poller.notifyClassified(context.Background(), nil)
+
+	if len(notifier.batches) != 1 {
+		t.Fatalf("expected one announced batch, got %d", len(notifier.batches))
+	}
+
+	notifier.err = errors.New("redis unavailable")
+	poller.notifyClassified(context.Background(), contents)
+	if len(logger.warnCalls) != 1 {
+		t.Errorf("expected a failed announcement to be logged, got %d warnings", len(logger.warnCalls))
+	}
+}
+
+func TestPoller_notifyClassified_NoNotifier(t *testing.T) {
+	poller := &Poller{logger: newMockLoggerWithCalls()}
+
+	// Must not panic without a notifier
+	poller.notifyClassified(context.Background(), []*domain.ClassifiedContent{{}})
+}
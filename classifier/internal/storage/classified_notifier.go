@@ -0,0 +1,68 @@
+// Package storage provides storage adapters for the classifier service.
+// classified_notifier.go announces classified batches on Redis pub/sub.
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+)
+
+// ClassifiedBatchEvent is published after a batch of classified content is
+// indexed. Subscribers such as the search cache drop what the new documents
+// make stale; they must not rely on receiving every event.
+type ClassifiedBatchEvent struct {
+	Sources      []string  `json:"sources"`
+	Count        int       `json:"count"`
+	ClassifiedAt time.Time `json:"classified_at"`
+}
+
+// ClassifiedNotifier publishes classified batches to a Redis channel.
+type ClassifiedNotifier struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewClassifiedNotifier creates a notifier publishing on channel.
+func NewClassifiedNotifier(client *redis.Client, channel string) *ClassifiedNotifier {
+	return &ClassifiedNotifier{client: client, channel: channel}
+}
+
+// NotifyClassified announces the sources of newly indexed classified content.
+func (n *ClassifiedNotifier) NotifyClassified(ctx context.Context, contents []*domain.ClassifiedContent) error {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	message, err := json.Marshal(NewClassifiedBatchEvent(contents, time.Now().UTC()))
+	if err != nil {
+		return fmt.Errorf("marshal classified event: %w", err)
+	}
+	if publishErr := n.client.Publish(ctx, n.channel, message).Err(); publishErr != nil {
+		return fmt.Errorf("publish classified event: %w", publishErr)
+	}
+	return nil
+}
+
+// NewClassifiedBatchEvent summarizes contents as their distinct, sorted sources.
+func NewClassifiedBatchEvent(contents []*domain.ClassifiedContent, classifiedAt time.Time) ClassifiedBatchEvent {
+	sources := make([]string, 0, len(contents))
+	for _, content := range contents {
+		if content.SourceName != "" {
+			sources = append(sources, content.SourceName)
+		}
+	}
+	slices.Sort(sources)
+
+	return ClassifiedBatchEvent{
+		Sources:      slices.Compact(sources),
+		Count:        len(contents),
+		ClassifiedAt: classifiedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package storage_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+	"github.com/jonesrussell/north-cloud/classifier/internal/storage"
+)
+
+func TestNewClassifiedBatchEvent(t *testing.T) {
+	contents := []*domain.ClassifiedContent{
+		{RawContent: domain.RawContent{SourceName: "sudbury_star"}},
+		{RawContent: domain.RawContent{SourceName: "cbc_sudbury"}},
+		{RawContent: domain.RawContent{SourceName: "sudbury_star"}},
+		{RawContent: domain.RawContent{}},
+	}
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	event := storage.NewClassifiedBatchEvent(contents, at)
+
+	if want := []string{"cbc_sudbury", "sudbury_star"}; !slices.Equal(event.Sources, want) {
+		t.Errorf("Sources: want %v, got %v", want, event.Sources)
+	}
+	if event.Count != len(contents) {
+		t.Errorf("Count: want %d, got %d", len(contents), event.Count)
+	}
+	if !event.ClassifiedAt.Equal(at) {
+		t.Errorf("ClassifiedAt: want %v, got %v", at, event.ClassifiedAt)
+	}
+}
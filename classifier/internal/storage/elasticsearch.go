@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"sync"
 	"time"
 
 	es "github.com/elastic/go-elasticsearch/v8"
@@ -15,7 +17,10 @@ import (
 
 // ElasticsearchStorage implements storage operations for the classifier
 type ElasticsearchStorage struct {
-	client *es.Client
+	client       *es.Client
+	outputFilter *OutputFilter
+	// sidecarIndexes records sidecar indexes already ensured by this process
+	sidecarIndexes sync.Map
 }
 
 // NewElasticsearchStorage creates a new Elasticsearch storage instance
@@ -25,6 +30,12 @@ func NewElasticsearchStorage(client *es.Client) *ElasticsearchStorage {
 	}
 }
 
+// WithOutputFilter applies per-index output field rules to classified writes.
+func (s *ElasticsearchStorage) WithOutputFilter(filter *OutputFilter) *ElasticsearchStorage {
+	s.outputFilter = filter
+	return s
+}
+
 // QueryRawContent queries for raw content with specified classification status
 // This matches the ElasticsearchClient interface expected by the Poller
 func (s *ElasticsearchStorage) QueryRawContent(ctx context.Context, status string, batchSize int) ([]*domain.RawContent, error) {
@@ -117,16 +128,31 @@ func (s *ElasticsearchStorage) IndexClassifiedContent(ctx context.Context, conte
 	now := time.Now()
 	content.ClassifiedAt = &now
 
-	docBytes, err := json.Marshal(content)
+	docs, err := s.outputFilter.encodeClassified(classifiedIndex, content, content.ID, now)
 	if err != nil {
-		return fmt.Errorf("failed to marshal document: %w", err)
+		return err
+	}
+
+	if err = s.indexDocument(ctx, classifiedIndex, content.ID, docs.main); err != nil {
+		return err
+	}
+
+	if docs.sidecar == nil {
+		return nil
 	}
+	if err = s.ensureSidecarIndex(ctx, docs.sidecarIndex); err != nil {
+		return err
+	}
+	return s.indexDocument(ctx, docs.sidecarIndex, content.ID, docs.sidecar)
+}
 
+// indexDocument writes one document by ID.
+func (s *ElasticsearchStorage) indexDocument(ctx context.Context, index, id string, doc []byte) error {
 	res, err := s.client.Index(
-		classifiedIndex,
-		bytes.NewReader(docBytes),
+		index,
+		bytes.NewReader(doc),
 		s.client.Index.WithContext(ctx),
-		s.client.Index.WithDocumentID(content.ID),
+		s.client.Index.WithDocumentID(id),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to index document: %w", err)
@@ -144,6 +170,34 @@ func (s *ElasticsearchStorage) IndexClassifiedContent(ctx context.Context, conte
 	return nil
 }
 
+// ensureSidecarIndex creates a sidecar index with its lookup-only mapping the
+// first time this process writes to it. An existing index is left unchanged.
+func (s *ElasticsearchStorage) ensureSidecarIndex(ctx context.Context, index string) error {
+	if _, ok := s.sidecarIndexes.Load(index); ok {
+		return nil
+	}
+
+	res, err := s.client.Indices.Create(
+		index,
+		s.client.Indices.Create.WithBody(bytes.NewReader(sidecarIndexBody)),
+		s.client.Indices.Create.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create sidecar index %s: %w", index, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	// 400 resource_already_exists_exception means another writer created it first
+	if res.IsError() && res.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("error creating sidecar index %s: %s", index, res.String())
+	}
+
+	s.sidecarIndexes.Store(index, struct{}{})
+	return nil
+}
+
 // UpdateRawContentStatus updates the classification_status field in raw_content
 // This matches the ElasticsearchClient interface expected by the Poller
 func (s *ElasticsearchStorage) UpdateRawContentStatus(ctx context.Context, contentID, status string, classifiedAt time.Time) error {
@@ -230,20 +284,23 @@ func (s *ElasticsearchStorage) BulkIndexClassifiedContent(ctx context.Context, c
 		content.ClassificationStatus = domain.StatusClassified
 		content.ClassifiedAt = &now
 
-		// Create bulk index action
-		meta := map[string]any{
-			"index": map[string]any{
-				"_index": classifiedIndex,
-				"_id":    content.ID,
-			},
+		docs, encodeErr := s.outputFilter.encodeClassified(classifiedIndex, content, content.ID, now)
+		if encodeErr != nil {
+			return fmt.Errorf("failed to encode content %s: %w", content.ID, encodeErr)
 		}
 
-		if err := json.NewEncoder(&buf).Encode(meta); err != nil {
-			return fmt.Errorf("failed to encode meta: %w", err)
+		if err := writeBulkIndex(&buf, classifiedIndex, content.ID, docs.main); err != nil {
+			return err
 		}
 
-		if err := json.NewEncoder(&buf).Encode(content); err != nil {
-			return fmt.Errorf("failed to encode content: %w", err)
+		if docs.sidecar == nil {
+			continue
+		}
+		if err := s.ensureSidecarIndex(ctx, docs.sidecarIndex); err != nil {
+			return err
+		}
+		if err := writeBulkIndex(&buf, docs.sidecarIndex, content.ID, docs.sidecar); err != nil {
+			return err
 		}
 	}
 
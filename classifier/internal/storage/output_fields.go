@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/config"
+	"github.com/jonesrussell/north-cloud/infrastructure/naming"
+)
+
+// ClassifiedDetailsSuffix is the index suffix for sidecar documents holding the
+// fields an output rule removed from the classified index.
+const ClassifiedDetailsSuffix = "_classified_details"
+
+// coreOutputFields are kept in the classified index whatever the whitelist says,
+// since the publisher, search, and re-classification rely on them.
+var coreOutputFields = []string{
+	"id", "url", "source_name", "title",
+	"content_type", "quality_score", "topics",
+	"classification_status", "classified_at", "crawled_at", "published_date",
+}
+
+// outputRule is a compiled config.OutputFieldsRule.
+type outputRule struct {
+	pattern string
+	keep    map[string]bool
+	sidecar bool
+}
+
+// OutputFilter splits classified documents into the fields stored in the
+// classified index and the remainder, per index.
+type OutputFilter struct {
+	rules []outputRule
+}
+
+// NewOutputFilter compiles the output rules. Rules with no fields keep every
+// field, which lets a specific pattern opt out of a broader rule listed after it.
+func NewOutputFilter(rules []config.OutputFieldsRule) (*OutputFilter, error) {
+	compiled := make([]outputRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Index == "" {
+			return nil, fmt.Errorf("output_fields[%d]: index pattern is required", i)
+		}
+		if _, err := path.Match(rule.Index, ""); err != nil {
+			return nil, fmt.Errorf("output_fields[%d]: invalid index pattern %q: %w", i, rule.Index, err)
+		}
+
+		var keep map[string]bool
+		if len(rule.Fields) > 0 {
+			keep = make(map[string]bool, len(rule.Fields)+len(coreOutputFields))
+			for _, field := range coreOutputFields {
+				keep[field] = true
+			}
+			for _, field := range rule.Fields {
+				keep[strings.TrimSpace(field)] = true
+			}
+		}
+		compiled = append(compiled, outputRule{pattern: rule.Index, keep: keep, sidecar: rule.Sidecar})
+	}
+	return &OutputFilter{rules: compiled}, nil
+}
+
+// ruleFor returns the first rule matching index, or nil when the index keeps every field.
+func (f *OutputFilter) ruleFor(index string) *outputRule {
+	if f == nil {
+		return nil
+	}
+	for i := range f.rules {
+		if matched, _ := path.Match(f.rules[i].pattern, index); matched {
+			if f.rules[i].keep == nil {
+				return nil
+			}
+			return &f.rules[i]
+		}
+	}
+	return nil
+}
+
+// outputDocs holds the encoded documents for one classified item.
+type outputDocs struct {
+	main         []byte
+	sidecarIndex string
+	sidecar      []byte
+}
+
+// encodeClassified marshals content for index, applying the matching output rule.
+// sidecar is nil when nothing was removed or the rule drops removed fields.
+func (f *OutputFilter) encodeClassified(index string, content any, id string, classifiedAt time.Time) (*outputDocs, error) {
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	rule := f.ruleFor(index)
+	if rule == nil {
+		return &outputDocs{main: raw}, nil
+	}
+
+	var doc map[string]json.RawMessage
+	if unmarshalErr := json.Unmarshal(raw, &doc); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to decode document for output filtering: %w", unmarshalErr)
+	}
+
+	removed := make(map[string]json.RawMessage)
+	for field, value := range doc {
+		if !rule.keep[field] {
+			removed[field] = value
+			delete(doc, field)
+		}
+	}
+
+	main, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal filtered document: %w", err)
+	}
+
+	out := &outputDocs{main: main}
+	if !rule.sidecar || len(removed) == 0 {
+		return out, nil
+	}
+
+	sidecar, err := json.Marshal(map[string]any{
+		"id":               id,
+		"classified_index": index,
+		"classified_at":    classifiedAt,
+		"fields":           removed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sidecar document: %w", err)
+	}
+	out.sidecarIndex = SidecarIndexFor(index)
+	out.sidecar = sidecar
+	return out, nil
+}
+
+// SidecarIndexFor returns the sidecar index for a classified index,
+// e.g. "example_com_classified_content" -> "example_com_classified_details".
+func SidecarIndexFor(classifiedIndex string) string {
+	return strings.TrimSuffix(classifiedIndex, naming.ClassifiedContentSuffix) + ClassifiedDetailsSuffix
+}
+
+// sidecarIndexBody creates sidecar indexes with only the lookup keys mapped;
+// the removed fields live in _source and are not indexed.
+var sidecarIndexBody = []byte(`{
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "id": {"type": "keyword"},
+      "classified_index": {"type": "keyword"},
+      "classified_at": {"type": "date"},
+      "fields": {"type": "object", "enabled": false}
+    }
+  }
+}`)
+
+// writeBulkIndex appends an index action and its document to buf.
+func writeBulkIndex(buf *bytes.Buffer, index, id string, doc []byte) error {
+	meta, err := json.Marshal(map[string]any{
+		"index": map[string]any{"_index": index, "_id": id},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode meta: %w", err)
+	}
+	buf.Write(meta)
+	buf.WriteByte('\n')
+	buf.Write(doc)
+	buf.WriteByte('\n')
+	return nil
+}
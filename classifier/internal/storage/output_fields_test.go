@@ -0,0 +1,149 @@
+//nolint:testpackage // Testing unexported output encoding requires same package access
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/classifier/internal/config"
+	"github.com/jonesrussell/north-cloud/classifier/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func leanRules(sidecar bool) []config.OutputFieldsRule {
+	return []config.OutputFieldsRule{
+		{Index: "archive_classified_content"},
+		{Index: "*_classified_content", Fields: []string{"body", "crime"}, Sidecar: sidecar},
+	}
+}
+
+func testClassified() *domain.ClassifiedContent {
+	return &domain.ClassifiedContent{
+		RawContent: domain.RawContent{
+			ID: "doc-1", URL: "https://cbc.ca/1", SourceName: "cbc",
+			SourceIndex: "cbc_raw_content", Title: "Title", RawText: "body text",
+		},
+		ContentType:    domain.ContentTypeArticle,
+		QualityScore:   80,
+		QualityFactors: map[string]any{"word_count": 0.9},
+		TopicScores:    map[string]float64{"crime": 0.8},
+	}
+}
+
+func TestNewOutputFilter_InvalidRules(t *testing.T) {
+	t.Helper()
+
+	_, err := NewOutputFilter([]config.OutputFieldsRule{{Fields: []string{"title"}}})
+	require.Error(t, err)
+
+	_, err = NewOutputFilter([]config.OutputFieldsRule{{Index: "[bad", Fields: []string{"title"}}})
+	require.Error(t, err)
+}
+
+func TestEncodeClassified_NoMatchingRuleKeepsEverything(t *testing.T) {
+	t.Helper()
+
+	filter, err := NewOutputFilter(leanRules(true))
+	require.NoError(t, err)
+
+	// archive_* matches the empty-whitelist rule first, so it opts out
+	docs, err := filter.encodeClassified("archive_classified_content", testClassified(), "doc-1", time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, docs.sidecar)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(docs.main, &doc))
+	assert.Contains(t, doc, "quality_factors")
+	assert.Contains(t, doc, "topic_scores")
+}
+
+func TestEncodeClassified_SplitsToSidecar(t *testing.T) {
+	t.Helper()
+
+	filter, err := NewOutputFilter(leanRules(true))
+	require.NoError(t, err)
+
+	docs, err := filter.encodeClassified("cbc_classified_content", testClassified(), "doc-1", time.Now())
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(docs.main, &doc))
+	assert.Equal(t, "doc-1", doc["id"], "core fields are always kept")
+	assert.Equal(t, "Title", doc["title"])
+	assert.InDelta(t, 80, doc["quality_score"], 0)
+	assert.NotContains(t, doc, "quality_factors")
+	assert.NotContains(t, doc, "topic_scores")
+	assert.NotContains(t, doc, "raw_text")
+
+	assert.Equal(t, "cbc_classified_details", docs.sidecarIndex)
+	var sidecar struct {
+		ID              string         `json:"id"`
+		ClassifiedIndex string         `json:"classified_index"`
+		Fields          map[string]any `json:"fields"`
+	}
+	require.NoError(t, json.Unmarshal(docs.sidecar, &sidecar))
+	assert.Equal(t, "doc-1", sidecar.ID)
+	assert.Equal(t, "cbc_classified_content", sidecar.ClassifiedIndex)
+	assert.Contains(t, sidecar.Fields, "quality_factors")
+	assert.Contains(t, sidecar.Fields, "raw_text")
+	assert.NotContains(t, sidecar.Fields, "title")
+}
+
+func TestEncodeClassified_DropWithoutSidecar(t *testing.T) {
+	t.Helper()
+
+	filter, err := NewOutputFilter(leanRules(false))
+	require.NoError(t, err)
+
+	docs, err := filter.encodeClassified("cbc_classified_content", testClassified(), "doc-1", time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, docs.sidecar)
+	assert.NotContains(t, string(docs.main), "quality_factors")
+}
+
+func TestBulkIndexClassifiedContent_WritesSidecar(t *testing.T) {
+	t.Helper()
+
+	var (
+		createdIndex string
+		bulkIndexes  []string
+	)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			createdIndex = strings.TrimPrefix(r.URL.Path, "/")
+			writeJSON(t, w, map[string]any{"acknowledged": true})
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		for scanner.Scan() {
+			var line map[string]map[string]any
+			if json.Unmarshal(scanner.Bytes(), &line) == nil {
+				if action, ok := line["index"]; ok {
+					if index, isString := action["_index"].(string); isString {
+						bulkIndexes = append(bulkIndexes, index)
+					}
+				}
+			}
+		}
+		writeJSON(t, w, map[string]any{"errors": false, "items": []any{}})
+	}
+
+	filter, err := NewOutputFilter(leanRules(true))
+	require.NoError(t, err)
+	s := NewElasticsearchStorage(newTestESClient(t, handler)).WithOutputFilter(filter)
+
+	require.NoError(t, s.BulkIndexClassifiedContent(context.Background(), []*domain.ClassifiedContent{testClassified()}))
+
+	assert.Equal(t, "cbc_classified_details", createdIndex)
+	assert.Equal(t, []string{"cbc_classified_content", "cbc_classified_details"}, bulkIndexes)
+}
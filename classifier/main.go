@@ -8,6 +8,7 @@ import (
 
 	"github.com/jonesrussell/north-cloud/classifier/cmd/processor"
 	"github.com/jonesrussell/north-cloud/classifier/internal/server"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -27,6 +28,8 @@ func initLogger() infralogger.Logger {
 }
 
 func main() {
+	defer crash.Start("classifier")()
+
 	// Get command from args, default to "both" (httpd + processor)
 	command := "both"
 	if len(os.Args) > 1 {
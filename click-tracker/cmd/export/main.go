@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/config"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	_ "github.com/lib/pq"
+)
+
+// Exit codes for the export command.
+const (
+	exitSuccess = 0
+	exitFailure = 1
+)
+
+// stdoutPath writes the export to standard output.
+const stdoutPath = "-"
+
+// uploadContentTypes are the object content types per export format.
+var uploadContentTypes = map[string]string{
+	storage.ExportFormatCSV:     "text/csv",
+	storage.ExportFormatParquet: "application/vnd.apache.parquet",
+}
+
+// usage describes the command.
+const usage = `Usage: export -since <time> [flags]
+
+Streams raw click events clicked in [since, until) as CSV or Parquet to a
+file, stdout or MinIO. Times are RFC 3339 or YYYY-MM-DD (midnight UTC).
+Parquet needs the ClickHouse storage backend.
+
+Flags:`
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// exportOptions are the parsed command-line flags.
+type exportOptions struct {
+	since, until time.Time
+	format       string
+	output       string
+	uploadKey    string
+}
+
+func run(args []string) int {
+	opts, err := parseFlags(args)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return exitFailure
+	}
+
+	cfg, err := config.Load(infraconfig.GetConfigPath("config.yml"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return exitFailure
+	}
+	if opts.uploadKey != "" {
+		if validationErr := cfg.Export.ValidateUpload(); validationErr != nil {
+			fmt.Fprintf(os.Stderr, "Invalid export config: %v\n", validationErr)
+			return exitFailure
+		}
+	}
+
+	exporter, closeStore, err := openExporter(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open storage: %v\n", err)
+		return exitFailure
+	}
+	defer closeStore()
+
+	if !exporter.SupportsExportFormat(opts.format) {
+		fmt.Fprintf(os.Stderr, "Format %q is not supported by the %s backend\n", opts.format, cfg.Storage.Backend)
+		return exitFailure
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	write := func(w io.Writer) error {
+		return exporter.ExportEvents(ctx, opts.since, opts.until, opts.format, w)
+	}
+
+	switch {
+	case opts.uploadKey != "":
+		err = upload(ctx, &cfg.Export, opts.uploadKey, uploadContentTypes[opts.format], write)
+	case opts.output == stdoutPath:
+		err = write(os.Stdout)
+	default:
+		err = writeFile(opts.output, write)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+		return exitFailure
+	}
+
+	if opts.uploadKey != "" {
+		fmt.Fprintf(os.Stderr, "Exported to s3://%s/%s\n", cfg.Export.Bucket, opts.uploadKey)
+	} else if opts.output != stdoutPath {
+		fmt.Fprintf(os.Stderr, "Exported to %s\n", opts.output)
+	}
+	return exitSuccess
+}
+
+// parseFlags parses and checks the command-line flags. A -o or -upload
+// ending in "/" gets the default export file name appended.
+func parseFlags(args []string) (*exportOptions, error) {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	since := flags.String("since", "", "start of the range, inclusive (required)")
+	until := flags.String("until", "", "end of the range, exclusive (default now)")
+	format := flags.String("format", storage.ExportFormatCSV, "csv or parquet")
+	output := flags.String("o", stdoutPath, `output file, or "-" for stdout`)
+	uploadKey := flags.String("upload", "", "upload to this object key in the configured MinIO bucket instead")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	opts := &exportOptions{format: *format, output: *output, uploadKey: *uploadKey, until: time.Now().UTC()}
+
+	var err error
+	if opts.since, err = storage.ParseExportTime(*since); err != nil {
+		return nil, errors.New("-since must be an RFC 3339 time or YYYY-MM-DD date")
+	}
+	if *until != "" {
+		if opts.until, err = storage.ParseExportTime(*until); err != nil {
+			return nil, errors.New("-until must be an RFC 3339 time or YYYY-MM-DD date")
+		}
+	}
+	if !opts.until.After(opts.since) {
+		return nil, errors.New("-until must be after -since")
+	}
+	if _, ok := uploadContentTypes[opts.format]; !ok {
+		return nil, fmt.Errorf("-format must be %q or %q", storage.ExportFormatCSV, storage.ExportFormatParquet)
+	}
+
+	fileName := storage.ExportFileName(opts.since, opts.until, opts.format)
+	if strings.HasSuffix(opts.output, "/") {
+		opts.output += fileName
+	}
+	if strings.HasSuffix(opts.uploadKey, "/") {
+		opts.uploadKey += fileName
+	}
+	return opts, nil
+}
+
+// eventExporter is what the export command reads from the storage backend.
+type eventExporter interface {
+	SupportsExportFormat(format string) bool
+	ExportEvents(ctx context.Context, since, until time.Time, format string, w io.Writer) error
+}
+
+// openExporter connects the backend selected by storage.backend.
+func openExporter(cfg *config.Config) (eventExporter, func(), error) {
+	if cfg.Storage.Backend == config.StorageBackendClickHouse {
+		if err := cfg.Storage.Validate(); err != nil {
+			return nil, nil, err
+		}
+		return storage.NewClickHouseStatsReader(storage.NewClickHouse(&cfg.Storage.ClickHouse)), func() {}, nil
+	}
+
+	db, err := sql.Open("postgres", cfg.Database.DSN())
+	if err != nil {
+		return nil, nil, fmt.Errorf("open database: %w", err)
+	}
+	return storage.NewStatsReader(db), func() { _ = db.Close() }, nil
+}
+
+// writeFile writes the export to path, removing the file if it fails.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+	if writeErr := write(f); writeErr != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return writeErr
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		return fmt.Errorf("close output: %w", closeErr)
+	}
+	return nil
+}
+
+// upload streams the export to MinIO as it is written, without buffering it
+// in memory or on disk. A failed export aborts the upload, so no partial
+// object is left behind.
+func upload(
+	ctx context.Context, cfg *config.ExportConfig, key, contentType string, write func(io.Writer) error,
+) error {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return fmt.Errorf("create minio client: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(write(pw))
+	}()
+
+	// Size -1 makes the client upload in parts as the export is written
+	_, err = client.PutObject(ctx, cfg.Bucket, key, pr, -1, minio.PutObjectOptions{ContentType: contentType})
+	_ = pr.CloseWithError(err)
+	if err != nil {
+		return fmt.Errorf("upload %s: %w", key, err)
+	}
+	return nil
+}
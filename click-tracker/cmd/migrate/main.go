@@ -1,17 +1,26 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"flag"
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/config"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+
+	_ "github.com/lib/pq"
 )
 
 // Exit codes for the migrate command.
@@ -27,19 +36,43 @@ func main() {
 	os.Exit(run())
 }
 
+// usage lists the supported commands.
+const usage = `Usage: migrate <command> [flags]
+
+Commands:
+  up               Apply all pending schema migrations
+  down             Roll back all schema migrations
+  rollup-backfill  Backfill click_rollups_hourly from click_events (resumable)
+  rollup-status    Show rollup backfill progress
+
+rollup-backfill flags:
+  -window duration  clicked_at span per batch, whole hours (default 6h)
+  -pause duration   sleep between batches (default 100ms)
+  -restart          discard saved progress and start from the oldest event`
+
 func run() int {
 	const minArgs = 2
 	if len(os.Args) < minArgs {
-		fmt.Fprintln(os.Stderr, "Usage: migrate <up|down>")
+		fmt.Fprintln(os.Stderr, usage)
 		return exitFailure
 	}
 
-	direction := os.Args[1]
-	if direction != "up" && direction != "down" {
-		fmt.Fprintf(os.Stderr, "Invalid direction: %q (must be \"up\" or \"down\")\n", direction)
+	command := os.Args[1]
+	switch command {
+	case "up", "down":
+		return runSchemaMigration(command)
+	case "rollup-backfill":
+		return runRollupBackfill(os.Args[minArgs:])
+	case "rollup-status":
+		return runRollupStatus()
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid command: %q\n\n%s\n", command, usage)
 		return exitFailure
 	}
+}
 
+// runSchemaMigration applies or rolls back the schema migrations.
+func runSchemaMigration(direction string) int {
 	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
@@ -64,6 +97,93 @@ func run() int {
 	return exitSuccess
 }
 
+// runRollupBackfill backfills rollups in batches until caught up. Interrupting
+// it (Ctrl-C) is safe; the next run resumes from the last committed batch.
+func runRollupBackfill(args []string) int {
+	flags := flag.NewFlagSet("rollup-backfill", flag.ContinueOnError)
+	window := flags.Duration("window", storage.DefaultBackfillWindow, "clicked_at span per batch")
+	pause := flags.Duration("pause", storage.DefaultBackfillPause, "sleep between batches")
+	restart := flags.Bool("restart", false, "discard saved progress")
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	db, err := openDatabase()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return exitFailure
+	}
+	defer func() { _ = db.Close() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	backfiller := storage.NewRollupBackfiller(db, storage.BackfillConfig{
+		Window:  *window,
+		Pause:   *pause,
+		Restart: *restart,
+		OnBatch: func(p *storage.BackfillProgress) {
+			fmt.Printf("[%5.1f%%] rolled up to %s (batch %d, %d rows)\n",
+				p.Percent(), p.Cursor.Format(time.RFC3339), p.Batches, p.RowsUpserted)
+		},
+	})
+
+	progress, err := backfiller.Run(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Rollup backfill stopped: %v\n", err)
+		if progress != nil {
+			fmt.Fprintln(os.Stderr, "Progress is saved; re-run rollup-backfill to resume.")
+		}
+		return exitFailure
+	}
+
+	fmt.Printf("Rollup backfill completed: %d batches, %d rows\n", progress.Batches, progress.RowsUpserted)
+	return exitSuccess
+}
+
+// runRollupStatus prints saved rollup backfill progress.
+func runRollupStatus() int {
+	db, err := openDatabase()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return exitFailure
+	}
+	defer func() { _ = db.Close() }()
+
+	progress, err := storage.NewRollupBackfiller(db, storage.BackfillConfig{}).Status(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load rollup status: %v\n", err)
+		return exitFailure
+	}
+	if progress == nil {
+		fmt.Println("No rollup backfill has run")
+		return exitSuccess
+	}
+
+	fmt.Printf("rollup:   %s\nstatus:   %s\nprogress: %.1f%% (%d batches, %d rows)\n",
+		progress.RollupName, progress.Status, progress.Percent(), progress.Batches, progress.RowsUpserted)
+	if progress.Cursor != nil && progress.RangeEnd != nil {
+		fmt.Printf("cursor:   %s of %s\n", progress.Cursor.Format(time.RFC3339), progress.RangeEnd.Format(time.RFC3339))
+	}
+	if progress.LastError != "" {
+		fmt.Printf("error:    %s\n", progress.LastError)
+	}
+	return exitSuccess
+}
+
+// openDatabase opens a database handle from the service config.
+func openDatabase() (*sql.DB, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("postgres", cfg.Database.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	return db, nil
+}
+
 // loadConfig loads the application configuration.
 func loadConfig() (*config.Config, error) {
 	configPath := infraconfig.GetConfigPath("config.yml")
@@ -6,15 +6,21 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/middleware"
+	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 )
 
 // SetupRoutes configures all API routes.
 // Health routes (/health, /health/memory) are registered by the infrastructure gin builder.
-// The done channel is closed on server shutdown to stop the rate limiter goroutine.
+// The done channel is closed on server shutdown to stop the rate limiter goroutines.
 func SetupRoutes(
 	router *gin.Engine,
 	clickHandler *handler.ClickHandler,
+	statsHandler *handler.StatsHandler,
+	streamHandler *handler.StreamHandler,
+	jwtSecret string,
 	maxClicksPerMin int,
+	maxImpressionsPerMin int,
 	rateLimitWindow time.Duration,
 	done <-chan struct{},
 ) {
@@ -23,4 +29,29 @@ func SetupRoutes(
 	click.Use(middleware.BotFilter())
 	click.Use(middleware.RateLimiter(maxClicksPerMin, rateLimitWindow, done))
 	click.GET("/click", clickHandler.HandleClick)
+
+	// Impression pixel, limited separately as a page loads one per result
+	impression := router.Group("")
+	impression.Use(middleware.BotFilter())
+	impression.Use(middleware.RateLimiter(maxImpressionsPerMin, rateLimitWindow, done))
+	impression.GET("/impression", clickHandler.HandleImpression)
+
+	// Aggregate stats for other services (e.g. publisher weekly reports,
+	// search personalization and ranking feedback)
+	// Read tokens (publisher's and search's service tokens) may POST stats queries
+	v1 := infragin.ProtectedGroup(router, "/api/v1", jwtSecret, infrajwt.WithReadRoutes("/api/v1/stats/results"))
+	v1.POST("/stats/results", statsHandler.TopResults)
+	v1.GET("/stats/sessions/:session_id", statsHandler.SessionResults)
+	v1.GET("/stats/clickthrough", statsHandler.ClickThroughExport)
+	v1.GET("/stats/campaigns", statsHandler.CampaignResults)
+	v1.GET("/stats/impressions", statsHandler.ImpressionResults)
+
+	// Verification key usage, for deciding when a rotated-out key can be dropped
+	v1.GET("/keys", clickHandler.KeyStatus)
+
+	// Raw event export for offline analysis
+	v1.GET("/export/events", statsHandler.ExportEvents)
+
+	// Live click stream for the dashboard; EventSource passes the JWT as ?token=
+	v1.GET("/clicks/events", streamHandler.ClickEvents)
 }
@@ -20,6 +20,8 @@ const (
 // The done channel is closed when the server shuts down, used to stop the rate limiter goroutine.
 func NewServer(
 	clickHandler *handler.ClickHandler,
+	statsHandler *handler.StatsHandler,
+	streamHandler *handler.StreamHandler,
 	cfg *config.Config,
 	log infralogger.Logger,
 	done <-chan struct{},
@@ -33,7 +35,8 @@ func NewServer(
 		WithTimeouts(defaultReadTimeout, defaultWriteTimeout, defaultIdleTimeout).
 		WithMetrics().
 		WithRoutes(func(router *gin.Engine) {
-			SetupRoutes(router, clickHandler, cfg.RateLimit.MaxClicksPerMinute, rateLimitWindow, done)
+			SetupRoutes(router, clickHandler, statsHandler, streamHandler, cfg.Auth.JWTSecret,
+				cfg.RateLimit.MaxClicksPerMinute, cfg.RateLimit.MaxImpressionsPerMinute, rateLimitWindow, done)
 		}).
 		Build()
 }
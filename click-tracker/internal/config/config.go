@@ -2,8 +2,12 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
 )
 
@@ -23,33 +27,91 @@ const (
 	defaultDBUser       = "postgres"
 	defaultDBSSLMode    = "disable"
 
-	defaultMaxClicksPerMinute = 10
-	defaultWindowSeconds      = 60
+	defaultClickHouseURL  = "http://localhost:8123"
+	defaultClickHouseDB   = "click_tracker"
+	defaultClickHouseUser = "default"
+
+	defaultMaxClicksPerMinute      = 10
+	defaultMaxImpressionsPerMinute = 300
+	defaultWindowSeconds           = 60
 
 	defaultMaxTimestampAgeH = 24
 	defaultFlushIntervalS   = 1
+
+	defaultFanoutStream = "click-events"
+	defaultFanoutMaxLen = 100000
+
+	defaultRawEventDays       = 90
+	defaultAnonymizeAfterDays = 30
+	defaultRetentionInterval  = time.Hour
+	minRetentionInterval      = time.Minute
+)
+
+// Storage backends for click events.
+const (
+	// StorageBackendPostgres writes to PostgreSQL; suited to low click volume.
+	StorageBackendPostgres = "postgres"
+	// StorageBackendClickHouse writes to ClickHouse with async inserts.
+	StorageBackendClickHouse = "clickhouse"
 )
 
+// clickHouseDatabasePattern keeps the database name a plain identifier. The
+// storage layer also quotes it in CREATE DATABASE.
+var clickHouseDatabasePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // Config holds the application configuration.
 type Config struct {
 	Service   ServiceConfig   `yaml:"service"`
 	Database  DatabaseConfig  `yaml:"database"`
+	Storage   StorageConfig   `yaml:"storage"`
 	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Retention RetentionConfig `yaml:"retention"`
+	Export    ExportConfig    `yaml:"export"`
+	Fanout    FanoutConfig    `yaml:"fanout"`
 	Logging   LoggingConfig   `yaml:"logging"`
+	Auth      AuthConfig      `yaml:"auth"`
 }
 
-// ServiceConfig holds service-level configuration.
+// AuthConfig holds JWT settings for the /api/v1 stats endpoints.
+// When JWTSecret is empty those endpoints are unauthenticated.
+type AuthConfig struct {
+	JWTSecret string `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
+}
+
+// ServiceConfig holds service-level configuration. HMACKeyID names
+// HMACSecret in key-ID-prefixed signatures and must match the ID the signers
+// (search, publisher) use; PreviousHMACKeys are "id:secret" keys still
+// accepted after a rotation, until links signed with them have expired.
 type ServiceConfig struct {
-	Name            string        `yaml:"name"`
-	Version         string        `yaml:"version"`
-	Port            int           `env:"CLICK_TRACKER_PORT"   yaml:"port"`
-	Debug           bool          `env:"APP_DEBUG"            yaml:"debug"`
-	HMACSecret      string        `env:"CLICK_TRACKER_SECRET" yaml:"hmac_secret"`
-	SignatureLength int           `yaml:"signature_length"`
-	MaxTimestampAge time.Duration `yaml:"max_timestamp_age"`
-	BufferSize      int           `yaml:"buffer_size"`
-	FlushInterval   time.Duration `yaml:"flush_interval"`
-	FlushThreshold  int           `yaml:"flush_threshold"`
+	Name             string        `yaml:"name"`
+	Version          string        `yaml:"version"`
+	Port             int           `env:"CLICK_TRACKER_PORT"          yaml:"port"`
+	Debug            bool          `env:"APP_DEBUG"                   yaml:"debug"`
+	HMACSecret       string        `env:"CLICK_TRACKER_SECRET"        yaml:"hmac_secret"`
+	HMACKeyID        string        `env:"CLICK_TRACKER_KEY_ID"        yaml:"hmac_key_id"`
+	PreviousHMACKeys []string      `env:"CLICK_TRACKER_PREVIOUS_KEYS" yaml:"previous_hmac_keys"`
+	SignatureLength  int           `yaml:"signature_length"`
+	MaxTimestampAge  time.Duration `yaml:"max_timestamp_age"`
+	BufferSize       int           `yaml:"buffer_size"`
+	FlushInterval    time.Duration `yaml:"flush_interval"`
+	FlushThreshold   int           `yaml:"flush_threshold"`
+}
+
+// VerificationKeys returns the keys click signatures are verified with: the
+// current key first, then the previous keys.
+func (s *ServiceConfig) VerificationKeys() ([]clickurl.Key, error) {
+	keys := []clickurl.Key{{ID: s.HMACKeyID, Secret: s.HMACSecret}}
+	for _, entry := range s.PreviousHMACKeys {
+		id, secret, found := strings.Cut(entry, ":")
+		if !found || id == "" || secret == "" {
+			return nil, &infraconfig.ValidationError{
+				Field:   "service.previous_hmac_keys",
+				Message: "entries must be id:secret",
+			}
+		}
+		keys = append(keys, clickurl.Key{ID: id, Secret: secret})
+	}
+	return keys, nil
 }
 
 // DatabaseConfig holds PostgreSQL database configuration.
@@ -70,10 +132,62 @@ func (d *DatabaseConfig) DSN() string {
 	)
 }
 
-// RateLimitConfig holds rate limiting configuration.
+// StorageConfig selects the backend click events are written to and stats
+// are read from. Database is only used by the postgres backend.
+type StorageConfig struct {
+	Backend    string           `env:"CLICK_TRACKER_STORAGE_BACKEND" yaml:"backend"`
+	ClickHouse ClickHouseConfig `yaml:"clickhouse"`
+}
+
+// ClickHouseConfig holds the ClickHouse HTTP interface settings.
+type ClickHouseConfig struct {
+	URL      string `env:"CLICKHOUSE_CLICK_TRACKER_URL"      yaml:"url"`
+	Database string `env:"CLICKHOUSE_CLICK_TRACKER_DB"       yaml:"database"`
+	User     string `env:"CLICKHOUSE_CLICK_TRACKER_USER"     yaml:"user"`
+	Password string `env:"CLICKHOUSE_CLICK_TRACKER_PASSWORD" yaml:"password"`
+}
+
+// RetentionConfig controls the scheduled retention job. Raw events lose
+// their session ID and user-agent hash after AnonymizeAfterDays and are
+// deleted after RawEventDays; hourly rollups are kept forever. With the
+// ClickHouse backend only anonymization applies, as column TTLs.
+type RetentionConfig struct {
+	Enabled            bool          `env:"CLICK_TRACKER_RETENTION_ENABLED"        yaml:"enabled"`
+	RawEventDays       int           `env:"CLICK_TRACKER_RETENTION_RAW_EVENT_DAYS" yaml:"raw_event_days"`
+	AnonymizeAfterDays int           `env:"CLICK_TRACKER_RETENTION_ANONYMIZE_DAYS" yaml:"anonymize_after_days"`
+	Interval           time.Duration `yaml:"interval"`
+}
+
+// ExportConfig holds the MinIO (S3-compatible) destination the export
+// command uploads to. It is only read by cmd/export with -upload.
+type ExportConfig struct {
+	Endpoint  string `env:"CLICK_TRACKER_EXPORT_MINIO_ENDPOINT"   yaml:"endpoint"`
+	AccessKey string `env:"CLICK_TRACKER_EXPORT_MINIO_ACCESS_KEY" json:"-"          yaml:"access_key"`
+	SecretKey string `env:"CLICK_TRACKER_EXPORT_MINIO_SECRET_KEY" json:"-"          yaml:"secret_key"`
+	UseSSL    bool   `env:"CLICK_TRACKER_EXPORT_MINIO_USE_SSL"    yaml:"use_ssl"`
+	Bucket    string `env:"CLICK_TRACKER_EXPORT_MINIO_BUCKET"     yaml:"bucket"`
+}
+
+// FanoutConfig enables publishing accepted click events to a Redis stream,
+// so other services can consume clicks as they happen instead of querying
+// the click database. Each stream is trimmed to about MaxLen entries.
+// Impressions are published only when ImpressionStream is set.
+type FanoutConfig struct {
+	Enabled          bool   `env:"CLICK_TRACKER_FANOUT_ENABLED"           yaml:"enabled"`
+	Address          string `env:"REDIS_ADDRESS"                          yaml:"address"`
+	Password         string `env:"REDIS_PASSWORD"                         yaml:"password"`
+	DB               int    `yaml:"db"`
+	Stream           string `env:"CLICK_TRACKER_FANOUT_STREAM"            yaml:"stream"`
+	ImpressionStream string `env:"CLICK_TRACKER_FANOUT_IMPRESSION_STREAM" yaml:"impression_stream"`
+	MaxLen           int64  `yaml:"max_len"`
+}
+
+// RateLimitConfig holds rate limiting configuration. Impressions have their
+// own, higher limit: one page view loads a pixel per result shown.
 type RateLimitConfig struct {
-	MaxClicksPerMinute int `yaml:"max_clicks_per_minute"`
-	WindowSeconds      int `yaml:"window_seconds"`
+	MaxClicksPerMinute      int `yaml:"max_clicks_per_minute"`
+	MaxImpressionsPerMinute int `yaml:"max_impressions_per_minute"`
+	WindowSeconds           int `yaml:"window_seconds"`
 }
 
 // LoggingConfig holds logging configuration.
@@ -91,10 +205,23 @@ func Load(path string) (*Config, error) {
 func setDefaults(cfg *Config) {
 	setServiceDefaults(&cfg.Service)
 	setDatabaseDefaults(&cfg.Database)
+	setStorageDefaults(&cfg.Storage)
 	setRateLimitDefaults(&cfg.RateLimit)
+	setRetentionDefaults(&cfg.Retention)
+	setFanoutDefaults(&cfg.Fanout)
 	setLoggingDefaults(&cfg.Logging)
 }
 
+// setFanoutDefaults applies default values to FanoutConfig.
+func setFanoutDefaults(f *FanoutConfig) {
+	if f.Stream == "" {
+		f.Stream = defaultFanoutStream
+	}
+	if f.MaxLen == 0 {
+		f.MaxLen = defaultFanoutMaxLen
+	}
+}
+
 // setServiceDefaults applies default values to ServiceConfig.
 func setServiceDefaults(svc *ServiceConfig) {
 	if svc.Name == "" {
@@ -142,16 +269,48 @@ func setDatabaseDefaults(db *DatabaseConfig) {
 	}
 }
 
+// setStorageDefaults applies default values to StorageConfig.
+func setStorageDefaults(st *StorageConfig) {
+	if st.Backend == "" {
+		st.Backend = StorageBackendPostgres
+	}
+	if st.ClickHouse.URL == "" {
+		st.ClickHouse.URL = defaultClickHouseURL
+	}
+	if st.ClickHouse.Database == "" {
+		st.ClickHouse.Database = defaultClickHouseDB
+	}
+	if st.ClickHouse.User == "" {
+		st.ClickHouse.User = defaultClickHouseUser
+	}
+}
+
 // setRateLimitDefaults applies default values to RateLimitConfig.
 func setRateLimitDefaults(rl *RateLimitConfig) {
 	if rl.MaxClicksPerMinute == 0 {
 		rl.MaxClicksPerMinute = defaultMaxClicksPerMinute
 	}
+	if rl.MaxImpressionsPerMinute == 0 {
+		rl.MaxImpressionsPerMinute = defaultMaxImpressionsPerMinute
+	}
 	if rl.WindowSeconds == 0 {
 		rl.WindowSeconds = defaultWindowSeconds
 	}
 }
 
+// setRetentionDefaults applies default values to RetentionConfig.
+func setRetentionDefaults(rc *RetentionConfig) {
+	if rc.RawEventDays == 0 {
+		rc.RawEventDays = defaultRawEventDays
+	}
+	if rc.AnonymizeAfterDays == 0 {
+		rc.AnonymizeAfterDays = defaultAnonymizeAfterDays
+	}
+	if rc.Interval == 0 {
+		rc.Interval = defaultRetentionInterval
+	}
+}
+
 // setLoggingDefaults applies default values to LoggingConfig.
 func setLoggingDefaults(log *LoggingConfig) {
 	if log.Level == "" {
@@ -173,5 +332,94 @@ func (c *Config) Validate() error {
 			Message: "is required",
 		}
 	}
+	keys, err := c.Service.VerificationKeys()
+	if err != nil {
+		return err
+	}
+	if _, ringErr := clickurl.NewKeyRing(keys...); ringErr != nil {
+		return &infraconfig.ValidationError{Field: "service.hmac_key_id", Message: ringErr.Error()}
+	}
+	if err := c.Storage.Validate(); err != nil {
+		return err
+	}
+	if err := c.Fanout.Validate(); err != nil {
+		return err
+	}
+	return c.Retention.Validate()
+}
+
+// Validate checks the Redis address and stream cap when fanout is enabled.
+func (f *FanoutConfig) Validate() error {
+	if !f.Enabled {
+		return nil
+	}
+	if f.Address == "" {
+		return &infraconfig.ValidationError{Field: "fanout.address", Message: "is required when fanout is enabled"}
+	}
+	if f.MaxLen < 1 {
+		return &infraconfig.ValidationError{Field: "fanout.max_len", Message: "must be at least 1"}
+	}
+	if f.ImpressionStream == f.Stream {
+		return &infraconfig.ValidationError{Field: "fanout.impression_stream", Message: "must differ from fanout.stream"}
+	}
+	return nil
+}
+
+// Validate checks the retention periods and interval when retention is enabled.
+func (r *RetentionConfig) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+	if r.RawEventDays < 1 {
+		return &infraconfig.ValidationError{Field: "retention.raw_event_days", Message: "must be at least 1"}
+	}
+	if r.AnonymizeAfterDays < 1 {
+		return &infraconfig.ValidationError{Field: "retention.anonymize_after_days", Message: "must be at least 1"}
+	}
+	if r.Interval < minRetentionInterval {
+		return &infraconfig.ValidationError{Field: "retention.interval", Message: "must be at least 1m"}
+	}
+	return nil
+}
+
+// ValidateUpload checks that an upload destination is configured.
+func (e *ExportConfig) ValidateUpload() error {
+	if e.Endpoint == "" {
+		return &infraconfig.ValidationError{Field: "export.endpoint", Message: "is required to upload"}
+	}
+	if e.AccessKey == "" || e.SecretKey == "" {
+		return &infraconfig.ValidationError{Field: "export.access_key", Message: "and secret_key are required to upload"}
+	}
+	if e.Bucket == "" {
+		return &infraconfig.ValidationError{Field: "export.bucket", Message: "is required to upload"}
+	}
+	return nil
+}
+
+// Validate checks the backend name and, for ClickHouse, its connection settings.
+func (s *StorageConfig) Validate() error {
+	if s.Backend == StorageBackendPostgres {
+		return nil
+	}
+	if s.Backend != StorageBackendClickHouse {
+		return &infraconfig.ValidationError{
+			Field:   "storage.backend",
+			Message: fmt.Sprintf("must be %q or %q", StorageBackendPostgres, StorageBackendClickHouse),
+		}
+	}
+
+	u, err := url.Parse(s.ClickHouse.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &infraconfig.ValidationError{
+			Field:   "storage.clickhouse.url",
+			Message: "must be an http or https URL",
+		}
+	}
+	if !clickHouseDatabasePattern.MatchString(s.ClickHouse.Database) {
+		return &infraconfig.ValidationError{
+			Field:   "storage.clickhouse.database",
+			Message: "must contain only letters, digits and underscores",
+		}
+	}
 	return nil
 }
@@ -36,11 +36,29 @@ func TestSetDefaults(t *testing.T) {
 	assertStringEqual(t, "database.database", defaultDBName, cfg.Database.Database)
 	assertStringEqual(t, "database.sslmode", defaultDBSSLMode, cfg.Database.SSLMode)
 
+	assertStringEqual(t, "storage.backend", StorageBackendPostgres, cfg.Storage.Backend)
+	assertStringEqual(t, "storage.clickhouse.url", defaultClickHouseURL, cfg.Storage.ClickHouse.URL)
+	assertStringEqual(t, "storage.clickhouse.database", defaultClickHouseDB, cfg.Storage.ClickHouse.Database)
+	assertStringEqual(t, "storage.clickhouse.user", defaultClickHouseUser, cfg.Storage.ClickHouse.User)
+
 	assertIntEqual(t, "rate_limit.max_clicks_per_minute",
 		defaultMaxClicksPerMinute, cfg.RateLimit.MaxClicksPerMinute)
+	assertIntEqual(t, "rate_limit.max_impressions_per_minute",
+		defaultMaxImpressionsPerMinute, cfg.RateLimit.MaxImpressionsPerMinute)
 	assertIntEqual(t, "rate_limit.window_seconds",
 		defaultWindowSeconds, cfg.RateLimit.WindowSeconds)
 
+	assertIntEqual(t, "retention.raw_event_days", defaultRawEventDays, cfg.Retention.RawEventDays)
+	assertIntEqual(t, "retention.anonymize_after_days", defaultAnonymizeAfterDays, cfg.Retention.AnonymizeAfterDays)
+	assertStringEqual(t, "fanout.stream", defaultFanoutStream, cfg.Fanout.Stream)
+	if cfg.Fanout.Enabled || cfg.Fanout.MaxLen != defaultFanoutMaxLen || cfg.Fanout.ImpressionStream != "" {
+		t.Errorf("fanout: got %+v, want disabled, clicks only, max_len %d", cfg.Fanout, defaultFanoutMaxLen)
+	}
+	if cfg.Retention.Enabled || cfg.Retention.Interval != defaultRetentionInterval {
+		t.Errorf("retention: got enabled=%v interval=%v, want disabled every %v",
+			cfg.Retention.Enabled, cfg.Retention.Interval, defaultRetentionInterval)
+	}
+
 	assertStringEqual(t, "logging.level", defaultLoggingLevel, cfg.Logging.Level)
 	assertStringEqual(t, "logging.format", defaultLoggingFmt, cfg.Logging.Format)
 }
@@ -76,6 +94,256 @@ func TestValidate_ValidConfig(t *testing.T) {
 	}
 }
 
+func TestValidate_HMACKeys(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name     string
+		keyID    string
+		previous []string
+		wantErr  string
+	}{
+		{name: "unnamed key"},
+		{name: "rotated", keyID: "2026b", previous: []string{"2026a:old-secret"}},
+		{
+			name:     "previous without id",
+			keyID:    "2026b",
+			previous: []string{"old-secret"},
+			wantErr:  "service.previous_hmac_keys: entries must be id:secret",
+		},
+		{
+			name:     "duplicate id",
+			keyID:    "2026a",
+			previous: []string{"2026a:old-secret"},
+			wantErr:  `service.hmac_key_id: key "2026a": duplicate key ID`,
+		},
+		{
+			name:    "invalid id",
+			keyID:   "2026.a",
+			wantErr: `service.hmac_key_id: key ID must be 1-16 of [A-Za-z0-9_-]: "2026.a"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			setDefaults(cfg)
+			cfg.Service.HMACSecret = "test-secret-key"
+			cfg.Service.HMACKeyID = tt.keyID
+			cfg.Service.PreviousHMACKeys = tt.previous
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no validation error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error: got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Storage(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name    string
+		mutate  func(*StorageConfig)
+		wantErr string
+	}{
+		{name: "postgres default", mutate: func(*StorageConfig) {}},
+		{name: "clickhouse", mutate: func(s *StorageConfig) { s.Backend = StorageBackendClickHouse }},
+		{
+			name:    "unknown backend",
+			mutate:  func(s *StorageConfig) { s.Backend = "mysql" },
+			wantErr: `storage.backend: must be "postgres" or "clickhouse"`,
+		},
+		{
+			name: "clickhouse without scheme",
+			mutate: func(s *StorageConfig) {
+				s.Backend = StorageBackendClickHouse
+				s.ClickHouse.URL = "clickhouse:8123"
+			},
+			wantErr: "storage.clickhouse.url: must be an http or https URL",
+		},
+		{
+			name: "clickhouse database with quote",
+			mutate: func(s *StorageConfig) {
+				s.Backend = StorageBackendClickHouse
+				s.ClickHouse.Database = "clicks`; DROP"
+			},
+			wantErr: "storage.clickhouse.database: must contain only letters, digits and underscores",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			setDefaults(cfg)
+			cfg.Service.HMACSecret = "test-secret-key"
+			tt.mutate(&cfg.Storage)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no validation error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error: got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Retention(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name    string
+		mutate  func(*RetentionConfig)
+		wantErr string
+	}{
+		{name: "disabled ignores values", mutate: func(r *RetentionConfig) { r.RawEventDays = -1 }},
+		{name: "enabled defaults", mutate: func(r *RetentionConfig) { r.Enabled = true }},
+		{
+			name:    "negative raw event days",
+			mutate:  func(r *RetentionConfig) { r.Enabled, r.RawEventDays = true, -1 },
+			wantErr: "retention.raw_event_days: must be at least 1",
+		},
+		{
+			name:    "negative anonymize days",
+			mutate:  func(r *RetentionConfig) { r.Enabled, r.AnonymizeAfterDays = true, -1 },
+			wantErr: "retention.anonymize_after_days: must be at least 1",
+		},
+		{
+			name:    "interval too short",
+			mutate:  func(r *RetentionConfig) { r.Enabled, r.Interval = true, time.Second },
+			wantErr: "retention.interval: must be at least 1m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			setDefaults(cfg)
+			cfg.Service.HMACSecret = "test-secret-key"
+			tt.mutate(&cfg.Retention)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no validation error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error: got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Fanout(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name    string
+		mutate  func(*FanoutConfig)
+		wantErr string
+	}{
+		{name: "disabled needs no address", mutate: func(*FanoutConfig) {}},
+		{name: "enabled", mutate: func(f *FanoutConfig) { f.Enabled, f.Address = true, "redis:6379" }},
+		{
+			name:    "enabled without address",
+			mutate:  func(f *FanoutConfig) { f.Enabled = true },
+			wantErr: "fanout.address: is required when fanout is enabled",
+		},
+		{
+			name:    "negative max len",
+			mutate:  func(f *FanoutConfig) { f.Enabled, f.Address, f.MaxLen = true, "redis:6379", -1 },
+			wantErr: "fanout.max_len: must be at least 1",
+		},
+		{
+			name: "impressions on the click stream",
+			mutate: func(f *FanoutConfig) {
+				f.Enabled, f.Address, f.ImpressionStream = true, "redis:6379", defaultFanoutStream
+			},
+			wantErr: "fanout.impression_stream: must differ from fanout.stream",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			setDefaults(cfg)
+			cfg.Service.HMACSecret = "test-secret-key"
+			tt.mutate(&cfg.Fanout)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no validation error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error: got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportConfig_ValidateUpload(t *testing.T) {
+	t.Helper()
+
+	valid := ExportConfig{Endpoint: "minio:9000", AccessKey: "key", SecretKey: "secret", Bucket: "click-exports"}
+	tests := []struct {
+		name    string
+		mutate  func(*ExportConfig)
+		wantErr string
+	}{
+		{name: "complete", mutate: func(*ExportConfig) {}},
+		{
+			name:    "missing endpoint",
+			mutate:  func(e *ExportConfig) { e.Endpoint = "" },
+			wantErr: "export.endpoint: is required to upload",
+		},
+		{
+			name:    "missing secret key",
+			mutate:  func(e *ExportConfig) { e.SecretKey = "" },
+			wantErr: "export.access_key: and secret_key are required to upload",
+		},
+		{
+			name:    "missing bucket",
+			mutate:  func(e *ExportConfig) { e.Bucket = "" },
+			wantErr: "export.bucket: is required to upload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export := valid
+			tt.mutate(&export)
+
+			err := export.ValidateUpload()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no validation error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error: got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestDSN(t *testing.T) {
 	t.Helper()
 
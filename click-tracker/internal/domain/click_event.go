@@ -3,14 +3,23 @@ package domain
 import "time"
 
 // ClickEvent represents a single search result click to be tracked.
+// DestinationHost is the destination's host without "www.", the key clicks
+// are grouped by per source. Channel is the signed distribution channel (e.g.
+// a publisher channel slug); the UTM fields are the campaign parameters of the
+// click or destination URL.
 type ClickEvent struct {
 	QueryID         string    `json:"query_id"`
 	ResultID        string    `json:"result_id"`
 	Position        int       `json:"position"`
 	Page            int       `json:"page"`
 	DestinationHash string    `json:"destination_hash"`
+	DestinationHost string    `json:"destination_host,omitempty"`
 	SessionID       string    `json:"session_id,omitempty"`
 	UserAgentHash   string    `json:"user_agent_hash,omitempty"`
+	Channel         string    `json:"channel,omitempty"`
+	UTMSource       string    `json:"utm_source,omitempty"`
+	UTMMedium       string    `json:"utm_medium,omitempty"`
+	UTMCampaign     string    `json:"utm_campaign,omitempty"`
 	GeneratedAt     time.Time `json:"generated_at"`
 	ClickedAt       time.Time `json:"clicked_at"`
 }
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ImpressionEvent represents a search result or article shown to a reader in
+// a results page, feed or email, recorded by the impression pixel. With the
+// clicks on the same result it gives a click-through rate. It carries the
+// fields of the signed link it was shown with; there is no destination hash
+// or user agent, as impressions are only counted.
+type ImpressionEvent struct {
+	QueryID         string    `json:"query_id"`
+	ResultID        string    `json:"result_id"`
+	Position        int       `json:"position"`
+	Page            int       `json:"page"`
+	DestinationHost string    `json:"destination_host,omitempty"`
+	SessionID       string    `json:"session_id,omitempty"`
+	Channel         string    `json:"channel,omitempty"`
+	GeneratedAt     time.Time `json:"generated_at"`
+	ShownAt         time.Time `json:"shown_at"`
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// CampaignStatsReader reads clicks per channel and campaign.
+type CampaignStatsReader interface {
+	CampaignResults(
+		ctx context.Context, channel string, since, until time.Time, limit int,
+	) ([]storage.CampaignClicks, error)
+}
+
+// channelWindow reads the channel, days and limit query parameters shared
+// by the per-channel stats, responding with 400 if one is invalid.
+func channelWindow(c *gin.Context) (channel string, since, until time.Time, limit int, ok bool) {
+	channel = c.Query("channel")
+	if channel != "" && !channelPattern.MatchString(channel) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel"})
+		return "", since, until, 0, false
+	}
+	days, ok := queryInt(c, "days", defaultStatsDays, 1, maxStatsDays)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 93"})
+		return "", since, until, 0, false
+	}
+	limit, ok = queryInt(c, "limit", defaultStatsLimit, 1, maxStatsLimit)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return "", since, until, 0, false
+	}
+
+	until = time.Now().UTC()
+	return channel, until.AddDate(0, 0, -days), until, limit, true
+}
+
+// CampaignResults returns clicks per channel and UTM campaign over the last
+// days (default 30, at most 93), most clicks first, optionally for one channel.
+// GET /api/v1/stats/campaigns?days=30&limit=10&channel=northern-news
+func (h *StatsHandler) CampaignResults(c *gin.Context) {
+	channel, since, until, limit, ok := channelWindow(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.reader.CampaignResults(c.Request.Context(), channel, since, until, limit)
+	if err != nil {
+		h.logger.Error("Failed to read campaign click stats", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read click stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"count":   len(results),
+		"since":   since,
+		"until":   until,
+	})
+}
@@ -0,0 +1,60 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+func getCampaignStats(t *testing.T, reader handler.ClickStatsReader, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/stats/campaigns", handler.NewStatsHandler(reader, infralogger.NewNop()).CampaignResults)
+
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStatsHandler_CampaignResults(t *testing.T) {
+	reader := &fakeStatsReader{}
+	w := getCampaignStats(t, reader, "/api/v1/stats/campaigns?channel=northern-news&limit=5")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if reader.gotChannel != "northern-news" || reader.gotLimit != 5 {
+		t.Errorf("unexpected channel %q or limit %d", reader.gotChannel, reader.gotLimit)
+	}
+
+	var resp struct {
+		Results []storage.CampaignClicks `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].UTMSource != "newsletter" {
+		t.Errorf("unexpected results %+v", resp.Results)
+	}
+}
+
+func TestStatsHandler_CampaignResults_Invalid(t *testing.T) {
+	for _, target := range []string{
+		"/api/v1/stats/campaigns?channel=bad%20channel",
+		"/api/v1/stats/campaigns?days=94",
+		"/api/v1/stats/campaigns?limit=101",
+	} {
+		if w := getCampaignStats(t, &fakeStatsReader{}, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, w.Code)
+		}
+	}
+}
@@ -1,52 +1,87 @@
 package handler
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
 	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/sse"
 )
 
 // errMissingParams is returned when required query parameters are absent or unparseable.
 var errMissingParams = errors.New("missing required parameters (q, r, p, t, u, sig)")
 
+// errInvalidSession is returned when the s parameter is not a valid session ID.
+var errInvalidSession = errors.New("invalid session parameter (s)")
+
+// errInvalidChannel is returned when the ch parameter is not a valid channel.
+var errInvalidChannel = errors.New("invalid channel parameter (ch)")
+
+// sessionIDPattern matches the session IDs search attaches to click URLs;
+// the length bound matches click_events.session_id.
+var sessionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// channelPattern matches channel slugs; the length bound matches click_events.channel.
+var channelPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
 // uaHashLength is the number of hex characters used for the truncated user-agent hash.
 const uaHashLength = 12
 
+// maxHostLength matches click_events.destination_host.
+const maxHostLength = 255
+
+// maxUTMLength is the most runes kept of a UTM parameter, matching the utm_* columns.
+const maxUTMLength = 128
+
 // defaultPage is the page number used when the pg parameter is absent or invalid.
 const defaultPage = 1
 
 // ClickHandler handles click redirect requests.
 type ClickHandler struct {
-	signer *clickurl.Signer
-	buffer *storage.Buffer
-	logger infralogger.Logger
-	maxAge time.Duration
+	verifier SignatureVerifier
+	keyUsage *keyUsageTracker
+	buffer   *storage.Buffer
+	logger   infralogger.Logger
+	maxAge   time.Duration
+	stream   sse.Publisher
 }
 
 // NewClickHandler creates a ClickHandler with the given dependencies.
 func NewClickHandler(
-	signer *clickurl.Signer,
+	verifier SignatureVerifier,
 	buffer *storage.Buffer,
 	log infralogger.Logger,
 	maxAge time.Duration,
 ) *ClickHandler {
 	return &ClickHandler{
-		signer: signer,
-		buffer: buffer,
-		logger: log,
-		maxAge: maxAge,
+		verifier: verifier,
+		keyUsage: newKeyUsageTracker(verifier.KeyIDs()),
+		buffer:   buffer,
+		logger:   log,
+		maxAge:   maxAge,
 	}
 }
 
+// WithStream publishes each recorded click to stream, for the live click
+// stream. Returns h for chaining.
+func (h *ClickHandler) WithStream(stream sse.Publisher) *ClickHandler {
+	h.stream = stream
+	return h
+}
+
 // HandleClick validates the signature, logs the event, and redirects.
 func (h *ClickHandler) HandleClick(c *gin.Context) {
 	params, err := parseClickParams(c)
@@ -68,30 +103,47 @@ func (h *ClickHandler) HandleClick(c *gin.Context) {
 	// Skip event storage for bots — still redirect so crawlers follow links
 	isBot, _ := c.Get("is_bot")
 	if isBot != true {
-		h.enqueueEvent(params, generated, c.Request.UserAgent())
+		utm := campaignParams(c.Request.URL.Query(), params.DestinationURL)
+		h.enqueueEvent(c.Request.Context(), params, generated, c.Request.UserAgent(), utm)
 	}
 
 	c.Redirect(http.StatusFound, params.DestinationURL)
 }
 
-// verifySignature checks the HMAC signature and responds with 403 if invalid.
+// verifySignature checks the HMAC signature against the verification keys,
+// counts the key that matched, and responds with 403 if none did.
 func (h *ClickHandler) verifySignature(c *gin.Context, params clickurl.ClickParams) bool {
-	msg := params.Message()
-	if !h.signer.Verify(msg, c.Query("sig")) {
+	keyID, ok := h.verifier.VerifyKey(params.Message(), c.Query("sig"))
+	if !ok {
 		c.JSON(http.StatusForbidden, gin.H{"error": "invalid signature"})
 		return false
 	}
+	h.keyUsage.record(keyID, time.Now())
 	return true
 }
 
-// enqueueEvent builds a ClickEvent and sends it to the buffer.
-func (h *ClickHandler) enqueueEvent(params clickurl.ClickParams, generated time.Time, userAgent string) {
+// utmParams are the campaign parameters recorded with each click.
+type utmParams struct {
+	source, medium, campaign string
+}
+
+// enqueueEvent builds a ClickEvent, sends it to the buffer and, once
+// buffered, to the click stream.
+func (h *ClickHandler) enqueueEvent(
+	ctx context.Context, params clickurl.ClickParams, generated time.Time, userAgent string, utm utmParams,
+) {
 	event := domain.ClickEvent{
 		QueryID:         params.QueryID,
 		ResultID:        params.ResultID,
 		Position:        params.Position,
 		Page:            params.Page,
 		DestinationHash: hashURL(params.DestinationURL),
+		DestinationHost: destinationHost(params.DestinationURL),
+		SessionID:       params.SessionID,
+		Channel:         params.Channel,
+		UTMSource:       utm.source,
+		UTMMedium:       utm.medium,
+		UTMCampaign:     utm.campaign,
 		UserAgentHash:   hashUA(userAgent),
 		GeneratedAt:     generated,
 		ClickedAt:       time.Now(),
@@ -100,7 +152,9 @@ func (h *ClickHandler) enqueueEvent(params clickurl.ClickParams, generated time.
 		h.logger.Warn("Click event buffer full, dropping event",
 			infralogger.String("query_id", params.QueryID),
 		)
+		return
 	}
+	h.publishClick(ctx, &event)
 }
 
 func parseClickParams(c *gin.Context) (clickurl.ClickParams, error) {
@@ -110,6 +164,8 @@ func parseClickParams(c *gin.Context) (clickurl.ClickParams, error) {
 	pgStr := c.Query("pg")
 	tStr := c.Query("t")
 	u := c.Query("u")
+	s := c.Query("s")
+	ch := c.Query("ch")
 
 	if q == "" || r == "" || pStr == "" || tStr == "" || u == "" {
 		return clickurl.ClickParams{}, errMissingParams
@@ -133,6 +189,14 @@ func parseClickParams(c *gin.Context) (clickurl.ClickParams, error) {
 		return clickurl.ClickParams{}, errMissingParams
 	}
 
+	if s != "" && !sessionIDPattern.MatchString(s) {
+		return clickurl.ClickParams{}, errInvalidSession
+	}
+
+	if ch != "" && !channelPattern.MatchString(ch) {
+		return clickurl.ClickParams{}, errInvalidChannel
+	}
+
 	return clickurl.ClickParams{
 		QueryID:        q,
 		ResultID:       r,
@@ -140,14 +204,61 @@ func parseClickParams(c *gin.Context) (clickurl.ClickParams, error) {
 		Page:           pg,
 		Timestamp:      t,
 		DestinationURL: u,
+		SessionID:      s,
+		Channel:        ch,
 	}, nil
 }
 
+// campaignParams reads utm_source, utm_medium and utm_campaign from the
+// click URL. They are not signed, so campaigns can tag links they share; when
+// the click URL has none, those of the destination URL are used.
+func campaignParams(query url.Values, destinationURL string) utmParams {
+	if query.Get("utm_source") == "" && query.Get("utm_medium") == "" && query.Get("utm_campaign") == "" {
+		if dest, err := url.Parse(destinationURL); err == nil {
+			query = dest.Query()
+		}
+	}
+	return utmParams{
+		source:   utmValue(query.Get("utm_source")),
+		medium:   utmValue(query.Get("utm_medium")),
+		campaign: utmValue(query.Get("utm_campaign")),
+	}
+}
+
+// utmValue trims a UTM parameter, drops control characters and caps its length.
+func utmValue(raw string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+	if runes := []rune(cleaned); len(runes) > maxUTMLength {
+		cleaned = string(runes[:maxUTMLength])
+	}
+	return cleaned
+}
+
 func hashURL(rawURL string) string {
 	h := sha256.Sum256([]byte(rawURL))
 	return hex.EncodeToString(h[:])
 }
 
+// destinationHost returns the lowercased host of rawURL without a leading
+// "www.", so clicks on one publisher's pages group together. It returns ""
+// for URLs without a host.
+func destinationHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if len(host) > maxHostLength {
+		return ""
+	}
+	return host
+}
+
 func hashUA(ua string) string {
 	if ua == "" {
 		return ""
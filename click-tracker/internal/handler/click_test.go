@@ -175,3 +175,122 @@ func TestHandleClick_MissingParams(t *testing.T) {
 		t.Fatalf("expected 400 for missing params, got %d", w.Code)
 	}
 }
+
+func TestHandleClick_WithSession(t *testing.T) {
+	r, buf := setupRouter(t)
+	defer buf.Close()
+
+	signer := clickurl.NewSigner(testSecret)
+	dest := "https://example.com/article"
+	target := signer.URL("", clickurl.ClickParams{
+		QueryID:        "q_abc",
+		ResultID:       "r_doc",
+		Position:       1,
+		Page:           1,
+		Timestamp:      time.Now().Unix(),
+		DestinationURL: dest,
+		SessionID:      "sess_123",
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected 302, got %d: %s", w.Code, w.Body.String())
+	}
+	if buf.Len() != 1 {
+		t.Fatalf("expected 1 buffered event, got %d", buf.Len())
+	}
+}
+
+func TestHandleClick_SessionIsSigned(t *testing.T) {
+	r, buf := setupRouter(t)
+	defer buf.Close()
+
+	// A session appended to a URL signed without one must not verify
+	target := signedURL(t, "q_abc", "r_doc", 3, 1, time.Now().Unix(), "https://example.com") + "&s=other"
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for unsigned session, got %d", w.Code)
+	}
+}
+
+func TestHandleClick_InvalidSession(t *testing.T) {
+	r, buf := setupRouter(t)
+	defer buf.Close()
+
+	target := signedURL(t, "q_abc", "r_doc", 3, 1, time.Now().Unix(), "https://example.com") +
+		"&s=" + url.QueryEscape("not a session")
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid session, got %d", w.Code)
+	}
+}
+
+func TestHandleClick_WithChannel(t *testing.T) {
+	r, buf := setupRouter(t)
+	defer buf.Close()
+
+	signer := clickurl.NewSigner(testSecret)
+	target := signer.URL("", clickurl.ClickParams{
+		QueryID:        "ch_0123456789abcdef",
+		ResultID:       "r_doc",
+		Position:       1,
+		Page:           1,
+		Timestamp:      time.Now().Unix(),
+		DestinationURL: "https://example.com/article?utm_source=newsletter",
+		Channel:        "northern-news",
+	}) + "&utm_campaign=weekly"
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected 302, got %d: %s", w.Code, w.Body.String())
+	}
+	if buf.Len() != 1 {
+		t.Fatalf("expected 1 buffered event, got %d", buf.Len())
+	}
+}
+
+func TestHandleClick_ChannelIsSigned(t *testing.T) {
+	r, buf := setupRouter(t)
+	defer buf.Close()
+
+	// A channel appended to a URL signed without one must not verify
+	target := signedURL(t, "q_abc", "r_doc", 3, 1, time.Now().Unix(), "https://example.com") + "&ch=other"
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for unsigned channel, got %d", w.Code)
+	}
+}
+
+func TestHandleClick_InvalidChannel(t *testing.T) {
+	r, buf := setupRouter(t)
+	defer buf.Close()
+
+	target := signedURL(t, "q_abc", "r_doc", 3, 1, time.Now().Unix(), "https://example.com") +
+		"&ch=" + url.QueryEscape("news/north")
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid channel, got %d", w.Code)
+	}
+}
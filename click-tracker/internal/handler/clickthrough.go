@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// Click-through export limits.
+const (
+	defaultClickThroughLimit     = 1000
+	maxClickThroughLimit         = 10000
+	defaultClickThroughMinClicks = 3
+	// clickThroughPrecision rounds positions and weighted clicks to hundredths
+	clickThroughPrecision = 100
+	// boostPrecision rounds boosts to thousandths
+	boostPrecision = 1000
+)
+
+// ClickThroughModel names how ClickThrough scores are computed, so consumers
+// can tell when the model changes.
+const ClickThroughModel = "position_debiased_v1"
+
+// ClickTotalsReader reads click totals per article and per source.
+type ClickTotalsReader interface {
+	ArticleTotals(ctx context.Context, since, until time.Time, minClicks, limit int) ([]storage.ClickTotals, error)
+	SourceTotals(ctx context.Context, since, until time.Time, minClicks, limit int) ([]storage.ClickTotals, error)
+}
+
+// ClickThrough is the click-through feedback for one article or source.
+// Impressions are not recorded, so WeightedClicks corrects clicks for
+// position bias instead of dividing by views: a click at position p counts
+// sqrt(p) times, as lower results are examined less often. Boost scales
+// WeightedClicks logarithmically to (0, 1] against the top entry.
+type ClickThrough struct {
+	ID             string  `json:"id"`
+	Clicks         int64   `json:"clicks"`
+	UniqueSessions int64   `json:"unique_sessions"`
+	AvgPosition    float64 `json:"avg_position"`
+	WeightedClicks float64 `json:"weighted_clicks"`
+	Boost          float64 `json:"boost"`
+}
+
+// ClickThroughExport returns per-article and per-source click-through
+// feedback over the last days (default 30, at most 93) for search ranking.
+// Articles are keyed by result ID and sources by destination host.
+// GET /api/v1/stats/clickthrough?days=30&limit=1000&min_clicks=3
+func (h *StatsHandler) ClickThroughExport(c *gin.Context) {
+	days, ok := queryInt(c, "days", defaultStatsDays, 1, maxStatsDays)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 93"})
+		return
+	}
+	limit, ok := queryInt(c, "limit", defaultClickThroughLimit, 1, maxClickThroughLimit)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 10000"})
+		return
+	}
+	minClicks, ok := queryInt(c, "min_clicks", defaultClickThroughMinClicks, 1, math.MaxInt32)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_clicks must be a positive integer"})
+		return
+	}
+
+	until := time.Now().UTC()
+	since := until.AddDate(0, 0, -days)
+	ctx := c.Request.Context()
+
+	articles, err := h.reader.ArticleTotals(ctx, since, until, minClicks, limit)
+	if err != nil {
+		h.logger.Error("Failed to read article click totals", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read click stats"})
+		return
+	}
+	sources, err := h.reader.SourceTotals(ctx, since, until, minClicks, limit)
+	if err != nil {
+		h.logger.Error("Failed to read source click totals", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read click stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"model":        ClickThroughModel,
+		"generated_at": until,
+		"since":        since,
+		"until":        until,
+		"articles":     scoreClickThrough(articles),
+		"sources":      scoreClickThrough(sources),
+	})
+}
+
+// scoreClickThrough weights totals for position bias and scales them to
+// boosts, highest boost first.
+func scoreClickThrough(totals []storage.ClickTotals) []ClickThrough {
+	scored := make([]ClickThrough, 0, len(totals))
+	maxWeighted := 0.0
+	for _, t := range totals {
+		if t.Clicks <= 0 {
+			continue
+		}
+		avgPosition := math.Max(float64(t.PositionSum)/float64(t.Clicks), 1)
+		weighted := float64(t.Clicks) * math.Sqrt(avgPosition)
+		maxWeighted = math.Max(maxWeighted, weighted)
+		scored = append(scored, ClickThrough{
+			ID:             t.ID,
+			Clicks:         t.Clicks,
+			UniqueSessions: t.UniqueSessions,
+			AvgPosition:    avgPosition,
+			WeightedClicks: weighted,
+		})
+	}
+
+	for i := range scored {
+		scored[i].Boost = math.Round(math.Log1p(scored[i].WeightedClicks)/math.Log1p(maxWeighted)*boostPrecision) / boostPrecision
+		scored[i].AvgPosition = math.Round(scored[i].AvgPosition*clickThroughPrecision) / clickThroughPrecision
+		scored[i].WeightedClicks = math.Round(scored[i].WeightedClicks*clickThroughPrecision) / clickThroughPrecision
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].WeightedClicks != scored[j].WeightedClicks {
+			return scored[i].WeightedClicks > scored[j].WeightedClicks
+		}
+		return scored[i].ID < scored[j].ID
+	})
+	return scored
+}
+
+// queryInt reads an optional integer query parameter within [lo, hi].
+func queryInt(c *gin.Context, name string, def, lo, hi int) (int, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return def, true
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil || parsed < lo || parsed > hi {
+		return 0, false
+	}
+	return parsed, true
+}
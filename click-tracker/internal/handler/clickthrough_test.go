@@ -0,0 +1,90 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+func getClickThrough(t *testing.T, reader handler.ClickStatsReader, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/stats/clickthrough", handler.NewStatsHandler(reader, infralogger.NewNop()).ClickThroughExport)
+
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStatsHandler_ClickThroughExport(t *testing.T) {
+	reader := &fakeStatsReader{
+		articles: []storage.ClickTotals{
+			{ID: "doc-1", Clicks: 10, UniqueSessions: 8, PositionSum: 10},
+			{ID: "doc-2", Clicks: 4, UniqueSessions: 4, PositionSum: 36},
+		},
+		sources: []storage.ClickTotals{{ID: "example.com", Clicks: 14, UniqueSessions: 12, PositionSum: 46}},
+	}
+	w := getClickThrough(t, reader, "/api/v1/stats/clickthrough?days=7&min_clicks=2")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if reader.gotMinClicks != 2 || reader.gotLimit != 1000 {
+		t.Errorf("unexpected min_clicks %d or limit %d", reader.gotMinClicks, reader.gotLimit)
+	}
+	if age := time.Since(reader.gotSince); age < 7*24*time.Hour-time.Minute || age > 7*24*time.Hour+time.Minute {
+		t.Errorf("expected since 7 days ago, got %s", reader.gotSince)
+	}
+
+	var resp struct {
+		Model    string                 `json:"model"`
+		Articles []handler.ClickThrough `json:"articles"`
+		Sources  []handler.ClickThrough `json:"sources"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Model != handler.ClickThroughModel {
+		t.Errorf("model = %q", resp.Model)
+	}
+
+	// doc-2's four clicks at position 9 outweigh doc-1's ten at position 1
+	want := []handler.ClickThrough{
+		{ID: "doc-2", Clicks: 4, UniqueSessions: 4, AvgPosition: 9, WeightedClicks: 12, Boost: 1},
+		{ID: "doc-1", Clicks: 10, UniqueSessions: 8, AvgPosition: 1, WeightedClicks: 10, Boost: 0.935},
+	}
+	if len(resp.Articles) != len(want) {
+		t.Fatalf("got %d articles, want %d: %+v", len(resp.Articles), len(want), resp.Articles)
+	}
+	for i := range want {
+		if resp.Articles[i] != want[i] {
+			t.Errorf("article %d = %+v, want %+v", i, resp.Articles[i], want[i])
+		}
+	}
+	if len(resp.Sources) != 1 || resp.Sources[0].ID != "example.com" || resp.Sources[0].Boost != 1 {
+		t.Errorf("unexpected sources %+v", resp.Sources)
+	}
+}
+
+func TestStatsHandler_ClickThroughExport_Invalid(t *testing.T) {
+	for _, target := range []string{
+		"/api/v1/stats/clickthrough?days=0",
+		"/api/v1/stats/clickthrough?days=94",
+		"/api/v1/stats/clickthrough?limit=10001",
+		"/api/v1/stats/clickthrough?min_clicks=0",
+	} {
+		if w := getClickThrough(t, &fakeStatsReader{}, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, w.Code)
+		}
+	}
+}
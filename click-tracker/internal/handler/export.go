@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// maxExportRange bounds one HTTP export; longer ranges go through cmd/export.
+const maxExportRange = 366 * 24 * time.Hour
+
+// exportContentTypes are the response content types per export format.
+var exportContentTypes = map[string]string{
+	storage.ExportFormatCSV:     "text/csv; charset=utf-8",
+	storage.ExportFormatParquet: "application/vnd.apache.parquet",
+}
+
+// EventExporter streams raw click events for offline analysis.
+type EventExporter interface {
+	SupportsExportFormat(format string) bool
+	ExportEvents(ctx context.Context, since, until time.Time, format string, w io.Writer) error
+}
+
+// ExportEvents streams the raw events clicked in [since, until) as CSV or,
+// with the ClickHouse backend, Parquet. since is required; until defaults to
+// now. Both take RFC 3339 times or YYYY-MM-DD dates (midnight UTC).
+// GET /api/v1/export/events?since=2026-03-01&until=2026-04-01&format=csv
+func (h *StatsHandler) ExportEvents(c *gin.Context) {
+	since, err := storage.ParseExportTime(c.Query("since"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 time or YYYY-MM-DD date"})
+		return
+	}
+	until := time.Now().UTC()
+	if raw := c.Query("until"); raw != "" {
+		if until, err = storage.ParseExportTime(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be an RFC 3339 time or YYYY-MM-DD date"})
+			return
+		}
+	}
+	if !until.After(since) || until.Sub(since) > maxExportRange {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "until must be after since and within 366 days"})
+		return
+	}
+
+	format := c.DefaultQuery("format", storage.ExportFormatCSV)
+	contentType, known := exportContentTypes[format]
+	if !known {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or parquet"})
+		return
+	}
+	// Parquet is encoded by ClickHouse; the PostgreSQL backend exports CSV only
+	if !h.reader.SupportsExportFormat(format) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": format + " export needs the ClickHouse storage backend; use format=csv",
+		})
+		return
+	}
+
+	// Large exports outlast the server's write timeout
+	if deadlineErr := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); deadlineErr != nil {
+		h.logger.Debug("Could not lift write deadline for export", infralogger.Error(deadlineErr))
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, storage.ExportFileName(since, until, format)))
+	c.Status(http.StatusOK)
+
+	if exportErr := h.reader.ExportEvents(c.Request.Context(), since, until, format, c.Writer); exportErr != nil {
+		if errors.Is(exportErr, context.Canceled) {
+			return
+		}
+		// The status is sent with the first bytes, so a failure can only cut the body short
+		h.logger.Error("Click event export failed",
+			infralogger.Error(exportErr),
+			infralogger.String("format", format),
+			infralogger.Int("bytes_written", c.Writer.Size()),
+		)
+	}
+}
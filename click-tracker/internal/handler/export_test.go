@@ -0,0 +1,82 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+func getExport(t *testing.T, reader handler.ClickStatsReader, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/export/events", handler.NewStatsHandler(reader, infralogger.NewNop()).ExportEvents)
+
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStatsHandler_ExportEvents(t *testing.T) {
+	reader := &fakeStatsReader{}
+	w := getExport(t, reader, "/api/v1/export/events?since=2026-03-01&until=2026-03-02T12:00:00%2B02:00")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Errorf("unexpected content type %q", got)
+	}
+	wantFile := `attachment; filename="clicks_20260301T000000Z_20260302T100000Z.csv"`
+	if got := w.Header().Get("Content-Disposition"); got != wantFile {
+		t.Errorf("content disposition: got %q, want %q", got, wantFile)
+	}
+	if !strings.HasPrefix(w.Body.String(), "clicked_at,result_id\n") {
+		t.Errorf("unexpected body %q", w.Body.String())
+	}
+
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !reader.gotSince.Equal(want) {
+		t.Errorf("since: got %v, want %v", reader.gotSince, want)
+	}
+	if want := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC); !reader.gotUntil.Equal(want) {
+		t.Errorf("until: got %v, want %v", reader.gotUntil, want)
+	}
+}
+
+func TestStatsHandler_ExportEvents_Invalid(t *testing.T) {
+	for _, target := range []string{
+		"/api/v1/export/events",
+		"/api/v1/export/events?since=yesterday",
+		"/api/v1/export/events?since=2026-03-02&until=2026-03-01",
+		"/api/v1/export/events?since=2024-01-01&until=2026-01-01",
+		"/api/v1/export/events?since=2026-03-01&until=2026-03-02&format=xlsx",
+	} {
+		if w := getExport(t, &fakeStatsReader{}, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, w.Code)
+		}
+	}
+}
+
+func TestStatsHandler_ExportEvents_ParquetNeedsClickHouse(t *testing.T) {
+	// The fake reader, like PostgreSQL, exports CSV only
+	reader := &fakeStatsReader{}
+	w := getExport(t, reader, "/api/v1/export/events?since=2026-03-01&until=2026-03-02&format=parquet")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "ClickHouse") {
+		t.Errorf("expected the error to name the backend Parquet needs, got %s", w.Body.String())
+	}
+	if !reader.gotSince.IsZero() {
+		t.Error("the export must be refused before the storage backend is read")
+	}
+}
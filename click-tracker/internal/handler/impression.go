@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// transparentGIF is the 1x1 transparent GIF served as the impression pixel.
+var transparentGIF = []byte(
+	"GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\xff\xff\xff" +
+		"!\xf9\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;",
+)
+
+// HandleImpression records that a link was shown and serves a transparent
+// pixel. It takes the same signed parameters as /click, so a signer's
+// ImpressionURL and URL for one result record a matching impression and
+// click. Impressions older than the maximum link age are not recorded, as
+// their clicks would be refused; the pixel is still served.
+// GET /impression
+func (h *ClickHandler) HandleImpression(c *gin.Context) {
+	params, err := parseClickParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.verifySignature(c, params) {
+		return
+	}
+
+	generated := time.Unix(params.Timestamp, 0)
+	isBot, _ := c.Get("is_bot")
+	if isBot != true && time.Since(generated) <= h.maxAge {
+		h.enqueueImpression(params, generated)
+	}
+
+	c.Header("Cache-Control", "no-store, max-age=0")
+	c.Data(http.StatusOK, "image/gif", transparentGIF)
+}
+
+// enqueueImpression builds an ImpressionEvent and sends it to the buffer.
+func (h *ClickHandler) enqueueImpression(params clickurl.ClickParams, generated time.Time) {
+	impression := domain.ImpressionEvent{
+		QueryID:         params.QueryID,
+		ResultID:        params.ResultID,
+		Position:        params.Position,
+		Page:            params.Page,
+		DestinationHost: destinationHost(params.DestinationURL),
+		SessionID:       params.SessionID,
+		Channel:         params.Channel,
+		GeneratedAt:     generated,
+		ShownAt:         time.Now(),
+	}
+	if !h.buffer.SendImpression(impression) {
+		h.logger.Warn("Impression buffer full, dropping impression",
+			infralogger.String("query_id", params.QueryID),
+		)
+	}
+}
@@ -0,0 +1,134 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/middleware"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// browserUA passes the bot filter, which treats an empty user agent as a bot.
+const browserUA = "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0"
+
+func setupImpressionRouter(t *testing.T) (*gin.Engine, *storage.Buffer) {
+	t.Helper()
+
+	buf := storage.NewBuffer(testBufferCapacity)
+	t.Cleanup(buf.Close)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.BotFilter())
+	h := handler.NewClickHandler(clickurl.NewSigner(testSecret), buf, infralogger.NewNop(), maxAgeHours*time.Hour)
+	r.GET("/impression", h.HandleImpression)
+	return r, buf
+}
+
+// impressionURL is the impression pixel URL of a signed click URL.
+func impressionURL(t *testing.T, generated time.Time) string {
+	t.Helper()
+
+	return strings.Replace(signedURL(t, "q_abc", "r_doc", 3, 1, generated.Unix(), "https://example.com/article"),
+		"/click?", "/impression?", 1)
+}
+
+func TestHandleImpression_RecordsAndServesPixel(t *testing.T) {
+	r, buf := setupImpressionRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, impressionURL(t, time.Now()), http.NoBody)
+	req.Header.Set("User-Agent", browserUA)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "image/gif" {
+		t.Errorf("expected image/gif, got %q", ct)
+	}
+	if !bytes.HasPrefix(w.Body.Bytes(), []byte("GIF89a")) {
+		t.Errorf("expected a GIF body, got %q", w.Body.String())
+	}
+	if w.Header().Get("Cache-Control") == "" {
+		t.Error("expected the pixel not to be cached")
+	}
+	if buf.ImpressionLen() != 1 || buf.Len() != 0 {
+		t.Errorf("expected 1 impression and no clicks, got %d and %d", buf.ImpressionLen(), buf.Len())
+	}
+}
+
+func TestHandleImpression_NotRecorded(t *testing.T) {
+	r, buf := setupImpressionRouter(t)
+
+	expired := httptest.NewRequest(http.MethodGet,
+		impressionURL(t, time.Now().Add(-expiredHoursOffset*time.Hour)), http.NoBody)
+	expired.Header.Set("User-Agent", browserUA)
+	bot := httptest.NewRequest(http.MethodGet, impressionURL(t, time.Now()), http.NoBody)
+	bot.Header.Set("User-Agent", "Googlebot/2.1 (+http://www.google.com/bot.html)")
+
+	for name, req := range map[string]*http.Request{"expired": expired, "bot": bot} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected the pixel, got %d", name, w.Code)
+		}
+	}
+	if buf.ImpressionLen() != 0 {
+		t.Errorf("expected no impressions recorded, got %d", buf.ImpressionLen())
+	}
+}
+
+func TestHandleImpression_InvalidSignature(t *testing.T) {
+	r, buf := setupImpressionRouter(t)
+
+	target := impressionURL(t, time.Now())
+	target = target[:strings.LastIndex(target, "sig=")] + "sig=000000000000"
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+	if buf.ImpressionLen() != 0 {
+		t.Errorf("expected no impressions recorded, got %d", buf.ImpressionLen())
+	}
+}
+
+func TestStatsHandler_ImpressionResults(t *testing.T) {
+	reader := &fakeStatsReader{}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/stats/impressions", handler.NewStatsHandler(reader, infralogger.NewNop()).ImpressionResults)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/stats/impressions?channel=northern-news&days=7", http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if reader.gotChannel != "northern-news" || time.Since(reader.gotSince) < 7*24*time.Hour {
+		t.Errorf("unexpected channel %q or since %v", reader.gotChannel, reader.gotSince)
+	}
+
+	var resp struct {
+		Count       int     `json:"count"`
+		Impressions int64   `json:"impressions"`
+		Clicks      int64   `json:"clicks"`
+		CTR         float64 `json:"ctr"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Count != 2 || resp.Impressions != 40 || resp.Clicks != 4 || resp.CTR != 0.1 {
+		t.Errorf("unexpected totals %+v", resp)
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// ImpressionStatsReader reads impressions and click-through rates per result.
+type ImpressionStatsReader interface {
+	ImpressionResults(
+		ctx context.Context, channel string, since, until time.Time, limit int,
+	) ([]storage.ResultImpressions, error)
+}
+
+// ImpressionResults returns the most-shown results over the last days
+// (default 30, at most 93) with their clicks and click-through rate, most
+// impressions first, optionally for one channel. Totals cover the listed
+// results only.
+// GET /api/v1/stats/impressions?days=30&limit=10&channel=northern-news
+func (h *StatsHandler) ImpressionResults(c *gin.Context) {
+	channel, since, until, limit, ok := channelWindow(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.reader.ImpressionResults(c.Request.Context(), channel, since, until, limit)
+	if err != nil {
+		h.logger.Error("Failed to read impression stats", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read impression stats"})
+		return
+	}
+
+	var impressions, clicks int64
+	for i := range results {
+		impressions += results[i].Impressions
+		clicks += results[i].Clicks
+	}
+	ctr := 0.0
+	if impressions > 0 {
+		ctr = float64(clicks) / float64(impressions)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":     results,
+		"count":       len(results),
+		"impressions": impressions,
+		"clicks":      clicks,
+		"ctr":         ctr,
+		"since":       since,
+		"until":       until,
+	})
+}
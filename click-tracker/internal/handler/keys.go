@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SignatureVerifier verifies click signatures and names the key that made
+// each one. *clickurl.KeyRing and *clickurl.Signer implement it.
+type SignatureVerifier interface {
+	VerifyKey(message, signature string) (keyID string, ok bool)
+	KeyIDs() []string
+}
+
+// KeyUsage is how often one verification key has matched a click signature
+// since this instance started.
+type KeyUsage struct {
+	KeyID          string     `json:"key_id"`
+	Verified       int64      `json:"verified"`
+	LastVerifiedAt *time.Time `json:"last_verified_at,omitempty"`
+}
+
+// keyUsageTracker counts verified signatures per key.
+type keyUsageTracker struct {
+	mu      sync.Mutex
+	started time.Time
+	usage   map[string]*KeyUsage
+}
+
+// newKeyUsageTracker tracks the given key IDs, starting from zero.
+func newKeyUsageTracker(keyIDs []string) *keyUsageTracker {
+	t := &keyUsageTracker{started: time.Now().UTC(), usage: make(map[string]*KeyUsage, len(keyIDs))}
+	for _, id := range keyIDs {
+		t.usage[id] = &KeyUsage{KeyID: id}
+	}
+	return t
+}
+
+// record counts one signature verified with keyID.
+func (t *keyUsageTracker) record(keyID string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage, ok := t.usage[keyID]
+	if !ok {
+		return
+	}
+	at = at.UTC()
+	usage.Verified++
+	usage.LastVerifiedAt = &at
+}
+
+// snapshot returns a copy of the usage of keyIDs, in that order.
+func (t *keyUsageTracker) snapshot(keyIDs []string) []KeyUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage := make([]KeyUsage, 0, len(keyIDs))
+	for _, id := range keyIDs {
+		if u, ok := t.usage[id]; ok {
+			usage = append(usage, *u)
+		}
+	}
+	return usage
+}
+
+// KeyStatus reports, per verification key, how many clicks it has verified
+// and when it last did, so an operator rotating keys can tell when links
+// signed with a previous key have stopped arriving. The current key is
+// listed first. Counts are per instance and reset on restart.
+// GET /api/v1/keys
+func (h *ClickHandler) KeyStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"keys":           h.keyUsage.snapshot(h.verifier.KeyIDs()),
+		"max_link_age":   h.maxAge.String(),
+		"counting_since": h.keyUsage.started,
+	})
+}
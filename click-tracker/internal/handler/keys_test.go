@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+var (
+	currentKey  = clickurl.Key{ID: "2026b", Secret: "current-secret"}
+	previousKey = clickurl.Key{ID: "2026a", Secret: testSecret}
+)
+
+func setupRotatedRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	ring, err := clickurl.NewKeyRing(currentKey, previousKey)
+	if err != nil {
+		t.Fatalf("create key ring: %v", err)
+	}
+	buf := storage.NewBuffer(testBufferCapacity)
+	t.Cleanup(buf.Close)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := handler.NewClickHandler(ring, buf, infralogger.NewNop(), maxAgeHours*time.Hour)
+	r.GET("/click", h.HandleClick)
+	r.GET("/api/v1/keys", h.KeyStatus)
+	return r
+}
+
+func keyedURL(t *testing.T, key clickurl.Key) string {
+	t.Helper()
+
+	signer, err := clickurl.NewKeyedSigner(key)
+	if err != nil {
+		t.Fatalf("create signer: %v", err)
+	}
+	return signer.URL("", clickurl.ClickParams{
+		QueryID: "q1", ResultID: "r1", Position: 1, Page: 1,
+		Timestamp: time.Now().Unix(), DestinationURL: "https://example.com/article",
+	})
+}
+
+func TestHandleClick_RotatedKeys(t *testing.T) {
+	r := setupRotatedRouter(t)
+
+	for name, target := range map[string]string{
+		"current key":  keyedURL(t, currentKey),
+		"previous key": keyedURL(t, previousKey),
+		// Links signed before key IDs verify against the key holding their secret
+		"unprefixed": signedURL(t, "q1", "r1", 1, 1, time.Now().Unix(), "https://example.com/article"),
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+		if w.Code != http.StatusFound {
+			t.Errorf("%s: expected 302, got %d: %s", name, w.Code, w.Body.String())
+		}
+	}
+
+	retired := keyedURL(t, clickurl.Key{ID: "2025z", Secret: "retired-secret"})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, retired, http.NoBody))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("retired key: expected 403, got %d", w.Code)
+	}
+}
+
+func TestClickHandler_KeyStatus(t *testing.T) {
+	r := setupRotatedRouter(t)
+	for range 2 {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, keyedURL(t, previousKey), http.NoBody))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/keys", http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Keys []handler.KeyUsage `json:"keys"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Keys) != 2 || resp.Keys[0].KeyID != currentKey.ID || resp.Keys[1].KeyID != previousKey.ID {
+		t.Fatalf("unexpected keys %+v", resp.Keys)
+	}
+	if resp.Keys[0].Verified != 0 || resp.Keys[0].LastVerifiedAt != nil {
+		t.Errorf("current key: expected no use, got %+v", resp.Keys[0])
+	}
+	if resp.Keys[1].Verified != 2 || resp.Keys[1].LastVerifiedAt == nil {
+		t.Errorf("previous key: expected 2 uses, got %+v", resp.Keys[1])
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// Stats query limits.
+const (
+	defaultStatsLimit = 10
+	maxStatsLimit     = 100
+	maxStatsRange     = 93 * 24 * time.Hour
+	maxStatsDays      = 93
+	defaultStatsDays  = 30
+)
+
+// ResultStatsReader reads aggregate clicks per result.
+type ResultStatsReader interface {
+	TopResults(ctx context.Context, resultIDs []string, since, until time.Time, limit int) ([]storage.ResultClicks, error)
+}
+
+// SessionStatsReader reads the clicks of one session.
+type SessionStatsReader interface {
+	SessionResults(ctx context.Context, sessionID string, since time.Time, limit int) ([]storage.SessionClicks, error)
+}
+
+// ClickStatsReader is everything StatsHandler reads.
+type ClickStatsReader interface {
+	ResultStatsReader
+	SessionStatsReader
+	ClickTotalsReader
+	CampaignStatsReader
+	ImpressionStatsReader
+	EventExporter
+}
+
+// StatsHandler serves aggregate click statistics to other services.
+type StatsHandler struct {
+	reader ClickStatsReader
+	logger infralogger.Logger
+}
+
+// NewStatsHandler creates a StatsHandler.
+func NewStatsHandler(reader ClickStatsReader, log infralogger.Logger) *StatsHandler {
+	return &StatsHandler{reader: reader, logger: log}
+}
+
+// resultStatsRequest asks for the top-clicked of a set of results.
+type resultStatsRequest struct {
+	ResultIDs []string  `binding:"required,min=1" json:"result_ids"`
+	Since     time.Time `binding:"required"       json:"since"`
+	Until     time.Time `binding:"required"       json:"until"`
+	Limit     int       `json:"limit"`
+}
+
+// TopResults returns the most-clicked of the given results in [since, until).
+// POST /api/v1/stats/results
+func (h *StatsHandler) TopResults(c *gin.Context) {
+	var req resultStatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+		return
+	}
+
+	if len(req.ResultIDs) > storage.MaxStatsResultIDs {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "too many result_ids"})
+		return
+	}
+	if !req.Until.After(req.Since) || req.Until.Sub(req.Since) > maxStatsRange {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "until must be after since and within 93 days"})
+		return
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultStatsLimit
+	}
+	limit = min(limit, maxStatsLimit)
+
+	results, err := h.reader.TopResults(c.Request.Context(), req.ResultIDs, req.Since, req.Until, limit)
+	if err != nil {
+		h.logger.Error("Failed to read result click stats", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read click stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"count":   len(results),
+		"since":   req.Since.UTC(),
+		"until":   req.Until.UTC(),
+	})
+}
+
+// SessionResults returns the results one session clicked in the last days
+// (default 30, at most 93), most clicks first. Search uses it to personalize ranking.
+// GET /api/v1/stats/sessions/:session_id?days=30&limit=10
+func (h *StatsHandler) SessionResults(c *gin.Context) {
+	sessionID := c.Param("session_id")
+	if !sessionIDPattern.MatchString(sessionID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidSession.Error()})
+		return
+	}
+
+	days := defaultStatsDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxStatsDays {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 93"})
+			return
+		}
+		days = parsed
+	}
+
+	limit := defaultStatsLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = min(parsed, maxStatsLimit)
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	results, err := h.reader.SessionResults(c.Request.Context(), sessionID, since, limit)
+	if err != nil {
+		h.logger.Error("Failed to read session click stats", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read click stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id": sessionID,
+		"results":    results,
+		"count":      len(results),
+		"since":      since,
+	})
+}
@@ -0,0 +1,190 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+type fakeStatsReader struct {
+	gotIDs       []string
+	gotLimit     int
+	gotMinClicks int
+	gotSession   string
+	gotChannel   string
+	gotSince     time.Time
+	gotUntil     time.Time
+	articles     []storage.ClickTotals
+	sources      []storage.ClickTotals
+}
+
+func (f *fakeStatsReader) TopResults(
+	_ context.Context, ids []string, _, _ time.Time, limit int,
+) ([]storage.ResultClicks, error) {
+	f.gotIDs = ids
+	f.gotLimit = limit
+	return []storage.ResultClicks{{ResultID: ids[0], Clicks: 7, UniqueSessions: 5}}, nil
+}
+
+func (f *fakeStatsReader) SessionResults(
+	_ context.Context, sessionID string, since time.Time, limit int,
+) ([]storage.SessionClicks, error) {
+	f.gotSession = sessionID
+	f.gotSince = since
+	f.gotLimit = limit
+	return []storage.SessionClicks{{ResultID: "doc-9", Clicks: 2}}, nil
+}
+
+func (f *fakeStatsReader) ArticleTotals(
+	_ context.Context, since, _ time.Time, minClicks, limit int,
+) ([]storage.ClickTotals, error) {
+	f.gotSince = since
+	f.gotMinClicks = minClicks
+	f.gotLimit = limit
+	return f.articles, nil
+}
+
+func (f *fakeStatsReader) SourceTotals(
+	_ context.Context, _, _ time.Time, _, _ int,
+) ([]storage.ClickTotals, error) {
+	return f.sources, nil
+}
+
+func (f *fakeStatsReader) CampaignResults(
+	_ context.Context, channel string, since, _ time.Time, limit int,
+) ([]storage.CampaignClicks, error) {
+	f.gotChannel = channel
+	f.gotSince = since
+	f.gotLimit = limit
+	return []storage.CampaignClicks{{Channel: channel, UTMSource: "newsletter", Clicks: 3}}, nil
+}
+
+func (f *fakeStatsReader) ImpressionResults(
+	_ context.Context, channel string, since, _ time.Time, limit int,
+) ([]storage.ResultImpressions, error) {
+	f.gotChannel = channel
+	f.gotSince = since
+	f.gotLimit = limit
+	return []storage.ResultImpressions{
+		{ResultID: "doc-1", Impressions: 30, Clicks: 3, CTR: 0.1},
+		{ResultID: "doc-2", Impressions: 10, Clicks: 1, CTR: 0.1},
+	}, nil
+}
+
+func (f *fakeStatsReader) SupportsExportFormat(format string) bool {
+	return format == storage.ExportFormatCSV
+}
+
+func (f *fakeStatsReader) ExportEvents(_ context.Context, since, until time.Time, _ string, w io.Writer) error {
+	f.gotSince = since
+	f.gotUntil = until
+	_, err := io.WriteString(w, "clicked_at,result_id\n2026-03-02T10:00:00.000Z,doc-1\n")
+	return err
+}
+
+func postStats(t *testing.T, reader handler.ClickStatsReader, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/stats/results", handler.NewStatsHandler(reader, infralogger.NewNop()).TopResults)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/stats/results", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStatsHandler_TopResults(t *testing.T) {
+	reader := &fakeStatsReader{}
+	w := postStats(t, reader, `{"result_ids":["doc-1","doc-2"],`+
+		`"since":"2026-03-02T00:00:00Z","until":"2026-03-09T00:00:00Z","limit":500}`)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if reader.gotLimit != 100 {
+		t.Errorf("expected limit capped to 100, got %d", reader.gotLimit)
+	}
+
+	var resp struct {
+		Results []storage.ResultClicks `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Clicks != 7 {
+		t.Errorf("unexpected results %+v", resp.Results)
+	}
+}
+
+func TestStatsHandler_TopResults_InvalidRange(t *testing.T) {
+	w := postStats(t, &fakeStatsReader{}, `{"result_ids":["doc-1"],`+
+		`"since":"2026-03-09T00:00:00Z","until":"2026-03-02T00:00:00Z"}`)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func getSessionStats(t *testing.T, reader handler.ClickStatsReader, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/stats/sessions/:session_id", handler.NewStatsHandler(reader, infralogger.NewNop()).SessionResults)
+
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStatsHandler_SessionResults(t *testing.T) {
+	reader := &fakeStatsReader{}
+	w := getSessionStats(t, reader, "/api/v1/stats/sessions/sess_1?days=7&limit=500")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if reader.gotSession != "sess_1" || reader.gotLimit != 100 {
+		t.Errorf("unexpected session %q or limit %d", reader.gotSession, reader.gotLimit)
+	}
+	if age := time.Since(reader.gotSince); age < 7*24*time.Hour-time.Minute || age > 7*24*time.Hour+time.Minute {
+		t.Errorf("expected since 7 days ago, got %s", reader.gotSince)
+	}
+
+	var resp struct {
+		Results []storage.SessionClicks `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].ResultID != "doc-9" {
+		t.Errorf("unexpected results %+v", resp.Results)
+	}
+}
+
+func TestStatsHandler_SessionResults_Invalid(t *testing.T) {
+	for _, target := range []string{
+		"/api/v1/stats/sessions/bad%20session",
+		"/api/v1/stats/sessions/sess_1?days=0",
+		"/api/v1/stats/sessions/sess_1?days=94",
+		"/api/v1/stats/sessions/sess_1?limit=x",
+	} {
+		if w := getSessionStats(t, &fakeStatsReader{}, target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, w.Code)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/sse"
+)
+
+// EventTypeClick is the SSE event type of a streamed click.
+const EventTypeClick = "click:recorded"
+
+// StreamedClick is what subscribers see of a click. It leaves out the query,
+// session, user agent and destination, so the stream cannot tie clicks to a
+// visitor or a search.
+type StreamedClick struct {
+	ResultID  string    `json:"result_id"`
+	Channel   string    `json:"channel,omitempty"`
+	ClickedAt time.Time `json:"clicked_at"`
+}
+
+// newClickStreamEvent builds the click:recorded event for a stored click.
+func newClickStreamEvent(event *domain.ClickEvent) sse.Event {
+	return sse.Event{
+		Type: EventTypeClick,
+		Data: StreamedClick{
+			ResultID:  event.ResultID,
+			Channel:   event.Channel,
+			ClickedAt: event.ClickedAt.UTC(),
+		},
+	}
+}
+
+// StreamHandler serves the live click stream.
+type StreamHandler struct {
+	broker sse.Broker
+	logger infralogger.Logger
+}
+
+// NewStreamHandler creates a StreamHandler subscribing to broker.
+func NewStreamHandler(broker sse.Broker, log infralogger.Logger) *StreamHandler {
+	return &StreamHandler{broker: broker, logger: log}
+}
+
+// ClickEvents streams click:recorded events for clicks recorded by this
+// instance until the client disconnects.
+// GET /api/v1/clicks/events
+func (h *StreamHandler) ClickEvents(c *gin.Context) {
+	// The server's write timeout would cut the stream off after a few seconds
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("Could not lift write deadline for click stream", infralogger.Error(err))
+	}
+
+	sse.Handler(h.broker, h.logger, sse.WithFilter(func(event sse.Event) bool {
+		return event.Type == EventTypeClick
+	}))(c)
+}
+
+// publishClick sends a stored click to stream subscribers. The broker drops
+// it rather than block the redirect when its buffer is full.
+func (h *ClickHandler) publishClick(ctx context.Context, event *domain.ClickEvent) {
+	if h.stream == nil {
+		return
+	}
+	if err := h.stream.Publish(ctx, newClickStreamEvent(event)); err != nil {
+		h.logger.Debug("Click not streamed", infralogger.Error(err))
+	}
+}
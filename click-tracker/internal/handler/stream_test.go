@@ -0,0 +1,175 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/handler"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/middleware"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/sse"
+)
+
+// streamTimeout bounds how long the stream test waits for an event.
+const streamTimeout = 5 * time.Second
+
+type fakePublisher struct {
+	mu     sync.Mutex
+	events []sse.Event
+}
+
+func (f *fakePublisher) Publish(_ context.Context, event sse.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func streamRouter(t *testing.T, publisher sse.Publisher, botFilter bool) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if botFilter {
+		r.Use(middleware.BotFilter())
+	}
+	buf := storage.NewBuffer(testBufferCapacity)
+	t.Cleanup(buf.Close)
+
+	h := handler.NewClickHandler(clickurl.NewSigner(testSecret), buf, infralogger.NewNop(), maxAgeHours*time.Hour).
+		WithStream(publisher)
+	r.GET("/click", h.HandleClick)
+	return r
+}
+
+func TestHandleClick_PublishesSanitizedClick(t *testing.T) {
+	publisher := &fakePublisher{}
+	r := streamRouter(t, publisher, false)
+
+	target := clickurl.NewSigner(testSecret).URL("", clickurl.ClickParams{
+		QueryID:        "q_abc",
+		ResultID:       "r_doc",
+		Position:       2,
+		Page:           1,
+		Timestamp:      time.Now().Unix(),
+		DestinationURL: "https://example.com/article",
+		SessionID:      "sess_123",
+		Channel:        "northern-news",
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected 302, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected 1 published event, got %d", len(publisher.events))
+	}
+
+	event := publisher.events[0]
+	if event.Type != handler.EventTypeClick {
+		t.Errorf("expected type %q, got %q", handler.EventTypeClick, event.Type)
+	}
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		t.Fatalf("marshal event data: %v", err)
+	}
+	for _, leaked := range []string{"q_abc", "sess_123", "example.com"} {
+		if strings.Contains(string(raw), leaked) {
+			t.Errorf("streamed click %s leaks %q", raw, leaked)
+		}
+	}
+	click, ok := event.Data.(handler.StreamedClick)
+	if !ok || click.ResultID != "r_doc" || click.Channel != "northern-news" || click.ClickedAt.IsZero() {
+		t.Errorf("unexpected streamed click %+v", event.Data)
+	}
+}
+
+func TestHandleClick_BotNotPublished(t *testing.T) {
+	publisher := &fakePublisher{}
+	r := streamRouter(t, publisher, true)
+
+	req := httptest.NewRequest(http.MethodGet,
+		signedURL(t, "q_abc", "r_doc", 1, 1, time.Now().Unix(), "https://example.com"), http.NoBody)
+	req.Header.Set("User-Agent", "Googlebot/2.1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected 302, got %d", w.Code)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected bot click not to be streamed, got %d events", len(publisher.events))
+	}
+}
+
+func TestStreamHandler_ClickEvents(t *testing.T) {
+	log := infralogger.NewNop()
+	broker := sse.NewBroker(log)
+	if err := broker.Start(context.Background()); err != nil {
+		t.Fatalf("start broker: %v", err)
+	}
+	t.Cleanup(func() { _ = broker.Stop() })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/clicks/events", handler.NewStreamHandler(broker, log).ClickEvents)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/clicks/events", http.NoBody)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	waitFor := func(prefix string) string {
+		t.Helper()
+		for lines.Scan() {
+			if strings.HasPrefix(lines.Text(), prefix) {
+				return lines.Text()
+			}
+		}
+		t.Fatalf("stream ended before %q: %v", prefix, lines.Err())
+		return ""
+	}
+
+	// Publish only once subscribed, or the broker has no one to send to
+	waitFor("event: connected")
+	if pubErr := broker.Publish(ctx, sse.Event{Type: "job:status", Data: "ignored"}); pubErr != nil {
+		t.Fatalf("publish: %v", pubErr)
+	}
+	click := handler.StreamedClick{ResultID: "r_doc", Channel: "northern-news", ClickedAt: time.Now().UTC()}
+	if pubErr := broker.Publish(ctx, sse.Event{Type: handler.EventTypeClick, Data: click}); pubErr != nil {
+		t.Fatalf("publish: %v", pubErr)
+	}
+
+	if got := waitFor("event: "); got != "event: "+handler.EventTypeClick {
+		t.Fatalf("expected only click events, got %q", got)
+	}
+	data := waitFor("data: ")
+	if !strings.Contains(data, `"result_id":"r_doc"`) || !strings.Contains(data, `"channel":"northern-news"`) {
+		t.Errorf("unexpected click data %s", data)
+	}
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CampaignClicks is the click count of one channel and campaign combination.
+// Empty fields mean the clicks carried no such parameter.
+type CampaignClicks struct {
+	Channel        string `json:"channel"`
+	UTMSource      string `json:"utm_source"`
+	UTMMedium      string `json:"utm_medium"`
+	UTMCampaign    string `json:"utm_campaign"`
+	Clicks         int64  `json:"clicks"`
+	UniqueSessions int64  `json:"unique_sessions"`
+}
+
+// campaignClicksQuery reads raw events only: rollups keep no attribution.
+// Unattributed clicks, with no channel or UTM parameter, are left out.
+const campaignClicksQuery = `
+	SELECT channel, utm_source, utm_medium, utm_campaign,
+		COUNT(*)::BIGINT, COUNT(DISTINCT NULLIF(session_id, ''))::BIGINT
+	FROM click_events
+	WHERE clicked_at >= $1 AND clicked_at < $2
+	  AND (channel <> '' OR utm_source <> '' OR utm_medium <> '' OR utm_campaign <> '')
+	  AND ($3::TEXT = '' OR channel = $3::TEXT)
+	GROUP BY channel, utm_source, utm_medium, utm_campaign
+	ORDER BY 5 DESC, channel, utm_source, utm_medium, utm_campaign
+	LIMIT $4
+`
+
+// CampaignResults returns clicks per channel and campaign between since and
+// until, most clicks first. A non-empty channel restricts them to that channel.
+// Events purged from click_events are not counted.
+func (r *StatsReader) CampaignResults(
+	ctx context.Context, channel string, since, until time.Time, limit int,
+) ([]CampaignClicks, error) {
+	rows, err := r.db.QueryContext(ctx, campaignClicksQuery, since, until, channel, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query campaign clicks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := []CampaignClicks{}
+	for rows.Next() {
+		var cc CampaignClicks
+		if scanErr := rows.Scan(
+			&cc.Channel, &cc.UTMSource, &cc.UTMMedium, &cc.UTMCampaign, &cc.Clicks, &cc.UniqueSessions,
+		); scanErr != nil {
+			return nil, fmt.Errorf("scan campaign clicks: %w", scanErr)
+		}
+		results = append(results, cc)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("iterate campaign clicks: %w", rowsErr)
+	}
+	return results, nil
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ClickTotals is the click count of one article or source over a time range.
+type ClickTotals struct {
+	// ID is the result ID of an article, or the destination host of a source.
+	ID     string
+	Clicks int64
+	// UniqueSessions is summed per hour, so a session clicking in two hours counts twice.
+	UniqueSessions int64
+	// PositionSum is the sum of the clicked positions, for the average position.
+	PositionSum int64
+}
+
+// articleTotalsQuery combines hourly rollups with raw events from hours that
+// have not been rolled up yet, like resultClicksQuery, for every result.
+const articleTotalsQuery = `
+	WITH rolled AS (
+		SELECT result_id, SUM(clicks) AS clicks, SUM(unique_sessions) AS sessions,
+			SUM(position_sum) AS positions
+		FROM click_rollups_hourly
+		WHERE bucket_start >= $1 AND bucket_start < $2
+		GROUP BY result_id
+	), raw AS (
+		SELECT e.result_id, COUNT(*) AS clicks, COUNT(DISTINCT e.session_id) AS sessions,
+			SUM(e.position) AS positions
+		FROM click_events e
+		WHERE e.clicked_at >= $1 AND e.clicked_at < $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM click_rollups_hourly r
+			WHERE r.result_id = e.result_id AND r.bucket_start = date_trunc('hour', e.clicked_at)
+		  )
+		GROUP BY e.result_id
+	)
+	SELECT result_id, SUM(clicks)::BIGINT, SUM(sessions)::BIGINT, SUM(positions)::BIGINT
+	FROM (SELECT * FROM rolled UNION ALL SELECT * FROM raw) totals
+	GROUP BY result_id
+	HAVING SUM(clicks) >= $3
+	ORDER BY 2 DESC, result_id
+	LIMIT $4
+`
+
+// ArticleTotals returns the most-clicked results between since and until
+// with at least minClicks clicks, most clicks first.
+func (r *StatsReader) ArticleTotals(
+	ctx context.Context, since, until time.Time, minClicks, limit int,
+) ([]ClickTotals, error) {
+	totals, err := r.queryTotals(ctx, articleTotalsQuery, since, until, minClicks, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query article totals: %w", err)
+	}
+	return totals, nil
+}
+
+// sourceTotalsQuery reads raw events only: rollups do not keep the
+// destination host. Events recorded before it was stored have no host.
+const sourceTotalsQuery = `
+	SELECT destination_host, SUM(clicks)::BIGINT, SUM(sessions)::BIGINT, SUM(positions)::BIGINT
+	FROM (
+		SELECT destination_host, COUNT(*) AS clicks, COUNT(DISTINCT session_id) AS sessions,
+			SUM(position) AS positions
+		FROM click_events
+		WHERE clicked_at >= $1 AND clicked_at < $2 AND destination_host <> ''
+		GROUP BY destination_host, date_trunc('hour', clicked_at)
+	) hourly
+	GROUP BY destination_host
+	HAVING SUM(clicks) >= $3
+	ORDER BY 2 DESC, destination_host
+	LIMIT $4
+`
+
+// SourceTotals returns the most-clicked destination hosts between since and
+// until with at least minClicks clicks, most clicks first. Events purged
+// from click_events are not counted.
+func (r *StatsReader) SourceTotals(
+	ctx context.Context, since, until time.Time, minClicks, limit int,
+) ([]ClickTotals, error) {
+	totals, err := r.queryTotals(ctx, sourceTotalsQuery, since, until, minClicks, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query source totals: %w", err)
+	}
+	return totals, nil
+}
+
+// queryTotals runs a totals query taking (since, until, minClicks, limit).
+func (r *StatsReader) queryTotals(
+	ctx context.Context, query string, since, until time.Time, minClicks, limit int,
+) ([]ClickTotals, error) {
+	rows, err := r.db.QueryContext(ctx, query, since, until, minClicks, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	totals := []ClickTotals{}
+	for rows.Next() {
+		var t ClickTotals
+		if scanErr := rows.Scan(&t.ID, &t.Clicks, &t.UniqueSessions, &t.PositionSum); scanErr != nil {
+			return nil, fmt.Errorf("scan: %w", scanErr)
+		}
+		totals = append(totals, t)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("iterate: %w", rowsErr)
+	}
+	return totals, nil
+}
@@ -0,0 +1,355 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/config"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+)
+
+// ClickHouse HTTP interface settings.
+const (
+	// clickHouseTimeout bounds each request, including an async insert's wait.
+	clickHouseTimeout = 10 * time.Second
+
+	// clickHouseTimeFormat is how DateTime64(3) values are written and bound.
+	clickHouseTimeFormat = "2006-01-02 15:04:05.000"
+
+	// clickHouseErrorLimit caps how much of an error response is kept.
+	clickHouseErrorLimit = 1024
+)
+
+// clickHouseEventsTable is ordered by result so stats for a set of results
+// read a narrow range; the bloom filter serves per-session lookups.
+const clickHouseEventsTable = `
+	CREATE TABLE IF NOT EXISTS click_events (
+		query_id         String,
+		result_id        String,
+		position         Int32,
+		page             Int32,
+		destination_hash String,
+		session_id       String,
+		user_agent_hash  String,
+		generated_at     DateTime64(3, 'UTC'),
+		clicked_at       DateTime64(3, 'UTC'),
+		destination_host String,
+		channel          LowCardinality(String),
+		utm_source       LowCardinality(String),
+		utm_medium       LowCardinality(String),
+		utm_campaign     LowCardinality(String),
+		INDEX idx_session_id session_id TYPE bloom_filter GRANULARITY 4
+	)
+	ENGINE = MergeTree
+	PARTITION BY toYYYYMM(clicked_at)
+	ORDER BY (result_id, clicked_at)
+`
+
+// clickHouseImpressionsTable is ordered like click_events, so click-through
+// rates join the two on ranges of the same results.
+const clickHouseImpressionsTable = `
+	CREATE TABLE IF NOT EXISTS impression_events (
+		query_id         String,
+		result_id        String,
+		position         Int32,
+		page             Int32,
+		destination_host String,
+		session_id       String,
+		channel          LowCardinality(String),
+		generated_at     DateTime64(3, 'UTC'),
+		shown_at         DateTime64(3, 'UTC')
+	)
+	ENGINE = MergeTree
+	PARTITION BY toYYYYMM(shown_at)
+	ORDER BY (result_id, shown_at)
+`
+
+// clickHouseColumnAdditions bring click_events tables created by earlier
+// versions up to date. Each is a no-op once applied.
+var clickHouseColumnAdditions = []string{
+	"ALTER TABLE click_events ADD COLUMN IF NOT EXISTS destination_host String",
+	"ALTER TABLE click_events ADD COLUMN IF NOT EXISTS channel LowCardinality(String)",
+	"ALTER TABLE click_events ADD COLUMN IF NOT EXISTS utm_source LowCardinality(String)",
+	"ALTER TABLE click_events ADD COLUMN IF NOT EXISTS utm_medium LowCardinality(String)",
+	"ALTER TABLE click_events ADD COLUMN IF NOT EXISTS utm_campaign LowCardinality(String)",
+}
+
+// clickHouseIdentifierColumns are the visitor identifiers cleared by
+// SetAnonymizationTTL, with the time column their age is measured from.
+var clickHouseIdentifierColumns = []struct{ table, column, timeColumn string }{
+	{"click_events", "session_id", "clicked_at"},
+	{"click_events", "user_agent_hash", "clicked_at"},
+	{"impression_events", "session_id", "shown_at"},
+}
+
+// ClickHouse talks to a ClickHouse server over its HTTP interface.
+type ClickHouse struct {
+	baseURL  string
+	database string
+	user     string
+	password string
+	client   *http.Client
+	// streamClient has no timeout, for responses that take long to read;
+	// the request context bounds them instead.
+	streamClient *http.Client
+}
+
+// NewClickHouse creates a ClickHouse client from configuration.
+func NewClickHouse(cfg *config.ClickHouseConfig) *ClickHouse {
+	return &ClickHouse{
+		baseURL:  strings.TrimRight(cfg.URL, "/"),
+		database: cfg.Database,
+		user:     cfg.User,
+		password: cfg.Password,
+		client:   &http.Client{Timeout: clickHouseTimeout},
+
+		streamClient: &http.Client{},
+	}
+}
+
+// Ping checks that the server is reachable.
+func (c *ClickHouse) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/ping", http.NoBody)
+	if err != nil {
+		return fmt.Errorf("build ping request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ping clickhouse: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ping clickhouse: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EnsureSchema creates the database and the click_events and
+// impression_events tables if they do not exist, and adds columns introduced
+// since click_events was created.
+func (c *ClickHouse) EnsureSchema(ctx context.Context) error {
+	// The database parameter cannot name a database that does not exist yet
+	createDB := "CREATE DATABASE IF NOT EXISTS " + quoteIdentifier(c.database)
+	if err := c.exec(ctx, createDB, url.Values{}, nil); err != nil {
+		return fmt.Errorf("create database: %w", err)
+	}
+	if err := c.exec(ctx, clickHouseEventsTable, c.params(), nil); err != nil {
+		return fmt.Errorf("create click_events: %w", err)
+	}
+	for _, alter := range clickHouseColumnAdditions {
+		if err := c.exec(ctx, alter, c.params(), nil); err != nil {
+			return fmt.Errorf("alter click_events: %w", err)
+		}
+	}
+	if err := c.exec(ctx, clickHouseImpressionsTable, c.params(), nil); err != nil {
+		return fmt.Errorf("create impression_events: %w", err)
+	}
+	return nil
+}
+
+// quoteIdentifier quotes name as a ClickHouse identifier, so a configured
+// name is never read as SQL.
+func quoteIdentifier(name string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(name)
+	return "`" + escaped + "`"
+}
+
+// SetAnonymizationTTL gives the visitor identifier columns a TTL, so
+// ClickHouse resets them to empty strings in events older than days as it
+// merges parts. It replaces any TTL set before. Events themselves are kept:
+// stats are answered from them, as there are no rollups.
+func (c *ClickHouse) SetAnonymizationTTL(ctx context.Context, days int) error {
+	for _, id := range clickHouseIdentifierColumns {
+		alter := fmt.Sprintf(
+			"ALTER TABLE %s MODIFY COLUMN %s String TTL toDateTime(%s) + INTERVAL %d DAY",
+			id.table, id.column, id.timeColumn, days,
+		)
+		if err := c.exec(ctx, alter, c.params(), nil); err != nil {
+			return fmt.Errorf("set %s.%s ttl: %w", id.table, id.column, err)
+		}
+	}
+	return nil
+}
+
+// params returns the request parameters shared by queries on the database.
+func (c *ClickHouse) params() url.Values {
+	return url.Values{"database": {c.database}}
+}
+
+// exec runs a statement, sending body as its data, and discards the response.
+func (c *ClickHouse) exec(ctx context.Context, query string, params url.Values, body io.Reader) error {
+	resp, err := c.do(ctx, query, params, body)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp)
+	return resp.Close()
+}
+
+// do sends query with params and returns the response body. Non-200
+// responses are returned as errors carrying ClickHouse's message.
+func (c *ClickHouse) do(ctx context.Context, query string, params url.Values, body io.Reader) (io.ReadCloser, error) {
+	return c.send(ctx, c.client, query, params, body)
+}
+
+// doStream is do without the request timeout, for large results.
+func (c *ClickHouse) doStream(ctx context.Context, query string, params url.Values) (io.ReadCloser, error) {
+	return c.send(ctx, c.streamClient, query, params, nil)
+}
+
+// send runs query on client.
+func (c *ClickHouse) send(
+	ctx context.Context, client *http.Client, query string, params url.Values, body io.Reader,
+) (io.ReadCloser, error) {
+	params.Set("query", query)
+	if body == nil {
+		body = http.NoBody
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/?"+params.Encode(), body)
+	if err != nil {
+		return nil, fmt.Errorf("build clickhouse request: %w", err)
+	}
+	req.Header.Set("X-ClickHouse-User", c.user)
+	if c.password != "" {
+		req.Header.Set("X-ClickHouse-Key", c.password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, clickHouseErrorLimit))
+		return nil, fmt.Errorf("clickhouse status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp.Body, nil
+}
+
+// ClickHouseWriter writes click events to ClickHouse with async inserts: the
+// server merges concurrent batches into fewer parts, so many replicas can
+// flush small batches without each insert creating a part of its own.
+type ClickHouseWriter struct {
+	ch *ClickHouse
+}
+
+// NewClickHouseWriter creates a ClickHouseWriter.
+func NewClickHouseWriter(ch *ClickHouse) *ClickHouseWriter {
+	return &ClickHouseWriter{ch: ch}
+}
+
+// clickHouseRow is one click_events row in JSONEachRow form.
+type clickHouseRow struct {
+	QueryID         string `json:"query_id"`
+	ResultID        string `json:"result_id"`
+	Position        int    `json:"position"`
+	Page            int    `json:"page"`
+	DestinationHash string `json:"destination_hash"`
+	SessionID       string `json:"session_id"`
+	UserAgentHash   string `json:"user_agent_hash"`
+	GeneratedAt     string `json:"generated_at"`
+	ClickedAt       string `json:"clicked_at"`
+	DestinationHost string `json:"destination_host"`
+	Channel         string `json:"channel"`
+	UTMSource       string `json:"utm_source"`
+	UTMMedium       string `json:"utm_medium"`
+	UTMCampaign     string `json:"utm_campaign"`
+}
+
+// WriteEvents inserts the whole batch in one request. It waits for the
+// server to flush its async insert buffer, so a nil error means the events
+// are stored.
+func (w *ClickHouseWriter) WriteEvents(ctx context.Context, events []domain.ClickEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for i := range events {
+		row := clickHouseRow{
+			QueryID:         events[i].QueryID,
+			ResultID:        events[i].ResultID,
+			Position:        events[i].Position,
+			Page:            events[i].Page,
+			DestinationHash: events[i].DestinationHash,
+			SessionID:       events[i].SessionID,
+			UserAgentHash:   events[i].UserAgentHash,
+			GeneratedAt:     events[i].GeneratedAt.UTC().Format(clickHouseTimeFormat),
+			ClickedAt:       events[i].ClickedAt.UTC().Format(clickHouseTimeFormat),
+			DestinationHost: events[i].DestinationHost,
+			Channel:         events[i].Channel,
+			UTMSource:       events[i].UTMSource,
+			UTMMedium:       events[i].UTMMedium,
+			UTMCampaign:     events[i].UTMCampaign,
+		}
+		if err := enc.Encode(&row); err != nil {
+			return fmt.Errorf("encode click event: %w", err)
+		}
+	}
+
+	params := w.ch.params()
+	params.Set("async_insert", "1")
+	params.Set("wait_for_async_insert", "1")
+	if err := w.ch.exec(ctx, "INSERT INTO click_events FORMAT JSONEachRow", params, &body); err != nil {
+		return fmt.Errorf("insert click events: %w", err)
+	}
+	return nil
+}
+
+// clickHouseImpressionRow is one impression_events row in JSONEachRow form.
+type clickHouseImpressionRow struct {
+	QueryID         string `json:"query_id"`
+	ResultID        string `json:"result_id"`
+	Position        int    `json:"position"`
+	Page            int    `json:"page"`
+	DestinationHost string `json:"destination_host"`
+	SessionID       string `json:"session_id"`
+	Channel         string `json:"channel"`
+	GeneratedAt     string `json:"generated_at"`
+	ShownAt         string `json:"shown_at"`
+}
+
+// WriteImpressions inserts the whole batch in one async insert, like WriteEvents.
+func (w *ClickHouseWriter) WriteImpressions(ctx context.Context, impressions []domain.ImpressionEvent) error {
+	if len(impressions) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for i := range impressions {
+		row := clickHouseImpressionRow{
+			QueryID:         impressions[i].QueryID,
+			ResultID:        impressions[i].ResultID,
+			Position:        impressions[i].Position,
+			Page:            impressions[i].Page,
+			DestinationHost: impressions[i].DestinationHost,
+			SessionID:       impressions[i].SessionID,
+			Channel:         impressions[i].Channel,
+			GeneratedAt:     impressions[i].GeneratedAt.UTC().Format(clickHouseTimeFormat),
+			ShownAt:         impressions[i].ShownAt.UTC().Format(clickHouseTimeFormat),
+		}
+		if err := enc.Encode(&row); err != nil {
+			return fmt.Errorf("encode impression event: %w", err)
+		}
+	}
+
+	params := w.ch.params()
+	params.Set("async_insert", "1")
+	params.Set("wait_for_async_insert", "1")
+	if err := w.ch.exec(ctx, "INSERT INTO impression_events FORMAT JSONEachRow", params, &body); err != nil {
+		return fmt.Errorf("insert impression events: %w", err)
+	}
+	return nil
+}
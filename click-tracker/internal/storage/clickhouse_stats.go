@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClickHouseStatsReader answers aggregate click queries from ClickHouse.
+// ClickHouse keeps every raw event, so it needs no hourly rollups.
+type ClickHouseStatsReader struct {
+	ch *ClickHouse
+}
+
+// NewClickHouseStatsReader creates a ClickHouseStatsReader.
+func NewClickHouseStatsReader(ch *ClickHouse) *ClickHouseStatsReader {
+	return &ClickHouseStatsReader{ch: ch}
+}
+
+// clickHouseResultClicksQuery counts distinct sessions per hour before
+// summing, so unique_sessions means the same as with the Postgres rollups.
+const clickHouseResultClicksQuery = `
+	SELECT result_id, sum(hour_clicks) AS clicks, sum(hour_sessions) AS unique_sessions
+	FROM (
+		SELECT result_id, count() AS hour_clicks, uniqExact(session_id) AS hour_sessions
+		FROM click_events
+		WHERE result_id IN {ids:Array(String)}
+		  AND clicked_at >= {since:DateTime64(3, 'UTC')} AND clicked_at < {until:DateTime64(3, 'UTC')}
+		GROUP BY result_id, toStartOfHour(clicked_at)
+	)
+	GROUP BY result_id
+	ORDER BY clicks DESC, result_id
+	LIMIT {limit:UInt32}
+	FORMAT JSONEachRow
+`
+
+// TopResults returns the most-clicked of resultIDs between since and until,
+// most clicks first. Results without clicks are omitted.
+func (r *ClickHouseStatsReader) TopResults(
+	ctx context.Context, resultIDs []string, since, until time.Time, limit int,
+) ([]ResultClicks, error) {
+	results := []ResultClicks{}
+	if len(resultIDs) == 0 {
+		return results, nil
+	}
+
+	params := r.queryParams()
+	params.Set("param_ids", clickHouseStringArray(resultIDs))
+	params.Set("param_since", since.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_until", until.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_limit", strconv.Itoa(limit))
+
+	err := r.query(ctx, clickHouseResultClicksQuery, params, func(dec *json.Decoder) error {
+		var rc ResultClicks
+		if decodeErr := dec.Decode(&rc); decodeErr != nil {
+			return decodeErr
+		}
+		results = append(results, rc)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query result clicks: %w", err)
+	}
+	return results, nil
+}
+
+// clickHouseSessionClicksQuery returns the last click as epoch milliseconds,
+// which decodes without depending on the server's date output format.
+const clickHouseSessionClicksQuery = `
+	SELECT result_id, count() AS clicks, toUnixTimestamp64Milli(max(clicked_at)) AS last_clicked_ms
+	FROM click_events
+	WHERE session_id = {session_id:String} AND clicked_at >= {since:DateTime64(3, 'UTC')}
+	GROUP BY result_id
+	ORDER BY clicks DESC, last_clicked_ms DESC
+	LIMIT {limit:UInt32}
+	FORMAT JSONEachRow
+`
+
+// clickHouseSessionRow is one row of clickHouseSessionClicksQuery.
+type clickHouseSessionRow struct {
+	ResultID      string `json:"result_id"`
+	Clicks        int64  `json:"clicks"`
+	LastClickedMs int64  `json:"last_clicked_ms"`
+}
+
+// SessionResults returns the results a session clicked since the given time,
+// most clicks first.
+func (r *ClickHouseStatsReader) SessionResults(
+	ctx context.Context, sessionID string, since time.Time, limit int,
+) ([]SessionClicks, error) {
+	params := r.queryParams()
+	params.Set("param_session_id", clickHouseEscapeParam(sessionID))
+	params.Set("param_since", since.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_limit", strconv.Itoa(limit))
+
+	results := []SessionClicks{}
+	err := r.query(ctx, clickHouseSessionClicksQuery, params, func(dec *json.Decoder) error {
+		var row clickHouseSessionRow
+		if decodeErr := dec.Decode(&row); decodeErr != nil {
+			return decodeErr
+		}
+		results = append(results, SessionClicks{
+			ResultID:      row.ResultID,
+			Clicks:        row.Clicks,
+			LastClickedAt: time.UnixMilli(row.LastClickedMs).UTC(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query session clicks: %w", err)
+	}
+	return results, nil
+}
+
+// queryParams returns the parameters for a stats query. 64-bit counts are
+// requested as JSON numbers rather than ClickHouse's default quoted strings.
+func (r *ClickHouseStatsReader) queryParams() url.Values {
+	params := r.ch.params()
+	params.Set("output_format_json_quote_64bit_integers", "0")
+	return params
+}
+
+// query runs a JSONEachRow query and calls decodeRow once per row.
+func (r *ClickHouseStatsReader) query(
+	ctx context.Context, query string, params url.Values, decodeRow func(*json.Decoder) error,
+) error {
+	body, err := r.ch.do(ctx, query, params, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = body.Close() }()
+
+	dec := json.NewDecoder(body)
+	for dec.More() {
+		if decodeErr := decodeRow(dec); decodeErr != nil {
+			return fmt.Errorf("decode row: %w", decodeErr)
+		}
+	}
+	return nil
+}
+
+// clickHouseEscapeParam escapes a String query parameter, which ClickHouse
+// reads in TabSeparated escaping.
+func clickHouseEscapeParam(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`).Replace(s)
+}
+
+// clickHouseStringArray formats an Array(String) query parameter literal.
+func clickHouseStringArray(values []string) string {
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range values {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('\'')
+		sb.WriteString(quote.Replace(v))
+		sb.WriteByte('\'')
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// clickHouseTotalsQuery groups clicks by one column; %s is result_id or
+// destination_host. Sessions are counted per hour, as in TopResults.
+const clickHouseTotalsQuery = `
+	SELECT %[1]s AS id, sum(hour_clicks) AS clicks, sum(hour_sessions) AS unique_sessions,
+		sum(hour_positions) AS position_sum
+	FROM (
+		SELECT %[1]s, count() AS hour_clicks, uniqExact(session_id) AS hour_sessions,
+			sum(position) AS hour_positions
+		FROM click_events
+		WHERE clicked_at >= {since:DateTime64(3, 'UTC')} AND clicked_at < {until:DateTime64(3, 'UTC')}
+		  AND %[1]s != ''
+		GROUP BY %[1]s, toStartOfHour(clicked_at)
+	)
+	GROUP BY id
+	HAVING clicks >= {min_clicks:UInt32}
+	ORDER BY clicks DESC, id
+	LIMIT {limit:UInt32}
+	FORMAT JSONEachRow
+`
+
+// clickHouseTotalsRow is one row of clickHouseTotalsQuery.
+type clickHouseTotalsRow struct {
+	ID             string `json:"id"`
+	Clicks         int64  `json:"clicks"`
+	UniqueSessions int64  `json:"unique_sessions"`
+	PositionSum    int64  `json:"position_sum"`
+}
+
+// ArticleTotals returns the most-clicked results between since and until
+// with at least minClicks clicks, most clicks first.
+func (r *ClickHouseStatsReader) ArticleTotals(
+	ctx context.Context, since, until time.Time, minClicks, limit int,
+) ([]ClickTotals, error) {
+	totals, err := r.queryTotals(ctx, "result_id", since, until, minClicks, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query article totals: %w", err)
+	}
+	return totals, nil
+}
+
+// SourceTotals returns the most-clicked destination hosts between since and
+// until with at least minClicks clicks, most clicks first.
+func (r *ClickHouseStatsReader) SourceTotals(
+	ctx context.Context, since, until time.Time, minClicks, limit int,
+) ([]ClickTotals, error) {
+	totals, err := r.queryTotals(ctx, "destination_host", since, until, minClicks, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query source totals: %w", err)
+	}
+	return totals, nil
+}
+
+// queryTotals runs clickHouseTotalsQuery grouped by column, which must be a
+// click_events column name.
+func (r *ClickHouseStatsReader) queryTotals(
+	ctx context.Context, column string, since, until time.Time, minClicks, limit int,
+) ([]ClickTotals, error) {
+	params := r.queryParams()
+	params.Set("param_since", since.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_until", until.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_min_clicks", strconv.Itoa(minClicks))
+	params.Set("param_limit", strconv.Itoa(limit))
+
+	totals := []ClickTotals{}
+	err := r.query(ctx, fmt.Sprintf(clickHouseTotalsQuery, column), params, func(dec *json.Decoder) error {
+		var row clickHouseTotalsRow
+		if decodeErr := dec.Decode(&row); decodeErr != nil {
+			return decodeErr
+		}
+		totals = append(totals, ClickTotals(row))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// clickHouseCampaignClicksQuery mirrors campaignClicksQuery.
+const clickHouseCampaignClicksQuery = `
+	SELECT channel, utm_source, utm_medium, utm_campaign,
+		count() AS clicks, uniqExactIf(session_id, session_id != '') AS unique_sessions
+	FROM click_events
+	WHERE clicked_at >= {since:DateTime64(3, 'UTC')} AND clicked_at < {until:DateTime64(3, 'UTC')}
+	  AND (channel != '' OR utm_source != '' OR utm_medium != '' OR utm_campaign != '')
+	  AND ({channel:String} = '' OR channel = {channel:String})
+	GROUP BY channel, utm_source, utm_medium, utm_campaign
+	ORDER BY clicks DESC, channel, utm_source, utm_medium, utm_campaign
+	LIMIT {limit:UInt32}
+	FORMAT JSONEachRow
+`
+
+// CampaignResults returns clicks per channel and campaign between since and
+// until, most clicks first. A non-empty channel restricts them to that channel.
+func (r *ClickHouseStatsReader) CampaignResults(
+	ctx context.Context, channel string, since, until time.Time, limit int,
+) ([]CampaignClicks, error) {
+	params := r.queryParams()
+	params.Set("param_channel", clickHouseEscapeParam(channel))
+	params.Set("param_since", since.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_until", until.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_limit", strconv.Itoa(limit))
+
+	results := []CampaignClicks{}
+	err := r.query(ctx, clickHouseCampaignClicksQuery, params, func(dec *json.Decoder) error {
+		var cc CampaignClicks
+		if decodeErr := dec.Decode(&cc); decodeErr != nil {
+			return decodeErr
+		}
+		results = append(results, cc)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query campaign clicks: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,299 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/config"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clickHouseRequest is what the fake server saw of one request.
+type clickHouseRequest struct {
+	params url.Values
+	user   string
+	key    string
+	body   string
+}
+
+// newFakeClickHouse serves respond to every request and records them.
+func newFakeClickHouse(t *testing.T, status int, respond string) (*ClickHouse, *[]clickHouseRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []clickHouseRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, clickHouseRequest{
+			params: r.URL.Query(),
+			user:   r.Header.Get("X-ClickHouse-User"),
+			key:    r.Header.Get("X-ClickHouse-Key"),
+			body:   string(body),
+		})
+		mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, respond)
+	}))
+	t.Cleanup(srv.Close)
+
+	ch := NewClickHouse(&config.ClickHouseConfig{
+		URL: srv.URL + "/", Database: "clicks", User: "tracker", Password: "pw",
+	})
+	return ch, &requests
+}
+
+func TestClickHouseWriter_WriteEvents(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "")
+
+	events := []domain.ClickEvent{testEvent("q1", "r1"), testEventWithPos("q2", "r2", "sess2", 2)}
+	events[1].Channel = "northern-news"
+	events[1].UTMSource = "newsletter"
+	require.NoError(t, NewClickHouseWriter(ch).WriteEvents(context.Background(), events))
+
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	assert.Equal(t, "INSERT INTO click_events FORMAT JSONEachRow", req.params.Get("query"))
+	assert.Equal(t, "clicks", req.params.Get("database"))
+	assert.Equal(t, "1", req.params.Get("async_insert"))
+	assert.Equal(t, "1", req.params.Get("wait_for_async_insert"))
+	assert.Equal(t, "tracker", req.user)
+	assert.Equal(t, "pw", req.key)
+
+	var rows []clickHouseRow
+	scanner := bufio.NewScanner(strings.NewReader(req.body))
+	for scanner.Scan() {
+		var row clickHouseRow
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, clickHouseRow{
+		QueryID: "q2", ResultID: "r2", Position: 2, Page: 1,
+		DestinationHash: "desthash", SessionID: "sess2", UserAgentHash: "uahash",
+		GeneratedAt: "2026-03-23 10:00:00.000", ClickedAt: "2026-03-23 10:00:01.000",
+		DestinationHost: "example.com", Channel: "northern-news", UTMSource: "newsletter",
+	}, rows[1])
+}
+
+func TestClickHouseWriter_EmptyBatch(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "")
+
+	require.NoError(t, NewClickHouseWriter(ch).WriteEvents(context.Background(), nil))
+	assert.Empty(t, *requests)
+}
+
+func TestClickHouseWriter_ServerError(t *testing.T) {
+	t.Helper()
+
+	ch, _ := newFakeClickHouse(t, http.StatusInternalServerError, "Code: 60. DB::Exception: Unknown table\n")
+
+	err := NewClickHouseWriter(ch).WriteEvents(context.Background(), []domain.ClickEvent{testEvent("q1", "r1")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "clickhouse status 500: Code: 60. DB::Exception: Unknown table")
+}
+
+func TestClickHouse_EnsureSchema(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "")
+
+	require.NoError(t, ch.EnsureSchema(context.Background()))
+
+	require.Len(t, *requests, 3+len(clickHouseColumnAdditions))
+	assert.Equal(t, "CREATE DATABASE IF NOT EXISTS `clicks`", (*requests)[0].params.Get("query"))
+	assert.Empty(t, (*requests)[0].params.Get("database"))
+	assert.Contains(t, (*requests)[1].params.Get("query"), "CREATE TABLE IF NOT EXISTS click_events")
+	assert.Equal(t, "clicks", (*requests)[1].params.Get("database"))
+	assert.Contains(t, (*requests)[2].params.Get("query"), "ADD COLUMN IF NOT EXISTS destination_host")
+	assert.Contains(t, (*requests)[len(*requests)-1].params.Get("query"), "CREATE TABLE IF NOT EXISTS impression_events")
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	t.Helper()
+
+	assert.Equal(t, "`click_tracker`", quoteIdentifier("click_tracker"))
+	assert.Equal(t, "`clicks\\`; DROP`", quoteIdentifier("clicks`; DROP"))
+	assert.Equal(t, "`a\\\\b`", quoteIdentifier(`a\b`))
+}
+
+func TestClickHouse_SetAnonymizationTTL(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "")
+
+	require.NoError(t, ch.SetAnonymizationTTL(context.Background(), 30))
+
+	require.Len(t, *requests, 3)
+	assert.Equal(t,
+		"ALTER TABLE click_events MODIFY COLUMN session_id String TTL toDateTime(clicked_at) + INTERVAL 30 DAY",
+		(*requests)[0].params.Get("query"))
+	assert.Contains(t, (*requests)[1].params.Get("query"), "MODIFY COLUMN user_agent_hash String TTL")
+	assert.Equal(t,
+		"ALTER TABLE impression_events MODIFY COLUMN session_id String TTL toDateTime(shown_at) + INTERVAL 30 DAY",
+		(*requests)[2].params.Get("query"))
+}
+
+func TestClickHouseStatsReader_TopResults(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK,
+		`{"result_id":"doc-2","clicks":12,"unique_sessions":9}`+"\n"+
+			`{"result_id":"doc-1","clicks":3,"unique_sessions":3}`+"\n")
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(7 * 24 * time.Hour)
+	results, err := NewClickHouseStatsReader(ch).TopResults(
+		context.Background(), []string{"doc-1", "it's"}, since, until, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []ResultClicks{
+		{ResultID: "doc-2", Clicks: 12, UniqueSessions: 9},
+		{ResultID: "doc-1", Clicks: 3, UniqueSessions: 3},
+	}, results)
+
+	require.Len(t, *requests, 1)
+	params := (*requests)[0].params
+	assert.Equal(t, `['doc-1','it\'s']`, params.Get("param_ids"))
+	assert.Equal(t, "2026-03-02 00:00:00.000", params.Get("param_since"))
+	assert.Equal(t, "2026-03-09 00:00:00.000", params.Get("param_until"))
+	assert.Equal(t, "5", params.Get("param_limit"))
+	assert.Equal(t, "0", params.Get("output_format_json_quote_64bit_integers"))
+}
+
+func TestClickHouseStatsReader_TopResults_NoIDs(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "")
+
+	results, err := NewClickHouseStatsReader(ch).TopResults(context.Background(), nil, time.Now(), time.Now(), 5)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, *requests)
+}
+
+func TestClickHouseStatsReader_SessionResults(t *testing.T) {
+	t.Helper()
+
+	last := time.Date(2026, 3, 4, 8, 30, 0, 0, time.UTC)
+	ch, requests := newFakeClickHouse(t, http.StatusOK,
+		`{"result_id":"doc-3","clicks":4,"last_clicked_ms":`+strconv.FormatInt(last.UnixMilli(), 10)+`}`+"\n")
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	results, err := NewClickHouseStatsReader(ch).SessionResults(context.Background(), "sess_1", since, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []SessionClicks{{ResultID: "doc-3", Clicks: 4, LastClickedAt: last}}, results)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "sess_1", (*requests)[0].params.Get("param_session_id"))
+}
+
+func TestClickHouseStatsReader_BadRow(t *testing.T) {
+	t.Helper()
+
+	ch, _ := newFakeClickHouse(t, http.StatusOK, `{"result_id":"doc-3","clicks":"4"}`)
+
+	_, err := NewClickHouseStatsReader(ch).SessionResults(context.Background(), "sess_1", time.Now(), 50)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decode row")
+}
+
+func TestClickHouseStatsReader_SourceTotals(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK,
+		`{"id":"example.com","clicks":14,"unique_sessions":12,"position_sum":46}`+"\n")
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	totals, err := NewClickHouseStatsReader(ch).SourceTotals(context.Background(), since, since.Add(time.Hour), 3, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []ClickTotals{{ID: "example.com", Clicks: 14, UniqueSessions: 12, PositionSum: 46}}, totals)
+
+	require.Len(t, *requests, 1)
+	params := (*requests)[0].params
+	assert.Contains(t, params.Get("query"), "GROUP BY destination_host, toStartOfHour(clicked_at)")
+	assert.Equal(t, "3", params.Get("param_min_clicks"))
+	assert.Equal(t, "1000", params.Get("param_limit"))
+}
+
+func TestClickHouseStatsReader_CampaignResults(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK,
+		`{"channel":"","utm_source":"newsletter","utm_medium":"email","utm_campaign":"weekly",`+
+			`"clicks":14,"unique_sessions":11}`+"\n")
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	results, err := NewClickHouseStatsReader(ch).CampaignResults(context.Background(), "", since, since.Add(time.Hour), 20)
+	require.NoError(t, err)
+	assert.Equal(t, []CampaignClicks{{
+		UTMSource: "newsletter", UTMMedium: "email", UTMCampaign: "weekly", Clicks: 14, UniqueSessions: 11,
+	}}, results)
+
+	require.Len(t, *requests, 1)
+	params := (*requests)[0].params
+	assert.True(t, params.Has("param_channel"))
+	assert.Empty(t, params.Get("param_channel"))
+	assert.Equal(t, "20", params.Get("param_limit"))
+}
+
+func TestClickHouseWriter_WriteImpressions(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "")
+
+	impressions := []domain.ImpressionEvent{testImpression("q1", "r1")}
+	require.NoError(t, NewClickHouseWriter(ch).WriteImpressions(context.Background(), impressions))
+	require.NoError(t, NewClickHouseWriter(ch).WriteImpressions(context.Background(), nil))
+
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	assert.Equal(t, "INSERT INTO impression_events FORMAT JSONEachRow", req.params.Get("query"))
+	assert.Equal(t, "1", req.params.Get("async_insert"))
+
+	var row clickHouseImpressionRow
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(req.body)), &row))
+	assert.Equal(t, clickHouseImpressionRow{
+		QueryID: "q1", ResultID: "r1", Position: 1, Page: 1,
+		DestinationHost: "example.com", SessionID: "sess1", Channel: "northern-news",
+		GeneratedAt: "2026-03-23 10:00:00.000", ShownAt: "2026-03-23 10:00:00.000",
+	}, row)
+}
+
+func TestClickHouseStatsReader_ImpressionResults(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK,
+		`{"result_id":"doc-1","impressions":80,"clicks":6}`+"\n"+
+			`{"result_id":"doc-2","impressions":25,"clicks":0}`+"\n")
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	results, err := NewClickHouseStatsReader(ch).ImpressionResults(
+		context.Background(), "northern-news", since, since.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []ResultImpressions{
+		{ResultID: "doc-1", Impressions: 80, Clicks: 6, CTR: 0.075},
+		{ResultID: "doc-2", Impressions: 25},
+	}, results)
+
+	require.Len(t, *requests, 1)
+	params := (*requests)[0].params
+	assert.Contains(t, params.Get("query"), "(query_id, result_id) IN")
+	assert.Equal(t, "northern-news", params.Get("param_channel"))
+	assert.Equal(t, "10", params.Get("param_limit"))
+}
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats.
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatParquet = "parquet"
+)
+
+// ErrExportFormat is returned for an export format the backend cannot write.
+// Callers check SupportsExportFormat before starting an export, so requests
+// for such a format are refused before anything is read.
+var ErrExportFormat = errors.New("export format not supported by the storage backend")
+
+// exportColumns are the click_events columns exported, in order. Both
+// backends write the same header so exports can be combined.
+var exportColumns = []string{
+	"clicked_at", "generated_at", "query_id", "result_id", "position", "page",
+	"destination_hash", "destination_host", "session_id", "user_agent_hash",
+	"channel", "utm_source", "utm_medium", "utm_campaign",
+}
+
+// exportTimeFormat matches ClickHouse's ISO DateTime64(3) output.
+const exportTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// exportDateFormat is the date-only form ParseExportTime accepts.
+const exportDateFormat = "2006-01-02"
+
+// exportFileTimeFormat is how export file names write their range.
+const exportFileTimeFormat = "20060102T150405Z"
+
+// exportFlushRows is how many CSV rows are buffered before flushing to the writer.
+const exportFlushRows = 1000
+
+// ParseExportTime parses an export range bound given as an RFC 3339 time or
+// a YYYY-MM-DD date, which means midnight UTC.
+func ParseExportTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(exportDateFormat, raw)
+}
+
+// ExportFileName names an export of [since, until) in format, e.g.
+// clicks_20260301T000000Z_20260401T000000Z.csv.
+func ExportFileName(since, until time.Time, format string) string {
+	return fmt.Sprintf("clicks_%s_%s.%s",
+		since.UTC().Format(exportFileTimeFormat), until.UTC().Format(exportFileTimeFormat), format)
+}
+
+// exportEventsQuery reads events in clicked_at order; anonymized identifiers
+// are exported as empty strings.
+const exportEventsQuery = `
+	SELECT clicked_at, generated_at, query_id, result_id, position, page,
+		destination_hash, destination_host, COALESCE(session_id, ''), COALESCE(user_agent_hash, ''),
+		channel, utm_source, utm_medium, utm_campaign
+	FROM click_events
+	WHERE clicked_at >= $1 AND clicked_at < $2
+	ORDER BY clicked_at, id
+`
+
+// SupportsExportFormat reports whether ExportEvents can write format.
+// PostgreSQL exports CSV only; Parquet needs the ClickHouse backend.
+func (r *StatsReader) SupportsExportFormat(format string) bool {
+	return format == ExportFormatCSV
+}
+
+// ExportEvents streams the raw events clicked between since and until to w
+// as CSV with a header row, oldest first. Events already purged are not
+// exported.
+func (r *StatsReader) ExportEvents(ctx context.Context, since, until time.Time, format string, w io.Writer) error {
+	if !r.SupportsExportFormat(format) {
+		return ErrExportFormat
+	}
+
+	rows, err := r.db.QueryContext(ctx, exportEventsQuery, since, until)
+	if err != nil {
+		return fmt.Errorf("query events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := csv.NewWriter(w)
+	if writeErr := out.Write(exportColumns); writeErr != nil {
+		return fmt.Errorf("write header: %w", writeErr)
+	}
+
+	record := make([]string, len(exportColumns))
+	var written int
+	for rows.Next() {
+		var (
+			clickedAt, generatedAt time.Time
+			position, page         int
+		)
+		if scanErr := rows.Scan(
+			&clickedAt, &generatedAt, &record[2], &record[3], &position, &page,
+			&record[6], &record[7], &record[8], &record[9],
+			&record[10], &record[11], &record[12], &record[13],
+		); scanErr != nil {
+			return fmt.Errorf("scan: %w", scanErr)
+		}
+		record[0] = clickedAt.UTC().Format(exportTimeFormat)
+		record[1] = generatedAt.UTC().Format(exportTimeFormat)
+		record[4] = strconv.Itoa(position)
+		record[5] = strconv.Itoa(page)
+
+		if writeErr := out.Write(record); writeErr != nil {
+			return fmt.Errorf("write row: %w", writeErr)
+		}
+		if written++; written%exportFlushRows == 0 {
+			out.Flush()
+			if flushErr := out.Error(); flushErr != nil {
+				return fmt.Errorf("write rows: %w", flushErr)
+			}
+		}
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return fmt.Errorf("iterate: %w", rowsErr)
+	}
+
+	out.Flush()
+	if flushErr := out.Error(); flushErr != nil {
+		return fmt.Errorf("write rows: %w", flushErr)
+	}
+	return nil
+}
+
+// clickHouseExportFormats maps export formats to ClickHouse output formats.
+var clickHouseExportFormats = map[string]string{
+	ExportFormatCSV:     "CSVWithNames",
+	ExportFormatParquet: "Parquet",
+}
+
+// SupportsExportFormat reports whether ExportEvents can write format.
+// ClickHouse writes both CSV and Parquet itself.
+func (r *ClickHouseStatsReader) SupportsExportFormat(format string) bool {
+	_, ok := clickHouseExportFormats[format]
+	return ok
+}
+
+// ExportEvents streams the events clicked between since and until to w in
+// format, oldest first. ClickHouse encodes the output, so rows are not
+// decoded here.
+func (r *ClickHouseStatsReader) ExportEvents(
+	ctx context.Context, since, until time.Time, format string, w io.Writer,
+) error {
+	chFormat, ok := clickHouseExportFormats[format]
+	if !ok {
+		return ErrExportFormat
+	}
+
+	query := "SELECT " + strings.Join(exportColumns, ", ") + `
+		FROM click_events
+		WHERE clicked_at >= {since:DateTime64(3, 'UTC')} AND clicked_at < {until:DateTime64(3, 'UTC')}
+		ORDER BY clicked_at
+		FORMAT ` + chFormat
+
+	params := r.ch.params()
+	params.Set("param_since", since.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_until", until.UTC().Format(clickHouseTimeFormat))
+	params.Set("date_time_output_format", "iso")
+
+	body, err := r.ch.doStream(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("query events: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	if _, copyErr := io.Copy(w, body); copyErr != nil {
+		return fmt.Errorf("copy events: %w", copyErr)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsReader_ExportEvents(t *testing.T) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	clicked := since.Add(10*time.Hour + 1500*time.Millisecond)
+	mock.ExpectQuery("FROM click_events").
+		WithArgs(since, until).
+		WillReturnRows(sqlmock.NewRows(exportColumns).
+			AddRow(clicked, clicked.Add(-time.Second), "q1", "doc-1", 2, 1,
+				"desthash", "example.com", "sess_1", "uahash", "", "newsletter", "email", "weekly, march").
+			AddRow(clicked, clicked, "q2", "doc-2", 1, 1,
+				"desthash", "example.com", "", "", "northern-news", "", "", ""))
+
+	var out bytes.Buffer
+	require.NoError(t, NewStatsReader(db).ExportEvents(context.Background(), since, until, ExportFormatCSV, &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, strings.Join(exportColumns, ","), lines[0])
+	assert.Equal(t, `2026-03-02T10:00:01.500Z,2026-03-02T10:00:00.500Z,q1,doc-1,2,1,`+
+		`desthash,example.com,sess_1,uahash,,newsletter,email,"weekly, march"`, lines[1])
+	assert.Equal(t, "2026-03-02T10:00:01.500Z,2026-03-02T10:00:01.500Z,q2,doc-2,1,1,"+
+		"desthash,example.com,,,northern-news,,,", lines[2])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsReader_ExportEvents_Parquet(t *testing.T) {
+	t.Helper()
+
+	reader := NewStatsReader(nil)
+	assert.False(t, reader.SupportsExportFormat(ExportFormatParquet))
+
+	err := reader.ExportEvents(context.Background(), time.Now(), time.Now(), ExportFormatParquet, &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrExportFormat)
+}
+
+func TestClickHouseStatsReader_ExportEvents(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "PAR1 parquet bytes")
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	reader := NewClickHouseStatsReader(ch)
+	require.True(t, reader.SupportsExportFormat(ExportFormatParquet))
+	require.NoError(t, reader.ExportEvents(context.Background(), since, since.Add(time.Hour), ExportFormatParquet, &out))
+	assert.Equal(t, "PAR1 parquet bytes", out.String())
+
+	require.Len(t, *requests, 1)
+	params := (*requests)[0].params
+	assert.True(t, strings.HasSuffix(params.Get("query"), "FORMAT Parquet"))
+	assert.Contains(t, params.Get("query"), "SELECT clicked_at, generated_at, query_id")
+	assert.Equal(t, "2026-03-02 00:00:00.000", params.Get("param_since"))
+	assert.Equal(t, "2026-03-02 01:00:00.000", params.Get("param_until"))
+	assert.Equal(t, "iso", params.Get("date_time_output_format"))
+}
+
+func TestClickHouseStatsReader_ExportEvents_UnknownFormat(t *testing.T) {
+	t.Helper()
+
+	ch, requests := newFakeClickHouse(t, http.StatusOK, "")
+
+	err := NewClickHouseStatsReader(ch).ExportEvents(context.Background(), time.Now(), time.Now(), "xlsx", &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrExportFormat)
+	assert.Empty(t, *requests)
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/config"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// FanoutEventField is the stream entry field holding the JSON event: a
+// domain.ClickEvent on the click stream, a domain.ImpressionEvent on the
+// impression stream.
+const FanoutEventField = "event"
+
+// EventPublisher publishes batches of accepted events to other consumers.
+type EventPublisher interface {
+	PublishEvents(ctx context.Context, events []domain.ClickEvent) error
+	PublishImpressions(ctx context.Context, impressions []domain.ImpressionEvent) error
+}
+
+// StreamPublisher appends events to Redis streams, one entry per event, in a
+// single round trip per batch. Streams are trimmed to about maxLen entries,
+// so consumers that fall further behind miss events.
+type StreamPublisher struct {
+	client           *redis.Client
+	stream           string
+	impressionStream string
+	maxLen           int64
+}
+
+// NewStreamPublisher creates a StreamPublisher for the configured streams.
+func NewStreamPublisher(client *redis.Client, cfg *config.FanoutConfig) *StreamPublisher {
+	return &StreamPublisher{
+		client:           client,
+		stream:           cfg.Stream,
+		impressionStream: cfg.ImpressionStream,
+		maxLen:           cfg.MaxLen,
+	}
+}
+
+// PublishEvents appends events to the click stream.
+func (p *StreamPublisher) PublishEvents(ctx context.Context, events []domain.ClickEvent) error {
+	return publishEntries(ctx, p, p.stream, events)
+}
+
+// PublishImpressions appends impressions to the impression stream, if one is
+// configured.
+func (p *StreamPublisher) PublishImpressions(ctx context.Context, impressions []domain.ImpressionEvent) error {
+	if p.impressionStream == "" {
+		return nil
+	}
+	return publishEntries(ctx, p, p.impressionStream, impressions)
+}
+
+// publishEntries XADDs one entry per item to stream in a pipeline.
+func publishEntries[T any](ctx context.Context, p *StreamPublisher, stream string, items []T) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	pipe := p.client.Pipeline()
+	for i := range items {
+		payload, err := json.Marshal(&items[i])
+		if err != nil {
+			return fmt.Errorf("encode %s entry: %w", stream, err)
+		}
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: stream,
+			MaxLen: p.maxLen,
+			Approx: true,
+			Values: map[string]any{FanoutEventField: payload},
+		})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("add to stream %s: %w", stream, err)
+	}
+	return nil
+}
+
+// FanoutWriter writes each batch to an EventWriter, then publishes it. The
+// batch is published whether or not the write succeeded, as it holds
+// accepted events either way. A failed publish is logged and not retried,
+// so it never fails the write.
+type FanoutWriter struct {
+	writer    EventWriter
+	publisher EventPublisher
+	log       infralogger.Logger
+}
+
+// NewFanoutWriter creates a FanoutWriter.
+func NewFanoutWriter(writer EventWriter, publisher EventPublisher, log infralogger.Logger) *FanoutWriter {
+	return &FanoutWriter{writer: writer, publisher: publisher, log: log}
+}
+
+// WriteEvents writes then publishes events, returning the write's error.
+func (w *FanoutWriter) WriteEvents(ctx context.Context, events []domain.ClickEvent) error {
+	err := w.writer.WriteEvents(ctx, events)
+	if publishErr := w.publisher.PublishEvents(ctx, events); publishErr != nil {
+		w.log.Error("Failed to publish click events",
+			infralogger.Error(publishErr),
+			infralogger.Int("batch_size", len(events)),
+		)
+	}
+	return err
+}
+
+// WriteImpressions writes then publishes impressions, returning the write's error.
+func (w *FanoutWriter) WriteImpressions(ctx context.Context, impressions []domain.ImpressionEvent) error {
+	err := w.writer.WriteImpressions(ctx, impressions)
+	if publishErr := w.publisher.PublishImpressions(ctx, impressions); publishErr != nil {
+		w.log.Error("Failed to publish impression events",
+			infralogger.Error(publishErr),
+			infralogger.Int("batch_size", len(impressions)),
+		)
+	}
+	return err
+}
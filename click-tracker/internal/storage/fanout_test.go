@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/config"
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingWriter is an EventWriter that records batches and returns err.
+type recordingWriter struct {
+	events      []domain.ClickEvent
+	impressions []domain.ImpressionEvent
+	err         error
+}
+
+func (w *recordingWriter) WriteEvents(_ context.Context, events []domain.ClickEvent) error {
+	w.events = append(w.events, events...)
+	return w.err
+}
+
+func (w *recordingWriter) WriteImpressions(_ context.Context, impressions []domain.ImpressionEvent) error {
+	w.impressions = append(w.impressions, impressions...)
+	return w.err
+}
+
+func newTestPublisher(t *testing.T, impressionStream string) (*StreamPublisher, *redis.Client) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewStreamPublisher(client, &config.FanoutConfig{
+		Stream:           "click-events",
+		ImpressionStream: impressionStream,
+		MaxLen:           100,
+	}), client
+}
+
+func TestStreamPublisher_PublishEvents(t *testing.T) {
+	t.Helper()
+
+	publisher, client := newTestPublisher(t, "")
+	ctx := context.Background()
+
+	require.NoError(t, publisher.PublishEvents(ctx, []domain.ClickEvent{testEvent("q1", "r1"), testEvent("q1", "r2")}))
+	require.NoError(t, publisher.PublishEvents(ctx, nil))
+
+	entries, err := client.XRange(ctx, "click-events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	var event domain.ClickEvent
+	require.NoError(t, json.Unmarshal([]byte(entries[1].Values[FanoutEventField].(string)), &event))
+	assert.Equal(t, "q1", event.QueryID)
+	assert.Equal(t, "r2", event.ResultID)
+}
+
+func TestStreamPublisher_PublishImpressions(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+	impressions := []domain.ImpressionEvent{testImpression("q1", "r1")}
+
+	// Without an impression stream, impressions are not published
+	publisher, client := newTestPublisher(t, "")
+	require.NoError(t, publisher.PublishImpressions(ctx, impressions))
+	exists, err := client.Exists(ctx, "click-events").Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+
+	publisher, client = newTestPublisher(t, "impression-events")
+	require.NoError(t, publisher.PublishImpressions(ctx, impressions))
+
+	entries, err := client.XRange(ctx, "impression-events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	var impression domain.ImpressionEvent
+	require.NoError(t, json.Unmarshal([]byte(entries[0].Values[FanoutEventField].(string)), &impression))
+	assert.Equal(t, "r1", impression.ResultID)
+}
+
+func TestFanoutWriter_PublishesAfterFailedWrite(t *testing.T) {
+	t.Helper()
+
+	publisher, client := newTestPublisher(t, "")
+	primary := &recordingWriter{err: errors.New("db down")}
+	writer := NewFanoutWriter(primary, publisher, infralogger.NewNop())
+	ctx := context.Background()
+
+	err := writer.WriteEvents(ctx, []domain.ClickEvent{testEvent("q1", "r1")})
+	require.ErrorIs(t, err, primary.err)
+	assert.Len(t, primary.events, 1)
+
+	count, err := client.XLen(ctx, "click-events").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestFanoutWriter_PublishFailureDoesNotFailWrite(t *testing.T) {
+	t.Helper()
+
+	publisher, client := newTestPublisher(t, "impression-events")
+	require.NoError(t, client.Close())
+
+	primary := &recordingWriter{}
+	writer := NewFanoutWriter(primary, publisher, infralogger.NewNop())
+	ctx := context.Background()
+
+	require.NoError(t, writer.WriteEvents(ctx, []domain.ClickEvent{testEvent("q1", "r1")}))
+	require.NoError(t, writer.WriteImpressions(ctx, []domain.ImpressionEvent{testImpression("q1", "r1")}))
+	assert.Len(t, primary.events, 1)
+	assert.Len(t, primary.impressions, 1)
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ResultImpressions is how often one result was shown and clicked. Only
+// clicks on links whose impression was recorded count, matched by query and
+// result ID, so results also linked from places without the impression
+// pixel do not get a rate above what readers actually saw.
+type ResultImpressions struct {
+	ResultID    string  `json:"result_id"`
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
+
+// setCTR computes the click-through rate from the counts.
+func (ri *ResultImpressions) setCTR() {
+	if ri.Impressions > 0 {
+		ri.CTR = float64(ri.Clicks) / float64(ri.Impressions)
+	}
+}
+
+// impressionResultsQuery reads raw events only: rollups keep no query IDs.
+// A link is one query and result ID pair; the clicks counted are those on
+// links shown in the range.
+const impressionResultsQuery = `
+	WITH shown AS (
+		SELECT result_id, COUNT(*)::BIGINT AS impressions
+		FROM impression_events
+		WHERE shown_at >= $1 AND shown_at < $2
+		  AND ($3::TEXT = '' OR channel = $3::TEXT)
+		GROUP BY result_id
+		ORDER BY impressions DESC, result_id
+		LIMIT $4
+	), clicked AS (
+		SELECT c.result_id, COUNT(*)::BIGINT AS clicks
+		FROM click_events c
+		WHERE c.clicked_at >= $1 AND c.clicked_at < $2
+		  AND c.result_id IN (SELECT result_id FROM shown)
+		  AND (c.query_id, c.result_id) IN (
+			SELECT query_id, result_id FROM impression_events
+			WHERE shown_at >= $1 AND shown_at < $2
+			  AND ($3::TEXT = '' OR channel = $3::TEXT)
+		  )
+		GROUP BY c.result_id
+	)
+	SELECT s.result_id, s.impressions, COALESCE(k.clicks, 0)
+	FROM shown s LEFT JOIN clicked k ON k.result_id = s.result_id
+	ORDER BY s.impressions DESC, s.result_id
+`
+
+// ImpressionResults returns the most-shown results between since and until
+// with their clicks and click-through rate, most impressions first. A
+// non-empty channel restricts them to impressions in that channel.
+func (r *StatsReader) ImpressionResults(
+	ctx context.Context, channel string, since, until time.Time, limit int,
+) ([]ResultImpressions, error) {
+	rows, err := r.db.QueryContext(ctx, impressionResultsQuery, since, until, channel, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query impressions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := []ResultImpressions{}
+	for rows.Next() {
+		var ri ResultImpressions
+		if scanErr := rows.Scan(&ri.ResultID, &ri.Impressions, &ri.Clicks); scanErr != nil {
+			return nil, fmt.Errorf("scan impressions: %w", scanErr)
+		}
+		ri.setCTR()
+		results = append(results, ri)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("iterate impressions: %w", rowsErr)
+	}
+	return results, nil
+}
+
+// clickHouseImpressionResultsQuery is impressionResultsQuery for ClickHouse;
+// the LEFT JOIN fills results without clicks with 0.
+const clickHouseImpressionResultsQuery = `
+	SELECT result_id, impressions, clicks
+	FROM (
+		SELECT result_id, count() AS impressions
+		FROM impression_events
+		WHERE shown_at >= {since:DateTime64(3, 'UTC')} AND shown_at < {until:DateTime64(3, 'UTC')}
+		  AND ({channel:String} = '' OR channel = {channel:String})
+		GROUP BY result_id
+		ORDER BY impressions DESC, result_id
+		LIMIT {limit:UInt32}
+	) AS shown
+	LEFT JOIN (
+		SELECT result_id, count() AS clicks
+		FROM click_events
+		WHERE clicked_at >= {since:DateTime64(3, 'UTC')} AND clicked_at < {until:DateTime64(3, 'UTC')}
+		  AND (query_id, result_id) IN (
+			SELECT query_id, result_id FROM impression_events
+			WHERE shown_at >= {since:DateTime64(3, 'UTC')} AND shown_at < {until:DateTime64(3, 'UTC')}
+			  AND ({channel:String} = '' OR channel = {channel:String})
+		  )
+		GROUP BY result_id
+	) AS clicked USING (result_id)
+	ORDER BY impressions DESC, result_id
+	FORMAT JSONEachRow
+`
+
+// ImpressionResults returns the most-shown results between since and until
+// with their clicks and click-through rate, most impressions first. A
+// non-empty channel restricts them to impressions in that channel.
+func (r *ClickHouseStatsReader) ImpressionResults(
+	ctx context.Context, channel string, since, until time.Time, limit int,
+) ([]ResultImpressions, error) {
+	params := r.queryParams()
+	params.Set("param_channel", clickHouseEscapeParam(channel))
+	params.Set("param_since", since.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_until", until.UTC().Format(clickHouseTimeFormat))
+	params.Set("param_limit", strconv.Itoa(limit))
+
+	results := []ResultImpressions{}
+	err := r.query(ctx, clickHouseImpressionResultsQuery, params, func(dec *json.Decoder) error {
+		var ri ResultImpressions
+		if decodeErr := dec.Decode(&ri); decodeErr != nil {
+			return decodeErr
+		}
+		ri.setCTR()
+		results = append(results, ri)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query impressions: %w", err)
+	}
+	return results, nil
+}
@@ -3,230 +3,164 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
-	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
 // Named constants to avoid magic numbers.
 const (
-	// columnsPerRow is the number of columns inserted per click event row.
-	columnsPerRow = 9
+	// columnsPerRow is the number of columns inserted per click event row,
+	// the length of clickEventColumns.
+	columnsPerRow = 14
+
+	// impressionColumnsPerRow is the length of impressionEventColumns.
+	impressionColumnsPerRow = 9
 
 	// insertBatchSize is the maximum number of rows per INSERT statement.
 	insertBatchSize = 50
-
-	// flushTimeout is the context timeout for each flush operation.
-	flushTimeout = 5 * time.Second
 )
 
-// Buffer is a channel-based event buffer for non-blocking click event ingestion.
-type Buffer struct {
-	events chan domain.ClickEvent
-	closed chan struct{}
-	once   sync.Once
+// clickEventColumns are the click_events columns inserted per event, in the
+// order batchInsert appends their values.
+var clickEventColumns = []string{
+	"query_id", "result_id", "position", "page",
+	"destination_hash", "session_id", "user_agent_hash",
+	"generated_at", "clicked_at", "destination_host",
+	"channel", "utm_source", "utm_medium", "utm_campaign",
 }
 
-// NewBuffer creates a buffer with a buffered channel of the given capacity.
-func NewBuffer(capacity int) *Buffer {
-	return &Buffer{
-		events: make(chan domain.ClickEvent, capacity),
-		closed: make(chan struct{}),
-	}
+// impressionEventColumns are the impression_events columns inserted per
+// impression, in the order insertImpressions appends their values.
+var impressionEventColumns = []string{
+	"query_id", "result_id", "position", "page", "destination_host",
+	"session_id", "channel", "generated_at", "shown_at",
 }
 
-// Send performs a non-blocking send of an event into the buffer.
-// It returns false if the buffer channel is full.
-func (b *Buffer) Send(event domain.ClickEvent) bool {
-	select {
-	case b.events <- event:
-		return true
-	default:
-		return false
-	}
+// PostgresWriter writes click events to the PostgreSQL click_events table
+// and impressions to impression_events.
+// It suits low-volume deployments; see ClickHouseWriter for high volume.
+type PostgresWriter struct {
+	db *sql.DB
 }
 
-// Len returns the number of events currently in the buffer channel.
-func (b *Buffer) Len() int {
-	return len(b.events)
+// NewPostgresWriter creates a PostgresWriter.
+func NewPostgresWriter(db *sql.DB) *PostgresWriter {
+	return &PostgresWriter{db: db}
 }
 
-// Close signals the buffer to stop accepting events.
-// It is safe to call multiple times.
-func (b *Buffer) Close() {
-	b.once.Do(func() {
-		close(b.closed)
-	})
-}
+// WriteEvents inserts events in chunks of insertBatchSize. A failed chunk
+// does not stop the rest; the failures are returned together.
+func (w *PostgresWriter) WriteEvents(ctx context.Context, events []domain.ClickEvent) error {
+	var errs []error
+	for start := 0; start < len(events); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(events))
 
-// Store manages buffered writes of click events to PostgreSQL.
-type Store struct {
-	db             *sql.DB
-	buffer         *Buffer
-	log            infralogger.Logger
-	flushInterval  time.Duration
-	flushThreshold int
-	wg             sync.WaitGroup
+		if err := w.batchInsert(ctx, events[start:end]); err != nil {
+			errs = append(errs, fmt.Errorf("insert rows %d-%d: %w", start, end-1, err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
-// NewStore creates a new Store that reads events from buffer and batch-inserts them.
-func NewStore(
-	db *sql.DB,
-	buffer *Buffer,
-	log infralogger.Logger,
-	flushInterval time.Duration,
-	flushThreshold int,
-) *Store {
-	return &Store{
-		db:             db,
-		buffer:         buffer,
-		log:            log,
-		flushInterval:  flushInterval,
-		flushThreshold: flushThreshold,
+// batchInsert builds and executes a single INSERT statement with multiple value tuples.
+func (w *PostgresWriter) batchInsert(ctx context.Context, events []domain.ClickEvent) error {
+	if len(events) == 0 {
+		return nil
 	}
-}
 
-// Start launches the background goroutine that reads events and flushes batches.
-func (s *Store) Start() {
-	s.wg.Add(1)
-	go s.flushLoop()
-}
+	args := make([]any, 0, len(events)*columnsPerRow)
+	var sb strings.Builder
 
-// Stop signals the buffer to close and waits for the flush goroutine to finish.
-func (s *Store) Stop() {
-	s.buffer.Close()
-	s.wg.Wait()
-}
+	sb.WriteString("INSERT INTO click_events (" + strings.Join(clickEventColumns, ", ") + ") VALUES ")
 
-// flushLoop reads events from the buffer, accumulates a batch, and flushes
-// when the batch reaches flushThreshold or the flushInterval ticker fires.
-func (s *Store) flushLoop() {
-	defer s.wg.Done()
-
-	ticker := time.NewTicker(s.flushInterval)
-	defer ticker.Stop()
-
-	batch := make([]domain.ClickEvent, 0, s.flushThreshold)
-
-	for {
-		select {
-		case event := <-s.buffer.events:
-			batch = append(batch, event)
-			if len(batch) >= s.flushThreshold {
-				s.flush(batch)
-				batch = make([]domain.ClickEvent, 0, s.flushThreshold)
-			}
-
-		case <-ticker.C:
-			if len(batch) > 0 {
-				s.flush(batch)
-				batch = make([]domain.ClickEvent, 0, s.flushThreshold)
-			}
-
-		case <-s.buffer.closed:
-			s.drain(&batch)
-			if len(batch) > 0 {
-				s.flush(batch)
-			}
-			return
+	for i := range events {
+		if i > 0 {
+			sb.WriteString(", ")
 		}
+
+		writeValueTuple(&sb, i)
+
+		args = append(args,
+			events[i].QueryID, events[i].ResultID, events[i].Position, events[i].Page,
+			events[i].DestinationHash, events[i].SessionID, events[i].UserAgentHash,
+			events[i].GeneratedAt, events[i].ClickedAt, events[i].DestinationHost,
+			events[i].Channel, events[i].UTMSource, events[i].UTMMedium, events[i].UTMCampaign,
+		)
 	}
-}
 
-// drain reads all remaining events from the buffer channel into the batch.
-func (s *Store) drain(batch *[]domain.ClickEvent) {
-	for {
-		select {
-		case event := <-s.buffer.events:
-			*batch = append(*batch, event)
-		default:
-			return
-		}
+	_, err := w.db.ExecContext(ctx, sb.String(), args...)
+	if err != nil {
+		return fmt.Errorf("exec batch insert: %w", err)
 	}
-}
 
-// flush writes a batch of events to PostgreSQL in chunks of insertBatchSize.
-func (s *Store) flush(batch []domain.ClickEvent) {
-	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
-	defer cancel()
+	return nil
+}
 
-	for start := 0; start < len(batch); start += insertBatchSize {
-		end := start + insertBatchSize
-		if end > len(batch) {
-			end = len(batch)
-		}
+// WriteImpressions inserts impressions in chunks of insertBatchSize. A
+// failed chunk does not stop the rest; the failures are returned together.
+func (w *PostgresWriter) WriteImpressions(ctx context.Context, impressions []domain.ImpressionEvent) error {
+	var errs []error
+	for start := 0; start < len(impressions); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(impressions))
 
-		if err := s.batchInsert(ctx, batch[start:end]); err != nil {
-			s.log.Error("Failed to insert click events",
-				infralogger.Error(err),
-				infralogger.Int("batch_size", end-start),
-			)
+		if err := w.insertImpressions(ctx, impressions[start:end]); err != nil {
+			errs = append(errs, fmt.Errorf("insert impression rows %d-%d: %w", start, end-1, err))
 		}
 	}
-
-	s.log.Debug("Flushed click events",
-		infralogger.Int("total", len(batch)),
-	)
+	return errors.Join(errs...)
 }
 
-// batchInsert builds and executes a single INSERT statement with multiple value tuples.
-func (s *Store) batchInsert(ctx context.Context, events []domain.ClickEvent) error {
-	if len(events) == 0 {
+// insertImpressions executes a single multi-row INSERT into impression_events.
+func (w *PostgresWriter) insertImpressions(ctx context.Context, impressions []domain.ImpressionEvent) error {
+	if len(impressions) == 0 {
 		return nil
 	}
 
-	args := make([]any, 0, len(events)*columnsPerRow)
+	args := make([]any, 0, len(impressions)*impressionColumnsPerRow)
 	var sb strings.Builder
 
-	sb.WriteString("INSERT INTO click_events (query_id, result_id, position, page, " +
-		"destination_hash, session_id, user_agent_hash, generated_at, clicked_at) VALUES ")
+	sb.WriteString("INSERT INTO impression_events (" + strings.Join(impressionEventColumns, ", ") + ") VALUES ")
 
-	for i := range events {
+	for i := range impressions {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
 
-		writeValueTuple(&sb, i)
+		writePlaceholders(&sb, i, impressionColumnsPerRow)
 
 		args = append(args,
-			events[i].QueryID, events[i].ResultID, events[i].Position, events[i].Page,
-			events[i].DestinationHash, events[i].SessionID, events[i].UserAgentHash,
-			events[i].GeneratedAt, events[i].ClickedAt,
+			impressions[i].QueryID, impressions[i].ResultID, impressions[i].Position, impressions[i].Page,
+			impressions[i].DestinationHost, impressions[i].SessionID, impressions[i].Channel,
+			impressions[i].GeneratedAt, impressions[i].ShownAt,
 		)
 	}
 
-	_, err := s.db.ExecContext(ctx, sb.String(), args...)
-	if err != nil {
-		return fmt.Errorf("exec batch insert: %w", err)
+	if _, err := w.db.ExecContext(ctx, sb.String(), args...); err != nil {
+		return fmt.Errorf("exec impression insert: %w", err)
 	}
 
 	return nil
 }
 
-// Placeholder column offsets within a single row tuple (1-indexed for PostgreSQL $N params).
-const (
-	colQueryID         = 1
-	colResultID        = 2
-	colPosition        = 3
-	colPage            = 4
-	colDestinationHash = 5
-	colSessionID       = 6
-	colUserAgentHash   = 7
-	colGeneratedAt     = 8
-	colClickedAt       = 9
-)
+// writeValueTuple writes a single ($1, $2, ..., $N) placeholder tuple of
+// columnsPerRow parameters to the builder, offset by the row index.
+func writeValueTuple(sb *strings.Builder, rowIndex int) {
+	writePlaceholders(sb, rowIndex, columnsPerRow)
+}
 
-// writeValueTuple writes a single ($1, $2, ..., $9) placeholder tuple to the builder,
+// writePlaceholders writes a placeholder tuple of columns parameters,
 // offset by the row index.
-func writeValueTuple(sb *strings.Builder, rowIndex int) {
-	base := rowIndex * columnsPerRow
-	fmt.Fprintf(sb, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
-		base+colQueryID, base+colResultID, base+colPosition, base+colPage,
-		base+colDestinationHash, base+colSessionID, base+colUserAgentHash,
-		base+colGeneratedAt, base+colClickedAt,
-	)
+func writePlaceholders(sb *strings.Builder, rowIndex, columns int) {
+	base := rowIndex * columns
+	sb.WriteByte('(')
+	for col := 1; col <= columns; col++ {
+		if col > 1 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(sb, "$%d", base+col)
+	}
+	sb.WriteByte(')')
 }
@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// DefaultRetentionBatchSize is the most rows anonymized or purged per statement.
+const DefaultRetentionBatchSize = 5000
+
+// RetentionPolicy is how long raw click events and impressions keep their
+// visitor identifiers and how long they are kept at all. Hourly rollups are
+// never purged.
+type RetentionPolicy struct {
+	// AnonymizeAfter is the age after which session_id and user_agent_hash are cleared.
+	AnonymizeAfter time.Duration
+	// RawEventAge is the age after which raw events are deleted.
+	RawEventAge time.Duration
+	// Interval is the time between runs.
+	Interval time.Duration
+	// BatchSize bounds the rows changed per statement; DefaultRetentionBatchSize if 0.
+	BatchSize int
+}
+
+// RetentionResult reports what one retention run changed. Anonymized and
+// Purged count click events and impressions together.
+type RetentionResult struct {
+	Rollup     *BackfillProgress
+	Anonymized int64
+	Purged     int64
+}
+
+// RetentionJob applies a RetentionPolicy to the PostgreSQL click_events table
+// on a schedule. Each run first catches the hourly rollup up, so purged hours
+// keep their aggregates and anonymized hours keep their unique session
+// counts; a run whose rollup fails or is held by another instance changes
+// nothing. Cutoffs are truncated to the hour so no hour is partly purged.
+type RetentionJob struct {
+	db         *sql.DB
+	backfiller *RollupBackfiller
+	policy     RetentionPolicy
+	log        infralogger.Logger
+	now        func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRetentionJob creates a retention job for the PostgreSQL backend.
+func NewRetentionJob(db *sql.DB, policy RetentionPolicy, log infralogger.Logger) *RetentionJob {
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = DefaultRetentionBatchSize
+	}
+	return &RetentionJob{
+		db:         db,
+		backfiller: NewRollupBackfiller(db, BackfillConfig{Pause: DefaultBackfillPause}),
+		policy:     policy,
+		log:        log,
+		now:        time.Now,
+	}
+}
+
+// Start runs the job now and then every policy interval until Stop.
+func (j *RetentionJob) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.policy.Interval)
+		defer ticker.Stop()
+
+		for {
+			j.runLogged(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop cancels a run in progress and waits for the job to exit. Batches
+// already committed stay applied.
+func (j *RetentionJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
+
+// runLogged runs once and logs the outcome.
+func (j *RetentionJob) runLogged(ctx context.Context) {
+	result, err := j.RunOnce(ctx)
+	switch {
+	case errors.Is(err, ErrBackfillLocked):
+		j.log.Info("Click retention skipped: rollup backfill running elsewhere")
+	case errors.Is(err, context.Canceled):
+		return
+	case err != nil:
+		j.log.Error("Click retention run failed",
+			infralogger.Error(err),
+			infralogger.Int64("anonymized", result.Anonymized),
+			infralogger.Int64("purged", result.Purged),
+		)
+	default:
+		j.log.Info("Click retention run completed",
+			infralogger.Int64("anonymized", result.Anonymized),
+			infralogger.Int64("purged", result.Purged),
+		)
+	}
+}
+
+// RunOnce rolls up complete hours, then anonymizes and purges raw events
+// and impressions older than the policy allows.
+func (j *RetentionJob) RunOnce(ctx context.Context) (RetentionResult, error) {
+	var result RetentionResult
+
+	progress, err := j.backfiller.Run(ctx)
+	if err != nil {
+		return result, fmt.Errorf("roll up before retention: %w", err)
+	}
+	result.Rollup = progress
+
+	now := j.now().UTC()
+	anonymizeBefore := now.Add(-j.policy.AnonymizeAfter).Truncate(time.Hour)
+	result.Anonymized, err = j.inBatches(ctx, anonymizeEventsQuery, anonymizeBefore)
+	if err != nil {
+		return result, fmt.Errorf("anonymize click events: %w", err)
+	}
+	anonymized, err := j.inBatches(ctx, anonymizeImpressionsQuery, anonymizeBefore)
+	result.Anonymized += anonymized
+	if err != nil {
+		return result, fmt.Errorf("anonymize impressions: %w", err)
+	}
+
+	// Impressions have no rollup to wait for
+	rawBefore := now.Add(-j.policy.RawEventAge).Truncate(time.Hour)
+	purgeBefore := rawBefore
+	if progress.Cursor != nil && progress.Cursor.Before(purgeBefore) {
+		purgeBefore = *progress.Cursor
+	}
+	result.Purged, err = j.inBatches(ctx, purgeEventsQuery, purgeBefore)
+	if err != nil {
+		return result, fmt.Errorf("purge click events: %w", err)
+	}
+	purged, err := j.inBatches(ctx, purgeImpressionsQuery, rawBefore)
+	result.Purged += purged
+	if err != nil {
+		return result, fmt.Errorf("purge impressions: %w", err)
+	}
+
+	return result, nil
+}
+
+// anonymizeEventsQuery clears the visitor identifiers of up to $2 events
+// clicked before $1.
+const anonymizeEventsQuery = `
+	UPDATE click_events SET session_id = NULL, user_agent_hash = NULL
+	WHERE clicked_at < $1 AND id IN (
+		SELECT id FROM click_events
+		WHERE clicked_at < $1 AND (session_id IS NOT NULL OR user_agent_hash IS NOT NULL)
+		LIMIT $2
+	)
+`
+
+// purgeEventsQuery deletes up to $2 events clicked before $1.
+const purgeEventsQuery = `
+	DELETE FROM click_events
+	WHERE clicked_at < $1 AND id IN (
+		SELECT id FROM click_events WHERE clicked_at < $1 LIMIT $2
+	)
+`
+
+// anonymizeImpressionsQuery clears the session IDs of up to $2 impressions
+// shown before $1.
+const anonymizeImpressionsQuery = `
+	UPDATE impression_events SET session_id = ''
+	WHERE shown_at < $1 AND id IN (
+		SELECT id FROM impression_events
+		WHERE shown_at < $1 AND session_id <> ''
+		LIMIT $2
+	)
+`
+
+// purgeImpressionsQuery deletes up to $2 impressions shown before $1.
+const purgeImpressionsQuery = `
+	DELETE FROM impression_events
+	WHERE shown_at < $1 AND id IN (
+		SELECT id FROM impression_events WHERE shown_at < $1 LIMIT $2
+	)
+`
+
+// inBatches runs query with (before, batch size) until it changes fewer rows
+// than the batch size, so no statement holds locks on many rows.
+func (j *RetentionJob) inBatches(ctx context.Context, query string, before time.Time) (int64, error) {
+	var total int64
+	for {
+		res, err := j.db.ExecContext(ctx, query, before, j.policy.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("count rows: %w", err)
+		}
+		total += rows
+		if rows < int64(j.policy.BatchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetentionJob(t *testing.T, policy RetentionPolicy, now time.Time) (*RetentionJob, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	j := NewRetentionJob(db, policy, infralogger.NewNop())
+	j.now = func() time.Time { return now }
+	j.backfiller.now = j.now
+	return j, mock
+}
+
+// expectCaughtUpRollup expects a backfill run with nothing left to roll up.
+func expectCaughtUpRollup(mock sqlmock.Sqlmock, now time.Time) {
+	hour := now.Truncate(time.Hour)
+	mock.ExpectQuery("pg_try_advisory_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery("FROM rollup_backfills").WillReturnRows(sqlmock.NewRows(progressColumns).AddRow(
+		HourlyRollupName, BackfillStatusCompleted, hour.Add(-time.Hour), hour, hour, 4, 120,
+		nil, now, now, now,
+	))
+	mock.ExpectExec("INSERT INTO rollup_backfills").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE rollup_backfills").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestRetentionJob_RunOnce(t *testing.T) {
+	t.Helper()
+
+	now := time.Date(2026, 10, 16, 12, 40, 0, 0, time.UTC)
+	j, mock := newTestRetentionJob(t, RetentionPolicy{
+		AnonymizeAfter: 30 * 24 * time.Hour,
+		RawEventAge:    90 * 24 * time.Hour,
+		BatchSize:      100,
+	}, now)
+
+	expectCaughtUpRollup(mock, now)
+
+	anonymizeBefore := time.Date(2026, 9, 16, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec("UPDATE click_events SET session_id = NULL").
+		WithArgs(anonymizeBefore, 100).WillReturnResult(sqlmock.NewResult(0, 100))
+	mock.ExpectExec("UPDATE click_events SET session_id = NULL").
+		WithArgs(anonymizeBefore, 100).WillReturnResult(sqlmock.NewResult(0, 7))
+	mock.ExpectExec("UPDATE impression_events SET session_id = ''").
+		WithArgs(anonymizeBefore, 100).WillReturnResult(sqlmock.NewResult(0, 20))
+
+	purgeBefore := time.Date(2026, 7, 18, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec("DELETE FROM click_events").
+		WithArgs(purgeBefore, 100).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM impression_events").
+		WithArgs(purgeBefore, 100).WillReturnResult(sqlmock.NewResult(0, 5))
+
+	result, err := j.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(127), result.Anonymized)
+	assert.Equal(t, int64(8), result.Purged)
+	assert.Equal(t, BackfillStatusCompleted, result.Rollup.Status)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetentionJob_SkipsWhenRollupLocked(t *testing.T) {
+	t.Helper()
+
+	now := time.Date(2026, 10, 16, 12, 40, 0, 0, time.UTC)
+	j, mock := newTestRetentionJob(t, RetentionPolicy{AnonymizeAfter: time.Hour, RawEventAge: time.Hour}, now)
+
+	mock.ExpectQuery("pg_try_advisory_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+
+	result, err := j.RunOnce(context.Background())
+	require.ErrorIs(t, err, ErrBackfillLocked)
+	assert.Zero(t, result.Anonymized)
+	assert.Zero(t, result.Purged)
+	require.NoError(t, mock.ExpectationsWereMet(), "nothing is anonymized or purged before the rollup")
+}
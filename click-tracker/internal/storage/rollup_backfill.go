@@ -0,0 +1,330 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Rollup backfill defaults and identifiers.
+const (
+	// HourlyRollupName identifies the click_rollups_hourly backfill in rollup_backfills.
+	HourlyRollupName = "click_rollups_hourly"
+
+	// DefaultBackfillWindow is the clicked_at span rolled up per batch.
+	DefaultBackfillWindow = 6 * time.Hour
+	// DefaultBackfillPause is the sleep between batches.
+	DefaultBackfillPause = 100 * time.Millisecond
+
+	percentComplete = 100
+
+	// backfillLockKey is the pg advisory lock that keeps backfills single-run.
+	backfillLockKey = 7_290_001
+)
+
+// Backfill statuses stored in rollup_backfills.status.
+const (
+	BackfillStatusRunning   = "running"
+	BackfillStatusFailed    = "failed"
+	BackfillStatusCompleted = "completed"
+)
+
+// ErrBackfillLocked is returned when another process is already running a backfill.
+var ErrBackfillLocked = errors.New("another rollup backfill is running")
+
+// BackfillProgress is the persisted state of a rollup backfill.
+type BackfillProgress struct {
+	RollupName   string
+	Status       string
+	RangeStart   *time.Time
+	RangeEnd     *time.Time
+	Cursor       *time.Time
+	Batches      int64
+	RowsUpserted int64
+	LastError    string
+	StartedAt    time.Time
+	UpdatedAt    time.Time
+	CompletedAt  *time.Time
+}
+
+// Percent returns how much of the range has been processed, from 0 to 100.
+func (p *BackfillProgress) Percent() float64 {
+	if p.RangeStart == nil || p.RangeEnd == nil || p.Cursor == nil {
+		return 0
+	}
+	total := p.RangeEnd.Sub(*p.RangeStart)
+	if total <= 0 {
+		return percentComplete
+	}
+	return min(float64(p.Cursor.Sub(*p.RangeStart))/float64(total)*percentComplete, percentComplete)
+}
+
+// BackfillConfig tunes a rollup backfill.
+type BackfillConfig struct {
+	// Window is the span of clicked_at processed per batch; rounded to whole hours.
+	Window time.Duration
+	// Pause is the sleep between batches to limit load on the events table; 0 disables.
+	Pause time.Duration
+	// Restart discards saved progress and starts from the oldest raw event.
+	Restart bool
+	// OnBatch, if set, is called after each committed batch.
+	OnBatch func(*BackfillProgress)
+}
+
+// RollupBackfiller fills click_rollups_hourly from historical click_events in
+// hour-aligned batches. Each batch and its progress update commit together,
+// so an interrupted run resumes from the last committed hour. Re-running a
+// completed backfill catches up to the latest complete hour.
+//
+// The backfill is retention-safe: it only upserts buckets that still have raw
+// events and never deletes rollup rows, so hours whose raw events were
+// already purged keep their rolled-up values. Re-rolling an hour never lowers
+// unique_sessions, which anonymized events no longer count.
+type RollupBackfiller struct {
+	db  *sql.DB
+	cfg BackfillConfig
+	now func() time.Time
+}
+
+// NewRollupBackfiller creates a backfiller for the hourly click rollup.
+func NewRollupBackfiller(db *sql.DB, cfg BackfillConfig) *RollupBackfiller {
+	if cfg.Window < time.Hour {
+		cfg.Window = DefaultBackfillWindow
+	}
+	cfg.Window = cfg.Window.Truncate(time.Hour)
+	return &RollupBackfiller{db: db, cfg: cfg, now: time.Now}
+}
+
+// Status returns the saved progress, or nil if no backfill has run.
+func (b *RollupBackfiller) Status(ctx context.Context) (*BackfillProgress, error) {
+	return loadProgress(ctx, b.db, HourlyRollupName)
+}
+
+// Run backfills up to the start of the current hour. It holds an advisory
+// lock for the duration and returns ErrBackfillLocked if another run holds it.
+func (b *RollupBackfiller) Run(ctx context.Context) (*BackfillProgress, error) {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	var locked bool
+	if lockErr := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", backfillLockKey).Scan(&locked); lockErr != nil {
+		return nil, fmt.Errorf("acquire backfill lock: %w", lockErr)
+	}
+	if !locked {
+		return nil, ErrBackfillLocked
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", backfillLockKey)
+	}()
+
+	progress, err := b.prepare(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	for progress.Cursor.Before(*progress.RangeEnd) {
+		windowEnd := progress.Cursor.Add(b.cfg.Window)
+		if windowEnd.After(*progress.RangeEnd) {
+			windowEnd = *progress.RangeEnd
+		}
+
+		if batchErr := b.runBatch(ctx, conn, progress, windowEnd); batchErr != nil {
+			b.recordFailure(conn, batchErr)
+			return progress, batchErr
+		}
+		if b.cfg.OnBatch != nil {
+			b.cfg.OnBatch(progress)
+		}
+
+		if b.cfg.Pause > 0 && progress.Cursor.Before(*progress.RangeEnd) {
+			select {
+			case <-ctx.Done():
+				return progress, ctx.Err()
+			case <-time.After(b.cfg.Pause):
+			}
+		}
+	}
+
+	completedAt := b.now().UTC()
+	if _, execErr := conn.ExecContext(ctx, `
+		UPDATE rollup_backfills
+		SET status = $2, completed_at = $3, updated_at = $3, last_error = NULL
+		WHERE rollup_name = $1`,
+		HourlyRollupName, BackfillStatusCompleted, completedAt,
+	); execErr != nil {
+		return progress, fmt.Errorf("mark backfill completed: %w", execErr)
+	}
+	progress.Status = BackfillStatusCompleted
+	progress.CompletedAt = &completedAt
+
+	return progress, nil
+}
+
+// prepare loads or initializes progress and extends the range to the
+// current hour.
+func (b *RollupBackfiller) prepare(ctx context.Context, conn *sql.Conn) (*BackfillProgress, error) {
+	rangeEnd := b.now().UTC().Truncate(time.Hour)
+
+	progress, err := loadProgress(ctx, conn, HourlyRollupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if progress == nil || b.cfg.Restart || progress.Cursor == nil {
+		var oldest sql.NullTime
+		if scanErr := conn.QueryRowContext(ctx, "SELECT MIN(clicked_at) FROM click_events").Scan(&oldest); scanErr != nil {
+			return nil, fmt.Errorf("find oldest click event: %w", scanErr)
+		}
+		rangeStart := rangeEnd
+		if oldest.Valid {
+			rangeStart = oldest.Time.UTC().Truncate(time.Hour)
+		}
+		cursor := rangeStart
+		progress = &BackfillProgress{
+			RollupName: HourlyRollupName,
+			RangeStart: &rangeStart,
+			Cursor:     &cursor,
+		}
+	}
+	progress.RangeEnd = &rangeEnd
+	progress.Status = BackfillStatusRunning
+	progress.LastError = ""
+	progress.CompletedAt = nil
+
+	if _, execErr := conn.ExecContext(ctx, `
+		INSERT INTO rollup_backfills
+			(rollup_name, status, range_start, range_end, cursor_at, batches, rows_upserted,
+			 last_error, started_at, updated_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULL, NOW(), NOW(), NULL)
+		ON CONFLICT (rollup_name) DO UPDATE SET
+			status = EXCLUDED.status,
+			range_start = EXCLUDED.range_start,
+			range_end = EXCLUDED.range_end,
+			cursor_at = EXCLUDED.cursor_at,
+			batches = EXCLUDED.batches,
+			rows_upserted = EXCLUDED.rows_upserted,
+			last_error = NULL,
+			updated_at = NOW(),
+			completed_at = NULL`,
+		progress.RollupName, progress.Status, progress.RangeStart, progress.RangeEnd,
+		progress.Cursor, progress.Batches, progress.RowsUpserted,
+	); execErr != nil {
+		return nil, fmt.Errorf("save backfill progress: %w", execErr)
+	}
+
+	return progress, nil
+}
+
+// runBatch rolls up [cursor, windowEnd) and advances the cursor in one transaction.
+func (b *RollupBackfiller) runBatch(
+	ctx context.Context,
+	conn *sql.Conn,
+	progress *BackfillProgress,
+	windowEnd time.Time,
+) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin batch: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO click_rollups_hourly (bucket_start, result_id, clicks, unique_sessions, position_sum, updated_at)
+		SELECT date_trunc('hour', clicked_at), result_id, COUNT(*), COUNT(DISTINCT session_id), SUM(position), NOW()
+		FROM click_events
+		WHERE clicked_at >= $1 AND clicked_at < $2
+		GROUP BY 1, 2
+		ON CONFLICT (bucket_start, result_id) DO UPDATE SET
+			clicks = EXCLUDED.clicks,
+			unique_sessions = GREATEST(click_rollups_hourly.unique_sessions, EXCLUDED.unique_sessions),
+			position_sum = EXCLUDED.position_sum,
+			updated_at = EXCLUDED.updated_at`,
+		*progress.Cursor, windowEnd,
+	)
+	if err != nil {
+		return fmt.Errorf("roll up %s to %s: %w", progress.Cursor.Format(time.RFC3339), windowEnd.Format(time.RFC3339), err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("count rolled up rows: %w", err)
+	}
+
+	if _, execErr := tx.ExecContext(ctx, `
+		UPDATE rollup_backfills
+		SET cursor_at = $2, batches = batches + 1, rows_upserted = rows_upserted + $3, updated_at = NOW()
+		WHERE rollup_name = $1`,
+		HourlyRollupName, windowEnd, rows,
+	); execErr != nil {
+		return fmt.Errorf("save backfill progress: %w", execErr)
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("commit batch: %w", commitErr)
+	}
+
+	progress.Cursor = &windowEnd
+	progress.Batches++
+	progress.RowsUpserted += rows
+	return nil
+}
+
+// recordFailure stores the error so the status command can report it.
+func (b *RollupBackfiller) recordFailure(conn *sql.Conn, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	_, _ = conn.ExecContext(ctx, `
+		UPDATE rollup_backfills SET status = $2, last_error = $3, updated_at = NOW()
+		WHERE rollup_name = $1`,
+		HourlyRollupName, BackfillStatusFailed, cause.Error(),
+	)
+}
+
+// queryRower is satisfied by *sql.DB and *sql.Conn.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func loadProgress(ctx context.Context, q queryRower, name string) (*BackfillProgress, error) {
+	var (
+		p           BackfillProgress
+		rangeStart  sql.NullTime
+		rangeEnd    sql.NullTime
+		cursor      sql.NullTime
+		lastError   sql.NullString
+		completedAt sql.NullTime
+	)
+	err := q.QueryRowContext(ctx, `
+		SELECT rollup_name, status, range_start, range_end, cursor_at, batches, rows_upserted,
+		       last_error, started_at, updated_at, completed_at
+		FROM rollup_backfills WHERE rollup_name = $1`, name,
+	).Scan(
+		&p.RollupName, &p.Status, &rangeStart, &rangeEnd, &cursor, &p.Batches, &p.RowsUpserted,
+		&lastError, &p.StartedAt, &p.UpdatedAt, &completedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil // no backfill has run yet
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load backfill progress: %w", err)
+	}
+
+	p.RangeStart = nullTimePtr(rangeStart)
+	p.RangeEnd = nullTimePtr(rangeEnd)
+	p.Cursor = nullTimePtr(cursor)
+	p.CompletedAt = nullTimePtr(completedAt)
+	p.LastError = lastError.String
+	return &p, nil
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.UTC()
+	return &v
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var progressColumns = []string{
+	"rollup_name", "status", "range_start", "range_end", "cursor_at", "batches", "rows_upserted",
+	"last_error", "started_at", "updated_at", "completed_at",
+}
+
+func newTestBackfiller(t *testing.T, cfg BackfillConfig, now time.Time) (*RollupBackfiller, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	b := NewRollupBackfiller(db, cfg)
+	b.now = func() time.Time { return now }
+	return b, mock
+}
+
+func expectBatch(mock sqlmock.Sqlmock, from, to time.Time, rows int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO click_rollups_hourly").
+		WithArgs(from, to).
+		WillReturnResult(sqlmock.NewResult(0, rows))
+	mock.ExpectExec("UPDATE rollup_backfills").
+		WithArgs(HourlyRollupName, to, rows).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestRollupBackfiller_RunFromOldestEvent(t *testing.T) {
+	t.Helper()
+
+	now := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	oldest := time.Date(2026, 3, 1, 1, 15, 0, 0, time.UTC)
+	b, mock := newTestBackfiller(t, BackfillConfig{Window: 6 * time.Hour}, now)
+
+	mock.ExpectQuery("pg_try_advisory_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery("FROM rollup_backfills").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("MIN\\(clicked_at\\)").WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(oldest))
+	mock.ExpectExec("INSERT INTO rollup_backfills").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	start := time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC)
+	mid := time.Date(2026, 3, 1, 7, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	expectBatch(mock, start, mid, 40)
+	expectBatch(mock, mid, end, 25)
+
+	mock.ExpectExec("UPDATE rollup_backfills").
+		WithArgs(HourlyRollupName, BackfillStatusCompleted, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	var batches int
+	b.cfg.OnBatch = func(*BackfillProgress) { batches++ }
+
+	progress, err := b.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, BackfillStatusCompleted, progress.Status)
+	assert.Equal(t, int64(2), progress.Batches)
+	assert.Equal(t, int64(65), progress.RowsUpserted)
+	assert.Equal(t, 2, batches)
+	assert.InDelta(t, 100.0, progress.Percent(), 0.001)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRollupBackfiller_ResumesFromSavedCursor(t *testing.T) {
+	t.Helper()
+
+	now := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	start := time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC)
+	cursor := time.Date(2026, 3, 1, 7, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	b, mock := newTestBackfiller(t, BackfillConfig{Window: 6 * time.Hour}, now)
+
+	mock.ExpectQuery("pg_try_advisory_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery("FROM rollup_backfills").WillReturnRows(sqlmock.NewRows(progressColumns).AddRow(
+		HourlyRollupName, BackfillStatusFailed, start, cursor, cursor, 1, 40,
+		"connection reset", start, cursor, nil,
+	))
+	mock.ExpectExec("INSERT INTO rollup_backfills").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectBatch(mock, cursor, end, 25)
+	mock.ExpectExec("UPDATE rollup_backfills").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	progress, err := b.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), progress.Batches)
+	assert.Equal(t, int64(65), progress.RowsUpserted)
+	assert.Empty(t, progress.LastError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRollupBackfiller_Locked(t *testing.T) {
+	t.Helper()
+
+	b, mock := newTestBackfiller(t, BackfillConfig{}, time.Now())
+	mock.ExpectQuery("pg_try_advisory_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+
+	_, err := b.Run(context.Background())
+	require.ErrorIs(t, err, ErrBackfillLocked)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewRollupBackfiller_WindowRoundedToHours(t *testing.T) {
+	t.Helper()
+
+	assert.Equal(t, DefaultBackfillWindow, NewRollupBackfiller(nil, BackfillConfig{Window: time.Minute}).cfg.Window)
+	assert.Equal(t, 2*time.Hour, NewRollupBackfiller(nil, BackfillConfig{Window: 150 * time.Minute}).cfg.Window)
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// MaxStatsResultIDs bounds how many result IDs one stats query may ask for.
+const MaxStatsResultIDs = 1000
+
+// ResultClicks is the click total for one result over a time range.
+type ResultClicks struct {
+	ResultID string `json:"result_id"`
+	Clicks   int64  `json:"clicks"`
+	// UniqueSessions is summed per hour, so a session clicking in two hours counts twice.
+	UniqueSessions int64 `json:"unique_sessions"`
+}
+
+// StatsReader answers aggregate click queries.
+type StatsReader struct {
+	db *sql.DB
+}
+
+// NewStatsReader creates a StatsReader.
+func NewStatsReader(db *sql.DB) *StatsReader {
+	return &StatsReader{db: db}
+}
+
+// resultClicksQuery combines hourly rollups with raw events from hours that
+// have not been rolled up yet, so totals stay correct once raw events are purged.
+const resultClicksQuery = `
+	WITH rolled AS (
+		SELECT result_id, SUM(clicks) AS clicks, SUM(unique_sessions) AS sessions
+		FROM click_rollups_hourly
+		WHERE result_id = ANY($1) AND bucket_start >= $2 AND bucket_start < $3
+		GROUP BY result_id
+	), raw AS (
+		SELECT e.result_id, COUNT(*) AS clicks, COUNT(DISTINCT e.session_id) AS sessions
+		FROM click_events e
+		WHERE e.result_id = ANY($1) AND e.clicked_at >= $2 AND e.clicked_at < $3
+		  AND NOT EXISTS (
+			SELECT 1 FROM click_rollups_hourly r
+			WHERE r.result_id = e.result_id AND r.bucket_start = date_trunc('hour', e.clicked_at)
+		  )
+		GROUP BY e.result_id
+	)
+	SELECT result_id, SUM(clicks)::BIGINT, SUM(sessions)::BIGINT
+	FROM (SELECT * FROM rolled UNION ALL SELECT * FROM raw) totals
+	GROUP BY result_id
+	ORDER BY 2 DESC, result_id
+	LIMIT $4
+`
+
+// TopResults returns the most-clicked of resultIDs between since and until,
+// most clicks first. Results without clicks are omitted.
+func (r *StatsReader) TopResults(
+	ctx context.Context, resultIDs []string, since, until time.Time, limit int,
+) ([]ResultClicks, error) {
+	results := []ResultClicks{}
+	if len(resultIDs) == 0 {
+		return results, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, resultClicksQuery, pq.Array(resultIDs), since, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query result clicks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var rc ResultClicks
+		if scanErr := rows.Scan(&rc.ResultID, &rc.Clicks, &rc.UniqueSessions); scanErr != nil {
+			return nil, fmt.Errorf("scan result clicks: %w", scanErr)
+		}
+		results = append(results, rc)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("iterate result clicks: %w", rowsErr)
+	}
+	return results, nil
+}
+
+// SessionClicks is how often one session clicked one result.
+type SessionClicks struct {
+	ResultID      string    `json:"result_id"`
+	Clicks        int64     `json:"clicks"`
+	LastClickedAt time.Time `json:"last_clicked_at"`
+}
+
+// sessionClicksQuery reads raw events only: rollups do not keep sessions.
+const sessionClicksQuery = `
+	SELECT result_id, COUNT(*)::BIGINT, MAX(clicked_at)
+	FROM click_events
+	WHERE session_id = $1 AND clicked_at >= $2
+	GROUP BY result_id
+	ORDER BY 2 DESC, 3 DESC
+	LIMIT $3
+`
+
+// SessionResults returns the results a session clicked since the given time,
+// most clicks first. Events purged from click_events are not counted.
+func (r *StatsReader) SessionResults(
+	ctx context.Context, sessionID string, since time.Time, limit int,
+) ([]SessionClicks, error) {
+	rows, err := r.db.QueryContext(ctx, sessionClicksQuery, sessionID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query session clicks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := []SessionClicks{}
+	for rows.Next() {
+		var sc SessionClicks
+		if scanErr := rows.Scan(&sc.ResultID, &sc.Clicks, &sc.LastClickedAt); scanErr != nil {
+			return nil, fmt.Errorf("scan session clicks: %w", scanErr)
+		}
+		results = append(results, sc)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("iterate session clicks: %w", rowsErr)
+	}
+	return results, nil
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsReader_TopResults(t *testing.T) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(7 * 24 * time.Hour)
+	mock.ExpectQuery("FROM click_rollups_hourly").
+		WithArgs(sqlmock.AnyArg(), since, until, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"result_id", "clicks", "sessions"}).
+			AddRow("doc-2", 12, 9).
+			AddRow("doc-1", 3, 3))
+
+	results, err := NewStatsReader(db).TopResults(context.Background(), []string{"doc-1", "doc-2"}, since, until, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []ResultClicks{
+		{ResultID: "doc-2", Clicks: 12, UniqueSessions: 9},
+		{ResultID: "doc-1", Clicks: 3, UniqueSessions: 3},
+	}, results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsReader_TopResults_NoIDs(t *testing.T) {
+	t.Helper()
+
+	results, err := NewStatsReader(nil).TopResults(context.Background(), nil, time.Now(), time.Now(), 5)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestStatsReader_SessionResults(t *testing.T) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	last := since.Add(48 * time.Hour)
+	mock.ExpectQuery("WHERE session_id = \\$1").
+		WithArgs("sess_1", since, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"result_id", "clicks", "last_clicked_at"}).
+			AddRow("doc-3", 4, last))
+
+	results, err := NewStatsReader(db).SessionResults(context.Background(), "sess_1", since, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []SessionClicks{{ResultID: "doc-3", Clicks: 4, LastClickedAt: last}}, results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsReader_ArticleTotals(t *testing.T) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(30 * 24 * time.Hour)
+	mock.ExpectQuery("HAVING SUM\\(clicks\\) >= \\$3").
+		WithArgs(since, until, 3, 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"result_id", "clicks", "sessions", "positions"}).
+			AddRow("doc-2", 12, 9, 30))
+
+	totals, err := NewStatsReader(db).ArticleTotals(context.Background(), since, until, 3, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []ClickTotals{{ID: "doc-2", Clicks: 12, UniqueSessions: 9, PositionSum: 30}}, totals)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsReader_SourceTotals(t *testing.T) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(30 * 24 * time.Hour)
+	mock.ExpectQuery("destination_host <> ''").
+		WithArgs(since, until, 3, 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"destination_host", "clicks", "sessions", "positions"}))
+
+	totals, err := NewStatsReader(db).SourceTotals(context.Background(), since, until, 3, 1000)
+	require.NoError(t, err)
+	assert.Empty(t, totals)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsReader_CampaignResults(t *testing.T) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(7 * 24 * time.Hour)
+	mock.ExpectQuery("GROUP BY channel, utm_source, utm_medium, utm_campaign").
+		WithArgs(since, until, "northern-news", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"channel", "utm_source", "utm_medium", "utm_campaign", "clicks", "sessions"}).
+			AddRow("northern-news", "newsletter", "email", "weekly", 14, 11))
+
+	results, err := NewStatsReader(db).CampaignResults(context.Background(), "northern-news", since, until, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []CampaignClicks{{
+		Channel: "northern-news", UTMSource: "newsletter", UTMMedium: "email", UTMCampaign: "weekly",
+		Clicks: 14, UniqueSessions: 11,
+	}}, results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsReader_ImpressionResults(t *testing.T) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(7 * 24 * time.Hour)
+	mock.ExpectQuery("FROM impression_events").
+		WithArgs(since, until, "", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"result_id", "impressions", "clicks"}).
+			AddRow("doc-1", 200, 15).
+			AddRow("doc-2", 40, 0))
+
+	results, err := NewStatsReader(db).ImpressionResults(context.Background(), "", since, until, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []ResultImpressions{
+		{ResultID: "doc-1", Impressions: 200, Clicks: 15, CTR: 0.075},
+		{ResultID: "doc-2", Impressions: 40},
+	}, results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// flushTimeout is the context timeout for each flush operation.
+const flushTimeout = 5 * time.Second
+
+// EventWriter persists batches of click and impression events to a storage backend.
+type EventWriter interface {
+	WriteEvents(ctx context.Context, events []domain.ClickEvent) error
+	WriteImpressions(ctx context.Context, impressions []domain.ImpressionEvent) error
+}
+
+// Buffer is a channel-based event buffer for non-blocking click and
+// impression event ingestion. Clicks and impressions have separate channels
+// of the same capacity, so a burst of impressions cannot crowd out clicks.
+type Buffer struct {
+	events      chan domain.ClickEvent
+	impressions chan domain.ImpressionEvent
+	closed      chan struct{}
+	once        sync.Once
+}
+
+// NewBuffer creates a buffer with buffered channels of the given capacity.
+func NewBuffer(capacity int) *Buffer {
+	return &Buffer{
+		events:      make(chan domain.ClickEvent, capacity),
+		impressions: make(chan domain.ImpressionEvent, capacity),
+		closed:      make(chan struct{}),
+	}
+}
+
+// Send performs a non-blocking send of an event into the buffer.
+// It returns false if the buffer channel is full.
+func (b *Buffer) Send(event domain.ClickEvent) bool {
+	select {
+	case b.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// SendImpression performs a non-blocking send of an impression into the
+// buffer. It returns false if the impression channel is full.
+func (b *Buffer) SendImpression(impression domain.ImpressionEvent) bool {
+	select {
+	case b.impressions <- impression:
+		return true
+	default:
+		return false
+	}
+}
+
+// Len returns the number of click events currently in the buffer channel.
+func (b *Buffer) Len() int {
+	return len(b.events)
+}
+
+// ImpressionLen returns the number of impressions currently in the buffer.
+func (b *Buffer) ImpressionLen() int {
+	return len(b.impressions)
+}
+
+// Close signals the buffer to stop accepting events.
+// It is safe to call multiple times.
+func (b *Buffer) Close() {
+	b.once.Do(func() {
+		close(b.closed)
+	})
+}
+
+// Store manages buffered writes of click and impression events to an EventWriter.
+type Store struct {
+	writer         EventWriter
+	buffer         *Buffer
+	log            infralogger.Logger
+	flushInterval  time.Duration
+	flushThreshold int
+	clock          clock.Clock
+	wg             sync.WaitGroup
+}
+
+// NewStore creates a new Store that reads events from buffer and hands them
+// to writer in batches.
+func NewStore(
+	writer EventWriter,
+	buffer *Buffer,
+	log infralogger.Logger,
+	flushInterval time.Duration,
+	flushThreshold int,
+) *Store {
+	return &Store{
+		writer:         writer,
+		buffer:         buffer,
+		log:            log,
+		flushInterval:  flushInterval,
+		flushThreshold: flushThreshold,
+		clock:          clock.Real(),
+	}
+}
+
+// Start launches the background goroutine that reads events and flushes batches.
+func (s *Store) Start() {
+	s.wg.Add(1)
+	go s.flushLoop()
+}
+
+// Stop signals the buffer to close and waits for the flush goroutine to finish.
+func (s *Store) Stop() {
+	s.buffer.Close()
+	s.wg.Wait()
+}
+
+// flushLoop reads events and impressions from the buffer, accumulates a
+// batch of each, and flushes a batch when it reaches flushThreshold or the
+// flushInterval ticker fires.
+func (s *Store) flushLoop() {
+	defer s.wg.Done()
+
+	ticker := s.clock.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.ClickEvent, 0, s.flushThreshold)
+	impressions := make([]domain.ImpressionEvent, 0, s.flushThreshold)
+
+	for {
+		select {
+		case event := <-s.buffer.events:
+			batch = append(batch, event)
+			if len(batch) >= s.flushThreshold {
+				s.flush(batch)
+				batch = make([]domain.ClickEvent, 0, s.flushThreshold)
+			}
+
+		case impression := <-s.buffer.impressions:
+			impressions = append(impressions, impression)
+			if len(impressions) >= s.flushThreshold {
+				s.flushImpressions(impressions)
+				impressions = make([]domain.ImpressionEvent, 0, s.flushThreshold)
+			}
+
+		case <-ticker.C():
+			if len(batch) > 0 {
+				s.flush(batch)
+				batch = make([]domain.ClickEvent, 0, s.flushThreshold)
+			}
+			if len(impressions) > 0 {
+				s.flushImpressions(impressions)
+				impressions = make([]domain.ImpressionEvent, 0, s.flushThreshold)
+			}
+
+		case <-s.buffer.closed:
+			s.drain(&batch, &impressions)
+			if len(batch) > 0 {
+				s.flush(batch)
+			}
+			if len(impressions) > 0 {
+				s.flushImpressions(impressions)
+			}
+			return
+		}
+	}
+}
+
+// drain reads all remaining events and impressions from the buffer channels
+// into the batches.
+func (s *Store) drain(batch *[]domain.ClickEvent, impressions *[]domain.ImpressionEvent) {
+	for {
+		select {
+		case event := <-s.buffer.events:
+			*batch = append(*batch, event)
+		case impression := <-s.buffer.impressions:
+			*impressions = append(*impressions, impression)
+		default:
+			return
+		}
+	}
+}
+
+// flush writes a batch of events. A failed batch is logged and dropped.
+func (s *Store) flush(batch []domain.ClickEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := s.writer.WriteEvents(ctx, batch); err != nil {
+		s.log.Error("Failed to write click events",
+			infralogger.Error(err),
+			infralogger.Int("batch_size", len(batch)),
+		)
+		return
+	}
+
+	s.log.Debug("Flushed click events",
+		infralogger.Int("total", len(batch)),
+	)
+}
+
+// flushImpressions writes a batch of impressions. A failed batch is logged and dropped.
+func (s *Store) flushImpressions(batch []domain.ImpressionEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := s.writer.WriteImpressions(ctx, batch); err != nil {
+		s.log.Error("Failed to write impression events",
+			infralogger.Error(err),
+			infralogger.Int("batch_size", len(batch)),
+		)
+		return
+	}
+
+	s.log.Debug("Flushed impression events",
+		infralogger.Int("total", len(batch)),
+	)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/domain"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -55,7 +56,7 @@ func TestWriteValueTuple_FirstRow(t *testing.T) {
 	var sb strings.Builder
 	writeValueTuple(&sb, 0)
 
-	assert.Equal(t, "($1, $2, $3, $4, $5, $6, $7, $8, $9)", sb.String())
+	assert.Equal(t, "($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", sb.String())
 }
 
 func TestWriteValueTuple_SecondRow(t *testing.T) {
@@ -64,7 +65,7 @@ func TestWriteValueTuple_SecondRow(t *testing.T) {
 	var sb strings.Builder
 	writeValueTuple(&sb, 1)
 
-	assert.Equal(t, "($10, $11, $12, $13, $14, $15, $16, $17, $18)", sb.String())
+	assert.Equal(t, "($15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)", sb.String())
 }
 
 func TestWriteValueTuple_ThirdRow(t *testing.T) {
@@ -73,7 +74,14 @@ func TestWriteValueTuple_ThirdRow(t *testing.T) {
 	var sb strings.Builder
 	writeValueTuple(&sb, 2)
 
-	assert.Equal(t, "($19, $20, $21, $22, $23, $24, $25, $26, $27)", sb.String())
+	assert.Equal(t, "($29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41, $42)", sb.String())
+}
+
+func TestClickEventColumns_MatchesColumnsPerRow(t *testing.T) {
+	t.Helper()
+
+	assert.Len(t, clickEventColumns, columnsPerRow)
+	assert.Len(t, impressionEventColumns, impressionColumnsPerRow)
 }
 
 // --- Store constructor test ---
@@ -89,7 +97,7 @@ func TestNewStore_Fields(t *testing.T) {
 	buf := NewBuffer(10)
 	log := infralogger.NewNop()
 
-	store := NewStore(db, buf, log, 2*time.Second, 100)
+	store := NewStore(NewPostgresWriter(db), buf, log, 2*time.Second, 100)
 
 	assert.Equal(t, 2*time.Second, store.flushInterval)
 	assert.Equal(t, 100, store.flushThreshold)
@@ -105,13 +113,13 @@ func TestBatchInsert_SingleEvent(t *testing.T) {
 
 	defer db.Close()
 
-	store := NewStore(db, NewBuffer(10), infralogger.NewNop(), time.Second, 5)
+	store := NewStore(NewPostgresWriter(db), NewBuffer(10), infralogger.NewNop(), time.Second, 5)
 
 	mock.ExpectExec("INSERT INTO click_events").
 		WithArgs(
 			"q1", "r1", 1, 1,
 			"desthash", "sess1", "uahash",
-			sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -129,13 +137,13 @@ func TestBatchInsert_MultipleEvents(t *testing.T) {
 
 	defer db.Close()
 
-	store := NewStore(db, NewBuffer(10), infralogger.NewNop(), time.Second, 5)
+	store := NewStore(NewPostgresWriter(db), NewBuffer(10), infralogger.NewNop(), time.Second, 5)
 
 	mock.ExpectExec("INSERT INTO click_events").
 		WithArgs(
-			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
-			"q2", "r2", 2, 1, "desthash", "sess2", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
-			"q3", "r3", 3, 1, "desthash", "sess3", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
+			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
+			"q2", "r2", 2, 1, "desthash", "sess2", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
+			"q3", "r3", 3, 1, "desthash", "sess3", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
 		).
 		WillReturnResult(sqlmock.NewResult(0, 3))
 
@@ -157,7 +165,7 @@ func TestBatchInsert_EmptySlice(t *testing.T) {
 
 	defer db.Close()
 
-	store := NewStore(db, NewBuffer(10), infralogger.NewNop(), time.Second, 5)
+	store := NewStore(NewPostgresWriter(db), NewBuffer(10), infralogger.NewNop(), time.Second, 5)
 
 	// No DB calls expected for empty batch.
 	store.flush([]domain.ClickEvent{})
@@ -173,13 +181,13 @@ func TestBatchInsert_DBError(t *testing.T) {
 
 	defer db.Close()
 
-	store := NewStore(db, NewBuffer(10), infralogger.NewNop(), time.Second, 5)
+	store := NewStore(NewPostgresWriter(db), NewBuffer(10), infralogger.NewNop(), time.Second, 5)
 
 	mock.ExpectExec("INSERT INTO click_events").
 		WithArgs(
 			"q1", "r1", 1, 1,
 			"desthash", "sess1", "uahash",
-			sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
 		).
 		WillReturnError(assert.AnError)
 
@@ -200,10 +208,11 @@ func TestDrain_EmptyBuffer(t *testing.T) {
 
 	defer db.Close()
 
-	store := NewStore(db, NewBuffer(10), infralogger.NewNop(), time.Second, 5)
+	store := NewStore(NewPostgresWriter(db), NewBuffer(10), infralogger.NewNop(), time.Second, 5)
 
 	batch := make([]domain.ClickEvent, 0, 5)
-	store.drain(&batch)
+	var impressions []domain.ImpressionEvent
+	store.drain(&batch, &impressions)
 
 	assert.Empty(t, batch)
 }
@@ -217,17 +226,46 @@ func TestDrain_WithEvents(t *testing.T) {
 	defer db.Close()
 
 	buf := NewBuffer(10)
-	store := NewStore(db, buf, infralogger.NewNop(), time.Second, 5)
+	store := NewStore(NewPostgresWriter(db), buf, infralogger.NewNop(), time.Second, 5)
 
 	buf.Send(testEvent("q1", "r1"))
 	buf.Send(testEventWithPos("q2", "r2", "sess2", 2))
 
 	batch := make([]domain.ClickEvent, 0, 5)
-	store.drain(&batch)
+	var impressions []domain.ImpressionEvent
+	store.drain(&batch, &impressions)
 
 	require.Len(t, batch, 2)
 	assert.Equal(t, "q1", batch[0].QueryID)
 	assert.Equal(t, "q2", batch[1].QueryID)
+	assert.Empty(t, impressions)
+}
+
+func TestStore_StartStop_DrainsImpressions(t *testing.T) {
+	t.Helper()
+
+	db, mock, setupErr := sqlmock.New()
+	require.NoError(t, setupErr)
+
+	defer db.Close()
+
+	buf := NewBuffer(100)
+	store := NewStore(NewPostgresWriter(db), buf, infralogger.NewNop(), 50*time.Millisecond, 1000)
+
+	buf.SendImpression(testImpression("q1", "r1"))
+	buf.Send(testEvent("q1", "r1"))
+	assert.Equal(t, 1, buf.ImpressionLen())
+
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectExec("INSERT INTO click_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO impression_events").
+		WithArgs("q1", "r1", 1, 1, "example.com", "sess1", "northern-news", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	store.Start()
+	store.Stop()
+
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 // --- Start/Stop integration tests ---
@@ -243,15 +281,15 @@ func TestStore_StartStop_DrainsPending(t *testing.T) {
 	buf := NewBuffer(100)
 
 	// High threshold so only drain-on-close triggers flush.
-	store := NewStore(db, buf, infralogger.NewNop(), 50*time.Millisecond, 1000)
+	store := NewStore(NewPostgresWriter(db), buf, infralogger.NewNop(), 50*time.Millisecond, 1000)
 
 	buf.Send(testEvent("q1", "r1"))
 	buf.Send(testEventWithPos("q2", "r2", "sess2", 2))
 
 	mock.ExpectExec("INSERT INTO click_events").
 		WithArgs(
-			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
-			"q2", "r2", 2, 1, "desthash", "sess2", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
+			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
+			"q2", "r2", 2, 1, "desthash", "sess2", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
 		).
 		WillReturnResult(sqlmock.NewResult(0, 2))
 
@@ -271,13 +309,14 @@ func TestStore_FlushLoop_ThresholdTrigger(t *testing.T) {
 
 	buf := NewBuffer(100)
 
-	// Threshold of 2, long interval — threshold should trigger flush.
-	store := NewStore(db, buf, infralogger.NewNop(), 10*time.Second, 2)
+	// Threshold of 2 and a clock that never moves — only the threshold can trigger a flush.
+	store := NewStore(NewPostgresWriter(db), buf, infralogger.NewNop(), 10*time.Second, 2)
+	store.clock = clock.NewFake(time.Now())
 
 	mock.ExpectExec("INSERT INTO click_events").
 		WithArgs(
-			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
-			"q2", "r2", 2, 1, "desthash", "sess2", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
+			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
+			"q2", "r2", 2, 1, "desthash", "sess2", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
 		).
 		WillReturnResult(sqlmock.NewResult(0, 2))
 
@@ -286,8 +325,7 @@ func TestStore_FlushLoop_ThresholdTrigger(t *testing.T) {
 	buf.Send(testEvent("q1", "r1"))
 	buf.Send(testEventWithPos("q2", "r2", "sess2", 2))
 
-	// Give the flush loop time to process.
-	time.Sleep(100 * time.Millisecond)
+	assert.Eventually(t, func() bool { return mock.ExpectationsWereMet() == nil }, time.Second, time.Millisecond)
 
 	store.Stop()
 
@@ -304,21 +342,26 @@ func TestStore_FlushLoop_IntervalTrigger(t *testing.T) {
 
 	buf := NewBuffer(100)
 
-	// High threshold, short interval — interval should trigger flush.
-	store := NewStore(db, buf, infralogger.NewNop(), 50*time.Millisecond, 1000)
+	// High threshold — only the interval ticker can trigger a flush.
+	fakeClock := clock.NewFake(time.Now())
+	store := NewStore(NewPostgresWriter(db), buf, infralogger.NewNop(), time.Minute, 1000)
+	store.clock = fakeClock
 
 	mock.ExpectExec("INSERT INTO click_events").
 		WithArgs(
-			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(),
+			"q1", "r1", 1, 1, "desthash", "sess1", "uahash", sqlmock.AnyArg(), sqlmock.AnyArg(), "example.com", "", "", "", "",
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	store.Start()
 
+	fakeClock.BlockUntil(1)
 	buf.Send(testEvent("q1", "r1"))
+	require.Eventually(t, func() bool { return buf.Len() == 0 }, time.Second, time.Millisecond)
 
-	// Wait for the interval ticker to fire.
-	time.Sleep(150 * time.Millisecond)
+	fakeClock.Advance(time.Minute)
+
+	assert.Eventually(t, func() bool { return mock.ExpectationsWereMet() == nil }, time.Second, time.Millisecond)
 
 	store.Stop()
 
@@ -334,6 +377,7 @@ func testEvent(queryID, resultID string) domain.ClickEvent {
 		Position:        1,
 		Page:            1,
 		DestinationHash: "desthash",
+		DestinationHost: "example.com",
 		SessionID:       "sess1",
 		UserAgentHash:   "uahash",
 		GeneratedAt:     time.Date(2026, 3, 23, 10, 0, 0, 0, time.UTC),
@@ -341,6 +385,20 @@ func testEvent(queryID, resultID string) domain.ClickEvent {
 	}
 }
 
+func testImpression(queryID, resultID string) domain.ImpressionEvent {
+	return domain.ImpressionEvent{
+		QueryID:         queryID,
+		ResultID:        resultID,
+		Position:        1,
+		Page:            1,
+		DestinationHost: "example.com",
+		SessionID:       "sess1",
+		Channel:         "northern-news",
+		GeneratedAt:     time.Date(2026, 3, 23, 10, 0, 0, 0, time.UTC),
+		ShownAt:         time.Date(2026, 3, 23, 10, 0, 0, 500, time.UTC),
+	}
+}
+
 func testEventWithPos(queryID, resultID, sessionID string, position int) domain.ClickEvent {
 	return domain.ClickEvent{
 		QueryID:         queryID,
@@ -348,6 +406,7 @@ func testEventWithPos(queryID, resultID, sessionID string, position int) domain.
 		Position:        position,
 		Page:            1,
 		DestinationHash: "desthash",
+		DestinationHost: "example.com",
 		SessionID:       sessionID,
 		UserAgentHash:   "uahash",
 		GeneratedAt:     time.Date(2026, 3, 23, 10, 0, 0, 0, time.UTC),
@@ -13,20 +13,31 @@ import (
 	"github.com/jonesrussell/north-cloud/click-tracker/internal/storage"
 	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
+	infraredis "github.com/jonesrussell/north-cloud/infrastructure/redis"
+	"github.com/jonesrussell/north-cloud/infrastructure/sse"
 
 	_ "github.com/lib/pq"
 )
 
-// Database connection timeout.
+// Storage connection and schema setup timeout.
 const dbPingTimeout = 5 * time.Second
 
+// day converts retention periods configured in days.
+const day = 24 * time.Hour
+
+// streamMaxClients bounds concurrent live click stream subscribers.
+const streamMaxClients = 50
+
 func main() {
 	os.Exit(run())
 }
 
 func run() int {
+	defer crash.Start("click-tracker")()
+
 	// Start profiling server (if enabled)
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("click-tracker"); pyroErr != nil {
@@ -54,16 +65,116 @@ func run() int {
 	}
 	defer func() { _ = log.Sync() }()
 
-	// Connect to database
-	db, err := connectDatabase(cfg, log)
+	// Connect to the storage backend
+	backend, err := openStorage(cfg, log)
 	if err != nil {
-		log.Error("Failed to connect to database", logger.Error(err))
+		log.Error("Failed to connect to storage", logger.Error(err))
 		return 1
 	}
-	defer func() { _ = db.Close() }()
+	defer backend.close()
+
+	// Also publish accepted events to Redis streams (if enabled)
+	if cfg.Fanout.Enabled {
+		defer setupFanout(&cfg.Fanout, backend, log)()
+	}
 
 	// Run server
-	return runServer(cfg, log, db)
+	return runServer(cfg, log, backend)
+}
+
+// storageBackend is the configured store's event writer and stats reader,
+// and its retention job when one runs in the service.
+type storageBackend struct {
+	writer    storage.EventWriter
+	reader    handler.ClickStatsReader
+	retention *storage.RetentionJob
+	close     func()
+}
+
+// openStorage connects the backend selected by storage.backend.
+func openStorage(cfg *config.Config, log logger.Logger) (*storageBackend, error) {
+	if cfg.Storage.Backend == config.StorageBackendClickHouse {
+		ch, err := connectClickHouse(cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		return &storageBackend{
+			writer: storage.NewClickHouseWriter(ch),
+			reader: storage.NewClickHouseStatsReader(ch),
+			close:  func() {},
+		}, nil
+	}
+
+	db, err := connectDatabase(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	backend := &storageBackend{
+		writer: storage.NewPostgresWriter(db),
+		reader: storage.NewStatsReader(db),
+		close:  func() { _ = db.Close() },
+	}
+	if cfg.Retention.Enabled {
+		backend.retention = storage.NewRetentionJob(db, storage.RetentionPolicy{
+			AnonymizeAfter: time.Duration(cfg.Retention.AnonymizeAfterDays) * day,
+			RawEventAge:    time.Duration(cfg.Retention.RawEventDays) * day,
+			Interval:       cfg.Retention.Interval,
+		}, log)
+	}
+	return backend, nil
+}
+
+// setupFanout wraps the backend's writer so stored batches are also
+// published to Redis streams. Without Redis, events are still stored and the
+// service runs without fanout rather than failing to start.
+func setupFanout(cfg *config.FanoutConfig, backend *storageBackend, log logger.Logger) func() {
+	redisClient, err := infraredis.NewClient(infraredis.Config{
+		Address:  cfg.Address,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+	if err != nil {
+		log.Warn("Event fanout disabled: Redis unavailable", logger.Error(err))
+		return func() {}
+	}
+
+	publisher := storage.NewStreamPublisher(redisClient, cfg)
+	backend.writer = storage.NewFanoutWriter(backend.writer, publisher, log)
+	log.Info("Event fanout enabled",
+		logger.String("redis_address", cfg.Address),
+		logger.String("stream", cfg.Stream),
+		logger.String("impression_stream", cfg.ImpressionStream),
+	)
+
+	return func() { _ = redisClient.Close() }
+}
+
+// connectClickHouse verifies the ClickHouse server and creates the
+// click_events table if needed; ClickHouse has no migration step.
+func connectClickHouse(cfg *config.Config, log logger.Logger) (*storage.ClickHouse, error) {
+	ch := storage.NewClickHouse(&cfg.Storage.ClickHouse)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbPingTimeout)
+	defer cancel()
+
+	if err := ch.Ping(ctx); err != nil {
+		return nil, err
+	}
+	if err := ch.EnsureSchema(ctx); err != nil {
+		return nil, fmt.Errorf("ensure clickhouse schema: %w", err)
+	}
+	if cfg.Retention.Enabled {
+		if err := ch.SetAnonymizationTTL(ctx, cfg.Retention.AnonymizeAfterDays); err != nil {
+			return nil, fmt.Errorf("set clickhouse retention: %w", err)
+		}
+	}
+
+	log.Info("ClickHouse connected",
+		logger.String("url", cfg.Storage.ClickHouse.URL),
+		logger.String("database", cfg.Storage.ClickHouse.Database),
+	)
+
+	return ch, nil
 }
 
 // loadConfig loads and validates configuration.
@@ -117,28 +228,57 @@ func connectDatabase(cfg *config.Config, log logger.Logger) (*sql.DB, error) {
 }
 
 // runServer creates all dependencies and starts the HTTP server.
-func runServer(cfg *config.Config, log logger.Logger, db *sql.DB) int {
-	// Create HMAC signer
-	signer := clickurl.NewSigner(cfg.Service.HMACSecret)
+func runServer(cfg *config.Config, log logger.Logger, backend *storageBackend) int {
+	// Verification keys: the current HMAC key and any previous keys still accepted
+	keys, err := cfg.Service.VerificationKeys()
+	if err != nil {
+		log.Error("Invalid HMAC keys", logger.Error(err))
+		return 1
+	}
+	verifier, err := clickurl.NewKeyRing(keys...)
+	if err != nil {
+		log.Error("Invalid HMAC keys", logger.Error(err))
+		return 1
+	}
 
 	// Create event buffer and store
 	buf := storage.NewBuffer(cfg.Service.BufferSize)
-	store := storage.NewStore(db, buf, log, cfg.Service.FlushInterval, cfg.Service.FlushThreshold)
+	store := storage.NewStore(backend.writer, buf, log, cfg.Service.FlushInterval, cfg.Service.FlushThreshold)
 	store.Start()
 	defer store.Stop()
 
-	// Create handler
-	clickHandler := handler.NewClickHandler(signer, buf, log, cfg.Service.MaxTimestampAge)
+	// Scheduled rollup, anonymization and purge of raw events
+	if backend.retention != nil {
+		backend.retention.Start()
+		defer backend.retention.Stop()
+	}
+
+	// Live click stream broker
+	broker := sse.NewBroker(log, sse.WithMaxClients(streamMaxClients))
+	if err := broker.Start(context.Background()); err != nil {
+		log.Error("Failed to start click stream", logger.Error(err))
+		return 1
+	}
+	defer func() { _ = broker.Stop() }()
+
+	// Create handlers
+	clickHandler := handler.NewClickHandler(verifier, buf, log, cfg.Service.MaxTimestampAge).WithStream(broker)
+	statsHandler := handler.NewStatsHandler(backend.reader, log)
+	streamHandler := handler.NewStreamHandler(broker, log)
 
 	// done channel signals background goroutines (rate limiter) on shutdown
 	done := make(chan struct{})
 	defer close(done)
 
 	// Create and run server
-	server := api.NewServer(clickHandler, cfg, log, done)
+	server := api.NewServer(clickHandler, statsHandler, streamHandler, cfg, log, done)
+
+	// Close open streams when shutdown begins, or they would hold it up
+	server.HTTPServer().RegisterOnShutdown(func() { _ = broker.Stop() })
 
 	log.Info("Click-tracker starting",
 		logger.Int("port", cfg.Service.Port),
+		logger.String("storage_backend", cfg.Storage.Backend),
 	)
 
 	if err := server.Run(); err != nil {
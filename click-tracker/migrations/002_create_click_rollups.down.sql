@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rollup_backfills;
+DROP TABLE IF EXISTS click_rollups_hourly;
@@ -0,0 +1,28 @@
+-- Hourly click rollups per result. Raw click_events can be purged once an
+-- hour is rolled up; rollup rows are never deleted by the backfill.
+CREATE TABLE click_rollups_hourly (
+    bucket_start    TIMESTAMPTZ  NOT NULL,
+    result_id       VARCHAR(128) NOT NULL,
+    clicks          BIGINT       NOT NULL,
+    unique_sessions BIGINT       NOT NULL,
+    position_sum    BIGINT       NOT NULL,
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (bucket_start, result_id)
+);
+
+CREATE INDEX idx_click_rollups_hourly_result_id ON click_rollups_hourly (result_id, bucket_start);
+
+-- Progress of resumable rollup backfills, one row per rollup.
+CREATE TABLE rollup_backfills (
+    rollup_name   VARCHAR(64)  PRIMARY KEY,
+    status        VARCHAR(16)  NOT NULL,
+    range_start   TIMESTAMPTZ,
+    range_end     TIMESTAMPTZ,
+    cursor_at     TIMESTAMPTZ,
+    batches       BIGINT       NOT NULL DEFAULT 0,
+    rows_upserted BIGINT       NOT NULL DEFAULT 0,
+    last_error    TEXT,
+    started_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    completed_at  TIMESTAMPTZ
+);
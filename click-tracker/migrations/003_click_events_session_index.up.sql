@@ -0,0 +1,4 @@
+-- Session lookups back the per-session engagement stats search uses to
+-- personalize ranking. Events without a session are not indexed.
+CREATE INDEX idx_click_events_session_id ON click_events (session_id, clicked_at)
+    WHERE session_id IS NOT NULL AND session_id <> '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_click_events_destination_host;
+
+ALTER TABLE click_events DROP COLUMN IF EXISTS destination_host;
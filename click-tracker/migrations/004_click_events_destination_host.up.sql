@@ -0,0 +1,6 @@
+-- The destination host groups clicks per source for the click-through
+-- export. Events recorded before this column have no host.
+ALTER TABLE click_events ADD COLUMN destination_host VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_click_events_destination_host ON click_events (destination_host, clicked_at)
+    WHERE destination_host <> '';
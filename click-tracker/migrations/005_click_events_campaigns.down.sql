@@ -0,0 +1,5 @@
+ALTER TABLE click_events
+    DROP COLUMN IF EXISTS utm_campaign,
+    DROP COLUMN IF EXISTS utm_medium,
+    DROP COLUMN IF EXISTS utm_source,
+    DROP COLUMN IF EXISTS channel;
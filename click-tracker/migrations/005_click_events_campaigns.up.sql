@@ -0,0 +1,7 @@
+-- Channel and campaign attribution. channel is signed into publisher click
+-- URLs; the utm_* values come from the click or destination URL.
+ALTER TABLE click_events
+    ADD COLUMN channel      VARCHAR(64)  NOT NULL DEFAULT '',
+    ADD COLUMN utm_source   VARCHAR(128) NOT NULL DEFAULT '',
+    ADD COLUMN utm_medium   VARCHAR(128) NOT NULL DEFAULT '',
+    ADD COLUMN utm_campaign VARCHAR(128) NOT NULL DEFAULT '';
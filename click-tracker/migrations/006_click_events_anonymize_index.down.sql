@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_click_events_identified_clicked_at;
@@ -0,0 +1,4 @@
+-- Finds events that still carry visitor identifiers, so the retention job's
+-- anonymization batches skip rows it has already cleared.
+CREATE INDEX idx_click_events_identified_clicked_at ON click_events (clicked_at)
+    WHERE session_id IS NOT NULL OR user_agent_hash IS NOT NULL;
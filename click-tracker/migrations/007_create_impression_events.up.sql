@@ -0,0 +1,26 @@
+-- Impressions: results shown to a reader in a results page, feed or email,
+-- recorded by the /impression pixel. With click_events they give
+-- click-through rates. There are no rollups; the retention job anonymizes
+-- and purges impressions on the same schedule as raw click events.
+CREATE TABLE impression_events (
+    id               BIGSERIAL,
+    query_id         VARCHAR(32)  NOT NULL,
+    result_id        VARCHAR(128) NOT NULL,
+    position         SMALLINT     NOT NULL,
+    page             SMALLINT     NOT NULL DEFAULT 1,
+    destination_host VARCHAR(255) NOT NULL DEFAULT '',
+    session_id       VARCHAR(32)  NOT NULL DEFAULT '',
+    channel          VARCHAR(64)  NOT NULL DEFAULT '',
+    generated_at     TIMESTAMPTZ  NOT NULL,
+    shown_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, shown_at)
+) PARTITION BY RANGE (shown_at);
+
+CREATE TABLE impression_events_default PARTITION OF impression_events DEFAULT;
+
+CREATE INDEX idx_impression_events_result_id ON impression_events (result_id, shown_at);
+CREATE INDEX idx_impression_events_shown_at  ON impression_events (shown_at);
+
+-- Finds impressions that still carry a session ID, for anonymization batches.
+CREATE INDEX idx_impression_events_identified_shown_at ON impression_events (shown_at)
+    WHERE session_id <> '';
@@ -0,0 +1,390 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	"github.com/jonesrussell/north-cloud/crawler/internal/job"
+	"github.com/jonesrussell/north-cloud/crawler/internal/sources"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// Reconcile actions: what a source's job needs to match the source.
+const (
+	ReconcileInSync = "in_sync"
+	ReconcileCreate = "create"
+	ReconcileUpdate = "update"
+	ReconcilePause  = "pause"
+	ReconcileResume = "resume"
+	// ReconcileDeferred means the job is running; reconcile again once it finishes.
+	ReconcileDeferred = "deferred"
+)
+
+// Reconcile change names.
+const (
+	changeURL           = "url"
+	changeSourceName    = "source_name"
+	changeInterval      = "interval"
+	changeEnabled       = "enabled"
+	changeSourceDeleted = "source_deleted"
+)
+
+// ReconcileJobRepository is the job persistence the reconciler needs.
+type ReconcileJobRepository interface {
+	ListSourceJobs(ctx context.Context) ([]*domain.Job, error)
+	UpsertAutoManaged(ctx context.Context, job *domain.Job) error
+	Update(ctx context.Context, job *domain.Job) error
+}
+
+// ReconcileItem is one source whose job does not match it.
+type ReconcileItem struct {
+	SourceID   string   `json:"source_id"`
+	SourceName string   `json:"source_name,omitempty"`
+	JobID      string   `json:"job_id,omitempty"`
+	Action     string   `json:"action"`
+	Changes    []string `json:"changes"`
+	Applied    bool     `json:"applied"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// ReconcileReport is the JSON response for the reconcile endpoints. Items lists
+// only sources that are not in sync; Summary counts every checked source by action.
+type ReconcileReport struct {
+	DryRun         bool            `json:"dry_run"`
+	SourcesChecked int             `json:"sources_checked"`
+	Summary        map[string]int  `json:"summary"`
+	Items          []ReconcileItem `json:"items"`
+	Errors         int             `json:"errors"`
+}
+
+// reconcilePlan is the change one source's job needs.
+type reconcilePlan struct {
+	item   ReconcileItem
+	source *sources.SourceListItem // nil when the source no longer exists
+	job    *domain.Job             // nil when the source has no job
+}
+
+// ReconcileSourcesHandler compares sources with their crawler jobs and brings the
+// jobs in line: creates jobs for enabled sources without one, pauses jobs of
+// disabled or deleted sources, resumes jobs of re-enabled sources, and updates
+// jobs whose URL, name or schedule drifted from the source.
+type ReconcileSourcesHandler struct {
+	SourcesClient    sources.Client
+	JobRepo          ReconcileJobRepository
+	ScheduleComputer *job.ScheduleComputer
+	Logger           infralogger.Logger
+}
+
+// NewReconcileSourcesHandler creates a new reconcile handler.
+func NewReconcileSourcesHandler(
+	sourcesClient sources.Client,
+	jobRepo ReconcileJobRepository,
+	scheduleComputer *job.ScheduleComputer,
+	logger infralogger.Logger,
+) *ReconcileSourcesHandler {
+	return &ReconcileSourcesHandler{
+		SourcesClient:    sourcesClient,
+		JobRepo:          jobRepo,
+		ScheduleComputer: scheduleComputer,
+		Logger:           logger,
+	}
+}
+
+// reconcileRequest limits a reconcile to some sources; empty means all.
+type reconcileRequest struct {
+	SourceIDs []string `json:"source_ids"`
+}
+
+// GetReconcileReport reports what reconciling would change, without changing anything.
+// GET /api/v1/admin/reconcile-sources[?source_id=...]
+func (h *ReconcileSourcesHandler) GetReconcileReport(c *gin.Context) {
+	sourceIDs, err := parseSourceIDs(c.QueryArray("source_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.reconcile(c, sourceIDs, false)
+}
+
+// ReconcileSources brings jobs in line with their sources and reports what changed.
+// POST /api/v1/admin/reconcile-sources with optional {"source_ids": [...]}
+func (h *ReconcileSourcesHandler) ReconcileSources(c *gin.Context) {
+	var req reconcileRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil && !errors.Is(bindErr, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": bindErr.Error()})
+		return
+	}
+	sourceIDs, err := parseSourceIDs(req.SourceIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.reconcile(c, sourceIDs, true)
+}
+
+// parseSourceIDs parses source IDs into a set; nil means every source.
+func parseSourceIDs(raw []string) (map[string]bool, error) {
+	if len(raw) == 0 {
+		return nil, nil //nolint:nilnil // nil set = no filter
+	}
+	ids := make(map[string]bool, len(raw))
+	for _, id := range raw {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source_id %q", id)
+		}
+		ids[parsed.String()] = true
+	}
+	return ids, nil
+}
+
+func (h *ReconcileSourcesHandler) reconcile(c *gin.Context, sourceIDs map[string]bool, apply bool) {
+	ctx := c.Request.Context()
+
+	sourceList, err := h.SourcesClient.ListSources(ctx)
+	if err != nil {
+		h.Logger.Error("Failed to list sources", infralogger.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to list sources"})
+		return
+	}
+	jobs, err := h.JobRepo.ListSourceJobs(ctx)
+	if err != nil {
+		h.Logger.Error("Failed to list source jobs", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list jobs"})
+		return
+	}
+
+	plans := h.plan(sourceList, jobs, sourceIDs, time.Now())
+
+	report := ReconcileReport{
+		DryRun:         !apply,
+		SourcesChecked: len(plans),
+		Summary:        make(map[string]int),
+		Items:          make([]ReconcileItem, 0),
+	}
+	for i := range plans {
+		p := &plans[i]
+		report.Summary[p.item.Action]++
+		if p.item.Action == ReconcileInSync {
+			continue
+		}
+		if apply && p.item.Action != ReconcileDeferred {
+			h.apply(ctx, p)
+		}
+		if p.item.Error != "" {
+			report.Errors++
+		}
+		report.Items = append(report.Items, p.item)
+	}
+
+	h.Logger.Info("Source reconcile completed",
+		infralogger.Bool("dry_run", report.DryRun),
+		infralogger.Int("sources_checked", report.SourcesChecked),
+		infralogger.Int("out_of_sync", len(report.Items)),
+		infralogger.Int("errors", report.Errors),
+	)
+
+	c.JSON(http.StatusOK, report)
+}
+
+// plan works out each source's action. With a source filter only those sources
+// are checked; without one, jobs whose source no longer exists are checked too.
+func (h *ReconcileSourcesHandler) plan(
+	sourceList []*sources.SourceListItem, jobs []*domain.Job, sourceIDs map[string]bool, now time.Time,
+) []reconcilePlan {
+	jobsBySource := make(map[string]*domain.Job, len(jobs))
+	for _, j := range jobs {
+		jobsBySource[j.SourceID] = j
+	}
+
+	plans := make([]reconcilePlan, 0, len(sourceList))
+	seen := make(map[string]bool, len(sourceList))
+	for _, src := range sourceList {
+		id := src.ID.String()
+		if sourceIDs != nil && !sourceIDs[id] {
+			continue
+		}
+		seen[id] = true
+		plans = append(plans, h.planSource(src, jobsBySource[id], now))
+	}
+
+	for _, j := range jobs {
+		if seen[j.SourceID] || (sourceIDs != nil && !sourceIDs[j.SourceID]) {
+			continue
+		}
+		p := reconcilePlan{job: j, item: newReconcileItem(j.SourceID, j)}
+		p.item.Action = pauseAction(j)
+		if p.item.Action != ReconcileInSync {
+			p.item.Changes = append(p.item.Changes, changeSourceDeleted)
+		}
+		plans = append(plans, p)
+	}
+	return plans
+}
+
+// planSource works out the action for a source and its job (nil when it has none).
+func (h *ReconcileSourcesHandler) planSource(src *sources.SourceListItem, j *domain.Job, now time.Time) reconcilePlan {
+	p := reconcilePlan{source: src, job: j, item: newReconcileItem(src.ID.String(), j)}
+	p.item.SourceName = src.Name
+
+	switch {
+	case j == nil && src.Enabled:
+		p.item.Action = ReconcileCreate
+		p.job = h.newJob(src, now)
+		return p
+	case j == nil:
+		p.item.Action = ReconcileInSync
+		return p
+	case !src.Enabled:
+		p.item.Action = pauseAction(j)
+		if p.item.Action != ReconcileInSync {
+			p.item.Changes = append(p.item.Changes, changeEnabled)
+		}
+		return p
+	}
+
+	if j.SourceName == nil || *j.SourceName != src.Name {
+		p.item.Changes = append(p.item.Changes, changeSourceName)
+	}
+	if j.URL != src.URL {
+		p.item.Changes = append(p.item.Changes, changeURL)
+	}
+	if j.AutoManaged && j.IntervalMinutes != nil {
+		schedule := h.schedule(src, j.FailureCount)
+		if *j.IntervalMinutes != schedule.IntervalMinutes || j.IntervalType != schedule.IntervalType {
+			p.item.Changes = append(p.item.Changes, changeInterval)
+		}
+	}
+
+	switch {
+	case j.IsPaused:
+		p.item.Action = ReconcileResume
+		p.item.Changes = append(p.item.Changes, changeEnabled)
+	case len(p.item.Changes) == 0:
+		p.item.Action = ReconcileInSync
+	default:
+		p.item.Action = ReconcileUpdate
+	}
+	if p.item.Action != ReconcileInSync && j.Status == "running" {
+		p.item.Action = ReconcileDeferred
+	}
+	return p
+}
+
+func newReconcileItem(sourceID string, j *domain.Job) ReconcileItem {
+	item := ReconcileItem{SourceID: sourceID, Changes: make([]string, 0)}
+	if j != nil {
+		item.JobID = j.ID
+		if j.SourceName != nil {
+			item.SourceName = *j.SourceName
+		}
+	}
+	return item
+}
+
+// pauseAction is the action for a job that should not run: pause unless it
+// is already idle, defer while it is running.
+func pauseAction(j *domain.Job) string {
+	switch {
+	case j.IsPaused:
+		return ReconcileInSync
+	case j.Status == "running":
+		return ReconcileDeferred
+	case j.Status == "pending" || j.Status == "scheduled":
+		return ReconcilePause
+	default:
+		// completed, failed and cancelled jobs do not run again
+		return ReconcileInSync
+	}
+}
+
+// schedule computes the schedule for a source, as the source events do.
+func (h *ReconcileSourcesHandler) schedule(src *sources.SourceListItem, failureCount int) job.ScheduleOutput {
+	return h.ScheduleComputer.ComputeSchedule(job.ScheduleInput{
+		RateLimit:    parseRateLimitInt(src.RateLimit),
+		MaxDepth:     src.MaxDepth,
+		Priority:     src.Priority,
+		FailureCount: failureCount,
+	})
+}
+
+// newJob builds the auto-managed job for an enabled source without one.
+func (h *ReconcileSourcesHandler) newJob(src *sources.SourceListItem, now time.Time) *domain.Job {
+	schedule := h.schedule(src, 0)
+	nextRun := now.Add(schedule.InitialDelay)
+	sourceName := src.Name
+	return &domain.Job{
+		ID:                  uuid.New().String(),
+		SourceID:            src.ID.String(),
+		SourceName:          &sourceName,
+		URL:                 src.URL,
+		IntervalMinutes:     &schedule.IntervalMinutes,
+		IntervalType:        schedule.IntervalType,
+		NextRunAt:           &nextRun,
+		Status:              "scheduled",
+		AutoManaged:         true,
+		Priority:            schedule.NumericPriority,
+		ScheduleEnabled:     true,
+		MaxRetries:          defaultMaxRetries,
+		RetryBackoffSeconds: defaultRetryBackoffSeconds,
+		SchedulerVersion:    1,
+	}
+}
+
+// apply makes the planned change, recording the outcome on the item.
+func (h *ReconcileSourcesHandler) apply(ctx context.Context, p *reconcilePlan) {
+	var err error
+	switch p.item.Action {
+	case ReconcileCreate:
+		err = h.JobRepo.UpsertAutoManaged(ctx, p.job)
+		p.item.JobID = p.job.ID
+	case ReconcilePause:
+		now := time.Now()
+		p.job.IsPaused = true
+		p.job.Status = "paused"
+		p.job.PausedAt = &now
+		p.job.NextRunAt = nil
+		err = h.JobRepo.Update(ctx, p.job)
+	case ReconcileResume, ReconcileUpdate:
+		h.alignJob(p)
+		err = h.JobRepo.Update(ctx, p.job)
+	}
+
+	if err != nil {
+		h.Logger.Error("Failed to reconcile job",
+			infralogger.String("source_id", p.item.SourceID),
+			infralogger.String("action", p.item.Action),
+			infralogger.Error(err),
+		)
+		p.item.Error = err.Error()
+		return
+	}
+	p.item.Applied = true
+}
+
+// alignJob copies the source's name, URL and schedule onto its job, and
+// resumes the job when it is paused.
+func (h *ReconcileSourcesHandler) alignJob(p *reconcilePlan) {
+	sourceName := p.source.Name
+	p.job.SourceName = &sourceName
+	p.job.URL = p.source.URL
+	if p.job.AutoManaged && p.job.IntervalMinutes != nil {
+		schedule := h.schedule(p.source, p.job.FailureCount)
+		p.job.IntervalMinutes = &schedule.IntervalMinutes
+		p.job.IntervalType = schedule.IntervalType
+	}
+	if p.job.IsPaused {
+		now := time.Now()
+		p.job.IsPaused = false
+		p.job.Status = "scheduled"
+		p.job.PausedAt = nil
+		p.job.NextRunAt = &now
+	}
+}
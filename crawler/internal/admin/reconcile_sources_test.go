@@ -0,0 +1,217 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/crawler/internal/admin"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	"github.com/jonesrussell/north-cloud/crawler/internal/job"
+	"github.com/jonesrussell/north-cloud/crawler/internal/sources"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// fakeReconcileRepo implements admin.ReconcileJobRepository in memory.
+type fakeReconcileRepo struct {
+	jobs    []*domain.Job
+	created []*domain.Job
+	updated []*domain.Job
+}
+
+func (r *fakeReconcileRepo) ListSourceJobs(_ context.Context) ([]*domain.Job, error) {
+	return r.jobs, nil
+}
+
+func (r *fakeReconcileRepo) UpsertAutoManaged(_ context.Context, j *domain.Job) error {
+	r.created = append(r.created, j)
+	return nil
+}
+
+func (r *fakeReconcileRepo) Update(_ context.Context, j *domain.Job) error {
+	r.updated = append(r.updated, j)
+	return nil
+}
+
+// reconcileFixture has one source (or orphaned job) per action.
+type reconcileFixture struct {
+	repo                                                    *fakeReconcileRepo
+	handler                                                 *admin.ReconcileSourcesHandler
+	missing, disabled, reEnabled, moved, inSync, gone, busy uuid.UUID
+}
+
+func newReconcileFixture(t *testing.T) *reconcileFixture {
+	t.Helper()
+	f := &reconcileFixture{
+		missing: uuid.New(), disabled: uuid.New(), reEnabled: uuid.New(), moved: uuid.New(),
+		inSync: uuid.New(), gone: uuid.New(), busy: uuid.New(),
+	}
+
+	computer := job.NewScheduleComputer()
+	schedule := computer.ComputeSchedule(job.ScheduleInput{RateLimit: 10, MaxDepth: 1})
+	newJob := func(sourceID uuid.UUID, name, url, status string, paused bool) *domain.Job {
+		interval := schedule.IntervalMinutes
+		return &domain.Job{
+			ID: uuid.NewString(), SourceID: sourceID.String(), SourceName: &name, URL: url,
+			Status: status, IsPaused: paused, AutoManaged: true,
+			IntervalMinutes: &interval, IntervalType: schedule.IntervalType,
+		}
+	}
+	source := func(id uuid.UUID, name, url string, enabled bool) *sources.SourceListItem {
+		return &sources.SourceListItem{ID: id, Name: name, URL: url, RateLimit: "10s", MaxDepth: 1, Enabled: enabled}
+	}
+
+	f.repo = &fakeReconcileRepo{jobs: []*domain.Job{
+		newJob(f.disabled, "Disabled", "https://disabled.example.com", "scheduled", false),
+		newJob(f.reEnabled, "Re-enabled", "https://reenabled.example.com", "paused", true),
+		newJob(f.moved, "Moved", "https://old.example.com", "scheduled", false),
+		newJob(f.inSync, "In Sync", "https://insync.example.com", "scheduled", false),
+		newJob(f.gone, "Gone", "https://gone.example.com", "pending", false),
+		newJob(f.busy, "Busy", "https://busy.example.com", "running", false),
+	}}
+	client := &mockSourcesClient{sources: []*sources.SourceListItem{
+		source(f.missing, "Missing", "https://missing.example.com", true),
+		source(f.disabled, "Disabled", "https://disabled.example.com", false),
+		source(f.reEnabled, "Re-enabled", "https://reenabled.example.com", true),
+		source(f.moved, "Moved", "https://new.example.com", true),
+		source(f.inSync, "In Sync", "https://insync.example.com", true),
+		source(f.busy, "Busy", "https://busy.example.com", false),
+	}}
+	f.handler = admin.NewReconcileSourcesHandler(client, f.repo, computer, infralogger.NewNop())
+	return f
+}
+
+func (f *reconcileFixture) serve(t *testing.T, req *http.Request) admin.ReconcileReport {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/admin/reconcile-sources", f.handler.GetReconcileReport)
+	router.POST("/api/v1/admin/reconcile-sources", f.handler.ReconcileSources)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report admin.ReconcileReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	return report
+}
+
+func itemsBySource(report admin.ReconcileReport) map[string]admin.ReconcileItem {
+	items := make(map[string]admin.ReconcileItem, len(report.Items))
+	for _, item := range report.Items {
+		items[item.SourceID] = item
+	}
+	return items
+}
+
+func TestReconcileSources_Report(t *testing.T) {
+	f := newReconcileFixture(t)
+
+	report := f.serve(t, httptest.NewRequest(http.MethodGet, "/api/v1/admin/reconcile-sources", http.NoBody))
+
+	if !report.DryRun || report.SourcesChecked != 7 {
+		t.Fatalf("expected a dry run over 7 sources, got %+v", report)
+	}
+	want := map[uuid.UUID]struct {
+		action  string
+		changes string
+	}{
+		f.missing:   {admin.ReconcileCreate, ""},
+		f.disabled:  {admin.ReconcilePause, "enabled"},
+		f.reEnabled: {admin.ReconcileResume, "enabled"},
+		f.moved:     {admin.ReconcileUpdate, "url"},
+		f.gone:      {admin.ReconcilePause, "source_deleted"},
+		f.busy:      {admin.ReconcileDeferred, "enabled"},
+	}
+	items := itemsBySource(report)
+	if len(items) != len(want) {
+		t.Fatalf("expected %d out-of-sync items, got %+v", len(want), report.Items)
+	}
+	for id, w := range want {
+		item := items[id.String()]
+		if item.Action != w.action || strings.Join(item.Changes, ",") != w.changes || item.Applied {
+			t.Errorf("source %s: got %+v, want action %s changes %q", id, item, w.action, w.changes)
+		}
+	}
+	if report.Summary[admin.ReconcileInSync] != 1 {
+		t.Errorf("expected 1 in-sync source, got summary %v", report.Summary)
+	}
+	if len(f.repo.created)+len(f.repo.updated) != 0 {
+		t.Error("dry run must not write jobs")
+	}
+}
+
+func TestReconcileSources_Apply(t *testing.T) {
+	f := newReconcileFixture(t)
+
+	report := f.serve(t, httptest.NewRequest(http.MethodPost, "/api/v1/admin/reconcile-sources", http.NoBody))
+
+	if report.DryRun || report.Errors != 0 {
+		t.Fatalf("expected an applied run without errors, got %+v", report)
+	}
+	if len(f.repo.created) != 1 || f.repo.created[0].SourceID != f.missing.String() {
+		t.Fatalf("expected a job created for the missing source, got %+v", f.repo.created)
+	}
+
+	updated := make(map[string]*domain.Job)
+	for _, j := range f.repo.updated {
+		updated[j.SourceID] = j
+	}
+	if len(updated) != 4 {
+		t.Fatalf("expected 4 jobs updated (busy deferred), got %d", len(updated))
+	}
+	if j := updated[f.disabled.String()]; !j.IsPaused || j.Status != "paused" || j.NextRunAt != nil {
+		t.Errorf("disabled source's job not paused: %+v", j)
+	}
+	if j := updated[f.gone.String()]; !j.IsPaused {
+		t.Errorf("deleted source's job not paused: %+v", j)
+	}
+	if j := updated[f.reEnabled.String()]; j.IsPaused || j.Status != "scheduled" || j.NextRunAt == nil {
+		t.Errorf("re-enabled source's job not resumed: %+v", j)
+	}
+	if j := updated[f.moved.String()]; j.URL != "https://new.example.com" {
+		t.Errorf("moved source's job URL not updated: %q", j.URL)
+	}
+	if item := itemsBySource(report)[f.busy.String()]; item.Applied {
+		t.Error("running job must not be changed")
+	}
+}
+
+func TestReconcileSources_SourceFilter(t *testing.T) {
+	f := newReconcileFixture(t)
+
+	body := `{"source_ids":["` + f.gone.String() + `"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/reconcile-sources", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	report := f.serve(t, req)
+
+	if report.SourcesChecked != 1 || len(report.Items) != 1 || report.Items[0].Action != admin.ReconcilePause {
+		t.Fatalf("expected only the deleted source's job paused, got %+v", report)
+	}
+	if len(f.repo.updated) != 1 || len(f.repo.created) != 0 {
+		t.Errorf("expected one job write, got %d updates and %d creates", len(f.repo.updated), len(f.repo.created))
+	}
+}
+
+func TestReconcileSources_InvalidSourceID(t *testing.T) {
+	f := newReconcileFixture(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/admin/reconcile-sources", f.handler.GetReconcileReport)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/reconcile-sources?source_id=nope", http.NoBody))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jonesrussell/north-cloud/crawler/internal/config"
 	"github.com/jonesrussell/north-cloud/crawler/internal/database"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -20,43 +21,44 @@ const (
 	serviceVersion      = "1.0.0"
 )
 
-// setupJobRoutes configures job-related endpoints
-func setupJobRoutes(v1 *gin.RouterGroup, jobsHandler *JobsHandler) {
+// setupJobRoutes configures job-related endpoints on the crawler:jobs group
+func setupJobRoutes(jobs *gin.RouterGroup, jobsHandler *JobsHandler) {
 	if jobsHandler != nil {
 		// Aggregate endpoints (before :id to avoid route conflict)
-		v1.GET("/jobs/status-counts", jobsHandler.GetJobStatusCounts)
+		jobs.GET("/jobs/status-counts", jobsHandler.GetJobStatusCounts)
+		jobs.GET("/jobs/source-health", jobsHandler.GetSourceHealth)
 
 		// Basic CRUD
-		v1.GET("/jobs", jobsHandler.ListJobs)
-		v1.POST("/jobs", jobsHandler.CreateJob)
-		v1.GET("/jobs/:id", jobsHandler.GetJob)
-		v1.PUT("/jobs/:id", jobsHandler.UpdateJob)
-		v1.DELETE("/jobs/:id", jobsHandler.DeleteJob)
+		jobs.GET("/jobs", jobsHandler.ListJobs)
+		jobs.POST("/jobs", jobsHandler.CreateJob)
+		jobs.GET("/jobs/:id", jobsHandler.GetJob)
+		jobs.PUT("/jobs/:id", jobsHandler.UpdateJob)
+		jobs.DELETE("/jobs/:id", jobsHandler.DeleteJob)
 
 		// Job control operations (new)
-		v1.POST("/jobs/:id/pause", jobsHandler.PauseJob)
-		v1.POST("/jobs/:id/resume", jobsHandler.ResumeJob)
-		v1.POST("/jobs/:id/cancel", jobsHandler.CancelJob)
-		v1.POST("/jobs/:id/retry", jobsHandler.RetryJob)
+		jobs.POST("/jobs/:id/pause", jobsHandler.PauseJob)
+		jobs.POST("/jobs/:id/resume", jobsHandler.ResumeJob)
+		jobs.POST("/jobs/:id/cancel", jobsHandler.CancelJob)
+		jobs.POST("/jobs/:id/retry", jobsHandler.RetryJob)
 
 		// Job execution history (new)
-		v1.GET("/jobs/:id/executions", jobsHandler.GetJobExecutions)
-		v1.GET("/jobs/:id/stats", jobsHandler.GetJobStats)
-		v1.GET("/executions/:id", jobsHandler.GetExecution)
+		jobs.GET("/jobs/:id/executions", jobsHandler.GetJobExecutions)
+		jobs.GET("/jobs/:id/stats", jobsHandler.GetJobStats)
+		jobs.GET("/executions/:id", jobsHandler.GetExecution)
 
 		// Scheduler metrics and distribution
-		v1.GET("/scheduler/metrics", jobsHandler.GetSchedulerMetrics)
-		v1.GET("/scheduler/distribution", jobsHandler.GetSchedulerDistribution)
-		v1.POST("/scheduler/rebalance", jobsHandler.PostSchedulerRebalance)
-		v1.POST("/scheduler/rebalance/preview", jobsHandler.PostSchedulerRebalancePreview)
+		jobs.GET("/scheduler/metrics", jobsHandler.GetSchedulerMetrics)
+		jobs.GET("/scheduler/distribution", jobsHandler.GetSchedulerDistribution)
+		jobs.POST("/scheduler/rebalance", jobsHandler.PostSchedulerRebalance)
+		jobs.POST("/scheduler/rebalance/preview", jobsHandler.PostSchedulerRebalancePreview)
 	} else {
 		// Fallback to placeholder endpoints if no handler provided
-		v1.GET("/jobs", func(c *gin.Context) {
+		jobs.GET("/jobs", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{
 				"jobs": []gin.H{},
 			})
 		})
-		v1.POST("/jobs", func(c *gin.Context) {
+		jobs.POST("/jobs", func(c *gin.Context) {
 			c.JSON(http.StatusCreated, gin.H{
 				"id":      "job-1",
 				"status":  "pending",
@@ -143,6 +145,20 @@ func setupDiscoveredDomainsRoutes(v1 *gin.RouterGroup, domainsHandler *Discovere
 	}
 }
 
+// setupCostRoutes configures cost attribution and owner quota endpoints
+func setupCostRoutes(v1 *gin.RouterGroup, costsHandler *CostsHandler) {
+	if costsHandler == nil {
+		return
+	}
+	v1.GET("/costs", costsHandler.GetReport)
+	v1.GET("/costs/owners", costsHandler.ListSourceOwners)
+	v1.PUT("/costs/owners/:source_id", costsHandler.SetSourceOwner)
+	v1.DELETE("/costs/owners/:source_id", costsHandler.DeleteSourceOwner)
+	v1.GET("/costs/quotas", costsHandler.ListOwnerQuotas)
+	v1.PUT("/costs/quotas/:owner", costsHandler.SetOwnerQuota)
+	v1.DELETE("/costs/quotas/:owner", costsHandler.DeleteOwnerQuota)
+}
+
 // NewServer creates a new HTTP server using the infrastructure gin package.
 func NewServer(
 	cfg config.Interface,
@@ -155,10 +171,12 @@ func NewServer(
 	sseHandler *SSEHandler, // Optional - pass nil to disable SSE
 	migrationHandler *MigrationHandler, // Optional - pass nil to disable migration endpoints
 	syncHandler *admin.SyncEnabledSourcesHandler, // Optional - pass nil to disable sync endpoint
+	reconcileHandler *admin.ReconcileSourcesHandler, // Optional - pass nil to disable reconcile endpoints
 	frontierHandler *FrontierHandler, // Optional - pass nil to disable frontier endpoints
 	domainsHandler *DiscoveredDomainsHandler, // Optional - pass nil to disable domains endpoints
 	backfillHandler *admin.BackfillIndigenousHandler, // Optional - pass nil to disable backfill
 	worstSourcesHandler *admin.BackfillWorstSourcesHandler, // Optional - pass nil to disable worst-sources backfill
+	costsHandler *CostsHandler, // Optional - pass nil to disable cost endpoints
 ) *infragin.Server {
 	// Extract port from address
 	port := extractPortFromAddress(cfg.GetServerConfig().Address)
@@ -189,8 +207,8 @@ func NewServer(
 			setupCrawlerRoutes(
 				router, jwtSecret, jobsHandler, discoveredLinksHandler,
 				logsHandler, logsV2Handler, executionRepo, sseHandler,
-				migrationHandler, syncHandler, frontierHandler, domainsHandler,
-				backfillHandler, worstSourcesHandler,
+				migrationHandler, syncHandler, reconcileHandler, frontierHandler, domainsHandler,
+				backfillHandler, worstSourcesHandler, costsHandler,
 			)
 
 			// Setup internal service-to-service routes
@@ -246,10 +264,12 @@ func setupCrawlerRoutes(
 	sseHandler *SSEHandler,
 	migrationHandler *MigrationHandler,
 	syncHandler *admin.SyncEnabledSourcesHandler,
+	reconcileHandler *admin.ReconcileSourcesHandler,
 	frontierHandler *FrontierHandler,
 	domainsHandler *DiscoveredDomainsHandler,
 	backfillHandler *admin.BackfillIndigenousHandler,
 	worstSourcesHandler *admin.BackfillWorstSourcesHandler,
+	costsHandler *CostsHandler,
 ) {
 	// API v1 routes - protected with JWT
 	v1 := infragin.ProtectedGroup(router, "/api/v1", jwtSecret)
@@ -288,8 +308,13 @@ func setupCrawlerRoutes(
 		})
 	})
 
+	// Job, execution and scheduler routes: scoped tokens need crawler:jobs:read,
+	// or crawler:jobs:write for changes
+	jobs := infragin.ProtectedGroup(router, "/api/v1", jwtSecret,
+		infrajwt.WithScope("crawler:jobs"), infrajwt.WithReadRoutes("/api/v1/scheduler/rebalance/preview"))
+
 	// Setup job routes
-	setupJobRoutes(v1, jobsHandler)
+	setupJobRoutes(jobs, jobsHandler)
 
 	// API v2 routes (minimal: run-now only; same JWT protection)
 	v2 := infragin.ProtectedGroup(router, "/api/v2", jwtSecret)
@@ -307,6 +332,9 @@ func setupCrawlerRoutes(
 	// Setup frontier routes
 	setupFrontierRoutes(v1, frontierHandler)
 
+	// Setup cost attribution routes
+	setupCostRoutes(v1, costsHandler)
+
 	// Setup migration routes (Phase 3)
 	setupMigrationRoutes(v1, migrationHandler)
 
@@ -315,6 +343,12 @@ func setupCrawlerRoutes(
 		v1.POST("/admin/sync-enabled-sources", syncHandler.SyncEnabledSources)
 	}
 
+	// Admin: reconcile crawler jobs with their sources (crawler:jobs scope)
+	if reconcileHandler != nil {
+		jobs.GET("/admin/reconcile-sources", reconcileHandler.GetReconcileReport)
+		jobs.POST("/admin/reconcile-sources", reconcileHandler.ReconcileSources)
+	}
+
 	// Admin: backfill indigenous sources
 	if backfillHandler != nil {
 		v1.POST("/backfill/indigenous", backfillHandler.BackfillIndigenous)
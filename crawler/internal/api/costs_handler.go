@@ -0,0 +1,203 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/crawler/internal/cost"
+	"github.com/jonesrussell/north-cloud/crawler/internal/database"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// maxCostNameLength matches the owner and tag column widths.
+const maxCostNameLength = 255
+
+// CostsHandler handles cost attribution, source ownership, and owner quota requests.
+type CostsHandler struct {
+	repo    database.CostRepositoryInterface
+	service *cost.Service
+	log     infralogger.Logger
+}
+
+// NewCostsHandler creates a new costs handler.
+func NewCostsHandler(repo database.CostRepositoryInterface, service *cost.Service, log infralogger.Logger) *CostsHandler {
+	return &CostsHandler{repo: repo, service: service, log: log}
+}
+
+// SourceOwnerRequest assigns a source to an owner.
+type SourceOwnerRequest struct {
+	Owner string   `json:"owner" binding:"required"`
+	Tags  []string `json:"tags"`
+}
+
+// OwnerQuotaRequest sets an owner's limits; omitted or null limits are unlimited.
+type OwnerQuotaRequest struct {
+	MonthlyComputeSeconds *int64 `json:"monthly_compute_seconds" binding:"omitempty,min=0"`
+	MonthlyBandwidthBytes *int64 `json:"monthly_bandwidth_bytes" binding:"omitempty,min=0"`
+	StorageBytes          *int64 `json:"storage_bytes"           binding:"omitempty,min=0"`
+}
+
+// GetReport handles GET /api/v1/costs?month=YYYY-MM&group_by=owner|tag|source
+func (h *CostsHandler) GetReport(c *gin.Context) {
+	month, err := h.service.ParseMonth(c.Query("month"))
+	if err != nil {
+		respondBadRequest(c, err.Error())
+		return
+	}
+
+	report, err := h.service.Report(c.Request.Context(), month, c.DefaultQuery("group_by", domain.CostGroupByOwner))
+	if errors.Is(err, cost.ErrInvalidGroupBy) {
+		respondBadRequest(c, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to build cost report", infralogger.Error(err))
+		respondInternalError(c, "Failed to build cost report")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ListSourceOwners handles GET /api/v1/costs/owners[?owner=]
+func (h *CostsHandler) ListSourceOwners(c *gin.Context) {
+	owners, err := h.repo.ListSourceOwners(c.Request.Context(), c.Query("owner"))
+	if err != nil {
+		h.log.Error("Failed to list source owners", infralogger.Error(err))
+		respondInternalError(c, "Failed to list source owners")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"owners": owners,
+		"total":  len(owners),
+	})
+}
+
+// SetSourceOwner handles PUT /api/v1/costs/owners/:source_id
+func (h *CostsHandler) SetSourceOwner(c *gin.Context) {
+	var req SourceOwnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	owner := strings.TrimSpace(req.Owner)
+	tags := make([]string, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if !validCostName(owner) || !allValidCostNames(tags) {
+		respondBadRequest(c, "owner and tags must be 1-255 characters")
+		return
+	}
+
+	saved, err := h.repo.UpsertSourceOwner(c.Request.Context(), c.Param("source_id"), owner, tags)
+	if err != nil {
+		h.log.Error("Failed to set source owner", infralogger.Error(err))
+		respondInternalError(c, "Failed to set source owner")
+		return
+	}
+	h.service.Invalidate()
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteSourceOwner handles DELETE /api/v1/costs/owners/:source_id
+func (h *CostsHandler) DeleteSourceOwner(c *gin.Context) {
+	err := h.repo.DeleteSourceOwner(c.Request.Context(), c.Param("source_id"))
+	if errors.Is(err, database.ErrCostRecordNotFound) {
+		respondNotFound(c, "Source owner")
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to delete source owner", infralogger.Error(err))
+		respondInternalError(c, "Failed to delete source owner")
+		return
+	}
+	h.service.Invalidate()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Source owner removed"})
+}
+
+// ListOwnerQuotas handles GET /api/v1/costs/quotas
+func (h *CostsHandler) ListOwnerQuotas(c *gin.Context) {
+	quotas, err := h.repo.ListOwnerQuotas(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to list owner quotas", infralogger.Error(err))
+		respondInternalError(c, "Failed to list owner quotas")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"quotas": quotas,
+		"total":  len(quotas),
+	})
+}
+
+// SetOwnerQuota handles PUT /api/v1/costs/quotas/:owner
+func (h *CostsHandler) SetOwnerQuota(c *gin.Context) {
+	var req OwnerQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	owner := c.Param("owner")
+	if !validCostName(owner) {
+		respondBadRequest(c, "owner must be 1-255 characters")
+		return
+	}
+
+	saved, err := h.repo.UpsertOwnerQuota(c.Request.Context(), &domain.OwnerQuota{
+		Owner:                 owner,
+		MonthlyComputeSeconds: req.MonthlyComputeSeconds,
+		MonthlyBandwidthBytes: req.MonthlyBandwidthBytes,
+		StorageBytes:          req.StorageBytes,
+	})
+	if err != nil {
+		h.log.Error("Failed to set owner quota", infralogger.Error(err))
+		respondInternalError(c, "Failed to set owner quota")
+		return
+	}
+	h.service.Invalidate()
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteOwnerQuota handles DELETE /api/v1/costs/quotas/:owner
+func (h *CostsHandler) DeleteOwnerQuota(c *gin.Context) {
+	err := h.repo.DeleteOwnerQuota(c.Request.Context(), c.Param("owner"))
+	if errors.Is(err, database.ErrCostRecordNotFound) {
+		respondNotFound(c, "Owner quota")
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to delete owner quota", infralogger.Error(err))
+		respondInternalError(c, "Failed to delete owner quota")
+		return
+	}
+	h.service.Invalidate()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Owner quota removed"})
+}
+
+// validCostName reports whether an owner or tag name fits its column.
+func validCostName(name string) bool {
+	return name != "" && len(name) <= maxCostNameLength
+}
+
+// allValidCostNames reports whether every tag fits its column.
+func allValidCostNames(names []string) bool {
+	for _, name := range names {
+		if !validCostName(name) {
+			return false
+		}
+	}
+	return true
+}
@@ -214,6 +214,11 @@ func (h *JobsHandler) CreateJob(c *gin.Context) {
 		return
 	}
 
+	if overridesErr := req.Overrides.Validate(); overridesErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": overridesErr.Error()})
+		return
+	}
+
 	// Determine initial status
 	status := statusPending
 	if req.IntervalMinutes != nil && req.ScheduleEnabled {
@@ -233,6 +238,7 @@ func (h *JobsHandler) CreateJob(c *gin.Context) {
 		RetryBackoffSeconds: retryBackoff,
 		Status:              status,
 		Metadata:            req.Metadata,
+		Overrides:           req.Overrides,
 	}
 
 	// Set nullable string fields as pointers
@@ -347,6 +353,18 @@ func (h *JobsHandler) UpdateJob(c *gin.Context) {
 		job.Metadata = req.Metadata
 	}
 
+	// An empty overrides object clears them; omitting the field keeps them.
+	if req.Overrides != nil {
+		if overridesErr := req.Overrides.Validate(); overridesErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": overridesErr.Error()})
+			return
+		}
+		job.Overrides = req.Overrides
+		if req.Overrides.IsEmpty() {
+			job.Overrides = nil
+		}
+	}
+
 	// Save changes (trigger will recalculate next_run_at if needed)
 	if updateErr := h.repo.Update(c.Request.Context(), job); updateErr != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -549,6 +567,21 @@ func (h *JobsHandler) GetJobStatusCounts(c *gin.Context) {
 	c.JSON(http.StatusOK, counts)
 }
 
+// GetSourceHealth handles GET /api/v1/jobs/source-health: per source, the
+// status of its current job and when it last crawled successfully.
+func (h *JobsHandler) GetSourceHealth(c *gin.Context) {
+	health, err := h.executionRepo.GetSourceCrawlHealth(c.Request.Context())
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve source crawl health")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sources": health,
+		"total":   len(health),
+	})
+}
+
 // GetJobStats handles GET /api/v1/jobs/:id/stats
 func (h *JobsHandler) GetJobStats(c *gin.Context) {
 	id := c.Param("id")
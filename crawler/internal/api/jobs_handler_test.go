@@ -4,6 +4,7 @@ package api_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -93,7 +94,9 @@ func (m *mockJobRepo) CountByStatus(ctx context.Context) (map[string]int, error)
 }
 
 // mockExecutionRepo implements database.ExecutionRepositoryInterface for testing.
-type mockExecutionRepo struct{}
+type mockExecutionRepo struct {
+	sourceHealth []*domain.SourceCrawlHealth
+}
 
 func (m *mockExecutionRepo) Create(ctx context.Context, execution *domain.JobExecution) error {
 	return nil
@@ -147,6 +150,10 @@ func (m *mockExecutionRepo) GetOrphanedRunningJobs(ctx context.Context) ([]*doma
 	return nil, errMockNoData
 }
 
+func (m *mockExecutionRepo) GetSourceCrawlHealth(ctx context.Context) ([]*domain.SourceCrawlHealth, error) {
+	return m.sourceHealth, nil
+}
+
 func (m *mockExecutionRepo) CleanupOldExecutions(ctx context.Context) (int, error) {
 	return 0, nil
 }
@@ -233,3 +240,44 @@ func TestJobsHandler_CreateJob_InvalidRequest(t *testing.T) {
 		t.Errorf("expected status 400 for invalid request, got %d", w.Code)
 	}
 }
+
+func TestJobsHandler_GetSourceHealth(t *testing.T) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	lastSuccess := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	execRepo := &mockExecutionRepo{sourceHealth: []*domain.SourceCrawlHealth{
+		{SourceID: "src-1", JobID: "job-1", JobStatus: "scheduled", LastSuccessAt: &lastSuccess},
+		{SourceID: "src-2", JobID: "job-2", JobStatus: "failed", FailureCount: 3},
+	}}
+	handler := api.NewJobsHandler(&mockJobRepo{}, execRepo)
+	router.GET("/api/v1/jobs/source-health", handler.GetSourceHealth)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/source-health", http.NoBody)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Sources []domain.SourceCrawlHealth `json:"sources"`
+		Total   int                        `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Total != 2 || len(resp.Sources) != 2 {
+		t.Fatalf("expected 2 sources, got total=%d len=%d", resp.Total, len(resp.Sources))
+	}
+	if resp.Sources[0].LastSuccessAt == nil || !resp.Sources[0].LastSuccessAt.Equal(lastSuccess) {
+		t.Errorf("expected last_success_at %v, got %v", lastSuccess, resp.Sources[0].LastSuccessAt)
+	}
+	if resp.Sources[1].JobStatus != "failed" || resp.Sources[1].FailureCount != 3 {
+		t.Errorf("unexpected second source: %+v", resp.Sources[1])
+	}
+}
@@ -42,6 +42,9 @@ type CreateJobRequest struct {
 
 	// Metadata (new)
 	Metadata map[string]any `json:"metadata"`
+
+	// Per-job environment overrides (proxy, user agent, render mode)
+	Overrides *domain.JobOverrides `json:"overrides"`
 }
 
 // UpdateJobRequest represents a job update request.
@@ -68,6 +71,9 @@ type UpdateJobRequest struct {
 
 	// Metadata (new)
 	Metadata map[string]any `json:"metadata"`
+
+	// Per-job environment overrides (proxy, user agent, render mode)
+	Overrides *domain.JobOverrides `json:"overrides"`
 }
 
 // JobStatsResponse represents aggregate statistics for a job.
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/jonesrussell/north-cloud/crawler/internal/api"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
 )
@@ -30,11 +31,12 @@ type StaleURLRecoverer interface {
 
 // backgroundCancels holds cancel functions for background goroutines.
 type backgroundCancels struct {
-	feedPollerCancel    context.CancelFunc
-	feedDiscoveryCancel context.CancelFunc
-	workerPoolCancel    context.CancelFunc
-	frontierStatsCancel context.CancelFunc
-	staleRecoveryCancel context.CancelFunc
+	feedPollerCancel       context.CancelFunc
+	feedDiscoveryCancel    context.CancelFunc
+	sectionDiscoveryCancel context.CancelFunc
+	workerPoolCancel       context.CancelFunc
+	frontierStatsCancel    context.CancelFunc
+	staleRecoveryCancel    context.CancelFunc
 }
 
 // startBackgroundWorkers launches background goroutines for feed polling,
@@ -47,11 +49,11 @@ func startBackgroundWorkers(deps *CommandDeps, sc *ServiceComponents) background
 		pollerCtx, cancel := context.WithCancel(context.Background())
 		bg.feedPollerCancel = cancel
 		interval := time.Duration(feedCfg.PollIntervalMinutes) * time.Minute
-		go func() {
+		crash.Go(deps.Logger, "feed-poller", func() {
 			if err := sc.FeedPoller.RunPollingLoop(pollerCtx, interval, sc.ListDue); err != nil {
 				deps.Logger.Error("Feed poller stopped with error", infralogger.Error(err))
 			}
-		}()
+		})
 		deps.Logger.Info("Feed poller started",
 			infralogger.Int("interval_minutes", feedCfg.PollIntervalMinutes))
 	}
@@ -61,23 +63,37 @@ func startBackgroundWorkers(deps *CommandDeps, sc *ServiceComponents) background
 		dCtx, cancel := context.WithCancel(context.Background())
 		bg.feedDiscoveryCancel = cancel
 		interval := time.Duration(feedCfg.DiscoveryIntervalMinutes) * time.Minute
-		go func() {
+		crash.Go(deps.Logger, "feed-discovery", func() {
 			if err := sc.FeedDiscoverer.RunDiscoveryLoop(dCtx, interval, sc.ListUndiscovered); err != nil {
 				deps.Logger.Error("Feed discovery stopped with error", infralogger.Error(err))
 			}
-		}()
+		})
 		deps.Logger.Info("Feed discovery started",
 			infralogger.Int("interval_minutes", feedCfg.DiscoveryIntervalMinutes))
 	}
 
+	if sc.SectionDiscoverer != nil {
+		discoveryCfg := deps.Config.GetDiscoveryConfig()
+		sCtx, cancel := context.WithCancel(context.Background())
+		bg.sectionDiscoveryCancel = cancel
+		interval := time.Duration(discoveryCfg.SectionDiscoveryIntervalHours) * time.Hour
+		crash.Go(deps.Logger, "section-discovery", func() {
+			if err := sc.SectionDiscoverer.RunSectionDiscoveryLoop(sCtx, interval, sc.ListSectionSources); err != nil {
+				deps.Logger.Error("Section discovery stopped with error", infralogger.Error(err))
+			}
+		})
+		deps.Logger.Info("Section discovery started",
+			infralogger.Int("interval_hours", discoveryCfg.SectionDiscoveryIntervalHours))
+	}
+
 	if sc.FrontierWorkerPool != nil {
 		wpCtx, cancel := context.WithCancel(context.Background())
 		bg.workerPoolCancel = cancel
-		go func() {
+		crash.Go(deps.Logger, "frontier-worker-pool", func() {
 			if err := sc.FrontierWorkerPool.Start(wpCtx); err != nil {
 				deps.Logger.Error("Frontier worker pool stopped with error", infralogger.Error(err))
 			}
-		}()
+		})
 		fetcherCfg := deps.Config.GetFetcherConfig()
 		deps.Logger.Info("Frontier worker pool started",
 			infralogger.Int("worker_count", fetcherCfg.WorkerCount))
@@ -86,7 +102,9 @@ func startBackgroundWorkers(deps *CommandDeps, sc *ServiceComponents) background
 	if sc.FrontierRepoForHandler != nil {
 		statsCtx, cancel := context.WithCancel(context.Background())
 		bg.frontierStatsCancel = cancel
-		go runFrontierStatsLogger(statsCtx, sc.FrontierRepoForHandler, deps.Logger)
+		crash.Go(deps.Logger, "frontier-stats-logger", func() {
+			runFrontierStatsLogger(statsCtx, sc.FrontierRepoForHandler, deps.Logger)
+		})
 		deps.Logger.Info("Frontier stats logger started")
 	}
 
@@ -94,8 +112,10 @@ func startBackgroundWorkers(deps *CommandDeps, sc *ServiceComponents) background
 		fetcherCfg := deps.Config.GetFetcherConfig()
 		recoveryCtx, cancel := context.WithCancel(context.Background())
 		bg.staleRecoveryCancel = cancel
-		go runStaleURLRecovery(recoveryCtx, sc.StaleURLRecoverer, deps.Logger,
-			fetcherCfg.StaleTimeout, fetcherCfg.StaleCheckInterval)
+		crash.Go(deps.Logger, "stale-url-recovery", func() {
+			runStaleURLRecovery(recoveryCtx, sc.StaleURLRecoverer, deps.Logger,
+				fetcherCfg.StaleTimeout, fetcherCfg.StaleCheckInterval)
+		})
 		deps.Logger.Info("Stale URL recovery started",
 			infralogger.String("stale_timeout", fetcherCfg.StaleTimeout.String()),
 			infralogger.String("check_interval", fetcherCfg.StaleCheckInterval.String()))
@@ -171,6 +191,8 @@ func runStaleURLRecovery(
 // It handles all phases of bootstrap and returns an error if any phase fails.
 // The function blocks until the server is interrupted or encounters an error.
 func Start() error {
+	defer crash.Start("crawler")()
+
 	// Phase 0: Start profiling servers (if enabled)
 	profiling.StartPprofServer()
 
@@ -232,6 +254,7 @@ func Start() error {
 		JobRepo:                  dbComponents.JobRepo,
 		FrontierRepoForHandler:   serviceComponents.FrontierRepoForHandler,
 		ESStorage:                storageComponents.ConcreteStorage,
+		CostsHandler:             serviceComponents.CostsHandler,
 	}
 	serverComponents := SetupHTTPServer(serverDeps)
 
@@ -23,6 +23,7 @@ type DatabaseComponents struct {
 	DecisionLogRepo     *database.DecisionLogRepository
 	DomainStateRepo     *database.DomainStateRepository
 	DomainAggregateRepo *database.DomainAggregateRepository
+	CostRepo            *database.CostRepository
 }
 
 // SetupDatabase connects to PostgreSQL and creates all repositories.
@@ -52,6 +53,7 @@ func SetupDatabase(cfg config.Interface) (*DatabaseComponents, error) {
 		DecisionLogRepo:     decisionLogRepo,
 		DomainStateRepo:     domainStateRepo,
 		DomainAggregateRepo: domainAggregateRepo,
+		CostRepo:            database.NewCostRepository(db),
 	}, nil
 }
 
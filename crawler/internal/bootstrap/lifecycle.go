@@ -72,6 +72,12 @@ func Shutdown(
 		bg.feedDiscoveryCancel()
 	}
 
+	// Stop section discoverer (cancels discovery goroutine)
+	if bg.sectionDiscoveryCancel != nil {
+		log.Info("Stopping section discoverer")
+		bg.sectionDiscoveryCancel()
+	}
+
 	// Stop frontier worker pool (cancels all worker goroutines)
 	if bg.workerPoolCancel != nil {
 		log.Info("Stopping frontier worker pool")
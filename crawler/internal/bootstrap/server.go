@@ -30,6 +30,7 @@ type HTTPServerDeps struct {
 	JobRepo                  *database.JobRepository
 	FrontierRepoForHandler   api.FrontierRepoForHandler
 	ESStorage                admin.ESSearcher
+	CostsHandler             *api.CostsHandler
 }
 
 // ServerComponents holds the HTTP server and error channel.
@@ -53,6 +54,12 @@ func SetupHTTPServer(deps *HTTPServerDeps) *ServerComponents {
 		deps.Logger,
 		syncStaggerMinutes*time.Minute,
 	)
+	reconcileHandler := admin.NewReconcileSourcesHandler(
+		sourceClient,
+		deps.JobRepo,
+		scheduleComputer,
+		deps.Logger,
+	)
 	backfillHandler := admin.NewBackfillIndigenousHandler(
 		sourceClient,
 		deps.JobRepo,
@@ -81,9 +88,9 @@ func SetupHTTPServer(deps *HTTPServerDeps) *ServerComponents {
 	server := api.NewServer(
 		deps.Config, deps.JobsHandler, deps.DiscoveredLinksHandler,
 		deps.LogsHandler, deps.LogsV2Handler, deps.ExecutionRepo,
-		deps.Logger, deps.SSEHandler, migrationHandler, syncHandler,
+		deps.Logger, deps.SSEHandler, migrationHandler, syncHandler, reconcileHandler,
 		frontierHandler, deps.DiscoveredDomainsHandler, backfillHandler,
-		worstSourcesHandler,
+		worstSourcesHandler, deps.CostsHandler,
 	)
 
 	deps.Logger.Info("Starting HTTP server", infralogger.String("addr", deps.Config.GetServerConfig().Address))
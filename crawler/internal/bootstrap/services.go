@@ -11,6 +11,7 @@ import (
 	"github.com/jonesrussell/north-cloud/crawler/internal/adaptive"
 	"github.com/jonesrussell/north-cloud/crawler/internal/api"
 	"github.com/jonesrussell/north-cloud/crawler/internal/config"
+	"github.com/jonesrussell/north-cloud/crawler/internal/cost"
 	"github.com/jonesrussell/north-cloud/crawler/internal/crawler"
 	crawlerevents "github.com/jonesrussell/north-cloud/crawler/internal/crawler/events"
 	"github.com/jonesrussell/north-cloud/crawler/internal/database"
@@ -38,6 +39,7 @@ type ServiceComponents struct {
 	DiscoveredDomainsHandler *api.DiscoveredDomainsHandler
 	LogsHandler              *api.LogsHandler
 	LogsV2Handler            *api.LogsStreamV2Handler
+	CostsHandler             *api.CostsHandler
 
 	// Services
 	Scheduler  *scheduler.IntervalScheduler
@@ -51,6 +53,10 @@ type ServiceComponents struct {
 	FeedDiscoverer   *feed.Discoverer
 	ListUndiscovered func(ctx context.Context) ([]feed.UndiscoveredSource, error)
 
+	// Section discoverer
+	SectionDiscoverer  *feed.SectionDiscoverer
+	ListSectionSources func(ctx context.Context) ([]feed.SectionSource, error)
+
 	// Frontier worker pool
 	FrontierWorkerPool *fetcher.WorkerPool
 
@@ -124,10 +130,14 @@ func SetupServices(
 		frontierForFeed = wrapped
 	}
 
+	// Cost attribution; the scheduler defers jobs of owners over quota
+	costService := createCostService(deps, storage, db)
+	costsHandler := api.NewCostsHandler(db.CostRepo, costService, deps.Logger)
+
 	// Create and start scheduler (if enabled)
 	var intervalScheduler *scheduler.IntervalScheduler
 	if deps.Config.GetSchedulerConfig().Enabled {
-		intervalScheduler = createAndStartScheduler(deps, storage, db, frontierForSubmission, sharedPool)
+		intervalScheduler = createAndStartScheduler(deps, storage, db, frontierForSubmission, sharedPool, costService)
 	} else {
 		deps.Logger.Info("Interval scheduler disabled (CRAWLER_SCHEDULER_ENABLED=false)")
 	}
@@ -146,6 +156,9 @@ func SetupServices(
 	// Create feed discoverer (if enabled)
 	feedDiscoverer, listUndiscovered := createFeedDiscoverer(deps, sharedPool)
 
+	// Create section discoverer (if enabled)
+	sectionDiscoverer, listSectionSources := createSectionDiscoverer(deps, sharedPool)
+
 	// Create frontier worker pool (if enabled); uses raw repo for claimer
 	workerPool := createFrontierWorkerPool(deps, db, storage, sharedPool)
 
@@ -164,12 +177,15 @@ func SetupServices(
 		DiscoveredDomainsHandler: domainsHandler,
 		LogsHandler:              logsHandler,
 		LogsV2Handler:            logsV2Handler,
+		CostsHandler:             costsHandler,
 		Scheduler:                intervalScheduler,
 		LogService:               logResult.Service,
 		FeedPoller:               feedPoller,
 		ListDue:                  listDue,
 		FeedDiscoverer:           feedDiscoverer,
 		ListUndiscovered:         listUndiscovered,
+		SectionDiscoverer:        sectionDiscoverer,
+		ListSectionSources:       listSectionSources,
 		FrontierWorkerPool:       workerPool,
 		FrontierRepoForHandler:   frontierForHandler,
 		StaleURLRecoverer:        staleRecoverer,
@@ -263,6 +279,7 @@ func createAndStartScheduler(
 	db *DatabaseComponents,
 	frontierForSubmission crawler.LinkFrontierSubmitter,
 	pool *proxypool.Pool,
+	quotaGate scheduler.QuotaGate,
 ) *scheduler.IntervalScheduler {
 	// Create crawler factory for job execution (each job gets an isolated instance)
 	crawlerFactory, err := createCrawlerFactory(deps, storage, db, frontierForSubmission, pool)
@@ -286,6 +303,7 @@ func createAndStartScheduler(
 		db.ExecutionRepo,
 		crawlerFactory,
 		scheduler.WithScraperConfig(scraperCfg),
+		scheduler.WithQuotaGate(quotaGate),
 	)
 
 	// Start the scheduler
@@ -298,6 +316,16 @@ func createAndStartScheduler(
 	return intervalScheduler
 }
 
+// createCostService creates the cost attribution service. Storage sizes are
+// read from Elasticsearch when the concrete storage is available.
+func createCostService(deps *CommandDeps, storage *StorageComponents, db *DatabaseComponents) *cost.Service {
+	var sizer cost.IndexSizer
+	if storage != nil && storage.ConcreteStorage != nil {
+		sizer = storage.ConcreteStorage
+	}
+	return cost.NewService(db.CostRepo, sizer, deps.Logger)
+}
+
 // createCrawlerFactory creates a crawler factory for job execution.
 // Each job gets an isolated crawler instance from the factory.
 func createCrawlerFactory(
@@ -358,6 +386,12 @@ func buildCrawlerParams(
 		frontierSubmitter = frontierForSubmission
 	}
 
+	// Render worker for jobs that override render_mode to dynamic.
+	var renderer crawler.PageRenderer
+	if crawlerCfg.RenderWorkerURL != "" {
+		renderer = render.NewClient(crawlerCfg.RenderWorkerURL)
+	}
+
 	return crawler.CrawlerParams{
 		Logger:            deps.Logger,
 		Bus:               bus,
@@ -372,6 +406,7 @@ func buildCrawlerParams(
 		HashTracker:       hashTracker,
 		FrontierSubmitter: frontierSubmitter,
 		ProxyPool:         pool,
+		Renderer:          renderer,
 	}, nil
 }
 
@@ -604,6 +639,105 @@ func buildListUndiscoveredFunc(
 	}
 }
 
+// sectionSuggestionNoteKind is the source-manager note kind for section suggestions.
+const sectionSuggestionNoteKind = "section_suggestion"
+
+// sectionSuggesterAdapter files section suggestions as source-manager notes.
+type sectionSuggesterAdapter struct {
+	client *apiclient.Client
+}
+
+// SuggestSection files a section suggestion as a note on the source. The dedupe
+// key keeps a restarted crawler from filing the same section twice.
+func (a *sectionSuggesterAdapter) SuggestSection(
+	ctx context.Context, sourceID string, suggestion feed.SectionSuggestion,
+) error {
+	body := fmt.Sprintf("New section %s found on the homepage.", suggestion.Section)
+	if suggestion.Reason == feed.SectionReasonUnmatched {
+		body = fmt.Sprintf("Section %s is linked from the homepage but none of its links match "+
+			"the source's article URL patterns.", suggestion.Section)
+	}
+	body += " Suggested pattern: " + suggestion.SuggestedPattern
+
+	_, err := a.client.CreateNote(ctx, sourceID, &apiclient.APISourceNote{
+		Kind:   sectionSuggestionNoteKind,
+		Author: "crawler",
+		Body:   body,
+		Details: map[string]any{
+			"section":           suggestion.Section,
+			"reason":            suggestion.Reason,
+			"suggested_pattern": suggestion.SuggestedPattern,
+			"sample_urls":       suggestion.SampleURLs,
+		},
+		DedupeKey: "section:" + suggestion.Section,
+	})
+	if err != nil {
+		return fmt.Errorf("create section note: %w", err)
+	}
+
+	return nil
+}
+
+// createSectionDiscoverer creates a section discoverer and its source listing callback.
+// Returns (nil, nil) if section discovery is disabled.
+func createSectionDiscoverer(
+	deps *CommandDeps,
+	pool *proxypool.Pool,
+) (discoverer *feed.SectionDiscoverer, listSourcesFn func(ctx context.Context) ([]feed.SectionSource, error)) {
+	discoveryCfg := deps.Config.GetDiscoveryConfig()
+	if !discoveryCfg.SectionDiscoveryEnabled {
+		deps.Logger.Info("Section discovery disabled")
+		return nil, nil
+	}
+
+	smCfg := deps.Config.GetSourceManagerConfig()
+	authCfg := deps.Config.GetAuthConfig()
+
+	apiClient := apiclient.NewClient(
+		apiclient.WithBaseURL(smCfg.URL+"/api/v1/sources"),
+		apiclient.WithJWTSecret(authCfg.JWTSecret),
+	)
+
+	httpFetcher := feed.NewHTTPFetcher(buildProxiedHTTPClient(
+		pool, feedHTTPFetchTimeout, deps.Logger,
+	))
+	discoverer = feed.NewSectionDiscoverer(
+		httpFetcher, &sectionSuggesterAdapter{client: apiClient}, &logAdapter{log: deps.Logger},
+	)
+
+	deps.Logger.Info("Section discoverer created",
+		infralogger.Int("interval_hours", discoveryCfg.SectionDiscoveryIntervalHours))
+
+	return discoverer, buildListSectionSourcesFunc(apiClient)
+}
+
+// buildListSectionSourcesFunc creates a closure that lists enabled sources for section discovery.
+func buildListSectionSourcesFunc(
+	client *apiclient.Client,
+) func(ctx context.Context) ([]feed.SectionSource, error) {
+	return func(ctx context.Context) ([]feed.SectionSource, error) {
+		apiSources, err := client.ListSources(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list sources for section discovery: %w", err)
+		}
+
+		sources := make([]feed.SectionSource, 0, len(apiSources))
+		for i := range apiSources {
+			if !apiSources[i].Enabled {
+				continue
+			}
+
+			sources = append(sources, feed.SectionSource{
+				SourceID:           apiSources[i].ID,
+				BaseURL:            apiSources[i].URL,
+				ArticleURLPatterns: apiSources[i].ArticleURLPatterns,
+			})
+		}
+
+		return sources, nil
+	}
+}
+
 // createFrontierWorkerPool creates a frontier worker pool if the fetcher is enabled.
 // Returns nil if the fetcher is disabled.
 func createFrontierWorkerPool(
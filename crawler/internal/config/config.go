@@ -84,6 +84,11 @@ const (
 	defaultFeedDiscoveryRetryHours      = 168 // 7 days
 )
 
+// Section discovery defaults
+const (
+	defaultSectionDiscoveryIntervalHours = 24
+)
+
 // Ensure Config implements Interface
 var _ Interface = (*Config)(nil)
 
@@ -181,6 +186,11 @@ type DiscoveryConfig struct {
 	GlobalCrawlBudgetPerDay int `env:"CRAWLER_DISCOVERY_GLOBAL_BUDGET_PER_DAY" yaml:"global_crawl_budget_per_day"`
 	// MaxNewCandidatesPerRun caps new candidates per pipeline run (0 = no cap).
 	MaxNewCandidatesPerRun int `env:"CRAWLER_DISCOVERY_MAX_CANDIDATES_PER_RUN" yaml:"max_new_candidates_per_run"`
+	// SectionDiscoveryEnabled: when true, source homepages are periodically sampled for new
+	// sections the crawl filters miss; findings are filed as source-manager notes. Default false.
+	SectionDiscoveryEnabled bool `env:"CRAWLER_SECTION_DISCOVERY_ENABLED" yaml:"section_discovery_enabled"`
+	// SectionDiscoveryIntervalHours is how often each source's homepage is sampled.
+	SectionDiscoveryIntervalHours int `env:"CRAWLER_SECTION_DISCOVERY_INTERVAL_HOURS" yaml:"section_discovery_interval_hours"`
 }
 
 // validateHTTPDConfig validates the configuration for the httpd command
@@ -460,11 +470,13 @@ func (c *Config) GetFeedConfig() *FeedConfig {
 func (c *Config) GetDiscoveryConfig() *DiscoveryConfig {
 	if c.Discovery == nil {
 		return &DiscoveryConfig{
-			AutoSourceDiscoveryEnabled: false,
-			Allowlist:                  nil,
-			Blocklist:                  nil,
-			GlobalCrawlBudgetPerDay:    0,
-			MaxNewCandidatesPerRun:     0,
+			AutoSourceDiscoveryEnabled:    false,
+			Allowlist:                     nil,
+			Blocklist:                     nil,
+			GlobalCrawlBudgetPerDay:       0,
+			MaxNewCandidatesPerRun:        0,
+			SectionDiscoveryEnabled:       false,
+			SectionDiscoveryIntervalHours: defaultSectionDiscoveryIntervalHours,
 		}
 	}
 	return c.Discovery
@@ -533,6 +545,10 @@ func setDiscoveryDefaults(cfg *Config) {
 			MaxNewCandidatesPerRun:     0,
 		}
 	}
+
+	if cfg.Discovery.SectionDiscoveryIntervalHours <= 0 {
+		cfg.Discovery.SectionDiscoveryIntervalHours = defaultSectionDiscoveryIntervalHours
+	}
 }
 
 // setupDevelopmentLogging configures logging settings based on environment variables.
@@ -7,6 +7,8 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -106,6 +108,12 @@ type Config struct {
 	ProxyPoolURLs []string `env:"CRAWLER_PROXY_POOL_URLS" yaml:"proxy_pool_urls"`
 	// ProxyStickyTTL is how long a domain stays assigned to the same proxy
 	ProxyStickyTTL time.Duration `env:"CRAWLER_PROXY_STICKY_TTL" yaml:"proxy_sticky_ttl"`
+	// SOCKSProxyURL is the SOCKS5 endpoint (e.g. a Tor client, "socks5h://tor:9050") used for SOCKSSources
+	SOCKSProxyURL string `env:"CRAWLER_SOCKS_PROXY_URL" yaml:"socks_proxy_url"`
+	// SOCKSSources lists source names that must only be fetched through SOCKSProxyURL
+	SOCKSSources []string `env:"CRAWLER_SOCKS_SOURCES" yaml:"socks_sources"`
+	// SOCKSIsolateCircuits sends per-execution SOCKS credentials so Tor builds a fresh circuit for each execution
+	SOCKSIsolateCircuits bool `env:"CRAWLER_SOCKS_ISOLATE_CIRCUITS" yaml:"socks_isolate_circuits"`
 	// ReadabilityFallbackEnabled enables a last-resort readability-style extractor when selectors yield no content (default: true).
 	// Set CRAWLER_READABILITY_FALLBACK_ENABLED=false to disable.
 	ReadabilityFallbackEnabled bool `env:"CRAWLER_READABILITY_FALLBACK_ENABLED" yaml:"readability_fallback_enabled"`
@@ -143,6 +151,9 @@ func (c *Config) Validate() error {
 	if c.ProxyPoolEnabled && len(c.ProxyPoolURLs) == 0 {
 		return errors.New("proxy_pool_urls must be non-empty when proxy pool is enabled")
 	}
+	if err := c.validateSOCKS(); err != nil {
+		return err
+	}
 	return c.TLS.Validate()
 }
 
@@ -188,6 +199,7 @@ func New(opts ...Option) *Config {
 		ProxyPoolEnabled:           false,
 		ProxyPoolURLs:              nil,
 		ProxyStickyTTL:             DefaultProxyStickyTTL,
+		SOCKSIsolateCircuits:       true,
 		ReadabilityFallbackEnabled: true,
 	}
 
@@ -328,3 +340,28 @@ func (c *TLSConfig) Validate() error {
 	}
 	return nil
 }
+
+// UsesSOCKS reports whether the named source must be fetched through the SOCKS proxy.
+func (c *Config) UsesSOCKS(sourceName string) bool {
+	return c.SOCKSProxyURL != "" && slices.Contains(c.SOCKSSources, sourceName)
+}
+
+// validateSOCKS checks the SOCKS proxy settings. A SOCKS source without a
+// usable proxy is an error: fetching it directly would defeat the point.
+func (c *Config) validateSOCKS() error {
+	if c.SOCKSProxyURL == "" {
+		if len(c.SOCKSSources) > 0 {
+			return errors.New("socks_proxy_url is required when socks_sources is set")
+		}
+		return nil
+	}
+
+	parsed, err := url.Parse(c.SOCKSProxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid socks_proxy_url: %w", err)
+	}
+	if (parsed.Scheme != "socks5" && parsed.Scheme != "socks5h") || parsed.Host == "" {
+		return errors.New("socks_proxy_url must be a socks5:// or socks5h:// URL with a host")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package crawler_test
+
+import (
+	"testing"
+
+	crawlerconfig "github.com/jonesrussell/north-cloud/crawler/internal/config/crawler"
+)
+
+func TestConfigValidate_SOCKS(t *testing.T) {
+	t.Helper()
+
+	cases := []struct {
+		name    string
+		proxy   string
+		sources []string
+		wantErr bool
+	}{
+		{name: "disabled", wantErr: false},
+		{name: "sources without proxy", sources: []string{"a"}, wantErr: true},
+		{name: "http proxy rejected", proxy: "http://squid:3128", sources: []string{"a"}, wantErr: true},
+		{name: "socks5h accepted", proxy: "socks5h://tor:9050", sources: []string{"a"}, wantErr: false},
+	}
+	for _, tc := range cases {
+		cfg := crawlerconfig.New()
+		cfg.SOCKSProxyURL = tc.proxy
+		cfg.SOCKSSources = tc.sources
+		if err := cfg.Validate(); (err != nil) != tc.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestConfigUsesSOCKS(t *testing.T) {
+	t.Helper()
+
+	cfg := crawlerconfig.New()
+	cfg.SOCKSSources = []string{"hidden_news"}
+	if cfg.UsesSOCKS("hidden_news") {
+		t.Error("UsesSOCKS() = true without a proxy URL")
+	}
+
+	cfg.SOCKSProxyURL = "socks5h://tor:9050"
+	if !cfg.UsesSOCKS("hidden_news") || cfg.UsesSOCKS("other") {
+		t.Error("UsesSOCKS() should match only listed sources")
+	}
+}
@@ -34,6 +34,27 @@ type Source struct {
 	// Used by the link collector to decide which URLs to pass to the detail collector.
 	// Optional — if empty, uses heuristic detection (og:type, JSON-LD, URL patterns).
 	ArticleURLPatterns []string `yaml:"article_url_patterns"`
+	// Credentials are the decrypted secrets the source needs to be fetched,
+	// resolved from source-manager at crawl start. Never loaded from or
+	// written to config files.
+	Credentials []SourceCredential `yaml:"-"`
+}
+
+// SourceCredential is a secret applied to requests to a source's own hosts.
+type SourceCredential struct {
+	// Kind is basic_auth, api_key or cookie.
+	Kind string
+	// Username is the basic auth user name.
+	Username string
+	// HeaderName is the header an API key is sent in.
+	HeaderName string
+	// Value is the password, API key or cookie string.
+	Value string
+}
+
+// String hides the credential's value from logs.
+func (c SourceCredential) String() string {
+	return c.Kind + ":****"
 }
 
 // Validate validates the source configuration.
@@ -47,6 +47,7 @@ type RawContentData struct {
 	OGURL              string
 	CanonicalURL       string
 	Author             string
+	Language           string // primary language subtag, e.g. "en" or "fr"
 	PublishedDate      *time.Time
 	ArticleSection     string
 	ArticleOpinion     bool
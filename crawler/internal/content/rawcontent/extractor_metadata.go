@@ -66,6 +66,7 @@ func extractMetadata(data *RawContentData, e *colly.HTMLElement) {
 	data.OGURL = extractMeta(e, "og:url")
 	data.CanonicalURL = extractAttr(e, "link[rel='canonical']", "href")
 	data.Author = extractMeta(e, "author")
+	data.Language = extractLanguage(e)
 
 	// Try to extract published date from meta tags
 	if dateStr := extractMeta(e, "article:published_time"); dateStr != "" {
@@ -110,6 +111,45 @@ func extractMetadata(data *RawContentData, e *colly.HTMLElement) {
 	data.OGSiteName = extendedOG.SiteName
 }
 
+// maxLanguageSubtagLength is the longest ISO 639 primary language subtag.
+const maxLanguageSubtagLength = 3
+
+// extractLanguage returns the page's primary language subtag ("fr" for
+// lang="fr-CA"), from the html lang attribute, then og:locale, then the
+// Content-Language meta tag. Returns "" when none is declared.
+func extractLanguage(e *colly.HTMLElement) string {
+	candidates := []string{
+		e.DOM.AttrOr("lang", ""),
+		e.ChildAttr("html", "lang"),
+		extractMeta(e, "og:locale"),
+		e.ChildAttr("meta[http-equiv='content-language']", "content"),
+	}
+	for _, candidate := range candidates {
+		if lang := primaryLanguageSubtag(candidate); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// primaryLanguageSubtag lowercases the language part of a tag such as
+// "fr-CA", "en_US" or "fr, en". Returns "" when it is not 2-3 letters.
+func primaryLanguageSubtag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, "-_, "); i >= 0 {
+		tag = tag[:i]
+	}
+	if len(tag) < 2 || len(tag) > maxLanguageSubtagLength {
+		return ""
+	}
+	for _, r := range tag {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return ""
+		}
+	}
+	return strings.ToLower(tag)
+}
+
 // dateCSSSelectors are common CSS class selectors for published date elements.
 var dateCSSSelectors = []string{".published-date", ".post-date", ".entry-date", ".article-date"}
 
@@ -684,6 +684,54 @@ func TestExtractRawContent_DateFallbackChain(t *testing.T) {
 	}
 }
 
+func TestExtractRawContent_Language(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "html lang with region",
+			html: `<html lang="fr-CA"><head></head><body></body></html>`,
+			want: "fr",
+		},
+		{
+			name: "falls back to og:locale",
+			html: `<html><head><meta property="og:locale" content="en_US"></head><body></body></html>`,
+			want: "en",
+		},
+		{
+			name: "falls back to content-language",
+			html: `<html><head><meta http-equiv="content-language" content="fr, en"></head><body></body></html>`,
+			want: "fr",
+		},
+		{
+			name: "ignores malformed tags",
+			html: `<html lang="x"><head><meta property="og:locale" content="FR_ca"></head><body></body></html>`,
+			want: "fr",
+		},
+		{
+			name: "empty when undeclared",
+			html: `<html><head></head><body></body></html>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Helper()
+
+			e := newHTMLElement(t, tt.html)
+			result := rawcontent.ExtractRawContent(e, "https://example.com/test", "", "", "", nil)
+			if result.Language != tt.want {
+				t.Errorf("Language = %q, want %q", result.Language, tt.want)
+			}
+		})
+	}
+}
+
 func TestExtractRawContent_AuthorFallbackChain(t *testing.T) {
 	t.Helper()
 
@@ -624,6 +624,7 @@ func (s *RawContentService) convertToRawContent(
 		OGDescription:        rawData.OGDescription,
 		OGImage:              rawData.OGImage,
 		Author:               rawData.Author,
+		Language:             rawData.Language,
 		PublishedDate:        rawData.PublishedDate,
 		CanonicalURL:         rawData.CanonicalURL,
 		ArticleSection:       rawData.ArticleSection,
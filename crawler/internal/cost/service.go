@@ -0,0 +1,320 @@
+// Package cost attributes crawl compute time, bandwidth, and Elasticsearch
+// storage to sources, aggregates it per owner or tag, and enforces optional
+// monthly quotas per owner.
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/naming"
+)
+
+const (
+	// defaultQuotaRefreshInterval bounds how stale the quota status used by the scheduler can be.
+	defaultQuotaRefreshInterval = 5 * time.Minute
+	// contentIndexPattern matches the per-source indexes whose size is attributed to the source.
+	contentIndexPattern = "*_raw_content,*_classified_content"
+	// monthLayout is the format of report months.
+	monthLayout = "2006-01"
+	msPerSecond = 1000
+)
+
+// ErrInvalidGroupBy is returned for an unknown report grouping.
+var ErrInvalidGroupBy = errors.New("group_by must be owner, tag, or source")
+
+// UsageStore provides per-source usage and owner quotas.
+type UsageStore interface {
+	SourceUsage(ctx context.Context, from, to time.Time) ([]*domain.SourceUsage, error)
+	ListOwnerQuotas(ctx context.Context) ([]*domain.OwnerQuota, error)
+}
+
+// IndexSizer reports Elasticsearch index sizes.
+type IndexSizer interface {
+	IndexStoreSizes(ctx context.Context, pattern string) (map[string]int64, error)
+}
+
+// Service builds cost reports and answers whether a source's owner is over quota.
+type Service struct {
+	store UsageStore
+	sizer IndexSizer // nil leaves storage at zero
+	log   infralogger.Logger
+	now   func() time.Time
+
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	refreshedAt time.Time
+	sourceOwner map[string]string   // sourceID -> owner
+	exceeded    map[string][]string // owner -> exceeded quota dimensions
+}
+
+// NewService creates a cost service. sizer may be nil when Elasticsearch is unavailable.
+func NewService(store UsageStore, sizer IndexSizer, log infralogger.Logger) *Service {
+	return &Service{
+		store:           store,
+		sizer:           sizer,
+		log:             log,
+		now:             time.Now,
+		refreshInterval: defaultQuotaRefreshInterval,
+		sourceOwner:     map[string]string{},
+		exceeded:        map[string][]string{},
+	}
+}
+
+// ParseMonth parses a YYYY-MM month; an empty string is the current month (UTC).
+func (s *Service) ParseMonth(month string) (time.Time, error) {
+	if month == "" {
+		now := s.now().UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	parsed, err := time.Parse(monthLayout, month)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("month must be YYYY-MM: %w", err)
+	}
+	return parsed, nil
+}
+
+// Report aggregates usage for the month starting at monthStart, grouped by
+// owner, tag, or source. Storage is the current index size whatever the month.
+func (s *Service) Report(ctx context.Context, monthStart time.Time, groupBy string) (*domain.CostReport, error) {
+	switch groupBy {
+	case domain.CostGroupByOwner, domain.CostGroupByTag, domain.CostGroupBySource:
+	default:
+		return nil, ErrInvalidGroupBy
+	}
+
+	from := monthStart.UTC()
+	to := from.AddDate(0, 1, 0)
+
+	usage, err := s.sourceUsage(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var quotas []*domain.OwnerQuota
+	if groupBy == domain.CostGroupByOwner {
+		quotas, err = s.store.ListOwnerQuotas(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list owner quotas: %w", err)
+		}
+	}
+
+	items, total := aggregate(usage, groupBy, quotas)
+
+	return &domain.CostReport{
+		Month:   from.Format(monthLayout),
+		GroupBy: groupBy,
+		From:    from,
+		To:      to,
+		Items:   items,
+		Total:   total,
+	}, nil
+}
+
+// sourceUsage loads per-source usage and fills in storage from Elasticsearch.
+// Storage lookup failures are logged and leave storage at zero.
+func (s *Service) sourceUsage(ctx context.Context, from, to time.Time) ([]*domain.SourceUsage, error) {
+	usage, err := s.store.SourceUsage(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query source usage: %w", err)
+	}
+
+	if s.sizer == nil {
+		return usage, nil
+	}
+
+	sizes, sizeErr := s.sizer.IndexStoreSizes(ctx, contentIndexPattern)
+	if sizeErr != nil {
+		s.log.Warn("Failed to read index sizes for cost attribution", infralogger.Error(sizeErr))
+		return usage, nil
+	}
+
+	for _, u := range usage {
+		if u.SourceName == "" {
+			continue
+		}
+		u.StorageBytes = sizes[naming.RawContentIndex(u.SourceName)] +
+			sizes[naming.ClassifiedContentIndex(u.SourceName)]
+	}
+
+	return usage, nil
+}
+
+// aggregate groups source usage by the given key. A source with several tags
+// counts toward each of them, so tag totals can exceed the overall total.
+func aggregate(
+	usage []*domain.SourceUsage,
+	groupBy string,
+	quotas []*domain.OwnerQuota,
+) (items []*domain.CostUsage, total *domain.CostUsage) {
+	groups := make(map[string]*domain.CostUsage)
+	total = &domain.CostUsage{Key: "total"}
+
+	for _, u := range usage {
+		add(total, u)
+		for _, key := range groupKeys(u, groupBy) {
+			group, ok := groups[key]
+			if !ok {
+				group = &domain.CostUsage{Key: key}
+				groups[key] = group
+			}
+			add(group, u)
+		}
+	}
+
+	// Owners with a quota are listed even before their first crawl
+	for _, quota := range quotas {
+		group, ok := groups[quota.Owner]
+		if !ok {
+			group = &domain.CostUsage{Key: quota.Owner}
+			groups[quota.Owner] = group
+		}
+		group.Quota = quota
+		group.QuotaExceeded = exceededDimensions(group, quota)
+	}
+
+	items = make([]*domain.CostUsage, 0, len(groups))
+	for _, group := range groups {
+		items = append(items, group)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+
+	return items, total
+}
+
+// groupKeys returns the groups a source's usage is counted in.
+func groupKeys(u *domain.SourceUsage, groupBy string) []string {
+	switch groupBy {
+	case domain.CostGroupByOwner:
+		if u.Owner == "" {
+			return []string{domain.CostUnassignedOwner}
+		}
+		return []string{u.Owner}
+	case domain.CostGroupByTag:
+		if len(u.Tags) == 0 {
+			return []string{domain.CostUntagged}
+		}
+		return u.Tags
+	default:
+		if u.SourceName != "" {
+			return []string{u.SourceName}
+		}
+		return []string{u.SourceID}
+	}
+}
+
+// add adds one source's usage to a group.
+func add(group *domain.CostUsage, u *domain.SourceUsage) {
+	group.Sources++
+	group.Executions += u.Executions
+	group.ComputeSeconds += float64(u.ComputeMs) / msPerSecond
+	group.BandwidthBytes += u.BandwidthBytes
+	group.StorageBytes += u.StorageBytes
+}
+
+// exceededDimensions lists the quota dimensions a group's usage is over.
+func exceededDimensions(group *domain.CostUsage, quota *domain.OwnerQuota) []string {
+	var exceeded []string
+	if quota.MonthlyComputeSeconds != nil && group.ComputeSeconds > float64(*quota.MonthlyComputeSeconds) {
+		exceeded = append(exceeded, domain.QuotaCompute)
+	}
+	if quota.MonthlyBandwidthBytes != nil && group.BandwidthBytes > *quota.MonthlyBandwidthBytes {
+		exceeded = append(exceeded, domain.QuotaBandwidth)
+	}
+	if quota.StorageBytes != nil && group.StorageBytes > *quota.StorageBytes {
+		exceeded = append(exceeded, domain.QuotaStorage)
+	}
+	return exceeded
+}
+
+// QuotaExceeded reports whether the owner of sourceID is over any of its
+// quotas this month, and which ones. Status is cached for up to five minutes;
+// when it cannot be computed, sources are treated as within quota.
+func (s *Service) QuotaExceeded(ctx context.Context, sourceID string) (exceeded bool, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.now().Sub(s.refreshedAt) >= s.refreshInterval {
+		s.refreshLocked(ctx)
+	}
+
+	owner, ok := s.sourceOwner[sourceID]
+	if !ok {
+		return false, ""
+	}
+	dims := s.exceeded[owner]
+	if len(dims) == 0 {
+		return false, ""
+	}
+	return true, fmt.Sprintf("owner %s is over its monthly %s quota", owner, strings.Join(dims, ", "))
+}
+
+// Invalidate forces the next QuotaExceeded call to recompute quota status,
+// e.g. after an owner or quota changes.
+func (s *Service) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshedAt = time.Time{}
+}
+
+// refreshLocked recomputes owner quota status for the current month and logs
+// owners that crossed a quota or came back under it. Callers hold s.mu.
+func (s *Service) refreshLocked(ctx context.Context) {
+	s.refreshedAt = s.now()
+
+	quotas, err := s.store.ListOwnerQuotas(ctx)
+	if err != nil {
+		s.log.Warn("Failed to load owner quotas; scheduling is not limited", infralogger.Error(err))
+		return
+	}
+
+	var usage []*domain.SourceUsage
+	if len(quotas) > 0 {
+		monthStart, _ := s.ParseMonth("")
+		usage, err = s.sourceUsage(ctx, monthStart, monthStart.AddDate(0, 1, 0))
+		if err != nil {
+			s.log.Warn("Failed to refresh owner quota status; scheduling is not limited", infralogger.Error(err))
+			return
+		}
+	}
+	items, _ := aggregate(usage, domain.CostGroupByOwner, quotas)
+
+	sourceOwner := make(map[string]string, len(usage))
+	for _, u := range usage {
+		if u.Owner != "" {
+			sourceOwner[u.SourceID] = u.Owner
+		}
+	}
+
+	exceeded := make(map[string][]string)
+	for _, item := range items {
+		if len(item.QuotaExceeded) == 0 {
+			continue
+		}
+		exceeded[item.Key] = item.QuotaExceeded
+		if len(s.exceeded[item.Key]) == 0 {
+			s.log.Warn("Owner exceeded monthly quota; pausing scheduling of its sources",
+				infralogger.String("owner", item.Key),
+				infralogger.String("quota", strings.Join(item.QuotaExceeded, ",")),
+			)
+		}
+	}
+	for owner := range s.exceeded {
+		if _, still := exceeded[owner]; !still {
+			s.log.Info("Owner back within quota; resuming scheduling", infralogger.String("owner", owner))
+		}
+	}
+
+	s.sourceOwner = sourceOwner
+	s.exceeded = exceeded
+}
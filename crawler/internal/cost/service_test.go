@@ -0,0 +1,168 @@
+package cost_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/crawler/internal/cost"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+type fakeStore struct {
+	usage      []*domain.SourceUsage
+	quotas     []*domain.OwnerQuota
+	usageCalls int
+}
+
+func (f *fakeStore) SourceUsage(_ context.Context, _, _ time.Time) ([]*domain.SourceUsage, error) {
+	f.usageCalls++
+	// Return copies: the service fills in storage on the returned rows
+	out := make([]*domain.SourceUsage, 0, len(f.usage))
+	for _, u := range f.usage {
+		row := *u
+		out = append(out, &row)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) ListOwnerQuotas(_ context.Context) ([]*domain.OwnerQuota, error) {
+	return f.quotas, nil
+}
+
+type fakeSizer map[string]int64
+
+func (f fakeSizer) IndexStoreSizes(_ context.Context, _ string) (map[string]int64, error) {
+	return f, nil
+}
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func newStore() *fakeStore {
+	return &fakeStore{
+		usage: []*domain.SourceUsage{
+			{SourceID: "s1", SourceName: "sudbury_com", Owner: "acme", Tags: []string{"news", "ontario"},
+				Executions: 3, ComputeMs: 90_000, BandwidthBytes: 4_000},
+			{SourceID: "s2", SourceName: "timmins_ca", Owner: "acme", Tags: []string{"news"},
+				Executions: 1, ComputeMs: 30_000, BandwidthBytes: 1_000},
+			{SourceID: "s3", SourceName: "example_org", Executions: 2, ComputeMs: 10_000, BandwidthBytes: 500},
+		},
+		quotas: []*domain.OwnerQuota{
+			{Owner: "acme", MonthlyComputeSeconds: int64Ptr(100)},
+			{Owner: "globex", StorageBytes: int64Ptr(1)},
+		},
+	}
+}
+
+func TestReport_GroupByOwnerAppliesQuotas(t *testing.T) {
+	t.Parallel()
+
+	sizes := fakeSizer{
+		"sudbury_com_raw_content":        700,
+		"sudbury_com_classified_content": 300,
+		"example_org_raw_content":        50,
+	}
+	svc := cost.NewService(newStore(), sizes, infralogger.NewNop())
+
+	report, err := svc.Report(context.Background(), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), domain.CostGroupByOwner)
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	if report.Month != "2026-03" || !report.To.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected period %s %v", report.Month, report.To)
+	}
+	if len(report.Items) != 3 {
+		t.Fatalf("expected (unassigned), acme, globex; got %d items", len(report.Items))
+	}
+
+	unassigned, acme, globex := report.Items[0], report.Items[1], report.Items[2]
+	if unassigned.Key != domain.CostUnassignedOwner || unassigned.StorageBytes != 50 {
+		t.Errorf("unexpected unassigned group %+v", unassigned)
+	}
+	if acme.Sources != 2 || acme.ComputeSeconds != 120 || acme.BandwidthBytes != 5_000 || acme.StorageBytes != 1_000 {
+		t.Errorf("unexpected acme usage %+v", acme)
+	}
+	if len(acme.QuotaExceeded) != 1 || acme.QuotaExceeded[0] != domain.QuotaCompute {
+		t.Errorf("expected acme over compute quota, got %v", acme.QuotaExceeded)
+	}
+	if globex.Sources != 0 || len(globex.QuotaExceeded) != 0 {
+		t.Errorf("owner with a quota but no sources should be listed within quota, got %+v", globex)
+	}
+	if report.Total.Sources != 3 || report.Total.Executions != 6 {
+		t.Errorf("unexpected total %+v", report.Total)
+	}
+}
+
+func TestReport_GroupByTagCountsEachTag(t *testing.T) {
+	t.Parallel()
+
+	svc := cost.NewService(newStore(), nil, infralogger.NewNop())
+
+	report, err := svc.Report(context.Background(), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), domain.CostGroupByTag)
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	got := make(map[string]int, len(report.Items))
+	for _, item := range report.Items {
+		got[item.Key] = item.Sources
+		if item.Quota != nil {
+			t.Errorf("quotas apply to owners only, got one on tag %s", item.Key)
+		}
+	}
+	want := map[string]int{"news": 2, "ontario": 1, domain.CostUntagged: 1}
+	for key, sources := range want {
+		if got[key] != sources {
+			t.Errorf("tag %s: expected %d sources, got %d", key, sources, got[key])
+		}
+	}
+
+	if _, err = svc.Report(context.Background(), time.Now(), "region"); err == nil {
+		t.Error("expected an error for an unknown grouping")
+	}
+}
+
+func TestQuotaExceeded_CachesUntilInvalidated(t *testing.T) {
+	t.Parallel()
+
+	store := newStore()
+	svc := cost.NewService(store, nil, infralogger.NewNop())
+	ctx := context.Background()
+
+	exceeded, reason := svc.QuotaExceeded(ctx, "s2")
+	if !exceeded || reason == "" {
+		t.Fatalf("expected acme's source to be over quota, got %v %q", exceeded, reason)
+	}
+	if exceeded, _ = svc.QuotaExceeded(ctx, "s3"); exceeded {
+		t.Error("unassigned sources are never over quota")
+	}
+	if store.usageCalls != 1 {
+		t.Errorf("expected status to be cached, got %d usage queries", store.usageCalls)
+	}
+
+	store.quotas[0].MonthlyComputeSeconds = int64Ptr(1000)
+	if exceeded, _ = svc.QuotaExceeded(ctx, "s2"); !exceeded {
+		t.Error("cached status should hold until invalidated")
+	}
+
+	svc.Invalidate()
+	if exceeded, _ = svc.QuotaExceeded(ctx, "s2"); exceeded {
+		t.Error("raised quota should resume scheduling after invalidation")
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	t.Parallel()
+
+	svc := cost.NewService(&fakeStore{}, nil, infralogger.NewNop())
+
+	month, err := svc.ParseMonth("2026-02")
+	if err != nil || !month.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected month %v (%v)", month, err)
+	}
+	if _, err = svc.ParseMonth("February"); err == nil {
+		t.Error("expected an error for a malformed month")
+	}
+}
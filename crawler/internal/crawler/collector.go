@@ -66,6 +66,11 @@ const retryCountKey = "retry_count"
 // setupCollector configures the collector for discovery and inline content extraction.
 // Content detection gates which pages get processed by ProcessHTML (no second HTTP request).
 func (c *Crawler) setupCollector(ctx context.Context, source *configtypes.Source) error {
+	if overridesErr := c.validateOverrides(); overridesErr != nil {
+		return overridesErr
+	}
+	c.logOverrides()
+
 	maxDepth := c.resolveMaxDepth(source)
 	opts := c.buildCollectorOptions(ctx, maxDepth, source)
 
@@ -73,8 +78,8 @@ func (c *Crawler) setupCollector(ctx context.Context, source *configtypes.Source
 
 	// Configure transport, timeout, and extensions
 	c.collector.SetRequestTimeout(c.cfg.RequestTimeout)
-	c.configureTransportFor(c.collector)
-	if c.cfg.UseRandomUserAgent {
+	transport := c.configureTransportFor(c.collector)
+	if c.cfg.UseRandomUserAgent && c.overrideUserAgent() == "" {
 		extensions.RandomUserAgent(c.collector)
 	}
 	if c.cfg.UseReferer {
@@ -93,7 +98,7 @@ func (c *Crawler) setupCollector(ctx context.Context, source *configtypes.Source
 	}
 
 	// Proxy rotation
-	if proxyErr := c.setupProxyRotation(); proxyErr != nil {
+	if proxyErr := c.setupProxyRotation(source); proxyErr != nil {
 		return fmt.Errorf("failed to set up proxy rotation: %w", proxyErr)
 	}
 
@@ -103,7 +108,12 @@ func (c *Crawler) setupCollector(ctx context.Context, source *configtypes.Source
 		return fmt.Errorf("failed to set rate limit: %w", setErr)
 	}
 
-	if c.cfg.TLS.InsecureSkipVerify {
+	// Job render override: wrap last so SetProxyFunc above still sees the *http.Transport.
+	if c.renderDynamic() {
+		c.collector.WithTransport(&renderTransport{renderer: c.renderer, base: transport})
+	}
+
+	if c.insecureSkipVerify() {
 		c.GetJobLogger().Warn(logs.CategoryLifecycle,
 			"TLS certificate verification is disabled",
 			logs.String("source", source.Name),
@@ -178,7 +188,9 @@ func (c *Crawler) buildCollectorOptions(
 	if !c.cfg.RespectRobotsTxt {
 		opts = append(opts, colly.IgnoreRobotsTxt())
 	}
-	if !c.cfg.UseRandomUserAgent {
+	if userAgent := c.overrideUserAgent(); userAgent != "" {
+		opts = append(opts, colly.UserAgent(userAgent))
+	} else if !c.cfg.UseRandomUserAgent {
 		opts = append(opts, colly.UserAgent(c.cfg.UserAgent))
 	}
 	if c.cfg.MaxBodySize > 0 {
@@ -270,8 +282,24 @@ func (c *Crawler) setupRedisStorage() error {
 }
 
 // setupProxyRotation configures proxy rotation if enabled.
-// Uses the shared proxy pool if available, otherwise falls back to legacy round-robin.
-func (c *Crawler) setupProxyRotation() error {
+// SOCKS-only sources always use the SOCKS proxy, even over a job override.
+// Otherwise a job proxy override wins, then the shared proxy pool if
+// available, falling back to legacy round-robin.
+func (c *Crawler) setupProxyRotation(source *configtypes.Source) error {
+	if routed, err := c.setupSOCKSProxy(source); routed || err != nil {
+		if routed && c.overrides != nil && c.overrides.ProxyURL != "" {
+			c.GetJobLogger().Warn(logs.CategoryLifecycle,
+				"Job proxy override ignored for SOCKS-only source",
+				logs.String("proxy_url", redactProxyURL(c.overrides.ProxyURL)),
+			)
+		}
+		return err
+	}
+
+	if overridden, err := c.setupOverrideProxy(); overridden || err != nil {
+		return err
+	}
+
 	// Shared proxy pool takes priority (injected via CrawlerParams).
 	if c.proxyPool != nil {
 		c.collector.SetProxyFunc(c.proxyPool.ProxyFunc())
@@ -368,7 +396,7 @@ func (c *Crawler) responseCallback(ctx context.Context) func(*colly.Response) {
 		if proxyURL := r.Request.ProxyURL; proxyURL != "" {
 			jl.Debug(logs.CategoryFetch, "Request via proxy",
 				logs.URL(pageURL),
-				logs.String("proxy", proxyURL),
+				logs.String("proxy", redactProxyURL(proxyURL)),
 			)
 		}
 		if c.cfg.Debug && c.cfg.TraceHTTP && r.Trace != nil {
@@ -433,10 +461,12 @@ func (c *Crawler) requestCallback(ctx context.Context) func(*colly.Request) {
 func (c *Crawler) setupCallbacks(ctx context.Context) {
 	// Resolve source hostname once; empty string disables off-domain filtering.
 	sourceHost := ""
+	var creds *sourceCredentials
 	if cc := c.getCrawlContext(); cc != nil && cc.Source != nil {
 		if parsed, parseErr := url.Parse(cc.Source.URL); parseErr == nil {
 			sourceHost = parsed.Hostname()
 		}
+		creds = newSourceCredentials(cc.Source)
 	}
 
 	// URL pre-filter: skip non-content URLs before fetching
@@ -449,6 +479,9 @@ func (c *Crawler) setupCallbacks(ctx context.Context) {
 		}
 	})
 
+	// Source credentials (basic auth, API key, login cookie) go only to the source's hosts
+	creds.install(c.collector)
+
 	c.collector.OnResponseHeaders(c.responseHeadersCallback())
 	c.collector.OnResponse(c.responseCallback(ctx))
 	c.collector.OnRequest(c.requestCallback(ctx))
@@ -699,11 +732,12 @@ func (c *Crawler) setRateLimit(delay, randomDelay time.Duration) error {
 	return nil
 }
 
-// configureTransportFor configures the HTTP transport with TLS settings for a given collector.
-func (c *Crawler) configureTransportFor(col *colly.Collector) {
-	col.WithTransport(&http.Transport{
+// configureTransportFor configures the HTTP transport with TLS settings for a given collector
+// and returns it so later setup steps can wrap it.
+func (c *Crawler) configureTransportFor(col *colly.Collector) *http.Transport {
+	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: c.cfg.TLS.InsecureSkipVerify,
+			InsecureSkipVerify: c.insecureSkipVerify(),
 			MinVersion:         c.cfg.TLS.MinVersion,
 			MaxVersion:         c.cfg.TLS.MaxVersion,
 		},
@@ -713,7 +747,9 @@ func (c *Crawler) configureTransportFor(col *colly.Collector) {
 		IdleConnTimeout:       defaultIdleConnTimeout,
 		ResponseHeaderTimeout: defaultResponseHeaderTimeout,
 		ExpectContinueTimeout: defaultExpectContinueTimeout,
-	})
+	}
+	col.WithTransport(transport)
+	return transport
 }
 
 // SetCollector sets the collector for the crawler.
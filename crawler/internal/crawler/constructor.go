@@ -52,6 +52,7 @@ type CrawlerParams struct {
 	HashTracker       *adaptive.HashTracker // For adaptive scheduling (optional)
 	FrontierSubmitter LinkFrontierSubmitter // Frontier submitter (optional)
 	ProxyPool         *proxypool.Pool       // Shared proxy pool (optional)
+	Renderer          PageRenderer          // Render worker client for job render overrides (optional)
 }
 
 // CrawlerResult holds the crawler instance
@@ -115,6 +116,7 @@ func NewCrawlerWithParams(p CrawlerParams) (*CrawlerResult, error) {
 		archiver:            archiver,
 		redisClient:         p.RedisClient,
 		proxyPool:           p.ProxyPool,
+		renderer:            p.Renderer,
 		hashTracker:         p.HashTracker,
 		startURLHashesMu:    &sync.RWMutex{},
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/jonesrussell/north-cloud/crawler/internal/content"
 	"github.com/jonesrussell/north-cloud/crawler/internal/content/rawcontent"
 	"github.com/jonesrussell/north-cloud/crawler/internal/crawler/events"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
 	"github.com/jonesrussell/north-cloud/crawler/internal/logs"
 	"github.com/jonesrussell/north-cloud/crawler/internal/metrics"
 	"github.com/jonesrussell/north-cloud/crawler/internal/sources"
@@ -136,6 +137,8 @@ type Interface interface {
 	Done() <-chan struct{}
 	// SetJobLogger sets the job logger for the current job execution
 	SetJobLogger(logger logs.JobLogger)
+	// SetJobOverrides sets per-job environment overrides for the next Start
+	SetJobOverrides(overrides *domain.JobOverrides)
 	// GetJobLogger returns the current job logger
 	GetJobLogger() logs.JobLogger
 	// GetStartURLHash returns the hash captured for a specific source's start URL
@@ -176,6 +179,10 @@ type Crawler struct {
 	archiver            Archiver      // HTML archiver for MinIO storage
 	redisClient         *redis.Client // Redis client for Colly storage (optional)
 	proxyPool           proxyPooler   // Shared proxy pool (optional)
+	renderer            PageRenderer  // Render worker client for dynamic render overrides (optional)
+
+	// Per-job environment overrides (set before Start, nil when none)
+	overrides *domain.JobOverrides
 
 	// Adaptive scheduling: stores hashes of start URL responses keyed by sourceID
 	startURLHashes   map[string]string     // sourceID -> SHA-256 hash
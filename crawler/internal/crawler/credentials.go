@@ -0,0 +1,120 @@
+package crawler
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+	configtypes "github.com/jonesrussell/north-cloud/crawler/internal/config/types"
+)
+
+// maxRedirects is colly's default redirect limit, which a custom redirect
+// handler replaces.
+const maxRedirects = 10
+
+// Source credential kinds, as stored by source-manager.
+const (
+	credentialKindBasicAuth = "basic_auth"
+	credentialKindAPIKey    = "api_key"
+	credentialKindCookie    = "cookie"
+)
+
+// sourceCredentials are the headers a source's credentials add to requests,
+// and the hosts they may be sent to: the source's own host and its allowed
+// domains. Links to other sites never receive them.
+type sourceCredentials struct {
+	headers   http.Header
+	hosts     map[string]bool
+	httpsOnly bool
+}
+
+// newSourceCredentials builds the request headers for source's credentials.
+// Returns nil when the source has none.
+func newSourceCredentials(source *configtypes.Source) *sourceCredentials {
+	if source == nil || len(source.Credentials) == 0 {
+		return nil
+	}
+
+	headers := make(http.Header)
+	for _, cred := range source.Credentials {
+		switch cred.Kind {
+		case credentialKindBasicAuth:
+			auth := base64.StdEncoding.EncodeToString([]byte(cred.Username + ":" + cred.Value))
+			headers.Set("Authorization", "Basic "+auth)
+		case credentialKindAPIKey:
+			if cred.HeaderName != "" {
+				headers.Set(cred.HeaderName, cred.Value)
+			}
+		case credentialKindCookie:
+			headers.Set("Cookie", cred.Value)
+		}
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+
+	hosts := make(map[string]bool, len(source.AllowedDomains)+1)
+	httpsOnly := false
+	if parsed, err := url.Parse(source.URL); err == nil && parsed.Hostname() != "" {
+		hosts[strings.ToLower(parsed.Hostname())] = true
+		httpsOnly = parsed.Scheme == "https"
+	}
+	for _, domain := range source.AllowedDomains {
+		hosts[strings.ToLower(domain)] = true
+	}
+
+	return &sourceCredentials{headers: headers, hosts: hosts, httpsOnly: httpsOnly}
+}
+
+// appliesTo reports whether credentials may be sent to u: one of the source's
+// hosts, and not over plain HTTP when the source is served over HTTPS.
+func (sc *sourceCredentials) appliesTo(u *url.URL) bool {
+	if sc.httpsOnly && u.Scheme != "https" {
+		return false
+	}
+	return sc.hosts[strings.ToLower(u.Hostname())]
+}
+
+// apply adds the credential headers to a request for u, if they apply to it.
+func (sc *sourceCredentials) apply(headers http.Header, u *url.URL) {
+	if sc == nil || !sc.appliesTo(u) {
+		return
+	}
+	for name, values := range sc.headers {
+		headers[name] = values
+	}
+}
+
+// install adds the credentials to the collector's requests for the source's
+// hosts. colly does not run OnRequest for redirects, and net/http copies the
+// original headers onto them (dropping only Authorization and Cookie when the
+// host changes), so the redirect handler strips every credential header from
+// a redirect the credentials do not apply to.
+func (sc *sourceCredentials) install(col *colly.Collector) {
+	if sc == nil {
+		return
+	}
+	col.OnRequest(func(r *colly.Request) {
+		sc.apply(*r.Headers, r.URL)
+	})
+	col.SetRedirectHandler(sc.checkRedirect)
+}
+
+// checkRedirect keeps colly's default redirect behaviour and removes the
+// credential headers from redirects to other hosts or to plain HTTP.
+func (sc *sourceCredentials) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return http.ErrUseLastResponse
+	}
+	if req.URL.Host != via[len(via)-1].URL.Host {
+		req.Header.Del("Authorization")
+	}
+	if !sc.appliesTo(req.URL) {
+		for name := range sc.headers {
+			req.Header.Del(name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package crawler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gocolly/colly/v2"
+	configtypes "github.com/jonesrussell/north-cloud/crawler/internal/config/types"
+	"github.com/jonesrussell/north-cloud/crawler/internal/crawler"
+)
+
+func credentialSource() *configtypes.Source {
+	return &configtypes.Source{
+		URL:            "https://news.example.com",
+		AllowedDomains: []string{"news.example.com", "cdn.example.com"},
+		Credentials: []configtypes.SourceCredential{
+			{Kind: "basic_auth", Username: "crawler", Value: "hunter2"},
+			{Kind: "api_key", HeaderName: "X-Api-Key", Value: "key-123"},
+			{Kind: "cookie", Value: "session=abc"},
+		},
+	}
+}
+
+func TestApplySourceCredentials(t *testing.T) {
+	source := credentialSource()
+
+	tests := []struct {
+		name    string
+		rawURL  string
+		applied bool
+	}{
+		{"source host", "https://news.example.com/article", true},
+		{"allowed domain", "https://CDN.example.com/feed", true},
+		{"other site", "https://tracker.example.net/pixel", false},
+		{"https downgraded to http", "http://news.example.com/article", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.rawURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			headers := make(http.Header)
+			crawler.ApplySourceCredentials(source, headers, u)
+
+			if got := headers.Get("X-Api-Key") != ""; got != tt.applied {
+				t.Fatalf("credentials applied = %v, want %v (headers %v)", got, tt.applied, headers)
+			}
+			if !tt.applied {
+				return
+			}
+			req := &http.Request{Header: headers}
+			if user, pass, ok := req.BasicAuth(); !ok || user != "crawler" || pass != "hunter2" {
+				t.Errorf("basic auth = %q/%q, want crawler/hunter2", user, pass)
+			}
+			if got := headers.Get("Cookie"); got != "session=abc" {
+				t.Errorf("Cookie = %q, want session=abc", got)
+			}
+		})
+	}
+}
+
+func TestApplySourceCredentials_NoCredentials(t *testing.T) {
+	headers := make(http.Header)
+	u, _ := url.Parse("https://news.example.com/")
+	crawler.ApplySourceCredentials(&configtypes.Source{URL: "https://news.example.com"}, headers, u)
+	if len(headers) != 0 {
+		t.Fatalf("expected no headers, got %v", headers)
+	}
+}
+
+func TestCheckRedirect_SendsCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "key-123" {
+			w.Header().Set("Location", "https://login.example.org/")
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	source := &configtypes.Source{
+		URL:            srv.URL,
+		AllowedDomains: []string{"127.0.0.1"},
+		Credentials:    []configtypes.SourceCredential{{Kind: "api_key", HeaderName: "X-Api-Key", Value: "key-123"}},
+	}
+	if err := crawler.CheckRedirect(context.Background(), source); err != nil {
+		t.Fatalf("expected the authenticated check to pass, got: %v", err)
+	}
+}
+
+func TestInstallSourceCredentials_RedirectToOtherHost(t *testing.T) {
+	var foreignHeaders http.Header
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignHeaders = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer foreign.Close()
+	foreignURL, err := url.Parse(foreign.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The source is served on 127.0.0.1 and redirects to localhost, another host
+	var sourceHeaders http.Header
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sourceHeaders = r.Header.Clone()
+		http.Redirect(w, r, "http://localhost:"+foreignURL.Port()+"/landing", http.StatusFound)
+	}))
+	defer site.Close()
+
+	source := &configtypes.Source{
+		URL: site.URL,
+		Credentials: []configtypes.SourceCredential{
+			{Kind: "basic_auth", Username: "crawler", Value: "hunter2"},
+			{Kind: "api_key", HeaderName: "X-Api-Key", Value: "key-123"},
+			{Kind: "cookie", Value: "session=abc"},
+		},
+	}
+	col := colly.NewCollector()
+	crawler.InstallSourceCredentials(col, source)
+	if err = col.Visit(site.URL + "/article"); err != nil {
+		t.Fatalf("Visit() error = %v", err)
+	}
+
+	if got := sourceHeaders.Get("X-Api-Key"); got != "key-123" {
+		t.Fatalf("source X-Api-Key = %q, want key-123", got)
+	}
+	if foreignHeaders == nil {
+		t.Fatal("redirect target was not requested")
+	}
+	for _, name := range []string{"X-Api-Key", "Authorization", "Cookie"} {
+		if got := foreignHeaders.Get(name); got != "" {
+			t.Errorf("redirect target received %s = %q", name, got)
+		}
+	}
+}
+
+func TestCheckCredentialRedirect_HTTPSDowngrade(t *testing.T) {
+	source := credentialSource()
+	via, err := http.NewRequest(http.MethodGet, "https://news.example.com/article", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crawler.ApplySourceCredentials(source, via.Header, via.URL)
+
+	// net/http keeps every header on a same-host redirect, even to plain HTTP
+	req, err := http.NewRequest(http.MethodGet, "http://news.example.com/article", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header = via.Header.Clone()
+	if err = crawler.CheckCredentialRedirect(source, req, []*http.Request{via}); err != nil {
+		t.Fatalf("CheckCredentialRedirect() error = %v", err)
+	}
+	for _, name := range []string{"X-Api-Key", "Authorization", "Cookie"} {
+		if got := req.Header.Get(name); got != "" {
+			t.Errorf("plain HTTP redirect kept %s = %q", name, got)
+		}
+	}
+}
@@ -2,8 +2,11 @@ package crawler
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 	"regexp"
 
+	"github.com/gocolly/colly/v2"
 	configtypes "github.com/jonesrussell/north-cloud/crawler/internal/config/types"
 )
 
@@ -39,6 +42,21 @@ var HandlePossibleDomainRedirect = handlePossibleDomainRedirect
 // HostsMatch exports hostsMatch for testing.
 var HostsMatch = hostsMatch
 
+// ApplySourceCredentials adds source's credential headers to headers for a request to u.
+func ApplySourceCredentials(source *configtypes.Source, headers http.Header, u *url.URL) {
+	newSourceCredentials(source).apply(headers, u)
+}
+
+// InstallSourceCredentials adds source's credentials to col's requests and redirects.
+func InstallSourceCredentials(col *colly.Collector, source *configtypes.Source) {
+	newSourceCredentials(source).install(col)
+}
+
+// CheckCredentialRedirect runs the redirect handler of source's credentials.
+func CheckCredentialRedirect(source *configtypes.Source, req *http.Request, via []*http.Request) error {
+	return newSourceCredentials(source).checkRedirect(req, via)
+}
+
 // CheckRedirect exports checkRedirect via a zero-value Crawler for testing.
 func CheckRedirect(ctx context.Context, source *configtypes.Source) error {
 	c := &Crawler{}
@@ -0,0 +1,147 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gocolly/colly/v2/proxy"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	"github.com/jonesrussell/north-cloud/crawler/internal/logs"
+	"github.com/jonesrussell/north-cloud/crawler/internal/render"
+)
+
+// renderedContentType is the Content-Type reported for pages fetched through the render worker.
+const renderedContentType = "text/html; charset=utf-8"
+
+// robotsTxtPath is fetched directly even when rendering: a browser would wrap it in HTML.
+const robotsTxtPath = "/robots.txt"
+
+// ErrRenderWorkerUnavailable is returned when a job forces dynamic rendering
+// but no render worker is configured.
+var ErrRenderWorkerUnavailable = errors.New("render_mode override is dynamic but no render worker is configured")
+
+// PageRenderer renders a URL with a headless browser. Satisfied by *render.Client.
+type PageRenderer interface {
+	Render(ctx context.Context, pageURL string) (*render.RenderResponse, error)
+}
+
+// SetJobOverrides sets per-job environment overrides (proxy, user agent,
+// render mode). Should be called before Start() for each job; nil clears them.
+func (c *Crawler) SetJobOverrides(overrides *domain.JobOverrides) {
+	if overrides.IsEmpty() {
+		c.overrides = nil
+		return
+	}
+	c.overrides = overrides
+}
+
+// overrideUserAgent returns the job's user agent override, or "".
+func (c *Crawler) overrideUserAgent() string {
+	if c.overrides == nil {
+		return ""
+	}
+	return c.overrides.UserAgent
+}
+
+// insecureSkipVerify reports whether TLS verification is disabled by config or job override.
+func (c *Crawler) insecureSkipVerify() bool {
+	return c.cfg.TLS.InsecureSkipVerify || (c.overrides != nil && c.overrides.InsecureSkipVerify)
+}
+
+// renderDynamic reports whether the job forces fetching through the render worker.
+func (c *Crawler) renderDynamic() bool {
+	return c.overrides != nil && c.overrides.RenderMode == domain.RenderModeDynamic
+}
+
+// validateOverrides checks that the environment can honour the job overrides.
+func (c *Crawler) validateOverrides() error {
+	if c.overrides == nil {
+		return nil
+	}
+	if err := c.overrides.Validate(); err != nil {
+		return fmt.Errorf("invalid job overrides: %w", err)
+	}
+	if c.renderDynamic() && c.renderer == nil {
+		return ErrRenderWorkerUnavailable
+	}
+	return nil
+}
+
+// setupOverrideProxy routes every request through the job's proxy override.
+// Returns false when the job has no proxy override.
+func (c *Crawler) setupOverrideProxy() (bool, error) {
+	if c.overrides == nil || c.overrides.ProxyURL == "" {
+		return false, nil
+	}
+
+	switcher, err := proxy.RoundRobinProxySwitcher(c.overrides.ProxyURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to create override proxy switcher: %w", err)
+	}
+	c.collector.SetProxyFunc(switcher)
+
+	c.GetJobLogger().Info(logs.CategoryLifecycle,
+		"Job proxy override enabled",
+		logs.String("proxy", c.overrides.ProxyURL),
+	)
+	return true, nil
+}
+
+// logOverrides records the overrides in effect so job logs explain odd fetch behaviour.
+func (c *Crawler) logOverrides() {
+	if c.overrides == nil {
+		return
+	}
+	c.GetJobLogger().Info(logs.CategoryLifecycle, "Job environment overrides applied",
+		logs.String("proxy_url", c.overrides.ProxyURL),
+		logs.String("user_agent", c.overrides.UserAgent),
+		logs.String("render_mode", c.overrides.RenderMode),
+		logs.Bool("insecure_skip_verify", c.overrides.InsecureSkipVerify),
+	)
+}
+
+// renderTransport fetches pages through the render worker instead of over
+// the network. Non-GET requests and robots.txt go to the base transport.
+type renderTransport struct {
+	renderer PageRenderer
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *renderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.URL.Path == robotsTxtPath {
+		return t.base.RoundTrip(req)
+	}
+
+	rendered, err := t.renderer.Render(req.Context(), req.URL.String())
+	if err != nil {
+		return nil, fmt.Errorf("render %s: %w", req.URL, err)
+	}
+
+	statusCode := rendered.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	body := []byte(rendered.HTML)
+	header := make(http.Header)
+	header.Set("Content-Type", renderedContentType)
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        strconv.Itoa(statusCode) + " " + http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,117 @@
+//nolint:testpackage // tests unexported override helpers and renderTransport
+package crawler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	crawlerconfig "github.com/jonesrussell/north-cloud/crawler/internal/config/crawler"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	"github.com/jonesrussell/north-cloud/crawler/internal/render"
+)
+
+type stubRenderer struct {
+	calls []string
+}
+
+func (r *stubRenderer) Render(_ context.Context, pageURL string) (*render.RenderResponse, error) {
+	r.calls = append(r.calls, pageURL)
+	return &render.RenderResponse{HTML: "<html><body>rendered</body></html>", FinalURL: pageURL}, nil
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRenderTransport_RendersGetRequests(t *testing.T) {
+	t.Helper()
+
+	renderer := &stubRenderer{}
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("base transport should not be called for page fetches")
+		return nil, nil
+	})
+	transport := &renderTransport{renderer: renderer, base: base}
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/news/story", http.NoBody)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "<html><body>rendered</body></html>" {
+		t.Errorf("body = %q, want rendered HTML", body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200 when render worker omits it", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != renderedContentType {
+		t.Errorf("Content-Type = %q, want %q", got, renderedContentType)
+	}
+	if len(renderer.calls) != 1 || renderer.calls[0] != "https://example.com/news/story" {
+		t.Errorf("renderer calls = %v", renderer.calls)
+	}
+}
+
+func TestRenderTransport_RobotsTxtBypassesRenderer(t *testing.T) {
+	t.Helper()
+
+	renderer := &stubRenderer{}
+	baseCalled := false
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		baseCalled = true
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	transport := &renderTransport{renderer: renderer, base: base}
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/robots.txt", http.NoBody)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if !baseCalled {
+		t.Error("robots.txt should be fetched by the base transport")
+	}
+	if len(renderer.calls) != 0 {
+		t.Errorf("renderer should not be called, got %v", renderer.calls)
+	}
+}
+
+func TestValidateOverrides_DynamicRequiresRenderer(t *testing.T) {
+	t.Helper()
+
+	c := &Crawler{cfg: &crawlerconfig.Config{}}
+	c.SetJobOverrides(&domain.JobOverrides{RenderMode: domain.RenderModeDynamic})
+
+	if err := c.validateOverrides(); !errors.Is(err, ErrRenderWorkerUnavailable) {
+		t.Errorf("validateOverrides() = %v, want ErrRenderWorkerUnavailable", err)
+	}
+
+	c.renderer = &stubRenderer{}
+	if err := c.validateOverrides(); err != nil {
+		t.Errorf("validateOverrides() with renderer = %v, want nil", err)
+	}
+}
+
+func TestSetJobOverrides_EmptyClears(t *testing.T) {
+	t.Helper()
+
+	c := &Crawler{cfg: &crawlerconfig.Config{}}
+	c.SetJobOverrides(&domain.JobOverrides{UserAgent: "ReproBot/1.0", InsecureSkipVerify: true})
+	if c.overrideUserAgent() != "ReproBot/1.0" || !c.insecureSkipVerify() {
+		t.Fatal("expected user agent and TLS overrides to apply")
+	}
+
+	c.SetJobOverrides(&domain.JobOverrides{})
+	if c.overrideUserAgent() != "" || c.insecureSkipVerify() {
+		t.Error("empty overrides should clear previous overrides")
+	}
+}
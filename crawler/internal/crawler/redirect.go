@@ -37,6 +37,8 @@ func (c *Crawler) checkRedirect(ctx context.Context, source *configtypes.Source)
 	if err != nil {
 		return fmt.Errorf("redirect check: build request: %w", err)
 	}
+	// Sites behind a login may redirect anonymous requests to a sign-in page elsewhere
+	newSourceCredentials(source).apply(req.Header, req.URL)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -0,0 +1,84 @@
+package crawler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gocolly/colly/v2/proxy"
+	configtypes "github.com/jonesrussell/north-cloud/crawler/internal/config/types"
+	"github.com/jonesrussell/north-cloud/crawler/internal/logs"
+)
+
+// circuitIDBytes is the entropy of the per-execution SOCKS credentials.
+const circuitIDBytes = 8
+
+// ErrSOCKSRenderUnsupported is returned when a SOCKS-only source is fetched
+// through the render worker, which would reach the site without the proxy.
+var ErrSOCKSRenderUnsupported = errors.New("source requires the SOCKS proxy and cannot use dynamic rendering")
+
+// setupSOCKSProxy routes every request of a SOCKS-only source through the
+// configured SOCKS endpoint. With circuit isolation on, each execution sends
+// unique SOCKS credentials; Tor's IsolateSOCKSAuth (on by default) then gives
+// the execution its own circuit. Returns false when the source is not SOCKS-only.
+func (c *Crawler) setupSOCKSProxy(source *configtypes.Source) (bool, error) {
+	if source == nil || !c.cfg.UsesSOCKS(source.Name) {
+		return false, nil
+	}
+	if c.renderDynamic() {
+		return false, ErrSOCKSRenderUnsupported
+	}
+
+	proxyURL, circuitID, err := socksProxyURL(c.cfg.SOCKSProxyURL, c.cfg.SOCKSIsolateCircuits)
+	if err != nil {
+		return false, err
+	}
+
+	switcher, err := proxy.RoundRobinProxySwitcher(proxyURL.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to create SOCKS proxy switcher: %w", err)
+	}
+	c.collector.SetProxyFunc(switcher)
+
+	c.GetJobLogger().Info(logs.CategoryLifecycle,
+		"Source routed through SOCKS proxy",
+		logs.String("source", source.Name),
+		logs.String("proxy", redactProxyURL(c.cfg.SOCKSProxyURL)),
+		logs.Bool("circuit_isolation", circuitID != ""),
+		logs.String("circuit_id", circuitID),
+	)
+	return true, nil
+}
+
+// socksProxyURL returns the proxy URL for one execution. When isolate is set
+// and the configured URL carries no credentials of its own, a random
+// username/password pair is added and its username returned as the circuit ID.
+func socksProxyURL(raw string, isolate bool) (*url.URL, string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid SOCKS proxy URL: %w", err)
+	}
+	if !isolate || parsed.User != nil {
+		return parsed, "", nil
+	}
+
+	buf := make([]byte, circuitIDBytes)
+	if _, randErr := rand.Read(buf); randErr != nil {
+		return nil, "", fmt.Errorf("generate SOCKS circuit id: %w", randErr)
+	}
+	circuitID := "nc-" + hex.EncodeToString(buf)
+	parsed.User = url.UserPassword(circuitID, circuitID)
+
+	return parsed, circuitID, nil
+}
+
+// redactProxyURL hides any password in a proxy URL before it is logged.
+func redactProxyURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "invalid-proxy-url"
+	}
+	return parsed.Redacted()
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	"github.com/lib/pq"
+)
+
+// ErrCostRecordNotFound is returned when a source owner or owner quota does not exist.
+var ErrCostRecordNotFound = errors.New("cost record not found")
+
+// CostRepository handles source ownership, owner quotas, and usage queries
+// for cost attribution.
+type CostRepository struct {
+	db *sqlx.DB
+}
+
+// NewCostRepository creates a new cost repository.
+func NewCostRepository(db *sqlx.DB) *CostRepository {
+	return &CostRepository{db: db}
+}
+
+// UpsertSourceOwner assigns a source to an owner, replacing its tags.
+func (r *CostRepository) UpsertSourceOwner(
+	ctx context.Context,
+	sourceID, owner string,
+	tags []string,
+) (*domain.SourceOwner, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	query := `
+		INSERT INTO source_owners (source_id, owner, tags)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (source_id)
+		DO UPDATE SET owner = EXCLUDED.owner, tags = EXCLUDED.tags
+		RETURNING source_id, owner, tags, created_at, updated_at
+	`
+
+	var so domain.SourceOwner
+	if err := r.db.GetContext(ctx, &so, query, sourceID, owner, pq.StringArray(tags)); err != nil {
+		return nil, fmt.Errorf("upsert source owner: %w", err)
+	}
+
+	return &so, nil
+}
+
+// DeleteSourceOwner removes a source's owner assignment.
+func (r *CostRepository) DeleteSourceOwner(ctx context.Context, sourceID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM source_owners WHERE source_id = $1`, sourceID)
+	if err != nil {
+		return fmt.Errorf("delete source owner: %w", err)
+	}
+
+	return execRequireRows(result, nil, ErrCostRecordNotFound)
+}
+
+// ListSourceOwners returns every owner assignment, optionally for one owner.
+func (r *CostRepository) ListSourceOwners(ctx context.Context, owner string) ([]*domain.SourceOwner, error) {
+	query := `
+		SELECT source_id, owner, tags, created_at, updated_at
+		FROM source_owners
+		WHERE $1 = '' OR owner = $1
+		ORDER BY owner, source_id
+	`
+
+	owners := []*domain.SourceOwner{}
+	if err := r.db.SelectContext(ctx, &owners, query, owner); err != nil {
+		return nil, fmt.Errorf("list source owners: %w", err)
+	}
+
+	return owners, nil
+}
+
+// UpsertOwnerQuota creates or replaces an owner's quota.
+func (r *CostRepository) UpsertOwnerQuota(ctx context.Context, quota *domain.OwnerQuota) (*domain.OwnerQuota, error) {
+	query := `
+		INSERT INTO owner_quotas (owner, monthly_compute_seconds, monthly_bandwidth_bytes, storage_bytes)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (owner)
+		DO UPDATE SET
+			monthly_compute_seconds = EXCLUDED.monthly_compute_seconds,
+			monthly_bandwidth_bytes = EXCLUDED.monthly_bandwidth_bytes,
+			storage_bytes = EXCLUDED.storage_bytes
+		RETURNING owner, monthly_compute_seconds, monthly_bandwidth_bytes, storage_bytes, created_at, updated_at
+	`
+
+	var saved domain.OwnerQuota
+	err := r.db.GetContext(ctx, &saved, query,
+		quota.Owner, quota.MonthlyComputeSeconds, quota.MonthlyBandwidthBytes, quota.StorageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("upsert owner quota: %w", err)
+	}
+
+	return &saved, nil
+}
+
+// DeleteOwnerQuota removes an owner's quota, making it unlimited.
+func (r *CostRepository) DeleteOwnerQuota(ctx context.Context, owner string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM owner_quotas WHERE owner = $1`, owner)
+	if err != nil {
+		return fmt.Errorf("delete owner quota: %w", err)
+	}
+
+	return execRequireRows(result, nil, ErrCostRecordNotFound)
+}
+
+// ListOwnerQuotas returns every owner quota.
+func (r *CostRepository) ListOwnerQuotas(ctx context.Context) ([]*domain.OwnerQuota, error) {
+	query := `
+		SELECT owner, monthly_compute_seconds, monthly_bandwidth_bytes, storage_bytes, created_at, updated_at
+		FROM owner_quotas
+		ORDER BY owner
+	`
+
+	quotas := []*domain.OwnerQuota{}
+	if err := r.db.SelectContext(ctx, &quotas, query); err != nil {
+		return nil, fmt.Errorf("list owner quotas: %w", err)
+	}
+
+	return quotas, nil
+}
+
+// SourceUsage returns the compute time and bandwidth of every source with a
+// job, summed over executions started in [from, to). Sources without
+// executions in the period are included with zero usage so their storage
+// still counts.
+func (r *CostRepository) SourceUsage(ctx context.Context, from, to time.Time) ([]*domain.SourceUsage, error) {
+	query := `
+		SELECT
+			j.source_id,
+			COALESCE(MAX(j.source_name), '') AS source_name,
+			COALESCE(o.owner, '') AS owner,
+			COALESCE(o.tags, '{}') AS tags,
+			COUNT(e.id) AS executions,
+			COALESCE(SUM(e.duration_ms), 0) AS compute_ms,
+			COALESCE(SUM((e.metadata->'crawl_metrics'->>'bytes_downloaded')::BIGINT), 0) AS bandwidth_bytes
+		FROM jobs j
+		LEFT JOIN job_executions e
+			ON e.job_id = j.id AND e.started_at >= $1 AND e.started_at < $2
+		LEFT JOIN source_owners o ON o.source_id = j.source_id
+		GROUP BY j.source_id, o.owner, o.tags
+		ORDER BY j.source_id
+	`
+
+	usage := []*domain.SourceUsage{}
+	if err := r.db.SelectContext(ctx, &usage, query, from, to); err != nil {
+		return nil, fmt.Errorf("query source usage: %w", err)
+	}
+
+	return usage, nil
+}
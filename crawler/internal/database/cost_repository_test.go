@@ -0,0 +1,76 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/jonesrussell/north-cloud/crawler/internal/database"
+)
+
+func newCostRepo(t *testing.T) (*database.CostRepository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	t.Cleanup(func() { mockDB.Close() })
+
+	return database.NewCostRepository(sqlx.NewDb(mockDB, "postgres")), mock
+}
+
+func TestCostRepository_SourceUsage(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newCostRepo(t)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	rows := sqlmock.NewRows([]string{
+		"source_id", "source_name", "owner", "tags", "executions", "compute_ms", "bandwidth_bytes",
+	}).
+		AddRow("s1", "sudbury_com", "acme", "{news,ontario}", 4, 120000, 5000).
+		AddRow("s2", "example_org", "", "{}", 0, 0, 0)
+
+	mock.ExpectQuery("FROM jobs j").WithArgs(from, to).WillReturnRows(rows)
+
+	usage, err := repo.SourceUsage(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("SourceUsage() error = %v", err)
+	}
+
+	if len(usage) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(usage))
+	}
+	if usage[0].Owner != "acme" || len(usage[0].Tags) != 2 || usage[0].ComputeMs != 120000 {
+		t.Errorf("unexpected usage row %+v", usage[0])
+	}
+	if usage[1].Owner != "" || len(usage[1].Tags) != 0 {
+		t.Errorf("expected unassigned source without tags, got %+v", usage[1])
+	}
+
+	if checkErr := mock.ExpectationsWereMet(); checkErr != nil {
+		t.Errorf("unfulfilled expectations: %v", checkErr)
+	}
+}
+
+func TestCostRepository_DeleteSourceOwner_NotFound(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newCostRepo(t)
+
+	mock.ExpectExec("DELETE FROM source_owners").
+		WithArgs("missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteSourceOwner(context.Background(), "missing")
+	if !errors.Is(err, database.ErrCostRecordNotFound) {
+		t.Errorf("expected ErrCostRecordNotFound, got %v", err)
+	}
+}
@@ -330,6 +330,43 @@ func (r *ExecutionRepository) GetFailureRate(ctx context.Context, window time.Du
 	return float64(failed) / float64(total), nil
 }
 
+// GetSourceCrawlHealth returns the crawl state of every source with a job: the
+// status of its most recently updated job and when any of its jobs last
+// completed an execution.
+func (r *ExecutionRepository) GetSourceCrawlHealth(ctx context.Context) ([]*domain.SourceCrawlHealth, error) {
+	query := `
+		SELECT
+			j.source_id,
+			j.id AS job_id,
+			j.status AS job_status,
+			j.is_paused,
+			j.next_run_at,
+			s.last_success_at,
+			j.last_failure_at,
+			j.failure_count
+		FROM (
+			SELECT DISTINCT ON (source_id) *
+			FROM jobs
+			ORDER BY source_id, updated_at DESC
+		) j
+		LEFT JOIN (
+			SELECT jb.source_id, MAX(e.completed_at) AS last_success_at
+			FROM job_executions e
+			JOIN jobs jb ON jb.id = e.job_id
+			WHERE e.status = 'completed'
+			GROUP BY jb.source_id
+		) s ON s.source_id = j.source_id
+		ORDER BY j.source_id
+	`
+
+	health := []*domain.SourceCrawlHealth{}
+	if err := r.db.SelectContext(ctx, &health, query); err != nil {
+		return nil, fmt.Errorf("failed to get source crawl health: %w", err)
+	}
+
+	return health, nil
+}
+
 // GetStuckJobs returns jobs that have been running longer than the threshold.
 func (r *ExecutionRepository) GetStuckJobs(ctx context.Context, threshold time.Duration) ([]*domain.Job, error) {
 	var jobs []*domain.Job
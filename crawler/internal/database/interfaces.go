@@ -55,6 +55,7 @@ type ExecutionRepositoryInterface interface {
 	GetFailureRate(ctx context.Context, window time.Duration) (float64, error)
 	GetStuckJobs(ctx context.Context, threshold time.Duration) ([]*domain.Job, error)
 	GetOrphanedRunningJobs(ctx context.Context) ([]*domain.Job, error)
+	GetSourceCrawlHealth(ctx context.Context) ([]*domain.SourceCrawlHealth, error)
 
 	// Maintenance operations
 	CleanupOldExecutions(ctx context.Context) (int, error)
@@ -67,6 +68,17 @@ type DomainStateRepositoryInterface interface {
 	GetByDomain(ctx context.Context, domainName string) (*domain.DomainState, error)
 }
 
+// CostRepositoryInterface defines the contract for cost attribution storage.
+type CostRepositoryInterface interface {
+	UpsertSourceOwner(ctx context.Context, sourceID, owner string, tags []string) (*domain.SourceOwner, error)
+	DeleteSourceOwner(ctx context.Context, sourceID string) error
+	ListSourceOwners(ctx context.Context, owner string) ([]*domain.SourceOwner, error)
+	UpsertOwnerQuota(ctx context.Context, quota *domain.OwnerQuota) (*domain.OwnerQuota, error)
+	DeleteOwnerQuota(ctx context.Context, owner string) error
+	ListOwnerQuotas(ctx context.Context) ([]*domain.OwnerQuota, error)
+	SourceUsage(ctx context.Context, from, to time.Time) ([]*domain.SourceUsage, error)
+}
+
 // DomainAggregateRepositoryInterface defines the contract for domain aggregate queries.
 type DomainAggregateRepositoryInterface interface {
 	ListAggregates(ctx context.Context, filters DomainListFilters) ([]*domain.DomainAggregate, error)
@@ -22,7 +22,7 @@ const jobInsertColumns = `id, source_id, source_name, url, type,
 	schedule_time, schedule_enabled,
 	interval_minutes, interval_type,
 	is_paused, max_retries, retry_backoff_seconds,
-	status, metadata`
+	status, metadata, overrides`
 
 // jobSelectBase lists columns for job SELECT queries (without auto-managed fields).
 const jobSelectBase = `id, source_id, source_name, url, type,
@@ -33,7 +33,7 @@ const jobSelectBase = `id, source_id, source_name, url, type,
 	status, scheduler_version,
 	created_at, updated_at, started_at, completed_at,
 	paused_at, cancelled_at,
-	error_message, metadata`
+	error_message, metadata, overrides`
 
 // jobSelectAutoManaged extends jobSelectBase with auto-managed fields.
 const jobSelectAutoManaged = jobSelectBase + `,
@@ -52,7 +52,7 @@ func NewJobRepository(db *sqlx.DB) *JobRepository {
 // Create inserts a new job into the database.
 func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
 	query := `INSERT INTO jobs (` + jobInsertColumns + `)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING created_at, updated_at, next_run_at`
 
 	err := r.db.QueryRowContext(
@@ -72,6 +72,7 @@ func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
 		job.RetryBackoffSeconds,
 		job.Status,
 		domain.MetadataPtr(job.Metadata),
+		job.Overrides,
 	).Scan(&job.CreatedAt, &job.UpdatedAt, &job.NextRunAt)
 
 	if err != nil {
@@ -85,7 +86,7 @@ func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
 // Returns wasInserted=true for new jobs, false when updating an existing job.
 func (r *JobRepository) CreateOrUpdate(ctx context.Context, job *domain.Job) (bool, error) {
 	query := `INSERT INTO jobs (` + jobInsertColumns + `)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		ON CONFLICT (source_id) DO UPDATE SET
 			source_name = EXCLUDED.source_name,
 			url = EXCLUDED.url,
@@ -102,6 +103,7 @@ func (r *JobRepository) CreateOrUpdate(ctx context.Context, job *domain.Job) (bo
 				ELSE EXCLUDED.status
 			END,
 			metadata = EXCLUDED.metadata,
+			overrides = COALESCE(EXCLUDED.overrides, jobs.overrides),
 			updated_at = NOW()
 		RETURNING id, created_at, updated_at, next_run_at
 	`
@@ -124,6 +126,7 @@ func (r *JobRepository) CreateOrUpdate(ctx context.Context, job *domain.Job) (bo
 		job.RetryBackoffSeconds,
 		job.Status,
 		domain.MetadataPtr(job.Metadata),
+		job.Overrides,
 	).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt, &job.NextRunAt)
 
 	if err != nil {
@@ -269,8 +272,9 @@ func (r *JobRepository) Update(ctx context.Context, job *domain.Job) error {
 		    status = $16,
 		    started_at = $17, completed_at = $18,
 		    paused_at = $19, cancelled_at = $20,
-		    error_message = $21, metadata = $22
-		WHERE id = $23
+		    error_message = $21, metadata = $22,
+		    overrides = $23
+		WHERE id = $24
 	`
 
 	result, execErr := r.db.ExecContext(
@@ -298,6 +302,7 @@ func (r *JobRepository) Update(ctx context.Context, job *domain.Job) error {
 		job.CancelledAt,
 		job.ErrorMessage,
 		domain.MetadataPtr(job.Metadata),
+		job.Overrides,
 		job.ID,
 	)
 
@@ -574,6 +579,19 @@ func (r *JobRepository) FindBySourceID(ctx context.Context, sourceID uuid.UUID)
 	return &job, nil
 }
 
+// ListSourceJobs returns every job that belongs to a source.
+func (r *JobRepository) ListSourceJobs(ctx context.Context) ([]*domain.Job, error) {
+	query := `SELECT ` + jobSelectAutoManaged + `
+		FROM jobs
+		WHERE source_id IS NOT NULL`
+
+	var jobs []*domain.Job
+	if err := r.db.SelectContext(ctx, &jobs, query); err != nil {
+		return nil, fmt.Errorf("list source jobs: %w", err)
+	}
+	return jobs, nil
+}
+
 // UpsertAutoManaged creates or updates an auto-managed job.
 // Uses source_id as the unique key for upsert.
 func (r *JobRepository) UpsertAutoManaged(ctx context.Context, job *domain.Job) error {
@@ -45,6 +45,7 @@ func TestJobRepository_CreateOrUpdate_Insert(t *testing.T) {
 			60,
 			"pending",
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "created_at", "updated_at", "next_run_at"}).
@@ -115,6 +116,7 @@ func TestJobRepository_CreateOrUpdate_UpdateExisting(t *testing.T) {
 			60,
 			"pending",
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "created_at", "updated_at", "next_run_at"}).
@@ -174,7 +176,7 @@ func TestJobRepository_List_WithSorting(t *testing.T) {
 		"status", "scheduler_version",
 		"created_at", "updated_at", "started_at", "completed_at",
 		"paused_at", "cancelled_at",
-		"error_message", "metadata",
+		"error_message", "metadata", "overrides",
 	}
 
 	// Expect query with ORDER BY next_run_at ASC NULLS LAST (no WHERE clause)
@@ -225,7 +227,7 @@ func TestJobRepository_List_NextRunAtDescNullsFirst(t *testing.T) {
 		"status", "scheduler_version",
 		"created_at", "updated_at", "started_at", "completed_at",
 		"paused_at", "cancelled_at",
-		"error_message", "metadata",
+		"error_message", "metadata", "overrides",
 	}
 
 	// next_run_at DESC must use NULLS FIRST so unscheduled jobs surface at top
@@ -276,7 +278,7 @@ func TestJobRepository_List_WithStatusFilter(t *testing.T) {
 		"status", "scheduler_version",
 		"created_at", "updated_at", "started_at", "completed_at",
 		"paused_at", "cancelled_at",
-		"error_message", "metadata",
+		"error_message", "metadata", "overrides",
 	}
 
 	mock.ExpectQuery("SELECT .+ FROM jobs\\s+WHERE status = \\$1").
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Cost report groupings.
+const (
+	CostGroupByOwner  = "owner"
+	CostGroupByTag    = "tag"
+	CostGroupBySource = "source"
+)
+
+// Placeholder keys for sources without an owner or tags.
+const (
+	CostUnassignedOwner = "(unassigned)"
+	CostUntagged        = "(untagged)"
+)
+
+// Quota dimensions named in QuotaExceeded.
+const (
+	QuotaCompute   = "compute"
+	QuotaBandwidth = "bandwidth"
+	QuotaStorage   = "storage"
+)
+
+// SourceOwner attributes a source's crawl costs to an owner (e.g. a hosted
+// client) and optional tags for finer chargeback groupings.
+type SourceOwner struct {
+	SourceID  string         `db:"source_id"  json:"source_id"`
+	Owner     string         `db:"owner"      json:"owner"`
+	Tags      pq.StringArray `db:"tags"       json:"tags"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// OwnerQuota holds an owner's optional limits. Compute and bandwidth are per
+// calendar month (UTC); storage is the current size of the owner's indexes.
+// A nil limit is unlimited.
+type OwnerQuota struct {
+	Owner                 string    `db:"owner"                   json:"owner"`
+	MonthlyComputeSeconds *int64    `db:"monthly_compute_seconds" json:"monthly_compute_seconds"`
+	MonthlyBandwidthBytes *int64    `db:"monthly_bandwidth_bytes" json:"monthly_bandwidth_bytes"`
+	StorageBytes          *int64    `db:"storage_bytes"           json:"storage_bytes"`
+	CreatedAt             time.Time `db:"created_at"              json:"created_at"`
+	UpdatedAt             time.Time `db:"updated_at"              json:"updated_at"`
+}
+
+// SourceUsage is one source's resource use over a period. Compute is the
+// wall-clock time of its job executions; bandwidth is the response bytes
+// those executions downloaded.
+type SourceUsage struct {
+	SourceID       string         `db:"source_id"       json:"source_id"`
+	SourceName     string         `db:"source_name"     json:"source_name"`
+	Owner          string         `db:"owner"           json:"owner"`
+	Tags           pq.StringArray `db:"tags"            json:"tags"`
+	Executions     int64          `db:"executions"      json:"executions"`
+	ComputeMs      int64          `db:"compute_ms"      json:"compute_ms"`
+	BandwidthBytes int64          `db:"bandwidth_bytes" json:"bandwidth_bytes"`
+
+	// StorageBytes is filled from Elasticsearch, not the database
+	StorageBytes int64 `db:"-" json:"storage_bytes"`
+}
+
+// CostUsage is the usage of one owner, tag, or source in a cost report.
+type CostUsage struct {
+	Key            string  `json:"key"`
+	Sources        int     `json:"sources"`
+	Executions     int64   `json:"executions"`
+	ComputeSeconds float64 `json:"compute_seconds"`
+	BandwidthBytes int64   `json:"bandwidth_bytes"`
+	StorageBytes   int64   `json:"storage_bytes"`
+
+	// Quota and QuotaExceeded are only set when grouping by owner
+	Quota         *OwnerQuota `json:"quota,omitempty"`
+	QuotaExceeded []string    `json:"quota_exceeded,omitempty"`
+}
+
+// CostReport aggregates resource use for a calendar month.
+type CostReport struct {
+	Month   string       `json:"month"` // YYYY-MM
+	GroupBy string       `json:"group_by"`
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	Items   []*CostUsage `json:"items"`
+	Total   *CostUsage   `json:"total"`
+}
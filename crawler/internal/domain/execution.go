@@ -54,6 +54,19 @@ type JobStats struct {
 	SuccessRate       float64    `json:"success_rate"` // 0.0 to 1.0
 }
 
+// SourceCrawlHealth is the crawl state of one source: its current job (the most
+// recently updated) and its last successful execution across all its jobs.
+type SourceCrawlHealth struct {
+	SourceID      string     `db:"source_id"       json:"source_id"`
+	JobID         string     `db:"job_id"          json:"job_id"`
+	JobStatus     string     `db:"job_status"      json:"job_status"`
+	IsPaused      bool       `db:"is_paused"       json:"is_paused"`
+	NextRunAt     *time.Time `db:"next_run_at"     json:"next_run_at,omitempty"`
+	LastSuccessAt *time.Time `db:"last_success_at" json:"last_success_at,omitempty"`
+	LastFailureAt *time.Time `db:"last_failure_at" json:"last_failure_at,omitempty"`
+	FailureCount  int        `db:"failure_count"   json:"failure_count"`
+}
+
 // AggregateStats represents system-wide scheduler statistics.
 type AggregateStats struct {
 	TotalExecutions int64   `json:"total_executions"`
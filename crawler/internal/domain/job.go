@@ -51,6 +51,9 @@ type Job struct {
 	ErrorMessage *string  `db:"error_message" json:"error_message,omitempty"`
 	Metadata     JSONBMap `db:"metadata"      json:"metadata,omitempty"`
 
+	// Per-job environment overrides (proxy, user agent, render mode)
+	Overrides *JobOverrides `db:"overrides" json:"overrides,omitempty"`
+
 	// Auto-managed job lifecycle
 	AutoManaged   bool       `db:"auto_managed"    json:"auto_managed"`
 	Priority      int        `db:"priority"        json:"priority"`
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Render modes a job override may force.
+const (
+	RenderModeStatic  = "static"
+	RenderModeDynamic = "dynamic"
+)
+
+// allowedOverrideProxySchemes are the proxy URL schemes Colly can dial.
+var allowedOverrideProxySchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"socks5": true,
+}
+
+// JobOverrides lets a single job replace the crawler's environment defaults
+// without editing the source. It exists mainly to reproduce production
+// extraction bugs, e.g. by pointing a job at nc-http-proxy in replay mode.
+type JobOverrides struct {
+	// ProxyURL routes every request of the job through this proxy, bypassing
+	// the proxy pool and global proxy rotation.
+	ProxyURL string `json:"proxy_url,omitempty"`
+	// UserAgent replaces the configured (or randomized) User-Agent.
+	UserAgent string `json:"user_agent,omitempty"`
+	// RenderMode forces static fetching or rendering through the render worker.
+	RenderMode string `json:"render_mode,omitempty"`
+	// InsecureSkipVerify disables TLS verification, needed when the proxy
+	// terminates TLS with its own certificate (nc-http-proxy record/replay).
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+}
+
+// IsEmpty reports whether no override is set.
+func (o *JobOverrides) IsEmpty() bool {
+	return o == nil || *o == JobOverrides{}
+}
+
+// Validate checks the override values.
+func (o *JobOverrides) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.ProxyURL != "" {
+		parsed, err := url.Parse(o.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		if !allowedOverrideProxySchemes[parsed.Scheme] || parsed.Host == "" {
+			return fmt.Errorf("invalid proxy_url %q: must be an http, https, or socks5 URL with a host", o.ProxyURL)
+		}
+	}
+
+	switch o.RenderMode {
+	case "", RenderModeStatic, RenderModeDynamic:
+	default:
+		return fmt.Errorf("invalid render_mode %q: must be %q or %q", o.RenderMode, RenderModeStatic, RenderModeDynamic)
+	}
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface for the JSONB overrides column.
+func (o *JobOverrides) Scan(value any) error {
+	if value == nil {
+		*o = JobOverrides{}
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return errors.New("unsupported type for JobOverrides")
+	}
+
+	if len(data) == 0 {
+		*o = JobOverrides{}
+		return nil
+	}
+
+	return json.Unmarshal(data, o)
+}
+
+// Value implements the driver.Valuer interface. Empty overrides are stored
+// as NULL so the column only carries data for jobs that actually override.
+func (o *JobOverrides) Value() (driver.Value, error) {
+	if o.IsEmpty() {
+		return nil, nil
+	}
+	return json.Marshal(o)
+}
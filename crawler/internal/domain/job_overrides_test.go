@@ -0,0 +1,68 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+)
+
+func TestJobOverrides_Validate(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name      string
+		overrides *domain.JobOverrides
+		wantErr   bool
+	}{
+		{"nil is valid", nil, false},
+		{"empty is valid", &domain.JobOverrides{}, false},
+		{"http proxy", &domain.JobOverrides{ProxyURL: "http://nc-http-proxy:8055"}, false},
+		{"socks5 proxy", &domain.JobOverrides{ProxyURL: "socks5://127.0.0.1:9050"}, false},
+		{"proxy without scheme", &domain.JobOverrides{ProxyURL: "nc-http-proxy:8055"}, true},
+		{"unsupported proxy scheme", &domain.JobOverrides{ProxyURL: "ftp://proxy:21"}, true},
+		{"static render", &domain.JobOverrides{RenderMode: domain.RenderModeStatic}, false},
+		{"dynamic render", &domain.JobOverrides{RenderMode: domain.RenderModeDynamic}, false},
+		{"unknown render mode", &domain.JobOverrides{RenderMode: "headless"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.overrides.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJobOverrides_ValueScanRoundTrip(t *testing.T) {
+	t.Helper()
+
+	empty := &domain.JobOverrides{}
+	value, err := empty.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	if value != nil {
+		t.Errorf("empty overrides Value() = %v, want nil", value)
+	}
+
+	original := &domain.JobOverrides{
+		ProxyURL:           "http://nc-http-proxy:8055",
+		UserAgent:          "NorthCloudBot/1.0",
+		RenderMode:         domain.RenderModeDynamic,
+		InsecureSkipVerify: true,
+	}
+	value, err = original.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	var scanned domain.JobOverrides
+	if scanErr := scanned.Scan(value); scanErr != nil {
+		t.Fatalf("Scan() error = %v", scanErr)
+	}
+	if scanned != *original {
+		t.Errorf("round trip = %+v, want %+v", scanned, *original)
+	}
+}
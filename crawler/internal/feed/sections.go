@@ -0,0 +1,406 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Section suggestion reasons.
+const (
+	// SectionReasonUnmatched means none of the section's links match the source's article URL patterns.
+	SectionReasonUnmatched = "unmatched_by_patterns"
+	// SectionReasonNew means the section appeared since the source was first sampled.
+	SectionReasonNew = "new_section"
+)
+
+const (
+	// minSectionLinks is how many article-like links a section needs on the
+	// homepage to count when it is not linked from the navigation.
+	minSectionLinks = 2
+	// maxSectionSamples caps the sample URLs kept per section.
+	maxSectionSamples = 3
+	// maxSectionSegmentLength skips root-level article slugs that look like sections.
+	maxSectionSegmentLength = 40
+	// navSelector matches the elements whose links are treated as site navigation.
+	navSelector = `nav, header, [role="navigation"]`
+)
+
+// ignoredSections are first path segments that never represent editorial sections.
+var ignoredSections = map[string]struct{}{
+	"about": {}, "about-us": {}, "account": {}, "author": {}, "authors": {},
+	"cdn-cgi": {}, "contact": {}, "contact-us": {}, "feed": {}, "login": {},
+	"logout": {}, "newsletter": {}, "newsletters": {}, "privacy": {}, "privacy-policy": {},
+	"register": {}, "rss": {}, "search": {}, "subscribe": {}, "tag": {}, "tags": {},
+	"terms": {}, "terms-of-service": {}, "terms-of-use": {}, "wp-admin": {},
+	"wp-content": {}, "wp-json": {},
+}
+
+// taxonomySections are prefixes whose second segment names the actual section
+// (e.g. /category/podcasts/).
+var taxonomySections = map[string]struct{}{
+	"category": {}, "section": {}, "sections": {}, "topics": {},
+}
+
+// SectionSource is an enabled source whose homepage is sampled for new sections.
+type SectionSource struct {
+	SourceID           string
+	BaseURL            string
+	ArticleURLPatterns []string
+}
+
+// SectionSuggestion describes a site section the source's crawl filters do not cover.
+type SectionSuggestion struct {
+	// Section is the path prefix, e.g. "/podcasts/".
+	Section string
+	// SuggestedPattern is an article_url_patterns entry that would cover the section.
+	SuggestedPattern string
+	// SampleURLs are links under the section found on the homepage.
+	SampleURLs []string
+	// Reason is SectionReasonUnmatched or SectionReasonNew.
+	Reason string
+}
+
+// SectionSuggester files a section suggestion for a source.
+type SectionSuggester interface {
+	SuggestSection(ctx context.Context, sourceID string, suggestion SectionSuggestion) error
+}
+
+// SectionDiscoverer samples source homepages for sections or URL patterns that
+// the current crawl filters do not cover and files them as suggestions.
+//
+// Sources with article URL patterns are checked against those patterns. Sources
+// without patterns crawl every on-site link, so only sections that appear after
+// the first sample are reported.
+type SectionDiscoverer struct {
+	fetcher   HTTPFetcher
+	suggester SectionSuggester
+	log       Logger
+
+	mu    sync.Mutex
+	known map[string]map[string]struct{} // sourceID -> sections sampled or already suggested
+}
+
+// NewSectionDiscoverer creates a section discoverer.
+func NewSectionDiscoverer(fetcher HTTPFetcher, suggester SectionSuggester, log Logger) *SectionDiscoverer {
+	return &SectionDiscoverer{
+		fetcher:   fetcher,
+		suggester: suggester,
+		log:       log,
+		known:     make(map[string]map[string]struct{}),
+	}
+}
+
+// RunSectionDiscoveryLoop samples every listed source on a fixed interval.
+// It blocks until ctx is cancelled and returns nil on clean shutdown.
+func (d *SectionDiscoverer) RunSectionDiscoveryLoop(
+	ctx context.Context,
+	interval time.Duration,
+	listSources func(ctx context.Context) ([]SectionSource, error),
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	d.discoverAll(ctx, listSources)
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.log.Info("section discovery loop stopped")
+			return nil
+		case <-ticker.C:
+			d.discoverAll(ctx, listSources)
+		}
+	}
+}
+
+// discoverAll lists sources and files suggestions for each.
+func (d *SectionDiscoverer) discoverAll(
+	ctx context.Context,
+	listSources func(ctx context.Context) ([]SectionSource, error),
+) {
+	sources, err := listSources(ctx)
+	if err != nil {
+		d.log.Error("failed to list sources for section discovery", "error", err.Error())
+		return
+	}
+
+	for i := range sources {
+		if ctx.Err() != nil {
+			return
+		}
+		d.discoverAndSuggest(ctx, sources[i])
+	}
+}
+
+// discoverAndSuggest samples one source and files its suggestions. A suggestion
+// that fails to file is forgotten so the next pass retries it.
+func (d *SectionDiscoverer) discoverAndSuggest(ctx context.Context, source SectionSource) {
+	for _, suggestion := range d.DiscoverSections(ctx, source) {
+		if err := d.suggester.SuggestSection(ctx, source.SourceID, suggestion); err != nil {
+			d.forget(source.SourceID, suggestion.Section)
+			d.log.Error("failed to file section suggestion",
+				"source_id", source.SourceID,
+				"section", suggestion.Section,
+				"error", err.Error(),
+			)
+			continue
+		}
+
+		d.log.Info("filed section suggestion",
+			"source_id", source.SourceID,
+			"section", suggestion.Section,
+			"reason", suggestion.Reason,
+		)
+	}
+}
+
+// DiscoverSections samples a source's homepage and returns the sections not yet
+// suggested that its crawl filters miss. Returned sections are remembered and
+// not suggested again by this discoverer.
+func (d *SectionDiscoverer) DiscoverSections(ctx context.Context, source SectionSource) []SectionSuggestion {
+	resp, err := d.fetcher.Fetch(ctx, source.BaseURL, nil, nil)
+	if err != nil {
+		d.log.Warn("failed to fetch homepage for section discovery",
+			"url", source.BaseURL,
+			"error", err.Error(),
+		)
+		return nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	sections := extractSections(source.BaseURL, resp.Body)
+	patterns := compileArticlePatterns(source.ArticleURLPatterns)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	known, sampledBefore := d.known[source.SourceID]
+	if !sampledBefore {
+		known = make(map[string]struct{}, len(sections))
+		d.known[source.SourceID] = known
+	}
+
+	var suggestions []SectionSuggestion
+	for _, section := range sortedSectionNames(sections) {
+		if _, seen := known[section]; seen {
+			continue
+		}
+
+		samples := sections[section]
+		reason, decided := classifySection(patterns, samples, sampledBefore)
+		if !decided {
+			continue
+		}
+		known[section] = struct{}{}
+
+		if reason == "" {
+			continue
+		}
+
+		suggestions = append(suggestions, SectionSuggestion{
+			Section:          section,
+			SuggestedPattern: suggestPattern(source.BaseURL, section),
+			SampleURLs:       samples,
+			Reason:           reason,
+		})
+	}
+
+	return suggestions
+}
+
+// classifySection decides whether a section needs a suggestion and why. It is
+// undecided when the source has patterns but the homepage offers no article
+// links under the section to test them against.
+func classifySection(patterns []*regexp.Regexp, samples []string, sampledBefore bool) (reason string, decided bool) {
+	if len(patterns) > 0 {
+		if len(samples) == 0 {
+			return "", false
+		}
+		if anyMatches(patterns, samples) {
+			return "", true
+		}
+		return SectionReasonUnmatched, true
+	}
+
+	if sampledBefore {
+		return SectionReasonNew, true
+	}
+
+	return "", true
+}
+
+// forget removes a section from a source's known set.
+func (d *SectionDiscoverer) forget(sourceID, section string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.known[sourceID], section)
+}
+
+// extractSections returns the homepage's sections keyed by path prefix, each
+// with up to maxSectionSamples sample URLs. A section counts when it is linked
+// from the navigation or has at least minSectionLinks article-like links.
+func extractSections(baseURL, body string) map[string][]string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	host := trimWWW(base.Hostname())
+	samples := make(map[string][]string)
+	linkCounts := make(map[string]int)
+	inNav := make(map[string]bool)
+	seenURLs := make(map[string]bool)
+
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		link, parseErr := base.Parse(strings.TrimSpace(href))
+		if parseErr != nil || (link.Scheme != "http" && link.Scheme != "https") {
+			return
+		}
+		if trimWWW(link.Hostname()) != host {
+			return
+		}
+
+		section, isArticle := sectionOf(link.Path)
+		if section == "" {
+			return
+		}
+
+		if s.Closest(navSelector).Length() > 0 {
+			inNav[section] = true
+		}
+
+		if !isArticle {
+			return
+		}
+
+		link.Fragment = ""
+		link.RawQuery = ""
+		linkURL := link.String()
+		if seenURLs[linkURL] {
+			return
+		}
+		seenURLs[linkURL] = true
+
+		linkCounts[section]++
+		if len(samples[section]) < maxSectionSamples {
+			samples[section] = append(samples[section], linkURL)
+		}
+	})
+
+	sections := make(map[string][]string)
+	for section := range inNav {
+		sections[section] = samples[section]
+	}
+	for section, count := range linkCounts {
+		if count >= minSectionLinks {
+			sections[section] = samples[section]
+		}
+	}
+
+	return sections
+}
+
+// sectionOf returns the section prefix for a URL path (e.g. "/podcasts/") and
+// whether the path looks like an article below that section.
+func sectionOf(path string) (section string, isArticle bool) {
+	segments := strings.FieldsFunc(strings.ToLower(path), func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return "", false
+	}
+
+	depth := 1
+	if _, isTaxonomy := taxonomySections[segments[0]]; isTaxonomy && len(segments) > 1 {
+		depth = 2
+	}
+
+	for _, segment := range segments[:depth] {
+		if !isSectionSegment(segment) {
+			return "", false
+		}
+	}
+
+	return "/" + strings.Join(segments[:depth], "/") + "/", len(segments) > depth
+}
+
+// isSectionSegment reports whether a path segment can name a section.
+func isSectionSegment(segment string) bool {
+	if _, ignored := ignoredSections[segment]; ignored {
+		return false
+	}
+	if len(segment) > maxSectionSegmentLength || strings.Contains(segment, ".") {
+		return false
+	}
+
+	// Date-based paths (/2026/03/...) are archives, not sections.
+	return strings.ContainsFunc(segment, func(r rune) bool { return r < '0' || r > '9' })
+}
+
+// compileArticlePatterns compiles article URL patterns, skipping invalid ones.
+func compileArticlePatterns(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if re, err := regexp.Compile(p); err == nil {
+			compiled = append(compiled, re)
+		}
+	}
+
+	return compiled
+}
+
+// anyMatches reports whether any URL matches any pattern.
+func anyMatches(patterns []*regexp.Regexp, urls []string) bool {
+	for _, u := range urls {
+		for _, p := range patterns {
+			if p.MatchString(u) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// suggestPattern builds an article URL pattern covering articles under a section.
+func suggestPattern(baseURL, section string) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+
+	return `^https?://(www\.)?` + regexp.QuoteMeta(trimWWW(base.Hostname())) +
+		regexp.QuoteMeta(section) + `[^?#]+`
+}
+
+// sortedSectionNames returns section keys in a stable order.
+func sortedSectionNames(sections map[string][]string) []string {
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// trimWWW strips a leading "www." from a host.
+func trimWWW(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
@@ -0,0 +1,150 @@
+package feed_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/crawler/internal/feed"
+)
+
+// --- Test fixtures ---
+
+const homepageWithoutPodcasts = `<!DOCTYPE html>
+<html>
+<body>
+  <nav>
+    <a href="/news/">News</a>
+    <a href="/sports/">Sports</a>
+    <a href="/about/">About</a>
+  </nav>
+  <main>
+    <a href="/news/2026/03/council-votes">Council votes</a>
+    <a href="https://www.example.com/news/2026/03/road-closure#comments">Road closure</a>
+    <a href="/sports/hockey/wolves-win">Wolves win</a>
+    <a href="/2026/03/archive-story">Archive story</a>
+    <a href="/tag/politics/">Politics</a>
+    <a href="https://other.example.org/podcasts/elsewhere">Elsewhere</a>
+  </main>
+</body>
+</html>`
+
+const homepageWithPodcasts = `<!DOCTYPE html>
+<html>
+<body>
+  <header>
+    <a href="/news/">News</a>
+    <a href="/sports/">Sports</a>
+  </header>
+  <main>
+    <a href="/news/2026/03/council-votes">Council votes</a>
+    <a href="/sports/hockey/wolves-win">Wolves win</a>
+    <a href="/podcasts/morning-show-ep-12">Morning show</a>
+    <a href="/podcasts/the-north-ep-4">The North</a>
+    <a href="/category/opinion/columnists-on-budget">Budget column</a>
+    <a href="/category/opinion/editorial-on-roads">Roads editorial</a>
+  </main>
+</body>
+</html>`
+
+// sectionFetcher serves a homepage body that tests can swap between passes.
+type sectionFetcher struct {
+	body string
+}
+
+func (f *sectionFetcher) Fetch(_ context.Context, _ string, _, _ *string) (*feed.FetchResponse, error) {
+	return &feed.FetchResponse{StatusCode: http.StatusOK, Body: f.body}, nil
+}
+
+// noopSuggester satisfies feed.SectionSuggester for DiscoverSections tests.
+type noopSuggester struct{}
+
+func (noopSuggester) SuggestSection(_ context.Context, _ string, _ feed.SectionSuggestion) error {
+	return nil
+}
+
+func sectionNames(suggestions []feed.SectionSuggestion) []string {
+	names := make([]string, 0, len(suggestions))
+	for i := range suggestions {
+		names = append(names, suggestions[i].Section)
+	}
+
+	return names
+}
+
+// --- Tests ---
+
+func TestDiscoverSections_UnmatchedByPatterns(t *testing.T) {
+	t.Parallel()
+
+	fetcher := &sectionFetcher{body: homepageWithPodcasts}
+	d := feed.NewSectionDiscoverer(fetcher, noopSuggester{}, &mockLogger{})
+	source := feed.SectionSource{
+		SourceID:           "src-1",
+		BaseURL:            "https://www.example.com",
+		ArticleURLPatterns: []string{`/news/\d{4}/`, `/sports/`},
+	}
+
+	suggestions := d.DiscoverSections(context.Background(), source)
+
+	requireLen(t, suggestions, 2)
+	assertEqual(t, "/category/opinion/", suggestions[0].Section)
+	assertEqual(t, "/podcasts/", suggestions[1].Section)
+	assertEqual(t, feed.SectionReasonUnmatched, suggestions[1].Reason)
+	assertEqual(t, "https://www.example.com/podcasts/morning-show-ep-12", suggestions[1].SampleURLs[0])
+
+	pattern := regexp.MustCompile(suggestions[1].SuggestedPattern)
+	if !pattern.MatchString("https://example.com/podcasts/the-north-ep-5") {
+		t.Errorf("suggested pattern %q should match new podcast episodes", suggestions[1].SuggestedPattern)
+	}
+
+	if again := d.DiscoverSections(context.Background(), source); len(again) != 0 {
+		t.Errorf("expected sections to be suggested once, got %v", sectionNames(again))
+	}
+}
+
+func TestDiscoverSections_NewSectionWithoutPatterns(t *testing.T) {
+	t.Parallel()
+
+	fetcher := &sectionFetcher{body: homepageWithoutPodcasts}
+	d := feed.NewSectionDiscoverer(fetcher, noopSuggester{}, &mockLogger{})
+	source := feed.SectionSource{SourceID: "src-1", BaseURL: "https://example.com/"}
+
+	if first := d.DiscoverSections(context.Background(), source); len(first) != 0 {
+		t.Fatalf("first sample only records a baseline, got %v", sectionNames(first))
+	}
+
+	fetcher.body = homepageWithPodcasts
+	suggestions := d.DiscoverSections(context.Background(), source)
+
+	requireLen(t, suggestions, 2)
+	assertEqual(t, "/category/opinion/", suggestions[0].Section)
+	assertEqual(t, "/podcasts/", suggestions[1].Section)
+	assertEqual(t, feed.SectionReasonNew, suggestions[1].Reason)
+}
+
+func TestDiscoverSections_NavSectionWithoutLinksIsRecheckedLater(t *testing.T) {
+	t.Parallel()
+
+	fetcher := &sectionFetcher{body: `<nav><a href="/podcasts/">Podcasts</a></nav>`}
+	d := feed.NewSectionDiscoverer(fetcher, noopSuggester{}, &mockLogger{})
+	source := feed.SectionSource{
+		SourceID:           "src-1",
+		BaseURL:            "https://example.com",
+		ArticleURLPatterns: []string{`/news/`},
+	}
+
+	if first := d.DiscoverSections(context.Background(), source); len(first) != 0 {
+		t.Fatalf("a section without article links cannot be judged yet, got %v", sectionNames(first))
+	}
+
+	fetcher.body = homepageWithPodcasts
+	suggestions := d.DiscoverSections(context.Background(), source)
+
+	names := sectionNames(suggestions)
+	if !slices.Contains(names, "/podcasts/") {
+		t.Errorf("expected /podcasts/ once it has article links, got %v", names)
+	}
+}
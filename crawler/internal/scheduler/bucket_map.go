@@ -4,6 +4,8 @@ package scheduler
 import (
 	"sync"
 	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
 )
 
 const (
@@ -38,6 +40,7 @@ type BucketMap struct {
 	slots      map[int64]int        // slot_key -> job_count
 	jobToSlot  map[string]int64     // job_id -> slot_key
 	lastPlaced map[string]time.Time // job_id -> last placement time
+	clock      clock.Clock
 }
 
 // NewBucketMap creates an empty BucketMap.
@@ -46,6 +49,7 @@ func NewBucketMap() *BucketMap {
 		slots:      make(map[int64]int),
 		jobToSlot:  make(map[string]int64),
 		lastPlaced: make(map[string]time.Time),
+		clock:      clock.Real(),
 	}
 }
 
@@ -66,7 +70,7 @@ func (b *BucketMap) AddJob(jobID string, slotKey int64) {
 	// Add to new slot
 	b.slots[slotKey]++
 	b.jobToSlot[jobID] = slotKey
-	b.lastPlaced[jobID] = time.Now()
+	b.lastPlaced[jobID] = b.clock.Now()
 }
 
 // RemoveJob removes a job from its slot.
@@ -124,7 +128,7 @@ func (b *BucketMap) FindLeastLoaded(start, end time.Time) int64 {
 // Searches the next 24 hours (or interval, whichever is larger) for the least-loaded slot.
 // Returns the scheduled time.
 func (b *BucketMap) PlaceNewJob(jobID string, interval time.Duration) time.Time {
-	now := time.Now()
+	now := b.clock.Now()
 
 	// Search window: next 24h or next interval, whichever is larger
 	searchDuration := searchWindowDefault
@@ -171,7 +175,7 @@ func (b *BucketMap) CalculateNextRunPreserveRhythm(jobID string, interval time.D
 	// Add to new slot
 	b.slots[nextSlot]++
 	b.jobToSlot[jobID] = nextSlot
-	b.lastPlaced[jobID] = time.Now()
+	b.lastPlaced[jobID] = b.clock.Now()
 
 	b.mu.Unlock()
 
@@ -188,7 +192,7 @@ func (b *BucketMap) CanMoveJob(jobID, status string, nextRunAt *time.Time) (stri
 
 	// Rule 2: Protection window for imminent jobs
 	if nextRunAt != nil {
-		if time.Until(*nextRunAt) <= ProtectionWindow {
+		if b.clock.Until(*nextRunAt) <= ProtectionWindow {
 			return "protection_window", false
 		}
 	}
@@ -197,7 +201,7 @@ func (b *BucketMap) CanMoveJob(jobID, status string, nextRunAt *time.Time) (stri
 	b.mu.RLock()
 	lastPlaced, exists := b.lastPlaced[jobID]
 	b.mu.RUnlock()
-	if exists && time.Since(lastPlaced) < PlacementCooldown {
+	if exists && b.clock.Since(lastPlaced) < PlacementCooldown {
 		return "placement_cooldown", false
 	}
 
@@ -234,7 +238,7 @@ func (b *BucketMap) GetDistribution(windowHours int) Distribution {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	now := time.Now()
+	now := b.clock.Now()
 	hourly := make([]HourlyCount, windowHours)
 	totalJobs := 0
 	peakHour := 0
@@ -12,6 +12,7 @@ import (
 	"github.com/jonesrussell/north-cloud/crawler/internal/database"
 	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
 	"github.com/jonesrussell/north-cloud/crawler/internal/logs"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -21,6 +22,7 @@ const (
 	defaultMetricsInterval       = 30 * time.Second
 	defaultExecutionTimeout      = 1 * time.Hour
 	defaultStuckJobCheckInterval = 2 * time.Minute
+	quotaDeferral                = 15 * time.Minute
 	hoursPerDay                  = 24
 	exponentialBackoffBase       = 2
 )
@@ -73,6 +75,18 @@ type IntervalScheduler struct {
 
 	// Scraper config for leadership_scrape jobs
 	scraperConfig *ScraperConfig
+
+	// Owner quota enforcement (optional)
+	quotaGate QuotaGate
+
+	// Time source; replaced with a fake clock in tests
+	clock clock.Clock
+}
+
+// QuotaGate reports whether a source's owner has used up a quota, in which
+// case the source's due jobs are not started.
+type QuotaGate interface {
+	QuotaExceeded(ctx context.Context, sourceID string) (exceeded bool, reason string)
 }
 
 // NewIntervalScheduler creates a new interval-based scheduler.
@@ -101,12 +115,16 @@ func NewIntervalScheduler(
 		stuckJobCheckInterval:  defaultStuckJobCheckInterval,
 		metrics:                &SchedulerMetrics{},
 		bucketMap:              NewBucketMap(),
+		clock:                  clock.Real(),
 	}
 
 	// Apply options
 	for _, opt := range opts {
 		opt(s)
 	}
+	if s.bucketMap != nil {
+		s.bucketMap.clock = s.clock
+	}
 
 	return s
 }
@@ -168,7 +186,7 @@ func (s *IntervalScheduler) Stop() error {
 func (s *IntervalScheduler) pollJobs() {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(s.checkInterval)
+	ticker := s.clock.NewTicker(s.checkInterval)
 	defer ticker.Stop()
 
 	s.logger.Info("Job poller started", infralogger.Duration("interval", s.checkInterval))
@@ -178,7 +196,7 @@ func (s *IntervalScheduler) pollJobs() {
 		case <-s.ctx.Done():
 			s.logger.Info("Job poller stopping")
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.checkAndExecuteJobs()
 		}
 	}
@@ -186,7 +204,7 @@ func (s *IntervalScheduler) pollJobs() {
 
 // checkAndExecuteJobs finds jobs ready to run and executes them.
 func (s *IntervalScheduler) checkAndExecuteJobs() {
-	s.metrics.UpdateLastCheck()
+	s.metrics.UpdateLastCheck(s.clock.Now())
 
 	// Get jobs ready to run
 	jobs, err := s.repo.GetJobsReadyToRun(s.ctx)
@@ -200,6 +218,10 @@ func (s *IntervalScheduler) checkAndExecuteJobs() {
 	}
 
 	for _, job := range jobs {
+		if s.deferIfOverQuota(job) {
+			continue
+		}
+
 		// Try to acquire lock
 		acquired, lockErr := s.acquireJobLock(job)
 		if lockErr != nil {
@@ -220,10 +242,42 @@ func (s *IntervalScheduler) checkAndExecuteJobs() {
 	}
 }
 
+// deferIfOverQuota pushes a due job back by quotaDeferral when its source's
+// owner is over quota, so it neither runs nor crowds out other due jobs.
+// The job starts normally once the quota is raised or the month rolls over.
+func (s *IntervalScheduler) deferIfOverQuota(job *domain.Job) bool {
+	if s.quotaGate == nil {
+		return false
+	}
+
+	exceeded, reason := s.quotaGate.QuotaExceeded(s.ctx, job.SourceID)
+	if !exceeded {
+		return false
+	}
+
+	nextRun := s.clock.Now().Add(quotaDeferral)
+	job.NextRunAt = &nextRun
+	if err := s.repo.Update(s.ctx, job); err != nil {
+		s.logger.Error("Failed to defer over-quota job",
+			infralogger.String("job_id", job.ID),
+			infralogger.Error(err),
+		)
+		return true
+	}
+
+	s.logger.Info("Deferred job: source owner over quota",
+		infralogger.String("job_id", job.ID),
+		infralogger.String("source_id", job.SourceID),
+		infralogger.String("reason", reason),
+		infralogger.Time("next_run_at", nextRun),
+	)
+	return true
+}
+
 // acquireJobLock attempts to acquire a distributed lock for a job.
 func (s *IntervalScheduler) acquireJobLock(job *domain.Job) (bool, error) {
 	lockToken := uuid.New()
-	now := time.Now()
+	now := s.clock.Now()
 
 	acquired, err := s.repo.AcquireLock(s.ctx, job.ID, lockToken, now, s.lockDuration)
 	if err != nil {
@@ -292,6 +346,9 @@ func (s *IntervalScheduler) CancelJob(jobID string) error {
 // The ssePublisher field is not synchronized because it's intended to be
 // set once during initialization and never changed during the scheduler's lifetime.
 func (s *IntervalScheduler) SetSSEPublisher(publisher *SSEPublisher) {
+	if publisher != nil {
+		publisher.clock = s.clock
+	}
 	s.ssePublisher = publisher
 }
 
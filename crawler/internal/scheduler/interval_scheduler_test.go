@@ -0,0 +1,75 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/crawler/internal/database"
+	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	"github.com/jonesrussell/north-cloud/crawler/internal/scheduler"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// updateOnlyJobRepo records Update calls; other methods are not used by these tests.
+type updateOnlyJobRepo struct {
+	database.JobRepositoryInterface
+	updated []*domain.Job
+}
+
+func (r *updateOnlyJobRepo) Update(_ context.Context, job *domain.Job) error {
+	r.updated = append(r.updated, job)
+	return nil
+}
+
+func newScheduledJob(intervalMinutes int) *domain.Job {
+	return &domain.Job{
+		ID:              "job-1",
+		SourceID:        "source-1",
+		IntervalMinutes: &intervalMinutes,
+		IntervalType:    "minutes",
+		ScheduleEnabled: true,
+	}
+}
+
+func TestScheduleNewJob_UsesInjectedClock(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 6, 1, 9, 0, 0, 0, time.UTC)
+	repo := &updateOnlyJobRepo{}
+	s := scheduler.NewIntervalScheduler(infralogger.NewNop(), repo, nil, nil,
+		scheduler.WithClock(clock.NewFake(now)),
+		scheduler.WithLoadBalancing(false),
+	)
+
+	job := newScheduledJob(30)
+	if err := s.ScheduleNewJob(job); err != nil {
+		t.Fatalf("ScheduleNewJob: %v", err)
+	}
+
+	if want := now.Add(30 * time.Minute); job.NextRunAt == nil || !job.NextRunAt.Equal(want) {
+		t.Errorf("expected next run %v, got %v", want, job.NextRunAt)
+	}
+	if len(repo.updated) != 1 {
+		t.Errorf("expected the job to be saved once, got %d updates", len(repo.updated))
+	}
+}
+
+func TestScheduleNewJob_LoadBalancedPlacementUsesInjectedClock(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 6, 1, 9, 0, 0, 0, time.UTC)
+	s := scheduler.NewIntervalScheduler(infralogger.NewNop(), &updateOnlyJobRepo{}, nil, nil,
+		scheduler.WithClock(clock.NewFake(now)),
+	)
+
+	job := newScheduledJob(60)
+	if err := s.ScheduleNewJob(job); err != nil {
+		t.Fatalf("ScheduleNewJob: %v", err)
+	}
+
+	if job.NextRunAt == nil || job.NextRunAt.Before(now) || !job.NextRunAt.Before(now.Add(time.Hour)) {
+		t.Errorf("expected placement within the first interval after %v, got %v", now, job.NextRunAt)
+	}
+}
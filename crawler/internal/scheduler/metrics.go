@@ -76,20 +76,21 @@ func (m *SchedulerMetrics) IncrementTotalExecutions() {
 	m.TotalExecutions++
 }
 
-// UpdateAggregateMetrics updates aggregate metrics (avg duration, success rate).
-func (m *SchedulerMetrics) UpdateAggregateMetrics(avgDuration, successRate float64) {
+// UpdateAggregateMetrics updates aggregate metrics (avg duration, success rate)
+// computed at the given time.
+func (m *SchedulerMetrics) UpdateAggregateMetrics(avgDuration, successRate float64, at time.Time) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.AverageDurationMs = avgDuration
 	m.SuccessRate = successRate
-	m.LastMetricsUpdate = time.Now()
+	m.LastMetricsUpdate = at
 }
 
-// UpdateLastCheck updates the last check timestamp.
-func (m *SchedulerMetrics) UpdateLastCheck() {
+// UpdateLastCheck sets the last check timestamp.
+func (m *SchedulerMetrics) UpdateLastCheck(at time.Time) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.LastCheckAt = time.Now()
+	m.LastCheckAt = at
 }
 
 // AddStaleLocksCleared adds to the stale locks cleared counter.
@@ -64,7 +64,9 @@ func TestSchedulerMetrics_UpdateAggregateMetrics(t *testing.T) {
 	avgDuration := 1500.5
 	successRate := 0.85
 
-	m.UpdateAggregateMetrics(avgDuration, successRate)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	m.UpdateAggregateMetrics(avgDuration, successRate, at)
 
 	if m.AverageDurationMs != avgDuration {
 		t.Errorf("AverageDurationMs = %f, want %f", m.AverageDurationMs, avgDuration)
@@ -72,8 +74,8 @@ func TestSchedulerMetrics_UpdateAggregateMetrics(t *testing.T) {
 	if m.SuccessRate != successRate {
 		t.Errorf("SuccessRate = %f, want %f", m.SuccessRate, successRate)
 	}
-	if m.LastMetricsUpdate.IsZero() {
-		t.Error("LastMetricsUpdate should be set")
+	if !m.LastMetricsUpdate.Equal(at) {
+		t.Errorf("LastMetricsUpdate = %v, want %v", m.LastMetricsUpdate, at)
 	}
 }
 
@@ -84,10 +86,11 @@ func TestSchedulerMetrics_UpdateLastCheck(t *testing.T) {
 		t.Error("LastCheckAt should be zero initially")
 	}
 
-	m.UpdateLastCheck()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.UpdateLastCheck(at)
 
-	if m.LastCheckAt.IsZero() {
-		t.Error("LastCheckAt should be set after UpdateLastCheck")
+	if !m.LastCheckAt.Equal(at) {
+		t.Errorf("LastCheckAt = %v, want %v", m.LastCheckAt, at)
 	}
 }
 
@@ -115,8 +118,8 @@ func TestSchedulerMetrics_Snapshot(t *testing.T) {
 	m.IncrementFailed()
 	m.IncrementCancelled()
 	m.IncrementTotalExecutions()
-	m.UpdateAggregateMetrics(1000.0, 0.9)
-	m.UpdateLastCheck()
+	m.UpdateAggregateMetrics(1000.0, 0.9, time.Now())
+	m.UpdateLastCheck(time.Now())
 	m.AddStaleLocksCleared(2)
 
 	// Take snapshot
@@ -174,7 +177,7 @@ func TestSchedulerMetrics_Concurrency(t *testing.T) {
 				m.IncrementFailed()
 				m.IncrementCancelled()
 				m.IncrementTotalExecutions()
-				m.UpdateLastCheck()
+				m.UpdateLastCheck(time.Now())
 				m.AddStaleLocksCleared(1)
 			}
 		}()
@@ -222,7 +225,7 @@ func TestSchedulerMetrics_ConcurrentSnapshots(t *testing.T) {
 			defer wg.Done()
 			for j := range 100 {
 				m.IncrementScheduled()
-				m.UpdateAggregateMetrics(float64(j), 0.5)
+				m.UpdateAggregateMetrics(float64(j), 0.5, time.Now())
 				time.Sleep(time.Microsecond)
 			}
 		}()
@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
 )
 
 // SchedulerOption is a functional option for configuring the IntervalScheduler.
@@ -63,6 +65,22 @@ func WithScraperConfig(cfg ScraperConfig) SchedulerOption {
 	}
 }
 
+// WithQuotaGate skips due jobs whose source owner is over quota.
+// Default: no quota enforcement
+func WithQuotaGate(gate QuotaGate) SchedulerOption {
+	return func(s *IntervalScheduler) {
+		s.quotaGate = gate
+	}
+}
+
+// WithClock sets the time source for polling, locks and next-run calculation.
+// Default: the system clock
+func WithClock(c clock.Clock) SchedulerOption {
+	return func(s *IntervalScheduler) {
+		s.clock = c
+	}
+}
+
 // WithLoadBalancing enables or disables load-balanced placement.
 // Default is true (enabled).
 func WithLoadBalancing(enabled bool) SchedulerOption {
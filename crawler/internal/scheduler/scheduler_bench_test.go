@@ -170,7 +170,7 @@ func BenchmarkMetricsCollection(b *testing.B) {
 		// Simulate concurrent metrics updates
 		metrics.IncrementScheduled()
 		metrics.IncrementCompleted()
-		metrics.UpdateAggregateMetrics(100.0, 0.95)
+		metrics.UpdateAggregateMetrics(100.0, 0.95, time.Now())
 	}
 }
 
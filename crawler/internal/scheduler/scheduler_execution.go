@@ -12,6 +12,7 @@ import (
 	"github.com/jonesrussell/north-cloud/crawler/internal/crawler"
 	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
 	"github.com/jonesrussell/north-cloud/crawler/internal/logs"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -32,7 +33,7 @@ func (s *IntervalScheduler) executeJob(job *domain.Job) {
 		JobID:           job.ID,
 		ExecutionNumber: s.getNextExecutionNumber(job.ID),
 		Status:          "running",
-		StartedAt:       time.Now(),
+		StartedAt:       s.clock.Now(),
 		RetryAttempt:    job.CurrentRetryCount,
 	}
 
@@ -47,7 +48,7 @@ func (s *IntervalScheduler) executeJob(job *domain.Job) {
 
 	// Update job status
 	job.Status = "running"
-	now := time.Now()
+	now := s.clock.Now()
 	job.StartedAt = &now
 
 	if err := s.repo.Update(s.ctx, job); err != nil {
@@ -70,7 +71,7 @@ func (s *IntervalScheduler) executeJob(job *domain.Job) {
 		Execution: execution,
 		Context:   jobCtx,
 		Cancel:    cancel,
-		StartTime: time.Now(),
+		StartTime: s.clock.Now(),
 	}
 
 	// Track active job
@@ -89,12 +90,12 @@ func (s *IntervalScheduler) executeJob(job *domain.Job) {
 }
 
 // writeLog writes a log entry if the log writer is available.
-func writeLog(w logs.Writer, level, message, jobID, execID string, fields map[string]any) {
+func (s *IntervalScheduler) writeLog(w logs.Writer, level, message, jobID, execID string, fields map[string]any) {
 	if w == nil {
 		return
 	}
 	w.WriteEntry(logs.LogEntry{
-		Timestamp: time.Now(),
+		Timestamp: s.clock.Now(),
 		Level:     level,
 		Message:   message,
 		JobID:     jobID,
@@ -156,7 +157,7 @@ func createCaptureFunc(w logs.Writer) func(logs.LogEntry) {
 func (s *IntervalScheduler) createJobCrawler(jobExec *JobExecution, logWriter logs.Writer) (crawler.Interface, error) {
 	crawlerInstance, err := s.factory.Create()
 	if err != nil {
-		writeLog(logWriter, "error", "Failed to create crawler: "+err.Error(),
+		s.writeLog(logWriter, "error", "Failed to create crawler: "+err.Error(),
 			jobExec.Job.ID, jobExec.Execution.ID, nil)
 		return nil, fmt.Errorf("create crawler for job %s: %w", jobExec.Job.ID, err)
 	}
@@ -172,6 +173,7 @@ func (s *IntervalScheduler) createJobCrawler(jobExec *JobExecution, logWriter lo
 		noThrottling,
 	)
 	crawlerInstance.SetJobLogger(jobLogger)
+	crawlerInstance.SetJobOverrides(jobExec.Job.Overrides)
 	jobLogger.StartHeartbeat(jobExec.Context)
 
 	jobExec.Crawler = crawlerInstance
@@ -218,7 +220,7 @@ func (s *IntervalScheduler) runCrawlJob(jobExec *JobExecution, logWriter logs.Wr
 		return
 	}
 
-	writeLog(logWriter, "info", "Starting job execution", job.ID, execution.ID, map[string]any{
+	s.writeLog(logWriter, "info", "Starting job execution", job.ID, execution.ID, map[string]any{
 		"source_id":     job.SourceID,
 		"url":           job.URL,
 		"retry_attempt": job.CurrentRetryCount,
@@ -232,14 +234,14 @@ func (s *IntervalScheduler) runCrawlJob(jobExec *JobExecution, logWriter logs.Wr
 	)
 
 	if job.SourceID == "" {
-		writeLog(logWriter, "error", "Job missing required source_id", job.ID, execution.ID, nil)
+		s.writeLog(logWriter, "error", "Job missing required source_id", job.ID, execution.ID, nil)
 		s.handleJobFailure(jobExec, errors.New("job missing required source_id"), nil)
 		return
 	}
 
 	// Capture startTime AFTER crawler creation to match original timing semantics
-	startTime := time.Now()
-	writeLog(logWriter, "info", "Starting crawler", job.ID, execution.ID, map[string]any{
+	startTime := s.clock.Now()
+	s.writeLog(logWriter, "info", "Starting crawler", job.ID, execution.ID, map[string]any{
 		"source_id": job.SourceID,
 	})
 
@@ -250,18 +252,18 @@ func (s *IntervalScheduler) runCrawlJob(jobExec *JobExecution, logWriter logs.Wr
 		return
 	}
 
-	writeLog(logWriter, "info", "Waiting for crawler to complete", job.ID, execution.ID, nil)
+	s.writeLog(logWriter, "info", "Waiting for crawler to complete", job.ID, execution.ID, nil)
 
 	err = crawlerInstance.Wait()
 	if err != nil {
-		writeLog(logWriter, "error", "Crawler failed: "+err.Error(), job.ID, execution.ID, nil)
+		s.writeLog(logWriter, "error", "Crawler failed: "+err.Error(), job.ID, execution.ID, nil)
 		s.handleJobFailure(jobExec, err, &startTime)
 		return
 	}
 
 	jobSummary := crawlerInstance.GetJobLogger().BuildSummary()
-	writeLog(logWriter, "info", "Job completed successfully", job.ID, execution.ID, map[string]any{
-		"duration_ms":     time.Since(startTime).Milliseconds(),
+	s.writeLog(logWriter, "info", "Job completed successfully", job.ID, execution.ID, map[string]any{
+		"duration_ms":     s.clock.Since(startTime).Milliseconds(),
 		"pages_crawled":   jobSummary.PagesCrawled,
 		"items_extracted": jobSummary.ItemsExtracted,
 		"error_count":     jobSummary.ErrorsCount,
@@ -274,9 +276,9 @@ func (s *IntervalScheduler) runCrawlJob(jobExec *JobExecution, logWriter logs.Wr
 func (s *IntervalScheduler) runLeadershipJob(jobExec *JobExecution, logWriter logs.Writer) {
 	job := jobExec.Job
 	execution := jobExec.Execution
-	startTime := time.Now()
+	startTime := s.clock.Now()
 
-	writeLog(logWriter, "info", "Starting leadership scrape job", job.ID, execution.ID, nil)
+	s.writeLog(logWriter, "info", "Starting leadership scrape job", job.ID, execution.ID, nil)
 
 	s.logger.Info("Executing leadership scrape job",
 		infralogger.String("job_id", job.ID),
@@ -284,20 +286,20 @@ func (s *IntervalScheduler) runLeadershipJob(jobExec *JobExecution, logWriter lo
 
 	if s.scraperConfig == nil {
 		err := errors.New("leadership scrape: scraper not configured")
-		writeLog(logWriter, "error", err.Error(), job.ID, execution.ID, nil)
+		s.writeLog(logWriter, "error", err.Error(), job.ID, execution.ID, nil)
 		s.handleJobFailure(jobExec, err, &startTime)
 		return
 	}
 
 	err := RunLeadershipScrapeJob(jobExec.Context, *s.scraperConfig, s.logger)
 	if err != nil {
-		writeLog(logWriter, "error", "Leadership scrape failed: "+err.Error(), job.ID, execution.ID, nil)
+		s.writeLog(logWriter, "error", "Leadership scrape failed: "+err.Error(), job.ID, execution.ID, nil)
 		s.handleJobFailure(jobExec, err, &startTime)
 		return
 	}
 
-	writeLog(logWriter, "info", "Leadership scrape completed successfully", job.ID, execution.ID, map[string]any{
-		"duration_ms": time.Since(startTime).Milliseconds(),
+	s.writeLog(logWriter, "info", "Leadership scrape completed successfully", job.ID, execution.ID, map[string]any{
+		"duration_ms": s.clock.Since(startTime).Milliseconds(),
 	})
 
 	s.handleLeadershipJobSuccess(jobExec, &startTime)
@@ -315,7 +317,7 @@ func (s *IntervalScheduler) logCrawlerStartError(
 	job *domain.Job, execID string, err error, logWriter logs.Writer,
 ) {
 	if errors.Is(err, context.DeadlineExceeded) {
-		writeLog(logWriter, "warn", "Crawl timed out (context deadline exceeded): "+err.Error(), job.ID, execID, nil)
+		s.writeLog(logWriter, "warn", "Crawl timed out (context deadline exceeded): "+err.Error(), job.ID, execID, nil)
 		s.logger.Warn("Crawl timed out: context deadline exceeded",
 			infralogger.String("job_id", job.ID),
 			infralogger.String("source_id", job.SourceID),
@@ -323,7 +325,7 @@ func (s *IntervalScheduler) logCrawlerStartError(
 			infralogger.Error(err),
 		)
 	} else if isExpectedStartError(err) {
-		writeLog(logWriter, "warn", "Crawler start failed (expected): "+err.Error(), job.ID, execID, nil)
+		s.writeLog(logWriter, "warn", "Crawler start failed (expected): "+err.Error(), job.ID, execID, nil)
 		s.logger.Warn("Crawler start failed (expected)",
 			infralogger.String("job_id", job.ID),
 			infralogger.String("source_id", job.SourceID),
@@ -331,7 +333,7 @@ func (s *IntervalScheduler) logCrawlerStartError(
 			infralogger.Error(err),
 		)
 	} else {
-		writeLog(logWriter, "error", "Crawler start failed: "+err.Error(), job.ID, execID, nil)
+		s.writeLog(logWriter, "error", "Crawler start failed: "+err.Error(), job.ID, execID, nil)
 		s.logger.Error("Crawler start failed",
 			infralogger.String("job_id", job.ID),
 			infralogger.String("source_id", job.SourceID),
@@ -349,12 +351,13 @@ func (s *IntervalScheduler) recoverFromPanic(job *domain.Job, execution *domain.
 		return
 	}
 
-	s.logger.Error("Recovered from panic in job execution",
+	crash.Handle(r, s.logger,
 		infralogger.String("job_id", job.ID),
-		infralogger.Any("panic", r),
+		infralogger.String("source_id", job.SourceID),
+		infralogger.String("execution_id", execution.ID),
 	)
 
-	now := time.Now()
+	now := s.clock.Now()
 	errMsg := fmt.Sprintf("panic: %v", r)
 
 	// Mark execution as failed
@@ -406,8 +409,8 @@ func (s *IntervalScheduler) completeJob(jobExec *JobExecution, startTime *time.T
 	job := jobExec.Job
 	execution := jobExec.Execution
 
-	now := time.Now()
-	durationMs := time.Since(*startTime).Milliseconds()
+	now := s.clock.Now()
+	durationMs := s.clock.Since(*startTime).Milliseconds()
 
 	// Update execution record
 	execution.Status = string(StateCompleted)
@@ -476,10 +479,10 @@ func (s *IntervalScheduler) handleJobFailure(jobExec *JobExecution, execErr erro
 	job := jobExec.Job
 	execution := jobExec.Execution
 
-	now := time.Now()
+	now := s.clock.Now()
 	var durationMs int64
 	if startTime != nil {
-		durationMs = time.Since(*startTime).Milliseconds()
+		durationMs = s.clock.Since(*startTime).Milliseconds()
 	}
 
 	// Capture crawl metrics before updating execution (Crawler may be nil if factory.Create failed)
@@ -508,7 +511,7 @@ func (s *IntervalScheduler) handleJobFailure(jobExec *JobExecution, execErr erro
 		// Schedule retry with backoff
 		job.CurrentRetryCount++
 		backoff := s.calculateBackoff(job)
-		nextRun := time.Now().Add(backoff)
+		nextRun := s.clock.Now().Add(backoff)
 		job.NextRunAt = &nextRun
 		job.Status = "scheduled"
 
@@ -579,7 +582,7 @@ func (s *IntervalScheduler) calculateNextRun(job *domain.Job) time.Time {
 	}
 
 	// Fallback to original behavior
-	return time.Now().Add(interval)
+	return s.clock.Now().Add(interval)
 }
 
 // calculateAdaptiveOrFixedNextRun calculates the next run time.
@@ -626,7 +629,7 @@ func (s *IntervalScheduler) calculateAdaptiveOrFixedNextRun(
 		infralogger.Duration("baseline_interval", baseline),
 	)
 
-	return time.Now().Add(state.CurrentInterval)
+	return s.clock.Now().Add(state.CurrentInterval)
 }
 
 // calculateBackoff calculates exponential backoff duration for retries.
@@ -1,8 +1,6 @@
 package scheduler
 
 import (
-	"time"
-
 	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
@@ -11,7 +9,7 @@ import (
 func (s *IntervalScheduler) cleanStaleLocks() {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(s.staleLockCheckInterval)
+	ticker := s.clock.NewTicker(s.staleLockCheckInterval)
 	defer ticker.Stop()
 
 	s.logger.Info("Stale lock cleaner started", infralogger.Duration("interval", s.staleLockCheckInterval))
@@ -21,8 +19,8 @@ func (s *IntervalScheduler) cleanStaleLocks() {
 		case <-s.ctx.Done():
 			s.logger.Info("Stale lock cleaner stopping")
 			return
-		case <-ticker.C:
-			cutoff := time.Now().Add(-s.lockDuration)
+		case <-ticker.C():
+			cutoff := s.clock.Now().Add(-s.lockDuration)
 			count, err := s.repo.ClearStaleLocks(s.ctx, cutoff)
 			if err != nil {
 				s.logger.Error("Failed to clear stale locks", infralogger.Error(err))
@@ -60,7 +58,7 @@ func (s *IntervalScheduler) recoverOrphanedJobs() {
 
 		s.failStuckExecution(job.ID)
 
-		now := time.Now()
+		now := s.clock.Now()
 		errMsg := "recovered: job orphaned by container restart"
 		s.resetJobAfterFailure(job, &errMsg, &now)
 		s.metrics.IncrementFailed()
@@ -73,7 +71,7 @@ func (s *IntervalScheduler) recoverOrphanedJobs() {
 func (s *IntervalScheduler) recoverStuckJobs() {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(s.stuckJobCheckInterval)
+	ticker := s.clock.NewTicker(s.stuckJobCheckInterval)
 	defer ticker.Stop()
 
 	s.logger.Info("Stuck job recovery started",
@@ -86,7 +84,7 @@ func (s *IntervalScheduler) recoverStuckJobs() {
 		case <-s.ctx.Done():
 			s.logger.Info("Stuck job recovery stopping")
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.checkForStuckJobs()
 		}
 	}
@@ -127,7 +125,7 @@ func (s *IntervalScheduler) resetStuckJob(job *domain.Job) {
 	s.failStuckExecution(job.ID)
 
 	// Reset the job itself
-	now := time.Now()
+	now := s.clock.Now()
 	errMsg := "recovered: job exceeded maximum execution time"
 	s.resetJobAfterFailure(job, &errMsg, &now)
 	s.metrics.IncrementFailed()
@@ -149,7 +147,7 @@ func (s *IntervalScheduler) failStuckExecution(jobID string) {
 		return
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 	errMsg := "recovered: job exceeded maximum execution time"
 	latestExec.Status = string(StateFailed)
 	latestExec.CompletedAt = &now
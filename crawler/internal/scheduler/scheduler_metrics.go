@@ -1,8 +1,6 @@
 package scheduler
 
 import (
-	"time"
-
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -10,7 +8,7 @@ import (
 func (s *IntervalScheduler) collectMetrics() {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(s.metricsInterval)
+	ticker := s.clock.NewTicker(s.metricsInterval)
 	defer ticker.Stop()
 
 	s.logger.Info("Metrics collector started", infralogger.Duration("interval", s.metricsInterval))
@@ -19,7 +17,7 @@ func (s *IntervalScheduler) collectMetrics() {
 		case <-s.ctx.Done():
 			s.logger.Info("Metrics collector stopping")
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.updateMetrics()
 		}
 	}
@@ -34,7 +32,7 @@ func (s *IntervalScheduler) updateMetrics() {
 		return
 	}
 
-	s.metrics.UpdateAggregateMetrics(stats.AvgDurationMs, stats.SuccessRate)
+	s.metrics.UpdateAggregateMetrics(stats.AvgDurationMs, stats.SuccessRate, s.clock.Now())
 
 	s.logger.Debug("Metrics updated",
 		infralogger.Float64("avg_duration_ms", stats.AvgDurationMs),
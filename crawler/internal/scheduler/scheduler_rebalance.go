@@ -3,7 +3,6 @@ package scheduler
 import (
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
@@ -58,7 +57,7 @@ func (s *IntervalScheduler) ScheduleNewJob(job *domain.Job) error {
 		job.Status = string(StateScheduled)
 	} else {
 		// Fallback to original behavior
-		nextRun := time.Now().Add(interval)
+		nextRun := s.clock.Now().Add(interval)
 		job.NextRunAt = &nextRun
 		job.Status = string(StateScheduled)
 	}
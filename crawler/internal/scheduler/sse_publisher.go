@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/jonesrussell/north-cloud/crawler/internal/domain"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/sse"
 )
@@ -21,6 +22,7 @@ const (
 type SSEPublisher struct {
 	broker   sse.Broker
 	logger   infralogger.Logger
+	clock    clock.Clock // the scheduler's clock once set with SetSSEPublisher
 	disabled atomic.Bool
 }
 
@@ -29,6 +31,7 @@ func NewSSEPublisher(broker sse.Broker, logger infralogger.Logger) *SSEPublisher
 	return &SSEPublisher{
 		broker: broker,
 		logger: logger,
+		clock:  clock.Real(),
 	}
 }
 
@@ -118,6 +121,7 @@ type ProgressTracker struct {
 	job           *domain.Job
 	execution     *domain.JobExecution
 	ctx           context.Context
+	clock         clock.Clock
 	lastEmitTime  time.Time
 	lastItemCount int
 	mu            sync.Mutex
@@ -125,12 +129,17 @@ type ProgressTracker struct {
 
 // NewProgressTracker creates a new progress tracker for a job execution.
 func NewProgressTracker(ctx context.Context, publisher *SSEPublisher, job *domain.Job, execution *domain.JobExecution) *ProgressTracker {
+	c := clock.Real()
+	if publisher != nil {
+		c = publisher.clock
+	}
 	return &ProgressTracker{
 		publisher:     publisher,
 		job:           job,
 		execution:     execution,
 		ctx:           ctx,
-		lastEmitTime:  time.Now(),
+		clock:         c,
+		lastEmitTime:  c.Now(),
 		lastItemCount: 0,
 	}
 }
@@ -146,14 +155,14 @@ func (pt *ProgressTracker) Update(itemsCrawled, itemsIndexed int) bool {
 	}
 
 	itemDelta := itemsCrawled - pt.lastItemCount
-	timeSinceLastEmit := time.Since(pt.lastEmitTime)
+	timeSinceLastEmit := pt.clock.Since(pt.lastEmitTime)
 
 	// Emit if either threshold is met
 	shouldEmit := itemDelta >= progressItemThreshold || timeSinceLastEmit >= progressIntervalDuration
 
 	if shouldEmit {
 		pt.publisher.PublishJobProgress(pt.ctx, pt.job, pt.execution, itemsCrawled, itemsIndexed)
-		pt.lastEmitTime = time.Now()
+		pt.lastEmitTime = pt.clock.Now()
 		pt.lastItemCount = itemsCrawled
 		return true
 	}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 )
 
 const (
@@ -23,6 +24,11 @@ const (
 	DefaultTimeout = 30 * time.Second
 	// ServiceTokenExpirationHours is the expiration time for service-to-service JWT tokens.
 	ServiceTokenExpirationHours = 24
+	// CredentialResolveScope is the scope source-manager requires to return
+	// decrypted credentials. Unscoped service tokens are refused.
+	CredentialResolveScope = "sources:credentials:read"
+	// credentialTokenTTL keeps tokens that can read secrets short-lived.
+	credentialTokenTTL = time.Minute
 )
 
 // ErrNotFound is returned when the API responds with 404 (e.g. no source for identity_key).
@@ -278,6 +284,69 @@ func (c *Client) EnableFeed(ctx context.Context, sourceID string) error {
 	return c.doRequest(req, &response)
 }
 
+// CreateNote attaches a note to a source via the source-manager API. It reports
+// false when a note with the same dedupe key was already filed for the source.
+func (c *Client) CreateNote(ctx context.Context, sourceID string, note *APISourceNote) (bool, error) {
+	notesURL, err := url.JoinPath(c.baseURL, sourceID, "notes")
+	if err != nil {
+		return false, fmt.Errorf("construct notes URL: %w", err)
+	}
+
+	body, marshalErr := json.Marshal(note)
+	if marshalErr != nil {
+		return false, fmt.Errorf("marshal note: %w", marshalErr)
+	}
+
+	req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, notesURL, bytes.NewReader(body))
+	if reqErr != nil {
+		return false, fmt.Errorf("create note request: %w", reqErr)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var response struct {
+		Created bool `json:"created"`
+	}
+	if doErr := c.doRequest(req, &response); doErr != nil {
+		return false, doErr
+	}
+
+	return response.Created, nil
+}
+
+// GetCredentials resolves the decrypted credentials the crawler needs to fetch
+// a source. A source without credentials returns an empty list, as does a
+// source-manager that does not store credentials (404).
+func (c *Client) GetCredentials(ctx context.Context, sourceID string) ([]APICredential, error) {
+	credentialsURL, err := url.JoinPath(c.baseURL, sourceID, "credentials", "resolved")
+	if err != nil {
+		return nil, fmt.Errorf("construct credentials URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, credentialsURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create credentials request: %w", err)
+	}
+	if c.jwtSecret != "" {
+		token, tokenErr := infrajwt.NewServiceToken(c.jwtSecret, "crawler-service", CredentialResolveScope, credentialTokenTTL)
+		if tokenErr != nil {
+			return nil, fmt.Errorf("failed to generate service token: %w", tokenErr)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	var response struct {
+		Credentials []APICredential `json:"credentials"`
+	}
+	if doErr := c.doRequest(req, &response); doErr != nil {
+		if errors.Is(doErr, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get source credentials: %w", doErr)
+	}
+
+	return response.Credentials, nil
+}
+
 // generateServiceToken generates a JWT token for service-to-service authentication.
 func (c *Client) generateServiceToken() (string, error) {
 	if c.jwtSecret == "" {
@@ -298,8 +367,8 @@ func (c *Client) generateServiceToken() (string, error) {
 
 // doRequest executes an HTTP request and decodes the response.
 func (c *Client) doRequest(req *http.Request, result any) error {
-	// Add JWT token if secret is configured
-	if c.jwtSecret != "" {
+	// Add JWT token if secret is configured and the caller has not set a scoped one
+	if c.jwtSecret != "" && req.Header.Get("Authorization") == "" {
 		token, err := c.generateServiceToken()
 		if err != nil {
 			return fmt.Errorf("failed to generate service token: %w", err)
@@ -0,0 +1,48 @@
+package apiclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jonesrussell/north-cloud/crawler/internal/sources/apiclient"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+const testSecret = "test-secret"
+
+func TestGetCredentials_SendsScopedToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sources/src-1/credentials/resolved" {
+			http.NotFound(w, r)
+			return
+		}
+		claims := &infrajwt.Claims{}
+		_, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), claims,
+			func(*jwt.Token) (any, error) { return []byte(testSecret), nil })
+		if err != nil || claims.Scope != apiclient.CredentialResolveScope {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"credentials":[{"kind":"api_key","header_name":"X-Api-Key","value":"key-123"}]}`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.NewClient(apiclient.WithBaseURL(srv.URL+"/api/v1/sources"), apiclient.WithJWTSecret(testSecret))
+	creds, err := client.GetCredentials(context.Background(), "src-1")
+	if err != nil {
+		t.Fatalf("GetCredentials: %v", err)
+	}
+	if len(creds) != 1 || creds[0].HeaderName != "X-Api-Key" || creds[0].Value != "key-123" {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+
+	// A source-manager without the credentials endpoint means no credentials
+	creds, err = client.GetCredentials(context.Background(), "src-2")
+	if err != nil || len(creds) != 0 {
+		t.Fatalf("expected no credentials on 404, got %+v, %v", creds, err)
+	}
+}
@@ -103,6 +103,23 @@ type ListSourcesResponse struct {
 	Total   int         `json:"total"`
 }
 
+// APISourceNote is a note filed against a source in the source-manager API.
+type APISourceNote struct {
+	Kind      string         `json:"kind"`
+	Author    string         `json:"author"`
+	Body      string         `json:"body"`
+	Details   map[string]any `json:"details,omitempty"`
+	DedupeKey string         `json:"dedupe_key,omitempty"`
+}
+
+// APICredential is a decrypted source credential as resolved by the source-manager API.
+type APICredential struct {
+	Kind       string `json:"kind"`
+	Username   string `json:"username,omitempty"`
+	HeaderName string `json:"header_name,omitempty"`
+	Value      string `json:"value"`
+}
+
 // ErrorResponse represents an error response from the API.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -62,6 +62,9 @@ const (
 	// WARNING: if this limit is ever reached, results will be silently truncated.
 	// Add pagination support if the dataset approaches this size.
 	indigenousSourcesLimit = 500
+
+	// sourcesPageSize is the page size ListSources requests (source-manager's maximum).
+	sourcesPageSize = 500
 )
 
 // NewHTTPClient creates a new HTTP client for source-manager.
@@ -114,38 +117,53 @@ func (c *HTTPClient) GetSource(ctx context.Context, sourceID uuid.UUID) (*Source
 	return &source, nil
 }
 
-// ListSources fetches all sources from source-manager.
+// ListSources fetches all sources from source-manager, a page of sourcesPageSize at a time.
 // Source-manager returns rate_limit as a string (e.g. "1s"); callers must parse it.
 func (c *HTTPClient) ListSources(ctx context.Context) ([]*SourceListItem, error) {
-	url := fmt.Sprintf("%s/api/v1/sources", c.baseURL)
+	all := make([]*SourceListItem, 0)
+	for page := 1; ; page++ {
+		pageSources, total, err := c.listSourcesPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, pageSources...)
+		if len(pageSources) < sourcesPageSize || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+// listSourcesPage fetches one page of sources and the total number of sources.
+func (c *HTTPClient) listSourcesPage(ctx context.Context, page int) ([]*SourceListItem, int, error) {
+	url := fmt.Sprintf("%s/api/v1/sources?limit=%d&page=%d", c.baseURL, sourcesPageSize, page)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, 0, fmt.Errorf("create request: %w", err)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("fetch sources: %w", err)
+		return nil, 0, fmt.Errorf("fetch sources: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		return nil, 0, fmt.Errorf("unexpected status: %s", resp.Status)
 	}
 
 	var payload struct {
 		Sources []*SourceListItem `json:"sources"`
-		Count   int               `json:"count"`
+		Total   int               `json:"total"`
 	}
 	if decodeErr := json.NewDecoder(resp.Body).Decode(&payload); decodeErr != nil {
-		return nil, fmt.Errorf("decode response: %w", decodeErr)
+		return nil, 0, fmt.Errorf("decode response: %w", decodeErr)
 	}
 
 	if payload.Sources == nil {
 		payload.Sources = []*SourceListItem{}
 	}
-	return payload.Sources, nil
+	return payload.Sources, payload.Total, nil
 }
 
 // ListIndigenousSources fetches all indigenous sources from the dedicated source-manager endpoint.
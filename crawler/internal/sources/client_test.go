@@ -175,3 +175,37 @@ func TestListIndigenousSources_TruncationWarning(t *testing.T) {
 		t.Errorf("expected %d sources, got %d", len(returned), len(got))
 	}
 }
+
+func TestListSources_FollowsPages(t *testing.T) {
+	t.Helper()
+
+	const total = 501
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Helper()
+		if r.URL.Query().Get("limit") != "500" {
+			t.Errorf("expected limit=500 in query, got: %s", r.URL.RawQuery)
+		}
+		count := 500
+		if r.URL.Query().Get("page") == "2" {
+			count = 1
+		}
+		page := make([]*sources.SourceListItem, 0, count)
+		for range count {
+			page = append(page, &sources.SourceListItem{ID: uuid.New(), Name: "S", Enabled: true})
+		}
+		payload := map[string]any{"sources": page, "total": total}
+		if encErr := json.NewEncoder(w).Encode(payload); encErr != nil {
+			t.Errorf("encode response: %v", encErr)
+		}
+	}))
+	defer srv.Close()
+
+	client := sources.NewHTTPClient(srv.URL, nil)
+	got, err := client.ListSources(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != total {
+		t.Errorf("expected %d sources, got %d", total, len(got))
+	}
+}
@@ -48,6 +48,21 @@ func (s *Sources) ValidateSourceByID(
 		return nil, fmt.Errorf("failed to convert source: %w", err)
 	}
 
-	// Convert to configtypes.Source
-	return types.ConvertToConfigSource(sourceConfig), nil
+	source := types.ConvertToConfigSource(sourceConfig)
+
+	// Resolve the credentials the source needs (basic auth, API key, login cookie)
+	creds, err := apiClient.GetCredentials(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source credentials: %w", err)
+	}
+	for _, cred := range creds {
+		source.Credentials = append(source.Credentials, configtypes.SourceCredential{
+			Kind:       cred.Kind,
+			Username:   cred.Username,
+			HeaderName: cred.HeaderName,
+			Value:      cred.Value,
+		})
+	}
+
+	return source, nil
 }
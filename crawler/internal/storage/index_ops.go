@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
@@ -144,3 +145,47 @@ func (s *Storage) ListIndices(ctx context.Context) ([]string, error) {
 	s.logger.Info("Retrieved index list")
 	return result, nil
 }
+
+// IndexStoreSizes returns the store size in bytes (primaries and replicas) of
+// every index matching pattern, keyed by index name.
+func (s *Storage) IndexStoreSizes(ctx context.Context, pattern string) (map[string]int64, error) {
+	res, err := s.client.Cat.Indices(
+		s.client.Cat.Indices.WithContext(ctx),
+		s.client.Cat.Indices.WithIndex(pattern),
+		s.client.Cat.Indices.WithH("index", "store.size"),
+		s.client.Cat.Indices.WithBytes("b"),
+		s.client.Cat.Indices.WithFormat("json"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list index sizes: %w", err)
+	}
+	defer func() {
+		if closeErr := res.Body.Close(); closeErr != nil {
+			s.logger.Error("Error closing response body", infralogger.Error(closeErr))
+		}
+	}()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("error listing index sizes: %s", res.String())
+	}
+
+	var rows []struct {
+		Index     string `json:"index"`
+		StoreSize string `json:"store.size"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&rows); decodeErr != nil {
+		return nil, fmt.Errorf("error decoding index sizes: %w", decodeErr)
+	}
+
+	sizes := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		// Closed or initializing indices report no size
+		size, parseErr := strconv.ParseInt(row.StoreSize, 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		sizes[row.Index] = size
+	}
+
+	return sizes, nil
+}
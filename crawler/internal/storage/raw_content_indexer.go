@@ -29,7 +29,8 @@ type RawContent struct {
 	OGDescription        string         `json:"og_description"` // Classifier needs this
 	OGImage              string         `json:"og_image,omitempty"`
 	Author               string         `json:"author,omitempty"`
-	PublishedDate        *time.Time     `json:"published_date"` // CRITICAL: Classifier needs this
+	Language             string         `json:"language,omitempty"` // primary subtag; picks the search analyzer
+	PublishedDate        *time.Time     `json:"published_date"`     // CRITICAL: Classifier needs this
 	CanonicalURL         string         `json:"canonical_url,omitempty"`
 	ArticleSection       string         `json:"article_section,omitempty"`
 	JSONLDData           map[string]any `json:"json_ld_data,omitempty"`
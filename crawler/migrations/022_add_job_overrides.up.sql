@@ -0,0 +1,3 @@
+-- Per-job environment overrides (proxy, user agent, render mode, TLS verify).
+-- NULL means the job uses the crawler's defaults.
+ALTER TABLE jobs ADD COLUMN overrides JSONB;
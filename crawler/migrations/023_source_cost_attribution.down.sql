@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS owner_quotas;
+DROP TABLE IF EXISTS source_owners;
+
+COMMIT;
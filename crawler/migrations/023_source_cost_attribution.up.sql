@@ -0,0 +1,33 @@
+-- Cost attribution: which owner (client) each source is billed to, and
+-- optional monthly quotas per owner. Usage itself is derived from
+-- job_executions and Elasticsearch index sizes, not stored.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS source_owners (
+    source_id   VARCHAR(255) PRIMARY KEY,
+    owner       VARCHAR(255) NOT NULL,
+    tags        TEXT[] NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_source_owners_owner ON source_owners (owner);
+
+-- NULL limits are unlimited.
+CREATE TABLE IF NOT EXISTS owner_quotas (
+    owner                    VARCHAR(255) PRIMARY KEY,
+    monthly_compute_seconds  BIGINT CHECK (monthly_compute_seconds >= 0),
+    monthly_bandwidth_bytes  BIGINT CHECK (monthly_bandwidth_bytes >= 0),
+    storage_bytes            BIGINT CHECK (storage_bytes >= 0),
+    created_at               TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at               TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_source_owners_updated_at BEFORE UPDATE ON source_owners
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_owner_quotas_updated_at BEFORE UPDATE ON owner_quotas
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMIT;
@@ -1,4 +1,23 @@
-import axios, { type AxiosInstance } from 'axios'
+import axios, { type AxiosError, type AxiosInstance, type InternalAxiosRequestConfig } from 'axios'
+
+export const TOKEN_KEY = 'dashboard_token'
+export const REFRESH_TOKEN_KEY = 'dashboard_refresh_token'
+
+export interface LoginResponse {
+  token: string
+  // Absent when the auth service has no session store; the token then lasts jwt_expiration
+  refresh_token?: string
+  expires_at: string
+}
+
+export interface InvitedUser {
+  email: string
+  role: 'admin' | 'viewer'
+  status: 'pending' | 'active'
+  invited_by: string
+  created_at: string
+  activated_at?: string
+}
 
 const authClient: AxiosInstance = axios.create({
   timeout: 10000,
@@ -12,16 +31,122 @@ export const authApi = {
    * Login with username and password
    * @param username - Username
    * @param password - Password
-   * @returns Promise with token
+   * @returns Promise with access token and refresh token
    */
   login: (username: string, password: string) => {
     // Call the auth service endpoint directly - nginx routes /api/v1/auth to auth service
-    return authClient.post('/api/v1/auth/login', {
+    return authClient.post<LoginResponse>('/api/v1/auth/login', {
       username,
       password,
     })
   },
+
+  /**
+   * Exchange a refresh token for a new access token; the refresh token rotates
+   * @param refreshToken - Current refresh token
+   * @returns Promise with access token and the next refresh token
+   */
+  refresh: (refreshToken: string) => {
+    return authClient.post<LoginResponse>('/api/v1/auth/refresh', {
+      refresh_token: refreshToken,
+    })
+  },
+
+  /**
+   * End the session behind a refresh token
+   * @param refreshToken - Current refresh token
+   */
+  logout: (refreshToken: string) => {
+    return authClient.post('/api/v1/auth/logout', {
+      refresh_token: refreshToken,
+    })
+  },
+
+  /**
+   * Set the password for an invited account; it activates on first login
+   * @param token - Invitation token from the invitation link
+   * @param password - Chosen password
+   * @returns Promise with the pending account
+   */
+  acceptInvitation: (token: string, password: string) => {
+    return authClient.post<InvitedUser>('/api/v1/auth/invitations/accept', {
+      token,
+      password,
+    })
+  },
 }
 
-export default authApi
+/**
+ * Store the tokens from a login or refresh response
+ */
+export const storeTokens = (data: LoginResponse): void => {
+  localStorage.setItem(TOKEN_KEY, data.token)
+  if (data.refresh_token) {
+    localStorage.setItem(REFRESH_TOKEN_KEY, data.refresh_token)
+  } else {
+    localStorage.removeItem(REFRESH_TOKEN_KEY)
+  }
+}
 
+/**
+ * Forget both tokens
+ */
+export const clearTokens = (): void => {
+  localStorage.removeItem(TOKEN_KEY)
+  localStorage.removeItem(REFRESH_TOKEN_KEY)
+}
+
+// A refresh token is single-use, so concurrent 401s share one refresh
+let refreshInFlight: Promise<string | null> | null = null
+
+/**
+ * Get a new access token with the stored refresh token
+ * @returns Promise with the new access token, or null if the session has ended
+ */
+export const refreshAccessToken = (): Promise<string | null> => {
+  const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY)
+  if (!refreshToken) {
+    return Promise.resolve(null)
+  }
+  if (!refreshInFlight) {
+    refreshInFlight = authApi
+      .refresh(refreshToken)
+      .then((response) => {
+        storeTokens(response.data)
+        return response.data.token
+      })
+      .catch(() => null)
+      .finally(() => {
+        refreshInFlight = null
+      })
+  }
+  return refreshInFlight
+}
+
+type RetriableRequestConfig = InternalAxiosRequestConfig & { _retriedAfterRefresh?: boolean }
+
+/**
+ * Response error handler for clients that send the access token: on a 401 it
+ * refreshes the access token and retries the request once, and otherwise
+ * calls onSessionEnded.
+ */
+export const retryAfterRefresh =
+  (client: AxiosInstance, onSessionEnded: () => void) =>
+  async (error: AxiosError): Promise<unknown> => {
+    const config = error.config as RetriableRequestConfig | undefined
+    if (error.response?.status !== 401 || !config) {
+      return Promise.reject(error)
+    }
+    if (!config._retriedAfterRefresh) {
+      config._retriedAfterRefresh = true
+      const token = await refreshAccessToken()
+      if (token) {
+        config.headers.Authorization = `Bearer ${token}`
+        return client.request(config)
+      }
+    }
+    onSessionEnded()
+    return Promise.reject(error)
+  }
+
+export default authApi
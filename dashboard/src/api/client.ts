@@ -15,6 +15,15 @@ import type {
   HealthStatus,
   ActiveChannelsResponse,
   RecentItemsResponse,
+  ChannelApproval,
+  ApprovalEdits,
+  ApprovalsListResponse,
+  ApprovalReviewRequest,
+  ApprovalStatus,
+  DeadLetter,
+  DeadLetterDetail,
+  DeadLetterStatus,
+  DeadLettersListResponse,
 } from '../types/publisher'
 import type {
   Index,
@@ -31,6 +40,7 @@ import type {
 } from '../types/indexManager'
 import type { ImportExcelResult } from '../types/source'
 import type { SyncReport } from '../types/crawler'
+import { TOKEN_KEY, clearTokens, retryAfterRefresh } from './auth'
 import type {
   SocialContent,
   SocialAccount,
@@ -60,12 +70,12 @@ const DEBUG = import.meta.env.DEV
 
 // Helper function to get token from localStorage
 const getToken = (): string | null => {
-  return localStorage.getItem('dashboard_token')
+  return localStorage.getItem(TOKEN_KEY)
 }
 
-// Helper function to handle 401 errors (redirect to login)
+// Helper function to handle a 401 that a refresh could not fix (redirect to login)
 const handleUnauthorized = (): void => {
-  localStorage.removeItem('dashboard_token')
+  clearTokens()
   // Only redirect if we're in a browser environment
   if (typeof window !== 'undefined') {
     window.location.href = '/dashboard/login'
@@ -137,16 +147,8 @@ const addAuthInterceptor = (client: AxiosInstance): void => {
     }
   )
 
-  // Response interceptor: Handle 401 errors
-  client.interceptors.response.use(
-    (response) => response,
-    (error) => {
-      if (error.response?.status === 401) {
-        handleUnauthorized()
-      }
-      return Promise.reject(error)
-    }
-  )
+  // Response interceptor: on 401, refresh the access token and retry once
+  client.interceptors.response.use((response) => response, retryAfterRefresh(client, handleUnauthorized))
 }
 
 // Add auth interceptors to all clients
@@ -372,6 +374,39 @@ export const publisherApi = {
       publisherClient.get(`/channels/${id}/preview`),
   },
 
+  // Editorial approval queue
+  approvals: {
+    list: (params?: {
+      status?: ApprovalStatus
+      channel_id?: string
+      limit?: number
+      offset?: number
+    }): Promise<AxiosResponse<ApprovalsListResponse>> => publisherClient.get('/approvals', { params }),
+    get: (id: string): Promise<AxiosResponse<ChannelApproval>> => publisherClient.get(`/approvals/${id}`),
+    edit: (id: string, edits: ApprovalEdits): Promise<AxiosResponse<ChannelApproval>> =>
+      publisherClient.patch(`/approvals/${id}`, edits),
+    approve: (id: string, data: ApprovalReviewRequest = {}): Promise<AxiosResponse<ChannelApproval>> =>
+      publisherClient.post(`/approvals/${id}/approve`, data),
+    reject: (id: string, note = ''): Promise<AxiosResponse<ChannelApproval>> =>
+      publisherClient.post(`/approvals/${id}/reject`, { note }),
+  },
+
+  // Dead-letter queue
+  deadLetters: {
+    list: (params?: {
+      status?: DeadLetterStatus
+      channel?: string
+      limit?: number
+      offset?: number
+    }): Promise<AxiosResponse<DeadLettersListResponse>> => publisherClient.get('/dead-letters', { params }),
+    get: (id: string): Promise<AxiosResponse<DeadLetterDetail>> => publisherClient.get(`/dead-letters/${id}`),
+    replay: (id: string): Promise<AxiosResponse<DeadLetter>> => publisherClient.post(`/dead-letters/${id}/replay`),
+    replayAll: (channel?: string): Promise<AxiosResponse<{ replayed: number }>> =>
+      publisherClient.post('/dead-letters/replay', channel ? { channel } : {}),
+    discard: (id: string): Promise<AxiosResponse<{ message: string }>> =>
+      publisherClient.delete(`/dead-letters/${id}`),
+  },
+
   // Publish History
   history: {
     list: (params?: {
@@ -1,4 +1,5 @@
 import axios, { type AxiosInstance } from 'axios'
+import { TOKEN_KEY, clearTokens, retryAfterRefresh } from './auth'
 
 export interface VerificationPerson {
   id: string
@@ -80,7 +81,7 @@ const verificationClient: AxiosInstance = axios.create({
 })
 
 verificationClient.interceptors.request.use((config) => {
-  const token = localStorage.getItem('dashboard_token')
+  const token = localStorage.getItem(TOKEN_KEY)
   if (token) {
     config.headers.Authorization = `Bearer ${token}`
   }
@@ -89,13 +90,10 @@ verificationClient.interceptors.request.use((config) => {
 
 verificationClient.interceptors.response.use(
   (response) => response,
-  (error) => {
-    if (error.response?.status === 401) {
-      localStorage.removeItem('dashboard_token')
-      window.location.href = '/dashboard/login'
-    }
-    return Promise.reject(error)
-  }
+  retryAfterRefresh(verificationClient, () => {
+    clearTokens()
+    window.location.href = '/dashboard/login'
+  })
 )
 
 export const verificationApi = {
@@ -1,8 +1,6 @@
 import { ref, computed } from 'vue'
 import { useRouter } from 'vue-router'
-import { authApi } from '../api/auth'
-
-const TOKEN_KEY = 'dashboard_token'
+import { authApi, clearTokens, storeTokens, REFRESH_TOKEN_KEY, TOKEN_KEY } from '../api/auth'
 
 // Reactive state
 const token = ref<string | null>(localStorage.getItem(TOKEN_KEY) || null)
@@ -23,11 +21,11 @@ export function useAuth() {
 
     try {
       const response = await authApi.login(username, password)
-      const { token: newToken } = response.data as { token?: string }
+      const data = response.data
 
-      if (newToken) {
-        token.value = newToken
-        localStorage.setItem(TOKEN_KEY, newToken)
+      if (data?.token) {
+        token.value = data.token
+        storeTokens(data)
         return { success: true }
       } else {
         error.value = 'No token received from server'
@@ -47,11 +45,16 @@ export function useAuth() {
   }
 
   /**
-   * Logout and clear token
+   * Logout, ending the server-side session, and clear tokens
    */
   const logout = (): void => {
+    const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY)
+    if (refreshToken) {
+      // Best effort: the session expires on its own if this fails
+      authApi.logout(refreshToken).catch(() => {})
+    }
     token.value = null
-    localStorage.removeItem(TOKEN_KEY)
+    clearTokens()
     router.push('/login')
   }
 
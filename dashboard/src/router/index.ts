@@ -3,6 +3,7 @@ import { createRouter, createWebHistory, type RouteRecordRaw } from 'vue-router'
 // Views
 import PipelineMonitorView from '../views/PipelineMonitorView.vue'
 import LoginView from '../views/LoginView.vue'
+import AcceptInviteView from '../views/AcceptInviteView.vue'
 import NotFoundView from '../views/NotFoundView.vue'
 
 // Operations views
@@ -55,6 +56,13 @@ const routes: RouteRecordRaw[] = [
     component: LoginView,
     meta: { title: 'Login', requiresAuth: false },
   },
+  // Invitation links (public; the token in the query authenticates)
+  {
+    path: '/accept-invite',
+    name: 'accept-invite',
+    component: AcceptInviteView,
+    meta: { title: 'Accept Invitation', requiresAuth: false },
+  },
 
   // ==========================================
   // Operations - daily cockpit
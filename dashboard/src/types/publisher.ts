@@ -24,6 +24,7 @@ export interface Channel {
   rules: ChannelRules
   rules_version: number
   enabled: boolean
+  requires_approval?: boolean
   created_at: string
   updated_at?: string
 }
@@ -35,6 +36,7 @@ export interface CreateChannelRequest {
   description?: string
   rules?: ChannelRules
   enabled?: boolean
+  requires_approval?: boolean
 }
 
 export interface UpdateChannelRequest {
@@ -44,6 +46,7 @@ export interface UpdateChannelRequest {
   description?: string
   rules?: ChannelRules
   enabled?: boolean
+  requires_approval?: boolean
 }
 
 export interface ChannelsListResponse {
@@ -123,6 +126,81 @@ export interface PublishHistoryListResponse {
   offset?: number
 }
 
+// ============================================================================
+// Editorial approval queue (channels with requires_approval)
+// ============================================================================
+
+export type ApprovalStatus = 'pending' | 'approved' | 'rejected' | 'published' | 'failed'
+
+export interface ApprovalEdits {
+  title?: string
+  body?: string
+  summary?: string
+}
+
+export interface ChannelApproval {
+  id: string // UUID
+  channel_id: string
+  content_id: string
+  title: string
+  url: string
+  source: string
+  quality_score: number
+  topics: string[]
+  edits: ApprovalEdits
+  status: ApprovalStatus
+  reviewed_by?: string
+  review_note?: string
+  reviewed_at?: string
+  created_at: string
+  updated_at: string
+}
+
+export interface ApprovalsListResponse {
+  approvals: ChannelApproval[]
+  count: number
+  total: number
+  limit: number
+  offset: number
+}
+
+export interface ApprovalReviewRequest {
+  note?: string
+  edits?: ApprovalEdits
+}
+
+// ============================================================================
+// Dead-letter queue (failed deliveries)
+// ============================================================================
+
+export type DeadLetterStatus = 'retrying' | 'dead'
+
+export interface DeadLetter {
+  id: string // UUID
+  channel_id?: string // Absent for built-in Redis channels
+  channel_name: string
+  content_id: string
+  title: string
+  last_error: string
+  attempts: number
+  status: DeadLetterStatus
+  next_retry_at?: string
+  created_at: string
+  updated_at: string
+}
+
+export interface DeadLetterDetail extends DeadLetter {
+  payload: Record<string, unknown>
+}
+
+export interface DeadLettersListResponse {
+  dead_letters: DeadLetter[]
+  count: number
+  total: number
+  limit: number
+  offset: number
+}
+
 // ============================================================================
 // Stats
 // ============================================================================
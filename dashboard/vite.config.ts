@@ -221,6 +221,12 @@ export default defineConfig({
         proxyTimeout: 10000,
         rewrite: () => '/health',
       },
+      // Click Tracker live click stream (SSE)
+      '/api/click-tracker/clicks/events': {
+        target: CLICK_TRACKER_API_URL,
+        changeOrigin: true,
+        rewrite: (path) => path.replace(/^\/api\/click-tracker/, '/api/v1'),
+      },
       // Click Tracker health endpoint
       '/api/health/click-tracker': {
         target: CLICK_TRACKER_API_URL,
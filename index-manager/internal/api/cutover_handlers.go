@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// WithCutoverService adds the alias blue/green cutover service.
+func (h *Handler) WithCutoverService(cutoverService *service.CutoverService) *Handler {
+	h.cutoverService = cutoverService
+	return h
+}
+
+// CreateCutover handles POST /api/v1/cutovers
+func (h *Handler) CreateCutover(c *gin.Context) {
+	var req domain.CutoverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.CreatedBy = requestActor(c)
+
+	resp, err := h.cutoverService.Cutover(c.Request.Context(), &req)
+	var verifyErr *service.CutoverVerificationError
+	if errors.As(err, &verifyErr) {
+		h.requestLogger(c).Warn("Cutover verification failed",
+			infralogger.String("alias", req.Alias),
+			infralogger.String("target_index", req.TargetIndex),
+		)
+		c.JSON(http.StatusConflict, gin.H{
+			"error":        verifyErr.Error(),
+			"verification": verifyErr.Verification,
+		})
+		return
+	}
+	if err != nil {
+		h.requestLogger(c).Error("Failed to cut over alias",
+			infralogger.String("alias", req.Alias),
+			infralogger.String("target_index", req.TargetIndex),
+			infralogger.Error(err),
+		)
+		c.JSON(cutoverErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if resp.DryRun {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListCutovers handles GET /api/v1/cutovers
+func (h *Handler) ListCutovers(c *gin.Context) {
+	cutovers, err := h.cutoverService.ListCutovers(c.Request.Context(), c.Query("alias"), queryInt(c, "limit"))
+	if err != nil {
+		h.requestLogger(c).Error("Failed to list cutovers", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cutovers": cutovers,
+		"count":    len(cutovers),
+	})
+}
+
+// GetCutover handles GET /api/v1/cutovers/:id
+func (h *Handler) GetCutover(c *gin.Context) {
+	id, ok := cutoverIDParam(c)
+	if !ok {
+		return
+	}
+
+	cutover, err := h.cutoverService.GetCutover(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(cutoverErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cutover)
+}
+
+// RollbackCutover handles POST /api/v1/cutovers/:id/rollback
+func (h *Handler) RollbackCutover(c *gin.Context) {
+	id, ok := cutoverIDParam(c)
+	if !ok {
+		return
+	}
+
+	cutover, err := h.cutoverService.Rollback(c.Request.Context(), id)
+	if err != nil {
+		h.requestLogger(c).Error("Failed to roll back cutover",
+			infralogger.Int64("cutover_id", id),
+			infralogger.Error(err),
+		)
+		c.JSON(cutoverErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cutover)
+}
+
+func cutoverIDParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be an integer"})
+		return 0, false
+	}
+	return id, true
+}
+
+// cutoverErrorStatus maps cutover errors to HTTP status codes.
+func cutoverErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCutoverInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, database.ErrIndexCutoverNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrCutoverNotActive):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
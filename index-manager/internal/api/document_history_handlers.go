@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// GetDocumentHistory handles GET /api/v1/indexes/:index_name/documents/:document_id/history
+func (h *Handler) GetDocumentHistory(c *gin.Context) {
+	indexName := c.Param("index_name")
+	documentID := c.Param("document_id")
+
+	history, err := h.documentService.GetDocumentHistory(c.Request.Context(), indexName, documentID, queryInt(c, "limit"))
+	if err != nil {
+		h.requestLogger(c).Error("Failed to get document history",
+			infralogger.String("index_name", indexName),
+			infralogger.String("document_id", documentID),
+			infralogger.Error(err),
+		)
+		c.JSON(revisionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// RestoreDocumentRevision handles
+// POST /api/v1/indexes/:index_name/documents/:document_id/history/:revision_id/restore
+func (h *Handler) RestoreDocumentRevision(c *gin.Context) {
+	indexName := c.Param("index_name")
+	documentID := c.Param("document_id")
+
+	revisionID, err := strconv.ParseInt(c.Param("revision_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "revision_id must be an integer"})
+		return
+	}
+
+	document, err := h.documentService.RestoreDocumentRevision(
+		c.Request.Context(), indexName, documentID, revisionID, requestActor(c),
+	)
+	if err != nil {
+		h.requestLogger(c).Error("Failed to restore document revision",
+			infralogger.String("index_name", indexName),
+			infralogger.String("document_id", documentID),
+			infralogger.Int64("revision_id", revisionID),
+			infralogger.Error(err),
+		)
+		c.JSON(revisionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.requestLogger(c).Info("Document revision restored",
+		infralogger.String("index_name", indexName),
+		infralogger.String("document_id", documentID),
+		infralogger.Int64("revision_id", revisionID),
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "document restored successfully",
+		"revision_id": revisionID,
+		"document":    document,
+	})
+}
+
+// revisionErrorStatus maps revision history errors to HTTP status codes.
+func revisionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, database.ErrDocumentRevisionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrRevisionHistoryDisabled):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// requestActor returns the JWT subject of the caller, or "" when unauthenticated.
+func requestActor(c *gin.Context) string {
+	if claims, ok := infrajwt.GetClaims(c); ok {
+		return claims.Sub
+	}
+	return ""
+}
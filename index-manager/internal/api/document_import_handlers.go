@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// maxImportBodyBytes bounds a single NDJSON import request.
+const maxImportBodyBytes = 100 << 20
+
+// WithDocumentImportService adds the bulk NDJSON document import service.
+func (h *Handler) WithDocumentImportService(importService *service.DocumentImportService) *Handler {
+	h.importService = importService
+	return h
+}
+
+// BulkImportDocuments handles POST /api/v1/indexes/:index_name/documents/bulk
+// The body is NDJSON, one document per line. ?id_field= names the document
+// field used as the ES _id; without it Elasticsearch generates IDs.
+func (h *Handler) BulkImportDocuments(c *gin.Context) {
+	indexName := c.Param("index_name")
+	idField := c.Query("id_field")
+
+	h.requestLogger(c).Info("Importing documents",
+		infralogger.String("index_name", indexName),
+		infralogger.String("id_field", idField),
+	)
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBodyBytes)
+	result, err := h.importService.ImportNDJSON(c.Request.Context(), indexName, body, idField)
+	if err != nil {
+		h.requestLogger(c).Error("Failed to import documents",
+			infralogger.String("index_name", indexName),
+			infralogger.Error(err),
+		)
+		var maxBytesErr *http.MaxBytesError
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrIndexNotFound):
+			statusCode = http.StatusNotFound
+		case errors.As(err, &maxBytesErr):
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		// Earlier batches may already be indexed; report them alongside the error.
+		c.JSON(statusCode, gin.H{"error": err.Error(), "result": result})
+		return
+	}
+
+	statusCode := http.StatusOK
+	if result.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+		if result.Indexed == 0 {
+			statusCode = http.StatusUnprocessableEntity
+		}
+	}
+
+	c.JSON(statusCode, result)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// WithDuplicateService adds the duplicate canonical_url service.
+func (h *Handler) WithDuplicateService(duplicateService *service.DuplicateService) *Handler {
+	h.duplicateService = duplicateService
+	return h
+}
+
+// GetDuplicates handles GET /api/v1/duplicates
+func (h *Handler) GetDuplicates(c *gin.Context) {
+	req := &domain.DuplicateReportRequest{
+		SourceName: c.Query("source_name"),
+		Limit:      queryInt(c, "limit"),
+	}
+
+	report, err := h.duplicateService.FindDuplicates(c.Request.Context(), req)
+	if err != nil {
+		h.requestLogger(c).Error("Failed to find duplicate documents", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// DedupeDuplicates handles POST /api/v1/duplicates/dedupe
+func (h *Handler) DedupeDuplicates(c *gin.Context) {
+	var req domain.DedupeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.requestLogger(c).Warn("Invalid dedupe request", infralogger.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.requestLogger(c).Info("Deduplicating canonical URLs",
+		infralogger.Bool("dry_run", req.DryRun),
+		infralogger.String("source_name", req.SourceName),
+		infralogger.Int("canonical_urls", len(req.CanonicalURLs)),
+	)
+
+	result, err := h.duplicateService.Dedupe(c.Request.Context(), &req)
+	if err != nil {
+		h.requestLogger(c).Error("Failed to dedupe documents", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	statusCode := http.StatusOK
+	if len(result.Errors) > 0 {
+		statusCode = http.StatusMultiStatus
+		if len(result.Deleted) == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+	}
+
+	c.JSON(statusCode, result)
+}
@@ -1,12 +1,10 @@
 package api
 
 import (
-	"context"
-	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
@@ -24,19 +22,21 @@ const (
 	defaultSortOrder = "asc"
 )
 
-// HealthChecker provides cluster health for dependency checks.
-type HealthChecker interface {
-	GetClusterHealth(ctx context.Context) (map[string]any, error)
-}
-
 // Handler handles HTTP requests for the index manager API
 type Handler struct {
 	indexService       *service.IndexService
 	documentService    *service.DocumentService
 	aggregationService *service.AggregationService
+	orphanService      *service.OrphanService
+	storageService     *service.StorageService
+	sourceAlertService *service.SourceAlertService
+	duplicateService   *service.DuplicateService
+	urlLookupService   *service.URLLookupService
+	importService      *service.DocumentImportService
+	cutoverService     *service.CutoverService
 	logger             infralogger.Logger
 	esHealth           HealthChecker
-	db                 *sql.DB
+	db                 DBPinger
 }
 
 // NewHandler creates a new API handler
@@ -54,88 +54,40 @@ func NewHandler(
 	}
 }
 
-// WithHealthDeps adds ES and DB health check dependencies.
-func (h *Handler) WithHealthDeps(esHealth HealthChecker, db *sql.DB) *Handler {
-	h.esHealth = esHealth
-	h.db = db
-	return h
-}
-
-const healthCheckTimeout = 5 * time.Second
-
-// HealthCheck handles GET /health
-func (h *Handler) HealthCheck(c *gin.Context) {
-	health := &domain.HealthStatus{
-		Status:    "healthy",
-		Version:   "1.0.0",
-		Checks:    make(map[string]string),
-		Timestamp: "",
-	}
-
-	httpStatus := http.StatusOK
-
-	health.Checks["elasticsearch"] = h.checkElasticsearch(c.Request.Context())
-	health.Checks["database"] = h.checkDatabase(c.Request.Context())
-
-	for _, status := range health.Checks {
-		if status != "ok" {
-			health.Status = "degraded"
-			httpStatus = http.StatusServiceUnavailable
-			break
-		}
-	}
-
-	c.JSON(httpStatus, health)
-}
-
-func (h *Handler) checkElasticsearch(parent context.Context) string {
-	if h.esHealth == nil {
-		return "ok"
-	}
-	ctx, cancel := context.WithTimeout(parent, healthCheckTimeout)
-	defer cancel()
-	healthData, err := h.esHealth.GetClusterHealth(ctx)
-	if err != nil {
-		h.logger.Warn("ES health check failed", infralogger.Error(err))
-		return fmt.Sprintf("error: %v", err)
-	}
-	if status, ok := healthData["status"].(string); ok && status == "red" {
-		return "cluster red"
-	}
-	return "ok"
-}
-
-func (h *Handler) checkDatabase(parent context.Context) string {
-	if h.db == nil {
-		return "ok"
-	}
-	ctx, cancel := context.WithTimeout(parent, healthCheckTimeout)
-	defer cancel()
-	if err := h.db.PingContext(ctx); err != nil {
-		h.logger.Warn("DB health check failed", infralogger.Error(err))
-		return fmt.Sprintf("error: %v", err)
-	}
-	return "ok"
+// requestLogger returns the request-scoped logger set by the infrastructure
+// request ID middleware, so handler logs carry request_id. It falls back to
+// the handler logger outside a request (e.g. in tests).
+func (h *Handler) requestLogger(c *gin.Context) infralogger.Logger {
+	return infralogger.FromContextOr(c.Request.Context(), h.logger)
 }
 
 // CreateIndex handles POST /api/v1/indexes
 func (h *Handler) CreateIndex(c *gin.Context) {
 	var req domain.CreateIndexRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("Invalid create index request", infralogger.Error(err))
+		h.requestLogger(c).Warn("Invalid create index request", infralogger.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.logger.Info("Creating index",
+	h.requestLogger(c).Info("Creating index",
 		infralogger.String("index_name", req.IndexName),
 		infralogger.String("index_type", string(req.IndexType)),
 		infralogger.String("source_name", req.SourceName),
 	)
 
 	index, err := h.indexService.CreateIndex(c.Request.Context(), &req)
+	var lintErr *service.MappingLintError
+	if errors.As(err, &lintErr) {
+		h.requestLogger(c).Warn("Rejected index mapping",
+			infralogger.String("index_name", req.IndexName),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "issues": lintErr.Issues})
+		return
+	}
 	if err != nil {
-		h.logger.Error("Failed to create index",
+		h.requestLogger(c).Error("Failed to create index",
 			infralogger.String("index_name", req.IndexName),
 			infralogger.Error(err),
 		)
@@ -143,7 +95,7 @@ func (h *Handler) CreateIndex(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Index created successfully",
+	h.requestLogger(c).Info("Index created successfully",
 		infralogger.String("index_name", index.Name),
 		infralogger.String("index_type", string(index.Type)),
 	)
@@ -151,6 +103,21 @@ func (h *Handler) CreateIndex(c *gin.Context) {
 	c.JSON(http.StatusCreated, index)
 }
 
+// LintIndexMapping handles POST /api/v1/indexes/lint
+func (h *Handler) LintIndexMapping(c *gin.Context) {
+	var req domain.LintMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	issues := service.LintMapping(req.Mapping)
+	c.JSON(http.StatusOK, domain.LintMappingResponse{
+		Valid:  !service.HasMappingErrors(issues),
+		Issues: issues,
+	})
+}
+
 // ListIndices handles GET /api/v1/indexes with pagination, filtering, and sorting
 func (h *Handler) ListIndices(c *gin.Context) {
 	// Parse filters
@@ -191,7 +158,7 @@ func (h *Handler) ListIndices(c *gin.Context) {
 		req.SortOrder = defaultSortOrder
 	}
 
-	h.logger.Debug("Listing indices",
+	h.requestLogger(c).Debug("Listing indices",
 		infralogger.String("type", req.Type),
 		infralogger.String("source", req.SourceName),
 		infralogger.String("search", req.Search),
@@ -204,7 +171,7 @@ func (h *Handler) ListIndices(c *gin.Context) {
 
 	response, err := h.indexService.ListIndices(c.Request.Context(), req)
 	if err != nil {
-		h.logger.Error("Failed to list indices", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to list indices", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -216,11 +183,11 @@ func (h *Handler) ListIndices(c *gin.Context) {
 func (h *Handler) GetIndex(c *gin.Context) {
 	indexName := c.Param("index_name")
 
-	h.logger.Debug("Getting index", infralogger.String("index_name", indexName))
+	h.requestLogger(c).Debug("Getting index", infralogger.String("index_name", indexName))
 
 	index, err := h.indexService.GetIndex(c.Request.Context(), indexName)
 	if err != nil {
-		h.logger.Error("Failed to get index",
+		h.requestLogger(c).Error("Failed to get index",
 			infralogger.String("index_name", indexName),
 			infralogger.Error(err),
 		)
@@ -235,10 +202,10 @@ func (h *Handler) GetIndex(c *gin.Context) {
 func (h *Handler) DeleteIndex(c *gin.Context) {
 	indexName := c.Param("index_name")
 
-	h.logger.Info("Deleting index", infralogger.String("index_name", indexName))
+	h.requestLogger(c).Info("Deleting index", infralogger.String("index_name", indexName))
 
 	if err := h.indexService.DeleteIndex(c.Request.Context(), indexName); err != nil {
-		h.logger.Error("Failed to delete index",
+		h.requestLogger(c).Error("Failed to delete index",
 			infralogger.String("index_name", indexName),
 			infralogger.Error(err),
 		)
@@ -246,7 +213,7 @@ func (h *Handler) DeleteIndex(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Index deleted successfully", infralogger.String("index_name", indexName))
+	h.requestLogger(c).Info("Index deleted successfully", infralogger.String("index_name", indexName))
 	c.JSON(http.StatusOK, gin.H{"message": "index deleted successfully"})
 }
 
@@ -256,7 +223,7 @@ func (h *Handler) GetIndexHealth(c *gin.Context) {
 
 	health, err := h.indexService.GetIndexHealth(c.Request.Context(), indexName)
 	if err != nil {
-		h.logger.Error("Failed to get index health",
+		h.requestLogger(c).Error("Failed to get index health",
 			infralogger.String("index_name", indexName),
 			infralogger.Error(err),
 		)
@@ -274,11 +241,11 @@ func (h *Handler) GetIndexHealth(c *gin.Context) {
 func (h *Handler) MigrateIndex(c *gin.Context) {
 	indexName := c.Param("index_name")
 
-	h.logger.Info("Migrating index", infralogger.String("index_name", indexName))
+	h.requestLogger(c).Info("Migrating index", infralogger.String("index_name", indexName))
 
 	result, err := h.indexService.MigrateIndex(c.Request.Context(), indexName)
 	if err != nil {
-		h.logger.Error("Failed to migrate index",
+		h.requestLogger(c).Error("Failed to migrate index",
 			infralogger.String("index_name", indexName),
 			infralogger.Error(err),
 		)
@@ -297,18 +264,18 @@ func (h *Handler) CreateIndexesForSource(c *gin.Context) {
 		IndexTypes []domain.IndexType `json:"index_types,omitempty"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil && err.Error() != "EOF" {
-		h.logger.Warn("Invalid request body", infralogger.Error(err))
+		h.requestLogger(c).Warn("Invalid request body", infralogger.Error(err))
 		// Continue with default index types
 	}
 
-	h.logger.Info("Creating indexes for source",
+	h.requestLogger(c).Info("Creating indexes for source",
 		infralogger.String("source_name", sourceName),
 		infralogger.Any("index_types", req.IndexTypes),
 	)
 
 	indices, err := h.indexService.CreateIndexesForSource(c.Request.Context(), sourceName, req.IndexTypes)
 	if err != nil {
-		h.logger.Error("Failed to create indexes for source",
+		h.requestLogger(c).Error("Failed to create indexes for source",
 			infralogger.String("source_name", sourceName),
 			infralogger.Error(err),
 		)
@@ -316,7 +283,7 @@ func (h *Handler) CreateIndexesForSource(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Indexes created for source",
+	h.requestLogger(c).Info("Indexes created for source",
 		infralogger.String("source_name", sourceName),
 		infralogger.Int("count", len(indices)),
 	)
@@ -332,7 +299,7 @@ func (h *Handler) CreateIndexesForSource(c *gin.Context) {
 func (h *Handler) ListIndexesForSource(c *gin.Context) {
 	sourceName := c.Param("source_name")
 
-	h.logger.Debug("Listing indexes for source", infralogger.String("source_name", sourceName))
+	h.requestLogger(c).Debug("Listing indexes for source", infralogger.String("source_name", sourceName))
 
 	// Use a high limit to return all indexes for a source
 	const allIndexesLimit = 1000
@@ -344,7 +311,7 @@ func (h *Handler) ListIndexesForSource(c *gin.Context) {
 		SortOrder:  "asc",
 	})
 	if err != nil {
-		h.logger.Error("Failed to list indexes for source",
+		h.requestLogger(c).Error("Failed to list indexes for source",
 			infralogger.String("source_name", sourceName),
 			infralogger.Error(err),
 		)
@@ -363,10 +330,10 @@ func (h *Handler) ListIndexesForSource(c *gin.Context) {
 func (h *Handler) DeleteIndexesForSource(c *gin.Context) {
 	sourceName := c.Param("source_name")
 
-	h.logger.Info("Deleting indexes for source", infralogger.String("source_name", sourceName))
+	h.requestLogger(c).Info("Deleting indexes for source", infralogger.String("source_name", sourceName))
 
 	if err := h.indexService.DeleteIndexesForSource(c.Request.Context(), sourceName); err != nil {
-		h.logger.Error("Failed to delete indexes for source",
+		h.requestLogger(c).Error("Failed to delete indexes for source",
 			infralogger.String("source_name", sourceName),
 			infralogger.Error(err),
 		)
@@ -374,7 +341,7 @@ func (h *Handler) DeleteIndexesForSource(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Indexes deleted for source", infralogger.String("source_name", sourceName))
+	h.requestLogger(c).Info("Indexes deleted for source", infralogger.String("source_name", sourceName))
 	c.JSON(http.StatusOK, gin.H{"message": "indexes deleted successfully"})
 }
 
@@ -382,24 +349,24 @@ func (h *Handler) DeleteIndexesForSource(c *gin.Context) {
 func (h *Handler) BulkCreateIndexes(c *gin.Context) {
 	var req domain.BulkCreateIndexRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("Invalid bulk create request", infralogger.Error(err))
+		h.requestLogger(c).Warn("Invalid bulk create request", infralogger.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.logger.Info("Bulk creating indexes", infralogger.Int("count", len(req.Indexes)))
+	h.requestLogger(c).Info("Bulk creating indexes", infralogger.Int("count", len(req.Indexes)))
 
 	results := make([]*domain.Index, 0, len(req.Indexes))
-	errors := make([]string, 0, len(req.Indexes))
+	errs := make([]string, 0, len(req.Indexes))
 
 	for _, indexReq := range req.Indexes {
 		index, err := h.indexService.CreateIndex(c.Request.Context(), &indexReq)
 		if err != nil {
-			h.logger.Warn("Failed to create index in bulk",
+			h.requestLogger(c).Warn("Failed to create index in bulk",
 				infralogger.String("index_name", indexReq.IndexName),
 				infralogger.Error(err),
 			)
-			errors = append(errors, err.Error())
+			errs = append(errs, err.Error())
 			continue
 		}
 		results = append(results, index)
@@ -410,15 +377,15 @@ func (h *Handler) BulkCreateIndexes(c *gin.Context) {
 		"count":   len(results),
 		"total":   len(req.Indexes),
 	}
-	if len(errors) > 0 {
-		response["errors"] = errors
-		response["failed"] = len(errors)
+	if len(errs) > 0 {
+		response["errors"] = errs
+		response["failed"] = len(errs)
 	}
 
 	statusCode := http.StatusCreated
-	if len(errors) == len(req.Indexes) {
+	if len(errs) == len(req.Indexes) {
 		statusCode = http.StatusInternalServerError
-	} else if len(errors) > 0 {
+	} else if len(errs) > 0 {
 		statusCode = http.StatusMultiStatus
 	}
 
@@ -429,23 +396,23 @@ func (h *Handler) BulkCreateIndexes(c *gin.Context) {
 func (h *Handler) BulkDeleteIndexes(c *gin.Context) {
 	var req domain.BulkDeleteIndexRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("Invalid bulk delete request", infralogger.Error(err))
+		h.requestLogger(c).Warn("Invalid bulk delete request", infralogger.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.logger.Info("Bulk deleting indexes", infralogger.Int("count", len(req.IndexNames)))
+	h.requestLogger(c).Info("Bulk deleting indexes", infralogger.Int("count", len(req.IndexNames)))
 
 	deleted := make([]string, 0, len(req.IndexNames))
-	errors := make([]string, 0, len(req.IndexNames))
+	errs := make([]string, 0, len(req.IndexNames))
 
 	for _, indexName := range req.IndexNames {
 		if err := h.indexService.DeleteIndex(c.Request.Context(), indexName); err != nil {
-			h.logger.Warn("Failed to delete index in bulk",
+			h.requestLogger(c).Warn("Failed to delete index in bulk",
 				infralogger.String("index_name", indexName),
 				infralogger.Error(err),
 			)
-			errors = append(errors, err.Error())
+			errs = append(errs, err.Error())
 			continue
 		}
 		deleted = append(deleted, indexName)
@@ -456,15 +423,15 @@ func (h *Handler) BulkDeleteIndexes(c *gin.Context) {
 		"count":   len(deleted),
 		"total":   len(req.IndexNames),
 	}
-	if len(errors) > 0 {
-		response["errors"] = errors
-		response["failed"] = len(errors)
+	if len(errs) > 0 {
+		response["errors"] = errs
+		response["failed"] = len(errs)
 	}
 
 	statusCode := http.StatusOK
-	if len(errors) == len(req.IndexNames) {
+	if len(errs) == len(req.IndexNames) {
 		statusCode = http.StatusInternalServerError
-	} else if len(errors) > 0 {
+	} else if len(errs) > 0 {
 		statusCode = http.StatusMultiStatus
 	}
 
@@ -475,7 +442,7 @@ func (h *Handler) BulkDeleteIndexes(c *gin.Context) {
 func (h *Handler) GetStats(c *gin.Context) {
 	stats, err := h.indexService.GetStats(c.Request.Context())
 	if err != nil {
-		h.logger.Error("Failed to get stats", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get stats", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -491,7 +458,7 @@ func (h *Handler) QueryDocuments(c *gin.Context) {
 	if bindErr := c.ShouldBindQuery(&req); bindErr != nil {
 		// Try to bind from JSON body if query params fail
 		if jsonErr := c.ShouldBindJSON(&req); jsonErr != nil {
-			h.logger.Warn("Invalid query documents request", infralogger.Error(jsonErr))
+			h.requestLogger(c).Warn("Invalid query documents request", infralogger.Error(jsonErr))
 			c.JSON(http.StatusBadRequest, gin.H{"error": jsonErr.Error()})
 			return
 		}
@@ -529,7 +496,7 @@ func (h *Handler) QueryDocuments(c *gin.Context) {
 
 	response, err := h.documentService.QueryDocuments(c.Request.Context(), indexName, &req)
 	if err != nil {
-		h.logger.Error("Failed to query documents",
+		h.requestLogger(c).Error("Failed to query documents",
 			infralogger.String("index_name", indexName),
 			infralogger.Error(err),
 		)
@@ -545,14 +512,14 @@ func (h *Handler) GetDocument(c *gin.Context) {
 	indexName := c.Param("index_name")
 	documentID := c.Param("document_id")
 
-	h.logger.Debug("Getting document",
+	h.requestLogger(c).Debug("Getting document",
 		infralogger.String("index_name", indexName),
 		infralogger.String("document_id", documentID),
 	)
 
 	document, err := h.documentService.GetDocument(c.Request.Context(), indexName, documentID)
 	if err != nil {
-		h.logger.Error("Failed to get document",
+		h.requestLogger(c).Error("Failed to get document",
 			infralogger.String("index_name", indexName),
 			infralogger.String("document_id", documentID),
 			infralogger.Error(err),
@@ -575,7 +542,7 @@ func (h *Handler) UpdateDocument(c *gin.Context) {
 
 	var doc domain.Document
 	if err := c.ShouldBindJSON(&doc); err != nil {
-		h.logger.Warn("Invalid update document request", infralogger.Error(err))
+		h.requestLogger(c).Warn("Invalid update document request", infralogger.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -583,13 +550,13 @@ func (h *Handler) UpdateDocument(c *gin.Context) {
 	// Set the document ID from URL parameter
 	doc.ID = documentID
 
-	h.logger.Info("Updating document",
+	h.requestLogger(c).Info("Updating document",
 		infralogger.String("index_name", indexName),
 		infralogger.String("document_id", documentID),
 	)
 
-	if err := h.documentService.UpdateDocument(c.Request.Context(), indexName, documentID, &doc); err != nil {
-		h.logger.Error("Failed to update document",
+	if err := h.documentService.UpdateDocument(c.Request.Context(), indexName, documentID, &doc, requestActor(c)); err != nil {
+		h.requestLogger(c).Error("Failed to update document",
 			infralogger.String("index_name", indexName),
 			infralogger.String("document_id", documentID),
 			infralogger.Error(err),
@@ -602,7 +569,7 @@ func (h *Handler) UpdateDocument(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Document updated successfully",
+	h.requestLogger(c).Info("Document updated successfully",
 		infralogger.String("index_name", indexName),
 		infralogger.String("document_id", documentID),
 	)
@@ -615,13 +582,13 @@ func (h *Handler) DeleteDocument(c *gin.Context) {
 	indexName := c.Param("index_name")
 	documentID := c.Param("document_id")
 
-	h.logger.Info("Deleting document",
+	h.requestLogger(c).Info("Deleting document",
 		infralogger.String("index_name", indexName),
 		infralogger.String("document_id", documentID),
 	)
 
 	if err := h.documentService.DeleteDocument(c.Request.Context(), indexName, documentID); err != nil {
-		h.logger.Error("Failed to delete document",
+		h.requestLogger(c).Error("Failed to delete document",
 			infralogger.String("index_name", indexName),
 			infralogger.String("document_id", documentID),
 			infralogger.Error(err),
@@ -634,7 +601,7 @@ func (h *Handler) DeleteDocument(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Document deleted successfully",
+	h.requestLogger(c).Info("Document deleted successfully",
 		infralogger.String("index_name", indexName),
 		infralogger.String("document_id", documentID),
 	)
@@ -648,18 +615,18 @@ func (h *Handler) BulkDeleteDocuments(c *gin.Context) {
 
 	var req domain.BulkDeleteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("Invalid bulk delete request", infralogger.Error(err))
+		h.requestLogger(c).Warn("Invalid bulk delete request", infralogger.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.logger.Info("Bulk deleting documents",
+	h.requestLogger(c).Info("Bulk deleting documents",
 		infralogger.String("index_name", indexName),
 		infralogger.Int("count", len(req.DocumentIDs)),
 	)
 
 	if err := h.documentService.BulkDeleteDocuments(c.Request.Context(), indexName, req.DocumentIDs); err != nil {
-		h.logger.Error("Failed to bulk delete documents",
+		h.requestLogger(c).Error("Failed to bulk delete documents",
 			infralogger.String("index_name", indexName),
 			infralogger.Error(err),
 		)
@@ -667,7 +634,7 @@ func (h *Handler) BulkDeleteDocuments(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Documents bulk deleted successfully",
+	h.requestLogger(c).Info("Documents bulk deleted successfully",
 		infralogger.String("index_name", indexName),
 		infralogger.Int("count", len(req.DocumentIDs)),
 	)
@@ -684,7 +651,7 @@ func (h *Handler) GetCrimeAggregation(c *gin.Context) {
 
 	result, err := h.aggregationService.GetCrimeAggregation(c.Request.Context(), req)
 	if err != nil {
-		h.logger.Error("Failed to get crime aggregation", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get crime aggregation", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -698,7 +665,7 @@ func (h *Handler) GetLocationAggregation(c *gin.Context) {
 
 	result, err := h.aggregationService.GetLocationAggregation(c.Request.Context(), req)
 	if err != nil {
-		h.logger.Error("Failed to get location aggregation", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get location aggregation", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -712,7 +679,7 @@ func (h *Handler) GetOverviewAggregation(c *gin.Context) {
 
 	result, err := h.aggregationService.GetOverviewAggregation(c.Request.Context(), req)
 	if err != nil {
-		h.logger.Error("Failed to get overview aggregation", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get overview aggregation", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -726,7 +693,7 @@ func (h *Handler) GetMiningAggregation(c *gin.Context) {
 
 	result, err := h.aggregationService.GetMiningAggregation(c.Request.Context(), req)
 	if err != nil {
-		h.logger.Error("Failed to get mining aggregation", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get mining aggregation", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -738,7 +705,7 @@ func (h *Handler) GetMiningAggregation(c *gin.Context) {
 func (h *Handler) GetSourceHealth(c *gin.Context) {
 	result, err := h.aggregationService.GetSourceHealth(c.Request.Context())
 	if err != nil {
-		h.logger.Error("Failed to get source health", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get source health", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -761,7 +728,7 @@ func (h *Handler) GetClassificationDrift(c *gin.Context) {
 	}
 	result, err := h.aggregationService.GetClassificationDriftAggregation(c.Request.Context(), req)
 	if err != nil {
-		h.logger.Error("Failed to get classification drift aggregation", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get classification drift aggregation", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -778,7 +745,7 @@ func (h *Handler) GetClassificationDriftTimeseries(c *gin.Context) {
 	}
 	result, err := h.aggregationService.GetClassificationDriftTimeseries(c.Request.Context(), days)
 	if err != nil {
-		h.logger.Error("Failed to get classification drift timeseries", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get classification drift timeseries", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -795,7 +762,7 @@ func (h *Handler) GetContentTypeMismatch(c *gin.Context) {
 	}
 	result, err := h.aggregationService.GetContentTypeMismatchCount(c.Request.Context(), hours)
 	if err != nil {
-		h.logger.Error("Failed to get content type mismatch count", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get content type mismatch count", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -812,7 +779,7 @@ func (h *Handler) GetSuspectedMisclassifications(c *gin.Context) {
 	}
 	result, err := h.aggregationService.GetSuspectedMisclassifications(c.Request.Context(), hours)
 	if err != nil {
-		h.logger.Error("Failed to get suspected misclassifications", infralogger.Error(err))
+		h.requestLogger(c).Error("Failed to get suspected misclassifications", infralogger.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
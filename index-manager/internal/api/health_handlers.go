@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+// ES cluster health colors.
+const (
+	clusterGreen  = "green"
+	clusterYellow = "yellow"
+	clusterRed    = "red"
+)
+
+// HealthChecker provides cluster health for dependency checks.
+type HealthChecker interface {
+	GetClusterHealth(ctx context.Context) (map[string]any, error)
+}
+
+// DBPinger checks database connectivity; *sql.DB satisfies it.
+type DBPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// WithHealthDeps adds ES and DB health check dependencies.
+func (h *Handler) WithHealthDeps(esHealth HealthChecker, db DBPinger) *Handler {
+	h.esHealth = esHealth
+	h.db = db
+	return h
+}
+
+// ElasticsearchCheck reports cluster health: green is healthy, yellow is
+// degraded, and red or an unreachable cluster is unhealthy.
+func (h *Handler) ElasticsearchCheck(ctx context.Context) infragin.CheckResult {
+	if h.esHealth == nil {
+		return infragin.CheckResult{Status: infragin.HealthStatusUnhealthy, Message: "elasticsearch client not configured"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	healthData, err := h.esHealth.GetClusterHealth(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		h.logger.Warn("ES health check failed", infralogger.Error(err))
+		return infragin.CheckResult{
+			Status:  infragin.HealthStatusUnhealthy,
+			Message: err.Error(),
+			Latency: latency.String(),
+		}
+	}
+
+	color, _ := healthData["status"].(string)
+	return infragin.CheckResult{
+		Status:  clusterColorStatus(color),
+		Message: "cluster " + color,
+		Latency: latency.String(),
+	}
+}
+
+// DatabaseCheck pings Postgres and reports the round-trip latency.
+func (h *Handler) DatabaseCheck(ctx context.Context) infragin.CheckResult {
+	if h.db == nil {
+		return infragin.CheckResult{Status: infragin.HealthStatusUnhealthy, Message: "database not configured"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.db.PingContext(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		h.logger.Warn("DB health check failed", infralogger.Error(err))
+		return infragin.CheckResult{
+			Status:  infragin.HealthStatusUnhealthy,
+			Message: err.Error(),
+			Latency: latency.String(),
+		}
+	}
+
+	return infragin.CheckResult{Status: infragin.HealthStatusHealthy, Latency: latency.String()}
+}
+
+// ReadinessCheck handles GET /ready for orchestration probes. The service is
+// ready when no dependency is unhealthy; a yellow cluster still serves traffic.
+func (h *Handler) ReadinessCheck(c *gin.Context) {
+	ctx := c.Request.Context()
+	checks := map[string]infragin.CheckResult{
+		"elasticsearch": h.ElasticsearchCheck(ctx),
+		"database":      h.DatabaseCheck(ctx),
+	}
+
+	if err := readinessError(checks); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "not_ready",
+			"error":  err.Error(),
+			"checks": checks,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ready", "checks": checks})
+}
+
+// clusterColorStatus maps an ES cluster color to a health status.
+func clusterColorStatus(color string) infragin.HealthStatus {
+	switch color {
+	case clusterGreen:
+		return infragin.HealthStatusHealthy
+	case clusterYellow:
+		return infragin.HealthStatusDegraded
+	case clusterRed:
+		return infragin.HealthStatusUnhealthy
+	default:
+		return infragin.HealthStatusUnhealthy
+	}
+}
+
+func readinessError(checks map[string]infragin.CheckResult) error {
+	for name, check := range checks {
+		if check.Status == infragin.HealthStatusUnhealthy {
+			return errors.New(name + " is unhealthy")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+//nolint:testpackage // Testing unexported helpers requires same package access
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+type fakeClusterHealth struct {
+	color string
+	err   error
+}
+
+func (f fakeClusterHealth) GetClusterHealth(ctx context.Context) (map[string]any, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errors.New("health check called without a deadline")
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return map[string]any{"status": f.color}, nil
+}
+
+type fakeDB struct {
+	err error
+}
+
+func (f fakeDB) PingContext(_ context.Context) error {
+	return f.err
+}
+
+func TestClusterColorStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		color string
+		want  infragin.HealthStatus
+	}{
+		{clusterGreen, infragin.HealthStatusHealthy},
+		{clusterYellow, infragin.HealthStatusDegraded},
+		{clusterRed, infragin.HealthStatusUnhealthy},
+		{"", infragin.HealthStatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		if got := clusterColorStatus(tt.color); got != tt.want {
+			t.Errorf("clusterColorStatus(%q) = %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestReadinessError(t *testing.T) {
+	t.Parallel()
+
+	healthy := infragin.CheckResult{Status: infragin.HealthStatusHealthy}
+	degraded := infragin.CheckResult{Status: infragin.HealthStatusDegraded}
+	unhealthy := infragin.CheckResult{Status: infragin.HealthStatusUnhealthy}
+
+	tests := []struct {
+		name    string
+		checks  map[string]infragin.CheckResult
+		wantErr string
+	}{
+		{"all healthy", map[string]infragin.CheckResult{"elasticsearch": healthy, "database": healthy}, ""},
+		{"degraded is ready", map[string]infragin.CheckResult{"elasticsearch": degraded, "database": healthy}, ""},
+		{"es unhealthy", map[string]infragin.CheckResult{"elasticsearch": unhealthy, "database": healthy}, "elasticsearch is unhealthy"},
+		{"db unhealthy", map[string]infragin.CheckResult{"elasticsearch": healthy, "database": unhealthy}, "database is unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := readinessError(tt.checks)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadinessCheck(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		es         fakeClusterHealth
+		db         fakeDB
+		wantCode   int
+		wantStatus string
+		wantES     infragin.HealthStatus
+		wantDB     infragin.HealthStatus
+	}{
+		{
+			name:       "green",
+			es:         fakeClusterHealth{color: clusterGreen},
+			wantCode:   http.StatusOK,
+			wantStatus: "ready",
+			wantES:     infragin.HealthStatusHealthy,
+			wantDB:     infragin.HealthStatusHealthy,
+		},
+		{
+			name:       "yellow",
+			es:         fakeClusterHealth{color: clusterYellow},
+			wantCode:   http.StatusOK,
+			wantStatus: "ready",
+			wantES:     infragin.HealthStatusDegraded,
+			wantDB:     infragin.HealthStatusHealthy,
+		},
+		{
+			name:       "red",
+			es:         fakeClusterHealth{color: clusterRed},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "not_ready",
+			wantES:     infragin.HealthStatusUnhealthy,
+			wantDB:     infragin.HealthStatusHealthy,
+		},
+		{
+			name:       "es unreachable",
+			es:         fakeClusterHealth{err: errors.New("connection refused")},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "not_ready",
+			wantES:     infragin.HealthStatusUnhealthy,
+			wantDB:     infragin.HealthStatusHealthy,
+		},
+		{
+			name:       "db down",
+			es:         fakeClusterHealth{color: clusterGreen},
+			db:         fakeDB{err: errors.New("connection refused")},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "not_ready",
+			wantES:     infragin.HealthStatusHealthy,
+			wantDB:     infragin.HealthStatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := (&Handler{logger: infralogger.NewNop()}).WithHealthDeps(tt.es, tt.db)
+			router := gin.New()
+			router.GET("/ready", h.ReadinessCheck)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/ready", http.NoBody)
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status code = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var body struct {
+				Status string                          `json:"status"`
+				Checks map[string]infragin.CheckResult `json:"checks"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", body.Status, tt.wantStatus)
+			}
+			if got := body.Checks["elasticsearch"].Status; got != tt.wantES {
+				t.Errorf("elasticsearch status = %q, want %q", got, tt.wantES)
+			}
+			if got := body.Checks["database"].Status; got != tt.wantDB {
+				t.Errorf("database status = %q, want %q", got, tt.wantDB)
+			}
+		})
+	}
+}
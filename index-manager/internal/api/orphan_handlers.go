@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// WithOrphanService adds the orphan index reconciliation service.
+func (h *Handler) WithOrphanService(orphanService *service.OrphanService) *Handler {
+	h.orphanService = orphanService
+	return h
+}
+
+// GetOrphanIndexes handles GET /api/v1/orphans
+func (h *Handler) GetOrphanIndexes(c *gin.Context) {
+	report, err := h.orphanService.DetectOrphans(c.Request.Context())
+	if err != nil {
+		h.requestLogger(c).Error("Failed to detect orphan indexes", infralogger.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// CleanupOrphanIndexes handles POST /api/v1/orphans/cleanup
+func (h *Handler) CleanupOrphanIndexes(c *gin.Context) {
+	var req domain.OrphanCleanupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.requestLogger(c).Warn("Invalid orphan cleanup request", infralogger.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Action != domain.OrphanActionArchive && req.Action != domain.OrphanActionDelete {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "action must be 'archive' or 'delete'"})
+		return
+	}
+
+	h.requestLogger(c).Info("Cleaning up orphan indexes",
+		infralogger.String("action", string(req.Action)),
+		infralogger.Int("count", len(req.IndexNames)),
+	)
+
+	result, err := h.orphanService.CleanupOrphans(c.Request.Context(), &req)
+	if err != nil {
+		h.requestLogger(c).Error("Failed to clean up orphan indexes", infralogger.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	statusCode := http.StatusOK
+	if len(result.Errors) > 0 {
+		statusCode = http.StatusMultiStatus
+		if len(result.Processed) == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+	}
+
+	c.JSON(statusCode, result)
+}
@@ -3,19 +3,29 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 )
 
+// readOnlyPostRoutes are POST endpoints that only read and need only indexes:read.
+var readOnlyPostRoutes = []string{
+	"/api/v1/indexes/lint",
+}
+
 // SetupRoutes configures all API routes
 func SetupRoutes(router *gin.Engine, handler *Handler, jwtSecret string) {
 	// Health routes are handled by the infrastructure/gin package (exposes /health)
-	// No manual health route needed here
+	// Readiness probe for container orchestration (public)
+	router.GET("/ready", handler.ReadinessCheck)
 
 	// API v1 routes — protected by JWT when secret is configured
-	v1 := infragin.ProtectedGroup(router, "/api/v1", jwtSecret)
+	// Scoped tokens need indexes:read, or indexes:write for endpoints that modify indexes or documents
+	v1 := infragin.ProtectedGroup(router, "/api/v1", jwtSecret,
+		infrajwt.WithScope("indexes"), infrajwt.WithReadRoutes(readOnlyPostRoutes...))
 	// Index management endpoints
 	indexes := v1.Group("/indexes")
 	indexes.POST("", handler.CreateIndex)                      // POST /api/v1/indexes
 	indexes.GET("", handler.ListIndices)                       // GET /api/v1/indexes
+	indexes.POST("/lint", handler.LintIndexMapping)            // POST /api/v1/indexes/lint
 	indexes.GET("/:index_name", handler.GetIndex)              // GET /api/v1/indexes/:index_name
 	indexes.DELETE("/:index_name", handler.DeleteIndex)        // DELETE /api/v1/indexes/:index_name
 	indexes.GET("/:index_name/health", handler.GetIndexHealth) // GET /api/v1/indexes/:index_name/health
@@ -27,6 +37,14 @@ func SetupRoutes(router *gin.Engine, handler *Handler, jwtSecret string) {
 	indexes.PUT("/:index_name/documents/:document_id", handler.UpdateDocument)      // PUT /api/v1/indexes/:index_name/documents/:document_id
 	indexes.DELETE("/:index_name/documents/:document_id", handler.DeleteDocument)   // DELETE /api/v1/indexes/:index_name/documents/:document_id
 	indexes.POST("/:index_name/documents/bulk-delete", handler.BulkDeleteDocuments) // POST /api/v1/indexes/:index_name/documents/bulk-delete
+	indexes.POST("/:index_name/documents/bulk", handler.BulkImportDocuments)        // POST /api/v1/indexes/:index_name/documents/bulk (NDJSON)
+
+	// Document revision history
+	indexes.GET("/:index_name/documents/:document_id/history", handler.GetDocumentHistory)
+	indexes.POST("/:index_name/documents/:document_id/history/:revision_id/restore", handler.RestoreDocumentRevision)
+
+	// Cross-index lookup by url / canonical_url
+	v1.GET("/documents/by-url", handler.GetDocumentsByURL) // GET /api/v1/documents/by-url?url=...
 
 	// Bulk operations
 	bulk := v1.Group("/indexes/bulk")
@@ -42,6 +60,28 @@ func SetupRoutes(router *gin.Engine, handler *Handler, jwtSecret string) {
 	// Statistics
 	v1.GET("/stats", handler.GetStats) // GET /api/v1/stats
 
+	// Orphan index reconciliation
+	orphans := v1.Group("/orphans")
+	orphans.GET("", handler.GetOrphanIndexes)              // GET /api/v1/orphans
+	orphans.POST("/cleanup", handler.CleanupOrphanIndexes) // POST /api/v1/orphans/cleanup
+
+	// Duplicate canonical_url report and dedupe
+	duplicates := v1.Group("/duplicates")
+	duplicates.GET("", handler.GetDuplicates)            // GET /api/v1/duplicates
+	duplicates.POST("/dedupe", handler.DedupeDuplicates) // POST /api/v1/duplicates/dedupe
+
+	// Storage growth tracking and forecasting
+	storage := v1.Group("/storage")
+	storage.GET("/forecast", handler.GetStorageForecast) // GET /api/v1/storage/forecast
+	storage.GET("/history", handler.GetStorageHistory)   // GET /api/v1/storage/history
+
+	// Alias blue/green cutovers
+	cutovers := v1.Group("/cutovers")
+	cutovers.POST("", handler.CreateCutover)                // POST /api/v1/cutovers
+	cutovers.GET("", handler.ListCutovers)                  // GET /api/v1/cutovers
+	cutovers.GET("/:id", handler.GetCutover)                // GET /api/v1/cutovers/:id
+	cutovers.POST("/:id/rollback", handler.RollbackCutover) // POST /api/v1/cutovers/:id/rollback
+
 	// Aggregation routes
 	aggregations := v1.Group("/aggregations")
 	aggregations.GET("/crime", handler.GetCrimeAggregation)                   // GET /api/v1/aggregations/crime
@@ -49,6 +89,7 @@ func SetupRoutes(router *gin.Engine, handler *Handler, jwtSecret string) {
 	aggregations.GET("/overview", handler.GetOverviewAggregation)             // GET /api/v1/aggregations/overview
 	aggregations.GET("/mining", handler.GetMiningAggregation)                 // GET /api/v1/aggregations/mining
 	aggregations.GET("/source-health", handler.GetSourceHealth)               // GET /api/v1/aggregations/source-health
+	aggregations.GET("/source-health/alerts", handler.GetSourceHealthAlerts)  // GET /api/v1/aggregations/source-health/alerts
 	aggregations.GET("/classification-drift", handler.GetClassificationDrift) // GET /api/v1/aggregations/classification-drift
 	aggregations.GET("/classification-drift-timeseries", handler.GetClassificationDriftTimeseries)
 	aggregations.GET("/content-type-mismatch", handler.GetContentTypeMismatch)
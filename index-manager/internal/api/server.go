@@ -24,8 +24,6 @@ type ServerConfig struct {
 	Debug        bool
 	ServiceName  string
 	JWTSecret    string
-	ESPing       func() error
-	DBPing       func() error
 }
 
 // NewServer creates a new HTTP server using the infrastructure gin package.
@@ -52,13 +50,10 @@ func NewServer(handler *Handler, config ServerConfig, infraLog infralogger.Logge
 		WithTimeouts(readTimeout, writeTimeout, defaultIdleTimeout).
 		WithMetrics()
 
-	// Wire dependency health checks
-	if config.ESPing != nil {
-		builder = builder.WithElasticsearchHealthCheck(config.ESPing)
-	}
-	if config.DBPing != nil {
-		builder = builder.WithDatabaseHealthCheck(config.DBPing)
-	}
+	// Wire dependency health checks (cluster color and DB latency on /health)
+	builder = builder.
+		WithHealthCheck("elasticsearch", handler.ElasticsearchCheck).
+		WithHealthCheck("database", handler.DatabaseCheck)
 
 	server := builder.
 		WithRoutes(func(router *gin.Engine) {
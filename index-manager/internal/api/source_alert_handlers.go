@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// WithSourceAlertService adds the source health alert service.
+func (h *Handler) WithSourceAlertService(sourceAlertService *service.SourceAlertService) *Handler {
+	h.sourceAlertService = sourceAlertService
+	return h
+}
+
+// GetSourceHealthAlerts handles GET /api/v1/aggregations/source-health/alerts
+func (h *Handler) GetSourceHealthAlerts(c *gin.Context) {
+	report, err := h.sourceAlertService.Evaluate(c.Request.Context())
+	if err != nil {
+		h.requestLogger(c).Error("Failed to evaluate source health alerts", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// WithStorageService adds the storage tracking and forecasting service.
+func (h *Handler) WithStorageService(storageService *service.StorageService) *Handler {
+	h.storageService = storageService
+	return h
+}
+
+// GetStorageForecast handles GET /api/v1/storage/forecast
+func (h *Handler) GetStorageForecast(c *gin.Context) {
+	method := domain.ForecastMethod(c.DefaultQuery("method", string(domain.ForecastMethodLinear)))
+	if method != domain.ForecastMethodLinear && method != domain.ForecastMethodSeasonal {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "method must be 'linear' or 'seasonal'"})
+		return
+	}
+
+	req := &domain.StorageForecastRequest{
+		Method:       method,
+		LookbackDays: queryInt(c, "lookback_days"),
+		HorizonDays:  queryInt(c, "horizon_days"),
+		TopIndexes:   queryInt(c, "top"),
+	}
+	if v := c.Query("threshold_percent"); v != "" {
+		if pct, err := strconv.ParseFloat(v, 64); err == nil {
+			req.ThresholdPercent = pct
+		}
+	}
+
+	forecast, err := h.storageService.Forecast(c.Request.Context(), req)
+	if err != nil {
+		h.requestLogger(c).Error("Failed to forecast storage", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, forecast)
+}
+
+// GetStorageHistory handles GET /api/v1/storage/history
+func (h *Handler) GetStorageHistory(c *gin.Context) {
+	history, err := h.storageService.GetIndexHistory(c.Request.Context(), c.Query("index"), queryInt(c, "days"))
+	if err != nil {
+		h.requestLogger(c).Error("Failed to get storage history", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// queryInt returns a positive integer query parameter, or 0 when absent or invalid.
+func queryInt(c *gin.Context, name string) int {
+	n, err := strconv.Atoi(c.Query(name))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// WithURLLookupService adds the cross-index URL lookup service.
+func (h *Handler) WithURLLookupService(urlLookupService *service.URLLookupService) *Handler {
+	h.urlLookupService = urlLookupService
+	return h
+}
+
+// GetDocumentsByURL handles GET /api/v1/documents/by-url
+func (h *Handler) GetDocumentsByURL(c *gin.Context) {
+	rawURL := c.Query("url")
+	if rawURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "url query parameter is required"})
+		return
+	}
+
+	result, err := h.urlLookupService.FindByURL(c.Request.Context(), rawURL)
+	if errors.Is(err, service.ErrInvalidLookupURL) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.requestLogger(c).Error("Failed to look up documents by url",
+			infralogger.String("url", rawURL),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -3,15 +3,19 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
 )
 
 // Start initializes and starts the index-manager application.
 func Start() error {
+	defer crash.Start("index-manager")()
+
 	// Phase 0: Start profiling server (if enabled)
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("index-manager"); pyroErr != nil {
@@ -43,12 +47,15 @@ func Start() error {
 	)
 
 	// Phase 2: Setup Elasticsearch
-	esClient, err := SetupElasticsearch(cfg)
+	esClient, err := SetupElasticsearch(cfg, log)
 	if err != nil {
 		return fmt.Errorf("failed to setup Elasticsearch: %w", err)
 	}
 	log.Info("Elasticsearch client initialized")
 
+	// Phase 2b: Synonyms set referenced by classified_content search analyzers
+	EnsureSearchSynonyms(esClient, log)
+
 	// Phase 3: Setup database
 	db, err := SetupDatabase(cfg)
 	if err != nil {
@@ -65,7 +72,10 @@ func Start() error {
 	CheckMappingVersionDrift(db, log)
 
 	// Phase 4: Setup and run HTTP server
-	server := SetupHTTPServer(cfg, esClient, db, log)
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	server := SetupHTTPServer(jobsCtx, cfg, esClient, db, log)
 
 	if runErr := server.Run(); runErr != nil {
 		log.Error("Server error", infralogger.Error(runErr))
@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// StartCutoverSweeper deletes indexes retained by cutovers once their grace
+// period ends, checking on every interval until ctx is cancelled.
+func StartCutoverSweeper(
+	ctx context.Context,
+	cutoverService *service.CutoverService,
+	interval time.Duration,
+	log infralogger.Logger,
+) {
+	if interval <= 0 {
+		return
+	}
+
+	log.Info("Cutover sweeper started", infralogger.Duration("interval", interval))
+
+	crash.Go(log, "cutover-sweeper", func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := cutoverService.SweepRetiredIndexes(ctx)
+				if err != nil {
+					log.Warn("Cutover sweep failed", infralogger.Error(err))
+				}
+				if deleted > 0 {
+					log.Info("Cutover sweep deleted retained indexes", infralogger.Int("deleted", deleted))
+				}
+			}
+		}
+	})
+}
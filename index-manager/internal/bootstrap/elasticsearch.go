@@ -8,11 +8,12 @@ import (
 	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch/mappings"
+	"github.com/jonesrussell/north-cloud/infrastructure/esmapping"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
 // SetupElasticsearch creates an Elasticsearch client.
-func SetupElasticsearch(cfg *config.Config) (*elasticsearch.Client, error) {
+func SetupElasticsearch(cfg *config.Config, log infralogger.Logger) (*elasticsearch.Client, error) {
 	esConfig := &elasticsearch.Config{
 		URL:        cfg.Elasticsearch.URL,
 		Username:   cfg.Elasticsearch.Username,
@@ -21,13 +22,26 @@ func SetupElasticsearch(cfg *config.Config) (*elasticsearch.Client, error) {
 		Timeout:    cfg.Elasticsearch.Timeout,
 	}
 
-	esClient, err := elasticsearch.NewClient(esConfig)
+	esClient, err := elasticsearch.NewClient(esConfig, log)
 	if err != nil {
 		return nil, fmt.Errorf("elasticsearch client: %w", err)
 	}
 	return esClient, nil
 }
 
+// EnsureSearchSynonyms creates the synonyms set the classified_content search
+// analyzers reference, so new classified indexes can be created. Failure is
+// logged: the service still runs, but classified index creation will fail
+// until the set exists.
+func EnsureSearchSynonyms(esClient *elasticsearch.Client, log infralogger.Logger) {
+	if err := esClient.EnsureSynonymsSet(context.Background(), esmapping.SearchSynonymsSet); err != nil {
+		log.Warn("Failed to ensure search synonyms set",
+			infralogger.String("synonyms_set", esmapping.SearchSynonymsSet),
+			infralogger.Error(err),
+		)
+	}
+}
+
 // CheckMappingVersionDrift logs warnings for indexes whose mapping version
 // is behind the current version constants.
 func CheckMappingVersionDrift(db *database.Connection, log infralogger.Logger) {
@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// StartOrphanReconciler periodically runs orphan detection and logs the
+// results until ctx is cancelled. It never archives or deletes indexes;
+// cleanup is always an explicit API call.
+func StartOrphanReconciler(
+	ctx context.Context,
+	orphanService *service.OrphanService,
+	interval time.Duration,
+	log infralogger.Logger,
+) {
+	if interval <= 0 {
+		return
+	}
+
+	log.Info("Orphan index reconciler started", infralogger.Duration("interval", interval))
+
+	crash.Go(log, "orphan-reconciler", func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reconcileOrphans(ctx, orphanService, log)
+			}
+		}
+	})
+}
+
+func reconcileOrphans(ctx context.Context, orphanService *service.OrphanService, log infralogger.Logger) {
+	report, err := orphanService.DetectOrphans(ctx)
+	if err != nil {
+		log.Warn("Orphan index reconciliation failed", infralogger.Error(err))
+		return
+	}
+
+	if report.Count == 0 {
+		log.Debug("Orphan index reconciliation found no orphans",
+			infralogger.Int("content_indexes", report.ContentIndexes),
+		)
+		return
+	}
+
+	for _, orphan := range report.Orphans {
+		log.Warn("Orphan index detected",
+			infralogger.String("index_name", orphan.IndexName),
+			infralogger.String("reason", string(orphan.Reason)),
+			infralogger.String("suggested_source", orphan.SuggestedSource),
+			infralogger.Int64("document_count", orphan.DocumentCount),
+		)
+	}
+	log.Info("Orphan index reconciliation completed",
+		infralogger.Int("orphans", report.Count),
+		infralogger.Int("content_indexes", report.ContentIndexes),
+	)
+}
@@ -8,25 +8,73 @@ import (
 	"github.com/jonesrussell/north-cloud/index-manager/internal/config"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/publisher"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/sourcemanager"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
 const httpTimeoutSeconds = 15
 
-// SetupHTTPServer creates and configures the HTTP server.
+// SetupHTTPServer creates and configures the HTTP server. Background jobs
+// started here stop when ctx is cancelled.
 func SetupHTTPServer(
+	ctx context.Context,
 	cfg *config.Config,
 	esClient *elasticsearch.Client,
 	db *database.Connection,
 	log infralogger.Logger,
 ) *infragin.Server {
 	indexService := service.NewIndexService(esClient, db, log, cfg.IndexTypes)
-	documentService := service.NewDocumentService(esClient, log)
+	documentService := service.NewDocumentService(esClient, log).WithRevisionStore(db)
+	if cfg.Publisher.URL != "" && cfg.Auth.InternalSecret != "" {
+		documentService.WithRetractor(
+			publisher.NewClient(cfg.Publisher.URL, cfg.Auth.InternalSecret, cfg.Publisher.Timeout))
+	}
 	aggregationService := service.NewAggregationService(esClient, log)
+	sourceClient := sourcemanager.NewClient(cfg.SourceManager.URL, cfg.Auth.JWTSecret, cfg.SourceManager.Timeout)
+	orphanService := service.NewOrphanService(
+		esClient,
+		sourceClient,
+		db,
+		indexService,
+		cfg.Orphans.IgnoredSources,
+		log,
+	)
+	storageService := service.NewStorageService(esClient, db, cfg.Storage.DiskThresholdPercent, log)
+	alertNotifier := buildAlertNotifier(&cfg.SourceAlerts)
+	sourceAlertService := service.NewSourceAlertService(
+		aggregationService,
+		sourceClient,
+		alertNotifier,
+		service.SourceAlertThresholds{
+			MaxBacklog:     cfg.SourceAlerts.MaxBacklog,
+			MinAvgQuality:  cfg.SourceAlerts.MinAvgQuality,
+			AlertOnStalled: cfg.SourceAlerts.AlertOnStalled,
+		},
+		cfg.SourceAlerts.Cooldown,
+		log,
+	)
+	cutoverService := service.NewCutoverService(esClient, db, service.CutoverSettings{
+		GracePeriod:   cfg.Cutover.GracePeriod,
+		MinCountRatio: cfg.Cutover.MinCountRatio,
+		SpotCheckSize: cfg.Cutover.SpotCheckSize,
+	}, log)
 	handler := api.NewHandler(indexService, documentService, aggregationService, log).
-		WithHealthDeps(esClient, db.DB)
+		WithHealthDeps(esClient, db.DB).
+		WithOrphanService(orphanService).
+		WithStorageService(storageService).
+		WithSourceAlertService(sourceAlertService).
+		WithDuplicateService(service.NewDuplicateService(esClient, log)).
+		WithURLLookupService(service.NewURLLookupService(esClient, log)).
+		WithDocumentImportService(service.NewDocumentImportService(esClient, log)).
+		WithCutoverService(cutoverService)
+
+	StartOrphanReconciler(ctx, orphanService, cfg.Orphans.ReconcileInterval, log)
+	StartStorageSnapshotter(ctx, storageService, cfg.Storage.SnapshotInterval, cfg.Storage.Retention, log)
+	StartSourceAlerter(ctx, sourceAlertService, cfg.SourceAlerts.CheckInterval, alertNotifier != nil, log)
+	StartCutoverSweeper(ctx, cutoverService, cfg.Cutover.SweepInterval, log)
 
 	serverConfig := api.ServerConfig{
 		Port:         cfg.Service.Port,
@@ -35,13 +83,6 @@ func SetupHTTPServer(
 		Debug:        cfg.Service.Debug,
 		ServiceName:  cfg.Service.Name,
 		JWTSecret:    cfg.Auth.JWTSecret,
-		ESPing: func() error {
-			_, err := esClient.GetClusterHealth(context.Background())
-			return err
-		},
-		DBPing: func() error {
-			return db.DB.PingContext(context.Background())
-		},
 	}
 
 	return api.NewServer(handler, serverConfig, log)
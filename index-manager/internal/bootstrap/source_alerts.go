@@ -0,0 +1,73 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/config"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/notifier"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// buildAlertNotifier returns a notifier for the configured webhook targets,
+// or nil when none are configured.
+func buildAlertNotifier(cfg *config.SourceAlertConfig) service.AlertNotifier {
+	var targets notifier.Multi
+	if cfg.WebhookURL != "" {
+		targets = append(targets, notifier.NewWebhookNotifier(cfg.WebhookURL, cfg.Timeout))
+	}
+	if cfg.SlackWebhookURL != "" {
+		targets = append(targets, notifier.NewSlackNotifier(cfg.SlackWebhookURL, cfg.Timeout))
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return targets
+}
+
+// StartSourceAlerter periodically checks source health thresholds and sends
+// notifications for new breaches until ctx is cancelled.
+func StartSourceAlerter(
+	ctx context.Context,
+	alertService *service.SourceAlertService,
+	interval time.Duration,
+	hasNotifier bool,
+	log infralogger.Logger,
+) {
+	if interval <= 0 {
+		return
+	}
+	if !hasNotifier {
+		log.Warn("Source alert check interval set but no webhook configured; alerts are only available via the API")
+		return
+	}
+
+	log.Info("Source health alerter started", infralogger.Duration("interval", interval))
+
+	crash.Go(log, "source-alerts", func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				checkSourceAlerts(ctx, alertService, log)
+			}
+		}
+	})
+}
+
+func checkSourceAlerts(ctx context.Context, alertService *service.SourceAlertService, log infralogger.Logger) {
+	sent, err := alertService.CheckAndNotify(ctx)
+	if err != nil {
+		log.Warn("Source health alert check failed", infralogger.Error(err))
+		return
+	}
+	if sent > 0 {
+		log.Info("Source health alerts sent", infralogger.Int("alerts", sent))
+	}
+}
@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/service"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// StartStorageSnapshotter records index sizes immediately and then on every
+// interval until ctx is cancelled, pruning snapshots older than retention.
+func StartStorageSnapshotter(
+	ctx context.Context,
+	storageService *service.StorageService,
+	interval time.Duration,
+	retention time.Duration,
+	log infralogger.Logger,
+) {
+	if interval <= 0 {
+		return
+	}
+
+	log.Info("Storage snapshotter started",
+		infralogger.Duration("interval", interval),
+		infralogger.Duration("retention", retention),
+	)
+
+	crash.Go(log, "storage-snapshotter", func() {
+		captureStorageSnapshot(ctx, storageService, retention, log)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				captureStorageSnapshot(ctx, storageService, retention, log)
+			}
+		}
+	})
+}
+
+func captureStorageSnapshot(
+	ctx context.Context,
+	storageService *service.StorageService,
+	retention time.Duration,
+	log infralogger.Logger,
+) {
+	count, err := storageService.CaptureSnapshot(ctx)
+	if err != nil {
+		log.Warn("Storage snapshot failed", infralogger.Error(err))
+		return
+	}
+
+	pruned, err := storageService.PruneSnapshots(ctx, retention)
+	if err != nil {
+		log.Warn("Storage snapshot pruning failed", infralogger.Error(err))
+	}
+
+	log.Debug("Storage snapshot recorded",
+		infralogger.Int("indexes", count),
+		infralogger.Int64("pruned", pruned),
+	)
+}
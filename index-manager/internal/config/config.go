@@ -26,11 +26,32 @@ const (
 	defaultLogFormat       = "json"
 	defaultShards          = 1
 	defaultReplicas        = 0
+	defaultSourceMgrURL    = "http://localhost:8050"
+	defaultSourceMgrTOSec  = 10
+	defaultPublisherTOSec  = 10
+	defaultSnapshotHours   = 1
+	defaultRetentionDays   = 180
+	defaultDiskThreshold   = 85
+	defaultAlertMaxBacklog = 1000
+	defaultAlertMinQuality = 40
+	defaultAlertCooldownH  = 6
+	defaultAlertTimeoutSec = 10
+	defaultCutoverGraceH   = 168
+	defaultCutoverSweepH   = 1
+	defaultCutoverMinRatio = 0.99
+	defaultCutoverSpotSize = 20
+	hoursPerDay            = 24
 )
 
+// defaultOrphanIgnoredSources lists index prefixes that are written by
+// services other than the crawler/classifier and never map to a source.
+var defaultOrphanIgnoredSources = []string{"rfp", "alert"}
+
 // AuthConfig holds authentication configuration.
 type AuthConfig struct {
 	JWTSecret string `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
+	// InternalSecret authenticates calls to other services' internal APIs.
+	InternalSecret string `env:"AUTH_INTERNAL_SECRET" yaml:"internal_secret"`
 }
 
 // Config holds the application configuration.
@@ -40,6 +61,12 @@ type Config struct {
 	Database      DatabaseConfig      `yaml:"database"`
 	Elasticsearch ElasticsearchConfig `yaml:"elasticsearch"`
 	IndexTypes    IndexTypesConfig    `yaml:"index_types"`
+	SourceManager SourceManagerConfig `yaml:"source_manager"`
+	Publisher     PublisherConfig     `yaml:"publisher"`
+	Orphans       OrphanConfig        `yaml:"orphans"`
+	Storage       StorageConfig       `yaml:"storage"`
+	SourceAlerts  SourceAlertConfig   `yaml:"source_alerts"`
+	Cutover       CutoverConfig       `yaml:"cutover"`
 	Logging       LoggingConfig       `yaml:"logging"`
 }
 
@@ -73,6 +100,70 @@ type ElasticsearchConfig struct {
 	Timeout    time.Duration `yaml:"timeout"`
 }
 
+// SourceManagerConfig holds source-manager API configuration.
+type SourceManagerConfig struct {
+	URL     string        `env:"SOURCE_MANAGER_URL" yaml:"url"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// PublisherConfig holds publisher internal API configuration. Deleting
+// classified documents retracts them from publisher channels when URL and
+// auth.internal_secret are both set.
+type PublisherConfig struct {
+	URL     string        `env:"PUBLISHER_URL" yaml:"url"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// OrphanConfig holds orphan index reconciliation configuration.
+type OrphanConfig struct {
+	// ReconcileInterval enables the periodic reconciliation job when > 0.
+	ReconcileInterval time.Duration `env:"ORPHAN_RECONCILE_INTERVAL" yaml:"reconcile_interval"`
+	// IgnoredSources are index prefixes never reported as orphans (e.g. "rfp").
+	IgnoredSources []string `yaml:"ignored_sources"`
+}
+
+// StorageConfig holds index size tracking and forecasting configuration.
+type StorageConfig struct {
+	// SnapshotInterval controls how often index sizes are recorded; negative disables tracking.
+	SnapshotInterval time.Duration `env:"STORAGE_SNAPSHOT_INTERVAL" yaml:"snapshot_interval"`
+	// Retention is how long snapshots are kept before being pruned.
+	Retention time.Duration `env:"STORAGE_SNAPSHOT_RETENTION" yaml:"retention"`
+	// DiskThresholdPercent is the default disk usage treated as "full" in forecasts.
+	DiskThresholdPercent float64 `env:"STORAGE_DISK_THRESHOLD_PERCENT" yaml:"disk_threshold_percent"`
+}
+
+// SourceAlertConfig holds source health alert thresholds and notification targets.
+type SourceAlertConfig struct {
+	// CheckInterval enables the periodic threshold check when > 0.
+	CheckInterval time.Duration `env:"SOURCE_ALERT_CHECK_INTERVAL" yaml:"check_interval"`
+	// MaxBacklog alerts when unclassified documents exceed it; negative disables.
+	MaxBacklog int64 `env:"SOURCE_ALERT_MAX_BACKLOG" yaml:"max_backlog"`
+	// MinAvgQuality alerts when a source's average quality falls below it; negative disables.
+	MinAvgQuality float64 `env:"SOURCE_ALERT_MIN_AVG_QUALITY" yaml:"min_avg_quality"`
+	// AlertOnStalled alerts when an enabled source classified nothing in 24h.
+	AlertOnStalled bool `env:"SOURCE_ALERT_ON_STALLED" yaml:"alert_on_stalled"`
+	// Cooldown is the minimum time between repeat notifications for the same breach.
+	Cooldown time.Duration `env:"SOURCE_ALERT_COOLDOWN" yaml:"cooldown"`
+	// WebhookURL receives alert batches as JSON.
+	WebhookURL string `env:"SOURCE_ALERT_WEBHOOK_URL" yaml:"webhook_url"`
+	// SlackWebhookURL is a Slack incoming webhook for alert messages.
+	SlackWebhookURL string `env:"SOURCE_ALERT_SLACK_WEBHOOK_URL" yaml:"slack_webhook_url"`
+	// Timeout bounds each notification request.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// CutoverConfig holds alias blue/green cutover verification and retention configuration.
+type CutoverConfig struct {
+	// GracePeriod is how long the previous index is kept for rollback after a cutover.
+	GracePeriod time.Duration `env:"CUTOVER_GRACE_PERIOD" yaml:"grace_period"`
+	// SweepInterval controls how often expired retained indexes are deleted; negative disables.
+	SweepInterval time.Duration `env:"CUTOVER_SWEEP_INTERVAL" yaml:"sweep_interval"`
+	// MinCountRatio is the minimum target/current document count ratio to pass verification.
+	MinCountRatio float64 `env:"CUTOVER_MIN_COUNT_RATIO" yaml:"min_count_ratio"`
+	// SpotCheckSize is how many random documents are compared field by field; negative disables.
+	SpotCheckSize int `env:"CUTOVER_SPOT_CHECK_SIZE" yaml:"spot_check_size"`
+}
+
 // IndexTypesConfig holds index type configurations.
 type IndexTypesConfig struct {
 	RawContent        IndexTypeConfig `yaml:"raw_content"`
@@ -105,6 +196,12 @@ func setDefaults(cfg *Config) {
 	setDatabaseDefaults(&cfg.Database)
 	setElasticsearchDefaults(&cfg.Elasticsearch)
 	setIndexTypeDefaults(&cfg.IndexTypes)
+	setSourceManagerDefaults(&cfg.SourceManager)
+	setPublisherDefaults(&cfg.Publisher)
+	setOrphanDefaults(&cfg.Orphans)
+	setStorageDefaults(&cfg.Storage)
+	setSourceAlertDefaults(&cfg.SourceAlerts)
+	setCutoverDefaults(&cfg.Cutover)
 	setLoggingDefaults(&cfg.Logging)
 }
 
@@ -173,6 +270,69 @@ func setIndexTypeDefaults(cfg *IndexTypesConfig) {
 	// No special handling needed since Go zero-value is 0
 }
 
+func setSourceManagerDefaults(sm *SourceManagerConfig) {
+	if sm.URL == "" {
+		sm.URL = defaultSourceMgrURL
+	}
+	if sm.Timeout == 0 {
+		sm.Timeout = defaultSourceMgrTOSec * time.Second
+	}
+}
+
+func setPublisherDefaults(p *PublisherConfig) {
+	if p.Timeout == 0 {
+		p.Timeout = defaultPublisherTOSec * time.Second
+	}
+}
+
+func setOrphanDefaults(o *OrphanConfig) {
+	if o.IgnoredSources == nil {
+		o.IgnoredSources = append([]string(nil), defaultOrphanIgnoredSources...)
+	}
+}
+
+func setStorageDefaults(s *StorageConfig) {
+	if s.SnapshotInterval == 0 {
+		s.SnapshotInterval = defaultSnapshotHours * time.Hour
+	}
+	if s.Retention == 0 {
+		s.Retention = defaultRetentionDays * hoursPerDay * time.Hour
+	}
+	if s.DiskThresholdPercent == 0 {
+		s.DiskThresholdPercent = defaultDiskThreshold
+	}
+}
+
+func setSourceAlertDefaults(a *SourceAlertConfig) {
+	if a.MaxBacklog == 0 {
+		a.MaxBacklog = defaultAlertMaxBacklog
+	}
+	if a.MinAvgQuality == 0 {
+		a.MinAvgQuality = defaultAlertMinQuality
+	}
+	if a.Cooldown == 0 {
+		a.Cooldown = defaultAlertCooldownH * time.Hour
+	}
+	if a.Timeout == 0 {
+		a.Timeout = defaultAlertTimeoutSec * time.Second
+	}
+}
+
+func setCutoverDefaults(c *CutoverConfig) {
+	if c.GracePeriod == 0 {
+		c.GracePeriod = defaultCutoverGraceH * time.Hour
+	}
+	if c.SweepInterval == 0 {
+		c.SweepInterval = defaultCutoverSweepH * time.Hour
+	}
+	if c.MinCountRatio == 0 {
+		c.MinCountRatio = defaultCutoverMinRatio
+	}
+	if c.SpotCheckSize == 0 {
+		c.SpotCheckSize = defaultCutoverSpotSize
+	}
+}
+
 func setLoggingDefaults(l *LoggingConfig) {
 	if l.Level == "" {
 		l.Level = defaultLogLevel
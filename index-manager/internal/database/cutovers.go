@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+)
+
+// ErrIndexCutoverNotFound is returned when an index cutover does not exist
+var ErrIndexCutoverNotFound = errors.New("index cutover not found")
+
+// IndexCutover is a recorded alias swap
+type IndexCutover struct {
+	ID               int64
+	Alias            string
+	PreviousIndex    string
+	NewIndex         string
+	RetiredIndex     string
+	Status           string
+	Forced           bool
+	Verification     []byte // JSON-encoded verification result
+	CreatedBy        string
+	CreatedAt        time.Time
+	RetainUntil      time.Time
+	RolledBackAt     sql.NullTime
+	RetiredDeletedAt sql.NullTime
+}
+
+const cutoverColumns = `id, alias, previous_index, new_index, retired_index, status, forced,
+	verification, created_by, created_at, retain_until, rolled_back_at, retired_deleted_at`
+
+// CreateIndexCutover records a new active cutover for an alias, marking any
+// previously active cutover for the same alias as superseded.
+func (c *Connection) CreateIndexCutover(ctx context.Context, cutover *IndexCutover) error {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin cutover transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE index_cutovers SET status = $2
+		WHERE alias = $1 AND status = $3
+	`, cutover.Alias, domain.CutoverStatusSuperseded, domain.CutoverStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to supersede previous cutover: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO index_cutovers
+			(alias, previous_index, new_index, retired_index, status, forced, verification, created_by, retain_until)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`, cutover.Alias, cutover.PreviousIndex, cutover.NewIndex, cutover.RetiredIndex, domain.CutoverStatusActive,
+		cutover.Forced, cutover.Verification, cutover.CreatedBy, cutover.RetainUntil,
+	).Scan(&cutover.ID, &cutover.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert index cutover: %w", err)
+	}
+	cutover.Status = domain.CutoverStatusActive
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit index cutover: %w", commitErr)
+	}
+
+	return nil
+}
+
+// GetIndexCutover returns a single cutover by ID.
+func (c *Connection) GetIndexCutover(ctx context.Context, id int64) (*IndexCutover, error) {
+	row := c.DB.QueryRowContext(ctx, `SELECT `+cutoverColumns+` FROM index_cutovers WHERE id = $1`, id)
+
+	cutover, err := scanIndexCutover(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrIndexCutoverNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index cutover: %w", err)
+	}
+
+	return cutover, nil
+}
+
+// ListIndexCutovers returns cutovers, newest first. An empty alias returns all aliases.
+func (c *Connection) ListIndexCutovers(ctx context.Context, alias string, limit int) ([]*IndexCutover, error) {
+	rows, err := c.DB.QueryContext(ctx, `
+		SELECT `+cutoverColumns+`
+		FROM index_cutovers
+		WHERE ($1 = '' OR alias = $1)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`, alias, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list index cutovers: %w", err)
+	}
+
+	return collectIndexCutovers(rows)
+}
+
+// ListExpiredIndexCutovers returns cutovers whose retained index is past its
+// grace period and not yet deleted. Rolled-back cutovers are excluded because
+// their retained index serves the alias again.
+func (c *Connection) ListExpiredIndexCutovers(ctx context.Context, now time.Time) ([]*IndexCutover, error) {
+	rows, err := c.DB.QueryContext(ctx, `
+		SELECT `+cutoverColumns+`
+		FROM index_cutovers
+		WHERE retired_deleted_at IS NULL AND status <> $2 AND retain_until < $1
+		ORDER BY retain_until
+	`, now, domain.CutoverStatusRolledBack)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired index cutovers: %w", err)
+	}
+
+	return collectIndexCutovers(rows)
+}
+
+// MarkIndexCutoverRolledBack marks an active cutover as rolled back.
+func (c *Connection) MarkIndexCutoverRolledBack(ctx context.Context, id int64) error {
+	res, err := c.DB.ExecContext(ctx, `
+		UPDATE index_cutovers SET status = $2, rolled_back_at = NOW()
+		WHERE id = $1 AND status = $3
+	`, id, domain.CutoverStatusRolledBack, domain.CutoverStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to mark index cutover rolled back: %w", err)
+	}
+
+	return requireOneRow(res)
+}
+
+// MarkIndexCutoverRetiredDeleted records that a cutover's retained index was deleted.
+func (c *Connection) MarkIndexCutoverRetiredDeleted(ctx context.Context, id int64) error {
+	res, err := c.DB.ExecContext(ctx, `
+		UPDATE index_cutovers SET retired_deleted_at = NOW()
+		WHERE id = $1 AND retired_deleted_at IS NULL
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark retired index deleted: %w", err)
+	}
+
+	return requireOneRow(res)
+}
+
+// requireOneRow returns ErrIndexCutoverNotFound when an update matched no rows.
+func requireOneRow(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected row count: %w", err)
+	}
+	if affected == 0 {
+		return ErrIndexCutoverNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanIndexCutover(row rowScanner) (*IndexCutover, error) {
+	cutover := &IndexCutover{}
+	err := row.Scan(
+		&cutover.ID, &cutover.Alias, &cutover.PreviousIndex, &cutover.NewIndex, &cutover.RetiredIndex,
+		&cutover.Status, &cutover.Forced, &cutover.Verification, &cutover.CreatedBy, &cutover.CreatedAt,
+		&cutover.RetainUntil, &cutover.RolledBackAt, &cutover.RetiredDeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return cutover, nil
+}
+
+func collectIndexCutovers(rows *sql.Rows) ([]*IndexCutover, error) {
+	defer func() { _ = rows.Close() }()
+
+	cutovers := make([]*IndexCutover, 0)
+	for rows.Next() {
+		cutover, scanErr := scanIndexCutover(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("failed to scan index cutover: %w", scanErr)
+		}
+		cutovers = append(cutovers, cutover)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", rowsErr)
+	}
+
+	return cutovers, nil
+}
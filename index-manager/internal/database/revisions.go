@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDocumentRevisionNotFound is returned when a document revision does not exist
+var ErrDocumentRevisionNotFound = errors.New("document revision not found")
+
+// DocumentRevision is a stored previous version of a document
+type DocumentRevision struct {
+	ID         int64
+	IndexName  string
+	DocumentID string
+	Source     []byte // JSON-encoded _source
+	Action     string
+	ChangedBy  string
+	CreatedAt  time.Time
+}
+
+// RecordDocumentRevision stores a previous document version and trims the
+// document's history to the newest keep revisions.
+func (c *Connection) RecordDocumentRevision(ctx context.Context, rev *DocumentRevision, keep int) error {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin revision transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO document_revisions (index_name, document_id, source, action, changed_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, rev.IndexName, rev.DocumentID, rev.Source, rev.Action, rev.ChangedBy).Scan(&rev.ID, &rev.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert document revision: %w", err)
+	}
+
+	if keep > 0 {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM document_revisions
+			WHERE index_name = $1 AND document_id = $2 AND id NOT IN (
+				SELECT id FROM document_revisions
+				WHERE index_name = $1 AND document_id = $2
+				ORDER BY created_at DESC, id DESC
+				LIMIT $3
+			)
+		`, rev.IndexName, rev.DocumentID, keep)
+		if err != nil {
+			return fmt.Errorf("failed to trim document revisions: %w", err)
+		}
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit document revision: %w", commitErr)
+	}
+
+	return nil
+}
+
+// ListDocumentRevisions returns a document's revisions, newest first.
+func (c *Connection) ListDocumentRevisions(
+	ctx context.Context,
+	indexName, documentID string,
+	limit int,
+) ([]*DocumentRevision, error) {
+	rows, err := c.DB.QueryContext(ctx, `
+		SELECT id, index_name, document_id, source, action, changed_by, created_at
+		FROM document_revisions
+		WHERE index_name = $1 AND document_id = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`, indexName, documentID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document revisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	revisions := make([]*DocumentRevision, 0)
+	for rows.Next() {
+		rev := &DocumentRevision{}
+		if scanErr := rows.Scan(
+			&rev.ID, &rev.IndexName, &rev.DocumentID, &rev.Source, &rev.Action, &rev.ChangedBy, &rev.CreatedAt,
+		); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan document revision: %w", scanErr)
+		}
+		revisions = append(revisions, rev)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", rowsErr)
+	}
+
+	return revisions, nil
+}
+
+// GetDocumentRevision returns a single revision by ID.
+func (c *Connection) GetDocumentRevision(ctx context.Context, id int64) (*DocumentRevision, error) {
+	rev := &DocumentRevision{}
+	err := c.DB.QueryRowContext(ctx, `
+		SELECT id, index_name, document_id, source, action, changed_by, created_at
+		FROM document_revisions
+		WHERE id = $1
+	`, id).Scan(&rev.ID, &rev.IndexName, &rev.DocumentID, &rev.Source, &rev.Action, &rev.ChangedBy, &rev.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDocumentRevisionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document revision: %w", err)
+	}
+
+	return rev, nil
+}
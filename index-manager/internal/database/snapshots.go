@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// IndexSizeSnapshot is a single size/doc-count sample for an index
+type IndexSizeSnapshot struct {
+	IndexName  string
+	SizeBytes  int64
+	DocCount   int64
+	CapturedAt time.Time
+}
+
+// RecordIndexSizeSnapshots stores one capture of every index in a single transaction.
+// All rows share capturedAt so they can be summed per capture.
+func (c *Connection) RecordIndexSizeSnapshots(ctx context.Context, capturedAt time.Time, snapshots []IndexSizeSnapshot) error {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin snapshot transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO index_size_snapshots (index_name, size_bytes, doc_count, captured_at)
+		VALUES ($1, $2, $3, $4)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare snapshot insert: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, snap := range snapshots {
+		if _, execErr := stmt.ExecContext(ctx, snap.IndexName, snap.SizeBytes, snap.DocCount, capturedAt); execErr != nil {
+			return fmt.Errorf("failed to insert snapshot for %s: %w", snap.IndexName, execErr)
+		}
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit snapshots: %w", commitErr)
+	}
+
+	return nil
+}
+
+// ListDailyTotalSizes returns, per day since the given time, the summed size and
+// doc count of all indexes at that day's last capture.
+func (c *Connection) ListDailyTotalSizes(ctx context.Context, since time.Time) ([]IndexSizeSnapshot, error) {
+	query := `
+		WITH captures AS (
+			SELECT captured_at, SUM(size_bytes) AS size_bytes, SUM(doc_count) AS doc_count
+			FROM index_size_snapshots
+			WHERE captured_at >= $1
+			GROUP BY captured_at
+		)
+		SELECT DISTINCT ON (date_trunc('day', captured_at))
+			date_trunc('day', captured_at), size_bytes, doc_count
+		FROM captures
+		ORDER BY date_trunc('day', captured_at), captured_at DESC
+	`
+
+	rows, err := c.DB.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily total sizes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var points []IndexSizeSnapshot
+	for rows.Next() {
+		var p IndexSizeSnapshot
+		if scanErr := rows.Scan(&p.CapturedAt, &p.SizeBytes, &p.DocCount); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan daily total size: %w", scanErr)
+		}
+		points = append(points, p)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", rowsErr)
+	}
+
+	return points, nil
+}
+
+// ListDailyIndexSizes returns, per index and day since the given time, the size and
+// doc count at that day's last capture. An empty indexName returns all indexes.
+func (c *Connection) ListDailyIndexSizes(ctx context.Context, indexName string, since time.Time) ([]IndexSizeSnapshot, error) {
+	query := `
+		SELECT DISTINCT ON (index_name, date_trunc('day', captured_at))
+			index_name, date_trunc('day', captured_at), size_bytes, doc_count
+		FROM index_size_snapshots
+		WHERE captured_at >= $1 AND ($2 = '' OR index_name = $2)
+		ORDER BY index_name, date_trunc('day', captured_at), captured_at DESC
+	`
+
+	rows, err := c.DB.QueryContext(ctx, query, since, indexName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily index sizes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var points []IndexSizeSnapshot
+	for rows.Next() {
+		var p IndexSizeSnapshot
+		if scanErr := rows.Scan(&p.IndexName, &p.CapturedAt, &p.SizeBytes, &p.DocCount); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan daily index size: %w", scanErr)
+		}
+		points = append(points, p)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", rowsErr)
+	}
+
+	return points, nil
+}
+
+// PruneIndexSizeSnapshots deletes snapshots captured before the given time.
+func (c *Connection) PruneIndexSizeSnapshots(ctx context.Context, before time.Time) (int64, error) {
+	res, err := c.DB.ExecContext(ctx, `DELETE FROM index_size_snapshots WHERE captured_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune index size snapshots: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read pruned row count: %w", err)
+	}
+
+	return deleted, nil
+}
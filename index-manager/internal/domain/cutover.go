@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// Cutover statuses
+const (
+	// CutoverStatusActive is the cutover currently serving the alias
+	CutoverStatusActive = "active"
+	// CutoverStatusSuperseded is a cutover replaced by a later one for the same alias
+	CutoverStatusSuperseded = "superseded"
+	// CutoverStatusRolledBack is a cutover whose alias was pointed back at the previous index
+	CutoverStatusRolledBack = "rolled_back"
+)
+
+// CutoverRequest asks to point a read alias at a new index
+type CutoverRequest struct {
+	// Alias is the name readers use, e.g. "example_com_classified_content".
+	// When it is still a concrete index it is retired and replaced by an alias.
+	Alias string `binding:"required" json:"alias"`
+	// TargetIndex is the backfilled index, e.g. "example_com_classified_content_v2"
+	TargetIndex string `binding:"required" json:"target_index"`
+	// RetainHours overrides the configured grace period for the previous index
+	RetainHours int `json:"retain_hours,omitempty"`
+	// DryRun runs verification only
+	DryRun bool `json:"dry_run,omitempty"`
+	// Force swaps the alias even when verification fails
+	Force bool `json:"force,omitempty"`
+	// CreatedBy is set from the caller's token, not the request body
+	CreatedBy string `json:"-"`
+}
+
+// CutoverFieldMismatch lists fields of a sampled document missing from the target index
+type CutoverFieldMismatch struct {
+	DocumentID    string   `json:"document_id"`
+	MissingFields []string `json:"missing_fields"`
+}
+
+// CutoverVerification is the result of comparing the current and target indexes
+type CutoverVerification struct {
+	SourceCount      int64                  `json:"source_count"`
+	TargetCount      int64                  `json:"target_count"`
+	CountRatio       float64                `json:"count_ratio"`
+	MinCountRatio    float64                `json:"min_count_ratio"`
+	CountOK          bool                   `json:"count_ok"`
+	Sampled          int                    `json:"sampled"`
+	MissingDocuments []string               `json:"missing_documents"`
+	FieldMismatches  []CutoverFieldMismatch `json:"field_mismatches"`
+	Passed           bool                   `json:"passed"`
+}
+
+// IndexCutover is a recorded alias swap
+type IndexCutover struct {
+	ID            int64                `json:"id"`
+	Alias         string               `json:"alias"`
+	PreviousIndex string               `json:"previous_index"`
+	NewIndex      string               `json:"new_index"`
+	RetiredIndex  string               `json:"retired_index"`
+	Status        string               `json:"status"`
+	Forced        bool                 `json:"forced"`
+	Verification  *CutoverVerification `json:"verification,omitempty"`
+	CreatedBy     string               `json:"created_by,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	RetainUntil   time.Time            `json:"retain_until"`
+	RolledBackAt  *time.Time           `json:"rolled_back_at,omitempty"`
+	// RetiredDeletedAt is when the retained previous index was deleted after the grace period
+	RetiredDeletedAt *time.Time `json:"retired_deleted_at,omitempty"`
+}
+
+// CutoverResponse is the result of a cutover request
+type CutoverResponse struct {
+	DryRun       bool                 `json:"dry_run"`
+	Verification *CutoverVerification `json:"verification"`
+	Cutover      *IndexCutover        `json:"cutover,omitempty"`
+}
@@ -118,3 +118,49 @@ type DocumentQueryResponse struct {
 type BulkDeleteRequest struct {
 	DocumentIDs []string `binding:"required" json:"document_ids"`
 }
+
+// DocumentImportError describes one NDJSON line that was not indexed
+type DocumentImportError struct {
+	Line       int    `json:"line"`
+	DocumentID string `json:"document_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+// DocumentImportResult reports the outcome of a bulk NDJSON import
+type DocumentImportResult struct {
+	IndexName string `json:"index_name"`
+	// Schema is the index type whose mapping documents were validated against,
+	// or "index_mapping" when the live mapping was used
+	Schema  string                `json:"schema"`
+	Total   int                   `json:"total"`
+	Indexed int                   `json:"indexed"`
+	Failed  int                   `json:"failed"`
+	Errors  []DocumentImportError `json:"errors,omitempty"`
+}
+
+// Document revision actions
+const (
+	// RevisionActionUpdate records the version overwritten by an update
+	RevisionActionUpdate = "update"
+	// RevisionActionRestore records the version overwritten by a restore
+	RevisionActionRestore = "restore"
+)
+
+// DocumentRevision is a previous version of a document, captured before it was overwritten
+type DocumentRevision struct {
+	ID         int64          `json:"id"`
+	IndexName  string         `json:"index_name"`
+	DocumentID string         `json:"document_id"`
+	Action     string         `json:"action"`
+	ChangedBy  string         `json:"changed_by,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	Source     map[string]any `json:"source"`
+}
+
+// DocumentHistoryResponse lists a document's revisions, newest first
+type DocumentHistoryResponse struct {
+	IndexName  string              `json:"index_name"`
+	DocumentID string              `json:"document_id"`
+	Revisions  []*DocumentRevision `json:"revisions"`
+	Count      int                 `json:"count"`
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// DuplicateCopy is one document in a duplicate canonical_url cluster
+type DuplicateCopy struct {
+	IndexName    string     `json:"index_name"`
+	DocumentID   string     `json:"document_id"`
+	Title        string     `json:"title,omitempty"`
+	SourceName   string     `json:"source_name,omitempty"`
+	QualityScore int        `json:"quality_score"`
+	CrawledAt    *time.Time `json:"crawled_at,omitempty"`
+}
+
+// DuplicateCluster groups documents sharing a canonical_url. Copies are
+// ordered best first: highest quality, then most recently crawled.
+type DuplicateCluster struct {
+	CanonicalURL  string           `json:"canonical_url"`
+	DocumentCount int64            `json:"document_count"`
+	Indexes       []string         `json:"indexes"`
+	Copies        []*DuplicateCopy `json:"copies"`
+	// Truncated is set when the cluster has more documents than were listed
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// DuplicateReport lists duplicate canonical_url clusters across classified indexes
+type DuplicateReport struct {
+	Clusters           []*DuplicateCluster `json:"clusters"`
+	Count              int                 `json:"count"`
+	RedundantDocuments int64               `json:"redundant_documents"`
+	GeneratedAt        time.Time           `json:"generated_at"`
+}
+
+// DuplicateReportRequest scopes a duplicate report
+type DuplicateReportRequest struct {
+	SourceName    string   `json:"source_name,omitempty"`
+	CanonicalURLs []string `json:"canonical_urls,omitempty"`
+	Limit         int      `json:"limit,omitempty"`
+}
+
+// DedupeRequest removes duplicate copies, keeping the best copy of each cluster
+type DedupeRequest struct {
+	DuplicateReportRequest
+
+	DryRun bool `json:"dry_run"`
+}
+
+// DedupeResult reports the outcome of a dedupe run
+type DedupeResult struct {
+	DryRun   bool             `json:"dry_run"`
+	Clusters int              `json:"clusters"`
+	Kept     []*DuplicateCopy `json:"kept"`
+	Deleted  []*DuplicateCopy `json:"deleted"`
+	Errors   []string         `json:"errors,omitempty"`
+}
@@ -29,6 +29,8 @@ type Index struct {
 	MappingVersion string    `json:"mapping_version,omitempty"`
 	CreatedAt      string    `json:"created_at,omitempty"`
 	UpdatedAt      string    `json:"updated_at,omitempty"`
+	// MappingWarnings lists non-blocking lint findings for a custom mapping
+	MappingWarnings []MappingIssue `json:"mapping_warnings,omitempty"`
 }
 
 // CreateIndexRequest represents a request to create an index
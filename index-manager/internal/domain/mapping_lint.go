@@ -0,0 +1,30 @@
+package domain
+
+// MappingIssueSeverity classifies a mapping lint finding
+type MappingIssueSeverity string
+
+const (
+	// MappingIssueError rejects the create request
+	MappingIssueError MappingIssueSeverity = "error"
+	// MappingIssueWarning is reported but does not block creation
+	MappingIssueWarning MappingIssueSeverity = "warning"
+)
+
+// MappingIssue is a single mapping lint finding
+type MappingIssue struct {
+	Field    string               `json:"field,omitempty"`
+	Rule     string               `json:"rule"`
+	Severity MappingIssueSeverity `json:"severity"`
+	Message  string               `json:"message"`
+}
+
+// LintMappingRequest is the body of POST /api/v1/indexes/lint
+type LintMappingRequest struct {
+	Mapping map[string]any `binding:"required" json:"mapping"`
+}
+
+// LintMappingResponse reports mapping lint findings
+type LintMappingResponse struct {
+	Valid  bool           `json:"valid"`
+	Issues []MappingIssue `json:"issues"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// OrphanReason explains why an index was flagged as orphaned
+type OrphanReason string
+
+const (
+	// OrphanReasonUnknownSource means no registered source matches the index prefix (typically a deleted source)
+	OrphanReasonUnknownSource OrphanReason = "unknown_source"
+	// OrphanReasonPossibleTypo means the prefix is a near-miss of a registered source name
+	OrphanReasonPossibleTypo OrphanReason = "possible_typo"
+	// OrphanReasonTestLeftover means the prefix looks like a test or scratch index
+	OrphanReasonTestLeftover OrphanReason = "test_leftover"
+)
+
+// OrphanAction is a cleanup action applied to orphaned indexes
+type OrphanAction string
+
+const (
+	// OrphanActionArchive closes the index, keeping its data on disk
+	OrphanActionArchive OrphanAction = "archive"
+	// OrphanActionDelete permanently deletes the index
+	OrphanActionDelete OrphanAction = "delete"
+)
+
+// OrphanIndex describes a content index with no matching registered source
+type OrphanIndex struct {
+	IndexName       string       `json:"index_name"`
+	IndexType       IndexType    `json:"index_type"`
+	SourceName      string       `json:"source_name"`
+	Reason          OrphanReason `json:"reason"`
+	SuggestedSource string       `json:"suggested_source,omitempty"`
+	DocumentCount   int64        `json:"document_count"`
+}
+
+// OrphanReport is the result of a reconciliation run
+type OrphanReport struct {
+	Orphans        []*OrphanIndex `json:"orphans"`
+	Count          int            `json:"count"`
+	ContentIndexes int            `json:"content_indexes"`
+	KnownSources   int            `json:"known_sources"`
+	CheckedAt      time.Time      `json:"checked_at"`
+}
+
+// OrphanCleanupRequest represents a bulk archive/delete request for orphaned indexes
+type OrphanCleanupRequest struct {
+	IndexNames []string     `binding:"required" json:"index_names"`
+	Action     OrphanAction `binding:"required" json:"action"`
+}
+
+// OrphanCleanupResult reports the outcome of a cleanup request
+type OrphanCleanupResult struct {
+	Action    OrphanAction `json:"action"`
+	Processed []string     `json:"processed"`
+	Skipped   []string     `json:"skipped,omitempty"`
+	Errors    []string     `json:"errors,omitempty"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// SourceAlertRule identifies which source health threshold was crossed
+type SourceAlertRule string
+
+const (
+	// SourceAlertRuleBacklog means raw documents are piling up unclassified
+	SourceAlertRuleBacklog SourceAlertRule = "backlog"
+	// SourceAlertRuleStalled means an enabled source produced no classified documents in 24h
+	SourceAlertRuleStalled SourceAlertRule = "stalled"
+	// SourceAlertRuleLowQuality means the source's average quality score fell below the minimum
+	SourceAlertRuleLowQuality SourceAlertRule = "low_quality"
+)
+
+// SourceAlert is a single source health threshold breach
+type SourceAlert struct {
+	Source     string          `json:"source"`
+	Rule       SourceAlertRule `json:"rule"`
+	Message    string          `json:"message"`
+	Value      float64         `json:"value"`
+	Threshold  float64         `json:"threshold"`
+	DetectedAt time.Time       `json:"detected_at"`
+}
+
+// SourceAlertReport lists every current breach, regardless of notification cooldown
+type SourceAlertReport struct {
+	Alerts    []SourceAlert `json:"alerts"`
+	Count     int           `json:"count"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
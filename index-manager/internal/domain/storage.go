@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// ForecastMethod selects the projection model for storage forecasting
+type ForecastMethod string
+
+const (
+	// ForecastMethodLinear fits a least-squares line to daily total size
+	ForecastMethodLinear ForecastMethod = "linear"
+	// ForecastMethodSeasonal projects using average growth per weekday
+	ForecastMethodSeasonal ForecastMethod = "seasonal"
+)
+
+// StoragePoint is a daily storage sample
+type StoragePoint struct {
+	Date      time.Time `json:"date"`
+	SizeBytes int64     `json:"size_bytes"`
+	DocCount  int64     `json:"doc_count"`
+}
+
+// IndexStorageHistory is the daily size history of a single index
+type IndexStorageHistory struct {
+	IndexName string         `json:"index_name"`
+	Points    []StoragePoint `json:"points"`
+}
+
+// IndexGrowth summarises the growth rate of a single index
+type IndexGrowth struct {
+	IndexName          string  `json:"index_name"`
+	CurrentSizeBytes   int64   `json:"current_size_bytes"`
+	CurrentDocCount    int64   `json:"current_doc_count"`
+	GrowthBytesPerDay  float64 `json:"growth_bytes_per_day"`
+	GrowthDocsPerDay   float64 `json:"growth_docs_per_day"`
+	ProjectedSizeBytes int64   `json:"projected_size_bytes"`
+}
+
+// StorageForecastRequest holds forecast parameters
+type StorageForecastRequest struct {
+	Method           ForecastMethod
+	LookbackDays     int
+	HorizonDays      int
+	TopIndexes       int
+	ThresholdPercent float64
+}
+
+// StorageForecast is the cluster storage projection
+type StorageForecast struct {
+	Method             ForecastMethod `json:"method"`
+	LookbackDays       int            `json:"lookback_days"`
+	HorizonDays        int            `json:"horizon_days"`
+	DataPoints         int            `json:"data_points"`
+	DiskTotalBytes     int64          `json:"disk_total_bytes"`
+	DiskUsedBytes      int64          `json:"disk_used_bytes"`
+	ThresholdPercent   float64        `json:"threshold_percent"`
+	ThresholdBytes     int64          `json:"threshold_bytes"`
+	GrowthBytesPerDay  float64        `json:"growth_bytes_per_day"`
+	DaysUntilThreshold *float64       `json:"days_until_threshold"`
+	ThresholdDate      *time.Time     `json:"threshold_date,omitempty"`
+	Projection         []StoragePoint `json:"projection"`
+	TopGrowers         []*IndexGrowth `json:"top_growers"`
+	GeneratedAt        time.Time      `json:"generated_at"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// URLMatch is a raw or classified document whose url or canonical_url matches a looked-up URL
+type URLMatch struct {
+	IndexName            string     `json:"index_name"`
+	IndexType            IndexType  `json:"index_type"`
+	DocumentID           string     `json:"document_id"`
+	URL                  string     `json:"url,omitempty"`
+	CanonicalURL         string     `json:"canonical_url,omitempty"`
+	Title                string     `json:"title,omitempty"`
+	SourceName           string     `json:"source_name,omitempty"`
+	ClassificationStatus string     `json:"classification_status,omitempty"`
+	ContentType          string     `json:"content_type,omitempty"`
+	QualityScore         *int       `json:"quality_score,omitempty"`
+	Topics               []string   `json:"topics,omitempty"`
+	CrawledAt            *time.Time `json:"crawled_at,omitempty"`
+	PublishedDate        *time.Time `json:"published_date,omitempty"`
+}
+
+// URLLookupResult lists the documents across all content indexes for one URL
+type URLLookupResult struct {
+	URL           string      `json:"url"`
+	NormalizedURL string      `json:"normalized_url"`
+	Matches       []*URLMatch `json:"matches"`
+	Count         int         `json:"count"`
+	// Truncated is set when more documents matched than were returned
+	Truncated bool `json:"truncated,omitempty"`
+}
@@ -0,0 +1,277 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Alias action types accepted by the _aliases API.
+const (
+	AliasActionAdd         = "add"
+	AliasActionRemove      = "remove"
+	AliasActionRemoveIndex = "remove_index"
+)
+
+// AliasAction is a single action applied atomically by UpdateAliases.
+// RemoveIndex actions ignore Alias and IsWriteIndex.
+type AliasAction struct {
+	Type         string
+	Index        string
+	Alias        string
+	IsWriteIndex bool
+}
+
+// SampledDocument is a document returned by SampleDocuments.
+type SampledDocument struct {
+	ID     string
+	Source map[string]any
+}
+
+// GetAliasTargets returns the indexes an alias points to, or nil when no such alias exists.
+func (c *Client) GetAliasTargets(ctx context.Context, alias string) ([]string, error) {
+	res, err := c.esClient.Indices.GetAlias(
+		c.esClient.Indices.GetAlias.WithName(alias),
+		c.esClient.Indices.GetAlias.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alias: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("error getting alias: %s", string(body))
+	}
+
+	var result map[string]any
+	if decodeErr := json.NewDecoder(res.Body).Decode(&result); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode alias response: %w", decodeErr)
+	}
+
+	targets := make([]string, 0, len(result))
+	for index := range result {
+		targets = append(targets, index)
+	}
+
+	return targets, nil
+}
+
+// UpdateAliases applies alias actions in a single atomic _aliases request.
+func (c *Client) UpdateAliases(ctx context.Context, actions []AliasAction) error {
+	body := map[string]any{"actions": buildAliasActions(actions)}
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alias actions: %w", err)
+	}
+
+	res, err := c.esClient.Indices.UpdateAliases(
+		strings.NewReader(string(bodyJSON)),
+		c.esClient.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update aliases: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		respBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("error updating aliases [%d]: %s", res.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// buildAliasActions converts alias actions to the _aliases request format.
+func buildAliasActions(actions []AliasAction) []map[string]any {
+	out := make([]map[string]any, 0, len(actions))
+	for _, action := range actions {
+		params := map[string]any{"index": action.Index}
+		if action.Type != AliasActionRemoveIndex {
+			params["alias"] = action.Alias
+		}
+		if action.Type == AliasActionAdd && action.IsWriteIndex {
+			params["is_write_index"] = true
+		}
+		out = append(out, map[string]any{action.Type: params})
+	}
+	return out
+}
+
+// SetIndexWriteBlock blocks or unblocks writes to an index.
+func (c *Client) SetIndexWriteBlock(ctx context.Context, indexName string, blocked bool) error {
+	bodyJSON, err := json.Marshal(map[string]any{"index.blocks.write": blocked})
+	if err != nil {
+		return fmt.Errorf("failed to marshal index settings: %w", err)
+	}
+
+	res, err := c.esClient.Indices.PutSettings(
+		strings.NewReader(string(bodyJSON)),
+		c.esClient.Indices.PutSettings.WithIndex(indexName),
+		c.esClient.Indices.PutSettings.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update index settings: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("error updating index settings: %s", string(body))
+	}
+
+	return nil
+}
+
+// CloneIndex copies a write-blocked index into a new index. The clone keeps
+// the source's settings, so its write block must be lifted before use.
+func (c *Client) CloneIndex(ctx context.Context, sourceIndex, targetIndex string) error {
+	res, err := c.esClient.Indices.Clone(
+		sourceIndex,
+		targetIndex,
+		c.esClient.Indices.Clone.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to clone index: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("error cloning index [%d]: %s", res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CountDocuments returns the exact document count of an index or alias.
+func (c *Client) CountDocuments(ctx context.Context, indexName string) (int64, error) {
+	res, err := c.esClient.Count(
+		c.esClient.Count.WithIndex(indexName),
+		c.esClient.Count.WithContext(ctx),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return 0, fmt.Errorf("error counting documents: %s", string(body))
+	}
+
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&result); decodeErr != nil {
+		return 0, fmt.Errorf("failed to decode count response: %w", decodeErr)
+	}
+
+	return result.Count, nil
+}
+
+// SampleDocuments returns up to size randomly chosen documents from an index or alias.
+func (c *Client) SampleDocuments(ctx context.Context, indexName string, size int) ([]SampledDocument, error) {
+	query := map[string]any{
+		"size": size,
+		"query": map[string]any{
+			"function_score": map[string]any{
+				"query":        map[string]any{"match_all": map[string]any{}},
+				"random_score": map[string]any{},
+			},
+		},
+	}
+
+	res, err := c.SearchDocuments(ctx, indexName, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("error sampling documents: %s", string(body))
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				ID     string         `json:"_id"`
+				Source map[string]any `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&result); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode sample response: %w", decodeErr)
+	}
+
+	docs := make([]SampledDocument, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		docs = append(docs, SampledDocument{ID: hit.ID, Source: hit.Source})
+	}
+
+	return docs, nil
+}
+
+// MultiGetDocuments fetches documents by ID. Missing documents are absent from the result.
+func (c *Client) MultiGetDocuments(ctx context.Context, indexName string, documentIDs []string) (map[string]map[string]any, error) {
+	bodyJSON, err := json.Marshal(map[string]any{"ids": documentIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mget body: %w", err)
+	}
+
+	res, err := c.esClient.Mget(
+		strings.NewReader(string(bodyJSON)),
+		c.esClient.Mget.WithIndex(indexName),
+		c.esClient.Mget.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("error getting documents: %s", string(body))
+	}
+
+	var result struct {
+		Docs []struct {
+			ID     string         `json:"_id"`
+			Found  bool           `json:"found"`
+			Source map[string]any `json:"_source"`
+		} `json:"docs"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&result); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode mget response: %w", decodeErr)
+	}
+
+	found := make(map[string]map[string]any, len(result.Docs))
+	for _, doc := range result.Docs {
+		if doc.Found {
+			found[doc.ID] = doc.Source
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,106 @@
+package elasticsearch //nolint:testpackage // testing Client methods with httptest mock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetAliasTargets_NotFound(t *testing.T) {
+	t.Helper()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"alias [x] missing","status":404}`))
+	})
+	client := newTestClient(t, handler)
+
+	targets, err := client.GetAliasTargets(context.Background(), "x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if targets != nil {
+		t.Errorf("expected nil targets, got %v", targets)
+	}
+}
+
+func TestGetAliasTargets_Found(t *testing.T) {
+	t.Helper()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_alias/news_classified_content" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"news_classified_content_v2":{"aliases":{"news_classified_content":{}}}}`))
+	})
+	client := newTestClient(t, handler)
+
+	targets, err := client.GetAliasTargets(context.Background(), "news_classified_content")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets) != 1 || targets[0] != "news_classified_content_v2" {
+		t.Errorf("unexpected targets %v", targets)
+	}
+}
+
+func TestUpdateAliases_RequestBody(t *testing.T) {
+	t.Helper()
+
+	var body struct {
+		Actions []map[string]map[string]any `json:"actions"`
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/_aliases" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	})
+	client := newTestClient(t, handler)
+
+	err := client.UpdateAliases(context.Background(), []AliasAction{
+		{Type: AliasActionRemoveIndex, Index: "news_classified_content"},
+		{Type: AliasActionAdd, Index: "news_classified_content_v2", Alias: "news_classified_content", IsWriteIndex: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(body.Actions) != 2 {
+		t.Fatalf("expected 2 actions, got %d", len(body.Actions))
+	}
+	removeIndex := body.Actions[0][AliasActionRemoveIndex]
+	if removeIndex["index"] != "news_classified_content" || removeIndex["alias"] != nil {
+		t.Errorf("unexpected remove_index action %v", removeIndex)
+	}
+	add := body.Actions[1][AliasActionAdd]
+	if add["alias"] != "news_classified_content" || add["is_write_index"] != true {
+		t.Errorf("unexpected add action %v", add)
+	}
+}
+
+func TestMultiGetDocuments_SkipsMissing(t *testing.T) {
+	t.Helper()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"docs":[
+			{"_id":"a","found":true,"_source":{"title":"A"}},
+			{"_id":"b","found":false}
+		]}`))
+	})
+	client := newTestClient(t, handler)
+
+	docs, err := client.MultiGetDocuments(context.Background(), "idx", []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(docs) != 1 || docs["a"]["title"] != "A" {
+		t.Errorf("unexpected docs %v", docs)
+	}
+}
@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -35,28 +36,20 @@ type Config struct {
 	Timeout    time.Duration
 }
 
-// NewClient creates a new Elasticsearch client using the standardized infrastructure client
-func NewClient(cfg *Config) (*Client, error) {
+// NewClient creates a new Elasticsearch client using the standardized infrastructure client.
+// Requests carry the HTTP request's correlation ID as X-Opaque-Id.
+func NewClient(cfg *Config, log logger.Logger) (*Client, error) {
 	ctx := context.Background()
 
-	// Create a logger for connection initialization
-	log, err := logger.New(logger.Config{
-		Level:  "info",
-		Format: "json",
-	})
-	if err != nil {
-		// If logger creation fails, continue without logging
-		log = nil
-	}
-
 	// Map index-manager config to standardized config
 	// Uses infrastructure defaults for retry config (10 attempts, 3s initial, 15s max)
 	esCfg := esclient.Config{
-		URL:         cfg.URL,
-		Username:    cfg.Username,
-		Password:    cfg.Password,
-		MaxRetries:  cfg.MaxRetries,
-		PingTimeout: cfg.Timeout,
+		URL:                cfg.URL,
+		Username:           cfg.Username,
+		Password:           cfg.Password,
+		MaxRetries:         cfg.MaxRetries,
+		PingTimeout:        cfg.Timeout,
+		PropagateRequestID: true,
 		// RetryConfig uses infrastructure defaults
 	}
 
@@ -157,6 +150,25 @@ func (c *Client) DeleteIndex(ctx context.Context, indexName string) error {
 	return nil
 }
 
+// CloseIndex closes an index. Closed indexes keep their data on disk but
+// release heap and are no longer searchable until reopened.
+func (c *Client) CloseIndex(ctx context.Context, indexName string) error {
+	res, err := c.esClient.Indices.Close([]string{indexName}, c.esClient.Indices.Close.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to close index: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("error closing index: %s", string(body))
+	}
+
+	return nil
+}
+
 // IndexExists checks if an index exists
 func (c *Client) IndexExists(ctx context.Context, indexName string) (bool, error) {
 	res, err := c.esClient.Indices.Exists([]string{indexName}, c.esClient.Indices.Exists.WithContext(ctx))
@@ -263,6 +275,96 @@ func (c *Client) GetAllIndexDocCounts(ctx context.Context) ([]IndexDocCount, err
 	return counts, nil
 }
 
+// IndexStorage holds the on-disk size and document count of an index.
+type IndexStorage struct {
+	Name      string
+	SizeBytes int64
+	DocCount  int64
+}
+
+// GetAllIndexStorage returns store size (bytes) and document counts for every
+// non-system index using the _cat/indices API.
+func (c *Client) GetAllIndexStorage(ctx context.Context) ([]IndexStorage, error) {
+	res, err := c.esClient.Cat.Indices(
+		c.esClient.Cat.Indices.WithContext(ctx),
+		c.esClient.Cat.Indices.WithFormat("json"),
+		c.esClient.Cat.Indices.WithBytes("b"),
+		c.esClient.Cat.Indices.WithH("index", "docs.count", "store.size"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indices: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("error listing indices: %s", string(body))
+	}
+
+	var rows []map[string]string
+	if decodeErr := json.NewDecoder(res.Body).Decode(&rows); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode cat response: %w", decodeErr)
+	}
+
+	storage := make([]IndexStorage, 0, len(rows))
+	for _, row := range rows {
+		name := row["index"]
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		// Closed indexes report empty values; treat them as zero.
+		docCount, _ := strconv.ParseInt(row["docs.count"], 10, 64)
+		sizeBytes, _ := strconv.ParseInt(row["store.size"], 10, 64)
+		storage = append(storage, IndexStorage{Name: name, SizeBytes: sizeBytes, DocCount: docCount})
+	}
+
+	return storage, nil
+}
+
+// DiskUsage holds cluster-wide data disk usage summed across nodes.
+type DiskUsage struct {
+	TotalBytes int64
+	UsedBytes  int64
+}
+
+// GetDiskUsage returns data disk usage summed across all nodes using _cat/allocation.
+func (c *Client) GetDiskUsage(ctx context.Context) (*DiskUsage, error) {
+	res, err := c.esClient.Cat.Allocation(
+		c.esClient.Cat.Allocation.WithContext(ctx),
+		c.esClient.Cat.Allocation.WithFormat("json"),
+		c.esClient.Cat.Allocation.WithBytes("b"),
+		c.esClient.Cat.Allocation.WithH("node", "disk.used", "disk.total"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocation: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("error getting allocation: %s", string(body))
+	}
+
+	var rows []map[string]*string
+	if decodeErr := json.NewDecoder(res.Body).Decode(&rows); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode allocation response: %w", decodeErr)
+	}
+
+	usage := &DiskUsage{}
+	for _, row := range rows {
+		// UNASSIGNED rows have null disk values.
+		if row["disk.total"] == nil || row["disk.used"] == nil {
+			continue
+		}
+		total, _ := strconv.ParseInt(*row["disk.total"], 10, 64)
+		used, _ := strconv.ParseInt(*row["disk.used"], 10, 64)
+		usage.TotalBytes += total
+		usage.UsedBytes += used
+	}
+
+	return usage, nil
+}
+
 // extractDocumentCount extracts document count from stats data
 func extractDocumentCount(statsData map[string]any, indexName string) int64 {
 	indices, ok1 := statsData["indices"].(map[string]any)
@@ -611,6 +713,35 @@ func (c *Client) UpdateDocument(ctx context.Context, indexName, documentID strin
 	return nil
 }
 
+// ReplaceDocument overwrites a document's entire source. Unlike UpdateDocument,
+// fields missing from doc are removed.
+func (c *Client) ReplaceDocument(ctx context.Context, indexName, documentID string, doc map[string]any) error {
+	docJSON, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	res, err := c.esClient.Index(
+		indexName,
+		strings.NewReader(string(docJSON)),
+		c.esClient.Index.WithContext(ctx),
+		c.esClient.Index.WithDocumentID(documentID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to replace document: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("replace document returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // DeleteDocument deletes a document by ID from an index
 func (c *Client) DeleteDocument(ctx context.Context, indexName, documentID string) error {
 	res, err := c.esClient.Delete(indexName, documentID, c.esClient.Delete.WithContext(ctx))
@@ -700,6 +831,81 @@ func (c *Client) BulkDeleteDocuments(ctx context.Context, indexName string, docu
 	return nil
 }
 
+// BulkDocument is a document to index with the bulk API. An empty ID lets
+// Elasticsearch generate one.
+type BulkDocument struct {
+	ID     string
+	Source map[string]any
+}
+
+// BulkItemResult is the per-document outcome of a bulk index request, in
+// request order.
+type BulkItemResult struct {
+	ID     string
+	Status int
+	Error  string
+}
+
+// BulkIndexDocuments indexes documents in a single bulk request and returns
+// one result per document. Item failures are reported in the results, not
+// as an error.
+func (c *Client) BulkIndexDocuments(ctx context.Context, indexName string, docs []BulkDocument) ([]BulkItemResult, error) {
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var bulkBody bytes.Buffer
+	encoder := json.NewEncoder(&bulkBody)
+	for i := range docs {
+		meta := map[string]any{"_index": indexName}
+		if docs[i].ID != "" {
+			meta["_id"] = docs[i].ID
+		}
+		if err := encoder.Encode(map[string]any{"index": meta}); err != nil {
+			return nil, fmt.Errorf("failed to marshal bulk action: %w", err)
+		}
+		if err := encoder.Encode(docs[i].Source); err != nil {
+			return nil, fmt.Errorf("failed to marshal document: %w", err)
+		}
+	}
+
+	res, err := c.esClient.Bulk(&bulkBody, c.esClient.Bulk.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute bulk index: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("bulk index returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	var bulkResponse struct {
+		Items []map[string]struct {
+			ID     string          `json:"_id"`
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&bulkResponse); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode bulk index response: %w", decodeErr)
+	}
+
+	results := make([]BulkItemResult, 0, len(bulkResponse.Items))
+	for _, item := range bulkResponse.Items {
+		outcome := item["index"]
+		result := BulkItemResult{ID: outcome.ID, Status: outcome.Status}
+		if len(outcome.Error) > 0 {
+			result.Error = string(outcome.Error)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // Reindex copies documents from source index to destination index using the ES Reindex API.
 func (c *Client) Reindex(ctx context.Context, sourceIndex, destIndex string) (int64, error) {
 	body := map[string]any{
@@ -741,10 +947,23 @@ func (c *Client) Reindex(ctx context.Context, sourceIndex, destIndex string) (in
 // Used in the path without encoding so Elasticsearch receives the wildcard literally.
 const ClassifiedContentIndexPattern = "*_classified_content"
 
+// ContentIndexPattern matches all raw and classified content indexes.
+const ContentIndexPattern = "*_raw_content,*_classified_content"
+
 // SearchAllClassifiedContent executes a search across all classified content indexes.
+func (c *Client) SearchAllClassifiedContent(ctx context.Context, query map[string]any) (*esapi.Response, error) {
+	return c.searchIndexPattern(ctx, ClassifiedContentIndexPattern, query)
+}
+
+// SearchAllContent executes a search across all raw and classified content indexes.
+func (c *Client) SearchAllContent(ctx context.Context, query map[string]any) (*esapi.Response, error) {
+	return c.searchIndexPattern(ctx, ContentIndexPattern, query)
+}
+
+// searchIndexPattern executes a search across the indexes matching pattern.
 // Uses a raw request path so the wildcard (*) is not URL-encoded; the go-elasticsearch
 // client would otherwise encode it and Elasticsearch would match zero indices.
-func (c *Client) SearchAllClassifiedContent(ctx context.Context, query map[string]any) (*esapi.Response, error) {
+func (c *Client) searchIndexPattern(ctx context.Context, pattern string, query map[string]any) (*esapi.Response, error) {
 	queryJSON, err := json.Marshal(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal query: %w", err)
@@ -754,8 +973,8 @@ func (c *Client) SearchAllClassifiedContent(ctx context.Context, query map[strin
 	if err != nil {
 		return nil, fmt.Errorf("invalid elasticsearch URL: %w", err)
 	}
-	// Build path with wildcard unencoded. Opaque avoids path encoding so ES receives *_classified_content.
-	baseURL.Opaque = "//" + baseURL.Host + "/" + ClassifiedContentIndexPattern + "/_search"
+	// Build path with wildcard unencoded. Opaque avoids path encoding so ES receives the pattern as-is.
+	baseURL.Opaque = "//" + baseURL.Host + "/" + pattern + "/_search"
 	baseURL.Path = ""
 	baseURL.RawPath = ""
 
@@ -840,3 +840,41 @@ func TestSearchAllClassifiedContent_Success(t *testing.T) {
 		t.Errorf("status = %d, want 200", res.StatusCode)
 	}
 }
+
+// --- BulkIndexDocuments ---
+
+func TestBulkIndexDocuments_PerItemResults(t *testing.T) {
+	t.Helper()
+
+	var lines int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decoder := json.NewDecoder(r.Body)
+		for decoder.More() {
+			var line map[string]any
+			if err := decoder.Decode(&line); err != nil {
+				t.Errorf("invalid NDJSON line: %v", err)
+				return
+			}
+			lines++
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"errors":true,"items":[` +
+			`{"index":{"_id":"a","status":201}},` +
+			`{"index":{"_id":"gen","status":400,"error":{"type":"mapper_parsing_exception"}}}]}`))
+	})
+	client := newTestClient(t, handler)
+
+	results, err := client.BulkIndexDocuments(context.Background(), "test_index", []BulkDocument{
+		{ID: "a", Source: map[string]any{"title": "A"}},
+		{Source: map[string]any{"title": "B"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines != 4 {
+		t.Errorf("expected 4 NDJSON lines (action + source per doc), got %d", lines)
+	}
+	if len(results) != 2 || results[0].Status != 201 || results[1].Error == "" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
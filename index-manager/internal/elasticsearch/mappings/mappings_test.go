@@ -110,7 +110,7 @@ func TestGetRawContentMapping_Structure(t *testing.T) {
 	expectedFields := []string{
 		"id", "url", "source_name", "title", "raw_html", "raw_text",
 		"og_type", "og_title", "og_description", "og_image", "og_url",
-		"meta_description", "meta_keywords", "canonical_url", "author",
+		"meta_description", "meta_keywords", "canonical_url", "author", "language",
 		"crawled_at", "published_date", "classification_status", "classified_at",
 		"word_count", "article_section", "json_ld_data", "meta",
 	}
@@ -121,7 +121,7 @@ func TestGetRawContentMapping_Structure(t *testing.T) {
 		}
 	}
 
-	expectedFieldCount := 23
+	expectedFieldCount := 24
 	if len(properties) != expectedFieldCount {
 		t.Errorf("raw_content has %d fields, want %d", len(properties), expectedFieldCount)
 	}
@@ -392,6 +392,29 @@ func TestGetClassifiedContentMapping_NestedLocationFields(t *testing.T) {
 	}
 }
 
+func TestGetClassifiedContentMapping_NestedLocalityFields(t *testing.T) {
+	t.Helper()
+
+	mapping := mappings.GetClassifiedContentMapping(1, 1)
+	properties := mapping["mappings"].(map[string]any)["properties"].(map[string]any)
+
+	localityObj, ok := properties["locality"].(map[string]any)
+	if !ok {
+		t.Fatal("locality field missing or not an object")
+	}
+	localityProps, ok := localityObj["properties"].(map[string]any)
+	if !ok {
+		t.Fatal("locality.properties missing")
+	}
+
+	expectedLocalityFields := []string{"region", "home_region", "salience", "is_local"}
+	for _, field := range expectedLocalityFields {
+		if _, exists := localityProps[field]; !exists {
+			t.Errorf("locality missing field %q", field)
+		}
+	}
+}
+
 func TestGetClassifiedContentMapping_NestedMiningFields(t *testing.T) {
 	t.Helper()
 
@@ -527,6 +550,9 @@ func TestGetClassifiedContentMapping_HasEnglishAnalyzer(t *testing.T) {
 	if _, hasEnglish := analyzerMap["english_content"]; !hasEnglish {
 		t.Error("missing english_content analyzer")
 	}
+	if _, hasFrench := analyzerMap["french_content"]; !hasFrench {
+		t.Error("missing french_content analyzer")
+	}
 }
 
 func TestGetClassifiedContentMapping_TextFieldsUseEnglishAnalyzer(t *testing.T) {
@@ -4,8 +4,8 @@ package mappings
 // Bump major for breaking changes (field type changes, removals).
 // Bump minor for additions.
 const (
-	RawContentMappingVersion        = "2.0.0"
-	ClassifiedContentMappingVersion = "2.3.0"
+	RawContentMappingVersion        = "2.1.0"
+	ClassifiedContentMappingVersion = "2.7.0"
 	CommunityMappingVersion         = "1.0.0"
 )
 
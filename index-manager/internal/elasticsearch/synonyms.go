@@ -0,0 +1,50 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// EnsureSynonymsSet creates the synonyms set setID, empty, unless it exists.
+// An index whose analyzers reference a missing set is created unusable, so
+// the set must exist before such an index is. Existing rules are kept.
+func (c *Client) EnsureSynonymsSet(ctx context.Context, setID string) error {
+	res, err := c.esClient.SynonymsGetSynonym(setID,
+		c.esClient.SynonymsGetSynonym.WithContext(ctx),
+		c.esClient.SynonymsGetSynonym.WithSize(1),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get synonyms set: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if !res.IsError() {
+		return nil
+	}
+	if res.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("error getting synonyms set: %s", string(body))
+	}
+
+	put, err := c.esClient.SynonymsPutSynonym(setID, strings.NewReader(`{"synonyms_set":[]}`),
+		c.esClient.SynonymsPutSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create synonyms set: %w", err)
+	}
+	defer func() {
+		_ = put.Body.Close()
+	}()
+
+	if put.IsError() {
+		body, _ := io.ReadAll(put.Body)
+		return fmt.Errorf("error creating synonyms set: %s", string(body))
+	}
+
+	return nil
+}
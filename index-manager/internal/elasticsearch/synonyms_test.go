@@ -0,0 +1,67 @@
+package elasticsearch //nolint:testpackage // testing Client methods with httptest mock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestEnsureSynonymsSet_Exists(t *testing.T) {
+	t.Helper()
+
+	var methods []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"count":2,"synonyms_set":[]}`))
+	})
+	client := newTestClient(t, handler)
+
+	if err := client.EnsureSynonymsSet(context.Background(), "north-cloud-search"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("requests = %v, want a single GET", methods)
+	}
+}
+
+func TestEnsureSynonymsSet_Creates(t *testing.T) {
+	t.Helper()
+
+	var putPath, putBody string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"resource_not_found_exception"}}`))
+			return
+		}
+		putPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		putBody = string(body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":"created"}`))
+	})
+	client := newTestClient(t, handler)
+
+	if err := client.EnsureSynonymsSet(context.Background(), "north-cloud-search"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if putPath != "/_synonyms/north-cloud-search" || putBody != `{"synonyms_set":[]}` {
+		t.Errorf("PUT %s %s, want an empty north-cloud-search set", putPath, putBody)
+	}
+}
+
+func TestEnsureSynonymsSet_ESError(t *testing.T) {
+	t.Helper()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"boom"}`))
+	})
+	client := newTestClient(t, handler)
+
+	if err := client.EnsureSynonymsSet(context.Background(), "north-cloud-search"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,124 @@
+// Package notifier delivers source health alerts to external webhooks
+// (a generic JSON webhook and Slack incoming webhooks).
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+)
+
+// maxErrorBodyBytes caps how much of a failed webhook response is echoed in errors.
+const maxErrorBodyBytes = 512
+
+// Notifier sends a batch of source alerts somewhere.
+type Notifier interface {
+	Notify(ctx context.Context, alerts []domain.SourceAlert) error
+}
+
+// webhookPayload is the JSON body posted to generic webhooks.
+type webhookPayload struct {
+	Service string               `json:"service"`
+	Alerts  []domain.SourceAlert `json:"alerts"`
+}
+
+// slackPayload is the JSON body accepted by Slack incoming webhooks.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// WebhookNotifier posts alerts as JSON to a generic webhook URL.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts JSON alert batches to url.
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: timeout}),
+	}
+}
+
+// Notify implements Notifier.
+func (n *WebhookNotifier) Notify(ctx context.Context, alerts []domain.SourceAlert) error {
+	return postJSON(ctx, n.httpClient, n.url, webhookPayload{Service: "index-manager", Alerts: alerts})
+}
+
+// SlackNotifier posts alerts as a text message to a Slack incoming webhook.
+type SlackNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewSlackNotifier creates a notifier for a Slack incoming webhook URL.
+func NewSlackNotifier(url string, timeout time.Duration) *SlackNotifier {
+	return &SlackNotifier{
+		url:        url,
+		httpClient: infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: timeout}),
+	}
+}
+
+// Notify implements Notifier.
+func (n *SlackNotifier) Notify(ctx context.Context, alerts []domain.SourceAlert) error {
+	return postJSON(ctx, n.httpClient, n.url, slackPayload{Text: FormatSlackText(alerts)})
+}
+
+// FormatSlackText renders alerts as a Slack mrkdwn message.
+func FormatSlackText(alerts []domain.SourceAlert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ":warning: *%d source health alert(s)*", len(alerts))
+	for _, alert := range alerts {
+		fmt.Fprintf(&b, "\n• `%s` [%s] %s", alert.Source, alert.Rule, alert.Message)
+	}
+	return b.String()
+}
+
+// Multi fans alerts out to several notifiers, attempting all of them.
+type Multi []Notifier
+
+// Notify implements Notifier, joining the errors of failed notifiers.
+func (m Multi) Notify(ctx context.Context, alerts []domain.SourceAlert) error {
+	errs := make([]error, 0, len(m))
+	for _, n := range m {
+		if err := n.Notify(ctx, alerts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal alert payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
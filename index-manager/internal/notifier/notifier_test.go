@@ -0,0 +1,112 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/notifier"
+)
+
+const testTimeout = 5 * time.Second
+
+func testAlerts() []domain.SourceAlert {
+	return []domain.SourceAlert{{
+		Source:  "example_com",
+		Rule:    domain.SourceAlertRuleBacklog,
+		Message: "500 documents awaiting classification (max 100)",
+	}}
+}
+
+func TestWebhookNotifier_PostsJSON(t *testing.T) {
+	t.Helper()
+
+	var received struct {
+		Service string               `json:"service"`
+		Alerts  []domain.SourceAlert `json:"alerts"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := notifier.NewWebhookNotifier(server.URL, testTimeout)
+	if err := n.Notify(context.Background(), testAlerts()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if received.Service != "index-manager" || len(received.Alerts) != 1 || received.Alerts[0].Source != "example_com" {
+		t.Errorf("unexpected payload: %+v", received)
+	}
+}
+
+func TestWebhookNotifier_NonSuccessStatus(t *testing.T) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := notifier.NewWebhookNotifier(server.URL, testTimeout).Notify(context.Background(), testAlerts())
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Notify() error = %v, want status 500 error", err)
+	}
+}
+
+func TestSlackNotifier_PostsText(t *testing.T) {
+	t.Helper()
+
+	var received struct {
+		Text string `json:"text"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := notifier.NewSlackNotifier(server.URL, testTimeout).Notify(context.Background(), testAlerts()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if !strings.Contains(received.Text, "`example_com` [backlog]") {
+		t.Errorf("slack text = %q", received.Text)
+	}
+}
+
+func TestMulti_AttemptsAllNotifiers(t *testing.T) {
+	t.Helper()
+
+	calls := 0
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	multi := notifier.Multi{
+		notifier.NewWebhookNotifier(failing.URL, testTimeout),
+		notifier.NewWebhookNotifier(ok.URL, testTimeout),
+	}
+	if err := multi.Notify(context.Background(), testAlerts()); err == nil {
+		t.Error("expected joined error from failing notifier")
+	}
+	if calls != 1 {
+		t.Errorf("healthy notifier calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,50 @@
+// Package publisher provides a minimal client for the publisher's internal
+// API, used to retract content deleted from classified indexes from the
+// channels it was published to.
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+)
+
+// retractionsPath is the publisher's internal retraction endpoint.
+const retractionsPath = "/api/internal/v1/retractions"
+
+type retractionRequest struct {
+	ContentID string `json:"content_id"`
+	Reason    string `json:"reason"`
+}
+
+// Client is an HTTP client for the publisher internal API.
+type Client struct {
+	baseURL string
+	service *infrahttp.ServiceClient
+}
+
+// NewClient creates a publisher client for the given base URL
+// (e.g. "http://publisher:8070") and internal shared secret.
+func NewClient(baseURL, secret string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:        timeout,
+			InternalSecret: secret,
+		}),
+	}
+}
+
+// RequestRetraction asks the publisher to take a content item down from every
+// channel it was published to. The publisher processes it on its next poll.
+func (c *Client) RequestRetraction(ctx context.Context, contentID, reason string) error {
+	body := retractionRequest{ContentID: contentID, Reason: reason}
+	if err := c.service.Do(ctx, http.MethodPost, c.baseURL+retractionsPath, body, nil); err != nil {
+		return fmt.Errorf("request retraction: %w", err)
+	}
+	return nil
+}
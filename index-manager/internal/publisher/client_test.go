@@ -0,0 +1,40 @@
+package publisher_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/publisher"
+)
+
+func TestRequestRetraction(t *testing.T) {
+	t.Helper()
+
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/internal/v1/retractions" || r.Header.Get("X-Internal-Secret") != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	client := publisher.NewClient(srv.URL+"/", "s3cret", time.Second)
+	if err := client.RequestRetraction(context.Background(), "doc-1", "deleted in index-manager"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["content_id"] != "doc-1" || got["reason"] != "deleted in index-manager" {
+		t.Errorf("unexpected request body: %v", got)
+	}
+
+	wrongSecret := publisher.NewClient(srv.URL, "wrong", time.Second)
+	if err := wrongSecret.RequestRetraction(context.Background(), "doc-1", ""); err == nil {
+		t.Error("expected an error for a rejected request")
+	}
+}
@@ -0,0 +1,529 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+const (
+	defaultCutoverListLimit = 50
+	maxCutoverListLimit     = 500
+	// classifiedContentReadSuffix is the suffix matched by the wildcard that
+	// search and publisher read (*_classified_content).
+	classifiedContentReadSuffix = "_classified_content"
+)
+
+var (
+	// ErrCutoverInvalid is returned when a cutover request cannot be applied.
+	ErrCutoverInvalid = errors.New("invalid cutover")
+	// ErrCutoverNotActive is returned when rolling back a cutover that no longer serves its alias.
+	ErrCutoverNotActive = errors.New("cutover is not active")
+)
+
+// CutoverVerificationError is returned when verification fails and the cutover was not forced.
+type CutoverVerificationError struct {
+	Verification *domain.CutoverVerification
+}
+
+func (e *CutoverVerificationError) Error() string {
+	return fmt.Sprintf(
+		"cutover verification failed: count ratio %.4f (min %.4f), %d missing documents, %d field mismatches",
+		e.Verification.CountRatio, e.Verification.MinCountRatio,
+		len(e.Verification.MissingDocuments), len(e.Verification.FieldMismatches),
+	)
+}
+
+// CutoverESClient defines the Elasticsearch operations needed by CutoverService.
+// The concrete *elasticsearch.Client satisfies this interface.
+type CutoverESClient interface {
+	IndexExists(ctx context.Context, indexName string) (bool, error)
+	DeleteIndex(ctx context.Context, indexName string) error
+	GetAliasTargets(ctx context.Context, alias string) ([]string, error)
+	UpdateAliases(ctx context.Context, actions []elasticsearch.AliasAction) error
+	SetIndexWriteBlock(ctx context.Context, indexName string, blocked bool) error
+	CloneIndex(ctx context.Context, sourceIndex, targetIndex string) error
+	CountDocuments(ctx context.Context, indexName string) (int64, error)
+	SampleDocuments(ctx context.Context, indexName string, size int) ([]elasticsearch.SampledDocument, error)
+	MultiGetDocuments(ctx context.Context, indexName string, documentIDs []string) (map[string]map[string]any, error)
+}
+
+// CutoverStore persists index cutovers.
+type CutoverStore interface {
+	CreateIndexCutover(ctx context.Context, cutover *database.IndexCutover) error
+	GetIndexCutover(ctx context.Context, id int64) (*database.IndexCutover, error)
+	ListIndexCutovers(ctx context.Context, alias string, limit int) ([]*database.IndexCutover, error)
+	ListExpiredIndexCutovers(ctx context.Context, now time.Time) ([]*database.IndexCutover, error)
+	MarkIndexCutoverRolledBack(ctx context.Context, id int64) error
+	MarkIndexCutoverRetiredDeleted(ctx context.Context, id int64) error
+}
+
+// CutoverSettings controls verification and retention of cutovers.
+type CutoverSettings struct {
+	// GracePeriod is how long the previous index is kept for rollback.
+	GracePeriod time.Duration
+	// MinCountRatio is the minimum target/current document count ratio.
+	MinCountRatio float64
+	// SpotCheckSize is the number of random documents compared field by field.
+	SpotCheckSize int
+}
+
+// CutoverService swaps read aliases onto backfilled indexes (blue/green) and
+// keeps the previous index for rollback until its grace period ends.
+type CutoverService struct {
+	esClient CutoverESClient
+	store    CutoverStore
+	settings CutoverSettings
+	logger   infralogger.Logger
+}
+
+// NewCutoverService creates a new alias cutover service.
+func NewCutoverService(
+	esClient CutoverESClient,
+	store CutoverStore,
+	settings CutoverSettings,
+	logger infralogger.Logger,
+) *CutoverService {
+	return &CutoverService{
+		esClient: esClient,
+		store:    store,
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// Cutover verifies the target index against the alias and, unless dry-run,
+// atomically points the alias at the target. When the alias name is still a
+// concrete index, that index is write-blocked, cloned to a retired name for
+// rollback, and removed in the same _aliases call that creates the alias.
+func (s *CutoverService) Cutover(ctx context.Context, req *domain.CutoverRequest) (*domain.CutoverResponse, error) {
+	alias := strings.TrimSpace(req.Alias)
+	target := strings.TrimSpace(req.TargetIndex)
+
+	currentTargets, err := s.validateCutover(ctx, alias, target)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := s.verify(ctx, alias, target)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &domain.CutoverResponse{DryRun: req.DryRun, Verification: verification}
+	if req.DryRun {
+		return resp, nil
+	}
+	if !verification.Passed && !req.Force {
+		return nil, &CutoverVerificationError{Verification: verification}
+	}
+
+	now := time.Now()
+	var previous, retired string
+	if len(currentTargets) == 0 {
+		previous = alias
+		retired = fmt.Sprintf("%s_retired_%d", alias, now.Unix())
+		if swapErr := s.replaceIndexWithAlias(ctx, alias, target, retired); swapErr != nil {
+			return nil, swapErr
+		}
+	} else {
+		previous = currentTargets[0]
+		retired = previous
+		if swapErr := s.esClient.UpdateAliases(ctx, swapAliasActions(alias, previous, target)); swapErr != nil {
+			return nil, fmt.Errorf("swap alias: %w", swapErr)
+		}
+	}
+
+	retain := s.settings.GracePeriod
+	if req.RetainHours > 0 {
+		retain = time.Duration(req.RetainHours) * time.Hour
+	}
+
+	verificationJSON, err := json.Marshal(verification)
+	if err != nil {
+		return nil, fmt.Errorf("marshal verification: %w", err)
+	}
+
+	row := &database.IndexCutover{
+		Alias:         alias,
+		PreviousIndex: previous,
+		NewIndex:      target,
+		RetiredIndex:  retired,
+		Forced:        !verification.Passed,
+		Verification:  verificationJSON,
+		CreatedBy:     req.CreatedBy,
+		RetainUntil:   now.Add(retain).UTC(),
+	}
+	if createErr := s.store.CreateIndexCutover(ctx, row); createErr != nil {
+		return nil, fmt.Errorf("alias %s now points to %s but the cutover was not recorded: %w", alias, target, createErr)
+	}
+
+	s.logger.Info("Index cutover completed",
+		infralogger.String("alias", alias),
+		infralogger.String("previous_index", previous),
+		infralogger.String("new_index", target),
+		infralogger.String("retired_index", retired),
+		infralogger.Bool("forced", row.Forced),
+	)
+
+	cutover, err := toDomainCutover(row)
+	if err != nil {
+		return nil, err
+	}
+	resp.Cutover = cutover
+
+	return resp, nil
+}
+
+// validateCutover checks the request and returns the alias's current targets
+// (empty when the alias name is still a concrete index).
+func (s *CutoverService) validateCutover(ctx context.Context, alias, target string) ([]string, error) {
+	if alias == "" || target == "" {
+		return nil, fmt.Errorf("%w: alias and target_index are required", ErrCutoverInvalid)
+	}
+	if alias == target {
+		return nil, fmt.Errorf("%w: target_index must differ from alias", ErrCutoverInvalid)
+	}
+	if strings.HasSuffix(alias, classifiedContentReadSuffix) && strings.HasSuffix(target, classifiedContentReadSuffix) {
+		return nil, fmt.Errorf(
+			"%w: target_index must not end in %s or readers would see it twice",
+			ErrCutoverInvalid, classifiedContentReadSuffix,
+		)
+	}
+
+	targetExists, err := s.esClient.IndexExists(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("check target index: %w", err)
+	}
+	if !targetExists {
+		return nil, fmt.Errorf("%w: target index %s does not exist", ErrCutoverInvalid, target)
+	}
+
+	currentTargets, err := s.esClient.GetAliasTargets(ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("get alias targets: %w", err)
+	}
+	switch {
+	case len(currentTargets) > 1:
+		return nil, fmt.Errorf("%w: alias %s points to %d indexes", ErrCutoverInvalid, alias, len(currentTargets))
+	case len(currentTargets) == 1 && currentTargets[0] == target:
+		return nil, fmt.Errorf("%w: alias %s already points to %s", ErrCutoverInvalid, alias, target)
+	case len(currentTargets) == 1:
+		return currentTargets, nil
+	}
+
+	aliasExists, err := s.esClient.IndexExists(ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("check current index: %w", err)
+	}
+	if !aliasExists {
+		return nil, fmt.Errorf("%w: %s is neither an index nor an alias", ErrCutoverInvalid, alias)
+	}
+
+	return nil, nil
+}
+
+// verify compares document counts and spot-checks random documents from the
+// alias against the target index.
+func (s *CutoverService) verify(ctx context.Context, alias, target string) (*domain.CutoverVerification, error) {
+	sourceCount, err := s.esClient.CountDocuments(ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("count current documents: %w", err)
+	}
+	targetCount, err := s.esClient.CountDocuments(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("count target documents: %w", err)
+	}
+
+	v := &domain.CutoverVerification{
+		SourceCount:      sourceCount,
+		TargetCount:      targetCount,
+		CountRatio:       1,
+		MinCountRatio:    s.settings.MinCountRatio,
+		MissingDocuments: []string{},
+		FieldMismatches:  []domain.CutoverFieldMismatch{},
+	}
+	if sourceCount > 0 {
+		v.CountRatio = float64(targetCount) / float64(sourceCount)
+	}
+	v.CountOK = v.CountRatio >= s.settings.MinCountRatio
+
+	if s.settings.SpotCheckSize > 0 && sourceCount > 0 {
+		if spotErr := s.spotCheck(ctx, alias, target, v); spotErr != nil {
+			return nil, spotErr
+		}
+	}
+
+	v.Passed = v.CountOK && len(v.MissingDocuments) == 0 && len(v.FieldMismatches) == 0
+	return v, nil
+}
+
+// spotCheck requires every sampled document to exist in the target with at
+// least the same top-level fields. The target may add fields.
+func (s *CutoverService) spotCheck(ctx context.Context, alias, target string, v *domain.CutoverVerification) error {
+	samples, err := s.esClient.SampleDocuments(ctx, alias, s.settings.SpotCheckSize)
+	if err != nil {
+		return fmt.Errorf("sample current documents: %w", err)
+	}
+	v.Sampled = len(samples)
+	if len(samples) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(samples))
+	for _, sample := range samples {
+		ids = append(ids, sample.ID)
+	}
+
+	found, err := s.esClient.MultiGetDocuments(ctx, target, ids)
+	if err != nil {
+		return fmt.Errorf("get target documents: %w", err)
+	}
+
+	for _, sample := range samples {
+		targetDoc, ok := found[sample.ID]
+		if !ok {
+			v.MissingDocuments = append(v.MissingDocuments, sample.ID)
+			continue
+		}
+
+		var missing []string
+		for field := range sample.Source {
+			if _, present := targetDoc[field]; !present {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			v.FieldMismatches = append(v.FieldMismatches, domain.CutoverFieldMismatch{
+				DocumentID:    sample.ID,
+				MissingFields: missing,
+			})
+		}
+	}
+
+	return nil
+}
+
+// replaceIndexWithAlias retires the concrete index named alias and creates the
+// alias on target. Writes to the old index are blocked from the clone until the
+// swap, so nothing written in between is lost from the retired copy.
+func (s *CutoverService) replaceIndexWithAlias(ctx context.Context, alias, target, retired string) error {
+	if err := s.esClient.SetIndexWriteBlock(ctx, alias, true); err != nil {
+		return fmt.Errorf("block writes on %s: %w", alias, err)
+	}
+
+	if err := s.esClient.CloneIndex(ctx, alias, retired); err != nil {
+		s.unblockAfterFailure(ctx, alias)
+		return fmt.Errorf("clone %s to %s: %w", alias, retired, err)
+	}
+
+	if err := s.esClient.SetIndexWriteBlock(ctx, retired, false); err != nil {
+		s.abandonRetiredClone(ctx, alias, retired)
+		return fmt.Errorf("unblock writes on %s: %w", retired, err)
+	}
+
+	actions := []elasticsearch.AliasAction{
+		{Type: elasticsearch.AliasActionRemoveIndex, Index: alias},
+		{Type: elasticsearch.AliasActionAdd, Index: target, Alias: alias, IsWriteIndex: true},
+	}
+	if err := s.esClient.UpdateAliases(ctx, actions); err != nil {
+		s.abandonRetiredClone(ctx, alias, retired)
+		return fmt.Errorf("replace %s with alias: %w", alias, err)
+	}
+
+	return nil
+}
+
+// abandonRetiredClone undoes a partial cutover: the clone is deleted and writes
+// to the original index are re-enabled.
+func (s *CutoverService) abandonRetiredClone(ctx context.Context, alias, retired string) {
+	if err := s.esClient.DeleteIndex(ctx, retired); err != nil {
+		s.logger.Warn("Failed to delete retired clone after aborted cutover",
+			infralogger.String("retired_index", retired),
+			infralogger.Error(err),
+		)
+	}
+	s.unblockAfterFailure(ctx, alias)
+}
+
+func (s *CutoverService) unblockAfterFailure(ctx context.Context, indexName string) {
+	if err := s.esClient.SetIndexWriteBlock(ctx, indexName, false); err != nil {
+		s.logger.Error("Failed to re-enable writes after aborted cutover; index is still write-blocked",
+			infralogger.String("index_name", indexName),
+			infralogger.Error(err),
+		)
+	}
+}
+
+// swapAliasActions moves alias from one index to another in a single request.
+func swapAliasActions(alias, from, to string) []elasticsearch.AliasAction {
+	return []elasticsearch.AliasAction{
+		{Type: elasticsearch.AliasActionRemove, Index: from, Alias: alias},
+		{Type: elasticsearch.AliasActionAdd, Index: to, Alias: alias, IsWriteIndex: true},
+	}
+}
+
+// Rollback points the alias of an active cutover back at its retained index.
+func (s *CutoverService) Rollback(ctx context.Context, id int64) (*domain.IndexCutover, error) {
+	row, err := s.store.GetIndexCutover(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if row.Status != domain.CutoverStatusActive || row.RetiredDeletedAt.Valid {
+		return nil, fmt.Errorf("%w: cutover %d is %s", ErrCutoverNotActive, id, row.Status)
+	}
+
+	exists, err := s.esClient.IndexExists(ctx, row.RetiredIndex)
+	if err != nil {
+		return nil, fmt.Errorf("check retained index: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: retained index %s no longer exists", ErrCutoverNotActive, row.RetiredIndex)
+	}
+
+	if blockErr := s.esClient.SetIndexWriteBlock(ctx, row.RetiredIndex, false); blockErr != nil {
+		return nil, fmt.Errorf("unblock writes on %s: %w", row.RetiredIndex, blockErr)
+	}
+	if swapErr := s.esClient.UpdateAliases(ctx, swapAliasActions(row.Alias, row.NewIndex, row.RetiredIndex)); swapErr != nil {
+		return nil, fmt.Errorf("swap alias back: %w", swapErr)
+	}
+
+	if markErr := s.store.MarkIndexCutoverRolledBack(ctx, id); markErr != nil {
+		return nil, fmt.Errorf("alias %s rolled back to %s but the cutover was not updated: %w",
+			row.Alias, row.RetiredIndex, markErr)
+	}
+
+	s.logger.Info("Index cutover rolled back",
+		infralogger.Int64("cutover_id", id),
+		infralogger.String("alias", row.Alias),
+		infralogger.String("restored_index", row.RetiredIndex),
+		infralogger.String("abandoned_index", row.NewIndex),
+	)
+
+	updated, err := s.store.GetIndexCutover(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainCutover(updated)
+}
+
+// GetCutover returns a single cutover.
+func (s *CutoverService) GetCutover(ctx context.Context, id int64) (*domain.IndexCutover, error) {
+	row, err := s.store.GetIndexCutover(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainCutover(row)
+}
+
+// ListCutovers returns cutovers newest first, optionally for a single alias.
+func (s *CutoverService) ListCutovers(ctx context.Context, alias string, limit int) ([]*domain.IndexCutover, error) {
+	if limit <= 0 || limit > maxCutoverListLimit {
+		limit = defaultCutoverListLimit
+	}
+
+	rows, err := s.store.ListIndexCutovers(ctx, alias, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list cutovers: %w", err)
+	}
+
+	cutovers := make([]*domain.IndexCutover, 0, len(rows))
+	for _, row := range rows {
+		cutover, convErr := toDomainCutover(row)
+		if convErr != nil {
+			return nil, convErr
+		}
+		cutovers = append(cutovers, cutover)
+	}
+
+	return cutovers, nil
+}
+
+// SweepRetiredIndexes deletes retained indexes whose grace period has ended.
+// An index still serving its alias is never deleted.
+func (s *CutoverService) SweepRetiredIndexes(ctx context.Context) (int, error) {
+	rows, err := s.store.ListExpiredIndexCutovers(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("list expired cutovers: %w", err)
+	}
+
+	deleted := 0
+	for _, row := range rows {
+		targets, aliasErr := s.esClient.GetAliasTargets(ctx, row.Alias)
+		if aliasErr != nil {
+			return deleted, fmt.Errorf("get alias targets for %s: %w", row.Alias, aliasErr)
+		}
+		if slices.Contains(targets, row.RetiredIndex) {
+			s.logger.Warn("Retained index still serves its alias; not deleting",
+				infralogger.Int64("cutover_id", row.ID),
+				infralogger.String("alias", row.Alias),
+				infralogger.String("retired_index", row.RetiredIndex),
+			)
+			continue
+		}
+
+		exists, existsErr := s.esClient.IndexExists(ctx, row.RetiredIndex)
+		if existsErr != nil {
+			return deleted, fmt.Errorf("check retained index %s: %w", row.RetiredIndex, existsErr)
+		}
+		if exists {
+			if deleteErr := s.esClient.DeleteIndex(ctx, row.RetiredIndex); deleteErr != nil {
+				return deleted, fmt.Errorf("delete retained index %s: %w", row.RetiredIndex, deleteErr)
+			}
+		}
+
+		if markErr := s.store.MarkIndexCutoverRetiredDeleted(ctx, row.ID); markErr != nil {
+			return deleted, fmt.Errorf("mark cutover %d swept: %w", row.ID, markErr)
+		}
+		deleted++
+
+		s.logger.Info("Deleted retained index after cutover grace period",
+			infralogger.Int64("cutover_id", row.ID),
+			infralogger.String("alias", row.Alias),
+			infralogger.String("retired_index", row.RetiredIndex),
+		)
+	}
+
+	return deleted, nil
+}
+
+func toDomainCutover(row *database.IndexCutover) (*domain.IndexCutover, error) {
+	cutover := &domain.IndexCutover{
+		ID:            row.ID,
+		Alias:         row.Alias,
+		PreviousIndex: row.PreviousIndex,
+		NewIndex:      row.NewIndex,
+		RetiredIndex:  row.RetiredIndex,
+		Status:        row.Status,
+		Forced:        row.Forced,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt,
+		RetainUntil:   row.RetainUntil,
+	}
+	if len(row.Verification) > 0 {
+		cutover.Verification = &domain.CutoverVerification{}
+		if err := json.Unmarshal(row.Verification, cutover.Verification); err != nil {
+			return nil, fmt.Errorf("decode cutover %d verification: %w", row.ID, err)
+		}
+	}
+	if row.RolledBackAt.Valid {
+		t := row.RolledBackAt.Time
+		cutover.RolledBackAt = &t
+	}
+	if row.RetiredDeletedAt.Valid {
+		t := row.RetiredDeletedAt.Time
+		cutover.RetiredDeletedAt = &t
+	}
+	return cutover, nil
+}
@@ -0,0 +1,438 @@
+//nolint:testpackage // Testing unexported helpers requires same package access
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+)
+
+// --- mocks ---
+
+type mockCutoverES struct {
+	indexes      map[string]bool
+	aliases      map[string][]string
+	counts       map[string]int64
+	samples      []elasticsearch.SampledDocument
+	targetDocs   map[string]map[string]any
+	aliasActions [][]elasticsearch.AliasAction
+	writeBlocks  map[string]bool
+	clones       []string
+	deleted      []string
+	updateErr    error
+}
+
+func newMockCutoverES() *mockCutoverES {
+	return &mockCutoverES{
+		indexes:     map[string]bool{},
+		aliases:     map[string][]string{},
+		counts:      map[string]int64{},
+		targetDocs:  map[string]map[string]any{},
+		writeBlocks: map[string]bool{},
+	}
+}
+
+func (m *mockCutoverES) IndexExists(_ context.Context, indexName string) (bool, error) {
+	return m.indexes[indexName], nil
+}
+
+func (m *mockCutoverES) DeleteIndex(_ context.Context, indexName string) error {
+	m.deleted = append(m.deleted, indexName)
+	delete(m.indexes, indexName)
+	return nil
+}
+
+func (m *mockCutoverES) GetAliasTargets(_ context.Context, alias string) ([]string, error) {
+	return m.aliases[alias], nil
+}
+
+func (m *mockCutoverES) UpdateAliases(_ context.Context, actions []elasticsearch.AliasAction) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.aliasActions = append(m.aliasActions, actions)
+	return nil
+}
+
+func (m *mockCutoverES) SetIndexWriteBlock(_ context.Context, indexName string, blocked bool) error {
+	m.writeBlocks[indexName] = blocked
+	return nil
+}
+
+func (m *mockCutoverES) CloneIndex(_ context.Context, _, targetIndex string) error {
+	m.clones = append(m.clones, targetIndex)
+	m.indexes[targetIndex] = true
+	return nil
+}
+
+func (m *mockCutoverES) CountDocuments(_ context.Context, indexName string) (int64, error) {
+	return m.counts[indexName], nil
+}
+
+func (m *mockCutoverES) SampleDocuments(_ context.Context, _ string, size int) ([]elasticsearch.SampledDocument, error) {
+	return m.samples[:min(size, len(m.samples))], nil
+}
+
+func (m *mockCutoverES) MultiGetDocuments(_ context.Context, _ string, ids []string) (map[string]map[string]any, error) {
+	found := make(map[string]map[string]any)
+	for _, id := range ids {
+		if doc, ok := m.targetDocs[id]; ok {
+			found[id] = doc
+		}
+	}
+	return found, nil
+}
+
+type mockCutoverStore struct {
+	rows map[int64]*database.IndexCutover
+}
+
+func newMockCutoverStore() *mockCutoverStore {
+	return &mockCutoverStore{rows: map[int64]*database.IndexCutover{}}
+}
+
+func (m *mockCutoverStore) CreateIndexCutover(_ context.Context, cutover *database.IndexCutover) error {
+	for _, row := range m.rows {
+		if row.Alias == cutover.Alias && row.Status == domain.CutoverStatusActive {
+			row.Status = domain.CutoverStatusSuperseded
+		}
+	}
+	cutover.ID = int64(len(m.rows) + 1)
+	cutover.Status = domain.CutoverStatusActive
+	cutover.CreatedAt = time.Now()
+	m.rows[cutover.ID] = cutover
+	return nil
+}
+
+func (m *mockCutoverStore) GetIndexCutover(_ context.Context, id int64) (*database.IndexCutover, error) {
+	row, ok := m.rows[id]
+	if !ok {
+		return nil, database.ErrIndexCutoverNotFound
+	}
+	return row, nil
+}
+
+func (m *mockCutoverStore) ListIndexCutovers(_ context.Context, _ string, _ int) ([]*database.IndexCutover, error) {
+	return nil, nil
+}
+
+func (m *mockCutoverStore) ListExpiredIndexCutovers(_ context.Context, now time.Time) ([]*database.IndexCutover, error) {
+	var expired []*database.IndexCutover
+	for _, row := range m.rows {
+		if !row.RetiredDeletedAt.Valid && row.Status != domain.CutoverStatusRolledBack && row.RetainUntil.Before(now) {
+			expired = append(expired, row)
+		}
+	}
+	return expired, nil
+}
+
+func (m *mockCutoverStore) MarkIndexCutoverRolledBack(_ context.Context, id int64) error {
+	m.rows[id].Status = domain.CutoverStatusRolledBack
+	m.rows[id].RolledBackAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+func (m *mockCutoverStore) MarkIndexCutoverRetiredDeleted(_ context.Context, id int64) error {
+	m.rows[id].RetiredDeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// --- helpers ---
+
+const (
+	testAlias  = "example_com_classified_content"
+	testTarget = "example_com_classified_content_v2"
+)
+
+func newTestCutoverService(es *mockCutoverES, store *mockCutoverStore) *CutoverService {
+	return NewCutoverService(es, store, CutoverSettings{
+		GracePeriod:   24 * time.Hour,
+		MinCountRatio: 0.99,
+		SpotCheckSize: 10,
+	}, &noopLogger{})
+}
+
+// healthyCutoverES is a concrete alias index with a fully backfilled target.
+func healthyCutoverES() *mockCutoverES {
+	es := newMockCutoverES()
+	es.indexes[testAlias] = true
+	es.indexes[testTarget] = true
+	es.counts[testAlias] = 1000
+	es.counts[testTarget] = 1000
+	es.samples = []elasticsearch.SampledDocument{
+		{ID: "a", Source: map[string]any{"title": "A", "url": "https://a"}},
+		{ID: "b", Source: map[string]any{"title": "B", "url": "https://b"}},
+	}
+	es.targetDocs["a"] = map[string]any{"title": "A", "url": "https://a", "topics": []string{"news"}}
+	es.targetDocs["b"] = map[string]any{"title": "B", "url": "https://b"}
+	return es
+}
+
+// --- tests ---
+
+func TestCutover_ConcreteIndexIsRetiredAndReplacedByAlias(t *testing.T) {
+	t.Helper()
+
+	es := healthyCutoverES()
+	store := newMockCutoverStore()
+	svc := newTestCutoverService(es, store)
+
+	resp, err := svc.Cutover(context.Background(), &domain.CutoverRequest{Alias: testAlias, TargetIndex: testTarget})
+	if err != nil {
+		t.Fatalf("Cutover: %v", err)
+	}
+	if !resp.Verification.Passed {
+		t.Fatalf("expected verification to pass: %+v", resp.Verification)
+	}
+
+	if len(es.clones) != 1 || !strings.HasPrefix(es.clones[0], testAlias+"_retired_") {
+		t.Fatalf("expected one retired clone, got %v", es.clones)
+	}
+	retired := es.clones[0]
+	if !es.writeBlocks[testAlias] {
+		t.Error("expected the old index to stay write-blocked")
+	}
+	if es.writeBlocks[retired] {
+		t.Error("expected the retired clone to be writable for rollback")
+	}
+
+	if len(es.aliasActions) != 1 {
+		t.Fatalf("expected one atomic alias update, got %d", len(es.aliasActions))
+	}
+	actions := es.aliasActions[0]
+	if actions[0].Type != elasticsearch.AliasActionRemoveIndex || actions[0].Index != testAlias {
+		t.Errorf("expected remove_index of %s first, got %+v", testAlias, actions[0])
+	}
+	if actions[1].Type != elasticsearch.AliasActionAdd || actions[1].Index != testTarget || !actions[1].IsWriteIndex {
+		t.Errorf("expected write alias add on %s, got %+v", testTarget, actions[1])
+	}
+
+	cutover := resp.Cutover
+	if cutover == nil || cutover.RetiredIndex != retired || cutover.PreviousIndex != testAlias {
+		t.Fatalf("unexpected cutover record: %+v", cutover)
+	}
+	if cutover.Forced {
+		t.Error("expected unforced cutover")
+	}
+	if until := time.Until(cutover.RetainUntil); until < 23*time.Hour || until > 25*time.Hour {
+		t.Errorf("expected retain_until about 24h out, got %s", until)
+	}
+}
+
+func TestCutover_ExistingAliasIsSwapped(t *testing.T) {
+	t.Helper()
+
+	es := healthyCutoverES()
+	delete(es.indexes, testAlias)
+	es.aliases[testAlias] = []string{"example_com_classified_content_v1"}
+	svc := newTestCutoverService(es, newMockCutoverStore())
+
+	resp, err := svc.Cutover(context.Background(), &domain.CutoverRequest{
+		Alias: testAlias, TargetIndex: testTarget, RetainHours: 48,
+	})
+	if err != nil {
+		t.Fatalf("Cutover: %v", err)
+	}
+
+	if len(es.clones) != 0 {
+		t.Errorf("expected no clone for an existing alias, got %v", es.clones)
+	}
+	actions := es.aliasActions[0]
+	if actions[0].Type != elasticsearch.AliasActionRemove || actions[0].Index != "example_com_classified_content_v1" {
+		t.Errorf("expected alias removal from v1, got %+v", actions[0])
+	}
+	if resp.Cutover.RetiredIndex != "example_com_classified_content_v1" {
+		t.Errorf("expected v1 retained, got %s", resp.Cutover.RetiredIndex)
+	}
+	if until := time.Until(resp.Cutover.RetainUntil); until < 47*time.Hour {
+		t.Errorf("expected retain_hours override, got %s", until)
+	}
+}
+
+func TestCutover_VerificationFailureBlocksUnlessForced(t *testing.T) {
+	t.Helper()
+
+	es := healthyCutoverES()
+	es.counts[testTarget] = 900
+	delete(es.targetDocs, "b")
+	delete(es.targetDocs["a"], "url")
+	svc := newTestCutoverService(es, newMockCutoverStore())
+
+	_, err := svc.Cutover(context.Background(), &domain.CutoverRequest{Alias: testAlias, TargetIndex: testTarget})
+	var verifyErr *CutoverVerificationError
+	if !errors.As(err, &verifyErr) {
+		t.Fatalf("expected CutoverVerificationError, got %v", err)
+	}
+	v := verifyErr.Verification
+	if v.CountOK {
+		t.Error("expected count check to fail at ratio 0.9")
+	}
+	if !slices.Equal(v.MissingDocuments, []string{"b"}) {
+		t.Errorf("expected document b missing, got %v", v.MissingDocuments)
+	}
+	if len(v.FieldMismatches) != 1 || !slices.Equal(v.FieldMismatches[0].MissingFields, []string{"url"}) {
+		t.Errorf("expected url missing on a, got %+v", v.FieldMismatches)
+	}
+	if len(es.aliasActions) != 0 {
+		t.Fatal("expected no alias change after failed verification")
+	}
+
+	resp, err := svc.Cutover(context.Background(), &domain.CutoverRequest{
+		Alias: testAlias, TargetIndex: testTarget, Force: true,
+	})
+	if err != nil {
+		t.Fatalf("forced Cutover: %v", err)
+	}
+	if !resp.Cutover.Forced {
+		t.Error("expected cutover recorded as forced")
+	}
+}
+
+func TestCutover_DryRunDoesNotSwap(t *testing.T) {
+	t.Helper()
+
+	es := healthyCutoverES()
+	store := newMockCutoverStore()
+	svc := newTestCutoverService(es, store)
+
+	resp, err := svc.Cutover(context.Background(), &domain.CutoverRequest{
+		Alias: testAlias, TargetIndex: testTarget, DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("Cutover: %v", err)
+	}
+	if resp.Cutover != nil || len(es.aliasActions) != 0 || len(store.rows) != 0 {
+		t.Error("expected dry run to leave aliases and history untouched")
+	}
+	if resp.Verification.Sampled != 2 {
+		t.Errorf("expected 2 sampled documents, got %d", resp.Verification.Sampled)
+	}
+}
+
+func TestCutover_Validation(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name   string
+		alias  string
+		target string
+		setup  func(*mockCutoverES)
+	}{
+		{name: "same name", alias: testAlias, target: testAlias},
+		{name: "target read twice", alias: testAlias, target: "example_com_v2_classified_content"},
+		{name: "missing target", alias: testAlias, target: testTarget, setup: func(es *mockCutoverES) {
+			delete(es.indexes, testTarget)
+		}},
+		{name: "already cut over", alias: testAlias, target: testTarget, setup: func(es *mockCutoverES) {
+			es.aliases[testAlias] = []string{testTarget}
+		}},
+		{name: "unknown alias", alias: "missing_classified_content", target: testTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := healthyCutoverES()
+			if tt.setup != nil {
+				tt.setup(es)
+			}
+			svc := newTestCutoverService(es, newMockCutoverStore())
+
+			_, err := svc.Cutover(context.Background(), &domain.CutoverRequest{Alias: tt.alias, TargetIndex: tt.target})
+			if !errors.Is(err, ErrCutoverInvalid) {
+				t.Errorf("expected ErrCutoverInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCutover_AliasFailureRestoresOldIndex(t *testing.T) {
+	t.Helper()
+
+	es := healthyCutoverES()
+	es.updateErr = errors.New("boom")
+	store := newMockCutoverStore()
+	svc := newTestCutoverService(es, store)
+
+	if _, err := svc.Cutover(context.Background(), &domain.CutoverRequest{Alias: testAlias, TargetIndex: testTarget}); err == nil {
+		t.Fatal("expected error")
+	}
+	if es.writeBlocks[testAlias] {
+		t.Error("expected writes re-enabled on the old index")
+	}
+	if len(es.deleted) != 1 || es.deleted[0] != es.clones[0] {
+		t.Errorf("expected the retired clone deleted, got %v", es.deleted)
+	}
+	if len(store.rows) != 0 {
+		t.Error("expected no cutover recorded")
+	}
+}
+
+func TestRollback_RestoresRetainedIndex(t *testing.T) {
+	t.Helper()
+
+	es := healthyCutoverES()
+	store := newMockCutoverStore()
+	svc := newTestCutoverService(es, store)
+
+	resp, err := svc.Cutover(context.Background(), &domain.CutoverRequest{Alias: testAlias, TargetIndex: testTarget})
+	if err != nil {
+		t.Fatalf("Cutover: %v", err)
+	}
+
+	rolledBack, err := svc.Rollback(context.Background(), resp.Cutover.ID)
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if rolledBack.Status != domain.CutoverStatusRolledBack || rolledBack.RolledBackAt == nil {
+		t.Errorf("expected rolled back status, got %+v", rolledBack)
+	}
+
+	actions := es.aliasActions[len(es.aliasActions)-1]
+	if actions[0].Index != testTarget || actions[1].Index != resp.Cutover.RetiredIndex {
+		t.Errorf("expected alias moved from target back to retained index, got %+v", actions)
+	}
+
+	if _, err = svc.Rollback(context.Background(), resp.Cutover.ID); !errors.Is(err, ErrCutoverNotActive) {
+		t.Errorf("expected ErrCutoverNotActive on second rollback, got %v", err)
+	}
+}
+
+func TestSweepRetiredIndexes(t *testing.T) {
+	t.Helper()
+
+	es := newMockCutoverES()
+	es.indexes["old_retired"] = true
+	es.indexes["serving"] = true
+	es.aliases["serving_alias"] = []string{"serving"}
+	store := newMockCutoverStore()
+	past := time.Now().Add(-time.Hour)
+	store.rows[1] = &database.IndexCutover{
+		ID: 1, Alias: "a", RetiredIndex: "old_retired", Status: domain.CutoverStatusSuperseded, RetainUntil: past,
+	}
+	store.rows[2] = &database.IndexCutover{
+		ID: 2, Alias: "serving_alias", RetiredIndex: "serving", Status: domain.CutoverStatusActive, RetainUntil: past,
+	}
+	store.rows[3] = &database.IndexCutover{
+		ID: 3, Alias: "b", RetiredIndex: "later", Status: domain.CutoverStatusActive, RetainUntil: time.Now().Add(time.Hour),
+	}
+	svc := newTestCutoverService(es, store)
+
+	deleted, err := svc.SweepRetiredIndexes(context.Background())
+	if err != nil {
+		t.Fatalf("SweepRetiredIndexes: %v", err)
+	}
+	if deleted != 1 || !slices.Equal(es.deleted, []string{"old_retired"}) {
+		t.Errorf("expected only old_retired deleted, got %d %v", deleted, es.deleted)
+	}
+	if !store.rows[1].RetiredDeletedAt.Valid || store.rows[2].RetiredDeletedAt.Valid {
+		t.Error("expected only cutover 1 marked swept")
+	}
+}
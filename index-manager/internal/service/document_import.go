@@ -0,0 +1,352 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch/mappings"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/naming"
+)
+
+const (
+	// importBatchSize is the number of documents sent per ES bulk request.
+	importBatchSize = 500
+	// maxImportLineBytes bounds a single NDJSON document.
+	maxImportLineBytes = 10 << 20
+	// maxImportErrors caps the per-line errors returned in a result.
+	maxImportErrors = 1000
+	// liveMappingSchema labels imports validated against the index's own mapping.
+	liveMappingSchema = "index_mapping"
+)
+
+// ErrIndexNotFound is returned when an import targets a missing index.
+var ErrIndexNotFound = errors.New("index not found")
+
+// DocumentImportESClient defines the Elasticsearch operations needed by DocumentImportService.
+// The concrete *elasticsearch.Client satisfies this interface.
+type DocumentImportESClient interface {
+	IndexExists(ctx context.Context, indexName string) (bool, error)
+	GetIndexMapping(ctx context.Context, indexName string) (map[string]any, error)
+	BulkIndexDocuments(ctx context.Context, indexName string, docs []elasticsearch.BulkDocument) ([]elasticsearch.BulkItemResult, error)
+}
+
+// DocumentImportService imports NDJSON documents into an index through the
+// ES bulk API after validating each one against the index type's schema.
+type DocumentImportService struct {
+	esClient DocumentImportESClient
+	logger   infralogger.Logger
+}
+
+// NewDocumentImportService creates a new bulk document import service.
+func NewDocumentImportService(esClient DocumentImportESClient, logger infralogger.Logger) *DocumentImportService {
+	return &DocumentImportService{esClient: esClient, logger: logger}
+}
+
+// pendingDocument is a validated document waiting for the next bulk request.
+type pendingDocument struct {
+	line int
+	doc  elasticsearch.BulkDocument
+}
+
+// ImportNDJSON reads one JSON document per line and indexes valid documents
+// in batches. idField, when set, names the document field used as the ES _id.
+// Invalid lines and rejected items are reported per line; only failures to
+// reach Elasticsearch abort the import.
+func (s *DocumentImportService) ImportNDJSON(
+	ctx context.Context,
+	indexName string,
+	body io.Reader,
+	idField string,
+) (*domain.DocumentImportResult, error) {
+	exists, err := s.esClient.IndexExists(ctx, indexName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check index existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrIndexNotFound, indexName)
+	}
+
+	schemaName, properties, err := s.schemaFor(ctx, indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.DocumentImportResult{IndexName: indexName, Schema: schemaName}
+	batch := make([]pendingDocument, 0, importBatchSize)
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxImportLineBytes)
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		result.Total++
+
+		doc, parseErr := parseImportLine(raw, idField)
+		if parseErr == nil {
+			parseErr = validateAgainstSchema(properties, doc.Source)
+		}
+		if parseErr != nil {
+			recordImportError(result, line, doc.ID, parseErr.Error())
+			continue
+		}
+
+		batch = append(batch, pendingDocument{line: line, doc: doc})
+		if len(batch) >= importBatchSize {
+			if flushErr := s.flushImport(ctx, indexName, batch, result); flushErr != nil {
+				return result, flushErr
+			}
+			batch = batch[:0]
+		}
+	}
+	if scanErr := scanner.Err(); scanErr != nil {
+		return result, fmt.Errorf("failed to read NDJSON at line %d: %w", line+1, scanErr)
+	}
+	if flushErr := s.flushImport(ctx, indexName, batch, result); flushErr != nil {
+		return result, flushErr
+	}
+
+	s.logger.Info("Imported documents",
+		infralogger.String("index_name", indexName),
+		infralogger.Int("total", result.Total),
+		infralogger.Int("indexed", result.Indexed),
+		infralogger.Int("failed", result.Failed),
+	)
+
+	return result, nil
+}
+
+// schemaFor returns the mapping properties documents are validated against:
+// the canonical mapping for content index types, otherwise the live mapping.
+func (s *DocumentImportService) schemaFor(ctx context.Context, indexName string) (string, map[string]any, error) {
+	var indexType domain.IndexType
+	switch {
+	case naming.IsRawContentIndex(indexName):
+		indexType = domain.IndexTypeRawContent
+	case naming.IsClassifiedContentIndex(indexName):
+		indexType = domain.IndexTypeClassifiedContent
+	}
+
+	if indexType != "" {
+		mapping, err := mappings.GetMappingForType(string(indexType), 1, 0)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to load %s schema: %w", indexType, err)
+		}
+		return string(indexType), mappingProperties(mapping), nil
+	}
+
+	mapping, err := s.esClient.GetIndexMapping(ctx, indexName)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load index mapping: %w", err)
+	}
+	return liveMappingSchema, mappingProperties(mapping), nil
+}
+
+// flushImport sends a batch and records per-item failures.
+func (s *DocumentImportService) flushImport(
+	ctx context.Context,
+	indexName string,
+	batch []pendingDocument,
+	result *domain.DocumentImportResult,
+) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	docs := make([]elasticsearch.BulkDocument, 0, len(batch))
+	for i := range batch {
+		docs = append(docs, batch[i].doc)
+	}
+
+	items, err := s.esClient.BulkIndexDocuments(ctx, indexName, docs)
+	if err != nil {
+		return fmt.Errorf("bulk index failed: %w", err)
+	}
+
+	for i := range batch {
+		if i >= len(items) {
+			recordImportError(result, batch[i].line, batch[i].doc.ID, "no result returned by elasticsearch")
+			continue
+		}
+		if items[i].Error != "" {
+			recordImportError(result, batch[i].line, items[i].ID, items[i].Error)
+			continue
+		}
+		result.Indexed++
+	}
+	return nil
+}
+
+func recordImportError(result *domain.DocumentImportResult, line int, documentID, message string) {
+	result.Failed++
+	if len(result.Errors) < maxImportErrors {
+		result.Errors = append(result.Errors, domain.DocumentImportError{
+			Line:       line,
+			DocumentID: documentID,
+			Error:      message,
+		})
+	}
+}
+
+// parseImportLine decodes one NDJSON line. When idField is set the field
+// must hold a string or number and is used as the document ID.
+func parseImportLine(raw []byte, idField string) (elasticsearch.BulkDocument, error) {
+	var source map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&source); err != nil {
+		return elasticsearch.BulkDocument{}, fmt.Errorf("invalid JSON object: %w", err)
+	}
+	if source == nil {
+		return elasticsearch.BulkDocument{}, errors.New("line is not a JSON object")
+	}
+
+	doc := elasticsearch.BulkDocument{Source: source}
+	if idField == "" {
+		return doc, nil
+	}
+
+	switch id := source[idField].(type) {
+	case string:
+		doc.ID = id
+	case json.Number:
+		doc.ID = id.String()
+	}
+	if doc.ID == "" {
+		return doc, fmt.Errorf("missing %s", idField)
+	}
+	return doc, nil
+}
+
+// mappingProperties extracts top-level properties from either a create body
+// ({"mappings":{"properties":…}}) or a bare mapping ({"properties":…}).
+func mappingProperties(mapping map[string]any) map[string]any {
+	root := mapping
+	if inner, ok := mapping["mappings"].(map[string]any); ok {
+		root = inner
+	}
+	properties, _ := root["properties"].(map[string]any)
+	return properties
+}
+
+// validateAgainstSchema rejects fields the strict mappings would refuse and
+// values whose JSON type cannot be coerced to the mapped field type.
+func validateAgainstSchema(properties, doc map[string]any) error {
+	if len(properties) == 0 {
+		return nil
+	}
+	problems := validateFields("", properties, doc)
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, "; "))
+}
+
+func validateFields(prefix string, properties, doc map[string]any) []string {
+	var problems []string
+	for _, name := range slices.Sorted(maps.Keys(doc)) {
+		value := doc[name]
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		field, ok := properties[name].(map[string]any)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not in the index schema", path))
+			continue
+		}
+		problems = append(problems, validateValue(path, field, value)...)
+	}
+	return problems
+}
+
+func validateValue(path string, field map[string]any, value any) []string {
+	if value == nil {
+		return nil
+	}
+	if values, isArray := value.([]any); isArray {
+		var problems []string
+		for _, item := range values {
+			problems = append(problems, validateValue(path, field, item)...)
+		}
+		return problems
+	}
+
+	if nested, hasProperties := field["properties"].(map[string]any); hasProperties {
+		object, isObject := value.(map[string]any)
+		if !isObject {
+			return []string{fmt.Sprintf("%s must be an object", path)}
+		}
+		return validateFields(path, nested, object)
+	}
+
+	fieldType, _ := field["type"].(string)
+	if !valueMatchesType(fieldType, value) {
+		return []string{fmt.Sprintf("%s must be %s, got %T", path, fieldType, value)}
+	}
+	return nil
+}
+
+// valueMatchesType mirrors Elasticsearch's default coercion: numeric strings
+// are accepted for numbers and dates may be strings or epoch millis. Types
+// not listed (geo_point, dense_vector, …) are left to Elasticsearch.
+func valueMatchesType(fieldType string, value any) bool {
+	switch fieldType {
+	case "keyword", "text", "wildcard", "constant_keyword", "match_only_text":
+		_, ok := value.(string)
+		return ok
+	case "long", "integer", "short", "byte", "unsigned_long":
+		switch v := value.(type) {
+		case json.Number:
+			_, err := v.Int64()
+			return err == nil
+		case string:
+			_, err := json.Number(v).Int64()
+			return err == nil
+		}
+		return false
+	case "float", "double", "half_float", "scaled_float":
+		switch v := value.(type) {
+		case json.Number:
+			return true
+		case string:
+			_, err := json.Number(v).Float64()
+			return err == nil
+		}
+		return false
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return true
+		case string:
+			return v == "true" || v == "false"
+		}
+		return false
+	case "date":
+		switch value.(type) {
+		case string, json.Number:
+			return true
+		}
+		return false
+	case "object", "nested":
+		_, ok := value.(map[string]any)
+		return ok
+	default:
+		return true
+	}
+}
@@ -0,0 +1,179 @@
+//nolint:testpackage // Testing unexported methods requires same package access
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+)
+
+type mockImportESClient struct {
+	exists   bool
+	mapping  map[string]any
+	batches  [][]elasticsearch.BulkDocument
+	rejectID string
+	bulkErr  error
+}
+
+func (m *mockImportESClient) IndexExists(_ context.Context, _ string) (bool, error) {
+	return m.exists, nil
+}
+
+func (m *mockImportESClient) GetIndexMapping(_ context.Context, _ string) (map[string]any, error) {
+	return m.mapping, nil
+}
+
+func (m *mockImportESClient) BulkIndexDocuments(
+	_ context.Context,
+	_ string,
+	docs []elasticsearch.BulkDocument,
+) ([]elasticsearch.BulkItemResult, error) {
+	if m.bulkErr != nil {
+		return nil, m.bulkErr
+	}
+	m.batches = append(m.batches, docs)
+	results := make([]elasticsearch.BulkItemResult, 0, len(docs))
+	for _, doc := range docs {
+		result := elasticsearch.BulkItemResult{ID: doc.ID, Status: 201}
+		if doc.ID == m.rejectID {
+			result.Status = 409
+			result.Error = `{"type":"version_conflict_engine_exception"}`
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+var dictionaryMapping = map[string]any{
+	"properties": map[string]any{
+		"word":       map[string]any{"type": "keyword"},
+		"definition": map[string]any{"type": "text"},
+		"frequency":  map[string]any{"type": "integer"},
+		"added_at":   map[string]any{"type": "date"},
+		"meta": map[string]any{"properties": map[string]any{
+			"verified": map[string]any{"type": "boolean"},
+		}},
+	},
+}
+
+func TestDocumentImportService_ImportNDJSON(t *testing.T) {
+	t.Parallel()
+
+	client := &mockImportESClient{exists: true, mapping: dictionaryMapping, rejectID: "dup"}
+	svc := NewDocumentImportService(client, &noopLogger{})
+
+	body := strings.Join([]string{
+		`{"word": "makwa", "definition": "bear", "frequency": 12, "meta": {"verified": true}}`,
+		``,
+		`{"word": "dup", "frequency": "7"}`,
+		`{"word": "waabooz", "frequency": "many"}`,
+		`{"word": "nibi", "colour": "blue"}`,
+		`not json`,
+		`{"definition": "no id"}`,
+	}, "\n")
+
+	result, err := svc.ImportNDJSON(context.Background(), "dictionary", strings.NewReader(body), "word")
+	if err != nil {
+		t.Fatalf("ImportNDJSON() error = %v", err)
+	}
+
+	if result.Schema != liveMappingSchema {
+		t.Errorf("Schema = %q, want %q for a non-content index", result.Schema, liveMappingSchema)
+	}
+	if result.Total != 6 || result.Indexed != 1 || result.Failed != 5 {
+		t.Errorf("got total=%d indexed=%d failed=%d, want 6/1/5", result.Total, result.Indexed, result.Failed)
+	}
+	if len(client.batches) != 1 || len(client.batches[0]) != 2 {
+		t.Fatalf("expected one bulk request with 2 valid documents, got %v", client.batches)
+	}
+	if client.batches[0][0].ID != "makwa" {
+		t.Errorf("document ID = %q, want id_field value", client.batches[0][0].ID)
+	}
+
+	wantLines := []int{3, 4, 5, 6, 7}
+	gotLines := make([]int, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		gotLines = append(gotLines, e.Line)
+	}
+	for _, line := range wantLines {
+		found := false
+		for _, got := range gotLines {
+			found = found || got == line
+		}
+		if !found {
+			t.Errorf("expected an error for line %d, got lines %v", line, gotLines)
+		}
+	}
+}
+
+func TestDocumentImportService_IndexNotFound(t *testing.T) {
+	t.Parallel()
+
+	svc := NewDocumentImportService(&mockImportESClient{}, &noopLogger{})
+	_, err := svc.ImportNDJSON(context.Background(), "missing", strings.NewReader(`{}`), "")
+	if !errors.Is(err, ErrIndexNotFound) {
+		t.Errorf("expected ErrIndexNotFound, got %v", err)
+	}
+}
+
+func TestDocumentImportService_UsesIndexTypeSchema(t *testing.T) {
+	t.Parallel()
+
+	client := &mockImportESClient{exists: true}
+	svc := NewDocumentImportService(client, &noopLogger{})
+
+	body := `{"title": "Story", "url": "https://example.com", "not_a_field": 1}`
+	result, err := svc.ImportNDJSON(context.Background(), "example_com_raw_content", strings.NewReader(body), "")
+	if err != nil {
+		t.Fatalf("ImportNDJSON() error = %v", err)
+	}
+	if result.Schema != "raw_content" {
+		t.Errorf("Schema = %q, want raw_content", result.Schema)
+	}
+	if result.Failed != 1 || !strings.Contains(result.Errors[0].Error, "not_a_field") {
+		t.Errorf("expected unknown field to be rejected, got %+v", result.Errors)
+	}
+}
+
+func TestDocumentImportService_BulkErrorAborts(t *testing.T) {
+	t.Parallel()
+
+	client := &mockImportESClient{exists: true, mapping: dictionaryMapping, bulkErr: errors.New("cluster unavailable")}
+	svc := NewDocumentImportService(client, &noopLogger{})
+
+	_, err := svc.ImportNDJSON(context.Background(), "dictionary", strings.NewReader(`{"word": "makwa"}`), "")
+	if err == nil {
+		t.Fatal("expected bulk failure to abort the import")
+	}
+}
+
+func TestValueMatchesType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fieldType string
+		value     any
+		want      bool
+	}{
+		{"keyword", "a", true},
+		{"keyword", json.Number("1"), false},
+		{"integer", json.Number("3"), true},
+		{"integer", json.Number("3.5"), false},
+		{"integer", "42", true},
+		{"float", json.Number("3.5"), true},
+		{"boolean", "true", true},
+		{"boolean", "yes", false},
+		{"date", "2026-01-01T00:00:00Z", true},
+		{"date", true, false},
+		{"geo_point", map[string]any{"lat": 1}, true},
+	}
+	for _, tt := range tests {
+		if got := valueMatchesType(tt.fieldType, tt.value); got != tt.want {
+			t.Errorf("valueMatchesType(%q, %v) = %v, want %v", tt.fieldType, tt.value, got, tt.want)
+		}
+	}
+}
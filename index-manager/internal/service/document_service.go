@@ -6,22 +6,65 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
+// Document revision history limits.
+const (
+	// maxRevisionsPerDocument caps stored history per document; older revisions are trimmed.
+	maxRevisionsPerDocument = 50
+	defaultHistoryLimit     = 20
+)
+
+// deletedDocumentReason is the retraction reason for classified documents deleted here.
+const deletedDocumentReason = "deleted in index-manager"
+
+// ErrRevisionHistoryDisabled is returned when no revision store is configured.
+var ErrRevisionHistoryDisabled = errors.New("document revision history is not enabled")
+
+// Retractor takes deleted content down from the channels it was published to.
+// The concrete *publisher.Client satisfies this interface.
+type Retractor interface {
+	RequestRetraction(ctx context.Context, contentID, reason string) error
+}
+
+// DocumentESClient defines the Elasticsearch operations needed by DocumentService.
+// The concrete *elasticsearch.Client satisfies this interface.
+type DocumentESClient interface {
+	IndexExists(ctx context.Context, indexName string) (bool, error)
+	SearchDocuments(ctx context.Context, indexName string, query map[string]any) (*esapi.Response, error)
+	GetDocument(ctx context.Context, indexName, documentID string) (map[string]any, error)
+	UpdateDocument(ctx context.Context, indexName, documentID string, doc map[string]any) error
+	ReplaceDocument(ctx context.Context, indexName, documentID string, doc map[string]any) error
+	DeleteDocument(ctx context.Context, indexName, documentID string) error
+	BulkDeleteDocuments(ctx context.Context, indexName string, documentIDs []string) error
+}
+
+// DocumentRevisionStore persists previous versions of documents.
+type DocumentRevisionStore interface {
+	RecordDocumentRevision(ctx context.Context, rev *database.DocumentRevision, keep int) error
+	ListDocumentRevisions(ctx context.Context, indexName, documentID string, limit int) ([]*database.DocumentRevision, error)
+	GetDocumentRevision(ctx context.Context, id int64) (*database.DocumentRevision, error)
+}
+
 // DocumentService provides business logic for document operations
 type DocumentService struct {
-	esClient     *elasticsearch.Client
+	esClient     DocumentESClient
+	revisions    DocumentRevisionStore
+	retractor    Retractor
 	queryBuilder *elasticsearch.DocumentQueryBuilder
 	logger       infralogger.Logger
 }
 
 // NewDocumentService creates a new document service
-func NewDocumentService(esClient *elasticsearch.Client, logger infralogger.Logger) *DocumentService {
+func NewDocumentService(esClient DocumentESClient, logger infralogger.Logger) *DocumentService {
 	return &DocumentService{
 		esClient:     esClient,
 		queryBuilder: elasticsearch.NewDocumentQueryBuilder(),
@@ -29,6 +72,20 @@ func NewDocumentService(esClient *elasticsearch.Client, logger infralogger.Logge
 	}
 }
 
+// WithRevisionStore enables revision history: every update or restore first
+// stores the version it overwrites.
+func (s *DocumentService) WithRevisionStore(store DocumentRevisionStore) *DocumentService {
+	s.revisions = store
+	return s
+}
+
+// WithRetractor enables retraction: documents deleted from a classified
+// content index are taken down from the publisher channels they went to.
+func (s *DocumentService) WithRetractor(retractor Retractor) *DocumentService {
+	s.retractor = retractor
+	return s
+}
+
 // QueryDocuments queries documents from an index with filters, pagination, and sorting.
 func (s *DocumentService) QueryDocuments(
 	ctx context.Context,
@@ -127,8 +184,14 @@ func (s *DocumentService) GetDocument(ctx context.Context, indexName, documentID
 	return s.mapToDocument(documentID, source), nil
 }
 
-// UpdateDocument updates a document in an index
-func (s *DocumentService) UpdateDocument(ctx context.Context, indexName, documentID string, doc *domain.Document) error {
+// UpdateDocument updates a document in an index. When revision history is
+// enabled the previous version is stored first; changedBy identifies the editor.
+func (s *DocumentService) UpdateDocument(
+	ctx context.Context,
+	indexName, documentID string,
+	doc *domain.Document,
+	changedBy string,
+) error {
 	// Verify index exists
 	exists, err := s.esClient.IndexExists(ctx, indexName)
 	if err != nil {
@@ -143,6 +206,10 @@ func (s *DocumentService) UpdateDocument(ctx context.Context, indexName, documen
 		infralogger.String("document_id", documentID),
 	)
 
+	if revErr := s.recordRevision(ctx, indexName, documentID, domain.RevisionActionUpdate, changedBy); revErr != nil {
+		return revErr
+	}
+
 	// Convert document to map for update
 	updateMap := s.documentToMap(doc)
 
@@ -154,6 +221,133 @@ func (s *DocumentService) UpdateDocument(ctx context.Context, indexName, documen
 	return nil
 }
 
+// GetDocumentHistory returns a document's stored revisions, newest first.
+func (s *DocumentService) GetDocumentHistory(
+	ctx context.Context,
+	indexName, documentID string,
+	limit int,
+) (*domain.DocumentHistoryResponse, error) {
+	if s.revisions == nil {
+		return nil, ErrRevisionHistoryDisabled
+	}
+	if limit <= 0 || limit > maxRevisionsPerDocument {
+		limit = defaultHistoryLimit
+	}
+
+	rows, err := s.revisions.ListDocumentRevisions(ctx, indexName, documentID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	revisions := make([]*domain.DocumentRevision, 0, len(rows))
+	for _, row := range rows {
+		rev, convErr := toDomainRevision(row)
+		if convErr != nil {
+			return nil, convErr
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return &domain.DocumentHistoryResponse{
+		IndexName:  indexName,
+		DocumentID: documentID,
+		Revisions:  revisions,
+		Count:      len(revisions),
+	}, nil
+}
+
+// RestoreDocumentRevision overwrites a document with a stored revision. The
+// version being replaced is itself stored, so a restore can be undone.
+func (s *DocumentService) RestoreDocumentRevision(
+	ctx context.Context,
+	indexName, documentID string,
+	revisionID int64,
+	changedBy string,
+) (*domain.Document, error) {
+	if s.revisions == nil {
+		return nil, ErrRevisionHistoryDisabled
+	}
+
+	row, err := s.revisions.GetDocumentRevision(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	// A revision ID from another document is treated as missing rather than restored across documents.
+	if row.IndexName != indexName || row.DocumentID != documentID {
+		return nil, database.ErrDocumentRevisionNotFound
+	}
+
+	rev, err := toDomainRevision(row)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Restoring document revision",
+		infralogger.String("index_name", indexName),
+		infralogger.String("document_id", documentID),
+		infralogger.Int64("revision_id", revisionID),
+	)
+
+	if revErr := s.recordRevision(ctx, indexName, documentID, domain.RevisionActionRestore, changedBy); revErr != nil {
+		return nil, revErr
+	}
+
+	if replaceErr := s.esClient.ReplaceDocument(ctx, indexName, documentID, rev.Source); replaceErr != nil {
+		return nil, fmt.Errorf("failed to restore document: %w", replaceErr)
+	}
+
+	return s.mapToDocument(documentID, rev.Source), nil
+}
+
+// recordRevision stores the current version of a document before it is
+// overwritten. It is a no-op when revision history is disabled.
+func (s *DocumentService) recordRevision(ctx context.Context, indexName, documentID, action, changedBy string) error {
+	if s.revisions == nil {
+		return nil
+	}
+
+	current, err := s.esClient.GetDocument(ctx, indexName, documentID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+
+	source, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode document revision: %w", err)
+	}
+
+	// Fail the write rather than overwrite a document whose previous version could not be saved.
+	if recordErr := s.revisions.RecordDocumentRevision(ctx, &database.DocumentRevision{
+		IndexName:  indexName,
+		DocumentID: documentID,
+		Source:     source,
+		Action:     action,
+		ChangedBy:  changedBy,
+	}, maxRevisionsPerDocument); recordErr != nil {
+		return fmt.Errorf("failed to record document revision: %w", recordErr)
+	}
+
+	return nil
+}
+
+// toDomainRevision decodes a stored revision.
+func toDomainRevision(row *database.DocumentRevision) (*domain.DocumentRevision, error) {
+	var source map[string]any
+	if err := json.Unmarshal(row.Source, &source); err != nil {
+		return nil, fmt.Errorf("failed to decode revision %d: %w", row.ID, err)
+	}
+
+	return &domain.DocumentRevision{
+		ID:         row.ID,
+		IndexName:  row.IndexName,
+		DocumentID: row.DocumentID,
+		Action:     row.Action,
+		ChangedBy:  row.ChangedBy,
+		CreatedAt:  row.CreatedAt,
+		Source:     source,
+	}, nil
+}
+
 // DeleteDocument deletes a document from an index
 func (s *DocumentService) DeleteDocument(ctx context.Context, indexName, documentID string) error {
 	// Verify index exists
@@ -175,6 +369,8 @@ func (s *DocumentService) DeleteDocument(ctx context.Context, indexName, documen
 		return fmt.Errorf("failed to delete document: %w", deleteErr)
 	}
 
+	s.requestRetractions(ctx, indexName, []string{documentID})
+
 	return nil
 }
 
@@ -203,9 +399,31 @@ func (s *DocumentService) BulkDeleteDocuments(ctx context.Context, indexName str
 		return fmt.Errorf("failed to bulk delete documents: %w", bulkErr)
 	}
 
+	s.requestRetractions(ctx, indexName, documentIDs)
+
 	return nil
 }
 
+// requestRetractions asks the publisher to retract documents deleted from a
+// classified content index. Raw content was never published, so other indexes
+// are skipped. A failed request is logged and does not fail the delete; the
+// retraction can be filed again through the publisher API.
+func (s *DocumentService) requestRetractions(ctx context.Context, indexName string, documentIDs []string) {
+	if s.retractor == nil || !strings.Contains(indexName, classifiedContentSuffix) {
+		return
+	}
+
+	for _, documentID := range documentIDs {
+		if err := s.retractor.RequestRetraction(ctx, documentID, deletedDocumentReason); err != nil {
+			s.logger.Warn("Failed to request retraction of deleted document",
+				infralogger.String("index_name", indexName),
+				infralogger.String("document_id", documentID),
+				infralogger.Error(err),
+			)
+		}
+	}
+}
+
 // mapToDocument converts Elasticsearch source map to domain Document
 //
 //nolint:gocognit // Complex mapping with many field extractions
@@ -3,9 +3,12 @@ package service //nolint:testpackage // testing unexported mapping methods
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
 	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
 )
 
@@ -1222,3 +1225,193 @@ func TestGetClassificationDriftTimeseries_ESError(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+// --- revision history ---
+
+type fakeDocumentES struct {
+	source   map[string]any
+	updated  map[string]any
+	replaced map[string]any
+}
+
+func (f *fakeDocumentES) IndexExists(_ context.Context, _ string) (bool, error) { return true, nil }
+
+func (f *fakeDocumentES) SearchDocuments(_ context.Context, _ string, _ map[string]any) (*esapi.Response, error) {
+	return nil, errTestES
+}
+
+func (f *fakeDocumentES) GetDocument(_ context.Context, _, _ string) (map[string]any, error) {
+	if f.source == nil {
+		return nil, errTestES
+	}
+	return f.source, nil
+}
+
+func (f *fakeDocumentES) UpdateDocument(_ context.Context, _, _ string, doc map[string]any) error {
+	f.updated = doc
+	return nil
+}
+
+func (f *fakeDocumentES) ReplaceDocument(_ context.Context, _, _ string, doc map[string]any) error {
+	f.replaced = doc
+	return nil
+}
+
+func (f *fakeDocumentES) DeleteDocument(_ context.Context, _, _ string) error { return nil }
+
+func (f *fakeDocumentES) BulkDeleteDocuments(_ context.Context, _ string, _ []string) error {
+	return nil
+}
+
+type fakeRevisionStore struct {
+	revisions []*database.DocumentRevision
+	err       error
+}
+
+func (f *fakeRevisionStore) RecordDocumentRevision(_ context.Context, rev *database.DocumentRevision, _ int) error {
+	if f.err != nil {
+		return f.err
+	}
+	rev.ID = int64(len(f.revisions) + 1)
+	f.revisions = append(f.revisions, rev)
+	return nil
+}
+
+func (f *fakeRevisionStore) ListDocumentRevisions(
+	_ context.Context, _, _ string, _ int,
+) ([]*database.DocumentRevision, error) {
+	return f.revisions, nil
+}
+
+func (f *fakeRevisionStore) GetDocumentRevision(_ context.Context, id int64) (*database.DocumentRevision, error) {
+	for _, rev := range f.revisions {
+		if rev.ID == id {
+			return rev, nil
+		}
+	}
+	return nil, database.ErrDocumentRevisionNotFound
+}
+
+func TestUpdateDocument_RecordsPreviousVersion(t *testing.T) {
+	t.Helper()
+
+	es := &fakeDocumentES{source: map[string]any{"title": "Original"}}
+	store := &fakeRevisionStore{}
+	svc := NewDocumentService(es, &noopLogger{}).WithRevisionStore(store)
+
+	err := svc.UpdateDocument(context.Background(), "idx", "doc-1", &domain.Document{Title: "Edited"}, "editor")
+	if err != nil {
+		t.Fatalf("UpdateDocument() error = %v", err)
+	}
+
+	if len(store.revisions) != 1 {
+		t.Fatalf("revisions = %d, want 1", len(store.revisions))
+	}
+	rev := store.revisions[0]
+	if rev.Action != domain.RevisionActionUpdate || rev.ChangedBy != "editor" {
+		t.Errorf("revision = %+v, want update by editor", rev)
+	}
+	if string(rev.Source) != `{"title":"Original"}` {
+		t.Errorf("revision source = %s, want original document", rev.Source)
+	}
+	if es.updated["title"] != "Edited" {
+		t.Errorf("updated title = %v, want Edited", es.updated["title"])
+	}
+}
+
+func TestUpdateDocument_RevisionFailureBlocksUpdate(t *testing.T) {
+	t.Helper()
+
+	es := &fakeDocumentES{source: map[string]any{"title": "Original"}}
+	svc := NewDocumentService(es, &noopLogger{}).WithRevisionStore(&fakeRevisionStore{err: errTestES})
+
+	if err := svc.UpdateDocument(context.Background(), "idx", "doc-1", &domain.Document{Title: "Edited"}, ""); err == nil {
+		t.Fatal("UpdateDocument() error = nil, want error")
+	}
+	if es.updated != nil {
+		t.Error("document was updated although its revision was not saved")
+	}
+}
+
+func TestRestoreDocumentRevision(t *testing.T) {
+	t.Helper()
+
+	es := &fakeDocumentES{source: map[string]any{"title": "Bad edit"}}
+	store := &fakeRevisionStore{revisions: []*database.DocumentRevision{{
+		ID: 1, IndexName: "idx", DocumentID: "doc-1", Source: []byte(`{"title":"Original"}`), Action: "update",
+	}}}
+	svc := NewDocumentService(es, &noopLogger{}).WithRevisionStore(store)
+
+	doc, err := svc.RestoreDocumentRevision(context.Background(), "idx", "doc-1", 1, "editor")
+	if err != nil {
+		t.Fatalf("RestoreDocumentRevision() error = %v", err)
+	}
+	if doc.Title != "Original" || es.replaced["title"] != "Original" {
+		t.Errorf("restored title = %q (replaced %v), want Original", doc.Title, es.replaced["title"])
+	}
+	if len(store.revisions) != 2 || store.revisions[1].Action != domain.RevisionActionRestore {
+		t.Fatalf("revisions = %d, want the overwritten version recorded as restore", len(store.revisions))
+	}
+	if string(store.revisions[1].Source) != `{"title":"Bad edit"}` {
+		t.Errorf("restore revision source = %s, want pre-restore document", store.revisions[1].Source)
+	}
+}
+
+func TestRestoreDocumentRevision_OtherDocument(t *testing.T) {
+	t.Helper()
+
+	store := &fakeRevisionStore{revisions: []*database.DocumentRevision{{
+		ID: 1, IndexName: "idx", DocumentID: "doc-2", Source: []byte(`{}`),
+	}}}
+	svc := NewDocumentService(&fakeDocumentES{}, &noopLogger{}).WithRevisionStore(store)
+
+	_, err := svc.RestoreDocumentRevision(context.Background(), "idx", "doc-1", 1, "")
+	if !errors.Is(err, database.ErrDocumentRevisionNotFound) {
+		t.Errorf("error = %v, want ErrDocumentRevisionNotFound", err)
+	}
+}
+
+func TestGetDocumentHistory_Disabled(t *testing.T) {
+	t.Helper()
+
+	svc := NewDocumentService(&fakeDocumentES{}, &noopLogger{})
+	if _, err := svc.GetDocumentHistory(context.Background(), "idx", "doc-1", 0); !errors.Is(err, ErrRevisionHistoryDisabled) {
+		t.Errorf("error = %v, want ErrRevisionHistoryDisabled", err)
+	}
+}
+
+// --- retraction ---
+
+type fakeRetractor struct {
+	contentIDs []string
+	err        error
+}
+
+func (f *fakeRetractor) RequestRetraction(_ context.Context, contentID, _ string) error {
+	f.contentIDs = append(f.contentIDs, contentID)
+	return f.err
+}
+
+func TestDeleteDocument_RequestsRetractionForClassifiedContent(t *testing.T) {
+	t.Helper()
+
+	retractor := &fakeRetractor{err: errTestES}
+	svc := NewDocumentService(&fakeDocumentES{}, &noopLogger{}).WithRetractor(retractor)
+
+	if err := svc.DeleteDocument(context.Background(), "example_com_classified_content", "doc-1"); err != nil {
+		t.Fatalf("DeleteDocument() error = %v, a failed retraction must not fail the delete", err)
+	}
+	if err := svc.BulkDeleteDocuments(context.Background(), "example_com_raw_content", []string{"doc-2"}); err != nil {
+		t.Fatalf("BulkDeleteDocuments() error = %v", err)
+	}
+	if err := svc.BulkDeleteDocuments(
+		context.Background(), "example_com_classified_content_v2", []string{"doc-3", "doc-4"},
+	); err != nil {
+		t.Fatalf("BulkDeleteDocuments() error = %v", err)
+	}
+
+	want := []string{"doc-1", "doc-3", "doc-4"}
+	if strings.Join(retractor.contentIDs, ",") != strings.Join(want, ",") {
+		t.Errorf("retracted = %v, want %v", retractor.contentIDs, want)
+	}
+}
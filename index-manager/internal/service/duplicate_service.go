@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+const (
+	defaultDuplicateClusters = 100
+	maxDuplicateClusters     = 1000
+	// maxCopiesPerCluster bounds top_hits; ES rejects more than 100 by default.
+	maxCopiesPerCluster = 100
+	minDuplicateCopies  = 2
+)
+
+// DuplicateESClient defines the Elasticsearch operations needed by DuplicateService.
+// The concrete *elasticsearch.Client satisfies this interface.
+type DuplicateESClient interface {
+	SearchAllClassifiedContent(ctx context.Context, query map[string]any) (*esapi.Response, error)
+	DeleteDocument(ctx context.Context, indexName, documentID string) error
+}
+
+// DuplicateService finds documents that share a canonical_url across
+// classified content indexes and removes redundant copies.
+type DuplicateService struct {
+	esClient DuplicateESClient
+	logger   infralogger.Logger
+}
+
+// NewDuplicateService creates a new duplicate canonical_url service.
+func NewDuplicateService(esClient DuplicateESClient, logger infralogger.Logger) *DuplicateService {
+	return &DuplicateService{esClient: esClient, logger: logger}
+}
+
+// FindDuplicates reports clusters of classified documents sharing a canonical_url,
+// largest clusters first.
+func (s *DuplicateService) FindDuplicates(
+	ctx context.Context,
+	req *domain.DuplicateReportRequest,
+) (*domain.DuplicateReport, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDuplicateClusters
+	}
+	limit = min(limit, maxDuplicateClusters)
+
+	res, err := s.esClient.SearchAllClassifiedContent(ctx, buildDuplicateQuery(req, limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute duplicate aggregation: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	var esResp duplicateAggResponse
+	if decodeErr := json.NewDecoder(res.Body).Decode(&esResp); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode duplicate aggregation: %w", decodeErr)
+	}
+
+	report := &domain.DuplicateReport{
+		Clusters:    make([]*domain.DuplicateCluster, 0, len(esResp.Aggregations.ByCanonical.Buckets)),
+		GeneratedAt: time.Now().UTC(),
+	}
+	for i := range esResp.Aggregations.ByCanonical.Buckets {
+		cluster := esResp.Aggregations.ByCanonical.Buckets[i].toCluster()
+		report.Clusters = append(report.Clusters, cluster)
+		report.RedundantDocuments += cluster.DocumentCount - 1
+	}
+	report.Count = len(report.Clusters)
+
+	return report, nil
+}
+
+// Dedupe keeps the best copy of each duplicate cluster and deletes the rest.
+// Only listed copies are deleted, so very large clusters may need another run.
+func (s *DuplicateService) Dedupe(ctx context.Context, req *domain.DedupeRequest) (*domain.DedupeResult, error) {
+	report, err := s.FindDuplicates(ctx, &req.DuplicateReportRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.DedupeResult{
+		DryRun:   req.DryRun,
+		Clusters: report.Count,
+		Kept:     make([]*domain.DuplicateCopy, 0, report.Count),
+		Deleted:  make([]*domain.DuplicateCopy, 0),
+	}
+
+	for _, cluster := range report.Clusters {
+		if len(cluster.Copies) == 0 {
+			continue
+		}
+		result.Kept = append(result.Kept, cluster.Copies[0])
+
+		for _, dup := range cluster.Copies[1:] {
+			if req.DryRun {
+				result.Deleted = append(result.Deleted, dup)
+				continue
+			}
+			if deleteErr := s.esClient.DeleteDocument(ctx, dup.IndexName, dup.DocumentID); deleteErr != nil {
+				s.logger.Warn("Failed to delete duplicate document",
+					infralogger.String("index_name", dup.IndexName),
+					infralogger.String("document_id", dup.DocumentID),
+					infralogger.Error(deleteErr),
+				)
+				result.Errors = append(result.Errors,
+					fmt.Sprintf("%s/%s: %v", dup.IndexName, dup.DocumentID, deleteErr))
+				continue
+			}
+			result.Deleted = append(result.Deleted, dup)
+		}
+	}
+
+	if !req.DryRun {
+		s.logger.Info("Deduplicated canonical URLs",
+			infralogger.Int("clusters", result.Clusters),
+			infralogger.Int("deleted", len(result.Deleted)),
+			infralogger.Int("errors", len(result.Errors)),
+		)
+	}
+
+	return result, nil
+}
+
+func buildDuplicateQuery(req *domain.DuplicateReportRequest, limit int) map[string]any {
+	filters := []any{
+		map[string]any{"exists": map[string]any{"field": "canonical_url"}},
+	}
+	if req.SourceName != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{"source_name": req.SourceName}})
+	}
+	if len(req.CanonicalURLs) > 0 {
+		filters = append(filters, map[string]any{"terms": map[string]any{"canonical_url": req.CanonicalURLs}})
+	}
+
+	return map[string]any{
+		"size": 0,
+		"query": map[string]any{
+			"bool": map[string]any{"filter": filters},
+		},
+		"aggs": map[string]any{
+			"by_canonical": map[string]any{
+				"terms": map[string]any{
+					"field":         "canonical_url",
+					"size":          limit,
+					"min_doc_count": minDuplicateCopies,
+					"order":         map[string]any{"_count": "desc"},
+				},
+				"aggs": map[string]any{
+					"copies": map[string]any{
+						"top_hits": map[string]any{
+							"size": maxCopiesPerCluster,
+							"sort": []any{
+								map[string]any{"quality_score": map[string]any{"order": "desc", "unmapped_type": "integer"}},
+								map[string]any{"crawled_at": map[string]any{"order": "desc", "unmapped_type": "date"}},
+							},
+							"_source": []string{"title", "source_name", "quality_score", "crawled_at"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type duplicateAggResponse struct {
+	Aggregations struct {
+		ByCanonical struct {
+			Buckets []duplicateBucket `json:"buckets"`
+		} `json:"by_canonical"`
+	} `json:"aggregations"`
+}
+
+type duplicateBucket struct {
+	Key      string `json:"key"`
+	DocCount int64  `json:"doc_count"`
+	Copies   struct {
+		Hits struct {
+			Hits []struct {
+				Index  string `json:"_index"`
+				ID     string `json:"_id"`
+				Source struct {
+					Title        string     `json:"title"`
+					SourceName   string     `json:"source_name"`
+					QualityScore float64    `json:"quality_score"`
+					CrawledAt    *time.Time `json:"crawled_at"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	} `json:"copies"`
+}
+
+func (b *duplicateBucket) toCluster() *domain.DuplicateCluster {
+	hits := b.Copies.Hits.Hits
+	cluster := &domain.DuplicateCluster{
+		CanonicalURL:  b.Key,
+		DocumentCount: b.DocCount,
+		Copies:        make([]*domain.DuplicateCopy, 0, len(hits)),
+		Truncated:     int64(len(hits)) < b.DocCount,
+	}
+
+	indexes := make(map[string]bool)
+	for i := range hits {
+		hit := &hits[i]
+		indexes[hit.Index] = true
+		cluster.Copies = append(cluster.Copies, &domain.DuplicateCopy{
+			IndexName:    hit.Index,
+			DocumentID:   hit.ID,
+			Title:        hit.Source.Title,
+			SourceName:   hit.Source.SourceName,
+			QualityScore: int(hit.Source.QualityScore),
+			CrawledAt:    hit.Source.CrawledAt,
+		})
+	}
+
+	cluster.Indexes = make([]string, 0, len(indexes))
+	for name := range indexes {
+		cluster.Indexes = append(cluster.Indexes, name)
+	}
+	sort.Strings(cluster.Indexes)
+
+	return cluster
+}
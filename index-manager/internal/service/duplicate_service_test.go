@@ -0,0 +1,154 @@
+//nolint:testpackage // Testing unexported methods requires same package access
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+)
+
+type mockDuplicateESClient struct {
+	searchResp *esapi.Response
+	deleted    []string
+	deleteErr  map[string]error
+}
+
+func (m *mockDuplicateESClient) SearchAllClassifiedContent(_ context.Context, _ map[string]any) (*esapi.Response, error) {
+	return m.searchResp, nil
+}
+
+func (m *mockDuplicateESClient) DeleteDocument(_ context.Context, indexName, documentID string) error {
+	key := indexName + "/" + documentID
+	if err := m.deleteErr[key]; err != nil {
+		return err
+	}
+	m.deleted = append(m.deleted, key)
+	return nil
+}
+
+const duplicateAggBody = `{
+	"aggregations": {
+		"by_canonical": {
+			"buckets": [
+				{
+					"key": "https://example.com/story",
+					"doc_count": 3,
+					"copies": {"hits": {"hits": [
+						{"_index": "b_classified_content", "_id": "b1", "_source": {"title": "Story", "source_name": "b", "quality_score": 90, "crawled_at": "2026-01-02T00:00:00Z"}},
+						{"_index": "a_classified_content", "_id": "a1", "_source": {"title": "Story", "source_name": "a", "quality_score": 60}},
+						{"_index": "a_classified_content", "_id": "a2", "_source": {"title": "Story", "source_name": "a", "quality_score": 40}}
+					]}}
+				},
+				{
+					"key": "https://example.com/other",
+					"doc_count": 5,
+					"copies": {"hits": {"hits": [
+						{"_index": "c_classified_content", "_id": "c1", "_source": {"quality_score": 70}},
+						{"_index": "c_classified_content", "_id": "c2", "_source": {"quality_score": 50}}
+					]}}
+				}
+			]
+		}
+	}
+}`
+
+func TestDuplicateService_FindDuplicates(t *testing.T) {
+	t.Parallel()
+
+	svc := NewDuplicateService(&mockDuplicateESClient{
+		searchResp: esapiResponse(t, http.StatusOK, duplicateAggBody),
+	}, &noopLogger{})
+
+	report, err := svc.FindDuplicates(context.Background(), &domain.DuplicateReportRequest{})
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+
+	if report.Count != 2 {
+		t.Fatalf("Count = %d, want 2", report.Count)
+	}
+	if report.RedundantDocuments != 6 {
+		t.Errorf("RedundantDocuments = %d, want 6", report.RedundantDocuments)
+	}
+
+	first := report.Clusters[0]
+	if len(first.Indexes) != 2 || first.Indexes[0] != "a_classified_content" {
+		t.Errorf("Indexes = %v, want sorted [a_classified_content b_classified_content]", first.Indexes)
+	}
+	if first.Copies[0].DocumentID != "b1" || first.Copies[0].QualityScore != 90 || first.Copies[0].CrawledAt == nil {
+		t.Errorf("unexpected best copy: %+v", first.Copies[0])
+	}
+	if first.Truncated {
+		t.Error("first cluster should not be truncated")
+	}
+	if !report.Clusters[1].Truncated {
+		t.Error("second cluster lists 2 of 5 copies and should be truncated")
+	}
+}
+
+func TestDuplicateService_DedupeKeepsBestCopy(t *testing.T) {
+	t.Parallel()
+
+	client := &mockDuplicateESClient{
+		searchResp: esapiResponse(t, http.StatusOK, duplicateAggBody),
+		deleteErr:  map[string]error{"c_classified_content/c2": errors.New("boom")},
+	}
+	svc := NewDuplicateService(client, &noopLogger{})
+
+	result, err := svc.Dedupe(context.Background(), &domain.DedupeRequest{})
+	if err != nil {
+		t.Fatalf("Dedupe() error = %v", err)
+	}
+
+	if len(result.Kept) != 2 || result.Kept[0].DocumentID != "b1" || result.Kept[1].DocumentID != "c1" {
+		t.Errorf("unexpected kept copies: %+v", result.Kept)
+	}
+	want := []string{"a_classified_content/a1", "a_classified_content/a2"}
+	if len(client.deleted) != len(want) || client.deleted[0] != want[0] || client.deleted[1] != want[1] {
+		t.Errorf("deleted = %v, want %v", client.deleted, want)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want one failed delete", result.Errors)
+	}
+}
+
+func TestDuplicateService_DedupeDryRun(t *testing.T) {
+	t.Parallel()
+
+	client := &mockDuplicateESClient{searchResp: esapiResponse(t, http.StatusOK, duplicateAggBody)}
+	svc := NewDuplicateService(client, &noopLogger{})
+
+	result, err := svc.Dedupe(context.Background(), &domain.DedupeRequest{DryRun: true})
+	if err != nil {
+		t.Fatalf("Dedupe() error = %v", err)
+	}
+
+	if len(client.deleted) != 0 {
+		t.Errorf("dry run deleted documents: %v", client.deleted)
+	}
+	if len(result.Deleted) != 3 {
+		t.Errorf("Deleted = %d, want 3 planned deletions", len(result.Deleted))
+	}
+}
+
+func TestBuildDuplicateQuery_Filters(t *testing.T) {
+	t.Parallel()
+
+	query := buildDuplicateQuery(&domain.DuplicateReportRequest{
+		SourceName:    "example",
+		CanonicalURLs: []string{"https://example.com/story"},
+	}, 10)
+
+	filters := query["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]any)
+	if len(filters) != 3 {
+		t.Errorf("filters = %d, want exists + source_name + canonical_url", len(filters))
+	}
+	terms := query["aggs"].(map[string]any)["by_canonical"].(map[string]any)["terms"].(map[string]any)
+	if terms["size"] != 10 || terms["min_doc_count"] != minDuplicateCopies {
+		t.Errorf("unexpected terms agg: %v", terms)
+	}
+}
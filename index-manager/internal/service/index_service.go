@@ -71,9 +71,22 @@ func (s *IndexService) CreateIndex(ctx context.Context, req *domain.CreateIndexR
 
 	// Get mapping
 	var mapping map[string]any
+	var mappingWarnings []domain.MappingIssue
 	var err error
 	if req.Mapping != nil {
+		issues := LintMapping(req.Mapping)
+		if HasMappingErrors(issues) {
+			return nil, &MappingLintError{Issues: issues}
+		}
+		for _, issue := range issues {
+			s.logger.Warn("Custom mapping lint warning",
+				infralogger.String("index_name", indexName),
+				infralogger.String("field", issue.Field),
+				infralogger.String("rule", issue.Rule),
+			)
+		}
 		mapping = req.Mapping
+		mappingWarnings = issues
 	} else {
 		mapping, err = mappings.GetMappingForType(string(req.IndexType), s.getShards(req.IndexType), s.getReplicas(req.IndexType))
 		if err != nil {
@@ -141,7 +154,9 @@ func (s *IndexService) CreateIndex(ctx context.Context, req *domain.CreateIndexR
 		infralogger.String("index_type", string(req.IndexType)),
 	)
 
-	return s.indexInfoToDomain(info, req.IndexType, req.SourceName), nil
+	index := s.indexInfoToDomain(info, req.IndexType, req.SourceName)
+	index.MappingWarnings = mappingWarnings
+	return index, nil
 }
 
 // DeleteIndex deletes an index and updates metadata
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+)
+
+// Mapping lint rule identifiers.
+const (
+	ruleMissingProperties  = "missing_properties"
+	ruleSourceDisabled     = "source_disabled"
+	ruleAggregatableField  = "aggregatable_keyword"
+	ruleAggregatableIndex  = "aggregatable_indexed"
+	ruleDateType           = "date_type"
+	ruleDateFormat         = "date_format"
+	ruleDynamicMapping     = "dynamic_mapping"
+	esTypeText             = "text"
+	esTypeKeyword          = "keyword"
+	esTypeDate             = "date"
+	isoDateFormatFragment  = "date_optional_time"
+	timestampFieldSuffix   = "_at"
+	dynamicStrict          = "strict"
+	dynamicDisabledSetting = "false"
+)
+
+// aggregatedFields are fields that dashboards and services aggregate or
+// filter on exactly. A text mapping on these breaks terms aggregations and
+// has forced reindexes in the past.
+var aggregatedFields = map[string]bool{
+	"source_name":           true,
+	"content_type":          true,
+	"content_subtype":       true,
+	"topics":                true,
+	"classification_status": true,
+	"url":                   true,
+	"canonical_url":         true,
+	"og_type":               true,
+}
+
+// MappingLintError is returned when a custom mapping has blocking issues.
+type MappingLintError struct {
+	Issues []domain.MappingIssue
+}
+
+func (e *MappingLintError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.Severity == domain.MappingIssueError {
+			messages = append(messages, issue.Message)
+		}
+	}
+	return "mapping rejected: " + strings.Join(messages, "; ")
+}
+
+// LintMapping checks an index mapping against North Cloud conventions. It
+// accepts either a full create body ({"settings":…,"mappings":…}) or a bare
+// mappings object ({"properties":…}).
+func LintMapping(mapping map[string]any) []domain.MappingIssue {
+	issues := make([]domain.MappingIssue, 0)
+
+	root := mapping
+	if inner, ok := mapping["mappings"].(map[string]any); ok {
+		root = inner
+	}
+
+	if source, ok := root["_source"].(map[string]any); ok {
+		if enabled, isBool := source["enabled"].(bool); isBool && !enabled {
+			issues = append(issues, domain.MappingIssue{
+				Field:    "_source",
+				Rule:     ruleSourceDisabled,
+				Severity: domain.MappingIssueError,
+				Message:  "_source must stay enabled: without it documents cannot be reindexed, updated, or migrated",
+			})
+		}
+	}
+
+	// dynamic may be a string ("strict") or a bool (false); unset means true.
+	if dynamic := fmt.Sprint(root["dynamic"]); dynamic != dynamicStrict && dynamic != dynamicDisabledSetting {
+		issues = append(issues, domain.MappingIssue{
+			Rule:     ruleDynamicMapping,
+			Severity: domain.MappingIssueWarning,
+			Message:  `set "dynamic": "strict" so unmapped fields are rejected instead of being mapped as text`,
+		})
+	}
+
+	properties, ok := root["properties"].(map[string]any)
+	if !ok || len(properties) == 0 {
+		issues = append(issues, domain.MappingIssue{
+			Rule:     ruleMissingProperties,
+			Severity: domain.MappingIssueError,
+			Message:  "mapping must define properties; leaving fields to dynamic mapping makes them text and un-aggregatable",
+		})
+		return issues
+	}
+
+	issues = append(issues, lintProperties("", properties)...)
+	return issues
+}
+
+// HasMappingErrors reports whether any issue blocks index creation.
+func HasMappingErrors(issues []domain.MappingIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == domain.MappingIssueError {
+			return true
+		}
+	}
+	return false
+}
+
+// lintProperties walks a properties map, recursing into object fields.
+func lintProperties(prefix string, properties map[string]any) []domain.MappingIssue {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	issues := make([]domain.MappingIssue, 0)
+	for _, name := range names {
+		field, ok := properties[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		if nested, hasNested := field["properties"].(map[string]any); hasNested {
+			issues = append(issues, lintProperties(path, nested)...)
+			continue
+		}
+
+		fieldType, _ := field["type"].(string)
+		if aggregatedFields[name] {
+			issues = append(issues, lintAggregatedField(path, fieldType, field)...)
+		}
+		issues = append(issues, lintDateField(path, name, fieldType, field)...)
+	}
+
+	return issues
+}
+
+func lintAggregatedField(path, fieldType string, field map[string]any) []domain.MappingIssue {
+	issues := make([]domain.MappingIssue, 0)
+
+	if fieldType == esTypeText {
+		if hasKeywordSubField(field) {
+			issues = append(issues, domain.MappingIssue{
+				Field:    path,
+				Rule:     ruleAggregatableField,
+				Severity: domain.MappingIssueWarning,
+				Message: fmt.Sprintf(
+					"%s is text with a keyword sub-field; map it as keyword so aggregations don't need %s.keyword", path, path,
+				),
+			})
+		} else {
+			issues = append(issues, domain.MappingIssue{
+				Field:    path,
+				Rule:     ruleAggregatableField,
+				Severity: domain.MappingIssueError,
+				Message:  fmt.Sprintf("%s is aggregated on and must be keyword, not text", path),
+			})
+		}
+	}
+
+	if indexed, isBool := field["index"].(bool); isBool && !indexed {
+		issues = append(issues, domain.MappingIssue{
+			Field:    path,
+			Rule:     ruleAggregatableIndex,
+			Severity: domain.MappingIssueError,
+			Message:  fmt.Sprintf("%s is filtered on and must not set index: false", path),
+		})
+	}
+
+	return issues
+}
+
+func lintDateField(path, name, fieldType string, field map[string]any) []domain.MappingIssue {
+	if fieldType != esTypeDate {
+		if strings.HasSuffix(name, timestampFieldSuffix) && fieldType != "" {
+			return []domain.MappingIssue{{
+				Field:    path,
+				Rule:     ruleDateType,
+				Severity: domain.MappingIssueError,
+				Message:  fmt.Sprintf("%s looks like a timestamp and must be type date, not %s", path, fieldType),
+			}}
+		}
+		return nil
+	}
+
+	format, hasFormat := field["format"].(string)
+	if hasFormat && !strings.Contains(format, isoDateFormatFragment) {
+		return []domain.MappingIssue{{
+			Field:    path,
+			Rule:     ruleDateFormat,
+			Severity: domain.MappingIssueWarning,
+			Message: fmt.Sprintf(
+				"%s format %q does not accept ISO 8601; use \"strict_date_optional_time||epoch_millis\"", path, format,
+			),
+		}}
+	}
+
+	return nil
+}
+
+func hasKeywordSubField(field map[string]any) bool {
+	subFields, ok := field["fields"].(map[string]any)
+	if !ok {
+		return false
+	}
+	for _, sub := range subFields {
+		if subMap, isMap := sub.(map[string]any); isMap && subMap["type"] == esTypeKeyword {
+			return true
+		}
+	}
+	return false
+}
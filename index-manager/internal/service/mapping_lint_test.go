@@ -0,0 +1,127 @@
+//nolint:testpackage // Testing unexported helpers requires same package access
+package service
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch/mappings"
+)
+
+func TestLintMapping_BuiltInMappingsHaveNoErrors(t *testing.T) {
+	t.Helper()
+
+	for _, indexType := range []string{"raw_content", "classified_content"} {
+		t.Run(indexType, func(t *testing.T) {
+			mapping, err := mappings.GetMappingForType(indexType, 1, 0)
+			if err != nil {
+				t.Fatalf("GetMappingForType: %v", err)
+			}
+			for _, issue := range LintMapping(mapping) {
+				if issue.Severity == domain.MappingIssueError {
+					t.Errorf("unexpected error on built-in mapping: %+v", issue)
+				}
+			}
+		})
+	}
+}
+
+func TestLintMapping_Rules(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name     string
+		mapping  map[string]any
+		wantRule string
+		wantSev  domain.MappingIssueSeverity
+	}{
+		{
+			name: "source_name as text",
+			mapping: strictMapping(map[string]any{
+				"source_name": map[string]any{"type": "text"},
+			}),
+			wantRule: ruleAggregatableField,
+			wantSev:  domain.MappingIssueError,
+		},
+		{
+			name: "source_name as text with keyword sub-field",
+			mapping: strictMapping(map[string]any{
+				"source_name": map[string]any{
+					"type":   "text",
+					"fields": map[string]any{"keyword": map[string]any{"type": "keyword"}},
+				},
+			}),
+			wantRule: ruleAggregatableField,
+			wantSev:  domain.MappingIssueWarning,
+		},
+		{
+			name: "nested topics not indexed",
+			mapping: strictMapping(map[string]any{
+				"meta": map[string]any{"properties": map[string]any{
+					"topics": map[string]any{"type": "keyword", "index": false},
+				}},
+			}),
+			wantRule: ruleAggregatableIndex,
+			wantSev:  domain.MappingIssueError,
+		},
+		{
+			name: "timestamp as keyword",
+			mapping: strictMapping(map[string]any{
+				"crawled_at": map[string]any{"type": "keyword"},
+			}),
+			wantRule: ruleDateType,
+			wantSev:  domain.MappingIssueError,
+		},
+		{
+			name: "date without ISO format",
+			mapping: strictMapping(map[string]any{
+				"published_date": map[string]any{"type": "date", "format": "epoch_millis"},
+			}),
+			wantRule: ruleDateFormat,
+			wantSev:  domain.MappingIssueWarning,
+		},
+		{
+			name: "_source disabled",
+			mapping: map[string]any{"mappings": map[string]any{
+				"dynamic":    "strict",
+				"_source":    map[string]any{"enabled": false},
+				"properties": map[string]any{"title": map[string]any{"type": "text"}},
+			}},
+			wantRule: ruleSourceDisabled,
+			wantSev:  domain.MappingIssueError,
+		},
+		{
+			name:     "no properties",
+			mapping:  map[string]any{"dynamic": "strict"},
+			wantRule: ruleMissingProperties,
+			wantSev:  domain.MappingIssueError,
+		},
+		{
+			name:     "dynamic mapping enabled",
+			mapping:  map[string]any{"properties": map[string]any{"title": map[string]any{"type": "text"}}},
+			wantRule: ruleDynamicMapping,
+			wantSev:  domain.MappingIssueWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintMapping(tt.mapping)
+			if len(issues) != 1 {
+				t.Fatalf("expected 1 issue, got %d: %+v", len(issues), issues)
+			}
+			if issues[0].Rule != tt.wantRule || issues[0].Severity != tt.wantSev {
+				t.Errorf("expected %s/%s, got %s/%s", tt.wantRule, tt.wantSev, issues[0].Rule, issues[0].Severity)
+			}
+		})
+	}
+}
+
+func strictMapping(properties map[string]any) map[string]any {
+	return map[string]any{
+		"mappings": map[string]any{
+			"dynamic":    "strict",
+			"properties": properties,
+		},
+	}
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch/mappings"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/sourcemanager"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/naming"
+)
+
+const (
+	// maxTypoDistance is the largest edit distance treated as a typo of a known source.
+	maxTypoDistance = 2
+	// minTypoNameLength avoids flagging very short prefixes as typos of unrelated sources.
+	minTypoNameLength = 5
+)
+
+// testLeftoverTokens mark index prefixes created by tests, smoke runs, or scratch work.
+var testLeftoverTokens = []string{"test", "tmp", "temp", "smoke", "demo", "scratch", "dev"}
+
+// OrphanESClient defines the Elasticsearch operations needed by OrphanService.
+// The concrete *elasticsearch.Client satisfies this interface.
+type OrphanESClient interface {
+	GetAllIndexDocCounts(ctx context.Context) ([]elasticsearch.IndexDocCount, error)
+	CloseIndex(ctx context.Context, indexName string) error
+}
+
+// SourceLister lists sources registered in source-manager.
+type SourceLister interface {
+	ListSources(ctx context.Context) ([]sourcemanager.Source, error)
+}
+
+// OrphanMetadataStore persists index metadata status changes.
+type OrphanMetadataStore interface {
+	GetIndexMetadata(ctx context.Context, indexName string) (*database.IndexMetadata, error)
+	SaveIndexMetadata(ctx context.Context, metadata *database.IndexMetadata) error
+}
+
+// IndexDeleter deletes an index and its metadata.
+type IndexDeleter interface {
+	DeleteIndex(ctx context.Context, indexName string) error
+}
+
+// OrphanService reconciles content indexes against registered sources.
+type OrphanService struct {
+	esClient       OrphanESClient
+	sources        SourceLister
+	metadata       OrphanMetadataStore
+	deleter        IndexDeleter
+	ignoredSources map[string]bool
+	logger         infralogger.Logger
+}
+
+// NewOrphanService creates a new orphan reconciliation service.
+func NewOrphanService(
+	esClient OrphanESClient,
+	sources SourceLister,
+	metadata OrphanMetadataStore,
+	deleter IndexDeleter,
+	ignoredSources []string,
+	logger infralogger.Logger,
+) *OrphanService {
+	ignored := make(map[string]bool, len(ignoredSources))
+	for _, name := range ignoredSources {
+		ignored[naming.SanitizeSourceName(name)] = true
+	}
+	return &OrphanService{
+		esClient:       esClient,
+		sources:        sources,
+		metadata:       metadata,
+		deleter:        deleter,
+		ignoredSources: ignored,
+		logger:         logger,
+	}
+}
+
+// DetectOrphans compares raw_content and classified_content indexes against
+// source-manager and returns those with no matching source.
+func (s *OrphanService) DetectOrphans(ctx context.Context) (*domain.OrphanReport, error) {
+	sources, err := s.sources.ListSources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sources: %w", err)
+	}
+	// An empty source list almost always means source-manager is misbehaving;
+	// refusing here prevents every index from being reported as an orphan.
+	if len(sources) == 0 {
+		return nil, errors.New("source-manager returned no sources; refusing to reconcile")
+	}
+
+	counts, err := s.esClient.GetAllIndexDocCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list indexes: %w", err)
+	}
+
+	known := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		if prefix := naming.SanitizeSourceName(src.Name); prefix != "" {
+			known[prefix] = true
+		}
+	}
+	knownNames := make([]string, 0, len(known))
+	for name := range known {
+		knownNames = append(knownNames, name)
+	}
+	sort.Strings(knownNames)
+
+	report := &domain.OrphanReport{
+		Orphans:      make([]*domain.OrphanIndex, 0),
+		KnownSources: len(known),
+		CheckedAt:    time.Now().UTC(),
+	}
+
+	for _, idx := range counts {
+		indexType := contentIndexType(idx.Name)
+		if indexType == "" {
+			continue
+		}
+		report.ContentIndexes++
+
+		base, _ := naming.BaseSourceFromIndex(idx.Name)
+		if known[base] || s.ignoredSources[base] {
+			continue
+		}
+
+		orphan := &domain.OrphanIndex{
+			IndexName:     idx.Name,
+			IndexType:     indexType,
+			SourceName:    base,
+			Reason:        domain.OrphanReasonUnknownSource,
+			DocumentCount: idx.DocCount,
+		}
+		switch {
+		case isTestLeftover(base):
+			orphan.Reason = domain.OrphanReasonTestLeftover
+		default:
+			if suggestion := closestSource(base, knownNames); suggestion != "" {
+				orphan.Reason = domain.OrphanReasonPossibleTypo
+				orphan.SuggestedSource = suggestion
+			}
+		}
+		report.Orphans = append(report.Orphans, orphan)
+	}
+
+	sort.Slice(report.Orphans, func(i, j int) bool {
+		return report.Orphans[i].IndexName < report.Orphans[j].IndexName
+	})
+	report.Count = len(report.Orphans)
+
+	return report, nil
+}
+
+// CleanupOrphans archives or deletes the requested indexes. Reconciliation is
+// re-run first so that only indexes that are still orphaned are touched.
+func (s *OrphanService) CleanupOrphans(
+	ctx context.Context,
+	req *domain.OrphanCleanupRequest,
+) (*domain.OrphanCleanupResult, error) {
+	if req.Action != domain.OrphanActionArchive && req.Action != domain.OrphanActionDelete {
+		return nil, fmt.Errorf("invalid action: %s", req.Action)
+	}
+
+	report, err := s.DetectOrphans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orphans := make(map[string]*domain.OrphanIndex, len(report.Orphans))
+	for _, orphan := range report.Orphans {
+		orphans[orphan.IndexName] = orphan
+	}
+
+	result := &domain.OrphanCleanupResult{
+		Action:    req.Action,
+		Processed: make([]string, 0, len(req.IndexNames)),
+	}
+
+	for _, indexName := range req.IndexNames {
+		orphan, ok := orphans[indexName]
+		if !ok {
+			result.Skipped = append(result.Skipped, indexName)
+			continue
+		}
+
+		var actionErr error
+		if req.Action == domain.OrphanActionDelete {
+			actionErr = s.deleter.DeleteIndex(ctx, indexName)
+		} else {
+			actionErr = s.archive(ctx, orphan)
+		}
+		if actionErr != nil {
+			s.logger.Warn("Failed to clean up orphan index",
+				infralogger.String("index_name", indexName),
+				infralogger.String("action", string(req.Action)),
+				infralogger.Error(actionErr),
+			)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", indexName, actionErr))
+			continue
+		}
+		result.Processed = append(result.Processed, indexName)
+	}
+
+	s.logger.Info("Orphan cleanup completed",
+		infralogger.String("action", string(req.Action)),
+		infralogger.Int("processed", len(result.Processed)),
+		infralogger.Int("skipped", len(result.Skipped)),
+		infralogger.Int("failed", len(result.Errors)),
+	)
+
+	return result, nil
+}
+
+// archive closes the index and marks its metadata as archived.
+func (s *OrphanService) archive(ctx context.Context, orphan *domain.OrphanIndex) error {
+	if err := s.esClient.CloseIndex(ctx, orphan.IndexName); err != nil {
+		return err
+	}
+
+	metadata, err := s.metadata.GetIndexMetadata(ctx, orphan.IndexName)
+	if err != nil || metadata == nil {
+		metadata = &database.IndexMetadata{
+			IndexName:      orphan.IndexName,
+			IndexType:      string(orphan.IndexType),
+			SourceName:     sql.NullString{String: orphan.SourceName, Valid: orphan.SourceName != ""},
+			MappingVersion: mappings.GetMappingVersion(string(orphan.IndexType)),
+		}
+	}
+	metadata.Status = string(domain.IndexStatusArchived)
+	if saveErr := s.metadata.SaveIndexMetadata(ctx, metadata); saveErr != nil {
+		s.logger.Warn("Failed to mark orphan index archived",
+			infralogger.String("index_name", orphan.IndexName),
+			infralogger.Error(saveErr),
+		)
+	}
+	return nil
+}
+
+// contentIndexType returns the index type for raw/classified content indexes, or "" otherwise.
+func contentIndexType(indexName string) domain.IndexType {
+	switch {
+	case naming.IsRawContentIndex(indexName):
+		return domain.IndexTypeRawContent
+	case naming.IsClassifiedContentIndex(indexName):
+		return domain.IndexTypeClassifiedContent
+	default:
+		return ""
+	}
+}
+
+// isTestLeftover reports whether any underscore-separated token of the prefix marks a test index.
+func isTestLeftover(prefix string) bool {
+	for token := range strings.SplitSeq(prefix, "_") {
+		if slices.Contains(testLeftoverTokens, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// closestSource returns the known source nearest to prefix within maxTypoDistance, or "".
+func closestSource(prefix string, known []string) string {
+	if len(prefix) < minTypoNameLength {
+		return ""
+	}
+	best := ""
+	bestDistance := maxTypoDistance + 1
+	for _, candidate := range known {
+		if d := levenshtein(prefix, candidate); d < bestDistance {
+			best = candidate
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// levenshtein computes the edit distance between two strings.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,221 @@
+//nolint:testpackage // Testing unexported helpers requires same package access
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/sourcemanager"
+)
+
+// --- mocks ---
+
+type mockOrphanES struct {
+	counts []elasticsearch.IndexDocCount
+	closed []string
+}
+
+func (m *mockOrphanES) GetAllIndexDocCounts(_ context.Context) ([]elasticsearch.IndexDocCount, error) {
+	return m.counts, nil
+}
+
+func (m *mockOrphanES) CloseIndex(_ context.Context, indexName string) error {
+	m.closed = append(m.closed, indexName)
+	return nil
+}
+
+type mockSourceLister struct {
+	sources []sourcemanager.Source
+	err     error
+}
+
+func (m *mockSourceLister) ListSources(_ context.Context) ([]sourcemanager.Source, error) {
+	return m.sources, m.err
+}
+
+type mockMetadataStore struct {
+	saved []*database.IndexMetadata
+}
+
+func (m *mockMetadataStore) GetIndexMetadata(_ context.Context, _ string) (*database.IndexMetadata, error) {
+	return nil, database.ErrIndexMetadataNotFound
+}
+
+func (m *mockMetadataStore) SaveIndexMetadata(_ context.Context, metadata *database.IndexMetadata) error {
+	m.saved = append(m.saved, metadata)
+	return nil
+}
+
+type mockIndexDeleter struct {
+	deleted []string
+}
+
+func (m *mockIndexDeleter) DeleteIndex(_ context.Context, indexName string) error {
+	m.deleted = append(m.deleted, indexName)
+	return nil
+}
+
+func newOrphanTestService(es *mockOrphanES, deleter *mockIndexDeleter, store *mockMetadataStore) *OrphanService {
+	sources := &mockSourceLister{sources: []sourcemanager.Source{
+		{Name: "Sudbury Star"},
+		{Name: "example.com"},
+	}}
+	return NewOrphanService(es, sources, store, deleter, []string{"rfp"}, &noopLogger{})
+}
+
+// --- DetectOrphans ---
+
+func TestDetectOrphans_ClassifiesReasons(t *testing.T) {
+	t.Helper()
+
+	es := &mockOrphanES{counts: []elasticsearch.IndexDocCount{
+		{Name: "sudbury_star_raw_content", DocCount: 10},
+		{Name: "example_com_classified_content", DocCount: 5},
+		{Name: "sudbury_stra_raw_content", DocCount: 3},
+		{Name: "crawler_test_raw_content", DocCount: 1},
+		{Name: "defunct_daily_classified_content", DocCount: 42},
+		{Name: "rfp_classified_content", DocCount: 100},
+		{Name: "northcloud_communities", DocCount: 7},
+	}}
+	svc := newOrphanTestService(es, &mockIndexDeleter{}, &mockMetadataStore{})
+
+	report, err := svc.DetectOrphans(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.ContentIndexes != 6 {
+		t.Errorf("ContentIndexes = %d, want 6", report.ContentIndexes)
+	}
+	if report.Count != 3 {
+		t.Fatalf("Count = %d, want 3: %+v", report.Count, report.Orphans)
+	}
+
+	want := map[string]domain.OrphanReason{
+		"crawler_test_raw_content":         domain.OrphanReasonTestLeftover,
+		"defunct_daily_classified_content": domain.OrphanReasonUnknownSource,
+		"sudbury_stra_raw_content":         domain.OrphanReasonPossibleTypo,
+	}
+	for _, orphan := range report.Orphans {
+		if want[orphan.IndexName] != orphan.Reason {
+			t.Errorf("%s: reason = %q, want %q", orphan.IndexName, orphan.Reason, want[orphan.IndexName])
+		}
+		if orphan.Reason == domain.OrphanReasonPossibleTypo && orphan.SuggestedSource != "sudbury_star" {
+			t.Errorf("%s: suggested = %q, want sudbury_star", orphan.IndexName, orphan.SuggestedSource)
+		}
+	}
+}
+
+func TestDetectOrphans_RefusesEmptySourceList(t *testing.T) {
+	t.Helper()
+
+	es := &mockOrphanES{counts: []elasticsearch.IndexDocCount{{Name: "a_raw_content"}}}
+	svc := NewOrphanService(es, &mockSourceLister{}, &mockMetadataStore{}, &mockIndexDeleter{}, nil, &noopLogger{})
+
+	if _, err := svc.DetectOrphans(context.Background()); err == nil {
+		t.Fatal("expected error for empty source list")
+	}
+}
+
+func TestDetectOrphans_SourceManagerError(t *testing.T) {
+	t.Helper()
+
+	lister := &mockSourceLister{err: errors.New("connection refused")}
+	svc := NewOrphanService(&mockOrphanES{}, lister, &mockMetadataStore{}, &mockIndexDeleter{}, nil, &noopLogger{})
+
+	if _, err := svc.DetectOrphans(context.Background()); err == nil {
+		t.Fatal("expected error when source-manager is unavailable")
+	}
+}
+
+// --- CleanupOrphans ---
+
+func TestCleanupOrphans_OnlyTouchesOrphans(t *testing.T) {
+	t.Helper()
+
+	es := &mockOrphanES{counts: []elasticsearch.IndexDocCount{
+		{Name: "sudbury_star_raw_content"},
+		{Name: "defunct_daily_raw_content"},
+	}}
+	deleter := &mockIndexDeleter{}
+	svc := newOrphanTestService(es, deleter, &mockMetadataStore{})
+
+	result, err := svc.CleanupOrphans(context.Background(), &domain.OrphanCleanupRequest{
+		IndexNames: []string{"defunct_daily_raw_content", "sudbury_star_raw_content"},
+		Action:     domain.OrphanActionDelete,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deleter.deleted) != 1 || deleter.deleted[0] != "defunct_daily_raw_content" {
+		t.Errorf("deleted = %v, want [defunct_daily_raw_content]", deleter.deleted)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "sudbury_star_raw_content" {
+		t.Errorf("skipped = %v, want [sudbury_star_raw_content]", result.Skipped)
+	}
+}
+
+func TestCleanupOrphans_ArchiveClosesAndMarksMetadata(t *testing.T) {
+	t.Helper()
+
+	es := &mockOrphanES{counts: []elasticsearch.IndexDocCount{{Name: "defunct_daily_raw_content"}}}
+	store := &mockMetadataStore{}
+	svc := newOrphanTestService(es, &mockIndexDeleter{}, store)
+
+	result, err := svc.CleanupOrphans(context.Background(), &domain.OrphanCleanupRequest{
+		IndexNames: []string{"defunct_daily_raw_content"},
+		Action:     domain.OrphanActionArchive,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Processed) != 1 || len(es.closed) != 1 {
+		t.Fatalf("processed = %v, closed = %v", result.Processed, es.closed)
+	}
+	if len(store.saved) != 1 || store.saved[0].Status != string(domain.IndexStatusArchived) {
+		t.Errorf("expected archived metadata, got %+v", store.saved)
+	}
+}
+
+func TestCleanupOrphans_InvalidAction(t *testing.T) {
+	t.Helper()
+
+	svc := newOrphanTestService(&mockOrphanES{}, &mockIndexDeleter{}, &mockMetadataStore{})
+
+	_, err := svc.CleanupOrphans(context.Background(), &domain.OrphanCleanupRequest{
+		IndexNames: []string{"x_raw_content"},
+		Action:     "purge",
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid action")
+	}
+}
+
+// --- helpers ---
+
+func TestLevenshtein(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"sudbury_star", "sudbury_stra", 2},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
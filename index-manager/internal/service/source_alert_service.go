@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/naming"
+)
+
+// SourceHealthProvider returns per-source pipeline health.
+// The concrete *AggregationService satisfies this interface.
+type SourceHealthProvider interface {
+	GetSourceHealth(ctx context.Context) (*domain.SourceHealthResponse, error)
+}
+
+// AlertNotifier delivers source alerts to an external channel.
+type AlertNotifier interface {
+	Notify(ctx context.Context, alerts []domain.SourceAlert) error
+}
+
+// SourceAlertThresholds configures when a source is considered unhealthy.
+// A negative MaxBacklog or MinAvgQuality disables that rule.
+type SourceAlertThresholds struct {
+	MaxBacklog     int64
+	MinAvgQuality  float64
+	AlertOnStalled bool
+}
+
+// SourceAlertService evaluates source health against thresholds and notifies
+// on new breaches. A breach is re-sent only after the cooldown elapses, and is
+// forgotten once the source recovers so the next breach notifies immediately.
+type SourceAlertService struct {
+	health     SourceHealthProvider
+	sources    SourceLister
+	notifier   AlertNotifier
+	thresholds SourceAlertThresholds
+	cooldown   time.Duration
+	logger     infralogger.Logger
+	now        func() time.Time
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+}
+
+// NewSourceAlertService creates a source health alert service. sources is used
+// to find enabled sources for the stalled rule; notifier may be nil, in which
+// case breaches are only reported through Evaluate.
+func NewSourceAlertService(
+	health SourceHealthProvider,
+	sources SourceLister,
+	notifier AlertNotifier,
+	thresholds SourceAlertThresholds,
+	cooldown time.Duration,
+	logger infralogger.Logger,
+) *SourceAlertService {
+	return &SourceAlertService{
+		health:     health,
+		sources:    sources,
+		notifier:   notifier,
+		thresholds: thresholds,
+		cooldown:   cooldown,
+		logger:     logger,
+		now:        time.Now,
+		lastSent:   make(map[string]time.Time),
+	}
+}
+
+// Evaluate returns every source currently crossing a threshold.
+func (s *SourceAlertService) Evaluate(ctx context.Context) (*domain.SourceAlertReport, error) {
+	healthResp, err := s.health.GetSourceHealth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get source health: %w", err)
+	}
+
+	enabled := s.enabledSources(ctx)
+	checkedAt := s.now().UTC()
+
+	alerts := make([]domain.SourceAlert, 0)
+	for i := range healthResp.Sources {
+		alerts = append(alerts, s.evaluateSource(&healthResp.Sources[i], enabled, checkedAt)...)
+	}
+
+	return &domain.SourceAlertReport{
+		Alerts:    alerts,
+		Count:     len(alerts),
+		CheckedAt: checkedAt,
+	}, nil
+}
+
+// CheckAndNotify evaluates thresholds and sends breaches that are new or past
+// their cooldown. Returns the number of alerts sent.
+func (s *SourceAlertService) CheckAndNotify(ctx context.Context) (int, error) {
+	report, err := s.Evaluate(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	due := s.dueAlerts(report.Alerts, report.CheckedAt)
+	if len(due) == 0 || s.notifier == nil {
+		return 0, nil
+	}
+
+	if notifyErr := s.notifier.Notify(ctx, due); notifyErr != nil {
+		return 0, fmt.Errorf("notify source alerts: %w", notifyErr)
+	}
+
+	s.mu.Lock()
+	for i := range due {
+		s.lastSent[alertKey(&due[i])] = report.CheckedAt
+	}
+	s.mu.Unlock()
+
+	return len(due), nil
+}
+
+// evaluateSource applies each enabled rule to a single source.
+func (s *SourceAlertService) evaluateSource(
+	health *domain.SourceHealth,
+	enabled map[string]bool,
+	at time.Time,
+) []domain.SourceAlert {
+	var alerts []domain.SourceAlert
+
+	if s.thresholds.MaxBacklog >= 0 && health.Backlog > s.thresholds.MaxBacklog {
+		alerts = append(alerts, domain.SourceAlert{
+			Source:     health.Source,
+			Rule:       domain.SourceAlertRuleBacklog,
+			Message:    fmt.Sprintf("%d documents awaiting classification (max %d)", health.Backlog, s.thresholds.MaxBacklog),
+			Value:      float64(health.Backlog),
+			Threshold:  float64(s.thresholds.MaxBacklog),
+			DetectedAt: at,
+		})
+	}
+
+	if s.thresholds.AlertOnStalled && enabled[health.Source] && health.Delta24h == 0 {
+		alerts = append(alerts, domain.SourceAlert{
+			Source:     health.Source,
+			Rule:       domain.SourceAlertRuleStalled,
+			Message:    "enabled source classified no documents in the last 24h",
+			DetectedAt: at,
+		})
+	}
+
+	// Sources with nothing classified have no meaningful average.
+	if s.thresholds.MinAvgQuality >= 0 && health.ClassifiedCount > 0 && health.AvgQuality < s.thresholds.MinAvgQuality {
+		alerts = append(alerts, domain.SourceAlert{
+			Source:     health.Source,
+			Rule:       domain.SourceAlertRuleLowQuality,
+			Message:    fmt.Sprintf("average quality %.1f below minimum %.1f", health.AvgQuality, s.thresholds.MinAvgQuality),
+			Value:      health.AvgQuality,
+			Threshold:  s.thresholds.MinAvgQuality,
+			DetectedAt: at,
+		})
+	}
+
+	return alerts
+}
+
+// enabledSources returns the index prefixes of enabled sources. When
+// source-manager is unreachable the stalled rule is skipped for this run.
+func (s *SourceAlertService) enabledSources(ctx context.Context) map[string]bool {
+	enabled := make(map[string]bool)
+	if !s.thresholds.AlertOnStalled || s.sources == nil {
+		return enabled
+	}
+
+	sources, err := s.sources.ListSources(ctx)
+	if err != nil {
+		s.logger.Warn("Skipping stalled source check: failed to list sources", infralogger.Error(err))
+		return enabled
+	}
+	for _, src := range sources {
+		if src.Enabled {
+			enabled[naming.SanitizeSourceName(src.Name)] = true
+		}
+	}
+	return enabled
+}
+
+// dueAlerts filters out breaches still inside their cooldown and forgets
+// breaches that are no longer active.
+func (s *SourceAlertService) dueAlerts(alerts []domain.SourceAlert, now time.Time) []domain.SourceAlert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := make(map[string]bool, len(alerts))
+	due := make([]domain.SourceAlert, 0, len(alerts))
+	for i := range alerts {
+		key := alertKey(&alerts[i])
+		active[key] = true
+		if sent, ok := s.lastSent[key]; ok && now.Sub(sent) < s.cooldown {
+			continue
+		}
+		due = append(due, alerts[i])
+	}
+
+	for key := range s.lastSent {
+		if !active[key] {
+			delete(s.lastSent, key)
+		}
+	}
+
+	return due
+}
+
+func alertKey(alert *domain.SourceAlert) string {
+	return alert.Source + "|" + string(alert.Rule)
+}
@@ -0,0 +1,184 @@
+//nolint:testpackage // Testing unexported helpers requires same package access
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/sourcemanager"
+)
+
+type fakeSourceHealth struct {
+	sources []domain.SourceHealth
+}
+
+func (f *fakeSourceHealth) GetSourceHealth(_ context.Context) (*domain.SourceHealthResponse, error) {
+	return &domain.SourceHealthResponse{Sources: f.sources, Total: len(f.sources)}, nil
+}
+
+type fakeAlertNotifier struct {
+	batches [][]domain.SourceAlert
+	err     error
+}
+
+func (f *fakeAlertNotifier) Notify(_ context.Context, alerts []domain.SourceAlert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.batches = append(f.batches, alerts)
+	return nil
+}
+
+func newTestAlertService(
+	t *testing.T,
+	health []domain.SourceHealth,
+	sources []sourcemanager.Source,
+	notifier *fakeAlertNotifier,
+) *SourceAlertService {
+	t.Helper()
+
+	return NewSourceAlertService(
+		&fakeSourceHealth{sources: health},
+		&mockSourceLister{sources: sources},
+		notifier,
+		SourceAlertThresholds{MaxBacklog: 100, MinAvgQuality: 40, AlertOnStalled: true},
+		time.Hour,
+		&noopLogger{},
+	)
+}
+
+func alertRules(alerts []domain.SourceAlert) map[string]domain.SourceAlertRule {
+	rules := make(map[string]domain.SourceAlertRule, len(alerts))
+	for _, alert := range alerts {
+		rules[alert.Source+"/"+string(alert.Rule)] = alert.Rule
+	}
+	return rules
+}
+
+func TestSourceAlertService_Evaluate(t *testing.T) {
+	t.Helper()
+
+	health := []domain.SourceHealth{
+		{Source: "backlogged", Backlog: 500, ClassifiedCount: 10, Delta24h: 5, AvgQuality: 70},
+		{Source: "stalled_enabled", ClassifiedCount: 10, Delta24h: 0, AvgQuality: 70},
+		{Source: "stalled_disabled", ClassifiedCount: 10, Delta24h: 0, AvgQuality: 70},
+		{Source: "low_quality", ClassifiedCount: 10, Delta24h: 3, AvgQuality: 25},
+		{Source: "empty", Delta24h: 1},
+		{Source: "healthy", Backlog: 10, ClassifiedCount: 10, Delta24h: 3, AvgQuality: 80},
+	}
+	sources := []sourcemanager.Source{
+		{Name: "Stalled Enabled", Enabled: true},
+		{Name: "Stalled Disabled", Enabled: false},
+	}
+	svc := newTestAlertService(t, health, sources, nil)
+
+	report, err := svc.Evaluate(context.Background())
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	rules := alertRules(report.Alerts)
+	want := []string{"backlogged/backlog", "stalled_enabled/stalled", "low_quality/low_quality"}
+	if len(rules) != len(want) {
+		t.Fatalf("alerts = %v, want %v", rules, want)
+	}
+	for _, key := range want {
+		if _, ok := rules[key]; !ok {
+			t.Errorf("missing alert %s in %v", key, rules)
+		}
+	}
+}
+
+func TestSourceAlertService_NegativeThresholdsDisableRules(t *testing.T) {
+	t.Helper()
+
+	svc := NewSourceAlertService(
+		&fakeSourceHealth{sources: []domain.SourceHealth{
+			{Source: "noisy", Backlog: 1_000_000, ClassifiedCount: 1, AvgQuality: 1},
+		}},
+		nil,
+		nil,
+		SourceAlertThresholds{MaxBacklog: -1, MinAvgQuality: -1},
+		time.Hour,
+		&noopLogger{},
+	)
+
+	report, err := svc.Evaluate(context.Background())
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if report.Count != 0 {
+		t.Errorf("expected no alerts with disabled rules, got %v", report.Alerts)
+	}
+}
+
+func TestSourceAlertService_CheckAndNotify_Cooldown(t *testing.T) {
+	t.Helper()
+
+	health := &fakeSourceHealth{sources: []domain.SourceHealth{{Source: "backlogged", Backlog: 500}}}
+	notifier := &fakeAlertNotifier{}
+	svc := NewSourceAlertService(
+		health, nil, notifier,
+		SourceAlertThresholds{MaxBacklog: 100, MinAvgQuality: -1},
+		time.Hour,
+		&noopLogger{},
+	)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if sent, _ := svc.CheckAndNotify(ctx); sent != 1 {
+		t.Fatalf("first check sent = %d, want 1", sent)
+	}
+
+	now = now.Add(30 * time.Minute)
+	if sent, _ := svc.CheckAndNotify(ctx); sent != 0 {
+		t.Errorf("check within cooldown sent = %d, want 0", sent)
+	}
+
+	now = now.Add(time.Hour)
+	if sent, _ := svc.CheckAndNotify(ctx); sent != 1 {
+		t.Errorf("check after cooldown sent = %d, want 1", sent)
+	}
+
+	// Recovery clears the breach so the next one notifies immediately.
+	health.sources[0].Backlog = 0
+	if sent, _ := svc.CheckAndNotify(ctx); sent != 0 {
+		t.Errorf("recovered check sent = %d, want 0", sent)
+	}
+	health.sources[0].Backlog = 500
+	now = now.Add(time.Minute)
+	if sent, _ := svc.CheckAndNotify(ctx); sent != 1 {
+		t.Errorf("re-breach sent = %d, want 1", sent)
+	}
+
+	if len(notifier.batches) != 3 {
+		t.Errorf("notifier batches = %d, want 3", len(notifier.batches))
+	}
+}
+
+func TestSourceAlertService_CheckAndNotify_FailureRetriesNextRun(t *testing.T) {
+	t.Helper()
+
+	notifier := &fakeAlertNotifier{err: errors.New("webhook down")}
+	svc := NewSourceAlertService(
+		&fakeSourceHealth{sources: []domain.SourceHealth{{Source: "backlogged", Backlog: 500}}},
+		nil, notifier,
+		SourceAlertThresholds{MaxBacklog: 100, MinAvgQuality: -1},
+		time.Hour,
+		&noopLogger{},
+	)
+	ctx := context.Background()
+
+	if _, err := svc.CheckAndNotify(ctx); err == nil {
+		t.Fatal("expected notify error")
+	}
+
+	notifier.err = nil
+	if sent, err := svc.CheckAndNotify(ctx); err != nil || sent != 1 {
+		t.Errorf("retry sent = %d, err = %v; want 1, nil", sent, err)
+	}
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+const (
+	defaultForecastLookbackDays = 30
+	maxForecastLookbackDays     = 365
+	defaultForecastHorizonDays  = 90
+	maxForecastHorizonDays      = 365
+	defaultForecastTopIndexes   = 10
+	// maxThresholdSearchDays bounds the seasonal day-by-day threshold search.
+	maxThresholdSearchDays = 3650
+	// minSeasonalPoints is two full weeks, the least needed to average each weekday.
+	minSeasonalPoints = 14
+	daysPerWeek       = 7
+	percentDivisor    = 100
+	hoursPerDay       = 24
+)
+
+// StorageESClient defines the Elasticsearch operations needed by StorageService.
+// The concrete *elasticsearch.Client satisfies this interface.
+type StorageESClient interface {
+	GetAllIndexStorage(ctx context.Context) ([]elasticsearch.IndexStorage, error)
+	GetDiskUsage(ctx context.Context) (*elasticsearch.DiskUsage, error)
+}
+
+// StorageSnapshotStore persists and queries index size snapshots.
+type StorageSnapshotStore interface {
+	RecordIndexSizeSnapshots(ctx context.Context, capturedAt time.Time, snapshots []database.IndexSizeSnapshot) error
+	ListDailyTotalSizes(ctx context.Context, since time.Time) ([]database.IndexSizeSnapshot, error)
+	ListDailyIndexSizes(ctx context.Context, indexName string, since time.Time) ([]database.IndexSizeSnapshot, error)
+	PruneIndexSizeSnapshots(ctx context.Context, before time.Time) (int64, error)
+}
+
+// StorageService tracks index size over time and forecasts disk exhaustion.
+type StorageService struct {
+	esClient         StorageESClient
+	store            StorageSnapshotStore
+	thresholdPercent float64
+	logger           infralogger.Logger
+}
+
+// NewStorageService creates a new storage tracking and forecasting service.
+// thresholdPercent is the default disk usage percentage treated as "full".
+func NewStorageService(
+	esClient StorageESClient,
+	store StorageSnapshotStore,
+	thresholdPercent float64,
+	logger infralogger.Logger,
+) *StorageService {
+	return &StorageService{
+		esClient:         esClient,
+		store:            store,
+		thresholdPercent: thresholdPercent,
+		logger:           logger,
+	}
+}
+
+// CaptureSnapshot records the current size and doc count of every index.
+func (s *StorageService) CaptureSnapshot(ctx context.Context) (int, error) {
+	storage, err := s.esClient.GetAllIndexStorage(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get index storage: %w", err)
+	}
+
+	snapshots := make([]database.IndexSizeSnapshot, 0, len(storage))
+	for _, idx := range storage {
+		snapshots = append(snapshots, database.IndexSizeSnapshot{
+			IndexName: idx.Name,
+			SizeBytes: idx.SizeBytes,
+			DocCount:  idx.DocCount,
+		})
+	}
+
+	if recordErr := s.store.RecordIndexSizeSnapshots(ctx, time.Now().UTC(), snapshots); recordErr != nil {
+		return 0, fmt.Errorf("record snapshots: %w", recordErr)
+	}
+
+	return len(snapshots), nil
+}
+
+// PruneSnapshots deletes snapshots older than the retention period.
+func (s *StorageService) PruneSnapshots(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.store.PruneIndexSizeSnapshots(ctx, time.Now().UTC().Add(-retention))
+}
+
+// GetIndexHistory returns the daily size history for a single index.
+func (s *StorageService) GetIndexHistory(
+	ctx context.Context,
+	indexName string,
+	days int,
+) (*domain.IndexStorageHistory, error) {
+	days = clampDays(days, defaultForecastLookbackDays, maxForecastLookbackDays)
+	rows, err := s.store.ListDailyIndexSizes(ctx, indexName, daysAgo(days))
+	if err != nil {
+		return nil, fmt.Errorf("list index sizes: %w", err)
+	}
+
+	return &domain.IndexStorageHistory{
+		IndexName: indexName,
+		Points:    snapshotsToPoints(rows),
+	}, nil
+}
+
+// Forecast projects cluster storage growth and estimates days until the disk threshold.
+func (s *StorageService) Forecast(
+	ctx context.Context,
+	req *domain.StorageForecastRequest,
+) (*domain.StorageForecast, error) {
+	s.normalizeForecastRequest(req)
+	since := daysAgo(req.LookbackDays)
+
+	totals, err := s.store.ListDailyTotalSizes(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("list total sizes: %w", err)
+	}
+	disk, err := s.esClient.GetDiskUsage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get disk usage: %w", err)
+	}
+
+	points := snapshotsToPoints(totals)
+	method := req.Method
+	if method == domain.ForecastMethodSeasonal && len(points) < minSeasonalPoints {
+		method = domain.ForecastMethodLinear
+	}
+
+	now := time.Now().UTC()
+	forecast := &domain.StorageForecast{
+		Method:           method,
+		LookbackDays:     req.LookbackDays,
+		HorizonDays:      req.HorizonDays,
+		DataPoints:       len(points),
+		DiskTotalBytes:   disk.TotalBytes,
+		DiskUsedBytes:    disk.UsedBytes,
+		ThresholdPercent: req.ThresholdPercent,
+		ThresholdBytes:   int64(float64(disk.TotalBytes) * req.ThresholdPercent / percentDivisor),
+		Projection:       make([]domain.StoragePoint, 0, req.HorizonDays),
+		GeneratedAt:      now,
+	}
+
+	if len(points) >= 2 {
+		dailyGrowth := projectGrowth(points, method)
+		forecast.GrowthBytesPerDay = mean(dailyGrowth)
+		forecast.Projection = buildProjection(points[len(points)-1], dailyGrowth, req.HorizonDays)
+
+		remaining := float64(forecast.ThresholdBytes - disk.UsedBytes)
+		if days, ok := daysUntil(remaining, dailyGrowth, points[len(points)-1].Date); ok {
+			forecast.DaysUntilThreshold = &days
+			date := now.Add(time.Duration(days * hoursPerDay * float64(time.Hour)))
+			forecast.ThresholdDate = &date
+		}
+	}
+
+	growers, err := s.topGrowers(ctx, since, req)
+	if err != nil {
+		s.logger.Warn("Failed to compute per-index growth", infralogger.Error(err))
+		growers = []*domain.IndexGrowth{}
+	}
+	forecast.TopGrowers = growers
+
+	return forecast, nil
+}
+
+func (s *StorageService) normalizeForecastRequest(req *domain.StorageForecastRequest) {
+	if req.Method != domain.ForecastMethodSeasonal {
+		req.Method = domain.ForecastMethodLinear
+	}
+	req.LookbackDays = clampDays(req.LookbackDays, defaultForecastLookbackDays, maxForecastLookbackDays)
+	req.HorizonDays = clampDays(req.HorizonDays, defaultForecastHorizonDays, maxForecastHorizonDays)
+	if req.TopIndexes <= 0 {
+		req.TopIndexes = defaultForecastTopIndexes
+	}
+	if req.ThresholdPercent <= 0 || req.ThresholdPercent > percentDivisor {
+		req.ThresholdPercent = s.thresholdPercent
+	}
+}
+
+// topGrowers ranks indexes by linear growth rate over the lookback window.
+func (s *StorageService) topGrowers(
+	ctx context.Context,
+	since time.Time,
+	req *domain.StorageForecastRequest,
+) ([]*domain.IndexGrowth, error) {
+	rows, err := s.store.ListDailyIndexSizes(ctx, "", since)
+	if err != nil {
+		return nil, err
+	}
+
+	byIndex := make(map[string][]database.IndexSizeSnapshot)
+	for _, row := range rows {
+		byIndex[row.IndexName] = append(byIndex[row.IndexName], row)
+	}
+
+	growers := make([]*domain.IndexGrowth, 0, len(byIndex))
+	for name, series := range byIndex {
+		points := snapshotsToPoints(series)
+		last := points[len(points)-1]
+		growth := &domain.IndexGrowth{
+			IndexName:          name,
+			CurrentSizeBytes:   last.SizeBytes,
+			CurrentDocCount:    last.DocCount,
+			ProjectedSizeBytes: last.SizeBytes,
+		}
+		if len(points) >= 2 {
+			growth.GrowthBytesPerDay = linearSlope(points, func(p domain.StoragePoint) float64 { return float64(p.SizeBytes) })
+			growth.GrowthDocsPerDay = linearSlope(points, func(p domain.StoragePoint) float64 { return float64(p.DocCount) })
+			growth.ProjectedSizeBytes = last.SizeBytes + int64(growth.GrowthBytesPerDay*float64(req.HorizonDays))
+		}
+		growers = append(growers, growth)
+	}
+
+	sort.Slice(growers, func(i, j int) bool {
+		return growers[i].GrowthBytesPerDay > growers[j].GrowthBytesPerDay
+	})
+	if len(growers) > req.TopIndexes {
+		growers = growers[:req.TopIndexes]
+	}
+
+	return growers, nil
+}
+
+// projectGrowth returns the expected growth for each day of a repeating cycle.
+// Linear yields a single-element cycle; seasonal yields one value per weekday,
+// indexed by time.Weekday.
+func projectGrowth(points []domain.StoragePoint, method domain.ForecastMethod) []float64 {
+	if method != domain.ForecastMethodSeasonal {
+		return []float64{linearSlope(points, func(p domain.StoragePoint) float64 { return float64(p.SizeBytes) })}
+	}
+
+	sums := make([]float64, daysPerWeek)
+	counts := make([]int, daysPerWeek)
+	for i := 1; i < len(points); i++ {
+		gapDays := points[i].Date.Sub(points[i-1].Date).Hours() / hoursPerDay
+		if gapDays <= 0 {
+			continue
+		}
+		perDay := float64(points[i].SizeBytes-points[i-1].SizeBytes) / gapDays
+		weekday := points[i].Date.Weekday()
+		sums[weekday] += perDay
+		counts[weekday]++
+	}
+
+	cycle := make([]float64, daysPerWeek)
+	for day := range cycle {
+		if counts[day] > 0 {
+			cycle[day] = sums[day] / float64(counts[day])
+		}
+	}
+	return cycle
+}
+
+// buildProjection extends the last point forward by horizon days using the growth cycle.
+func buildProjection(last domain.StoragePoint, cycle []float64, horizon int) []domain.StoragePoint {
+	projection := make([]domain.StoragePoint, 0, horizon)
+	size := float64(last.SizeBytes)
+	for d := 1; d <= horizon; d++ {
+		date := last.Date.AddDate(0, 0, d)
+		size += cycleGrowth(cycle, date)
+		projection = append(projection, domain.StoragePoint{
+			Date:      date,
+			SizeBytes: int64(math.Max(size, 0)),
+		})
+	}
+	return projection
+}
+
+// daysUntil returns the number of days until cumulative growth covers remaining bytes.
+// It reports false when storage is not growing toward the threshold.
+func daysUntil(remaining float64, cycle []float64, from time.Time) (float64, bool) {
+	if remaining <= 0 {
+		return 0, true
+	}
+	if mean(cycle) <= 0 {
+		return 0, false
+	}
+	if len(cycle) == 1 {
+		return remaining / cycle[0], true
+	}
+
+	var cumulative float64
+	for d := 1; d <= maxThresholdSearchDays; d++ {
+		growth := cycleGrowth(cycle, from.AddDate(0, 0, d))
+		if cumulative+growth >= remaining && growth > 0 {
+			return float64(d-1) + (remaining-cumulative)/growth, true
+		}
+		cumulative += growth
+	}
+	return 0, false
+}
+
+func cycleGrowth(cycle []float64, date time.Time) float64 {
+	if len(cycle) == 1 {
+		return cycle[0]
+	}
+	return cycle[date.Weekday()]
+}
+
+// linearSlope fits a least-squares line and returns the slope in units per day.
+func linearSlope(points []domain.StoragePoint, value func(domain.StoragePoint) float64) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+	origin := points[0].Date
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := p.Date.Sub(origin).Hours() / hoursPerDay
+		y := value(p)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func snapshotsToPoints(rows []database.IndexSizeSnapshot) []domain.StoragePoint {
+	points := make([]domain.StoragePoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, domain.StoragePoint{
+			Date:      row.CapturedAt,
+			SizeBytes: row.SizeBytes,
+			DocCount:  row.DocCount,
+		})
+	}
+	return points
+}
+
+func clampDays(days, defaultDays, maxDays int) int {
+	if days <= 0 {
+		return defaultDays
+	}
+	return min(days, maxDays)
+}
+
+func daysAgo(days int) time.Time {
+	return time.Now().UTC().AddDate(0, 0, -days)
+}
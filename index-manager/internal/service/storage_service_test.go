@@ -0,0 +1,194 @@
+//nolint:testpackage // Testing unexported helpers requires same package access
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/database"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/elasticsearch"
+)
+
+// --- mocks ---
+
+type mockStorageES struct {
+	disk elasticsearch.DiskUsage
+}
+
+func (m *mockStorageES) GetAllIndexStorage(_ context.Context) ([]elasticsearch.IndexStorage, error) {
+	return nil, nil
+}
+
+func (m *mockStorageES) GetDiskUsage(_ context.Context) (*elasticsearch.DiskUsage, error) {
+	return &m.disk, nil
+}
+
+type mockSnapshotStore struct {
+	totals  []database.IndexSizeSnapshot
+	indexes []database.IndexSizeSnapshot
+}
+
+func (m *mockSnapshotStore) RecordIndexSizeSnapshots(
+	_ context.Context, _ time.Time, _ []database.IndexSizeSnapshot,
+) error {
+	return nil
+}
+
+func (m *mockSnapshotStore) ListDailyTotalSizes(_ context.Context, _ time.Time) ([]database.IndexSizeSnapshot, error) {
+	return m.totals, nil
+}
+
+func (m *mockSnapshotStore) ListDailyIndexSizes(
+	_ context.Context, _ string, _ time.Time,
+) ([]database.IndexSizeSnapshot, error) {
+	return m.indexes, nil
+}
+
+func (m *mockSnapshotStore) PruneIndexSizeSnapshots(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+// dailySeries builds n daily snapshots starting at a Monday, growing by growth(day).
+func dailySeries(name string, n int, start int64, growth func(time.Weekday) int64) []database.IndexSizeSnapshot {
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC) // Monday
+	size := start
+	out := make([]database.IndexSizeSnapshot, 0, n)
+	for i := range n {
+		if i > 0 {
+			size += growth(day.Weekday())
+		}
+		out = append(out, database.IndexSizeSnapshot{IndexName: name, SizeBytes: size, CapturedAt: day})
+		day = day.AddDate(0, 0, 1)
+	}
+	return out
+}
+
+// --- tests ---
+
+func TestLinearSlope(t *testing.T) {
+	t.Helper()
+
+	points := snapshotsToPoints(dailySeries("a", 10, 1000, func(time.Weekday) int64 { return 100 }))
+	slope := linearSlope(points, func(p domain.StoragePoint) float64 { return float64(p.SizeBytes) })
+	if math.Abs(slope-100) > 1e-9 {
+		t.Errorf("expected slope 100, got %f", slope)
+	}
+
+	if got := linearSlope(points[:1], func(p domain.StoragePoint) float64 { return float64(p.SizeBytes) }); got != 0 {
+		t.Errorf("expected 0 slope for single point, got %f", got)
+	}
+}
+
+func TestProjectGrowth_Seasonal(t *testing.T) {
+	t.Helper()
+
+	// Weekdays grow by 100, weekends by 0.
+	weekdayOnly := func(d time.Weekday) int64 {
+		if d == time.Saturday || d == time.Sunday {
+			return 0
+		}
+		return 100
+	}
+	points := snapshotsToPoints(dailySeries("a", 28, 0, weekdayOnly))
+
+	cycle := projectGrowth(points, domain.ForecastMethodSeasonal)
+	if len(cycle) != daysPerWeek {
+		t.Fatalf("expected %d-day cycle, got %d", daysPerWeek, len(cycle))
+	}
+	if cycle[time.Saturday] != 0 || cycle[time.Sunday] != 0 {
+		t.Errorf("expected zero weekend growth, got sat=%f sun=%f", cycle[time.Saturday], cycle[time.Sunday])
+	}
+	if cycle[time.Wednesday] != 100 {
+		t.Errorf("expected weekday growth 100, got %f", cycle[time.Wednesday])
+	}
+}
+
+func TestDaysUntil(t *testing.T) {
+	t.Helper()
+
+	from := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC) // Sunday
+
+	tests := []struct {
+		name      string
+		remaining float64
+		cycle     []float64
+		wantDays  float64
+		wantOK    bool
+	}{
+		{name: "linear", remaining: 1000, cycle: []float64{100}, wantDays: 10, wantOK: true},
+		{name: "already over threshold", remaining: -5, cycle: []float64{100}, wantDays: 0, wantOK: true},
+		{name: "shrinking", remaining: 1000, cycle: []float64{-10}, wantOK: false},
+		// Mon..Fri +100, weekend 0: 500 bytes are consumed by Friday (day 5).
+		{name: "seasonal", remaining: 500, cycle: []float64{0, 100, 100, 100, 100, 100, 0}, wantDays: 5, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, ok := daysUntil(tt.remaining, tt.cycle, from)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if ok && math.Abs(days-tt.wantDays) > 1e-9 {
+				t.Errorf("expected %f days, got %f", tt.wantDays, days)
+			}
+		})
+	}
+}
+
+func TestForecast_Linear(t *testing.T) {
+	t.Helper()
+
+	constant := func(time.Weekday) int64 { return 1000 }
+	store := &mockSnapshotStore{
+		totals: dailySeries("", 10, 10000, constant),
+		indexes: append(
+			dailySeries("fast_classified_content", 10, 5000, constant),
+			dailySeries("slow_classified_content", 10, 5000, func(time.Weekday) int64 { return 10 })...,
+		),
+	}
+	es := &mockStorageES{disk: elasticsearch.DiskUsage{TotalBytes: 100000, UsedBytes: 50000}}
+	svc := NewStorageService(es, store, 80, &noopLogger{})
+
+	forecast, err := svc.Forecast(context.Background(), &domain.StorageForecastRequest{HorizonDays: 5, TopIndexes: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if forecast.Method != domain.ForecastMethodLinear {
+		t.Errorf("expected linear method, got %s", forecast.Method)
+	}
+	if forecast.ThresholdBytes != 80000 {
+		t.Errorf("expected threshold 80000, got %d", forecast.ThresholdBytes)
+	}
+	// (80000 - 50000) / 1000 per day = 30 days
+	if forecast.DaysUntilThreshold == nil || math.Abs(*forecast.DaysUntilThreshold-30) > 1e-9 {
+		t.Errorf("expected 30 days until threshold, got %v", forecast.DaysUntilThreshold)
+	}
+	if len(forecast.Projection) != 5 {
+		t.Errorf("expected 5 projection points, got %d", len(forecast.Projection))
+	}
+	if len(forecast.TopGrowers) != 1 || forecast.TopGrowers[0].IndexName != "fast_classified_content" {
+		t.Errorf("expected fast_classified_content as top grower, got %+v", forecast.TopGrowers)
+	}
+}
+
+func TestForecast_SeasonalFallsBackWithShortHistory(t *testing.T) {
+	t.Helper()
+
+	store := &mockSnapshotStore{totals: dailySeries("", 5, 0, func(time.Weekday) int64 { return 1 })}
+	es := &mockStorageES{disk: elasticsearch.DiskUsage{TotalBytes: 100, UsedBytes: 10}}
+	svc := NewStorageService(es, store, 85, &noopLogger{})
+
+	forecast, err := svc.Forecast(context.Background(), &domain.StorageForecastRequest{
+		Method: domain.ForecastMethodSeasonal,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if forecast.Method != domain.ForecastMethodLinear {
+		t.Errorf("expected fallback to linear, got %s", forecast.Method)
+	}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// maxURLMatches bounds the documents returned for one URL.
+const maxURLMatches = 100
+
+// ErrInvalidLookupURL is returned when the looked-up URL is not an absolute http(s) URL.
+var ErrInvalidLookupURL = errors.New("url must be an absolute http(s) URL")
+
+// urlTrackingParams are query parameters dropped when normalizing a looked-up URL.
+var urlTrackingParams = map[string]struct{}{
+	"utm_source": {}, "utm_medium": {}, "utm_campaign": {}, "utm_term": {}, "utm_content": {},
+	"fbclid": {}, "gclid": {}, "msclkid": {},
+}
+
+// URLLookupESClient defines the Elasticsearch operations needed by URLLookupService.
+// The concrete *elasticsearch.Client satisfies this interface.
+type URLLookupESClient interface {
+	SearchAllContent(ctx context.Context, query map[string]any) (*esapi.Response, error)
+}
+
+// URLLookupService finds every raw and classified document stored for a URL.
+type URLLookupService struct {
+	esClient URLLookupESClient
+	logger   infralogger.Logger
+}
+
+// NewURLLookupService creates a new cross-index URL lookup service.
+func NewURLLookupService(esClient URLLookupESClient, logger infralogger.Logger) *URLLookupService {
+	return &URLLookupService{esClient: esClient, logger: logger}
+}
+
+// FindByURL returns the documents in all raw and classified indexes whose url
+// or canonical_url is the given URL. URLs are compared normalized: scheme,
+// "www.", a trailing slash, the fragment, and tracking parameters are ignored.
+func (s *URLLookupService) FindByURL(ctx context.Context, rawURL string) (*domain.URLLookupResult, error) {
+	normalized, variants, err := urlVariants(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.esClient.SearchAllContent(ctx, buildURLLookupQuery(variants))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents by url: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	var esResp urlLookupResponse
+	if decodeErr := json.NewDecoder(res.Body).Decode(&esResp); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode url lookup response: %w", decodeErr)
+	}
+
+	result := &domain.URLLookupResult{
+		URL:           rawURL,
+		NormalizedURL: normalized,
+		Matches:       make([]*domain.URLMatch, 0, len(esResp.Hits.Hits)),
+		Truncated:     esResp.Hits.Total.Value > int64(len(esResp.Hits.Hits)),
+	}
+	for i := range esResp.Hits.Hits {
+		result.Matches = append(result.Matches, esResp.Hits.Hits[i].toMatch())
+	}
+	result.Count = len(result.Matches)
+
+	return result, nil
+}
+
+// normalizeLookupURL lowercases the scheme and host, forces https, drops "www.",
+// the default port, the fragment, tracking parameters and a trailing slash,
+// and sorts the query.
+func normalizeLookupURL(rawURL string) (*url.URL, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return nil, ErrInvalidLookupURL
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return nil, ErrInvalidLookupURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := parsed.Query()
+	for param := range query {
+		if _, tracking := urlTrackingParams[strings.ToLower(param)]; tracking {
+			query.Del(param)
+		}
+	}
+
+	return &url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     strings.TrimRight(parsed.Path, "/"),
+		RawQuery: query.Encode(),
+	}, nil
+}
+
+// urlVariants returns the normalized URL and the spellings it may be stored
+// under: http or https, with or without "www.", with or without a trailing slash.
+func urlVariants(rawURL string) (normalized string, variants []string, err error) {
+	base, err := normalizeLookupURL(rawURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	seen := map[string]struct{}{strings.TrimSpace(rawURL): {}}
+	for _, scheme := range []string{"https", "http"} {
+		for _, host := range []string{base.Host, "www." + base.Host} {
+			for _, path := range []string{base.Path, base.Path + "/"} {
+				variant := url.URL{Scheme: scheme, Host: host, Path: path, RawQuery: base.RawQuery}
+				seen[variant.String()] = struct{}{}
+			}
+		}
+	}
+
+	variants = make([]string, 0, len(seen))
+	for v := range seen {
+		variants = append(variants, v)
+	}
+	sort.Strings(variants)
+
+	return base.String(), variants, nil
+}
+
+func buildURLLookupQuery(variants []string) map[string]any {
+	return map[string]any{
+		"size":             maxURLMatches,
+		"track_total_hits": true,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{"terms": map[string]any{"canonical_url": variants}},
+					map[string]any{"terms": map[string]any{"url": variants}},
+				},
+				"minimum_should_match": 1,
+			},
+		},
+		"sort": []any{
+			map[string]any{"crawled_at": map[string]any{"order": "desc", "unmapped_type": "date"}},
+		},
+		"_source": []string{
+			"url", "canonical_url", "title", "source_name", "classification_status",
+			"content_type", "quality_score", "topics", "crawled_at", "published_date",
+		},
+	}
+}
+
+type urlLookupResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []urlLookupHit `json:"hits"`
+	} `json:"hits"`
+}
+
+type urlLookupHit struct {
+	Index  string `json:"_index"`
+	ID     string `json:"_id"`
+	Source struct {
+		URL                  string     `json:"url"`
+		CanonicalURL         string     `json:"canonical_url"`
+		Title                string     `json:"title"`
+		SourceName           string     `json:"source_name"`
+		ClassificationStatus string     `json:"classification_status"`
+		ContentType          string     `json:"content_type"`
+		QualityScore         *float64   `json:"quality_score"`
+		Topics               []string   `json:"topics"`
+		CrawledAt            *time.Time `json:"crawled_at"`
+		PublishedDate        *time.Time `json:"published_date"`
+	} `json:"_source"`
+}
+
+func (h *urlLookupHit) toMatch() *domain.URLMatch {
+	match := &domain.URLMatch{
+		IndexName:            h.Index,
+		IndexType:            contentIndexType(h.Index),
+		DocumentID:           h.ID,
+		URL:                  h.Source.URL,
+		CanonicalURL:         h.Source.CanonicalURL,
+		Title:                h.Source.Title,
+		SourceName:           h.Source.SourceName,
+		ClassificationStatus: h.Source.ClassificationStatus,
+		ContentType:          h.Source.ContentType,
+		Topics:               h.Source.Topics,
+		CrawledAt:            h.Source.CrawledAt,
+		PublishedDate:        h.Source.PublishedDate,
+	}
+	if h.Source.QualityScore != nil {
+		score := int(*h.Source.QualityScore)
+		match.QualityScore = &score
+	}
+	return match
+}
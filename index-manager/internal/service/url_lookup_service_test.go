@@ -0,0 +1,105 @@
+//nolint:testpackage // Testing unexported methods requires same package access
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jonesrussell/north-cloud/index-manager/internal/domain"
+)
+
+type mockURLLookupESClient struct {
+	searchResp *esapi.Response
+	query      map[string]any
+}
+
+func (m *mockURLLookupESClient) SearchAllContent(_ context.Context, query map[string]any) (*esapi.Response, error) {
+	m.query = query
+	return m.searchResp, nil
+}
+
+const urlLookupBody = `{
+	"hits": {
+		"total": {"value": 3},
+		"hits": [
+			{"_index": "example_com_classified_content", "_id": "c1", "_source": {
+				"url": "https://www.example.com/news/story?utm_source=x", "canonical_url": "https://example.com/news/story",
+				"title": "Story", "source_name": "example_com", "content_type": "article", "quality_score": 72,
+				"topics": ["crime"], "crawled_at": "2026-03-01T10:00:00Z"}},
+			{"_index": "example_com_raw_content", "_id": "c1", "_source": {
+				"url": "https://www.example.com/news/story?utm_source=x", "canonical_url": "https://example.com/news/story",
+				"title": "Story", "source_name": "example_com", "classification_status": "classified"}}
+		]
+	}
+}`
+
+func TestURLLookupService_FindByURL(t *testing.T) {
+	t.Parallel()
+
+	client := &mockURLLookupESClient{searchResp: esapiResponse(t, http.StatusOK, urlLookupBody)}
+	svc := NewURLLookupService(client, &noopLogger{})
+
+	result, err := svc.FindByURL(context.Background(), "http://WWW.Example.com/news/story/#comments")
+	if err != nil {
+		t.Fatalf("FindByURL() error = %v", err)
+	}
+
+	if result.NormalizedURL != "https://example.com/news/story" {
+		t.Errorf("NormalizedURL = %q", result.NormalizedURL)
+	}
+	if result.Count != 2 || !result.Truncated {
+		t.Fatalf("Count = %d, Truncated = %v, want 2 and true", result.Count, result.Truncated)
+	}
+
+	classified, raw := result.Matches[0], result.Matches[1]
+	if classified.IndexType != domain.IndexTypeClassifiedContent || raw.IndexType != domain.IndexTypeRawContent {
+		t.Errorf("IndexTypes = %q, %q", classified.IndexType, raw.IndexType)
+	}
+	if classified.QualityScore == nil || *classified.QualityScore != 72 {
+		t.Errorf("QualityScore = %v, want 72", classified.QualityScore)
+	}
+	if raw.QualityScore != nil || raw.ClassificationStatus != "classified" {
+		t.Errorf("raw match = %+v", raw)
+	}
+
+	should := client.query["query"].(map[string]any)["bool"].(map[string]any)["should"].([]any)
+	variants := should[0].(map[string]any)["terms"].(map[string]any)["canonical_url"].([]string)
+	for _, want := range []string{"https://example.com/news/story", "http://www.example.com/news/story/"} {
+		if !slices.Contains(variants, want) {
+			t.Errorf("variants %v missing %q", variants, want)
+		}
+	}
+}
+
+func TestNormalizeLookupURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://example.com/a", "https://example.com/a"},
+		{"HTTP://www.Example.COM:80/a/?b=2&a=1&utm_medium=rss#top", "https://example.com/a?a=1&b=2"},
+		{"https://example.com:8443/", "https://example.com:8443"},
+	}
+	for _, tt := range tests {
+		got, err := normalizeLookupURL(tt.in)
+		if err != nil {
+			t.Errorf("normalizeLookupURL(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("normalizeLookupURL(%q) = %q, want %q", tt.in, got.String(), tt.want)
+		}
+	}
+
+	for _, bad := range []string{"example.com/a", "ftp://example.com/a", "/news/story"} {
+		if _, err := normalizeLookupURL(bad); !errors.Is(err, ErrInvalidLookupURL) {
+			t.Errorf("normalizeLookupURL(%q) error = %v, want ErrInvalidLookupURL", bad, err)
+		}
+	}
+}
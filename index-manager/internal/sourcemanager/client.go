@@ -0,0 +1,74 @@
+// Package sourcemanager provides a minimal read-only client for the
+// source-manager API, used to reconcile Elasticsearch indexes against
+// registered sources.
+package sourcemanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+)
+
+// pageSize matches source-manager's maximum list limit.
+const pageSize = 500
+
+// Source is the subset of a source-manager source needed by index-manager.
+type Source struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+}
+
+type listSourcesResponse struct {
+	Sources []Source `json:"sources"`
+	Total   int      `json:"total"`
+}
+
+// Client is an HTTP client for the source-manager API.
+type Client struct {
+	baseURL string
+	service *infrahttp.ServiceClient
+}
+
+// NewClient creates a source-manager client for the given base URL
+// (e.g. "http://source-manager:8050"). Requests carry a read-only service
+// token signed with jwtSecret.
+func NewClient(baseURL, jwtSecret string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:   timeout,
+			JWTSecret: jwtSecret,
+			Subject:   "index-manager",
+		}),
+	}
+}
+
+// ListSources returns every registered source, paging through the list API.
+func (c *Client) ListSources(ctx context.Context) ([]Source, error) {
+	var all []Source
+	for offset := 0; ; offset += pageSize {
+		page, total, err := c.listPage(ctx, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+func (c *Client) listPage(ctx context.Context, offset int) (sources []Source, total int, err error) {
+	endpoint := fmt.Sprintf("%s/api/v1/sources?limit=%d&offset=%d", c.baseURL, pageSize, offset)
+	var decoded listSourcesResponse
+	if err = c.service.Do(ctx, http.MethodGet, endpoint, nil, &decoded); err != nil {
+		return nil, 0, fmt.Errorf("list sources: %w", err)
+	}
+	return decoded.Sources, decoded.Total, nil
+}
@@ -0,0 +1,61 @@
+package sourcemanager_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/index-manager/internal/sourcemanager"
+)
+
+func TestClient_ListSourcesPagesWithServiceToken(t *testing.T) {
+	t.Parallel()
+
+	const total = 501
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Errorf("expected a service token, got %q", r.Header.Get("Authorization"))
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var page []sourcemanager.Source
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, sourcemanager.Source{ID: strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"sources": page, "total": total})
+	}))
+	t.Cleanup(srv.Close)
+
+	client := sourcemanager.NewClient(srv.URL, "test-secret", time.Second)
+	sources, err := client.ListSources(context.Background())
+	if err != nil {
+		t.Fatalf("ListSources: %v", err)
+	}
+	if len(sources) != total {
+		t.Errorf("got %d sources, want %d", len(sources), total)
+	}
+}
+
+func TestClient_ListSourcesBoundsErrorBody(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(strings.Repeat("x", 4096)))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := sourcemanager.NewClient(srv.URL, "test-secret", time.Second)
+	_, err := client.ListSources(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a 401")
+	}
+	if len(err.Error()) > 1024 {
+		t.Errorf("error holds %d bytes of body, want it bounded", len(err.Error()))
+	}
+}
@@ -0,0 +1,7 @@
+-- Rollback: Drop index_size_snapshots table
+-- WARNING: This will permanently delete all storage history
+
+DROP INDEX IF EXISTS idx_index_size_snapshots_captured_at;
+DROP INDEX IF EXISTS idx_index_size_snapshots_index_captured;
+
+DROP TABLE IF EXISTS index_size_snapshots;
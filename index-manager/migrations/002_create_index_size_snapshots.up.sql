@@ -0,0 +1,16 @@
+-- Migration: Create index_size_snapshots table
+-- Description: Periodic per-index size and doc-count samples for storage growth forecasting
+-- Version: 002
+
+CREATE TABLE IF NOT EXISTS index_size_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    index_name VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    doc_count BIGINT NOT NULL,
+    captured_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_index_size_snapshots_index_captured
+    ON index_size_snapshots(index_name, captured_at DESC);
+CREATE INDEX IF NOT EXISTS idx_index_size_snapshots_captured_at
+    ON index_size_snapshots(captured_at DESC);
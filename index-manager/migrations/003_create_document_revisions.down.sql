@@ -0,0 +1,6 @@
+-- Rollback: Drop document_revisions table
+-- WARNING: This will permanently delete all document history
+
+DROP INDEX IF EXISTS idx_document_revisions_document;
+
+DROP TABLE IF EXISTS document_revisions;
@@ -0,0 +1,16 @@
+-- Migration: Create document_revisions table
+-- Description: Previous versions of documents overwritten via the API, for history and restore
+-- Version: 003
+
+CREATE TABLE IF NOT EXISTS document_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    index_name VARCHAR(255) NOT NULL,
+    document_id VARCHAR(512) NOT NULL,
+    source JSONB NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    changed_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_revisions_document
+    ON document_revisions(index_name, document_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- Rollback: Drop index_cutovers table
+-- WARNING: This will permanently delete the cutover history; retained indexes are left in place
+
+DROP INDEX IF EXISTS idx_index_cutovers_active_alias;
+DROP INDEX IF EXISTS idx_index_cutovers_alias;
+
+DROP TABLE IF EXISTS index_cutovers;
@@ -0,0 +1,26 @@
+-- Migration: Create index_cutovers table
+-- Description: Alias blue/green cutovers, with the previous index retained for rollback
+-- Version: 004
+
+CREATE TABLE IF NOT EXISTS index_cutovers (
+    id BIGSERIAL PRIMARY KEY,
+    alias VARCHAR(255) NOT NULL,
+    previous_index VARCHAR(255) NOT NULL,
+    new_index VARCHAR(255) NOT NULL,
+    retired_index VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    forced BOOLEAN NOT NULL DEFAULT FALSE,
+    verification JSONB,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    retain_until TIMESTAMP NOT NULL,
+    rolled_back_at TIMESTAMP,
+    retired_deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_index_cutovers_alias
+    ON index_cutovers(alias, created_at DESC);
+
+-- Only one cutover may serve an alias at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_index_cutovers_active_alias
+    ON index_cutovers(alias) WHERE status = 'active';
@@ -0,0 +1,78 @@
+package clickurl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoKeys is returned when a KeyRing is created without keys.
+var ErrNoKeys = errors.New("at least one key is required")
+
+// KeyRing verifies signatures made with any of several keys, so the signing
+// key can be rotated while links signed with the previous one are still in
+// flight. A key-ID-prefixed signature is checked against that key only;
+// a signature without a key ID predates key IDs and is checked against
+// every key, as the secret that made it may since have been given an ID.
+type KeyRing struct {
+	signers map[string]*Signer
+	ids     []string
+}
+
+// NewKeyRing creates a KeyRing from keys, in order of preference. Key IDs
+// must be unique; at most one key may have an empty ID.
+func NewKeyRing(keys ...Key) (*KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	ring := &KeyRing{signers: make(map[string]*Signer, len(keys))}
+	for _, key := range keys {
+		if key.Secret == "" {
+			return nil, fmt.Errorf("key %q: secret is required", key.ID)
+		}
+		if _, exists := ring.signers[key.ID]; exists {
+			return nil, fmt.Errorf("key %q: duplicate key ID", key.ID)
+		}
+		signer, err := NewKeyedSigner(key)
+		if err != nil {
+			return nil, err
+		}
+		ring.signers[key.ID] = signer
+		ring.ids = append(ring.ids, key.ID)
+	}
+	return ring, nil
+}
+
+// Verify reports whether signature is a valid signature of message by one
+// of the keys.
+func (r *KeyRing) Verify(message, signature string) bool {
+	_, ok := r.VerifyKey(message, signature)
+	return ok
+}
+
+// VerifyKey verifies signature and returns the ID of the key that made it.
+func (r *KeyRing) VerifyKey(message, signature string) (string, bool) {
+	keyID, _ := SplitSignature(signature)
+	if keyID != "" {
+		signer, ok := r.signers[keyID]
+		if !ok {
+			return "", false
+		}
+		return keyID, signer.Verify(message, signature)
+	}
+
+	// Check every key rather than stopping at the first match, so the time
+	// taken does not reveal which key matched
+	matched, found := "", false
+	for _, id := range r.ids {
+		if r.signers[id].Verify(message, signature) && !found {
+			matched, found = id, true
+		}
+	}
+	return matched, found
+}
+
+// KeyIDs returns the key IDs in order of preference.
+func (r *KeyRing) KeyIDs() []string {
+	return append([]string(nil), r.ids...)
+}
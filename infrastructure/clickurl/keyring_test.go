@@ -0,0 +1,75 @@
+package clickurl_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+)
+
+const testMessage = "query1|result1|0|1|1700000000|https://example.com"
+
+func newTestRing(t *testing.T) *clickurl.KeyRing {
+	t.Helper()
+
+	ring, err := clickurl.NewKeyRing(
+		clickurl.Key{ID: "new", Secret: "secret-new"},
+		clickurl.Key{ID: "old", Secret: "secret-old"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return ring
+}
+
+func TestKeyRing_VerifyKey(t *testing.T) {
+	ring := newTestRing(t)
+
+	for _, key := range []clickurl.Key{{ID: "new", Secret: "secret-new"}, {ID: "old", Secret: "secret-old"}} {
+		signer, _ := clickurl.NewKeyedSigner(key)
+		keyID, ok := ring.VerifyKey(testMessage, signer.Sign(testMessage))
+		if !ok || keyID != key.ID {
+			t.Errorf("key %q: got (%q, %v), want (%q, true)", key.ID, keyID, ok, key.ID)
+		}
+	}
+}
+
+func TestKeyRing_VerifyKey_Unprefixed(t *testing.T) {
+	ring := newTestRing(t)
+
+	// Links signed before key IDs carry no prefix
+	keyID, ok := ring.VerifyKey(testMessage, clickurl.NewSigner("secret-old").Sign(testMessage))
+	if !ok || keyID != "old" {
+		t.Fatalf("got (%q, %v), want (\"old\", true)", keyID, ok)
+	}
+}
+
+func TestKeyRing_VerifyKey_Rejects(t *testing.T) {
+	ring := newTestRing(t)
+	retired, _ := clickurl.NewKeyedSigner(clickurl.Key{ID: "retired", Secret: "secret-retired"})
+	mislabelled, _ := clickurl.NewKeyedSigner(clickurl.Key{ID: "new", Secret: "secret-old"})
+
+	for name, sig := range map[string]string{
+		"unknown key":        retired.Sign(testMessage),
+		"wrong key":          mislabelled.Sign(testMessage),
+		"unprefixed unknown": clickurl.NewSigner("secret-retired").Sign(testMessage),
+	} {
+		if ring.Verify(testMessage, sig) {
+			t.Errorf("%s: expected verification to fail", name)
+		}
+	}
+}
+
+func TestNewKeyRing_Invalid(t *testing.T) {
+	tests := map[string][]clickurl.Key{
+		"no keys":      nil,
+		"empty secret": {{ID: "a"}},
+		"duplicate id": {{ID: "a", Secret: "x"}, {ID: "a", Secret: "y"}},
+		"invalid id":   {{ID: "a.b", Secret: "x"}},
+		"two unnamed":  {{Secret: "x"}, {Secret: "y"}},
+	}
+	for name, keys := range tests {
+		if _, err := clickurl.NewKeyRing(keys...); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -7,13 +7,27 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
 // SignatureLength is the number of hex characters used for the truncated HMAC signature.
 const SignatureLength = 12
 
+// KeyIDSeparator separates the key ID from the HMAC in a key-ID-prefixed
+// signature, e.g. "2026a.0123456789ab".
+const KeyIDSeparator = "."
+
+// keyIDPattern keeps key IDs short and safe in a query string unescaped.
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,16}$`)
+
+// ErrInvalidKeyID is returned for a key ID that does not match keyIDPattern.
+var ErrInvalidKeyID = errors.New("key ID must be 1-16 of [A-Za-z0-9_-]")
+
 // ClickParams holds the parameters that identify a specific click event.
 // These fields are combined into a pipe-delimited message for HMAC signing.
 type ClickParams struct {
@@ -23,12 +37,20 @@ type ClickParams struct {
 	Page           int
 	Timestamp      int64
 	DestinationURL string
+	// SessionID attributes the click to an anonymous search session or a
+	// widget API key so search can personalize ranking. Optional.
+	SessionID string
+	// Channel attributes the click to the publisher channel (or other
+	// distribution channel) the link was sent through. Optional.
+	Channel string
 }
 
 // Message returns a pipe-delimited string representation of the click parameters
-// suitable for HMAC signing. Format: "queryid|resultid|pos|page|timestamp|url".
+// suitable for HMAC signing. Format: "queryid|resultid|pos|page|timestamp|url",
+// followed by "|session" when SessionID is set, or "|session|channel" when
+// Channel is set, so older URLs still verify.
 func (p ClickParams) Message() string {
-	return fmt.Sprintf(
+	message := fmt.Sprintf(
 		"%s|%s|%s|%s|%s|%s",
 		p.QueryID,
 		p.ResultID,
@@ -37,23 +59,63 @@ func (p ClickParams) Message() string {
 		strconv.FormatInt(p.Timestamp, 10),
 		p.DestinationURL,
 	)
+	switch {
+	case p.Channel != "":
+		message += "|" + p.SessionID + "|" + p.Channel
+	case p.SessionID != "":
+		message += "|" + p.SessionID
+	}
+
+	return message
+}
+
+// Key is an HMAC secret and the ID that names it in signatures. An empty ID
+// signs without a prefix, as signers did before key IDs existed.
+type Key struct {
+	ID     string
+	Secret string
 }
 
 // Signer provides HMAC-SHA256 signing and verification using a shared secret.
 type Signer struct {
+	keyID  string
 	secret []byte
 }
 
-// NewSigner creates a new Signer with the given secret string.
+// NewSigner creates a new Signer with the given secret string. Its
+// signatures carry no key ID.
 func NewSigner(secret string) *Signer {
 	return &Signer{
 		secret: []byte(secret),
 	}
 }
 
+// NewKeyedSigner creates a Signer whose signatures are prefixed with key.ID,
+// so verifiers holding several keys know which one to check. An empty ID
+// behaves like NewSigner.
+func NewKeyedSigner(key Key) (*Signer, error) {
+	if key.ID != "" && !keyIDPattern.MatchString(key.ID) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKeyID, key.ID)
+	}
+	return &Signer{keyID: key.ID, secret: []byte(key.Secret)}, nil
+}
+
+// KeyID returns the ID prefixed to this signer's signatures, or "".
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
 // Sign computes an HMAC-SHA256 of the message and returns the first SignatureLength
-// hex characters as the signature.
+// hex characters as the signature, prefixed with the key ID when there is one.
 func (s *Signer) Sign(message string) string {
+	if s.keyID == "" {
+		return s.mac(message)
+	}
+	return s.keyID + KeyIDSeparator + s.mac(message)
+}
+
+// mac returns the truncated hex HMAC of message.
+func (s *Signer) mac(message string) string {
 	mac := hmac.New(sha256.New, s.secret)
 	mac.Write([]byte(message))
 	fullHex := hex.EncodeToString(mac.Sum(nil))
@@ -63,8 +125,72 @@ func (s *Signer) Sign(message string) string {
 
 // Verify checks whether the given signature matches the HMAC-SHA256 of the message.
 // Uses hmac.Equal for constant-time comparison to prevent timing attacks.
+// A key-ID-prefixed signature only verifies against a signer with that ID.
 func (s *Signer) Verify(message, signature string) bool {
-	expected := s.Sign(message)
+	keyID, mac := SplitSignature(signature)
+	if keyID != "" && keyID != s.keyID {
+		return false
+	}
 
-	return hmac.Equal([]byte(expected), []byte(signature))
+	return hmac.Equal([]byte(s.mac(message)), []byte(mac))
+}
+
+// VerifyKey is Verify, also returning the signer's key ID, so a Signer can
+// stand in for a KeyRing holding one key.
+func (s *Signer) VerifyKey(message, signature string) (string, bool) {
+	return s.keyID, s.Verify(message, signature)
+}
+
+// KeyIDs returns the signer's key ID.
+func (s *Signer) KeyIDs() []string {
+	return []string{s.keyID}
+}
+
+// SplitSignature splits a signature into its key ID, empty if it has none,
+// and its HMAC.
+func SplitSignature(signature string) (keyID, mac string) {
+	if id, rest, found := strings.Cut(signature, KeyIDSeparator); found {
+		return id, rest
+	}
+	return "", signature
+}
+
+// URL returns the signed click-tracker redirect URL for p under baseURL
+// (e.g. "https://click.example.com"). The click-tracker parses the same
+// q, r, p, pg, t, u, s (session), ch (channel), and sig query parameters;
+// s and ch are only present when set.
+func (s *Signer) URL(baseURL string, p ClickParams) string {
+	return s.signedURL(baseURL, "/click", p)
+}
+
+// ImpressionURL returns the signed click-tracker impression pixel URL for p,
+// to be loaded where the link to p is shown. It carries the same parameters
+// and signature as URL, so the impression and the click of a link match.
+func (s *Signer) ImpressionURL(baseURL string, p ClickParams) string {
+	return s.signedURL(baseURL, "/impression", p)
+}
+
+// signedURL returns the signed URL of p on the click-tracker path.
+func (s *Signer) signedURL(baseURL, path string, p ClickParams) string {
+	optional := ""
+	if p.SessionID != "" {
+		optional = "&s=" + url.QueryEscape(p.SessionID)
+	}
+	if p.Channel != "" {
+		optional += "&ch=" + url.QueryEscape(p.Channel)
+	}
+
+	return fmt.Sprintf(
+		"%s%s?q=%s&r=%s&p=%d&pg=%d&t=%d&u=%s%s&sig=%s",
+		strings.TrimRight(baseURL, "/"),
+		path,
+		url.QueryEscape(p.QueryID),
+		url.QueryEscape(p.ResultID),
+		p.Position,
+		p.Page,
+		p.Timestamp,
+		url.QueryEscape(p.DestinationURL),
+		optional,
+		s.Sign(p.Message()),
+	)
 }
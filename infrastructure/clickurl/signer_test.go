@@ -73,6 +73,47 @@ func TestSign_Deterministic(t *testing.T) {
 	}
 }
 
+func TestURL(t *testing.T) {
+	signer := newTestSigner(t)
+	params := clickurl.ClickParams{
+		QueryID:        "q-123",
+		ResultID:       "r-456",
+		Position:       2,
+		Page:           1,
+		Timestamp:      1700000000,
+		DestinationURL: "https://example.com/a?b=c",
+	}
+
+	got := signer.URL("https://click.example.com/", params)
+	expected := "https://click.example.com/click?q=q-123&r=r-456&p=2&pg=1&t=1700000000" +
+		"&u=https%3A%2F%2Fexample.com%2Fa%3Fb%3Dc&sig=" + signer.Sign(params.Message())
+
+	if got != expected {
+		t.Fatalf("expected URL %q, got %q", expected, got)
+	}
+}
+
+func TestImpressionURL(t *testing.T) {
+	signer := newTestSigner(t)
+	params := clickurl.ClickParams{
+		QueryID:        "q-123",
+		ResultID:       "r-456",
+		Position:       2,
+		Page:           1,
+		Timestamp:      1700000000,
+		DestinationURL: "https://example.com",
+		Channel:        "northern-news",
+	}
+
+	got := signer.ImpressionURL("https://click.example.com/", params)
+	expected := "https://click.example.com/impression?q=q-123&r=r-456&p=2&pg=1&t=1700000000" +
+		"&u=https%3A%2F%2Fexample.com&ch=northern-news&sig=" + signer.Sign(params.Message())
+
+	if got != expected {
+		t.Fatalf("expected URL %q, got %q", expected, got)
+	}
+}
+
 func TestBuildMessage(t *testing.T) {
 	params := clickurl.ClickParams{
 		QueryID:        "q-123",
@@ -90,3 +131,118 @@ func TestBuildMessage(t *testing.T) {
 		t.Fatalf("expected message %q, got %q", expected, got)
 	}
 }
+
+func TestBuildMessage_WithSession(t *testing.T) {
+	params := clickurl.ClickParams{
+		QueryID:        "q-123",
+		ResultID:       "r-456",
+		Position:       2,
+		Page:           1,
+		Timestamp:      1700000000,
+		DestinationURL: "https://example.com/article",
+		SessionID:      "sess_abc",
+	}
+
+	expected := "q-123|r-456|2|1|1700000000|https://example.com/article|sess_abc"
+	got := params.Message()
+
+	if got != expected {
+		t.Fatalf("expected message %q, got %q", expected, got)
+	}
+}
+
+func TestURL_WithSession(t *testing.T) {
+	signer := newTestSigner(t)
+	params := clickurl.ClickParams{
+		QueryID:        "q-123",
+		ResultID:       "r-456",
+		Position:       2,
+		Page:           1,
+		Timestamp:      1700000000,
+		DestinationURL: "https://example.com",
+		SessionID:      "sess_abc",
+	}
+
+	got := signer.URL("https://click.example.com", params)
+	expected := "https://click.example.com/click?q=q-123&r=r-456&p=2&pg=1&t=1700000000" +
+		"&u=https%3A%2F%2Fexample.com&s=sess_abc&sig=" + signer.Sign(params.Message())
+
+	if got != expected {
+		t.Fatalf("expected URL %q, got %q", expected, got)
+	}
+}
+
+func TestBuildMessage_WithChannel(t *testing.T) {
+	params := clickurl.ClickParams{
+		QueryID:        "q-123",
+		ResultID:       "r-456",
+		Position:       2,
+		Page:           1,
+		Timestamp:      1700000000,
+		DestinationURL: "https://example.com/article",
+		Channel:        "northern-news",
+	}
+
+	// The empty session slot keeps a channel from being read as a session.
+	expected := "q-123|r-456|2|1|1700000000|https://example.com/article||northern-news"
+	if got := params.Message(); got != expected {
+		t.Fatalf("expected message %q, got %q", expected, got)
+	}
+
+	params.SessionID = "sess_abc"
+	expected = "q-123|r-456|2|1|1700000000|https://example.com/article|sess_abc|northern-news"
+	if got := params.Message(); got != expected {
+		t.Fatalf("expected message %q, got %q", expected, got)
+	}
+}
+
+func TestURL_WithChannel(t *testing.T) {
+	signer := newTestSigner(t)
+	params := clickurl.ClickParams{
+		QueryID:        "q-123",
+		ResultID:       "r-456",
+		Position:       1,
+		Page:           1,
+		Timestamp:      1700000000,
+		DestinationURL: "https://example.com",
+		SessionID:      "sess_abc",
+		Channel:        "northern-news",
+	}
+
+	got := signer.URL("https://click.example.com", params)
+	expected := "https://click.example.com/click?q=q-123&r=r-456&p=1&pg=1&t=1700000000" +
+		"&u=https%3A%2F%2Fexample.com&s=sess_abc&ch=northern-news&sig=" + signer.Sign(params.Message())
+
+	if got != expected {
+		t.Fatalf("expected URL %q, got %q", expected, got)
+	}
+}
+
+func TestKeyedSigner(t *testing.T) {
+	signer, err := clickurl.NewKeyedSigner(clickurl.Key{ID: "2026a", Secret: testSecret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	message := "query1|result1|0|1|1700000000|https://example.com"
+
+	sig := signer.Sign(message)
+	if want := "2026a." + newTestSigner(t).Sign(message); sig != want {
+		t.Fatalf("expected signature %q, got %q", want, sig)
+	}
+	if !signer.Verify(message, sig) {
+		t.Fatal("expected keyed signature to verify")
+	}
+
+	other, _ := clickurl.NewKeyedSigner(clickurl.Key{ID: "2026b", Secret: testSecret})
+	if other.Verify(message, sig) {
+		t.Fatal("expected signature naming another key to fail verification")
+	}
+}
+
+func TestNewKeyedSigner_InvalidKeyID(t *testing.T) {
+	for _, id := range []string{"has.dot", "has space", "waytoolongkeyid-2026"} {
+		if _, err := clickurl.NewKeyedSigner(clickurl.Key{ID: id, Secret: testSecret}); err == nil {
+			t.Errorf("expected key ID %q to be rejected", id)
+		}
+	}
+}
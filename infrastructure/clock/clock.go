@@ -0,0 +1,56 @@
+// Package clock abstracts the system clock so time-dependent code (schedulers,
+// TTLs, flush loops) can be driven deterministically in tests.
+//
+// Production code takes a Clock and defaults to Real(); tests pass a *Fake and
+// move time forward with Advance.
+package clock
+
+import "time"
+
+// Clock is the subset of the time package that services depend on.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// Until returns the duration until t.
+	Until(t time.Time) time.Duration
+	// NewTicker returns a ticker that fires every d. d must be positive.
+	NewTicker(d time.Duration) Ticker
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// Ticker is the clock-agnostic counterpart of *time.Ticker.
+type Ticker interface {
+	// C returns the channel on which ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker. No more ticks are sent after Stop returns.
+	Stop()
+	// Reset stops the ticker and resets its period to d.
+	Reset(d time.Duration)
+}
+
+// Real returns a Clock backed by the time package.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) Until(t time.Time) time.Duration        { return time.Until(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{ticker: time.NewTicker(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time   { return t.ticker.C }
+func (t realTicker) Stop()                 { t.ticker.Stop() }
+func (t realTicker) Reset(d time.Duration) { t.ticker.Reset(d) }
@@ -0,0 +1,204 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a manually driven Clock for tests. Time stands still until Advance
+// or Set is called; tickers and After channels fire as time passes their
+// deadlines, in deadline order.
+//
+// Like the time package, tick channels have a buffer of one and ticks are
+// dropped for slow receivers.
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending ticker or After channel.
+type fakeWaiter struct {
+	deadline time.Time
+	period   time.Duration // zero for one-shot After channels
+	ch       chan time.Time
+}
+
+// NewFake returns a fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+
+	return f
+}
+
+// Now returns the fake's current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Until returns the fake duration until t.
+func (f *Fake) Until(t time.Time) time.Duration {
+	return t.Sub(f.Now())
+}
+
+// NewTicker returns a ticker that fires each time the fake clock passes
+// another multiple of d.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	w := &fakeWaiter{period: d, ch: make(chan time.Time, 1)}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.deadline = f.now.Add(d)
+	f.addWaiterLocked(w)
+
+	return &fakeTicker{clock: f, waiter: w}
+}
+
+// After returns a channel that receives the fake time once d has elapsed.
+// A non-positive d fires immediately.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	w := &fakeWaiter{ch: make(chan time.Time, 1)}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d <= 0 {
+		w.ch <- f.now
+		return w.ch
+	}
+	w.deadline = f.now.Add(d)
+	f.addWaiterLocked(w)
+
+	return w.ch
+}
+
+// Advance moves the fake clock forward by d, firing every ticker and After
+// channel whose deadline falls within the interval.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.advanceToLocked(f.now.Add(d))
+}
+
+// Set moves the fake clock to t. Moving backwards fires nothing.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if t.Before(f.now) {
+		f.now = t
+		return
+	}
+	f.advanceToLocked(t)
+}
+
+// BlockUntil waits until at least n tickers or After channels are pending.
+// Tests call it before Advance so a goroutine under test has registered its
+// ticker by the time the clock moves.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// Waiters returns the number of pending tickers and After channels.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.waiters)
+}
+
+func (f *Fake) advanceToLocked(target time.Time) {
+	for {
+		next := f.nextDueLocked(target)
+		if next == nil {
+			break
+		}
+		f.now = next.deadline
+		select {
+		case next.ch <- f.now:
+		default:
+		}
+		if next.period > 0 {
+			next.deadline = next.deadline.Add(next.period)
+		} else {
+			f.removeWaiterLocked(next)
+		}
+	}
+	f.now = target
+}
+
+// nextDueLocked returns the waiter with the earliest deadline not after target.
+func (f *Fake) nextDueLocked(target time.Time) *fakeWaiter {
+	var next *fakeWaiter
+	for _, w := range f.waiters {
+		if w.deadline.After(target) {
+			continue
+		}
+		if next == nil || w.deadline.Before(next.deadline) {
+			next = w
+		}
+	}
+
+	return next
+}
+
+func (f *Fake) addWaiterLocked(w *fakeWaiter) {
+	f.waiters = append(f.waiters, w)
+	f.cond.Broadcast()
+}
+
+func (f *Fake) removeWaiterLocked(w *fakeWaiter) {
+	for i, existing := range f.waiters {
+		if existing == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+type fakeTicker struct {
+	clock  *Fake
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.clock.removeWaiterLocked(t.waiter)
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.clock.removeWaiterLocked(t.waiter)
+	t.waiter.period = d
+	t.waiter.deadline = t.clock.now.Add(d)
+	t.clock.addWaiterLocked(t.waiter)
+}
@@ -0,0 +1,100 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+)
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFake_NowMovesOnlyWhenAdvanced(t *testing.T) {
+	t.Parallel()
+
+	fc := clock.NewFake(epoch)
+	if !fc.Now().Equal(epoch) {
+		t.Fatalf("expected %v, got %v", epoch, fc.Now())
+	}
+
+	fc.Advance(90 * time.Second)
+
+	if got := fc.Since(epoch); got != 90*time.Second {
+		t.Errorf("expected 90s elapsed, got %v", got)
+	}
+	if got := fc.Until(epoch.Add(2 * time.Minute)); got != 30*time.Second {
+		t.Errorf("expected 30s remaining, got %v", got)
+	}
+}
+
+func TestFake_TickerFiresPerPeriod(t *testing.T) {
+	t.Parallel()
+
+	fc := clock.NewFake(epoch)
+	ticker := fc.NewTicker(time.Minute)
+
+	fc.Advance(59 * time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("ticker fired before its period elapsed")
+	default:
+	}
+
+	fc.Advance(time.Second)
+	tick := <-ticker.C()
+	if !tick.Equal(epoch.Add(time.Minute)) {
+		t.Errorf("expected tick at 1m, got %v", tick)
+	}
+
+	// Skipping several periods delivers one tick (buffer of one), like time.Ticker.
+	fc.Advance(5 * time.Minute)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Error("slow receiver should see dropped ticks")
+	default:
+	}
+
+	ticker.Stop()
+	fc.Advance(time.Hour)
+	select {
+	case <-ticker.C():
+		t.Error("stopped ticker fired")
+	default:
+	}
+}
+
+func TestFake_AfterFiresOnce(t *testing.T) {
+	t.Parallel()
+
+	fc := clock.NewFake(epoch)
+	ch := fc.After(10 * time.Second)
+
+	fc.Advance(10 * time.Second)
+	if got := <-ch; !got.Equal(epoch.Add(10 * time.Second)) {
+		t.Errorf("expected fire at 10s, got %v", got)
+	}
+	if fc.Waiters() != 0 {
+		t.Errorf("expected After to be removed once fired, %d pending", fc.Waiters())
+	}
+}
+
+func TestFake_BlockUntilWaitsForRegistration(t *testing.T) {
+	t.Parallel()
+
+	fc := clock.NewFake(epoch)
+	fired := make(chan time.Time)
+
+	go func() {
+		ticker := fc.NewTicker(time.Second)
+		defer ticker.Stop()
+		fired <- <-ticker.C()
+	}()
+
+	fc.BlockUntil(1)
+	fc.Advance(time.Second)
+
+	if got := <-fired; !got.Equal(epoch.Add(time.Second)) {
+		t.Errorf("expected tick at 1s, got %v", got)
+	}
+}
@@ -0,0 +1,169 @@
+// Package crash recovers panics, logs them with a structured stack trace, and
+// optionally ships crash reports to a Sentry-compatible endpoint.
+//
+// Usage:
+//
+//	func main() {
+//	    defer crash.Start("crawler")()
+//
+//	    crash.Go(log, "scheduler", scheduler.Run)
+//	}
+package crash
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reporting defaults.
+const (
+	// DefaultFlushTimeout bounds how long Flush waits for in-flight reports.
+	DefaultFlushTimeout = 5 * time.Second
+	// sendTimeout bounds a single report upload.
+	sendTimeout = 10 * time.Second
+)
+
+// Report describes one recovered panic.
+type Report struct {
+	// Service is the name passed to Init.
+	Service string
+	// Value is the recovered panic value formatted with %v.
+	Value string
+	// Type is the Go type of the recovered value (e.g. "runtime.Error").
+	Type string
+	// Stack is the goroutine stack captured at recovery (debug.Stack format).
+	Stack string
+	// Frames are the panicking goroutine's frames, outermost caller first.
+	Frames []Frame
+	// Fields holds the structured log fields supplied by the caller.
+	Fields map[string]any
+	// Time is when the panic was recovered.
+	Time time.Time
+}
+
+// Frame is a single stack frame.
+type Frame struct {
+	Function string
+	Module   string
+	File     string
+	Line     int
+}
+
+// Reporter ships crash reports to an external collector.
+type Reporter interface {
+	Report(ctx context.Context, report *Report) error
+}
+
+var (
+	mu          sync.RWMutex
+	reporter    Reporter
+	serviceName string
+	inflight    sync.WaitGroup
+)
+
+// Init configures crash reporting for the process from environment variables:
+//   - SENTRY_DSN: Sentry-compatible DSN; reporting is disabled when empty
+//   - SENTRY_ENVIRONMENT: environment tag (default: APP_ENV, then "development")
+//   - SENTRY_RELEASE: release tag (optional)
+//
+// Panics are always logged; Init only controls whether they are also shipped.
+func Init(service string) error {
+	mu.Lock()
+	serviceName = service
+	mu.Unlock()
+
+	dsn := os.Getenv("SENTRY_DSN")
+	if dsn == "" {
+		return nil
+	}
+
+	environment := os.Getenv("SENTRY_ENVIRONMENT")
+	if environment == "" {
+		environment = os.Getenv("APP_ENV")
+	}
+	if environment == "" {
+		environment = "development"
+	}
+	hostname, _ := os.Hostname()
+
+	sentry, err := NewSentryReporter(SentryConfig{
+		DSN:         dsn,
+		Environment: environment,
+		Release:     os.Getenv("SENTRY_RELEASE"),
+		ServerName:  hostname,
+	})
+	if err != nil {
+		return err
+	}
+
+	SetReporter(sentry)
+	return nil
+}
+
+// Start is how a service entrypoint sets up crash reporting: it calls Init,
+// warns on stderr (the logger is not set up yet) when reporting cannot be
+// enabled, and returns a function that flushes in-flight reports for up to
+// DefaultFlushTimeout, to be deferred:
+//
+//	defer crash.Start("auth")()
+func Start(service string) (flush func()) {
+	if err := Init(service); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: crash reporting disabled: %v\n", err)
+	}
+	return func() { Flush(DefaultFlushTimeout) }
+}
+
+// SetReporter replaces the process-wide reporter. A nil reporter disables shipping.
+func SetReporter(r Reporter) {
+	mu.Lock()
+	defer mu.Unlock()
+	reporter = r
+}
+
+// Flush waits up to timeout for in-flight reports to be sent.
+// It returns false if the timeout elapsed first.
+func Flush(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// dispatch sends the report in the background so a crashing request or
+// goroutine is not held up by the collector.
+func dispatch(report *Report, onError func(error)) {
+	mu.RLock()
+	r := reporter
+	mu.RUnlock()
+	if r == nil {
+		return
+	}
+
+	inflight.Add(1)
+	go func() {
+		defer inflight.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		if err := r.Report(ctx, report); err != nil {
+			onError(err)
+		}
+	}()
+}
+
+// currentService returns the service name passed to Init.
+func currentService() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return serviceName
+}
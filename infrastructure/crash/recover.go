@@ -0,0 +1,138 @@
+package crash
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// maxStackDepth bounds how many frames are captured for a report.
+	maxStackDepth = 64
+	// packagePrefix identifies this package's own frames.
+	packagePrefix = "github.com/jonesrussell/north-cloud/infrastructure/crash."
+)
+
+// Recover recovers a panic, logs it with a stack trace, and reports it.
+// It must be deferred directly:
+//
+//	defer crash.Recover(log, logger.String("job_id", id))
+func Recover(log logger.Logger, fields ...logger.Field) {
+	if r := recover(); r != nil {
+		Handle(r, log, fields...)
+	}
+}
+
+// Handle logs and reports a value already obtained from recover(). Use it when
+// the caller needs to run its own cleanup after a panic (e.g. marking a job failed).
+func Handle(r any, log logger.Logger, fields ...logger.Field) {
+	now := time.Now()
+	stack := string(debug.Stack())
+
+	logFields := make([]logger.Field, 0, len(fields)+2)
+	logFields = append(logFields, logger.Any("panic", r), logger.String("stack", stack))
+	logFields = append(logFields, fields...)
+	log.Error("Panic recovered", logFields...)
+
+	report := &Report{
+		Service: currentService(),
+		Value:   fmt.Sprint(r),
+		Type:    fmt.Sprintf("%T", r),
+		Stack:   stack,
+		Frames:  panicFrames(),
+		Fields:  fieldMap(fields),
+		Time:    now,
+	}
+	dispatch(report, func(err error) {
+		log.Warn("Failed to send crash report", logger.Error(err))
+	})
+}
+
+// Go runs fn in a new goroutine that recovers, logs, and reports panics instead
+// of crashing the process.
+func Go(log logger.Logger, name string, fn func()) {
+	go func() {
+		defer Recover(log, logger.String("goroutine", name))
+		fn()
+	}()
+}
+
+// panicFrames returns the frames of the panicking code, outermost caller first.
+// Frames belonging to the recovery machinery (this package, deferred calls and
+// runtime.gopanic) are dropped.
+func panicFrames() []Frame {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(0, pcs)
+	iter := runtime.CallersFrames(pcs[:n])
+
+	var all []runtime.Frame
+	panicIdx := -1
+	for {
+		frame, more := iter.Next()
+		if frame.Function == "runtime.gopanic" {
+			panicIdx = len(all)
+		}
+		all = append(all, frame)
+		if !more {
+			break
+		}
+	}
+
+	// Without a gopanic frame Handle was called outside a deferred recover;
+	// report from the caller of Handle.
+	start := panicIdx + 1
+	if panicIdx < 0 {
+		for start < len(all) && isRecoveryFrame(all[start].Function) {
+			start++
+		}
+	}
+
+	frames := make([]Frame, 0, len(all)-start)
+	for i := len(all) - 1; i >= start; i-- {
+		if all[i].Function == "runtime.goexit" {
+			continue
+		}
+		module, function := splitFunction(all[i].Function)
+		frames = append(frames, Frame{
+			Function: function,
+			Module:   module,
+			File:     all[i].File,
+			Line:     all[i].Line,
+		})
+	}
+	return frames
+}
+
+// isRecoveryFrame reports whether a frame belongs to the runtime or this package.
+func isRecoveryFrame(function string) bool {
+	return strings.HasPrefix(function, "runtime.") || strings.HasPrefix(function, packagePrefix)
+}
+
+// splitFunction splits "github.com/a/b/pkg.(*T).Method" into
+// "github.com/a/b/pkg" and "(*T).Method".
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	cut := slash + 1 + dot
+	return name[:cut], name[cut+1:]
+}
+
+// fieldMap flattens structured log fields into a map for the report.
+func fieldMap(fields []logger.Field) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return enc.Fields
+}
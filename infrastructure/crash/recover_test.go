@@ -0,0 +1,147 @@
+package crash_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// captureReporter records reports for assertions.
+type captureReporter struct {
+	mu      sync.Mutex
+	reports []*crash.Report
+}
+
+func (c *captureReporter) Report(_ context.Context, report *crash.Report) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reports = append(c.reports, report)
+	return nil
+}
+
+func (c *captureReporter) all() []*crash.Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*crash.Report(nil), c.reports...)
+}
+
+func useReporter(t *testing.T) *captureReporter {
+	t.Helper()
+
+	reporter := &captureReporter{}
+	crash.SetReporter(reporter)
+	t.Cleanup(func() { crash.SetReporter(nil) })
+	return reporter
+}
+
+func panicWithJob() {
+	defer crash.Recover(logger.NewNop(), logger.String("job_id", "job-1"))
+	explode()
+}
+
+func explode() {
+	panic("boom")
+}
+
+func TestRecover_ReportsPanic(t *testing.T) {
+	reporter := useReporter(t)
+
+	panicWithJob()
+
+	if !crash.Flush(time.Second) {
+		t.Fatal("flush timed out")
+	}
+	reports := reporter.all()
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(reports))
+	}
+
+	report := reports[0]
+	if report.Value != "boom" || report.Type != "string" {
+		t.Errorf("unexpected value/type %q/%q", report.Value, report.Type)
+	}
+	if report.Fields["job_id"] != "job-1" {
+		t.Errorf("expected job_id field, got %v", report.Fields)
+	}
+	if !strings.Contains(report.Stack, "explode") {
+		t.Error("expected stack to include the panicking function")
+	}
+
+	// Innermost frame is last and is the function that panicked.
+	last := report.Frames[len(report.Frames)-1]
+	if last.Function != "explode" || !strings.HasSuffix(last.Module, "infrastructure/crash_test") {
+		t.Errorf("unexpected innermost frame %+v", last)
+	}
+}
+
+func TestRecover_NoPanic(t *testing.T) {
+	reporter := useReporter(t)
+
+	func() {
+		defer crash.Recover(logger.NewNop())
+	}()
+
+	crash.Flush(time.Second)
+	if n := len(reporter.all()); n != 0 {
+		t.Errorf("expected no reports, got %d", n)
+	}
+}
+
+func TestGo_RecoversPanic(t *testing.T) {
+	reporter := useReporter(t)
+
+	done := make(chan struct{})
+	crash.Go(logger.NewNop(), "worker", func() {
+		defer close(done)
+		var m map[string]int
+		m["x"] = 1
+	})
+	<-done
+
+	deadline := time.Now().Add(time.Second)
+	for len(reporter.all()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	crash.Flush(time.Second)
+
+	reports := reporter.all()
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(reports))
+	}
+	if reports[0].Fields["goroutine"] != "worker" {
+		t.Errorf("expected goroutine field, got %v", reports[0].Fields)
+	}
+}
+
+func TestHandle_WithoutReporter(t *testing.T) {
+	crash.SetReporter(nil)
+
+	// Logging only; must not block or panic.
+	crash.Handle("boom", logger.NewNop())
+
+	if !crash.Flush(time.Second) {
+		t.Error("flush should return immediately with no reporter")
+	}
+}
+
+func TestStart_WithoutDSN(t *testing.T) {
+	t.Setenv("SENTRY_DSN", "")
+
+	flush := crash.Start("test-service")
+
+	done := make(chan struct{})
+	go func() {
+		flush()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("flush blocked with nothing in flight")
+	}
+}
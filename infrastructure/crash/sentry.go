@@ -0,0 +1,208 @@
+package crash
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// sentryClient identifies this reporter in the X-Sentry-Auth header.
+	sentryClient = "north-cloud-crash/1.0"
+	// sentryProtocolVersion is the Sentry store protocol version.
+	sentryProtocolVersion = 7
+	// eventIDByteLen produces the 32 hex character event ID Sentry expects.
+	eventIDByteLen = 16
+	// maxErrorBodyBytes bounds how much of an error response is read.
+	maxErrorBodyBytes = 1024
+	// inAppPrefix marks frames from North Cloud code as in-app.
+	inAppPrefix = "github.com/jonesrussell/north-cloud/"
+)
+
+// ErrInvalidDSN is returned when a Sentry DSN cannot be parsed.
+var ErrInvalidDSN = errors.New("invalid sentry DSN")
+
+// SentryConfig configures a SentryReporter.
+type SentryConfig struct {
+	// DSN is a Sentry-compatible DSN: https://<public_key>@<host>[/<path>]/<project_id>.
+	// Self-hosted Sentry and GlitchTip both accept it.
+	DSN string
+	// Environment tags events (e.g. "production").
+	Environment string
+	// Release tags events with the deployed version.
+	Release string
+	// ServerName tags events with the reporting host.
+	ServerName string
+	// HTTPClient sends reports. Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+}
+
+// SentryReporter ships reports to a Sentry-compatible store endpoint.
+type SentryReporter struct {
+	cfg        SentryConfig
+	storeURL   string
+	authHeader string
+	httpClient *http.Client
+}
+
+// NewSentryReporter creates a reporter for the given DSN.
+func NewSentryReporter(cfg SentryConfig) (*SentryReporter, error) {
+	storeURL, publicKey, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: sendTimeout}
+	}
+
+	return &SentryReporter{
+		cfg:      cfg,
+		storeURL: storeURL,
+		authHeader: fmt.Sprintf("Sentry sentry_version=%d, sentry_client=%s, sentry_key=%s",
+			sentryProtocolVersion, sentryClient, publicKey),
+		httpClient: httpClient,
+	}, nil
+}
+
+// parseDSN returns the store endpoint and public key for a DSN.
+func parseDSN(dsn string) (storeURL, publicKey string, err error) {
+	u, parseErr := url.Parse(dsn)
+	if parseErr != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrInvalidDSN, parseErr)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", "", fmt.Errorf("%w: scheme must be http(s) with a host", ErrInvalidDSN)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", fmt.Errorf("%w: missing public key", ErrInvalidDSN)
+	}
+
+	path := strings.TrimRight(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	projectID := path[slash+1:]
+	if slash < 0 || projectID == "" {
+		return "", "", fmt.Errorf("%w: missing project ID", ErrInvalidDSN)
+	}
+
+	storeURL = fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, path[:slash], projectID)
+	return storeURL, u.User.Username(), nil
+}
+
+// sentryEvent is the subset of the Sentry event payload the reporter sends.
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	Exception   sentryExceptions  `json:"exception"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+// Report sends a crash report as a fatal-level event.
+func (s *SentryReporter) Report(ctx context.Context, report *Report) error {
+	body, err := json.Marshal(s.buildEvent(report))
+	if err != nil {
+		return fmt.Errorf("marshal sentry event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create sentry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", s.authHeader)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send sentry event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("sentry returned %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	return nil
+}
+
+// buildEvent converts a report into a Sentry event.
+func (s *SentryReporter) buildEvent(report *Report) *sentryEvent {
+	frames := make([]sentryFrame, 0, len(report.Frames))
+	for _, f := range report.Frames {
+		frames = append(frames, sentryFrame{
+			Function: f.Function,
+			Module:   f.Module,
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    strings.HasPrefix(f.Module, inAppPrefix),
+		})
+	}
+
+	var tags map[string]string
+	if report.Service != "" {
+		tags = map[string]string{"service": report.Service}
+	}
+
+	return &sentryEvent{
+		EventID:     newEventID(),
+		Timestamp:   report.Time.UTC().Format(time.RFC3339),
+		Level:       "fatal",
+		Platform:    "go",
+		Logger:      report.Service,
+		ServerName:  s.cfg.ServerName,
+		Environment: s.cfg.Environment,
+		Release:     s.cfg.Release,
+		Tags:        tags,
+		Extra:       report.Fields,
+		Exception: sentryExceptions{Values: []sentryException{{
+			Type:       "panic: " + report.Type,
+			Value:      report.Value,
+			Stacktrace: &sentryStacktrace{Frames: frames},
+		}}},
+	}
+}
+
+// newEventID returns a random 32 hex character event ID.
+func newEventID() string {
+	b := make([]byte, eventIDByteLen)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
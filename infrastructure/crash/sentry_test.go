@@ -0,0 +1,116 @@
+package crash_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
+)
+
+func TestNewSentryReporter_InvalidDSN(t *testing.T) {
+	t.Parallel()
+
+	for _, dsn := range []string{
+		"",
+		"not a url",
+		"ftp://key@sentry.example.com/1",
+		"https://sentry.example.com/1",
+		"https://key@sentry.example.com/",
+	} {
+		if _, err := crash.NewSentryReporter(crash.SentryConfig{DSN: dsn}); !errors.Is(err, crash.ErrInvalidDSN) {
+			t.Errorf("DSN %q: expected ErrInvalidDSN, got %v", dsn, err)
+		}
+	}
+}
+
+func TestSentryReporter_Report(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotPath string
+		gotAuth string
+		event   map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("X-Sentry-Auth")
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":"x"}`))
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://publickey@", 1) + "/sentry/42"
+	reporter, err := crash.NewSentryReporter(crash.SentryConfig{
+		DSN:         dsn,
+		Environment: "production",
+		Release:     "1.2.3",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = reporter.Report(context.Background(), &crash.Report{
+		Service: "crawler",
+		Value:   "boom",
+		Type:    "string",
+		Frames: []crash.Frame{
+			{Function: "main", Module: "main", File: "/app/main.go", Line: 10},
+			{Function: "(*Scheduler).run", Module: "github.com/jonesrussell/north-cloud/crawler/internal/scheduler", Line: 42},
+		},
+		Fields: map[string]any{"job_id": "job-1"},
+		Time:   time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/sentry/api/42/store/" {
+		t.Errorf("unexpected store path %q", gotPath)
+	}
+	if !strings.Contains(gotAuth, "sentry_key=publickey") || !strings.Contains(gotAuth, "sentry_version=7") {
+		t.Errorf("unexpected auth header %q", gotAuth)
+	}
+	if event["level"] != "fatal" || event["environment"] != "production" || event["release"] != "1.2.3" {
+		t.Errorf("unexpected event envelope %v", event)
+	}
+	if tags, _ := event["tags"].(map[string]any); tags["service"] != "crawler" {
+		t.Errorf("expected service tag, got %v", event["tags"])
+	}
+
+	values := event["exception"].(map[string]any)["values"].([]any)
+	exception := values[0].(map[string]any)
+	if exception["value"] != "boom" {
+		t.Errorf("unexpected exception %v", exception)
+	}
+	frames := exception["stacktrace"].(map[string]any)["frames"].([]any)
+	if frames[0].(map[string]any)["in_app"] != false || frames[1].(map[string]any)["in_app"] != true {
+		t.Errorf("unexpected in_app flags %v", frames)
+	}
+}
+
+func TestSentryReporter_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://key@", 1) + "/1"
+	reporter, err := crash.NewSentryReporter(crash.SentryConfig{DSN: dsn})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if reportErr := reporter.Report(context.Background(), &crash.Report{Time: time.Now()}); reportErr == nil {
+		t.Error("expected error for 429 response")
+	}
+}
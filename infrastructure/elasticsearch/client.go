@@ -26,7 +26,10 @@ func NewClient(ctx context.Context, cfg Config, log logger.Logger) (*es.Client,
 	url := normalizeURL(cfg.URL)
 
 	// Create transport with TLS if configured
-	transport := createTransport(cfg.TLS)
+	var transport http.RoundTripper = createTransport(cfg.TLS)
+	if cfg.PropagateRequestID {
+		transport = &requestIDTransport{next: transport}
+	}
 
 	// Create client configuration
 	clientConfig := es.Config{
@@ -35,6 +35,11 @@ type Config struct {
 	// PingTimeout is the timeout for ping verification (default: 5s)
 	PingTimeout time.Duration
 
+	// PropagateRequestID forwards the request correlation ID stored with
+	// logger.WithRequestID as the X-Opaque-Id header, so it appears in ES slow
+	// logs and the tasks API.
+	PropagateRequestID bool
+
 	// RetryConfig is the configuration for connection retry logic
 	// If nil, default retry config will be used (5 attempts, 2s initial, 10s max)
 	RetryConfig *retry.Config
@@ -0,0 +1,30 @@
+package elasticsearch
+
+import (
+	"net/http"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// OpaqueIDHeader is the header Elasticsearch records in slow logs, the tasks
+// API, and deprecation logs to identify the caller of a request.
+const OpaqueIDHeader = "X-Opaque-Id"
+
+// requestIDTransport copies the request correlation ID from the request
+// context onto outbound Elasticsearch requests.
+type requestIDTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := logger.RequestIDFromContext(req.Context())
+	if requestID == "" || req.Header.Get(OpaqueIDHeader) != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the caller's request.
+	clone := req.Clone(req.Context())
+	clone.Header.Set(OpaqueIDHeader, requestID)
+	return t.next.RoundTrip(clone)
+}
@@ -0,0 +1,63 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+func TestRequestIDTransport_SetsOpaqueID(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(OpaqueIDHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &requestIDTransport{next: http.DefaultTransport}}
+
+	ctx := logger.WithRequestID(context.Background(), "req-123")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, http.NoBody)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got != "req-123" {
+		t.Errorf("%s = %q, want req-123", OpaqueIDHeader, got)
+	}
+	if req.Header.Get(OpaqueIDHeader) != "" {
+		t.Error("transport must not modify the caller's request")
+	}
+}
+
+func TestRequestIDTransport_NoRequestID(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(OpaqueIDHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &requestIDTransport{next: http.DefaultTransport}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got != "" {
+		t.Errorf("%s = %q, want empty without a request ID", OpaqueIDHeader, got)
+	}
+}
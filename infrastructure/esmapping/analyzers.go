@@ -1,7 +1,22 @@
 package esmapping
 
-// EnglishAnalysisSettings returns custom English analyzer settings for classified_content.
-func EnglishAnalysisSettings() map[string]any {
+// FrenchSubfield is the multi-field of title, raw_text and body analyzed as
+// French. Searches in French query it instead of the English-analyzed parent.
+const FrenchSubfield = "fr"
+
+// SuggestSubfield is the multi-field of title kept unstemmed and shingled
+// (one to three words), which the search phrase suggester corrects typos from.
+const SuggestSubfield = "suggest"
+
+// SearchSynonymsSet is the Elasticsearch synonyms set the search analyzers of
+// title, raw_text and body expand queries with. The search service manages its
+// rules; the set must exist before an index referencing it is created.
+const SearchSynonymsSet = "north-cloud-search"
+
+// ContentAnalysisSettings returns the English, French and suggestion analyzers
+// for classified_content. The *_search analyzers add the synonyms set at query
+// time only, so changing a synonym reloads them without reindexing.
+func ContentAnalysisSettings() map[string]any {
 	return map[string]any{
 		"analyzer": map[string]any{
 			"english_content": map[string]any{
@@ -9,10 +24,50 @@ func EnglishAnalysisSettings() map[string]any {
 				"tokenizer": "standard",
 				"filter":    []string{"lowercase", "english_stop", "english_stemmer"},
 			},
+			"french_content": map[string]any{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    []string{"french_elision", "lowercase", "french_stop", "french_stemmer"},
+			},
+			"english_search": map[string]any{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    []string{"lowercase", "search_synonyms", "english_stop", "english_stemmer"},
+			},
+			"french_search": map[string]any{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    []string{"french_elision", "lowercase", "search_synonyms", "french_stop", "french_stemmer"},
+			},
+			"suggest_shingle": map[string]any{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    []string{"lowercase", "suggest_shingle"},
+			},
 		},
 		"filter": map[string]any{
 			"english_stop":    map[string]any{"type": "stop", "stopwords": "_english_"},
 			"english_stemmer": map[string]any{"type": "stemmer", "language": "english"},
+			"french_elision": map[string]any{
+				"type":          "elision",
+				"articles_case": true,
+				"articles": []string{
+					"l", "m", "t", "qu", "n", "s", "j", "d", "c",
+					"jusqu", "quoiqu", "lorsqu", "puisqu",
+				},
+			},
+			"french_stop":    map[string]any{"type": "stop", "stopwords": "_french_"},
+			"french_stemmer": map[string]any{"type": "stemmer", "language": "light_french"},
+			"search_synonyms": map[string]any{
+				"type":         "synonym_graph",
+				"synonyms_set": SearchSynonymsSet,
+				"updateable":   true,
+			},
+			"suggest_shingle": map[string]any{
+				"type":             "shingle",
+				"min_shingle_size": 2,
+				"max_shingle_size": 3,
+			},
 		},
 	}
 }
@@ -22,3 +77,37 @@ func setEnglishContentAnalyzer(properties map[string]any, field string) {
 		fieldMap["analyzer"] = "english_content"
 	}
 }
+
+// setEnglishSearchAnalyzer expands queries on field with the search synonyms.
+func setEnglishSearchAnalyzer(properties map[string]any, field string) {
+	if fieldMap, ok := properties[field].(map[string]any); ok {
+		fieldMap["search_analyzer"] = "english_search"
+	}
+}
+
+// addFrenchSubfield indexes field a second time with the French analyzer.
+func addFrenchSubfield(properties map[string]any, field string) {
+	addSubfield(properties, field, FrenchSubfield, map[string]any{
+		"type":            "text",
+		"analyzer":        "french_content",
+		"search_analyzer": "french_search",
+	})
+}
+
+// addSuggestSubfield indexes field a second time, unstemmed and shingled.
+func addSuggestSubfield(properties map[string]any, field string) {
+	addSubfield(properties, field, SuggestSubfield, map[string]any{"type": "text", "analyzer": "suggest_shingle"})
+}
+
+func addSubfield(properties map[string]any, field, name string, mapping map[string]any) {
+	fieldMap, ok := properties[field].(map[string]any)
+	if !ok {
+		return
+	}
+	subfields, ok := fieldMap["fields"].(map[string]any)
+	if !ok {
+		subfields = map[string]any{}
+		fieldMap["fields"] = subfields
+	}
+	subfields[name] = mapping
+}
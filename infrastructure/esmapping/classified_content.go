@@ -57,6 +57,11 @@ func getRawContentFields() map[string]any {
 		"author": map[string]any{
 			"type": "text",
 		},
+		// Primary language subtag from the page ("en", "fr"); selects the
+		// subfields a language-restricted search queries
+		"language": map[string]any{
+			"type": "keyword",
+		},
 		"crawled_at": map[string]any{
 			"type":   "date",
 			"format": ESDateFormat,
@@ -210,6 +215,27 @@ func getLocationMapping() map[string]any {
 	}
 }
 
+// getLocalityMapping returns the nested locality salience object mapping
+func getLocalityMapping() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"region": map[string]any{
+				"type": "keyword",
+			},
+			"home_region": map[string]any{
+				"type": "keyword",
+			},
+			"salience": map[string]any{
+				"type": "float",
+			},
+			"is_local": map[string]any{
+				"type": "boolean",
+			},
+		},
+	}
+}
+
 // getMiningMapping returns the nested mining object mapping
 func getMiningMapping() map[string]any {
 	return map[string]any{
@@ -537,6 +563,7 @@ func getClassificationFields() map[string]any {
 		},
 		"crime":         getCrimeMapping(),
 		"location":      getLocationMapping(),
+		"locality":      getLocalityMapping(),
 		"mining":        getMiningMapping(),
 		"coforge":       getCoforgeMapping(),
 		"indigenous":    getIndigenousMapping(),
@@ -596,11 +623,24 @@ func ClassifiedContentIndex(shards, replicas int) map[string]any {
 	setEnglishContentAnalyzer(properties, "body")
 	setEnglishContentAnalyzer(properties, "content_type")
 
+	// Query-time synonyms on the searched text fields
+	setEnglishSearchAnalyzer(properties, "title")
+	setEnglishSearchAnalyzer(properties, "raw_text")
+	setEnglishSearchAnalyzer(properties, "body")
+
+	// French multi-fields, so French documents can be searched with French stemming
+	addFrenchSubfield(properties, "title")
+	addFrenchSubfield(properties, "raw_text")
+	addFrenchSubfield(properties, "body")
+
+	// Unstemmed title shingles for spelling suggestions
+	addSuggestSubfield(properties, "title")
+
 	return map[string]any{
 		"settings": map[string]any{
 			"number_of_shards":   shards,
 			"number_of_replicas": replicas,
-			"analysis":           EnglishAnalysisSettings(),
+			"analysis":           ContentAnalysisSettings(),
 		},
 		"mappings": map[string]any{
 			"dynamic":    "strict",
@@ -64,6 +64,82 @@ func TestClassifiedContentIndex_ICPMapping(t *testing.T) {
 	}
 }
 
+func TestClassifiedContentIndex_FrenchSubfields(t *testing.T) {
+	t.Helper()
+	m := esmapping.ClassifiedContentIndex(1, 1)
+	props := m["mappings"].(map[string]any)["properties"].(map[string]any)
+
+	for _, field := range []string{"title", "raw_text", "body"} {
+		fieldMap := props[field].(map[string]any)
+		if fieldMap["analyzer"] != "english_content" {
+			t.Errorf("%s.analyzer = %v, want english_content", field, fieldMap["analyzer"])
+		}
+		subfields, _ := fieldMap["fields"].(map[string]any)
+		fr, ok := subfields[esmapping.FrenchSubfield].(map[string]any)
+		if !ok || fr["analyzer"] != "french_content" {
+			t.Errorf("%s missing french_content subfield: %v", field, fieldMap["fields"])
+		}
+	}
+
+	if lang := props["language"].(map[string]any); lang["type"] != "keyword" {
+		t.Errorf("language.type = %v, want keyword", lang["type"])
+	}
+	analyzers := m["settings"].(map[string]any)["analysis"].(map[string]any)["analyzer"].(map[string]any)
+	if analyzers["french_content"] == nil {
+		t.Error("missing french_content analyzer")
+	}
+}
+
+func TestClassifiedContentIndex_SearchSynonyms(t *testing.T) {
+	t.Helper()
+	m := esmapping.ClassifiedContentIndex(1, 1)
+	props := m["mappings"].(map[string]any)["properties"].(map[string]any)
+
+	for _, field := range []string{"title", "raw_text", "body"} {
+		fieldMap := props[field].(map[string]any)
+		if fieldMap["search_analyzer"] != "english_search" {
+			t.Errorf("%s.search_analyzer = %v, want english_search", field, fieldMap["search_analyzer"])
+		}
+		fr := fieldMap["fields"].(map[string]any)[esmapping.FrenchSubfield].(map[string]any)
+		if fr["search_analyzer"] != "french_search" {
+			t.Errorf("%s.fr.search_analyzer = %v, want french_search", field, fr["search_analyzer"])
+		}
+	}
+
+	analysis := m["settings"].(map[string]any)["analysis"].(map[string]any)
+	synonyms := analysis["filter"].(map[string]any)["search_synonyms"].(map[string]any)
+	if synonyms["synonyms_set"] != esmapping.SearchSynonymsSet || synonyms["updateable"] != true {
+		t.Errorf("search_synonyms = %v, want the updateable %s set", synonyms, esmapping.SearchSynonymsSet)
+	}
+	// Index-time analyzers cannot use an updateable filter
+	english := analysis["analyzer"].(map[string]any)["english_content"].(map[string]any)
+	for _, filter := range english["filter"].([]string) {
+		if filter == "search_synonyms" {
+			t.Error("english_content must not use the synonyms filter")
+		}
+	}
+}
+
+func TestClassifiedContentIndex_TitleSuggestSubfield(t *testing.T) {
+	t.Helper()
+	m := esmapping.ClassifiedContentIndex(1, 1)
+	props := m["mappings"].(map[string]any)["properties"].(map[string]any)
+
+	subfields, _ := props["title"].(map[string]any)["fields"].(map[string]any)
+	suggest, ok := subfields[esmapping.SuggestSubfield].(map[string]any)
+	if !ok || suggest["analyzer"] != "suggest_shingle" {
+		t.Errorf("title missing suggest_shingle subfield: %v", subfields)
+	}
+
+	analysis := m["settings"].(map[string]any)["analysis"].(map[string]any)
+	if analysis["analyzer"].(map[string]any)["suggest_shingle"] == nil {
+		t.Error("missing suggest_shingle analyzer")
+	}
+	if analysis["filter"].(map[string]any)["suggest_shingle"] == nil {
+		t.Error("missing suggest_shingle filter")
+	}
+}
+
 func TestClassifiedContentIndex_JSONStableSnapshot(t *testing.T) {
 	t.Helper()
 	s, err := esmapping.ToIndentedJSON(esmapping.ClassifiedContentIndex(1, 1))
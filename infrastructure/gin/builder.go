@@ -151,11 +151,14 @@ func (b *ServerBuilder) Build() *Server {
 }
 
 // ProtectedGroup creates a router group with JWT authentication middleware.
-// Use this for routes that require authentication.
-func ProtectedGroup(router *gin.Engine, path, jwtSecret string) *gin.RouterGroup {
+// Use this for routes that require authentication. opts declare the scope the
+// group's routes need (jwt.WithScope) and non-GET routes that only read
+// (jwt.WithReadRoutes); without a scope, tokens limited to explicit scopes
+// are refused and read tokens may only read.
+func ProtectedGroup(router *gin.Engine, path, jwtSecret string, opts ...jwt.MiddlewareOption) *gin.RouterGroup {
 	group := router.Group(path)
 	if jwtSecret != "" {
-		group.Use(jwt.Middleware(jwtSecret))
+		group.Use(jwt.Middleware(jwtSecret, opts...))
 	}
 	return group
 }
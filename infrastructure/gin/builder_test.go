@@ -0,0 +1,94 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ginpkg "github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+const builderTestSecret = "test-secret-key-32-chars-minimum"
+
+func signBuilderToken(t *testing.T, scope string) string {
+	t.Helper()
+
+	claims := &jwt.Claims{
+		Sub:   "dashboard",
+		Scope: scope,
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ExpiresAt: gojwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	signed, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(builderTestSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+// TestProtectedGroup_ReadTokenIsReadOnly mounts routes the way services do,
+// without options, and checks that a read token can read but not change
+// anything in any of them.
+func TestProtectedGroup_ReadTokenIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	ginpkg.SetMode(ginpkg.TestMode)
+	router := ginpkg.New()
+	ok := func(c *ginpkg.Context) { c.Status(http.StatusOK) }
+
+	routes := []struct {
+		service string
+		method  string
+		path    string
+	}{
+		{"source-manager", http.MethodGet, "/sources/:id"},
+		{"source-manager", http.MethodDelete, "/sources/:id"},
+		{"source-manager", http.MethodPut, "/sources/:id/credentials/:kind"},
+		{"publisher", http.MethodPost, "/channels"},
+		{"classifier", http.MethodPut, "/rules/:id"},
+		{"click-tracker", http.MethodGet, "/stats/campaigns"},
+		{"social-publisher", http.MethodDelete, "/accounts/:id"},
+		{"rfp-ingestor", http.MethodGet, "/status"},
+	}
+	for _, route := range routes {
+		v1 := infragin.ProtectedGroup(router, "/"+route.service+"/api/v1", builderTestSecret)
+		v1.Handle(route.method, route.path, ok)
+	}
+
+	readToken := signBuilderToken(t, jwt.ScopeRead)
+	fullToken := signBuilderToken(t, jwt.ScopeAdmin)
+
+	cases := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		want   int
+	}{
+		{"read token reads a source", readToken, http.MethodGet, "/source-manager/api/v1/sources/x", http.StatusOK},
+		{"read token deletes a source", readToken, http.MethodDelete, "/source-manager/api/v1/sources/x", http.StatusForbidden},
+		{"read token stores a credential", readToken, http.MethodPut, "/source-manager/api/v1/sources/x/credentials/basic", http.StatusForbidden},
+		{"read token creates a channel", readToken, http.MethodPost, "/publisher/api/v1/channels", http.StatusForbidden},
+		{"read token edits a rule", readToken, http.MethodPut, "/classifier/api/v1/rules/1", http.StatusForbidden},
+		{"read token reads stats", readToken, http.MethodGet, "/click-tracker/api/v1/stats/campaigns", http.StatusOK},
+		{"read token deletes an account", readToken, http.MethodDelete, "/social-publisher/api/v1/accounts/x", http.StatusForbidden},
+		{"read token reads status", readToken, http.MethodGet, "/rfp-ingestor/api/v1/status", http.StatusOK},
+		{"admin token deletes a source", fullToken, http.MethodDelete, "/source-manager/api/v1/sources/x", http.StatusOK},
+		{"admin token deletes an account", fullToken, http.MethodDelete, "/social-publisher/api/v1/accounts/x", http.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, http.NoBody)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, tc.want)
+		}
+	}
+}
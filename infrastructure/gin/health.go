@@ -1,6 +1,7 @@
 package gin
 
 import (
+	"context"
 	"net/http"
 	"sync"
 	"time"
@@ -46,7 +47,8 @@ type CheckResult struct {
 }
 
 // HealthChecker is a function that performs a health check and returns the result.
-type HealthChecker func() CheckResult
+// The context is the health request's context, so checks stop when the caller goes away.
+type HealthChecker func(ctx context.Context) CheckResult
 
 // HealthOptions configures the health endpoint behavior.
 type HealthOptions struct {
@@ -112,7 +114,7 @@ func healthHandler(serviceName, version string, checks map[string]HealthChecker)
 		if len(checks) > 0 {
 			response.Checks = make(map[string]CheckResult, len(checks))
 			for name, checker := range checks {
-				result := checker()
+				result := checker(c.Request.Context())
 				response.Checks[name] = result
 
 				// Update overall status based on check results
@@ -247,7 +249,7 @@ func appendInt(buf []byte, n int) []byte {
 // DatabaseHealthChecker creates a health checker for database connectivity.
 // The pingFunc should attempt to ping the database and return an error if it fails.
 func DatabaseHealthChecker(pingFunc func() error) HealthChecker {
-	return func() CheckResult {
+	return func(_ context.Context) CheckResult {
 		start := time.Now()
 		err := pingFunc()
 		latency := time.Since(start)
@@ -271,7 +273,7 @@ func DatabaseHealthChecker(pingFunc func() error) HealthChecker {
 // RedisHealthChecker creates a health checker for Redis connectivity.
 // The pingFunc should attempt to ping Redis and return an error if it fails.
 func RedisHealthChecker(pingFunc func() error) HealthChecker {
-	return func() CheckResult {
+	return func(_ context.Context) CheckResult {
 		start := time.Now()
 		err := pingFunc()
 		latency := time.Since(start)
@@ -295,7 +297,7 @@ func RedisHealthChecker(pingFunc func() error) HealthChecker {
 // ElasticsearchHealthChecker creates a health checker for Elasticsearch connectivity.
 // The pingFunc should attempt to ping Elasticsearch and return an error if it fails.
 func ElasticsearchHealthChecker(pingFunc func() error) HealthChecker {
-	return func() CheckResult {
+	return func(_ context.Context) CheckResult {
 		start := time.Now()
 		err := pingFunc()
 		latency := time.Since(start)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -163,17 +164,18 @@ func formatMaxAge(d time.Duration) string {
 }
 
 // RecoveryMiddleware creates a Gin middleware for panic recovery with logging.
-// It catches panics, logs them with the infrastructure logger, and returns a 500 error.
+// It catches panics, logs them with a stack trace, reports them through the
+// crash package (when configured), and returns a 500 error.
 func RecoveryMiddleware(log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				// Log the panic
-				log.Error("Panic recovered",
-					logger.Any("error", err),
+				// Log and report the panic
+				crash.Handle(err, log,
 					logger.String("path", c.Request.URL.Path),
 					logger.String("method", c.Request.Method),
 					logger.String("client_ip", c.ClientIP()),
+					logger.String("request_id", c.GetString("request_id")),
 				)
 
 				// Return 500 error
@@ -226,6 +228,8 @@ func RequestIDLoggerMiddleware(log logger.Logger) gin.HandlerFunc {
 		// can retrieve it via logger.FromContext(c.Request.Context())
 		reqLog := log.With(logger.String("request_id", requestID))
 		ctx := logger.WithContext(c.Request.Context(), reqLog)
+		// The bare ID lets outbound clients forward it (e.g. as X-Opaque-Id to ES)
+		ctx = logger.WithRequestID(ctx, requestID)
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
@@ -41,11 +41,12 @@ func TestRequestIDLoggerMiddleware_PreservesExistingID(t *testing.T) {
 	router := ginpkg.New()
 	router.Use(infragin.RequestIDLoggerMiddleware(log))
 
-	var gotGinCtxID string
+	var gotGinCtxID, gotGoCtxID string
 	router.GET("/test", func(c *ginpkg.Context) {
 		if v, ok := c.Get("request_id"); ok {
 			gotGinCtxID, _ = v.(string)
 		}
+		gotGoCtxID = logger.RequestIDFromContext(c.Request.Context())
 		c.String(http.StatusOK, "ok")
 	})
 
@@ -60,6 +61,9 @@ func TestRequestIDLoggerMiddleware_PreservesExistingID(t *testing.T) {
 	if gotGinCtxID != inboundID {
 		t.Errorf("gin context request_id = %q, want %q", gotGinCtxID, inboundID)
 	}
+	if gotGoCtxID != inboundID {
+		t.Errorf("Go context request ID = %q, want %q", gotGoCtxID, inboundID)
+	}
 }
 
 func TestRequestIDLoggerMiddleware_RejectsOversizedID(t *testing.T) {
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+const (
+	// ErrorBodyBytes bounds how much of an error response body is kept.
+	ErrorBodyBytes = 512
+
+	// ServiceTokenTTL is the lifetime of the service token minted per request.
+	ServiceTokenTTL = 5 * time.Minute
+
+	// InternalSecretHeader carries the shared service-to-service secret.
+	InternalSecretHeader = "X-Internal-Secret"
+)
+
+// StatusError is a non-2xx response from another service.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// CheckResponse returns a *StatusError holding the start of the body when
+// resp is not 2xx, and nil otherwise.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, ErrorBodyBytes))
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(raw)}
+}
+
+// ServiceClientConfig configures a ServiceClient.
+type ServiceClientConfig struct {
+	// Timeout bounds each request. Zero means DefaultTimeout.
+	Timeout time.Duration
+
+	// JWTSecret signs the service token sent as a bearer token with each
+	// request. No token is sent when it is empty.
+	JWTSecret string
+
+	// Subject identifies the calling service in the service token.
+	Subject string
+
+	// Scope limits what the service token may do. Empty means jwt.ScopeRead.
+	Scope string
+
+	// InternalSecret, if set, is sent in the InternalSecretHeader.
+	InternalSecret string
+}
+
+// ServiceClient makes authenticated JSON calls to other North Cloud services.
+type ServiceClient struct {
+	httpClient *http.Client
+	cfg        ServiceClientConfig
+}
+
+// NewServiceClient creates a service client with standardized HTTP settings.
+func NewServiceClient(cfg ServiceClientConfig) *ServiceClient {
+	if cfg.Scope == "" {
+		cfg.Scope = jwt.ScopeRead
+	}
+	return &ServiceClient{
+		httpClient: NewClient(&ClientConfig{Timeout: cfg.Timeout}),
+		cfg:        cfg,
+	}
+}
+
+// Do sends in (when not nil) as a JSON body to endpoint and decodes the
+// response into out (when not nil). A non-2xx response returns a *StatusError.
+func (c *ServiceClient) Do(ctx context.Context, method, endpoint string, in, out any) error {
+	body := io.Reader(http.NoBody)
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authErr := c.authorize(req); authErr != nil {
+		return authErr
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if statusErr := CheckResponse(resp); statusErr != nil {
+		return statusErr
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if decodeErr := json.NewDecoder(resp.Body).Decode(out); decodeErr != nil {
+		return fmt.Errorf("decode response: %w", decodeErr)
+	}
+	return nil
+}
+
+// authorize adds the configured service credentials to req.
+func (c *ServiceClient) authorize(req *http.Request) error {
+	if c.cfg.JWTSecret != "" {
+		token, err := jwt.NewServiceToken(c.cfg.JWTSecret, c.cfg.Subject, c.cfg.Scope, ServiceTokenTTL)
+		if err != nil {
+			return fmt.Errorf("sign service token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if c.cfg.InternalSecret != "" {
+		req.Header.Set(InternalSecretHeader, c.cfg.InternalSecret)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+)
+
+func TestServiceClient_Do(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Errorf("expected a service token, got %q", r.Header.Get("Authorization"))
+		}
+		if got := r.Header.Get(infrahttp.InternalSecretHeader); got != "shared" {
+			t.Errorf("internal secret = %q, want %q", got, "shared")
+		}
+		var in map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"echo": in["name"]})
+	}))
+	t.Cleanup(srv.Close)
+
+	client := infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+		JWTSecret:      "test-secret",
+		Subject:        "test",
+		InternalSecret: "shared",
+	})
+
+	var out map[string]string
+	err := client.Do(context.Background(), http.MethodPost, srv.URL, map[string]string{"name": "sudbury"}, &out)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if out["echo"] != "sudbury" {
+		t.Errorf("echo = %q, want %q", out["echo"], "sudbury")
+	}
+}
+
+func TestServiceClient_DoStatusError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(strings.Repeat("x", infrahttp.ErrorBodyBytes*2)))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{})
+	err := client.Do(context.Background(), http.MethodGet, srv.URL, nil, nil)
+
+	var statusErr *infrahttp.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a StatusError, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", statusErr.StatusCode, http.StatusForbidden)
+	}
+	if len(statusErr.Body) != infrahttp.ErrorBodyBytes {
+		t.Errorf("kept %d body bytes, want %d", len(statusErr.Body), infrahttp.ErrorBodyBytes)
+	}
+}
@@ -0,0 +1,100 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ScopeSearchAPI is the scope of search API keys. Middleware rejects it: an
+// API key only authenticates the public search API, not other services.
+const ScopeSearchAPI = "search_api"
+
+// apiKeyIDBytes is the number of random bytes in an API key ID.
+const apiKeyIDBytes = 8
+
+// ErrNotAPIKey is returned when a token that is not a search API key is parsed as one.
+var ErrNotAPIKey = errors.New("token is not a search API key")
+
+// apiKeyIndexPattern allows classified content indexes and wildcards over
+// them, so a key can never search raw content or another service's indexes.
+var apiKeyIndexPattern = regexp.MustCompile(`^[a-z0-9*][a-z0-9_*.-]*_classified_content$`)
+
+// ValidAPIKeyIndex reports whether pattern may be granted to an API key: a
+// classified content index name, optionally with wildcards.
+func ValidAPIKeyIndex(pattern string) bool {
+	return apiKeyIndexPattern.MatchString(pattern)
+}
+
+// APIKeyClaims are the claims of a search API key: who holds it, how much
+// it may search, and where. The key ID (jti) identifies it for rate limits,
+// quotas, and revocation.
+type APIKeyClaims struct {
+	Claims
+	// RateLimit is the number of requests allowed per minute; 0 uses the search default
+	RateLimit int `json:"rate_limit,omitempty"`
+	// DailyQuota is the number of requests allowed per UTC day; 0 uses the search default
+	DailyQuota int `json:"daily_quota,omitempty"`
+	// Indexes are the index patterns the key may search; empty allows every classified index
+	Indexes []string `json:"indexes,omitempty"`
+}
+
+// NewAPIKey signs a search API key for claims, valid for ttl. The scope and
+// a new key ID are set on claims; the signed key and its ID are returned.
+func NewAPIKey(secret string, claims *APIKeyClaims, ttl time.Duration) (key, keyID string, err error) {
+	if secret == "" {
+		return "", "", ErrNoSecret
+	}
+
+	id := make([]byte, apiKeyIDBytes)
+	if _, randErr := rand.Read(id); randErr != nil {
+		return "", "", fmt.Errorf("generate api key id: %w", randErr)
+	}
+
+	now := time.Now()
+	claims.Scope = ScopeSearchAPI
+	claims.ID = hex.EncodeToString(id)
+	claims.Subject = claims.Sub
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		return "", "", fmt.Errorf("sign api key: %w", err)
+	}
+	return signed, claims.ID, nil
+}
+
+// ParseAPIKey validates a search API key and returns its claims. Tokens with
+// another scope are rejected, so a dashboard token cannot stand in for a key.
+func ParseAPIKey(secret, key string) (*APIKeyClaims, error) {
+	token, err := jwt.ParseWithClaims(key, &APIKeyClaims{}, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*APIKeyClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if claims.Scope != ScopeSearchAPI || claims.ID == "" {
+		return nil, ErrNotAPIKey
+	}
+	for _, pattern := range claims.Indexes {
+		if !ValidAPIKeyIndex(pattern) {
+			return nil, fmt.Errorf("api key index pattern %q is not a classified content index", pattern)
+		}
+	}
+	return claims, nil
+}
@@ -0,0 +1,100 @@
+package jwt_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+func TestAPIKey_RoundTrip(t *testing.T) {
+	t.Helper()
+
+	key, keyID, err := jwt.NewAPIKey(testSecret, &jwt.APIKeyClaims{
+		Claims:     jwt.Claims{Sub: "partner-news"},
+		RateLimit:  120,
+		DailyQuota: 5000,
+		Indexes:    []string{"sudbury_*_classified_content"},
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	if len(keyID) != 16 {
+		t.Errorf("expected a 16-character key ID, got %q", keyID)
+	}
+
+	claims, err := jwt.ParseAPIKey(testSecret, key)
+	if err != nil {
+		t.Fatalf("ParseAPIKey: %v", err)
+	}
+	if claims.ID != keyID || claims.Sub != "partner-news" || claims.Scope != jwt.ScopeSearchAPI {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if claims.RateLimit != 120 || claims.DailyQuota != 5000 || claims.Indexes[0] != "sudbury_*_classified_content" {
+		t.Errorf("limits not preserved: %+v", claims)
+	}
+
+	if _, wrongSecretErr := jwt.ParseAPIKey("another-secret-key-32-chars-min", key); wrongSecretErr == nil {
+		t.Error("expected a key signed with another secret to be rejected")
+	}
+}
+
+func TestParseAPIKey_RejectsOtherTokens(t *testing.T) {
+	t.Helper()
+
+	if _, err := jwt.ParseAPIKey(testSecret, signToken(t, "")); !errors.Is(err, jwt.ErrNotAPIKey) {
+		t.Errorf("expected ErrNotAPIKey for a dashboard token, got %v", err)
+	}
+}
+
+func TestParseAPIKey_RejectsForeignIndexes(t *testing.T) {
+	t.Helper()
+
+	key, _, err := jwt.NewAPIKey(testSecret, &jwt.APIKeyClaims{
+		Claims:  jwt.Claims{Sub: "partner"},
+		Indexes: []string{"sudbury_com_raw_content"},
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	if _, parseErr := jwt.ParseAPIKey(testSecret, key); parseErr == nil {
+		t.Error("expected a key granting raw content to be rejected")
+	}
+}
+
+func TestValidAPIKeyIndex(t *testing.T) {
+	t.Helper()
+
+	valid := []string{"*_classified_content", "sudbury_com_classified_content", "sudbury_*_classified_content"}
+	for _, pattern := range valid {
+		if !jwt.ValidAPIKeyIndex(pattern) {
+			t.Errorf("expected %q to be valid", pattern)
+		}
+	}
+	invalid := []string{"*", "sudbury_com_raw_content", "a_classified_content,b_raw_content", "-x_classified_content", ""}
+	for _, pattern := range invalid {
+		if jwt.ValidAPIKeyIndex(pattern) {
+			t.Errorf("expected %q to be rejected", pattern)
+		}
+	}
+}
+
+func TestMiddleware_RejectsAPIKeys(t *testing.T) {
+	t.Helper()
+
+	key, _, err := jwt.NewAPIKey(testSecret, &jwt.APIKeyClaims{Claims: jwt.Claims{Sub: "partner"}}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/indexes", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+key)
+	w := httptest.NewRecorder()
+	newGuardedRouter().ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401: an API key must not authenticate other services", w.Code)
+	}
+}
@@ -0,0 +1,159 @@
+package jwt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IntrospectPath is the auth service's RFC 7662 token introspection endpoint.
+const IntrospectPath = "/api/v1/auth/introspect"
+
+const (
+	// defaultIntrospectionCacheTTL is how long an answer is reused, and so
+	// how long a revoked token may keep working in other services.
+	defaultIntrospectionCacheTTL = time.Minute
+	// introspectTimeout bounds a call to the auth service.
+	introspectTimeout = 5 * time.Second
+	// maxCachedIntrospections bounds the answer cache; it is cleared when full.
+	maxCachedIntrospections = 4096
+)
+
+// IntrospectionResponse is the auth service's RFC 7662 answer. Only Active
+// is set for a token that must be refused.
+type IntrospectionResponse struct {
+	Active bool   `json:"active"`
+	Scope  string `json:"scope,omitempty"`
+	Sub    string `json:"sub,omitempty"`
+	// Exp and Iat are Unix timestamps; Iat is absent for service keys.
+	Exp int64 `json:"exp,omitempty"`
+	Iat int64 `json:"iat,omitempty"`
+	// JTI is the session ID, search API key ID, or service key ID.
+	JTI string `json:"jti,omitempty"`
+}
+
+// Claims returns the claims a request authenticated with the token carries.
+func (r *IntrospectionResponse) Claims() *Claims {
+	claims := &Claims{
+		Sub:   r.Sub,
+		Scope: r.Scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:      r.JTI,
+			Subject: r.Sub,
+		},
+	}
+	if r.Exp > 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(time.Unix(r.Exp, 0))
+	}
+	if r.Iat > 0 {
+		claims.IssuedAt = jwt.NewNumericDate(time.Unix(r.Iat, 0))
+	}
+	return claims
+}
+
+// Introspector asks the auth service what a token grants. An error means
+// the token could not be checked; an inactive response means it must be
+// refused.
+type Introspector interface {
+	Introspect(ctx context.Context, token string) (*IntrospectionResponse, error)
+}
+
+// cachedIntrospection is an answer and when it stops being reused.
+type cachedIntrospection struct {
+	response *IntrospectionResponse
+	expires  time.Time
+}
+
+// RemoteIntrospector introspects tokens with the auth service and caches
+// each answer for cacheTTL, so services validate JWTs and service keys
+// without the JWT secret, and see revoked sessions and keys.
+type RemoteIntrospector struct {
+	introspectURL string
+	client        *http.Client
+	cacheTTL      time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedIntrospection
+}
+
+// NewRemoteIntrospector creates an introspector for the auth service at
+// authURL (e.g. "http://auth:8040"). A cacheTTL of 0 uses one minute.
+func NewRemoteIntrospector(authURL string, cacheTTL time.Duration) *RemoteIntrospector {
+	if cacheTTL <= 0 {
+		cacheTTL = defaultIntrospectionCacheTTL
+	}
+	return &RemoteIntrospector{
+		introspectURL: strings.TrimRight(authURL, "/") + IntrospectPath,
+		client:        &http.Client{Timeout: introspectTimeout},
+		cacheTTL:      cacheTTL,
+		cache:         make(map[string]cachedIntrospection),
+	}
+}
+
+// Introspect returns the auth service's answer for token, from the cache
+// when possible.
+func (i *RemoteIntrospector) Introspect(ctx context.Context, token string) (*IntrospectionResponse, error) {
+	digest := sha256.Sum256([]byte(token))
+	cacheKey := hex.EncodeToString(digest[:])
+
+	now := time.Now()
+	i.mu.Lock()
+	cached, ok := i.cache[cacheKey]
+	i.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.response, nil
+	}
+
+	response, err := i.fetch(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := now.Add(i.cacheTTL)
+	if response.Active && response.Exp > 0 && time.Unix(response.Exp, 0).Before(expires) {
+		expires = time.Unix(response.Exp, 0)
+	}
+	i.mu.Lock()
+	if len(i.cache) >= maxCachedIntrospections {
+		clear(i.cache)
+	}
+	i.cache[cacheKey] = cachedIntrospection{response: response, expires: expires}
+	i.mu.Unlock()
+
+	return response, nil
+}
+
+// fetch asks the auth service about token.
+func (i *RemoteIntrospector) fetch(ctx context.Context, token string) (*IntrospectionResponse, error) {
+	form := url.Values{"token": {token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.introspectURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspect token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspect token: auth service returned status %d", resp.StatusCode)
+	}
+
+	var result IntrospectionResponse
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&result); decodeErr != nil {
+		return nil, fmt.Errorf("decode introspection response: %w", decodeErr)
+	}
+	return &result, nil
+}
@@ -0,0 +1,124 @@
+package jwt_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+// newIntrospectionServer answers for the "viewer" token (read scope) and
+// the "api-key" token (search API), reports every other token inactive,
+// fails for "broken", and counts calls.
+func newIntrospectionServer(t *testing.T) (server *httptest.Server, calls *int) {
+	t.Helper()
+
+	var count int
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if r.URL.Path != jwt.IntrospectPath || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		exp := time.Now().Add(time.Hour).Unix()
+		switch r.PostFormValue("token") {
+		case "viewer":
+			_ = json.NewEncoder(w).Encode(jwt.IntrospectionResponse{
+				Active: true, Sub: "ana@example.com", Scope: jwt.ScopeRead, Exp: exp, JTI: "0123456789abcdef",
+			})
+		case "api-key":
+			_ = json.NewEncoder(w).Encode(jwt.IntrospectionResponse{Active: true, Sub: "partner", Scope: jwt.ScopeSearchAPI, Exp: exp})
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_ = json.NewEncoder(w).Encode(jwt.IntrospectionResponse{Active: false})
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &count
+}
+
+func TestRemoteIntrospector(t *testing.T) {
+	t.Helper()
+
+	server, calls := newIntrospectionServer(t)
+	introspector := jwt.NewRemoteIntrospector(server.URL+"/", time.Minute)
+	ctx := context.Background()
+
+	for range 2 {
+		response, err := introspector.Introspect(ctx, "viewer")
+		if err != nil {
+			t.Fatalf("Introspect() error = %v", err)
+		}
+		claims := response.Claims()
+		if !response.Active || claims.Sub != "ana@example.com" || claims.Scope != jwt.ScopeRead || claims.ID != "0123456789abcdef" {
+			t.Errorf("Introspect() = %+v, claims %+v", response, claims)
+		}
+	}
+	for range 2 {
+		response, err := introspector.Introspect(ctx, "revoked")
+		if err != nil || response.Active {
+			t.Errorf("Introspect(revoked) = %+v, %v, want inactive", response, err)
+		}
+	}
+	if *calls != 2 {
+		t.Errorf("auth calls = %d, want 2 (answers cached)", *calls)
+	}
+
+	// Failures are not cached: the next request tries again
+	for range 2 {
+		if _, err := introspector.Introspect(ctx, "broken"); err == nil {
+			t.Error("Introspect(broken) error = nil, want a failure")
+		}
+	}
+	if *calls != 4 {
+		t.Errorf("auth calls = %d, want 4", *calls)
+	}
+}
+
+func TestMiddleware_Introspection(t *testing.T) {
+	t.Helper()
+
+	server, _ := newIntrospectionServer(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// No secret: every credential is introspected
+	group := router.Group("/api/v1",
+		jwt.Middleware("", jwt.WithIntrospector(jwt.NewRemoteIntrospector(server.URL, 0)), jwt.WithScope("indexes")))
+	group.GET("/indexes", func(c *gin.Context) {
+		claims, _ := jwt.GetClaims(c)
+		c.String(http.StatusOK, claims.Sub)
+	})
+	group.DELETE("/indexes/:name", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	cases := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		want   int
+	}{
+		{"active token", "viewer", http.MethodGet, "/api/v1/indexes", http.StatusOK},
+		{"scope is enforced", "viewer", http.MethodDelete, "/api/v1/indexes/x", http.StatusForbidden},
+		{"inactive token", "revoked", http.MethodGet, "/api/v1/indexes", http.StatusUnauthorized},
+		{"search api key", "api-key", http.MethodGet, "/api/v1/indexes", http.StatusUnauthorized},
+		{"auth unavailable", "broken", http.MethodGet, "/api/v1/indexes", http.StatusServiceUnavailable},
+		{"signed jwt is not trusted locally", signToken(t, ""), http.MethodGet, "/api/v1/indexes", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tc.want, w.Body.String())
+			}
+		})
+	}
+}
@@ -3,61 +3,197 @@ package jwt
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Token scopes. Tokens without a scope predate scoping and keep full access.
+// Explicit scopes (see ValidScope) limit a token to named resources.
+const (
+	// ScopeAdmin grants full access.
+	ScopeAdmin = "admin"
+	// ScopeRead limits a token to requests that do not modify anything.
+	ScopeRead = "read"
+)
+
 // Claims represents JWT claims
 type Claims struct {
-	Sub string `json:"sub"`
+	Sub   string `json:"sub"`
+	Scope string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Middleware creates a JWT authentication middleware
-func Middleware(secret string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Skip auth for health check endpoints
-		if c.Request.URL.Path == "/health" || strings.HasPrefix(c.Request.URL.Path, "/health/") {
-			c.Next()
-			return
-		}
+// HasFullAccess reports whether the token may do anything: it has no scope
+// or includes ScopeAdmin.
+func (c *Claims) HasFullAccess() bool {
+	return c.Scope == "" || slices.Contains(c.Scopes(), ScopeAdmin)
+}
 
-		// Extract token from Authorization header or query parameter
-		// Query parameter is needed for SSE (EventSource) which can't set custom headers
-		tokenString := extractToken(c)
-		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization"})
-			c.Abort()
-			return
-		}
+// MiddlewareOption configures Middleware.
+type MiddlewareOption func(*middlewareOptions)
+
+type middlewareOptions struct {
+	serviceKeys   ServiceKeyVerifier
+	introspector  Introspector
+	resource      string
+	requiredScope string
+	readRoutes    map[string]bool
+}
+
+// WithServiceKeyVerifier sets how service keys are verified, replacing
+// DefaultServiceKeyVerifier. A nil verifier refuses service keys.
+func WithServiceKeyVerifier(verifier ServiceKeyVerifier) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.serviceKeys = verifier
+	}
+}
 
-		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-			// Validate signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("invalid signing method")
-			}
-			return []byte(secret), nil
-		})
-
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			c.Abort()
-			return
+// WithIntrospector validates every credential, JWT or service key, by
+// introspecting it with the auth service instead of checking the signature,
+// so the service needs no JWT secret and refuses revoked sessions.
+func WithIntrospector(introspector Introspector) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.introspector = introspector
+	}
+}
+
+// WithReadRoutes declares routes that only read although their method is not
+// GET, HEAD or OPTIONS, as gin full paths (e.g. "/api/v1/indexes/lint"). A
+// read token may use them, and WithScope requires read access for them.
+func WithReadRoutes(readRoutes ...string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		if o.readRoutes == nil {
+			o.readRoutes = make(map[string]bool, len(readRoutes))
+		}
+		for _, route := range readRoutes {
+			o.readRoutes[route] = true
 		}
+	}
+}
+
+// Middleware creates a JWT authentication middleware. Service keys (see
+// ServiceKeyPrefix) are accepted alongside JWTs, as a bearer token or in the
+// X-API-Key header, and verified with DefaultServiceKeyVerifier.
+//
+// Scopes fail closed. With WithScope or WithRequiredScope, a token without
+// full access needs the scope they declare. Without them, a read token may
+// only GET, HEAD, OPTIONS and WithReadRoutes, and a token with only explicit
+// scopes is refused with 403.
+func Middleware(secret string, opts ...MiddlewareOption) gin.HandlerFunc {
+	options := middlewareOptions{serviceKeys: DefaultServiceKeyVerifier()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return (&authenticator{secret: secret, options: options}).handle
+}
+
+// authenticator is the handler built by Middleware.
+type authenticator struct {
+	secret  string
+	options middlewareOptions
+}
+
+func (a *authenticator) handle(c *gin.Context) {
+	// Skip auth for health check endpoints
+	if c.Request.URL.Path == "/health" || strings.HasPrefix(c.Request.URL.Path, "/health/") {
+		c.Next()
+		return
+	}
 
-		if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-			// Store claims in context for use in handlers
-			c.Set("claims", claims)
-			c.Next()
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			c.Abort()
-			return
+	// Extract token from Authorization header or query parameter
+	// Query parameter is needed for SSE (EventSource) which can't set custom headers
+	tokenString := extractToken(c)
+	if tokenString == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization"})
+		c.Abort()
+		return
+	}
+
+	var claims *Claims
+	switch {
+	case a.options.introspector != nil:
+		claims = authenticateIntrospected(c, a.options.introspector, tokenString)
+	case IsServiceKey(tokenString):
+		claims = authenticateServiceKey(c, a.options.serviceKeys, tokenString)
+	default:
+		claims = a.authenticateJWT(c, tokenString)
+	}
+	if claims == nil {
+		return
+	}
+
+	if !claims.HasFullAccess() && !a.allowScope(c, claims) {
+		return
+	}
+
+	// Store claims in context for use in handlers
+	c.Set("claims", claims)
+	c.Next()
+}
+
+// authenticateJWT validates a signed JWT and returns its claims, or aborts
+// and returns nil.
+func (a *authenticator) authenticateJWT(c *gin.Context, tokenString string) *Claims {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
+		// Validate signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
 		}
+		return []byte(a.secret), nil
+	})
+
+	// API keys share the signing secret but only authenticate search
+	if claims, ok := token.Claims.(*Claims); err == nil && ok && token.Valid && claims.Scope != ScopeSearchAPI {
+		return claims
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+	c.Abort()
+	return nil
+}
+
+// authenticateServiceKey verifies a service key and returns its claims, or
+// aborts and returns nil. An unverifiable key is a 503, not a 401, so an
+// auth outage is not mistaken for a revoked key.
+func authenticateServiceKey(c *gin.Context, verifier ServiceKeyVerifier, key string) *Claims {
+	if verifier == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		c.Abort()
+		return nil
+	}
+
+	claims, err := verifier.VerifyServiceKey(c.Request.Context(), key)
+	if errors.Is(err, ErrInvalidServiceKey) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		c.Abort()
+		return nil
+	}
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "service key verification unavailable"})
+		c.Abort()
+		return nil
 	}
+	return claims
+}
+
+// authenticateIntrospected returns the claims of a token the auth service
+// reports active, or aborts and returns nil. Like service keys, a token that
+// cannot be checked is a 503, and search API keys are refused.
+func authenticateIntrospected(c *gin.Context, introspector Introspector, token string) *Claims {
+	response, err := introspector.Introspect(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "token introspection unavailable"})
+		c.Abort()
+		return nil
+	}
+	if !response.Active || response.Scope == ScopeSearchAPI {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		c.Abort()
+		return nil
+	}
+	return response.Claims()
 }
 
 // GetClaims extracts claims from the gin context
@@ -84,6 +220,11 @@ func extractToken(c *gin.Context) string {
 		return ""
 	}
 
+	// Service keys may also come in their own header
+	if key := c.GetHeader(ServiceKeyHeader); key != "" {
+		return key
+	}
+
 	// Fallback to query parameter for SSE endpoints (EventSource can't set headers)
 	return c.Query("token")
 }
@@ -0,0 +1,157 @@
+package jwt_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+const testSecret = "test-secret-key-32-chars-minimum"
+
+func signToken(t *testing.T, scope string) string {
+	t.Helper()
+
+	claims := &jwt.Claims{
+		Sub:   "dashboard",
+		Scope: scope,
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ExpiresAt: gojwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	signed, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func newGuardedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/api/v1", jwt.Middleware(testSecret, jwt.WithReadRoutes("/api/v1/indexes/lint")))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	group.GET("/indexes", ok)
+	group.DELETE("/indexes/:name", ok)
+	group.POST("/indexes/lint", ok)
+	return router
+}
+
+func TestMiddleware_ReadTokenIsReadOnly(t *testing.T) {
+	t.Helper()
+
+	router := newGuardedRouter()
+	cases := []struct {
+		name   string
+		scope  string
+		method string
+		path   string
+		want   int
+	}{
+		{"read token can list", jwt.ScopeRead, http.MethodGet, "/api/v1/indexes", http.StatusOK},
+		{"read token cannot delete", jwt.ScopeRead, http.MethodDelete, "/api/v1/indexes/x", http.StatusForbidden},
+		{"read token can use allowlisted POST", jwt.ScopeRead, http.MethodPost, "/api/v1/indexes/lint", http.StatusOK},
+		{"unscoped token keeps full access", "", http.MethodDelete, "/api/v1/indexes/x", http.StatusOK},
+		{"admin token can delete", jwt.ScopeAdmin, http.MethodDelete, "/api/v1/indexes/x", http.StatusOK},
+		{"unknown scope is not full access", "reporting", http.MethodDelete, "/api/v1/indexes/x", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+signToken(t, tc.scope))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tc.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewServiceToken_AcceptedByMiddleware(t *testing.T) {
+	token, err := jwt.NewServiceToken(testSecret, "publisher", jwt.ScopeRead, time.Minute)
+	if err != nil {
+		t.Fatalf("new service token: %v", err)
+	}
+
+	router := newGuardedRouter()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/indexes", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+
+	if _, noSecretErr := jwt.NewServiceToken("", "publisher", jwt.ScopeRead, time.Minute); noSecretErr == nil {
+		t.Error("expected error without secret")
+	}
+}
+
+// scopedServiceKeys grants testServiceKey the scope it holds.
+type scopedServiceKeys string
+
+func (s scopedServiceKeys) VerifyServiceKey(_ context.Context, key string) (*jwt.Claims, error) {
+	if key != testServiceKey {
+		return nil, jwt.ErrInvalidServiceKey
+	}
+	return &jwt.Claims{Sub: "grafana", Scope: string(s)}, nil
+}
+
+func TestMiddleware_ScopedTokensFailClosed(t *testing.T) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	keys := jwt.WithServiceKeyVerifier(scopedServiceKeys("crawler:jobs:read"))
+	// A service that declares no scope, like source-manager
+	v1 := router.Group("/api/v1", jwt.Middleware(testSecret, keys))
+	v1.DELETE("/sources/:id", ok)
+	v1.PUT("/sources/:id/credentials/:kind", ok)
+	v1.GET("/sources", ok)
+	jobs := router.Group("/api/v1", jwt.Middleware(testSecret, keys, jwt.WithScope("crawler:jobs")))
+	jobs.GET("/jobs", ok)
+	jobs.DELETE("/jobs/:id", ok)
+	// Wrapping the middleware in a closure does not change what it allows
+	unscoped := jwt.Middleware(testSecret, keys)
+	scoped := jwt.Middleware(testSecret, keys, jwt.WithScope("crawler:jobs"))
+	router.DELETE("/api/v2/sources/:id", func(c *gin.Context) { unscoped(c) }, ok)
+	router.GET("/api/v2/jobs", func(c *gin.Context) { scoped(c) }, ok)
+	router.DELETE("/api/v2/jobs/:id", func(c *gin.Context) { scoped(c) }, ok)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{"unguarded write route", http.MethodDelete, "/api/v1/sources/x", http.StatusForbidden},
+		{"unguarded credential write", http.MethodPut, "/api/v1/sources/x/credentials/basic", http.StatusForbidden},
+		{"unguarded read route", http.MethodGet, "/api/v1/sources", http.StatusForbidden},
+		{"scoped route within scope", http.MethodGet, "/api/v1/jobs", http.StatusOK},
+		{"scoped route beyond scope", http.MethodDelete, "/api/v1/jobs/x", http.StatusForbidden},
+		{"wrapped unscoped route", http.MethodDelete, "/api/v2/sources/x", http.StatusForbidden},
+		{"wrapped scoped route within scope", http.MethodGet, "/api/v2/jobs", http.StatusOK},
+		{"wrapped scoped route beyond scope", http.MethodDelete, "/api/v2/jobs/x", http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, http.NoBody)
+			req.Header.Set(jwt.ServiceKeyHeader, testServiceKey)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tc.want, w.Body.String())
+			}
+		})
+	}
+}
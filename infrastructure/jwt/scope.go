@@ -0,0 +1,151 @@
+package jwt
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Explicit scopes grant one access level on one resource: "<resource>:read"
+// or "<resource>:write", where the resource is one or more colon-separated
+// names such as "crawler:jobs" or "indexes". A token's scope claim holds one
+// or more scopes separated by spaces, as in OAuth 2.0.
+const (
+	// AccessRead is the access level of scopes that only read.
+	AccessRead = "read"
+	// AccessWrite is the access level of scopes that modify; it includes read.
+	AccessWrite = "write"
+)
+
+// explicitScopePattern matches "<resource>:read" and "<resource>:write".
+var explicitScopePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*(:[a-z][a-z0-9-]*)*:(read|write)$`)
+
+// ValidScope reports whether scope is ScopeAdmin, ScopeRead, or an explicit
+// scope such as "crawler:jobs:write".
+func ValidScope(scope string) bool {
+	return scope == ScopeAdmin || scope == ScopeRead || explicitScopePattern.MatchString(scope)
+}
+
+// ParseScopes splits a space-separated scope claim.
+func ParseScopes(scope string) []string {
+	return strings.Fields(scope)
+}
+
+// JoinScopes builds a scope claim from scopes, sorted and without duplicates.
+func JoinScopes(scopes []string) string {
+	sorted := slices.Clone(scopes)
+	slices.Sort(sorted)
+	return strings.Join(slices.Compact(sorted), " ")
+}
+
+// Scopes returns the scopes the token carries.
+func (c *Claims) Scopes() []string {
+	return ParseScopes(c.Scope)
+}
+
+// HasScope reports whether the token grants the explicit scope required,
+// e.g. "crawler:jobs:write". Full access grants every scope. A write scope
+// grants read on the same resource, a scope on a resource grants it on the
+// resource's children ("crawler:read" grants "crawler:jobs:read"), and
+// ScopeRead grants every read scope.
+func (c *Claims) HasScope(required string) bool {
+	if c.HasFullAccess() {
+		return true
+	}
+	resource, access, ok := splitScope(required)
+	if !ok {
+		return false
+	}
+
+	for _, granted := range c.Scopes() {
+		if granted == ScopeRead {
+			if access == AccessRead {
+				return true
+			}
+			continue
+		}
+		grantedResource, grantedAccess, grantedOK := splitScope(granted)
+		if !grantedOK || (grantedAccess == AccessRead && access == AccessWrite) {
+			continue
+		}
+		if resource == grantedResource || strings.HasPrefix(resource, grantedResource+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitScope splits an explicit scope into its resource and access level.
+func splitScope(scope string) (resource, access string, ok bool) {
+	if !explicitScopePattern.MatchString(scope) {
+		return "", "", false
+	}
+	i := strings.LastIndex(scope, ":")
+	return scope[:i], scope[i+1:], true
+}
+
+// WithScope makes Middleware require "<resource>:read" for GET, HEAD and
+// OPTIONS requests and WithReadRoutes, and "<resource>:write" for everything
+// else, from tokens without full access. Declaring a scope opens the routes
+// to tokens with explicit scopes.
+func WithScope(resource string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.resource = resource
+	}
+}
+
+// WithRequiredScope makes Middleware require the explicit scope required,
+// e.g. "sources:credentials:read", from tokens without full access on every
+// route. It takes precedence over WithScope.
+func WithRequiredScope(required string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.requiredScope = required
+	}
+}
+
+// allowScope reports whether a token without full access may go on to the
+// route, rejecting the request with 403 when it may not. Routes that declare
+// a scope require it. Elsewhere a read token may only read, and explicit
+// scopes are refused.
+func (a *authenticator) allowScope(c *gin.Context, claims *Claims) bool {
+	reads := isSafeMethod(c.Request.Method) || a.options.readRoutes[c.FullPath()]
+
+	required := a.options.requiredScope
+	if required == "" && a.options.resource != "" {
+		required = a.options.resource + ":" + AccessWrite
+		if reads {
+			required = a.options.resource + ":" + AccessRead
+		}
+	}
+	if required != "" {
+		if claims.HasScope(required) {
+			return true
+		}
+		rejectScope(c, required)
+		return false
+	}
+
+	if reads && slices.Contains(claims.Scopes(), ScopeRead) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "token scope does not allow this operation"})
+	c.Abort()
+	return false
+}
+
+// isSafeMethod reports whether method only reads.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// rejectScope aborts with 403, naming the missing scope.
+func rejectScope(c *gin.Context, required string) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":          "token scope does not allow this operation",
+		"required_scope": required,
+	})
+	c.Abort()
+}
@@ -0,0 +1,106 @@
+package jwt_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+func TestValidScope(t *testing.T) {
+	t.Helper()
+
+	valid := []string{jwt.ScopeAdmin, jwt.ScopeRead, "indexes:read", "crawler:jobs:write", "source-manager:sources:read"}
+	for _, scope := range valid {
+		if !jwt.ValidScope(scope) {
+			t.Errorf("ValidScope(%q) = false, want true", scope)
+		}
+	}
+	invalid := []string{"", "write", "indexes", "indexes:delete", "Crawler:jobs:read", ":read", "crawler::read", "indexes:read extra"}
+	for _, scope := range invalid {
+		if jwt.ValidScope(scope) {
+			t.Errorf("ValidScope(%q) = true, want false", scope)
+		}
+	}
+}
+
+func TestClaims_HasScope(t *testing.T) {
+	t.Helper()
+
+	cases := []struct {
+		name     string
+		scope    string
+		required string
+		want     bool
+	}{
+		{"unscoped token has every scope", "", "crawler:jobs:write", true},
+		{"admin among scopes has every scope", "indexes:read admin", "crawler:jobs:write", true},
+		{"exact scope", "crawler:jobs:write", "crawler:jobs:write", true},
+		{"write includes read", "crawler:jobs:write", "crawler:jobs:read", true},
+		{"read excludes write", "crawler:jobs:read", "crawler:jobs:write", false},
+		{"parent resource covers children", "crawler:read", "crawler:jobs:read", true},
+		{"child does not cover parent", "crawler:jobs:write", "crawler:write", false},
+		{"name prefix is not a parent", "crawler:jobs:write", "crawler:jobs-archive:write", false},
+		{"read token has every read scope", jwt.ScopeRead, "indexes:read", true},
+		{"read token has no write scope", jwt.ScopeRead, "indexes:write", false},
+		{"one of several scopes", "indexes:read crawler:jobs:write", "crawler:jobs:write", true},
+		{"other resource", "indexes:write", "crawler:jobs:read", false},
+	}
+	for _, tc := range cases {
+		claims := &jwt.Claims{Scope: tc.scope}
+		if got := claims.HasScope(tc.required); got != tc.want {
+			t.Errorf("%s: HasScope(%q) with %q = %v, want %v", tc.name, tc.required, tc.scope, got, tc.want)
+		}
+	}
+}
+
+func TestJoinScopes(t *testing.T) {
+	t.Helper()
+
+	got := jwt.JoinScopes([]string{"indexes:read", "crawler:jobs:write", "indexes:read"})
+	if got != "crawler:jobs:write indexes:read" {
+		t.Errorf("JoinScopes() = %q, want sorted and deduplicated", got)
+	}
+}
+
+func TestMiddleware_WithScope(t *testing.T) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/api/v1", jwt.Middleware(testSecret, jwt.WithScope("indexes"), jwt.WithReadRoutes("/api/v1/indexes/lint")))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	group.GET("/indexes", ok)
+	group.DELETE("/indexes/:name", ok)
+	group.POST("/indexes/lint", ok)
+	router.GET("/api/v1/jobs", jwt.Middleware(testSecret, jwt.WithRequiredScope("crawler:jobs:read")), ok)
+
+	cases := []struct {
+		name   string
+		scope  string
+		method string
+		path   string
+		want   int
+	}{
+		{"indexes:read can list", "indexes:read", http.MethodGet, "/api/v1/indexes", http.StatusOK},
+		{"indexes:read cannot delete", "indexes:read", http.MethodDelete, "/api/v1/indexes/x", http.StatusForbidden},
+		{"indexes:read can use allowlisted POST", "indexes:read", http.MethodPost, "/api/v1/indexes/lint", http.StatusOK},
+		{"indexes:write can delete", "indexes:write", http.MethodDelete, "/api/v1/indexes/x", http.StatusOK},
+		{"other resource cannot list", "crawler:jobs:write", http.MethodGet, "/api/v1/indexes", http.StatusForbidden},
+		{"read token can list", jwt.ScopeRead, http.MethodGet, "/api/v1/indexes", http.StatusOK},
+		{"unscoped token can delete", "", http.MethodDelete, "/api/v1/indexes/x", http.StatusOK},
+		{"required scope passes", "crawler:jobs:write", http.MethodGet, "/api/v1/jobs", http.StatusOK},
+		{"required scope rejects", "indexes:write", http.MethodGet, "/api/v1/jobs", http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, http.NoBody)
+		req.Header.Set("Authorization", "Bearer "+signToken(t, tc.scope))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, tc.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ServiceKeyPrefix starts every service key, which tells Middleware a
+// credential is a key to verify with the auth service rather than a JWT.
+const ServiceKeyPrefix = "nck_"
+
+// ServiceKeyHeader carries a service key for clients that do not send it as
+// a bearer token.
+const ServiceKeyHeader = "X-API-Key"
+
+// ServiceKeyVerifyPath is the auth service endpoint that verifies service keys.
+const ServiceKeyVerifyPath = "/api/v1/auth/service-keys/verify"
+
+const (
+	// defaultServiceKeyCacheTTL is how long a verification is reused, and so
+	// how long a revoked key may keep working in other services.
+	defaultServiceKeyCacheTTL = time.Minute
+	// serviceKeyVerifyTimeout bounds a call to the auth service.
+	serviceKeyVerifyTimeout = 5 * time.Second
+	// maxCachedServiceKeys bounds the verification cache; it is cleared when full.
+	maxCachedServiceKeys = 1024
+)
+
+// ErrInvalidServiceKey is returned for a service key that is unknown,
+// revoked, or expired.
+var ErrInvalidServiceKey = errors.New("invalid service key")
+
+// ServiceKeyVerifier resolves a service key to the claims it grants. It
+// returns ErrInvalidServiceKey when the key must be refused, and any other
+// error when the key could not be checked.
+type ServiceKeyVerifier interface {
+	VerifyServiceKey(ctx context.Context, key string) (*Claims, error)
+}
+
+// IsServiceKey reports whether credential is a service key.
+func IsServiceKey(credential string) bool {
+	return strings.HasPrefix(credential, ServiceKeyPrefix)
+}
+
+// ServiceKeyResponse is the auth service's answer for a valid service key.
+type ServiceKeyResponse struct {
+	KeyID     string    `json:"key_id"`
+	Name      string    `json:"name"`
+	Scope     string    `json:"scope"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Claims returns the claims a request authenticated with the key carries:
+// the key name as subject and the key ID as jti.
+func (r *ServiceKeyResponse) Claims() *Claims {
+	return &Claims{
+		Sub:   r.Name,
+		Scope: r.Scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        r.KeyID,
+			Subject:   r.Name,
+			ExpiresAt: jwt.NewNumericDate(r.ExpiresAt),
+		},
+	}
+}
+
+// cachedServiceKey is a verification result; nil claims mark an invalid key.
+type cachedServiceKey struct {
+	claims  *Claims
+	expires time.Time
+}
+
+// RemoteServiceKeyVerifier verifies service keys with the auth service and
+// caches each result for cacheTTL, so services do not call auth on every
+// request and never need the keys themselves.
+type RemoteServiceKeyVerifier struct {
+	verifyURL string
+	client    *http.Client
+	cacheTTL  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedServiceKey
+}
+
+// NewRemoteServiceKeyVerifier creates a verifier for the auth service at
+// authURL (e.g. "http://auth:8040"). A cacheTTL of 0 uses one minute.
+func NewRemoteServiceKeyVerifier(authURL string, cacheTTL time.Duration) *RemoteServiceKeyVerifier {
+	if cacheTTL <= 0 {
+		cacheTTL = defaultServiceKeyCacheTTL
+	}
+	return &RemoteServiceKeyVerifier{
+		verifyURL: strings.TrimRight(authURL, "/") + ServiceKeyVerifyPath,
+		client:    &http.Client{Timeout: serviceKeyVerifyTimeout},
+		cacheTTL:  cacheTTL,
+		cache:     make(map[string]cachedServiceKey),
+	}
+}
+
+// VerifyServiceKey returns the claims of key, from the cache when possible.
+func (v *RemoteServiceKeyVerifier) VerifyServiceKey(ctx context.Context, key string) (*Claims, error) {
+	digest := sha256.Sum256([]byte(key))
+	cacheKey := hex.EncodeToString(digest[:])
+
+	now := time.Now()
+	v.mu.Lock()
+	cached, ok := v.cache[cacheKey]
+	v.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		if cached.claims == nil {
+			return nil, ErrInvalidServiceKey
+		}
+		return cached.claims, nil
+	}
+
+	claims, err := v.fetch(ctx, key)
+	if err != nil && !errors.Is(err, ErrInvalidServiceKey) {
+		return nil, err
+	}
+
+	expires := now.Add(v.cacheTTL)
+	if claims != nil && claims.ExpiresAt != nil && claims.ExpiresAt.Before(expires) {
+		expires = claims.ExpiresAt.Time
+	}
+	v.mu.Lock()
+	if len(v.cache) >= maxCachedServiceKeys {
+		clear(v.cache)
+	}
+	v.cache[cacheKey] = cachedServiceKey{claims: claims, expires: expires}
+	v.mu.Unlock()
+
+	return claims, err
+}
+
+// fetch asks the auth service about key.
+func (v *RemoteServiceKeyVerifier) fetch(ctx context.Context, key string) (*Claims, error) {
+	body, err := json.Marshal(map[string]string{"key": key})
+	if err != nil {
+		return nil, fmt.Errorf("encode service key request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create service key request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("verify service key: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrInvalidServiceKey
+	default:
+		return nil, fmt.Errorf("verify service key: auth service returned status %d", resp.StatusCode)
+	}
+
+	var result ServiceKeyResponse
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&result); decodeErr != nil {
+		return nil, fmt.Errorf("decode service key response: %w", decodeErr)
+	}
+	return result.Claims(), nil
+}
+
+// defaultServiceKeys holds the verifier Middleware uses unless told otherwise.
+var defaultServiceKeys struct {
+	once     sync.Once
+	verifier ServiceKeyVerifier
+}
+
+// DefaultServiceKeyVerifier returns a process-wide RemoteServiceKeyVerifier
+// for the auth service at AUTH_URL, or nil when AUTH_URL is not set, in
+// which case service keys are refused.
+func DefaultServiceKeyVerifier() ServiceKeyVerifier {
+	defaultServiceKeys.once.Do(func() {
+		if authURL := os.Getenv("AUTH_URL"); authURL != "" {
+			defaultServiceKeys.verifier = NewRemoteServiceKeyVerifier(authURL, 0)
+		}
+	})
+	return defaultServiceKeys.verifier
+}
@@ -0,0 +1,150 @@
+package jwt_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+)
+
+const (
+	testServiceKey    = jwt.ServiceKeyPrefix + "0123456789abcdef_secret"
+	unknownServiceKey = jwt.ServiceKeyPrefix + "fedcba9876543210_secret"
+)
+
+// fakeServiceKeys grants read access to testServiceKey and fails with err.
+type fakeServiceKeys struct {
+	err error
+}
+
+func (f *fakeServiceKeys) VerifyServiceKey(_ context.Context, key string) (*jwt.Claims, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if key != testServiceKey {
+		return nil, jwt.ErrInvalidServiceKey
+	}
+	return &jwt.Claims{Sub: "grafana", Scope: jwt.ScopeRead}, nil
+}
+
+func newServiceKeyRouter(verifier jwt.ServiceKeyVerifier) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/api/v1", jwt.Middleware(testSecret, jwt.WithServiceKeyVerifier(verifier)))
+	group.GET("/indexes", func(c *gin.Context) {
+		claims, _ := jwt.GetClaims(c)
+		c.String(http.StatusOK, claims.Sub)
+	})
+	group.DELETE("/indexes/:name", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func TestMiddleware_ServiceKeys(t *testing.T) {
+	t.Helper()
+
+	cases := []struct {
+		name     string
+		verifier jwt.ServiceKeyVerifier
+		header   string
+		value    string
+		method   string
+		want     int
+	}{
+		{"bearer key", &fakeServiceKeys{}, "Authorization", "Bearer " + testServiceKey, http.MethodGet, http.StatusOK},
+		{"api key header", &fakeServiceKeys{}, jwt.ServiceKeyHeader, testServiceKey, http.MethodGet, http.StatusOK},
+		{"key scope is enforced", &fakeServiceKeys{}, jwt.ServiceKeyHeader, testServiceKey, http.MethodDelete, http.StatusForbidden},
+		{"unknown key", &fakeServiceKeys{}, jwt.ServiceKeyHeader, unknownServiceKey, http.MethodGet, http.StatusUnauthorized},
+		{"auth unavailable", &fakeServiceKeys{err: errors.New("connection refused")}, jwt.ServiceKeyHeader, testServiceKey, http.MethodGet, http.StatusServiceUnavailable},
+		{"no verifier", nil, jwt.ServiceKeyHeader, testServiceKey, http.MethodGet, http.StatusUnauthorized},
+		{"jwt in api key header", &fakeServiceKeys{}, jwt.ServiceKeyHeader, signToken(t, ""), http.MethodGet, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/api/v1/indexes", http.NoBody)
+			if tc.method == http.MethodDelete {
+				req = httptest.NewRequest(tc.method, "/api/v1/indexes/x", http.NoBody)
+			}
+			req.Header.Set(tc.header, tc.value)
+			w := httptest.NewRecorder()
+			newServiceKeyRouter(tc.verifier).ServeHTTP(w, req)
+
+			if w.Code != tc.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tc.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRemoteServiceKeyVerifier(t *testing.T) {
+	t.Helper()
+
+	calls := 0
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != jwt.ServiceKeyVerifyPath || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Key string `json:"key"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body.Key {
+		case testServiceKey:
+			_ = json.NewEncoder(w).Encode(jwt.ServiceKeyResponse{
+				KeyID: "0123456789abcdef", Name: "grafana", Scope: jwt.ScopeRead, ExpiresAt: expiresAt,
+			})
+		case unknownServiceKey:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	verifier := jwt.NewRemoteServiceKeyVerifier(server.URL+"/", time.Minute)
+	ctx := context.Background()
+
+	for range 2 {
+		claims, err := verifier.VerifyServiceKey(ctx, testServiceKey)
+		if err != nil {
+			t.Fatalf("VerifyServiceKey() error = %v", err)
+		}
+		if claims.Sub != "grafana" || claims.Scope != jwt.ScopeRead || claims.ID != "0123456789abcdef" {
+			t.Errorf("claims = %+v", claims)
+		}
+		if !claims.ExpiresAt.Equal(expiresAt) {
+			t.Errorf("expires_at = %v, want %v", claims.ExpiresAt, expiresAt)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("auth calls = %d, want 1 (cached)", calls)
+	}
+
+	for range 2 {
+		if _, err := verifier.VerifyServiceKey(ctx, unknownServiceKey); !errors.Is(err, jwt.ErrInvalidServiceKey) {
+			t.Errorf("unknown key error = %v, want ErrInvalidServiceKey", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("auth calls = %d, want 2 (refusal cached)", calls)
+	}
+
+	// Failures are not cached: the next request tries again
+	for range 2 {
+		_, err := verifier.VerifyServiceKey(ctx, jwt.ServiceKeyPrefix+"broken")
+		if err == nil || errors.Is(err, jwt.ErrInvalidServiceKey) {
+			t.Errorf("server error = %v, want a verification failure", err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("auth calls = %d, want 4", calls)
+	}
+}
@@ -0,0 +1,39 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoSecret is returned when a token is requested without a signing secret.
+var ErrNoSecret = errors.New("jwt secret not configured")
+
+// NewServiceToken signs a short-lived HS256 token for service-to-service calls.
+// subject identifies the calling service; scope limits what the token may do
+// (ScopeRead for callers that only read).
+func NewServiceToken(secret, subject, scope string, ttl time.Duration) (string, error) {
+	if secret == "" {
+		return "", ErrNoSecret
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		Sub:   subject,
+		Scope: scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("sign service token: %w", err)
+	}
+	return signed, nil
+}
@@ -9,6 +9,8 @@ import (
 
 type ctxKey struct{}
 
+type requestIDKey struct{}
+
 // WithContext returns a new context with the given logger stored in it.
 func WithContext(ctx context.Context, l Logger) context.Context {
 	return context.WithValue(ctx, ctxKey{}, l)
@@ -27,6 +29,27 @@ func FromContext(ctx context.Context) Logger {
 	return fallbackLogger()
 }
 
+// FromContextOr retrieves the logger from the context, returning def when
+// none is stored. Use it where a request-scoped logger is preferred but a
+// component logger is always available.
+func FromContextOr(ctx context.Context, def Logger) Logger {
+	if l, ok := ctx.Value(ctxKey{}).(Logger); ok {
+		return l
+	}
+	return def
+}
+
+// WithRequestID returns a new context carrying the request correlation ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request correlation ID, or "" if none is set.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
 var (
 	fallbackLog  Logger
 	fallbackOnce sync.Once
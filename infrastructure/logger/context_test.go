@@ -123,3 +123,31 @@ func requireLogger(t *testing.T, l logger.Logger) {
 		t.Fatal("expected non-nil logger")
 	}
 }
+
+func TestFromContextOr_ReturnsDefaultWhenMissing(t *testing.T) {
+	t.Parallel()
+
+	def := logger.NewNop()
+	if got := logger.FromContextOr(context.Background(), def); got != def {
+		t.Errorf("FromContextOr = %v, want default %v", got, def)
+	}
+
+	stored := logger.NewNop()
+	ctx := logger.WithContext(context.Background(), stored)
+	if got := logger.FromContextOr(ctx, def); got != stored {
+		t.Errorf("FromContextOr = %v, want stored logger %v", got, stored)
+	}
+}
+
+func TestWithRequestID_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	if got := logger.RequestIDFromContext(context.Background()); got != "" {
+		t.Errorf("RequestIDFromContext on empty context = %q, want empty", got)
+	}
+
+	ctx := logger.WithRequestID(context.Background(), "abc")
+	if got := logger.RequestIDFromContext(ctx); got != "abc" {
+		t.Errorf("RequestIDFromContext = %q, want abc", got)
+	}
+}
@@ -0,0 +1,151 @@
+// Package secretbox seals secrets at rest with AES-256-GCM. A Keyring holds
+// the configured key and, while a key change is rolled out, the previous one;
+// each sealed value records the ID of the key that sealed it. Sealed values
+// are bound to associated data, so a ciphertext copied onto another row does
+// not open.
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeyLength is the AES-256 key size; keys are configured as 64 hex characters.
+const KeyLength = 32
+
+// keyIDBytes is how many bytes of the key's SHA-256 identify it.
+const keyIDBytes = 8
+
+var (
+	// ErrUnknownKey is returned when opening a value sealed with a key that is
+	// neither the current nor the previous key.
+	ErrUnknownKey = errors.New("secret was sealed with an unknown key")
+	// errTooShort is returned when a sealed value is shorter than its nonce.
+	errTooShort = errors.New("ciphertext too short")
+)
+
+// Key is one AES-256-GCM key.
+type Key struct {
+	aead cipher.AEAD
+	id   string
+}
+
+// NewKey creates a key from KeyLength raw bytes. Its ID is a prefix of the
+// key's SHA-256.
+func NewKey(raw []byte) (*Key, error) {
+	if len(raw) != KeyLength {
+		return nil, fmt.Errorf("key must be %d bytes (%d hex characters), got %d", KeyLength, 2*KeyLength, len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return &Key{aead: aead, id: hex.EncodeToString(sum[:keyIDBytes])}, nil
+}
+
+// ParseKey creates a key from 64 hex characters.
+func ParseKey(keyHex string) (*Key, error) {
+	raw, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+	return NewKey(raw)
+}
+
+// GenerateKey creates a random key, such as a per-value data key in envelope
+// encryption. The caller should clear raw once it is sealed.
+func GenerateKey() (key *Key, raw []byte, err error) {
+	raw = make([]byte, KeyLength)
+	if _, err = io.ReadFull(rand.Reader, raw); err != nil {
+		return nil, nil, fmt.Errorf("generate key: %w", err)
+	}
+	if key, err = NewKey(raw); err != nil {
+		return nil, nil, err
+	}
+	return key, raw, nil
+}
+
+// ID identifies the key without revealing it.
+func (k *Key) ID() string {
+	return k.id
+}
+
+// Seal encrypts plaintext with a random nonce, bound to aad. Returns
+// nonce || ciphertext.
+func (k *Key) Seal(plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return k.aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// Open decrypts what Seal produced for aad.
+func (k *Key) Open(sealed, aad []byte) ([]byte, error) {
+	nonceSize := k.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errTooShort
+	}
+	return k.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], aad)
+}
+
+// Keyring holds the current key, which seals, and optionally the previous
+// key, which only opens.
+type Keyring struct {
+	current  *Key
+	previous *Key
+}
+
+// NewKeyring creates a keyring from hex keys. An empty current key returns a
+// nil keyring, for callers that treat encryption as optional.
+func NewKeyring(currentHex, previousHex string) (*Keyring, error) {
+	if currentHex == "" {
+		return nil, nil //nolint:nilnil // no key configured is not an error
+	}
+	current, err := ParseKey(currentHex)
+	if err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+	ring := &Keyring{current: current}
+	if previousHex != "" {
+		if ring.previous, err = ParseKey(previousHex); err != nil {
+			return nil, fmt.Errorf("previous key: %w", err)
+		}
+	}
+	return ring, nil
+}
+
+// Current returns the key new values are sealed with.
+func (r *Keyring) Current() *Key {
+	return r.current
+}
+
+// Key returns the current or previous key with id, or ErrUnknownKey.
+func (r *Keyring) Key(id string) (*Key, error) {
+	for _, key := range []*Key{r.current, r.previous} {
+		if key != nil && key.id == id {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+}
+
+// Open decrypts a value sealed with the key with keyID.
+func (r *Keyring) Open(keyID string, sealed, aad []byte) ([]byte, error) {
+	key, err := r.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return key.Open(sealed, aad)
+}
@@ -0,0 +1,102 @@
+package secretbox_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/secretbox"
+)
+
+var (
+	testKey    = strings.Repeat("ab", secretbox.KeyLength)
+	testOldKey = strings.Repeat("cd", secretbox.KeyLength)
+)
+
+func TestParseKey_Rejects(t *testing.T) {
+	for name, keyHex := range map[string]string{
+		"too short": "abcd",
+		"not hex":   strings.Repeat("zz", secretbox.KeyLength),
+	} {
+		if _, err := secretbox.ParseKey(keyHex); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestKey_BindsToAssociatedData(t *testing.T) {
+	key, err := secretbox.ParseKey(testKey)
+	if err != nil {
+		t.Fatalf("ParseKey() error = %v", err)
+	}
+
+	sealed, err := key.Seal([]byte("app-password"), []byte("partner-wp"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if strings.Contains(string(sealed), "app-password") {
+		t.Error("sealed value contains the plaintext")
+	}
+	opened, err := key.Open(sealed, []byte("partner-wp"))
+	if err != nil || string(opened) != "app-password" {
+		t.Errorf("Open() = %q, %v, want the plaintext", opened, err)
+	}
+	if _, err = key.Open(sealed, []byte("other-wp")); err == nil {
+		t.Error("a value copied to other associated data must not open")
+	}
+	if _, err = key.Open(sealed[:4], []byte("partner-wp")); err == nil {
+		t.Error("expected an error for a truncated value")
+	}
+}
+
+func TestKeyring_OpensWithPreviousKey(t *testing.T) {
+	old, err := secretbox.NewKeyring(testOldKey, "")
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	sealed, err := old.Current().Seal([]byte("s3cret"), nil)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	rotated, err := secretbox.NewKeyring(testKey, testOldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	opened, err := rotated.Open(old.Current().ID(), sealed, nil)
+	if err != nil || string(opened) != "s3cret" {
+		t.Errorf("Open() = %q, %v, want the value sealed with the previous key", opened, err)
+	}
+
+	unrelated, err := secretbox.NewKeyring(strings.Repeat("ef", secretbox.KeyLength), "")
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	if _, err = unrelated.Open(old.Current().ID(), sealed, nil); !errors.Is(err, secretbox.ErrUnknownKey) {
+		t.Errorf("Open() error = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestNewKeyring_Empty(t *testing.T) {
+	ring, err := secretbox.NewKeyring("", "")
+	if err != nil || ring != nil {
+		t.Errorf("NewKeyring(\"\") = %v, %v, want no keyring and no error", ring, err)
+	}
+	if _, err = secretbox.NewKeyring(testKey, "abcd"); err == nil {
+		t.Error("expected an error for an invalid previous key")
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	first, raw, err := secretbox.GenerateKey()
+	if err != nil || len(raw) != secretbox.KeyLength {
+		t.Fatalf("GenerateKey() = %d bytes, %v", len(raw), err)
+	}
+	second, _, err := secretbox.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	if first.ID() == second.ID() {
+		t.Error("generated keys should differ")
+	}
+}
@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -14,6 +16,10 @@ const (
 	defaultHTTPTimeout = 30 * time.Second
 )
 
+// ErrNotFound is wrapped by errors for a requested index, document or source
+// that the service reports does not exist (HTTP 404).
+var ErrNotFound = errors.New("not found")
+
 // IndexManagerClient is a client for the index-manager API
 type IndexManagerClient struct {
 	baseURL    string
@@ -112,3 +118,47 @@ func (c *IndexManagerClient) ListIndices(ctx context.Context) ([]string, error)
 
 	return indices, nil
 }
+
+// GetIndex returns an index's details (health, document count, size) as
+// index-manager reports them. Returns an error wrapping ErrNotFound when the
+// index does not exist.
+func (c *IndexManagerClient) GetIndex(ctx context.Context, indexName string) (json.RawMessage, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/indexes/%s", c.baseURL, url.PathEscape(indexName))
+	return c.getRaw(ctx, endpoint, "index "+indexName)
+}
+
+// GetDocument returns one document of an index as index-manager reports it.
+// Returns an error wrapping ErrNotFound when the index or document does not exist.
+func (c *IndexManagerClient) GetDocument(ctx context.Context, indexName, documentID string) (json.RawMessage, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/indexes/%s/documents/%s",
+		c.baseURL, url.PathEscape(indexName), url.PathEscape(documentID))
+	return c.getRaw(ctx, endpoint, "document "+indexName+"/"+documentID)
+}
+
+// getRaw GETs endpoint and returns the JSON body; what names the resource in errors.
+func (c *IndexManagerClient) getRaw(ctx context.Context, endpoint, what string) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", what, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -32,6 +34,7 @@ type Source struct {
 	Type      string         `json:"type"`
 	Selectors map[string]any `json:"selectors"`
 	Enabled   bool           `json:"enabled"`
+	Lifecycle string         `json:"lifecycle,omitempty"`
 	FeedURL   *string        `json:"feed_url,omitempty"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -59,7 +62,8 @@ type UpdateSourceRequest struct {
 	IngestionMode           string         `json:"ingestion_mode,omitempty"`
 }
 
-// TestCrawlRequest represents a request to test crawl a source
+// TestCrawlRequest represents a request to test crawl a draft source.
+// Selectors use the source selector shape ({"article": {...}, "list": {...}}).
 type TestCrawlRequest struct {
 	URL       string         `json:"url"`
 	Selectors map[string]any `json:"selectors"`
@@ -67,6 +71,7 @@ type TestCrawlRequest struct {
 
 // TestCrawlResponse represents the response from test crawl
 type TestCrawlResponse struct {
+	URL          string           `json:"url"`
 	Success      bool             `json:"success"`
 	ArticleCount int              `json:"article_count"`
 	SuccessRate  float64          `json:"success_rate"`
@@ -74,6 +79,24 @@ type TestCrawlResponse struct {
 	Articles     []map[string]any `json:"articles"`
 }
 
+// SelectorSuggestion is a proposed selector for one article field
+type SelectorSuggestion struct {
+	Selector   string `json:"selector"`
+	Sample     string `json:"sample"`
+	Source     string `json:"source"`
+	Confidence string `json:"confidence"`
+}
+
+// SuggestSelectorsResponse represents proposed selectors for a sample article
+type SuggestSelectorsResponse struct {
+	URL            string                        `json:"url"`
+	Template       string                        `json:"template,omitempty"`
+	Fields         map[string]SelectorSuggestion `json:"fields"`
+	StructuredData map[string]string             `json:"structured_data"`
+	Selectors      map[string]any                `json:"selectors"`
+	Warnings       []string                      `json:"warnings"`
+}
+
 // CreateSource creates a new source
 //
 //nolint:dupl // Similar HTTP client pattern across different services is acceptable
@@ -130,7 +153,7 @@ func (c *SourceManagerClient) ListSources(ctx context.Context) ([]Source, error)
 	offset := 0
 
 	for {
-		sources, total, err := c.listSourcesPage(ctx, listSourcesPageSize, offset)
+		sources, total, err := c.ListSourcesWithParams(ctx, ListSourcesParams{Limit: listSourcesPageSize, Offset: offset})
 		if err != nil {
 			return nil, err
 		}
@@ -147,11 +170,54 @@ func (c *SourceManagerClient) ListSources(ctx context.Context) ([]Source, error)
 	return allSources, nil
 }
 
-// listSourcesPage fetches a single page of sources from the source-manager API.
+// ListSourcesParams filters and pages a source listing. Zero values leave a
+// filter off; source-manager applies its own default and maximum page size.
+type ListSourcesParams struct {
+	Search    string // terms matched against name and URL
+	Enabled   *bool
+	Lifecycle string // draft, active, paused or archived
+	Type      string
+	Region    string // indigenous region tag
+	Domain    string // host, including its subdomains
+	Limit     int
+	Offset    int
+}
+
+// ListSourcesWithParams fetches one filtered page of sources from the
+// source-manager API, with the total number of matching sources.
 //
 //nolint:dupl // Similar HTTP client pattern across different services is acceptable
-func (c *SourceManagerClient) listSourcesPage(ctx context.Context, limit, offset int) ([]Source, int, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/sources?limit=%d&offset=%d", c.baseURL, limit, offset)
+func (c *SourceManagerClient) ListSourcesWithParams(ctx context.Context, params ListSourcesParams) ([]Source, int, error) {
+	queryParams := url.Values{}
+	if params.Search != "" {
+		queryParams.Add("search", params.Search)
+	}
+	if params.Enabled != nil {
+		queryParams.Add("enabled", strconv.FormatBool(*params.Enabled))
+	}
+	if params.Lifecycle != "" {
+		queryParams.Add("lifecycle", params.Lifecycle)
+	}
+	if params.Type != "" {
+		queryParams.Add("type", params.Type)
+	}
+	if params.Region != "" {
+		queryParams.Add("region", params.Region)
+	}
+	if params.Domain != "" {
+		queryParams.Add("domain", params.Domain)
+	}
+	if params.Limit > 0 {
+		queryParams.Add("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset > 0 {
+		queryParams.Add("offset", strconv.Itoa(params.Offset))
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/sources", c.baseURL)
+	if len(queryParams) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams.Encode())
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
 	if err != nil {
@@ -185,10 +251,24 @@ func (c *SourceManagerClient) listSourcesPage(ctx context.Context, limit, offset
 }
 
 // GetSource gets a source by ID
-//
-//nolint:dupl // Similar HTTP client pattern across different services is acceptable
 func (c *SourceManagerClient) GetSource(ctx context.Context, sourceID string) (*Source, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/sources/%s", c.baseURL, sourceID)
+	body, err := c.GetSourceRaw(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var source Source
+	if err = json.Unmarshal(body, &source); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &source, nil
+}
+
+// GetSourceRaw gets a source by ID as source-manager returns it, with every
+// field. Returns an error wrapping ErrNotFound when there is no such source.
+func (c *SourceManagerClient) GetSourceRaw(ctx context.Context, sourceID string) (json.RawMessage, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/sources/%s", c.baseURL, url.PathEscape(sourceID))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
 	if err != nil {
@@ -206,6 +286,9 @@ func (c *SourceManagerClient) GetSource(ctx context.Context, sourceID string) (*
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("source %s: %w", sourceID, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		var errorResp struct {
 			Error string `json:"error"`
@@ -216,12 +299,7 @@ func (c *SourceManagerClient) GetSource(ctx context.Context, sourceID string) (*
 		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	var source Source
-	if err = json.Unmarshal(body, &source); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	return &source, nil
+	return body, nil
 }
 
 // UpdateSource updates a source
@@ -339,33 +417,69 @@ func (c *SourceManagerClient) EnableFeed(ctx context.Context, sourceID string) e
 	return nil
 }
 
-// TestCrawl tests crawling a source without saving
-//
-//nolint:dupl // Similar HTTP client pattern across different services is acceptable
+// TestCrawl tests a draft source's selectors without saving
 func (c *SourceManagerClient) TestCrawl(ctx context.Context, req TestCrawlRequest) (*TestCrawlResponse, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/sources/test-crawl", c.baseURL)
+	endpoint := fmt.Sprintf("%s/api/v1/sources/test", c.baseURL)
 
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	return c.postTestCrawl(ctx, endpoint, body)
+}
+
+// TestSource tests a saved source's stored selectors against its URL
+func (c *SourceManagerClient) TestSource(ctx context.Context, sourceID string) (*TestCrawlResponse, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/sources/%s/test", c.baseURL, sourceID)
+	return c.postTestCrawl(ctx, endpoint, nil)
+}
+
+// SuggestSelectors proposes selectors from a sample article URL
+func (c *SourceManagerClient) SuggestSelectors(ctx context.Context, articleURL string) (*SuggestSelectorsResponse, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/sources/suggest-selectors", c.baseURL)
+
+	body, err := json.Marshal(map[string]string{"url": articleURL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var suggestResp SuggestSelectorsResponse
+	if err = c.postJSON(ctx, endpoint, body, &suggestResp); err != nil {
+		return nil, err
+	}
+
+	return &suggestResp, nil
+}
+
+// postTestCrawl posts to a source-manager test endpoint and decodes the result
+func (c *SourceManagerClient) postTestCrawl(ctx context.Context, endpoint string, body []byte) (*TestCrawlResponse, error) {
+	var testResp TestCrawlResponse
+	if err := c.postJSON(ctx, endpoint, body, &testResp); err != nil {
+		return nil, err
+	}
+
+	return &testResp, nil
+}
+
+// postJSON posts body to a source-manager endpoint and decodes a 200 response into out
+func (c *SourceManagerClient) postJSON(ctx context.Context, endpoint string, body []byte, out any) error {
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -373,15 +487,14 @@ func (c *SourceManagerClient) TestCrawl(ctx context.Context, req TestCrawlReques
 			Error string `json:"error"`
 		}
 		if jsonErr := json.Unmarshal(respBody, &errorResp); jsonErr == nil && errorResp.Error != "" {
-			return nil, fmt.Errorf("source-manager error: %s", errorResp.Error)
+			return fmt.Errorf("source-manager error: %s", errorResp.Error)
 		}
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(respBody))
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(respBody))
 	}
 
-	var testResp TestCrawlResponse
-	if err = json.Unmarshal(respBody, &testResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err = json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return &testResp, nil
+	return nil
 }
@@ -17,6 +17,7 @@ type AuditEntry struct {
 	ErrorCode   int
 	Timestamp   time.Time
 	ParamKeys   []string
+	Cancelled   bool
 }
 
 // logToolAudit emits a structured INFO log line to stderr for audit purposes.
@@ -42,6 +43,10 @@ func logToolAudit(log logger.Logger, entry AuditEntry) {
 		fields = append(fields, logger.Int("error_code", entry.ErrorCode))
 	}
 
+	if entry.Cancelled {
+		fields = append(fields, logger.Bool("cancelled", true))
+	}
+
 	log.Info("tool_call", fields...)
 }
 
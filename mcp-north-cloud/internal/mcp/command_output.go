@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Partial output of a running command is sent as progress in chunks, at most
+// every outputFlushInterval or once outputFlushBytes of complete lines are waiting.
+const (
+	outputFlushInterval = 500 * time.Millisecond
+	outputFlushBytes    = 4096
+)
+
+// commandOutput collects a command's combined stdout and stderr and, when the
+// tool call reports progress, streams it to the client line by line as it is
+// written. The full output is still returned for the final result.
+type commandOutput struct {
+	mu       sync.Mutex
+	all      bytes.Buffer
+	pending  bytes.Buffer
+	progress *progressReporter
+	stop     chan struct{}
+	stopped  sync.WaitGroup
+}
+
+func newCommandOutput(progress *progressReporter) *commandOutput {
+	o := &commandOutput{progress: progress, stop: make(chan struct{})}
+	if progress != nil {
+		o.stopped.Add(1)
+		go o.flushPeriodically()
+	}
+	return o
+}
+
+// Write implements io.Writer for cmd.Stdout and cmd.Stderr.
+func (o *commandOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.all.Write(p)
+	if o.progress == nil {
+		return len(p), nil
+	}
+	o.pending.Write(p)
+	if o.pending.Len() >= outputFlushBytes {
+		o.flushLocked(false)
+	}
+	return len(p), nil
+}
+
+func (o *commandOutput) flushPeriodically() {
+	defer o.stopped.Done()
+	ticker := time.NewTicker(outputFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-ticker.C:
+			o.mu.Lock()
+			o.flushLocked(false)
+			o.mu.Unlock()
+		}
+	}
+}
+
+// flushLocked sends the complete lines waiting to be streamed, or everything
+// when final. Must be called with o.mu held.
+func (o *commandOutput) flushLocked(final bool) {
+	n := o.pending.Len()
+	if !final {
+		n = bytes.LastIndexByte(o.pending.Bytes(), '\n') + 1
+	}
+	if n == 0 {
+		return
+	}
+	o.progress.Output(string(o.pending.Next(n)))
+}
+
+// close stops streaming, sends any output still waiting and returns the full output.
+func (o *commandOutput) close() string {
+	if o.progress != nil {
+		close(o.stop)
+		o.stopped.Wait()
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.progress != nil {
+		o.flushLocked(true)
+	}
+	return o.all.String()
+}
+
+// runCommand runs cmd like CombinedOutput, streaming its output as progress
+// when the tool call in progress asked for it. The command is killed when the
+// tool call's context is cancelled.
+func runCommand(cmd *exec.Cmd, progress *progressReporter) (string, error) {
+	output := newCommandOutput(progress)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	progress.Output("$ " + strings.Join(cmd.Args, " ") + "\n")
+	err := cmd.Run()
+	return output.close(), err
+}
@@ -64,7 +64,10 @@ func (s *Server) executeOnboardWorkflow(ctx context.Context, id any, args onboar
 	result := map[string]any{}
 	stepsCompleted := make([]string, 0, maxOnboardSteps)
 
+	progress := progressFrom(ctx)
+
 	// Step 1: Create the source
+	progress.Step(0, maxOnboardSteps, "Creating source "+args.Name)
 	source, err := s.sourceClient.CreateSource(ctx, client.CreateSourceRequest{
 		Name:      args.Name,
 		URL:       args.URL,
@@ -80,6 +83,7 @@ func (s *Server) executeOnboardWorkflow(ctx context.Context, id any, args onboar
 	stepsCompleted = append(stepsCompleted, "source_created")
 
 	// Step 2: Start or schedule crawl
+	progress.Step(1, maxOnboardSteps, "Creating crawl job for source "+source.ID)
 	stepsCompleted, err = s.onboardCrawlStep(ctx, args, source.ID, result, stepsCompleted)
 	if err != nil {
 		result["crawl_error"] = err.Error()
@@ -87,6 +91,7 @@ func (s *Server) executeOnboardWorkflow(ctx context.Context, id any, args onboar
 		return s.successResponse(id, result)
 	}
 
+	progress.Step(maxOnboardSteps, maxOnboardSteps, "Source onboarded")
 	result["steps_completed"] = stepsCompleted
 	result["message"] = fmt.Sprintf("Source '%s' onboarded successfully with %d steps completed", args.Name, len(stepsCompleted))
 	return s.successResponse(id, result)
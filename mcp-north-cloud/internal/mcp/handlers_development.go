@@ -49,7 +49,7 @@ func (s *Server) handleLintFile(ctx context.Context, id any, arguments json.RawM
 		return s.errorResponse(id, InvalidParams, setupErr.Error())
 	}
 
-	return s.executeLintCommand(id, lintCommand, lintType, serviceDir)
+	return s.executeLintCommand(ctx, id, lintCommand, lintType, serviceDir)
 }
 
 // detectProjectRoot determines the project root directory
@@ -154,9 +154,8 @@ func (s *Server) setupFrontendFileLint(ctx context.Context, serviceDir, serviceN
 }
 
 // executeLintCommand runs the lint command and returns the response
-func (s *Server) executeLintCommand(id any, lintCommand *exec.Cmd, lintType, serviceDir string) *Response {
-	output, err := lintCommand.CombinedOutput()
-	outputStr := string(output)
+func (s *Server) executeLintCommand(ctx context.Context, id any, lintCommand *exec.Cmd, lintType, serviceDir string) *Response {
+	outputStr, err := runCommand(lintCommand, progressFrom(ctx))
 	displayOutput, outputTruncated, outputTotalBytes := truncateCommandOutput(outputStr, maxCommandOutputBytes)
 
 	result := map[string]any{
@@ -268,8 +267,7 @@ func (s *Server) executeBuildTestCommand(ctx context.Context, id any, serviceNam
 			fmt.Sprintf("service '%s' not found or doesn't have Taskfile.yml or package.json", serviceName))
 	}
 
-	output, err := cmd.CombinedOutput()
-	outputStr := string(output)
+	outputStr, err := runCommand(cmd, progressFrom(ctx))
 	displayOutput, outputTruncated, outputTotalBytes := truncateCommandOutput(outputStr, maxCommandOutputBytes)
 
 	result := map[string]any{
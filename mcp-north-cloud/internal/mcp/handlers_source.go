@@ -53,8 +53,14 @@ func (s *Server) handleAddSource(ctx context.Context, id any, arguments json.Raw
 
 func (s *Server) handleListSources(ctx context.Context, id any, arguments json.RawMessage) *Response {
 	var args struct {
-		Limit  int `json:"limit"`
-		Offset int `json:"offset"`
+		Limit     int    `json:"limit"`
+		Offset    int    `json:"offset"`
+		Search    string `json:"search"`
+		Active    *bool  `json:"active"`
+		Lifecycle string `json:"lifecycle"`
+		Type      string `json:"type"`
+		Region    string `json:"region"`
+		Domain    string `json:"domain"`
 	}
 
 	if len(arguments) > 0 {
@@ -63,13 +69,6 @@ func (s *Server) handleListSources(ctx context.Context, id any, arguments json.R
 		}
 	}
 
-	sources, err := s.sourceClient.ListSources(ctx)
-	if err != nil {
-		return s.errorResponse(id, InternalError, fmt.Sprintf("Failed to list sources: %v", err))
-	}
-
-	// Apply pagination in MCP layer
-	total := len(sources)
 	limit := max(args.Limit, 0)
 	if limit == 0 {
 		limit = defaultLimit
@@ -78,26 +77,36 @@ func (s *Server) handleListSources(ctx context.Context, id any, arguments json.R
 
 	offset := max(args.Offset, 0)
 
-	var paginatedSources []client.Source
-	if offset >= total {
-		paginatedSources = []client.Source{}
-	} else {
-		end := min(offset+limit, total)
-		paginatedSources = sources[offset:end]
+	// Filtering and pagination happen in source-manager; only this page is fetched.
+	sources, total, err := s.sourceClient.ListSourcesWithParams(ctx, client.ListSourcesParams{
+		Search:    args.Search,
+		Enabled:   args.Active,
+		Lifecycle: args.Lifecycle,
+		Type:      args.Type,
+		Region:    args.Region,
+		Domain:    args.Domain,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return s.errorResponse(id, InternalError, fmt.Sprintf("Failed to list sources: %v", err))
 	}
 
 	// Return compact summaries (omit selectors and timestamps to reduce token usage)
-	summaries := make([]map[string]any, 0, len(paginatedSources))
-	for i := range paginatedSources {
+	summaries := make([]map[string]any, 0, len(sources))
+	for i := range sources {
 		summary := map[string]any{
-			"id":     paginatedSources[i].ID,
-			"name":   paginatedSources[i].Name,
-			"url":    paginatedSources[i].URL,
-			"type":   paginatedSources[i].Type,
-			"active": paginatedSources[i].Enabled,
+			"id":     sources[i].ID,
+			"name":   sources[i].Name,
+			"url":    sources[i].URL,
+			"type":   sources[i].Type,
+			"active": sources[i].Enabled,
 		}
-		if paginatedSources[i].FeedURL != nil {
-			summary["feed_url"] = *paginatedSources[i].FeedURL
+		if sources[i].Lifecycle != "" {
+			summary["lifecycle"] = sources[i].Lifecycle
+		}
+		if sources[i].FeedURL != nil {
+			summary["feed_url"] = *sources[i].FeedURL
 		}
 		summaries = append(summaries, summary)
 	}
@@ -249,6 +258,7 @@ func (s *Server) handleEnableFeed(ctx context.Context, id any, arguments json.Ra
 
 func (s *Server) handleTestSource(ctx context.Context, id any, arguments json.RawMessage) *Response {
 	var args struct {
+		SourceID  string         `json:"source_id"`
 		URL       string         `json:"url"`
 		Selectors map[string]any `json:"selectors"`
 	}
@@ -257,17 +267,63 @@ func (s *Server) handleTestSource(ctx context.Context, id any, arguments json.Ra
 		return s.errorResponse(id, InvalidParams, "Invalid arguments: "+err.Error())
 	}
 
-	if args.URL == "" || args.Selectors == nil {
-		return s.errorResponse(id, InvalidParams, "url and selectors are required")
+	var (
+		result *client.TestCrawlResponse
+		err    error
+	)
+	switch {
+	case args.SourceID != "":
+		result, err = s.sourceClient.TestSource(ctx, args.SourceID)
+	case args.URL != "":
+		result, err = s.sourceClient.TestCrawl(ctx, client.TestCrawlRequest{
+			URL:       args.URL,
+			Selectors: testSelectors(args.Selectors),
+		})
+	default:
+		return s.errorResponse(id, InvalidParams, "source_id or url is required")
 	}
-
-	result, err := s.sourceClient.TestCrawl(ctx, client.TestCrawlRequest{
-		URL:       args.URL,
-		Selectors: args.Selectors,
-	})
 	if err != nil {
 		return s.errorResponse(id, InternalError, fmt.Sprintf("Failed to test source: %v", err))
 	}
 
 	return s.successResponse(id, result)
 }
+
+func (s *Server) handleSuggestSelectors(ctx context.Context, id any, arguments json.RawMessage) *Response {
+	var args struct {
+		URL string `json:"url"`
+	}
+
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return s.errorResponse(id, InvalidParams, "Invalid arguments: "+err.Error())
+	}
+	if args.URL == "" {
+		return s.errorResponse(id, InvalidParams, "url is required")
+	}
+
+	result, err := s.sourceClient.SuggestSelectors(ctx, args.URL)
+	if err != nil {
+		return s.errorResponse(id, InternalError, fmt.Sprintf("Failed to suggest selectors: %v", err))
+	}
+
+	return s.successResponse(id, result)
+}
+
+// testSelectors maps the tool's flat selectors ({title, body, date, author}) to
+// the source selector shape. Selectors already in that shape pass through.
+func testSelectors(flat map[string]any) map[string]any {
+	if flat == nil {
+		return nil
+	}
+	if _, ok := flat["article"]; ok {
+		return flat
+	}
+	article := make(map[string]any, len(flat))
+	for key, value := range flat {
+		if key == "date" {
+			key = "published_time"
+		}
+		article[key] = value
+	}
+	return map[string]any{"article": article}
+}
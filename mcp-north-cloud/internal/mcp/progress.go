@@ -0,0 +1,175 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// Notification methods exchanged with the client.
+const (
+	methodProgress  = "notifications/progress"
+	methodCancelled = "notifications/cancelled"
+)
+
+// NotifyFunc writes a notification to the client. It may be called from
+// several goroutines at once.
+type NotifyFunc func(*Notification)
+
+// WithNotifier lets tool calls send notifications, such as progress and
+// partial output, while they run. Without it progress is not reported.
+func WithNotifier(notify NotifyFunc) ServerOption {
+	return func(s *Server) { s.notify = notify }
+}
+
+// progressParams is the payload of notifications/progress. Message carries
+// a step description or a chunk of command output.
+type progressParams struct {
+	ProgressToken any     `json:"progressToken"`
+	Progress      float64 `json:"progress"`
+	Total         float64 `json:"total,omitempty"`
+	Message       string  `json:"message,omitempty"`
+}
+
+// progressReporter sends progress notifications for one tool call, under the
+// token the client passed in params._meta.progressToken. A nil reporter
+// (no token, or no notifier) reports nothing.
+type progressReporter struct {
+	mu       sync.Mutex
+	token    any
+	notify   NotifyFunc
+	progress float64
+}
+
+type progressKey struct{}
+
+// withProgress returns ctx carrying a progress reporter for token, or ctx
+// unchanged when the client asked for no progress.
+func (s *Server) withProgress(ctx context.Context, token any) context.Context {
+	if token == nil || s.notify == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressKey{}, &progressReporter{token: token, notify: s.notify})
+}
+
+// progressFrom returns the progress reporter of the tool call running in ctx, or nil.
+func progressFrom(ctx context.Context) *progressReporter {
+	p, _ := ctx.Value(progressKey{}).(*progressReporter)
+	return p
+}
+
+// Step reports that done of total steps are complete; message says what happens next.
+func (p *progressReporter) Step(done, total int, message string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress = float64(done)
+	p.send(float64(total), message)
+}
+
+// Output reports a chunk of output; progress counts the bytes produced so far.
+func (p *progressReporter) Output(chunk string) {
+	if p == nil || chunk == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress += float64(len(chunk))
+	p.send(0, chunk)
+}
+
+// send must be called with p.mu held, so that notifications leave in progress order.
+func (p *progressReporter) send(total float64, message string) {
+	params, err := json.Marshal(progressParams{
+		ProgressToken: p.token,
+		Progress:      p.progress,
+		Total:         total,
+		Message:       message,
+	})
+	if err != nil {
+		return
+	}
+	p.notify(&Notification{JSONRPC: "2.0", Method: methodProgress, Params: params})
+}
+
+// inflightCalls holds the cancel functions of running tool calls, by request ID,
+// so that notifications/cancelled can stop them.
+type inflightCalls struct {
+	mu    sync.Mutex
+	calls map[string]context.CancelFunc
+}
+
+// start registers a tool call and returns its context. done must be called when it ends.
+func (c *inflightCalls) start(ctx context.Context, id any) (callCtx context.Context, done func()) {
+	key, ok := requestKey(id)
+	if !ok {
+		return ctx, func() {}
+	}
+	callCtx, cancel := context.WithCancel(ctx)
+
+	c.mu.Lock()
+	if c.calls == nil {
+		c.calls = make(map[string]context.CancelFunc)
+	}
+	c.calls[key] = cancel
+	c.mu.Unlock()
+
+	return callCtx, func() {
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// cancel stops the tool call with the given request ID. Reports whether one was running.
+func (c *inflightCalls) cancel(id any) bool {
+	key, ok := requestKey(id)
+	if !ok {
+		return false
+	}
+	c.mu.Lock()
+	cancel, found := c.calls[key]
+	c.mu.Unlock()
+	if found {
+		cancel()
+	}
+	return found
+}
+
+// requestKey identifies a JSON-RPC request ID; 7 and "7" are different requests.
+func requestKey(id any) (string, bool) {
+	if id == nil {
+		return "", false
+	}
+	key, err := json.Marshal(id)
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// cancelledParams is the payload of notifications/cancelled.
+type cancelledParams struct {
+	RequestID any    `json:"requestId"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// handleCancelled stops a running tool call at the client's request. It is a
+// notification, so it never has a response.
+func (s *Server) handleCancelled(req *Request) {
+	var params cancelledParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return
+	}
+	if s.inflight.cancel(params.RequestID) && s.log != nil {
+		s.log.Info("Tool call cancelled by client",
+			logger.Any("request_id", params.RequestID),
+			logger.String("reason", params.Reason),
+		)
+	}
+}
@@ -0,0 +1,109 @@
+//nolint:testpackage // tests unexported progress reporting and cancellation
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordProgress returns a server whose notifications are collected, and the collected progress params.
+func recordProgress(t *testing.T) (s *Server, sent func() []progressParams) {
+	t.Helper()
+	var mu sync.Mutex
+	var params []progressParams
+	s = NewServer("local", nil, nil, nil, nil, nil, nil, nil, nil, "", "", "",
+		WithNotifier(func(n *Notification) {
+			if n.Method != methodProgress {
+				t.Errorf("unexpected notification %q", n.Method)
+			}
+			var p progressParams
+			if err := json.Unmarshal(n.Params, &p); err != nil {
+				t.Errorf("unmarshal progress: %v", err)
+			}
+			mu.Lock()
+			params = append(params, p)
+			mu.Unlock()
+		}))
+	return s, func() []progressParams {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]progressParams(nil), params...)
+	}
+}
+
+func TestRunCommand_StreamsOutputAsProgress(t *testing.T) {
+	t.Helper()
+	s, sent := recordProgress(t)
+	ctx := s.withProgress(context.Background(), "tok-1")
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", "echo one; echo two >&2; printf three")
+	output, err := runCommand(cmd, progressFrom(ctx))
+	if err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+	if output != "one\ntwo\nthree" {
+		t.Errorf("expected full combined output, got %q", output)
+	}
+
+	var streamed strings.Builder
+	last := 0.0
+	for _, p := range sent() {
+		if p.ProgressToken != "tok-1" {
+			t.Errorf("expected progress token tok-1, got %v", p.ProgressToken)
+		}
+		if p.Progress <= last {
+			t.Errorf("progress must increase: %v after %v", p.Progress, last)
+		}
+		last = p.Progress
+		streamed.WriteString(p.Message)
+	}
+	if want := "$ sh -c echo one; echo two >&2; printf three\none\ntwo\nthree"; streamed.String() != want {
+		t.Errorf("expected streamed output %q, got %q", want, streamed.String())
+	}
+}
+
+func TestWithProgress_NoTokenReportsNothing(t *testing.T) {
+	t.Helper()
+	s, sent := recordProgress(t)
+	ctx := s.withProgress(context.Background(), nil)
+
+	progressFrom(ctx).Step(0, 2, "ignored")
+	output, err := runCommand(exec.CommandContext(ctx, "echo", "hi"), progressFrom(ctx))
+	if err != nil || output != "hi\n" {
+		t.Fatalf("expected plain output, got %q, %v", output, err)
+	}
+	if got := sent(); len(got) != 0 {
+		t.Errorf("expected no notifications without a progress token, got %d", len(got))
+	}
+}
+
+func TestHandleRequest_CancelledNotificationStopsToolCall(t *testing.T) {
+	t.Helper()
+	s := NewServer("local", nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	callCtx, done := s.inflight.start(context.Background(), float64(7))
+	defer done()
+
+	cancel := func(requestID string) {
+		resp := s.HandleRequestWithContext(context.Background(), &Request{
+			JSONRPC: "2.0",
+			Method:  methodCancelled,
+			Params:  json.RawMessage(`{"requestId":` + requestID + `,"reason":"user stopped it"}`),
+		})
+		if resp != nil {
+			t.Errorf("expected no response to a notification, got %+v", resp)
+		}
+	}
+
+	cancel(`"7"`)
+	if callCtx.Err() != nil {
+		t.Fatal("a string request ID must not cancel numeric request 7")
+	}
+	cancel(`7`)
+	if callCtx.Err() == nil {
+		t.Fatal("expected tool call 7 to be cancelled")
+	}
+}
@@ -1,11 +1,20 @@
 package mcp
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+
+	"github.com/jonesrussell/north-cloud/mcp-north-cloud/internal/client"
 )
 
-const northcloudScheme = "northcloud://"
+const (
+	northcloudScheme = "northcloud://"
+	mimeTypeJSON     = "application/json"
+)
 
 // getAllResources returns the list of static resource metadata.
 func getAllResources() []ResourceListItem {
@@ -31,8 +40,36 @@ func getAllResources() []ResourceListItem {
 	}
 }
 
-// readResource returns content for a known URI. For unknown URI returns error with ResourceNotFound.
-func readResource(uri string) ([]ResourceContent, error) {
+// getResourceTemplates returns the live resources read from North Cloud
+// services. They are too many to list, so clients fill in the template.
+func getResourceTemplates() []ResourceTemplate {
+	return []ResourceTemplate{
+		{
+			URITemplate: "northcloud://sources/{id}",
+			Name:        "Source",
+			Description: "A content source's full configuration from source-manager (URL, selectors, feed, lifecycle)",
+			MimeType:    mimeTypeJSON,
+		},
+		{
+			URITemplate: "northcloud://indexes/{name}",
+			Name:        "Elasticsearch Index",
+			Description: "An index's health, document count and size from index-manager (e.g. cbc_news_classified_content)",
+			MimeType:    mimeTypeJSON,
+		},
+		{
+			URITemplate: "northcloud://articles/{index}/{id}",
+			Name:        "Article",
+			Description: "One indexed document (title, body, classification) by index name and document ID, " +
+				"as returned in search_content results",
+			MimeType: mimeTypeJSON,
+		},
+	}
+}
+
+// readResource returns content for a known URI: a static doc, or a source,
+// index or article fetched from its service. Unknown URIs, and entities the
+// service does not have, return a *ResourceNotFoundError.
+func (s *Server) readResource(ctx context.Context, uri string) ([]ResourceContent, error) {
 	if !strings.HasPrefix(uri, northcloudScheme) {
 		return nil, resourceNotFoundError(uri)
 	}
@@ -49,9 +86,50 @@ func readResource(uri string) ([]ResourceContent, error) {
 		return []ResourceContent{{URI: uri, MimeType: "text/plain", Text: staticSelectors}}, nil
 	case "docs/pipeline":
 		return []ResourceContent{{URI: uri, MimeType: "text/plain", Text: staticPipeline}}, nil
+	}
+
+	kind, rest, _ := strings.Cut(path, "/")
+	segments, ok := resourceSegments(rest)
+	if !ok {
+		return nil, resourceNotFoundError(uri)
+	}
+
+	var body json.RawMessage
+	var err error
+	switch {
+	case kind == "sources" && len(segments) == 1 && s.sourceClient != nil:
+		body, err = s.sourceClient.GetSourceRaw(ctx, segments[0])
+	case kind == "indexes" && len(segments) == 1 && s.indexClient != nil:
+		body, err = s.indexClient.GetIndex(ctx, segments[0])
+	case kind == "articles" && len(segments) == 2 && s.indexClient != nil:
+		body, err = s.indexClient.GetDocument(ctx, segments[0], segments[1])
 	default:
 		return nil, resourceNotFoundError(uri)
 	}
+	if errors.Is(err, client.ErrNotFound) {
+		return nil, resourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", uri, err)
+	}
+	return []ResourceContent{{URI: uri, MimeType: mimeTypeJSON, Text: string(body)}}, nil
+}
+
+// resourceSegments splits the part of a resource path after its kind into
+// unescaped, non-empty segments ("a%2Fb/c" is "a/b" and "c").
+func resourceSegments(rest string) ([]string, bool) {
+	if rest == "" {
+		return nil, false
+	}
+	segments := strings.Split(rest, "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil || unescaped == "" {
+			return nil, false
+		}
+		segments[i] = unescaped
+	}
+	return segments, true
 }
 
 func resourceNotFoundError(uri string) error {
@@ -70,7 +148,7 @@ func (e *ResourceNotFoundError) Error() string {
 // Static doc content (short, 1–2 lines per tool or 5–8 selector examples or one short paragraph per stage).
 //
 //nolint:lll // long single-line content strings for static docs
-const staticToolReference = `get_auth_token: Get JWT for API calls. onboard_source: Add source and start crawling. start_crawl: Create one-off crawl job (source_id, url). schedule_crawl: Create recurring job (source_id, url, interval_minutes, interval_type). list_crawl_jobs: List jobs (optional status, limit, offset). control_crawl_job: Pause/resume/cancel (job_id, action). get_crawl_stats: Job stats (job_id). add_source, list_sources, update_source, delete_source, test_source: Source CRUD and test crawl. create_channel, list_channels, delete_channel, preview_channel: Channel CRUD and preview. get_publish_history, get_publisher_stats: History and stats. search_content: Full-text search (query, filters). classify_content: Classify one content item (title, raw_text, url). list_indexes, delete_index: Index list/delete. lint_file, build_service, test_service: Dev helpers (file_path or service_name).`
+const staticToolReference = `get_auth_token: Get JWT for API calls. onboard_source: Add source and start crawling. start_crawl: Create one-off crawl job (source_id, url). schedule_crawl: Create recurring job (source_id, url, interval_minutes, interval_type). list_crawl_jobs: List jobs (optional status, limit, offset). control_crawl_job: Pause/resume/cancel (job_id, action). get_crawl_stats: Job stats (job_id). add_source, list_sources, update_source, delete_source, test_source, suggest_selectors: Source CRUD, test crawl and selector suggestions. create_channel, list_channels, delete_channel, preview_channel: Channel CRUD and preview. get_publish_history, get_publisher_stats: History and stats. search_content: Full-text search (query, filters). classify_content: Classify one content item (title, raw_text, url). list_indexes, delete_index: Index list/delete. lint_file, build_service, test_service: Dev helpers (file_path or service_name).`
 
 //nolint:lll // static doc string for selector cheatsheet
 const staticSelectors = `title: h1 or .headline. body: article or .content or main. date: time[datetime] or .date. author: .byline or [rel="author"]. link: a[href]. image: img or picture source.`
@@ -0,0 +1,129 @@
+//nolint:testpackage // tests unexported resource handlers
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/mcp-north-cloud/internal/client"
+)
+
+// newResourceServer returns a server whose source-manager and index-manager
+// clients talk to a fake service that answers the given paths with JSON bodies
+// and everything else with 404.
+func newResourceServer(t *testing.T, bodies map[string]string) *Server {
+	t.Helper()
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(service.Close)
+
+	auth := client.NewAuthenticatedClientWithTimeout("", time.Second)
+	return NewServer("local",
+		client.NewIndexManagerClient(service.URL, auth), nil,
+		client.NewSourceManagerClient(service.URL, auth),
+		nil, nil, nil, nil, nil, "", "", "")
+}
+
+func readResourceRequest(t *testing.T, s *Server, uri string) *Response {
+	t.Helper()
+	params, err := json.Marshal(resourcesReadParams{URI: uri})
+	if err != nil {
+		t.Fatalf("marshal params: %v", err)
+	}
+	req := &Request{JSONRPC: "2.0", ID: "1", Method: "resources/read", Params: params}
+	return s.HandleRequestWithContext(context.Background(), req)
+}
+
+func TestHandleResourcesRead_LiveResources(t *testing.T) {
+	t.Helper()
+	s := newResourceServer(t, map[string]string{
+		"/api/v1/sources/src-1":                                  `{"id":"src-1","name":"CBC","lifecycle":"active"}`,
+		"/api/v1/indexes/cbc_classified_content":                 `{"name":"cbc_classified_content","document_count":12}`,
+		"/api/v1/indexes/cbc_classified_content/documents/a%2Fb": `{"id":"a/b","title":"Story"}`,
+	})
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"northcloud://sources/src-1", `{"id":"src-1","name":"CBC","lifecycle":"active"}`},
+		{"northcloud://indexes/cbc_classified_content", `{"name":"cbc_classified_content","document_count":12}`},
+		{"northcloud://articles/cbc_classified_content/a%2Fb", `{"id":"a/b","title":"Story"}`},
+	}
+	for _, tt := range tests {
+		resp := readResourceRequest(t, s, tt.uri)
+		if resp.Error != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.uri, resp.Error)
+		}
+		var result struct {
+			Contents []ResourceContent `json:"contents"`
+		}
+		if err := json.Unmarshal(resp.Result, &result); err != nil {
+			t.Fatalf("%s: unmarshal result: %v", tt.uri, err)
+		}
+		if len(result.Contents) != 1 {
+			t.Fatalf("%s: expected one content item, got %d", tt.uri, len(result.Contents))
+		}
+		got := result.Contents[0]
+		if got.URI != tt.uri || got.MimeType != mimeTypeJSON || got.Text != tt.want {
+			t.Errorf("%s: got %+v", tt.uri, got)
+		}
+	}
+}
+
+func TestHandleResourcesRead_MissingLiveResource_ReturnsResourceNotFound(t *testing.T) {
+	t.Helper()
+	s := newResourceServer(t, nil)
+
+	for _, uri := range []string{
+		"northcloud://sources/missing",
+		"northcloud://indexes/missing",
+		"northcloud://articles/cbc_classified_content/missing",
+		"northcloud://articles/only-an-id",
+		"northcloud://sources/",
+	} {
+		resp := readResourceRequest(t, s, uri)
+		if resp.Error == nil || resp.Error.Code != ResourceNotFound {
+			t.Errorf("%s: expected ResourceNotFound, got %+v", uri, resp.Error)
+		}
+	}
+}
+
+func TestHandleResourceTemplatesList_ReturnsTemplates(t *testing.T) {
+	t.Helper()
+	s := NewServer("local", nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+	req := &Request{JSONRPC: "2.0", ID: "1", Method: "resources/templates/list"}
+	resp := s.HandleRequestWithContext(context.Background(), req)
+	if resp == nil || resp.Error != nil {
+		t.Fatalf("expected result, got %+v", resp)
+	}
+	var result struct {
+		ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	templates := make(map[string]bool, len(result.ResourceTemplates))
+	for _, tmpl := range result.ResourceTemplates {
+		templates[tmpl.URITemplate] = true
+	}
+	for _, want := range []string{
+		"northcloud://sources/{id}", "northcloud://indexes/{name}", "northcloud://articles/{index}/{id}",
+	} {
+		if !templates[want] {
+			t.Errorf("expected template %s", want)
+		}
+	}
+}
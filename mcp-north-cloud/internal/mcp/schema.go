@@ -0,0 +1,267 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// argumentsField names the whole arguments object in validation errors.
+const argumentsField = "(arguments)"
+
+// FieldError describes one argument that does not match a tool's input schema.
+type FieldError struct {
+	Field   string `json:"field"`
+	Problem string `json:"problem"`
+}
+
+// toolSchemaMap builds a name->input schema lookup from all tools.
+func toolSchemaMap() map[string]map[string]any {
+	all := getAllTools()
+	m := make(map[string]map[string]any, len(all))
+	for _, t := range all {
+		m[t.Name] = t.InputSchema
+	}
+	return m
+}
+
+var toolSchemas = toolSchemaMap()
+
+// validateArguments checks tool arguments against the tool's input schema and
+// returns every mismatch, so a caller can fix all of them in one retry.
+// Missing or null arguments are validated as an empty object.
+//
+// The supported keywords are the ones the tool definitions use: type,
+// properties, required, enum, items, minimum, maximum, minLength and
+// additionalProperties (false only).
+func validateArguments(schema map[string]any, arguments json.RawMessage) []FieldError {
+	trimmed := bytes.TrimSpace(arguments)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		trimmed = []byte("{}")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []FieldError{{Field: argumentsField, Problem: "is not valid JSON: " + err.Error()}}
+	}
+
+	var errs []FieldError
+	validateValue(argumentsField, schema, value, &errs)
+	return errs
+}
+
+// validateValue appends the schema mismatches of value at path to errs.
+func validateValue(path string, schema map[string]any, value any, errs *[]FieldError) {
+	if schemaType, ok := schema["type"].(string); ok && !matchesType(schemaType, value) {
+		*errs = append(*errs, FieldError{
+			Field:   path,
+			Problem: fmt.Sprintf("must be %s %s, got %s", article(schemaType), schemaType, jsonTypeName(value)),
+		})
+		return
+	}
+
+	if allowed := stringList(schema["enum"]); len(allowed) > 0 {
+		if s, isString := value.(string); !isString || !slices.Contains(allowed, s) {
+			*errs = append(*errs, FieldError{
+				Field:   path,
+				Problem: "must be one of: " + strings.Join(allowed, ", "),
+			})
+			return
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		validateObject(path, schema, v, errs)
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				validateValue(fmt.Sprintf("%s[%d]", path, i), items, item, errs)
+			}
+		}
+	case json.Number:
+		validateRange(path, schema, v, errs)
+	case string:
+		if minLength, ok := schemaNumber(schema["minLength"]); ok && float64(len(v)) < minLength {
+			*errs = append(*errs, FieldError{
+				Field:   path,
+				Problem: fmt.Sprintf("must be at least %s characters", formatNumber(minLength)),
+			})
+		}
+	}
+}
+
+// validateObject checks required fields, declared properties and, when the
+// schema sets additionalProperties to false, undeclared fields.
+func validateObject(path string, schema map[string]any, obj map[string]any, errs *[]FieldError) {
+	properties, _ := schema["properties"].(map[string]any)
+
+	for _, name := range stringList(schema["required"]) {
+		if field, present := obj[name]; !present || field == nil {
+			*errs = append(*errs, FieldError{Field: joinPath(path, name), Problem: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propSchema, declared := properties[name].(map[string]any)
+		if !declared {
+			if additional, isBool := schema["additionalProperties"].(bool); isBool && !additional {
+				*errs = append(*errs, FieldError{Field: joinPath(path, name), Problem: "is not a known field"})
+			}
+			continue
+		}
+		if obj[name] == nil {
+			continue
+		}
+		validateValue(joinPath(path, name), propSchema, obj[name], errs)
+	}
+}
+
+// validateRange checks minimum and maximum for a number.
+func validateRange(path string, schema map[string]any, n json.Number, errs *[]FieldError) {
+	f, err := n.Float64()
+	if err != nil {
+		return
+	}
+	if minimum, ok := schemaNumber(schema["minimum"]); ok && f < minimum {
+		*errs = append(*errs, FieldError{Field: path, Problem: "must be at least " + formatNumber(minimum)})
+	}
+	if maximum, ok := schemaNumber(schema["maximum"]); ok && f > maximum {
+		*errs = append(*errs, FieldError{Field: path, Problem: "must be at most " + formatNumber(maximum)})
+	}
+}
+
+// matchesType reports whether a decoded JSON value has the given schema type.
+func matchesType(schemaType string, value any) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := strconv.ParseInt(n.String(), 10, 64)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// jsonTypeName names the JSON type of a decoded value for error messages.
+func jsonTypeName(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "number " + v.String()
+		}
+		return "integer " + v.String()
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// stringList reads a schema keyword holding a list of strings.
+func stringList(raw any) []string {
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// schemaNumber reads a numeric schema keyword.
+func schemaNumber(raw any) (float64, bool) {
+	switch v := raw.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// joinPath appends a property name to a field path; top-level fields are named bare.
+func joinPath(path, name string) string {
+	if path == argumentsField {
+		return name
+	}
+	return path + "." + name
+}
+
+// article returns "an" for schema types starting with a vowel, otherwise "a".
+func article(schemaType string) string {
+	if strings.ContainsRune("aeiou", rune(schemaType[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+// validationErrorResponse reports schema mismatches as InvalidParams, listing
+// every field in the message and as structured data.
+func (s *Server) validationErrorResponse(id any, toolName string, errs []FieldError) *Response {
+	problems := make([]string, 0, len(errs))
+	for _, e := range errs {
+		problems = append(problems, e.Field+" "+e.Problem)
+	}
+
+	resp := s.errorResponse(id, InvalidParams,
+		fmt.Sprintf("Invalid arguments for %s: %s", toolName, strings.Join(problems, "; ")))
+	resp.Error.Data = map[string]any{
+		"tool":   toolName,
+		"errors": errs,
+	}
+	return resp
+}
@@ -0,0 +1,113 @@
+//nolint:testpackage // testing unexported validateArguments and routeToolCall
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidateArguments_ListsEveryWrongField(t *testing.T) {
+	t.Helper()
+	args := json.RawMessage(`{"url": 42, "interval_minutes": 1.5, "interval_type": "weeks"}`)
+
+	errs := validateArguments(toolSchemas["schedule_crawl"], args)
+
+	want := map[string]string{
+		"source_id":        "is required",
+		"url":              "must be a string, got integer 42",
+		"interval_minutes": "must be an integer, got number 1.5",
+		"interval_type":    "must be one of: minutes, hours, days",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %+v", len(want), len(errs), errs)
+	}
+	for _, e := range errs {
+		if want[e.Field] != e.Problem {
+			t.Errorf("field %s: expected %q, got %q", e.Field, want[e.Field], e.Problem)
+		}
+	}
+}
+
+func TestValidateArguments_ArrayItemsAndNull(t *testing.T) {
+	t.Helper()
+
+	errs := validateArguments(toolSchemas["search_content"], json.RawMessage(`{"query":"fire","topics":["crime",7]}`))
+	if len(errs) != 1 || errs[0].Field != "topics[1]" {
+		t.Fatalf("expected a single topics[1] error, got %+v", errs)
+	}
+
+	errs = validateArguments(toolSchemas["search_content"], nil)
+	if len(errs) != 1 || errs[0].Field != "query" {
+		t.Fatalf("missing arguments should be validated as {}, got %+v", errs)
+	}
+
+	if errs = validateArguments(toolSchemas["search_content"], json.RawMessage(`{"query":"fire","page":2}`)); len(errs) != 0 {
+		t.Errorf("expected valid arguments, got %+v", errs)
+	}
+}
+
+func TestValidateArguments_KeywordsBeyondToolDefinitions(t *testing.T) {
+	t.Helper()
+	schema := map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": 100},
+			"name":  map[string]any{"type": "string", "minLength": 2},
+		},
+	}
+
+	errs := validateArguments(schema, json.RawMessage(`{"limit":500,"name":"a","extra":true}`))
+
+	got := make([]string, 0, len(errs))
+	for _, e := range errs {
+		got = append(got, e.Field+" "+e.Problem)
+	}
+	want := "extra is not a known field|limit must be at most 100|name must be at least 2 characters"
+	if strings.Join(got, "|") != want {
+		t.Errorf("expected %q, got %q", want, strings.Join(got, "|"))
+	}
+
+	errs = validateArguments(schema, json.RawMessage(`[1,2]`))
+	if len(errs) != 1 || errs[0].Problem != "must be an object, got array" {
+		t.Errorf("expected a non-object error, got %+v", errs)
+	}
+}
+
+func TestRouteToolCall_InvalidArgumentsNeverReachHandler(t *testing.T) {
+	t.Helper()
+	// Clients are nil, so reaching the handler would panic.
+	s := NewServer("prod", nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
+
+	resp := s.routeToolCall(context.Background(), "1", "start_crawl", json.RawMessage(`{"url":"https://example.com"}`))
+
+	if resp.Error == nil || resp.Error.Code != InvalidParams {
+		t.Fatalf("expected InvalidParams, got %+v", resp.Error)
+	}
+	if !strings.Contains(resp.Error.Message, "source_id is required") {
+		t.Errorf("expected message to name the missing field, got %q", resp.Error.Message)
+	}
+	data, _ := resp.Error.Data.(map[string]any)
+	fieldErrs, _ := data["errors"].([]FieldError)
+	if len(fieldErrs) != 1 || fieldErrs[0].Field != "source_id" {
+		t.Errorf("expected structured field errors, got %+v", resp.Error.Data)
+	}
+}
+
+func TestToolSchemas_RequiredFieldsAreDeclared(t *testing.T) {
+	t.Helper()
+	for _, tool := range getAllTools() {
+		if tool.InputSchema["type"] != "object" {
+			t.Errorf("%s: input schema must be an object", tool.Name)
+			continue
+		}
+		properties, _ := tool.InputSchema["properties"].(map[string]any)
+		for _, name := range stringList(tool.InputSchema["required"]) {
+			if _, ok := properties[name]; !ok {
+				t.Errorf("%s: required field %q is not declared in properties", tool.Name, name)
+			}
+		}
+	}
+}
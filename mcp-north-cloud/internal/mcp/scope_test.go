@@ -30,14 +30,14 @@ func TestGetToolsForEnv_Counts(t *testing.T) {
 	localTools := getToolsForEnv(EnvLocal)
 	prodTools := getToolsForEnv(EnvProd)
 
-	// Local = 23 shared + 3 local = 26
-	expectedLocal := 26
+	// Local = 24 shared + 3 local = 27
+	expectedLocal := 27
 	if len(localTools) != expectedLocal {
 		t.Errorf("local tools = %d, want %d", len(localTools), expectedLocal)
 	}
 
-	// Prod = 23 shared + 14 prod = 37
-	expectedProd := 37
+	// Prod = 24 shared + 14 prod = 38
+	expectedProd := 38
 	if len(prodTools) != expectedProd {
 		t.Errorf("prod tools = %d, want %d", len(prodTools), expectedProd)
 	}
@@ -29,6 +29,8 @@ type Server struct {
 	ollamaURL        string // empty = extract_schema unavailable
 	ollamaModel      string
 	rendererURL      string // empty = js_render unavailable
+	notify           NotifyFunc
+	inflight         inflightCalls
 }
 
 // ServerOption configures optional Server fields.
@@ -86,10 +88,16 @@ func (s *Server) HandleRequest(req *Request) *Response {
 }
 
 // HandleRequestWithContext processes an MCP request with the given context and returns a response.
-// Returns nil for notifications (requests without ID) - they don't require responses.
+// Returns nil for notifications (requests without ID) - they don't require responses - and
+// for tool calls the client cancelled. It may be called concurrently, which lets a
+// notifications/cancelled stop a tool call that is still running.
 func (s *Server) HandleRequestWithContext(ctx context.Context, req *Request) *Response {
 	requestID := req.ID
 
+	if req.Method == methodCancelled {
+		s.handleCancelled(req)
+		return nil
+	}
 	if req.Method == "initialize" {
 		return s.handleInitialize(req, requestID)
 	}
@@ -108,8 +116,11 @@ func (s *Server) HandleRequestWithContext(ctx context.Context, req *Request) *Re
 	if req.Method == "resources/list" {
 		return s.handleResourcesList(req, requestID)
 	}
+	if req.Method == "resources/templates/list" {
+		return s.handleResourceTemplatesList(req, requestID)
+	}
 	if req.Method == "resources/read" {
-		return s.handleResourcesRead(req, requestID)
+		return s.handleResourcesRead(ctx, req, requestID)
 	}
 	if req.Method == "ping" {
 		return &Response{
@@ -226,18 +237,25 @@ func (s *Server) handleToolsCall(ctx context.Context, req *Request, id any) *Res
 		return resp
 	}
 
-	// Execute and time
-	resp := s.routeToolCall(ctx, id, params.Name, params.Arguments)
+	// Execute and time; the call can be cancelled and may report progress
+	callCtx, done := s.inflight.start(ctx, id)
+	defer done()
+	if params.Meta != nil {
+		callCtx = s.withProgress(callCtx, params.Meta.ProgressToken)
+	}
+	resp := s.routeToolCall(callCtx, id, params.Name, params.Arguments)
 	duration := time.Since(start)
+	cancelled := callCtx.Err() != nil && ctx.Err() == nil
 
 	// Audit log
 	entry := AuditEntry{
 		ToolName:   params.Name,
 		RequestID:  id,
 		DurationMs: duration.Milliseconds(),
-		Success:    resp.Error == nil,
+		Success:    resp.Error == nil && !cancelled,
 		Timestamp:  start,
 		ParamKeys:  extractParamKeys(params.Arguments),
+		Cancelled:  cancelled,
 	}
 	if resp.Error != nil {
 		entry.ErrorCode = resp.Error.Code
@@ -247,6 +265,10 @@ func (s *Server) handleToolsCall(ctx context.Context, req *Request, id any) *Res
 	}
 	logToolAudit(s.log, entry)
 
+	// The client has given up on a cancelled call and expects no response to it.
+	if cancelled {
+		return nil
+	}
 	return resp
 }
 
@@ -267,6 +289,7 @@ var toolHandlers = map[string]toolHandlerFunc{
 	"delete_source":           (*Server).handleDeleteSource,
 	"enable_feed":             (*Server).handleEnableFeed,
 	"test_source":             (*Server).handleTestSource,
+	"suggest_selectors":       (*Server).handleSuggestSelectors,
 	"create_channel":          (*Server).handleCreateChannel,
 	"list_channels":           (*Server).handleListChannels,
 	"delete_channel":          (*Server).handleDeleteChannel,
@@ -325,6 +348,9 @@ func (s *Server) routeToolCall(ctx context.Context, id any, toolName string, arg
 		}
 	}
 	if h, ok := toolHandlers[toolName]; ok {
+		if errs := validateArguments(toolSchemas[toolName], arguments); len(errs) > 0 {
+			return s.validationErrorResponse(id, toolName, errs)
+		}
 		return h(s, ctx, id, arguments)
 	}
 	return &Response{
@@ -433,13 +459,27 @@ func (s *Server) handleResourcesList(_ *Request, id any) *Response {
 	return &Response{JSONRPC: "2.0", ID: id, Result: json.RawMessage(resultJSON)}
 }
 
+// handleResourceTemplatesList handles resources/templates/list: the URI templates of live resources.
+func (s *Server) handleResourceTemplatesList(_ *Request, id any) *Response {
+	result := map[string]any{"resourceTemplates": getResourceTemplates()}
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return &Response{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error:   &ErrorObject{Code: InternalError, Message: fmt.Sprintf("Failed to marshal result: %v", err)},
+		}
+	}
+	return &Response{JSONRPC: "2.0", ID: id, Result: json.RawMessage(resultJSON)}
+}
+
 // resourcesReadParams for resources/read.
 type resourcesReadParams struct {
 	URI string `json:"uri"`
 }
 
 // handleResourcesRead handles resources/read: returns content for known northcloud:// URIs.
-func (s *Server) handleResourcesRead(req *Request, id any) *Response {
+func (s *Server) handleResourcesRead(ctx context.Context, req *Request, id any) *Response {
 	var params resourcesReadParams
 	if unmarshalErr := json.Unmarshal(req.Params, &params); unmarshalErr != nil || params.URI == "" {
 		return &Response{
@@ -448,7 +488,7 @@ func (s *Server) handleResourcesRead(req *Request, id any) *Response {
 			Error:   &ErrorObject{Code: InvalidParams, Message: "uri is required"},
 		}
 	}
-	contents, err := readResource(params.URI)
+	contents, err := s.readResource(ctx, params.URI)
 	if err != nil {
 		var notFound *ResourceNotFoundError
 		if errors.As(err, &notFound) {
@@ -458,11 +498,7 @@ func (s *Server) handleResourcesRead(req *Request, id any) *Response {
 				Error:   &ErrorObject{Code: ResourceNotFound, Message: err.Error()},
 			}
 		}
-		return &Response{
-			JSONRPC: "2.0",
-			ID:      id,
-			Error:   &ErrorObject{Code: InternalError, Message: err.Error()},
-		}
+		return s.errorResponse(id, InternalError, err.Error())
 	}
 	result := map[string]any{"contents": contents}
 	resultJSON, marshalErr := json.Marshal(result)
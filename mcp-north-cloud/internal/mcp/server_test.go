@@ -161,7 +161,7 @@ func TestHandleResourcesRead_ValidURI_ReturnsContents(t *testing.T) {
 	s := NewServer("local", nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 	params := `{"uri":"northcloud://docs/pipeline"}`
 	req := &Request{JSONRPC: "2.0", ID: "1", Method: "resources/read", Params: json.RawMessage(params)}
-	resp := s.handleResourcesRead(req, "1")
+	resp := s.handleResourcesRead(context.Background(), req, "1")
 	if resp == nil || resp.Result == nil {
 		t.Fatal("expected non-nil result")
 	}
@@ -184,7 +184,7 @@ func TestHandleResourcesRead_UnknownURI_ReturnsResourceNotFound(t *testing.T) {
 	s := NewServer("local", nil, nil, nil, nil, nil, nil, nil, nil, "", "", "")
 	params := `{"uri":"northcloud://docs/nonexistent"}`
 	req := &Request{JSONRPC: "2.0", ID: "1", Method: "resources/read", Params: json.RawMessage(params)}
-	resp := s.handleResourcesRead(req, "1")
+	resp := s.handleResourcesRead(context.Background(), req, "1")
 	if resp == nil || resp.Error == nil {
 		t.Fatal("expected error for unknown URI")
 	}
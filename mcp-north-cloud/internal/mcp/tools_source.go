@@ -43,12 +43,38 @@ func getSourceManagerTools() []Tool {
 		{
 			Name:  "list_sources",
 			Scope: ScopeShared,
-			Description: "List all configured content sources with pagination. " +
+			Description: "List configured content sources with filters and pagination. " +
 				"Use when: You need to see what sources are registered, find a source_id, or check source status. " +
-				"Returns: paginated list with id, name, url, type, active status (default 20, max 100).",
+				"Filter by search text, domain, type, region, lifecycle or active instead of paging through everything. " +
+				"Returns: paginated list with id, name, url, type, active status and total matches (default 20, max 100).",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
+					"search": map[string]any{
+						"type":        "string",
+						"description": "Words that must all appear in the source name or URL (case-insensitive)",
+					},
+					"domain": map[string]any{
+						"type":        "string",
+						"description": "Only sources on this domain or its subdomains (e.g. 'cbc.ca')",
+					},
+					"type": map[string]any{
+						"type":        "string",
+						"description": "Only sources of this type (e.g. 'news', 'indigenous', 'government')",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only sources tagged with this indigenous region (e.g. 'canada')",
+					},
+					"lifecycle": map[string]any{
+						"type":        "string",
+						"enum":        []string{"draft", "active", "paused", "archived"},
+						"description": "Only sources in this lifecycle state",
+					},
+					"active": map[string]any{
+						"type":        "boolean",
+						"description": "Only active (true) or inactive (false) sources",
+					},
 					"limit": map[string]any{
 						"type":        "integer",
 						"description": "Maximum number of sources to return (default: 20, max: 100)",
@@ -141,22 +167,47 @@ func getSourceManagerTools() []Tool {
 		{
 			Name:  "test_source",
 			Scope: ScopeShared,
-			Description: "Test crawl a source without saving the results. Use when: Validating selectors " +
-				"before adding a source. Call before add_source or onboard_source if selectors are uncertain.",
+			Description: "Test crawl a source without saving the results: fetches up to three articles, " +
+				"applies the selectors, and returns title/body/date samples with warnings for empty fields. " +
+				"Use when: Validating selectors before adding a source (url + selectors), or checking a saved " +
+				"source (source_id). Call before add_source or onboard_source if selectors are uncertain.",
 			InputSchema: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
+					"source_id": map[string]any{
+						"type":        "string",
+						"description": "Saved source to test with its stored selectors (instead of url)",
+					},
 					"url": map[string]any{
 						"type":        "string",
-						"description": "URL to test crawl",
+						"description": "URL to test crawl (a listing page or an article)",
 					},
 					"selectors": map[string]any{
 						"type": "object",
-						"description": "CSS selectors to test. Minimal: {title: 'h1', body: 'article'}. " +
-							"Optional: date (e.g. 'time[datetime]'), author (e.g. '.byline').",
+						"description": "CSS selectors to test with url; unset ones use source defaults. " +
+							"Minimal: {title: 'h1', body: 'article'}. Optional: date (e.g. 'time[datetime]'). " +
+							"The full source shape ({article: {...}, list: {...}}) is also accepted.",
+					},
+				},
+			},
+		},
+		{
+			Name:  "suggest_selectors",
+			Scope: ScopeShared,
+			Description: "Propose article selectors (title, body, container, author, date) for a new source from " +
+				"one sample article URL, using its JSON-LD, OpenGraph tags and DOM heuristics. Returns each " +
+				"selector with a sample and confidence, plus 'selectors' in source form. Nothing is saved. " +
+				"Use when: Onboarding a source with unknown selectors. Pass an article page, not a listing; " +
+				"review low-confidence fields, then test_source with the selectors before add_source.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"url": map[string]any{
+						"type":        "string",
+						"description": "URL of one article on the source's site",
 					},
 				},
-				"required": []string{"url", "selectors"},
+				"required": []string{"url"},
 			},
 		},
 	}
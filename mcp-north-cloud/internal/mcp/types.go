@@ -18,6 +18,14 @@ type Response struct {
 	Error   *ErrorObject    `json:"error,omitempty"`
 }
 
+// Notification is a JSON-RPC message without an ID, sent by the server while
+// a request is running (e.g. notifications/progress).
+type Notification struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	JSONRPC string      `json:"jsonrpc"`
@@ -81,6 +89,13 @@ func (s ToolScope) IsAllowed(env string) bool {
 type ToolCallParams struct {
 	Name      string          `json:"name"`
 	Arguments json.RawMessage `json:"arguments"`
+	Meta      *RequestMeta    `json:"_meta,omitempty"`
+}
+
+// RequestMeta is the _meta object of a request. A client that sets
+// ProgressToken receives notifications/progress for the call under that token.
+type RequestMeta struct {
+	ProgressToken any `json:"progressToken,omitempty"`
 }
 
 // Prompt represents an MCP prompt template (for prompts/list and prompts/get).
@@ -118,6 +133,15 @@ type ResourceListItem struct {
 	MimeType    string `json:"mimeType,omitempty"`
 }
 
+// ResourceTemplate is a parameterized resource for resources/templates/list;
+// URITemplate follows RFC 6570 (e.g. "northcloud://sources/{id}").
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
 // ResourceContent is one content item for resources/read.
 type ResourceContent struct {
 	URI      string `json:"uri"`
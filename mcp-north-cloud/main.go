@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
@@ -43,7 +44,7 @@ func main() {
 
 	// Read from stdin, write to stdout
 	reader := bufio.NewReader(os.Stdin)
-	writer := os.Stdout
+	writer := &messageWriter{encoder: json.NewEncoder(os.Stdout), log: log}
 
 	// Initialize service clients
 	clients := initializeClients(cfg, log)
@@ -79,6 +80,7 @@ func main() {
 		cfg.Services.RendererURL,
 		mcp.WithLogger(log),
 		mcp.WithServiceURLs(serviceURLs),
+		mcp.WithNotifier(writer.notify),
 	)
 
 	// Process requests
@@ -124,11 +126,36 @@ func initializeClients(cfg *config.Config, log logger.Logger) *serviceClients {
 	}
 }
 
-func processRequests(reader *bufio.Reader, writer io.Writer, server *mcp.Server, log logger.Logger) {
+// messageWriter writes responses and notifications to stdout. Requests are
+// handled concurrently, so every message goes through one lock.
+type messageWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	log     logger.Logger
+}
+
+func (w *messageWriter) write(message any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if encodeErr := w.encoder.Encode(message); encodeErr != nil {
+		w.log.Error("Failed to encode message", logger.Error(encodeErr))
+	}
+}
+
+// notify sends a server notification, such as tool call progress.
+func (w *messageWriter) notify(notification *mcp.Notification) {
+	w.write(notification)
+}
+
+// processRequests reads requests until EOF. Requests with an ID are handled in
+// their own goroutine, so a long tool call keeps streaming progress and can be
+// stopped by a notifications/cancelled read after it. Notifications are handled
+// in order as they arrive. In-flight requests are answered before returning.
+func processRequests(reader *bufio.Reader, writer *messageWriter, server *mcp.Server, log logger.Logger) {
 	decoder := json.NewDecoder(reader)
-	encoder := json.NewEncoder(writer)
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
 
-	const requestTimeout = 60 * time.Second // must be >= HTTP client timeout
 	for {
 		var request mcp.Request
 		if err := decoder.Decode(&request); err != nil {
@@ -137,7 +164,7 @@ func processRequests(reader *bufio.Reader, writer io.Writer, server *mcp.Server,
 				break
 			}
 			log.Error("Failed to parse request", logger.Error(err))
-			sendError(encoder, 0, mcp.ParseError, "Failed to parse request", nil)
+			sendError(writer, 0, mcp.ParseError, "Failed to parse request", nil)
 			continue
 		}
 
@@ -146,25 +173,36 @@ func processRequests(reader *bufio.Reader, writer io.Writer, server *mcp.Server,
 			logger.Any("id", request.ID),
 		)
 
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-		response := server.HandleRequestWithContext(ctx, &request)
-		cancel()
-		if response != nil {
-			if response.ID == nil && request.ID != nil {
-				response.ID = request.ID
-			}
-			if request.ID == nil {
-				continue
-			}
-			if encodeErr := encoder.Encode(response); encodeErr != nil {
-				log.Error("Failed to encode response", logger.Error(encodeErr))
-			}
+		if request.ID == nil {
+			handleRequest(server, writer, &request)
+			continue
 		}
+
+		inflight.Add(1)
+		go func() {
+			defer inflight.Done()
+			handleRequest(server, writer, &request)
+		}()
 	}
 }
 
-func sendError(encoder *json.Encoder, id any, code int, message string, data any) {
-	errorResponse := mcp.ErrorResponse{
+func handleRequest(server *mcp.Server, writer *messageWriter, request *mcp.Request) {
+	const requestTimeout = 60 * time.Second // must be >= HTTP client timeout
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	response := server.HandleRequestWithContext(ctx, request)
+	if response == nil || request.ID == nil {
+		return
+	}
+	if response.ID == nil {
+		response.ID = request.ID
+	}
+	writer.write(response)
+}
+
+func sendError(writer *messageWriter, id any, code int, message string, data any) {
+	writer.write(mcp.ErrorResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Error: mcp.ErrorObject{
@@ -172,8 +210,5 @@ func sendError(encoder *json.Encoder, id any, code int, message string, data any
 			Message: message,
 			Data:    data,
 		},
-	}
-	if encodeErr := encoder.Encode(errorResponse); encodeErr != nil {
-		fmt.Fprintf(os.Stderr, "Failed to encode error response: %v\n", encodeErr)
-	}
+	})
 }
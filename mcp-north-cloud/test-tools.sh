@@ -73,9 +73,9 @@ test_tools_list() {
     tool_count=$(echo "$response" | jq '.result.tools | length' 2>/dev/null)
 
     if [ "${MCP_ENV:-local}" = "prod" ]; then
-        expected_tools=37
+        expected_tools=38
     else
-        expected_tools=26
+        expected_tools=27
     fi
 
     if [ "$tool_count" -eq "$expected_tools" ]; then
@@ -141,6 +141,18 @@ if [ "${MCP_TEST_PROMPTS:-0}" = "1" ]; then
         echo -e "${RED}✗ FAILED: resources/list${NC}"
         ((failed++)) || true
     fi
+    # resources/templates/list
+    echo -e "${YELLOW}Testing: resources/templates/list${NC}"
+    req='{"jsonrpc":"2.0","id":1,"method":"resources/templates/list","params":{}}'
+    resp=$(timeout 5 bash -c "echo '$req' | $MCP_BIN" 2>&1 | head -1)
+    n=$(echo "$resp" | jq '.result.resourceTemplates | length' 2>/dev/null || echo "0")
+    if [ "${n:-0}" -ge 1 ]; then
+        echo -e "${GREEN}✓ PASSED: resources/templates/list ($n templates)${NC}"
+        ((passed++)) || true
+    else
+        echo -e "${RED}✗ FAILED: resources/templates/list${NC}"
+        ((failed++)) || true
+    fi
 fi
 
 # Note: The following tool tests require actual services to be running
@@ -163,6 +163,11 @@ func (h *AdminHandler) appendEntriesFromDir(dir string, entries *[]string) {
 }
 
 func (h *AdminHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	writeJSON(w, status, data)
+}
+
+// writeJSON writes data as a JSON response with status.
+func writeJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	if encodeErr := json.NewEncoder(w).Encode(data); encodeErr != nil {
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// introspectPath is the auth service's RFC 7662 token introspection endpoint.
+const introspectPath = "/api/v1/auth/introspect"
+
+// introspectTimeout bounds a call to the auth service.
+const introspectTimeout = 5 * time.Second
+
+// Token scopes the admin API honors, alongside full access (no scope, or
+// "admin"). Reads need proxy:read, proxy:write, or read; anything else
+// needs proxy:write.
+const (
+	scopeAdmin      = "admin"
+	scopeRead       = "read"
+	scopeProxyRead  = "proxy:read"
+	scopeProxyWrite = "proxy:write"
+	scopeSearchAPI  = "search_api"
+)
+
+// introspection is the part of the auth service's answer the proxy uses.
+type introspection struct {
+	Active bool   `json:"active"`
+	Scope  string `json:"scope"`
+	Sub    string `json:"sub"`
+}
+
+// AdminAuth protects the admin API with North Cloud tokens: JWTs and
+// service keys, checked by introspecting them with the auth service, so the
+// proxy needs no JWT secret and sees revocations at once.
+type AdminAuth struct {
+	introspectURL string
+	client        *http.Client
+}
+
+// NewAdminAuth creates an AdminAuth for the auth service at authURL
+// (e.g. "http://auth:8040").
+func NewAdminAuth(authURL string) *AdminAuth {
+	return &AdminAuth{
+		introspectURL: strings.TrimRight(authURL, "/") + introspectPath,
+		client:        &http.Client{Timeout: introspectTimeout},
+	}
+}
+
+// Wrap requires a bearer token (or X-API-Key service key) on every request
+// to next, and a token allowed to write on requests other than GET.
+func (a *AdminAuth) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r)
+		if token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "missing authorization"})
+			return
+		}
+
+		result, err := a.introspect(r.Context(), token)
+		if err != nil {
+			fmt.Printf("warning: admin token introspection failed: %v\n", err)
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "token introspection unavailable"})
+			return
+		}
+		if !result.Active || result.Scope == scopeSearchAPI {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid token"})
+			return
+		}
+
+		required := scopeProxyWrite
+		if r.Method == http.MethodGet {
+			required = scopeProxyRead
+		}
+		if !grants(result.Scope, required) {
+			writeJSON(w, http.StatusForbidden, map[string]string{
+				"error":          "token scope does not allow this operation",
+				"required_scope": required,
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// introspect asks the auth service about token.
+func (a *AdminAuth) introspect(ctx context.Context, token string) (*introspection, error) {
+	form := url.Values{"token": {token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.introspectURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspect token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspect token: auth service returned status %d", resp.StatusCode)
+	}
+
+	var result introspection
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&result); decodeErr != nil {
+		return nil, fmt.Errorf("decode introspection response: %w", decodeErr)
+	}
+	return &result, nil
+}
+
+// grants reports whether a token with scope may do what required allows.
+func grants(scope, required string) bool {
+	scopes := strings.Fields(scope)
+	if len(scopes) == 0 || slices.Contains(scopes, scopeAdmin) || slices.Contains(scopes, scopeProxyWrite) {
+		return true
+	}
+	if required != scopeProxyRead {
+		return false
+	}
+	return slices.Contains(scopes, scopeProxyRead) || slices.Contains(scopes, scopeRead)
+}
+
+// bearerToken returns the request's bearer token or X-API-Key header.
+func bearerToken(r *http.Request) string {
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found {
+		return strings.TrimSpace(token)
+	}
+	return r.Header.Get("X-API-Key")
+}
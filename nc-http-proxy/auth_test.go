@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newFakeAuth answers introspection requests: "admin" has full access,
+// "viewer" may read, "proxy-key" holds proxy:write, "api-key" is a search
+// API key, "broken" fails, and every other token is inactive.
+func newFakeAuth(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != introspectPath || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		answers := map[string]introspection{
+			"admin":     {Active: true, Sub: "dashboard"},
+			"viewer":    {Active: true, Sub: "ana@example.com", Scope: scopeRead},
+			"proxy-key": {Active: true, Sub: "ci", Scope: "crawler:jobs:read " + scopeProxyWrite},
+			"api-key":   {Active: true, Sub: "partner", Scope: scopeSearchAPI},
+		}
+		token := r.PostFormValue("token")
+		if token == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(answers[token])
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAdminAuth(t *testing.T) {
+	t.Helper()
+	cfg := &Config{
+		Mode:        ModeReplay,
+		FixturesDir: t.TempDir(),
+		CacheDir:    t.TempDir(),
+		CertsDir:    t.TempDir(),
+	}
+	proxy, err := NewProxy(cfg)
+	if err != nil {
+		t.Fatalf("failed to create proxy: %v", err)
+	}
+	admin := NewAdminAuth(newFakeAuth(t).URL + "/").Wrap(NewAdminHandler(proxy))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		method string
+		path   string
+		want   int
+	}{
+		{"no token", "", "", http.MethodGet, "/admin/status", http.StatusUnauthorized},
+		{"inactive token", "Authorization", "Bearer revoked", http.MethodGet, "/admin/status", http.StatusUnauthorized},
+		{"search api key", "Authorization", "Bearer api-key", http.MethodGet, "/admin/status", http.StatusUnauthorized},
+		{"viewer reads", "Authorization", "Bearer viewer", http.MethodGet, "/admin/status", http.StatusOK},
+		{"viewer cannot switch mode", "Authorization", "Bearer viewer", http.MethodPost, "/admin/mode/live", http.StatusForbidden},
+		{"admin switches mode", "Authorization", "Bearer admin", http.MethodPost, "/admin/mode/record", http.StatusOK},
+		{"service key with proxy:write", "X-API-Key", "proxy-key", http.MethodDelete, "/admin/cache", http.StatusOK},
+		{"auth unavailable", "Authorization", "Bearer broken", http.MethodGet, "/admin/status", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			admin.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d (body %s)", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	if proxy.Mode() != ModeRecord {
+		t.Errorf("expected mode record after the admin's switch, got %s", proxy.Mode())
+	}
+}
@@ -33,6 +33,9 @@ type Config struct {
 	CacheDir    string
 	CertsDir    string
 	LiveTimeout time.Duration
+	// AuthURL is the auth service that admin API tokens are introspected
+	// with. Empty leaves the admin API open.
+	AuthURL string
 }
 
 // Default configuration values.
@@ -87,5 +90,7 @@ func LoadConfig() *Config {
 		}
 	}
 
+	cfg.AuthURL = os.Getenv("PROXY_AUTH_URL")
+
 	return cfg
 }
@@ -40,6 +40,7 @@ func TestLoadConfigFromEnv(t *testing.T) {
 	t.Setenv("PROXY_FIXTURES_DIR", "/custom/fixtures")
 	t.Setenv("PROXY_CACHE_DIR", "/custom/cache")
 	t.Setenv("PROXY_LIVE_TIMEOUT", "60s")
+	t.Setenv("PROXY_AUTH_URL", "http://auth:8040")
 
 	cfg := LoadConfig()
 
@@ -55,6 +56,9 @@ func TestLoadConfigFromEnv(t *testing.T) {
 	if cfg.CacheDir != "/custom/cache" {
 		t.Errorf("expected cache dir /custom/cache, got %s", cfg.CacheDir)
 	}
+	if cfg.AuthURL != "http://auth:8040" {
+		t.Errorf("expected auth url http://auth:8040, got %s", cfg.AuthURL)
+	}
 }
 
 func TestModeIsValid(t *testing.T) {
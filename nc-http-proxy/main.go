@@ -26,7 +26,11 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to create proxy: %w", err)
 	}
-	admin := NewAdminHandler(proxy)
+	var admin http.Handler = NewAdminHandler(proxy)
+	if cfg.AuthURL != "" {
+		admin = NewAdminAuth(cfg.AuthURL).Wrap(admin)
+		fmt.Printf("Admin API requires tokens introspected with %s\n", cfg.AuthURL)
+	}
 
 	mux := http.NewServeMux()
 
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
 )
 
 // Start initializes and runs the pipeline service.
 func Start() error {
+	defer crash.Start("pipeline")()
+
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("pipeline"); pyroErr != nil {
 		fmt.Fprintf(os.Stderr, "WARNING: Pyroscope failed to start: %v\n", pyroErr)
@@ -8,6 +8,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/jmoiron/sqlx"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	esclient "github.com/jonesrussell/north-cloud/infrastructure/elasticsearch"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
@@ -19,13 +20,13 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-const defaultServerPort = ":8070"
-
 func main() {
 	os.Exit(run())
 }
 
 func run() int {
+	defer crash.Start("publisher-api")()
+
 	// Start profiling server (if enabled)
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("publisher-api"); pyroErr != nil {
@@ -80,24 +81,12 @@ func run() int {
 	return 0
 }
 
-// loadAndValidateConfig loads configuration from file or creates default
+// loadAndValidateConfig loads configuration from config.yml when present,
+// with environment overrides, and exits when it is invalid
 func loadAndValidateConfig(infraLog logger.Logger) *config.Config {
-	configPath := infraconfig.GetConfigPath("config.yml")
-	cfg, err := config.Load(configPath)
+	cfg, err := config.Resolve(infraconfig.GetConfigPath("config.yml"))
 	if err != nil {
-		// Config file is optional - create default config if file doesn't exist
-		infraLog.Warn("Failed to load config file, using defaults",
-			logger.String("config_path", configPath),
-			logger.Error(err),
-		)
-		cfg = &config.Config{}
-		// Apply defaults manually
-		if cfg.Server.Address == "" {
-			cfg.Server.Address = defaultServerPort
-		}
-		if validateErr := cfg.Validate(); validateErr != nil {
-			infraLog.Fatal("Invalid default configuration", logger.Error(validateErr))
-		}
+		infraLog.Fatal("Invalid configuration", logger.Error(err))
 	}
 	return cfg
 }
@@ -9,9 +9,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/pipeline"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
+	"github.com/jonesrussell/north-cloud/publisher/internal/credentials"
 	"github.com/jonesrussell/north-cloud/publisher/internal/database"
 	"github.com/jonesrussell/north-cloud/publisher/internal/discovery"
 	"github.com/jonesrussell/north-cloud/publisher/internal/router"
@@ -23,6 +25,8 @@ const (
 )
 
 func main() {
+	defer crash.Start("publisher-router")()
+
 	// Start profiling server (if enabled)
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("publisher-router"); pyroErr != nil {
@@ -86,8 +90,23 @@ func main() {
 		PollInterval:      cfg.PollInterval,
 		DiscoveryInterval: cfg.DiscoveryInterval,
 		BatchSize:         cfg.BatchSize,
+		ClickBaseURL:      cfg.ClickBaseURL,
+		ClickKey:          cfg.ClickKey,
+		Domains:           cfg.Domains,
+		DedupWindow:       cfg.DedupWindow,
+		HealthInterval:    cfg.HealthInterval,
+		CircuitThreshold:  cfg.CircuitThreshold,
+		RedisDelivery:     cfg.RedisDelivery,
+		StreamMaxLen:      cfg.StreamMaxLen,
+	}
+	// Initialize credential store (channels referencing stored credentials fail delivery without a key)
+	credentialStore, credErr := credentials.NewStore(repo, cfg.Credentials.Key, cfg.Credentials.PreviousKey)
+	if credErr != nil {
+		appLogger.Fatal("Failed to initialize credential store", infralogger.Error(credErr))
 	}
-	routerService := router.NewService(repo, discoveryService, esClient, redisClient, routerConfig, appLogger, pipelineClient, nil)
+
+	routerService := router.NewService(repo, discoveryService, esClient, redisClient, routerConfig, appLogger, pipelineClient, nil).
+		WithCredentials(credentialStore)
 
 	// Setup graceful shutdown
 	serviceCtx, cancel := context.WithCancel(context.Background())
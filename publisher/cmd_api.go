@@ -58,27 +58,12 @@ func runAPIServerWithStop() (func(), error) {
 
 	infraLog.Info("Starting Publisher API Server")
 
-	// Load configuration
-	configPath := infraconfig.GetConfigPath("config.yml")
-	cfg, err := config.Load(configPath)
+	// Load configuration (config.yml when present, environment overrides)
+	cfg, err := config.Resolve(infraconfig.GetConfigPath("config.yml"))
 	if err != nil {
-		// Config file is optional - try loading from environment variables only
-		infraLog.Warn("Failed to load config file, trying environment variables",
-			infralogger.String("config_path", configPath),
-			infralogger.Error(err),
-		)
-		cfg = &config.Config{}
-		// Apply environment variables to the config
-		if envErr := infraconfig.ApplyEnvOverrides(cfg); envErr != nil {
-			infraLog.Error("Failed to apply environment overrides", infralogger.Error(envErr))
-		}
-		// Apply defaults for any missing values
-		config.SetDefaults(cfg)
-		if validateErr := cfg.Validate(); validateErr != nil {
-			infraLog.Error("Invalid configuration from environment", infralogger.Error(validateErr))
-			_ = infraLog.Sync()
-			return nil, fmt.Errorf("invalid configuration from environment: %w", validateErr)
-		}
+		infraLog.Error("Invalid configuration", infralogger.Error(err))
+		_ = infraLog.Sync()
+		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
 	// Convert config database to database.Config
@@ -164,25 +149,10 @@ func runAPIServerInternal() int {
 
 	infraLog.Info("Starting Publisher API Server")
 
-	// Load configuration
-	configPath := infraconfig.GetConfigPath("config.yml")
-	cfg, err := config.Load(configPath)
+	// Load configuration (config.yml when present, environment overrides)
+	cfg, err := config.Resolve(infraconfig.GetConfigPath("config.yml"))
 	if err != nil {
-		// Config file is optional - try loading from environment variables only
-		infraLog.Warn("Failed to load config file, trying environment variables",
-			infralogger.String("config_path", configPath),
-			infralogger.Error(err),
-		)
-		cfg = &config.Config{}
-		// Apply environment variables to the config
-		if envErr := infraconfig.ApplyEnvOverrides(cfg); envErr != nil {
-			infraLog.Error("Failed to apply environment overrides", infralogger.Error(envErr))
-		}
-		// Apply defaults for any missing values
-		config.SetDefaults(cfg)
-		if validateErr := cfg.Validate(); validateErr != nil {
-			infraLog.Fatal("Invalid configuration from environment", infralogger.Error(validateErr))
-		}
+		infraLog.Fatal("Invalid configuration", infralogger.Error(err))
 	}
 
 	// Convert config database to database.Config
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+)
+
+// runConfig runs a config subcommand. "check" loads the configuration the way
+// every other command does and reports whether it is valid, YAML keys and
+// environment variables the publisher ignores, and with --show every
+// resolved setting (secrets masked). Exits 1 when the configuration is invalid.
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "Usage: publisher config check [--show]")
+		return 1
+	}
+	show := len(args) > 1 && args[1] == "--show"
+
+	path := infraconfig.GetConfigPath("config.yml")
+	cfg, resolveErr := config.Resolve(path)
+
+	// CLI output (not operational log)
+	warnings := config.StrayEnv(os.Environ())
+	unknown, err := config.UnknownKeys(path)
+	if err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	for _, key := range unknown {
+		warnings = append(warnings, path+": "+key)
+	}
+
+	if resolveErr == nil {
+		if cfg.Source == "" {
+			fmt.Printf("Config file: none (%s not found), environment variables only\n", path)
+		} else {
+			fmt.Printf("Config file: %s, environment variables override it\n", cfg.Source)
+		}
+	}
+	for _, warning := range warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	if resolveErr != nil {
+		fmt.Printf("Configuration is invalid: %v\n", resolveErr)
+		return 1
+	}
+
+	if show {
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, setting := range config.Settings(cfg) {
+			source := ""
+			if setting.FromEnv {
+				source = "from " + setting.Env
+			} else if setting.Env != "" {
+				source = "(" + setting.Env + ")"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", setting.Key, setting.Value, source)
+		}
+		_ = w.Flush()
+		fmt.Println()
+	}
+	fmt.Println("Configuration is valid.")
+	return 0
+}
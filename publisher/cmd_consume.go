@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/streams"
+	"github.com/redis/go-redis/v9"
+)
+
+// runConsume reads channel streams through a consumer group and prints each
+// message as "<stream> <id> <payload>", acknowledging it once printed. It is
+// a reference consumer for checking delivery: run it with a group of its own
+// so it does not take messages from a real consumer's group.
+func runConsume(args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: publisher consume <group> <channel>...")
+		return 1
+	}
+
+	cfg, err := config.Resolve(infraconfig.GetConfigPath("config.yml"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		return 1
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.URL,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer client.Close()
+
+	name, err := os.Hostname()
+	if err != nil {
+		name = "publisher-consume"
+	}
+	consumer := streams.NewConsumer(client, streams.ConsumerConfig{
+		Streams: args[1:],
+		Group:   args[0],
+		Name:    fmt.Sprintf("%s-%d", name, os.Getpid()),
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// CLI output (not operational log)
+	runErr := consumer.Run(ctx, func(_ context.Context, msg streams.Message) error {
+		fmt.Printf("%s %s %s\n", msg.Stream, msg.ID, msg.Payload)
+		return nil
+	})
+	if runErr != nil && ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "Consume failed: %v\n", runErr)
+		return 1
+	}
+	return 0
+}
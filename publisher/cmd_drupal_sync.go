@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+)
+
+// drupalSyncTimeout bounds a whole sync run.
+const drupalSyncTimeout = 2 * time.Minute
+
+// runDrupalSync compares the Drupal site's groups with the channels table and
+// prints the result. With --create it also creates the proposed (disabled)
+// channel for every group that has none.
+func runDrupalSync(args []string) int {
+	create := len(args) > 0 && args[0] == "--create"
+
+	cfg, err := config.Resolve(infraconfig.GetConfigPath("config.yml"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		return 1
+	}
+	if cfg.Drupal.URL == "" {
+		fmt.Fprintln(os.Stderr, "DRUPAL_URL is not set")
+		return 1
+	}
+
+	db, err := database.NewPostgresConnection(database.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), drupalSyncTimeout)
+	defer cancel()
+
+	client := drupal.NewClient(drupal.Config{
+		BaseURL:    cfg.Drupal.URL,
+		Token:      cfg.Drupal.Token,
+		GroupTypes: cfg.Drupal.GroupTypes,
+	}, &http.Client{Timeout: cfg.Drupal.Timeout})
+	groups, err := client.ListGroups(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list Drupal groups: %v\n", err)
+		return 1
+	}
+
+	repo := database.NewRepository(db)
+	channels, err := repo.ListChannels(ctx, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list channels: %v\n", err)
+		return 1
+	}
+
+	// CLI output (not operational log)
+	plan := drupal.Plan(groups, channels)
+	for _, linked := range plan.Linked {
+		note := ""
+		if linked.LabelChanged {
+			note = fmt.Sprintf(" (group now labelled %q)", linked.Group.Label)
+		}
+		fmt.Printf("linked    %s -> %s%s\n", linked.Group.ID, linked.ChannelName, note)
+	}
+	for _, orphan := range plan.Orphaned {
+		fmt.Printf("orphaned  %s -> %s (group no longer listed)\n", orphan.GroupID, orphan.ChannelName)
+	}
+
+	failed := 0
+	for i := range plan.Missing {
+		proposal := &plan.Missing[i]
+		if !create {
+			fmt.Printf("missing   %s -> would create %q (%s)\n",
+				proposal.Group.ID, proposal.Channel.Name, proposal.Channel.Slug)
+			continue
+		}
+		if _, createErr := repo.CreateChannel(ctx, &proposal.Channel); createErr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create channel for group %s: %v\n", proposal.Group.ID, createErr)
+			failed++
+			continue
+		}
+		fmt.Printf("created   %s -> %q (%s, disabled)\n", proposal.Group.ID, proposal.Channel.Name, proposal.Channel.Slug)
+	}
+
+	fmt.Printf("\n%d linked, %d missing, %d orphaned\n", len(plan.Linked), len(plan.Missing), len(plan.Orphaned))
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
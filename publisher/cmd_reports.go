@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/clicks"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/reports"
+)
+
+// reportsRunTimeout bounds a whole weekly email run.
+const reportsRunTimeout = 10 * time.Minute
+
+// runWeeklyReports emails last week's report (or the week containing the date
+// given as the next argument) to each channel's report recipients. Meant to
+// run from cron, e.g. Mondays at 06:00 UTC.
+func runWeeklyReports(args []string) int {
+	log, err := infralogger.New(infralogger.Config{Level: "info", Format: "json"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create logger: %v\n", err)
+		return 1
+	}
+	defer func() { _ = log.Sync() }()
+	log = log.With(infralogger.String("service", "publisher-reports"))
+
+	weekStart := reports.LastCompleteWeek(time.Now())
+	if len(args) > 0 {
+		parsed, parseErr := time.Parse("2006-01-02", args[0])
+		if parseErr != nil {
+			fmt.Fprintf(os.Stderr, "Invalid week date %q (want YYYY-MM-DD)\n", args[0])
+			return 1
+		}
+		weekStart = reports.WeekStart(parsed)
+	}
+
+	cfg, err := config.Resolve(infraconfig.GetConfigPath("config.yml"))
+	if err != nil {
+		log.Error("Invalid configuration", infralogger.Error(err))
+		return 1
+	}
+
+	db, err := database.NewPostgresConnection(database.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+	})
+	if err != nil {
+		log.Error("Failed to connect to database", infralogger.Error(err))
+		return 1
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), reportsRunTimeout)
+	defer cancel()
+
+	repo := database.NewRepository(db)
+	channels, err := repo.ListChannels(ctx, true)
+	if err != nil {
+		log.Error("Failed to list channels", infralogger.Error(err))
+		return 1
+	}
+
+	gen := reports.NewGenerator(repo,
+		clicks.NewClient(cfg.ClickTracker.URL, cfg.Auth.JWTSecret, cfg.ClickTracker.Timeout))
+	result, err := reports.SendWeekly(ctx, gen, reports.NewMailer(cfg.Reports), channels, weekStart, log)
+	if err != nil {
+		log.Error("Weekly reports not sent", infralogger.Error(err))
+		return 1
+	}
+
+	log.Info("Weekly reports complete",
+		infralogger.String("week_start", weekStart.Format("2006-01-02")),
+		infralogger.Int("sent", result.Sent),
+		infralogger.Int("skipped", result.Skipped),
+		infralogger.Int("failed", result.Failed),
+	)
+	if result.Failed > 0 {
+		return 1
+	}
+	return 0
+}
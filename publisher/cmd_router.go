@@ -12,6 +12,7 @@ import (
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/pipeline"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
+	"github.com/jonesrussell/north-cloud/publisher/internal/credentials"
 	"github.com/jonesrussell/north-cloud/publisher/internal/database"
 	"github.com/jonesrussell/north-cloud/publisher/internal/discovery"
 	"github.com/jonesrussell/north-cloud/publisher/internal/router"
@@ -97,8 +98,26 @@ func runRouterWithStop() (func(), error) {
 		PollInterval:      cfg.PollInterval,
 		DiscoveryInterval: cfg.DiscoveryInterval,
 		BatchSize:         cfg.BatchSize,
+		ClickBaseURL:      cfg.ClickBaseURL,
+		ClickKey:          cfg.ClickKey,
+		DrupalURL:         cfg.DrupalURL,
+		DrupalToken:       cfg.DrupalToken,
+		Domains:           cfg.Domains,
+		DedupWindow:       cfg.DedupWindow,
+		HealthInterval:    cfg.HealthInterval,
+		CircuitThreshold:  cfg.CircuitThreshold,
+		RedisDelivery:     cfg.RedisDelivery,
+		StreamMaxLen:      cfg.StreamMaxLen,
 	}
-	routerService := router.NewService(repo, discoveryService, esClient, redisClient, routerConfig, appLogger, pipelineClient, tp)
+	// Initialize credential store (channels referencing stored credentials fail delivery without a key)
+	credentialStore, credErr := credentials.NewStore(repo, cfg.Credentials.Key, cfg.Credentials.PreviousKey)
+	if credErr != nil {
+		_ = appLogger.Sync()
+		return nil, fmt.Errorf("failed to initialize credential store: %w", credErr)
+	}
+
+	routerService := router.NewService(repo, discoveryService, esClient, redisClient, routerConfig, appLogger, pipelineClient, tp).
+		WithCredentials(credentialStore)
 
 	// Setup graceful shutdown context
 	serviceCtx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// defaultApprovalListLimit is the page size of approval listings without a limit
+const defaultApprovalListLimit = 50
+
+// listApprovals lists items routed to channels that require approval
+// GET /api/v1/approvals?status=pending&channel_id=...&limit=50&offset=0
+func (r *Router) listApprovals(c *gin.Context) {
+	var filter models.ApprovalFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultApprovalListLimit
+	}
+
+	approvals, total, err := r.repo.ListChannelApprovals(c.Request.Context(), &filter)
+	if err != nil {
+		r.handleRepositoryError(c, err, "approval", "list")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"approvals": approvals,
+		"count":     len(approvals),
+		"total":     total,
+		"limit":     filter.Limit,
+		"offset":    filter.Offset,
+	})
+}
+
+// getApproval returns one approval item
+// GET /api/v1/approvals/:id
+func (r *Router) getApproval(c *gin.Context) {
+	id, ok := parseUUID(c, "id", "approval")
+	if !ok {
+		return
+	}
+
+	approval, err := r.repo.GetChannelApproval(c.Request.Context(), id)
+	if err != nil {
+		r.handleRepositoryError(c, err, "approval", "get")
+		return
+	}
+
+	c.JSON(http.StatusOK, approval)
+}
+
+// approveItem approves a pending item, optionally with edits; the router
+// delivers it on its next poll
+// POST /api/v1/approvals/:id/approve
+func (r *Router) approveItem(c *gin.Context) {
+	r.reviewApproval(c, models.ApprovalStatusApproved)
+}
+
+// rejectItem rejects a pending item so it is never delivered
+// POST /api/v1/approvals/:id/reject
+func (r *Router) rejectItem(c *gin.Context) {
+	r.reviewApproval(c, models.ApprovalStatusRejected)
+}
+
+// reviewApproval records an editor's decision on a pending item
+func (r *Router) reviewApproval(c *gin.Context, status string) {
+	id, ok := parseUUID(c, "id", "approval")
+	if !ok {
+		return
+	}
+
+	var req models.ApprovalReviewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request payload",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+	if status == models.ApprovalStatusRejected {
+		req.Edits = nil
+	}
+
+	reviewer := requestActor(c)
+	approval, err := r.repo.ReviewChannelApproval(c.Request.Context(), id, status, reviewer, req.Note, req.Edits)
+	if err != nil {
+		r.handleApprovalError(c, err, "review")
+		return
+	}
+
+	r.log.Info("Approval reviewed",
+		infralogger.String("approval_id", id.String()),
+		infralogger.String("content_id", approval.ContentID),
+		infralogger.String("status", status),
+		infralogger.String("reviewed_by", reviewer),
+	)
+
+	c.JSON(http.StatusOK, approval)
+}
+
+// editApproval replaces the title, body, or summary edits of a pending item
+// PATCH /api/v1/approvals/:id
+func (r *Router) editApproval(c *gin.Context) {
+	id, ok := parseUUID(c, "id", "approval")
+	if !ok {
+		return
+	}
+
+	var edits models.ApprovalEdits
+	if err := c.ShouldBindJSON(&edits); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+	if edits.IsEmpty() {
+		handleValidationError(c, models.ErrNoFieldsToUpdate)
+		return
+	}
+
+	approval, err := r.repo.EditChannelApproval(c.Request.Context(), id, &edits)
+	if err != nil {
+		r.handleApprovalError(c, err, "edit")
+		return
+	}
+
+	c.JSON(http.StatusOK, approval)
+}
+
+// handleApprovalError maps an already-reviewed item to 409 and other errors
+// through handleRepositoryError
+func (r *Router) handleApprovalError(c *gin.Context, err error, operation string) {
+	if errors.Is(err, models.ErrApprovalNotPending) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "approval has already been reviewed",
+		})
+		return
+	}
+	r.handleRepositoryError(c, err, "approval", operation)
+}
+
+// requestActor returns the JWT subject of the caller, or "" when unauthenticated
+func requestActor(c *gin.Context) string {
+	if claims, ok := infrajwt.GetClaims(c); ok {
+		return claims.Sub
+	}
+	return ""
+}
@@ -0,0 +1,210 @@
+//nolint:testpackage // Testing unexported handlers requires same package access
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var approvalTestColumns = []string{
+	"id", "channel_id", "content_id", "title", "url", "source", "quality_score", "topics", "payload",
+	"edits", "status", "reviewed_by", "review_note", "reviewed_at", "created_at", "updated_at",
+}
+
+// newApprovalTestEngine serves the approval endpoints from a mocked database.
+func newApprovalTestEngine(t *testing.T) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	r := &Router{repo: database.NewRepository(sqlx.NewDb(db, "postgres")), log: infralogger.NewNop()}
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	approvals := engine.Group("/api/v1/approvals")
+	approvals.GET("", r.listApprovals)
+	approvals.GET("/:id", r.getApproval)
+	approvals.PATCH("/:id", r.editApproval)
+	approvals.POST("/:id/approve", r.approveItem)
+	approvals.POST("/:id/reject", r.rejectItem)
+	return engine, mock
+}
+
+func serveApprovals(engine *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequestWithContext(context.Background(), method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func approvalRow(id uuid.UUID, status, edits string) *sqlmock.Rows {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var editsJSON []byte
+	if edits != "" {
+		editsJSON = []byte(edits)
+	}
+	return sqlmock.NewRows(approvalTestColumns).AddRow(
+		id, uuid.New(), "doc-1", "Title", "https://example.com/doc-1", "example", 80, "{violent_crime}",
+		[]byte(`{"id":"doc-1"}`), editsJSON, status, "", "", now, now, now,
+	)
+}
+
+func TestApproveItem_WithEdits(t *testing.T) {
+	engine, mock := newApprovalTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").
+		WithArgs(id, models.ApprovalStatusApproved, "", "tightened the headline", `{"title":"Edited title"}`).
+		WillReturnRows(approvalRow(id, models.ApprovalStatusApproved, `{"title":"Edited title"}`))
+
+	rec := serveApprovals(engine, http.MethodPost, "/api/v1/approvals/"+id.String()+"/approve",
+		`{"note":"tightened the headline","edits":{"title":"Edited title"}}`)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var approval models.ChannelApproval
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &approval))
+	assert.Equal(t, models.ApprovalStatusApproved, approval.Status)
+	require.NotNil(t, approval.Edits.Title)
+	assert.Equal(t, "Edited title", *approval.Edits.Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApproveItem_WithoutBody(t *testing.T) {
+	engine, mock := newApprovalTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").
+		WithArgs(id, models.ApprovalStatusApproved, "", "", nil).
+		WillReturnRows(approvalRow(id, models.ApprovalStatusApproved, ""))
+
+	rec := serveApprovals(engine, http.MethodPost, "/api/v1/approvals/"+id.String()+"/approve", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRejectItem_IgnoresEdits(t *testing.T) {
+	engine, mock := newApprovalTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").
+		WithArgs(id, models.ApprovalStatusRejected, "", "off topic", nil).
+		WillReturnRows(approvalRow(id, models.ApprovalStatusRejected, ""))
+
+	rec := serveApprovals(engine, http.MethodPost, "/api/v1/approvals/"+id.String()+"/reject",
+		`{"note":"off topic","edits":{"title":"Edited title"}}`)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var approval models.ChannelApproval
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &approval))
+	assert.Equal(t, models.ApprovalStatusRejected, approval.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewApproval_AlreadyReviewed(t *testing.T) {
+	engine, mock := newApprovalTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").WillReturnRows(sqlmock.NewRows(approvalTestColumns))
+	mock.ExpectQuery("SELECT (.+) FROM channel_approvals WHERE id").WithArgs(id).
+		WillReturnRows(approvalRow(id, models.ApprovalStatusRejected, ""))
+
+	rec := serveApprovals(engine, http.MethodPost, "/api/v1/approvals/"+id.String()+"/approve", "")
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewApproval_NotFound(t *testing.T) {
+	engine, mock := newApprovalTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").WillReturnRows(sqlmock.NewRows(approvalTestColumns))
+	mock.ExpectQuery("SELECT (.+) FROM channel_approvals WHERE id").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(approvalTestColumns))
+
+	rec := serveApprovals(engine, http.MethodPost, "/api/v1/approvals/"+id.String()+"/reject", "")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewApproval_InvalidRequests(t *testing.T) {
+	engine, _ := newApprovalTestEngine(t)
+	id := uuid.New().String()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"invalid id", http.MethodPost, "/api/v1/approvals/not-a-uuid/approve", ""},
+		{"malformed body", http.MethodPost, "/api/v1/approvals/" + id + "/approve", `{"note":`},
+		{"empty title edit", http.MethodPost, "/api/v1/approvals/" + id + "/approve", `{"edits":{"title":""}}`},
+		{"edit without fields", http.MethodPatch, "/api/v1/approvals/" + id, `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveApprovals(engine, tt.method, tt.path, tt.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestEditApproval(t *testing.T) {
+	engine, mock := newApprovalTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").
+		WithArgs(id, `{"body":"Edited body"}`).
+		WillReturnRows(approvalRow(id, models.ApprovalStatusPending, `{"body":"Edited body"}`))
+
+	rec := serveApprovals(engine, http.MethodPatch, "/api/v1/approvals/"+id.String(), `{"body":"Edited body"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var approval models.ChannelApproval
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &approval))
+	assert.Equal(t, models.ApprovalStatusPending, approval.Status)
+	require.NotNil(t, approval.Edits.Body)
+	assert.Equal(t, "Edited body", *approval.Edits.Body)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListApprovals_DefaultsToPageSize(t *testing.T) {
+	engine, mock := newApprovalTestEngine(t)
+
+	mock.ExpectQuery("SELECT COUNT").WithArgs(models.ApprovalStatusPending).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM channel_approvals").
+		WithArgs(models.ApprovalStatusPending, defaultApprovalListLimit, 0).
+		WillReturnRows(approvalRow(uuid.New(), models.ApprovalStatusPending, ""))
+
+	rec := serveApprovals(engine, http.MethodGet, "/api/v1/approvals?status=pending", "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Approvals []models.ChannelApproval `json:"approvals"`
+		Limit     int                      `json:"limit"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Approvals, 1)
+	assert.Equal(t, defaultApprovalListLimit, body.Limit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
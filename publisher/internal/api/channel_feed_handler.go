@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// Feed channel output formats.
+const (
+	feedFormatRSS    = "rss"
+	feedFormatAtom   = "atom"
+	atomContentType  = "application/atom+xml; charset=utf-8"
+	feedCacheControl = "public, max-age=300"
+)
+
+// atomFeed is a minimal Atom 1.0 document.
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Links      []atomLink     `xml:"link"`
+	Summary    string         `xml:"summary,omitempty"`
+	Author     *atomAuthor    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// getChannelFeed serves a feed channel's most recent items as RSS 2.0 or Atom.
+// The route is public so feed readers can subscribe without credentials; only
+// enabled channels of type feed are served.
+// GET /feeds/:id?format=rss|atom
+func (r *Router) getChannelFeed(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", feedFormatRSS)
+	if format != feedFormatRSS && format != feedFormatAtom {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be rss or atom"})
+		return
+	}
+
+	channel, err := r.repo.GetChannelByID(ctx, channelID)
+	if err != nil {
+		r.handleRepositoryError(c, err, "feed", "get")
+		return
+	}
+	if channel.DeliveryType() != models.ChannelTypeFeed || !channel.Enabled {
+		c.JSON(http.StatusNotFound, gin.H{"error": "feed not found"})
+		return
+	}
+
+	items, err := r.repo.ListChannelFeedItems(ctx, channelID, models.FeedItemLimit(channel.Config.Feed))
+	if err != nil {
+		r.handleRepositoryError(c, err, "feed", "list items for")
+		return
+	}
+
+	var body []byte
+	contentType := rssContentType
+	if format == feedFormatAtom {
+		contentType = atomContentType
+		body, err = xml.MarshalIndent(buildAtomFeed(channel, items, c.Request.URL.String()), "", "  ")
+	} else {
+		body, err = xml.MarshalIndent(buildRSSFeed(channel, items), "", "  ")
+	}
+	if err != nil {
+		r.log.Error("Failed to render channel feed",
+			infralogger.String("channel_id", channelID.String()),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render feed"})
+		return
+	}
+
+	c.Header("Cache-Control", feedCacheControl)
+	if len(items) > 0 {
+		c.Header("Last-Modified", items[0].AddedAt.UTC().Format(http.TimeFormat))
+	}
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
+
+// feedTitle returns the configured feed title and description, falling back
+// to the channel's name and description.
+func feedTitle(channel *models.Channel) (title, description string) {
+	title, description = channel.Name, channel.Description
+	if cfg := channel.Config.Feed; cfg != nil {
+		if cfg.Title != "" {
+			title = cfg.Title
+		}
+		if cfg.Description != "" {
+			description = cfg.Description
+		}
+	}
+	return title, description
+}
+
+// feedLink returns the site link of a feed channel, if configured.
+func feedLink(channel *models.Channel) string {
+	if channel.Config.Feed == nil {
+		return ""
+	}
+	return channel.Config.Feed.Link
+}
+
+// feedUpdated is the time of the newest item, or now for an empty feed.
+func feedUpdated(items []models.ChannelFeedItem) time.Time {
+	if len(items) == 0 {
+		return time.Now().UTC()
+	}
+	return items[0].AddedAt.UTC()
+}
+
+// buildRSSFeed renders feed items as an RSS 2.0 document.
+func buildRSSFeed(channel *models.Channel, items []models.ChannelFeedItem) rssFeed {
+	title, description := feedTitle(channel)
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         title,
+			Link:          feedLink(channel),
+			Description:   description,
+			LastBuildDate: feedUpdated(items).Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(items)),
+		},
+	}
+
+	for i := range items {
+		it := &items[i]
+		item := rssItem{
+			Title:       it.Title,
+			Link:        it.URL,
+			GUID:        rssGUID{Value: it.ContentID},
+			PubDate:     feedItemTime(it).Format(time.RFC1123Z),
+			Description: it.Summary,
+			Categories:  it.Topics,
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+	return feed
+}
+
+// buildAtomFeed renders feed items as an Atom 1.0 document. self is the
+// request URL, used for the feed's rel="self" link.
+func buildAtomFeed(channel *models.Channel, items []models.ChannelFeedItem, self string) atomFeed {
+	title, description := feedTitle(channel)
+	feed := atomFeed{
+		ID:       "urn:uuid:" + channel.ID.String(),
+		Title:    title,
+		Subtitle: description,
+		Updated:  feedUpdated(items).Format(time.RFC3339),
+		Links:    []atomLink{{Href: self, Rel: "self"}},
+		Entries:  make([]atomEntry, 0, len(items)),
+	}
+	if link := feedLink(channel); link != "" {
+		feed.Links = append(feed.Links, atomLink{Href: link, Rel: "alternate"})
+	}
+
+	for i := range items {
+		it := &items[i]
+		entry := atomEntry{
+			ID:         "urn:north-cloud:content:" + it.ContentID,
+			Title:      it.Title,
+			Updated:    it.AddedAt.UTC().Format(time.RFC3339),
+			Published:  feedItemTime(it).Format(time.RFC3339),
+			Summary:    it.Summary,
+			Categories: make([]atomCategory, 0, len(it.Topics)),
+		}
+		if it.URL != "" {
+			entry.Links = []atomLink{{Href: it.URL, Rel: "alternate"}}
+		}
+		if it.Source != "" {
+			entry.Author = &atomAuthor{Name: it.Source}
+		}
+		for _, topic := range it.Topics {
+			entry.Categories = append(entry.Categories, atomCategory{Term: topic})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+// feedItemTime is the article's published date, or when it joined the feed.
+func feedItemTime(item *models.ChannelFeedItem) time.Time {
+	if item.PublishedAt != nil && !item.PublishedAt.IsZero() {
+		return item.PublishedAt.UTC()
+	}
+	return item.AddedAt.UTC()
+}
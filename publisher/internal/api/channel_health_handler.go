@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// attachChannelHealth sets the health record of every channel with a circuit
+// breaker (all types but redis). Channels never probed and never failed
+// report status unknown. A failed lookup leaves health unset.
+func (r *Router) attachChannelHealth(ctx context.Context, channels []models.Channel) {
+	attachHealthRecords(ctx, r.repo, r.log, channels)
+}
+
+// channelHealthLister loads the health record of every channel that has one.
+type channelHealthLister interface {
+	ListChannelHealth(ctx context.Context) (map[uuid.UUID]*models.ChannelHealth, error)
+}
+
+// attachHealthRecords is attachChannelHealth over any health record source.
+func attachHealthRecords(ctx context.Context, lister channelHealthLister, log infralogger.Logger, channels []models.Channel) {
+	health, err := lister.ListChannelHealth(ctx)
+	if err != nil {
+		log.Warn("Failed to load channel health", infralogger.Error(err))
+		return
+	}
+
+	for i := range channels {
+		ch := &channels[i]
+		if ch.DeliveryType() == models.ChannelTypeRedis {
+			continue
+		}
+		if h, ok := health[ch.ID]; ok {
+			ch.Health = h
+			continue
+		}
+		ch.Health = &models.ChannelHealth{
+			ChannelID: ch.ID,
+			Circuit:   models.CircuitClosed,
+			Status:    models.ChannelHealthUnknown,
+		}
+	}
+}
+
+// resetChannelHealth clears a channel's failures and closes its circuit; the
+// router picks this up on its next poll and releases the queued items.
+// DELETE /api/v1/channels/:id/health
+func (r *Router) resetChannelHealth(c *gin.Context) {
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	if err := r.repo.DeleteChannelHealth(c.Request.Context(), channelID); err != nil {
+		r.handleRepositoryError(c, err, "channel health", "reset")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Channel health reset",
+	})
+}
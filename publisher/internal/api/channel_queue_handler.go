@@ -0,0 +1,290 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+)
+
+// Queue feed formats and item statuses.
+const (
+	queueFormatJSON  = "json"
+	queueFormatRSS   = "rss"
+	queueStatusQueue = "queued"
+	queueStatusHeld  = "held"
+	rssContentType   = "application/rss+xml; charset=utf-8"
+)
+
+// queuedItemResponse is the JSON view of an upcoming channel item.
+type queuedItemResponse struct {
+	ContentID    string    `json:"content_id"`
+	Title        string    `json:"title"`
+	URL          string    `json:"url"`
+	Source       string    `json:"source"`
+	ContentType  string    `json:"content_type"`
+	QualityScore int       `json:"quality_score"`
+	Topics       []string  `json:"topics"`
+	CrawledAt    time.Time `json:"crawled_at"`
+	Status       string    `json:"status,omitempty"`
+}
+
+// getChannelQueue returns content matching the channel that the router has not
+// published yet, as JSON or as a private RSS feed (?format=rss). RSS readers
+// can authenticate with ?token=<jwt>.
+// GET /api/v1/channels/:id/queue?limit=50&format=json|rss
+func (r *Router) getChannelQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", queueFormatJSON)
+	if format != queueFormatJSON && format != queueFormatRSS {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or rss"})
+		return
+	}
+
+	if r.esClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Elasticsearch not configured"})
+		return
+	}
+
+	channel, err := r.repo.GetChannelByID(ctx, channelID)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channel", "get")
+		return
+	}
+
+	cursor, err := r.repo.GetCursor(ctx)
+	if err != nil {
+		r.log.Error("Failed to load router cursor", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load router cursor"})
+		return
+	}
+
+	holds, err := r.repo.ListChannelHolds(ctx, channelID)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channel", "list holds for")
+		return
+	}
+	heldIDs := make(map[string]bool, len(holds))
+	for i := range holds {
+		heldIDs[holds[i].ContentID] = true
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	preview, err := router.NewQueuePreviewer(r.esClient, r.log).Preview(ctx, channel, cursor, heldIDs, limit)
+	if err != nil {
+		r.log.Error("Failed to preview channel queue",
+			infralogger.String("channel_id", channelID.String()),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview channel queue"})
+		return
+	}
+
+	if format == queueFormatRSS {
+		r.writeQueueRSS(c, channel, preview)
+		return
+	}
+
+	items := make([]queuedItemResponse, 0, len(preview.Items))
+	for i := range preview.Items {
+		items = append(items, toQueuedItemResponse(&preview.Items[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"channel_id":    channel.ID,
+		"channel":       channel.RedisChannel,
+		"enabled":       channel.Enabled,
+		"items":         items,
+		"count":         len(items),
+		"scanned":       preview.Scanned,
+		"truncated":     preview.Truncated,
+		"holds":         holds,
+		"generated_at":  time.Now().UTC(),
+		"rules_version": channel.RulesVersion,
+	})
+}
+
+// holdChannelItem pulls a content item from a channel so the router skips it
+// POST /api/v1/channels/:id/holds
+func (r *Router) holdChannelItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	var req models.ChannelHoldCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if _, err := r.repo.GetChannelByID(ctx, channelID); err != nil {
+		r.handleRepositoryError(c, err, "channel", "get")
+		return
+	}
+
+	hold, err := r.repo.CreateChannelHold(ctx, channelID, &req)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channel", "hold item for")
+		return
+	}
+
+	r.log.Info("Content held from channel",
+		infralogger.String("channel_id", channelID.String()),
+		infralogger.String("content_id", req.ContentID),
+	)
+
+	c.JSON(http.StatusCreated, hold)
+}
+
+// listChannelHolds lists items pulled from a channel
+// GET /api/v1/channels/:id/holds
+func (r *Router) listChannelHolds(c *gin.Context) {
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	holds, err := r.repo.ListChannelHolds(c.Request.Context(), channelID)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channel", "list holds for")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"holds": holds,
+		"count": len(holds),
+	})
+}
+
+// releaseChannelItem removes a hold. Items the router has already passed stay
+// unpublished; releasing only affects items still in the queue.
+// DELETE /api/v1/channels/:id/holds/:content_id
+func (r *Router) releaseChannelItem(c *gin.Context) {
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+	contentID := c.Param("content_id")
+
+	if err := r.repo.DeleteChannelHold(c.Request.Context(), channelID, contentID); err != nil {
+		r.handleRepositoryError(c, err, "hold", "release")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Hold released",
+	})
+}
+
+func toQueuedItemResponse(q *router.QueuedItem) queuedItemResponse {
+	status := queueStatusQueue
+	if q.Held {
+		status = queueStatusHeld
+	}
+	topics := q.Item.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return queuedItemResponse{
+		ContentID:    q.Item.ID,
+		Title:        q.Item.Title,
+		URL:          q.Item.URL,
+		Source:       q.Item.Source,
+		ContentType:  q.Item.ContentType,
+		QualityScore: q.Item.QualityScore,
+		Topics:       topics,
+		CrawledAt:    q.Item.CrawledAt,
+		Status:       status,
+	}
+}
+
+// rssFeed is a minimal RSS 2.0 document.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link,omitempty"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// writeQueueRSS renders a queue preview as an RSS 2.0 feed.
+func (r *Router) writeQueueRSS(c *gin.Context, channel *models.Channel, preview *router.QueuePreview) {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         "Upcoming: " + channel.Name,
+			Link:          c.Request.URL.Path,
+			Description:   fmt.Sprintf("Routed but unpublished items for %s", channel.RedisChannel),
+			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(preview.Items)),
+		},
+	}
+
+	for i := range preview.Items {
+		q := &preview.Items[i]
+		status := queueStatusQueue
+		if q.Held {
+			status = queueStatusHeld
+		}
+		item := rssItem{
+			Title: fmt.Sprintf("[%s] %s", status, q.Item.Title),
+			Link:  q.Item.URL,
+			GUID:  rssGUID{Value: q.Item.ID},
+			Description: fmt.Sprintf("Source: %s | Type: %s | Quality: %d | Topics: %s",
+				q.Item.Source, q.Item.ContentType, q.Item.QualityScore, strings.Join(q.Item.Topics, ", ")),
+			Categories: q.Item.Topics,
+		}
+		if !q.Item.CrawledAt.IsZero() {
+			item.PubDate = q.Item.CrawledAt.UTC().Format(time.RFC1123Z)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		r.log.Error("Failed to render queue feed", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render feed"})
+		return
+	}
+
+	c.Data(http.StatusOK, rssContentType, append([]byte(xml.Header), body...))
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+)
+
+// renderChannelItem returns the exact payload a channel would be sent for a
+// content item, after its templates and transforms, without delivering it.
+// Works for disabled channels and items the channel's rules do not match, so
+// editors can check a route before enabling it.
+// GET /api/v1/channels/:id/render/:content_id
+func (r *Router) renderChannelItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	contentID := c.Param("content_id")
+
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+	if r.esClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Elasticsearch not configured"})
+		return
+	}
+
+	channel, err := r.repo.GetChannelByID(ctx, channelID)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channel", "get")
+		return
+	}
+
+	item, err := router.FetchContentItem(ctx, r.esClient, contentID, r.log)
+	if errors.Is(err, router.ErrContentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "content item not found"})
+		return
+	}
+	if err != nil {
+		r.log.Error("Failed to fetch content item", infralogger.String("content_id", contentID), infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch content item"})
+		return
+	}
+
+	rendered, err := router.NewDeliveryRenderer(r.cfg.ClickTracker.BaseURL, r.cfg.ClickTracker.SigningKey()).
+		Render(channel, item)
+	if err != nil {
+		// Template errors and missing delivery config are the channel's, not the server's
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "Channel cannot render this item",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"channel_id":   channel.ID,
+		"channel":      channel.Name,
+		"enabled":      channel.Enabled,
+		"content_id":   item.ID,
+		"rules_match":  channel.Rules.Matches(item.RouteFields()),
+		"channel_type": rendered.ChannelType,
+		"payload":      rendered.Payload,
+		"notes":        rendered.Notes,
+	})
+}
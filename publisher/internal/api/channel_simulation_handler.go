@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+)
+
+// simulateRoute reports how many documents of the last N days a channel's
+// rules would have matched, by topic, quality band, and source, with a sample.
+// The rules are a draft, an existing channel's, or a draft applied to an
+// existing channel.
+// POST /api/v1/channels/simulate
+func (r *Router) simulateRoute(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.RouteSimulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+	if req.ChannelID == nil && req.Rules == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "channel_id or rules is required"})
+		return
+	}
+	if req.Rules != nil {
+		if err := req.Rules.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	rules := req.Rules
+	response := gin.H{"draft": req.Rules != nil}
+	if req.ChannelID != nil {
+		channel, err := r.repo.GetChannelByID(ctx, *req.ChannelID)
+		if err != nil {
+			r.handleRepositoryError(c, err, "channel", "get")
+			return
+		}
+		response["channel_id"] = channel.ID
+		response["channel"] = channel.RedisChannel
+		response["current_rules"] = channel.Rules
+		if rules == nil {
+			rules = &channel.Rules
+		}
+	}
+
+	simulation, ok := r.runSimulation(c, rules, req.Days, req.SampleSize)
+	if !ok {
+		return
+	}
+
+	response["rules"] = rules
+	response["simulation"] = simulation
+	response["sample_items"] = simulationSamples(simulation)
+	c.JSON(http.StatusOK, response)
+}
+
+// runSimulation simulates rules and writes the error response on failure
+func (r *Router) runSimulation(
+	c *gin.Context, rules *models.Rules, days, sampleSize int,
+) (*router.RouteSimulation, bool) {
+	if r.esClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Elasticsearch not configured"})
+		return nil, false
+	}
+
+	simulation, err := router.NewRouteSimulator(r.esClient, r.log).Simulate(c.Request.Context(), rules, days, sampleSize)
+	if err != nil {
+		r.log.Error("Failed to simulate route", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to simulate route"})
+		return nil, false
+	}
+	return simulation, true
+}
+
+// simulationSamples maps a simulation's sample matches to the queue item view
+func simulationSamples(simulation *router.RouteSimulation) []queuedItemResponse {
+	samples := make([]queuedItemResponse, 0, len(simulation.Samples))
+	for i := range simulation.Samples {
+		item := toQueuedItemResponse(&router.QueuedItem{Item: simulation.Samples[i]})
+		item.Status = ""
+		samples = append(samples, item)
+	}
+	return samples
+}
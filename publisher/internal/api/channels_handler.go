@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
@@ -27,6 +28,7 @@ func (r *Router) listChannels(c *gin.Context) {
 		})
 		return
 	}
+	r.attachChannelHealth(ctx, channels)
 
 	c.JSON(http.StatusOK, gin.H{
 		"channels": channels,
@@ -54,6 +56,9 @@ func (r *Router) createChannel(c *gin.Context) {
 		})
 		return
 	}
+	if !r.checkCredentialRefs(c, req.Config) {
+		return
+	}
 
 	channel, err := r.repo.CreateChannel(ctx, &req)
 	if err != nil {
@@ -79,8 +84,10 @@ func (r *Router) getChannel(c *gin.Context) {
 		r.handleRepositoryError(c, err, "channel", "get")
 		return
 	}
+	channels := []models.Channel{*channel}
+	r.attachChannelHealth(ctx, channels)
 
-	c.JSON(http.StatusOK, channel)
+	c.JSON(http.StatusOK, channels[0])
 }
 
 // updateChannel updates a channel
@@ -106,6 +113,9 @@ func (r *Router) updateChannel(c *gin.Context) {
 		handleValidationError(c, validateErr)
 		return
 	}
+	if !r.checkCredentialRefs(c, req.Config) {
+		return
+	}
 
 	channel, err := r.repo.UpdateChannel(ctx, id, &req)
 	if err != nil {
@@ -137,9 +147,9 @@ func (r *Router) deleteChannel(c *gin.Context) {
 	})
 }
 
-// previewChannel returns a preview of content that would match this channel's rules
-// GET /api/v1/channels/:id/preview
-// Note: This is a placeholder - full implementation would query Elasticsearch
+// previewChannel returns the content the channel's rules matched over the
+// last 7 days (?days=N to change), with a sample of the most recent matches
+// GET /api/v1/channels/:id/preview?days=7&sample_size=20
 func (r *Router) previewChannel(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -154,9 +164,14 @@ func (r *Router) previewChannel(c *gin.Context) {
 		return
 	}
 
-	// Build response with channel details and rules summary
-	// Full implementation would query Elasticsearch for matching content
-	response := gin.H{
+	days, _ := strconv.Atoi(c.Query("days"))
+	sampleSize, _ := strconv.Atoi(c.Query("sample_size"))
+	simulation, ok := r.runSimulation(c, &channel.Rules, days, sampleSize)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
 		"channel": channel,
 		"rules_summary": gin.H{
 			"include_topics": channel.Rules.IncludeTopics,
@@ -166,10 +181,8 @@ func (r *Router) previewChannel(c *gin.Context) {
 			"rules_is_empty": channel.Rules.IsEmpty(),
 			"rules_version":  channel.RulesVersion,
 		},
-		"matching_count": 0,       // Would be populated by ES query
-		"sample_items":   []any{}, // Would be populated by ES query
-		"note":           "Preview endpoint - full ES query not implemented yet",
-	}
-
-	c.JSON(http.StatusOK, response)
+		"matching_count": simulation.Matched,
+		"sample_items":   simulationSamples(simulation),
+		"simulation":     simulation,
+	})
 }
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/credentials"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// listCredentials lists stored credentials with masked values, optionally for one tenant
+// GET /api/v1/credentials?tenant=
+func (r *Router) listCredentials(c *gin.Context) {
+	creds, err := r.repo.ListCredentials(c.Request.Context(), c.Query("tenant"))
+	if err != nil {
+		r.handleRepositoryError(c, err, "credentials", "list")
+		return
+	}
+	for i := range creds {
+		creds[i].Mask()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"credentials": creds,
+		"count":       len(creds),
+		"enabled":     r.credentials.Enabled(),
+	})
+}
+
+// createCredential encrypts and stores a credential. The value is never returned.
+// POST /api/v1/credentials
+func (r *Router) createCredential(c *gin.Context) {
+	if !r.requireCredentialStore(c) {
+		return
+	}
+
+	var req models.CredentialCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cred, err := r.credentials.Create(c.Request.Context(), &req, requestActor(c))
+	if err != nil {
+		r.handleRepositoryError(c, err, "credential", "create")
+		return
+	}
+
+	r.log.Info("Credential created",
+		infralogger.String("credential", cred.Name),
+		infralogger.String("tenant", cred.Tenant),
+		infralogger.String("created_by", cred.CreatedBy),
+	)
+	cred.Mask()
+	c.JSON(http.StatusCreated, cred)
+}
+
+// getCredential returns a credential with its value masked
+// GET /api/v1/credentials/:id
+func (r *Router) getCredential(c *gin.Context) {
+	id, ok := parseUUID(c, "id", "credential")
+	if !ok {
+		return
+	}
+
+	cred, err := r.repo.GetCredentialByID(c.Request.Context(), id)
+	if err != nil {
+		r.handleRepositoryError(c, err, "credential", "get")
+		return
+	}
+
+	cred.Mask()
+	c.JSON(http.StatusOK, cred)
+}
+
+// rotateCredential replaces a credential's value. Channels referencing it use
+// the new value from their next delivery.
+// POST /api/v1/credentials/:id/rotate
+func (r *Router) rotateCredential(c *gin.Context) {
+	if !r.requireCredentialStore(c) {
+		return
+	}
+	id, ok := parseUUID(c, "id", "credential")
+	if !ok {
+		return
+	}
+
+	var req models.CredentialRotateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	cred, err := r.credentials.Rotate(c.Request.Context(), id, req.Value)
+	if err != nil {
+		r.handleRepositoryError(c, err, "credential", "rotate")
+		return
+	}
+
+	r.log.Info("Credential rotated",
+		infralogger.String("credential", cred.Name),
+		infralogger.Int("version", cred.Version),
+		infralogger.String("rotated_by", requestActor(c)),
+	)
+	cred.Mask()
+	c.JSON(http.StatusOK, cred)
+}
+
+// deleteCredential removes a credential no channel references
+// DELETE /api/v1/credentials/:id
+func (r *Router) deleteCredential(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := parseUUID(c, "id", "credential")
+	if !ok {
+		return
+	}
+
+	cred, err := r.repo.GetCredentialByID(ctx, id)
+	if err != nil {
+		r.handleRepositoryError(c, err, "credential", "delete")
+		return
+	}
+
+	channels, err := r.repo.ListChannels(ctx, false)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channels", "list")
+		return
+	}
+	users := make([]string, 0)
+	for i := range channels {
+		for _, ref := range channels[i].Config.CredentialRefs() {
+			if ref == cred.Name {
+				users = append(users, channels[i].Name)
+			}
+		}
+	}
+	if len(users) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":    "credential is referenced by channels",
+			"channels": users,
+		})
+		return
+	}
+
+	if deleteErr := r.repo.DeleteCredential(ctx, id); deleteErr != nil {
+		r.handleRepositoryError(c, deleteErr, "credential", "delete")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credential deleted successfully"})
+}
+
+// reencryptCredentials moves credentials still sealed with the previous key
+// onto the current key, completing a key change
+// POST /api/v1/credentials/reencrypt
+func (r *Router) reencryptCredentials(c *gin.Context) {
+	if !r.requireCredentialStore(c) {
+		return
+	}
+
+	moved, err := r.credentials.Reencrypt(c.Request.Context())
+	if err != nil {
+		r.log.Error("Failed to re-encrypt credentials", infralogger.Int("reencrypted", moved), infralogger.Error(err))
+		status := http.StatusInternalServerError
+		if errors.Is(err, credentials.ErrUnknownKey) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error":       "Failed to re-encrypt credentials",
+			"details":     err.Error(),
+			"reencrypted": moved,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reencrypted": moved})
+}
+
+// requireCredentialStore responds 503 when no encryption key is configured.
+func (r *Router) requireCredentialStore(c *gin.Context) bool {
+	if r.credentials.Enabled() {
+		return true
+	}
+	c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Credential store not configured (PUBLISHER_CREDENTIALS_KEY)"})
+	return false
+}
+
+// checkCredentialRefs responds 400 when a channel config references a
+// credential that does not exist.
+func (r *Router) checkCredentialRefs(c *gin.Context, cfg *models.ChannelConfig) bool {
+	if cfg == nil {
+		return true
+	}
+	for _, name := range cfg.CredentialRefs() {
+		_, err := r.repo.GetCredentialByName(c.Request.Context(), name)
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown credential: " + name})
+			return false
+		}
+		if err != nil {
+			r.handleRepositoryError(c, err, "credential", "get")
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// defaultDeadLetterListLimit is the page size of dead letter listings without a limit
+const defaultDeadLetterListLimit = 50
+
+// deadLetterDetail is a dead letter with the routed content item it would deliver
+type deadLetterDetail struct {
+	*models.DeadLetter
+	Payload json.RawMessage `json:"payload"`
+}
+
+// listDeadLetters lists failed deliveries awaiting retry or replay
+// GET /api/v1/dead-letters?status=dead&channel=...&limit=50&offset=0
+func (r *Router) listDeadLetters(c *gin.Context) {
+	var filter models.DeadLetterFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultDeadLetterListLimit
+	}
+
+	letters, total, err := r.repo.ListDeadLetters(c.Request.Context(), &filter)
+	if err != nil {
+		r.handleRepositoryError(c, err, "dead letter", "list")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dead_letters": letters,
+		"count":        len(letters),
+		"total":        total,
+		"limit":        filter.Limit,
+		"offset":       filter.Offset,
+	})
+}
+
+// getDeadLetter returns one dead letter with its full payload
+// GET /api/v1/dead-letters/:id
+func (r *Router) getDeadLetter(c *gin.Context) {
+	id, ok := parseUUID(c, "id", "dead letter")
+	if !ok {
+		return
+	}
+
+	letter, err := r.repo.GetDeadLetter(c.Request.Context(), id)
+	if err != nil {
+		r.handleRepositoryError(c, err, "dead letter", "get")
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetterDetail{DeadLetter: letter, Payload: letter.Payload})
+}
+
+// replayDeadLetter makes one item due on the router's next poll with a fresh set of attempts
+// POST /api/v1/dead-letters/:id/replay
+func (r *Router) replayDeadLetter(c *gin.Context) {
+	id, ok := parseUUID(c, "id", "dead letter")
+	if !ok {
+		return
+	}
+
+	letter, err := r.repo.ReplayDeadLetter(c.Request.Context(), id)
+	if err != nil {
+		r.handleRepositoryError(c, err, "dead letter", "replay")
+		return
+	}
+
+	r.log.Info("Dead letter replayed",
+		infralogger.String("dead_letter_id", id.String()),
+		infralogger.String("content_id", letter.ContentID),
+		infralogger.String("channel", letter.ChannelName),
+		infralogger.String("requested_by", requestActor(c)),
+	)
+
+	c.JSON(http.StatusOK, letter)
+}
+
+// replayDeadLetters replays every dead letter, or those of one channel, e.g.
+// once a downstream outage is fixed
+// POST /api/v1/dead-letters/replay
+func (r *Router) replayDeadLetters(c *gin.Context) {
+	var req models.DeadLetterReplayRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request payload",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	replayed, err := r.repo.ReplayDeadLetters(c.Request.Context(), req.ChannelName)
+	if err != nil {
+		r.handleRepositoryError(c, err, "dead letter", "replay")
+		return
+	}
+
+	r.log.Info("Dead letters replayed",
+		infralogger.String("channel", req.ChannelName),
+		infralogger.Int64("replayed", replayed),
+		infralogger.String("requested_by", requestActor(c)),
+	)
+
+	c.JSON(http.StatusOK, gin.H{"replayed": replayed})
+}
+
+// discardDeadLetter removes an item so it is never retried
+// DELETE /api/v1/dead-letters/:id
+func (r *Router) discardDeadLetter(c *gin.Context) {
+	id, ok := parseUUID(c, "id", "dead letter")
+	if !ok {
+		return
+	}
+
+	if err := r.repo.DeleteDeadLetter(c.Request.Context(), id); err != nil {
+		r.handleRepositoryError(c, err, "dead letter", "discard")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Dead letter discarded",
+	})
+}
@@ -0,0 +1,190 @@
+//nolint:testpackage // Testing unexported handlers requires same package access
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var deadLetterTestColumns = []string{
+	"id", "channel_id", "channel_name", "content_id", "title", "payload", "last_error",
+	"attempts", "status", "next_retry_at", "created_at", "updated_at",
+}
+
+// newDeadLetterTestEngine serves the dead letter endpoints from a mocked database.
+func newDeadLetterTestEngine(t *testing.T) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	r := &Router{repo: database.NewRepository(sqlx.NewDb(db, "postgres")), log: infralogger.NewNop()}
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	deadLetters := engine.Group("/api/v1/dead-letters")
+	deadLetters.GET("", r.listDeadLetters)
+	deadLetters.POST("/replay", r.replayDeadLetters)
+	deadLetters.GET("/:id", r.getDeadLetter)
+	deadLetters.POST("/:id/replay", r.replayDeadLetter)
+	deadLetters.DELETE("/:id", r.discardDeadLetter)
+	return engine, mock
+}
+
+func serveDeadLetters(engine *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequestWithContext(context.Background(), method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func deadLetterRow(id uuid.UUID, status string) *sqlmock.Rows {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return sqlmock.NewRows(deadLetterTestColumns).AddRow(
+		id, nil, "articles:crime", "doc-1", "Title", []byte(`{"id":"doc-1"}`), "connection refused",
+		3, status, now, now, now,
+	)
+}
+
+func TestListDeadLetters(t *testing.T) {
+	engine, mock := newDeadLetterTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("SELECT COUNT").WithArgs("articles:crime", models.DeadLetterStatusDead).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM channel_dead_letters").
+		WithArgs("articles:crime", models.DeadLetterStatusDead, defaultDeadLetterListLimit, 0).
+		WillReturnRows(deadLetterRow(id, models.DeadLetterStatusDead))
+
+	rec := serveDeadLetters(engine, http.MethodGet, "/api/v1/dead-letters?channel=articles:crime&status=dead", "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		DeadLetters []models.DeadLetter `json:"dead_letters"`
+		Total       int                 `json:"total"`
+		Limit       int                 `json:"limit"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.DeadLetters, 1)
+	assert.Equal(t, id, body.DeadLetters[0].ID)
+	assert.Equal(t, 1, body.Total)
+	assert.Equal(t, defaultDeadLetterListLimit, body.Limit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListDeadLetters_InvalidStatus(t *testing.T) {
+	engine, mock := newDeadLetterTestEngine(t)
+
+	rec := serveDeadLetters(engine, http.MethodGet, "/api/v1/dead-letters?status=delivered", "")
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeadLetter_IncludesPayload(t *testing.T) {
+	engine, mock := newDeadLetterTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("SELECT (.+) FROM channel_dead_letters WHERE id").WithArgs(id).
+		WillReturnRows(deadLetterRow(id, models.DeadLetterStatusRetrying))
+
+	rec := serveDeadLetters(engine, http.MethodGet, "/api/v1/dead-letters/"+id.String(), "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]any{"id": "doc-1"}, body["payload"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeadLetter_NotFound(t *testing.T) {
+	engine, mock := newDeadLetterTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("SELECT (.+) FROM channel_dead_letters WHERE id").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(deadLetterTestColumns))
+
+	rec := serveDeadLetters(engine, http.MethodGet, "/api/v1/dead-letters/"+id.String(), "")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeadLetter_InvalidID(t *testing.T) {
+	engine, _ := newDeadLetterTestEngine(t)
+
+	rec := serveDeadLetters(engine, http.MethodGet, "/api/v1/dead-letters/not-a-uuid", "")
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	engine, mock := newDeadLetterTestEngine(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_dead_letters").WithArgs(id).
+		WillReturnRows(deadLetterRow(id, models.DeadLetterStatusRetrying))
+
+	rec := serveDeadLetters(engine, http.MethodPost, "/api/v1/dead-letters/"+id.String()+"/replay", "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var letter models.DeadLetter
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &letter))
+	assert.Equal(t, models.DeadLetterStatusRetrying, letter.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplayDeadLetters(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		channel string
+	}{
+		{name: "all channels", body: "", channel: ""},
+		{name: "one channel", body: `{"channel":"articles:crime"}`, channel: "articles:crime"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, mock := newDeadLetterTestEngine(t)
+			mock.ExpectExec("UPDATE channel_dead_letters").WithArgs(tt.channel).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			rec := serveDeadLetters(engine, http.MethodPost, "/api/v1/dead-letters/replay", tt.body)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, `{"replayed":2}`, rec.Body.String())
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDiscardDeadLetter(t *testing.T) {
+	engine, mock := newDeadLetterTestEngine(t)
+	id := uuid.New()
+	missing := uuid.New()
+
+	mock.ExpectExec("DELETE FROM channel_dead_letters").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM channel_dead_letters").WithArgs(missing).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rec := serveDeadLetters(engine, http.MethodDelete, "/api/v1/dead-letters/"+id.String(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveDeadLetters(engine, http.MethodDelete, "/api/v1/dead-letters/"+missing.String(), "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
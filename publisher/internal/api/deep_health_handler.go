@@ -0,0 +1,481 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+	"github.com/jonesrussell/north-cloud/publisher/internal/streams"
+	"github.com/redis/go-redis/v9"
+)
+
+// Deep health dependency statuses.
+const (
+	dependencyOK      = "ok"
+	dependencyError   = "error"
+	dependencySkipped = "skipped"
+
+	deepHealthTimeout         = 10 * time.Second
+	classifiedIndexGlob       = "*_classified_content"
+	overallHealthy            = "healthy"
+	overallDegraded           = "degraded"
+	overallUnhealthy          = "unhealthy"
+	dependencyTypeChannel     = "channel"
+	dependencyTypeDestination = "destination"
+	dependencyTypeES          = "elasticsearch"
+)
+
+// DependencyStatus is the result of checking a single dependency.
+type DependencyStatus struct {
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	Status      string         `json:"status"`
+	Error       string         `json:"error,omitempty"`
+	LatencyMS   int64          `json:"latency_ms"`
+	LastSuccess *time.Time     `json:"last_success,omitempty"`
+	Details     map[string]any `json:"details,omitempty"`
+}
+
+// DeepHealthResponse aggregates all dependency checks for the ops dashboard.
+type DeepHealthResponse struct {
+	Status       string             `json:"status"`
+	CheckedAt    time.Time          `json:"checked_at"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+// healthTracker remembers the last successful check per dependency for the
+// lifetime of the API process.
+type healthTracker struct {
+	mu          sync.Mutex
+	lastSuccess map[string]time.Time
+}
+
+func newHealthTracker() *healthTracker {
+	return &healthTracker{lastSuccess: make(map[string]time.Time)}
+}
+
+// record stamps a successful check and returns the last success time, if any.
+func (t *healthTracker) record(name string, ok bool, at time.Time) *time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ok {
+		t.lastSuccess[name] = at
+	}
+	last, found := t.lastSuccess[name]
+	if !found {
+		return nil
+	}
+	return &last
+}
+
+// deepHealthStore is the repository subset the deep health check reads.
+type deepHealthStore interface {
+	channelHealthLister
+	Ping(ctx context.Context) error
+	ListChannels(ctx context.Context, enabledOnly bool) ([]models.Channel, error)
+	GetLastPublishedByChannel(ctx context.Context) (map[string]time.Time, error)
+}
+
+// destinationPinger checks that a delivery destination answers.
+type destinationPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// deepHealth checks every dependency the publisher relies on. Optional
+// dependencies left nil are reported as skipped.
+type deepHealth struct {
+	store       deepHealthStore
+	redis       *redis.Client
+	es          *elasticsearch.Client
+	drupal      destinationPinger
+	credentials router.CredentialResolver
+	getenv      func(string) string
+	cfg         *config.Config
+	log         infralogger.Logger
+	tracker     *healthTracker
+}
+
+func newDeepHealth(store deepHealthStore, redisClient *redis.Client, esClient *elasticsearch.Client,
+	cfg *config.Config, log infralogger.Logger,
+) *deepHealth {
+	return &deepHealth{
+		store:   store,
+		redis:   redisClient,
+		es:      esClient,
+		getenv:  os.Getenv,
+		cfg:     cfg,
+		log:     log,
+		tracker: newHealthTracker(),
+	}
+}
+
+// withDestinations sets the Drupal site drupal channels deliver to and the
+// store channel credentials are read from.
+func (d *deepHealth) withDestinations(drupalSite destinationPinger, credentials router.CredentialResolver) *deepHealth {
+	d.drupal = drupalSite
+	d.credentials = credentials
+	return d
+}
+
+// getDeepHealth checks every dependency the publisher relies on
+// GET /api/v1/health/deep
+func (r *Router) getDeepHealth(c *gin.Context) {
+	r.deep.handle(c)
+}
+
+func (d *deepHealth) handle(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), deepHealthTimeout)
+	defer cancel()
+
+	deps := []DependencyStatus{
+		d.checkDependency("postgres", "database", func() (map[string]any, error) {
+			if d.store == nil {
+				return nil, errors.New("database repository not initialized")
+			}
+			return nil, d.store.Ping(ctx)
+		}),
+		d.checkDependency("redis", "redis", func() (map[string]any, error) {
+			if d.redis == nil {
+				return nil, errDependencyNotConfigured
+			}
+			return nil, d.redis.Ping(ctx).Err()
+		}),
+		d.checkDependency("smtp", "smtp", func() (map[string]any, error) {
+			return d.checkSMTP(ctx)
+		}),
+		d.checkDependency("drupal", dependencyTypeDestination, func() (map[string]any, error) {
+			return d.checkDrupal(ctx)
+		}),
+	}
+
+	channels, channelDeps := d.checkChannelDestinations(ctx)
+	for _, route := range d.routeIndexPatterns(channels) {
+		deps = append(deps, d.checkDependency("elasticsearch:"+route.pattern, dependencyTypeES,
+			func() (map[string]any, error) {
+				return d.checkIndexPattern(ctx, route)
+			}))
+	}
+	deps = append(deps, channelDeps...)
+
+	c.JSON(http.StatusOK, DeepHealthResponse{
+		Status:       overallStatus(deps),
+		CheckedAt:    time.Now().UTC(),
+		Dependencies: deps,
+	})
+}
+
+// errDependencyNotConfigured marks an optional dependency that was never configured.
+var errDependencyNotConfigured = errors.New("not configured")
+
+// checkDependency runs check, timing it and recording the last success.
+func (d *deepHealth) checkDependency(name, depType string, check func() (map[string]any, error)) DependencyStatus {
+	start := time.Now()
+	details, err := check()
+	status := DependencyStatus{
+		Name:      name,
+		Type:      depType,
+		Status:    dependencyOK,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Details:   details,
+	}
+
+	switch {
+	case errors.Is(err, errDependencyNotConfigured):
+		status.Status = dependencySkipped
+		status.Error = err.Error()
+	case err != nil:
+		status.Status = dependencyError
+		status.Error = err.Error()
+		d.log.Warn("Deep health check failed",
+			infralogger.String("dependency", name),
+			infralogger.Error(err),
+		)
+	}
+
+	status.LastSuccess = d.tracker.record(name, err == nil, time.Now().UTC())
+	return status
+}
+
+// indexRoute is an Elasticsearch index pattern and the routes that read it.
+type indexRoute struct {
+	pattern string
+	routes  []string
+}
+
+// routeIndexPatterns returns the index pattern of every active route: the
+// classified content glob the router searches for channel routes, then each
+// configured city's index.
+func (d *deepHealth) routeIndexPatterns(channels []models.Channel) []indexRoute {
+	channelRoutes := make([]string, 0, len(channels))
+	for i := range channels {
+		channelRoutes = append(channelRoutes, channels[i].Slug)
+	}
+	routes := []indexRoute{{pattern: classifiedIndexGlob, routes: channelRoutes}}
+
+	if d.cfg == nil {
+		return routes
+	}
+	seen := map[string]int{classifiedIndexGlob: 0}
+	for _, city := range d.cfg.Cities {
+		pattern := city.Index
+		if pattern == "" {
+			pattern = city.Name + d.cfg.Service.IndexSuffix
+		}
+		if i, ok := seen[pattern]; ok {
+			routes[i].routes = append(routes[i].routes, city.Name)
+			continue
+		}
+		seen[pattern] = len(routes)
+		routes = append(routes, indexRoute{pattern: pattern, routes: []string{city.Name}})
+	}
+	return routes
+}
+
+// checkIndexPattern verifies a route's index pattern resolves to at least
+// one index and that none of its indexes are red.
+func (d *deepHealth) checkIndexPattern(ctx context.Context, route indexRoute) (map[string]any, error) {
+	if d.es == nil {
+		return nil, errDependencyNotConfigured
+	}
+
+	res, err := d.es.Cat.Indices(
+		d.es.Cat.Indices.WithContext(ctx),
+		d.es.Cat.Indices.WithIndex(route.pattern),
+		d.es.Cat.Indices.WithFormat("json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch returned %s", res.Status())
+	}
+
+	var indexes []map[string]any
+	if decodeErr := decodeJSONBody(res.Body, &indexes); decodeErr != nil {
+		return nil, fmt.Errorf("parse index list: %w", decodeErr)
+	}
+
+	unhealthy := make([]string, 0)
+	for _, idx := range indexes {
+		if health, _ := idx["health"].(string); health == "red" {
+			name, _ := idx["index"].(string)
+			unhealthy = append(unhealthy, name)
+		}
+	}
+
+	details := map[string]any{
+		"pattern":       route.pattern,
+		"routes":        route.routes,
+		"index_count":   len(indexes),
+		"red_indexes":   unhealthy,
+		"healthy_count": len(indexes) - len(unhealthy),
+	}
+	if len(indexes) == 0 {
+		return details, fmt.Errorf("no indexes match %s", route.pattern)
+	}
+	if len(unhealthy) > 0 {
+		return details, fmt.Errorf("%d indexes are red", len(unhealthy))
+	}
+	return details, nil
+}
+
+// checkSMTP dials the SMTP server weekly reports are mailed through.
+func (d *deepHealth) checkSMTP(ctx context.Context) (map[string]any, error) {
+	if d.cfg == nil || d.cfg.Reports.SMTPHost == "" {
+		return nil, errDependencyNotConfigured
+	}
+
+	addr := net.JoinHostPort(d.cfg.Reports.SMTPHost, strconv.Itoa(d.cfg.Reports.SMTPPort))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return map[string]any{"address": addr}, err
+	}
+	_ = conn.Close()
+	return map[string]any{"address": addr}, nil
+}
+
+// checkDrupal pings the JSON:API of the Drupal site drupal channels create
+// nodes on and redis channels' groups live on.
+func (d *deepHealth) checkDrupal(ctx context.Context) (map[string]any, error) {
+	if d.drupal == nil {
+		return nil, errDependencyNotConfigured
+	}
+	var details map[string]any
+	if d.cfg != nil {
+		details = map[string]any{"url": d.cfg.Drupal.URL}
+	}
+	return details, d.drupal.Ping(ctx)
+}
+
+// checkChannelDestinations reports one entry per enabled channel and returns
+// the channels it listed. Redis channels report their stream's consumer group
+// lag or their subscriber count, depending on the delivery mode; other channel
+// types report the router's latest probe and circuit breaker state (see
+// router.Prober) and whether the credentials they deliver with resolve. The
+// last success is the channel's most recent publish.
+func (d *deepHealth) checkChannelDestinations(ctx context.Context) ([]models.Channel, []DependencyStatus) {
+	if d.store == nil {
+		return nil, nil
+	}
+
+	channels, err := d.store.ListChannels(ctx, true)
+	if err != nil {
+		d.log.Warn("Deep health could not list channels", infralogger.Error(err))
+		return nil, []DependencyStatus{{
+			Name:   "channels",
+			Type:   dependencyTypeChannel,
+			Status: dependencyError,
+			Error:  err.Error(),
+		}}
+	}
+
+	lastPublished, err := d.store.GetLastPublishedByChannel(ctx)
+	if err != nil {
+		d.log.Warn("Deep health could not load last publish times", infralogger.Error(err))
+		lastPublished = map[string]time.Time{}
+	}
+	attachHealthRecords(ctx, d.store, d.log, channels)
+
+	out := make([]DependencyStatus, 0, len(channels))
+	for i := range channels {
+		ch := &channels[i]
+		status := DependencyStatus{
+			Name:    ch.RedisChannel,
+			Type:    dependencyTypeChannel,
+			Status:  dependencyOK,
+			Details: map[string]any{"slug": ch.Slug, "type": ch.DeliveryType()},
+		}
+		if published, ok := lastPublished[ch.RedisChannel]; ok {
+			status.LastSuccess = &published
+		}
+
+		start := time.Now()
+		switch {
+		case ch.DeliveryType() != models.ChannelTypeRedis:
+			applyChannelHealth(&status, ch.Health)
+			d.applyChannelCredentials(ctx, &status, ch)
+		case d.redis == nil:
+			status.Status = dependencySkipped
+			status.Error = errDependencyNotConfigured.Error()
+		default:
+			if redisErr := d.applyRedisDelivery(ctx, &status, ch.RedisChannel); redisErr != nil {
+				status.Status = dependencyError
+				status.Error = redisErr.Error()
+			}
+		}
+		status.LatencyMS = time.Since(start).Milliseconds()
+
+		out = append(out, status)
+	}
+
+	return channels, out
+}
+
+// applyRedisDelivery reports how a redis channel is consumed under the
+// configured delivery mode: consumer group lag and pending entries on its
+// stream, and/or its pub/sub subscriber count.
+func (d *deepHealth) applyRedisDelivery(ctx context.Context, status *DependencyStatus, channel string) error {
+	mode := streams.ModeStreams
+	if d.cfg != nil && d.cfg.Redis.Delivery != "" {
+		mode = d.cfg.Redis.Delivery
+	}
+	status.Details["delivery"] = mode
+
+	if mode != streams.ModePubSub {
+		lags, err := streams.Lag(ctx, d.redis, channel)
+		if err != nil {
+			return err
+		}
+		applyStreamLag(status, lags)
+	}
+	if mode != streams.ModeStreams {
+		subs, err := d.redis.PubSubNumSub(ctx, channel).Result()
+		if err != nil {
+			return err
+		}
+		status.Details["subscribers"] = subs[channel]
+	}
+	return nil
+}
+
+// applyStreamLag adds each consumer group's lag and pending entries, and
+// their totals. The total lag is -1 when any group's lag is unknown.
+func applyStreamLag(status *DependencyStatus, lags []streams.GroupLag) {
+	groups := make([]map[string]any, 0, len(lags))
+	var totalLag, totalPending int64
+	for _, lag := range lags {
+		groups = append(groups, map[string]any{"group": lag.Group, "lag": lag.Lag, "pending": lag.Pending})
+		totalPending += lag.Pending
+		if lag.Lag < 0 || totalLag < 0 {
+			totalLag = -1
+			continue
+		}
+		totalLag += lag.Lag
+	}
+	status.Details["consumer_groups"] = groups
+	status.Details["lag"] = totalLag
+	status.Details["pending"] = totalPending
+}
+
+// applyChannelHealth reports a directly delivered channel (e.g. WordPress)
+// from its health record: an error while its circuit is open.
+func applyChannelHealth(status *DependencyStatus, health *models.ChannelHealth) {
+	if health == nil {
+		return
+	}
+	status.Details["circuit"] = health.Circuit
+	status.Details["health"] = health.Status
+	status.Details["consecutive_failures"] = health.ConsecutiveFailures
+	if health.LastProbeAt != nil {
+		status.Details["last_probe_at"] = health.LastProbeAt
+	}
+	if !health.Allows() {
+		status.Status = dependencyError
+		status.Error = health.LastError
+	}
+}
+
+// applyChannelCredentials reports an error when a secret the channel's
+// destination needs does not resolve, so a missing or rotated-away credential
+// shows before the next delivery fails.
+func (d *deepHealth) applyChannelCredentials(ctx context.Context, status *DependencyStatus, ch *models.Channel) {
+	if err := router.CheckChannelCredentials(ctx, d.credentials, d.getenv, ch); err != nil {
+		status.Details["credentials"] = dependencyError
+		status.Status = dependencyError
+		status.Error = "credentials: " + err.Error()
+		return
+	}
+	status.Details["credentials"] = dependencyOK
+}
+
+// overallStatus is unhealthy when the database is down, degraded when any
+// other dependency fails, and healthy otherwise.
+func overallStatus(deps []DependencyStatus) string {
+	result := overallHealthy
+	for i := range deps {
+		if deps[i].Status != dependencyError {
+			continue
+		}
+		if deps[i].Name == "postgres" {
+			return overallUnhealthy
+		}
+		result = overallDegraded
+	}
+	return result
+}
@@ -0,0 +1,249 @@
+//nolint:testpackage // Testing the unexported deep health checker requires same package access
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/streams"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeepHealthStore serves channels and health records from memory.
+type fakeDeepHealthStore struct {
+	pingErr       error
+	channels      []models.Channel
+	lastPublished map[string]time.Time
+	health        map[uuid.UUID]*models.ChannelHealth
+}
+
+func (f *fakeDeepHealthStore) Ping(context.Context) error { return f.pingErr }
+
+func (f *fakeDeepHealthStore) ListChannels(context.Context, bool) ([]models.Channel, error) {
+	return append([]models.Channel(nil), f.channels...), nil
+}
+
+func (f *fakeDeepHealthStore) GetLastPublishedByChannel(context.Context) (map[string]time.Time, error) {
+	return f.lastPublished, nil
+}
+
+func (f *fakeDeepHealthStore) ListChannelHealth(context.Context) (map[uuid.UUID]*models.ChannelHealth, error) {
+	return f.health, nil
+}
+
+// fakePinger answers pings with err.
+type fakePinger struct{ err error }
+
+func (f fakePinger) Ping(context.Context) error { return f.err }
+
+// fakeResolver reveals stored credentials from a map.
+type fakeResolver map[string]string
+
+func (f fakeResolver) Reveal(_ context.Context, name string) (string, error) {
+	value, ok := f[name]
+	if !ok {
+		return "", models.ErrNotFound
+	}
+	return value, nil
+}
+
+// newFakeES serves _cat/indices: patterns in indexes answer with those
+// indexes, any other pattern with none.
+func newFakeES(t *testing.T, indexes map[string][]map[string]string) *elasticsearch.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		pattern := strings.TrimPrefix(r.URL.Path, "/_cat/indices/")
+		list, ok := indexes[pattern]
+		if !ok {
+			list = []map[string]string{}
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+	return client
+}
+
+func newTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func runDeepHealth(t *testing.T, d *deepHealth) (DeepHealthResponse, map[string]DependencyStatus) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/health/deep", d.handle)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/health/deep", http.NoBody)
+	engine.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp DeepHealthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	byName := make(map[string]DependencyStatus, len(resp.Dependencies))
+	for _, dep := range resp.Dependencies {
+		byName[dep.Name] = dep
+	}
+	return resp, byName
+}
+
+func deepHealthChannels() []models.Channel {
+	return []models.Channel{
+		{ID: uuid.New(), Slug: "crime", RedisChannel: "articles:crime", Type: models.ChannelTypeRedis, Enabled: true},
+		{
+			ID: uuid.New(), Slug: "partner", RedisChannel: "wp:partner", Type: models.ChannelTypeWordPress, Enabled: true,
+			Config: models.ChannelConfig{WordPress: &models.WordPressConfig{
+				BaseURL: "https://partner.example.com", PasswordCredential: "partner-wp",
+			}},
+		},
+	}
+}
+
+func deepHealthConfig() *config.Config {
+	cfg := &config.Config{Cities: []config.CityConfig{{Name: "sudbury"}}}
+	cfg.Service.IndexSuffix = "_classified_content"
+	return cfg
+}
+
+func greenIndexes() map[string][]map[string]string {
+	return map[string][]map[string]string{
+		classifiedIndexGlob: {
+			{"index": "sudbury_classified_content", "health": "green"},
+			{"index": "timmins_classified_content", "health": "yellow"},
+		},
+		"sudbury_classified_content": {{"index": "sudbury_classified_content", "health": "green"}},
+	}
+}
+
+func TestDeepHealth_Healthy(t *testing.T) {
+	store := &fakeDeepHealthStore{channels: deepHealthChannels()}
+	d := newDeepHealth(store, newTestRedis(t), newFakeES(t, greenIndexes()), deepHealthConfig(), infralogger.NewNop()).
+		withDestinations(fakePinger{}, fakeResolver{"partner-wp": "s3cret"})
+
+	resp, deps := runDeepHealth(t, d)
+
+	assert.Equal(t, overallHealthy, resp.Status)
+	assert.Equal(t, dependencyOK, deps["postgres"].Status)
+	assert.Equal(t, dependencyOK, deps["redis"].Status)
+	assert.Equal(t, dependencySkipped, deps["smtp"].Status)
+	assert.Equal(t, dependencyOK, deps["drupal"].Status)
+	assert.NotNil(t, deps["drupal"].LastSuccess)
+
+	channelRoute := deps["elasticsearch:"+classifiedIndexGlob]
+	assert.Equal(t, dependencyOK, channelRoute.Status)
+	assert.ElementsMatch(t, []any{"crime", "partner"}, channelRoute.Details["routes"])
+	cityRoute := deps["elasticsearch:sudbury_classified_content"]
+	assert.Equal(t, dependencyOK, cityRoute.Status)
+	assert.Equal(t, []any{"sudbury"}, cityRoute.Details["routes"])
+
+	assert.Equal(t, dependencyOK, deps["articles:crime"].Status)
+	assert.Equal(t, streams.ModeStreams, deps["articles:crime"].Details["delivery"], "streams is the default delivery")
+	assert.InDelta(t, 0, deps["articles:crime"].Details["lag"], 0, "a stream nobody published to has no groups")
+	assert.Equal(t, dependencyOK, deps["wp:partner"].Status)
+	assert.Equal(t, dependencyOK, deps["wp:partner"].Details["credentials"])
+}
+
+func TestDeepHealth_DegradedDestinations(t *testing.T) {
+	channels := deepHealthChannels()
+	store := &fakeDeepHealthStore{
+		channels: channels,
+		health: map[uuid.UUID]*models.ChannelHealth{
+			channels[1].ID: {ChannelID: channels[1].ID, Circuit: models.CircuitClosed},
+		},
+	}
+	indexes := greenIndexes()
+	delete(indexes, "sudbury_classified_content")
+	d := newDeepHealth(store, newTestRedis(t), newFakeES(t, indexes), deepHealthConfig(), infralogger.NewNop()).
+		withDestinations(fakePinger{err: errors.New("drupal JSON:API returned 403")}, fakeResolver{})
+
+	resp, deps := runDeepHealth(t, d)
+
+	assert.Equal(t, overallDegraded, resp.Status)
+	assert.Equal(t, dependencyOK, deps["postgres"].Status)
+	assert.Equal(t, dependencyError, deps["drupal"].Status)
+	assert.Contains(t, deps["drupal"].Error, "403")
+	assert.Equal(t, dependencyOK, deps["elasticsearch:"+classifiedIndexGlob].Status)
+	assert.Equal(t, dependencyError, deps["elasticsearch:sudbury_classified_content"].Status,
+		"a city route whose index is gone fails on its own")
+
+	partner := deps["wp:partner"]
+	assert.Equal(t, dependencyError, partner.Status)
+	assert.Equal(t, dependencyError, partner.Details["credentials"])
+	assert.Contains(t, partner.Error, "partner-wp")
+}
+
+func TestDeepHealth_FailingDatabase(t *testing.T) {
+	store := &fakeDeepHealthStore{pingErr: errors.New("connection refused")}
+	d := newDeepHealth(store, nil, nil, &config.Config{}, infralogger.NewNop())
+
+	resp, deps := runDeepHealth(t, d)
+
+	assert.Equal(t, overallUnhealthy, resp.Status)
+	assert.Equal(t, dependencyError, deps["postgres"].Status)
+	assert.Nil(t, deps["postgres"].LastSuccess)
+	assert.Equal(t, dependencySkipped, deps["redis"].Status)
+	assert.Equal(t, dependencySkipped, deps["drupal"].Status)
+	assert.Equal(t, dependencySkipped, deps["elasticsearch:"+classifiedIndexGlob].Status)
+}
+
+func TestDeepHealth_StreamsDeliveryReportsLag(t *testing.T) {
+	ctx := context.Background()
+	client := newTestRedis(t)
+	producer := streams.NewProducer(client, streams.ModeStreams, 0)
+	consumer := streams.NewConsumer(client, streams.ConsumerConfig{
+		Streams: []string{"articles:crime"},
+		Group:   "streetcode",
+		Name:    "web-1",
+		Block:   10 * time.Millisecond,
+	})
+	require.NoError(t, consumer.EnsureGroups(ctx))
+	require.NoError(t, producer.Publish(ctx, "articles:crime", []byte(`{"id":"doc-1"}`)))
+	_, err := consumer.Read(ctx)
+	require.NoError(t, err)
+	require.NoError(t, producer.Publish(ctx, "articles:crime", []byte(`{"id":"doc-2"}`)))
+
+	cfg := &config.Config{}
+	cfg.Redis.Delivery = streams.ModeStreams
+	store := &fakeDeepHealthStore{channels: deepHealthChannels()[:1]}
+	d := newDeepHealth(store, client, nil, cfg, infralogger.NewNop())
+
+	_, deps := runDeepHealth(t, d)
+	lags, err := streams.Lag(ctx, client, "articles:crime")
+	require.NoError(t, err)
+	require.Len(t, lags, 1)
+
+	crime := deps["articles:crime"]
+	assert.Equal(t, dependencyOK, crime.Status)
+	assert.Equal(t, streams.ModeStreams, crime.Details["delivery"])
+	assert.NotContains(t, crime.Details, "subscribers", "pub/sub is not used for streams delivery")
+	assert.InDelta(t, lags[0].Lag, crime.Details["lag"], 0, "doc-2 is not yet delivered to the group")
+	assert.InDelta(t, 1, crime.Details["pending"], 0, "doc-1 is read but not acknowledged")
+	groups, ok := crime.Details["consumer_groups"].([]any)
+	require.True(t, ok)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "streetcode", groups[0].(map[string]any)["group"])
+}
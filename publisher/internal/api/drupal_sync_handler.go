@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// getDrupalSyncPlan lists the Drupal site's groups next to the channels
+// table: groups without a channel (with a ready-to-create proposal), linked
+// channels, and channels whose group no longer exists
+// GET /api/v1/channels/drupal-sync
+func (r *Router) getDrupalSyncPlan(c *gin.Context) {
+	plan, ok := r.drupalSyncPlan(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// provisionDrupalChannels creates the proposed channels for the selected
+// groups. Groups that already have a channel or are not listed by the site
+// are returned as skipped.
+// POST /api/v1/channels/drupal-sync
+func (r *Router) provisionDrupalChannels(c *gin.Context) {
+	var req models.DrupalProvisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	plan, ok := r.drupalSyncPlan(c)
+	if !ok {
+		return
+	}
+
+	created := []*models.Channel{}
+	provisioned := make(map[string]bool, len(req.GroupIDs))
+	for i := range plan.Missing {
+		proposal := &plan.Missing[i]
+		if !slices.Contains(req.GroupIDs, proposal.Group.ID) {
+			continue
+		}
+
+		channel, err := r.repo.CreateChannel(c.Request.Context(), &proposal.Channel)
+		if err != nil {
+			r.handleRepositoryError(c, err, "channel", "create")
+			return
+		}
+		created = append(created, channel)
+		provisioned[proposal.Group.ID] = true
+
+		r.log.Info("Provisioned channel for Drupal group",
+			infralogger.String("channel_id", channel.ID.String()),
+			infralogger.String("group_id", proposal.Group.ID),
+			infralogger.String("requested_by", requestActor(c)),
+		)
+	}
+
+	skipped := []string{}
+	for _, groupID := range req.GroupIDs {
+		if !provisioned[groupID] {
+			skipped = append(skipped, groupID)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"created": created,
+		"skipped": skipped,
+	})
+}
+
+// drupalSyncPlan fetches the site's groups and compares them with the channels
+// table, writing the error response and returning false on failure
+func (r *Router) drupalSyncPlan(c *gin.Context) (*models.DrupalSyncPlan, bool) {
+	if r.drupal == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Drupal group discovery is not configured (set DRUPAL_URL and DRUPAL_GROUP_TYPES)",
+		})
+		return nil, false
+	}
+
+	groups, err := r.drupal.ListGroups(c.Request.Context())
+	if err != nil {
+		r.log.Warn("Failed to list Drupal groups", infralogger.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "Failed to list Drupal groups",
+			"details": err.Error(),
+		})
+		return nil, false
+	}
+
+	channels, err := r.repo.ListChannels(c.Request.Context(), false)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channel", "list")
+		return nil, false
+	}
+
+	return drupal.Plan(groups, channels), true
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/reports"
+)
+
+// Report output formats.
+const (
+	reportFormatJSON = "json"
+	reportFormatHTML = "html"
+	htmlContentType  = "text/html; charset=utf-8"
+	reportWeekLayout = "2006-01-02"
+)
+
+// getWeeklyReport returns a channel's weekly editorial report: published
+// counts by day and topic, top-clicked items, and delivery failures.
+// week is any date in the week (Monday–Sunday, UTC); it defaults to last week.
+// GET /api/v1/channels/:id/reports/weekly?week=2026-03-02&format=json|html
+func (r *Router) getWeeklyReport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	channelID, ok := parseUUID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", reportFormatJSON)
+	if format != reportFormatJSON && format != reportFormatHTML {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or html"})
+		return
+	}
+
+	weekStart := reports.LastCompleteWeek(time.Now())
+	if week := c.Query("week"); week != "" {
+		parsed, err := time.Parse(reportWeekLayout, week)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "week must be a date (YYYY-MM-DD)"})
+			return
+		}
+		weekStart = reports.WeekStart(parsed)
+	}
+
+	channel, err := r.repo.GetChannelByID(ctx, channelID)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channel", "get")
+		return
+	}
+
+	report, err := r.reports.Weekly(ctx, channel, weekStart)
+	if err != nil {
+		if !errors.Is(err, reports.ErrClicksUnavailable) {
+			r.log.Error("Failed to generate weekly report",
+				infralogger.String("channel_id", channelID.String()),
+				infralogger.Error(err),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate report"})
+			return
+		}
+		r.log.Warn("Weekly report generated without click data",
+			infralogger.String("channel_id", channelID.String()),
+			infralogger.Error(err),
+		)
+	}
+
+	if format == reportFormatJSON {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	html, err := reports.RenderHTML(report)
+	if err != nil {
+		r.log.Error("Failed to render weekly report", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render report"})
+		return
+	}
+	c.Data(http.StatusOK, htmlContentType, html)
+}
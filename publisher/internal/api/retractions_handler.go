@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// internalRequester is recorded as requested_by for retractions filed by other services
+const internalRequester = "internal"
+
+// requestRetraction takes a content item down from every channel it was
+// published to, on the router's next poll
+// POST /api/v1/retractions
+func (r *Router) requestRetraction(c *gin.Context) {
+	r.fileRetraction(c, requestActor(c))
+}
+
+// requestInternalRetraction is requestRetraction for other services, e.g.
+// index-manager when a classified document is deleted
+// POST /api/internal/v1/retractions
+func (r *Router) requestInternalRetraction(c *gin.Context) {
+	r.fileRetraction(c, internalRequester)
+}
+
+// fileRetraction binds a retraction request and queues it
+func (r *Router) fileRetraction(c *gin.Context, requestedBy string) {
+	var req models.RetractionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	retraction, err := r.repo.RequestRetraction(c.Request.Context(), req.ContentID, req.Reason, requestedBy)
+	if err != nil {
+		r.handleRepositoryError(c, err, "retraction", "request")
+		return
+	}
+
+	r.log.Info("Retraction requested",
+		infralogger.String("content_id", req.ContentID),
+		infralogger.String("reason", req.Reason),
+		infralogger.String("requested_by", requestedBy),
+	)
+
+	c.JSON(http.StatusAccepted, retraction)
+}
+
+// listRetractions lists retractions with their per-channel results
+// GET /api/v1/retractions?status=failed&limit=50&offset=0
+func (r *Router) listRetractions(c *gin.Context) {
+	var filter models.RetractionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	retractions, total, err := r.repo.ListRetractions(c.Request.Context(), &filter)
+	if err != nil {
+		r.handleRepositoryError(c, err, "retraction", "list")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"retractions": retractions,
+		"count":       len(retractions),
+		"total":       total,
+		"limit":       filter.Limit,
+		"offset":      filter.Offset,
+	})
+}
+
+// getRetraction returns one retraction
+// GET /api/v1/retractions/:id
+func (r *Router) getRetraction(c *gin.Context) {
+	id, ok := parseUUID(c, "id", "retraction")
+	if !ok {
+		return
+	}
+
+	retraction, err := r.repo.GetRetraction(c.Request.Context(), id)
+	if err != nil {
+		r.handleRepositoryError(c, err, "retraction", "get")
+		return
+	}
+
+	c.JSON(http.StatusOK, retraction)
+}
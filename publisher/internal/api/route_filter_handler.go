@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+)
+
+// routeFilterResult is how a filter evaluated for one content item
+type routeFilterResult struct {
+	ContentID string              `json:"content_id"`
+	Title     string              `json:"title,omitempty"`
+	Matches   bool                `json:"matches"`
+	Fields    *models.RouteFields `json:"fields,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// testRouteFilter parses a channel filter expression and reports its
+// canonical form, or where it fails to parse. With content_ids, it also
+// evaluates the filter against those items, showing the fields it saw.
+// POST /api/v1/routing/filters/test
+func (r *Router) testRouteFilter(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.RouteFilterTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	filter, err := models.ParseRouteFilter(req.Filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"valid":  false,
+			"error":  err.Error(),
+			"fields": models.RouteFilterFields(),
+		})
+		return
+	}
+
+	results := make([]routeFilterResult, 0, len(req.ContentIDs))
+	if len(req.ContentIDs) > 0 && r.esClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Elasticsearch not configured"})
+		return
+	}
+	for _, contentID := range req.ContentIDs {
+		result := routeFilterResult{ContentID: contentID}
+		item, fetchErr := router.FetchContentItem(ctx, r.esClient, contentID, r.log)
+		switch {
+		case errors.Is(fetchErr, router.ErrContentNotFound):
+			result.Error = "content item not found"
+		case fetchErr != nil:
+			r.log.Error("Failed to fetch content item", infralogger.String("content_id", contentID), infralogger.Error(fetchErr))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch content item"})
+			return
+		default:
+			result.Title = item.Title
+			result.Fields = item.RouteFields()
+			result.Matches = filter.Matches(result.Fields)
+		}
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"valid":   true,
+		"filter":  filter.String(),
+		"fields":  models.RouteFilterFields(),
+		"results": results,
+	})
+}
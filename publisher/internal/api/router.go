@@ -3,14 +3,20 @@ package api
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/clicks"
 	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/credentials"
 	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+	"github.com/jonesrussell/north-cloud/publisher/internal/reports"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
 )
@@ -32,17 +38,65 @@ type Router struct {
 	esClient    *elasticsearch.Client
 	cfg         *config.Config
 	log         logger.Logger
+	deep        *deepHealth
+	reports     *reports.Generator
+	drupal      *drupal.Client // nil when group discovery is not configured
+	credentials *credentials.Store
 }
 
 // NewRouter creates a new API router
 func NewRouter(repo *database.Repository, redisClient *redis.Client, esClient *elasticsearch.Client, cfg *config.Config, log logger.Logger) *Router {
-	return &Router{
+	r := &Router{
 		repo:        repo,
 		redisClient: redisClient,
 		esClient:    esClient,
 		cfg:         cfg,
 		log:         log,
+		reports: reports.NewGenerator(repo,
+			clicks.NewClient(cfg.ClickTracker.URL, cfg.Auth.JWTSecret, cfg.ClickTracker.Timeout)),
+		drupal:      newDrupalClient(&cfg.Drupal),
+		credentials: newCredentialStore(repo, &cfg.Credentials, log),
 	}
+	r.deep = r.newDeepHealth()
+	return r
+}
+
+// newDeepHealth builds the deep health check from the router's dependencies,
+// leaving unconfigured ones nil so they report as skipped.
+func (r *Router) newDeepHealth() *deepHealth {
+	var store deepHealthStore
+	if r.repo != nil {
+		store = r.repo
+	}
+	var drupalSite destinationPinger
+	if r.drupal != nil {
+		drupalSite = r.drupal
+	}
+	return newDeepHealth(store, r.redisClient, r.esClient, r.cfg, r.log).
+		withDestinations(drupalSite, r.credentials)
+}
+
+// newCredentialStore returns the credential store. Keys are checked when the
+// config loads, so an invalid key here leaves the store disabled.
+func newCredentialStore(repo *database.Repository, cfg *config.CredentialsConfig, log logger.Logger) *credentials.Store {
+	store, err := credentials.NewStore(repo, cfg.Key, cfg.PreviousKey)
+	if err != nil {
+		log.Error("Invalid credentials key: credential store disabled", logger.Error(err))
+		store, _ = credentials.NewStore(repo, "", "")
+	}
+	return store
+}
+
+// newDrupalClient returns the group discovery client, or nil when no Drupal URL is configured
+func newDrupalClient(cfg *config.DrupalConfig) *drupal.Client {
+	if cfg.URL == "" {
+		return nil
+	}
+	return drupal.NewClient(drupal.Config{
+		BaseURL:    cfg.URL,
+		Token:      cfg.Token,
+		GroupTypes: cfg.GroupTypes,
+	}, &http.Client{Timeout: cfg.Timeout})
 }
 
 // NewServer creates a new HTTP server using the infrastructure gin package.
@@ -85,6 +139,8 @@ func (r *Router) NewServer(log logger.Logger) *infragin.Server {
 			router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 			// Claudriel: public JSON (optional bearer LEADS_API_KEY) — must stay outside /api/v1 JWT group
 			router.GET("/api/leads", r.listClaudrielLeads)
+			// Feed channels: public RSS/Atom so readers subscribe without credentials
+			router.GET("/feeds/:id", r.getChannelFeed)
 			// Setup service-specific routes (health routes added by builder)
 			r.setupServiceRoutes(router)
 		}).
@@ -126,17 +182,71 @@ func extractPort(address string) int {
 // Health routes are handled by the infrastructure gin package.
 func (r *Router) setupServiceRoutes(router *gin.Engine) {
 	// API v1 routes - protected with JWT
-	v1 := infragin.ProtectedGroup(router, "/api/v1", r.cfg.Auth.JWTSecret)
+	// Read tokens may also use the POST endpoints that only evaluate rules
+	v1 := infragin.ProtectedGroup(router, "/api/v1", r.cfg.Auth.JWTSecret,
+		infrajwt.WithReadRoutes("/api/v1/channels/simulate", "/api/v1/routing/filters/test"))
+
+	// Deep health: per-dependency status for the ops dashboard
+	v1.GET("/health/deep", r.getDeepHealth)
 
 	// Channels (Layer 2 custom channels with rules)
 	channels := v1.Group("/channels")
 	channels.GET("", r.listChannels)
 	channels.POST("", r.createChannel)
-	channels.GET("/:id/preview", r.previewChannel) // Preview matching content
+	channels.GET("/drupal-sync", r.getDrupalSyncPlan)            // Drupal groups vs channels
+	channels.POST("/drupal-sync", r.provisionDrupalChannels)     // Create channels for selected groups
+	channels.POST("/simulate", r.simulateRoute)                  // Match draft or saved rules against recent content
+	channels.GET("/:id/preview", r.previewChannel)               // Preview matching content
+	channels.GET("/:id/queue", r.getChannelQueue)                // Upcoming items (JSON or ?format=rss)
+	channels.GET("/:id/render/:content_id", r.renderChannelItem) // Payload the channel would be sent for an item
+	channels.GET("/:id/holds", r.listChannelHolds)
+	channels.GET("/:id/reports/weekly", r.getWeeklyReport)          // JSON or ?format=html
+	channels.POST("/:id/holds", r.holdChannelItem)                  // Pull an item before it posts
+	channels.DELETE("/:id/holds/:content_id", r.releaseChannelItem) // Release a pulled item
+	channels.DELETE("/:id/health", r.resetChannelHealth)            // Close the circuit breaker
 	channels.GET("/:id", r.getChannel)
 	channels.PUT("/:id", r.updateChannel)
 	channels.DELETE("/:id", r.deleteChannel)
 
+	// Editorial approval queue (channels with requires_approval)
+	approvals := v1.Group("/approvals")
+	approvals.GET("", r.listApprovals)
+	approvals.GET("/:id", r.getApproval)
+	approvals.PATCH("/:id", r.editApproval)       // Edit title, body, or summary while pending
+	approvals.POST("/:id/approve", r.approveItem) // Deliver on the router's next poll
+	approvals.POST("/:id/reject", r.rejectItem)   // Never deliver
+
+	// Dead-letter queue (failed deliveries, retried with backoff)
+	deadLetters := v1.Group("/dead-letters")
+	deadLetters.GET("", r.listDeadLetters)
+	deadLetters.POST("/replay", r.replayDeadLetters) // Replay all, or one channel's
+	deadLetters.GET("/:id", r.getDeadLetter)
+	deadLetters.POST("/:id/replay", r.replayDeadLetter) // Retry on the router's next poll
+	deadLetters.DELETE("/:id", r.discardDeadLetter)     // Never retry
+
+	// Retractions (take content down from the channels it was published to)
+	retractions := v1.Group("/retractions")
+	retractions.GET("", r.listRetractions)
+	retractions.POST("", r.requestRetraction) // Processed on the router's next poll
+	retractions.GET("/:id", r.getRetraction)
+
+	// Routing domains (enable, disable, and parameterize; applied on the router's next poll)
+	routing := v1.Group("/routing")
+	routing.GET("/domains", r.listRoutingDomains)
+	routing.PUT("/domains/:name", r.updateRoutingDomain)
+	routing.DELETE("/domains/:name", r.resetRoutingDomain)       // Back to config.yml or defaults
+	routing.GET("/decisions/:content_id", r.getRoutingDecisions) // Per-domain decisions for one item
+	routing.POST("/filters/test", r.testRouteFilter)             // Validate a filter expression, optionally against items
+
+	// Channel credentials (encrypted at rest; values are never returned)
+	creds := v1.Group("/credentials")
+	creds.GET("", r.listCredentials)
+	creds.POST("", r.createCredential)
+	creds.POST("/reencrypt", r.reencryptCredentials) // Move credentials off credentials.previous_key
+	creds.GET("/:id", r.getCredential)
+	creds.POST("/:id/rotate", r.rotateCredential) // Replace the value; used from the next delivery
+	creds.DELETE("/:id", r.deleteCredential)
+
 	// Publish History
 	history := v1.Group("/publish-history")
 	history.GET("", r.listPublishHistory)
@@ -157,4 +267,20 @@ func (r *Router) setupServiceRoutes(router *gin.Engine) {
 	// Metadata (topics and indexes for Routing V2)
 	v1.GET("/topics", r.listTopics)
 	v1.GET("/indexes", r.listIndexes)
+
+	r.setupInternalRoutes(router)
+}
+
+// setupInternalRoutes configures service-to-service routes, protected by a
+// shared secret (X-Internal-Secret header) rather than JWT. Without a
+// configured secret the routes are not registered.
+func (r *Router) setupInternalRoutes(router *gin.Engine) {
+	if r.cfg.Auth.InternalSecret == "" {
+		r.log.Warn("AUTH_INTERNAL_SECRET not configured: internal endpoints will NOT be registered")
+		return
+	}
+
+	internal := router.Group("/api/internal/v1")
+	internal.Use(infragin.InternalAuthMiddleware(r.cfg.Auth.InternalSecret))
+	internal.POST("/retractions", r.requestInternalRetraction)
 }
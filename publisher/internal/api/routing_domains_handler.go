@@ -0,0 +1,166 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+)
+
+// listRoutingDomains lists every routing domain in routing order with its
+// effective setting and where it came from (default, config, or database)
+// GET /api/v1/routing/domains
+func (r *Router) listRoutingDomains(c *gin.Context) {
+	settings, ok := r.resolveRoutingDomains(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"domains": settings,
+		"count":   len(settings),
+	})
+}
+
+// updateRoutingDomain saves a domain's enabled flag and params, overriding
+// config.yml. The router picks it up on its next poll.
+// PUT /api/v1/routing/domains/:name
+func (r *Router) updateRoutingDomain(c *gin.Context) {
+	name := c.Param("name")
+	registry := router.NewDomainRegistry()
+	if _, ok := registry.Lookup(name); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "routing domain not found"})
+		return
+	}
+
+	var req models.RoutingDomainUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+		return
+	}
+	if err := registry.ValidateParams(name, req.Params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid routing domain params",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	saved, err := r.repo.SaveRoutingDomainSetting(c.Request.Context(), name, *req.Enabled, req.Params, requestActor(c))
+	if err != nil {
+		r.handleRepositoryError(c, err, "routing domain", "update")
+		return
+	}
+
+	r.log.Info("Routing domain updated",
+		infralogger.String("domain", name),
+		infralogger.Bool("enabled", saved.Enabled),
+		infralogger.String("updated_by", saved.UpdatedBy),
+	)
+
+	r.respondRoutingDomain(c, name)
+}
+
+// resetRoutingDomain removes a domain's saved setting so config.yml or the
+// built-in defaults apply again
+// DELETE /api/v1/routing/domains/:name
+func (r *Router) resetRoutingDomain(c *gin.Context) {
+	name := c.Param("name")
+	if _, ok := router.NewDomainRegistry().Lookup(name); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "routing domain not found"})
+		return
+	}
+
+	if err := r.repo.DeleteRoutingDomainSetting(c.Request.Context(), name); err != nil {
+		r.handleRepositoryError(c, err, "routing domain setting", "reset")
+		return
+	}
+
+	r.respondRoutingDomain(c, name)
+}
+
+// getRoutingDecisions runs every routing domain, enabled or not, against one
+// classified content item and reports the channels each would route it to.
+// Dedup, holds, and approval are not applied.
+// GET /api/v1/routing/decisions/:content_id
+func (r *Router) getRoutingDecisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	contentID := c.Param("content_id")
+
+	if r.esClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Elasticsearch not configured"})
+		return
+	}
+
+	settings, ok := r.resolveRoutingDomains(c)
+	if !ok {
+		return
+	}
+
+	channels, err := r.repo.ListEnabledChannelsWithRules(ctx)
+	if err != nil {
+		r.handleRepositoryError(c, err, "channels", "list")
+		return
+	}
+
+	item, err := router.FetchContentItem(ctx, r.esClient, contentID, r.log)
+	if errors.Is(err, router.ErrContentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "content item not found"})
+		return
+	}
+	if err != nil {
+		r.log.Error("Failed to fetch content item", infralogger.String("content_id", contentID), infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch content item"})
+		return
+	}
+
+	decisions := router.NewDomainRegistry().Explain(settings, channels, item)
+	routedTo := make([]string, 0)
+	for _, decision := range decisions {
+		if decision.Applied {
+			routedTo = append(routedTo, decision.Channels...)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"content_id":    item.ID,
+		"title":         item.Title,
+		"quality_score": item.QualityScore,
+		"topics":        item.Topics,
+		"content_type":  item.ContentType,
+		"decisions":     decisions,
+		"channels":      routedTo,
+	})
+}
+
+// resolveRoutingDomains resolves the effective domain settings and writes the
+// error response on failure
+func (r *Router) resolveRoutingDomains(c *gin.Context) ([]router.DomainSetting, bool) {
+	saved, err := r.repo.ListRoutingDomainSettings(c.Request.Context())
+	if err != nil {
+		r.handleRepositoryError(c, err, "routing domains", "list")
+		return nil, false
+	}
+	return router.NewDomainRegistry().Resolve(r.cfg.Routing.Domains, saved), true
+}
+
+// respondRoutingDomain writes the named domain's effective setting
+func (r *Router) respondRoutingDomain(c *gin.Context, name string) {
+	settings, ok := r.resolveRoutingDomains(c)
+	if !ok {
+		return
+	}
+	for i := range settings {
+		if settings[i].Name == name {
+			c.JSON(http.StatusOK, settings[i])
+			return
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "routing domain not found"})
+}
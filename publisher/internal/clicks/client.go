@@ -0,0 +1,70 @@
+// Package clicks reads aggregate click statistics from the click-tracker service.
+package clicks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+)
+
+// ErrNotConfigured is returned when no click-tracker URL is set.
+var ErrNotConfigured = errors.New("click-tracker URL not configured")
+
+// ResultClicks is the click total for one content item.
+type ResultClicks struct {
+	ResultID       string `json:"result_id"`
+	Clicks         int64  `json:"clicks"`
+	UniqueSessions int64  `json:"unique_sessions"`
+}
+
+// Client calls the click-tracker stats API with a service JWT.
+type Client struct {
+	baseURL string
+	service *infrahttp.ServiceClient
+}
+
+// NewClient creates a click-tracker client. An empty baseURL yields a client
+// whose calls return ErrNotConfigured.
+func NewClient(baseURL, jwtSecret string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:   timeout,
+			JWTSecret: jwtSecret,
+			Subject:   "publisher",
+		}),
+	}
+}
+
+// Enabled reports whether a click-tracker URL is configured.
+func (c *Client) Enabled() bool {
+	return c != nil && c.baseURL != ""
+}
+
+// TopResults returns the most-clicked of contentIDs in [since, until), most clicks first.
+func (c *Client) TopResults(
+	ctx context.Context, contentIDs []string, since, until time.Time, limit int,
+) ([]ResultClicks, error) {
+	if !c.Enabled() {
+		return nil, ErrNotConfigured
+	}
+
+	body := map[string]any{
+		"result_ids": contentIDs,
+		"since":      since.UTC(),
+		"until":      until.UTC(),
+		"limit":      limit,
+	}
+	var decoded struct {
+		Results []ResultClicks `json:"results"`
+	}
+	if err := c.service.Do(ctx, http.MethodPost, c.baseURL+"/api/v1/stats/results", body, &decoded); err != nil {
+		return nil, fmt.Errorf("call click-tracker: %w", err)
+	}
+	return decoded.Results, nil
+}
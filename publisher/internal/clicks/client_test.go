@@ -0,0 +1,48 @@
+package clicks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/clicks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TopResults(t *testing.T) {
+	var (
+		gotAuth string
+		gotReq  map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/stats/results", r.URL.Path)
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_, _ = w.Write([]byte(`{"results":[{"result_id":"doc-1","clicks":4,"unique_sessions":3}],"count":1}`))
+	}))
+	defer srv.Close()
+
+	client := clicks.NewClient(srv.URL+"/", "secret", time.Second)
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	results, err := client.TopResults(context.Background(), []string{"doc-1", "doc-2"}, since, since.AddDate(0, 0, 7), 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []clicks.ResultClicks{{ResultID: "doc-1", Clicks: 4, UniqueSessions: 3}}, results)
+	assert.True(t, strings.HasPrefix(gotAuth, "Bearer "))
+	assert.Equal(t, []any{"doc-1", "doc-2"}, gotReq["result_ids"])
+}
+
+func TestClient_NotConfigured(t *testing.T) {
+	client := clicks.NewClient("", "", time.Second)
+	assert.False(t, client.Enabled())
+
+	_, err := client.TopResults(context.Background(), []string{"doc-1"}, time.Now(), time.Now(), 10)
+	assert.True(t, errors.Is(err, clicks.ErrNotConfigured))
+}
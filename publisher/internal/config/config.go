@@ -2,11 +2,13 @@ package config
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
 	infracontext "github.com/jonesrussell/north-cloud/infrastructure/context"
 )
@@ -20,6 +22,18 @@ const (
 	DefaultShutdownTimeoutSeconds = 30
 	// DefaultServerAddress is the default server listen address
 	DefaultServerAddress = ":8070"
+	// DefaultDedupWindow is how long a published story blocks its variants from other sources
+	DefaultDedupWindow = 48 * time.Hour
+	// DefaultRedisDelivery is how items reach Redis channels unless configured
+	DefaultRedisDelivery = "streams"
+	// DefaultStreamMaxLen is the approximate cap on entries kept per channel stream
+	DefaultStreamMaxLen = 10000
+	// DefaultHealthCheckInterval is how often each channel destination is probed
+	DefaultHealthCheckInterval = time.Minute
+	// DefaultCircuitFailureThreshold is how many failures in a row open a channel's circuit
+	DefaultCircuitFailureThreshold = 5
+	// credentialsKeyBytes is the AES-256 key size of the credential store
+	credentialsKeyBytes = 32
 )
 
 type Config struct {
@@ -32,6 +46,87 @@ type Config struct {
 	Cities        []CityConfig        `yaml:"cities"`
 	Sources       SourcesConfig       `yaml:"sources"` // Optional: Sources service configuration
 	Auth          AuthConfig          `yaml:"auth"`
+	ClickTracker  ClickTrackerConfig  `yaml:"click_tracker"` // Optional: click stats for weekly reports
+	Reports       ReportsConfig       `yaml:"reports"`
+	Drupal        DrupalConfig        `yaml:"drupal"` // Optional: group discovery for channel provisioning
+	Routing       RoutingConfig       `yaml:"routing"`
+	Credentials   CredentialsConfig   `yaml:"credentials"` // Optional: encrypted channel credential store
+	// Source is the file the configuration was read from; empty when it came
+	// from environment variables alone (see Resolve).
+	Source string `yaml:"-"`
+}
+
+// CredentialsConfig holds the AES-256 keys channel credentials are encrypted
+// with, as 64 hex characters. The credential store is disabled when Key is
+// empty. During a key change the old key goes in PreviousKey until
+// POST /api/v1/credentials/reencrypt has moved every credential to Key.
+type CredentialsConfig struct {
+	Key         string `env:"PUBLISHER_CREDENTIALS_KEY"          yaml:"key"`
+	PreviousKey string `env:"PUBLISHER_CREDENTIALS_PREVIOUS_KEY" yaml:"previous_key"`
+}
+
+// Validate checks that configured keys are 32 bytes of hex.
+func (c *CredentialsConfig) Validate() error {
+	for name, key := range map[string]string{"key": c.Key, "previous_key": c.PreviousKey} {
+		if key == "" {
+			continue
+		}
+		if decoded, err := hex.DecodeString(key); err != nil || len(decoded) != credentialsKeyBytes {
+			return fmt.Errorf("credentials.%s must be %d hex characters", name, 2*credentialsKeyBytes)
+		}
+	}
+	if c.Key == "" && c.PreviousKey != "" {
+		return errors.New("credentials.previous_key requires credentials.key")
+	}
+	return nil
+}
+
+// RoutingConfig enables, disables, and parameterizes routing domains by name
+// (e.g. "topic", "quality_tier", "db_channel"). Settings saved through the API
+// take precedence; domains not listed use their built-in defaults.
+type RoutingConfig struct {
+	Domains map[string]RoutingDomainConfig `yaml:"domains"`
+}
+
+// RoutingDomainConfig is one domain's setting. A nil Enabled keeps the
+// domain's default; Params override the domain's default parameters.
+type RoutingDomainConfig struct {
+	Enabled *bool          `yaml:"enabled"`
+	Params  map[string]any `yaml:"params"`
+}
+
+// DrupalConfig points at the Drupal site whose groups are offered as channels.
+// Group discovery is disabled when URL is empty.
+type DrupalConfig struct {
+	URL        string        `env:"DRUPAL_URL"         yaml:"url"`         // e.g. "https://streetcode.example.com"
+	Token      string        `env:"DRUPAL_TOKEN"       yaml:"token"`       // Bearer token for JSON:API; empty for anonymous access
+	GroupTypes []string      `env:"DRUPAL_GROUP_TYPES" yaml:"group_types"` // Group bundles to list, e.g. ["community"]
+	Timeout    time.Duration `yaml:"timeout"`                              // Request timeout (default: 10s)
+}
+
+// ClickTrackerConfig points at the click-tracker stats API (URL, internal) and
+// signs click-tracked links in chat cards (BaseURL, public; Secret and KeyID shared with click-tracker)
+type ClickTrackerConfig struct {
+	URL     string        `env:"CLICK_TRACKER_URL"      yaml:"url"`      // e.g. "http://click-tracker:8093"; empty disables click stats
+	Timeout time.Duration `yaml:"timeout"`                               // Request timeout (default: 5s)
+	BaseURL string        `env:"CLICK_TRACKER_BASE_URL" yaml:"base_url"` // e.g. "https://northcloud.one/api"; empty keeps plain links
+	Secret  string        `env:"CLICK_TRACKER_SECRET"   yaml:"secret"`   // HMAC secret, same value as the click-tracker's
+	KeyID   string        `env:"CLICK_TRACKER_KEY_ID"   yaml:"key_id"`   // Names Secret in signatures; empty signs without a key ID
+}
+
+// SigningKey returns the key click-tracked links are signed with.
+func (c *ClickTrackerConfig) SigningKey() clickurl.Key {
+	return clickurl.Key{ID: c.KeyID, Secret: c.Secret}
+}
+
+// ReportsConfig configures weekly report emails. Reports are still available
+// through the API when SMTP is not configured.
+type ReportsConfig struct {
+	SMTPHost     string `env:"REPORTS_SMTP_HOST"     yaml:"smtp_host"`
+	SMTPPort     int    `env:"REPORTS_SMTP_PORT"     yaml:"smtp_port"` // Default: 587
+	SMTPUsername string `env:"REPORTS_SMTP_USERNAME" yaml:"smtp_username"`
+	SMTPPassword string `env:"REPORTS_SMTP_PASSWORD" yaml:"smtp_password"`
+	From         string `env:"REPORTS_FROM"          yaml:"from"` // e.g. "North Cloud <reports@example.com>"
 }
 
 type DatabaseConfig struct {
@@ -53,12 +148,34 @@ type RedisConfig struct {
 	URL      string `env:"REDIS_URL"      yaml:"url"`
 	Password string `env:"REDIS_PASSWORD" yaml:"password"`
 	DB       int    `yaml:"db"`
+	// Delivery is how items reach Redis channels: "streams" (default; the
+	// channel name is the stream key), "pubsub", or "both" while consumers move
+	// from pub/sub to streams.
+	Delivery string `env:"PUBLISHER_REDIS_DELIVERY" yaml:"delivery"`
+	// StreamMaxLen caps each channel stream at about this many entries (default 10000).
+	StreamMaxLen int64 `env:"PUBLISHER_STREAM_MAX_LEN" yaml:"stream_max_len"`
+}
+
+// Validate checks the delivery mode and stream cap.
+func (c *RedisConfig) Validate() error {
+	switch c.Delivery {
+	case "", "streams", "pubsub", "both":
+	default:
+		return fmt.Errorf("redis.delivery must be streams, pubsub, or both, got %q", c.Delivery)
+	}
+	if c.StreamMaxLen < 0 {
+		return fmt.Errorf("redis.stream_max_len must not be negative, got %d", c.StreamMaxLen)
+	}
+	return nil
 }
 
 type AuthConfig struct {
 	JWTSecret string `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
 	// LeadsAPIKey when non-empty requires Authorization: Bearer <key> on GET /api/leads (Claudriel).
 	LeadsAPIKey string `env:"LEADS_API_KEY" yaml:"leads_api_key"`
+	// InternalSecret authenticates service-to-service calls (X-Internal-Secret),
+	// e.g. index-manager filing retractions. Internal routes are off when empty.
+	InternalSecret string `env:"AUTH_INTERNAL_SECRET" yaml:"internal_secret"`
 }
 
 type ServiceConfig struct {
@@ -68,6 +185,15 @@ type ServiceConfig struct {
 	MinQualityScore      int           `yaml:"min_quality_score"`      // Minimum quality score for classified content (0-100)
 	IndexSuffix          string        `yaml:"index_suffix"`           // Index suffix (_articles or _classified_content)
 	PipelineURL          string        `env:"PIPELINE_URL"                    yaml:"pipeline_url"`
+	// DedupWindow is how long a story published to a channel blocks variants
+	// from other sources (same normalized title and opening); negative disables
+	DedupWindow time.Duration `env:"PUBLISHER_DEDUP_WINDOW" yaml:"dedup_window"`
+	// HealthCheckInterval is how often custom channel destinations are probed;
+	// negative disables probes
+	HealthCheckInterval time.Duration `env:"PUBLISHER_HEALTH_CHECK_INTERVAL" yaml:"health_check_interval"`
+	// CircuitFailureThreshold is how many failed probes or deliveries in a row
+	// pause routing to a channel; negative disables the circuit breaker
+	CircuitFailureThreshold int `env:"PUBLISHER_CIRCUIT_FAILURE_THRESHOLD" yaml:"circuit_failure_threshold"`
 }
 
 type CityConfig struct {
@@ -114,6 +240,9 @@ func (c *Config) Validate() error {
 	if c.Redis.URL == "" {
 		return errors.New("redis.url is required")
 	}
+	if err := c.Redis.Validate(); err != nil {
+		return err
+	}
 	if c.Service.CheckInterval <= 0 {
 		return fmt.Errorf("service.check_interval must be positive, got %v", c.Service.CheckInterval)
 	}
@@ -125,7 +254,10 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("cities[%d].name is required", i)
 		}
 	}
-	return nil
+	if _, err := clickurl.NewKeyedSigner(c.ClickTracker.SigningKey()); err != nil {
+		return fmt.Errorf("click_tracker.key_id: %w", err)
+	}
+	return c.Credentials.Validate()
 }
 
 // SetDefaults sets default values for configuration fields
@@ -145,9 +277,33 @@ func SetDefaults(cfg *Config) {
 	if cfg.Service.BatchSize == 0 {
 		cfg.Service.BatchSize = 100
 	}
+	if cfg.Service.DedupWindow == 0 {
+		cfg.Service.DedupWindow = DefaultDedupWindow
+	}
+	if cfg.Service.HealthCheckInterval == 0 {
+		cfg.Service.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	if cfg.Service.CircuitFailureThreshold == 0 {
+		cfg.Service.CircuitFailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if cfg.Redis.Delivery == "" {
+		cfg.Redis.Delivery = DefaultRedisDelivery
+	}
+	if cfg.Redis.StreamMaxLen == 0 {
+		cfg.Redis.StreamMaxLen = DefaultStreamMaxLen
+	}
 	if cfg.Sources.Timeout == 0 {
 		cfg.Sources.Timeout = 5 * time.Second
 	}
+	if cfg.ClickTracker.Timeout == 0 {
+		cfg.ClickTracker.Timeout = 5 * time.Second
+	}
+	if cfg.Drupal.Timeout == 0 {
+		cfg.Drupal.Timeout = 10 * time.Second
+	}
+	if cfg.Reports.SMTPPort == 0 {
+		cfg.Reports.SMTPPort = 587
+	}
 	// Database defaults
 	if cfg.Database.Host == "" {
 		cfg.Database.Host = "localhost"
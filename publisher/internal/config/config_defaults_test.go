@@ -251,6 +251,21 @@ func TestConfig_Validate_NonPositiveCheckInterval(t *testing.T) {
 	assert.Contains(t, err.Error(), "service.check_interval must be positive")
 }
 
+func TestConfig_Validate_InvalidClickKeyID(t *testing.T) {
+	t.Helper()
+
+	cfg := &Config{
+		Elasticsearch: ElasticsearchConfig{URL: "http://localhost:9200"},
+		Redis:         RedisConfig{URL: "redis://localhost:6379"},
+		Service:       ServiceConfig{CheckInterval: 5 * time.Minute},
+		ClickTracker:  ClickTrackerConfig{Secret: "secret", KeyID: "2026.a"},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "click_tracker.key_id")
+}
+
 func TestConfig_Validate_SourcesEnabledWithoutURL(t *testing.T) {
 	t.Helper()
 
@@ -339,3 +354,13 @@ func TestConstants(t *testing.T) {
 	assert.Equal(t, 30, DefaultShutdownTimeoutSeconds)
 	assert.Equal(t, ":8070", DefaultServerAddress)
 }
+
+func TestSetDefaults_ReportDefaults(t *testing.T) {
+	t.Helper()
+
+	cfg := &Config{}
+	SetDefaults(cfg)
+
+	assert.Equal(t, 5*time.Second, cfg.ClickTracker.Timeout)
+	assert.Equal(t, 587, cfg.Reports.SMTPPort)
+}
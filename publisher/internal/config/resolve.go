@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"gopkg.in/yaml.v3"
+)
+
+// secretMask replaces secret values in Settings.
+const secretMask = "********"
+
+// legacyEnv maps environment variables that older docs and deployments used
+// to the variable the publisher reads instead.
+var legacyEnv = map[string]string{
+	"REDIS_ADDR":        "REDIS_URL",
+	"ELASTICSEARCH_URL": "ES_URL",
+}
+
+// Resolve loads the configuration every publisher command uses (api, router,
+// both, reports, drupal-sync, consume, config check): the YAML file at path
+// with environment variables overriding it, or the environment alone when the
+// file does not exist. Defaults are applied and the result is validated. A
+// file that exists but cannot be read, parsed, or validated is an error, not
+// a reason to fall back to the environment.
+func Resolve(path string) (*Config, error) {
+	cfg, err := infraconfig.LoadWithDefaults(path, SetDefaults)
+	switch {
+	case err == nil:
+		cfg.Source = path
+	case errors.Is(err, fs.ErrNotExist):
+		cfg = &Config{}
+		if envErr := infraconfig.ApplyEnvOverrides(cfg); envErr != nil {
+			return nil, fmt.Errorf("apply environment: %w", envErr)
+		}
+		SetDefaults(cfg)
+	default:
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	if serverErr := cfg.Server.Validate(); serverErr != nil {
+		return nil, fmt.Errorf("server config validation: %w", serverErr)
+	}
+	if validateErr := cfg.Validate(); validateErr != nil {
+		return nil, fmt.Errorf("invalid config: %w", validateErr)
+	}
+	return cfg, nil
+}
+
+// UnknownKeys returns the keys of the YAML file at path that no setting
+// reads, e.g. a misspelled or renamed key, as yaml.v3 reports them. A missing
+// file has none.
+func UnknownKeys(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read config file %s: %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var cfg Config
+	err = decoder.Decode(&cfg)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	return typeErr.Errors, nil
+}
+
+// StrayEnv returns a warning for each variable of environ (as from
+// os.Environ) that has a legacy name the publisher does not read.
+func StrayEnv(environ []string) []string {
+	var warnings []string
+	for _, entry := range environ {
+		name, value, _ := strings.Cut(entry, "=")
+		if replacement, ok := legacyEnv[name]; ok && value != "" {
+			warnings = append(warnings, fmt.Sprintf("%s is not read; use %s", name, replacement))
+		}
+	}
+	slices.Sort(warnings)
+	return warnings
+}
+
+// Setting is one resolved configuration value.
+type Setting struct {
+	// Key is the YAML path, e.g. "redis.delivery".
+	Key string
+	// Env is the environment variable that overrides it, if any.
+	Env   string
+	Value string
+	// FromEnv is true when Env is set and so decided the value.
+	FromEnv bool
+}
+
+// Settings lists every scalar setting of cfg in declaration order. Secrets
+// (passwords, tokens, keys) that are set show as a mask.
+func Settings(cfg *Config) []Setting {
+	var settings []Setting
+	collectSettings(reflect.ValueOf(cfg).Elem(), "", &settings)
+	return settings
+}
+
+func collectSettings(v reflect.Value, prefix string, settings *[]Setting) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		key := prefix + name
+		value := v.Field(i)
+
+		if value.Kind() == reflect.Struct && field.Type.PkgPath() == t.PkgPath() {
+			collectSettings(value, key+".", settings)
+			continue
+		}
+
+		env := field.Tag.Get("env")
+		*settings = append(*settings, Setting{
+			Key:     key,
+			Env:     env,
+			Value:   formatSetting(field.Name, value),
+			FromEnv: env != "" && os.Getenv(env) != "",
+		})
+	}
+}
+
+// formatSetting renders a value for display; lists of structs and maps show
+// their size only.
+func formatSetting(fieldName string, value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Map:
+		return fmt.Sprintf("(%d entries)", value.Len())
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Struct {
+			return fmt.Sprintf("(%d entries)", value.Len())
+		}
+	}
+
+	formatted := fmt.Sprint(value.Interface())
+	if formatted != "" && isSecretField(fieldName) {
+		return secretMask
+	}
+	return formatted
+}
+
+// isSecretField reports whether a field holds a credential.
+func isSecretField(name string) bool {
+	return strings.Contains(name, "Password") || strings.Contains(name, "Secret") ||
+		strings.Contains(name, "Token") || strings.HasSuffix(name, "Key")
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestResolve_EnvironmentOnlyWhenFileMissing(t *testing.T) {
+	t.Setenv("ES_URL", "http://es:9200")
+	t.Setenv("REDIS_URL", "redis:6379")
+
+	cfg, err := Resolve(filepath.Join(t.TempDir(), "missing.yml"))
+
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Source)
+	assert.Equal(t, "http://es:9200", cfg.Elasticsearch.URL)
+	assert.Equal(t, DefaultRedisDelivery, cfg.Redis.Delivery)
+	assert.Equal(t, DefaultServerAddress, cfg.Server.Address)
+}
+
+func TestResolve_EnvironmentOverridesFile(t *testing.T) {
+	path := writeConfigFile(t, "elasticsearch:\n  url: http://file:9200\nredis:\n  url: file:6379\n")
+	t.Setenv("ES_URL", "http://env:9200")
+
+	cfg, err := Resolve(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, path, cfg.Source)
+	assert.Equal(t, "http://env:9200", cfg.Elasticsearch.URL)
+	assert.Equal(t, "file:6379", cfg.Redis.URL)
+}
+
+func TestResolve_InvalidFileDoesNotFallBackToEnvironment(t *testing.T) {
+	path := writeConfigFile(t, "elasticsearch:\n  url: http://file:9200\nredis:\n  url: file:6379\n  delivery: kafka\n")
+	t.Setenv("ES_URL", "http://env:9200")
+	t.Setenv("REDIS_URL", "env:6379")
+
+	_, err := Resolve(path)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "redis.delivery")
+}
+
+func TestResolve_MissingRequiredSetting(t *testing.T) {
+	t.Setenv("ES_URL", "")
+	t.Setenv("REDIS_URL", "redis:6379")
+
+	_, err := Resolve(filepath.Join(t.TempDir(), "missing.yml"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "elasticsearch.url")
+}
+
+func TestUnknownKeys(t *testing.T) {
+	path := writeConfigFile(t, "router:\n  check_interval: 5m\nservice:\n  batch_size: 10\n")
+
+	unknown, err := UnknownKeys(path)
+
+	require.NoError(t, err)
+	require.Len(t, unknown, 1)
+	assert.Contains(t, unknown[0], "field router not found")
+}
+
+func TestUnknownKeys_MissingFile(t *testing.T) {
+	unknown, err := UnknownKeys(filepath.Join(t.TempDir(), "missing.yml"))
+
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+}
+
+func TestStrayEnv(t *testing.T) {
+	warnings := StrayEnv([]string{"REDIS_ADDR=redis:6379", "REDIS_URL=redis:6379", "ELASTICSEARCH_URL=", "PATH=/bin"})
+
+	assert.Equal(t, []string{"REDIS_ADDR is not read; use REDIS_URL"}, warnings)
+}
+
+func TestSettings_MasksSecrets(t *testing.T) {
+	t.Setenv("REDIS_URL", "redis:6379")
+	cfg := &Config{}
+	cfg.Redis.URL = "redis:6379"
+	cfg.Redis.Password = "hunter2"
+	cfg.Auth.JWTSecret = "jwt"
+	cfg.Credentials.Key = "abcd"
+
+	byKey := make(map[string]Setting)
+	for _, setting := range Settings(cfg) {
+		byKey[setting.Key] = setting
+	}
+
+	assert.Equal(t, "redis:6379", byKey["redis.url"].Value)
+	assert.True(t, byKey["redis.url"].FromEnv)
+	assert.Equal(t, secretMask, byKey["redis.password"].Value)
+	assert.Equal(t, secretMask, byKey["auth.jwt_secret"].Value)
+	assert.Equal(t, secretMask, byKey["credentials.key"].Value)
+	assert.Empty(t, byKey["database.password"].Value, "unset secrets stay empty")
+	assert.NotContains(t, byKey, "source")
+}
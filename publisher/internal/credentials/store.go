@@ -0,0 +1,152 @@
+// Package credentials stores channel secrets encrypted at rest with AES-256-GCM.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/infrastructure/secretbox"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+var (
+	// ErrNotConfigured is returned when no encryption key is configured.
+	ErrNotConfigured = errors.New("credential encryption key is not configured")
+	// ErrUnknownKey is returned for a credential sealed with a key that is
+	// neither the current nor the previous key.
+	ErrUnknownKey = secretbox.ErrUnknownKey
+)
+
+// Store creates, rotates, and decrypts channel credentials. Each value is
+// sealed with the current key and bound to the credential's name, so a
+// ciphertext copied onto another row does not decrypt. A previous key can be
+// configured while a key change is rolled out; Reencrypt moves credentials
+// still sealed with it onto the current key.
+type Store struct {
+	repo *database.Repository
+	keys *secretbox.Keyring
+}
+
+// NewStore creates a store. An empty key leaves the store unconfigured: every
+// operation returns ErrNotConfigured. Keys are 64 hex characters (32 bytes).
+func NewStore(repo *database.Repository, keyHex, previousKeyHex string) (*Store, error) {
+	keys, err := secretbox.NewKeyring(keyHex, previousKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("credentials %w", err)
+	}
+	return &Store{repo: repo, keys: keys}, nil
+}
+
+// Enabled reports whether an encryption key is configured.
+func (s *Store) Enabled() bool {
+	return s.keys != nil
+}
+
+// Create encrypts and stores a new credential.
+func (s *Store) Create(
+	ctx context.Context, req *models.CredentialCreateRequest, actor string,
+) (*models.Credential, error) {
+	if !s.Enabled() {
+		return nil, ErrNotConfigured
+	}
+	current := s.keys.Current()
+	ciphertext, err := current.Seal([]byte(req.Value), []byte(req.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateCredential(ctx, &models.Credential{
+		Name:        req.Name,
+		Tenant:      req.Tenant,
+		Kind:        req.Kind,
+		Description: req.Description,
+		Ciphertext:  ciphertext,
+		KeyID:       current.ID(),
+		Hint:        models.CredentialHint(req.Value),
+		CreatedBy:   actor,
+	})
+}
+
+// Rotate replaces a credential's value. Channels referencing it use the new
+// value from their next delivery.
+func (s *Store) Rotate(ctx context.Context, id uuid.UUID, value string) (*models.Credential, error) {
+	if !s.Enabled() {
+		return nil, ErrNotConfigured
+	}
+	cred, err := s.repo.GetCredentialByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	current := s.keys.Current()
+	ciphertext, err := current.Seal([]byte(value), []byte(cred.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.RotateCredential(ctx, id, ciphertext, current.ID(), models.CredentialHint(value))
+}
+
+// Reveal decrypts the named credential's value. Returns models.ErrNotFound
+// when no credential has that name.
+func (s *Store) Reveal(ctx context.Context, name string) (string, error) {
+	if !s.Enabled() {
+		return "", ErrNotConfigured
+	}
+	cred, err := s.repo.GetCredentialByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return s.open(cred)
+}
+
+// Reencrypt seals every credential still encrypted with the previous key
+// with the current key, and returns how many were moved. Credentials sealed
+// with an unknown key are left alone and reported in the error.
+func (s *Store) Reencrypt(ctx context.Context) (int, error) {
+	if !s.Enabled() {
+		return 0, ErrNotConfigured
+	}
+	creds, err := s.repo.ListCredentials(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	current := s.keys.Current()
+	moved := 0
+	var errs []error
+	for i := range creds {
+		cred := &creds[i]
+		if cred.KeyID == current.ID() {
+			continue
+		}
+		value, openErr := s.open(cred)
+		if openErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", cred.Name, openErr))
+			continue
+		}
+		ciphertext, sealErr := current.Seal([]byte(value), []byte(cred.Name))
+		if sealErr != nil {
+			return moved, sealErr
+		}
+		if updateErr := s.repo.ReencryptCredential(ctx, cred.ID, ciphertext, current.ID()); updateErr != nil {
+			return moved, updateErr
+		}
+		moved++
+	}
+	return moved, errors.Join(errs...)
+}
+
+// open decrypts a credential with the key it was sealed with.
+func (s *Store) open(cred *models.Credential) (string, error) {
+	plaintext, err := s.keys.Open(cred.KeyID, cred.Ciphertext, []byte(cred.Name))
+	if errors.Is(err, ErrUnknownKey) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("decrypt credential: %w", err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,67 @@
+//nolint:testpackage // Testing unexported open requires same package access
+package credentials
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/secretbox"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey     = strings.Repeat("ab", secretbox.KeyLength)
+	testOldKey  = strings.Repeat("cd", secretbox.KeyLength)
+	testJunkKey = strings.Repeat("ef", secretbox.KeyLength)
+)
+
+func TestNewStore_Keys(t *testing.T) {
+	store, err := NewStore(nil, "", "")
+	require.NoError(t, err)
+	assert.False(t, store.Enabled())
+	_, revealErr := store.Reveal(t.Context(), "partner-wp")
+	require.ErrorIs(t, revealErr, ErrNotConfigured)
+
+	_, err = NewStore(nil, "abcd", "")
+	require.Error(t, err, "keys must be 32 bytes")
+	_, err = NewStore(nil, strings.Repeat("zz", secretbox.KeyLength), "")
+	require.Error(t, err, "keys must be hex")
+}
+
+func TestStore_BindsValueToName(t *testing.T) {
+	store, err := NewStore(nil, testKey, "")
+	require.NoError(t, err)
+
+	sealed, err := store.keys.Current().Seal([]byte("app-password"), []byte("partner-wp"))
+	require.NoError(t, err)
+	cred := &models.Credential{Name: "partner-wp", Ciphertext: sealed, KeyID: store.keys.Current().ID()}
+
+	value, err := store.open(cred)
+	require.NoError(t, err)
+	assert.Equal(t, "app-password", value)
+
+	cred.Name = "other-wp"
+	_, err = store.open(cred)
+	require.Error(t, err, "a ciphertext copied to another credential must not decrypt")
+}
+
+func TestStore_OpensWithPreviousKey(t *testing.T) {
+	old, err := NewStore(nil, testOldKey, "")
+	require.NoError(t, err)
+	sealed, err := old.keys.Current().Seal([]byte("s3cret"), []byte("partner-hook"))
+	require.NoError(t, err)
+	cred := &models.Credential{Name: "partner-hook", Ciphertext: sealed, KeyID: old.keys.Current().ID()}
+
+	rotated, err := NewStore(nil, testKey, testOldKey)
+	require.NoError(t, err)
+	value, err := rotated.open(cred)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	unrelated, err := NewStore(nil, testJunkKey, "")
+	require.NoError(t, err)
+	_, err = unrelated.open(cred)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
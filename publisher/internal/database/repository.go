@@ -19,7 +19,8 @@ import (
 const (
 	whereEnabledTrue = " WHERE enabled = true"
 	// channelsSelectList is the column list for SELECT/RETURNING on channels (single source for schema changes)
-	channelsSelectList = "id, name, slug, redis_channel, description, rules, rules_version, enabled, created_at, updated_at"
+	channelsSelectList = "id, name, slug, redis_channel, description, rules, rules_version, enabled, channel_type, config, " +
+		"requires_approval, created_at, updated_at"
 	// updateQueryExtraArgs is the number of additional arguments added to update queries
 	// (updated_at timestamp and id for WHERE clause)
 	updateQueryExtraArgs = 2
@@ -57,6 +58,20 @@ func (r *Repository) CreateChannel(ctx context.Context, req *models.ChannelCreat
 		}
 	}
 
+	configJSON := []byte("{}")
+	if req.Config != nil {
+		var err error
+		configJSON, err = json.Marshal(req.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal channel config: %w", err)
+		}
+	}
+
+	channelType := req.Type
+	if channelType == "" {
+		channelType = models.ChannelTypeRedis
+	}
+
 	channel := &models.Channel{
 		ID:           uuid.New(),
 		Name:         req.Name,
@@ -66,6 +81,8 @@ func (r *Repository) CreateChannel(ctx context.Context, req *models.ChannelCreat
 		RulesJSON:    rulesJSON,
 		RulesVersion: 1,
 		Enabled:      true,
+		Type:         channelType,
+		ConfigJSON:   configJSON,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -73,18 +90,22 @@ func (r *Repository) CreateChannel(ctx context.Context, req *models.ChannelCreat
 	if req.Enabled != nil {
 		channel.Enabled = *req.Enabled
 	}
+	if req.RequiresApproval != nil {
+		channel.RequiresApproval = *req.RequiresApproval
+	}
 
 	query := `
-		INSERT INTO channels (id, name, slug, redis_channel, description, rules, rules_version, enabled, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, name, slug, redis_channel, description, rules, rules_version, enabled, created_at, updated_at
-	`
+		INSERT INTO channels (id, name, slug, redis_channel, description, rules, rules_version, enabled,
+			channel_type, config, requires_approval, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING ` + channelsSelectList
 
 	err := r.db.QueryRowxContext(
 		ctx, query,
 		channel.ID, channel.Name, channel.Slug, channel.RedisChannel,
 		channel.Description, channel.RulesJSON, channel.RulesVersion,
-		channel.Enabled, channel.CreatedAt, channel.UpdatedAt,
+		channel.Enabled, channel.Type, channel.ConfigJSON, channel.RequiresApproval,
+		channel.CreatedAt, channel.UpdatedAt,
 	).StructScan(channel)
 
 	if err != nil {
@@ -94,8 +115,8 @@ func (r *Repository) CreateChannel(ctx context.Context, req *models.ChannelCreat
 		return nil, fmt.Errorf("failed to create channel: %w", err)
 	}
 
-	if parseErr := channel.ParseRules(); parseErr != nil {
-		return nil, fmt.Errorf("failed to parse rules: %w", parseErr)
+	if parseErr := parseChannel(channel); parseErr != nil {
+		return nil, fmt.Errorf("failed to parse channel: %w", parseErr)
 	}
 
 	return channel, nil
@@ -129,8 +150,8 @@ func (r *Repository) getChannelBy(ctx context.Context, query string, arg any) (*
 		}
 		return nil, fmt.Errorf("failed to get channel: %w", err)
 	}
-	if parseErr := channel.ParseRules(); parseErr != nil {
-		return nil, fmt.Errorf("failed to parse rules: %w", parseErr)
+	if parseErr := parseChannel(channel); parseErr != nil {
+		return nil, fmt.Errorf("failed to parse channel: %w", parseErr)
 	}
 	return channel, nil
 }
@@ -155,8 +176,8 @@ func (r *Repository) ListChannels(ctx context.Context, enabledOnly bool) ([]mode
 
 	// Parse rules for each channel
 	for i := range channels {
-		if parseErr := channels[i].ParseRules(); parseErr != nil {
-			return nil, fmt.Errorf("failed to parse rules for channel %s: %w", channels[i].Slug, parseErr)
+		if parseErr := parseChannel(&channels[i]); parseErr != nil {
+			return nil, fmt.Errorf("failed to parse channel %s: %w", channels[i].Slug, parseErr)
 		}
 	}
 
@@ -194,6 +215,19 @@ func (r *Repository) UpdateChannel(ctx context.Context, id uuid.UUID, req *model
 	if req.Enabled != nil {
 		updates["enabled"] = *req.Enabled
 	}
+	if req.Type != nil {
+		updates["channel_type"] = *req.Type
+	}
+	if req.RequiresApproval != nil {
+		updates["requires_approval"] = *req.RequiresApproval
+	}
+	if req.Config != nil {
+		configJSON, err := json.Marshal(req.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal channel config: %w", err)
+		}
+		updates["config"] = configJSON
+	}
 
 	query, args, err := buildUpdateQuery(
 		"channels",
@@ -217,13 +251,24 @@ func (r *Repository) UpdateChannel(ctx context.Context, id uuid.UUID, req *model
 		return nil, fmt.Errorf("failed to update channel: %w", err)
 	}
 
-	if parseErr := channel.ParseRules(); parseErr != nil {
-		return nil, fmt.Errorf("failed to parse rules: %w", parseErr)
+	if parseErr := parseChannel(channel); parseErr != nil {
+		return nil, fmt.Errorf("failed to parse channel: %w", parseErr)
 	}
 
 	return channel, nil
 }
 
+// parseChannel decodes a channel's JSONB rules and config
+func parseChannel(channel *models.Channel) error {
+	if err := channel.ParseRules(); err != nil {
+		return fmt.Errorf("rules: %w", err)
+	}
+	if err := channel.ParseConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return nil
+}
+
 // DeleteChannel deletes a channel
 func (r *Repository) DeleteChannel(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM channels WHERE id = $1`
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// channelApprovalColumns is the column list for SELECT/RETURNING on channel_approvals
+const channelApprovalColumns = `id, channel_id, content_id, title, url, source, quality_score, topics, payload,
+	edits, status, reviewed_by, review_note, reviewed_at, created_at, updated_at`
+
+// defaultApprovalLimit bounds approval listings without an explicit limit
+const defaultApprovalLimit = 50
+
+// CreateChannelApproval queues a routed item for editorial approval. An item
+// already queued for the channel, in any status, is left unchanged.
+func (r *Repository) CreateChannelApproval(ctx context.Context, approval *models.ChannelApproval) error {
+	query := `
+		INSERT INTO channel_approvals (channel_id, content_id, title, url, source, quality_score, topics, payload)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (channel_id, content_id) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query,
+		approval.ChannelID, approval.ContentID, approval.Title, approval.URL, approval.Source,
+		approval.QualityScore, approval.Topics, approval.Payload,
+	); err != nil {
+		return fmt.Errorf("failed to create channel approval: %w", err)
+	}
+
+	return nil
+}
+
+// GetChannelApproval returns an approval by ID
+func (r *Repository) GetChannelApproval(ctx context.Context, id uuid.UUID) (*models.ChannelApproval, error) {
+	approval := &models.ChannelApproval{}
+	query := `SELECT ` + channelApprovalColumns + ` FROM channel_approvals WHERE id = $1`
+	if err := r.db.GetContext(ctx, approval, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get channel approval: %w", err)
+	}
+
+	if err := approval.ParseEdits(); err != nil {
+		return nil, fmt.Errorf("failed to parse approval edits: %w", err)
+	}
+
+	return approval, nil
+}
+
+// ListChannelApprovals returns approvals matching filter, oldest first, and
+// the total number of matches
+func (r *Repository) ListChannelApprovals(
+	ctx context.Context, filter *models.ApprovalFilter,
+) ([]models.ChannelApproval, int, error) {
+	var conditions []string
+	var args []any
+	if filter.ChannelID != "" {
+		args = append(args, filter.ChannelID)
+		conditions = append(conditions, "channel_id = $"+strconv.Itoa(len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, "status = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM channel_approvals`+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count channel approvals: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultApprovalLimit
+	}
+	args = append(args, limit, filter.Offset)
+	query := `SELECT ` + channelApprovalColumns + ` FROM channel_approvals` + where +
+		` ORDER BY created_at, id LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	approvals := []models.ChannelApproval{}
+	if err := r.db.SelectContext(ctx, &approvals, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list channel approvals: %w", err)
+	}
+	for i := range approvals {
+		if err := approvals[i].ParseEdits(); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse approval edits: %w", err)
+		}
+	}
+
+	return approvals, total, nil
+}
+
+// ReviewChannelApproval approves or rejects a pending item, storing the
+// reviewer's note and, when non-nil, their edits. Returns ErrNotFound for an
+// unknown ID and ErrApprovalNotPending when the item was already reviewed.
+func (r *Repository) ReviewChannelApproval(
+	ctx context.Context, id uuid.UUID, status, reviewer, note string, edits *models.ApprovalEdits,
+) (*models.ChannelApproval, error) {
+	editsJSON, err := marshalApprovalEdits(edits)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE channel_approvals
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = NOW(), updated_at = NOW(),
+			edits = COALESCE($5::jsonb, edits)
+		WHERE id = $1 AND status = '` + models.ApprovalStatusPending + `'
+		RETURNING ` + channelApprovalColumns
+
+	return r.updateChannelApproval(ctx, id, query, id, status, reviewer, note, editsJSON)
+}
+
+// EditChannelApproval replaces the edits of a pending item
+func (r *Repository) EditChannelApproval(
+	ctx context.Context, id uuid.UUID, edits *models.ApprovalEdits,
+) (*models.ChannelApproval, error) {
+	editsJSON, err := marshalApprovalEdits(edits)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE channel_approvals
+		SET edits = $2::jsonb, updated_at = NOW()
+		WHERE id = $1 AND status = '` + models.ApprovalStatusPending + `'
+		RETURNING ` + channelApprovalColumns
+
+	return r.updateChannelApproval(ctx, id, query, id, editsJSON)
+}
+
+// updateChannelApproval runs an UPDATE guarded by status = pending and tells
+// an unknown ID apart from an item that is no longer pending.
+func (r *Repository) updateChannelApproval(
+	ctx context.Context, id uuid.UUID, query string, args ...any,
+) (*models.ChannelApproval, error) {
+	approval := &models.ChannelApproval{}
+	err := r.db.QueryRowxContext(ctx, query, args...).StructScan(approval)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, getErr := r.GetChannelApproval(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, models.ErrApprovalNotPending
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update channel approval: %w", err)
+	}
+
+	if parseErr := approval.ParseEdits(); parseErr != nil {
+		return nil, fmt.Errorf("failed to parse approval edits: %w", parseErr)
+	}
+
+	return approval, nil
+}
+
+// ListApprovedItems returns up to limit approved items of enabled channels, oldest review first
+func (r *Repository) ListApprovedItems(ctx context.Context, limit int) ([]models.ChannelApproval, error) {
+	approvals := []models.ChannelApproval{}
+	query := `SELECT ` + channelApprovalColumns + `
+		FROM channel_approvals
+		WHERE status = '` + models.ApprovalStatusApproved + `'
+			AND channel_id IN (SELECT id FROM channels WHERE enabled = true)
+		ORDER BY reviewed_at, id
+		LIMIT $1
+	`
+	if err := r.db.SelectContext(ctx, &approvals, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list approved items: %w", err)
+	}
+	for i := range approvals {
+		if err := approvals[i].ParseEdits(); err != nil {
+			return nil, fmt.Errorf("failed to parse approval edits: %w", err)
+		}
+	}
+
+	return approvals, nil
+}
+
+// SetChannelApprovalStatus records the outcome of delivering an approved item
+func (r *Repository) SetChannelApprovalStatus(ctx context.Context, id uuid.UUID, status string) error {
+	query := `UPDATE channel_approvals SET status = $2, updated_at = NOW() WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, id, status); err != nil {
+		return fmt.Errorf("failed to set channel approval status: %w", err)
+	}
+
+	return nil
+}
+
+// marshalApprovalEdits encodes edits for a JSONB parameter; nil stays SQL NULL
+func marshalApprovalEdits(edits *models.ApprovalEdits) (any, error) {
+	if edits == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(edits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal approval edits: %w", err)
+	}
+	return string(data), nil
+}
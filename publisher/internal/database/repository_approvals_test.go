@@ -0,0 +1,171 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+var approvalColumns = []string{
+	"id", "channel_id", "content_id", "title", "url", "source", "quality_score", "topics", "payload",
+	"edits", "status", "reviewed_by", "review_note", "reviewed_at", "created_at", "updated_at",
+}
+
+func approvalRows(id uuid.UUID, status, edits string) *sqlmock.Rows {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var editsJSON []byte
+	if edits != "" {
+		editsJSON = []byte(edits)
+	}
+	return sqlmock.NewRows(approvalColumns).AddRow(
+		id, uuid.New(), "doc-1", "Title", "https://example.com/doc-1", "example", 80, "{violent_crime}",
+		[]byte(`{"id":"doc-1"}`), editsJSON, status, "editor", "", now, now, now,
+	)
+}
+
+func newApprovalTestRepo(t *testing.T) (*database.Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, setupErr := sqlmock.New()
+	if setupErr != nil {
+		t.Fatalf("failed to create sqlmock: %v", setupErr)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return database.NewRepository(sqlx.NewDb(db, "postgres")), mock
+}
+
+func TestRepository_ReviewChannelApproval_Approves(t *testing.T) {
+	t.Helper()
+
+	repo, mock := newApprovalTestRepo(t)
+	id := uuid.New()
+	title := "Edited title"
+
+	mock.ExpectQuery(`UPDATE channel_approvals .+ WHERE id = \$1 AND status = 'pending'`).
+		WithArgs(id, models.ApprovalStatusApproved, "editor", "looks good", `{"title":"Edited title"}`).
+		WillReturnRows(approvalRows(id, models.ApprovalStatusApproved, `{"title":"Edited title"}`))
+
+	approval, err := repo.ReviewChannelApproval(context.Background(), id,
+		models.ApprovalStatusApproved, "editor", "looks good", &models.ApprovalEdits{Title: &title})
+	if err != nil {
+		t.Fatalf("ReviewChannelApproval() error = %v", err)
+	}
+	if approval.Status != models.ApprovalStatusApproved {
+		t.Errorf("Status = %q, want %q", approval.Status, models.ApprovalStatusApproved)
+	}
+	if approval.Edits.Title == nil || *approval.Edits.Title != title {
+		t.Errorf("Edits.Title = %v, want %q", approval.Edits.Title, title)
+	}
+	if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+		t.Errorf("unfulfilled expectations: %v", expectErr)
+	}
+}
+
+func TestRepository_ReviewChannelApproval_Rejects(t *testing.T) {
+	t.Helper()
+
+	repo, mock := newApprovalTestRepo(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").
+		WithArgs(id, models.ApprovalStatusRejected, "editor", "", nil).
+		WillReturnRows(approvalRows(id, models.ApprovalStatusRejected, ""))
+
+	approval, err := repo.ReviewChannelApproval(context.Background(), id,
+		models.ApprovalStatusRejected, "editor", "", nil)
+	if err != nil {
+		t.Fatalf("ReviewChannelApproval() error = %v", err)
+	}
+	if approval.Status != models.ApprovalStatusRejected {
+		t.Errorf("Status = %q, want %q", approval.Status, models.ApprovalStatusRejected)
+	}
+	if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+		t.Errorf("unfulfilled expectations: %v", expectErr)
+	}
+}
+
+func TestRepository_ReviewChannelApproval_AlreadyReviewed(t *testing.T) {
+	t.Helper()
+
+	repo, mock := newApprovalTestRepo(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").WillReturnRows(sqlmock.NewRows(approvalColumns))
+	mock.ExpectQuery("SELECT (.+) FROM channel_approvals WHERE id").
+		WithArgs(id).
+		WillReturnRows(approvalRows(id, models.ApprovalStatusPublished, ""))
+
+	_, err := repo.ReviewChannelApproval(context.Background(), id,
+		models.ApprovalStatusApproved, "editor", "", nil)
+	if !errors.Is(err, models.ErrApprovalNotPending) {
+		t.Errorf("ReviewChannelApproval() error = %v, want ErrApprovalNotPending", err)
+	}
+}
+
+func TestRepository_ReviewChannelApproval_NotFound(t *testing.T) {
+	t.Helper()
+
+	repo, mock := newApprovalTestRepo(t)
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_approvals").WillReturnRows(sqlmock.NewRows(approvalColumns))
+	mock.ExpectQuery("SELECT (.+) FROM channel_approvals WHERE id").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(approvalColumns))
+
+	_, err := repo.ReviewChannelApproval(context.Background(), id,
+		models.ApprovalStatusApproved, "editor", "", nil)
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("ReviewChannelApproval() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRepository_EditChannelApproval_OnlyWhilePending(t *testing.T) {
+	t.Helper()
+
+	repo, mock := newApprovalTestRepo(t)
+	id := uuid.New()
+	summary := "New summary"
+
+	mock.ExpectQuery(`UPDATE channel_approvals\s+SET edits = \$2::jsonb, updated_at = NOW\(\)\s+WHERE id = \$1 AND status = 'pending'`).
+		WithArgs(id, `{"summary":"New summary"}`).
+		WillReturnRows(sqlmock.NewRows(approvalColumns))
+	mock.ExpectQuery("SELECT (.+) FROM channel_approvals WHERE id").
+		WithArgs(id).
+		WillReturnRows(approvalRows(id, models.ApprovalStatusRejected, ""))
+
+	_, err := repo.EditChannelApproval(context.Background(), id, &models.ApprovalEdits{Summary: &summary})
+	if !errors.Is(err, models.ErrApprovalNotPending) {
+		t.Errorf("EditChannelApproval() error = %v, want ErrApprovalNotPending", err)
+	}
+	if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+		t.Errorf("unfulfilled expectations: %v", expectErr)
+	}
+}
+
+func TestRepository_ListApprovedItems_EnabledChannelsOnly(t *testing.T) {
+	t.Helper()
+
+	repo, mock := newApprovalTestRepo(t)
+	id := uuid.New()
+
+	mock.ExpectQuery(`WHERE status = 'approved'\s+AND channel_id IN \(SELECT id FROM channels WHERE enabled = true\)`).
+		WithArgs(10).
+		WillReturnRows(approvalRows(id, models.ApprovalStatusApproved, `{"body":"Edited body"}`))
+
+	approved, err := repo.ListApprovedItems(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("ListApprovedItems() error = %v", err)
+	}
+	if len(approved) != 1 || approved[0].Edits.Body == nil || *approved[0].Edits.Body != "Edited body" {
+		t.Errorf("ListApprovedItems() = %+v, want one item with its edits parsed", approved)
+	}
+}
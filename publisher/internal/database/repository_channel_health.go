@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// channelHealthColumns is the column list for SELECT on channel_health
+const channelHealthColumns = `channel_id, circuit, consecutive_failures, last_error, last_failure_at,
+	opened_at, last_probe_at, last_probe_ok, updated_at`
+
+// ListChannelHealth returns the health record of every channel that has one,
+// keyed by channel ID. Channels without a record have never failed or been probed.
+func (r *Repository) ListChannelHealth(ctx context.Context) (map[uuid.UUID]*models.ChannelHealth, error) {
+	var records []models.ChannelHealth
+	if err := r.db.SelectContext(ctx, &records, `SELECT `+channelHealthColumns+` FROM channel_health`); err != nil {
+		return nil, fmt.Errorf("failed to list channel health: %w", err)
+	}
+
+	byChannel := make(map[uuid.UUID]*models.ChannelHealth, len(records))
+	for i := range records {
+		records[i].Summarize()
+		byChannel[records[i].ChannelID] = &records[i]
+	}
+	return byChannel, nil
+}
+
+// SaveChannelHealth inserts or replaces a channel's health record
+func (r *Repository) SaveChannelHealth(ctx context.Context, health *models.ChannelHealth) error {
+	query := `
+		INSERT INTO channel_health (channel_id, circuit, consecutive_failures, last_error, last_failure_at,
+			opened_at, last_probe_at, last_probe_ok, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (channel_id) DO UPDATE SET
+			circuit = EXCLUDED.circuit,
+			consecutive_failures = EXCLUDED.consecutive_failures,
+			last_error = EXCLUDED.last_error,
+			last_failure_at = EXCLUDED.last_failure_at,
+			opened_at = EXCLUDED.opened_at,
+			last_probe_at = EXCLUDED.last_probe_at,
+			last_probe_ok = EXCLUDED.last_probe_ok,
+			updated_at = NOW()`
+	_, err := r.db.ExecContext(ctx, query,
+		health.ChannelID, health.Circuit, health.ConsecutiveFailures, health.LastError, health.LastFailureAt,
+		health.OpenedAt, health.LastProbeAt, health.LastProbeOK,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save channel health: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteChannelHealth removes a channel's health record, closing its circuit.
+// Returns ErrNotFound when the channel has no record.
+func (r *Repository) DeleteChannelHealth(ctx context.Context, channelID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM channel_health WHERE channel_id = $1`, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete channel health: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// credentialColumns is the column list for SELECT/RETURNING on channel_credentials
+const credentialColumns = `id, name, tenant, kind, description, ciphertext, key_id, hint,
+	version, rotated_at, created_by, created_at, updated_at`
+
+// CreateCredential stores an encrypted credential. Returns ErrAlreadyExists
+// when the name is taken.
+func (r *Repository) CreateCredential(ctx context.Context, cred *models.Credential) (*models.Credential, error) {
+	created := &models.Credential{}
+	query := `
+		INSERT INTO channel_credentials (id, name, tenant, kind, description, ciphertext, key_id, hint, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + credentialColumns
+	err := r.db.GetContext(ctx, created, query,
+		uuid.New(), cred.Name, cred.Tenant, cred.Kind, cred.Description,
+		cred.Ciphertext, cred.KeyID, cred.Hint, cred.CreatedBy,
+	)
+	if err != nil {
+		if isPQUniqueViolation(err) {
+			return nil, models.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create credential: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListCredentials returns the credentials of a tenant, or every credential
+// when tenant is empty, ordered by tenant and name
+func (r *Repository) ListCredentials(ctx context.Context, tenant string) ([]models.Credential, error) {
+	creds := []models.Credential{}
+	query := `SELECT ` + credentialColumns + ` FROM channel_credentials
+		WHERE ($1 = '' OR tenant = $1)
+		ORDER BY tenant, name`
+	if err := r.db.SelectContext(ctx, &creds, query, tenant); err != nil {
+		return nil, fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	return creds, nil
+}
+
+// GetCredentialByID returns a credential. Returns ErrNotFound when it does not exist.
+func (r *Repository) GetCredentialByID(ctx context.Context, id uuid.UUID) (*models.Credential, error) {
+	return r.getCredential(ctx, `id = $1`, id)
+}
+
+// GetCredentialByName returns a credential. Returns ErrNotFound when it does not exist.
+func (r *Repository) GetCredentialByName(ctx context.Context, name string) (*models.Credential, error) {
+	return r.getCredential(ctx, `name = $1`, name)
+}
+
+func (r *Repository) getCredential(ctx context.Context, where string, arg any) (*models.Credential, error) {
+	cred := &models.Credential{}
+	query := `SELECT ` + credentialColumns + ` FROM channel_credentials WHERE ` + where
+	if err := r.db.GetContext(ctx, cred, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+
+	return cred, nil
+}
+
+// RotateCredential replaces a credential's value and bumps its version.
+// Returns ErrNotFound when it does not exist.
+func (r *Repository) RotateCredential(
+	ctx context.Context, id uuid.UUID, ciphertext []byte, keyID, hint string,
+) (*models.Credential, error) {
+	cred := &models.Credential{}
+	query := `
+		UPDATE channel_credentials
+		SET ciphertext = $2, key_id = $3, hint = $4, version = version + 1,
+			rotated_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + credentialColumns
+	if err := r.db.GetContext(ctx, cred, query, id, ciphertext, keyID, hint); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to rotate credential: %w", err)
+	}
+
+	return cred, nil
+}
+
+// ReencryptCredential stores a credential's unchanged value sealed with
+// another key; the version is kept
+func (r *Repository) ReencryptCredential(ctx context.Context, id uuid.UUID, ciphertext []byte, keyID string) error {
+	query := `UPDATE channel_credentials SET ciphertext = $2, key_id = $3, updated_at = NOW() WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, id, ciphertext, keyID); err != nil {
+		return fmt.Errorf("failed to re-encrypt credential: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCredential removes a credential. Returns ErrNotFound when it does not exist.
+func (r *Repository) DeleteCredential(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM channel_credentials WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
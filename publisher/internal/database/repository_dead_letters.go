@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// deadLetterColumns is the column list for SELECT/RETURNING on channel_dead_letters
+const deadLetterColumns = `id, channel_id, channel_name, content_id, title, payload, last_error,
+	attempts, status, next_retry_at, created_at, updated_at`
+
+// defaultDeadLetterLimit bounds dead letter listings without an explicit limit
+const defaultDeadLetterLimit = 50
+
+// RecordDeadLetter stores a failed delivery for retry. An item already in the
+// queue for the channel is replaced and its attempt count goes up by one, so
+// an item that keeps failing still backs off and is marked dead. The stored
+// ID and attempt count are set on letter.
+func (r *Repository) RecordDeadLetter(ctx context.Context, letter *models.DeadLetter) error {
+	query := `
+		INSERT INTO channel_dead_letters
+			(channel_id, channel_name, content_id, title, payload, last_error, attempts, status, next_retry_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (channel_name, content_id) DO UPDATE SET
+			channel_id = EXCLUDED.channel_id,
+			title = EXCLUDED.title,
+			payload = EXCLUDED.payload,
+			last_error = EXCLUDED.last_error,
+			attempts = channel_dead_letters.attempts + 1,
+			status = EXCLUDED.status,
+			next_retry_at = EXCLUDED.next_retry_at,
+			updated_at = NOW()
+		RETURNING id, attempts
+	`
+	if err := r.db.QueryRowxContext(ctx, query,
+		letter.ChannelID, letter.ChannelName, letter.ContentID, letter.Title, letter.Payload,
+		letter.LastError, letter.Attempts, letter.Status, letter.NextRetryAt,
+	).Scan(&letter.ID, &letter.Attempts); err != nil {
+		return fmt.Errorf("failed to record dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeadLetter returns a dead letter by ID
+func (r *Repository) GetDeadLetter(ctx context.Context, id uuid.UUID) (*models.DeadLetter, error) {
+	letter := &models.DeadLetter{}
+	query := `SELECT ` + deadLetterColumns + ` FROM channel_dead_letters WHERE id = $1`
+	if err := r.db.GetContext(ctx, letter, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+
+	return letter, nil
+}
+
+// ListDeadLetters returns dead letters matching filter, most recently failed
+// first, and the total number of matches
+func (r *Repository) ListDeadLetters(
+	ctx context.Context, filter *models.DeadLetterFilter,
+) ([]models.DeadLetter, int, error) {
+	var conditions []string
+	var args []any
+	if filter.ChannelName != "" {
+		args = append(args, filter.ChannelName)
+		conditions = append(conditions, "channel_name = $"+strconv.Itoa(len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, "status = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM channel_dead_letters`+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDeadLetterLimit
+	}
+	args = append(args, limit, filter.Offset)
+	query := `SELECT ` + deadLetterColumns + ` FROM channel_dead_letters` + where +
+		` ORDER BY updated_at DESC, id LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	letters := []models.DeadLetter{}
+	if err := r.db.SelectContext(ctx, &letters, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	return letters, total, nil
+}
+
+// ListDueDeadLetters returns up to limit retrying items whose next retry has passed, oldest due first
+func (r *Repository) ListDueDeadLetters(ctx context.Context, limit int) ([]models.DeadLetter, error) {
+	letters := []models.DeadLetter{}
+	query := `SELECT ` + deadLetterColumns + `
+		FROM channel_dead_letters
+		WHERE status = '` + models.DeadLetterStatusRetrying + `' AND next_retry_at <= NOW()
+		ORDER BY next_retry_at, id
+		LIMIT $1
+	`
+	if err := r.db.SelectContext(ctx, &letters, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list due dead letters: %w", err)
+	}
+
+	return letters, nil
+}
+
+// UpdateDeadLetterAttempt records a failed retry: the attempt count, error,
+// status, and when to retry next (nil for dead items)
+func (r *Repository) UpdateDeadLetterAttempt(
+	ctx context.Context, id uuid.UUID, attempts int, lastError, status string, nextRetryAt *time.Time,
+) error {
+	query := `
+		UPDATE channel_dead_letters
+		SET attempts = $2, last_error = $3, status = $4, next_retry_at = $5, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.ExecContext(ctx, query, id, attempts, lastError, status, nextRetryAt); err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDeadLetter removes a delivered or discarded item. Returns ErrNotFound for an unknown ID.
+func (r *Repository) DeleteDeadLetter(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM channel_dead_letters WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// ReplayDeadLetter makes an item due for delivery on the router's next poll
+// with a fresh set of attempts. Returns ErrNotFound for an unknown ID.
+func (r *Repository) ReplayDeadLetter(ctx context.Context, id uuid.UUID) (*models.DeadLetter, error) {
+	letter := &models.DeadLetter{}
+	query := `
+		UPDATE channel_dead_letters
+		SET status = '` + models.DeadLetterStatusRetrying + `', attempts = 0, next_retry_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + deadLetterColumns
+	if err := r.db.QueryRowxContext(ctx, query, id).StructScan(letter); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to replay dead letter: %w", err)
+	}
+
+	return letter, nil
+}
+
+// ReplayDeadLetters makes every item of a channel, or of all channels when
+// channelName is empty, due on the router's next poll with a fresh set of
+// attempts. Returns the number of items replayed.
+func (r *Repository) ReplayDeadLetters(ctx context.Context, channelName string) (int64, error) {
+	query := `
+		UPDATE channel_dead_letters
+		SET status = '` + models.DeadLetterStatusRetrying + `', attempts = 0, next_retry_at = NOW(), updated_at = NOW()
+		WHERE $1 = '' OR channel_name = $1
+	`
+	result, err := r.db.ExecContext(ctx, query, channelName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay dead letters: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+func TestRepository_RecordDeadLetter_CountsRepeatFailures(t *testing.T) {
+	t.Helper()
+
+	db, mock, setupErr := sqlmock.New()
+	if setupErr != nil {
+		t.Fatalf("failed to create sqlmock: %v", setupErr)
+	}
+	defer db.Close()
+
+	repo := database.NewRepository(sqlx.NewDb(db, "postgres"))
+	storedID := uuid.New()
+	nextRetry := time.Date(2026, 3, 1, 12, 1, 0, 0, time.UTC)
+	letter := &models.DeadLetter{
+		ChannelName: "articles:crime",
+		ContentID:   "doc-1",
+		Payload:     []byte(`{"id":"doc-1"}`),
+		LastError:   "connection refused",
+		Attempts:    1,
+		Status:      models.DeadLetterStatusRetrying,
+		NextRetryAt: &nextRetry,
+	}
+
+	mock.ExpectQuery(`attempts = channel_dead_letters\.attempts \+ 1.+RETURNING id, attempts`).
+		WithArgs(nil, "articles:crime", "doc-1", "", letter.Payload, "connection refused", 1,
+			models.DeadLetterStatusRetrying, &nextRetry).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "attempts"}).AddRow(storedID, 4))
+
+	if err := repo.RecordDeadLetter(context.Background(), letter); err != nil {
+		t.Fatalf("RecordDeadLetter() error = %v", err)
+	}
+	if letter.ID != storedID {
+		t.Errorf("ID = %s, want the stored row's %s", letter.ID, storedID)
+	}
+	if letter.Attempts != 4 {
+		t.Errorf("Attempts = %d, want 4 from the existing row", letter.Attempts)
+	}
+	if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+		t.Errorf("unfulfilled expectations: %v", expectErr)
+	}
+}
+
+func TestRepository_ReplayDeadLetter_NotFound(t *testing.T) {
+	t.Helper()
+
+	db, mock, setupErr := sqlmock.New()
+	if setupErr != nil {
+		t.Fatalf("failed to create sqlmock: %v", setupErr)
+	}
+	defer db.Close()
+
+	repo := database.NewRepository(sqlx.NewDb(db, "postgres"))
+	id := uuid.New()
+
+	mock.ExpectQuery("UPDATE channel_dead_letters").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.ReplayDeadLetter(context.Background(), id)
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("ReplayDeadLetter() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// channelFeedItemColumns is the column list for SELECT on channel_feed_items
+const channelFeedItemColumns = `id, channel_id, content_id, title, url, summary, source, image_url,
+	topics, published_at, added_at`
+
+// AddChannelFeedItem appends an item to a feed channel and trims the channel to
+// its newest maxItems items. Adding an item already in the feed is a no-op.
+func (r *Repository) AddChannelFeedItem(ctx context.Context, item *models.ChannelFeedItem, maxItems int) error {
+	insert := `
+		INSERT INTO channel_feed_items
+			(channel_id, content_id, title, url, summary, source, image_url, topics, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (channel_id, content_id) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, insert,
+		item.ChannelID, item.ContentID, item.Title, item.URL, item.Summary,
+		item.Source, item.ImageURL, item.Topics, item.PublishedAt,
+	); err != nil {
+		return fmt.Errorf("failed to add channel feed item: %w", err)
+	}
+
+	trim := `
+		DELETE FROM channel_feed_items
+		WHERE channel_id = $1
+		  AND id NOT IN (
+			SELECT id FROM channel_feed_items
+			WHERE channel_id = $1
+			ORDER BY added_at DESC
+			LIMIT $2
+		  )
+	`
+	if _, err := r.db.ExecContext(ctx, trim, item.ChannelID, maxItems); err != nil {
+		return fmt.Errorf("failed to trim channel feed: %w", err)
+	}
+
+	return nil
+}
+
+// ListChannelFeedItems returns up to limit feed items for a channel, newest first
+func (r *Repository) ListChannelFeedItems(ctx context.Context, channelID uuid.UUID, limit int) ([]models.ChannelFeedItem, error) {
+	items := []models.ChannelFeedItem{}
+	query := `SELECT ` + channelFeedItemColumns + `
+		FROM channel_feed_items
+		WHERE channel_id = $1
+		ORDER BY added_at DESC
+		LIMIT $2
+	`
+	if err := r.db.SelectContext(ctx, &items, query, channelID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list channel feed items: %w", err)
+	}
+
+	return items, nil
+}
+
+// RemoveChannelFeedItem removes an item from a feed channel. Removing an item
+// that is not in the feed is a no-op.
+func (r *Repository) RemoveChannelFeedItem(ctx context.Context, channelID uuid.UUID, contentID string) error {
+	query := `DELETE FROM channel_feed_items WHERE channel_id = $1 AND content_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, channelID, contentID); err != nil {
+		return fmt.Errorf("failed to remove channel feed item: %w", err)
+	}
+
+	return nil
+}
@@ -13,7 +13,8 @@ import (
 )
 
 // publishHistoryColumns is the column list for SELECT/INSERT/RETURNING on publish_history (single source for schema changes)
-const publishHistoryColumns = "id, route_id, article_id, article_title, article_url, channel_name, published_at, quality_score, topics"
+const publishHistoryColumns = "id, route_id, article_id, article_title, article_url, channel_name, published_at, quality_score, topics, " +
+	"remote_id, retracted_at"
 
 // ChannelStat holds per-channel publish statistics (total count and last published time)
 type ChannelStat struct {
@@ -37,18 +38,20 @@ func (r *Repository) CreatePublishHistory(ctx context.Context, req *models.Publi
 		PublishedAt:  time.Now(),
 		QualityScore: req.QualityScore,
 		Topics:       pq.StringArray(req.Topics),
+		RemoteID:     req.RemoteID,
 	}
 
 	query := `
-		INSERT INTO publish_history (` + publishHistoryColumns + `)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO publish_history (` + publishHistoryColumns + `, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULL, NULLIF($11, ''))
 		RETURNING ` + publishHistoryColumns + `
 	`
 
 	err := r.db.QueryRowxContext(
 		ctx, query,
 		history.ID, history.RouteID, history.ContentID, history.ContentTitle, history.ContentURL,
-		history.ChannelName, history.PublishedAt, history.QualityScore, history.Topics,
+		history.ChannelName, history.PublishedAt, history.QualityScore, history.Topics, history.RemoteID,
+		req.ContentHash,
 	).StructScan(history)
 
 	if err != nil {
@@ -208,6 +211,25 @@ func (r *Repository) CheckContentPublished(ctx context.Context, contentID, chann
 	return exists, nil
 }
 
+// CheckContentHashPublished checks if a story with the given content hash was
+// published to a channel since the given time, from any source
+func (r *Repository) CheckContentHashPublished(
+	ctx context.Context, channelName, contentHash string, since time.Time,
+) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM publish_history
+			WHERE channel_name = $1 AND content_hash = $2 AND published_at >= $3
+		)
+	`
+	if err := r.db.GetContext(ctx, &exists, query, channelName, contentHash, since); err != nil {
+		return false, fmt.Errorf("failed to check content hash: %w", err)
+	}
+
+	return exists, nil
+}
+
 // GetPublishStats retrieves publishing statistics
 func (r *Repository) GetPublishStats(ctx context.Context, startDate, endDate *time.Time) (map[string]int, error) {
 	query := `
@@ -363,16 +385,50 @@ func (r *Repository) GetChannelStats(ctx context.Context) (map[string]ChannelSta
 	return stats, nil
 }
 
-// DeleteAllPublishHistory deletes all publish history entries
+// DeleteAllPublishHistory deletes all publish history entries, and the
+// routing decisions that would otherwise keep items from being published again
 func (r *Repository) DeleteAllPublishHistory(ctx context.Context) (int64, error) {
 	query := `DELETE FROM publish_history`
 	result, err := r.db.ExecContext(ctx, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete all publish history: %w", err)
 	}
+	if _, err = r.db.ExecContext(ctx, `DELETE FROM routing_decisions`); err != nil {
+		return 0, fmt.Errorf("failed to delete routing decisions: %w", err)
+	}
 	count, err := result.RowsAffected()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	return count, nil
 }
+
+// GetLastPublishedByChannel returns the most recent publish time for every channel
+// that has ever received content.
+func (r *Repository) GetLastPublishedByChannel(ctx context.Context) (map[string]time.Time, error) {
+	query := `
+		SELECT channel_name, MAX(published_at)
+		FROM publish_history
+		GROUP BY channel_name
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last published by channel: %w", err)
+	}
+	defer rows.Close()
+
+	lastPublished := make(map[string]time.Time)
+	for rows.Next() {
+		var channelName string
+		var publishedAt time.Time
+		if scanErr := rows.Scan(&channelName, &publishedAt); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan last published: %w", scanErr)
+		}
+		lastPublished[channelName] = publishedAt
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("row iteration error: %w", rowsErr)
+	}
+
+	return lastPublished, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// channelHoldColumns is the column list for SELECT/RETURNING on channel_holds
+const channelHoldColumns = `id, channel_id, content_id, content_title, reason, created_at`
+
+// CreateChannelHold pulls a content item from a channel. Holding an already
+// held item is a no-op that returns the existing hold.
+func (r *Repository) CreateChannelHold(
+	ctx context.Context, channelID uuid.UUID, req *models.ChannelHoldCreateRequest,
+) (*models.ChannelHold, error) {
+	query := `
+		INSERT INTO channel_holds (channel_id, content_id, content_title, reason)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (channel_id, content_id) DO UPDATE SET reason = channel_holds.reason
+		RETURNING ` + channelHoldColumns
+
+	hold := &models.ChannelHold{}
+	err := r.db.QueryRowxContext(ctx, query, channelID, req.ContentID, req.ContentTitle, req.Reason).StructScan(hold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create channel hold: %w", err)
+	}
+
+	return hold, nil
+}
+
+// DeleteChannelHold releases a held content item. Returns ErrNotFound if the item is not held.
+func (r *Repository) DeleteChannelHold(ctx context.Context, channelID uuid.UUID, contentID string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM channel_holds WHERE channel_id = $1 AND content_id = $2`,
+		channelID, contentID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete channel hold: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListChannelHolds returns the holds for a channel, newest first
+func (r *Repository) ListChannelHolds(ctx context.Context, channelID uuid.UUID) ([]models.ChannelHold, error) {
+	holds := []models.ChannelHold{}
+	query := `SELECT ` + channelHoldColumns + `
+		FROM channel_holds
+		WHERE channel_id = $1
+		ORDER BY created_at DESC
+	`
+	if err := r.db.SelectContext(ctx, &holds, query, channelID); err != nil {
+		return nil, fmt.Errorf("failed to list channel holds: %w", err)
+	}
+
+	return holds, nil
+}
+
+// IsContentHeld checks if a content item has been pulled from a channel
+func (r *Repository) IsContentHeld(ctx context.Context, contentID string, channelID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM channel_holds
+			WHERE channel_id = $1 AND content_id = $2
+		)
+	`
+	if err := r.db.GetContext(ctx, &exists, query, channelID, contentID); err != nil {
+		return false, fmt.Errorf("failed to check channel hold: %w", err)
+	}
+
+	return exists, nil
+}
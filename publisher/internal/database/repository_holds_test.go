@@ -0,0 +1,63 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+func TestRepository_IsContentHeld(t *testing.T) {
+	t.Helper()
+
+	db, mock, setupErr := sqlmock.New()
+	if setupErr != nil {
+		t.Fatalf("failed to create sqlmock: %v", setupErr)
+	}
+	defer db.Close()
+
+	repo := database.NewRepository(sqlx.NewDb(db, "postgres"))
+	channelID := uuid.New()
+
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(channelID, "doc-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	held, err := repo.IsContentHeld(context.Background(), "doc-1", channelID)
+	if err != nil {
+		t.Fatalf("IsContentHeld() error = %v", err)
+	}
+	if !held {
+		t.Error("IsContentHeld() = false, want true")
+	}
+	if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+		t.Errorf("unfulfilled expectations: %v", expectErr)
+	}
+}
+
+func TestRepository_DeleteChannelHold_NotFound(t *testing.T) {
+	t.Helper()
+
+	db, mock, setupErr := sqlmock.New()
+	if setupErr != nil {
+		t.Fatalf("failed to create sqlmock: %v", setupErr)
+	}
+	defer db.Close()
+
+	repo := database.NewRepository(sqlx.NewDb(db, "postgres"))
+	channelID := uuid.New()
+
+	mock.ExpectExec("DELETE FROM channel_holds").
+		WithArgs(channelID, "missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteChannelHold(context.Background(), channelID, "missing")
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("DeleteChannelHold() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// deliveryFailureRetention is how long channel_delivery_failures rows are kept.
+const deliveryFailureRetention = 90 * 24 * time.Hour
+
+// maxFailureErrorLength bounds the stored error text.
+const maxFailureErrorLength = 1000
+
+// RecordDeliveryFailure stores a failed delivery and prunes the channel's
+// failures older than 90 days.
+func (r *Repository) RecordDeliveryFailure(ctx context.Context, failure *models.DeliveryFailure) error {
+	errText := failure.Error
+	if len(errText) > maxFailureErrorLength {
+		errText = errText[:maxFailureErrorLength]
+	}
+
+	insert := `
+		INSERT INTO channel_delivery_failures (channel_id, channel_name, content_id, content_title, error)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	if _, err := r.db.ExecContext(ctx, insert,
+		failure.ChannelID, failure.ChannelName, failure.ContentID, failure.ContentTitle, errText,
+	); err != nil {
+		return fmt.Errorf("failed to record delivery failure: %w", err)
+	}
+
+	prune := `DELETE FROM channel_delivery_failures WHERE channel_name = $1 AND failed_at < $2`
+	if _, err := r.db.ExecContext(ctx, prune, failure.ChannelName, time.Now().Add(-deliveryFailureRetention)); err != nil {
+		return fmt.Errorf("failed to prune delivery failures: %w", err)
+	}
+
+	return nil
+}
+
+// CountPublished returns how many items were published to a channel in [since, until)
+func (r *Repository) CountPublished(ctx context.Context, channelName string, since, until time.Time) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) FROM publish_history
+		WHERE channel_name = $1 AND published_at >= $2 AND published_at < $3
+	`
+	if err := r.db.GetContext(ctx, &count, query, channelName, since, until); err != nil {
+		return 0, fmt.Errorf("failed to count published items: %w", err)
+	}
+	return count, nil
+}
+
+// PublishedByTopic counts items published to a channel in [since, until) per topic, most first
+func (r *Repository) PublishedByTopic(
+	ctx context.Context, channelName string, since, until time.Time,
+) ([]models.TopicCount, error) {
+	counts := []models.TopicCount{}
+	query := `
+		SELECT topic, COUNT(*) AS count
+		FROM publish_history, unnest(topics) AS topic
+		WHERE channel_name = $1 AND published_at >= $2 AND published_at < $3
+		GROUP BY topic
+		ORDER BY count DESC, topic
+	`
+	if err := r.db.SelectContext(ctx, &counts, query, channelName, since, until); err != nil {
+		return nil, fmt.Errorf("failed to count published items by topic: %w", err)
+	}
+	return counts, nil
+}
+
+// PublishedByDay counts items published to a channel in [since, until) per UTC day
+func (r *Repository) PublishedByDay(
+	ctx context.Context, channelName string, since, until time.Time,
+) ([]models.DayCount, error) {
+	counts := []models.DayCount{}
+	query := `
+		SELECT date_trunc('day', published_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS count
+		FROM publish_history
+		WHERE channel_name = $1 AND published_at >= $2 AND published_at < $3
+		GROUP BY day
+		ORDER BY day
+	`
+	if err := r.db.SelectContext(ctx, &counts, query, channelName, since, until); err != nil {
+		return nil, fmt.Errorf("failed to count published items by day: %w", err)
+	}
+	return counts, nil
+}
+
+// ListPublishedInPeriod returns up to limit items published to a channel in [since, until), newest first
+func (r *Repository) ListPublishedInPeriod(
+	ctx context.Context, channelName string, since, until time.Time, limit int,
+) ([]models.PublishHistory, error) {
+	history := []models.PublishHistory{}
+	query := `SELECT ` + publishHistoryColumns + `
+		FROM publish_history
+		WHERE channel_name = $1 AND published_at >= $2 AND published_at < $3
+		ORDER BY published_at DESC
+		LIMIT $4
+	`
+	if err := r.db.SelectContext(ctx, &history, query, channelName, since, until, limit); err != nil {
+		return nil, fmt.Errorf("failed to list published items: %w", err)
+	}
+	return history, nil
+}
+
+// DeliveryFailureReasons groups a channel's delivery failures in [since, until)
+// by error, most frequent first, and returns the total failure count.
+func (r *Repository) DeliveryFailureReasons(
+	ctx context.Context, channelName string, since, until time.Time, limit int,
+) (reasons []models.FailureReason, total int, err error) {
+	countQuery := `
+		SELECT COUNT(*) FROM channel_delivery_failures
+		WHERE channel_name = $1 AND failed_at >= $2 AND failed_at < $3
+	`
+	if err = r.db.GetContext(ctx, &total, countQuery, channelName, since, until); err != nil {
+		return nil, 0, fmt.Errorf("failed to count delivery failures: %w", err)
+	}
+
+	reasons = []models.FailureReason{}
+	if total == 0 {
+		return reasons, 0, nil
+	}
+
+	query := `
+		SELECT error, COUNT(*) AS count, MAX(failed_at) AS last_seen
+		FROM channel_delivery_failures
+		WHERE channel_name = $1 AND failed_at >= $2 AND failed_at < $3
+		GROUP BY error
+		ORDER BY count DESC, last_seen DESC
+		LIMIT $4
+	`
+	if err = r.db.SelectContext(ctx, &reasons, query, channelName, since, until, limit); err != nil {
+		return nil, 0, fmt.Errorf("failed to group delivery failures: %w", err)
+	}
+	return reasons, total, nil
+}
@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// retractionColumns is the column list for SELECT/RETURNING on content_retractions
+const retractionColumns = `id, content_id, reason, requested_by, status, attempts, last_error, results,
+	next_attempt_at, created_at, updated_at`
+
+// defaultRetractionLimit bounds retraction listings without an explicit limit
+const defaultRetractionLimit = 50
+
+// retractedReviewNote is the review note given to pending approvals of retracted content
+const retractedReviewNote = "retracted"
+
+// RequestRetraction queues a content item for retraction. Requesting an item
+// already known restarts it with a fresh set of attempts, so a failed
+// retraction can be retried by requesting it again.
+func (r *Repository) RequestRetraction(
+	ctx context.Context, contentID, reason, requestedBy string,
+) (*models.Retraction, error) {
+	retraction := &models.Retraction{}
+	query := `
+		INSERT INTO content_retractions (content_id, reason, requested_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (content_id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			requested_by = EXCLUDED.requested_by,
+			status = '` + models.RetractionStatusPending + `',
+			attempts = 0,
+			last_error = '',
+			next_attempt_at = NOW(),
+			updated_at = NOW()
+		RETURNING ` + retractionColumns
+	if err := r.db.GetContext(ctx, retraction, query, contentID, reason, requestedBy); err != nil {
+		return nil, fmt.Errorf("failed to request retraction: %w", err)
+	}
+	if err := retraction.ParseResults(); err != nil {
+		return nil, fmt.Errorf("failed to decode retraction results: %w", err)
+	}
+
+	return retraction, nil
+}
+
+// GetRetraction returns a retraction by ID
+func (r *Repository) GetRetraction(ctx context.Context, id uuid.UUID) (*models.Retraction, error) {
+	retraction := &models.Retraction{}
+	query := `SELECT ` + retractionColumns + ` FROM content_retractions WHERE id = $1`
+	if err := r.db.GetContext(ctx, retraction, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get retraction: %w", err)
+	}
+	if err := retraction.ParseResults(); err != nil {
+		return nil, fmt.Errorf("failed to decode retraction results: %w", err)
+	}
+
+	return retraction, nil
+}
+
+// ListRetractions returns retractions matching filter, most recently updated
+// first, and the total number of matches
+func (r *Repository) ListRetractions(
+	ctx context.Context, filter *models.RetractionFilter,
+) ([]models.Retraction, int, error) {
+	where := ""
+	var args []any
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where = " WHERE status = $1"
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM content_retractions`+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count retractions: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultRetractionLimit
+	}
+	args = append(args, limit, filter.Offset)
+	query := `SELECT ` + retractionColumns + ` FROM content_retractions` + where +
+		` ORDER BY updated_at DESC, id LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	retractions := []models.Retraction{}
+	if err := r.db.SelectContext(ctx, &retractions, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list retractions: %w", err)
+	}
+	if err := parseRetractionResults(retractions); err != nil {
+		return nil, 0, err
+	}
+
+	return retractions, total, nil
+}
+
+// ListDueRetractions returns up to limit pending retractions whose next attempt has passed, oldest first
+func (r *Repository) ListDueRetractions(ctx context.Context, limit int) ([]models.Retraction, error) {
+	retractions := []models.Retraction{}
+	query := `SELECT ` + retractionColumns + `
+		FROM content_retractions
+		WHERE status = '` + models.RetractionStatusPending + `' AND next_attempt_at <= NOW()
+		ORDER BY next_attempt_at, id
+		LIMIT $1
+	`
+	if err := r.db.SelectContext(ctx, &retractions, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list due retractions: %w", err)
+	}
+	if err := parseRetractionResults(retractions); err != nil {
+		return nil, err
+	}
+
+	return retractions, nil
+}
+
+// UpdateRetraction stores the outcome of a processing pass: status, attempts,
+// last error, per-channel results, and when to try again
+func (r *Repository) UpdateRetraction(ctx context.Context, retraction *models.Retraction) error {
+	resultsJSON, err := json.Marshal(retraction.Results)
+	if err != nil {
+		return fmt.Errorf("failed to marshal retraction results: %w", err)
+	}
+
+	query := `
+		UPDATE content_retractions
+		SET status = $2, attempts = $3, last_error = $4, results = $5, next_attempt_at = $6, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, execErr := r.db.ExecContext(ctx, query,
+		retraction.ID, retraction.Status, retraction.Attempts, retraction.LastError, resultsJSON,
+		retraction.NextAttemptAt,
+	); execErr != nil {
+		return fmt.Errorf("failed to update retraction: %w", execErr)
+	}
+
+	return nil
+}
+
+// MarkPublishHistoryRetracted records that a published item was taken down from its channel
+func (r *Repository) MarkPublishHistoryRetracted(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE publish_history SET retracted_at = NOW() WHERE id = $1 AND retracted_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark publish history retracted: %w", err)
+	}
+
+	return nil
+}
+
+// DropQueuedContent stops a retracted item from being delivered later: its
+// dead letters and scheduled items are removed and pending approvals rejected.
+func (r *Repository) DropQueuedContent(ctx context.Context, contentID string) error {
+	statements := []struct {
+		what  string
+		query string
+		args  []any
+	}{
+		{"dead letters", `DELETE FROM channel_dead_letters WHERE content_id = $1`, []any{contentID}},
+		{"scheduled items", `DELETE FROM channel_scheduled_items WHERE content_id = $1`, []any{contentID}},
+		{"pending approvals", `
+			UPDATE channel_approvals
+			SET status = '` + models.ApprovalStatusRejected + `', review_note = $2, reviewed_at = NOW(), updated_at = NOW()
+			WHERE content_id = $1 AND status = '` + models.ApprovalStatusPending + `'`,
+			[]any{contentID, retractedReviewNote}},
+	}
+
+	for _, stmt := range statements {
+		if _, err := r.db.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return fmt.Errorf("failed to drop queued %s: %w", stmt.what, err)
+		}
+	}
+
+	return nil
+}
+
+// parseRetractionResults decodes the per-channel results of each retraction
+func parseRetractionResults(retractions []models.Retraction) error {
+	for i := range retractions {
+		if err := retractions[i].ParseResults(); err != nil {
+			return fmt.Errorf("failed to decode retraction results: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// routingDecisionColumns is the column list for SELECT/RETURNING on routing_decisions
+const routingDecisionColumns = `id, idempotency_key, content_id, channel_id, channel_name, status,
+	attempts, claimed_at, updated_at`
+
+// ClaimRoutingDecision claims the delivery of decision's item to its channel
+// as of claimedAt. The claim wins when no decision exists for the key, or when
+// the existing one failed (or, with inDoubt, is in doubt); any other existing
+// decision wins over it and false is returned.
+func (r *Repository) ClaimRoutingDecision(
+	ctx context.Context, decision *models.RoutingDecision, claimedAt time.Time, inDoubt bool,
+) (bool, error) {
+	query := `
+		INSERT INTO routing_decisions
+			(id, idempotency_key, content_id, channel_id, channel_name, status, claimed_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, '` + models.RoutingDecisionPending + `', $6, NOW())
+		ON CONFLICT (idempotency_key) DO UPDATE SET
+			status = '` + models.RoutingDecisionPending + `',
+			attempts = routing_decisions.attempts + 1,
+			claimed_at = EXCLUDED.claimed_at,
+			updated_at = NOW()
+		WHERE routing_decisions.status = '` + models.RoutingDecisionFailed + `'
+			OR ($7 AND routing_decisions.status = '` + models.RoutingDecisionInDoubt + `')
+		RETURNING id`
+	var id uuid.UUID
+	err := r.db.GetContext(ctx, &id, query,
+		uuid.New(), decision.IdempotencyKey, decision.ContentID, decision.ChannelID, decision.ChannelName,
+		claimedAt, inDoubt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim routing decision: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetRoutingDecision returns the decision of an idempotency key. Returns
+// ErrNotFound when it does not exist.
+func (r *Repository) GetRoutingDecision(ctx context.Context, key string) (*models.RoutingDecision, error) {
+	decision := &models.RoutingDecision{}
+	query := `SELECT ` + routingDecisionColumns + ` FROM routing_decisions WHERE idempotency_key = $1`
+	if err := r.db.GetContext(ctx, decision, query, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get routing decision: %w", err)
+	}
+
+	return decision, nil
+}
+
+// CompleteRoutingDecision records the outcome (delivered or failed) of a claimed delivery
+func (r *Repository) CompleteRoutingDecision(ctx context.Context, key, status string) error {
+	query := `UPDATE routing_decisions SET status = $2, updated_at = NOW() WHERE idempotency_key = $1`
+	if _, err := r.db.ExecContext(ctx, query, key, status); err != nil {
+		return fmt.Errorf("failed to complete routing decision: %w", err)
+	}
+
+	return nil
+}
+
+// MarkRoutingDecisionInDoubt marks a pending decision claimed before cutoff as
+// in doubt. Returns false when the decision is no longer pending or was
+// claimed again since, so only one router reports it.
+func (r *Repository) MarkRoutingDecisionInDoubt(ctx context.Context, key string, cutoff time.Time) (bool, error) {
+	query := `
+		UPDATE routing_decisions
+		SET status = '` + models.RoutingDecisionInDoubt + `', updated_at = NOW()
+		WHERE idempotency_key = $1 AND status = '` + models.RoutingDecisionPending + `' AND claimed_at < $2`
+	result, err := r.db.ExecContext(ctx, query, key, cutoff)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark routing decision in doubt: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// routingDomainColumns is the column list for SELECT/RETURNING on routing_domains
+const routingDomainColumns = `name, enabled, params, updated_by, updated_at`
+
+// ListRoutingDomainSettings returns every saved routing domain setting
+func (r *Repository) ListRoutingDomainSettings(ctx context.Context) ([]models.RoutingDomainSetting, error) {
+	settings := []models.RoutingDomainSetting{}
+	query := `SELECT ` + routingDomainColumns + ` FROM routing_domains ORDER BY name`
+	if err := r.db.SelectContext(ctx, &settings, query); err != nil {
+		return nil, fmt.Errorf("failed to list routing domain settings: %w", err)
+	}
+	for i := range settings {
+		if err := settings[i].ParseParams(); err != nil {
+			return nil, fmt.Errorf("failed to decode routing domain params: %w", err)
+		}
+	}
+
+	return settings, nil
+}
+
+// SaveRoutingDomainSetting creates or replaces a routing domain's setting
+func (r *Repository) SaveRoutingDomainSetting(
+	ctx context.Context, name string, enabled bool, params map[string]any, updatedBy string,
+) (*models.RoutingDomainSetting, error) {
+	if params == nil {
+		params = map[string]any{}
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal routing domain params: %w", err)
+	}
+
+	setting := &models.RoutingDomainSetting{}
+	query := `
+		INSERT INTO routing_domains (name, enabled, params, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			params = EXCLUDED.params,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING ` + routingDomainColumns
+	if getErr := r.db.GetContext(ctx, setting, query, name, enabled, paramsJSON, updatedBy); getErr != nil {
+		return nil, fmt.Errorf("failed to save routing domain setting: %w", getErr)
+	}
+	if parseErr := setting.ParseParams(); parseErr != nil {
+		return nil, fmt.Errorf("failed to decode routing domain params: %w", parseErr)
+	}
+
+	return setting, nil
+}
+
+// DeleteRoutingDomainSetting removes a domain's saved setting so it falls back
+// to config.yml or its defaults. Returns ErrNotFound when none was saved.
+func (r *Repository) DeleteRoutingDomainSetting(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM routing_domains WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete routing domain setting: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
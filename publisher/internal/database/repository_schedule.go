@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// scheduledItemColumns is the column list for SELECT on channel_scheduled_items
+const scheduledItemColumns = `id, channel_id, content_id, title, payload, queued_at, quality_score, content_hash`
+
+// QueueScheduledItem queues a routed item until its channel's schedule allows
+// delivery. Queuing an item already waiting on the channel is a no-op.
+func (r *Repository) QueueScheduledItem(ctx context.Context, item *models.ScheduledItem) error {
+	query := `
+		INSERT INTO channel_scheduled_items (channel_id, content_id, title, payload, quality_score, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (channel_id, content_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		item.ChannelID, item.ContentID, item.Title, item.Payload, item.QualityScore, item.ContentHash)
+	if err != nil {
+		return fmt.Errorf("failed to queue scheduled item: %w", err)
+	}
+
+	return nil
+}
+
+// ListScheduledItems returns up to limit of a channel's queued items, oldest first
+func (r *Repository) ListScheduledItems(ctx context.Context, channelID uuid.UUID, limit int) ([]models.ScheduledItem, error) {
+	items := []models.ScheduledItem{}
+	query := `SELECT ` + scheduledItemColumns + `
+		FROM channel_scheduled_items
+		WHERE channel_id = $1
+		ORDER BY queued_at, id
+		LIMIT $2
+	`
+	if err := r.db.SelectContext(ctx, &items, query, channelID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list scheduled items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetQueuedVariant returns the item queued on a channel with the given content
+// hash, or ErrNotFound when none is waiting
+func (r *Repository) GetQueuedVariant(
+	ctx context.Context, channelID uuid.UUID, contentHash string,
+) (*models.ScheduledItem, error) {
+	var item models.ScheduledItem
+	query := `SELECT ` + scheduledItemColumns + `
+		FROM channel_scheduled_items
+		WHERE channel_id = $1 AND content_hash = $2
+		ORDER BY quality_score DESC
+		LIMIT 1
+	`
+	if err := r.db.GetContext(ctx, &item, query, channelID, contentHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get queued variant: %w", err)
+	}
+
+	return &item, nil
+}
+
+// ListScheduledItemsByQuality returns up to limit of a channel's queued items,
+// highest quality first, then oldest first
+func (r *Repository) ListScheduledItemsByQuality(
+	ctx context.Context, channelID uuid.UUID, limit int,
+) ([]models.ScheduledItem, error) {
+	items := []models.ScheduledItem{}
+	query := `SELECT ` + scheduledItemColumns + `
+		FROM channel_scheduled_items
+		WHERE channel_id = $1
+		ORDER BY quality_score DESC, queued_at, id
+		LIMIT $2
+	`
+	if err := r.db.SelectContext(ctx, &items, query, channelID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list scheduled items by quality: %w", err)
+	}
+
+	return items, nil
+}
+
+// TrimScheduledItems keeps a channel's keep highest quality queued items and
+// deletes the rest, returning how many were dropped
+func (r *Repository) TrimScheduledItems(ctx context.Context, channelID uuid.UUID, keep int) (int64, error) {
+	query := `
+		DELETE FROM channel_scheduled_items
+		WHERE id IN (
+			SELECT id FROM channel_scheduled_items
+			WHERE channel_id = $1
+			ORDER BY quality_score DESC, queued_at, id
+			OFFSET $2
+		)
+	`
+	result, err := r.db.ExecContext(ctx, query, channelID, keep)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trim scheduled items: %w", err)
+	}
+	dropped, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return dropped, nil
+}
+
+// CountScheduledItems returns the number of queued items per channel
+func (r *Repository) CountScheduledItems(ctx context.Context) (map[uuid.UUID]int, error) {
+	rows := []struct {
+		ChannelID uuid.UUID `db:"channel_id"`
+		Count     int       `db:"count"`
+	}{}
+	query := `SELECT channel_id, COUNT(*) AS count FROM channel_scheduled_items GROUP BY channel_id`
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to count scheduled items: %w", err)
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[row.ChannelID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// DeleteScheduledItem removes a released item from the queue
+func (r *Repository) DeleteScheduledItem(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM channel_scheduled_items WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete scheduled item: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+// Package drupal talks to the Drupal site through JSON:API: it lists groups so
+// the publisher can offer a channel for each group, and creates nodes (with
+// their images as media) for drupal channels.
+package drupal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+const (
+	// pageLimit is the page size requested from JSON:API (Drupal caps it at 50)
+	pageLimit = 50
+	// maxPages bounds pagination so a misbehaving next link cannot loop forever
+	maxPages = 100
+	// maxErrorBodyBytes bounds how much of an error response is read
+	maxErrorBodyBytes = 4096
+)
+
+// ErrNoGroupTypes is returned when no group bundles are configured to list
+var ErrNoGroupTypes = errors.New("no drupal group types configured")
+
+// Config identifies the Drupal site and the group bundles to list
+type Config struct {
+	BaseURL string
+	// Token is sent as a bearer token; empty sends anonymous requests
+	Token      string
+	GroupTypes []string
+}
+
+// Client lists groups and creates content on a single Drupal site
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewClient creates a Drupal client. The http.Client carries the timeout.
+func NewClient(cfg Config, httpClient *http.Client) *Client {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &Client{cfg: cfg, httpClient: httpClient}
+}
+
+// Ping checks that the site's JSON:API entry point answers with 200. The
+// publisher uses it to probe drupal channels.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+"/jsonapi", http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.api+json")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("drupal JSON:API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// jsonAPIPage is the subset of a JSON:API collection response the client reads
+type jsonAPIPage struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Label      string `json:"label"`
+			InternalID int    `json:"drupal_internal__id"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Links struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"links"`
+}
+
+// ListGroups returns the groups of every configured bundle, sorted by label within each bundle
+func (c *Client) ListGroups(ctx context.Context) ([]models.DrupalGroup, error) {
+	if len(c.cfg.GroupTypes) == 0 {
+		return nil, ErrNoGroupTypes
+	}
+
+	var groups []models.DrupalGroup
+	for _, groupType := range c.cfg.GroupTypes {
+		typeGroups, err := c.listGroupType(ctx, groupType)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, typeGroups...)
+	}
+
+	return groups, nil
+}
+
+// listGroupType follows the JSON:API next links of one group bundle
+func (c *Client) listGroupType(ctx context.Context, groupType string) ([]models.DrupalGroup, error) {
+	resource := "group--" + groupType
+	query := url.Values{}
+	query.Set("fields["+resource+"]", "label,drupal_internal__id")
+	query.Set("sort", "label")
+	query.Set("page[limit]", fmt.Sprint(pageLimit))
+	next := c.cfg.BaseURL + "/jsonapi/group/" + url.PathEscape(groupType) + "?" + query.Encode()
+
+	var groups []models.DrupalGroup
+	for page := 0; next != "" && page < maxPages; page++ {
+		body, err := c.getPage(ctx, next)
+		if err != nil {
+			return nil, fmt.Errorf("list %s groups: %w", groupType, err)
+		}
+
+		for i := range body.Data {
+			groups = append(groups, models.DrupalGroup{
+				ID:         body.Data[i].ID,
+				InternalID: body.Data[i].Attributes.InternalID,
+				Type:       groupType,
+				Label:      body.Data[i].Attributes.Label,
+			})
+		}
+
+		next = ""
+		if body.Links.Next != nil {
+			next = body.Links.Next.Href
+		}
+	}
+
+	return groups, nil
+}
+
+// getPage fetches and decodes one JSON:API page
+func (c *Client) getPage(ctx context.Context, pageURL string) (*jsonAPIPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.api+json")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return nil, fmt.Errorf("drupal JSON:API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	var page jsonAPIPage
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&page); decodeErr != nil {
+		return nil, fmt.Errorf("decode response: %w", decodeErr)
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,64 @@
+package drupal_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGroups_FollowsNextLinks(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/jsonapi/group/community", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "label,drupal_internal__id", r.URL.Query().Get("fields[group--community]"))
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Query().Get("page[offset]") == "" {
+			fmt.Fprintf(w, `{"data":[{"id":"a1","attributes":{"label":"Sudbury","drupal_internal__id":3}}],
+				"links":{"next":{"href":"%s/jsonapi/group/community?fields%%5Bgroup--community%%5D=label,drupal_internal__id&page%%5Boffset%%5D=50"}}}`, srv.URL)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"b2","attributes":{"label":"Timmins","drupal_internal__id":9}}],"links":{}}`))
+	}))
+	defer srv.Close()
+
+	client := drupal.NewClient(drupal.Config{
+		BaseURL: srv.URL + "/", Token: "secret", GroupTypes: []string{"community"},
+	}, srv.Client())
+
+	groups, err := client.ListGroups(context.Background())
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "a1", groups[0].ID)
+	assert.Equal(t, 3, groups[0].InternalID)
+	assert.Equal(t, "community", groups[0].Type)
+	assert.Equal(t, "Timmins", groups[1].Label)
+}
+
+func TestListGroups_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "access denied", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	client := drupal.NewClient(drupal.Config{BaseURL: srv.URL, GroupTypes: []string{"community"}}, srv.Client())
+
+	_, err := client.ListGroups(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Contains(t, err.Error(), "access denied")
+}
+
+func TestListGroups_NoGroupTypes(t *testing.T) {
+	client := drupal.NewClient(drupal.Config{BaseURL: "http://drupal.invalid"}, http.DefaultClient)
+
+	_, err := client.ListGroups(context.Background())
+	require.ErrorIs(t, err, drupal.ErrNoGroupTypes)
+}
@@ -0,0 +1,188 @@
+package drupal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// jsonAPIContentType is the media type JSON:API requires on writes
+const jsonAPIContentType = "application/vnd.api+json"
+
+// Node is an article created on the Drupal site
+type Node struct {
+	// Type is the node bundle, e.g. "article"
+	Type       string
+	Title      string
+	Body       string
+	BodyFormat string
+	Summary    string
+	Published  bool
+	// ImageField is the node's media reference field; the media entity MediaID
+	// of bundle MediaType is attached to it when both are set
+	ImageField string
+	MediaType  string
+	MediaID    string
+}
+
+// Image is a downloaded image to upload as a media entity
+type Image struct {
+	Filename string
+	Data     []byte
+	// Alt is the image's alternative text, required by core's image media
+	Alt string
+}
+
+// CreatedEntity is the subset of a JSON:API create response the publisher uses
+type CreatedEntity struct {
+	ID         string
+	InternalID int
+}
+
+// jsonAPIResource is the subset of a JSON:API single-resource document the client reads
+type jsonAPIResource struct {
+	Data struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			InternalID int `json:"drupal_internal__id"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// CreateImageMedia uploads an image to the media bundle's file field and
+// creates the media entity that wraps it, returning the media entity.
+func (c *Client) CreateImageMedia(ctx context.Context, mediaType, fileField string, img *Image) (*CreatedEntity, error) {
+	uploadURL := c.cfg.BaseURL + "/jsonapi/media/" + url.PathEscape(mediaType) + "/" + url.PathEscape(fileField)
+	headers := map[string]string{
+		"Content-Type":        "application/octet-stream",
+		"Content-Disposition": mime.FormatMediaType("file", map[string]string{"filename": img.Filename}),
+	}
+	file, err := c.send(ctx, http.MethodPost, uploadURL, img.Data, headers)
+	if err != nil {
+		return nil, fmt.Errorf("upload image file: %w", err)
+	}
+
+	document := map[string]any{
+		"data": map[string]any{
+			"type":       "media--" + mediaType,
+			"attributes": map[string]any{"name": img.Filename, "status": true},
+			"relationships": map[string]any{
+				fileField: map[string]any{
+					"data": map[string]any{
+						"type": "file--file",
+						"id":   file.ID,
+						"meta": map[string]any{"alt": img.Alt},
+					},
+				},
+			},
+		},
+	}
+	media, err := c.sendDocument(ctx, http.MethodPost, c.cfg.BaseURL+"/jsonapi/media/"+url.PathEscape(mediaType), document)
+	if err != nil {
+		return nil, fmt.Errorf("create media: %w", err)
+	}
+
+	return media, nil
+}
+
+// CreateNode creates a node, referencing node.MediaID from node.ImageField when set
+func (c *Client) CreateNode(ctx context.Context, node *Node) (*CreatedEntity, error) {
+	created, err := c.sendDocument(ctx, http.MethodPost, c.cfg.BaseURL+"/jsonapi/node/"+url.PathEscape(node.Type),
+		NodeDocument(node))
+	if err != nil {
+		return nil, fmt.Errorf("create node: %w", err)
+	}
+
+	return created, nil
+}
+
+// NodeDocument returns the JSON:API document CreateNode posts for node
+func NodeDocument(node *Node) map[string]any {
+	resource := map[string]any{
+		"type": "node--" + node.Type,
+		"attributes": map[string]any{
+			"title":  node.Title,
+			"status": node.Published,
+			"body": map[string]any{
+				"value":   node.Body,
+				"format":  node.BodyFormat,
+				"summary": node.Summary,
+			},
+		},
+	}
+	if node.ImageField != "" && node.MediaID != "" {
+		resource["relationships"] = map[string]any{
+			node.ImageField: map[string]any{
+				"data": map[string]any{"type": "media--" + node.MediaType, "id": node.MediaID},
+			},
+		}
+	}
+
+	return map[string]any{"data": resource}
+}
+
+// UnpublishNode sets a node's status to unpublished. The node is kept so an
+// editor can review or restore it.
+func (c *Client) UnpublishNode(ctx context.Context, nodeType, id string) error {
+	resource := map[string]any{
+		"type":       "node--" + nodeType,
+		"id":         id,
+		"attributes": map[string]any{"status": false},
+	}
+
+	endpoint := c.cfg.BaseURL + "/jsonapi/node/" + url.PathEscape(nodeType) + "/" + url.PathEscape(id)
+	if _, err := c.sendDocument(ctx, http.MethodPatch, endpoint, map[string]any{"data": resource}); err != nil {
+		return fmt.Errorf("unpublish node: %w", err)
+	}
+
+	return nil
+}
+
+// sendDocument sends a JSON:API document with the given method
+func (c *Client) sendDocument(ctx context.Context, method, endpoint string, document map[string]any) (*CreatedEntity, error) {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("marshal document: %w", err)
+	}
+
+	return c.send(ctx, method, endpoint, body, map[string]string{"Content-Type": jsonAPIContentType})
+}
+
+// send makes a write request and decodes the resource in the response
+func (c *Client) send(ctx context.Context, method, endpoint string, body []byte, headers map[string]string) (*CreatedEntity, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", jsonAPIContentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return nil, fmt.Errorf("drupal JSON:API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	var created jsonAPIResource
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&created); decodeErr != nil {
+		return nil, fmt.Errorf("decode response: %w", decodeErr)
+	}
+
+	return &CreatedEntity{ID: created.Data.ID, InternalID: created.Data.Attributes.InternalID}, nil
+}
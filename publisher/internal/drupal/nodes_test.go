@@ -0,0 +1,63 @@
+package drupal_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNode_ReturnsCreatedEntity(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/jsonapi/node/article", r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"n1","attributes":{"drupal_internal__id":7}}}`))
+	}))
+	defer srv.Close()
+
+	client := drupal.NewClient(drupal.Config{BaseURL: srv.URL}, srv.Client())
+
+	created, err := client.CreateNode(context.Background(), &drupal.Node{Type: "article", Title: "Headline"})
+	require.NoError(t, err)
+	assert.Equal(t, "n1", created.ID)
+	assert.Equal(t, 7, created.InternalID)
+}
+
+func TestCreateImageMedia_UploadError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "file extension not allowed", http.StatusUnprocessableEntity)
+	}))
+	defer srv.Close()
+
+	client := drupal.NewClient(drupal.Config{BaseURL: srv.URL}, srv.Client())
+
+	_, err := client.CreateImageMedia(context.Background(), "image", "field_media_image",
+		&drupal.Image{Filename: "a.tiff", Data: []byte("x")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upload image file")
+	assert.Contains(t, err.Error(), "422")
+}
+
+func TestUnpublishNode_PatchesStatus(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/jsonapi/node/article/n1", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"data":{"id":"n1","attributes":{"drupal_internal__id":7}}}`))
+	}))
+	defer srv.Close()
+
+	client := drupal.NewClient(drupal.Config{BaseURL: srv.URL}, srv.Client())
+
+	require.NoError(t, client.UnpublishNode(context.Background(), "article", "n1"))
+	data, _ := body["data"].(map[string]any)
+	assert.Equal(t, "node--article", data["type"])
+	assert.Equal(t, map[string]any{"status": false}, data["attributes"])
+}
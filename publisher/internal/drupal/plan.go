@@ -0,0 +1,96 @@
+package drupal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// redisChannelPrefix starts the Redis channel of provisioned group channels
+const redisChannelPrefix = "drupal:group:"
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Plan compares discovered groups with the channels table. Groups without a
+// channel are offered as disabled redis channels with the group prefilled;
+// channels linked to a group the site no longer lists are reported as orphaned.
+func Plan(groups []models.DrupalGroup, channels []models.Channel) *models.DrupalSyncPlan {
+	plan := &models.DrupalSyncPlan{
+		Missing:  []models.DrupalChannelProposal{},
+		Linked:   []models.DrupalLinkedChannel{},
+		Orphaned: []models.DrupalOrphanedChannel{},
+	}
+
+	byGroup := make(map[string]*models.Channel, len(channels))
+	slugs := make(map[string]bool, len(channels))
+	for i := range channels {
+		slugs[channels[i].Slug] = true
+		if channels[i].Config.Drupal != nil {
+			byGroup[channels[i].Config.Drupal.GroupID] = &channels[i]
+		}
+	}
+
+	discovered := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		discovered[group.ID] = true
+
+		if ch, linked := byGroup[group.ID]; linked {
+			plan.Linked = append(plan.Linked, models.DrupalLinkedChannel{
+				Group:        group,
+				ChannelID:    ch.ID,
+				ChannelName:  ch.Name,
+				LabelChanged: ch.Name != group.Label,
+			})
+			continue
+		}
+
+		proposal := propose(group, slugs)
+		slugs[proposal.Channel.Slug] = true
+		plan.Missing = append(plan.Missing, proposal)
+	}
+
+	for i := range channels {
+		link := channels[i].Config.Drupal
+		if link != nil && !discovered[link.GroupID] {
+			plan.Orphaned = append(plan.Orphaned, models.DrupalOrphanedChannel{
+				ChannelID:   channels[i].ID,
+				ChannelName: channels[i].Name,
+				GroupID:     link.GroupID,
+			})
+		}
+	}
+
+	return plan
+}
+
+// propose builds the channel offered for a group. The slug is the group label,
+// suffixed with the group's internal ID when another channel already uses it.
+func propose(group models.DrupalGroup, takenSlugs map[string]bool) models.DrupalChannelProposal {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(group.Label), "-"), "-")
+	if slug == "" || takenSlugs[slug] {
+		slug = strings.TrimPrefix(slug+"-"+strconv.Itoa(group.InternalID), "-")
+	}
+
+	name := group.Label
+	if name == "" {
+		name = "Drupal group " + strconv.Itoa(group.InternalID)
+	}
+
+	enabled := false
+	return models.DrupalChannelProposal{
+		Group: group,
+		Channel: models.ChannelCreateRequest{
+			Name:         name,
+			Slug:         slug,
+			RedisChannel: redisChannelPrefix + slug,
+			Description:  "Drupal " + group.Type + " group " + name,
+			Enabled:      &enabled,
+			Type:         models.ChannelTypeRedis,
+			Config: &models.ChannelConfig{
+				Drupal: &models.DrupalConfig{GroupID: group.ID, GroupType: group.Type},
+			},
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package drupal_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sudburyGroupID = "0b5c3a52-4f7e-4c3a-9d6c-6b1f3d1a2e01"
+	timminsGroupID = "0b5c3a52-4f7e-4c3a-9d6c-6b1f3d1a2e02"
+	closedGroupID  = "0b5c3a52-4f7e-4c3a-9d6c-6b1f3d1a2e03"
+)
+
+func linkedChannel(name, slug, groupID string) models.Channel {
+	return models.Channel{
+		ID:     uuid.New(),
+		Name:   name,
+		Slug:   slug,
+		Config: models.ChannelConfig{Drupal: &models.DrupalConfig{GroupID: groupID}},
+	}
+}
+
+func TestPlan_MissingLinkedOrphaned(t *testing.T) {
+	groups := []models.DrupalGroup{
+		{ID: sudburyGroupID, InternalID: 3, Type: "community", Label: "Greater Sudbury"},
+		{ID: timminsGroupID, InternalID: 9, Type: "community", Label: "Timmins"},
+	}
+	channels := []models.Channel{
+		linkedChannel("Sudbury", "sudbury", sudburyGroupID),
+		linkedChannel("Kirkland Lake", "kirkland-lake", closedGroupID),
+		{ID: uuid.New(), Name: "Crime", Slug: "crime"},
+	}
+
+	plan := drupal.Plan(groups, channels)
+
+	require.Len(t, plan.Linked, 1)
+	assert.Equal(t, channels[0].ID, plan.Linked[0].ChannelID)
+	assert.True(t, plan.Linked[0].LabelChanged)
+
+	require.Len(t, plan.Orphaned, 1)
+	assert.Equal(t, closedGroupID, plan.Orphaned[0].GroupID)
+
+	require.Len(t, plan.Missing, 1)
+	proposal := plan.Missing[0].Channel
+	assert.Equal(t, "Timmins", proposal.Name)
+	assert.Equal(t, "timmins", proposal.Slug)
+	assert.Equal(t, "drupal:group:timmins", proposal.RedisChannel)
+	assert.False(t, *proposal.Enabled)
+	assert.Equal(t, timminsGroupID, proposal.Config.Drupal.GroupID)
+	assert.Equal(t, "community", proposal.Config.Drupal.GroupType)
+	require.NoError(t, proposal.Validate())
+}
+
+func TestPlan_SlugCollisionUsesInternalID(t *testing.T) {
+	groups := []models.DrupalGroup{
+		{ID: sudburyGroupID, InternalID: 3, Type: "community", Label: "Crime"},
+		{ID: timminsGroupID, InternalID: 9, Type: "community", Label: ""},
+	}
+
+	plan := drupal.Plan(groups, []models.Channel{{ID: uuid.New(), Name: "Crime", Slug: "crime"}})
+
+	require.Len(t, plan.Missing, 2)
+	assert.Equal(t, "crime-3", plan.Missing[0].Channel.Slug)
+	assert.Equal(t, "9", plan.Missing[1].Channel.Slug)
+	assert.Equal(t, "Drupal group 9", plan.Missing[1].Channel.Name)
+}
@@ -7,19 +7,29 @@ import (
 	"github.com/google/uuid"
 )
 
-// Channel represents a custom routing channel with embedded rules
+// Channel represents a custom routing channel with embedded rules.
+// Type selects delivery (redis pub/sub, wordpress, or webhook); RedisChannel stays the
+// routing key used for dedup and publish history whatever the type. RequiresApproval
+// holds routed items for an editor to approve before delivery.
 type Channel struct {
-	ID           uuid.UUID `db:"id"            json:"id"`
-	Name         string    `db:"name"          json:"name"`
-	Slug         string    `db:"slug"          json:"slug"`
-	RedisChannel string    `db:"redis_channel" json:"redis_channel"`
-	Description  string    `db:"description"   json:"description"`
-	Rules        Rules     `db:"-"             json:"rules"`
-	RulesJSON    []byte    `db:"rules"         json:"-"`
-	RulesVersion int       `db:"rules_version" json:"rules_version"`
-	Enabled      bool      `db:"enabled"       json:"enabled"`
-	CreatedAt    time.Time `db:"created_at"    json:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"    json:"updated_at"`
+	ID               uuid.UUID     `db:"id"                json:"id"`
+	Name             string        `db:"name"              json:"name"`
+	Slug             string        `db:"slug"              json:"slug"`
+	RedisChannel     string        `db:"redis_channel"     json:"redis_channel"`
+	Description      string        `db:"description"       json:"description"`
+	Rules            Rules         `db:"-"                 json:"rules"`
+	RulesJSON        []byte        `db:"rules"             json:"-"`
+	RulesVersion     int           `db:"rules_version"     json:"rules_version"`
+	Enabled          bool          `db:"enabled"           json:"enabled"`
+	Type             string        `db:"channel_type"      json:"type"`
+	Config           ChannelConfig `db:"-"                 json:"config"`
+	ConfigJSON       []byte        `db:"config"            json:"-"`
+	RequiresApproval bool          `db:"requires_approval" json:"requires_approval"`
+	CreatedAt        time.Time     `db:"created_at"        json:"created_at"`
+	UpdatedAt        time.Time     `db:"updated_at"        json:"updated_at"`
+	// Health is the destination's probe and circuit breaker record; set by
+	// the channels API, nil elsewhere
+	Health *ChannelHealth `db:"-" json:"health,omitempty"`
 }
 
 // ParseRules parses RulesJSON into Rules struct
@@ -31,36 +41,81 @@ func (c *Channel) ParseRules() error {
 	return json.Unmarshal(c.RulesJSON, &c.Rules)
 }
 
-// ChannelCreateRequest represents the request payload for creating a channel
+// ParseConfig parses ConfigJSON into the Config struct
+func (c *Channel) ParseConfig() error {
+	if len(c.ConfigJSON) == 0 {
+		c.Config = ChannelConfig{}
+		return nil
+	}
+	return json.Unmarshal(c.ConfigJSON, &c.Config)
+}
+
+// DeliveryType returns the channel type, treating an unset type as redis
+func (c *Channel) DeliveryType() string {
+	if c.Type == "" {
+		return ChannelTypeRedis
+	}
+	return c.Type
+}
+
+// ChannelCreateRequest represents the request payload for creating a channel.
+// Type defaults to redis; other types need the matching Config block.
 type ChannelCreateRequest struct {
-	Name         string `binding:"required,min=1,max=255" json:"name"`
-	Slug         string `binding:"required,min=1,max=255" json:"slug"`
-	RedisChannel string `binding:"required,min=1,max=255" json:"redis_channel"`
-	Description  string `binding:"max=1000"               json:"description"`
-	Rules        *Rules `json:"rules"`
-	Enabled      *bool  `json:"enabled"`
+	Name             string         `binding:"required,min=1,max=255" json:"name"`
+	Slug             string         `binding:"required,min=1,max=255" json:"slug"`
+	RedisChannel     string         `binding:"required,min=1,max=255" json:"redis_channel"`
+	Description      string         `binding:"max=1000"               json:"description"`
+	Rules            *Rules         `json:"rules"`
+	Enabled          *bool          `json:"enabled"`
+	Type             string         `binding:"omitempty,max=50"       json:"type"`
+	Config           *ChannelConfig `json:"config"`
+	RequiresApproval *bool          `json:"requires_approval"`
 }
 
-// ChannelUpdateRequest represents the request payload for updating a channel
+// ChannelUpdateRequest represents the request payload for updating a channel.
+// A Type change is validated against Config, so switching to wordpress needs both.
 type ChannelUpdateRequest struct {
-	Name         *string `binding:"omitempty,min=1,max=255" json:"name"`
-	Slug         *string `binding:"omitempty,min=1,max=255" json:"slug"`
-	RedisChannel *string `binding:"omitempty,min=1,max=255" json:"redis_channel"`
-	Description  *string `binding:"omitempty,max=1000"      json:"description"`
-	Rules        *Rules  `json:"rules"`
-	Enabled      *bool   `json:"enabled"`
+	Name             *string        `binding:"omitempty,min=1,max=255" json:"name"`
+	Slug             *string        `binding:"omitempty,min=1,max=255" json:"slug"`
+	RedisChannel     *string        `binding:"omitempty,min=1,max=255" json:"redis_channel"`
+	Description      *string        `binding:"omitempty,max=1000"      json:"description"`
+	Rules            *Rules         `json:"rules"`
+	Enabled          *bool          `json:"enabled"`
+	Type             *string        `binding:"omitempty,max=50"        json:"type"`
+	Config           *ChannelConfig `json:"config"`
+	RequiresApproval *bool          `json:"requires_approval"`
 }
 
 // Validate validates the channel create request
 func (r *ChannelCreateRequest) Validate() error {
-	return nil
+	if r.Type == "" {
+		r.Type = ChannelTypeRedis
+	}
+	if r.Rules != nil {
+		if err := r.Rules.Validate(); err != nil {
+			return err
+		}
+	}
+	return ValidateChannelConfig(r.Type, r.Config)
 }
 
 // Validate validates the channel update request
 func (r *ChannelUpdateRequest) Validate() error {
 	if r.Name == nil && r.Slug == nil && r.RedisChannel == nil &&
-		r.Description == nil && r.Rules == nil && r.Enabled == nil {
+		r.Description == nil && r.Rules == nil && r.Enabled == nil &&
+		r.Type == nil && r.Config == nil && r.RequiresApproval == nil {
 		return ErrNoFieldsToUpdate
 	}
+	if r.Rules != nil {
+		if err := r.Rules.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.Type != nil {
+		return ValidateChannelConfig(*r.Type, r.Config)
+	}
+	if r.Config != nil {
+		return r.Config.Validate()
+	}
 	return nil
 }
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Approval statuses. Pending items wait for an editor; approved items are
+// delivered by the router's next poll and end as published or failed.
+const (
+	ApprovalStatusPending   = "pending"
+	ApprovalStatusApproved  = "approved"
+	ApprovalStatusRejected  = "rejected"
+	ApprovalStatusPublished = "published"
+	ApprovalStatusFailed    = "failed"
+)
+
+// ErrApprovalNotPending is returned when reviewing or editing an item that
+// has already been approved or rejected
+var ErrApprovalNotPending = errors.New("approval is not pending")
+
+// ChannelApproval is an item routed to a channel that requires editorial
+// approval. Payload is the content item as routed; Edits override its title,
+// body, or summary when it is delivered.
+type ChannelApproval struct {
+	ID           uuid.UUID      `db:"id"            json:"id"`
+	ChannelID    uuid.UUID      `db:"channel_id"    json:"channel_id"`
+	ContentID    string         `db:"content_id"    json:"content_id"`
+	Title        string         `db:"title"         json:"title"`
+	URL          string         `db:"url"           json:"url"`
+	Source       string         `db:"source"        json:"source"`
+	QualityScore int            `db:"quality_score" json:"quality_score"`
+	Topics       pq.StringArray `db:"topics"        json:"topics"`
+	Payload      []byte         `db:"payload"       json:"-"`
+	Edits        ApprovalEdits  `db:"-"             json:"edits"`
+	EditsJSON    []byte         `db:"edits"         json:"-"`
+	Status       string         `db:"status"        json:"status"`
+	ReviewedBy   string         `db:"reviewed_by"   json:"reviewed_by,omitempty"`
+	ReviewNote   string         `db:"review_note"   json:"review_note,omitempty"`
+	ReviewedAt   *time.Time     `db:"reviewed_at"   json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time      `db:"created_at"    json:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"    json:"updated_at"`
+}
+
+// ParseEdits parses EditsJSON into Edits
+func (a *ChannelApproval) ParseEdits() error {
+	if len(a.EditsJSON) == 0 {
+		a.Edits = ApprovalEdits{}
+		return nil
+	}
+	return json.Unmarshal(a.EditsJSON, &a.Edits)
+}
+
+// ApprovalEdits are editor changes applied to an item when it is delivered.
+// Nil fields keep the routed value.
+type ApprovalEdits struct {
+	Title   *string `binding:"omitempty,min=1,max=1000" json:"title,omitempty"`
+	Body    *string `json:"body,omitempty"`
+	Summary *string `binding:"omitempty,max=2000"       json:"summary,omitempty"`
+}
+
+// IsEmpty reports whether no field is edited
+func (e *ApprovalEdits) IsEmpty() bool {
+	return e.Title == nil && e.Body == nil && e.Summary == nil
+}
+
+// ApprovalReviewRequest is the payload for approving or rejecting an item.
+// Edits are optional when approving and ignored when rejecting.
+type ApprovalReviewRequest struct {
+	Note  string         `binding:"max=1000" json:"note"`
+	Edits *ApprovalEdits `json:"edits"`
+}
+
+// ApprovalFilter scopes an approval listing
+type ApprovalFilter struct {
+	ChannelID string `binding:"omitempty,uuid"                                           form:"channel_id"`
+	Status    string `binding:"omitempty,oneof=pending approved rejected published failed" form:"status"`
+	Limit     int    `binding:"omitempty,min=1,max=500"                                  form:"limit"` // Default 50
+	Offset    int    `binding:"omitempty,min=0"                                          form:"offset"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// canaryBuckets is the number of sampling buckets, one per percentage point.
+const canaryBuckets = 100
+
+// CanaryConfig sends only a sample of the items matching a channel's rules, to
+// trial a new destination (or a new classifier version) before full rollout.
+// Sampling is deterministic: an item's or source's bucket comes from hashing it
+// with the channel ID, so every poll, retry, and preview agrees, raising a
+// percentage only adds items, and channels sample independently.
+type CanaryConfig struct {
+	// Percent of matching items delivered (1-100; 0 = no item sampling)
+	Percent int `json:"percent,omitempty"`
+	// SourcePercent of sources whose items are delivered (1-100; 0 = no source sampling)
+	SourcePercent int `json:"source_percent,omitempty"`
+	// Sources limits delivery to items from these sources
+	Sources []string `json:"sources,omitempty"`
+}
+
+// Validate checks the canary settings
+func (c *CanaryConfig) Validate() error {
+	if c.Percent < 0 || c.Percent > canaryBuckets || c.SourcePercent < 0 || c.SourcePercent > canaryBuckets {
+		return fmt.Errorf("%w: canary.percent and canary.source_percent must be between 1 and 100", ErrInvalidChannelConfig)
+	}
+	if c.Percent == 0 && c.SourcePercent == 0 && len(c.Sources) == 0 {
+		return fmt.Errorf("%w: canary needs percent, source_percent, or sources", ErrInvalidChannelConfig)
+	}
+	return nil
+}
+
+// Admits reports whether an item matching the channel's rules is in the
+// channel's sample. A nil config admits everything.
+func (c *CanaryConfig) Admits(channelID uuid.UUID, itemID, source string) bool {
+	if c == nil {
+		return true
+	}
+	if len(c.Sources) > 0 && !slices.Contains(c.Sources, source) {
+		return false
+	}
+	if c.SourcePercent > 0 && canaryBucket(channelID, "source:"+source) >= c.SourcePercent {
+		return false
+	}
+	if c.Percent > 0 && canaryBucket(channelID, "item:"+itemID) >= c.Percent {
+		return false
+	}
+	return true
+}
+
+// canaryBucket hashes key with the channel ID into a bucket from 0 to 99.
+func canaryBucket(channelID uuid.UUID, key string) int {
+	h := fnv.New64a()
+	_, _ = h.Write(channelID[:])
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum64() % canaryBuckets)
+}
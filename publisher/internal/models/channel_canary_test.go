@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanaryConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&models.CanaryConfig{Percent: 10}).Validate())
+	assert.NoError(t, (&models.CanaryConfig{Sources: []string{"sudbury_com"}}).Validate())
+
+	for name, cfg := range map[string]*models.CanaryConfig{
+		"empty":              {},
+		"percent too high":   {Percent: 101},
+		"negative source pc": {SourcePercent: -1},
+	} {
+		err := cfg.Validate()
+		assert.True(t, errors.Is(err, models.ErrInvalidChannelConfig), "%s: got %v", name, err)
+	}
+}
+
+func TestCanaryConfig_Admits(t *testing.T) {
+	channelID := uuid.MustParse("6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f")
+
+	var nilCanary *models.CanaryConfig
+	assert.True(t, nilCanary.Admits(channelID, "any", "any"), "channels without a canary get everything")
+
+	const items = 2000
+	ten := &models.CanaryConfig{Percent: 10}
+	fifty := &models.CanaryConfig{Percent: 50}
+	admitted := 0
+	for i := range items {
+		id := "doc-" + strconv.Itoa(i)
+		if ten.Admits(channelID, id, "src") {
+			admitted++
+			assert.True(t, fifty.Admits(channelID, id, "src"), "raising the percentage only adds items")
+		}
+		assert.Equal(t, ten.Admits(channelID, id, "src"), ten.Admits(channelID, id, "src"), "sampling is deterministic")
+	}
+	assert.InDelta(t, items/10, admitted, items/25)
+
+	listed := &models.CanaryConfig{Sources: []string{"sudbury_com"}}
+	assert.True(t, listed.Admits(channelID, "doc-1", "sudbury_com"))
+	assert.False(t, listed.Admits(channelID, "doc-1", "cbc_ca"))
+}
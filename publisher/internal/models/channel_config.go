@@ -0,0 +1,495 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Channel delivery types
+const (
+	// ChannelTypeRedis publishes to the channel's Redis pub/sub topic (Drupal and other subscribers)
+	ChannelTypeRedis = "redis"
+	// ChannelTypeWordPress creates posts through the WordPress REST API
+	ChannelTypeWordPress = "wordpress"
+	// ChannelTypeWebhook POSTs the publish payload as JSON to a URL
+	ChannelTypeWebhook = "webhook"
+	// ChannelTypeFeed keeps the most recent items as an RSS/Atom feed served by the publisher API
+	ChannelTypeFeed = "feed"
+	// ChannelTypeChat posts story cards to a Slack or Discord incoming webhook
+	ChannelTypeChat = "chat"
+	// ChannelTypeDrupal creates nodes, with their images as media, through the Drupal site's JSON:API
+	ChannelTypeDrupal = "drupal"
+)
+
+// Drupal node defaults, matching a standard Drupal install with core media
+const (
+	DrupalDefaultNodeType       = "article"
+	DrupalDefaultBodyFormat     = "basic_html"
+	DrupalDefaultMediaType      = "image"
+	DrupalDefaultMediaFileField = "field_media_image"
+)
+
+// Chat platforms accepted for chat channels
+const (
+	ChatPlatformSlack   = "slack"
+	ChatPlatformDiscord = "discord"
+)
+
+// WordPress post statuses accepted for channel delivery
+const (
+	WordPressStatusPublish = "publish"
+	WordPressStatusDraft   = "draft"
+	WordPressStatusPending = "pending"
+)
+
+// Webhook delivery limits
+const (
+	// WebhookDefaultMaxAttempts is used when a webhook channel sets no max_attempts
+	WebhookDefaultMaxAttempts = 3
+	// WebhookMaxAttemptsLimit caps max_attempts so one endpoint cannot stall routing
+	WebhookMaxAttemptsLimit = 10
+)
+
+// Feed size limits
+const (
+	// FeedDefaultMaxItems is used when a feed channel sets no max_items
+	FeedDefaultMaxItems = 50
+	// FeedMaxItemsLimit caps max_items to keep feed documents small
+	FeedMaxItemsLimit = 500
+)
+
+// Chat delivery limits
+const (
+	// ChatDefaultMaxPerMinute is used when a chat channel sets no max_per_minute
+	ChatDefaultMaxPerMinute = 6
+	// ChatMaxPerMinuteLimit stays under Slack's and Discord's per-webhook rate limits
+	ChatMaxPerMinuteLimit = 30
+	// ChatDefaultMaxBatch is used when a chat channel sets no max_batch
+	ChatDefaultMaxBatch = 5
+	// ChatMaxBatchLimit is Discord's limit of embeds per message
+	ChatMaxBatchLimit = 10
+	// ChatMaxBatchSecondsLimit caps batch_seconds so stories are not held for long
+	ChatMaxBatchSecondsLimit = 3600
+)
+
+// webhookReservedHeaders are set by the publisher and cannot be overridden per channel
+var webhookReservedHeaders = map[string]bool{
+	"Content-Type":            true,
+	"Content-Length":          true,
+	"Host":                    true,
+	"X-North-Cloud-Signature": true,
+	"X-North-Cloud-Timestamp": true,
+}
+
+// drupalMachineName matches Drupal bundle, field, and text format machine names
+var drupalMachineName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ErrInvalidChannelConfig is returned when a channel's type or type-specific settings are invalid
+var ErrInvalidChannelConfig = errors.New("invalid channel config")
+
+// ChannelConfig holds the type-specific delivery settings of a channel.
+// Only the delivery block matching the channel's type is used; Report,
+// Schedule, Template, Transform, RateLimit, and Canary apply to channels of
+// every type.
+type ChannelConfig struct {
+	WordPress *WordPressConfig `json:"wordpress,omitempty"`
+	Webhook   *WebhookConfig   `json:"webhook,omitempty"`
+	Feed      *FeedConfig      `json:"feed,omitempty"`
+	Chat      *ChatConfig      `json:"chat,omitempty"`
+	Report    *ReportConfig    `json:"report,omitempty"`
+	Schedule  *ScheduleConfig  `json:"schedule,omitempty"`
+	Drupal    *DrupalConfig    `json:"drupal,omitempty"`
+	Template  *TemplateConfig  `json:"template,omitempty"`
+	Transform *TransformConfig `json:"transform,omitempty"`
+	RateLimit *RateLimitConfig `json:"rate_limit,omitempty"`
+	Canary    *CanaryConfig    `json:"canary,omitempty"`
+}
+
+// DrupalConfig links a redis channel to a group on the Drupal site, or sets
+// how a drupal channel creates nodes. The group is sent to subscribers as
+// publisher.drupal_group_id and lets group discovery tell which groups already
+// have a channel; the node settings are used by drupal channels only.
+type DrupalConfig struct {
+	// GroupID is the group's JSON:API UUID (required for redis channels)
+	GroupID string `json:"group_id,omitempty"`
+	// GroupType is the group bundle, e.g. "community"
+	GroupType string `json:"group_type,omitempty"`
+	// NodeType is the node bundle to create (default "article")
+	NodeType string `json:"node_type,omitempty"`
+	// BodyFormat is the body's text format (default "basic_html")
+	BodyFormat string `json:"body_format,omitempty"`
+	// Unpublished creates nodes unpublished, for review on the site
+	Unpublished bool `json:"unpublished,omitempty"`
+	// ImageField is the node's media reference field that receives the item's
+	// og_image; empty creates nodes without images
+	ImageField string `json:"image_field,omitempty"`
+	// MediaType is the media bundle images are created as (default "image")
+	MediaType string `json:"media_type,omitempty"`
+	// MediaFileField is the media bundle's image field (default "field_media_image")
+	MediaFileField string `json:"media_file_field,omitempty"`
+	// TokenCredential names a stored credential holding the JSON:API bearer
+	// token for this channel's nodes; empty uses DRUPAL_TOKEN
+	TokenCredential string `json:"token_credential,omitempty"`
+}
+
+// ReportConfig lists who receives the channel's weekly editorial report by email.
+type ReportConfig struct {
+	Recipients []string `json:"recipients"`
+}
+
+// WordPressConfig targets a WordPress site's REST API.
+// The application password is read from the environment variable named by
+// PasswordEnv, or decrypted from the stored credential named by
+// PasswordCredential, so it is never stored in the channel or returned by the API.
+type WordPressConfig struct {
+	// BaseURL is the site root, e.g. "https://partner.example.com"
+	BaseURL  string `json:"base_url"`
+	Username string `json:"username"`
+	// PasswordEnv names the env var holding the user's application password
+	PasswordEnv string `json:"password_env,omitempty"`
+	// PasswordCredential names the stored credential holding the password
+	PasswordCredential string `json:"password_credential,omitempty"`
+	// Status is the post status to create: publish (default), draft, or pending
+	Status string `json:"status,omitempty"`
+	// CategoryMap maps topics (and crime category pages) to WordPress category IDs
+	CategoryMap map[string]int `json:"category_map,omitempty"`
+	// DefaultCategoryID is used when no topic maps to a category; 0 leaves WordPress' default
+	DefaultCategoryID int `json:"default_category_id,omitempty"`
+}
+
+// WebhookConfig targets an HTTP endpoint that receives the publish payload.
+// When SecretEnv or SecretCredential is set, each request is signed with
+// HMAC-SHA256 using the secret held in that env var or stored credential; the
+// secret itself is never stored in the channel.
+type WebhookConfig struct {
+	// URL receives a POST per routed item
+	URL string `json:"url"`
+	// SecretEnv names the env var holding the HMAC signing secret (optional)
+	SecretEnv string `json:"secret_env,omitempty"`
+	// SecretCredential names the stored credential holding the signing secret (optional)
+	SecretCredential string `json:"secret_credential,omitempty"`
+	// Headers are added to every request (e.g. an API key the consumer expects)
+	Headers map[string]string `json:"headers,omitempty"`
+	// MaxAttempts bounds delivery attempts on 5xx, 429, and network errors (default 3)
+	MaxAttempts int `json:"max_attempts,omitempty"`
+}
+
+// FeedConfig describes a channel's RSS/Atom feed. All settings are optional;
+// a feed channel without config.feed uses the channel name and 50 items.
+type FeedConfig struct {
+	// Title overrides the feed title (defaults to the channel name)
+	Title string `json:"title,omitempty"`
+	// Description overrides the feed description (defaults to the channel description)
+	Description string `json:"description,omitempty"`
+	// Link is the site the feed represents, used as the feed's <link>
+	Link string `json:"link,omitempty"`
+	// MaxItems is how many of the most recent items the feed keeps (default 50)
+	MaxItems int `json:"max_items,omitempty"`
+}
+
+// ChatConfig targets a Slack or Discord incoming webhook. Webhook URLs embed
+// their credentials, so the URL is read from the env var named by
+// WebhookURLEnv, or decrypted from the stored credential named by
+// WebhookURLCredential, and never stored in the channel.
+type ChatConfig struct {
+	// Platform is slack or discord; it selects the message format
+	Platform string `json:"platform"`
+	// WebhookURLEnv names the env var holding the incoming webhook URL
+	WebhookURLEnv string `json:"webhook_url_env,omitempty"`
+	// WebhookURLCredential names the stored credential holding the webhook URL
+	WebhookURLCredential string `json:"webhook_url_credential,omitempty"`
+	// MaxPerMinute bounds messages posted per minute (default 6); items routed
+	// while the limit is reached are batched into the next message
+	MaxPerMinute int `json:"max_per_minute,omitempty"`
+	// MaxBatch bounds the story cards in one message (default 5, at most 10)
+	MaxBatch int `json:"max_batch,omitempty"`
+	// BatchSeconds holds items for this long to collect them into one message;
+	// 0 posts as soon as the rate limit allows
+	BatchSeconds int `json:"batch_seconds,omitempty"`
+}
+
+// IsValidChannelType reports whether t is a supported channel type
+func IsValidChannelType(t string) bool {
+	switch t {
+	case ChannelTypeRedis, ChannelTypeWordPress, ChannelTypeWebhook, ChannelTypeFeed, ChannelTypeChat, ChannelTypeDrupal:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateChannelConfig checks that cfg has the settings required by channelType
+func ValidateChannelConfig(channelType string, cfg *ChannelConfig) error {
+	if !IsValidChannelType(channelType) {
+		return fmt.Errorf("%w: unknown channel type %q", ErrInvalidChannelConfig, channelType)
+	}
+
+	switch channelType {
+	case ChannelTypeWordPress:
+		if cfg == nil || cfg.WordPress == nil {
+			return fmt.Errorf("%w: wordpress channels require config.wordpress", ErrInvalidChannelConfig)
+		}
+	case ChannelTypeWebhook:
+		if cfg == nil || cfg.Webhook == nil {
+			return fmt.Errorf("%w: webhook channels require config.webhook", ErrInvalidChannelConfig)
+		}
+	case ChannelTypeChat:
+		if cfg == nil || cfg.Chat == nil {
+			return fmt.Errorf("%w: chat channels require config.chat", ErrInvalidChannelConfig)
+		}
+	case ChannelTypeRedis:
+		if cfg != nil && cfg.Drupal != nil && cfg.Drupal.GroupID == "" {
+			return fmt.Errorf("%w: drupal.group_id is required for redis channels", ErrInvalidChannelConfig)
+		}
+	}
+
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Validate()
+}
+
+// Validate checks every type-specific block that is present
+func (c *ChannelConfig) Validate() error {
+	if c.WordPress != nil {
+		if err := c.WordPress.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Webhook != nil {
+		if err := c.Webhook.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Feed != nil {
+		if err := c.Feed.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Chat != nil {
+		if err := c.Chat.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Schedule != nil {
+		if err := c.Schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Template != nil {
+		if err := c.Template.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.RateLimit != nil {
+		if err := c.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Canary != nil {
+		if err := c.Canary.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Transform != nil {
+		if err := c.Transform.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Drupal != nil {
+		if err := c.Drupal.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Report != nil {
+		for _, recipient := range c.Report.Recipients {
+			if _, err := mail.ParseAddress(recipient); err != nil {
+				return fmt.Errorf("%w: report recipient %q is not an email address", ErrInvalidChannelConfig, recipient)
+			}
+		}
+	}
+	return nil
+}
+
+// CredentialRefs returns the names of the stored credentials the config references
+func (c *ChannelConfig) CredentialRefs() []string {
+	var refs []string
+	add := func(name string) {
+		if name != "" && !slices.Contains(refs, name) {
+			refs = append(refs, name)
+		}
+	}
+	if c.WordPress != nil {
+		add(c.WordPress.PasswordCredential)
+	}
+	if c.Webhook != nil {
+		add(c.Webhook.SecretCredential)
+	}
+	if c.Chat != nil {
+		add(c.Chat.WebhookURLCredential)
+	}
+	if c.Drupal != nil {
+		add(c.Drupal.TokenCredential)
+	}
+	return refs
+}
+
+// Validate checks the Drupal settings
+func (d *DrupalConfig) Validate() error {
+	if d.GroupID != "" {
+		if _, err := uuid.Parse(d.GroupID); err != nil {
+			return fmt.Errorf("%w: drupal.group_id must be a UUID", ErrInvalidChannelConfig)
+		}
+	}
+	for name, value := range map[string]string{
+		"node_type":        d.NodeType,
+		"body_format":      d.BodyFormat,
+		"image_field":      d.ImageField,
+		"media_type":       d.MediaType,
+		"media_file_field": d.MediaFileField,
+	} {
+		if value != "" && !drupalMachineName.MatchString(value) {
+			return fmt.Errorf("%w: drupal.%s must be a Drupal machine name", ErrInvalidChannelConfig, name)
+		}
+	}
+	return nil
+}
+
+// WithDefaults returns the settings with the node and media defaults applied
+func (d DrupalConfig) WithDefaults() DrupalConfig {
+	if d.NodeType == "" {
+		d.NodeType = DrupalDefaultNodeType
+	}
+	if d.BodyFormat == "" {
+		d.BodyFormat = DrupalDefaultBodyFormat
+	}
+	if d.MediaType == "" {
+		d.MediaType = DrupalDefaultMediaType
+	}
+	if d.MediaFileField == "" {
+		d.MediaFileField = DrupalDefaultMediaFileField
+	}
+	return d
+}
+
+// Validate checks the WordPress settings
+func (w *WordPressConfig) Validate() error {
+	parsed, err := url.Parse(w.BaseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: wordpress.base_url must be an http(s) URL", ErrInvalidChannelConfig)
+	}
+	if w.Username == "" {
+		return fmt.Errorf("%w: wordpress.username is required", ErrInvalidChannelConfig)
+	}
+	if (w.PasswordEnv == "") == (w.PasswordCredential == "") {
+		return fmt.Errorf("%w: wordpress needs one of password_env or password_credential", ErrInvalidChannelConfig)
+	}
+	switch w.Status {
+	case "", WordPressStatusPublish, WordPressStatusDraft, WordPressStatusPending:
+	default:
+		return fmt.Errorf("%w: wordpress.status must be publish, draft, or pending", ErrInvalidChannelConfig)
+	}
+	return nil
+}
+
+// Validate checks the webhook settings
+func (w *WebhookConfig) Validate() error {
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: webhook.url must be an http(s) URL", ErrInvalidChannelConfig)
+	}
+	if w.SecretEnv != "" && w.SecretCredential != "" {
+		return fmt.Errorf("%w: webhook takes secret_env or secret_credential, not both", ErrInvalidChannelConfig)
+	}
+	if w.MaxAttempts < 0 || w.MaxAttempts > WebhookMaxAttemptsLimit {
+		return fmt.Errorf("%w: webhook.max_attempts must be between 1 and %d",
+			ErrInvalidChannelConfig, WebhookMaxAttemptsLimit)
+	}
+	for name := range w.Headers {
+		canonical := http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if canonical == "" || strings.ContainsAny(canonical, " :\r\n") {
+			return fmt.Errorf("%w: webhook header name %q is invalid", ErrInvalidChannelConfig, name)
+		}
+		if webhookReservedHeaders[canonical] {
+			return fmt.Errorf("%w: webhook header %s is set by the publisher", ErrInvalidChannelConfig, canonical)
+		}
+	}
+	return nil
+}
+
+// Attempts returns the configured delivery attempts, applying the default
+func (w *WebhookConfig) Attempts() int {
+	if w.MaxAttempts <= 0 {
+		return WebhookDefaultMaxAttempts
+	}
+	return w.MaxAttempts
+}
+
+// Validate checks the feed settings
+func (f *FeedConfig) Validate() error {
+	if f.MaxItems < 0 || f.MaxItems > FeedMaxItemsLimit {
+		return fmt.Errorf("%w: feed.max_items must be between 1 and %d", ErrInvalidChannelConfig, FeedMaxItemsLimit)
+	}
+	if f.Link != "" {
+		parsed, err := url.Parse(f.Link)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: feed.link must be an http(s) URL", ErrInvalidChannelConfig)
+		}
+	}
+	return nil
+}
+
+// Validate checks the chat settings
+func (c *ChatConfig) Validate() error {
+	switch c.Platform {
+	case ChatPlatformSlack, ChatPlatformDiscord:
+	default:
+		return fmt.Errorf("%w: chat.platform must be slack or discord", ErrInvalidChannelConfig)
+	}
+	if (c.WebhookURLEnv == "") == (c.WebhookURLCredential == "") {
+		return fmt.Errorf("%w: chat needs one of webhook_url_env or webhook_url_credential", ErrInvalidChannelConfig)
+	}
+	if c.MaxPerMinute < 0 || c.MaxPerMinute > ChatMaxPerMinuteLimit {
+		return fmt.Errorf("%w: chat.max_per_minute must be between 1 and %d", ErrInvalidChannelConfig, ChatMaxPerMinuteLimit)
+	}
+	if c.MaxBatch < 0 || c.MaxBatch > ChatMaxBatchLimit {
+		return fmt.Errorf("%w: chat.max_batch must be between 1 and %d", ErrInvalidChannelConfig, ChatMaxBatchLimit)
+	}
+	if c.BatchSeconds < 0 || c.BatchSeconds > ChatMaxBatchSecondsLimit {
+		return fmt.Errorf("%w: chat.batch_seconds must be between 0 and %d", ErrInvalidChannelConfig, ChatMaxBatchSecondsLimit)
+	}
+	return nil
+}
+
+// PerMinute returns the configured message rate, applying the default
+func (c *ChatConfig) PerMinute() int {
+	if c.MaxPerMinute <= 0 {
+		return ChatDefaultMaxPerMinute
+	}
+	return c.MaxPerMinute
+}
+
+// BatchSize returns the configured cards per message, applying the default
+func (c *ChatConfig) BatchSize() int {
+	if c.MaxBatch <= 0 {
+		return ChatDefaultMaxBatch
+	}
+	return c.MaxBatch
+}
+
+// FeedItemLimit returns how many items a channel's feed keeps, applying the
+// default when cfg is nil or unset
+func FeedItemLimit(cfg *FeedConfig) int {
+	if cfg == nil || cfg.MaxItems <= 0 {
+		return FeedDefaultMaxItems
+	}
+	return cfg.MaxItems
+}
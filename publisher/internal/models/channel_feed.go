@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ChannelFeedItem is an item kept in a feed channel's RSS/Atom feed.
+// Only the channel's most recent items are retained.
+type ChannelFeedItem struct {
+	ID          uuid.UUID      `db:"id"           json:"id"`
+	ChannelID   uuid.UUID      `db:"channel_id"   json:"channel_id"`
+	ContentID   string         `db:"content_id"   json:"content_id"`
+	Title       string         `db:"title"        json:"title"`
+	URL         string         `db:"url"          json:"url"`
+	Summary     string         `db:"summary"      json:"summary"`
+	Source      string         `db:"source"       json:"source"`
+	ImageURL    string         `db:"image_url"    json:"image_url"`
+	Topics      pq.StringArray `db:"topics"       json:"topics"`
+	PublishedAt *time.Time     `db:"published_at" json:"published_at,omitempty"`
+	AddedAt     time.Time      `db:"added_at"     json:"added_at"`
+}
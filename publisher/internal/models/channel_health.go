@@ -0,0 +1,129 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Circuit breaker states. A closed circuit delivers normally; an open one
+// queues items instead; a half-open one lets deliveries through again and
+// closes on the first success or reopens on the first failure.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// Channel health statuses reported by the API.
+const (
+	ChannelHealthUnknown   = "unknown"
+	ChannelHealthHealthy   = "healthy"
+	ChannelHealthDegraded  = "degraded"
+	ChannelHealthUnhealthy = "unhealthy"
+)
+
+// ChannelHealth is the probe and delivery record of a channel's destination
+// and the state of its circuit breaker.
+type ChannelHealth struct {
+	ChannelID           uuid.UUID  `db:"channel_id"           json:"channel_id"`
+	Circuit             string     `db:"circuit"              json:"circuit"`
+	ConsecutiveFailures int        `db:"consecutive_failures" json:"consecutive_failures"`
+	LastError           string     `db:"last_error"           json:"last_error,omitempty"`
+	LastFailureAt       *time.Time `db:"last_failure_at"      json:"last_failure_at,omitempty"`
+	OpenedAt            *time.Time `db:"opened_at"            json:"opened_at,omitempty"`
+	LastProbeAt         *time.Time `db:"last_probe_at"        json:"last_probe_at,omitempty"`
+	LastProbeOK         *bool      `db:"last_probe_ok"        json:"last_probe_ok,omitempty"`
+	UpdatedAt           time.Time  `db:"updated_at"           json:"updated_at"`
+	// Status summarizes the record: unhealthy while the circuit is open,
+	// degraded after recent failures, healthy otherwise
+	Status string `db:"-" json:"status"`
+}
+
+// NewChannelHealth returns the record of a channel with no failures.
+func NewChannelHealth(channelID uuid.UUID) *ChannelHealth {
+	return &ChannelHealth{ChannelID: channelID, Circuit: CircuitClosed, Status: ChannelHealthHealthy}
+}
+
+// Allows reports whether items may be delivered to the channel now.
+func (h *ChannelHealth) Allows() bool {
+	return h.Circuit != CircuitOpen
+}
+
+// RecordFailure counts a failed probe or delivery. The circuit opens once
+// threshold failures in a row are reached, or at once when half-open; a
+// threshold of zero never opens it. Returns true when the circuit opened.
+func (h *ChannelHealth) RecordFailure(errText string, at time.Time, threshold int) bool {
+	h.ConsecutiveFailures++
+	h.LastError = errText
+	h.LastFailureAt = &at
+	if h.Circuit == CircuitOpen {
+		return false
+	}
+	if threshold > 0 && (h.Circuit == CircuitHalfOpen || h.ConsecutiveFailures >= threshold) {
+		h.Circuit = CircuitOpen
+		h.OpenedAt = &at
+		h.Summarize()
+		return true
+	}
+	h.Summarize()
+	return false
+}
+
+// RecordSuccess records a delivery that went through, closing a half-open
+// circuit. Returns false when there was nothing to change.
+func (h *ChannelHealth) RecordSuccess() bool {
+	if h.Circuit == CircuitClosed && h.ConsecutiveFailures == 0 {
+		return false
+	}
+	if h.Circuit == CircuitOpen {
+		// Only a probe or the cool-down half-opens a circuit
+		return false
+	}
+	h.Circuit = CircuitClosed
+	h.ConsecutiveFailures = 0
+	h.OpenedAt = nil
+	h.Summarize()
+	return true
+}
+
+// HalfOpen lets deliveries through an open circuit again to test the destination.
+func (h *ChannelHealth) HalfOpen() {
+	if h.Circuit == CircuitOpen {
+		h.Circuit = CircuitHalfOpen
+		h.Summarize()
+	}
+}
+
+// RecordProbe stores the outcome of a health probe. A failed probe counts
+// like a failed delivery; a successful one half-opens an open circuit and
+// clears the failures of a closed one. Returns true when the circuit opened.
+func (h *ChannelHealth) RecordProbe(probeErr error, at time.Time, threshold int) bool {
+	ok := probeErr == nil
+	h.LastProbeAt = &at
+	h.LastProbeOK = &ok
+	if !ok {
+		return h.RecordFailure(probeErr.Error(), at, threshold)
+	}
+	switch h.Circuit {
+	case CircuitOpen:
+		h.HalfOpen()
+	case CircuitClosed:
+		h.RecordSuccess()
+	}
+	h.Summarize()
+	return false
+}
+
+// Summarize sets Status from the circuit and failure count, e.g. on a
+// record loaded from the database.
+func (h *ChannelHealth) Summarize() {
+	switch {
+	case h.Circuit == CircuitOpen:
+		h.Status = ChannelHealthUnhealthy
+	case h.Circuit == CircuitHalfOpen || h.ConsecutiveFailures > 0:
+		h.Status = ChannelHealthDegraded
+	default:
+		h.Status = ChannelHealthHealthy
+	}
+}
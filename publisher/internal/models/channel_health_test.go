@@ -0,0 +1,56 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelHealth_OpensAfterThreshold(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	h := models.NewChannelHealth(uuid.New())
+
+	assert.False(t, h.RecordFailure("503", now, 3))
+	assert.False(t, h.RecordFailure("503", now, 3))
+	assert.Equal(t, models.ChannelHealthDegraded, h.Status)
+	assert.True(t, h.Allows())
+
+	assert.True(t, h.RecordFailure("503", now, 3), "the third failure in a row opens the circuit")
+	assert.False(t, h.Allows())
+	assert.Equal(t, models.ChannelHealthUnhealthy, h.Status)
+	assert.Equal(t, &now, h.OpenedAt)
+
+	assert.False(t, h.RecordSuccess(), "only a probe or the cool-down half-opens a circuit")
+}
+
+func TestChannelHealth_HalfOpenTrial(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	h := models.NewChannelHealth(uuid.New())
+	h.RecordFailure("timeout", now, 1)
+
+	assert.False(t, h.RecordProbe(nil, now.Add(time.Minute), 1))
+	assert.Equal(t, models.CircuitHalfOpen, h.Circuit)
+	assert.True(t, h.Allows())
+
+	assert.True(t, h.RecordFailure("timeout", now.Add(2*time.Minute), 5), "a half-open circuit reopens on the first failure")
+
+	h.HalfOpen()
+	assert.True(t, h.RecordSuccess())
+	assert.Equal(t, models.CircuitClosed, h.Circuit)
+	assert.Zero(t, h.ConsecutiveFailures)
+	assert.Equal(t, models.ChannelHealthHealthy, h.Status)
+}
+
+func TestChannelHealth_ZeroThresholdNeverOpens(t *testing.T) {
+	h := models.NewChannelHealth(uuid.New())
+	for range 10 {
+		assert.False(t, h.RecordProbe(errors.New("connection refused"), time.Now(), 0))
+	}
+	assert.True(t, h.Allows())
+	assert.Equal(t, 10, h.ConsecutiveFailures)
+	assert.Equal(t, "connection refused", h.LastError)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChannelHold marks a content item an editor pulled from a channel before it
+// was published. The router skips held items for that channel.
+type ChannelHold struct {
+	ID           uuid.UUID `db:"id"            json:"id"`
+	ChannelID    uuid.UUID `db:"channel_id"    json:"channel_id"`
+	ContentID    string    `db:"content_id"    json:"content_id"`
+	ContentTitle string    `db:"content_title" json:"content_title"`
+	Reason       string    `db:"reason"        json:"reason"`
+	CreatedAt    time.Time `db:"created_at"    json:"created_at"`
+}
+
+// ChannelHoldCreateRequest represents the request payload for pulling an item from a channel
+type ChannelHoldCreateRequest struct {
+	ContentID    string `binding:"required,min=1,max=255" json:"content_id"`
+	ContentTitle string `binding:"max=1000"               json:"content_title"`
+	Reason       string `binding:"max=1000"               json:"reason"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import "fmt"
+
+// Rate limit overflow modes: what happens to items routed once a channel has
+// used its quota
+const (
+	// RateLimitOverflowQueue holds overflow items and delivers them oldest
+	// first as the quota frees up
+	RateLimitOverflowQueue = "queue"
+	// RateLimitOverflowDropLowestQuality holds overflow items, delivers the
+	// highest quality first, and drops the lowest quality ones beyond max_queued
+	RateLimitOverflowDropLowestQuality = "drop_lowest_quality"
+	// RateLimitOverflowSummarize delivers what fits in the quota and rolls the
+	// rest into one digest post
+	RateLimitOverflowSummarize = "summarize"
+)
+
+// Rate limit bounds
+const (
+	// RateLimitDefaultMaxQueued is the drop_lowest_quality backlog when max_queued is not set
+	RateLimitDefaultMaxQueued = 100
+	// RateLimitMaxQueuedLimit caps max_queued
+	RateLimitMaxQueuedLimit = 1000
+)
+
+// RateLimitConfig caps how many items a channel receives per rolling hour and
+// day, for destinations whose APIs throttle us. Items routed to a rate-limited
+// channel wait in its schedule queue and are released within the quota;
+// Overflow decides what happens to items beyond it.
+type RateLimitConfig struct {
+	// MaxPerHour limits deliveries in the last 60 minutes (0 = no hourly limit)
+	MaxPerHour int `json:"max_per_hour,omitempty"`
+	// MaxPerDay limits deliveries in the last 24 hours (0 = no daily limit)
+	MaxPerDay int `json:"max_per_day,omitempty"`
+	// Overflow is queue (default), drop_lowest_quality, or summarize
+	Overflow string `json:"overflow,omitempty"`
+	// MaxQueued bounds the drop_lowest_quality backlog (default 100)
+	MaxQueued int `json:"max_queued,omitempty"`
+}
+
+// Validate checks the rate limit settings
+func (r *RateLimitConfig) Validate() error {
+	if r.MaxPerHour < 0 || r.MaxPerDay < 0 {
+		return fmt.Errorf("%w: rate_limit.max_per_hour and rate_limit.max_per_day cannot be negative", ErrInvalidChannelConfig)
+	}
+	if r.MaxPerHour == 0 && r.MaxPerDay == 0 {
+		return fmt.Errorf("%w: rate_limit needs max_per_hour or max_per_day", ErrInvalidChannelConfig)
+	}
+	switch r.Overflow {
+	case "", RateLimitOverflowQueue, RateLimitOverflowDropLowestQuality, RateLimitOverflowSummarize:
+	default:
+		return fmt.Errorf("%w: rate_limit.overflow must be one of %s, %s, %s", ErrInvalidChannelConfig,
+			RateLimitOverflowQueue, RateLimitOverflowDropLowestQuality, RateLimitOverflowSummarize)
+	}
+	if r.MaxQueued < 0 || r.MaxQueued > RateLimitMaxQueuedLimit {
+		return fmt.Errorf("%w: rate_limit.max_queued must be between 0 and %d", ErrInvalidChannelConfig, RateLimitMaxQueuedLimit)
+	}
+	return nil
+}
+
+// OverflowMode returns the overflow mode, defaulting to queue
+func (r *RateLimitConfig) OverflowMode() string {
+	if r.Overflow == "" {
+		return RateLimitOverflowQueue
+	}
+	return r.Overflow
+}
+
+// QueueLimit returns the drop_lowest_quality backlog size
+func (r *RateLimitConfig) QueueLimit() int {
+	if r.MaxQueued == 0 {
+		return RateLimitDefaultMaxQueued
+	}
+	return r.MaxQueued
+}
+
+// Remaining returns how many more items may be delivered given the number
+// delivered in the last hour and the last day
+func (r *RateLimitConfig) Remaining(lastHour, lastDay int) int {
+	remaining := -1
+	if r.MaxPerHour > 0 {
+		remaining = max(r.MaxPerHour-lastHour, 0)
+	}
+	if r.MaxPerDay > 0 {
+		dayRemaining := max(r.MaxPerDay-lastDay, 0)
+		if remaining < 0 || dayRemaining < remaining {
+			remaining = dayRemaining
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,51 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   models.RateLimitConfig
+		wantErr bool
+	}{
+		{name: "hourly limit", limit: models.RateLimitConfig{MaxPerHour: 10}},
+		{name: "both limits with summarize", limit: models.RateLimitConfig{MaxPerHour: 5, MaxPerDay: 40, Overflow: "summarize"}},
+		{name: "no limits", limit: models.RateLimitConfig{Overflow: "queue"}, wantErr: true},
+		{name: "negative limit", limit: models.RateLimitConfig{MaxPerDay: -1}, wantErr: true},
+		{name: "unknown overflow", limit: models.RateLimitConfig{MaxPerDay: 5, Overflow: "discard"}, wantErr: true},
+		{name: "backlog too large", limit: models.RateLimitConfig{MaxPerDay: 5, MaxQueued: 5000}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&models.ChannelConfig{RateLimit: &tt.limit}).Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, models.ErrInvalidChannelConfig), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRateLimitConfig_Remaining(t *testing.T) {
+	limit := &models.RateLimitConfig{MaxPerHour: 10, MaxPerDay: 50}
+
+	assert.Equal(t, 7, limit.Remaining(3, 20))
+	assert.Equal(t, 2, limit.Remaining(3, 48), "the daily limit is tighter")
+	assert.Equal(t, 0, limit.Remaining(12, 20), "over the limit never goes negative")
+	assert.Equal(t, 4, (&models.RateLimitConfig{MaxPerDay: 4}).Remaining(100, 0), "no hourly limit")
+}
+
+func TestRateLimitConfig_Defaults(t *testing.T) {
+	limit := &models.RateLimitConfig{MaxPerHour: 1}
+
+	assert.Equal(t, models.RateLimitOverflowQueue, limit.OverflowMode())
+	assert.Equal(t, models.RateLimitDefaultMaxQueued, limit.QueueLimit())
+}
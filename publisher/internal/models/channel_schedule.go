@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schedule limits
+const (
+	// hoursPerDay bounds start_hour and end_hour
+	hoursPerDay = 24
+	// ScheduleMaxIntervalSeconds caps min_interval_seconds at one day
+	ScheduleMaxIntervalSeconds = 86400
+)
+
+// scheduleDays maps the accepted day names to weekdays
+var scheduleDays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ScheduleConfig controls when routed items are delivered to a channel.
+// Items routed outside the publishing window, before the embargo lifts, or
+// faster than the minimum interval are queued and released later in the
+// order they were routed.
+type ScheduleConfig struct {
+	// Timezone is the IANA zone the hours and days are read in (default UTC)
+	Timezone string `json:"timezone,omitempty"`
+	// StartHour and EndHour bound the daily window [start, end); equal values
+	// publish all day and a start after the end wraps past midnight
+	StartHour int `json:"start_hour,omitempty"`
+	EndHour   int `json:"end_hour,omitempty"`
+	// Days limits publishing to these days (sun..sat); empty allows every day
+	Days []string `json:"days,omitempty"`
+	// EmbargoUntil holds every item until this time
+	EmbargoUntil *time.Time `json:"embargo_until,omitempty"`
+	// MinIntervalSeconds spreads bursts: at most one item per interval
+	MinIntervalSeconds int `json:"min_interval_seconds,omitempty"`
+}
+
+// Validate checks the schedule settings
+func (s *ScheduleConfig) Validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("%w: schedule.timezone %q is not a known time zone", ErrInvalidChannelConfig, s.Timezone)
+	}
+	if s.StartHour < 0 || s.StartHour >= hoursPerDay || s.EndHour < 0 || s.EndHour >= hoursPerDay {
+		return fmt.Errorf("%w: schedule.start_hour and schedule.end_hour must be between 0 and 23", ErrInvalidChannelConfig)
+	}
+	for _, day := range s.Days {
+		if _, ok := scheduleDays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("%w: schedule day %q must be one of sun, mon, tue, wed, thu, fri, sat",
+				ErrInvalidChannelConfig, day)
+		}
+	}
+	if s.MinIntervalSeconds < 0 || s.MinIntervalSeconds > ScheduleMaxIntervalSeconds {
+		return fmt.Errorf("%w: schedule.min_interval_seconds must be between 0 and %d",
+			ErrInvalidChannelConfig, ScheduleMaxIntervalSeconds)
+	}
+	return nil
+}
+
+// Allows reports whether the schedule permits delivery at t: the embargo has
+// lifted and t falls on an allowed day within the daily window.
+func (s *ScheduleConfig) Allows(t time.Time) bool {
+	if s.EmbargoUntil != nil && t.Before(*s.EmbargoUntil) {
+		return false
+	}
+
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+
+	if len(s.Days) > 0 && !s.allowsDay(local.Weekday()) {
+		return false
+	}
+
+	hour := local.Hour()
+	switch {
+	case s.StartHour == s.EndHour:
+		return true
+	case s.StartHour < s.EndHour:
+		return hour >= s.StartHour && hour < s.EndHour
+	default:
+		return hour >= s.StartHour || hour < s.EndHour
+	}
+}
+
+// allowsDay reports whether day is listed in Days
+func (s *ScheduleConfig) allowsDay(day time.Weekday) bool {
+	for _, name := range s.Days {
+		if scheduleDays[strings.ToLower(name)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Interval returns the minimum gap between deliveries
+func (s *ScheduleConfig) Interval() time.Duration {
+	return time.Duration(s.MinIntervalSeconds) * time.Second
+}
+
+// ScheduledItem is a routed item waiting for its channel's schedule or rate
+// limit to allow delivery. Payload is the content item as routed, so release does not depend
+// on Elasticsearch.
+type ScheduledItem struct {
+	ID        uuid.UUID `db:"id"         json:"id"`
+	ChannelID uuid.UUID `db:"channel_id" json:"channel_id"`
+	ContentID string    `db:"content_id" json:"content_id"`
+	Title     string    `db:"title"      json:"title"`
+	Payload   []byte    `db:"payload"    json:"-"`
+	QueuedAt  time.Time `db:"queued_at"  json:"queued_at"`
+	// QualityScore orders the backlog of drop_lowest_quality rate-limited channels
+	QualityScore int `db:"quality_score" json:"quality_score"`
+	// ContentHash identifies other sources' variants of the story in the queue
+	ContentHash string `db:"content_hash" json:"-"`
+}
@@ -0,0 +1,97 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule models.ScheduleConfig
+		wantErr  bool
+	}{
+		{name: "empty schedule", schedule: models.ScheduleConfig{}},
+		{
+			name: "full schedule",
+			schedule: models.ScheduleConfig{
+				Timezone: "America/Toronto", StartHour: 7, EndHour: 22,
+				Days: []string{"mon", "Fri"}, MinIntervalSeconds: 600,
+			},
+		},
+		{name: "unknown timezone", schedule: models.ScheduleConfig{Timezone: "Mars/Olympus"}, wantErr: true},
+		{name: "hour out of range", schedule: models.ScheduleConfig{EndHour: 24}, wantErr: true},
+		{name: "unknown day", schedule: models.ScheduleConfig{Days: []string{"monday"}}, wantErr: true},
+		{name: "negative interval", schedule: models.ScheduleConfig{MinIntervalSeconds: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&models.ChannelConfig{Schedule: &tt.schedule}).Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, models.ErrInvalidChannelConfig), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestScheduleConfig_Allows(t *testing.T) {
+	// Wednesday 2026-03-04 in Toronto (UTC-5)
+	toronto := func(hour int) time.Time {
+		return time.Date(2026, 3, 4, hour+5, 30, 0, 0, time.UTC)
+	}
+	embargo := toronto(12)
+
+	tests := []struct {
+		name     string
+		schedule models.ScheduleConfig
+		at       time.Time
+		want     bool
+	}{
+		{name: "no restrictions", schedule: models.ScheduleConfig{}, at: toronto(3), want: true},
+		{
+			name:     "inside daytime window",
+			schedule: models.ScheduleConfig{Timezone: "America/Toronto", StartHour: 7, EndHour: 22},
+			at:       toronto(7), want: true,
+		},
+		{
+			name:     "end hour is exclusive",
+			schedule: models.ScheduleConfig{Timezone: "America/Toronto", StartHour: 7, EndHour: 22},
+			at:       toronto(22),
+		},
+		{
+			name:     "overnight window after midnight",
+			schedule: models.ScheduleConfig{Timezone: "America/Toronto", StartHour: 22, EndHour: 6},
+			at:       toronto(2), want: true,
+		},
+		{
+			name:     "overnight window at midday",
+			schedule: models.ScheduleConfig{Timezone: "America/Toronto", StartHour: 22, EndHour: 6},
+			at:       toronto(12),
+		},
+		{
+			name:     "day not listed",
+			schedule: models.ScheduleConfig{Timezone: "America/Toronto", Days: []string{"mon", "tue"}},
+			at:       toronto(12),
+		},
+		{
+			name:     "day listed",
+			schedule: models.ScheduleConfig{Timezone: "America/Toronto", Days: []string{"WED"}},
+			at:       toronto(12), want: true,
+		},
+		{name: "under embargo", schedule: models.ScheduleConfig{EmbargoUntil: &embargo}, at: toronto(11)},
+		{name: "embargo lifted", schedule: models.ScheduleConfig{EmbargoUntil: &embargo}, at: toronto(12), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.schedule.Allows(tt.at))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// TemplateMaxLength caps each template's source so a channel config stays small
+const TemplateMaxLength = 8192
+
+// ellipsis ends text cut by the truncate template function
+const ellipsis = "…"
+
+// TemplateFuncs are the functions available to channel templates, beyond the
+// text/template builtins
+var TemplateFuncs = template.FuncMap{
+	// truncate cuts s to at most n characters, ending with an ellipsis when cut
+	"truncate": func(n int, s string) string {
+		if n <= 0 || utf8.RuneCountInString(s) <= n {
+			return s
+		}
+		return strings.TrimSpace(string([]rune(s)[:n])) + ellipsis
+	},
+	// join joins a list such as .Topics
+	"join": func(sep string, items []string) string {
+		return strings.Join(items, sep)
+	},
+	// escape HTML-escapes text placed inside HTML bodies
+	"escape": html.EscapeString,
+	// default returns fallback when value is empty
+	"default": func(fallback, value string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	},
+	// date formats a time with a Go layout, e.g. {{date "Jan 2, 2006" .PublishedDate}}
+	"date": func(layout string, t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	},
+}
+
+// TemplateConfig customizes the title, body, and summary a channel delivers.
+// Each is a Go text/template executed against the routed item (e.g. {{.Title}},
+// {{.Body}}, {{.URL}}, {{.Source}}, {{.OGDescription}}) plus {{.Channel}}, the
+// channel name. Empty templates leave the field as classified. The rendered
+// fields replace the originals in every delivery type: the Redis and webhook
+// payloads, WordPress posts, feed entries, and chat cards.
+type TemplateConfig struct {
+	// Title replaces the item title
+	Title string `json:"title,omitempty"`
+	// Body replaces the item body, e.g. to add an attribution line or a "Read more" link
+	Body string `json:"body,omitempty"`
+	// Summary replaces the OG description used as excerpt and card text
+	Summary string `json:"summary,omitempty"`
+}
+
+// Validate parses each template so syntax errors and unknown functions are
+// rejected when the channel is saved rather than at delivery
+func (t *TemplateConfig) Validate() error {
+	for _, field := range []struct{ name, text string }{
+		{"title", t.Title}, {"body", t.Body}, {"summary", t.Summary},
+	} {
+		if len(field.text) > TemplateMaxLength {
+			return fmt.Errorf("%w: template.%s must be at most %d bytes",
+				ErrInvalidChannelConfig, field.name, TemplateMaxLength)
+		}
+		if field.text == "" {
+			continue
+		}
+		if _, err := ParseChannelTemplate(field.name, field.text); err != nil {
+			return fmt.Errorf("%w: template.%s: %w", ErrInvalidChannelConfig, field.name, err)
+		}
+	}
+	return nil
+}
+
+// ParseChannelTemplate parses one channel template with TemplateFuncs
+func ParseChannelTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(TemplateFuncs).Parse(text)
+}
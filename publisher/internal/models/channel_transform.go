@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TransformMaxFields caps the rename, drop, and topic_tags entries of a channel
+const TransformMaxFields = 100
+
+// transformReservedFields identify the item and channel to subscribers, so
+// transforms cannot drop or rename them
+var transformReservedFields = map[string]bool{
+	"id":        true,
+	"publisher": true,
+}
+
+// TransformConfig reshapes what a channel delivers so each destination gets
+// the fields it expects. Title and body rules apply to every delivery type;
+// field rules (topic_tags, rename, drop) reshape the JSON payload of redis and
+// webhook channels. Transforms run after the channel's templates.
+type TransformConfig struct {
+	// TitlePrefix is prepended to the title, e.g. "[Sudbury] "
+	TitlePrefix string `json:"title_prefix,omitempty"`
+	// TruncateBodyWords cuts the body to at most this many words; 0 keeps it whole
+	TruncateBodyWords int `json:"truncate_body_words,omitempty"`
+	// TopicTags maps topics to the destination's tags, sent as the payload's
+	// "tags" field; topics without a mapping are left out
+	TopicTags map[string]string `json:"topic_tags,omitempty"`
+	// Rename maps payload field names to the names the destination expects
+	Rename map[string]string `json:"rename,omitempty"`
+	// Drop lists payload fields the destination should not receive
+	Drop []string `json:"drop,omitempty"`
+}
+
+// Validate checks the transform settings
+func (t *TransformConfig) Validate() error {
+	if t.TruncateBodyWords < 0 {
+		return fmt.Errorf("%w: transform.truncate_body_words must not be negative", ErrInvalidChannelConfig)
+	}
+	if len(t.Rename)+len(t.Drop)+len(t.TopicTags) > TransformMaxFields {
+		return fmt.Errorf("%w: transform allows at most %d rename, drop, and topic_tags entries",
+			ErrInvalidChannelConfig, TransformMaxFields)
+	}
+	for topic, tag := range t.TopicTags {
+		if strings.TrimSpace(topic) == "" || strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("%w: transform.topic_tags entries need a topic and a tag", ErrInvalidChannelConfig)
+		}
+	}
+
+	dropped := make(map[string]bool, len(t.Drop))
+	for _, field := range t.Drop {
+		if field == "" || transformReservedFields[field] {
+			return fmt.Errorf("%w: transform cannot drop field %q", ErrInvalidChannelConfig, field)
+		}
+		dropped[field] = true
+	}
+
+	targets := make(map[string]string, len(t.Rename))
+	for from, to := range t.Rename {
+		if from == "" || to == "" || transformReservedFields[from] || transformReservedFields[to] {
+			return fmt.Errorf("%w: transform cannot rename field %q to %q", ErrInvalidChannelConfig, from, to)
+		}
+		if dropped[from] {
+			return fmt.Errorf("%w: transform field %q is both renamed and dropped", ErrInvalidChannelConfig, from)
+		}
+		if other, taken := targets[to]; taken {
+			return fmt.Errorf("%w: transform renames both %q and %q to %q", ErrInvalidChannelConfig, other, from, to)
+		}
+		targets[to] = from
+	}
+	return nil
+}
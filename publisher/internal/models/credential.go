@@ -0,0 +1,104 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Credential kinds
+const (
+	CredentialKindToken      = "token"
+	CredentialKindPassword   = "password"
+	CredentialKindSecret     = "secret"
+	CredentialKindWebhookURL = "webhook_url"
+)
+
+// credentialHintLength is how many trailing characters of a value are kept in
+// clear for masking; shorter values are masked entirely.
+const (
+	credentialHintLength   = 4
+	credentialMinHintValue = 12
+	credentialMask         = "****"
+)
+
+// credentialName matches credential names, e.g. "partner-wp" or "acme.slack"
+var credentialName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,99}$`)
+
+// Credential is a channel secret (Drupal token, webhook signing secret,
+// WordPress application password, chat webhook URL) stored encrypted at rest.
+// Channels reference it by name; its value is only decrypted for delivery and
+// never returned by the API.
+type Credential struct {
+	ID          uuid.UUID `db:"id"          json:"id"`
+	Name        string    `db:"name"        json:"name"`
+	Tenant      string    `db:"tenant"      json:"tenant"`
+	Kind        string    `db:"kind"        json:"kind"`
+	Description string    `db:"description" json:"description"`
+	Ciphertext  []byte    `db:"ciphertext"  json:"-"`
+	// KeyID identifies the encryption key the ciphertext was sealed with
+	KeyID string `db:"key_id" json:"key_id"`
+	// Hint is the clear tail of the value used to build MaskedValue
+	Hint        string     `db:"hint"       json:"-"`
+	MaskedValue string     `db:"-"          json:"masked_value"`
+	Version     int        `db:"version"    json:"version"`
+	RotatedAt   *time.Time `db:"rotated_at" json:"rotated_at,omitempty"`
+	CreatedBy   string     `db:"created_by" json:"created_by"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// Mask sets MaskedValue from the stored hint
+func (c *Credential) Mask() {
+	c.MaskedValue = credentialMask + c.Hint
+}
+
+// CredentialHint returns the clear tail kept for masking a value. Short values
+// keep nothing so the hint cannot give most of a secret away.
+func CredentialHint(value string) string {
+	if len(value) < credentialMinHintValue {
+		return ""
+	}
+	return value[len(value)-credentialHintLength:]
+}
+
+// CredentialCreateRequest stores a new credential
+type CredentialCreateRequest struct {
+	Name        string `binding:"required" json:"name"`
+	Tenant      string `json:"tenant"`
+	Kind        string `binding:"required" json:"kind"`
+	Description string `json:"description"`
+	Value       string `binding:"required" json:"value"`
+}
+
+// Validate checks the name and kind
+func (r *CredentialCreateRequest) Validate() error {
+	if !credentialName.MatchString(r.Name) {
+		return errors.New("name must be lowercase letters, digits, '.', '_' or '-' (at most 100)")
+	}
+	if !IsValidCredentialKind(r.Kind) {
+		return errors.New("kind must be token, password, secret, or webhook_url")
+	}
+	if strings.TrimSpace(r.Value) == "" {
+		return errors.New("value must not be blank")
+	}
+	return nil
+}
+
+// CredentialRotateRequest replaces a credential's value
+type CredentialRotateRequest struct {
+	Value string `binding:"required" json:"value"`
+}
+
+// IsValidCredentialKind reports whether kind is a supported credential kind
+func IsValidCredentialKind(kind string) bool {
+	switch kind {
+	case CredentialKindToken, CredentialKindPassword, CredentialKindSecret, CredentialKindWebhookURL:
+		return true
+	default:
+		return false
+	}
+}
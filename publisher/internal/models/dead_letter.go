@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dead letter statuses. Retrying items are delivered again when next_retry_at
+// passes; dead items have used every attempt, or belong to a channel that was
+// disabled or deleted, and wait for a replay.
+const (
+	DeadLetterStatusRetrying = "retrying"
+	DeadLetterStatusDead     = "dead"
+)
+
+// DeadLetter is an item the router failed to deliver to a channel. Payload is
+// the content item as routed. ChannelID is nil for Redis channels produced by
+// the built-in routing layers.
+type DeadLetter struct {
+	ID          uuid.UUID  `db:"id"            json:"id"`
+	ChannelID   *uuid.UUID `db:"channel_id"    json:"channel_id,omitempty"`
+	ChannelName string     `db:"channel_name"  json:"channel_name"`
+	ContentID   string     `db:"content_id"    json:"content_id"`
+	Title       string     `db:"title"         json:"title"`
+	Payload     []byte     `db:"payload"       json:"-"`
+	LastError   string     `db:"last_error"    json:"last_error"`
+	Attempts    int        `db:"attempts"      json:"attempts"`
+	Status      string     `db:"status"        json:"status"`
+	NextRetryAt *time.Time `db:"next_retry_at" json:"next_retry_at,omitempty"`
+	CreatedAt   time.Time  `db:"created_at"    json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"    json:"updated_at"`
+}
+
+// DeadLetterFilter scopes a dead letter listing
+type DeadLetterFilter struct {
+	ChannelName string `binding:"omitempty,max=255"             form:"channel"`
+	Status      string `binding:"omitempty,oneof=retrying dead" form:"status"`
+	Limit       int    `binding:"omitempty,min=1,max=500"       form:"limit"` // Default 50
+	Offset      int    `binding:"omitempty,min=0"               form:"offset"`
+}
+
+// DeadLetterReplayRequest scopes a bulk replay. An empty channel replays
+// every dead letter.
+type DeadLetterReplayRequest struct {
+	ChannelName string `binding:"omitempty,max=255" json:"channel"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "github.com/google/uuid"
+
+// DrupalGroup is a group listed by the Drupal site's JSON:API
+type DrupalGroup struct {
+	ID         string `json:"id"`          // JSON:API UUID
+	InternalID int    `json:"internal_id"` // drupal_internal__id
+	Type       string `json:"type"`        // Group bundle, e.g. "community"
+	Label      string `json:"label"`
+}
+
+// DrupalChannelProposal offers a new channel for a group without one.
+// Channel is ready to create: disabled, with the group ID prefilled.
+type DrupalChannelProposal struct {
+	Group   DrupalGroup          `json:"group"`
+	Channel ChannelCreateRequest `json:"channel"`
+}
+
+// DrupalLinkedChannel is a channel whose config.drupal.group_id matches a
+// discovered group. LabelChanged is set when the group was renamed in Drupal.
+type DrupalLinkedChannel struct {
+	Group        DrupalGroup `json:"group"`
+	ChannelID    uuid.UUID   `json:"channel_id"`
+	ChannelName  string      `json:"channel_name"`
+	LabelChanged bool        `json:"label_changed"`
+}
+
+// DrupalOrphanedChannel is a channel linked to a group the Drupal site no longer lists
+type DrupalOrphanedChannel struct {
+	ChannelID   uuid.UUID `json:"channel_id"`
+	ChannelName string    `json:"channel_name"`
+	GroupID     string    `json:"group_id"`
+}
+
+// DrupalSyncPlan compares the Drupal site's groups with the channels table
+type DrupalSyncPlan struct {
+	Missing  []DrupalChannelProposal `json:"missing"`
+	Linked   []DrupalLinkedChannel   `json:"linked"`
+	Orphaned []DrupalOrphanedChannel `json:"orphaned"`
+}
+
+// DrupalProvisionRequest selects the proposed channels to create by group ID
+type DrupalProvisionRequest struct {
+	GroupIDs []string `binding:"required,min=1,max=500,dive,uuid" json:"group_ids"`
+}
@@ -18,6 +18,8 @@ type PublishHistory struct {
 	PublishedAt  time.Time      `db:"published_at"  json:"published_at"`
 	QualityScore int            `db:"quality_score" json:"quality_score"`
 	Topics       pq.StringArray `db:"topics"        json:"topics"`
+	RemoteID     string         `db:"remote_id"     json:"remote_id,omitempty"` // Post or node created on a remote channel (WordPress, Drupal)
+	RetractedAt  *time.Time     `db:"retracted_at"  json:"retracted_at,omitempty"`
 }
 
 // PublishHistoryCreateRequest represents the data needed to create a publish history entry
@@ -29,6 +31,9 @@ type PublishHistoryCreateRequest struct {
 	ChannelName  string     `binding:"required"          json:"channel_name"`
 	QualityScore int        `json:"quality_score"`
 	Topics       []string   `json:"topics"`
+	RemoteID     string     `json:"remote_id,omitempty"`
+	// ContentHash identifies the story across sources (see router.contentHash)
+	ContentHash string `json:"content_hash,omitempty"`
 }
 
 // PublishHistoryFilter represents filter criteria for querying publish history
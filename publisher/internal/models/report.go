@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChannelReport summarises one channel's output over a period. It backs the
+// weekly editorial report (GET /api/v1/channels/:id/reports/weekly).
+type ChannelReport struct {
+	ChannelID    uuid.UUID `json:"channel_id"`
+	ChannelName  string    `json:"channel_name"`
+	RedisChannel string    `json:"redis_channel"`
+	ChannelType  string    `json:"channel_type"`
+	PeriodStart  time.Time `json:"period_start"`
+	PeriodEnd    time.Time `json:"period_end"`
+	GeneratedAt  time.Time `json:"generated_at"`
+
+	Published int          `json:"published"`
+	ByTopic   []TopicCount `json:"by_topic"`
+	ByDay     []DayCount   `json:"by_day"`
+
+	// TopClicked is empty when ClicksAvailable is false (click-tracker not configured or unreachable)
+	TopClicked      []ClickedItem `json:"top_clicked"`
+	ClicksAvailable bool          `json:"clicks_available"`
+
+	Failures       int             `json:"failures"`
+	FailureReasons []FailureReason `json:"failure_reasons"`
+}
+
+// TopicCount is the number of items published with a topic
+type TopicCount struct {
+	Topic string `db:"topic" json:"topic"`
+	Count int    `db:"count" json:"count"`
+}
+
+// DayCount is the number of items published on a UTC day
+type DayCount struct {
+	Day   time.Time `db:"day"   json:"day"`
+	Count int       `db:"count" json:"count"`
+}
+
+// ClickedItem is a published item with its clicks in the report period
+type ClickedItem struct {
+	ContentID      string `json:"content_id"`
+	Title          string `json:"title"`
+	URL            string `json:"url"`
+	Clicks         int64  `json:"clicks"`
+	UniqueSessions int64  `json:"unique_sessions"`
+}
+
+// FailureReason groups delivery failures with the same error
+type FailureReason struct {
+	Error    string    `db:"error"     json:"error"`
+	Count    int       `db:"count"     json:"count"`
+	LastSeen time.Time `db:"last_seen" json:"last_seen"`
+}
+
+// DeliveryFailure records an item the router could not deliver to a channel
+type DeliveryFailure struct {
+	ChannelID    *uuid.UUID `db:"channel_id"`
+	ChannelName  string     `db:"channel_name"`
+	ContentID    string     `db:"content_id"`
+	ContentTitle string     `db:"content_title"`
+	Error        string     `db:"error"`
+}
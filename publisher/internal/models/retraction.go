@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Retraction statuses. Pending retractions are processed on the router's next
+// poll; done means every channel the item went to was handled; failed means a
+// channel still refused after the last attempt.
+const (
+	RetractionStatusPending = "pending"
+	RetractionStatusDone    = "done"
+	RetractionStatusFailed  = "failed"
+)
+
+// Per-channel retraction outcomes
+const (
+	// RetractionOutcomeRetracted means the item was taken down (or a retraction
+	// message was sent, for Redis and webhook channels).
+	RetractionOutcomeRetracted = "retracted"
+	// RetractionOutcomeUnsupported means the channel type cannot take items down (chat).
+	RetractionOutcomeUnsupported = "unsupported"
+	// RetractionOutcomeWaiting means the channel is disabled; it is retracted once re-enabled.
+	RetractionOutcomeWaiting = "waiting"
+	// RetractionOutcomeFailed means the channel returned an error; it is retried.
+	RetractionOutcomeFailed = "failed"
+)
+
+// Retraction is a request to take a content item down from every channel it
+// was published to, e.g. because the source removed it or it was misclassified.
+type Retraction struct {
+	ID            uuid.UUID          `db:"id"              json:"id"`
+	ContentID     string             `db:"content_id"      json:"content_id"`
+	Reason        string             `db:"reason"          json:"reason"`
+	RequestedBy   string             `db:"requested_by"    json:"requested_by"`
+	Status        string             `db:"status"          json:"status"`
+	Attempts      int                `db:"attempts"        json:"attempts"`
+	LastError     string             `db:"last_error"      json:"last_error,omitempty"`
+	Results       []RetractionResult `db:"-"               json:"results"`
+	ResultsJSON   []byte             `db:"results"         json:"-"`
+	NextAttemptAt time.Time          `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time          `db:"created_at"      json:"created_at"`
+	UpdatedAt     time.Time          `db:"updated_at"      json:"updated_at"`
+}
+
+// ParseResults decodes ResultsJSON into Results
+func (r *Retraction) ParseResults() error {
+	r.Results = []RetractionResult{}
+	if len(r.ResultsJSON) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.ResultsJSON, &r.Results)
+}
+
+// RetractionResult is the outcome of a retraction on one channel
+type RetractionResult struct {
+	ChannelName string `json:"channel_name"`
+	Outcome     string `json:"outcome"`
+	Error       string `json:"error,omitempty"`
+}
+
+// RetractionRequest asks for a content item to be retracted
+type RetractionRequest struct {
+	ContentID string `binding:"required,max=255" json:"content_id"`
+	Reason    string `binding:"max=1000"         json:"reason"`
+}
+
+// RetractionFilter scopes a retraction listing
+type RetractionFilter struct {
+	Status string `binding:"omitempty,oneof=pending done failed" form:"status"`
+	Limit  int    `binding:"omitempty,min=1,max=500"             form:"limit"` // Default 50
+	Offset int    `binding:"omitempty,min=0"                     form:"offset"`
+}
@@ -0,0 +1,479 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxRouteFilterLength bounds a filter expression so one channel cannot make
+// every routed item expensive to match.
+const MaxRouteFilterLength = 2000
+
+// ErrInvalidRouteFilter is returned for a filter expression that does not parse.
+var ErrInvalidRouteFilter = errors.New("invalid filter")
+
+// Route filter fields.
+const (
+	FilterFieldTopics           = "topics"
+	FilterFieldSource           = "source"
+	FilterFieldContentType      = "content_type"
+	FilterFieldQualityScore     = "quality_score"
+	FilterFieldWordCount        = "word_count"
+	FilterFieldCrimeRelevance   = "crime.relevance"
+	FilterFieldLocationCity     = "location.city"
+	FilterFieldLocationProvince = "location.province"
+)
+
+// filterFieldKinds says how each field compares: as a list of strings, a
+// string, or an integer.
+var filterFieldKinds = map[string]filterKind{
+	FilterFieldTopics:           filterKindList,
+	FilterFieldSource:           filterKindString,
+	FilterFieldContentType:      filterKindString,
+	FilterFieldQualityScore:     filterKindNumber,
+	FilterFieldWordCount:        filterKindNumber,
+	FilterFieldCrimeRelevance:   filterKindString,
+	FilterFieldLocationCity:     filterKindString,
+	FilterFieldLocationProvince: filterKindString,
+}
+
+type filterKind int
+
+const (
+	filterKindString filterKind = iota
+	filterKindList
+	filterKindNumber
+)
+
+// RouteFields are the values of a content item a filter is evaluated against.
+type RouteFields struct {
+	Topics           []string `json:"topics"`
+	Source           string   `json:"source"`
+	ContentType      string   `json:"content_type"`
+	QualityScore     int      `json:"quality_score"`
+	WordCount        int      `json:"word_count"`
+	CrimeRelevance   string   `json:"crime.relevance"`
+	LocationCity     string   `json:"location.city"`
+	LocationProvince string   `json:"location.province"`
+}
+
+// RouteFilterFields returns the fields a filter can use, sorted.
+func RouteFilterFields() []string {
+	return slices.Sorted(maps.Keys(filterFieldKinds))
+}
+
+// RouteFilterTestRequest checks a filter expression and, optionally,
+// evaluates it against classified content items.
+type RouteFilterTestRequest struct {
+	Filter     string   `binding:"required"         json:"filter"`
+	ContentIDs []string `binding:"omitempty,max=50" json:"content_ids"`
+}
+
+// RouteFilter is a parsed filter expression, e.g.
+//
+//	topics IN (crime, court) AND NOT source = "tabloid_example"
+//	  AND (location.city = sudbury OR word_count >= 400)
+//
+// Comparisons are =, !=, <, <=, >, >= and IN (...), combined with AND, OR,
+// NOT and parentheses; AND binds tighter than OR. For topics, = and IN mean
+// "has the topic" and != means "does not have it". String comparisons ignore
+// case; values may be bare words or quoted.
+type RouteFilter struct {
+	root filterNode
+}
+
+// ParseRouteFilter parses a filter expression.
+func ParseRouteFilter(expr string) (*RouteFilter, error) {
+	if len(expr) > MaxRouteFilterLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidRouteFilter, MaxRouteFilterLength)
+	}
+	tokens, err := lexRouteFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, tok.errorf("unexpected %q", tok.text)
+	}
+	return &RouteFilter{root: root}, nil
+}
+
+// Matches reports whether the fields satisfy the filter.
+func (f *RouteFilter) Matches(fields *RouteFields) bool {
+	return f.root.eval(fields)
+}
+
+// String returns the expression in canonical form: keywords upper case,
+// values quoted, and parentheses only where precedence needs them.
+func (f *RouteFilter) String() string {
+	return f.root.String()
+}
+
+// filterNode is a node of a parsed filter.
+type filterNode interface {
+	eval(fields *RouteFields) bool
+	String() string
+}
+
+type filterAnd struct{ left, right filterNode }
+
+func (n filterAnd) eval(fields *RouteFields) bool { return n.left.eval(fields) && n.right.eval(fields) }
+func (n filterAnd) String() string                { return groupOr(n.left) + " AND " + groupOr(n.right) }
+
+type filterOr struct{ left, right filterNode }
+
+func (n filterOr) eval(fields *RouteFields) bool { return n.left.eval(fields) || n.right.eval(fields) }
+func (n filterOr) String() string                { return n.left.String() + " OR " + n.right.String() }
+
+type filterNot struct{ operand filterNode }
+
+func (n filterNot) eval(fields *RouteFields) bool { return !n.operand.eval(fields) }
+
+func (n filterNot) String() string {
+	if _, ok := n.operand.(filterComparison); ok {
+		return "NOT " + n.operand.String()
+	}
+	if _, ok := n.operand.(filterNot); ok {
+		return "NOT " + n.operand.String()
+	}
+	return "NOT (" + n.operand.String() + ")"
+}
+
+// groupOr parenthesizes an OR operand of AND.
+func groupOr(n filterNode) string {
+	if _, ok := n.(filterOr); ok {
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+// filterComparison compares one field with one or more values.
+type filterComparison struct {
+	field  string
+	op     string
+	values []string
+	number int
+}
+
+func (n filterComparison) eval(fields *RouteFields) bool {
+	switch n.field {
+	case FilterFieldTopics:
+		has := slices.ContainsFunc(fields.Topics, func(topic string) bool { return n.anyValue(topic) })
+		return has == (n.op != "!=")
+	case FilterFieldQualityScore:
+		return n.compareNumber(fields.QualityScore)
+	case FilterFieldWordCount:
+		return n.compareNumber(fields.WordCount)
+	case FilterFieldSource:
+		return n.compareString(fields.Source)
+	case FilterFieldContentType:
+		return n.compareString(fields.ContentType)
+	case FilterFieldCrimeRelevance:
+		return n.compareString(fields.CrimeRelevance)
+	case FilterFieldLocationCity:
+		return n.compareString(fields.LocationCity)
+	case FilterFieldLocationProvince:
+		return n.compareString(fields.LocationProvince)
+	default:
+		return false
+	}
+}
+
+func (n filterComparison) anyValue(value string) bool {
+	return slices.ContainsFunc(n.values, func(want string) bool { return strings.EqualFold(want, value) })
+}
+
+func (n filterComparison) compareString(value string) bool {
+	return n.anyValue(value) == (n.op != "!=")
+}
+
+func (n filterComparison) compareNumber(value int) bool {
+	switch n.op {
+	case "=":
+		return value == n.number
+	case "!=":
+		return value != n.number
+	case "<":
+		return value < n.number
+	case "<=":
+		return value <= n.number
+	case ">":
+		return value > n.number
+	default: // ">="
+		return value >= n.number
+	}
+}
+
+func (n filterComparison) String() string {
+	if filterFieldKinds[n.field] == filterKindNumber {
+		return n.field + " " + n.op + " " + strconv.Itoa(n.number)
+	}
+	quoted := make([]string, 0, len(n.values))
+	for _, value := range n.values {
+		quoted = append(quoted, quoteFilterValue(value))
+	}
+	if n.op == "IN" {
+		return n.field + " IN (" + strings.Join(quoted, ", ") + ")"
+	}
+	return n.field + " " + n.op + " " + quoted[0]
+}
+
+// quoteFilterValue quotes a value so it lexes back unchanged; values holding
+// a double quote use single quotes.
+func quoteFilterValue(value string) string {
+	if strings.Contains(value, `"`) {
+		return "'" + value + "'"
+	}
+	return `"` + value + `"`
+}
+
+// Filter tokens.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+	pos  int // 1-based column
+}
+
+// keyword reports whether the token is the given keyword, ignoring case.
+func (t filterToken) keyword(word string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, word)
+}
+
+func (t filterToken) errorf(format string, args ...any) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("%w: %s at end of expression", ErrInvalidRouteFilter, fmt.Sprintf(format, args...))
+	}
+	return fmt.Errorf("%w: %s at column %d", ErrInvalidRouteFilter, fmt.Sprintf(format, args...), t.pos)
+}
+
+// lexRouteFilter splits an expression into tokens.
+func lexRouteFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokenLParen, text: "(", pos: start + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokenRParen, text: ")", pos: start + 1})
+			i++
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: tokenComma, text: ",", pos: start + 1})
+			i++
+		case r == '"' || r == '\'':
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string at column %d", ErrInvalidRouteFilter, start+1)
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: string(runes[start+1 : i]), pos: start + 1})
+			i++
+		case r == '=' || r == '<' || r == '>' || r == '!':
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "!" {
+				return nil, fmt.Errorf("%w: use NOT or != instead of ! at column %d", ErrInvalidRouteFilter, start+1)
+			}
+			if op == "==" {
+				op = "="
+			}
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: op, pos: start + 1})
+		case isFilterWordRune(r):
+			for i < len(runes) && isFilterWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: tokenWord, text: string(runes[start:i]), pos: start + 1})
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at column %d", ErrInvalidRouteFilter, r, start+1)
+		}
+	}
+	return append(tokens, filterToken{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+func isFilterWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-' || r == ':'
+}
+
+// filterParser is a recursive descent parser over the tokens of an expression.
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken { return p.tokens[p.pos] }
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr parses and-expressions joined by OR.
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword("OR") {
+		p.next()
+		right, rightErr := p.parseAnd()
+		if rightErr != nil {
+			return nil, rightErr
+		}
+		left = filterOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses unary expressions joined by AND.
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword("AND") {
+		p.next()
+		right, rightErr := p.parseNot()
+		if rightErr != nil {
+			return nil, rightErr
+		}
+		left = filterAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseNot parses NOT, a parenthesized expression, or a comparison.
+func (p *filterParser) parseNot() (filterNode, error) {
+	tok := p.peek()
+	switch {
+	case tok.keyword("NOT"):
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{operand: operand}, nil
+	case tok.kind == tokenLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, closing.errorf("expected )")
+		}
+		return inner, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+// parseComparison parses "field op value" or "field IN (values)".
+func (p *filterParser) parseComparison() (filterNode, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenWord {
+		return nil, fieldTok.errorf("expected a field")
+	}
+	field := strings.ToLower(fieldTok.text)
+	kind, ok := filterFieldKinds[field]
+	if !ok {
+		return nil, fieldTok.errorf("unknown field %q", fieldTok.text)
+	}
+
+	opTok := p.next()
+	cmp := filterComparison{field: field}
+	switch {
+	case opTok.keyword("IN"):
+		if kind == filterKindNumber {
+			return nil, opTok.errorf("%s does not support IN", field)
+		}
+		cmp.op = "IN"
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		cmp.values = values
+		return cmp, nil
+	case opTok.kind == tokenOperator:
+		cmp.op = opTok.text
+	default:
+		return nil, opTok.errorf("expected an operator after %s", field)
+	}
+
+	valueTok := p.next()
+	if valueTok.kind != tokenWord && valueTok.kind != tokenString {
+		return nil, valueTok.errorf("expected a value")
+	}
+	if kind == filterKindNumber {
+		number, err := strconv.Atoi(valueTok.text)
+		if err != nil {
+			return nil, valueTok.errorf("%s needs a whole number, got %q", field, valueTok.text)
+		}
+		cmp.number = number
+		return cmp, nil
+	}
+	if cmp.op != "=" && cmp.op != "!=" {
+		return nil, opTok.errorf("%s only supports =, != and IN", field)
+	}
+	cmp.values = []string{valueTok.text}
+	return cmp, nil
+}
+
+// parseList parses "(value, value, ...)".
+func (p *filterParser) parseList() ([]string, error) {
+	if open := p.next(); open.kind != tokenLParen {
+		return nil, open.errorf("expected ( after IN")
+	}
+	var values []string
+	for {
+		tok := p.next()
+		if tok.kind != tokenWord && tok.kind != tokenString {
+			return nil, tok.errorf("expected a value")
+		}
+		values = append(values, tok.text)
+
+		sep := p.next()
+		switch sep.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return values, nil
+		default:
+			return nil, sep.errorf("expected , or )")
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteFilter_Matches(t *testing.T) {
+	fields := &models.RouteFields{
+		Topics:         []string{"crime", "local_news"},
+		Source:         "sudbury_star",
+		ContentType:    "article",
+		QualityScore:   72,
+		WordCount:      640,
+		CrimeRelevance: "core_street_crime",
+		LocationCity:   "sudbury",
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`topics = crime`, true},
+		{`topics != crime`, false},
+		{`topics IN (court, "Local_News")`, true},
+		{`crime.relevance = core_street_crime AND location.city = Sudbury`, true},
+		{`source = 'tabloid' OR word_count >= 600`, true},
+		{`NOT source IN (sudbury_star, tabloid)`, false},
+		{`quality_score > 80 OR topics = crime AND word_count < 100`, false},
+		{`(quality_score > 80 OR topics = crime) AND word_count >= 100`, true},
+		{`location.province = ON`, false},
+		{`not not content_type == article`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filter, err := models.ParseRouteFilter(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.Matches(fields))
+		})
+	}
+}
+
+func TestParseRouteFilter_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantMsg string
+	}{
+		{`topic = crime`, `unknown field "topic" at column 1`},
+		{`word_count >= many`, `word_count needs a whole number, got "many" at column 15`},
+		{`source > a`, `source only supports =, != and IN at column 8`},
+		{`quality_score IN (1, 2)`, `quality_score does not support IN at column 15`},
+		{`topics = crime AND`, `expected a field at end of expression`},
+		{`(topics = crime`, `expected ) at end of expression`},
+		{`topics IN (crime court)`, `expected , or ) at column 18`},
+		{`source = "open`, `unterminated string at column 10`},
+		{`!topics = crime`, `use NOT or != instead of ! at column 1`},
+		{`topics = crime source = x`, `unexpected "source" at column 16`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := models.ParseRouteFilter(tt.expr)
+			require.ErrorIs(t, err, models.ErrInvalidRouteFilter)
+			assert.Equal(t, "invalid filter: "+tt.wantMsg, err.Error())
+		})
+	}
+}
+
+func TestRouteFilter_StringRoundTrips(t *testing.T) {
+	filter, err := models.ParseRouteFilter(
+		`not (topics in (crime,court) or source = 'say "hi"') and (word_count>=400 or not location.city=sudbury)`)
+	require.NoError(t, err)
+
+	canonical := `NOT (topics IN ("crime", "court") OR source = 'say "hi"') AND ` +
+		`(word_count >= 400 OR NOT location.city = "sudbury")`
+	assert.Equal(t, canonical, filter.String())
+
+	reparsed, err := models.ParseRouteFilter(filter.String())
+	require.NoError(t, err)
+	assert.Equal(t, canonical, reparsed.String())
+}
+
+func TestRules_Filter(t *testing.T) {
+	rules := models.Rules{MinQualityScore: 50, Filter: `location.city = sudbury`}
+	require.NoError(t, rules.Validate())
+
+	assert.True(t, rules.Matches(&models.RouteFields{QualityScore: 60, LocationCity: "sudbury"}))
+	assert.False(t, rules.Matches(&models.RouteFields{QualityScore: 60, LocationCity: "timmins"}))
+	assert.False(t, rules.Matches(&models.RouteFields{QualityScore: 40, LocationCity: "sudbury"}))
+
+	broken := models.Rules{Filter: `location.city =`}
+	require.ErrorIs(t, broken.Validate(), models.ErrInvalidRouteFilter)
+	assert.False(t, broken.Matches(&models.RouteFields{LocationCity: "sudbury"}), "a broken filter matches nothing")
+}
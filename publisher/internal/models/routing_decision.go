@@ -0,0 +1,46 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Routing decision statuses. A pending decision is being delivered; an
+// in_doubt one was pending when the router stopped, so whether the channel
+// received the item is unknown.
+const (
+	RoutingDecisionPending   = "pending"
+	RoutingDecisionDelivered = "delivered"
+	RoutingDecisionFailed    = "failed"
+	RoutingDecisionInDoubt   = "in_doubt"
+)
+
+// RoutingDecision is the idempotency record of delivering one content item to
+// one channel. It is claimed before delivery so a router restarted mid-batch
+// cannot deliver the same item twice.
+type RoutingDecision struct {
+	ID             uuid.UUID  `db:"id"              json:"id"`
+	IdempotencyKey string     `db:"idempotency_key" json:"idempotency_key"`
+	ContentID      string     `db:"content_id"      json:"content_id"`
+	ChannelID      *uuid.UUID `db:"channel_id"      json:"channel_id,omitempty"`
+	ChannelName    string     `db:"channel_name"    json:"channel_name"`
+	Status         string     `db:"status"          json:"status"`
+	Attempts       int        `db:"attempts"        json:"attempts"`
+	ClaimedAt      time.Time  `db:"claimed_at"      json:"claimed_at"`
+	UpdatedAt      time.Time  `db:"updated_at"      json:"updated_at"`
+}
+
+// RoutingDecisionKey returns the idempotency key of delivering contentID to a
+// channel: the hex SHA-256 of content ID, channel ID (empty for built-in
+// routes), and channel name.
+func RoutingDecisionKey(contentID string, channelID *uuid.UUID, channelName string) string {
+	var id string
+	if channelID != nil {
+		id = channelID.String()
+	}
+	sum := sha256.Sum256([]byte(contentID + "\x00" + id + "\x00" + channelName))
+	return hex.EncodeToString(sum[:])
+}
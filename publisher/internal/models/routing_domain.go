@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RoutingDomainSetting is a routing domain's saved enabled flag and parameters
+type RoutingDomainSetting struct {
+	Name       string         `db:"name"       json:"name"`
+	Enabled    bool           `db:"enabled"    json:"enabled"`
+	Params     map[string]any `db:"-"          json:"params"`
+	ParamsJSON []byte         `db:"params"     json:"-"`
+	UpdatedBy  string         `db:"updated_by" json:"updated_by"`
+	UpdatedAt  time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// ParseParams decodes ParamsJSON into Params
+func (s *RoutingDomainSetting) ParseParams() error {
+	s.Params = map[string]any{}
+	if len(s.ParamsJSON) == 0 {
+		return nil
+	}
+	return json.Unmarshal(s.ParamsJSON, &s.Params)
+}
+
+// RoutingDomainUpdateRequest saves a routing domain's setting. Params replace
+// the saved parameters; omitted parameters use the domain's defaults.
+type RoutingDomainUpdateRequest struct {
+	Enabled *bool          `binding:"required" json:"enabled"`
+	Params  map[string]any `json:"params"`
+}
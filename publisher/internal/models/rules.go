@@ -1,6 +1,9 @@
 package models
 
-import "slices"
+import (
+	"fmt"
+	"slices"
+)
 
 // Rules defines the filtering rules for a custom channel
 type Rules struct {
@@ -8,6 +11,9 @@ type Rules struct {
 	ExcludeTopics   []string `json:"exclude_topics"`
 	MinQualityScore int      `json:"min_quality_score"`
 	ContentTypes    []string `json:"content_types"`
+	// Filter is an optional boolean expression over more item fields (see
+	// RouteFilter); it must hold in addition to the rules above.
+	Filter string `json:"filter,omitempty"`
 }
 
 // IsEmpty returns true if no rules are defined (matches everything)
@@ -15,15 +21,30 @@ func (r *Rules) IsEmpty() bool {
 	return len(r.IncludeTopics) == 0 &&
 		len(r.ExcludeTopics) == 0 &&
 		r.MinQualityScore == 0 &&
-		len(r.ContentTypes) == 0
+		len(r.ContentTypes) == 0 &&
+		r.Filter == ""
 }
 
-// Matches checks if a content item matches the rules
-func (r *Rules) Matches(qualityScore int, contentType string, topics []string) bool {
+// Validate checks that the filter expression parses
+func (r *Rules) Validate() error {
+	if r.Filter == "" {
+		return nil
+	}
+	if _, err := ParseRouteFilter(r.Filter); err != nil {
+		return fmt.Errorf("rules.filter: %w", err)
+	}
+	return nil
+}
+
+// Matches checks if a content item matches the rules. A filter that does not
+// parse matches nothing, so a bad expression stops a route rather than
+// widening it.
+func (r *Rules) Matches(fields *RouteFields) bool {
 	// Fast path: empty rules match everything
 	if r.IsEmpty() {
 		return true
 	}
+	qualityScore, contentType, topics := fields.QualityScore, fields.ContentType, fields.Topics
 
 	// Quality check
 	if r.MinQualityScore > 0 && qualityScore < r.MinQualityScore {
@@ -45,6 +66,14 @@ func (r *Rules) Matches(qualityScore int, contentType string, topics []string) b
 		return false
 	}
 
+	if r.Filter != "" {
+		// Expressions are short; parsing per match keeps Rules a plain value
+		filter, err := ParseRouteFilter(r.Filter)
+		if err != nil || !filter.Matches(fields) {
+			return false
+		}
+	}
+
 	return true
 }
 
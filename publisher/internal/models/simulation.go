@@ -0,0 +1,14 @@
+package models
+
+import "github.com/google/uuid"
+
+// RouteSimulationRequest asks what a channel's rules would have matched over
+// the last Days days. Rules is a draft to test; without it the rules of the
+// channel named by ChannelID are used. With both, the draft replaces the
+// channel's rules.
+type RouteSimulationRequest struct {
+	ChannelID  *uuid.UUID `json:"channel_id"`
+	Rules      *Rules     `json:"rules"`
+	Days       int        `binding:"omitempty,min=1,max=90"  json:"days"`        // Default 7
+	SampleSize int        `binding:"omitempty,min=1,max=100" json:"sample_size"` // Default 20
+}
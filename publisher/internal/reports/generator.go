@@ -0,0 +1,151 @@
+// Package reports builds the weekly editorial report for a channel: what was
+// published (by topic and day), which items readers clicked, and what failed.
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/clicks"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+const (
+	// reportPeriod is the span of a weekly report.
+	reportPeriod = 7 * 24 * time.Hour
+	// topClickedLimit is how many clicked items a report lists.
+	topClickedLimit = 10
+	// failureReasonLimit is how many distinct failure reasons a report lists.
+	failureReasonLimit = 10
+	// maxClickLookupItems bounds the published items sent to click-tracker (its per-request limit).
+	maxClickLookupItems = 1000
+)
+
+// ErrClicksUnavailable wraps click-tracker failures; the report is still returned.
+var ErrClicksUnavailable = errors.New("click stats unavailable")
+
+// Store reads the publish history and delivery failures a report summarises.
+type Store interface {
+	CountPublished(ctx context.Context, channelName string, since, until time.Time) (int, error)
+	PublishedByTopic(ctx context.Context, channelName string, since, until time.Time) ([]models.TopicCount, error)
+	PublishedByDay(ctx context.Context, channelName string, since, until time.Time) ([]models.DayCount, error)
+	ListPublishedInPeriod(
+		ctx context.Context, channelName string, since, until time.Time, limit int,
+	) ([]models.PublishHistory, error)
+	DeliveryFailureReasons(
+		ctx context.Context, channelName string, since, until time.Time, limit int,
+	) ([]models.FailureReason, int, error)
+}
+
+// ClickSource returns click totals for published items.
+type ClickSource interface {
+	Enabled() bool
+	TopResults(ctx context.Context, contentIDs []string, since, until time.Time, limit int) ([]clicks.ResultClicks, error)
+}
+
+// Generator builds channel reports.
+type Generator struct {
+	store  Store
+	clicks ClickSource
+	now    func() time.Time
+}
+
+// NewGenerator creates a report generator. clickSource may be disabled, in
+// which case reports omit the top-clicked section.
+func NewGenerator(store Store, clickSource ClickSource) *Generator {
+	return &Generator{store: store, clicks: clickSource, now: time.Now}
+}
+
+// WeekStart returns the Monday 00:00 UTC that starts the week containing t.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// LastCompleteWeek returns the start of the most recent full week before now.
+func LastCompleteWeek(now time.Time) time.Time {
+	return WeekStart(now).AddDate(0, 0, -7)
+}
+
+// Weekly builds the report for channel covering the week starting at weekStart.
+// Click-tracker errors do not fail the report; the clicks section is marked unavailable.
+func (g *Generator) Weekly(ctx context.Context, channel *models.Channel, weekStart time.Time) (*models.ChannelReport, error) {
+	since := WeekStart(weekStart)
+	until := since.Add(reportPeriod)
+	name := channel.RedisChannel
+
+	report := &models.ChannelReport{
+		ChannelID:    channel.ID,
+		ChannelName:  channel.Name,
+		RedisChannel: name,
+		ChannelType:  channel.DeliveryType(),
+		PeriodStart:  since,
+		PeriodEnd:    until,
+		GeneratedAt:  g.now().UTC(),
+		TopClicked:   []models.ClickedItem{},
+	}
+
+	var err error
+	if report.Published, err = g.store.CountPublished(ctx, name, since, until); err != nil {
+		return nil, err
+	}
+	if report.ByTopic, err = g.store.PublishedByTopic(ctx, name, since, until); err != nil {
+		return nil, err
+	}
+	if report.ByDay, err = g.store.PublishedByDay(ctx, name, since, until); err != nil {
+		return nil, err
+	}
+	if report.FailureReasons, report.Failures, err = g.store.DeliveryFailureReasons(
+		ctx, name, since, until, failureReasonLimit,
+	); err != nil {
+		return nil, err
+	}
+
+	if report.Published > 0 && g.clicks != nil && g.clicks.Enabled() {
+		if clickErr := g.addTopClicked(ctx, report, since, until); clickErr != nil {
+			return report, fmt.Errorf("%w: %w", ErrClicksUnavailable, clickErr)
+		}
+	}
+	return report, nil
+}
+
+// addTopClicked fills the report's top-clicked items from click-tracker.
+func (g *Generator) addTopClicked(ctx context.Context, report *models.ChannelReport, since, until time.Time) error {
+	published, err := g.store.ListPublishedInPeriod(ctx, report.RedisChannel, since, until, maxClickLookupItems)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*models.PublishHistory, len(published))
+	ids := make([]string, 0, len(published))
+	for i := range published {
+		if _, seen := byID[published[i].ContentID]; seen {
+			continue
+		}
+		byID[published[i].ContentID] = &published[i]
+		ids = append(ids, published[i].ContentID)
+	}
+
+	results, err := g.clicks.TopResults(ctx, ids, since, until, topClickedLimit)
+	if err != nil {
+		return err
+	}
+
+	report.ClicksAvailable = true
+	for _, result := range results {
+		item := models.ClickedItem{
+			ContentID:      result.ResultID,
+			Clicks:         result.Clicks,
+			UniqueSessions: result.UniqueSessions,
+		}
+		if history, ok := byID[result.ResultID]; ok {
+			item.Title = history.ContentTitle
+			item.URL = history.ContentURL
+		}
+		report.TopClicked = append(report.TopClicked, item)
+	}
+	return nil
+}
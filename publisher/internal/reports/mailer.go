@@ -0,0 +1,90 @@
+package reports
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+)
+
+// ErrMailNotConfigured is returned when sending without an SMTP host or sender.
+var ErrMailNotConfigured = errors.New("report email is not configured")
+
+// Mailer sends HTML reports over SMTP. smtp.SendMail upgrades to TLS when the
+// server offers STARTTLS.
+type Mailer struct {
+	cfg      config.ReportsConfig
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+// NewMailer creates a mailer from the reports config.
+func NewMailer(cfg config.ReportsConfig) *Mailer {
+	return &Mailer{cfg: cfg, sendMail: smtp.SendMail, now: time.Now}
+}
+
+// Enabled reports whether an SMTP host and sender are configured.
+func (m *Mailer) Enabled() bool {
+	return m.cfg.SMTPHost != "" && m.cfg.From != ""
+}
+
+// Send emails an HTML document to recipients.
+func (m *Mailer) Send(to []string, subject string, html []byte) error {
+	if !m.Enabled() {
+		return ErrMailNotConfigured
+	}
+	if len(to) == 0 {
+		return nil
+	}
+
+	from, err := mail.ParseAddress(m.cfg.From)
+	if err != nil {
+		return fmt.Errorf("parse reports.from: %w", err)
+	}
+
+	msg, err := buildMessage(m.cfg.From, to, subject, html, m.now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", m.cfg.SMTPUsername, m.cfg.SMTPPassword, m.cfg.SMTPHost)
+	}
+
+	addr := net.JoinHostPort(m.cfg.SMTPHost, strconv.Itoa(m.cfg.SMTPPort))
+	if sendErr := m.sendMail(addr, auth, from.Address, to, msg); sendErr != nil {
+		return fmt.Errorf("send report email: %w", sendErr)
+	}
+	return nil
+}
+
+// buildMessage encodes a quoted-printable HTML email.
+func buildMessage(from string, to []string, subject string, html []byte, now time.Time) ([]byte, error) {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&msg)
+	if _, err := qp.Write(html); err != nil {
+		return nil, fmt.Errorf("encode report email: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("encode report email: %w", err)
+	}
+	return msg.Bytes(), nil
+}
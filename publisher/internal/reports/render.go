@@ -0,0 +1,37 @@
+package reports
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+//go:embed templates/weekly.html
+var templateFS embed.FS
+
+// weeklyTemplate renders a ChannelReport as a self-contained HTML page
+// (inline styles, so it survives email clients and prints cleanly to PDF).
+var weeklyTemplate = template.Must(template.New("weekly.html").Funcs(template.FuncMap{
+	"date":     func(t time.Time) string { return t.UTC().Format("Jan 2, 2006") },
+	"datetime": func(t time.Time) string { return t.UTC().Format("Jan 2, 2006 15:04 MST") },
+	"weekday":  func(t time.Time) string { return t.UTC().Format("Mon Jan 2") },
+	"lastDay":  func(t time.Time) time.Time { return t.AddDate(0, 0, -1) },
+}).ParseFS(templateFS, "templates/weekly.html"))
+
+// RenderHTML renders the report as an HTML document.
+func RenderHTML(report *models.ChannelReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := weeklyTemplate.Execute(&buf, report); err != nil {
+		return nil, fmt.Errorf("render weekly report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Subject returns the email subject for a report.
+func Subject(report *models.ChannelReport) string {
+	return fmt.Sprintf("[North Cloud] %s — week of %s", report.ChannelName, report.PeriodStart.Format("Jan 2, 2006"))
+}
@@ -0,0 +1,165 @@
+//nolint:testpackage // Testing the mailer's injected SMTP sender requires same package access
+package reports
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/clicks"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	since, until time.Time
+}
+
+func (f *fakeStore) CountPublished(_ context.Context, _ string, since, until time.Time) (int, error) {
+	f.since, f.until = since, until
+	return 3, nil
+}
+
+func (f *fakeStore) PublishedByTopic(context.Context, string, time.Time, time.Time) ([]models.TopicCount, error) {
+	return []models.TopicCount{{Topic: "crime", Count: 2}, {Topic: "politics", Count: 1}}, nil
+}
+
+func (f *fakeStore) PublishedByDay(context.Context, string, time.Time, time.Time) ([]models.DayCount, error) {
+	return []models.DayCount{{Day: f.since, Count: 3}}, nil
+}
+
+func (f *fakeStore) ListPublishedInPeriod(
+	context.Context, string, time.Time, time.Time, int,
+) ([]models.PublishHistory, error) {
+	return []models.PublishHistory{
+		{ContentID: "doc-1", ContentTitle: "Break-in on <Elm St>", ContentURL: "https://news.example/1"},
+		{ContentID: "doc-2", ContentTitle: "Council votes"},
+	}, nil
+}
+
+func (f *fakeStore) DeliveryFailureReasons(
+	context.Context, string, time.Time, time.Time, int,
+) ([]models.FailureReason, int, error) {
+	return []models.FailureReason{{Error: "webhook returned 503", Count: 2, LastSeen: f.since}}, 2, nil
+}
+
+type fakeClicks struct {
+	err error
+}
+
+func (f *fakeClicks) Enabled() bool { return true }
+
+func (f *fakeClicks) TopResults(context.Context, []string, time.Time, time.Time, int) ([]clicks.ResultClicks, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []clicks.ResultClicks{{ResultID: "doc-1", Clicks: 9, UniqueSessions: 7}}, nil
+}
+
+func testChannel() *models.Channel {
+	return &models.Channel{ID: uuid.New(), Name: "Sudbury Crime", RedisChannel: "sudbury:crime"}
+}
+
+func TestWeekStart(t *testing.T) {
+	// Sunday 2026-03-08 belongs to the week starting Monday 2026-03-02
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		WeekStart(time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		LastCompleteWeek(time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC)))
+}
+
+func TestGenerator_Weekly(t *testing.T) {
+	store := &fakeStore{}
+	gen := NewGenerator(store, &fakeClicks{})
+
+	report, err := gen.Weekly(context.Background(), testChannel(), time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), store.since)
+	assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), store.until)
+	assert.Equal(t, 3, report.Published)
+	assert.Equal(t, 2, report.Failures)
+	assert.True(t, report.ClicksAvailable)
+	require.Len(t, report.TopClicked, 1)
+	assert.Equal(t, "Break-in on <Elm St>", report.TopClicked[0].Title)
+	assert.Equal(t, int64(9), report.TopClicked[0].Clicks)
+}
+
+func TestGenerator_Weekly_ClicksUnavailable(t *testing.T) {
+	gen := NewGenerator(&fakeStore{}, &fakeClicks{err: errors.New("connection refused")})
+
+	report, err := gen.Weekly(context.Background(), testChannel(), time.Now())
+	require.ErrorIs(t, err, ErrClicksUnavailable)
+	require.NotNil(t, report)
+	assert.False(t, report.ClicksAvailable)
+
+	html, err := RenderHTML(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "Click data is not available")
+}
+
+func TestRenderHTML_EscapesContent(t *testing.T) {
+	report, err := NewGenerator(&fakeStore{}, &fakeClicks{}).Weekly(context.Background(), testChannel(), time.Now())
+	require.NoError(t, err)
+
+	html, err := RenderHTML(report)
+	require.NoError(t, err)
+	body := string(html)
+	assert.Contains(t, body, "Sudbury Crime")
+	assert.Contains(t, body, "Break-in on &lt;Elm St&gt;")
+	assert.Contains(t, body, "webhook returned 503")
+}
+
+func TestMailer_Send(t *testing.T) {
+	var (
+		gotAddr string
+		gotFrom string
+		gotTo   []string
+		gotMsg  string
+	)
+	mailer := NewMailer(config.ReportsConfig{
+		SMTPHost: "smtp.example.com", SMTPPort: 587, From: "North Cloud <reports@example.com>",
+	})
+	mailer.sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, string(msg)
+		return nil
+	}
+
+	require.NoError(t, mailer.Send([]string{"editor@example.com"}, "Weekly — report", []byte("<p>hi</p>")))
+
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, "reports@example.com", gotFrom)
+	assert.Equal(t, []string{"editor@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "Content-Type: text/html; charset=utf-8")
+	assert.True(t, strings.Contains(gotMsg, "Subject: =?utf-8?q?"), gotMsg)
+}
+
+func TestSendWeekly_SkipsChannelsWithoutRecipients(t *testing.T) {
+	mailer := NewMailer(config.ReportsConfig{SMTPHost: "smtp.example.com", SMTPPort: 25, From: "reports@example.com"})
+	var sent int
+	mailer.sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+		sent++
+		return nil
+	}
+
+	withRecipients := testChannel()
+	withRecipients.Config.Report = &models.ReportConfig{Recipients: []string{"editor@example.com"}}
+	channels := []models.Channel{*withRecipients, *testChannel()}
+
+	result, err := SendWeekly(context.Background(), NewGenerator(&fakeStore{}, nil), mailer,
+		channels, time.Now(), infralogger.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, SendResult{Sent: 1, Skipped: 1}, result)
+	assert.Equal(t, 1, sent)
+
+	_, err = SendWeekly(context.Background(), NewGenerator(&fakeStore{}, nil), NewMailer(config.ReportsConfig{}),
+		channels, time.Now(), infralogger.NewNop())
+	assert.ErrorIs(t, err, ErrMailNotConfigured)
+}
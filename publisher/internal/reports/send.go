@@ -0,0 +1,77 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// SendResult counts the outcome of a weekly email run.
+type SendResult struct {
+	Sent    int
+	Skipped int
+	Failed  int
+}
+
+// SendWeekly emails the week's report to the recipients of each channel that
+// lists any in config.report. A failure for one channel does not stop the rest.
+func SendWeekly(
+	ctx context.Context,
+	gen *Generator,
+	mailer *Mailer,
+	channels []models.Channel,
+	weekStart time.Time,
+	log infralogger.Logger,
+) (SendResult, error) {
+	var result SendResult
+	if !mailer.Enabled() {
+		return result, ErrMailNotConfigured
+	}
+
+	for i := range channels {
+		channel := &channels[i]
+		if channel.Config.Report == nil || len(channel.Config.Report.Recipients) == 0 {
+			result.Skipped++
+			continue
+		}
+
+		report, err := gen.Weekly(ctx, channel, weekStart)
+		if err != nil && !errors.Is(err, ErrClicksUnavailable) {
+			log.Error("Failed to generate weekly report",
+				infralogger.String("channel_id", channel.ID.String()),
+				infralogger.Error(err),
+			)
+			result.Failed++
+			continue
+		}
+		if err != nil {
+			log.Warn("Weekly report sent without click data",
+				infralogger.String("channel_id", channel.ID.String()),
+				infralogger.Error(err),
+			)
+		}
+
+		html, err := RenderHTML(report)
+		if err == nil {
+			err = mailer.Send(channel.Config.Report.Recipients, Subject(report), html)
+		}
+		if err != nil {
+			log.Error("Failed to send weekly report",
+				infralogger.String("channel_id", channel.ID.String()),
+				infralogger.Error(err),
+			)
+			result.Failed++
+			continue
+		}
+
+		log.Info("Weekly report sent",
+			infralogger.String("channel_id", channel.ID.String()),
+			infralogger.Int("recipients", len(channel.Config.Report.Recipients)),
+		)
+		result.Sent++
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// approvedReleaseLimit bounds approved items delivered per poll.
+const approvedReleaseLimit = 100
+
+// requiresApproval reports whether a route's DB channel holds items for editorial approval.
+func requiresApproval(route ChannelRoute) bool {
+	return route.Target != nil && route.Target.RequiresApproval
+}
+
+// queueForApproval stores an item as pending approval for its channel.
+func (s *Service) queueForApproval(ctx context.Context, item *ContentItem, route ChannelRoute) {
+	payload, err := json.Marshal(item)
+	if err != nil {
+		s.logger.Error("Failed to encode item for approval",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		return
+	}
+
+	approval := &models.ChannelApproval{
+		ChannelID:    route.Target.ID,
+		ContentID:    item.ID,
+		Title:        item.Title,
+		URL:          item.URL,
+		Source:       item.Source,
+		QualityScore: item.QualityScore,
+		Topics:       item.Topics,
+		Payload:      payload,
+	}
+	if createErr := s.repo.CreateChannelApproval(ctx, approval); createErr != nil {
+		s.logger.Error("Failed to queue item for approval",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(createErr),
+		)
+		return
+	}
+
+	s.logger.Info("Content item awaiting approval",
+		infralogger.String("content_id", item.ID),
+		infralogger.String("channel", route.Channel),
+	)
+}
+
+// releaseApproved delivers items editors approved since the last poll, with
+// their edits applied. Channels with a schedule receive them in their queue.
+func (s *Service) releaseApproved(ctx context.Context, channels []models.Channel) {
+	approved, err := s.repo.ListApprovedItems(ctx, approvedReleaseLimit)
+	if err != nil {
+		s.logger.Error("Failed to list approved items", infralogger.Error(err))
+		return
+	}
+	if len(approved) == 0 {
+		return
+	}
+
+	byID := make(map[uuid.UUID]*models.Channel, len(channels))
+	for i := range channels {
+		byID[channels[i].ID] = &channels[i]
+	}
+
+	for i := range approved {
+		ch, ok := byID[approved[i].ChannelID]
+		if !ok {
+			// Enabled after this poll loaded its channels; picked up next poll.
+			continue
+		}
+		status := s.releaseApprovedItem(ctx, &approved[i], ch)
+		if setErr := s.repo.SetChannelApprovalStatus(ctx, approved[i].ID, status); setErr != nil {
+			s.logger.Error("Failed to record approval outcome",
+				infralogger.String("content_id", approved[i].ContentID),
+				infralogger.String("channel", ch.RedisChannel),
+				infralogger.Error(setErr),
+			)
+		}
+	}
+}
+
+// releaseApprovedItem delivers one approved item and returns its final status:
+// published when delivered or handed to the channel's schedule queue, otherwise failed.
+func (s *Service) releaseApprovedItem(ctx context.Context, approval *models.ChannelApproval, ch *models.Channel) string {
+	var item ContentItem
+	if err := json.Unmarshal(approval.Payload, &item); err != nil {
+		s.logger.Error("Dropping undecodable approved item",
+			infralogger.String("content_id", approval.ContentID),
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Error(err),
+		)
+		return models.ApprovalStatusFailed
+	}
+	applyApprovalEdits(&item, &approval.Edits)
+
+	id := ch.ID
+	route := ChannelRoute{Channel: ch.RedisChannel, ChannelID: &id, Target: ch}
+	if !s.shouldPublish(ctx, &item, route) {
+		return models.ApprovalStatusFailed
+	}
+
+	if isQueued(route) || !s.circuitAllows(route) {
+		s.queueScheduled(ctx, &item, route)
+		return models.ApprovalStatusPublished
+	}
+	if s.deliverAndRecord(ctx, &item, route) {
+		return models.ApprovalStatusPublished
+	}
+	return models.ApprovalStatusFailed
+}
+
+// applyApprovalEdits overrides an item's title, body, and summary with an editor's changes.
+func applyApprovalEdits(item *ContentItem, edits *models.ApprovalEdits) {
+	if edits.Title != nil {
+		item.Title = *edits.Title
+		item.OGTitle = *edits.Title
+	}
+	if edits.Body != nil {
+		item.Body = *edits.Body
+	}
+	if edits.Summary != nil {
+		item.OGDescription = *edits.Summary
+	}
+}
@@ -0,0 +1,149 @@
+//nolint:testpackage // Testing unexported approval helpers requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	assert.False(t, requiresApproval(ChannelRoute{Channel: "content:crime"}), "layer 1 routes have no channel")
+	assert.False(t, requiresApproval(ChannelRoute{Target: &models.Channel{}}))
+	assert.True(t, requiresApproval(ChannelRoute{Target: &models.Channel{RequiresApproval: true}}))
+}
+
+func TestApplyApprovalEdits_RoundTrip(t *testing.T) {
+	routed := &ContentItem{
+		ID:            "doc-1",
+		Title:         "Police investigate downtown stabbing",
+		OGTitle:       "Police investigate downtown stabbing",
+		Body:          "Original body",
+		OGDescription: "Original summary",
+		Topics:        []string{"violent_crime"},
+	}
+	payload, err := json.Marshal(routed)
+	require.NoError(t, err)
+
+	var released ContentItem
+	require.NoError(t, json.Unmarshal(payload, &released))
+
+	title := "Man charged after downtown stabbing"
+	summary := "A 34-year-old man faces an assault charge."
+	applyApprovalEdits(&released, &models.ApprovalEdits{Title: &title, Summary: &summary})
+
+	assert.Equal(t, "doc-1", released.ID)
+	assert.Equal(t, title, released.Title)
+	assert.Equal(t, title, released.OGTitle)
+	assert.Equal(t, summary, released.OGDescription)
+	assert.Equal(t, "Original body", released.Body, "unedited fields keep the routed value")
+	assert.Equal(t, []string{"violent_crime"}, released.Topics)
+}
+
+// approvedRows is what ListApprovedItems returns for one approved item.
+func approvedRows(ch *models.Channel, payload []byte, edits string, reviewedAt time.Time) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "channel_id", "content_id", "title", "url", "source", "quality_score", "topics", "payload",
+		"edits", "status", "reviewed_by", "review_note", "reviewed_at", "created_at", "updated_at",
+	}).AddRow(
+		uuid.New(), ch.ID, "doc-1", "Title", "https://example.com/doc-1", "example", 80, "{violent_crime}", payload,
+		[]byte(edits), models.ApprovalStatusApproved, "editor", "", reviewedAt, reviewedAt, reviewedAt,
+	)
+}
+
+func TestApprovalFlow_HeldUntilApprovedThenDeliveredOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var delivered []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		title, _ := body["title"].(string)
+		delivered = append(delivered, title)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	ch := webhookChannel(&models.WebhookConfig{URL: srv.URL})
+	ch.RequiresApproval = true
+	id := ch.ID
+	route := ChannelRoute{Channel: ch.RedisChannel, ChannelID: &id, Target: ch}
+	item := &ContentItem{ID: "doc-1", Title: "Title"}
+	ctx := context.Background()
+
+	// Routed: queued for an editor, not delivered.
+	expectPublishable(mock)
+	mock.ExpectExec("INSERT INTO channel_approvals").
+		WithArgs(ch.ID, "doc-1", "Title", "", "", 0, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.False(t, s.publishToChannel(ctx, item, route))
+	assert.Empty(t, delivered, "an item is held until an editor approves it")
+
+	// Approved with an edited title: delivered on the next poll.
+	payload, err := json.Marshal(item)
+	require.NoError(t, err)
+	mock.ExpectQuery("FROM channel_approvals").WithArgs(approvedReleaseLimit).
+		WillReturnRows(approvedRows(ch, payload, `{"title":"Edited title"}`, now))
+	expectPublishable(mock)
+	expectClaimed(mock)
+	mock.ExpectExec("UPDATE routing_decisions").
+		WithArgs(sqlmock.AnyArg(), models.RoutingDecisionDelivered).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO publish_history").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectExec("UPDATE channel_approvals SET status").
+		WithArgs(sqlmock.AnyArg(), models.ApprovalStatusPublished).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.releaseApproved(ctx, []models.Channel{*ch})
+	assert.Equal(t, []string{"Edited title"}, delivered)
+
+	// Next poll: the item is published, so neither the release nor routing it again delivers it.
+	mock.ExpectQuery("FROM channel_approvals").WithArgs(approvedReleaseLimit).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("FROM publish_history").WithArgs("doc-1", ch.RedisChannel).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	s.releaseApproved(ctx, []models.Channel{*ch})
+	assert.False(t, s.publishToChannel(ctx, item, route))
+
+	assert.Len(t, delivered, 1, "an approved item is delivered exactly once")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReleaseApproved_FailedDeliveryIsMarkedFailed(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var calls int
+	srv := statusServer(t, http.StatusBadRequest, &calls)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	ch := webhookChannel(&models.WebhookConfig{URL: srv.URL})
+	ch.RequiresApproval = true
+	payload, err := json.Marshal(&ContentItem{ID: "doc-1", Title: "Title"})
+	require.NoError(t, err)
+
+	mock.ExpectQuery("FROM channel_approvals").WillReturnRows(approvedRows(ch, payload, "", now))
+	expectPublishable(mock)
+	expectClaimed(mock)
+	mock.ExpectExec("UPDATE routing_decisions").
+		WithArgs(sqlmock.AnyArg(), models.RoutingDecisionFailed).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO channel_delivery_failures").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM channel_delivery_failures").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO channel_dead_letters").WillReturnRows(storedDeadLetterRows(1))
+	mock.ExpectExec("UPDATE channel_approvals SET status").
+		WithArgs(sqlmock.AnyArg(), models.ApprovalStatusFailed).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.releaseApproved(context.Background(), []models.Channel{*ch})
+
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet(), "the failure is retried from the dead letter queue, not the approval")
+}
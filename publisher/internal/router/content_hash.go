@@ -0,0 +1,125 @@
+package router
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"unicode"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// Content hash settings.
+const (
+	// contentHashBodyWords is how much of the body identifies a story. Sources
+	// append their own bylines, boilerplate and related links to wire stories,
+	// so only the opening is compared.
+	contentHashBodyWords = 120
+	// contentHashMinBodyWords is the shortest body hashed; shorter items (briefs,
+	// listings) match too easily on title alone.
+	contentHashMinBodyWords = 20
+)
+
+// contentHash identifies a story across sources: a SHA-256 of the normalized
+// title and the opening words of the body (lowercase, punctuation removed).
+// Items with too little body text return "" and are not deduplicated by hash.
+func contentHash(item *ContentItem) string {
+	body := item.RawText
+	if body == "" {
+		body = item.Body
+	}
+	bodyWords := normalizedWords(body)
+	if len(bodyWords) < contentHashMinBodyWords {
+		return ""
+	}
+	if len(bodyWords) > contentHashBodyWords {
+		bodyWords = bodyWords[:contentHashBodyWords]
+	}
+
+	normalized := strings.Join(normalizedWords(item.Title), " ") + "\n" + strings.Join(bodyWords, " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizedWords lowercases text and splits it into words of letters and digits.
+func normalizedWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// isPublishedVariant reports whether another source's variant of the story
+// was published to the channel within the dedup window. Lookup errors count
+// as published so a story is not posted twice by accident.
+func (s *Service) isPublishedVariant(ctx context.Context, item *ContentItem, channelName string) bool {
+	if s.config.DedupWindow <= 0 {
+		return false
+	}
+	hash := contentHash(item)
+	if hash == "" {
+		return false
+	}
+
+	since := s.clock.Now().Add(-s.config.DedupWindow)
+	published, err := s.repo.CheckContentHashPublished(ctx, channelName, hash, since)
+	if err != nil {
+		s.logger.Error("Error checking content hash",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", channelName),
+			infralogger.Error(err),
+		)
+		return true
+	}
+	if published {
+		s.logger.Info("Skipping variant of a story already published from another source",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("source", item.Source),
+			infralogger.String("channel", channelName),
+		)
+	}
+	return published
+}
+
+// replaceQueuedVariant keeps the higher quality of an item and another
+// source's variant already waiting in the channel's queue. It returns false
+// when the queued variant is kept and the item should not be queued.
+func (s *Service) replaceQueuedVariant(ctx context.Context, item *ContentItem, route ChannelRoute, hash string) bool {
+	if s.config.DedupWindow <= 0 || hash == "" {
+		return true
+	}
+
+	queued, err := s.repo.GetQueuedVariant(ctx, route.Target.ID, hash)
+	if errors.Is(err, models.ErrNotFound) {
+		return true
+	}
+	if err != nil {
+		s.logger.Warn("Failed to look up queued variants",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		return true
+	}
+	if queued.ContentID == item.ID {
+		return true
+	}
+	if queued.QualityScore >= item.QualityScore {
+		s.logger.Debug("Keeping queued variant of higher quality",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("queued_content_id", queued.ContentID),
+			infralogger.String("channel", route.Channel),
+		)
+		return false
+	}
+
+	s.logger.Info("Replacing queued variant with higher quality item",
+		infralogger.String("content_id", item.ID),
+		infralogger.String("queued_content_id", queued.ContentID),
+		infralogger.String("channel", route.Channel),
+	)
+	s.dequeueScheduled(ctx, queued.ID, route.Channel)
+	return true
+}
@@ -0,0 +1,64 @@
+//nolint:testpackage // Testing unexported content hash helpers requires same package access
+package router
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wireStory = "Provincial police say a transport truck rolled over on Highway 17 west of the city " +
+	"early Tuesday morning, closing both lanes for several hours while crews cleared the scene and " +
+	"investigators examined the wreckage. No injuries were reported."
+
+func TestContentHash_MatchesVariantsAcrossSources(t *testing.T) {
+	a := &ContentItem{Title: "Highway 17 closed after crash", Body: wireStory + " Follow Sudbury.com on Twitter."}
+	b := &ContentItem{
+		Title:   "HIGHWAY 17 CLOSED AFTER CRASH!",
+		RawText: strings.ReplaceAll(wireStory, ",", "") + "\n\nRelated: more traffic news from TBNewsWatch",
+	}
+
+	require.NotEmpty(t, contentHash(a))
+	assert.NotEqual(t, contentHash(a), contentHash(b), "different tails within the opening words differ")
+
+	long := wireStory + " " + strings.Repeat("The highway reopened later that afternoon. ", 20)
+	a.Body, b.RawText = long+"Sudbury.com", strings.ReplaceAll(long, ",", "")+"TBNewsWatch staff"
+	assert.Equal(t, contentHash(a), contentHash(b), "sources' additions after the opening are ignored")
+}
+
+func TestContentHash_ShortBodiesAreNotHashed(t *testing.T) {
+	assert.Empty(t, contentHash(&ContentItem{Title: "Weather", Body: "Sunny with a high of 12."}))
+}
+
+func TestShouldPublish_SkipsVariantPublishedWithinWindow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := (&Service{
+		repo:   database.NewRepository(sqlx.NewDb(db, "postgres")),
+		logger: infralogger.NewNop(),
+		config: Config{DedupWindow: 48 * time.Hour},
+	}).WithClock(clock.NewFake(now))
+	item := &ContentItem{ID: "doc-2", Title: "Highway 17 closed", Body: wireStory}
+
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("doc-2", "content:traffic").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("content:traffic", contentHash(item), now.Add(-48*time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	id := uuid.New()
+	assert.False(t, s.shouldPublish(context.Background(), item, ChannelRoute{Channel: "content:traffic", ChannelID: &id}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
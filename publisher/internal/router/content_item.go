@@ -1,6 +1,10 @@
 package router
 
-import "time"
+import (
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
 
 // CoforgeData holds Coforge classification fields from Elasticsearch.
 type CoforgeData struct {
@@ -198,6 +202,20 @@ type ContentItem struct {
 	Sort []any `json:"-"`
 }
 
+// RouteFields returns the values channel rules and filters are matched against.
+func (c *ContentItem) RouteFields() *models.RouteFields {
+	return &models.RouteFields{
+		Topics:           c.Topics,
+		Source:           c.Source,
+		ContentType:      c.ContentType,
+		QualityScore:     c.QualityScore,
+		WordCount:        c.WordCount,
+		CrimeRelevance:   c.CrimeRelevance,
+		LocationCity:     c.LocationCity,
+		LocationProvince: c.LocationProvince,
+	}
+}
+
 // extractNestedFields copies values from nested Elasticsearch objects into the
 // flat ContentItem fields used by domain routing functions.
 // Call after unmarshaling from Elasticsearch.
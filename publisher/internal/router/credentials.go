@@ -0,0 +1,92 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// CredentialResolver decrypts the stored credential a channel references by name.
+type CredentialResolver interface {
+	Reveal(ctx context.Context, name string) (string, error)
+}
+
+// errNoCredentialStore is returned for channels referencing a stored
+// credential when the router runs without a credential store.
+var errNoCredentialStore = errors.New("no credential store configured")
+
+// credentialUser is implemented by deliverers that read channel secrets.
+type credentialUser interface {
+	useCredentials(resolver CredentialResolver)
+}
+
+// WithCredentials lets channels reference secrets stored encrypted in the
+// credential store instead of env vars.
+func (s *Service) WithCredentials(resolver CredentialResolver) *Service {
+	for _, deliverer := range s.deliverers {
+		if user, ok := deliverer.(credentialUser); ok {
+			user.useCredentials(resolver)
+		}
+	}
+	return s
+}
+
+// channelSecret returns a channel secret from the named stored credential, or
+// else from the named env var. It is read on every call so a rotated value
+// applies without a restart. Empty values are an error.
+func channelSecret(
+	ctx context.Context, resolver CredentialResolver, getenv func(string) string, credential, envVar string,
+) (string, error) {
+	if credential == "" {
+		value := getenv(envVar)
+		if value == "" {
+			return "", fmt.Errorf("env var %s is empty", envVar)
+		}
+		return value, nil
+	}
+
+	if resolver == nil {
+		return "", fmt.Errorf("credential %s: %w", credential, errNoCredentialStore)
+	}
+	value, err := resolver.Reveal(ctx, credential)
+	if err != nil {
+		return "", fmt.Errorf("credential %s: %w", credential, err)
+	}
+	if value == "" {
+		return "", fmt.Errorf("credential %s is empty", credential)
+	}
+	return value, nil
+}
+
+// CheckChannelCredentials resolves every secret the channel's destination
+// reads on delivery, the same way its deliverer does, without delivering.
+// Optional secrets that are not configured are not an error.
+func CheckChannelCredentials(
+	ctx context.Context, resolver CredentialResolver, getenv func(string) string, channel *models.Channel,
+) error {
+	cfg := &channel.Config
+	var err error
+	switch channel.DeliveryType() {
+	case models.ChannelTypeWordPress:
+		if cfg.WordPress == nil {
+			return fmt.Errorf("%w: missing config.wordpress", ErrWordPressNotConfigured)
+		}
+		_, err = channelSecret(ctx, resolver, getenv, cfg.WordPress.PasswordCredential, cfg.WordPress.PasswordEnv)
+	case models.ChannelTypeWebhook:
+		if cfg.Webhook != nil && (cfg.Webhook.SecretEnv != "" || cfg.Webhook.SecretCredential != "") {
+			_, err = channelSecret(ctx, resolver, getenv, cfg.Webhook.SecretCredential, cfg.Webhook.SecretEnv)
+		}
+	case models.ChannelTypeChat:
+		if cfg.Chat == nil {
+			return fmt.Errorf("%w: missing config.chat", ErrChatNotConfigured)
+		}
+		_, err = channelSecret(ctx, resolver, getenv, cfg.Chat.WebhookURLCredential, cfg.Chat.WebhookURLEnv)
+	case models.ChannelTypeDrupal:
+		if cfg.Drupal != nil && cfg.Drupal.TokenCredential != "" {
+			_, err = channelSecret(ctx, resolver, nil, cfg.Drupal.TokenCredential, "")
+		}
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+//nolint:testpackage // Testing unexported deliverer fields requires same package access
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCredentials resolves credentials from a map.
+type fakeCredentials map[string]string
+
+func (f fakeCredentials) Reveal(_ context.Context, name string) (string, error) {
+	value, ok := f[name]
+	if !ok {
+		return "", models.ErrNotFound
+	}
+	return value, nil
+}
+
+func TestWebhookDeliverer_SignsWithStoredCredential(t *testing.T) {
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(WebhookSignatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	channel := webhookChannel(&models.WebhookConfig{URL: srv.URL, SecretCredential: "acme-hook"})
+	item := &ContentItem{ID: "a1", Title: "Story"}
+
+	d := newTestWebhookDeliverer(srv, nil)
+	err := d.Deliver(t.Context(), channel, item)
+	require.ErrorIs(t, err, ErrWebhookNotConfigured, "no store: never deliver unsigned")
+	assert.Empty(t, signature)
+
+	svc := &Service{deliverers: map[string]Deliverer{models.ChannelTypeWebhook: d}}
+	svc.WithCredentials(fakeCredentials{"acme-hook": "s3cret"})
+	require.NoError(t, d.Deliver(t.Context(), channel, item))
+	assert.Contains(t, signature, "sha256=")
+}
+
+func TestChannelSecret(t *testing.T) {
+	getenv := func(name string) string { return map[string]string{"WP_PASSWORD": "from-env"}[name] }
+	store := fakeCredentials{"partner-wp": "from-store", "blank": ""}
+
+	value, err := channelSecret(t.Context(), store, getenv, "", "WP_PASSWORD")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", value)
+
+	value, err = channelSecret(t.Context(), store, getenv, "partner-wp", "WP_PASSWORD")
+	require.NoError(t, err)
+	assert.Equal(t, "from-store", value, "a credential reference wins over the env var")
+
+	_, err = channelSecret(t.Context(), store, getenv, "missing", "")
+	require.ErrorIs(t, err, models.ErrNotFound)
+	_, err = channelSecret(t.Context(), store, getenv, "blank", "")
+	require.Error(t, err)
+	_, err = channelSecret(t.Context(), nil, getenv, "partner-wp", "")
+	require.ErrorIs(t, err, errNoCredentialStore)
+}
+
+func TestCheckChannelCredentials(t *testing.T) {
+	getenv := func(name string) string { return map[string]string{"CHAT_URL": "https://hooks.example.com/x"}[name] }
+	store := fakeCredentials{"partner-wp": "from-store"}
+
+	tests := []struct {
+		name    string
+		channel *models.Channel
+		wantErr bool
+	}{
+		{"redis needs nothing", &models.Channel{Type: models.ChannelTypeRedis}, false},
+		{"wordpress stored password", &models.Channel{
+			Type:   models.ChannelTypeWordPress,
+			Config: models.ChannelConfig{WordPress: &models.WordPressConfig{PasswordCredential: "partner-wp"}},
+		}, false},
+		{"wordpress missing password", &models.Channel{
+			Type:   models.ChannelTypeWordPress,
+			Config: models.ChannelConfig{WordPress: &models.WordPressConfig{PasswordEnv: "WP_UNSET"}},
+		}, true},
+		{"unsigned webhook", &models.Channel{
+			Type:   models.ChannelTypeWebhook,
+			Config: models.ChannelConfig{Webhook: &models.WebhookConfig{URL: "https://example.com"}},
+		}, false},
+		{"webhook missing secret", &models.Channel{
+			Type:   models.ChannelTypeWebhook,
+			Config: models.ChannelConfig{Webhook: &models.WebhookConfig{SecretCredential: "missing"}},
+		}, true},
+		{"chat url from env", &models.Channel{
+			Type:   models.ChannelTypeChat,
+			Config: models.ChannelConfig{Chat: &models.ChatConfig{WebhookURLEnv: "CHAT_URL"}},
+		}, false},
+		{"drupal missing token", &models.Channel{
+			Type:   models.ChannelTypeDrupal,
+			Config: models.ChannelConfig{Drupal: &models.DrupalConfig{TokenCredential: "missing"}},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckChannelCredentials(t.Context(), store, getenv, tt.channel)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
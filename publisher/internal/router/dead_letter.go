@@ -0,0 +1,236 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+const (
+	// deadLetterRetryLimit bounds dead letters retried per poll.
+	deadLetterRetryLimit = 100
+	// deadLetterMaxAttempts is the number of deliveries, including the first,
+	// before an item is marked dead.
+	deadLetterMaxAttempts = 8
+	// deadLetterBaseDelay is the wait before the first retry; each further retry doubles it.
+	deadLetterBaseDelay = time.Minute
+	// deadLetterMaxDelay caps the wait between retries.
+	deadLetterMaxDelay = time.Hour
+)
+
+// deadLetterBackoff returns the wait before retrying an item that has failed
+// attempts times: deadLetterBaseDelay doubled per attempt, capped at deadLetterMaxDelay.
+func deadLetterBackoff(attempts int) time.Duration {
+	delay := deadLetterBaseDelay
+	for i := 1; i < attempts && delay < deadLetterMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, deadLetterMaxDelay)
+}
+
+// errChannelUnavailable is the last error of dead letters whose custom
+// channel was disabled or deleted.
+var errChannelUnavailable = errors.New("channel is disabled or deleted")
+
+// deadLetter stores a failed delivery with its payload and error for retry.
+// An item that was already queued for the channel failed again, so it backs
+// off further, or is marked dead, like a failed retry.
+func (s *Service) deadLetter(ctx context.Context, item *ContentItem, route ChannelRoute, deliverErr error) {
+	nextRetryAt := s.clock.Now().Add(deadLetterBackoff(1))
+	letter := s.storeDeadLetter(ctx, item, route, deliverErr, models.DeadLetterStatusRetrying, &nextRetryAt)
+	if letter != nil && letter.Attempts > 1 {
+		s.recordFailedAttempt(ctx, letter.ID, item.ID, route.Channel, letter.Attempts, deliverErr)
+	}
+}
+
+// storeDeadLetter stores an item in the dead letter queue with status; dead
+// items have no next retry and wait for a replay. Returns the stored letter,
+// or nil when it could not be stored.
+func (s *Service) storeDeadLetter(
+	ctx context.Context, item *ContentItem, route ChannelRoute, deliverErr error, status string, nextRetryAt *time.Time,
+) *models.DeadLetter {
+	payload, err := json.Marshal(item)
+	if err != nil {
+		s.logger.Error("Failed to encode dead letter",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		return nil
+	}
+
+	letter := &models.DeadLetter{
+		ChannelID:   route.ChannelID,
+		ChannelName: route.Channel,
+		ContentID:   item.ID,
+		Title:       item.Title,
+		Payload:     payload,
+		LastError:   deliverErr.Error(),
+		Attempts:    1,
+		Status:      status,
+		NextRetryAt: nextRetryAt,
+	}
+	if recordErr := s.repo.RecordDeadLetter(ctx, letter); recordErr != nil {
+		s.logger.Error("Failed to record dead letter",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(recordErr),
+		)
+		return nil
+	}
+	return letter
+}
+
+// retryDeadLetters delivers dead letters whose next retry is due. Items of a
+// custom channel that is disabled or deleted are marked dead, so they stop
+// coming due; replay them once the channel is enabled again.
+func (s *Service) retryDeadLetters(ctx context.Context, channels []models.Channel) {
+	due, err := s.repo.ListDueDeadLetters(ctx, deadLetterRetryLimit)
+	if err != nil {
+		s.logger.Error("Failed to list due dead letters", infralogger.Error(err))
+		return
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	byID := make(map[uuid.UUID]*models.Channel, len(channels))
+	for i := range channels {
+		byID[channels[i].ID] = &channels[i]
+	}
+
+	for i := range due {
+		route := ChannelRoute{Channel: due[i].ChannelName}
+		if due[i].ChannelID != nil {
+			ch, ok := byID[*due[i].ChannelID]
+			if !ok {
+				s.markDeadLetterDead(ctx, &due[i], errChannelUnavailable)
+				continue
+			}
+			id := ch.ID
+			route = ChannelRoute{Channel: ch.RedisChannel, ChannelID: &id, Target: ch}
+		}
+		s.retryDeadLetter(ctx, &due[i], route)
+	}
+}
+
+// retryDeadLetter delivers one dead letter. Delivered items, and items that no
+// longer need delivering (already published or held), leave the queue; a
+// failure schedules the next attempt or marks the item dead.
+func (s *Service) retryDeadLetter(ctx context.Context, letter *models.DeadLetter, route ChannelRoute) {
+	var item ContentItem
+	if err := json.Unmarshal(letter.Payload, &item); err != nil {
+		s.logger.Error("Dropping undecodable dead letter",
+			infralogger.String("content_id", letter.ContentID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		s.removeDeadLetter(ctx, letter.ID, route.Channel)
+		return
+	}
+
+	if !s.shouldPublish(ctx, &item, route) {
+		s.removeDeadLetter(ctx, letter.ID, route.Channel)
+		return
+	}
+
+	// A channel out of quota, or whose circuit is open, keeps the letter due
+	// for the next poll without using up an attempt.
+	if !s.circuitAllows(route) {
+		return
+	}
+	if route.Target != nil && route.Target.Config.RateLimit != nil &&
+		s.remainingQuota(ctx, route.Target, s.clock.Now()) == 0 {
+		return
+	}
+
+	// A replay may deliver an item whose outcome was in doubt; an item
+	// delivered since it was dead-lettered leaves the queue.
+	claimed, decisionStatus := s.claimDelivery(ctx, &item, route, true)
+	if !claimed {
+		if decisionStatus == models.RoutingDecisionDelivered {
+			s.removeDeadLetter(ctx, letter.ID, route.Channel)
+		}
+		return
+	}
+
+	remoteID, deliverErr := s.deliver(ctx, &item, route)
+	s.finishDelivery(ctx, &item, route, deliverErr)
+	s.recordDeliveryOutcome(ctx, route, deliverErr)
+	if deliverErr == nil {
+		s.recordPublished(ctx, &item, route, remoteID)
+		s.removeDeadLetter(ctx, letter.ID, route.Channel)
+		return
+	}
+
+	s.recordFailedAttempt(ctx, letter.ID, item.ID, route.Channel, letter.Attempts+1, deliverErr)
+}
+
+// recordFailedAttempt records that delivery failed for the attempts-th time:
+// the next retry backs off further, and the last allowed attempt marks the
+// item dead.
+func (s *Service) recordFailedAttempt(
+	ctx context.Context, id uuid.UUID, contentID, channelName string, attempts int, deliverErr error,
+) {
+	status := models.DeadLetterStatusRetrying
+	var nextRetryAt *time.Time
+	if attempts >= deadLetterMaxAttempts {
+		status = models.DeadLetterStatusDead
+	} else {
+		next := s.clock.Now().Add(deadLetterBackoff(attempts))
+		nextRetryAt = &next
+	}
+
+	s.logger.Warn("Dead letter delivery failed",
+		infralogger.String("content_id", contentID),
+		infralogger.String("channel", channelName),
+		infralogger.Int("attempts", attempts),
+		infralogger.String("status", status),
+		infralogger.Error(deliverErr),
+	)
+
+	if err := s.repo.UpdateDeadLetterAttempt(ctx, id, attempts, deliverErr.Error(), status, nextRetryAt); err != nil {
+		s.logger.Error("Failed to record dead letter attempt",
+			infralogger.String("content_id", contentID),
+			infralogger.String("channel", channelName),
+			infralogger.Error(err),
+		)
+	}
+}
+
+// markDeadLetterDead stops retrying a letter without using an attempt; it
+// waits for a replay.
+func (s *Service) markDeadLetterDead(ctx context.Context, letter *models.DeadLetter, reason error) {
+	s.logger.Warn("Dead letter marked dead",
+		infralogger.String("content_id", letter.ContentID),
+		infralogger.String("channel", letter.ChannelName),
+		infralogger.Error(reason),
+	)
+
+	err := s.repo.UpdateDeadLetterAttempt(
+		ctx, letter.ID, letter.Attempts, reason.Error(), models.DeadLetterStatusDead, nil,
+	)
+	if err != nil {
+		s.logger.Error("Failed to mark dead letter dead",
+			infralogger.String("content_id", letter.ContentID),
+			infralogger.String("channel", letter.ChannelName),
+			infralogger.Error(err),
+		)
+	}
+}
+
+// removeDeadLetter deletes a dead letter that no longer needs retrying. If
+// removal fails the item is retried again and skipped by the publish history check.
+func (s *Service) removeDeadLetter(ctx context.Context, id uuid.UUID, channelName string) {
+	if err := s.repo.DeleteDeadLetter(ctx, id); err != nil {
+		s.logger.Warn("Failed to remove dead letter",
+			infralogger.String("channel", channelName),
+			infralogger.Error(err),
+		)
+	}
+}
@@ -0,0 +1,317 @@
+//nolint:testpackage // Testing unexported dead letter helpers requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, deadLetterBackoff(1))
+	assert.Equal(t, 2*time.Minute, deadLetterBackoff(2))
+	assert.Equal(t, 32*time.Minute, deadLetterBackoff(6))
+	assert.Equal(t, time.Hour, deadLetterBackoff(7), "delay is capped")
+	assert.Equal(t, time.Hour, deadLetterBackoff(100))
+}
+
+func TestDeadLetterBackoff_RetriesFitBeforeDead(t *testing.T) {
+	var total time.Duration
+	for attempts := 1; attempts < deadLetterMaxAttempts; attempts++ {
+		total += deadLetterBackoff(attempts)
+	}
+
+	assert.Less(t, total, 4*time.Hour, "an item should be marked dead within a few hours of failing")
+}
+
+func TestDeadLetter_FirstRetryScheduledFromClock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := (&Service{
+		repo:   database.NewRepository(sqlx.NewDb(db, "postgres")),
+		logger: infralogger.NewNop(),
+	}).WithClock(clock.NewFake(now))
+
+	mock.ExpectQuery("INSERT INTO channel_dead_letters").
+		WithArgs(
+			sqlmock.AnyArg(), "articles:crime", "doc-1", "Title", sqlmock.AnyArg(),
+			"connection refused", 1, "retrying", now.Add(time.Minute),
+		).
+		WillReturnRows(storedDeadLetterRows(1))
+
+	item := &ContentItem{ID: "doc-1", Title: "Title"}
+	s.deadLetter(context.Background(), item, ChannelRoute{Channel: "articles:crime"}, errors.New("connection refused"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// storedDeadLetterRows is what RecordDeadLetter's upsert returns.
+func storedDeadLetterRows(attempts int) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "attempts"}).AddRow(uuid.New(), attempts)
+}
+
+func newDeadLetterTestService(t *testing.T, now time.Time, srv *httptest.Server) (*Service, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	deliverers := map[string]Deliverer{}
+	if srv != nil {
+		deliverers[models.ChannelTypeWebhook] = newTestWebhookDeliverer(srv, nil)
+	}
+	s := (&Service{
+		repo:       database.NewRepository(sqlx.NewDb(db, "postgres")),
+		logger:     infralogger.NewNop(),
+		deliverers: deliverers,
+	}).WithClock(clock.NewFake(now))
+	return s, mock
+}
+
+// webhookLetter returns a webhook channel's route and a dead letter for it
+// that has failed attempts times.
+func webhookLetter(t *testing.T, url string, attempts int) (*models.DeadLetter, ChannelRoute) {
+	t.Helper()
+	ch := webhookChannel(&models.WebhookConfig{URL: url})
+	payload, err := json.Marshal(&ContentItem{ID: "doc-1", Title: "Title"})
+	require.NoError(t, err)
+
+	id := ch.ID
+	letter := &models.DeadLetter{
+		ID:          uuid.New(),
+		ChannelID:   &id,
+		ChannelName: ch.RedisChannel,
+		ContentID:   "doc-1",
+		Payload:     payload,
+		Attempts:    attempts,
+		Status:      models.DeadLetterStatusRetrying,
+	}
+	return letter, ChannelRoute{Channel: ch.RedisChannel, ChannelID: &id, Target: ch}
+}
+
+// expectPublishable expects the publish history and hold checks to pass.
+func expectPublishable(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("FROM publish_history").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("FROM channel_holds").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+}
+
+func expectClaimed(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("INSERT INTO routing_decisions").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+}
+
+func statusServer(t *testing.T, status int, calls *int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		*calls++
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRetryDeadLetter_UndecodablePayloadIsDropped(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newDeadLetterTestService(t, now, nil)
+	letter, route := webhookLetter(t, "http://unused", 1)
+	letter.Payload = []byte("not json")
+
+	mock.ExpectExec("DELETE FROM channel_dead_letters").WithArgs(letter.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryDeadLetter_AlreadyPublishedIsDropped(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newDeadLetterTestService(t, now, nil)
+	letter, route := webhookLetter(t, "http://unused", 1)
+
+	mock.ExpectQuery("FROM publish_history").WithArgs("doc-1", route.Channel).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec("DELETE FROM channel_dead_letters").WithArgs(letter.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryDeadLetter_OpenCircuitWaitsWithoutAttempt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var calls int
+	srv := statusServer(t, http.StatusOK, &calls)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	s.config.CircuitThreshold = 1
+	letter, route := webhookLetter(t, srv.URL, 2)
+	s.health = map[uuid.UUID]*models.ChannelHealth{
+		route.Target.ID: {ChannelID: route.Target.ID, Circuit: models.CircuitOpen},
+	}
+
+	expectPublishable(mock)
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.Zero(t, calls, "nothing is delivered while the circuit is open")
+	assert.NoError(t, mock.ExpectationsWereMet(), "the letter is neither claimed nor updated")
+}
+
+func TestRetryDeadLetter_OutOfQuotaWaitsWithoutAttempt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var calls int
+	srv := statusServer(t, http.StatusOK, &calls)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	letter, route := webhookLetter(t, srv.URL, 2)
+	route.Target.Config.RateLimit = &models.RateLimitConfig{MaxPerHour: 3}
+
+	expectPublishable(mock)
+	mock.ExpectQuery("SELECT COUNT").WithArgs(route.Channel, now.Add(-time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.Zero(t, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryDeadLetter_DeliveredSinceIsDropped(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var calls int
+	srv := statusServer(t, http.StatusOK, &calls)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	letter, route := webhookLetter(t, srv.URL, 2)
+	key := models.RoutingDecisionKey("doc-1", route.ChannelID, route.Channel)
+
+	expectPublishable(mock)
+	mock.ExpectQuery("INSERT INTO routing_decisions").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("SELECT .+ FROM routing_decisions").
+		WillReturnRows(routingDecisionRows(key, models.RoutingDecisionDelivered, now.Add(-time.Hour)))
+	mock.ExpectExec("DELETE FROM channel_dead_letters").WithArgs(letter.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.Zero(t, calls, "an item delivered since it was dead-lettered is not sent again")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryDeadLetter_SuccessRecordsAndRemoves(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var calls int
+	srv := statusServer(t, http.StatusOK, &calls)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	letter, route := webhookLetter(t, srv.URL, 2)
+
+	expectPublishable(mock)
+	expectClaimed(mock)
+	mock.ExpectExec("UPDATE routing_decisions").
+		WithArgs(sqlmock.AnyArg(), models.RoutingDecisionDelivered).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO publish_history").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectExec("DELETE FROM channel_dead_letters").WithArgs(letter.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryDeadLetter_FailureBacksOff(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var calls int
+	srv := statusServer(t, http.StatusBadRequest, &calls)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	letter, route := webhookLetter(t, srv.URL, 2)
+
+	expectPublishable(mock)
+	expectClaimed(mock)
+	mock.ExpectExec("UPDATE routing_decisions").
+		WithArgs(sqlmock.AnyArg(), models.RoutingDecisionFailed).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE channel_dead_letters").
+		WithArgs(letter.ID, 3, sqlmock.AnyArg(), models.DeadLetterStatusRetrying, now.Add(deadLetterBackoff(3))).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryDeadLetter_LastAttemptMarksDead(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var calls int
+	srv := statusServer(t, http.StatusBadRequest, &calls)
+	s, mock := newDeadLetterTestService(t, now, srv)
+	letter, route := webhookLetter(t, srv.URL, deadLetterMaxAttempts-1)
+
+	expectPublishable(mock)
+	expectClaimed(mock)
+	mock.ExpectExec("UPDATE routing_decisions").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE channel_dead_letters").
+		WithArgs(letter.ID, deadLetterMaxAttempts, sqlmock.AnyArg(), models.DeadLetterStatusDead, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.retryDeadLetter(context.Background(), letter, route)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryDeadLetters_UnavailableChannelMarksDead(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newDeadLetterTestService(t, now, nil)
+	letter, _ := webhookLetter(t, "http://unused", 2)
+
+	mock.ExpectQuery("SELECT (.+) FROM channel_dead_letters").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "channel_id", "channel_name", "content_id", "title", "payload", "last_error",
+			"attempts", "status", "next_retry_at", "created_at", "updated_at",
+		}).AddRow(
+			letter.ID, *letter.ChannelID, letter.ChannelName, letter.ContentID, "", letter.Payload, "boom",
+			letter.Attempts, letter.Status, now, now, now,
+		))
+	mock.ExpectExec("UPDATE channel_dead_letters").
+		WithArgs(letter.ID, 2, errChannelUnavailable.Error(), models.DeadLetterStatusDead, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.retryDeadLetters(context.Background(), nil)
+
+	assert.NoError(t, mock.ExpectationsWereMet(), "a letter of a disabled or deleted channel stops coming due")
+}
+
+func TestDeadLetter_RepeatedFailureBacksOff(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newDeadLetterTestService(t, now, nil)
+	storedID := uuid.New()
+
+	mock.ExpectQuery("INSERT INTO channel_dead_letters").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "attempts"}).AddRow(storedID, 3))
+	mock.ExpectExec("UPDATE channel_dead_letters").
+		WithArgs(storedID, 3, "connection refused", models.DeadLetterStatusRetrying, now.Add(deadLetterBackoff(3))).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	item := &ContentItem{ID: "doc-1", Title: "Title"}
+	s.deadLetter(context.Background(), item, ChannelRoute{Channel: "articles:crime"}, errors.New("connection refused"))
+
+	assert.NoError(t, mock.ExpectationsWereMet(), "an item dead-lettered again keeps its attempts and backs off")
+}
@@ -0,0 +1,122 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/streams"
+)
+
+// Deliverer sends a routed content item to a DB channel whose type is not redis.
+type Deliverer interface {
+	Deliver(ctx context.Context, channel *models.Channel, item *ContentItem) error
+}
+
+// RemoteDeliverer is a Deliverer that creates a post or node on a remote site.
+// The returned ID is kept in the publish history so the item can be retracted.
+type RemoteDeliverer interface {
+	Deliverer
+	DeliverRemote(ctx context.Context, channel *models.Channel, item *ContentItem) (remoteID string, err error)
+}
+
+// Retractor is implemented by deliverers that can take a delivered item down
+// again. published is the item's publish history entry, including its remote ID.
+type Retractor interface {
+	Retract(ctx context.Context, channel *models.Channel, published *models.PublishHistory, reason string) error
+}
+
+// defaultDeliverers returns the built-in deliverers keyed by channel type.
+// Feed channels are stored through feeds (the repository).
+func defaultDeliverers(feeds FeedStore, chat *ChatDeliverer, drupalSite *DrupalDeliverer) map[string]Deliverer {
+	return map[string]Deliverer{
+		models.ChannelTypeWordPress: NewWordPressDeliverer(nil),
+		models.ChannelTypeWebhook:   NewWebhookDeliverer(nil),
+		models.ChannelTypeFeed:      NewFeedDeliverer(feeds),
+		models.ChannelTypeChat:      chat,
+		models.ChannelTypeDrupal:    drupalSite,
+	}
+}
+
+// routeType returns the delivery type of a route; routes without a DB channel are redis.
+func routeType(route ChannelRoute) string {
+	if route.Target == nil {
+		return models.ChannelTypeRedis
+	}
+	return route.Target.DeliveryType()
+}
+
+// deliver publishes the item to Redis, or hands it to the deliverer for the
+// route's channel type. The channel's templates and transforms are applied first.
+// The remote ID is set for channels that create a post or node on another site.
+func (s *Service) deliver(ctx context.Context, item *ContentItem, route ChannelRoute) (remoteID string, err error) {
+	item, err = applyChannelTemplate(route.Target, item)
+	if err != nil {
+		return "", err
+	}
+	item = applyChannelTransform(route.Target, item)
+
+	channelType := routeType(route)
+	if channelType == models.ChannelTypeRedis {
+		return "", s.publishRedis(ctx, item, route)
+	}
+
+	deliverer, ok := s.deliverers[channelType]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownChannelType, channelType)
+	}
+	if remote, isRemote := deliverer.(RemoteDeliverer); isRemote {
+		return remote.DeliverRemote(ctx, route.Target, item)
+	}
+	return "", deliverer.Deliver(ctx, route.Target, item)
+}
+
+// publishRedis publishes the standard JSON payload to the route's Redis
+// channel: its stream, its pub/sub channel, or both (see streams.Producer).
+// Channels linked to a Drupal group also name the group for subscribers.
+func (s *Service) publishRedis(ctx context.Context, item *ContentItem, route ChannelRoute) error {
+	messageJSON, err := json.Marshal(redisPayload(item, route, s.clock.Now()))
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+
+	if publishErr := s.streams.Publish(ctx, route.Channel, messageJSON); publishErr != nil {
+		return fmt.Errorf("publish to Redis: %w", publishErr)
+	}
+	return nil
+}
+
+// redisPayload builds the message published for a Redis route at publishedAt.
+func redisPayload(item *ContentItem, route ChannelRoute, publishedAt time.Time) map[string]any {
+	payload := buildPublishPayload(item, route.Channel, route.ChannelID, publishedAt)
+	if route.Target != nil && route.Target.Config.Drupal != nil {
+		if publisher, ok := payload["publisher"].(map[string]any); ok {
+			publisher["drupal_group_id"] = route.Target.Config.Drupal.GroupID
+		}
+	}
+	return transformPayload(route.Target, item, payload)
+}
+
+// recordStreamLag records the consumer group lag of every stream the router
+// has added to since it started.
+func (s *Service) recordStreamLag(ctx context.Context) {
+	if s.telemetry == nil || s.streams == nil {
+		return
+	}
+	for _, stream := range s.streams.Keys() {
+		lags, err := streams.Lag(ctx, s.redisClient, stream)
+		if err != nil {
+			s.logger.Warn("Failed to read stream lag",
+				infralogger.String("stream", stream),
+				infralogger.Error(err),
+			)
+			continue
+		}
+		for _, lag := range lags {
+			s.telemetry.RecordStreamLag(lag.Stream, lag.Group, lag.Lag, lag.Pending)
+		}
+	}
+}
@@ -0,0 +1,497 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+const (
+	// chatTimeout bounds a single post to a chat webhook.
+	chatTimeout = 15 * time.Second
+	// chatRateWindow is the window max_per_minute is counted over.
+	chatRateWindow = time.Minute
+	// chatMaxQueued bounds the cards waiting per channel; Deliver fails beyond it.
+	chatMaxQueued = 200
+	// chatSummaryRunes bounds the summary shown on a card.
+	chatSummaryRunes = 280
+	// chatTitleRunes is Discord's embed title limit.
+	chatTitleRunes = 256
+	// chatQueryIDPrefix marks click-tracker query IDs minted for chat channels.
+	chatQueryIDPrefix = "ch_"
+	// chatQueryIDHexChars is how much of the channel ID goes into the query ID.
+	chatQueryIDHexChars = 16
+)
+
+// Chat delivery errors.
+var (
+	// ErrChatNotConfigured is returned when a chat channel lacks usable settings.
+	ErrChatNotConfigured = errors.New("chat channel is not configured")
+	// ErrChatQueueFull is returned when a channel already has chatMaxQueued cards waiting.
+	ErrChatQueueFull = errors.New("chat channel queue is full")
+	// ErrChatClosed is returned for items routed after Flush.
+	ErrChatClosed = errors.New("chat deliverer is shut down")
+)
+
+// clickChannelPattern matches the channel slugs click-tracker accepts in ch.
+var clickChannelPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// chatCard is one story as shown in a chat message.
+type chatCard struct {
+	Title       string
+	Link        string // click-tracked when click links are configured
+	URL         string // the article itself
+	Summary     string
+	Source      string
+	ImageURL    string
+	PublishedAt time.Time
+}
+
+// chatQueue holds the cards waiting for one channel and when it last posted.
+type chatQueue struct {
+	channel *models.Channel // latest copy, so config changes apply to queued cards
+	cards   []chatCard
+	sent    []time.Time
+	timer   *time.Timer
+	sending bool
+}
+
+// ChatDeliverer posts story cards to Slack or Discord incoming webhooks.
+// Each channel posts at most max_per_minute messages; items routed while the
+// limit is reached (or during batch_seconds) are combined into one message of
+// up to max_batch cards. Waiting cards live in memory and are flushed on
+// shutdown, so delivery is at most once.
+type ChatDeliverer struct {
+	httpClient   *http.Client
+	logger       infralogger.Logger
+	getenv       func(string) string
+	credentials  CredentialResolver
+	now          func() time.Time
+	clickBaseURL string
+	clickSigner  *clickurl.Signer
+
+	mu     sync.Mutex
+	queues map[uuid.UUID]*chatQueue
+	closed bool
+}
+
+// NewChatDeliverer creates a chat deliverer. A nil httpClient uses the shared
+// infrastructure client with a 15s timeout.
+func NewChatDeliverer(httpClient *http.Client, logger infralogger.Logger) *ChatDeliverer {
+	if httpClient == nil {
+		httpClient = infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: chatTimeout})
+	}
+	return &ChatDeliverer{
+		httpClient: httpClient,
+		logger:     logger,
+		getenv:     os.Getenv,
+		now:        time.Now,
+		queues:     make(map[uuid.UUID]*chatQueue),
+	}
+}
+
+// WithClickLinks makes card titles link through the click-tracker. The key
+// must be one the click-tracker verifies with; an empty URL or secret, or an
+// invalid key ID, keeps plain links.
+func (d *ChatDeliverer) WithClickLinks(baseURL string, key clickurl.Key) *ChatDeliverer {
+	if baseURL == "" || key.Secret == "" {
+		return d
+	}
+	signer, err := clickurl.NewKeyedSigner(key)
+	if err != nil {
+		if d.logger != nil {
+			d.logger.Warn("Click links disabled", infralogger.Error(err))
+		}
+		return d
+	}
+	d.clickBaseURL = baseURL
+	d.clickSigner = signer
+	return d
+}
+
+// Deliver queues a card for the channel and posts right away when the rate
+// limit and batch window allow; otherwise the card goes out with the next batch.
+func (d *ChatDeliverer) Deliver(ctx context.Context, channel *models.Channel, item *ContentItem) error {
+	cfg := channel.Config.Chat
+	if cfg == nil {
+		return fmt.Errorf("%w: missing config.chat", ErrChatNotConfigured)
+	}
+	webhookURL, err := d.webhookURL(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	card := d.buildCard(channel, item)
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return ErrChatClosed
+	}
+	q := d.queueFor(channel)
+	if len(q.cards) >= chatMaxQueued {
+		d.mu.Unlock()
+		return fmt.Errorf("%w: %d cards waiting", ErrChatQueueFull, len(q.cards))
+	}
+	q.cards = append(q.cards, card)
+	if q.timer != nil || q.sending {
+		d.mu.Unlock()
+		return nil
+	}
+
+	delay := max(d.rateDelay(q, cfg), time.Duration(cfg.BatchSeconds)*time.Second)
+	if delay > 0 {
+		d.scheduleLocked(channel.ID, q, delay)
+		d.mu.Unlock()
+		return nil
+	}
+	batch := d.takeLocked(q, cfg)
+	d.mu.Unlock()
+
+	err = d.post(ctx, webhookURL, channel, batch)
+	d.finishSend(channel.ID, q)
+	return err
+}
+
+// useCredentials sets the store webhook_url_credential is read from.
+func (d *ChatDeliverer) useCredentials(resolver CredentialResolver) {
+	d.credentials = resolver
+}
+
+// webhookURL returns the channel's incoming webhook URL from its stored
+// credential or env var, read on every post.
+func (d *ChatDeliverer) webhookURL(ctx context.Context, cfg *models.ChatConfig) (string, error) {
+	webhookURL, err := channelSecret(ctx, d.credentials, d.getenv, cfg.WebhookURLCredential, cfg.WebhookURLEnv)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrChatNotConfigured, err)
+	}
+	return webhookURL, nil
+}
+
+// Flush posts every waiting card, ignoring the rate limit, and stops the
+// batch timers. Items routed after Flush are rejected with ErrChatClosed.
+func (d *ChatDeliverer) Flush(ctx context.Context) {
+	d.mu.Lock()
+	d.closed = true
+	pending := make(map[*models.Channel][]chatCard, len(d.queues))
+	for _, q := range d.queues {
+		if q.timer != nil {
+			q.timer.Stop()
+			q.timer = nil
+		}
+		if len(q.cards) > 0 {
+			pending[q.channel] = q.cards
+			q.cards = nil
+		}
+	}
+	d.mu.Unlock()
+
+	for channel, cards := range pending {
+		webhookURL, err := d.webhookURL(ctx, channel.Config.Chat)
+		if err != nil {
+			d.logDropped(channel, len(cards), err)
+			continue
+		}
+		batchSize := channel.Config.Chat.BatchSize()
+		for start := 0; start < len(cards); start += batchSize {
+			end := min(start+batchSize, len(cards))
+			if err := d.post(ctx, webhookURL, channel, cards[start:end]); err != nil {
+				d.logDropped(channel, end-start, err)
+			}
+		}
+	}
+}
+
+// queueFor returns the channel's queue, creating it on first use. Callers hold d.mu.
+func (d *ChatDeliverer) queueFor(channel *models.Channel) *chatQueue {
+	q, ok := d.queues[channel.ID]
+	if !ok {
+		q = &chatQueue{}
+		d.queues[channel.ID] = q
+	}
+	q.channel = channel
+	return q
+}
+
+// rateDelay returns how long the channel must wait before its next message.
+// Callers hold d.mu.
+func (d *ChatDeliverer) rateDelay(q *chatQueue, cfg *models.ChatConfig) time.Duration {
+	now := d.now()
+	recent := q.sent[:0]
+	for _, sentAt := range q.sent {
+		if now.Sub(sentAt) < chatRateWindow {
+			recent = append(recent, sentAt)
+		}
+	}
+	q.sent = recent
+	if len(q.sent) < cfg.PerMinute() {
+		return 0
+	}
+	return q.sent[0].Add(chatRateWindow).Sub(now)
+}
+
+// takeLocked removes the next batch from the queue and counts it against the
+// rate limit. Callers hold d.mu.
+func (d *ChatDeliverer) takeLocked(q *chatQueue, cfg *models.ChatConfig) []chatCard {
+	n := min(len(q.cards), cfg.BatchSize())
+	batch := make([]chatCard, n)
+	copy(batch, q.cards[:n])
+	q.cards = q.cards[n:]
+	q.sent = append(q.sent, d.now())
+	q.sending = true
+	return batch
+}
+
+// scheduleLocked flushes the channel's queue after delay. Callers hold d.mu.
+func (d *ChatDeliverer) scheduleLocked(channelID uuid.UUID, q *chatQueue, delay time.Duration) {
+	if d.closed {
+		return
+	}
+	q.timer = time.AfterFunc(delay, func() { d.flushQueue(channelID) })
+}
+
+// finishSend clears the in-flight flag and schedules any cards that arrived meanwhile.
+func (d *ChatDeliverer) finishSend(channelID uuid.UUID, q *chatQueue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	q.sending = false
+	if len(q.cards) > 0 && q.timer == nil {
+		d.scheduleLocked(channelID, q, d.rateDelay(q, q.channel.Config.Chat))
+	}
+}
+
+// flushQueue posts the next batch for a channel once its timer fires.
+func (d *ChatDeliverer) flushQueue(channelID uuid.UUID) {
+	d.mu.Lock()
+	q, ok := d.queues[channelID]
+	if !ok || d.closed {
+		d.mu.Unlock()
+		return
+	}
+	q.timer = nil
+	if len(q.cards) == 0 || q.sending {
+		d.mu.Unlock()
+		return
+	}
+	cfg := q.channel.Config.Chat
+	if delay := d.rateDelay(q, cfg); delay > 0 {
+		d.scheduleLocked(channelID, q, delay)
+		d.mu.Unlock()
+		return
+	}
+	channel := q.channel
+	batch := d.takeLocked(q, cfg)
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), chatTimeout)
+	defer cancel()
+	webhookURL, err := d.webhookURL(ctx, cfg)
+	if err == nil {
+		err = d.post(ctx, webhookURL, channel, batch)
+	}
+	if err != nil {
+		d.logDropped(channel, len(batch), err)
+	}
+	d.finishSend(channelID, q)
+}
+
+// logDropped records cards that could not be posted; they are not retried.
+func (d *ChatDeliverer) logDropped(channel *models.Channel, count int, err error) {
+	if d.logger == nil {
+		return
+	}
+	d.logger.Error("Chat post failed, cards dropped",
+		infralogger.String("channel", channel.Name),
+		infralogger.String("channel_id", channel.ID.String()),
+		infralogger.Int("cards", count),
+		infralogger.Error(err),
+	)
+}
+
+// buildCard maps a content item to a chat card.
+func (d *ChatDeliverer) buildCard(channel *models.Channel, item *ContentItem) chatCard {
+	card := chatCard{
+		Title:    item.Title,
+		URL:      item.URL,
+		Summary:  item.OGDescription,
+		Source:   item.Source,
+		ImageURL: item.OGImage,
+	}
+	if card.Title == "" {
+		card.Title = item.OGTitle
+	}
+	if card.URL == "" {
+		card.URL = item.OGURL
+	}
+	if card.Summary == "" {
+		card.Summary = item.Body
+	}
+	card.Title = summarize(card.Title, chatTitleRunes)
+	card.Summary = summarize(card.Summary, chatSummaryRunes)
+	if !item.PublishedDate.IsZero() {
+		card.PublishedAt = item.PublishedDate
+	}
+
+	card.Link = card.URL
+	if d.clickSigner != nil && card.URL != "" {
+		card.Link = d.clickSigner.URL(d.clickBaseURL, clickurl.ClickParams{
+			QueryID:        chatQueryID(channel.ID),
+			ResultID:       item.ID,
+			Position:       1,
+			Page:           1,
+			Timestamp:      d.now().Unix(),
+			DestinationURL: card.URL,
+			Channel:        clickChannel(channel.Slug),
+		})
+	}
+	return card
+}
+
+// clickChannel returns the slug to attribute clicks to, or "" when
+// click-tracker would reject it; the query ID still identifies the channel.
+func clickChannel(slug string) string {
+	if !clickChannelPattern.MatchString(slug) {
+		return ""
+	}
+	return slug
+}
+
+// chatQueryID is the click-tracker query ID for a chat channel, so clicks can
+// be attributed to the channel in click stats.
+func chatQueryID(channelID uuid.UUID) string {
+	return chatQueryIDPrefix + strings.ReplaceAll(channelID.String(), "-", "")[:chatQueryIDHexChars]
+}
+
+// post sends one message with the given cards.
+func (d *ChatDeliverer) post(ctx context.Context, webhookURL string, channel *models.Channel, cards []chatCard) error {
+	if webhookURL == "" {
+		return fmt.Errorf("%w: env var %s is empty", ErrChatNotConfigured, channel.Config.Chat.WebhookURLEnv)
+	}
+
+	body, err := json.Marshal(buildChatMessage(channel, cards))
+	if err != nil {
+		return fmt.Errorf("marshal chat message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send chat message: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyBytes))
+		return &WebhookStatusError{StatusCode: resp.StatusCode, Body: string(raw)}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// buildChatMessage renders cards for the channel's platform.
+func buildChatMessage(channel *models.Channel, cards []chatCard) map[string]any {
+	if channel.Config.Chat.Platform == models.ChatPlatformDiscord {
+		return buildDiscordMessage(cards)
+	}
+	return buildSlackMessage(channel.Name, cards)
+}
+
+// slackEscaper escapes the characters Slack mrkdwn treats as control sequences.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// buildSlackMessage renders cards as Block Kit sections, one per story.
+func buildSlackMessage(channelName string, cards []chatCard) map[string]any {
+	fallback := cards[0].Title
+	if len(cards) > 1 {
+		fallback = fmt.Sprintf("%d new stories in %s", len(cards), channelName)
+	}
+
+	blocks := make([]map[string]any, 0, len(cards)*3)
+	for i, card := range cards {
+		if i > 0 {
+			blocks = append(blocks, map[string]any{"type": "divider"})
+		}
+
+		text := "*" + slackEscaper.Replace(card.Title) + "*"
+		if card.Link != "" {
+			text = "*<" + card.Link + "|" + slackEscaper.Replace(card.Title) + ">*"
+		}
+		if card.Summary != "" {
+			text += "\n" + slackEscaper.Replace(card.Summary)
+		}
+		section := map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": text},
+		}
+		if card.ImageURL != "" {
+			section["accessory"] = map[string]any{"type": "image", "image_url": card.ImageURL, "alt_text": card.Title}
+		}
+		blocks = append(blocks, section)
+
+		meta := []string{}
+		if card.Source != "" {
+			meta = append(meta, slackEscaper.Replace(card.Source))
+		}
+		if card.URL != "" && card.URL != card.Link {
+			// Click links expire with the click-tracker's max_timestamp_age; keep a plain one
+			meta = append(meta, "<"+card.URL+"|original>")
+		}
+		if len(meta) > 0 {
+			blocks = append(blocks, map[string]any{
+				"type":     "context",
+				"elements": []map[string]any{{"type": "mrkdwn", "text": strings.Join(meta, " · ")}},
+			})
+		}
+	}
+	return map[string]any{"text": fallback, "blocks": blocks}
+}
+
+// buildDiscordMessage renders cards as embeds, one per story.
+func buildDiscordMessage(cards []chatCard) map[string]any {
+	embeds := make([]map[string]any, 0, len(cards))
+	for _, card := range cards {
+		embed := map[string]any{"title": card.Title}
+		if card.Link != "" {
+			embed["url"] = card.Link
+		}
+		description := card.Summary
+		if card.URL != "" && card.URL != card.Link {
+			// Click links expire with the click-tracker's max_timestamp_age; keep a plain one
+			description = strings.TrimSpace(description + "\n[original](" + card.URL + ")")
+		}
+		if description != "" {
+			embed["description"] = description
+		}
+		if card.Source != "" {
+			embed["footer"] = map[string]any{"text": card.Source}
+		}
+		if card.ImageURL != "" {
+			embed["thumbnail"] = map[string]any{"url": card.ImageURL}
+		}
+		if !card.PublishedAt.IsZero() {
+			embed["timestamp"] = card.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		embeds = append(embeds, embed)
+	}
+	return map[string]any{"embeds": embeds}
+}
@@ -0,0 +1,200 @@
+//nolint:testpackage // Testing unexported chat deliverer fields requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chatRecorder collects the JSON bodies posted to a test chat webhook.
+type chatRecorder struct {
+	mu     sync.Mutex
+	bodies []map[string]any
+}
+
+func (r *chatRecorder) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, req *http.Request) {
+		raw, _ := io.ReadAll(req.Body)
+		var body map[string]any
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Errorf("decode chat body: %v", err)
+		}
+		r.mu.Lock()
+		r.bodies = append(r.bodies, body)
+		r.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (r *chatRecorder) posts() []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]map[string]any(nil), r.bodies...)
+}
+
+func newTestChatDeliverer(srv *httptest.Server) *ChatDeliverer {
+	d := NewChatDeliverer(srv.Client(), nil)
+	d.getenv = func(name string) string {
+		if name == "NEWSROOM_CHAT_URL" {
+			return srv.URL
+		}
+		return ""
+	}
+	d.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return d
+}
+
+func chatChannel(cfg *models.ChatConfig) *models.Channel {
+	cfg.WebhookURLEnv = "NEWSROOM_CHAT_URL"
+	return &models.Channel{
+		ID:     uuid.New(),
+		Name:   "Sudbury crime",
+		Slug:   "sudbury-crime",
+		Type:   models.ChannelTypeChat,
+		Config: models.ChannelConfig{Chat: cfg},
+	}
+}
+
+func chatItem(id string) *ContentItem {
+	return &ContentItem{
+		ID:            id,
+		Title:         "Story " + id,
+		URL:           "https://news.example.com/" + id,
+		Source:        "Sudbury Star",
+		OGDescription: "What happened & why <it> matters",
+	}
+}
+
+func TestChatDeliverer_SlackCardWithClickLink(t *testing.T) {
+	rec := &chatRecorder{}
+	srv := httptest.NewServer(rec.handler(t))
+	defer srv.Close()
+
+	d := newTestChatDeliverer(srv).WithClickLinks("https://click.example.com", clickurl.Key{ID: "2026a", Secret: "secret"})
+	channel := chatChannel(&models.ChatConfig{Platform: models.ChatPlatformSlack})
+
+	require.NoError(t, d.Deliver(context.Background(), channel, chatItem("doc-1")))
+
+	posts := rec.posts()
+	require.Len(t, posts, 1, "an idle channel posts right away")
+	assert.Equal(t, "Story doc-1", posts[0]["text"])
+
+	raw, err := json.Marshal(posts[0]["blocks"])
+	require.NoError(t, err)
+	blocks := string(raw)
+	assert.Contains(t, blocks, "https://click.example.com/click?q="+chatQueryID(channel.ID)+"\\u0026r=doc-1")
+	assert.Contains(t, blocks, "\\u0026ch="+channel.Slug+"\\u0026sig=2026a.")
+	assert.Contains(t, blocks, "What happened \\u0026amp; why \\u0026lt;it\\u0026gt; matters", "mrkdwn is escaped")
+	assert.Contains(t, blocks, "Sudbury Star · \\u003chttps://news.example.com/doc-1|original\\u003e")
+}
+
+func TestChatDeliverer_RateLimitBatchesIntoNextMessage(t *testing.T) {
+	rec := &chatRecorder{}
+	srv := httptest.NewServer(rec.handler(t))
+	defer srv.Close()
+
+	d := newTestChatDeliverer(srv)
+	channel := chatChannel(&models.ChatConfig{Platform: models.ChatPlatformDiscord, MaxPerMinute: 1})
+
+	for _, id := range []string{"doc-1", "doc-2", "doc-3"} {
+		require.NoError(t, d.Deliver(context.Background(), channel, chatItem(id)))
+	}
+	require.Len(t, rec.posts(), 1, "later items wait for the rate limit")
+
+	d.Flush(context.Background())
+
+	posts := rec.posts()
+	require.Len(t, posts, 2)
+	embeds, _ := posts[1]["embeds"].([]any)
+	require.Len(t, embeds, 2, "waiting items are batched into one message")
+	embed, _ := embeds[0].(map[string]any)
+	assert.Equal(t, "Story doc-2", embed["title"])
+	assert.Equal(t, "https://news.example.com/doc-2", embed["url"])
+	assert.Equal(t, "Sudbury Star", embed["footer"].(map[string]any)["text"])
+
+	err := d.Deliver(context.Background(), channel, chatItem("doc-4"))
+	assert.True(t, errors.Is(err, ErrChatClosed), "got %v", err)
+}
+
+func TestChatDeliverer_BatchWindowRespectsMaxBatch(t *testing.T) {
+	rec := &chatRecorder{}
+	srv := httptest.NewServer(rec.handler(t))
+	defer srv.Close()
+
+	d := newTestChatDeliverer(srv)
+	channel := chatChannel(&models.ChatConfig{
+		Platform:     models.ChatPlatformSlack,
+		BatchSeconds: 300,
+		MaxBatch:     2,
+	})
+
+	for _, id := range []string{"doc-1", "doc-2", "doc-3"} {
+		require.NoError(t, d.Deliver(context.Background(), channel, chatItem(id)))
+	}
+	assert.Empty(t, rec.posts(), "items are held for the batch window")
+
+	d.Flush(context.Background())
+
+	posts := rec.posts()
+	require.Len(t, posts, 2)
+	assert.Equal(t, "2 new stories in Sudbury crime", posts[0]["text"])
+	assert.Equal(t, "Story doc-3", posts[1]["text"])
+}
+
+func TestChatDeliverer_MissingWebhookURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("nothing must be posted without a webhook URL")
+	}))
+	defer srv.Close()
+
+	d := newTestChatDeliverer(srv)
+	channel := chatChannel(&models.ChatConfig{Platform: models.ChatPlatformSlack})
+	channel.Config.Chat.WebhookURLEnv = "UNSET"
+
+	err := d.Deliver(context.Background(), channel, chatItem("doc-1"))
+	assert.True(t, errors.Is(err, ErrChatNotConfigured), "got %v", err)
+}
+
+func TestChatDeliverer_ReturnsWebhookErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_payload", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	d := newTestChatDeliverer(srv)
+	channel := chatChannel(&models.ChatConfig{Platform: models.ChatPlatformSlack})
+
+	err := d.Deliver(context.Background(), channel, chatItem("doc-1"))
+
+	var statusErr *WebhookStatusError
+	require.True(t, errors.As(err, &statusErr), "got %v", err)
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+	assert.True(t, strings.HasPrefix(statusErr.Body, "invalid_payload"))
+}
+
+func TestClickChannel(t *testing.T) {
+	assert.Equal(t, "northern-news", clickChannel("northern-news"))
+	assert.Empty(t, clickChannel("news/north"))
+	assert.Empty(t, clickChannel(strings.Repeat("a", 65)))
+}
+
+func TestChatQueryID_FitsClickTracker(t *testing.T) {
+	id := chatQueryID(uuid.New())
+	assert.True(t, strings.HasPrefix(id, chatQueryIDPrefix))
+	assert.LessOrEqual(t, len(id), 32, "click_events.query_id is VARCHAR(32)")
+}
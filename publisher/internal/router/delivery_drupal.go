@@ -0,0 +1,262 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+const (
+	// drupalTimeout bounds a single JSON:API request or image download.
+	drupalTimeout = 30 * time.Second
+	// drupalMaxImageBytes bounds the og_image download.
+	drupalMaxImageBytes = 10 << 20
+	// drupalMaxFilenameLength keeps uploaded filenames within Drupal's limits.
+	drupalMaxFilenameLength = 100
+)
+
+// imageExtensions are the extensions given to images whose URL has none
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var (
+	// ErrDrupalNotConfigured is returned when drupal channels are routed to without a Drupal site.
+	ErrDrupalNotConfigured = errors.New("drupal site is not configured")
+	// errNotAnImage is returned when the og_image URL does not serve an image.
+	errNotAnImage = errors.New("og_image is not an image")
+)
+
+// DrupalDeliverer creates a node on the Drupal site for each routed item. The
+// item's og_image is downloaded, uploaded through the JSON:API file endpoint,
+// wrapped in a media entity, and referenced from the node's image field.
+type DrupalDeliverer struct {
+	baseURL     string
+	token       string
+	httpClient  *http.Client
+	logger      infralogger.Logger
+	credentials CredentialResolver
+}
+
+// NewDrupalDeliverer creates a Drupal deliverer for the site at baseURL. A nil
+// httpClient uses the shared infrastructure client with a 30s timeout.
+func NewDrupalDeliverer(baseURL, token string, httpClient *http.Client, logger infralogger.Logger) *DrupalDeliverer {
+	if httpClient == nil {
+		httpClient = infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: drupalTimeout})
+	}
+	return &DrupalDeliverer{baseURL: baseURL, token: token, httpClient: httpClient, logger: logger}
+}
+
+// Deliver creates the node.
+func (d *DrupalDeliverer) Deliver(ctx context.Context, channel *models.Channel, item *ContentItem) error {
+	_, err := d.DeliverRemote(ctx, channel, item)
+	return err
+}
+
+// DeliverRemote creates the node and returns "<node type>/<uuid>". An image
+// that cannot be downloaded is skipped and the node is created without it;
+// Drupal rejecting the upload fails the delivery.
+func (d *DrupalDeliverer) DeliverRemote(ctx context.Context, channel *models.Channel, item *ContentItem) (string, error) {
+	if d.baseURL == "" {
+		return "", fmt.Errorf("%w: DRUPAL_URL is empty", ErrDrupalNotConfigured)
+	}
+
+	var cfg models.DrupalConfig
+	if channel.Config.Drupal != nil {
+		cfg = *channel.Config.Drupal
+	}
+	cfg = cfg.WithDefaults()
+
+	client, err := d.client(ctx, &cfg)
+	if err != nil {
+		return "", err
+	}
+	node := buildDrupalNode(item, &cfg)
+
+	if cfg.ImageField != "" && item.OGImage != "" {
+		img, fetchErr := d.fetchImage(ctx, item.OGImage)
+		if fetchErr != nil {
+			d.logger.Warn("Skipping Drupal node image",
+				infralogger.String("channel", channel.Name),
+				infralogger.String("content_id", item.ID),
+				infralogger.String("og_image", item.OGImage),
+				infralogger.Error(fetchErr),
+			)
+		} else {
+			img.Alt = item.Title
+			media, mediaErr := client.CreateImageMedia(ctx, cfg.MediaType, cfg.MediaFileField, img)
+			if mediaErr != nil {
+				return "", fmt.Errorf("create drupal image media: %w", mediaErr)
+			}
+			node.MediaID = media.ID
+		}
+	}
+
+	created, err := client.CreateNode(ctx, node)
+	if err != nil {
+		return "", fmt.Errorf("create drupal node: %w", err)
+	}
+	return node.Type + "/" + created.ID, nil
+}
+
+// Retract unpublishes the node created for the item. The node is kept so an
+// editor can review it.
+func (d *DrupalDeliverer) Retract(
+	ctx context.Context, channel *models.Channel, published *models.PublishHistory, _ string,
+) error {
+	if d.baseURL == "" {
+		return fmt.Errorf("%w: DRUPAL_URL is empty", ErrDrupalNotConfigured)
+	}
+	nodeType, nodeID, ok := strings.Cut(published.RemoteID, "/")
+	if !ok || nodeType == "" || nodeID == "" {
+		return fmt.Errorf("%w: no drupal node recorded", ErrNotRetractable)
+	}
+
+	var cfg models.DrupalConfig
+	if channel != nil && channel.Config.Drupal != nil {
+		cfg = *channel.Config.Drupal
+	}
+	client, err := d.client(ctx, &cfg)
+	if err != nil {
+		return err
+	}
+	if unpublishErr := client.UnpublishNode(ctx, nodeType, nodeID); unpublishErr != nil {
+		return fmt.Errorf("unpublish drupal node: %w", unpublishErr)
+	}
+	return nil
+}
+
+// Probe checks that the Drupal site's JSON:API answers with the channel's token.
+func (d *DrupalDeliverer) Probe(ctx context.Context, channel *models.Channel) error {
+	if d.baseURL == "" {
+		return fmt.Errorf("%w: DRUPAL_URL is empty", ErrDrupalNotConfigured)
+	}
+
+	var cfg models.DrupalConfig
+	if channel.Config.Drupal != nil {
+		cfg = *channel.Config.Drupal
+	}
+	client, err := d.client(ctx, &cfg)
+	if err != nil {
+		return err
+	}
+	if pingErr := client.Ping(ctx); pingErr != nil {
+		return fmt.Errorf("ping drupal: %w", pingErr)
+	}
+	return nil
+}
+
+// useCredentials sets the store token_credential is read from.
+func (d *DrupalDeliverer) useCredentials(resolver CredentialResolver) {
+	d.credentials = resolver
+}
+
+// client builds a JSON:API client authenticated with the channel's stored
+// token credential, or DRUPAL_TOKEN when the channel names none.
+func (d *DrupalDeliverer) client(ctx context.Context, cfg *models.DrupalConfig) (*drupal.Client, error) {
+	token := d.token
+	if cfg.TokenCredential != "" {
+		var err error
+		token, err = channelSecret(ctx, d.credentials, nil, cfg.TokenCredential, "")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDrupalNotConfigured, err)
+		}
+	}
+	return drupal.NewClient(drupal.Config{BaseURL: d.baseURL, Token: token}, d.httpClient), nil
+}
+
+// buildDrupalNode maps a content item onto a node of the channel's bundle
+func buildDrupalNode(item *ContentItem, cfg *models.DrupalConfig) *drupal.Node {
+	body := item.Body
+	if body == "" {
+		body = item.RawText
+	}
+
+	return &drupal.Node{
+		Type:       cfg.NodeType,
+		Title:      item.Title,
+		Body:       body,
+		BodyFormat: cfg.BodyFormat,
+		Summary:    item.OGDescription,
+		Published:  !cfg.Unpublished,
+		ImageField: cfg.ImageField,
+		MediaType:  cfg.MediaType,
+	}
+}
+
+// fetchImage downloads an http(s) image of at most drupalMaxImageBytes
+func (d *DrupalDeliverer) fetchImage(ctx context.Context, imageURL string) (*drupal.Image, error) {
+	parsed, err := url.Parse(imageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: unsupported URL", errNotAnImage)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download returned %d", resp.StatusCode)
+	}
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("%w: content type %q", errNotAnImage, contentType)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, drupalMaxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	if len(data) > drupalMaxImageBytes {
+		return nil, fmt.Errorf("image exceeds %d bytes", drupalMaxImageBytes)
+	}
+
+	return &drupal.Image{Filename: imageFilename(parsed.Path, contentType), Data: data}, nil
+}
+
+// imageFilename derives a safe upload filename from the URL path, adding an
+// extension for the content type when the path has none.
+func imageFilename(urlPath, contentType string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, path.Base(urlPath))
+	name = strings.Trim(name, ".-")
+	if len(name) > drupalMaxFilenameLength {
+		name = name[len(name)-drupalMaxFilenameLength:]
+	}
+	if name == "" {
+		name = "image"
+	}
+
+	if path.Ext(name) == "" {
+		name += imageExtensions[contentType]
+	}
+	return name
+}
@@ -0,0 +1,156 @@
+//nolint:testpackage // Testing unexported Drupal mapping requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDrupalSite records the JSON:API requests of a delivery and serves one image
+type fakeDrupalSite struct {
+	mu       sync.Mutex
+	paths    []string
+	node     map[string]any
+	filename string
+}
+
+func (f *fakeDrupalSite) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/images/council.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("jpeg-bytes"))
+			return
+		case "/images/page":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html></html>"))
+			return
+		}
+
+		f.paths = append(f.paths, r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/jsonapi/media/image/field_media_image":
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "jpeg-bytes", string(body))
+			f.filename = r.Header.Get("Content-Disposition")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"file-1"}}`))
+		case "/jsonapi/media/image":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"media-1"}}`))
+		case "/jsonapi/node/article/node-1":
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&f.node))
+			_, _ = w.Write([]byte(`{"data":{"id":"node-1"}}`))
+		case "/jsonapi/node/article":
+			assert.Equal(t, "application/vnd.api+json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&f.node))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"node-1","attributes":{"drupal_internal__id":42}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func drupalChannel(imageField string) *models.Channel {
+	return &models.Channel{
+		Name:   "Sudbury Drupal",
+		Type:   models.ChannelTypeDrupal,
+		Config: models.ChannelConfig{Drupal: &models.DrupalConfig{ImageField: imageField}},
+	}
+}
+
+func TestDrupalDeliverer_AttachesImageAsMedia(t *testing.T) {
+	site := &fakeDrupalSite{}
+	srv := httptest.NewServer(site.handler(t))
+	defer srv.Close()
+
+	d := NewDrupalDeliverer(srv.URL, "token", srv.Client(), infralogger.NewNop())
+	item := &ContentItem{
+		ID: "doc-1", Title: "Council approves budget", Body: "<p>Story</p>",
+		OGDescription: "Budget passes", OGImage: srv.URL + "/images/council.jpg",
+	}
+
+	require.NoError(t, d.Deliver(context.Background(), drupalChannel("field_media"), item))
+
+	assert.Equal(t, []string{
+		"/jsonapi/media/image/field_media_image", "/jsonapi/media/image", "/jsonapi/node/article",
+	}, site.paths)
+	assert.Equal(t, `file; filename=council.jpg`, site.filename)
+
+	data, _ := site.node["data"].(map[string]any)
+	attributes, _ := data["attributes"].(map[string]any)
+	assert.Equal(t, "Council approves budget", attributes["title"])
+	assert.Equal(t, true, attributes["status"])
+	assert.Equal(t, map[string]any{"value": "<p>Story</p>", "format": "basic_html", "summary": "Budget passes"},
+		attributes["body"])
+	relationships, _ := data["relationships"].(map[string]any)
+	assert.Equal(t, map[string]any{"data": map[string]any{"type": "media--image", "id": "media-1"}},
+		relationships["field_media"])
+}
+
+func TestDrupalDeliverer_SkipsUnusableImage(t *testing.T) {
+	site := &fakeDrupalSite{}
+	srv := httptest.NewServer(site.handler(t))
+	defer srv.Close()
+
+	d := NewDrupalDeliverer(srv.URL, "token", srv.Client(), infralogger.NewNop())
+	item := &ContentItem{Title: "t", OGImage: srv.URL + "/images/page"}
+
+	require.NoError(t, d.Deliver(context.Background(), drupalChannel("field_media"), item))
+
+	assert.Equal(t, []string{"/jsonapi/node/article"}, site.paths)
+	data, _ := site.node["data"].(map[string]any)
+	assert.NotContains(t, data, "relationships")
+}
+
+func TestDrupalDeliverer_RetractUnpublishesRecordedNode(t *testing.T) {
+	site := &fakeDrupalSite{}
+	srv := httptest.NewServer(site.handler(t))
+	defer srv.Close()
+
+	d := NewDrupalDeliverer(srv.URL, "token", srv.Client(), infralogger.NewNop())
+	remoteID, err := d.DeliverRemote(context.Background(), drupalChannel(""), &ContentItem{Title: "t"})
+	require.NoError(t, err)
+	assert.Equal(t, "article/node-1", remoteID)
+
+	published := &models.PublishHistory{ContentID: "doc-1", RemoteID: remoteID}
+	require.NoError(t, d.Retract(context.Background(), drupalChannel(""), published, "misclassified"))
+
+	assert.Equal(t, []string{"/jsonapi/node/article", "/jsonapi/node/article/node-1"}, site.paths)
+	data, _ := site.node["data"].(map[string]any)
+	assert.Equal(t, map[string]any{"status": false}, data["attributes"])
+
+	err = d.Retract(context.Background(), drupalChannel(""), &models.PublishHistory{}, "")
+	assert.ErrorIs(t, err, ErrNotRetractable)
+}
+
+func TestDrupalDeliverer_NotConfigured(t *testing.T) {
+	d := NewDrupalDeliverer("", "", nil, infralogger.NewNop())
+
+	err := d.Deliver(context.Background(), drupalChannel(""), &ContentItem{Title: "t"})
+	assert.True(t, errors.Is(err, ErrDrupalNotConfigured))
+}
+
+func TestImageFilename(t *testing.T) {
+	assert.Equal(t, "council.jpg", imageFilename("/images/council.jpg", "image/jpeg"))
+	assert.Equal(t, "photo-1.jpg", imageFilename("/a/photo(1)", "image/jpeg"))
+	assert.Equal(t, "image.png", imageFilename("/", "image/png"))
+}
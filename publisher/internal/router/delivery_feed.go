@@ -0,0 +1,91 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// feedSummaryRunes bounds the summary taken from the body when an item has no OG description.
+const feedSummaryRunes = 300
+
+// FeedStore persists the items of feed channels.
+type FeedStore interface {
+	AddChannelFeedItem(ctx context.Context, item *models.ChannelFeedItem, maxItems int) error
+	RemoveChannelFeedItem(ctx context.Context, channelID uuid.UUID, contentID string) error
+}
+
+// FeedDeliverer appends routed items to the channel's feed; the API renders
+// the stored items as RSS or Atom.
+type FeedDeliverer struct {
+	store FeedStore
+}
+
+// NewFeedDeliverer creates a feed deliverer backed by store.
+func NewFeedDeliverer(store FeedStore) *FeedDeliverer {
+	return &FeedDeliverer{store: store}
+}
+
+// Deliver stores the item, keeping only the channel's newest max_items.
+func (d *FeedDeliverer) Deliver(ctx context.Context, channel *models.Channel, item *ContentItem) error {
+	if err := d.store.AddChannelFeedItem(ctx, buildFeedItem(channel, item), models.FeedItemLimit(channel.Config.Feed)); err != nil {
+		return fmt.Errorf("add feed item: %w", err)
+	}
+	return nil
+}
+
+// Retract removes the item from the channel's feed.
+func (d *FeedDeliverer) Retract(ctx context.Context, channel *models.Channel, published *models.PublishHistory, _ string) error {
+	if err := d.store.RemoveChannelFeedItem(ctx, channel.ID, published.ContentID); err != nil {
+		return fmt.Errorf("remove feed item: %w", err)
+	}
+	return nil
+}
+
+// buildFeedItem maps a content item to a feed entry.
+func buildFeedItem(channel *models.Channel, item *ContentItem) *models.ChannelFeedItem {
+	feedItem := &models.ChannelFeedItem{
+		ChannelID: channel.ID,
+		ContentID: item.ID,
+		Title:     item.Title,
+		URL:       item.URL,
+		Summary:   item.OGDescription,
+		Source:    item.Source,
+		ImageURL:  item.OGImage,
+		Topics:    item.Topics,
+	}
+	if feedItem.Title == "" {
+		feedItem.Title = item.OGTitle
+	}
+	if feedItem.URL == "" {
+		feedItem.URL = item.OGURL
+	}
+	if feedItem.Summary == "" {
+		feedItem.Summary = summarize(item.Body, feedSummaryRunes)
+	}
+	if feedItem.Topics == nil {
+		feedItem.Topics = []string{}
+	}
+	if !item.PublishedDate.IsZero() {
+		published := item.PublishedDate
+		feedItem.PublishedAt = &published
+	}
+	return feedItem
+}
+
+// summarize collapses whitespace and cuts text to at most maxRunes runes on a word boundary.
+func summarize(text string, maxRunes int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	cut := string(runes[:maxRunes])
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
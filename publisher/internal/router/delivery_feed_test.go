@@ -0,0 +1,103 @@
+//nolint:testpackage // Testing unexported feed summary limits requires same package access
+package router
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFeedStore struct {
+	items    []*models.ChannelFeedItem
+	maxItems int
+	err      error
+}
+
+func (f *fakeFeedStore) AddChannelFeedItem(_ context.Context, item *models.ChannelFeedItem, maxItems int) error {
+	f.items = append(f.items, item)
+	f.maxItems = maxItems
+	return f.err
+}
+
+func (f *fakeFeedStore) RemoveChannelFeedItem(_ context.Context, channelID uuid.UUID, contentID string) error {
+	kept := f.items[:0]
+	for _, item := range f.items {
+		if item.ChannelID != channelID || item.ContentID != contentID {
+			kept = append(kept, item)
+		}
+	}
+	f.items = kept
+	return f.err
+}
+
+func TestFeedDeliverer_StoresItem(t *testing.T) {
+	store := &fakeFeedStore{}
+	channel := &models.Channel{
+		ID:     uuid.New(),
+		Type:   models.ChannelTypeFeed,
+		Config: models.ChannelConfig{Feed: &models.FeedConfig{MaxItems: 25}},
+	}
+	published := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	err := NewFeedDeliverer(store).Deliver(context.Background(), channel, &ContentItem{
+		ID:            "doc-1",
+		Title:         "Council votes",
+		URL:           "https://news.example/council",
+		Source:        "news.example",
+		OGDescription: "Council votes 7-2",
+		OGImage:       "https://news.example/img.jpg",
+		Topics:        []string{"politics"},
+		PublishedDate: published,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, store.items, 1)
+	assert.Equal(t, 25, store.maxItems)
+	got := store.items[0]
+	assert.Equal(t, channel.ID, got.ChannelID)
+	assert.Equal(t, "doc-1", got.ContentID)
+	assert.Equal(t, "Council votes 7-2", got.Summary)
+	assert.Equal(t, "https://news.example/img.jpg", got.ImageURL)
+	require.NotNil(t, got.PublishedAt)
+	assert.True(t, published.Equal(*got.PublishedAt))
+}
+
+func TestFeedDeliverer_DefaultsAndErrors(t *testing.T) {
+	store := &fakeFeedStore{err: errors.New("db down")}
+	channel := &models.Channel{ID: uuid.New(), Type: models.ChannelTypeFeed}
+
+	err := NewFeedDeliverer(store).Deliver(context.Background(), channel, &ContentItem{
+		ID:    "doc-2",
+		Title: "Untitled",
+		Body:  strings.Repeat("word ", 200),
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, models.FeedDefaultMaxItems, store.maxItems)
+	got := store.items[0]
+	assert.Nil(t, got.PublishedAt)
+	assert.NotNil(t, got.Topics)
+	assert.True(t, strings.HasSuffix(got.Summary, "…"))
+	assert.LessOrEqual(t, len([]rune(got.Summary)), feedSummaryRunes+1)
+}
+
+func TestFeedDeliverer_RetractRemovesItem(t *testing.T) {
+	store := &fakeFeedStore{}
+	channel := &models.Channel{ID: uuid.New(), Type: models.ChannelTypeFeed}
+	d := NewFeedDeliverer(store)
+	require.NoError(t, d.Deliver(context.Background(), channel, &ContentItem{ID: "doc-1", Title: "One"}))
+	require.NoError(t, d.Deliver(context.Background(), channel, &ContentItem{ID: "doc-2", Title: "Two"}))
+
+	err := d.Retract(context.Background(), channel, &models.PublishHistory{ContentID: "doc-1"}, "removed by source")
+	require.NoError(t, err)
+
+	require.Len(t, store.items, 1)
+	assert.Equal(t, "doc-2", store.items[0].ContentID)
+}
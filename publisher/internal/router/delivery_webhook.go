@@ -0,0 +1,227 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	"github.com/jonesrussell/north-cloud/infrastructure/retry"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// Webhook request headers set by the publisher.
+const (
+	// WebhookSignatureHeader carries "sha256=<hex HMAC of timestamp + "." + body>"
+	WebhookSignatureHeader = "X-North-Cloud-Signature"
+	// WebhookTimestampHeader carries the Unix time the request was signed
+	WebhookTimestampHeader = "X-North-Cloud-Timestamp"
+)
+
+const (
+	// webhookTimeout bounds a single delivery attempt.
+	webhookTimeout = 15 * time.Second
+	// webhookRetryDelay is the first backoff delay between attempts.
+	webhookRetryDelay = 500 * time.Millisecond
+	// webhookMaxRetryDelay caps the backoff delay.
+	webhookMaxRetryDelay = 10 * time.Second
+	// webhookErrorBodyBytes bounds how much of an error response is kept.
+	webhookErrorBodyBytes = 512
+)
+
+// pingAction is the "action" of the health probe a webhook channel receives.
+const pingAction = "ping"
+
+// ErrWebhookNotConfigured is returned when a webhook channel lacks usable settings.
+var ErrWebhookNotConfigured = errors.New("webhook channel is not configured")
+
+// WebhookStatusError is a non-2xx response from a webhook endpoint.
+type WebhookStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *WebhookStatusError) Error() string {
+	return fmt.Sprintf("webhook returned %d: %s", e.StatusCode, e.Body)
+}
+
+// WebhookDeliverer POSTs the standard publish payload (the same JSON Redis
+// subscribers receive) to the channel's URL.
+type WebhookDeliverer struct {
+	httpClient  *http.Client
+	getenv      func(string) string
+	credentials CredentialResolver
+	now         func() time.Time
+	retryDelay  time.Duration
+}
+
+// NewWebhookDeliverer creates a webhook deliverer. A nil httpClient uses the
+// shared infrastructure client with a 15s timeout.
+func NewWebhookDeliverer(httpClient *http.Client) *WebhookDeliverer {
+	if httpClient == nil {
+		httpClient = infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: webhookTimeout})
+	}
+	return &WebhookDeliverer{
+		httpClient: httpClient,
+		getenv:     os.Getenv,
+		now:        time.Now,
+		retryDelay: webhookRetryDelay,
+	}
+}
+
+// Deliver sends the payload, retrying 5xx, 429, and network errors up to the
+// channel's max_attempts.
+func (d *WebhookDeliverer) Deliver(ctx context.Context, channel *models.Channel, item *ContentItem) error {
+	return d.send(ctx, channel, webhookPayload(channel, item, d.now()))
+}
+
+// webhookPayload builds the body posted for an item at sentAt.
+func webhookPayload(channel *models.Channel, item *ContentItem, sentAt time.Time) map[string]any {
+	channelID := channel.ID
+	return transformPayload(channel, item, buildPublishPayload(item, channel.RedisChannel, &channelID, sentAt))
+}
+
+// Retract sends a retraction payload (action "retract") for the item, signed
+// and retried like a delivery.
+func (d *WebhookDeliverer) Retract(
+	ctx context.Context, channel *models.Channel, published *models.PublishHistory, reason string,
+) error {
+	channelID := channel.ID
+	payload := buildRetractionPayload(published.ContentID, reason, channel.RedisChannel, &channelID, d.now())
+	return d.send(ctx, channel, payload)
+}
+
+// useCredentials sets the store secret_credential is read from.
+func (d *WebhookDeliverer) useCredentials(resolver CredentialResolver) {
+	d.credentials = resolver
+}
+
+// Probe POSTs a signed ping (action "ping") once, without retries. Any 2xx
+// response counts as healthy.
+func (d *WebhookDeliverer) Probe(ctx context.Context, channel *models.Channel) error {
+	cfg, secret, err := d.settings(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	channelID := channel.ID
+	body, err := json.Marshal(map[string]any{
+		"action": pingAction,
+		"publisher": map[string]any{
+			"channel_id": &channelID,
+			"channel":    channel.RedisChannel,
+			"sent_at":    d.now().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook ping: %w", err)
+	}
+
+	return d.post(ctx, cfg, body, secret)
+}
+
+// settings returns the channel's webhook config and signing secret. The
+// secret is read on every call, so rotating it needs no restart.
+func (d *WebhookDeliverer) settings(ctx context.Context, channel *models.Channel) (*models.WebhookConfig, string, error) {
+	cfg := channel.Config.Webhook
+	if cfg == nil {
+		return nil, "", fmt.Errorf("%w: missing config.webhook", ErrWebhookNotConfigured)
+	}
+
+	var secret string
+	if cfg.SecretEnv != "" || cfg.SecretCredential != "" {
+		var err error
+		secret, err = channelSecret(ctx, d.credentials, d.getenv, cfg.SecretCredential, cfg.SecretEnv)
+		if err != nil {
+			// Never fall back to unsigned delivery for a channel that expects signatures
+			return nil, "", fmt.Errorf("%w: %w", ErrWebhookNotConfigured, err)
+		}
+	}
+
+	return cfg, secret, nil
+}
+
+// send marshals and POSTs a payload with retries.
+func (d *WebhookDeliverer) send(ctx context.Context, channel *models.Channel, payload map[string]any) error {
+	cfg, secret, err := d.settings(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	retryCfg := retry.Config{
+		MaxAttempts:  cfg.Attempts(),
+		InitialDelay: d.retryDelay,
+		MaxDelay:     webhookMaxRetryDelay,
+		Multiplier:   2.0,
+		IsRetryable:  isRetryableWebhookError,
+	}
+	return retry.Retry(ctx, retryCfg, func() error {
+		return d.post(ctx, cfg, body, secret)
+	})
+}
+
+// post makes one signed delivery attempt.
+func (d *WebhookDeliverer) post(ctx context.Context, cfg *models.WebhookConfig, body []byte, secret string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		timestamp := strconv.FormatInt(d.now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, timestamp, body))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyBytes))
+		return &WebhookStatusError{StatusCode: resp.StatusCode, Body: string(raw)}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// SignWebhookPayload returns the signature header value for a payload.
+// Consumers recompute it over "<timestamp>.<raw body>" to verify a request.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// isRetryableWebhookError retries server errors, rate limiting, and transport failures.
+func isRetryableWebhookError(err error) bool {
+	var statusErr *WebhookStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return retry.DefaultIsRetryable(err) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,124 @@
+//nolint:testpackage // Testing unexported webhook deliverer fields requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookDeliverer(srv *httptest.Server, env map[string]string) *WebhookDeliverer {
+	d := NewWebhookDeliverer(srv.Client())
+	d.getenv = func(name string) string { return env[name] }
+	d.now = func() time.Time { return time.Unix(1700000000, 0) }
+	d.retryDelay = time.Millisecond
+	return d
+}
+
+func webhookChannel(cfg *models.WebhookConfig) *models.Channel {
+	return &models.Channel{
+		ID:           uuid.New(),
+		RedisChannel: "partners:acme",
+		Type:         models.ChannelTypeWebhook,
+		Config:       models.ChannelConfig{Webhook: cfg},
+	}
+}
+
+func TestWebhookDeliverer_SignsPayload(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+		timestamp string
+		apiKey    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(WebhookSignatureHeader)
+		timestamp = r.Header.Get(WebhookTimestampHeader)
+		apiKey = r.Header.Get("X-Api-Key")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	d := newTestWebhookDeliverer(srv, map[string]string{"ACME_WEBHOOK_SECRET": "s3cret"})
+	channel := webhookChannel(&models.WebhookConfig{
+		URL:       srv.URL,
+		SecretEnv: "ACME_WEBHOOK_SECRET",
+		Headers:   map[string]string{"X-Api-Key": "abc"},
+	})
+
+	require.NoError(t, d.Deliver(context.Background(), channel, &ContentItem{ID: "doc-1", Title: "Hello"}))
+
+	assert.Equal(t, "1700000000", timestamp)
+	assert.Equal(t, SignWebhookPayload("s3cret", timestamp, body), signature)
+	assert.Equal(t, "abc", apiKey)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "doc-1", payload["id"])
+	assert.Equal(t, "Hello", payload["title"])
+	publisher, _ := payload["publisher"].(map[string]any)
+	assert.Equal(t, "partners:acme", publisher["channel"])
+	assert.Equal(t, channel.ID.String(), publisher["channel_id"])
+}
+
+func TestWebhookDeliverer_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d := newTestWebhookDeliverer(srv, nil)
+	channel := webhookChannel(&models.WebhookConfig{URL: srv.URL})
+
+	require.NoError(t, d.Deliver(context.Background(), channel, &ContentItem{ID: "doc-1"}))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestWebhookDeliverer_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		http.Error(w, "bad payload", http.StatusUnprocessableEntity)
+	}))
+	defer srv.Close()
+
+	d := newTestWebhookDeliverer(srv, nil)
+	channel := webhookChannel(&models.WebhookConfig{URL: srv.URL, MaxAttempts: 5})
+
+	err := d.Deliver(context.Background(), channel, &ContentItem{ID: "doc-1"})
+
+	var statusErr *WebhookStatusError
+	require.True(t, errors.As(err, &statusErr), "got %v", err)
+	assert.Equal(t, http.StatusUnprocessableEntity, statusErr.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestWebhookDeliverer_MissingSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("unsigned request must not be sent")
+	}))
+	defer srv.Close()
+
+	d := newTestWebhookDeliverer(srv, nil)
+	channel := webhookChannel(&models.WebhookConfig{URL: srv.URL, SecretEnv: "UNSET"})
+
+	err := d.Deliver(context.Background(), channel, &ContentItem{ID: "doc-1"})
+	assert.True(t, errors.Is(err, ErrWebhookNotConfigured))
+}
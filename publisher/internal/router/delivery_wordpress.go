@@ -0,0 +1,168 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/wordpress"
+)
+
+// wordPressTimeout bounds a single create-post request.
+const wordPressTimeout = 30 * time.Second
+
+// ErrWordPressNotConfigured is returned when a wordpress channel lacks usable settings.
+var ErrWordPressNotConfigured = errors.New("wordpress channel is not configured")
+
+// WordPressDeliverer creates a WordPress post for each routed item, using the
+// same fields the Redis payload gives Drupal subscribers.
+type WordPressDeliverer struct {
+	httpClient  *http.Client
+	getenv      func(string) string
+	credentials CredentialResolver
+}
+
+// NewWordPressDeliverer creates a WordPress deliverer. A nil httpClient uses
+// the shared infrastructure client with a 30s timeout.
+func NewWordPressDeliverer(httpClient *http.Client) *WordPressDeliverer {
+	if httpClient == nil {
+		httpClient = infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: wordPressTimeout})
+	}
+	return &WordPressDeliverer{httpClient: httpClient, getenv: os.Getenv}
+}
+
+// Deliver creates the post.
+func (d *WordPressDeliverer) Deliver(ctx context.Context, channel *models.Channel, item *ContentItem) error {
+	_, err := d.DeliverRemote(ctx, channel, item)
+	return err
+}
+
+// DeliverRemote creates the post and returns its ID.
+func (d *WordPressDeliverer) DeliverRemote(ctx context.Context, channel *models.Channel, item *ContentItem) (string, error) {
+	client, err := d.client(ctx, channel)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := client.CreatePost(ctx, buildWordPressPost(item, channel.Config.WordPress))
+	if err != nil {
+		return "", fmt.Errorf("create wordpress post: %w", err)
+	}
+	return strconv.Itoa(created.ID), nil
+}
+
+// Retract moves the post created for the item to the trash.
+func (d *WordPressDeliverer) Retract(
+	ctx context.Context, channel *models.Channel, published *models.PublishHistory, _ string,
+) error {
+	postID, err := strconv.Atoi(published.RemoteID)
+	if err != nil {
+		return fmt.Errorf("%w: no wordpress post ID recorded", ErrNotRetractable)
+	}
+
+	client, err := d.client(ctx, channel)
+	if err != nil {
+		return err
+	}
+	if trashErr := client.TrashPost(ctx, postID); trashErr != nil {
+		return fmt.Errorf("trash wordpress post: %w", trashErr)
+	}
+	return nil
+}
+
+// Probe checks that the channel's site is up and accepts its application password.
+func (d *WordPressDeliverer) Probe(ctx context.Context, channel *models.Channel) error {
+	client, err := d.client(ctx, channel)
+	if err != nil {
+		return err
+	}
+	if pingErr := client.Ping(ctx); pingErr != nil {
+		return fmt.Errorf("ping wordpress: %w", pingErr)
+	}
+	return nil
+}
+
+// useCredentials sets the store password_credential is read from.
+func (d *WordPressDeliverer) useCredentials(resolver CredentialResolver) {
+	d.credentials = resolver
+}
+
+// client builds a client for the channel's site. The application password is
+// read from the stored credential or env var on every call, so rotating it
+// needs no restart.
+func (d *WordPressDeliverer) client(ctx context.Context, channel *models.Channel) (*wordpress.Client, error) {
+	cfg := channel.Config.WordPress
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: missing config.wordpress", ErrWordPressNotConfigured)
+	}
+	password, err := channelSecret(ctx, d.credentials, d.getenv, cfg.PasswordCredential, cfg.PasswordEnv)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWordPressNotConfigured, err)
+	}
+
+	return wordpress.NewClient(wordpress.Config{
+		BaseURL:  cfg.BaseURL,
+		Username: cfg.Username,
+		Password: password,
+	}, d.httpClient), nil
+}
+
+// buildWordPressPost maps a content item onto a WordPress post: title, body,
+// OG fields and canonical URL (as post meta), and categories from topics.
+// Post meta is only stored for keys the site registers with show_in_rest.
+func buildWordPressPost(item *ContentItem, cfg *models.WordPressConfig) *wordpress.Post {
+	status := cfg.Status
+	if status == "" {
+		status = models.WordPressStatusPublish
+	}
+
+	content := item.Body
+	if content == "" {
+		content = item.RawText
+	}
+
+	return &wordpress.Post{
+		Title:      item.Title,
+		Content:    content,
+		Excerpt:    item.OGDescription,
+		Status:     status,
+		Categories: wordPressCategories(item, cfg),
+		Meta: map[string]any{
+			"canonical_url":  item.URL,
+			"og_title":       item.OGTitle,
+			"og_description": item.OGDescription,
+			"og_image":       item.OGImage,
+			"og_url":         item.OGURL,
+			"north_cloud_id": item.ID,
+			"source":         item.Source,
+		},
+	}
+}
+
+// wordPressCategories maps topics and crime category pages to category IDs,
+// falling back to the channel's default category.
+func wordPressCategories(item *ContentItem, cfg *models.WordPressConfig) []int {
+	seen := make(map[int]bool)
+	var categories []int
+	for _, names := range [][]string{item.Topics, item.CategoryPages} {
+		for _, name := range names {
+			id, ok := cfg.CategoryMap[name]
+			if !ok || seen[id] {
+				continue
+			}
+			seen[id] = true
+			categories = append(categories, id)
+		}
+	}
+
+	if len(categories) == 0 && cfg.DefaultCategoryID > 0 {
+		categories = []int{cfg.DefaultCategoryID}
+	}
+	return categories
+}
@@ -0,0 +1,112 @@
+//nolint:testpackage // Testing unexported WordPress mapping requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildWordPressPost_MapsDrupalFields(t *testing.T) {
+	item := &ContentItem{
+		ID:            "doc-1",
+		Title:         "Council approves budget",
+		Body:          "<p>Full story</p>",
+		URL:           "https://news.example/budget",
+		Source:        "news_example",
+		OGTitle:       "Budget approved",
+		OGDescription: "Council votes 7-2",
+		OGImage:       "https://news.example/img.jpg",
+		Topics:        []string{"politics", "local_news"},
+		CategoryPages: []string{"courts"},
+	}
+	cfg := &models.WordPressConfig{
+		CategoryMap: map[string]int{"politics": 3, "courts": 9, "local_news": 3},
+	}
+
+	post := buildWordPressPost(item, cfg)
+
+	assert.Equal(t, "Council approves budget", post.Title)
+	assert.Equal(t, "<p>Full story</p>", post.Content)
+	assert.Equal(t, "Council votes 7-2", post.Excerpt)
+	assert.Equal(t, models.WordPressStatusPublish, post.Status)
+	assert.Equal(t, []int{3, 9}, post.Categories)
+	assert.Equal(t, "https://news.example/budget", post.Meta["canonical_url"])
+	assert.Equal(t, "Budget approved", post.Meta["og_title"])
+	assert.Equal(t, "https://news.example/img.jpg", post.Meta["og_image"])
+}
+
+func TestWordPressCategories_DefaultCategory(t *testing.T) {
+	item := &ContentItem{Topics: []string{"weather"}}
+
+	assert.Equal(t, []int{5}, wordPressCategories(item, &models.WordPressConfig{DefaultCategoryID: 5}))
+	assert.Nil(t, wordPressCategories(item, &models.WordPressConfig{}))
+}
+
+func TestWordPressDeliverer_Deliver(t *testing.T) {
+	var status string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pass, _ := r.BasicAuth()
+		assert.Equal(t, "secret", pass)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		status, _ = body["status"].(string)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1,"link":"x"}`))
+	}))
+	defer srv.Close()
+
+	d := NewWordPressDeliverer(srv.Client())
+	d.getenv = func(name string) string {
+		if name == "WP_PARTNER_PASSWORD" {
+			return "secret"
+		}
+		return ""
+	}
+	channel := &models.Channel{
+		Type: models.ChannelTypeWordPress,
+		Config: models.ChannelConfig{WordPress: &models.WordPressConfig{
+			BaseURL: srv.URL, Username: "bot", PasswordEnv: "WP_PARTNER_PASSWORD", Status: "draft",
+		}},
+	}
+
+	require.NoError(t, d.Deliver(context.Background(), channel, &ContentItem{Title: "t"}))
+	assert.Equal(t, "draft", status)
+
+	channel.Config.WordPress.PasswordEnv = "UNSET"
+	err := d.Deliver(context.Background(), channel, &ContentItem{Title: "t"})
+	assert.True(t, errors.Is(err, ErrWordPressNotConfigured))
+}
+
+func TestWordPressDeliverer_RetractTrashesRecordedPost(t *testing.T) {
+	var method, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		_, _ = w.Write([]byte(`{"id":17}`))
+	}))
+	defer srv.Close()
+
+	d := NewWordPressDeliverer(srv.Client())
+	d.getenv = func(string) string { return "secret" }
+	channel := &models.Channel{
+		Type: models.ChannelTypeWordPress,
+		Config: models.ChannelConfig{WordPress: &models.WordPressConfig{
+			BaseURL: srv.URL, Username: "bot", PasswordEnv: "WP_PARTNER_PASSWORD",
+		}},
+	}
+
+	published := &models.PublishHistory{ContentID: "doc-1", RemoteID: "17"}
+	require.NoError(t, d.Retract(context.Background(), channel, published, "removed by source"))
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/wp-json/wp/v2/posts/17", path)
+
+	err := d.Retract(context.Background(), channel, &models.PublishHistory{ContentID: "doc-2"}, "")
+	assert.ErrorIs(t, err, ErrNotRetractable)
+}
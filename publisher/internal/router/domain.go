@@ -1,13 +1,19 @@
 package router
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
 
 // ChannelRoute represents a routing decision: a Redis channel name and an optional
 // DB channel ID. ChannelID is nil for all auto-generated channels; only
 // DBChannelDomain sets it (to link back to the publisher.channels table row).
+// Target is the DB channel itself, whose type decides how the item is delivered;
+// routes without a Target are published to Redis.
 type ChannelRoute struct {
 	Channel   string
 	ChannelID *uuid.UUID
+	Target    *models.Channel
 }
 
 // RoutingDomain is implemented by each routing layer.
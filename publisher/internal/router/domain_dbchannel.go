@@ -20,8 +20,9 @@ func NewDBChannelDomain(channels []models.Channel) *DBChannelDomain {
 // Name returns the domain identifier.
 func (d *DBChannelDomain) Name() string { return "db_channel" }
 
-// Routes returns ChannelRoutes for each custom channel whose rules match the content item.
-// Each route carries a non-nil ChannelID referencing the publisher.channels DB row.
+// Routes returns ChannelRoutes for each custom channel whose rules match the
+// content item and whose canary sample, if any, includes it. Each route
+// carries a non-nil ChannelID referencing the publisher.channels DB row.
 func (d *DBChannelDomain) Routes(item *ContentItem) []ChannelRoute {
 	routes := make([]ChannelRoute, 0, len(d.channels))
 	for i := range d.channels {
@@ -29,11 +30,13 @@ func (d *DBChannelDomain) Routes(item *ContentItem) []ChannelRoute {
 		if !ch.Enabled {
 			continue
 		}
-		if ch.Rules.Matches(item.QualityScore, item.ContentType, item.Topics) {
+		if ch.Rules.Matches(item.RouteFields()) &&
+			ch.Config.Canary.Admits(ch.ID, item.ID, item.Source) {
 			id := ch.ID // copy to avoid loop variable address reuse
 			routes = append(routes, ChannelRoute{
 				Channel:   ch.RedisChannel,
 				ChannelID: &id,
+				Target:    ch,
 			})
 		}
 	}
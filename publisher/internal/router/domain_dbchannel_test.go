@@ -120,3 +120,16 @@ func TestDBChannelDomain_Routes(t *testing.T) {
 		})
 	}
 }
+
+func TestDBChannelDomain_Routes_Canary(t *testing.T) {
+	canary := models.Channel{
+		ID:           uuid.New(),
+		RedisChannel: "partners:trial",
+		Enabled:      true,
+		Config:       models.ChannelConfig{Canary: &models.CanaryConfig{Sources: []string{"sudbury_com"}, Percent: 100}},
+	}
+	domain := router.NewDBChannelDomain([]models.Channel{canary})
+
+	assert.Len(t, domain.Routes(&router.ContentItem{ID: "a1", Source: "sudbury_com"}), 1)
+	assert.Nil(t, domain.Routes(&router.ContentItem{ID: "a1", Source: "cbc_ca"}), "sources outside the canary are not routed")
+}
@@ -0,0 +1,148 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// GeographyMapping maps a city or province to a community channel. Cities use
+// the classifier's canonical names ("sudbury", "thunder-bay"); "Thunder Bay"
+// matches too. A mapping without a city matches every item in the province.
+// When Classifiers is set (e.g. ["crime"]), only items those classifiers
+// flagged are routed, so "Sudbury crime" can get a channel of its own.
+type GeographyMapping struct {
+	City        string   `json:"city"`
+	Province    string   `json:"province"`
+	Channel     string   `json:"channel"`
+	Classifiers []string `json:"classifiers"`
+}
+
+// geographyParams are the registry params of the geography domain.
+type geographyParams struct {
+	Mappings      []GeographyMapping `json:"mappings"`
+	MinConfidence float64            `json:"min_confidence"`
+}
+
+// GeographyDomain routes content items to community channels by their
+// classified city or province. City mappings take precedence: province-only
+// mappings apply when no mapping of the item's city matched. Items without a
+// location, or located with less than the minimum confidence, are not routed.
+type GeographyDomain struct {
+	mappings      []GeographyMapping
+	minConfidence float64
+}
+
+// NewGeographyDomain creates a GeographyDomain.
+func NewGeographyDomain(mappings []GeographyMapping, minConfidence float64) *GeographyDomain {
+	normalized := make([]GeographyMapping, 0, len(mappings))
+	for _, m := range mappings {
+		m.City = normalizeGeoCity(m.City)
+		m.Province = strings.ToUpper(strings.TrimSpace(m.Province))
+		normalized = append(normalized, m)
+	}
+	return &GeographyDomain{mappings: normalized, minConfidence: minConfidence}
+}
+
+// defaultGeographyParams returns the geography domain's defaults as registry params.
+// Without mappings the domain routes nothing.
+func defaultGeographyParams() map[string]any {
+	return map[string]any{"mappings": []any{}, "min_confidence": 0}
+}
+
+// buildGeographyDomain creates a GeographyDomain from registry params.
+func buildGeographyDomain(raw json.RawMessage) (*GeographyDomain, error) {
+	var params geographyParams
+	if err := decodeDomainParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if params.MinConfidence < 0 || params.MinConfidence > 1 {
+		return nil, errors.New("invalid params: min_confidence must be between 0 and 1")
+	}
+	for _, m := range params.Mappings {
+		if m.Channel == "" {
+			return nil, errors.New("invalid params: mapping channel is required")
+		}
+		if m.City == "" && m.Province == "" {
+			return nil, errors.New("invalid params: mapping needs a city or a province")
+		}
+		for _, classifier := range m.Classifiers {
+			if classifier != crimeTopicPrefix && classifier != entertainmentTopicPrefix {
+				return nil, errors.New("invalid params: mapping classifiers must be crime or entertainment")
+			}
+		}
+	}
+	return NewGeographyDomain(params.Mappings, params.MinConfidence), nil
+}
+
+// Name returns the domain identifier.
+func (d *GeographyDomain) Name() string { return "geography" }
+
+// Routes returns the channels of the mappings that match the item's city, or
+// of its province when no city mapping matched.
+func (d *GeographyDomain) Routes(item *ContentItem) []ChannelRoute {
+	if len(d.mappings) == 0 || item.LocationConfidence < d.minConfidence {
+		return nil
+	}
+	city := normalizeGeoCity(item.LocationCity)
+	province := strings.ToUpper(item.LocationProvince)
+	if city == "" && province == "" {
+		return nil
+	}
+
+	classifiers := activeTopicPrefixes(item)
+	var cityChannels, provinceChannels []string
+	for _, m := range d.mappings {
+		if !geoClassifiersMatch(m.Classifiers, classifiers) {
+			continue
+		}
+		switch {
+		case m.City != "":
+			if m.City == city && (m.Province == "" || m.Province == province) {
+				cityChannels = appendUnique(cityChannels, m.Channel)
+			}
+		case m.Province == province:
+			provinceChannels = appendUnique(provinceChannels, m.Channel)
+		}
+	}
+
+	if len(cityChannels) > 0 {
+		return channelRoutesFromSlice(cityChannels)
+	}
+	return channelRoutesFromSlice(provinceChannels)
+}
+
+// geoClassifiersMatch reports whether the item was flagged by one of the
+// mapping's classifiers; mappings without classifiers match every item.
+func geoClassifiersMatch(required, active []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, classifier := range required {
+		if slices.Contains(active, classifier) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeGeoCity converts a city name to the classifier's canonical form,
+// e.g. "Thunder Bay" to "thunder-bay".
+func normalizeGeoCity(city string) string {
+	city = strings.ToLower(strings.TrimSpace(city))
+	return strings.Join(strings.FieldsFunc(city, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "-")
+}
+
+// appendUnique appends name unless it is already present.
+func appendUnique(names []string, name string) []string {
+	if slices.Contains(names, name) {
+		return names
+	}
+	return append(names, name)
+}
+
+// compile-time interface check
+var _ RoutingDomain = (*GeographyDomain)(nil)
@@ -0,0 +1,96 @@
+package router_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeographyDomain_Routes(t *testing.T) {
+	domain := router.NewGeographyDomain([]router.GeographyMapping{
+		{City: "Sudbury", Channel: "community:sudbury:crime", Classifiers: []string{"crime"}},
+		{City: "Thunder Bay", Province: "on", Channel: "community:thunder-bay:crime", Classifiers: []string{"crime"}},
+		{City: "sudbury", Channel: "community:sudbury"},
+		{Province: "ON", Channel: "community:ontario"},
+	}, 0.5)
+
+	crime := func(city, province string) *router.ContentItem {
+		return &router.ContentItem{
+			CrimeRelevance:     "core_street_crime",
+			LocationCity:       city,
+			LocationProvince:   province,
+			LocationCountry:    "canada",
+			LocationConfidence: 0.9,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		item     *router.ContentItem
+		expected []string
+	}{
+		{
+			name:     "sudbury crime goes to the sudbury channels",
+			item:     crime("sudbury", "ON"),
+			expected: []string{"community:sudbury:crime", "community:sudbury"},
+		},
+		{
+			name:     "thunder bay crime goes to its own channel",
+			item:     crime("thunder-bay", "ON"),
+			expected: []string{"community:thunder-bay:crime"},
+		},
+		{
+			name: "classifier-restricted mapping skips other content",
+			item: &router.ContentItem{
+				LocationCity: "sudbury", LocationProvince: "ON", LocationConfidence: 0.9,
+			},
+			expected: []string{"community:sudbury"},
+		},
+		{
+			name:     "unmapped city falls back to province",
+			item:     crime("timmins", "ON"),
+			expected: []string{"community:ontario"},
+		},
+		{
+			name:     "other province is not routed",
+			item:     crime("winnipeg", "MB"),
+			expected: nil,
+		},
+		{
+			name: "low confidence location is not routed",
+			item: &router.ContentItem{
+				LocationCity: "sudbury", LocationProvince: "ON", LocationConfidence: 0.3,
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var names []string
+			for _, r := range domain.Routes(tc.item) {
+				names = append(names, r.Channel)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestGeographyDomain_ParamsValidation(t *testing.T) {
+	registry := router.NewDomainRegistry()
+
+	require.NoError(t, registry.ValidateParams("geography", map[string]any{
+		"mappings": []any{map[string]any{"city": "sudbury", "channel": "community:sudbury"}},
+	}))
+	require.Error(t, registry.ValidateParams("geography", map[string]any{
+		"mappings": []any{map[string]any{"city": "sudbury"}},
+	}), "channel is required")
+	require.Error(t, registry.ValidateParams("geography", map[string]any{
+		"mappings": []any{map[string]any{"channel": "community:nowhere"}},
+	}), "city or province is required")
+	require.Error(t, registry.ValidateParams("geography", map[string]any{
+		"mappings": []any{map[string]any{"city": "sudbury", "channel": "c", "classifiers": []any{"mining"}}},
+	}))
+}
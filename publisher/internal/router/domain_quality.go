@@ -0,0 +1,90 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// defaultQualityTierPrefix is prepended to a tier name to form its channel name.
+const defaultQualityTierPrefix = "quality:"
+
+// QualityTier is a named minimum quality score.
+type QualityTier struct {
+	Name            string `json:"name"`
+	MinQualityScore int    `json:"min_quality_score"`
+}
+
+// qualityTierParams are the registry params of the quality tier domain.
+type qualityTierParams struct {
+	Tiers  []QualityTier `json:"tiers"`
+	Prefix string        `json:"prefix"`
+}
+
+// QualityTierDomain routes content items to {prefix}{tier} for the highest
+// quality tier their score reaches, e.g. quality:high. Items below every tier
+// are not routed.
+type QualityTierDomain struct {
+	tiers  []QualityTier // highest minimum first
+	prefix string
+}
+
+// NewQualityTierDomain creates a QualityTierDomain.
+func NewQualityTierDomain(tiers []QualityTier, prefix string) *QualityTierDomain {
+	sorted := append([]QualityTier(nil), tiers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinQualityScore > sorted[j].MinQualityScore
+	})
+	return &QualityTierDomain{tiers: sorted, prefix: prefix}
+}
+
+// defaultQualityTierParams returns the quality tier domain's defaults as registry params.
+func defaultQualityTierParams() map[string]any {
+	const (
+		highMinQuality   = 80
+		mediumMinQuality = 60
+	)
+	return map[string]any{
+		"tiers": []map[string]any{
+			{"name": "high", "min_quality_score": highMinQuality},
+			{"name": "medium", "min_quality_score": mediumMinQuality},
+		},
+		"prefix": defaultQualityTierPrefix,
+	}
+}
+
+// buildQualityTierDomain creates a QualityTierDomain from registry params.
+func buildQualityTierDomain(raw json.RawMessage) (*QualityTierDomain, error) {
+	var params qualityTierParams
+	if err := decodeDomainParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if len(params.Tiers) == 0 {
+		return nil, errors.New("invalid params: at least one tier is required")
+	}
+	for _, tier := range params.Tiers {
+		if tier.Name == "" {
+			return nil, errors.New("invalid params: tier name is required")
+		}
+		if tier.MinQualityScore < 0 || tier.MinQualityScore > maxQualityScore {
+			return nil, errors.New("invalid params: tier min_quality_score must be between 0 and 100")
+		}
+	}
+	return NewQualityTierDomain(params.Tiers, params.Prefix), nil
+}
+
+// Name returns the domain identifier.
+func (d *QualityTierDomain) Name() string { return "quality_tier" }
+
+// Routes returns the channel of the highest tier the item's quality score reaches.
+func (d *QualityTierDomain) Routes(item *ContentItem) []ChannelRoute {
+	for _, tier := range d.tiers {
+		if item.QualityScore >= tier.MinQualityScore {
+			return []ChannelRoute{{Channel: d.prefix + tier.Name}}
+		}
+	}
+	return nil
+}
+
+// compile-time interface check
+var _ RoutingDomain = (*QualityTierDomain)(nil)
@@ -0,0 +1,36 @@
+package router_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQualityTierDomain_Routes(t *testing.T) {
+	domain := router.NewQualityTierDomain([]router.QualityTier{
+		{Name: "medium", MinQualityScore: 60},
+		{Name: "high", MinQualityScore: 80},
+	}, "quality:")
+
+	tests := []struct {
+		name     string
+		score    int
+		expected []string
+	}{
+		{name: "highest tier reached wins", score: 92, expected: []string{"quality:high"}},
+		{name: "score equal to minimum reaches tier", score: 60, expected: []string{"quality:medium"}},
+		{name: "below every tier is not routed", score: 59, expected: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			routes := domain.Routes(&router.ContentItem{QualityScore: tc.score})
+			var names []string
+			for _, r := range routes {
+				names = append(names, r.Channel)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// Sources of a resolved domain setting, lowest precedence first.
+const (
+	DomainSourceDefault  = "default"
+	DomainSourceConfig   = "config"
+	DomainSourceDatabase = "database"
+)
+
+// ErrUnknownDomain is returned for a routing domain name the registry does not know.
+var ErrUnknownDomain = errors.New("unknown routing domain")
+
+// ErrContentNotFound is returned when no classified index holds a content ID.
+var ErrContentNotFound = errors.New("content item not found")
+
+// DomainSpec describes a routing domain the registry can build.
+type DomainSpec struct {
+	Name           string
+	Description    string
+	DefaultEnabled bool
+	// DefaultParams are the parameters used when config and saved settings set none.
+	DefaultParams map[string]any
+	// build creates the domain from its merged parameters, already encoded as JSON.
+	build func(params json.RawMessage, channels []models.Channel) (RoutingDomain, error)
+}
+
+// DomainSetting is a domain's effective setting after applying config.yml and
+// saved settings to its defaults.
+type DomainSetting struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Enabled     bool           `json:"enabled"`
+	Params      map[string]any `json:"params"`
+	// Source is where the setting came from: default, config, or database.
+	Source string `json:"source"`
+}
+
+// DomainDecision is what one domain decided for a content item.
+type DomainDecision struct {
+	Domain  string `json:"domain"`
+	Enabled bool   `json:"enabled"`
+	// Channels are the routes the domain produces, whether or not it is enabled.
+	Channels []string `json:"channels"`
+	// Applied is true when the domain is enabled and produced routes.
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
+
+// DomainRegistry holds the routing domains the router can run, in routing
+// order. Which domains run, and with what parameters, is decided per poll from
+// config.yml and the settings saved through the API.
+type DomainRegistry struct {
+	specs []DomainSpec
+}
+
+// NewDomainRegistry creates a registry of the built-in routing domains.
+func NewDomainRegistry() *DomainRegistry {
+	return &DomainRegistry{specs: []DomainSpec{
+		{
+			Name:           "topic",
+			Description:    "content:{topic} for each topic not handled by a dedicated domain (Layer 1)",
+			DefaultEnabled: true,
+			DefaultParams:  defaultTopicDomainParams(),
+			build: func(params json.RawMessage, _ []models.Channel) (RoutingDomain, error) {
+				return buildTopicDomain(params)
+			},
+		},
+		{
+			Name:           "db_channel",
+			Description:    "Custom channels whose rules match (Layer 2)",
+			DefaultEnabled: true,
+			build: func(params json.RawMessage, channels []models.Channel) (RoutingDomain, error) {
+				if err := decodeDomainParams(params, &struct{}{}); err != nil {
+					return nil, err
+				}
+				return NewDBChannelDomain(channels), nil
+			},
+		},
+		fixedDomainSpec("crime", "Crime channels from the crime classifier (Layer 3)", NewCrimeDomain),
+		fixedDomainSpec("location", "Geographic channels from detected locations (Layer 4)", NewLocationDomain),
+		{
+			Name:           "geography",
+			Description:    "Community channels mapped from the classified city or province",
+			DefaultEnabled: true,
+			DefaultParams:  defaultGeographyParams(),
+			build: func(params json.RawMessage, _ []models.Channel) (RoutingDomain, error) {
+				return buildGeographyDomain(params)
+			},
+		},
+		fixedDomainSpec("mining", "Mining channels from the mining classifier (Layer 5)", NewMiningDomain),
+		fixedDomainSpec("entertainment", "Entertainment channels (Layer 6)", NewEntertainmentDomain),
+		fixedDomainSpec("indigenous", "Indigenous channels (Layer 7)", NewIndigenousDomain),
+		fixedDomainSpec("coforge", "Coforge channels (Layer 8)", NewCoforgeDomain),
+		fixedDomainSpec("recipe", "Recipe channels (Layer 9)", NewRecipeDomain),
+		fixedDomainSpec("job", "Job channels (Layer 10)", NewJobDomain),
+		fixedDomainSpec("rfp", "RFP channels (Layer 11)", NewRFPDomain),
+		fixedDomainSpec("need_signal", "Need signal channels (Layer 12)", NewNeedSignalDomain),
+		{
+			Name:           "quality_tier",
+			Description:    "{prefix}{tier} for the highest quality tier an item reaches",
+			DefaultEnabled: false,
+			DefaultParams:  defaultQualityTierParams(),
+			build: func(params json.RawMessage, _ []models.Channel) (RoutingDomain, error) {
+				return buildQualityTierDomain(params)
+			},
+		},
+	}}
+}
+
+// fixedDomainSpec describes an enabled-by-default domain that takes no parameters.
+func fixedDomainSpec[D RoutingDomain](name, description string, newDomain func() D) DomainSpec {
+	return DomainSpec{
+		Name:           name,
+		Description:    description,
+		DefaultEnabled: true,
+		build: func(params json.RawMessage, _ []models.Channel) (RoutingDomain, error) {
+			if err := decodeDomainParams(params, &struct{}{}); err != nil {
+				return nil, err
+			}
+			return newDomain(), nil
+		},
+	}
+}
+
+// Specs returns the registered domains in routing order.
+func (r *DomainRegistry) Specs() []DomainSpec {
+	return r.specs
+}
+
+// Lookup returns the named domain's spec.
+func (r *DomainRegistry) Lookup(name string) (DomainSpec, bool) {
+	for _, spec := range r.specs {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return DomainSpec{}, false
+}
+
+// Resolve returns every domain's effective setting, in routing order. A saved
+// setting replaces the config.yml one; both merge their params onto the
+// domain's defaults.
+func (r *DomainRegistry) Resolve(
+	configured map[string]config.RoutingDomainConfig, saved []models.RoutingDomainSetting,
+) []DomainSetting {
+	savedByName := make(map[string]*models.RoutingDomainSetting, len(saved))
+	for i := range saved {
+		savedByName[saved[i].Name] = &saved[i]
+	}
+
+	settings := make([]DomainSetting, 0, len(r.specs))
+	for _, spec := range r.specs {
+		setting := DomainSetting{
+			Name:        spec.Name,
+			Description: spec.Description,
+			Enabled:     spec.DefaultEnabled,
+			Params:      maps.Clone(spec.DefaultParams),
+			Source:      DomainSourceDefault,
+		}
+		if cfg, ok := configured[spec.Name]; ok {
+			if cfg.Enabled != nil {
+				setting.Enabled = *cfg.Enabled
+			}
+			setting.Params = mergeDomainParams(spec.DefaultParams, cfg.Params)
+			setting.Source = DomainSourceConfig
+		}
+		if row, ok := savedByName[spec.Name]; ok {
+			setting.Enabled = row.Enabled
+			setting.Params = mergeDomainParams(spec.DefaultParams, row.Params)
+			setting.Source = DomainSourceDatabase
+		}
+		if setting.Params == nil {
+			setting.Params = map[string]any{}
+		}
+		settings = append(settings, setting)
+	}
+	return settings
+}
+
+// ValidateParams reports whether params are valid for the named domain.
+func (r *DomainRegistry) ValidateParams(name string, params map[string]any) error {
+	spec, ok := r.Lookup(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownDomain, name)
+	}
+	_, err := spec.buildWith(mergeDomainParams(spec.DefaultParams, params), nil)
+	return err
+}
+
+// Build creates the enabled domains of settings, in routing order. A domain
+// whose params are invalid (e.g. a typo in config.yml) is built with its
+// default params, and the error is returned alongside the domains.
+func (r *DomainRegistry) Build(settings []DomainSetting, channels []models.Channel) ([]RoutingDomain, error) {
+	domains := make([]RoutingDomain, 0, len(settings))
+	var errs []error
+	for _, setting := range settings {
+		if !setting.Enabled {
+			continue
+		}
+		domain, err := r.buildSetting(setting, channels)
+		if err != nil {
+			errs = append(errs, err)
+			spec, _ := r.Lookup(setting.Name)
+			if domain, err = spec.buildWith(spec.DefaultParams, channels); err != nil {
+				continue
+			}
+		}
+		domains = append(domains, domain)
+	}
+	return domains, errors.Join(errs...)
+}
+
+// Explain runs every domain of settings, enabled or not, against item and
+// reports what each would route, for debugging routing decisions.
+func (r *DomainRegistry) Explain(
+	settings []DomainSetting, channels []models.Channel, item *ContentItem,
+) []DomainDecision {
+	decisions := make([]DomainDecision, 0, len(settings))
+	for _, setting := range settings {
+		decision := DomainDecision{Domain: setting.Name, Enabled: setting.Enabled, Channels: []string{}}
+		domain, err := r.buildSetting(setting, channels)
+		if err != nil {
+			decision.Error = err.Error()
+			decisions = append(decisions, decision)
+			continue
+		}
+		for _, route := range domain.Routes(item) {
+			decision.Channels = append(decision.Channels, route.Channel)
+		}
+		decision.Applied = setting.Enabled && len(decision.Channels) > 0
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
+
+// FetchContentItem fetches one classified content item by document ID.
+func FetchContentItem(
+	ctx context.Context, esClient *elasticsearch.Client, contentID string, logger infralogger.Logger,
+) (*ContentItem, error) {
+	query := map[string]any{
+		"query": map[string]any{
+			"ids": map[string]any{"values": []string{contentID}},
+		},
+	}
+	items, err := searchContentItems(ctx, esClient, query, 1, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrContentNotFound
+	}
+	return &items[0], nil
+}
+
+// buildSetting builds the domain of one setting.
+func (r *DomainRegistry) buildSetting(setting DomainSetting, channels []models.Channel) (RoutingDomain, error) {
+	spec, ok := r.Lookup(setting.Name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDomain, setting.Name)
+	}
+	return spec.buildWith(setting.Params, channels)
+}
+
+// buildWith encodes params and builds the domain.
+func (s DomainSpec) buildWith(params map[string]any, channels []models.Channel) (RoutingDomain, error) {
+	if params == nil {
+		params = map[string]any{}
+	}
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: encode params: %w", s.Name, err)
+	}
+	domain, err := s.build(raw, channels)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.Name, err)
+	}
+	return domain, nil
+}
+
+// mergeDomainParams overlays params onto defaults; keys in params win.
+func mergeDomainParams(defaults, params map[string]any) map[string]any {
+	merged := maps.Clone(defaults)
+	if merged == nil {
+		merged = make(map[string]any, len(params))
+	}
+	maps.Copy(merged, params)
+	return merged
+}
+
+// decodeDomainParams decodes a domain's params, rejecting unknown ones.
+func decodeDomainParams(params json.RawMessage, dst any) error {
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
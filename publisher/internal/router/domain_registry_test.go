@@ -0,0 +1,128 @@
+package router_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func settingByName(t *testing.T, settings []router.DomainSetting, name string) router.DomainSetting {
+	t.Helper()
+	for _, s := range settings {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("domain %q not resolved", name)
+	return router.DomainSetting{}
+}
+
+func TestDomainRegistry_ResolveDefaults(t *testing.T) {
+	settings := router.NewDomainRegistry().Resolve(nil, nil)
+
+	names := make([]string, 0, len(settings))
+	for _, s := range settings {
+		names = append(names, s.Name)
+		assert.Equal(t, router.DomainSourceDefault, s.Source)
+	}
+	assert.Equal(t, []string{
+		"topic", "db_channel", "crime", "location", "geography", "mining", "entertainment",
+		"indigenous", "coforge", "recipe", "job", "rfp", "need_signal", "quality_tier",
+	}, names)
+	assert.True(t, settingByName(t, settings, "topic").Enabled)
+	assert.False(t, settingByName(t, settings, "quality_tier").Enabled)
+}
+
+func TestDomainRegistry_ResolvePrecedence(t *testing.T) {
+	enabled, disabled := true, false
+	configured := map[string]config.RoutingDomainConfig{
+		"quality_tier": {Enabled: &enabled, Params: map[string]any{"prefix": "tier:"}},
+		"crime":        {Enabled: &disabled},
+	}
+	saved := []models.RoutingDomainSetting{
+		{Name: "crime", Enabled: true},
+	}
+
+	settings := router.NewDomainRegistry().Resolve(configured, saved)
+
+	quality := settingByName(t, settings, "quality_tier")
+	assert.True(t, quality.Enabled)
+	assert.Equal(t, router.DomainSourceConfig, quality.Source)
+	assert.Equal(t, "tier:", quality.Params["prefix"])
+	assert.NotNil(t, quality.Params["tiers"], "unset params keep their defaults")
+
+	crime := settingByName(t, settings, "crime")
+	assert.True(t, crime.Enabled, "saved setting overrides config")
+	assert.Equal(t, router.DomainSourceDatabase, crime.Source)
+}
+
+func TestDomainRegistry_BuildSkipsDisabledAndAppliesParams(t *testing.T) {
+	registry := router.NewDomainRegistry()
+	settings := registry.Resolve(nil, []models.RoutingDomainSetting{
+		{Name: "topic", Enabled: true, Params: map[string]any{"skip_topics": []any{"sports"}, "prefix": "topic:"}},
+		{Name: "crime", Enabled: false},
+	})
+
+	domains, err := registry.Build(settings, nil)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(domains))
+	for _, d := range domains {
+		names = append(names, d.Name())
+	}
+	assert.NotContains(t, names, "crime")
+	assert.NotContains(t, names, "quality_tier")
+	require.Equal(t, "topic", domains[0].Name())
+
+	routes := domains[0].Routes(&router.ContentItem{Topics: []string{"sports", "mining"}})
+	require.Len(t, routes, 1)
+	assert.Equal(t, "topic:mining", routes[0].Channel)
+}
+
+func TestDomainRegistry_BuildFallsBackToDefaultsOnInvalidParams(t *testing.T) {
+	registry := router.NewDomainRegistry()
+	settings := registry.Resolve(map[string]config.RoutingDomainConfig{
+		"topic": {Params: map[string]any{"prefx": "typo:"}},
+	}, nil)
+
+	domains, err := registry.Build(settings, nil)
+	require.Error(t, err)
+	require.Equal(t, "topic", domains[0].Name())
+
+	routes := domains[0].Routes(&router.ContentItem{Topics: []string{"news"}})
+	require.Len(t, routes, 1)
+	assert.Equal(t, "content:news", routes[0].Channel)
+}
+
+func TestDomainRegistry_ValidateParams(t *testing.T) {
+	registry := router.NewDomainRegistry()
+
+	require.NoError(t, registry.ValidateParams("quality_tier", map[string]any{
+		"tiers": []any{map[string]any{"name": "top", "min_quality_score": 90}},
+	}))
+	require.Error(t, registry.ValidateParams("quality_tier", map[string]any{"tiers": []any{}}))
+	require.Error(t, registry.ValidateParams("crime", map[string]any{"threshold": 1}))
+	require.ErrorIs(t, registry.ValidateParams("nope", nil), router.ErrUnknownDomain)
+}
+
+func TestDomainRegistry_ExplainIncludesDisabledDomains(t *testing.T) {
+	registry := router.NewDomainRegistry()
+	settings := registry.Resolve(nil, nil)
+
+	decisions := registry.Explain(settings, nil, &router.ContentItem{Topics: []string{"news"}, QualityScore: 85})
+
+	topic := decisions[0]
+	assert.Equal(t, "topic", topic.Domain)
+	assert.True(t, topic.Applied)
+	assert.Equal(t, []string{"content:news"}, topic.Channels)
+
+	quality := decisions[len(decisions)-1]
+	assert.Equal(t, "quality_tier", quality.Domain)
+	assert.False(t, quality.Enabled)
+	assert.False(t, quality.Applied)
+	assert.Equal(t, []string{"quality:high"}, quality.Channels)
+}
@@ -1,5 +1,13 @@
 package router
 
+import (
+	"encoding/json"
+	"sort"
+)
+
+// defaultTopicPrefix is prepended to each topic to form its channel name.
+const defaultTopicPrefix = "content:"
+
 // layer1SkipTopics lists topics handled by dedicated routing layers.
 // These topics are excluded from Layer 1 auto-routing to prevent bypassing
 // their specialised classifiers (e.g., coforge → Layer 8).
@@ -13,24 +21,58 @@ var layer1SkipTopics = map[string]bool{
 	"need_signal": true,
 }
 
+// topicDomainParams are the registry params of the topic domain.
+type topicDomainParams struct {
+	SkipTopics []string `json:"skip_topics"`
+	Prefix     string   `json:"prefix"`
+}
+
 // TopicDomain routes content items to content:{topic} for each non-skipped topic tag.
 // This is Layer 1 in the routing pipeline.
-type TopicDomain struct{}
+type TopicDomain struct {
+	skip   map[string]bool
+	prefix string
+}
+
+// NewTopicDomain creates a TopicDomain with the default skip list and prefix.
+func NewTopicDomain() *TopicDomain {
+	return &TopicDomain{skip: layer1SkipTopics, prefix: defaultTopicPrefix}
+}
 
-// NewTopicDomain creates a TopicDomain.
-func NewTopicDomain() *TopicDomain { return &TopicDomain{} }
+// defaultTopicDomainParams returns the topic domain's defaults as registry params.
+func defaultTopicDomainParams() map[string]any {
+	skip := make([]string, 0, len(layer1SkipTopics))
+	for topic := range layer1SkipTopics {
+		skip = append(skip, topic)
+	}
+	sort.Strings(skip)
+	return map[string]any{"skip_topics": skip, "prefix": defaultTopicPrefix}
+}
+
+// buildTopicDomain creates a TopicDomain from registry params.
+func buildTopicDomain(raw json.RawMessage) (*TopicDomain, error) {
+	var params topicDomainParams
+	if err := decodeDomainParams(raw, &params); err != nil {
+		return nil, err
+	}
+	skip := make(map[string]bool, len(params.SkipTopics))
+	for _, topic := range params.SkipTopics {
+		skip[topic] = true
+	}
+	return &TopicDomain{skip: skip, prefix: params.Prefix}, nil
+}
 
 // Name returns the domain identifier.
 func (d *TopicDomain) Name() string { return "topic" }
 
-// Routes returns a content:{topic} channel for each topic not in layer1SkipTopics.
+// Routes returns a {prefix}{topic} channel for each topic not in the skip list.
 func (d *TopicDomain) Routes(item *ContentItem) []ChannelRoute {
 	names := make([]string, 0, len(item.Topics))
 	for _, topic := range item.Topics {
-		if layer1SkipTopics[topic] {
+		if d.skip[topic] {
 			continue
 		}
-		names = append(names, "content:"+topic)
+		names = append(names, d.prefix+topic)
 	}
 	return channelRoutesFromSlice(names)
 }
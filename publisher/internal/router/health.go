@@ -0,0 +1,227 @@
+package router
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+const (
+	// probeTimeout bounds a single channel health probe.
+	probeTimeout = 10 * time.Second
+	// probeConcurrency bounds how many channels are probed at once.
+	probeConcurrency = 4
+	// defaultCircuitCooldown is how long an open circuit waits before a trial
+	// delivery when probes are disabled.
+	defaultCircuitCooldown = time.Minute
+)
+
+// Prober is implemented by deliverers that can check a channel's destination
+// without delivering anything: Drupal answering JSON:API, a WordPress site
+// accepting its application password, a webhook answering a ping with 2xx.
+type Prober interface {
+	Probe(ctx context.Context, channel *models.Channel) error
+}
+
+// probeResult is the outcome of one channel's probe.
+type probeResult struct {
+	channel *models.Channel
+	at      time.Time
+	err     error
+}
+
+// checkChannelHealth loads the channels' health records, probes the
+// destinations that are due, and half-opens circuits whose cool-down has
+// passed. A failed load keeps the records of the previous poll.
+func (s *Service) checkChannelHealth(ctx context.Context, channels []models.Channel) {
+	health, err := s.repo.ListChannelHealth(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load channel health", infralogger.Error(err))
+		return
+	}
+	s.health = health
+
+	for _, result := range s.probeDue(ctx, channels) {
+		s.applyProbe(ctx, result)
+	}
+
+	now := s.clock.Now()
+	for i := range channels {
+		ch := &channels[i]
+		if !hasCircuit(ch) {
+			continue
+		}
+		h := s.health[ch.ID]
+		if h != nil && h.Circuit == models.CircuitOpen && !s.isProbed(ch) &&
+			h.OpenedAt != nil && now.Sub(*h.OpenedAt) >= s.circuitCooldown() {
+			h.HalfOpen()
+			s.logger.Info("Channel circuit half-open after cool-down, trying a delivery",
+				infralogger.String("channel", ch.RedisChannel),
+			)
+			s.saveChannelHealth(ctx, ch, h)
+		}
+		if s.telemetry != nil {
+			s.telemetry.RecordCircuit(ch.RedisChannel, h != nil && !h.Allows())
+		}
+	}
+}
+
+// probeDue probes, a few at a time, every channel whose last probe is older
+// than the health check interval.
+func (s *Service) probeDue(ctx context.Context, channels []models.Channel) []probeResult {
+	if s.config.HealthInterval <= 0 {
+		return nil
+	}
+
+	now := s.clock.Now()
+	var due []*models.Channel
+	for i := range channels {
+		ch := &channels[i]
+		if !s.isProbed(ch) {
+			continue
+		}
+		if h := s.health[ch.ID]; h != nil && h.LastProbeAt != nil && now.Sub(*h.LastProbeAt) < s.config.HealthInterval {
+			continue
+		}
+		due = append(due, ch)
+	}
+
+	results := make([]probeResult, len(due))
+	sem := make(chan struct{}, probeConcurrency)
+	var wg sync.WaitGroup
+	for i, ch := range due {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+			prober, _ := s.deliverers[ch.DeliveryType()].(Prober)
+			results[i] = probeResult{channel: ch, at: now, err: prober.Probe(probeCtx, ch)}
+		})
+	}
+	wg.Wait()
+
+	return results
+}
+
+// applyProbe records a probe's outcome and saves the channel's health.
+func (s *Service) applyProbe(ctx context.Context, result probeResult) {
+	ch := result.channel
+	h := s.channelHealth(ch.ID)
+	wasOpen := h.Circuit == models.CircuitOpen
+
+	if h.RecordProbe(result.err, result.at, s.config.CircuitThreshold) {
+		s.logger.Warn("Channel circuit opened after failed probes, queuing its items",
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Int("consecutive_failures", h.ConsecutiveFailures),
+			infralogger.Error(result.err),
+		)
+	} else if result.err != nil {
+		s.logger.Warn("Channel health probe failed",
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Error(result.err),
+		)
+	} else if wasOpen {
+		s.logger.Info("Channel health probe succeeded, circuit half-open",
+			infralogger.String("channel", ch.RedisChannel),
+		)
+	}
+
+	s.saveChannelHealth(ctx, ch, h)
+}
+
+// recordDeliveryOutcome feeds a delivery's result to the channel's circuit
+// breaker. Health is saved only when it changed.
+func (s *Service) recordDeliveryOutcome(ctx context.Context, route ChannelRoute, deliverErr error) {
+	if s.config.CircuitThreshold <= 0 || route.Target == nil || !hasCircuit(route.Target) {
+		return
+	}
+
+	ch := route.Target
+	if deliverErr == nil {
+		h, ok := s.health[ch.ID]
+		if !ok || !h.RecordSuccess() {
+			return
+		}
+		s.logger.Info("Channel delivery succeeded, circuit closed",
+			infralogger.String("channel", ch.RedisChannel),
+		)
+		s.saveChannelHealth(ctx, ch, h)
+		return
+	}
+
+	h := s.channelHealth(ch.ID)
+	if h.RecordFailure(deliverErr.Error(), s.clock.Now(), s.config.CircuitThreshold) {
+		s.logger.Warn("Channel circuit opened after failed deliveries, queuing its items",
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Int("consecutive_failures", h.ConsecutiveFailures),
+			infralogger.Error(deliverErr),
+		)
+	}
+	s.saveChannelHealth(ctx, ch, h)
+}
+
+// circuitAllows reports whether the route's channel may be delivered to now.
+// Routes without a DB channel, and redis channels, have no circuit.
+func (s *Service) circuitAllows(route ChannelRoute) bool {
+	if s.config.CircuitThreshold <= 0 || route.Target == nil || !hasCircuit(route.Target) {
+		return true
+	}
+	h, ok := s.health[route.Target.ID]
+	return !ok || h.Allows()
+}
+
+// channelHealth returns the channel's health record, creating it when missing.
+func (s *Service) channelHealth(channelID uuid.UUID) *models.ChannelHealth {
+	if s.health == nil {
+		s.health = make(map[uuid.UUID]*models.ChannelHealth)
+	}
+	h, ok := s.health[channelID]
+	if !ok {
+		h = models.NewChannelHealth(channelID)
+		s.health[channelID] = h
+	}
+	return h
+}
+
+// saveChannelHealth persists a channel's health record. A failed save is
+// logged; the record in memory still applies until the next poll.
+func (s *Service) saveChannelHealth(ctx context.Context, ch *models.Channel, h *models.ChannelHealth) {
+	if err := s.repo.SaveChannelHealth(ctx, h); err != nil {
+		s.logger.Warn("Failed to save channel health",
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Error(err),
+		)
+	}
+	if s.telemetry != nil {
+		s.telemetry.RecordCircuit(ch.RedisChannel, !h.Allows())
+	}
+}
+
+// isProbed reports whether the channel's deliverer can probe its destination
+// and probes are enabled.
+func (s *Service) isProbed(ch *models.Channel) bool {
+	if s.config.HealthInterval <= 0 || !hasCircuit(ch) {
+		return false
+	}
+	_, ok := s.deliverers[ch.DeliveryType()].(Prober)
+	return ok
+}
+
+// circuitCooldown is how long an open circuit that is not probed waits before a trial delivery.
+func (s *Service) circuitCooldown() time.Duration {
+	if s.config.HealthInterval > 0 {
+		return s.config.HealthInterval
+	}
+	return defaultCircuitCooldown
+}
+
+// hasCircuit reports whether a channel's deliveries go through a circuit
+// breaker: every type but redis, whose outages affect all channels alike.
+func hasCircuit(ch *models.Channel) bool {
+	return ch.DeliveryType() != models.ChannelTypeRedis
+}
@@ -0,0 +1,119 @@
+//nolint:testpackage // Testing the unexported circuit breaker requires same package access
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHealthTestService(t *testing.T, now time.Time, cfg Config) (*Service, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	s := (&Service{
+		repo:       database.NewRepository(sqlx.NewDb(db, "postgres")),
+		logger:     infralogger.NewNop(),
+		config:     cfg,
+		deliverers: map[string]Deliverer{},
+	}).WithClock(clock.NewFake(now))
+	return s, mock
+}
+
+func channelHealthColumns() []string {
+	return []string{
+		"channel_id", "circuit", "consecutive_failures", "last_error", "last_failure_at",
+		"opened_at", "last_probe_at", "last_probe_ok", "updated_at",
+	}
+}
+
+func TestCheckChannelHealth_FailedProbeOpensCircuit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var ping map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&ping)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	s, mock := newHealthTestService(t, now, Config{HealthInterval: time.Minute, CircuitThreshold: 1})
+	s.deliverers[models.ChannelTypeWebhook] = NewWebhookDeliverer(srv.Client())
+	channel := webhookChannel(&models.WebhookConfig{URL: srv.URL})
+
+	mock.ExpectQuery("SELECT (.+) FROM channel_health").
+		WillReturnRows(sqlmock.NewRows(channelHealthColumns()))
+	mock.ExpectExec("INSERT INTO channel_health").
+		WithArgs(channel.ID, models.CircuitOpen, 1, sqlmock.AnyArg(), now, now, now, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.checkChannelHealth(t.Context(), []models.Channel{*channel})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, pingAction, ping["action"])
+	assert.False(t, s.circuitAllows(ChannelRoute{Channel: channel.RedisChannel, Target: channel}))
+	assert.True(t, s.circuitAllows(ChannelRoute{Channel: "articles:crime"}), "redis routes have no circuit")
+}
+
+func TestCheckChannelHealth_CooldownHalfOpensUnprobedChannel(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newHealthTestService(t, now, Config{HealthInterval: time.Minute, CircuitThreshold: 3})
+	channel := models.Channel{ID: uuid.New(), RedisChannel: "newsroom", Type: models.ChannelTypeChat}
+	opened := now.Add(-2 * time.Minute)
+
+	mock.ExpectQuery("SELECT (.+) FROM channel_health").
+		WillReturnRows(sqlmock.NewRows(channelHealthColumns()).
+			AddRow(channel.ID, models.CircuitOpen, 3, "429", opened, opened, nil, nil, opened))
+	mock.ExpectExec("INSERT INTO channel_health").
+		WithArgs(channel.ID, models.CircuitHalfOpen, 3, "429", opened, opened, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.checkChannelHealth(t.Context(), []models.Channel{channel})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, s.circuitAllows(ChannelRoute{Channel: channel.RedisChannel, Target: &channel}))
+}
+
+func TestRecordDeliveryOutcome_OpensAndCloses(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newHealthTestService(t, now, Config{CircuitThreshold: 2})
+	channel := webhookChannel(&models.WebhookConfig{URL: "https://partner.example/hook"})
+	route := ChannelRoute{Channel: channel.RedisChannel, Target: channel}
+	deliverErr := errors.New("webhook returned 503: down")
+
+	mock.ExpectExec("INSERT INTO channel_health").
+		WithArgs(channel.ID, models.CircuitClosed, 1, deliverErr.Error(), now, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO channel_health").
+		WithArgs(channel.ID, models.CircuitOpen, 2, deliverErr.Error(), now, now, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO channel_health").
+		WithArgs(channel.ID, models.CircuitClosed, 0, deliverErr.Error(), now, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.recordDeliveryOutcome(t.Context(), route, deliverErr)
+	assert.True(t, s.circuitAllows(route))
+	s.recordDeliveryOutcome(t.Context(), route, deliverErr)
+	assert.False(t, s.circuitAllows(route), "the second failure in a row opens the circuit")
+
+	s.health[channel.ID].HalfOpen()
+	s.recordDeliveryOutcome(t.Context(), route, nil)
+	s.recordDeliveryOutcome(t.Context(), route, nil)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "a success with nothing to change is not saved")
+	assert.True(t, s.circuitAllows(route))
+}
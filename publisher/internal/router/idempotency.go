@@ -0,0 +1,117 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// routingClaimTimeout is how long a delivery may stay claimed before the
+// router that claimed it is assumed to have stopped mid-delivery.
+const routingClaimTimeout = 10 * time.Minute
+
+// errDeliveryInDoubt is the dead letter error of a delivery whose outcome is unknown.
+var errDeliveryInDoubt = errors.New(
+	"delivery outcome unknown (router stopped mid-delivery); check the destination and replay",
+)
+
+// routingDecisionKey returns the idempotency key of delivering item on route.
+func routingDecisionKey(item *ContentItem, route ChannelRoute) string {
+	return models.RoutingDecisionKey(item.ID, route.ChannelID, route.Channel)
+}
+
+// claimDelivery claims the delivery of item on route in routing_decisions, so
+// an item is delivered once even when the Redis dedup key has expired or a
+// router restarted mid-batch. Items whose delivery failed can be claimed
+// again; in-doubt items only with inDoubt set, when an operator replays them.
+// Returns false, with the existing decision's status, when the item must not
+// be delivered. Errors count as not claimed so nothing is delivered twice.
+func (s *Service) claimDelivery(
+	ctx context.Context, item *ContentItem, route ChannelRoute, inDoubt bool,
+) (claimed bool, status string) {
+	key := routingDecisionKey(item, route)
+	decision := &models.RoutingDecision{
+		IdempotencyKey: key,
+		ContentID:      item.ID,
+		ChannelID:      route.ChannelID,
+		ChannelName:    route.Channel,
+	}
+	claimed, err := s.repo.ClaimRoutingDecision(ctx, decision, s.clock.Now(), inDoubt)
+	if err != nil {
+		s.logger.Error("Error claiming routing decision — skipping to prevent duplicate publish",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		return false, ""
+	}
+	if claimed {
+		return true, models.RoutingDecisionPending
+	}
+
+	existing, err := s.repo.GetRoutingDecision(ctx, key)
+	if err != nil {
+		s.logger.Error("Error getting routing decision",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		return false, ""
+	}
+	if existing.Status == models.RoutingDecisionPending {
+		s.reportStaleClaim(ctx, item, route, existing)
+	}
+	return false, existing.Status
+}
+
+// reportStaleClaim marks a delivery claimed longer than routingClaimTimeout
+// ago as in doubt and dead-letters it as dead: the destination may or may not
+// have the item, so it is left to an operator to check and replay rather than
+// delivered again.
+func (s *Service) reportStaleClaim(
+	ctx context.Context, item *ContentItem, route ChannelRoute, decision *models.RoutingDecision,
+) {
+	cutoff := s.clock.Now().Add(-routingClaimTimeout)
+	if !decision.ClaimedAt.Before(cutoff) {
+		return
+	}
+
+	marked, err := s.repo.MarkRoutingDecisionInDoubt(ctx, decision.IdempotencyKey, cutoff)
+	if err != nil {
+		s.logger.Error("Error marking routing decision in doubt",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		return
+	}
+	if !marked {
+		return
+	}
+
+	s.logger.Warn("Delivery outcome unknown; dead-lettered for review",
+		infralogger.String("content_id", item.ID),
+		infralogger.String("channel", route.Channel),
+		infralogger.Time("claimed_at", decision.ClaimedAt),
+	)
+	s.storeDeadLetter(ctx, item, route, errDeliveryInDoubt, models.DeadLetterStatusDead, nil)
+}
+
+// finishDelivery records the outcome of a claimed delivery.
+func (s *Service) finishDelivery(ctx context.Context, item *ContentItem, route ChannelRoute, deliverErr error) {
+	status := models.RoutingDecisionDelivered
+	if deliverErr != nil {
+		status = models.RoutingDecisionFailed
+	}
+	if err := s.repo.CompleteRoutingDecision(ctx, routingDecisionKey(item, route), status); err != nil {
+		s.logger.Warn("Failed to record routing decision outcome",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.String("status", status),
+			infralogger.Error(err),
+		)
+	}
+}
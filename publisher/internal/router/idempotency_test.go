@@ -0,0 +1,129 @@
+//nolint:testpackage // Testing unexported idempotency helpers requires same package access
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotencyTestService(t *testing.T, now time.Time) (*Service, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	s := (&Service{
+		repo:   database.NewRepository(sqlx.NewDb(db, "postgres")),
+		logger: infralogger.NewNop(),
+	}).WithClock(clock.NewFake(now))
+	return s, mock
+}
+
+func routingDecisionRows(key, status string, claimedAt time.Time) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "idempotency_key", "content_id", "channel_id", "channel_name", "status",
+		"attempts", "claimed_at", "updated_at",
+	}).AddRow(uuid.New(), key, "doc-1", nil, "articles:crime", status, 1, claimedAt, claimedAt)
+}
+
+func TestRoutingDecisionKey(t *testing.T) {
+	channelID := uuid.New()
+
+	key := models.RoutingDecisionKey("doc-1", &channelID, "articles:crime")
+	assert.Len(t, key, 64)
+	assert.Equal(t, key, models.RoutingDecisionKey("doc-1", &channelID, "articles:crime"))
+	assert.NotEqual(t, key, models.RoutingDecisionKey("doc-1", nil, "articles:crime"))
+	assert.NotEqual(t, key, models.RoutingDecisionKey("doc-2", &channelID, "articles:crime"))
+}
+
+func TestClaimDelivery_FirstClaimWins(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newIdempotencyTestService(t, now)
+	item := &ContentItem{ID: "doc-1"}
+	route := ChannelRoute{Channel: "articles:crime"}
+
+	mock.ExpectQuery("INSERT INTO routing_decisions").
+		WithArgs(sqlmock.AnyArg(), routingDecisionKey(item, route), "doc-1", nil, "articles:crime", now, false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+	claimed, status := s.claimDelivery(context.Background(), item, route, false)
+
+	assert.True(t, claimed)
+	assert.Equal(t, models.RoutingDecisionPending, status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDelivery_DeliveredIsNotClaimedAgain(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newIdempotencyTestService(t, now)
+	item := &ContentItem{ID: "doc-1"}
+	route := ChannelRoute{Channel: "articles:crime"}
+	key := routingDecisionKey(item, route)
+
+	mock.ExpectQuery("INSERT INTO routing_decisions").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("SELECT .+ FROM routing_decisions").
+		WithArgs(key).
+		WillReturnRows(routingDecisionRows(key, models.RoutingDecisionDelivered, now.Add(-time.Hour)))
+
+	claimed, status := s.claimDelivery(context.Background(), item, route, false)
+
+	assert.False(t, claimed)
+	assert.Equal(t, models.RoutingDecisionDelivered, status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDelivery_RecentPendingIsLeftAlone(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newIdempotencyTestService(t, now)
+	item := &ContentItem{ID: "doc-1"}
+	route := ChannelRoute{Channel: "articles:crime"}
+	key := routingDecisionKey(item, route)
+
+	mock.ExpectQuery("INSERT INTO routing_decisions").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("SELECT .+ FROM routing_decisions").
+		WillReturnRows(routingDecisionRows(key, models.RoutingDecisionPending, now.Add(-time.Minute)))
+
+	claimed, status := s.claimDelivery(context.Background(), item, route, false)
+
+	assert.False(t, claimed)
+	assert.Equal(t, models.RoutingDecisionPending, status)
+	assert.NoError(t, mock.ExpectationsWereMet(), "an in-flight delivery is neither marked nor dead-lettered")
+}
+
+func TestClaimDelivery_StalePendingIsDeadLetteredInDoubt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newIdempotencyTestService(t, now)
+	item := &ContentItem{ID: "doc-1", Title: "Title"}
+	route := ChannelRoute{Channel: "articles:crime"}
+	key := routingDecisionKey(item, route)
+
+	mock.ExpectQuery("INSERT INTO routing_decisions").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("SELECT .+ FROM routing_decisions").
+		WillReturnRows(routingDecisionRows(key, models.RoutingDecisionPending, now.Add(-time.Hour)))
+	mock.ExpectExec("UPDATE routing_decisions").
+		WithArgs(key, now.Add(-routingClaimTimeout)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO channel_dead_letters").
+		WithArgs(
+			sqlmock.AnyArg(), "articles:crime", "doc-1", "Title", sqlmock.AnyArg(),
+			errDeliveryInDoubt.Error(), 1, models.DeadLetterStatusDead, nil,
+		).
+		WillReturnRows(storedDeadLetterRows(1))
+
+	claimed, _ := s.claimDelivery(context.Background(), item, route, false)
+
+	assert.False(t, claimed, "an in-doubt delivery is never repeated automatically")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -209,7 +209,7 @@ func TestLayer2RoutingScenarios(t *testing.T) {
 
 			for i := range tc.channels {
 				ch := &tc.channels[i]
-				if ch.Rules.Matches(tc.item.QualityScore, tc.item.ContentType, tc.item.Topics) {
+				if ch.Rules.Matches(tc.item.RouteFields()) {
 					matchedChannels = append(matchedChannels, ch.RedisChannel)
 				}
 			}
@@ -310,7 +310,7 @@ func TestCombinedLayerRoutingScenarios(t *testing.T) {
 			var layer2Channels []string
 			for i := range tc.customChannels {
 				ch := &tc.customChannels[i]
-				if ch.Rules.Matches(tc.item.QualityScore, tc.item.ContentType, tc.item.Topics) {
+				if ch.Rules.Matches(tc.item.RouteFields()) {
 					layer2Channels = append(layer2Channels, ch.RedisChannel)
 				}
 			}
@@ -445,7 +445,9 @@ func TestRulesEdgeCases(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := tc.rules.Matches(tc.qualityScore, tc.contentType, tc.topics)
+			result := tc.rules.Matches(&models.RouteFields{
+				QualityScore: tc.qualityScore, ContentType: tc.contentType, Topics: tc.topics,
+			})
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -508,7 +510,7 @@ func createTestChannel(slug, redisChannel string, rules models.Rules) models.Cha
 
 // TestAllDomainsProduce_FullyClassifiedContentItem verifies that each domain in the
 // routing pipeline produces at least one route when given a fully-classified content item.
-// This catches domains accidentally omitted from routeContentItem's domain slice,
+// This catches domains accidentally omitted from the domain registry,
 // or domains whose entry conditions are misconfigured.
 func TestAllDomainsProduce_FullyClassifiedContentItem(t *testing.T) {
 	// Build a fully-classified content item that every domain should match.
@@ -550,7 +552,7 @@ func TestAllDomainsProduce_FullyClassifiedContentItem(t *testing.T) {
 		Enabled:      true,
 	}
 
-	// domains in the same order as routeContentItem constructs them
+	// domains in the same order as NewDomainRegistry registers them
 	domainCases := []struct {
 		name   string
 		domain router.RoutingDomain
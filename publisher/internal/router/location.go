@@ -13,6 +13,12 @@ const (
 	LocationSpecificityCity = "city"
 )
 
+// Channel prefixes of the classifiers that get geographic channels.
+const (
+	crimeTopicPrefix         = "crime"
+	entertainmentTopicPrefix = "entertainment"
+)
+
 // LocationDomain routes content items to geographic channels for active domain classifiers.
 // Active classifiers are crime and entertainment; mining is excluded because
 // MiningDomain already generates mining:canada / mining:international.
@@ -82,10 +88,10 @@ func activeTopicPrefixes(item *ContentItem) []string {
 	prefixes := make([]string, 0, maxPrefixes)
 
 	if item.CrimeRelevance != CrimeRelevanceNotCrime && item.CrimeRelevance != "" {
-		prefixes = append(prefixes, "crime")
+		prefixes = append(prefixes, crimeTopicPrefix)
 	}
 	if item.Entertainment != nil && item.Entertainment.Relevance != EntertainmentRelevanceNot && item.Entertainment.Relevance != "" {
-		prefixes = append(prefixes, "entertainment")
+		prefixes = append(prefixes, entertainmentTopicPrefix)
 	}
 
 	return prefixes
@@ -0,0 +1,99 @@
+package router
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// Queue preview limits.
+const (
+	defaultQueuePreviewLimit = 50
+	maxQueuePreviewLimit     = 200
+	queuePreviewPageSize     = 200
+	// queuePreviewMaxScan bounds how many unrouted items are inspected per
+	// preview so a large backlog with few matches cannot stall the request.
+	queuePreviewMaxScan = 2000
+)
+
+// QueuedItem is a content item the router has not reached yet that matches a channel.
+type QueuedItem struct {
+	Item ContentItem
+	Held bool
+}
+
+// QueuePreview is the upcoming content for a single channel.
+type QueuePreview struct {
+	Items []QueuedItem
+	// Scanned is the number of unrouted items inspected.
+	Scanned int
+	// Truncated is true when the scan stopped before the end of the backlog.
+	Truncated bool
+}
+
+// QueuePreviewer lists routed-but-unpublished content for a custom channel.
+// It reads past the router's persisted cursor with the same query the router
+// uses, so items appear here until the next poll publishes them.
+type QueuePreviewer struct {
+	esClient *elasticsearch.Client
+	logger   infralogger.Logger
+}
+
+// NewQueuePreviewer creates a queue previewer.
+func NewQueuePreviewer(esClient *elasticsearch.Client, logger infralogger.Logger) *QueuePreviewer {
+	return &QueuePreviewer{esClient: esClient, logger: logger}
+}
+
+// NormalizeQueuePreviewLimit clamps a requested limit to the allowed range.
+func NormalizeQueuePreviewLimit(limit int) int {
+	if limit <= 0 {
+		return defaultQueuePreviewLimit
+	}
+	return min(limit, maxQueuePreviewLimit)
+}
+
+// Preview returns up to limit items after cursor that match the channel's rules.
+// heldIDs marks content an editor has pulled; held items stay in the preview
+// so they can be released.
+func (p *QueuePreviewer) Preview(
+	ctx context.Context,
+	channel *models.Channel,
+	cursor []any,
+	heldIDs map[string]bool,
+	limit int,
+) (*QueuePreview, error) {
+	limit = NormalizeQueuePreviewLimit(limit)
+	preview := &QueuePreview{Items: make([]QueuedItem, 0, limit)}
+
+	searchAfter := cursor
+	for preview.Scanned < queuePreviewMaxScan {
+		items, err := searchContentItems(ctx, p.esClient, buildContentQuery(searchAfter), queuePreviewPageSize, p.logger)
+		if err != nil {
+			return nil, fmt.Errorf("fetch queued content: %w", err)
+		}
+
+		for i := range items {
+			preview.Scanned++
+			if !channel.Rules.Matches(items[i].RouteFields()) ||
+				!channel.Config.Canary.Admits(channel.ID, items[i].ID, items[i].Source) {
+				continue
+			}
+			preview.Items = append(preview.Items, QueuedItem{Item: items[i], Held: heldIDs[items[i].ID]})
+			if len(preview.Items) == limit {
+				preview.Truncated = i < len(items)-1 || len(items) == queuePreviewPageSize
+				return preview, nil
+			}
+		}
+
+		if len(items) < queuePreviewPageSize {
+			return preview, nil
+		}
+		searchAfter = items[len(items)-1].Sort
+	}
+
+	preview.Truncated = true
+	return preview, nil
+}
@@ -0,0 +1,84 @@
+//nolint:testpackage // Testing internal router requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+func newTestESClient(t *testing.T, handler http.HandlerFunc) *elasticsearch.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		handler(w, req)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("create ES client: %v", err)
+	}
+	return client
+}
+
+func TestQueuePreviewer_Preview_FiltersByRulesAndMarksHolds(t *testing.T) {
+	t.Helper()
+
+	var gotBody map[string]any
+	esClient := newTestESClient(t, func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		_, _ = io.WriteString(w, `{"hits":{"hits":[
+			{"_id":"a","_source":{"title":"A","quality_score":80,"content_type":"article","topics":["crime"]},"sort":[1]},
+			{"_id":"b","_source":{"title":"B","quality_score":20,"content_type":"article","topics":["crime"]},"sort":[2]},
+			{"_id":"c","_source":{"title":"C","quality_score":90,"content_type":"article","topics":["crime"]},"sort":[3]}
+		]}}`)
+	})
+
+	channel := &models.Channel{Rules: models.Rules{IncludeTopics: []string{"crime"}, MinQualityScore: 50}}
+	previewer := NewQueuePreviewer(esClient, infralogger.NewNop())
+
+	preview, err := previewer.Preview(context.Background(), channel, []any{float64(0)}, map[string]bool{"c": true}, 10)
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+
+	if _, ok := gotBody["search_after"]; !ok {
+		t.Error("expected query to resume from the router cursor")
+	}
+	if preview.Scanned != 3 {
+		t.Errorf("Scanned = %d, want 3", preview.Scanned)
+	}
+	if len(preview.Items) != 2 {
+		t.Fatalf("len(Items) = %d, want 2", len(preview.Items))
+	}
+	if preview.Items[0].Item.ID != "a" || preview.Items[0].Held {
+		t.Errorf("Items[0] = %+v, want queued a", preview.Items[0])
+	}
+	if preview.Items[1].Item.ID != "c" || !preview.Items[1].Held {
+		t.Errorf("Items[1] = %+v, want held c", preview.Items[1])
+	}
+	if preview.Truncated {
+		t.Error("Truncated = true, want false for a short page")
+	}
+}
+
+func TestNormalizeQueuePreviewLimit(t *testing.T) {
+	t.Helper()
+
+	cases := map[int]int{0: defaultQueuePreviewLimit, -1: defaultQueuePreviewLimit, 10: 10, 10000: maxQueuePreviewLimit}
+	for in, want := range cases {
+		if got := NormalizeQueuePreviewLimit(in); got != want {
+			t.Errorf("NormalizeQueuePreviewLimit(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
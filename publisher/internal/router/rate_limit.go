@@ -0,0 +1,164 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// Rate limit windows and digest settings.
+const (
+	rateLimitDayWindow = 24 * time.Hour
+	// digestContentType marks the digest posts of summarize channels.
+	digestContentType = "digest"
+)
+
+// routeRateLimit returns the rate limit of a route's DB channel, or nil when
+// the channel is not rate limited.
+func routeRateLimit(route ChannelRoute) *models.RateLimitConfig {
+	if route.Target == nil {
+		return nil
+	}
+	return route.Target.Config.RateLimit
+}
+
+// remainingQuota returns how many more items a rate-limited channel may
+// receive now, counting its deliveries in the last hour and day. Lookup
+// errors return 0 so a throttled destination is not flooded by accident.
+func (s *Service) remainingQuota(ctx context.Context, ch *models.Channel, now time.Time) int {
+	limit := ch.Config.RateLimit
+	if limit == nil {
+		return scheduledReleaseLimit
+	}
+
+	var lastHour, lastDay int
+	if limit.MaxPerHour > 0 {
+		since := now.Add(-time.Hour)
+		count, err := s.repo.GetPublishCountByChannel(ctx, ch.RedisChannel, &since)
+		if err != nil {
+			s.logQuotaError(ch, err)
+			return 0
+		}
+		lastHour = count
+	}
+	if limit.MaxPerDay > 0 {
+		since := now.Add(-rateLimitDayWindow)
+		count, err := s.repo.GetPublishCountByChannel(ctx, ch.RedisChannel, &since)
+		if err != nil {
+			s.logQuotaError(ch, err)
+			return 0
+		}
+		lastDay = count
+	}
+
+	remaining := limit.Remaining(lastHour, lastDay)
+	if remaining < 0 {
+		return scheduledReleaseLimit
+	}
+	return remaining
+}
+
+func (s *Service) logQuotaError(ch *models.Channel, err error) {
+	s.logger.Error("Failed to count channel deliveries for rate limit",
+		infralogger.String("channel", ch.RedisChannel),
+		infralogger.Error(err),
+	)
+}
+
+// trimOverflow drops the lowest quality items of a drop_lowest_quality
+// channel's backlog beyond keep.
+func (s *Service) trimOverflow(ctx context.Context, ch *models.Channel, keep int) {
+	dropped, err := s.repo.TrimScheduledItems(ctx, ch.ID, keep)
+	if err != nil {
+		s.logger.Error("Failed to trim rate limit backlog",
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Error(err),
+		)
+		return
+	}
+	if dropped > 0 {
+		s.logger.Info("Dropped lowest quality items over the channel's rate limit",
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Int64("dropped", dropped),
+		)
+	}
+}
+
+// releaseWithDigest delivers a summarize channel's queued items when more are
+// waiting than its quota allows: the first slots deliver items one by one and
+// the last delivers a digest of every remaining item, which then leave the queue.
+func (s *Service) releaseWithDigest(
+	ctx context.Context, queued []models.ScheduledItem, route ChannelRoute, slots int, now time.Time,
+) {
+	delivered := 0
+	next := 0
+	for ; next < len(queued) && delivered < slots-1; next++ {
+		if s.releaseItem(ctx, &queued[next], route) {
+			delivered++
+		}
+	}
+
+	rest := make([]ContentItem, 0, len(queued)-next)
+	for i := next; i < len(queued); i++ {
+		var item ContentItem
+		if err := json.Unmarshal(queued[i].Payload, &item); err == nil && s.shouldPublish(ctx, &item, route) {
+			rest = append(rest, item)
+		}
+	}
+
+	switch len(rest) {
+	case 0:
+	case 1:
+		s.deliverAndRecord(ctx, &rest[0], route)
+	default:
+		digest := buildDigest(rest, route.Channel, now)
+		if !s.deliverAndRecord(ctx, digest, route) {
+			// Failed digests are dead-lettered; keep the items for the next release.
+			return
+		}
+		s.logger.Info("Summarized items over the channel's rate limit",
+			infralogger.String("channel", route.Channel),
+			infralogger.Int("items", len(rest)),
+		)
+	}
+
+	for i := next; i < len(queued); i++ {
+		s.dequeueScheduled(ctx, queued[i].ID, route.Channel)
+	}
+}
+
+// buildDigest rolls items into one post listing their titles and links. It
+// carries the highest quality score and every topic of its items.
+func buildDigest(items []ContentItem, channelName string, now time.Time) *ContentItem {
+	var body strings.Builder
+	topics := make([]string, 0)
+	seenTopics := make(map[string]bool)
+	quality := 0
+	for i := range items {
+		fmt.Fprintf(&body, "- %s\n  %s\n", items[i].Title, items[i].URL)
+		quality = max(quality, items[i].QualityScore)
+		for _, topic := range items[i].Topics {
+			if !seenTopics[topic] {
+				seenTopics[topic] = true
+				topics = append(topics, topic)
+			}
+		}
+	}
+	text := strings.TrimRight(body.String(), "\n")
+
+	return &ContentItem{
+		ID:            fmt.Sprintf("digest:%s:%d", channelName, now.Unix()),
+		Title:         fmt.Sprintf("%d more stories", len(items)),
+		Body:          text,
+		RawText:       text,
+		PublishedDate: now,
+		QualityScore:  quality,
+		Topics:        topics,
+		ContentType:   digestContentType,
+	}
+}
@@ -0,0 +1,102 @@
+//nolint:testpackage // Testing unexported rate limit helpers requires same package access
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitTestService(t *testing.T, now time.Time) (*Service, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	s := (&Service{
+		repo:         database.NewRepository(sqlx.NewDb(db, "postgres")),
+		logger:       infralogger.NewNop(),
+		lastReleased: make(map[uuid.UUID]time.Time),
+	}).WithClock(clock.NewFake(now))
+	return s, mock
+}
+
+func TestRemainingQuota_CountsHourAndDay(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newRateLimitTestService(t, now)
+	ch := &models.Channel{
+		RedisChannel: "community:sudbury",
+		Config:       models.ChannelConfig{RateLimit: &models.RateLimitConfig{MaxPerHour: 5, MaxPerDay: 20}},
+	}
+
+	mock.ExpectQuery("SELECT COUNT").WithArgs("community:sudbury", now.Add(-time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT COUNT").WithArgs("community:sudbury", now.Add(-24*time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(18))
+
+	assert.Equal(t, 2, s.remainingQuota(context.Background(), ch, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReleaseChannel_OutOfQuotaReleasesNothing(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newRateLimitTestService(t, now)
+	ch := &models.Channel{
+		ID:           uuid.New(),
+		RedisChannel: "community:sudbury",
+		Config:       models.ChannelConfig{RateLimit: &models.RateLimitConfig{MaxPerHour: 3}},
+	}
+
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	s.releaseChannel(context.Background(), ch, now)
+
+	assert.NoError(t, mock.ExpectationsWereMet(), "the queue must not be listed once the quota is used")
+}
+
+func TestReleaseChannel_DropLowestQualityTrimsBacklog(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newRateLimitTestService(t, now)
+	ch := &models.Channel{
+		ID:           uuid.New(),
+		RedisChannel: "community:sudbury",
+		Config: models.ChannelConfig{RateLimit: &models.RateLimitConfig{
+			MaxPerDay: 10, Overflow: models.RateLimitOverflowDropLowestQuality, MaxQueued: 25,
+		}},
+	}
+
+	mock.ExpectExec("DELETE FROM channel_scheduled_items").WithArgs(ch.ID, 25).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+
+	s.releaseChannel(context.Background(), ch, now)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildDigest(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	items := []ContentItem{
+		{Title: "Fire downtown", URL: "https://example.com/fire", QualityScore: 70, Topics: []string{"crime", "local"}},
+		{Title: "Road closed", URL: "https://example.com/road", QualityScore: 85, Topics: []string{"local"}},
+	}
+
+	digest := buildDigest(items, "community:sudbury", now)
+
+	assert.Equal(t, "digest:community:sudbury:1772366400", digest.ID)
+	assert.Equal(t, "2 more stories", digest.Title)
+	assert.Equal(t, "- Fire downtown\n  https://example.com/fire\n- Road closed\n  https://example.com/road", digest.Body)
+	assert.Equal(t, 85, digest.QualityScore)
+	assert.Equal(t, []string{"crime", "local"}, digest.Topics)
+	assert.Equal(t, digestContentType, digest.ContentType)
+}
@@ -0,0 +1,115 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/drupal"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// ErrUnknownChannelType is returned when no deliverer handles a channel's type.
+var ErrUnknownChannelType = errors.New("no deliverer for channel type")
+
+// RenderedDelivery is what a channel would be sent for one content item.
+type RenderedDelivery struct {
+	ChannelType string `json:"channel_type"`
+	// Payload is the message, post, node document, feed entry, or chat
+	// message body as the channel's deliverer builds it.
+	Payload any `json:"payload"`
+	// Notes describe what a real delivery adds or changes, e.g. signatures
+	// or an uploaded image.
+	Notes []string `json:"notes"`
+}
+
+// DeliveryRenderer builds the payloads channels would be sent for an item,
+// using the deliverers' own builders, without sending anything.
+type DeliveryRenderer struct {
+	chat *ChatDeliverer
+	now  func() time.Time
+}
+
+// NewDeliveryRenderer creates a renderer. Chat cards link through the
+// click-tracker when clickBaseURL and clickKey are set, as the router's do.
+func NewDeliveryRenderer(clickBaseURL string, clickKey clickurl.Key) *DeliveryRenderer {
+	return &DeliveryRenderer{
+		chat: NewChatDeliverer(nil, nil).WithClickLinks(clickBaseURL, clickKey),
+		now:  time.Now,
+	}
+}
+
+// Render applies the channel's templates and transforms to item and returns
+// the payload its deliverer would send. Routing rules, dedup, holds, and
+// approval are not applied, so any item can be rendered for any channel.
+func (r *DeliveryRenderer) Render(channel *models.Channel, item *ContentItem) (*RenderedDelivery, error) {
+	item, err := applyChannelTemplate(channel, item)
+	if err != nil {
+		return nil, err
+	}
+	item = applyChannelTransform(channel, item)
+
+	rendered := &RenderedDelivery{ChannelType: channel.DeliveryType(), Notes: []string{}}
+	switch rendered.ChannelType {
+	case models.ChannelTypeRedis:
+		channelID := channel.ID
+		route := ChannelRoute{Channel: channel.RedisChannel, ChannelID: &channelID, Target: channel}
+		rendered.Payload = redisPayload(item, route, r.now())
+		rendered.note("publisher.published_at is set when the message is published")
+	case models.ChannelTypeWebhook:
+		cfg := channel.Config.Webhook
+		if cfg == nil {
+			return nil, fmt.Errorf("%w: missing config.webhook", ErrWebhookNotConfigured)
+		}
+		rendered.Payload = webhookPayload(channel, item, r.now())
+		rendered.note("publisher.published_at is set when the request is sent")
+		if cfg.SecretEnv != "" || cfg.SecretCredential != "" {
+			rendered.note("the request is signed with " + WebhookSignatureHeader + " and " + WebhookTimestampHeader)
+		}
+	case models.ChannelTypeWordPress:
+		if channel.Config.WordPress == nil {
+			return nil, fmt.Errorf("%w: missing config.wordpress", ErrWordPressNotConfigured)
+		}
+		rendered.Payload = buildWordPressPost(item, channel.Config.WordPress)
+	case models.ChannelTypeDrupal:
+		rendered.Payload = renderDrupalNode(channel, item, rendered)
+	case models.ChannelTypeFeed:
+		rendered.Payload = buildFeedItem(channel, item)
+		rendered.note("the entry's id and added_at are assigned when it is stored")
+	case models.ChannelTypeChat:
+		cfg := channel.Config.Chat
+		if cfg == nil {
+			return nil, fmt.Errorf("%w: missing config.chat", ErrChatNotConfigured)
+		}
+		rendered.Payload = buildChatMessage(channel, []chatCard{r.chat.buildCard(channel, item)})
+		if cfg.BatchSize() > 1 {
+			rendered.note(fmt.Sprintf("items routed together share one message of up to %d cards", cfg.BatchSize()))
+		}
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownChannelType, rendered.ChannelType)
+	}
+	return rendered, nil
+}
+
+// renderDrupalNode returns the JSON:API document of the node the item
+// becomes. The image media is only created on delivery.
+func renderDrupalNode(channel *models.Channel, item *ContentItem, rendered *RenderedDelivery) map[string]any {
+	var cfg models.DrupalConfig
+	if channel.Config.Drupal != nil {
+		cfg = *channel.Config.Drupal
+	}
+	cfg = cfg.WithDefaults()
+
+	if cfg.ImageField != "" && item.OGImage != "" {
+		rendered.note(fmt.Sprintf("og_image %s is uploaded as %s media and referenced from %s",
+			item.OGImage, cfg.MediaType, cfg.ImageField))
+	}
+	return drupal.NodeDocument(buildDrupalNode(item, &cfg))
+}
+
+// note adds a note to the rendered delivery.
+func (d *RenderedDelivery) note(text string) {
+	d.Notes = append(d.Notes, text)
+}
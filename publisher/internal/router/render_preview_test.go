@@ -0,0 +1,136 @@
+//nolint:testpackage // Testing rendered payloads against unexported builders requires same package access
+package router
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderTestItem() *ContentItem {
+	return &ContentItem{
+		ID:            "doc-1",
+		Title:         "Council approves budget",
+		Body:          "Council voted 7-2 on Tuesday to approve the budget.",
+		URL:           "https://news.example/budget",
+		Source:        "news_example",
+		OGDescription: "Budget passes",
+		OGImage:       "https://news.example/budget.jpg",
+		Topics:        []string{"politics"},
+	}
+}
+
+func TestDeliveryRenderer_DrupalAppliesTemplateAndTransform(t *testing.T) {
+	channel := &models.Channel{
+		ID:   uuid.New(),
+		Name: "Sudbury Politics",
+		Type: models.ChannelTypeDrupal,
+		Config: models.ChannelConfig{
+			Drupal:    &models.DrupalConfig{ImageField: "field_image"},
+			Template:  &models.TemplateConfig{Summary: "{{.Channel}}: {{.OGDescription}}"},
+			Transform: &models.TransformConfig{TitlePrefix: "[Sudbury] ", TruncateBodyWords: 3},
+		},
+	}
+	item := renderTestItem()
+
+	rendered, err := NewDeliveryRenderer("", clickurl.Key{}).Render(channel, item)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.ChannelTypeDrupal, rendered.ChannelType)
+	data := rendered.Payload.(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, "node--"+models.DrupalDefaultNodeType, data["type"])
+	attributes := data["attributes"].(map[string]any)
+	assert.Equal(t, "[Sudbury] Council approves budget", attributes["title"])
+	assert.Equal(t, map[string]any{
+		"value":   "Council voted 7-2…",
+		"format":  models.DrupalDefaultBodyFormat,
+		"summary": "Sudbury Politics: Budget passes",
+	}, attributes["body"])
+	require.Len(t, rendered.Notes, 1)
+	assert.Contains(t, rendered.Notes[0], "field_image")
+
+	assert.Equal(t, "Council approves budget", item.Title, "the item itself is left untouched")
+}
+
+func TestDeliveryRenderer_ChatUsesPlatformFormat(t *testing.T) {
+	channel := &models.Channel{
+		ID:     uuid.New(),
+		Name:   "Newsroom",
+		Type:   models.ChannelTypeChat,
+		Config: models.ChannelConfig{Chat: &models.ChatConfig{Platform: models.ChatPlatformDiscord}},
+	}
+
+	rendered, err := NewDeliveryRenderer("", clickurl.Key{}).Render(channel, renderTestItem())
+	require.NoError(t, err)
+
+	embeds := rendered.Payload.(map[string]any)["embeds"].([]map[string]any)
+	require.Len(t, embeds, 1)
+	assert.Equal(t, "Council approves budget", embeds[0]["title"])
+	assert.Equal(t, "https://news.example/budget", embeds[0]["url"])
+}
+
+func TestDeliveryRenderer_ChatLinksThroughClickTracker(t *testing.T) {
+	channel := &models.Channel{
+		ID:     uuid.New(),
+		Name:   "Newsroom",
+		Type:   models.ChannelTypeChat,
+		Config: models.ChannelConfig{Chat: &models.ChatConfig{Platform: models.ChatPlatformDiscord}},
+	}
+
+	rendered, err := NewDeliveryRenderer("https://clicks.example", clickurl.Key{Secret: "secret"}).Render(channel, renderTestItem())
+	require.NoError(t, err)
+
+	embeds := rendered.Payload.(map[string]any)["embeds"].([]map[string]any)
+	assert.Contains(t, embeds[0]["url"], "https://clicks.example/")
+}
+
+func TestDeliveryRenderer_RedisMatchesPublishedMessage(t *testing.T) {
+	channel := &models.Channel{
+		ID:           uuid.New(),
+		Name:         "Sudbury",
+		RedisChannel: "articles:sudbury",
+		Config: models.ChannelConfig{
+			Drupal:    &models.DrupalConfig{GroupID: "42"},
+			Transform: &models.TransformConfig{Drop: []string{"raw_html"}},
+		},
+	}
+
+	rendered, err := NewDeliveryRenderer("", clickurl.Key{}).Render(channel, renderTestItem())
+	require.NoError(t, err)
+
+	payload := rendered.Payload.(map[string]any)
+	publisher := payload["publisher"].(map[string]any)
+	assert.Equal(t, "articles:sudbury", publisher["channel"])
+	assert.Equal(t, "42", publisher["drupal_group_id"])
+	assert.NotContains(t, payload, "raw_html")
+}
+
+func TestDeliveryRenderer_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel *models.Channel
+		wantErr error
+	}{
+		{
+			name:    "webhook without config",
+			channel: &models.Channel{Type: models.ChannelTypeWebhook},
+			wantErr: ErrWebhookNotConfigured,
+		},
+		{
+			name:    "unknown type",
+			channel: &models.Channel{Type: "fax"},
+			wantErr: ErrUnknownChannelType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDeliveryRenderer("", clickurl.Key{}).Render(tt.channel, renderTestItem())
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
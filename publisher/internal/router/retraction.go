@@ -0,0 +1,230 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// retractionBatchLimit bounds how many due retractions one poll processes.
+const retractionBatchLimit = 50
+
+// retractAction is the "action" of retraction messages sent to Redis and webhook subscribers.
+const retractAction = "retract"
+
+// ErrNotRetractable is returned when a publish history entry lacks what a
+// channel needs to take the item down, e.g. a post published before remote
+// IDs were recorded.
+var ErrNotRetractable = errors.New("published item cannot be retracted")
+
+// errRetractionUnsupported marks channel types that cannot take items down.
+var errRetractionUnsupported = errors.New("channel type does not support retraction")
+
+// processRetractions takes retracted content down from every channel it was
+// published to. Channels that fail are retried with the dead letter backoff;
+// disabled channels are retried once enabled again.
+func (s *Service) processRetractions(ctx context.Context) {
+	due, err := s.repo.ListDueRetractions(ctx, retractionBatchLimit)
+	if err != nil {
+		s.logger.Error("Failed to list due retractions", infralogger.Error(err))
+		return
+	}
+
+	for i := range due {
+		s.processRetraction(ctx, &due[i])
+	}
+}
+
+// processRetraction handles one retraction and records its outcome.
+func (s *Service) processRetraction(ctx context.Context, retraction *models.Retraction) {
+	history, err := s.repo.GetPublishHistoryByContentID(ctx, retraction.ContentID)
+	if err != nil {
+		s.logger.Error("Failed to load publish history for retraction",
+			infralogger.String("content_id", retraction.ContentID),
+			infralogger.Error(err),
+		)
+		return
+	}
+
+	results := make([]models.RetractionResult, 0, len(history))
+	var lastErr error
+	waiting := false
+	for i := range history {
+		result, retractErr := s.retractPublished(ctx, &history[i], retraction.Reason)
+		results = append(results, result)
+		switch {
+		case retractErr != nil:
+			lastErr = retractErr
+		case result.Outcome == models.RetractionOutcomeWaiting:
+			waiting = true
+		}
+	}
+
+	if dropErr := s.repo.DropQueuedContent(ctx, retraction.ContentID); dropErr != nil {
+		lastErr = dropErr
+	}
+
+	s.finishRetraction(ctx, retraction, results, lastErr, waiting)
+}
+
+// finishRetraction stores the results of a pass. Failures are retried with
+// backoff until deadLetterMaxAttempts; waiting channels are checked again on
+// the next poll.
+func (s *Service) finishRetraction(
+	ctx context.Context,
+	retraction *models.Retraction,
+	results []models.RetractionResult,
+	lastErr error,
+	waiting bool,
+) {
+	retraction.Results = results
+	retraction.LastError = ""
+	retraction.Status = models.RetractionStatusDone
+	now := s.clock.Now()
+	retraction.NextAttemptAt = now
+
+	switch {
+	case lastErr != nil:
+		retraction.Attempts++
+		retraction.LastError = lastErr.Error()
+		retraction.Status = models.RetractionStatusPending
+		if retraction.Attempts >= deadLetterMaxAttempts {
+			retraction.Status = models.RetractionStatusFailed
+		}
+		retraction.NextAttemptAt = now.Add(deadLetterBackoff(retraction.Attempts))
+		s.logger.Warn("Retraction attempt failed",
+			infralogger.String("content_id", retraction.ContentID),
+			infralogger.Int("attempts", retraction.Attempts),
+			infralogger.String("status", retraction.Status),
+			infralogger.Error(lastErr),
+		)
+	case waiting:
+		retraction.Status = models.RetractionStatusPending
+	default:
+		s.logger.Info("Content retracted",
+			infralogger.String("content_id", retraction.ContentID),
+			infralogger.Int("channels", len(results)),
+		)
+	}
+
+	if err := s.repo.UpdateRetraction(ctx, retraction); err != nil {
+		s.logger.Error("Failed to record retraction outcome",
+			infralogger.String("content_id", retraction.ContentID),
+			infralogger.Error(err),
+		)
+	}
+}
+
+// retractPublished takes one published item down from its channel. Entries
+// already retracted, and channels that have since been deleted, count as done.
+func (s *Service) retractPublished(
+	ctx context.Context, published *models.PublishHistory, reason string,
+) (models.RetractionResult, error) {
+	result := models.RetractionResult{
+		ChannelName: published.ChannelName,
+		Outcome:     models.RetractionOutcomeRetracted,
+	}
+	if published.RetractedAt != nil {
+		return result, nil
+	}
+
+	var channel *models.Channel
+	if published.RouteID != nil {
+		ch, err := s.repo.GetChannelByID(ctx, *published.RouteID)
+		if errors.Is(err, models.ErrNotFound) {
+			return result, nil
+		}
+		if err != nil {
+			return failedRetraction(result, err)
+		}
+		if !ch.Enabled {
+			result.Outcome = models.RetractionOutcomeWaiting
+			return result, nil
+		}
+		channel = ch
+	}
+
+	if err := s.retractFrom(ctx, channel, published, reason); err != nil {
+		if errors.Is(err, errRetractionUnsupported) {
+			result.Outcome = models.RetractionOutcomeUnsupported
+			return result, nil
+		}
+		return failedRetraction(result, err)
+	}
+
+	if err := s.repo.MarkPublishHistoryRetracted(ctx, published.ID); err != nil {
+		s.logger.Warn("Failed to mark publish history retracted",
+			infralogger.String("content_id", published.ContentID),
+			infralogger.String("channel", published.ChannelName),
+			infralogger.Error(err),
+		)
+	}
+	return result, nil
+}
+
+// retractFrom sends the retraction to a custom channel's deliverer, or
+// publishes a retraction message on the Redis channel the item went to.
+func (s *Service) retractFrom(
+	ctx context.Context, channel *models.Channel, published *models.PublishHistory, reason string,
+) error {
+	channelType := models.ChannelTypeRedis
+	if channel != nil {
+		channelType = channel.DeliveryType()
+	}
+
+	if channelType == models.ChannelTypeRedis {
+		return s.publishRetraction(ctx, published, reason)
+	}
+
+	retractor, ok := s.deliverers[channelType].(Retractor)
+	if !ok {
+		return errRetractionUnsupported
+	}
+	return retractor.Retract(ctx, channel, published, reason)
+}
+
+// publishRetraction publishes a retraction message on the item's Redis channel.
+func (s *Service) publishRetraction(ctx context.Context, published *models.PublishHistory, reason string) error {
+	payload := buildRetractionPayload(
+		published.ContentID, reason, published.ChannelName, published.RouteID, s.clock.Now(),
+	)
+	messageJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal retraction: %w", err)
+	}
+
+	if publishErr := s.streams.Publish(ctx, published.ChannelName, messageJSON); publishErr != nil {
+		return fmt.Errorf("publish retraction to Redis: %w", publishErr)
+	}
+	return nil
+}
+
+// buildRetractionPayload builds the message telling subscribers to take an
+// item down. Subscribers tell it apart from a publish by its "action" field.
+func buildRetractionPayload(
+	contentID, reason, channelName string, channelID *uuid.UUID, retractedAt time.Time,
+) map[string]any {
+	return map[string]any{
+		"action": retractAction,
+		"id":     contentID,
+		"reason": reason,
+		"publisher": map[string]any{
+			"channel_id":   channelID,
+			"channel":      channelName,
+			"retracted_at": retractedAt.Format(time.RFC3339),
+		},
+	}
+}
+
+// failedRetraction records a channel error on its result.
+func failedRetraction(result models.RetractionResult, err error) (models.RetractionResult, error) {
+	result.Outcome = models.RetractionOutcomeFailed
+	result.Error = err.Error()
+	return result, fmt.Errorf("retract from %s: %w", result.ChannelName, err)
+}
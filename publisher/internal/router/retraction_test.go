@@ -0,0 +1,114 @@
+//nolint:testpackage // Testing unexported retraction helpers requires same package access
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/database"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetractionTestService(t *testing.T, now time.Time) (*Service, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	s := (&Service{
+		repo:   database.NewRepository(sqlx.NewDb(db, "postgres")),
+		logger: infralogger.NewNop(),
+	}).WithClock(clock.NewFake(now))
+	return s, mock
+}
+
+func TestFinishRetraction_Statuses(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		attempts     int
+		lastErr      error
+		waiting      bool
+		wantStatus   string
+		wantAttempts int
+		wantNext     time.Time
+	}{
+		{name: "all channels handled", wantStatus: models.RetractionStatusDone, wantNext: now},
+		{name: "disabled channel waits", waiting: true, wantStatus: models.RetractionStatusPending, wantNext: now},
+		{
+			name: "failure is retried with backoff", attempts: 1, lastErr: errors.New("site down"),
+			wantStatus: models.RetractionStatusPending, wantAttempts: 2, wantNext: now.Add(2 * time.Minute),
+		},
+		{
+			name: "last attempt fails", attempts: deadLetterMaxAttempts - 1, lastErr: errors.New("site down"),
+			wantStatus: models.RetractionStatusFailed, wantAttempts: deadLetterMaxAttempts, wantNext: now.Add(time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newRetractionTestService(t, now)
+			retraction := &models.Retraction{ID: uuid.New(), ContentID: "doc-1", Attempts: tt.attempts}
+			lastError := ""
+			if tt.lastErr != nil {
+				lastError = tt.lastErr.Error()
+			}
+
+			mock.ExpectExec("UPDATE content_retractions").
+				WithArgs(retraction.ID, tt.wantStatus, tt.wantAttempts, lastError, sqlmock.AnyArg(), tt.wantNext).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			s.finishRetraction(context.Background(), retraction, []models.RetractionResult{}, tt.lastErr, tt.waiting)
+
+			assert.Equal(t, tt.wantStatus, retraction.Status)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRetractPublished_AlreadyRetractedIsSkipped(t *testing.T) {
+	s, mock := newRetractionTestService(t, time.Now())
+	retractedAt := time.Now()
+
+	result, err := s.retractPublished(context.Background(), &models.PublishHistory{
+		ChannelName: "articles:crime", ContentID: "doc-1", RetractedAt: &retractedAt,
+	}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, models.RetractionOutcomeRetracted, result.Outcome)
+	assert.NoError(t, mock.ExpectationsWereMet(), "no queries for an entry already retracted")
+}
+
+func TestRetractFrom_ChatIsUnsupported(t *testing.T) {
+	s := &Service{deliverers: defaultDeliverers(nil, nil, nil)}
+	channel := &models.Channel{Type: models.ChannelTypeChat}
+
+	err := s.retractFrom(context.Background(), channel, &models.PublishHistory{ContentID: "doc-1"}, "")
+
+	assert.ErrorIs(t, err, errRetractionUnsupported)
+}
+
+func TestBuildRetractionPayload(t *testing.T) {
+	channelID := uuid.New()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	payload := buildRetractionPayload("doc-1", "removed by source", "articles:crime", &channelID, at)
+
+	assert.Equal(t, "retract", payload["action"])
+	assert.Equal(t, "doc-1", payload["id"])
+	assert.Equal(t, "removed by source", payload["reason"])
+	assert.Equal(t, map[string]any{
+		"channel_id":   &channelID,
+		"channel":      "articles:crime",
+		"retracted_at": "2026-03-01T12:00:00Z",
+	}, payload["publisher"])
+}
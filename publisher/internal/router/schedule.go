@@ -0,0 +1,211 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+const (
+	// scheduledReleaseLimit bounds items released per channel per poll when the
+	// channel sets no minimum interval.
+	scheduledReleaseLimit = 100
+	// scheduledSkipLimit bounds queued items examined per poll for an interval
+	// channel, so already-published or held items do not use up its slot.
+	scheduledSkipLimit = 10
+)
+
+// routeSchedule returns the schedule of a route's DB channel, or nil when
+// the route is delivered immediately.
+func routeSchedule(route ChannelRoute) *models.ScheduleConfig {
+	if route.Target == nil {
+		return nil
+	}
+	return route.Target.Config.Schedule
+}
+
+// isQueued reports whether items routed to a route's channel wait in its
+// schedule queue: channels with a schedule or a rate limit.
+func isQueued(route ChannelRoute) bool {
+	return routeSchedule(route) != nil || routeRateLimit(route) != nil
+}
+
+// queueScheduled stores an item for its channel's schedule or rate limit to
+// release later. Of variants of one story from several sources, only the
+// highest quality waits in the queue.
+func (s *Service) queueScheduled(ctx context.Context, item *ContentItem, route ChannelRoute) {
+	hash := contentHash(item)
+	if !s.replaceQueuedVariant(ctx, item, route, hash) {
+		return
+	}
+
+	payload, err := json.Marshal(item)
+	if err != nil {
+		s.logger.Error("Failed to encode scheduled item",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+		return
+	}
+
+	scheduled := &models.ScheduledItem{
+		ChannelID: route.Target.ID,
+		ContentID: item.ID,
+		Title:     item.Title,
+		Payload:   payload,
+
+		QualityScore: item.QualityScore,
+		ContentHash:  hash,
+	}
+	if queueErr := s.repo.QueueScheduledItem(ctx, scheduled); queueErr != nil {
+		s.logger.Error("Failed to queue scheduled item",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(queueErr),
+		)
+		return
+	}
+
+	s.logger.Debug("Queued content item for scheduled delivery",
+		infralogger.String("content_id", item.ID),
+		infralogger.String("channel", route.Channel),
+	)
+}
+
+// releaseScheduled delivers queued items whose channel schedule and rate limit allow it.
+// Items of disabled channels wait until the channel is enabled again.
+func (s *Service) releaseScheduled(ctx context.Context, channels []models.Channel) {
+	counts, err := s.repo.CountScheduledItems(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count scheduled items", infralogger.Error(err))
+		return
+	}
+	if len(counts) == 0 {
+		return
+	}
+
+	now := s.clock.Now()
+	for i := range channels {
+		if counts[channels[i].ID] == 0 {
+			continue
+		}
+		s.releaseChannel(ctx, &channels[i], now)
+	}
+}
+
+// releaseChannel delivers a channel's queued items, oldest first. A channel
+// with a minimum interval gets at most one delivery per interval, a
+// rate-limited channel no more than its remaining quota; a channel whose
+// schedule and rate limit were removed, or whose circuit closed again, is
+// drained. Nothing is released while the circuit is open.
+func (s *Service) releaseChannel(ctx context.Context, ch *models.Channel, now time.Time) {
+	id := ch.ID
+	route := ChannelRoute{Channel: ch.RedisChannel, ChannelID: &id, Target: ch}
+	if !s.circuitAllows(route) {
+		return
+	}
+
+	rateLimit := ch.Config.RateLimit
+	if rateLimit != nil && rateLimit.OverflowMode() == models.RateLimitOverflowDropLowestQuality {
+		s.trimOverflow(ctx, ch, rateLimit.QueueLimit())
+	}
+
+	schedule := ch.Config.Schedule
+	if schedule != nil && !schedule.Allows(now) {
+		return
+	}
+
+	limit := scheduledReleaseLimit
+	maxDeliveries := scheduledReleaseLimit
+	spaced := schedule != nil && schedule.Interval() > 0
+	if spaced {
+		if last, ok := s.lastReleased[ch.ID]; ok && now.Sub(last) < schedule.Interval() {
+			return
+		}
+		limit = scheduledSkipLimit
+		maxDeliveries = 1
+	}
+	if rateLimit != nil {
+		remaining := s.remainingQuota(ctx, ch, now)
+		if remaining == 0 {
+			return
+		}
+		maxDeliveries = min(maxDeliveries, remaining)
+	}
+
+	queued, err := s.listQueued(ctx, ch, limit)
+	if err != nil {
+		s.logger.Error("Failed to list scheduled items",
+			infralogger.String("channel", ch.RedisChannel),
+			infralogger.Error(err),
+		)
+		return
+	}
+
+	if rateLimit != nil && rateLimit.OverflowMode() == models.RateLimitOverflowSummarize && len(queued) > maxDeliveries {
+		s.releaseWithDigest(ctx, queued, route, maxDeliveries, now)
+		return
+	}
+
+	delivered := 0
+	for i := range queued {
+		if !s.releaseItem(ctx, &queued[i], route) {
+			if !s.circuitAllows(route) {
+				// The failure opened the circuit; the rest wait for it to close
+				return
+			}
+			continue
+		}
+		delivered++
+		if spaced {
+			s.lastReleased[ch.ID] = now
+		}
+		if delivered == maxDeliveries {
+			return
+		}
+	}
+}
+
+// listQueued lists a channel's queued items in release order: highest quality
+// first for drop_lowest_quality channels, otherwise oldest first.
+func (s *Service) listQueued(ctx context.Context, ch *models.Channel, limit int) ([]models.ScheduledItem, error) {
+	if ch.Config.RateLimit != nil && ch.Config.RateLimit.OverflowMode() == models.RateLimitOverflowDropLowestQuality {
+		return s.repo.ListScheduledItemsByQuality(ctx, ch.ID, limit)
+	}
+	return s.repo.ListScheduledItems(ctx, ch.ID, limit)
+}
+
+// releaseItem delivers one queued item and removes it from the queue. Items
+// that fail delivery go to the dead-letter queue, as for unscheduled channels.
+func (s *Service) releaseItem(ctx context.Context, queued *models.ScheduledItem, route ChannelRoute) bool {
+	var item ContentItem
+	delivered := false
+	if err := json.Unmarshal(queued.Payload, &item); err != nil {
+		s.logger.Error("Dropping undecodable scheduled item",
+			infralogger.String("content_id", queued.ContentID),
+			infralogger.String("channel", route.Channel),
+			infralogger.Error(err),
+		)
+	} else if s.shouldPublish(ctx, &item, route) {
+		delivered = s.deliverAndRecord(ctx, &item, route)
+	}
+
+	s.dequeueScheduled(ctx, queued.ID, route.Channel)
+	return delivered
+}
+
+// dequeueScheduled removes a released item. If removal fails the item is
+// released again next poll and skipped by the publish history check.
+func (s *Service) dequeueScheduled(ctx context.Context, id uuid.UUID, channelName string) {
+	if err := s.repo.DeleteScheduledItem(ctx, id); err != nil {
+		s.logger.Warn("Failed to remove released scheduled item",
+			infralogger.String("channel", channelName),
+			infralogger.Error(err),
+		)
+	}
+}
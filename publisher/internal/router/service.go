@@ -10,11 +10,15 @@ import (
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	"github.com/jonesrussell/north-cloud/infrastructure/clock"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/pipeline"
+	"github.com/jonesrussell/north-cloud/publisher/internal/config"
 	"github.com/jonesrussell/north-cloud/publisher/internal/database"
 	"github.com/jonesrussell/north-cloud/publisher/internal/discovery"
 	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/jonesrussell/north-cloud/publisher/internal/streams"
 	"github.com/jonesrussell/north-cloud/publisher/internal/telemetry"
 	"github.com/redis/go-redis/v9"
 )
@@ -23,6 +27,30 @@ type Config struct {
 	PollInterval      time.Duration
 	DiscoveryInterval time.Duration
 	BatchSize         int
+	// ClickBaseURL and ClickKey sign click-tracked links in chat cards;
+	// an empty URL or secret keeps plain article links
+	ClickBaseURL string
+	ClickKey     clickurl.Key
+	// DrupalURL and DrupalToken identify the site drupal channels create nodes on
+	DrupalURL   string
+	DrupalToken string
+	// Domains enables, disables, and parameterizes routing domains by name;
+	// settings saved through the API take precedence
+	Domains map[string]config.RoutingDomainConfig
+	// DedupWindow is how long a story published to a channel blocks variants
+	// from other sources; zero disables content hash deduplication
+	DedupWindow time.Duration
+	// HealthInterval is how often custom channel destinations are probed and
+	// how long an open circuit without a probe waits before a trial delivery;
+	// zero disables probes
+	HealthInterval time.Duration
+	// CircuitThreshold is how many failures in a row open a channel's circuit;
+	// zero disables the circuit breaker
+	CircuitThreshold int
+	// RedisDelivery is streams (default), pubsub, or both; StreamMaxLen caps
+	// each channel stream (see streams.NewProducer)
+	RedisDelivery string
+	StreamMaxLen  int64
 }
 
 // Service handles routing content items to Redis channels using two-layer routing
@@ -31,11 +59,21 @@ type Service struct {
 	discovery   *discovery.Service
 	esClient    *elasticsearch.Client
 	redisClient *redis.Client
+	streams     *streams.Producer
 	logger      infralogger.Logger
 	config      Config
 	lastSort    []any
 	pipeline    *pipeline.Client
 	telemetry   *telemetry.Provider
+	deliverers  map[string]Deliverer
+	chat        *ChatDeliverer
+	domains     *DomainRegistry
+	clock       clock.Clock
+	// lastReleased is when each scheduled channel last received a queued item
+	lastReleased map[uuid.UUID]time.Time
+	// health is each custom channel's probe and circuit breaker record,
+	// reloaded every poll
+	health map[uuid.UUID]*models.ChannelHealth
 }
 
 // NewService creates a new router service
@@ -66,19 +104,36 @@ func NewService(
 		cfg.BatchSize = defaultBatchSize
 	}
 
+	chat := NewChatDeliverer(nil, logger).WithClickLinks(cfg.ClickBaseURL, cfg.ClickKey)
+
 	return &Service{
 		repo:        repo,
 		discovery:   disc,
 		esClient:    esClient,
 		redisClient: redisClient,
+		streams:     streams.NewProducer(redisClient, cfg.RedisDelivery, cfg.StreamMaxLen),
 		logger:      logger,
 		config:      cfg,
 		lastSort:    []any{},
 		pipeline:    pipelineClient,
 		telemetry:   tp,
+		deliverers:  defaultDeliverers(repo, chat, NewDrupalDeliverer(cfg.DrupalURL, cfg.DrupalToken, nil, logger)),
+		chat:        chat,
+		domains:     NewDomainRegistry(),
+		clock:       clock.Real(),
+
+		lastReleased: make(map[uuid.UUID]time.Time),
+		health:       make(map[uuid.UUID]*models.ChannelHealth),
 	}
 }
 
+// WithClock replaces the system clock used for polling, scheduled releases
+// and dead letter retries. Tests pass a clock.Fake to drive time directly.
+func (s *Service) WithClock(c clock.Clock) *Service {
+	s.clock = c
+	return s
+}
+
 // Start begins the router service loop
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("Router service starting (routing v2)...")
@@ -96,8 +151,8 @@ func (s *Service) Start(ctx context.Context) error {
 		s.logger.Error("Initial index discovery failed", infralogger.Error(discErr))
 	}
 
-	discoveryTicker := time.NewTicker(s.config.DiscoveryInterval)
-	pollTicker := time.NewTicker(s.config.PollInterval)
+	discoveryTicker := s.clock.NewTicker(s.config.DiscoveryInterval)
+	pollTicker := s.clock.NewTicker(s.config.PollInterval)
 	defer discoveryTicker.Stop()
 	defer pollTicker.Stop()
 
@@ -108,22 +163,34 @@ func (s *Service) Start(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			s.logger.Info("Router service stopping...")
+			s.flushChat()
 			return ctx.Err()
 
-		case <-discoveryTicker.C:
+		case <-discoveryTicker.C():
 			if _, discErr := s.discovery.DiscoverIndexes(ctx); discErr != nil {
 				s.logger.Error("Index discovery failed", infralogger.Error(discErr))
 			}
 
-		case <-pollTicker.C:
+		case <-pollTicker.C():
 			s.pollAndRoute(ctx)
 		}
 	}
 }
 
+// flushChat posts chat cards still waiting for their batch window or rate limit.
+func (s *Service) flushChat() {
+	const chatFlushTimeout = 5 * time.Second
+	if s.chat == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), chatFlushTimeout)
+	defer cancel()
+	s.chat.Flush(ctx)
+}
+
 // pollAndRoute fetches new content items and routes them
 func (s *Service) pollAndRoute(ctx context.Context) {
-	pollStart := time.Now()
+	pollStart := s.clock.Now()
 
 	indexes := s.discovery.GetIndexes()
 	if len(indexes) == 0 {
@@ -140,6 +207,25 @@ func (s *Service) pollAndRoute(ctx context.Context) {
 		return
 	}
 
+	domains, err := s.loadDomains(ctx, channels)
+	if err != nil {
+		s.logger.Error("Failed to load routing domain settings — aborting poll cycle",
+			infralogger.Error(err),
+		)
+		return
+	}
+	s.checkChannelHealth(ctx, channels)
+
+	// Deferred calls run last-in first-out: approved items reach a channel's
+	// schedule queue before it is released, retractions drop queued items
+	// before they can be delivered, dead letters are retried, and stream lag
+	// is measured once every delivery of the poll is done.
+	defer s.recordStreamLag(ctx)
+	defer s.retryDeadLetters(ctx, channels)
+	defer s.processRetractions(ctx)
+	defer s.releaseScheduled(ctx, channels)
+	defer s.releaseApproved(ctx, channels)
+
 	// Loop until we've drained the queue
 	var totalItems int
 	for {
@@ -162,7 +248,7 @@ func (s *Service) pollAndRoute(ctx context.Context) {
 
 		var publishedCount int
 		for i := range items {
-			publishedTo := s.routeContentItem(ctx, &items[i], channels)
+			publishedTo := s.routeContentItem(ctx, &items[i], domains)
 			publishedCount += len(publishedTo)
 			if s.telemetry != nil {
 				s.telemetry.RecordChannelsPerDoc(len(publishedTo))
@@ -197,7 +283,7 @@ func (s *Service) pollAndRoute(ctx context.Context) {
 		s.telemetry.Metrics.CursorLag.Set(0)
 		return
 	}
-	s.telemetry.RecordBatch(totalItems, time.Since(pollStart))
+	s.telemetry.RecordBatch(totalItems, s.clock.Since(pollStart))
 }
 
 // publishRoutes publishes a content item to each ChannelRoute and returns names of channels
@@ -205,32 +291,34 @@ func (s *Service) pollAndRoute(ctx context.Context) {
 func (s *Service) publishRoutes(ctx context.Context, item *ContentItem, routes []ChannelRoute) []string {
 	published := make([]string, 0, len(routes))
 	for _, route := range routes {
-		if s.publishToChannel(ctx, item, route.Channel, route.ChannelID) {
+		if s.publishToChannel(ctx, item, route) {
 			published = append(published, route.Channel)
 		}
 	}
 	return published
 }
 
-// routeContentItem routes a single content item through all routing domains and returns the list
-// of channel names where publish succeeded.
-func (s *Service) routeContentItem(ctx context.Context, item *ContentItem, channels []models.Channel) []string {
-	const maxChannelsPerItem = 30
+// loadDomains builds the enabled routing domains from config.yml and the
+// settings saved through the API. Domains with invalid params fall back to
+// their defaults.
+func (s *Service) loadDomains(ctx context.Context, channels []models.Channel) ([]RoutingDomain, error) {
+	saved, err := s.repo.ListRoutingDomainSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	domains := []RoutingDomain{
-		NewTopicDomain(),
-		NewDBChannelDomain(channels),
-		NewCrimeDomain(),
-		NewLocationDomain(),
-		NewMiningDomain(),
-		NewEntertainmentDomain(),
-		NewIndigenousDomain(),
-		NewCoforgeDomain(),
-		NewRecipeDomain(),
-		NewJobDomain(),
-		NewRFPDomain(),
-		NewNeedSignalDomain(),
+	settings := s.domains.Resolve(s.config.Domains, saved)
+	domains, buildErr := s.domains.Build(settings, channels)
+	if buildErr != nil {
+		s.logger.Warn("Invalid routing domain params, using defaults", infralogger.Error(buildErr))
 	}
+	return domains, nil
+}
+
+// routeContentItem routes a single content item through the enabled routing domains
+// and returns the list of channel names where publish succeeded.
+func (s *Service) routeContentItem(ctx context.Context, item *ContentItem, domains []RoutingDomain) []string {
+	const maxChannelsPerItem = 30
 
 	var publishedChannels []string
 	for _, domain := range domains {
@@ -265,18 +353,27 @@ const classifiedContentWildcard = "*_classified_content"
 // Uses a wildcard pattern instead of listing individual indexes to avoid exceeding
 // Elasticsearch's HTTP line length limit when many indexes exist.
 func (s *Service) fetchContentItems(ctx context.Context, _ []string) ([]ContentItem, error) {
-	query := s.buildESQuery()
+	return searchContentItems(ctx, s.esClient, s.buildESQuery(), s.config.BatchSize, s.logger)
+}
 
+// searchContentItems runs query against all classified indexes and decodes the hits.
+func searchContentItems(
+	ctx context.Context,
+	esClient *elasticsearch.Client,
+	query map[string]any,
+	size int,
+	logger infralogger.Logger,
+) ([]ContentItem, error) {
 	queryJSON, err := json.Marshal(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal query: %w", err)
 	}
 
-	res, err := s.esClient.Search(
-		s.esClient.Search.WithContext(ctx),
-		s.esClient.Search.WithIndex(classifiedContentWildcard),
-		s.esClient.Search.WithBody(bytes.NewReader(queryJSON)),
-		s.esClient.Search.WithSize(s.config.BatchSize),
+	res, err := esClient.Search(
+		esClient.Search.WithContext(ctx),
+		esClient.Search.WithIndex(classifiedContentWildcard),
+		esClient.Search.WithBody(bytes.NewReader(queryJSON)),
+		esClient.Search.WithSize(size),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute search: %w", err)
@@ -286,7 +383,7 @@ func (s *Service) fetchContentItems(ctx context.Context, _ []string) ([]ContentI
 	if res.IsError() {
 		const httpStatusNotFound = 404
 		if res.StatusCode == httpStatusNotFound {
-			s.logger.Debug("Indexes not found (this is normal for new sources)")
+			logger.Debug("Indexes not found (this is normal for new sources)")
 			return []ContentItem{}, nil
 		}
 		errorBody, readErr := io.ReadAll(res.Body)
@@ -317,7 +414,7 @@ func (s *Service) fetchContentItems(ctx context.Context, _ []string) ([]ContentI
 		if len(errorPreview) > maxErrorBodyLength {
 			errorPreview = errorPreview[:maxErrorBodyLength] + "... (truncated)"
 		}
-		s.logger.Error("Failed to decode Elasticsearch response",
+		logger.Error("Failed to decode Elasticsearch response",
 			infralogger.Int("response_length", len(bodyBytes)),
 			infralogger.String("response_preview", errorPreview),
 			infralogger.Error(decodeErr),
@@ -329,7 +426,7 @@ func (s *Service) fetchContentItems(ctx context.Context, _ []string) ([]ContentI
 	for _, hit := range esResponse.Hits.Hits {
 		var item ContentItem
 		if unmarshalErr := json.Unmarshal(hit.Source, &item); unmarshalErr != nil {
-			s.logger.Error("Error unmarshaling content item",
+			logger.Error("Error unmarshaling content item",
 				infralogger.String("content_id", hit.ID),
 				infralogger.Error(unmarshalErr),
 			)
@@ -346,6 +443,11 @@ func (s *Service) fetchContentItems(ctx context.Context, _ []string) ([]ContentI
 
 // buildESQuery builds an Elasticsearch query for all classified content
 func (s *Service) buildESQuery() map[string]any {
+	return buildContentQuery(s.lastSort)
+}
+
+// buildContentQuery builds the routing query for all classified content after searchAfter.
+func buildContentQuery(searchAfter []any) map[string]any {
 	mustClauses := []map[string]any{
 		{
 			"terms": map[string]any{
@@ -369,16 +471,42 @@ func (s *Service) buildESQuery() map[string]any {
 	}
 
 	// Add search_after if we have a cursor
-	if len(s.lastSort) > 0 {
-		query["search_after"] = s.lastSort
+	if len(searchAfter) > 0 {
+		query["search_after"] = searchAfter
 	}
 
 	return query
 }
 
-// publishToChannel publishes a content item to a Redis channel.
-// Returns true if the item was successfully published, false otherwise.
-func (s *Service) publishToChannel(ctx context.Context, item *ContentItem, channelName string, channelID *uuid.UUID) bool {
+// publishToChannel delivers a content item to a channel: Redis pub/sub, or the
+// channel's destination for non-Redis DB channels (see deliver). Items routed
+// to a channel that requires approval wait for an editor (see releaseApproved);
+// items routed to a channel with a schedule or rate limit, or whose circuit
+// breaker is open, are queued and released by releaseScheduled. Returns true if the item was published now,
+// false otherwise.
+func (s *Service) publishToChannel(ctx context.Context, item *ContentItem, route ChannelRoute) bool {
+	if !s.shouldPublish(ctx, item, route) {
+		return false
+	}
+
+	if requiresApproval(route) {
+		s.queueForApproval(ctx, item, route)
+		return false
+	}
+
+	if isQueued(route) || !s.circuitAllows(route) {
+		s.queueScheduled(ctx, item, route)
+		return false
+	}
+
+	return s.deliverAndRecord(ctx, item, route)
+}
+
+// shouldPublish reports whether an item is neither already published to the
+// channel, from this or (within the dedup window) another source, nor held by an editor.
+func (s *Service) shouldPublish(ctx context.Context, item *ContentItem, route ChannelRoute) bool {
+	channelName, channelID := route.Channel, route.ChannelID
+
 	// Check if already published to this channel
 	published, checkErr := s.repo.CheckContentPublished(ctx, item.ID, channelName)
 	if checkErr != nil {
@@ -390,33 +518,51 @@ func (s *Service) publishToChannel(ctx context.Context, item *ContentItem, chann
 		return false
 	}
 
-	if published {
+	if published || s.isPublishedVariant(ctx, item, channelName) {
 		if s.telemetry != nil {
 			s.telemetry.RecordDedupHit()
 		}
 		return false
 	}
 
-	messageJSON, err := json.Marshal(buildPublishPayload(item, channelName, channelID))
-	if err != nil {
-		s.logger.Error("Failed to marshal message",
-			infralogger.String("content_id", item.ID),
-			infralogger.Error(err),
-		)
+	return !s.isHeld(ctx, item, channelName, channelID)
+}
+
+// deliverAndRecord claims the delivery of an item (see claimDelivery), delivers
+// it and records it in the publish history. A failed delivery is recorded for
+// the weekly report and the channel's circuit breaker, and dead-lettered for retry.
+func (s *Service) deliverAndRecord(ctx context.Context, item *ContentItem, route ChannelRoute) bool {
+	if claimed, _ := s.claimDelivery(ctx, item, route, false); !claimed {
 		return false
 	}
 
-	if publishErr := s.redisClient.Publish(ctx, channelName, messageJSON).Err(); publishErr != nil {
-		s.logger.Error("Failed to publish to Redis",
+	remoteID, deliverErr := s.deliver(ctx, item, route)
+	s.finishDelivery(ctx, item, route, deliverErr)
+	s.recordDeliveryOutcome(ctx, route, deliverErr)
+	if deliverErr != nil {
+		s.logger.Error("Failed to deliver content item",
 			infralogger.String("content_id", item.ID),
-			infralogger.String("channel", channelName),
-			infralogger.Error(publishErr),
+			infralogger.String("channel", route.Channel),
+			infralogger.String("channel_type", routeType(route)),
+			infralogger.Error(deliverErr),
 		)
+		s.recordDeliveryFailure(ctx, item, route.Channel, route.ChannelID, deliverErr)
+		s.deadLetter(ctx, item, route, deliverErr)
 		return false
 	}
 
+	return s.recordPublished(ctx, item, route, remoteID)
+}
+
+// recordPublished records a delivered item in the publish history.
+func (s *Service) recordPublished(ctx context.Context, item *ContentItem, route ChannelRoute, remoteID string) bool {
+	channelName, channelID := route.Channel, route.ChannelID
+
 	// Record in publish history
-	if _, historyErr := s.repo.CreatePublishHistory(ctx, buildHistoryReq(channelID, item, channelName)); historyErr != nil {
+	historyReq := buildHistoryReq(channelID, item, channelName)
+	historyReq.RemoteID = remoteID
+	historyReq.ContentHash = contentHash(item)
+	if _, historyErr := s.repo.CreatePublishHistory(ctx, historyReq); historyErr != nil {
 		s.logger.Error("Error recording publish history — skipping to prevent duplicate publish",
 			infralogger.String("content_id", item.ID),
 			infralogger.String("channel", channelName),
@@ -438,12 +584,61 @@ func (s *Service) publishToChannel(ctx context.Context, item *ContentItem, chann
 	return true
 }
 
-// buildPublishPayload constructs the Redis message payload for a content item.
-func buildPublishPayload(item *ContentItem, channelName string, channelID *uuid.UUID) map[string]any {
+// recordDeliveryFailure stores a failed delivery for the weekly report's failure summary.
+func (s *Service) recordDeliveryFailure(
+	ctx context.Context, item *ContentItem, channelName string, channelID *uuid.UUID, deliverErr error,
+) {
+	failure := &models.DeliveryFailure{
+		ChannelID:    channelID,
+		ChannelName:  channelName,
+		ContentID:    item.ID,
+		ContentTitle: item.Title,
+		Error:        deliverErr.Error(),
+	}
+	if err := s.repo.RecordDeliveryFailure(ctx, failure); err != nil {
+		s.logger.Warn("Failed to record delivery failure",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", channelName),
+			infralogger.Error(err),
+		)
+	}
+}
+
+// isHeld reports whether an editor pulled the item from a custom channel.
+// Lookup errors are treated as held so a pulled item is never published by accident.
+func (s *Service) isHeld(ctx context.Context, item *ContentItem, channelName string, channelID *uuid.UUID) bool {
+	if channelID == nil {
+		return false
+	}
+
+	held, err := s.repo.IsContentHeld(ctx, item.ID, *channelID)
+	if err != nil {
+		s.logger.Error("Error checking channel hold",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", channelName),
+			infralogger.Error(err),
+		)
+		return true
+	}
+	if held {
+		s.logger.Info("Skipping content held by editor",
+			infralogger.String("content_id", item.ID),
+			infralogger.String("channel", channelName),
+		)
+	}
+
+	return held
+}
+
+// buildPublishPayload constructs the Redis message payload for a content item
+// published at publishedAt.
+func buildPublishPayload(
+	item *ContentItem, channelName string, channelID *uuid.UUID, publishedAt time.Time,
+) map[string]any {
 	return map[string]any{
 		"publisher": map[string]any{
 			"channel_id":   channelID,
-			"published_at": time.Now().Format(time.RFC3339),
+			"published_at": publishedAt.Format(time.RFC3339),
 			"channel":      channelName,
 		},
 		"id":                item.ID,
@@ -541,7 +736,7 @@ func (s *Service) emitPublishedEvent(ctx context.Context, item *ContentItem, cha
 		ContentURL: item.URL,
 		SourceName: item.Source,
 		Stage:      "published",
-		OccurredAt: time.Now(),
+		OccurredAt: s.clock.Now(),
 		Metadata: map[string]any{
 			"channels":      channels,
 			"quality_score": item.QualityScore,
@@ -187,7 +187,9 @@ func TestRulesMatches(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := tc.rules.Matches(tc.qualityScore, tc.contentType, tc.topics)
+			result := tc.rules.Matches(&models.RouteFields{
+				QualityScore: tc.qualityScore, ContentType: tc.contentType, Topics: tc.topics,
+			})
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -0,0 +1,266 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// Route simulation limits.
+const (
+	defaultSimulationDays       = 7
+	maxSimulationDays           = 90
+	defaultSimulationSampleSize = 20
+	maxSimulationSampleSize     = 100
+	simulationPageSize          = 500
+	// simulationMaxScan bounds how many documents one simulation inspects so a
+	// long window over busy sources cannot stall the request.
+	simulationMaxScan = 20000
+	// qualityBandWidth is the width of the quality score bands in the breakdown.
+	qualityBandWidth = 10
+	maxQualityScore  = 100
+)
+
+// SimulationCount is the number of matching documents for one topic or source.
+type SimulationCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// QualityBandCount is the number of matching documents in a quality score band.
+type QualityBandCount struct {
+	Band  string `json:"band"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max"`
+	Count int    `json:"count"`
+}
+
+// QualityThreshold is how many documents would match with min_quality_score
+// set to MinQualityScore and every other rule unchanged.
+type QualityThreshold struct {
+	MinQualityScore int `json:"min_quality_score"`
+	Matched         int `json:"matched"`
+}
+
+// RouteSimulation reports what a channel's rules would have matched over a
+// past window of classified content.
+type RouteSimulation struct {
+	Days  int       `json:"days"`
+	Since time.Time `json:"since"`
+	// Scanned is the number of routable documents inspected.
+	Scanned int `json:"scanned"`
+	Matched int `json:"matched"`
+	// Truncated is true when the window held more documents than one
+	// simulation inspects; counts then cover the oldest Scanned documents.
+	Truncated         bool               `json:"truncated"`
+	ByTopic           []SimulationCount  `json:"by_topic"`
+	BySource          []SimulationCount  `json:"by_source"`
+	ByQualityBand     []QualityBandCount `json:"by_quality_band"`
+	QualityThresholds []QualityThreshold `json:"quality_thresholds"`
+	Samples           []ContentItem      `json:"-"`
+}
+
+// RouteSimulator replays classified content from the last N days against a
+// channel's rules, using the router's own matching, so operators can tune
+// rules such as min_quality_score before saving them.
+type RouteSimulator struct {
+	esClient *elasticsearch.Client
+	logger   infralogger.Logger
+	now      func() time.Time
+}
+
+// NewRouteSimulator creates a route simulator.
+func NewRouteSimulator(esClient *elasticsearch.Client, logger infralogger.Logger) *RouteSimulator {
+	return &RouteSimulator{esClient: esClient, logger: logger, now: time.Now}
+}
+
+// NormalizeSimulationDays clamps a requested window to the allowed range.
+func NormalizeSimulationDays(days int) int {
+	if days <= 0 {
+		return defaultSimulationDays
+	}
+	return min(days, maxSimulationDays)
+}
+
+// NormalizeSimulationSampleSize clamps a requested sample size to the allowed range.
+func NormalizeSimulationSampleSize(size int) int {
+	if size <= 0 {
+		return defaultSimulationSampleSize
+	}
+	return min(size, maxSimulationSampleSize)
+}
+
+// Simulate matches the documents crawled in the last days days against rules
+// and returns the counts and up to sampleSize of the most recent matches.
+func (s *RouteSimulator) Simulate(
+	ctx context.Context, rules *models.Rules, days, sampleSize int,
+) (*RouteSimulation, error) {
+	days = NormalizeSimulationDays(days)
+	sampleSize = NormalizeSimulationSampleSize(sampleSize)
+	since := s.now().UTC().Add(-time.Duration(days) * 24 * time.Hour)
+
+	acc := newSimulationAccumulator(rules, sampleSize)
+	var searchAfter []any
+	for acc.scanned < simulationMaxScan {
+		query := buildSimulationQuery(since, searchAfter)
+		items, err := searchContentItems(ctx, s.esClient, query, simulationPageSize, s.logger)
+		if err != nil {
+			return nil, fmt.Errorf("fetch simulation content: %w", err)
+		}
+
+		for i := range items {
+			acc.add(&items[i])
+		}
+
+		if len(items) < simulationPageSize {
+			return acc.result(days, since, false), nil
+		}
+		searchAfter = items[len(items)-1].Sort
+	}
+
+	return acc.result(days, since, true), nil
+}
+
+// buildSimulationQuery is the router's content query limited to documents
+// crawled since the start of the window.
+func buildSimulationQuery(since time.Time, searchAfter []any) map[string]any {
+	query := buildContentQuery(searchAfter)
+	boolQuery, _ := query["query"].(map[string]any)["bool"].(map[string]any)
+	must, _ := boolQuery["must"].([]map[string]any)
+	boolQuery["must"] = append(must, map[string]any{
+		"range": map[string]any{
+			"crawled_at": map[string]any{"gte": since.Format(time.RFC3339)},
+		},
+	})
+	return query
+}
+
+// simulationAccumulator collects the counts of a simulation.
+type simulationAccumulator struct {
+	rules      *models.Rules
+	anyQuality models.Rules
+	sampleSize int
+
+	scanned   int
+	matched   int
+	topics    map[string]int
+	sources   map[string]int
+	bands     []int
+	threshold []int // documents matching every rule but quality, by band of their score
+	samples   []ContentItem
+}
+
+func newSimulationAccumulator(rules *models.Rules, sampleSize int) *simulationAccumulator {
+	anyQuality := *rules
+	anyQuality.MinQualityScore = 0
+	bandCount := maxQualityScore/qualityBandWidth + 1
+	return &simulationAccumulator{
+		rules:      rules,
+		anyQuality: anyQuality,
+		sampleSize: sampleSize,
+		topics:     make(map[string]int),
+		sources:    make(map[string]int),
+		bands:      make([]int, bandCount),
+		threshold:  make([]int, bandCount),
+	}
+}
+
+// add counts one document.
+func (a *simulationAccumulator) add(item *ContentItem) {
+	a.scanned++
+	fields := item.RouteFields()
+	if a.anyQuality.Matches(fields) {
+		a.threshold[qualityBand(item.QualityScore)]++
+	}
+	if !a.rules.Matches(fields) {
+		return
+	}
+
+	a.matched++
+	a.bands[qualityBand(item.QualityScore)]++
+	a.sources[item.Source]++
+	for _, topic := range item.Topics {
+		a.topics[topic]++
+	}
+
+	// Items arrive oldest first; keep the most recent matches.
+	if len(a.samples) == a.sampleSize {
+		a.samples = a.samples[1:]
+	}
+	a.samples = append(a.samples, *item)
+}
+
+// result builds the simulation report.
+func (a *simulationAccumulator) result(days int, since time.Time, truncated bool) *RouteSimulation {
+	samples := make([]ContentItem, 0, len(a.samples))
+	for i := len(a.samples) - 1; i >= 0; i-- {
+		samples = append(samples, a.samples[i])
+	}
+
+	return &RouteSimulation{
+		Days:              days,
+		Since:             since,
+		Scanned:           a.scanned,
+		Matched:           a.matched,
+		Truncated:         truncated,
+		ByTopic:           sortedCounts(a.topics),
+		BySource:          sortedCounts(a.sources),
+		ByQualityBand:     a.qualityBands(),
+		QualityThresholds: a.qualityThresholds(),
+		Samples:           samples,
+	}
+}
+
+// qualityBands lists the matched counts per quality band, lowest band first.
+func (a *simulationAccumulator) qualityBands() []QualityBandCount {
+	bands := make([]QualityBandCount, 0, len(a.bands))
+	for i, count := range a.bands {
+		low := i * qualityBandWidth
+		high := min(low+qualityBandWidth-1, maxQualityScore)
+		bands = append(bands, QualityBandCount{
+			Band:  strconv.Itoa(low) + "-" + strconv.Itoa(high),
+			Min:   low,
+			Max:   high,
+			Count: count,
+		})
+	}
+	return bands
+}
+
+// qualityThresholds lists, for each band's lower bound, how many documents
+// would match with that min_quality_score.
+func (a *simulationAccumulator) qualityThresholds() []QualityThreshold {
+	thresholds := make([]QualityThreshold, len(a.threshold))
+	matched := 0
+	for i := len(a.threshold) - 1; i >= 0; i-- {
+		matched += a.threshold[i]
+		thresholds[i] = QualityThreshold{MinQualityScore: i * qualityBandWidth, Matched: matched}
+	}
+	return thresholds
+}
+
+// qualityBand returns the band index of a quality score.
+func qualityBand(score int) int {
+	return min(max(score, 0), maxQualityScore) / qualityBandWidth
+}
+
+// sortedCounts orders counts by count, then name.
+func sortedCounts(counts map[string]int) []SimulationCount {
+	out := make([]SimulationCount, 0, len(counts))
+	for name, count := range counts {
+		out = append(out, SimulationCount{Name: name, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
@@ -0,0 +1,97 @@
+//nolint:testpackage // Testing internal router requires same package access
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+func TestRouteSimulator_Simulate_CountsMatches(t *testing.T) {
+	t.Helper()
+
+	var gotBody string
+	esClient := newTestESClient(t, func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		gotBody = string(body)
+		_, _ = io.WriteString(w, `{"hits":{"hits":[
+			{"_id":"a","_source":{"title":"A","source":"s1","quality_score":82,"content_type":"article","topics":["crime"]},"sort":[1]},
+			{"_id":"b","_source":{"title":"B","source":"s2","quality_score":45,"content_type":"article","topics":["crime"]},"sort":[2]},
+			{"_id":"c","_source":{"title":"C","source":"s1","quality_score":61,"content_type":"article","topics":["crime","courts"]},"sort":[3]},
+			{"_id":"d","_source":{"title":"D","source":"s1","quality_score":95,"content_type":"article","topics":["sports"]},"sort":[4]}
+		]}}`)
+	})
+
+	simulator := NewRouteSimulator(esClient, infralogger.NewNop())
+	simulator.now = func() time.Time { return time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC) }
+	rules := &models.Rules{IncludeTopics: []string{"crime"}, MinQualityScore: 50}
+
+	sim, err := simulator.Simulate(context.Background(), rules, 7, 1)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	if !strings.Contains(gotBody, `"crawled_at":{"gte":"2026-03-01T00:00:00Z"}`) {
+		t.Errorf("expected query limited to the window, got %s", gotBody)
+	}
+	if sim.Scanned != 4 || sim.Matched != 2 || sim.Truncated {
+		t.Errorf("Scanned/Matched/Truncated = %d/%d/%v, want 4/2/false", sim.Scanned, sim.Matched, sim.Truncated)
+	}
+	if len(sim.Samples) != 1 || sim.Samples[0].ID != "c" {
+		t.Errorf("expected the most recent match as the only sample, got %+v", sim.Samples)
+	}
+
+	topics, _ := json.Marshal(sim.ByTopic)
+	if string(topics) != `[{"name":"crime","count":2},{"name":"courts","count":1}]` {
+		t.Errorf("ByTopic = %s", topics)
+	}
+	if len(sim.BySource) != 1 || sim.BySource[0] != (SimulationCount{Name: "s1", Count: 2}) {
+		t.Errorf("BySource = %+v", sim.BySource)
+	}
+	if sim.ByQualityBand[8].Band != "80-89" || sim.ByQualityBand[8].Count != 1 || sim.ByQualityBand[6].Count != 1 {
+		t.Errorf("ByQualityBand = %+v", sim.ByQualityBand)
+	}
+	if last := sim.ByQualityBand[len(sim.ByQualityBand)-1]; last.Band != "100-100" {
+		t.Errorf("last band = %+v, want 100-100", last)
+	}
+}
+
+func TestRouteSimulator_QualityThresholdsIgnoreCurrentMinimum(t *testing.T) {
+	t.Helper()
+
+	acc := newSimulationAccumulator(&models.Rules{IncludeTopics: []string{"crime"}, MinQualityScore: 70}, 5)
+	for _, score := range []int{30, 55, 72, 90} {
+		acc.add(&ContentItem{QualityScore: score, ContentType: "article", Topics: []string{"crime"}})
+	}
+	acc.add(&ContentItem{QualityScore: 99, ContentType: "article", Topics: []string{"sports"}})
+
+	sim := acc.result(7, time.Time{}, false)
+
+	want := map[int]int{0: 4, 30: 4, 40: 3, 50: 3, 60: 2, 70: 2, 80: 1, 90: 1, 100: 0}
+	for _, threshold := range sim.QualityThresholds {
+		if expected, ok := want[threshold.MinQualityScore]; ok && threshold.Matched != expected {
+			t.Errorf("min_quality_score %d matched %d, want %d", threshold.MinQualityScore, threshold.Matched, expected)
+		}
+	}
+	if sim.Matched != 2 {
+		t.Errorf("Matched = %d, want 2", sim.Matched)
+	}
+}
+
+func TestNormalizeSimulationDays(t *testing.T) {
+	t.Helper()
+
+	if got := NormalizeSimulationDays(0); got != defaultSimulationDays {
+		t.Errorf("NormalizeSimulationDays(0) = %d", got)
+	}
+	if got := NormalizeSimulationDays(365); got != maxSimulationDays {
+		t.Errorf("NormalizeSimulationDays(365) = %d", got)
+	}
+}
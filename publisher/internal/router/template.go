@@ -0,0 +1,63 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// templateData is what channel templates execute against: every ContentItem
+// field plus the channel name
+type templateData struct {
+	*ContentItem
+	Channel string
+}
+
+// applyChannelTemplate returns the item with the channel's title, body, and
+// summary templates rendered into Title, Body, and OGDescription. Items for
+// channels without templates are returned as is; otherwise the original item
+// is left untouched so dedup, history, and dead letters keep the classified text.
+func applyChannelTemplate(channel *models.Channel, item *ContentItem) (*ContentItem, error) {
+	if channel == nil || channel.Config.Template == nil {
+		return item, nil
+	}
+	tmpl := channel.Config.Template
+
+	data := templateData{ContentItem: item, Channel: channel.Name}
+	rendered := *item
+	for _, field := range []struct {
+		name string
+		text string
+		dest *string
+	}{
+		{"title", tmpl.Title, &rendered.Title},
+		{"body", tmpl.Body, &rendered.Body},
+		{"summary", tmpl.Summary, &rendered.OGDescription},
+	} {
+		if field.text == "" {
+			continue
+		}
+		out, err := renderTemplate(field.name, field.text, data)
+		if err != nil {
+			return nil, fmt.Errorf("render %s template: %w", field.name, err)
+		}
+		*field.dest = out
+	}
+
+	return &rendered, nil
+}
+
+// renderTemplate parses and executes one channel template, trimming surrounding whitespace
+func renderTemplate(name, text string, data templateData) (string, error) {
+	parsed, err := models.ParseChannelTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	if execErr := parsed.Execute(&out, data); execErr != nil {
+		return "", execErr
+	}
+	return strings.TrimSpace(out.String()), nil
+}
@@ -0,0 +1,68 @@
+//nolint:testpackage // Testing unexported template rendering requires same package access
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyChannelTemplate_RendersFields(t *testing.T) {
+	item := &ContentItem{
+		ID:            "doc-1",
+		Title:         "Council approves budget",
+		Body:          "Council voted 7-2 on Tuesday.",
+		URL:           "https://news.example/budget",
+		Source:        "news_example",
+		OGDescription: "Budget passes",
+		PublishedDate: time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+		Topics:        []string{"politics", "local_news"},
+	}
+	channel := &models.Channel{
+		Name: "Sudbury Politics",
+		Config: models.ChannelConfig{Template: &models.TemplateConfig{
+			Title: "{{.Channel}}: {{.Title}}",
+			Body: `
+{{.Body}}
+Published {{date "Jan 2, 2006" .PublishedDate}} by {{.Source}}.
+<a href="{{escape .URL}}">Read more</a>
+`,
+			Summary: `{{truncate 10 .Body}} [{{join ", " .Topics}}]`,
+		}},
+	}
+
+	rendered, err := applyChannelTemplate(channel, item)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sudbury Politics: Council approves budget", rendered.Title)
+	assert.Equal(t, "Council voted 7-2 on Tuesday.\nPublished Mar 4, 2026 by news_example.\n"+
+		`<a href="https://news.example/budget">Read more</a>`, rendered.Body)
+	assert.Equal(t, "Council vo… [politics, local_news]", rendered.OGDescription)
+	assert.Equal(t, "Council approves budget", item.Title, "original item must not change")
+	assert.Equal(t, "Budget passes", item.OGDescription)
+}
+
+func TestApplyChannelTemplate_EmptyTemplatesKeepFields(t *testing.T) {
+	item := &ContentItem{Title: "Headline", Body: "Body"}
+
+	unchanged, err := applyChannelTemplate(&models.Channel{}, item)
+	require.NoError(t, err)
+	assert.Same(t, item, unchanged)
+
+	channel := &models.Channel{Config: models.ChannelConfig{Template: &models.TemplateConfig{Body: "{{.Body}} (via North Cloud)"}}}
+	rendered, err := applyChannelTemplate(channel, item)
+	require.NoError(t, err)
+	assert.Equal(t, "Headline", rendered.Title)
+	assert.Equal(t, "Body (via North Cloud)", rendered.Body)
+}
+
+func TestApplyChannelTemplate_UnknownFieldFails(t *testing.T) {
+	channel := &models.Channel{Config: models.ChannelConfig{Template: &models.TemplateConfig{Title: "{{.Headline}}"}}}
+
+	_, err := applyChannelTemplate(channel, &ContentItem{Title: "Headline"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "render title template")
+}
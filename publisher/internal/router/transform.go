@@ -0,0 +1,99 @@
+package router
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+)
+
+// truncatedSuffix ends a body cut by truncate_body_words
+const truncatedSuffix = "…"
+
+// applyChannelTransform returns the item with the channel's title prefix and
+// body truncation applied. Like templates, it works on a copy so dedup,
+// history, and dead letters keep the classified text.
+func applyChannelTransform(channel *models.Channel, item *ContentItem) *ContentItem {
+	if channel == nil || channel.Config.Transform == nil {
+		return item
+	}
+	cfg := channel.Config.Transform
+	if cfg.TitlePrefix == "" && cfg.TruncateBodyWords == 0 {
+		return item
+	}
+
+	transformed := *item
+	if cfg.TitlePrefix != "" && !strings.HasPrefix(transformed.Title, cfg.TitlePrefix) {
+		transformed.Title = cfg.TitlePrefix + transformed.Title
+	}
+	if cfg.TruncateBodyWords > 0 {
+		transformed.Body = truncateWords(transformed.Body, cfg.TruncateBodyWords)
+	}
+	return &transformed
+}
+
+// transformPayload applies the channel's field rules to a redis or webhook
+// payload: mapped topics become "tags", then fields are dropped and renamed.
+func transformPayload(channel *models.Channel, item *ContentItem, payload map[string]any) map[string]any {
+	if channel == nil || channel.Config.Transform == nil {
+		return payload
+	}
+	cfg := channel.Config.Transform
+
+	if len(cfg.TopicTags) > 0 {
+		payload["tags"] = mapTopicTags(cfg.TopicTags, item.Topics)
+	}
+	for _, field := range cfg.Drop {
+		delete(payload, field)
+	}
+
+	renamed := make(map[string]any, len(cfg.Rename))
+	for from, to := range cfg.Rename {
+		if value, ok := payload[from]; ok {
+			renamed[to] = value
+			delete(payload, from)
+		}
+	}
+	for to, value := range renamed {
+		payload[to] = value
+	}
+	return payload
+}
+
+// mapTopicTags returns the tags for the item's topics in topic order, without
+// duplicates; topics without a mapping are skipped.
+func mapTopicTags(topicTags map[string]string, topics []string) []string {
+	tags := make([]string, 0, len(topics))
+	seen := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		tag, ok := topicTags[topic]
+		if !ok || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// truncateWords cuts text after its first n words, keeping the original
+// spacing and line breaks, and ends it with an ellipsis when cut.
+func truncateWords(text string, n int) string {
+	words := 0
+	inWord := false
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+		if inWord {
+			continue
+		}
+		inWord = true
+		words++
+		if words > n {
+			return strings.TrimRightFunc(text[:i], unicode.IsSpace) + truncatedSuffix
+		}
+	}
+	return text
+}
@@ -0,0 +1,65 @@
+//nolint:testpackage // Testing unexported transforms requires same package access
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonesrussell/north-cloud/publisher/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyChannelTransform_PrefixAndTruncate(t *testing.T) {
+	item := &ContentItem{Title: "Council approves budget", Body: "Council voted\n\n7-2 on Tuesday night."}
+	channel := &models.Channel{Config: models.ChannelConfig{Transform: &models.TransformConfig{
+		TitlePrefix:       "[Sudbury] ",
+		TruncateBodyWords: 3,
+	}}}
+
+	transformed := applyChannelTransform(channel, item)
+
+	assert.Equal(t, "[Sudbury] Council approves budget", transformed.Title)
+	assert.Equal(t, "Council voted\n\n7-2…", transformed.Body)
+	assert.Equal(t, "Council approves budget", item.Title, "original item must not change")
+
+	again := applyChannelTransform(channel, transformed)
+	assert.Equal(t, transformed.Title, again.Title, "prefix is not added twice")
+}
+
+func TestTruncateWords_ShortTextUnchanged(t *testing.T) {
+	assert.Equal(t, "two words ", truncateWords("two words ", 2))
+	assert.Empty(t, truncateWords("", 5))
+}
+
+func TestTransformPayload_TagsDropAndRename(t *testing.T) {
+	item := &ContentItem{ID: "doc-1", Title: "Headline", Topics: []string{"crime", "local_news", "violent_crime"}}
+	channelID := uuid.New()
+	channel := &models.Channel{Config: models.ChannelConfig{Transform: &models.TransformConfig{
+		TopicTags: map[string]string{"crime": "Public Safety", "violent_crime": "Public Safety", "local_news": "Local"},
+		Drop:      []string{"raw_html", "raw_text"},
+		Rename:    map[string]string{"title": "headline", "canonical_url": "link"},
+	}}}
+
+	payload := transformPayload(channel, item, buildPublishPayload(item, "sudbury", &channelID, time.Now()))
+
+	assert.Equal(t, []string{"Public Safety", "Local"}, payload["tags"])
+	assert.Equal(t, "Headline", payload["headline"])
+	assert.Contains(t, payload, "link")
+	assert.Equal(t, "doc-1", payload["id"])
+	for _, gone := range []string{"title", "canonical_url", "raw_html", "raw_text"} {
+		assert.NotContains(t, payload, gone)
+	}
+}
+
+func TestTransformPayload_NoTransformKeepsPayload(t *testing.T) {
+	item := &ContentItem{ID: "doc-1", Title: "Headline"}
+	publishedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	payload := transformPayload(&models.Channel{}, item, buildPublishPayload(item, "sudbury", nil, publishedAt))
+
+	assert.Equal(t, "Headline", payload["title"])
+	assert.NotContains(t, payload, "tags")
+	assert.Equal(t, map[string]any{
+		"channel_id": (*uuid.UUID)(nil), "published_at": "2026-03-01T12:00:00Z", "channel": "sudbury",
+	}, payload["publisher"])
+}
@@ -0,0 +1,184 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Consumer defaults.
+const (
+	defaultReadCount = 10
+	defaultBlock     = 5 * time.Second
+	defaultClaimIdle = time.Minute
+	// StartNew creates a group that reads only entries added after it exists.
+	StartNew = "$"
+	// StartOldest creates a group that first reads every entry still in the stream.
+	StartOldest = "0"
+)
+
+// ConsumerConfig configures a consumer group reader.
+type ConsumerConfig struct {
+	Streams []string
+	Group   string
+	// Name identifies this consumer within the group; run each replica with its own.
+	Name string
+	// Count bounds the entries read from each stream per call (default 10).
+	Count int64
+	// Block is how long a read waits for new entries (default 5s).
+	Block time.Duration
+	// ClaimIdle is how long an entry stays pending, unacknowledged, before any
+	// consumer of the group claims it for redelivery (default 1m).
+	ClaimIdle time.Duration
+	// StartID is where a group created by EnsureGroups starts: StartNew (the
+	// default) or StartOldest.
+	StartID string
+}
+
+// Message is one stream entry.
+type Message struct {
+	Stream  string
+	ID      string
+	Payload []byte
+}
+
+// Consumer reads channel streams through a consumer group.
+type Consumer struct {
+	client *redis.Client
+	cfg    ConsumerConfig
+}
+
+// NewConsumer creates a Consumer, applying defaults to cfg.
+func NewConsumer(client *redis.Client, cfg ConsumerConfig) *Consumer {
+	if cfg.Count <= 0 {
+		cfg.Count = defaultReadCount
+	}
+	if cfg.Block <= 0 {
+		cfg.Block = defaultBlock
+	}
+	if cfg.ClaimIdle <= 0 {
+		cfg.ClaimIdle = defaultClaimIdle
+	}
+	if cfg.StartID == "" {
+		cfg.StartID = StartNew
+	}
+	return &Consumer{client: client, cfg: cfg}
+}
+
+// EnsureGroups creates the consumer group on every stream, and the stream
+// itself when nothing was published to it yet. Existing groups are kept.
+func (c *Consumer) EnsureGroups(ctx context.Context) error {
+	for _, stream := range c.cfg.Streams {
+		err := c.client.XGroupCreateMkStream(ctx, stream, c.cfg.Group, c.cfg.StartID).Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("create group %s on %s: %w", c.cfg.Group, stream, err)
+		}
+	}
+	return nil
+}
+
+// Claim takes over entries of the group that have been pending longer than
+// ClaimIdle, e.g. read by a consumer that crashed before acknowledging them.
+func (c *Consumer) Claim(ctx context.Context) ([]Message, error) {
+	var messages []Message
+	for _, stream := range c.cfg.Streams {
+		claimed, _, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    c.cfg.Group,
+			Consumer: c.cfg.Name,
+			MinIdle:  c.cfg.ClaimIdle,
+			Start:    "0-0",
+			Count:    c.cfg.Count,
+		}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("claim pending entries of %s: %w", stream, err)
+		}
+		messages = appendMessages(messages, stream, claimed)
+	}
+	return messages, nil
+}
+
+// Read returns entries not yet delivered to the group, waiting up to Block
+// for new ones. No entries is not an error.
+func (c *Consumer) Read(ctx context.Context) ([]Message, error) {
+	args := make([]string, 0, 2*len(c.cfg.Streams))
+	args = append(args, c.cfg.Streams...)
+	for range c.cfg.Streams {
+		args = append(args, ">")
+	}
+
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.cfg.Group,
+		Consumer: c.cfg.Name,
+		Streams:  args,
+		Count:    c.cfg.Count,
+		Block:    c.cfg.Block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read group %s: %w", c.cfg.Group, err)
+	}
+
+	var messages []Message
+	for _, stream := range streams {
+		messages = appendMessages(messages, stream.Stream, stream.Messages)
+	}
+	return messages, nil
+}
+
+// Ack acknowledges a processed message so it is not delivered again.
+func (c *Consumer) Ack(ctx context.Context, msg Message) error {
+	if err := c.client.XAck(ctx, msg.Stream, c.cfg.Group, msg.ID).Err(); err != nil {
+		return fmt.Errorf("ack %s on %s: %w", msg.ID, msg.Stream, err)
+	}
+	return nil
+}
+
+// Run creates the groups, then hands every claimed and newly read message to
+// handle until ctx is done. Messages handled without error are acknowledged;
+// the others stay pending and are claimed again after ClaimIdle.
+func (c *Consumer) Run(ctx context.Context, handle func(context.Context, Message) error) error {
+	if err := c.EnsureGroups(ctx); err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		claimed, err := c.Claim(ctx)
+		if err != nil {
+			return err
+		}
+		read, err := c.Read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+
+		for _, msg := range append(claimed, read...) {
+			if handle(ctx, msg) != nil {
+				continue
+			}
+			if ackErr := c.Ack(ctx, msg); ackErr != nil {
+				return ackErr
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// appendMessages converts the entries of stream to Messages. Entries not
+// written by a Producer have an empty payload.
+func appendMessages(messages []Message, stream string, entries []redis.XMessage) []Message {
+	for _, entry := range entries {
+		payload, _ := entry.Values[PayloadField].(string)
+		messages = append(messages, Message{Stream: stream, ID: entry.ID, Payload: []byte(payload)})
+	}
+	return messages
+}
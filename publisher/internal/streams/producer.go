@@ -0,0 +1,122 @@
+// Package streams delivers channel messages over Redis Streams. Each channel
+// name is a stream key. Consumers read a stream through a consumer group,
+// acknowledge what they processed, and claim entries that a crashed consumer
+// left pending, so a message is not lost when its consumer is down.
+package streams
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PayloadField is the stream entry field holding the JSON message.
+const PayloadField = "payload"
+
+// Delivery modes. Pub/sub reaches only subscribers connected at publish time;
+// both sends every message twice so consumers can move to streams one at a time.
+const (
+	ModeStreams = "streams"
+	ModePubSub  = "pubsub"
+	ModeBoth    = "both"
+)
+
+// DefaultMaxLen is how many entries each stream keeps when no cap is configured.
+const DefaultMaxLen = 10000
+
+// Producer publishes channel messages to streams, pub/sub, or both. Streams
+// are trimmed to about maxLen entries so an abandoned channel cannot fill Redis.
+type Producer struct {
+	client *redis.Client
+	mode   string
+	maxLen int64
+
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+// NewProducer creates a Producer. An empty mode means streams; maxLen <= 0
+// means DefaultMaxLen.
+func NewProducer(client *redis.Client, mode string, maxLen int64) *Producer {
+	if mode == "" {
+		mode = ModeStreams
+	}
+	if maxLen <= 0 {
+		maxLen = DefaultMaxLen
+	}
+	return &Producer{client: client, mode: mode, maxLen: maxLen, keys: make(map[string]struct{})}
+}
+
+// Publish sends message on channel: appended to the channel's stream and/or
+// published to its pub/sub channel, depending on the mode.
+func (p *Producer) Publish(ctx context.Context, channel string, message []byte) error {
+	if p.mode != ModePubSub {
+		err := p.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: channel,
+			MaxLen: p.maxLen,
+			Approx: true,
+			Values: map[string]any{PayloadField: message},
+		}).Err()
+		if err != nil {
+			return fmt.Errorf("add to stream: %w", err)
+		}
+		p.remember(channel)
+	}
+	if p.mode != ModeStreams {
+		if err := p.client.Publish(ctx, channel, message).Err(); err != nil {
+			return fmt.Errorf("publish: %w", err)
+		}
+	}
+	return nil
+}
+
+// Keys returns the streams this producer has added to, sorted.
+func (p *Producer) Keys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keys := make([]string, 0, len(p.keys))
+	for key := range p.keys {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (p *Producer) remember(stream string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[stream] = struct{}{}
+}
+
+// GroupLag is how far one consumer group is behind on a stream.
+type GroupLag struct {
+	Stream string
+	Group  string
+	// Lag is the number of entries not yet delivered to the group, or -1 when
+	// Redis cannot tell (entries were trimmed before the group read them).
+	Lag int64
+	// Pending is the number of entries delivered but not acknowledged.
+	Pending int64
+}
+
+// Lag returns the lag of every consumer group of stream. A stream that does
+// not exist has no groups.
+func Lag(ctx context.Context, client *redis.Client, stream string) ([]GroupLag, error) {
+	groups, err := client.XInfoGroups(ctx, stream).Result()
+	if err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stream groups of %s: %w", stream, err)
+	}
+
+	lags := make([]GroupLag, 0, len(groups))
+	for _, group := range groups {
+		lags = append(lags, GroupLag{Stream: stream, Group: group.Name, Lag: group.Lag, Pending: group.Pending})
+	}
+	return lags, nil
+}
@@ -0,0 +1,131 @@
+package streams_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jonesrussell/north-cloud/publisher/internal/streams"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRedis(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client, mr
+}
+
+func TestProducer_StreamsModeAddsToChannelStream(t *testing.T) {
+	client, mr := setupRedis(t)
+	ctx := context.Background()
+	producer := streams.NewProducer(client, "", 0)
+
+	require.NoError(t, producer.Publish(ctx, "articles:crime", []byte(`{"id":"doc-1"}`)))
+
+	entries, err := client.XRange(ctx, "articles:crime", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, `{"id":"doc-1"}`, entries[0].Values[streams.PayloadField])
+	assert.Equal(t, []string{"articles:crime"}, producer.Keys())
+	assert.Empty(t, mr.PubSubChannels(""), "streams mode does not publish to pub/sub")
+}
+
+func TestProducer_PubSubModeDoesNotAddToStream(t *testing.T) {
+	client, _ := setupRedis(t)
+	ctx := context.Background()
+	producer := streams.NewProducer(client, streams.ModePubSub, 0)
+
+	sub := client.Subscribe(ctx, "articles:crime")
+	defer sub.Close()
+	_, err := sub.Receive(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, producer.Publish(ctx, "articles:crime", []byte(`{"id":"doc-1"}`)))
+
+	msg, err := sub.ReceiveMessage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"doc-1"}`, msg.Payload)
+
+	exists, err := client.Exists(ctx, "articles:crime").Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+	assert.Empty(t, producer.Keys())
+}
+
+func TestConsumer_ReadAckAndLag(t *testing.T) {
+	client, _ := setupRedis(t)
+	ctx := context.Background()
+	producer := streams.NewProducer(client, streams.ModeStreams, 0)
+	consumer := streams.NewConsumer(client, streams.ConsumerConfig{
+		Streams: []string{"articles:crime"},
+		Group:   "streetcode",
+		Name:    "web-1",
+		Block:   10 * time.Millisecond,
+	})
+	require.NoError(t, consumer.EnsureGroups(ctx))
+	require.NoError(t, consumer.EnsureGroups(ctx), "an existing group is kept")
+
+	require.NoError(t, producer.Publish(ctx, "articles:crime", []byte(`{"id":"doc-1"}`)))
+	require.NoError(t, producer.Publish(ctx, "articles:crime", []byte(`{"id":"doc-2"}`)))
+
+	messages, err := consumer.Read(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "articles:crime", messages[0].Stream)
+	assert.JSONEq(t, `{"id":"doc-1"}`, string(messages[0].Payload))
+
+	require.NoError(t, consumer.Ack(ctx, messages[0]))
+
+	lags, err := streams.Lag(ctx, client, "articles:crime")
+	require.NoError(t, err)
+	require.Len(t, lags, 1)
+	assert.Equal(t, "streetcode", lags[0].Group)
+	assert.Equal(t, int64(1), lags[0].Pending, "the second message is read but not acknowledged")
+
+	messages, err = consumer.Read(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages, "read messages are not delivered to the group again")
+}
+
+func TestConsumer_ClaimsEntriesLeftPending(t *testing.T) {
+	client, _ := setupRedis(t)
+	ctx := context.Background()
+	producer := streams.NewProducer(client, streams.ModeStreams, 0)
+	newConsumer := func(name string) *streams.Consumer {
+		return streams.NewConsumer(client, streams.ConsumerConfig{
+			Streams:   []string{"articles:crime"},
+			Group:     "streetcode",
+			Name:      name,
+			Block:     10 * time.Millisecond,
+			ClaimIdle: time.Millisecond,
+		})
+	}
+	crashed, survivor := newConsumer("web-1"), newConsumer("web-2")
+	require.NoError(t, crashed.EnsureGroups(ctx))
+	require.NoError(t, producer.Publish(ctx, "articles:crime", []byte(`{"id":"doc-1"}`)))
+
+	read, err := crashed.Read(ctx)
+	require.NoError(t, err)
+	require.Len(t, read, 1)
+
+	time.Sleep(5 * time.Millisecond)
+	claimed, err := survivor.Claim(ctx)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, read[0].ID, claimed[0].ID)
+}
+
+func TestLag_MissingStreamHasNoGroups(t *testing.T) {
+	client, _ := setupRedis(t)
+
+	lags, err := streams.Lag(context.Background(), client, "articles:none")
+
+	require.NoError(t, err)
+	assert.Empty(t, lags)
+}
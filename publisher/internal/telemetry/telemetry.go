@@ -27,6 +27,13 @@ type Metrics struct {
 
 	// Dedup metrics
 	DedupHits prometheus.Counter
+
+	// Stream metrics: per channel stream and consumer group
+	StreamLag     *prometheus.GaugeVec
+	StreamPending *prometheus.GaugeVec
+
+	// Channel health metrics: per custom channel
+	CircuitOpen *prometheus.GaugeVec
 }
 
 // Provider wraps Prometheus metrics for the publisher.
@@ -41,6 +48,8 @@ func NewProvider() *Provider {
 	initRoutingMetrics(m)
 	initBatchMetrics(m)
 	initDedupMetrics(m)
+	initStreamMetrics(m)
+	initChannelHealthMetrics(m)
 	return &Provider{Metrics: m}
 }
 
@@ -88,6 +97,25 @@ func (p *Provider) RecordDedupHit() {
 	p.Metrics.DedupHits.Inc()
 }
 
+// RecordStreamLag records how many entries of a channel stream a consumer group
+// has not read yet (lag, skipped when Redis cannot tell) and has read but not
+// acknowledged (pending).
+func (p *Provider) RecordStreamLag(stream, group string, lag, pending int64) {
+	if lag >= 0 {
+		p.Metrics.StreamLag.WithLabelValues(stream, group).Set(float64(lag))
+	}
+	p.Metrics.StreamPending.WithLabelValues(stream, group).Set(float64(pending))
+}
+
+// RecordCircuit records whether a channel's circuit breaker is open.
+func (p *Provider) RecordCircuit(channel string, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+	p.Metrics.CircuitOpen.WithLabelValues(channel).Set(value)
+}
+
 func initCursorMetrics(m *Metrics) {
 	m.CursorLag = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "publisher_cursor_lag_seconds",
@@ -138,3 +166,22 @@ func initDedupMetrics(m *Metrics) {
 		Help: "Total deduplication hits (content already published to channel)",
 	})
 }
+
+func initStreamMetrics(m *Metrics) {
+	m.StreamLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "publisher_stream_lag_entries",
+		Help: "Channel stream entries not yet read by a consumer group",
+	}, []string{"stream", "group"})
+
+	m.StreamPending = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "publisher_stream_pending_entries",
+		Help: "Channel stream entries read by a consumer group but not acknowledged",
+	}, []string{"stream", "group"})
+}
+
+func initChannelHealthMetrics(m *Metrics) {
+	m.CircuitOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "publisher_channel_circuit_open",
+		Help: "1 while a channel's circuit breaker is open and its items are queued, otherwise 0",
+	}, []string{"channel"})
+}
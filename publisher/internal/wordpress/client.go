@@ -0,0 +1,175 @@
+// Package wordpress is a minimal WordPress REST API client for creating and
+// trashing posts on partner sites. It authenticates with application passwords (HTTP Basic).
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// postsPath is the WordPress REST API posts collection
+const postsPath = "/wp-json/wp/v2/posts"
+
+// currentUserPath is the WordPress REST API endpoint of the authenticated user
+const currentUserPath = "/wp-json/wp/v2/users/me"
+
+// maxErrorBodyBytes bounds how much of an error response is read
+const maxErrorBodyBytes = 4096
+
+// Config identifies a WordPress site and the user posts are created as
+type Config struct {
+	BaseURL  string
+	Username string
+	// Password is a WordPress application password, not the user's login password
+	Password string
+}
+
+// Post is the body of a create-post request
+type Post struct {
+	Title      string         `json:"title"`
+	Content    string         `json:"content"`
+	Excerpt    string         `json:"excerpt,omitempty"`
+	Status     string         `json:"status"`
+	Categories []int          `json:"categories,omitempty"`
+	Meta       map[string]any `json:"meta,omitempty"`
+}
+
+// CreatedPost is the subset of the WordPress post response the publisher uses
+type CreatedPost struct {
+	ID   int    `json:"id"`
+	Link string `json:"link"`
+}
+
+// APIError is a non-2xx response from the WordPress REST API
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("wordpress API returned %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("wordpress API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// Client creates posts on a single WordPress site
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewClient creates a WordPress client. The http.Client carries the timeout.
+func NewClient(cfg Config, httpClient *http.Client) *Client {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &Client{cfg: cfg, httpClient: httpClient}
+}
+
+// CreatePost creates a post and returns its ID and permalink
+func (c *Client) CreatePost(ctx context.Context, post *Post) (*CreatedPost, error) {
+	body, err := json.Marshal(post)
+	if err != nil {
+		return nil, fmt.Errorf("marshal post: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+postsPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create post: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, decodeAPIError(resp)
+	}
+
+	var created CreatedPost
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&created); decodeErr != nil {
+		return nil, fmt.Errorf("decode post response: %w", decodeErr)
+	}
+
+	return &created, nil
+}
+
+// TrashPost moves a post to the trash, where an editor can restore it.
+// A post that no longer exists is treated as already removed.
+func (c *Client) TrashPost(ctx context.Context, id int) error {
+	endpoint := c.cfg.BaseURL + postsPath + "/" + strconv.Itoa(id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("trash post: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return decodeAPIError(resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// Ping checks that the site is up and accepts the application password by
+// fetching the authenticated user. The publisher uses it to probe wordpress channels.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+currentUserPath, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return decodeAPIError(resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// decodeAPIError reads a WordPress error body ({"code","message"}) when present
+func decodeAPIError(resp *http.Response) error {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(raw))}
+	var wpErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &wpErr) == nil && wpErr.Code != "" {
+		apiErr.Code = wpErr.Code
+		apiErr.Message = wpErr.Message
+	}
+
+	return apiErr
+}
@@ -0,0 +1,81 @@
+package wordpress_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/publisher/internal/wordpress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePost_SendsAuthenticatedPost(t *testing.T) {
+	var got wordpress.Post
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/wp-json/wp/v2/posts", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "editor", user)
+		assert.Equal(t, "app pass", pass)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":42,"link":"https://partner.example/?p=42"}`))
+	}))
+	defer srv.Close()
+
+	client := wordpress.NewClient(wordpress.Config{
+		BaseURL: srv.URL + "/", Username: "editor", Password: "app pass",
+	}, srv.Client())
+
+	created, err := client.CreatePost(context.Background(), &wordpress.Post{
+		Title: "Headline", Content: "<p>Body</p>", Status: "draft", Categories: []int{7},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, created.ID)
+	assert.Equal(t, "https://partner.example/?p=42", created.Link)
+	assert.Equal(t, "Headline", got.Title)
+	assert.Equal(t, []int{7}, got.Categories)
+}
+
+func TestCreatePost_DecodesAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":"rest_cannot_create","message":"Sorry, you are not allowed to create posts as this user."}`))
+	}))
+	defer srv.Close()
+
+	client := wordpress.NewClient(wordpress.Config{BaseURL: srv.URL}, srv.Client())
+	_, err := client.CreatePost(context.Background(), &wordpress.Post{Title: "x"})
+
+	var apiErr *wordpress.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, "rest_cannot_create", apiErr.Code)
+}
+
+func TestTrashPost(t *testing.T) {
+	var method, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		if r.URL.Path == "/wp-json/wp/v2/posts/404" {
+			http.Error(w, `{"code":"rest_post_invalid_id","message":"Invalid post ID."}`, http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":42,"status":"trash"}`))
+	}))
+	defer srv.Close()
+
+	client := wordpress.NewClient(wordpress.Config{BaseURL: srv.URL, Username: "editor", Password: "x"}, srv.Client())
+
+	require.NoError(t, client.TrashPost(context.Background(), 42))
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/wp-json/wp/v2/posts/42", path)
+
+	require.NoError(t, client.TrashPost(context.Background(), 404), "a missing post is already gone")
+}
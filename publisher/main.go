@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 )
 
@@ -16,6 +17,8 @@ var (
 )
 
 func main() {
+	defer crash.Start("publisher")()
+
 	// Get command from args, default to "both" (api + router)
 	command := "both"
 	if len(os.Args) > 1 {
@@ -30,6 +33,14 @@ func main() {
 		runAPIServer()
 	case "router":
 		runRouter()
+	case "reports":
+		os.Exit(runWeeklyReports(os.Args[2:]))
+	case "drupal-sync":
+		os.Exit(runDrupalSync(os.Args[2:]))
+	case "consume":
+		os.Exit(runConsume(os.Args[2:]))
+	case "config":
+		os.Exit(runConfig(os.Args[2:]))
 	case "version":
 		// CLI output (not operational log)
 		fmt.Printf("Publisher version %s\n", version)
@@ -105,6 +116,10 @@ func printUsage() {
 	fmt.Println("  both       Start both HTTP API server and router (default)")
 	fmt.Println("  api        Start the HTTP API server only")
 	fmt.Println("  router     Start the background router service only")
+	fmt.Println("  reports    Email last week's channel reports (optional week date: YYYY-MM-DD)")
+	fmt.Println("  drupal-sync  Compare Drupal groups with channels (--create adds missing channels, disabled)")
+	fmt.Println("  consume    Print channel stream messages through a consumer group: consume <group> <channel>...")
+	fmt.Println("  config check  Validate config.yml and environment overrides (--show lists every setting)")
 	fmt.Println("  version    Print version information")
 	fmt.Println("  help       Show this help message")
 	fmt.Println()
@@ -113,6 +128,10 @@ func printUsage() {
 	fmt.Println("  publisher both           # Same as above")
 	fmt.Println("  publisher api            # Start API server only on port 8070")
 	fmt.Println("  publisher router         # Start router service only")
+	fmt.Println("  publisher reports        # Email weekly reports (run from cron on Mondays)")
+	fmt.Println("  publisher drupal-sync --create  # Create a channel for every new Drupal group")
+	fmt.Println("  publisher consume debug articles:crime  # Follow a channel stream as group \"debug\"")
+	fmt.Println("  publisher config check --show  # Show the configuration every command would use")
 	fmt.Println()
 	fmt.Println("Environment Variables:")
 	fmt.Println("  Database:")
@@ -128,9 +147,18 @@ func printUsage() {
 	fmt.Println("    GIN_MODE                     - Gin mode: debug|release (default: debug)")
 	fmt.Println()
 	fmt.Println("  Router Service:")
-	fmt.Println("    ELASTICSEARCH_URL            - Elasticsearch URL (default: http://localhost:9200)")
-	fmt.Println("    REDIS_ADDR                   - Redis address (default: localhost:6379)")
+	fmt.Println("    ES_URL                       - Elasticsearch URL (required)")
+	fmt.Println("    REDIS_URL                    - Redis address, e.g. redis:6379 (required)")
 	fmt.Println("    REDIS_PASSWORD               - Redis password (optional)")
+	fmt.Println("    PUBLISHER_REDIS_DELIVERY     - Channel delivery: streams, pubsub, or both (default: streams)")
 	fmt.Println("    PUBLISHER_ROUTER_CHECK_INTERVAL - Check interval (default: 5m)")
 	fmt.Println("    PUBLISHER_ROUTER_BATCH_SIZE     - Batch size (default: 100)")
+	fmt.Println()
+	fmt.Println("  Weekly Reports:")
+	fmt.Println("    CLICK_TRACKER_URL            - Click-tracker API for top-clicked items (optional)")
+	fmt.Println("    REPORTS_SMTP_HOST            - SMTP host for report emails")
+	fmt.Println("    REPORTS_SMTP_PORT            - SMTP port (default: 587)")
+	fmt.Println("    REPORTS_SMTP_USERNAME        - SMTP username (optional)")
+	fmt.Println("    REPORTS_SMTP_PASSWORD        - SMTP password (optional)")
+	fmt.Println("    REPORTS_FROM                 - Sender address")
 }
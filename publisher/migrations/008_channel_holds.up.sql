@@ -0,0 +1,13 @@
+-- Editor holds: content pulled from a channel before the router publishes it.
+-- The router skips held (channel, content) pairs; see GET /api/v1/channels/:id/queue.
+CREATE TABLE channel_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel_id UUID NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    content_id VARCHAR(255) NOT NULL,
+    content_title TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (channel_id, content_id)
+);
+
+CREATE INDEX idx_channel_holds_channel_id ON channel_holds (channel_id, created_at DESC);
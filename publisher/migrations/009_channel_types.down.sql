@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_channels_channel_type;
+
+ALTER TABLE channels
+    DROP COLUMN IF EXISTS config,
+    DROP COLUMN IF EXISTS channel_type;
@@ -0,0 +1,10 @@
+-- Migration: 009_channel_types
+-- Description: Channel delivery type. 'redis' channels publish to Redis pub/sub
+-- (Drupal and other subscribers); other types deliver directly using the
+-- type-specific settings in config (e.g. {"wordpress": {...}}).
+
+ALTER TABLE channels
+    ADD COLUMN channel_type VARCHAR(50) NOT NULL DEFAULT 'redis',
+    ADD COLUMN config JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_channels_channel_type ON channels(channel_type);
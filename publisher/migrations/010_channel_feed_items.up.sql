@@ -0,0 +1,21 @@
+-- Migration: 010_channel_feed_items
+-- Description: Items kept by 'feed' channels. The router appends each routed
+-- item and trims the channel to its newest config.feed.max_items; the API
+-- renders them as RSS or Atom at GET /feeds/:id.
+
+CREATE TABLE channel_feed_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel_id UUID NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    content_id VARCHAR(255) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    topics TEXT[] NOT NULL DEFAULT '{}',
+    published_at TIMESTAMP WITH TIME ZONE,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (channel_id, content_id)
+);
+
+CREATE INDEX idx_channel_feed_items_channel ON channel_feed_items (channel_id, added_at DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS channel_delivery_failures;
@@ -0,0 +1,15 @@
+-- Migration: 011_channel_delivery_failures
+-- Description: Items the router failed to deliver to a channel. Feeds the
+-- failure summary of the weekly editorial report; rows are kept 90 days.
+
+CREATE TABLE channel_delivery_failures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel_id UUID REFERENCES channels(id) ON DELETE CASCADE,
+    channel_name VARCHAR(255) NOT NULL,
+    content_id VARCHAR(255) NOT NULL,
+    content_title TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_channel_delivery_failures_channel ON channel_delivery_failures (channel_name, failed_at DESC);
@@ -0,0 +1,16 @@
+-- Migration: 012_channel_scheduled_items
+-- Description: Items routed to a channel whose config.schedule does not allow
+-- delivery yet (outside publishing hours, under embargo, or within the
+-- minimum interval). The router releases them oldest first once it does.
+
+CREATE TABLE channel_scheduled_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel_id UUID NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    content_id VARCHAR(255) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (channel_id, content_id)
+);
+
+CREATE INDEX idx_channel_scheduled_items_channel ON channel_scheduled_items (channel_id, queued_at);
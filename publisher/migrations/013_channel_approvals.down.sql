@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS channel_approvals;
+ALTER TABLE channels DROP COLUMN IF EXISTS requires_approval;
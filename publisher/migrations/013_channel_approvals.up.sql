@@ -0,0 +1,30 @@
+-- Migration: 013_channel_approvals
+-- Description: Editorial approval for custom channels. Items routed to a
+-- channel with requires_approval wait in channel_approvals until an editor
+-- approves (optionally editing title, body, or summary) or rejects them; the
+-- router delivers approved items on its next poll.
+
+ALTER TABLE channels ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE channel_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel_id UUID NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    content_id VARCHAR(255) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT '',
+    quality_score INTEGER NOT NULL DEFAULT 0,
+    topics TEXT[] NOT NULL DEFAULT '{}',
+    payload JSONB NOT NULL,
+    edits JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'published', 'failed')),
+    reviewed_by VARCHAR(255) NOT NULL DEFAULT '',
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (channel_id, content_id)
+);
+
+CREATE INDEX idx_channel_approvals_status ON channel_approvals (status, created_at);
@@ -0,0 +1,24 @@
+-- Migration: 014_channel_dead_letters
+-- Description: Dead-letter queue for failed deliveries. Each item the router
+-- could not deliver is kept with its full payload and last error and retried
+-- with exponential backoff; after the last attempt it stays as 'dead' until
+-- an operator replays or discards it. Delivered items are removed.
+
+CREATE TABLE channel_dead_letters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel_id UUID REFERENCES channels(id) ON DELETE CASCADE,
+    channel_name VARCHAR(255) NOT NULL,
+    content_id VARCHAR(255) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    last_error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'retrying'
+        CHECK (status IN ('retrying', 'dead')),
+    next_retry_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (channel_name, content_id)
+);
+
+CREATE INDEX idx_channel_dead_letters_due ON channel_dead_letters (status, next_retry_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS content_retractions;
+
+ALTER TABLE publish_history
+    DROP COLUMN IF EXISTS retracted_at,
+    DROP COLUMN IF EXISTS remote_id;
@@ -0,0 +1,27 @@
+-- Migration: 015_content_retractions
+-- Description: Retraction of published content. Each publish history row
+-- keeps the ID of the post or node a remote channel created (WordPress post
+-- ID, Drupal node type/UUID) and when it was taken down again. A retraction
+-- request is processed by the router on its next poll and retried with
+-- backoff until every channel the item went to has been handled.
+
+ALTER TABLE publish_history
+    ADD COLUMN remote_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN retracted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE content_retractions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    content_id VARCHAR(255) NOT NULL UNIQUE,
+    reason TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'done', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    results JSONB NOT NULL DEFAULT '[]',
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_content_retractions_due ON content_retractions (status, next_attempt_at);
@@ -0,0 +1,14 @@
+-- Migration: 016_routing_domains
+-- Description: Saved settings of routing domains (topic, db_channel, crime, …).
+-- A row enables or disables a domain and sets its parameters, taking
+-- precedence over the routing.domains section of config.yml. Domains without
+-- a row use their config or built-in defaults. The router reloads the table
+-- on every poll.
+
+CREATE TABLE routing_domains (
+    name VARCHAR(50) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
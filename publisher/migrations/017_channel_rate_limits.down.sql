@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_publish_history_channel_published;
+DROP INDEX IF EXISTS idx_channel_scheduled_items_quality;
+ALTER TABLE channel_scheduled_items DROP COLUMN IF EXISTS quality_score;
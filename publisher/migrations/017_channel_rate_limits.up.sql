@@ -0,0 +1,14 @@
+-- Migration: 017_channel_rate_limits
+-- Description: Items of rate-limited channels (config.rate_limit) wait in
+-- channel_scheduled_items like scheduled ones. Their quality score is stored
+-- so drop_lowest_quality channels can release the best items first and drop
+-- the worst when the backlog is full.
+
+ALTER TABLE channel_scheduled_items ADD COLUMN quality_score INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_channel_scheduled_items_quality
+    ON channel_scheduled_items (channel_id, quality_score DESC, queued_at);
+
+-- Counts of a channel's recent deliveries for its hourly and daily quota
+CREATE INDEX idx_publish_history_channel_published
+    ON publish_history (channel_name, published_at DESC);
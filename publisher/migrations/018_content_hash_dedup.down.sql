@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_channel_scheduled_items_hash;
+ALTER TABLE channel_scheduled_items DROP COLUMN IF EXISTS content_hash;
+DROP INDEX IF EXISTS idx_publish_history_channel_hash;
+ALTER TABLE publish_history DROP COLUMN IF EXISTS content_hash;
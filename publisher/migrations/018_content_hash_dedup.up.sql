@@ -0,0 +1,17 @@
+-- Migration: 018_content_hash_dedup
+-- Description: Hash of a published item's normalized title and opening body,
+-- so the same wire story crawled from several sources is published to a
+-- channel once. Queued items carry it too, so a channel's queue keeps only
+-- the highest quality variant.
+
+ALTER TABLE publish_history ADD COLUMN content_hash VARCHAR(64);
+
+CREATE INDEX idx_publish_history_channel_hash
+    ON publish_history (channel_name, content_hash, published_at DESC)
+    WHERE content_hash IS NOT NULL;
+
+ALTER TABLE channel_scheduled_items ADD COLUMN content_hash VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_channel_scheduled_items_hash
+    ON channel_scheduled_items (channel_id, content_hash)
+    WHERE content_hash <> '';
@@ -0,0 +1,26 @@
+-- Migration: 019_channel_credentials
+-- Description: Channel secrets (Drupal tokens, webhook signing secrets,
+-- WordPress application passwords, chat webhook URLs) stored encrypted with
+-- AES-256-GCM. Channels reference a credential by name from their config
+-- (e.g. config.webhook.secret_credential). key_id identifies the encryption
+-- key so a key change can be detected and credentials re-encrypted; hint keeps
+-- the last characters of long values in clear for masked display. tenant
+-- groups the credentials of one partner or site.
+
+CREATE TABLE channel_credentials (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    tenant VARCHAR(100) NOT NULL DEFAULT '',
+    kind VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    ciphertext BYTEA NOT NULL,
+    key_id VARCHAR(16) NOT NULL,
+    hint VARCHAR(8) NOT NULL DEFAULT '',
+    version INTEGER NOT NULL DEFAULT 1,
+    rotated_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_channel_credentials_tenant ON channel_credentials (tenant, name);
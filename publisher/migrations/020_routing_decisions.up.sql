@@ -0,0 +1,23 @@
+-- Migration: 020_routing_decisions
+-- Description: Idempotency records for deliveries. Before delivering an item
+-- to a channel the router claims the row keyed by idempotency_key (a hash of
+-- content ID, channel ID, and channel name); the unique constraint lets only
+-- one claim win. Rows move from pending to delivered or failed. A pending row
+-- older than the claim timeout means the router stopped mid-delivery: it is
+-- marked in_doubt and never delivered again automatically, only by replaying
+-- its dead letter.
+
+CREATE TABLE routing_decisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    idempotency_key VARCHAR(64) NOT NULL UNIQUE,
+    content_id VARCHAR(255) NOT NULL,
+    channel_id UUID,
+    channel_name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_routing_decisions_content ON routing_decisions (content_id);
+CREATE INDEX idx_routing_decisions_status ON routing_decisions (status, claimed_at);
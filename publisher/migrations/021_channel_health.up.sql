@@ -0,0 +1,20 @@
+-- Migration: 021_channel_health
+-- Description: Health of each channel's destination and the state of its
+-- circuit breaker. The router probes destinations periodically and counts
+-- consecutive probe and delivery failures; past the threshold the circuit
+-- opens and items routed to the channel wait in channel_scheduled_items
+-- instead of being delivered. A successful probe (or, for channels that
+-- cannot be probed, the cool-down) half-opens it; the next delivery closes
+-- it again or reopens it.
+
+CREATE TABLE channel_health (
+    channel_id UUID PRIMARY KEY REFERENCES channels(id) ON DELETE CASCADE,
+    circuit VARCHAR(20) NOT NULL DEFAULT 'closed',
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    last_failure_at TIMESTAMP WITH TIME ZONE,
+    opened_at TIMESTAMP WITH TIME ZONE,
+    last_probe_at TIMESTAMP WITH TIME ZONE,
+    last_probe_ok BOOLEAN,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
 	"github.com/jonesrussell/north-cloud/publisher/internal/config"
 	"github.com/jonesrussell/north-cloud/publisher/internal/database"
@@ -21,32 +22,31 @@ type RouterConfig struct {
 	ESURL             string
 	RedisAddr         string
 	RedisPassword     string
+	RedisDelivery     string
+	StreamMaxLen      int64
 	PollInterval      time.Duration
 	DiscoveryInterval time.Duration
 	BatchSize         int
 	PipelineURL       string
+	ClickBaseURL      string
+	ClickKey          clickurl.Key
+	DrupalURL         string
+	DrupalToken       string
+	Domains           map[string]config.RoutingDomainConfig
+	DedupWindow       time.Duration
+	HealthInterval    time.Duration
+	CircuitThreshold  int
+	Credentials       config.CredentialsConfig
 }
 
 // LoadRouterConfig loads configuration from config file with env var overrides
 func LoadRouterConfig() RouterConfig {
-	// Load main config
-	configPath := infraconfig.GetConfigPath("config.yml")
-	cfg, err := config.Load(configPath)
+	// Load main config (config.yml when present, environment overrides).
+	// Use fmt for config loading errors since logger isn't initialized yet
+	cfg, err := config.Resolve(infraconfig.GetConfigPath("config.yml"))
 	if err != nil {
-		// Config file is optional - try loading from environment variables only
-		// Use fmt for config loading errors since logger isn't initialized yet
-		fmt.Printf("Warning: Failed to load config file (%s), trying environment variables: %v\n", configPath, err)
-		cfg = &config.Config{}
-		// Apply environment variables to the config
-		if envErr := infraconfig.ApplyEnvOverrides(cfg); envErr != nil {
-			fmt.Fprintf(os.Stderr, "Failed to apply environment overrides: %v\n", envErr)
-		}
-		// Apply defaults for any missing values
-		config.SetDefaults(cfg)
-		if validateErr := cfg.Validate(); validateErr != nil {
-			fmt.Fprintf(os.Stderr, "Invalid configuration from environment: %v\n", validateErr)
-			os.Exit(1)
-		}
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
 	}
 
 	// Map CheckInterval to PollInterval for Routing V2
@@ -68,9 +68,20 @@ func LoadRouterConfig() RouterConfig {
 		ESURL:             cfg.Elasticsearch.URL,
 		RedisAddr:         cfg.Redis.URL,
 		RedisPassword:     cfg.Redis.Password,
+		RedisDelivery:     cfg.Redis.Delivery,
+		StreamMaxLen:      cfg.Redis.StreamMaxLen,
 		PollInterval:      pollInterval,
 		DiscoveryInterval: defaultDiscoveryInterval,
 		BatchSize:         cfg.Service.BatchSize,
 		PipelineURL:       cfg.Service.PipelineURL,
+		ClickBaseURL:      cfg.ClickTracker.BaseURL,
+		ClickKey:          cfg.ClickTracker.SigningKey(),
+		DrupalURL:         cfg.Drupal.URL,
+		DrupalToken:       cfg.Drupal.Token,
+		Domains:           cfg.Routing.Domains,
+		DedupWindow:       max(cfg.Service.DedupWindow, 0),
+		HealthInterval:    max(cfg.Service.HealthCheckInterval, 0),
+		CircuitThreshold:  max(cfg.Service.CircuitFailureThreshold, 0),
+		Credentials:       cfg.Credentials,
 	}
 }
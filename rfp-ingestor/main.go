@@ -9,6 +9,7 @@ import (
 	"time"
 
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
 	"github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/rfp-ingestor/internal/api"
@@ -27,6 +28,8 @@ func main() {
 }
 
 func run() int {
+	defer crash.Start("rfp-ingestor")()
+
 	cfg, err := config.Load(infraconfig.GetConfigPath("config.yml"))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
@@ -0,0 +1,263 @@
+// Package analytics records executed searches in Redis and reports the most
+// searched queries, the queries that find nothing, and search latency.
+// Counts are kept per UTC day and expire after the configured retention.
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	dayLayout     = "2006-01-02"
+	hoursPerDay   = 24
+	recordTimeout = 2 * time.Second
+	reportTTL     = time.Minute
+
+	fieldSearches    = "searches"
+	fieldZeroResults = "zero_results"
+	fieldOverflow    = "le_inf"
+
+	percentile50 = 0.50
+	percentile90 = 0.90
+	percentile95 = 0.95
+	percentile99 = 0.99
+
+	// latencyPrecision rounds reported percentiles to tenths of a millisecond
+	latencyPrecision = 10
+)
+
+// latencyBucketsMs are the upper bounds of the latency histogram; slower
+// searches fall in the overflow bucket.
+var latencyBucketsMs = []int64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// Analytics records searches and reads the daily counts back into reports.
+type Analytics struct {
+	client *redis.Client
+	cfg    config.AnalyticsConfig
+	logger infralogger.Logger
+	events chan domain.QueryEvent
+}
+
+// New creates analytics on client. Call Run to record tracked searches.
+func New(client *redis.Client, cfg config.AnalyticsConfig, log infralogger.Logger) *Analytics {
+	return &Analytics{
+		client: client,
+		cfg:    cfg,
+		logger: log,
+		events: make(chan domain.QueryEvent, cfg.BufferSize),
+	}
+}
+
+// Track queues event for recording without blocking the search. Events that
+// do not fit in the buffer are dropped, so a slow Redis never slows searches.
+func (a *Analytics) Track(event domain.QueryEvent) {
+	select {
+	case a.events <- event:
+	default:
+		a.logger.Debug("Search analytics buffer full, dropping event")
+	}
+}
+
+// Run records tracked searches until ctx is done.
+func (a *Analytics) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-a.events:
+			recordCtx, cancel := context.WithTimeout(ctx, recordTimeout)
+			if err := a.Record(recordCtx, event); err != nil {
+				a.logger.Warn("Search analytics record failed", infralogger.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// Record counts one search in the day it ran: its query, whether it found
+// nothing, and its latency bucket.
+func (a *Analytics) Record(ctx context.Context, event domain.QueryEvent) error {
+	day := event.At.UTC().Format(dayLayout)
+	stats := a.dayKey("stats", day)
+	expiry := a.cfg.Retention + hoursPerDay*time.Hour
+
+	pipe := a.client.TxPipeline()
+	pipe.HIncrBy(ctx, stats, fieldSearches, 1)
+	pipe.HIncrBy(ctx, stats, latencyField(event.Latency), 1)
+	if event.Hits == 0 {
+		pipe.HIncrBy(ctx, stats, fieldZeroResults, 1)
+	}
+	pipe.Expire(ctx, stats, expiry)
+
+	if event.Query != "" {
+		queries := a.dayKey("queries", day)
+		pipe.ZIncrBy(ctx, queries, 1, event.Query)
+		pipe.Expire(ctx, queries, expiry)
+		if event.Hits == 0 {
+			zero := a.dayKey("zero", day)
+			pipe.ZIncrBy(ctx, zero, 1, event.Query)
+			pipe.Expire(ctx, zero, expiry)
+		}
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record search: %w", err)
+	}
+	return nil
+}
+
+// TopQueries returns the limit most searched queries of the last days days.
+func (a *Analytics) TopQueries(ctx context.Context, days, limit int) ([]domain.QueryCount, error) {
+	return a.rankQueries(ctx, "queries", days, limit)
+}
+
+// ZeroResultQueries returns the limit most searched queries of the last days
+// days that found nothing.
+func (a *Analytics) ZeroResultQueries(ctx context.Context, days, limit int) ([]domain.QueryCount, error) {
+	return a.rankQueries(ctx, "zero", days, limit)
+}
+
+// rankQueries sums the daily counts of kind into a short-lived report key and
+// reads the top of it in one transaction, so concurrent reports cannot mix. Queries searched fewer than MinCount times are left
+// out: a query only one person typed may identify them.
+func (a *Analytics) rankQueries(ctx context.Context, kind string, days, limit int) ([]domain.QueryCount, error) {
+	keys := a.windowKeys(kind, days)
+	report := a.cfg.KeyPrefix + ":report:" + kind + ":" + strconv.Itoa(days)
+
+	pipe := a.client.TxPipeline()
+	pipe.ZUnionStore(ctx, report, &redis.ZStore{Keys: keys, Aggregate: "SUM"})
+	pipe.Expire(ctx, report, reportTTL)
+	ranked := pipe.ZRevRangeByScoreWithScores(ctx, report, &redis.ZRangeBy{
+		Min:   strconv.Itoa(a.cfg.MinCount),
+		Max:   "+inf",
+		Count: int64(limit),
+	})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("rank %s: %w", kind, err)
+	}
+
+	counts := make([]domain.QueryCount, 0, len(ranked.Val()))
+	for _, z := range ranked.Val() {
+		query, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		counts = append(counts, domain.QueryCount{Query: query, Searches: int64(z.Score)})
+	}
+	return counts, nil
+}
+
+// Latency sums the daily search counts and latency histograms of the last
+// days days.
+func (a *Analytics) Latency(ctx context.Context, days int) (*domain.LatencyReport, error) {
+	pipe := a.client.Pipeline()
+	keys := a.windowKeys("stats", days)
+	results := make([]*redis.MapStringStringCmd, len(keys))
+	for i, key := range keys {
+		results[i] = pipe.HGetAll(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("read search stats: %w", err)
+	}
+
+	totals := make(map[string]int64)
+	for _, result := range results {
+		for field, value := range result.Val() {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			totals[field] += n
+		}
+	}
+
+	report := &domain.LatencyReport{
+		WindowDays:  days,
+		Searches:    totals[fieldSearches],
+		ZeroResults: totals[fieldZeroResults],
+	}
+	if report.Searches == 0 {
+		return report, nil
+	}
+	report.ZeroResultRate = float64(report.ZeroResults) / float64(report.Searches)
+
+	histogram := make([]int64, len(latencyBucketsMs)+1)
+	for i, bound := range latencyBucketsMs {
+		histogram[i] = totals[bucketField(bound)]
+	}
+	histogram[len(latencyBucketsMs)] = totals[fieldOverflow]
+	report.P50Ms = percentile(histogram, percentile50)
+	report.P90Ms = percentile(histogram, percentile90)
+	report.P95Ms = percentile(histogram, percentile95)
+	report.P99Ms = percentile(histogram, percentile99)
+	return report, nil
+}
+
+// windowKeys are the daily keys of kind for the last days days, today first.
+func (a *Analytics) windowKeys(kind string, days int) []string {
+	now := time.Now().UTC()
+	keys := make([]string, days)
+	for i := range days {
+		keys[i] = a.dayKey(kind, now.AddDate(0, 0, -i).Format(dayLayout))
+	}
+	return keys
+}
+
+func (a *Analytics) dayKey(kind, day string) string {
+	return a.cfg.KeyPrefix + ":" + kind + ":" + day
+}
+
+// latencyField is the histogram bucket a search taking latency falls in.
+func latencyField(latency time.Duration) string {
+	ms := latency.Milliseconds()
+	for _, bound := range latencyBucketsMs {
+		if ms <= bound {
+			return bucketField(bound)
+		}
+	}
+	return fieldOverflow
+}
+
+func bucketField(boundMs int64) string {
+	return "le_" + strconv.FormatInt(boundMs, 10)
+}
+
+// percentile estimates the p-th latency from histogram counts, interpolating
+// linearly within the bucket the rank falls in. Ranks in the overflow bucket
+// report its lower bound, the slowest latency the histogram can tell apart.
+func percentile(histogram []int64, p float64) float64 {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+	rank := p * float64(total)
+
+	var cumulative int64
+	for i, count := range histogram {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = float64(latencyBucketsMs[i-1])
+		}
+		if i == len(latencyBucketsMs) {
+			return lower
+		}
+		upper := float64(latencyBucketsMs[i])
+		value := lower + (upper-lower)*(rank-float64(cumulative))/float64(count)
+		return math.Round(value*latencyPrecision) / latencyPrecision
+	}
+	return 0
+}
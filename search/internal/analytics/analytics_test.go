@@ -0,0 +1,143 @@
+package analytics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/analytics"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestAnalytics(t *testing.T) *analytics.Analytics {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	cfg := config.AnalyticsConfig{
+		Retention: 30 * 24 * time.Hour, MinCount: 2, KeyPrefix: "search:analytics", BufferSize: 10,
+	}
+	return analytics.New(client, cfg, infralogger.NewNop())
+}
+
+func record(t *testing.T, a *analytics.Analytics, query string, hits int64, latency time.Duration, at time.Time) {
+	t.Helper()
+
+	event := domain.QueryEvent{Query: query, Hits: hits, Latency: latency, At: at}
+	if err := a.Record(context.Background(), event); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+}
+
+func TestAnalytics_TopAndZeroResultQueries(t *testing.T) {
+	t.Helper()
+
+	a := newTestAnalytics(t)
+	ctx := context.Background()
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+
+	record(t, a, "wildfire", 8, 20*time.Millisecond, now)
+	record(t, a, "wildfire", 5, 20*time.Millisecond, yesterday)
+	record(t, a, "wildfire", 3, 20*time.Millisecond, yesterday)
+	record(t, a, "mining", 2, 20*time.Millisecond, now)
+	record(t, a, "mining", 2, 20*time.Millisecond, now)
+	record(t, a, "jane doe", 0, 20*time.Millisecond, now)
+	record(t, a, "sudbry", 0, 20*time.Millisecond, now)
+	record(t, a, "sudbry", 0, 20*time.Millisecond, yesterday)
+
+	top, err := a.TopQueries(ctx, 7, 10)
+	if err != nil {
+		t.Fatalf("TopQueries: %v", err)
+	}
+	want := []domain.QueryCount{{Query: "wildfire", Searches: 3}, {Query: "sudbry", Searches: 2}, {Query: "mining", Searches: 2}}
+	if len(top) != len(want) {
+		t.Fatalf("expected queries searched at least twice, got %+v", top)
+	}
+	for i := range want {
+		if top[i] != want[i] {
+			t.Errorf("top[%d] = %+v, want %+v", i, top[i], want[i])
+		}
+	}
+
+	today, err := a.TopQueries(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("TopQueries: %v", err)
+	}
+	if len(today) != 1 || today[0].Query != "mining" {
+		t.Errorf("expected only today's counts in a one-day window, got %+v", today)
+	}
+
+	zero, err := a.ZeroResultQueries(ctx, 7, 10)
+	if err != nil {
+		t.Fatalf("ZeroResultQueries: %v", err)
+	}
+	if len(zero) != 1 || zero[0].Query != "sudbry" {
+		t.Errorf("expected the repeated zero-result query only, got %+v", zero)
+	}
+}
+
+func TestAnalytics_Latency(t *testing.T) {
+	t.Helper()
+
+	a := newTestAnalytics(t)
+	now := time.Now()
+	for range 9 {
+		record(t, a, "fire", 3, 40*time.Millisecond, now)
+	}
+	record(t, a, "", 0, 3*time.Second, now)
+
+	report, err := a.Latency(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("Latency: %v", err)
+	}
+	if report.Searches != 10 || report.ZeroResults != 1 || report.ZeroResultRate != 0.1 {
+		t.Errorf("unexpected volume %+v", report)
+	}
+	// Nine searches fall in the 25-50ms bucket and one in 2.5-5s
+	if report.P50Ms < 25 || report.P50Ms > 50 {
+		t.Errorf("p50 = %v, want within 25-50ms", report.P50Ms)
+	}
+	if report.P99Ms < 2500 || report.P99Ms > 5000 {
+		t.Errorf("p99 = %v, want within 2500-5000ms", report.P99Ms)
+	}
+}
+
+func TestAnalytics_LatencyWithoutSearches(t *testing.T) {
+	t.Helper()
+
+	report, err := newTestAnalytics(t).Latency(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("Latency: %v", err)
+	}
+	if report.Searches != 0 || report.P50Ms != 0 {
+		t.Errorf("expected an empty report, got %+v", report)
+	}
+}
+
+func TestAnalytics_TrackRecordsInBackground(t *testing.T) {
+	t.Helper()
+
+	a := newTestAnalytics(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.Run(ctx)
+
+	a.Track(domain.QueryEvent{Query: "flood", Hits: 1, Latency: time.Millisecond, At: time.Now()})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		report, err := a.Latency(ctx, 1)
+		if err == nil && report.Searches == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("expected the tracked search to be recorded")
+}
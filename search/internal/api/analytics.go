@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+// WithAnalytics enables the query analytics API behind JWTs signed with jwtSecret.
+func (h *Handler) WithAnalytics(jwtSecret string) *Handler {
+	h.analyticsSecret = jwtSecret
+	return h
+}
+
+// AnalyticsAuthMiddleware requires a valid JWT for the analytics API. Without
+// a secret analytics is disabled and the API answers 404, so what people
+// search for is never served unauthenticated.
+func (h *Handler) AnalyticsAuthMiddleware() gin.HandlerFunc {
+	if h.analyticsSecret == "" {
+		return func(c *gin.Context) {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrorResponse{
+				Error:     "Query analytics is disabled",
+				Code:      "ANALYTICS_DISABLED",
+				Timestamp: time.Now(),
+			})
+		}
+	}
+	return infrajwt.Middleware(h.analyticsSecret)
+}
+
+// TopQueries handles the most-searched-queries report.
+// Query params: days (window, default 7, up to the retention), limit (default 20, max 100).
+func (h *Handler) TopQueries(c *gin.Context) {
+	h.queriesReport(c, "Top queries report failed", h.searchService.TopQueries)
+}
+
+// ZeroResultQueries handles the report of the most searched queries that
+// found nothing. Query params as TopQueries.
+func (h *Handler) ZeroResultQueries(c *gin.Context) {
+	h.queriesReport(c, "Zero-result queries report failed", h.searchService.ZeroResultQueries)
+}
+
+// QueryLatency handles the search volume and latency percentiles report.
+// Query params: days (window, default 7, up to the retention).
+func (h *Handler) QueryLatency(c *gin.Context) {
+	result, err := h.searchService.QueryLatency(c.Request.Context(), parseAnalyticsRequest(c))
+	if err != nil {
+		h.analyticsError(c, "Latency report failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) queriesReport(
+	c *gin.Context,
+	failure string,
+	report func(context.Context, *domain.AnalyticsRequest) (*domain.QueriesReport, error),
+) {
+	result, err := report(c.Request.Context(), parseAnalyticsRequest(c))
+	if err != nil {
+		h.analyticsError(c, failure, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// parseAnalyticsRequest reads days and limit; invalid values fall back to defaults.
+func parseAnalyticsRequest(c *gin.Context) *domain.AnalyticsRequest {
+	req := &domain.AnalyticsRequest{}
+	if days, err := strconv.Atoi(c.Query("days")); err == nil {
+		req.Days = days
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil {
+		req.Limit = limit
+	}
+	return req
+}
+
+func (h *Handler) analyticsError(c *gin.Context, failure string, err error) {
+	if errors.Is(err, service.ErrAnalyticsUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:     err.Error(),
+			Code:      "ANALYTICS_UNAVAILABLE",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	h.logger.Error(failure, infralogger.Error(err))
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:     failure,
+		Code:      "ANALYTICS_ERROR",
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	// apiKeyHeader carries the API key; Authorization: Bearer and, for feed
+	// readers that cannot set headers, ?api_key= work too.
+	apiKeyHeader = "X-API-Key"
+	// apiKeyContextKey holds the authenticated *domain.APIKey in the gin context.
+	apiKeyContextKey = "api_key"
+)
+
+// WithAPIKeys requires an API key issued by the auth service on the search
+// API and enforces each key's limits.
+func (h *Handler) WithAPIKeys(cfg *config.APIKeysConfig) *Handler {
+	h.apiKeys = cfg
+	h.revokedKeys = make(map[string]bool, len(cfg.Revoked))
+	for _, id := range cfg.Revoked {
+		h.revokedKeys[strings.TrimSpace(id)] = true
+	}
+	return h
+}
+
+// APIKeyMiddleware authenticates the caller's API key, counts the request
+// against the key's rate limit and daily quota, and restricts the searches
+// it runs to the key's index patterns. Without API keys configured every
+// request passes.
+func (h *Handler) APIKeyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.apiKeys == nil {
+			c.Next()
+			return
+		}
+
+		key, ok := h.authenticateAPIKey(c)
+		if !ok {
+			return
+		}
+
+		usage := h.searchService.ConsumeQuota(c.Request.Context(), key)
+		if usage != nil {
+			setQuotaHeaders(c, usage)
+			if !usage.Allowed() {
+				h.rejectOverLimit(c, key, usage)
+				return
+			}
+		}
+
+		c.Set(apiKeyContextKey, key)
+		c.Request = c.Request.WithContext(domain.WithIndexPatterns(c.Request.Context(), key.Indexes))
+		c.Next()
+	}
+}
+
+// authenticateAPIKey verifies the request's API key and resolves its limits.
+// It aborts the request and returns false when the key is missing, invalid,
+// or revoked.
+func (h *Handler) authenticateAPIKey(c *gin.Context) (*domain.APIKey, bool) {
+	value := requestAPIKey(c)
+	if value == "" {
+		abortAPIKey(c, "Missing API key", "API_KEY_REQUIRED")
+		return nil, false
+	}
+
+	claims, err := infrajwt.ParseAPIKey(h.apiKeys.JWTSecret, value)
+	if err != nil {
+		abortAPIKey(c, "Invalid or expired API key", "INVALID_API_KEY")
+		return nil, false
+	}
+	if h.revokedKeys[claims.ID] {
+		h.logger.Warn("Revoked API key used",
+			infralogger.String("key_id", claims.ID),
+			infralogger.String("key_name", claims.Sub),
+		)
+		abortAPIKey(c, "API key has been revoked", "API_KEY_REVOKED")
+		return nil, false
+	}
+
+	key := &domain.APIKey{
+		ID:         claims.ID,
+		Name:       claims.Sub,
+		RateLimit:  claims.RateLimit,
+		DailyQuota: claims.DailyQuota,
+		Indexes:    claims.Indexes,
+	}
+	if key.RateLimit == 0 {
+		key.RateLimit = h.apiKeys.DefaultRateLimit
+	}
+	if key.DailyQuota == 0 {
+		key.DailyQuota = h.apiKeys.DefaultDailyQuota
+	}
+	return key, true
+}
+
+// requestAPIKey reads the key from X-API-Key, a bearer token, or ?api_key=.
+func requestAPIKey(c *gin.Context) string {
+	if key := c.GetHeader(apiKeyHeader); key != "" {
+		return key
+	}
+	if bearer, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		return bearer
+	}
+	return c.Query("api_key")
+}
+
+func abortAPIKey(c *gin.Context, message, code string) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+		Error:     message,
+		Code:      code,
+		Timestamp: time.Now(),
+	})
+}
+
+// setQuotaHeaders reports the key's standing: X-RateLimit-* for the current
+// minute and X-Quota-* for the current UTC day. Resets are Unix seconds.
+func setQuotaHeaders(c *gin.Context, usage *domain.QuotaUsage) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(usage.RateLimit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(usage.RateRemaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(usage.RateReset.Unix(), 10))
+	c.Header("X-Quota-Limit", strconv.Itoa(usage.DailyQuota))
+	c.Header("X-Quota-Remaining", strconv.Itoa(usage.QuotaRemaining))
+	c.Header("X-Quota-Reset", strconv.FormatInt(usage.QuotaReset.Unix(), 10))
+}
+
+// rejectOverLimit answers 429 with Retry-After set to when the exceeded
+// limit resets.
+func (h *Handler) rejectOverLimit(c *gin.Context, key *domain.APIKey, usage *domain.QuotaUsage) {
+	reset, code, message := usage.RateReset, "RATE_LIMITED", "Rate limit exceeded"
+	if usage.Exceeded == domain.LimitQuota {
+		reset, code, message = usage.QuotaReset, "QUOTA_EXCEEDED", "Daily quota exceeded"
+	}
+	retryAfter := max(1, int(time.Until(reset).Seconds()+1))
+
+	h.logger.Debug("API key over limit",
+		infralogger.String("key_id", key.ID),
+		infralogger.String("limit", usage.Exceeded),
+	)
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{
+		Error:     message,
+		Code:      code,
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,205 @@
+//nolint:testpackage // tests the API key middleware against the unexported handler state
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+const apiKeyTestSecret = "test-secret-key-32-chars-minimum"
+
+type fakeLimiter struct {
+	keys     []*domain.APIKey
+	exceeded string
+	err      error
+}
+
+func (f *fakeLimiter) Consume(_ context.Context, key *domain.APIKey, now time.Time) (*domain.QuotaUsage, error) {
+	f.keys = append(f.keys, key)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.QuotaUsage{
+		Exceeded:       f.exceeded,
+		RateLimit:      key.RateLimit,
+		RateRemaining:  key.RateLimit - 1,
+		RateReset:      now.Add(30 * time.Second),
+		DailyQuota:     key.DailyQuota,
+		QuotaRemaining: key.DailyQuota - 1,
+		QuotaReset:     now.Add(time.Hour),
+	}, nil
+}
+
+func newAPIKeyRouter(limiter *fakeLimiter, revoked ...string) (*gin.Engine, *[]string) {
+	searchService := service.NewSearchService(nil, &config.Config{}, newTestLogger(), nil).WithQuotas(limiter)
+	handler := (&Handler{searchService: searchService, logger: newTestLogger()}).WithAPIKeys(&config.APIKeysConfig{
+		Enabled:           true,
+		JWTSecret:         apiKeyTestSecret,
+		DefaultRateLimit:  60,
+		DefaultDailyQuota: 10000,
+		Revoked:           revoked,
+	})
+
+	var patterns []string
+	router := gin.New()
+	router.GET("/search", handler.APIKeyMiddleware(), func(c *gin.Context) {
+		patterns = domain.IndexPatterns(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	return router, &patterns
+}
+
+func newTestAPIKey(t *testing.T, claims *infrajwt.APIKeyClaims) (key, keyID string) {
+	t.Helper()
+
+	key, keyID, err := infrajwt.NewAPIKey(apiKeyTestSecret, claims, time.Hour)
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	return key, keyID
+}
+
+func TestAPIKeyMiddleware_Authentication(t *testing.T) {
+	t.Helper()
+
+	key, _ := newTestAPIKey(t, &infrajwt.APIKeyClaims{Claims: infrajwt.Claims{Sub: "partner"}})
+	revokedKey, revokedID := newTestAPIKey(t, &infrajwt.APIKeyClaims{Claims: infrajwt.Claims{Sub: "old-partner"}})
+	dashboardToken, err := infrajwt.NewServiceToken(apiKeyTestSecret, "dashboard", "", time.Hour)
+	if err != nil {
+		t.Fatalf("NewServiceToken: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		headers  map[string]string
+		wantCode int
+		wantErr  string
+	}{
+		{"missing key", "/search", nil, http.StatusUnauthorized, "API_KEY_REQUIRED"},
+		{"garbage key", "/search", map[string]string{apiKeyHeader: "nope"}, http.StatusUnauthorized, "INVALID_API_KEY"},
+		{"dashboard token", "/search", map[string]string{"Authorization": "Bearer " + dashboardToken},
+			http.StatusUnauthorized, "INVALID_API_KEY"},
+		{"revoked key", "/search", map[string]string{apiKeyHeader: revokedKey}, http.StatusUnauthorized, "API_KEY_REVOKED"},
+		{"header key", "/search", map[string]string{apiKeyHeader: key}, http.StatusOK, ""},
+		{"bearer key", "/search", map[string]string{"Authorization": "Bearer " + key}, http.StatusOK, ""},
+		{"query key", "/search?api_key=" + key, nil, http.StatusOK, ""},
+	}
+
+	router, _ := newAPIKeyRouter(&fakeLimiter{}, revokedID)
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.target, http.NoBody)
+		for name, value := range tt.headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.wantCode, w.Code)
+		}
+		if tt.wantErr != "" && !strings.Contains(w.Body.String(), tt.wantErr) {
+			t.Errorf("%s: expected error code %s, got %s", tt.name, tt.wantErr, w.Body.String())
+		}
+	}
+}
+
+func TestAPIKeyMiddleware_LimitsAndIndexes(t *testing.T) {
+	t.Helper()
+
+	key, keyID := newTestAPIKey(t, &infrajwt.APIKeyClaims{
+		Claims:    infrajwt.Claims{Sub: "partner"},
+		RateLimit: 5,
+		Indexes:   []string{"sudbury_*_classified_content"},
+	})
+	limiter := &fakeLimiter{}
+	router, patterns := newAPIKeyRouter(limiter)
+
+	req := httptest.NewRequest(http.MethodGet, "/search", http.NoBody)
+	req.Header.Set(apiKeyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	counted := limiter.keys[0]
+	if counted.ID != keyID || counted.RateLimit != 5 || counted.DailyQuota != 10000 {
+		t.Errorf("expected the key's rate limit and the default quota, got %+v", counted)
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "4" {
+		t.Errorf("X-RateLimit-Remaining = %q", got)
+	}
+	if got := w.Header().Get("X-Quota-Limit"); got != "10000" {
+		t.Errorf("X-Quota-Limit = %q", got)
+	}
+	if len(*patterns) != 1 || (*patterns)[0] != "sudbury_*_classified_content" {
+		t.Errorf("expected searches restricted to the key's indexes, got %v", *patterns)
+	}
+}
+
+func TestAPIKeyMiddleware_OverLimit(t *testing.T) {
+	t.Helper()
+
+	key, _ := newTestAPIKey(t, &infrajwt.APIKeyClaims{Claims: infrajwt.Claims{Sub: "partner"}})
+	for limit, wantCode := range map[string]string{domain.LimitRate: "RATE_LIMITED", domain.LimitQuota: "QUOTA_EXCEEDED"} {
+		router, _ := newAPIKeyRouter(&fakeLimiter{exceeded: limit})
+
+		req := httptest.NewRequest(http.MethodGet, "/search", http.NoBody)
+		req.Header.Set(apiKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusTooManyRequests || !strings.Contains(w.Body.String(), wantCode) {
+			t.Errorf("%s: expected 429 %s, got %d %s", limit, wantCode, w.Code, w.Body.String())
+		}
+		if w.Header().Get("Retry-After") == "" {
+			t.Errorf("%s: expected Retry-After", limit)
+		}
+	}
+}
+
+func TestAPIKeyMiddleware_LimiterDown(t *testing.T) {
+	t.Helper()
+
+	key, _ := newTestAPIKey(t, &infrajwt.APIKeyClaims{Claims: infrajwt.Claims{Sub: "partner"}})
+	router, _ := newAPIKeyRouter(&fakeLimiter{err: errors.New("connection refused")})
+
+	req := httptest.NewRequest(http.MethodGet, "/search", http.NoBody)
+	req.Header.Set(apiKeyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected a valid key to pass while limits are unavailable, got %d", w.Code)
+	}
+	if w.Header().Get("X-RateLimit-Limit") != "" {
+		t.Error("expected no limit headers without counters")
+	}
+}
+
+func TestAPIKeyMiddleware_Disabled(t *testing.T) {
+	t.Helper()
+
+	handler := &Handler{logger: newTestLogger()}
+	router := gin.New()
+	router.GET("/search", handler.APIKeyMiddleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search", http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected an open search API without keys configured, got %d", w.Code)
+	}
+}
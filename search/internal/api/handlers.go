@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
 	"github.com/jonesrussell/north-cloud/search/internal/domain"
 	"github.com/jonesrussell/north-cloud/search/internal/service"
 )
@@ -21,6 +24,13 @@ const (
 type Handler struct {
 	searchService *service.SearchService
 	logger        infralogger.Logger
+	widgetKeys    map[string]*config.WidgetKey // nil disables the widget API
+	// analyticsSecret signs the JWTs the analytics API requires; empty disables it
+	analyticsSecret string
+	// synonymsSecret signs the JWTs the synonyms API requires; empty disables it
+	synonymsSecret string
+	apiKeys        *config.APIKeysConfig // nil leaves the search API open
+	revokedKeys    map[string]bool
 }
 
 // NewHandler creates a new handler instance
@@ -31,13 +41,32 @@ func NewHandler(searchService *service.SearchService, log infralogger.Logger) *H
 	}
 }
 
-// Search handles search requests (both GET and POST)
+// Search handles search requests (both GET and POST). format=rss or
+// format=jsonfeed renders the newest matches as a feed instead of JSON.
 func (h *Handler) Search(c *gin.Context) {
 	var req domain.SearchRequest
 
+	format := c.DefaultQuery("format", formatJSON)
+	if !validSearchFormat(format) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "format must be json, rss or jsonfeed",
+			Code:      "VALIDATION_ERROR",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
 	// Support both GET and POST
 	if c.Request.Method == http.MethodGet {
-		req = h.parseQueryParams(c)
+		var err error
+		if req, err = h.parseQueryParams(c); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:     err.Error(),
+				Code:      "VALIDATION_ERROR",
+				Timestamp: time.Now(),
+			})
+			return
+		}
 	} else {
 		if err := c.ShouldBindJSON(&req); err != nil {
 			h.logger.Warn("Invalid search request body",
@@ -52,6 +81,10 @@ func (h *Handler) Search(c *gin.Context) {
 		}
 	}
 
+	if format != formatJSON {
+		prepareFeedRequest(c, &req)
+	}
+
 	// Execute search
 	result, err := h.searchService.Search(c.Request.Context(), &req)
 	if err != nil {
@@ -67,6 +100,14 @@ func (h *Handler) Search(c *gin.Context) {
 			statusCode = http.StatusBadRequest
 			errorCode = "VALIDATION_ERROR"
 		}
+		if errors.Is(err, service.ErrReputationUnavailable) {
+			statusCode = http.StatusServiceUnavailable
+			errorCode = "REPUTATION_UNAVAILABLE"
+		}
+		if errors.Is(err, service.ErrCursorExpired) {
+			statusCode = http.StatusGone
+			errorCode = "CURSOR_EXPIRED"
+		}
 
 		c.JSON(statusCode, ErrorResponse{
 			Error:     err.Error(),
@@ -76,18 +117,33 @@ func (h *Handler) Search(c *gin.Context) {
 		return
 	}
 
+	if format != formatJSON {
+		if feedErr := writeSearchFeed(c, format, &req, result); feedErr != nil {
+			h.logger.Error("Search feed failed", infralogger.Error(feedErr))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:     "Feed temporarily unavailable",
+				Code:      "FEED_ERROR",
+				Timestamp: time.Now(),
+			})
+		}
+		return
+	}
+
 	c.JSON(http.StatusOK, result)
 }
 
-// parseQueryParams parses search parameters from query string (GET requests)
-func (h *Handler) parseQueryParams(c *gin.Context) domain.SearchRequest {
-	return domain.SearchRequest{
+// parseQueryParams parses search parameters from query string (GET requests).
+// Unparseable date range parameters are an error rather than ignored.
+func (h *Handler) parseQueryParams(c *gin.Context) (domain.SearchRequest, error) {
+	req := domain.SearchRequest{
 		Query:      c.Query("q"),
+		Lang:       c.Query("lang"),
 		Filters:    parseFilters(c),
 		Pagination: parsePagination(c),
 		Sort:       parseSort(c),
 		Options:    parseOptions(c),
 	}
+	return req, parseDateRanges(c, req.Filters)
 }
 
 // parseFilters parses filter parameters from query string
@@ -118,6 +174,11 @@ func parseFilters(c *gin.Context) *domain.Filters {
 	if sources := c.Query("sources"); sources != "" {
 		filters.SourceNames = strings.Split(sources, ",")
 	}
+	if minReputation := c.Query("min_reputation"); minReputation != "" {
+		if mr, err := strconv.Atoi(minReputation); err == nil {
+			filters.MinReputation = mr
+		}
+	}
 	if fromDate := c.Query("from_date"); fromDate != "" {
 		if fd, err := time.Parse("2006-01-02", fromDate); err == nil {
 			filters.FromDate = &fd
@@ -129,11 +190,77 @@ func parseFilters(c *gin.Context) *domain.Filters {
 		}
 	}
 
+	if cities := queryList(c, "cities", "city"); len(cities) > 0 {
+		filters.Cities = cities
+	}
+	if provinces := queryList(c, "provinces", "province"); len(provinces) > 0 {
+		filters.Provinces = provinces
+	}
+
 	parseRfpFilters(c, filters)
 
 	return filters
 }
 
+// queryList collects the comma-separated values of the given parameters
+func queryList(c *gin.Context, keys ...string) []string {
+	var values []string
+	for _, key := range keys {
+		for _, raw := range c.QueryArray(key) {
+			for _, value := range strings.Split(raw, ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// parseDateRanges parses the published_* and crawled_* range parameters.
+// Each accepts a date (2006-01-02) or an RFC 3339 timestamp; a date-only
+// upper bound covers the whole day.
+func parseDateRanges(c *gin.Context, filters *domain.Filters) error {
+	bounds := []struct {
+		key    string
+		target **time.Time
+		isEnd  bool
+	}{
+		{"published_from", &filters.PublishedFrom, false},
+		{"published_to", &filters.PublishedTo, true},
+		{"crawled_from", &filters.CrawledFrom, false},
+		{"crawled_to", &filters.CrawledTo, true},
+	}
+	for _, bound := range bounds {
+		raw := c.Query(bound.key)
+		if raw == "" {
+			continue
+		}
+		t, err := parseDateBound(raw, bound.isEnd)
+		if err != nil {
+			return fmt.Errorf("%s must be a date (YYYY-MM-DD) or RFC 3339 timestamp", bound.key)
+		}
+		*bound.target = &t
+	}
+	return nil
+}
+
+// parseDateBound parses a date or RFC 3339 timestamp. A date-only end bound
+// is moved to the last millisecond of that day (UTC).
+func parseDateBound(raw string, isEnd bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if isEnd {
+		t = t.AddDate(0, 0, 1).Add(-time.Millisecond)
+	}
+	return t, nil
+}
+
 // parseRfpFilters parses RFP-specific filter parameters from query string.
 func parseRfpFilters(c *gin.Context, filters *domain.Filters) {
 	if rfpProvince := c.Query("rfp_province"); rfpProvince != "" {
@@ -171,6 +298,7 @@ func parsePagination(c *gin.Context) *domain.Pagination {
 			pagination.Size = s
 		}
 	}
+	pagination.Cursor = c.Query("cursor")
 
 	return pagination
 }
@@ -199,6 +327,9 @@ func parseOptions(c *gin.Context) *domain.Options {
 	if facets := c.Query("facets"); facets != "" {
 		options.IncludeFacets = facets == trueString
 	}
+	options.SessionID = c.Query("session")
+	options.Personalize = c.Query("personalize") == trueString
+	options.AutoCorrect = c.Query("auto_correct") == trueString
 
 	return options
 }
@@ -328,6 +459,116 @@ func (h *Handler) SearchCommunities(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// SourceCoverage handles the per-source freshness and coverage report.
+// Query params: source (optional), days (window, default 30), gap_hours
+// (minimum empty span reported as a gap; defaults to each source's crawl interval).
+func (h *Handler) SourceCoverage(c *gin.Context) {
+	req := &domain.CoverageRequest{Source: strings.TrimSpace(c.Query("source"))}
+	if days := c.Query("days"); days != "" {
+		if d, err := strconv.Atoi(days); err == nil {
+			req.Days = d
+		}
+	}
+	if gapHours := c.Query("gap_hours"); gapHours != "" {
+		if g, err := strconv.ParseFloat(gapHours, 64); err == nil && g > 0 {
+			req.GapThreshold = time.Duration(g * float64(time.Hour))
+		}
+	}
+
+	result, err := h.searchService.SourceCoverage(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Source coverage report failed",
+			infralogger.Error(err),
+			infralogger.String("source", req.Source),
+		)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:     "Coverage report failed",
+			Code:      "COVERAGE_ERROR",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// TrendingTerms handles the trending topics and cities report.
+// Query params: hours (recent window, default 24, max 168), baseline_days
+// (window before it setting the usual rate, default 7, max 30), limit
+// (default 10, max 50).
+func (h *Handler) TrendingTerms(c *gin.Context) {
+	req := &domain.TrendingRequest{}
+	if hours, err := strconv.Atoi(c.Query("hours")); err == nil {
+		req.Hours = hours
+	}
+	if baselineDays, err := strconv.Atoi(c.Query("baseline_days")); err == nil {
+		req.BaselineDays = baselineDays
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil {
+		req.Limit = limit
+	}
+
+	result, err := h.searchService.TrendingTerms(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Trending terms report failed", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:     "Trending report failed",
+			Code:      "TRENDING_ERROR",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RelatedArticles handles the related-coverage lookup for an article page.
+// Query params: days (how far back, default 30, max 365), limit (default 5,
+// max 20), session (attached to click URLs).
+func (h *Handler) RelatedArticles(c *gin.Context) {
+	req := &domain.RelatedRequest{
+		ArticleID: c.Param("id"),
+		SessionID: c.Query("session"),
+	}
+	if days := c.Query("days"); days != "" {
+		if d, err := strconv.Atoi(days); err == nil {
+			req.Days = d
+		}
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			req.Limit = l
+		}
+	}
+
+	result, err := h.searchService.RelatedArticles(c.Request.Context(), req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorCode := "RELATED_ERROR"
+		switch {
+		case errors.Is(err, service.ErrArticleNotFound):
+			statusCode = http.StatusNotFound
+			errorCode = "ARTICLE_NOT_FOUND"
+		case strings.Contains(err.Error(), "validation"):
+			statusCode = http.StatusBadRequest
+			errorCode = "VALIDATION_ERROR"
+		default:
+			h.logger.Error("Related articles lookup failed",
+				infralogger.Error(err),
+				infralogger.String("article_id", req.ArticleID),
+			)
+		}
+		c.JSON(statusCode, ErrorResponse{
+			Error:     err.Error(),
+			Code:      errorCode,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error     string    `json:"error"`
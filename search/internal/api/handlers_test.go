@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -359,6 +360,20 @@ func TestParsePagination_InvalidSize(t *testing.T) {
 	}
 }
 
+func TestParsePagination_Cursor(t *testing.T) {
+	t.Helper()
+
+	c := newTestContext("cursor=*&size=100")
+	pagination := parsePagination(c)
+
+	if pagination.Cursor != "*" {
+		t.Errorf("expected cursor=*, got %q", pagination.Cursor)
+	}
+	if pagination.Size != 100 {
+		t.Errorf("expected size=100, got %d", pagination.Size)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // parseSort
 // ---------------------------------------------------------------------------
@@ -451,6 +466,17 @@ func TestParseOptions_NonTrueValue(t *testing.T) {
 	}
 }
 
+func TestParseOptions_AutoCorrect(t *testing.T) {
+	t.Helper()
+
+	if !parseOptions(newTestContext("auto_correct=true")).AutoCorrect {
+		t.Error("expected AutoCorrect=true")
+	}
+	if parseOptions(newTestContext("")).AutoCorrect {
+		t.Error("expected AutoCorrect=false by default")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // parseQueryParams (full integration of parse* functions)
 // ---------------------------------------------------------------------------
@@ -461,15 +487,21 @@ func TestParseQueryParams_FullQuery(t *testing.T) {
 	c := newTestContext(
 		"q=northern+mining&topics=mining,indigenous&content_type=article" +
 			"&min_quality=50&page=2&size=25&sort=published_date&order=desc" +
-			"&highlights=true&facets=true&sources=cbc",
+			"&highlights=true&facets=true&sources=cbc&lang=fr",
 	)
 
 	h := &Handler{}
-	req := h.parseQueryParams(c)
+	req, err := h.parseQueryParams(c)
+	if err != nil {
+		t.Fatalf("parseQueryParams: %v", err)
+	}
 
 	if req.Query != "northern mining" {
 		t.Errorf("expected query=%q, got %q", "northern mining", req.Query)
 	}
+	if req.Lang != "fr" {
+		t.Errorf("expected lang=%q, got %q", "fr", req.Lang)
+	}
 	if req.Filters == nil {
 		t.Fatal("expected filters to be set")
 	}
@@ -513,7 +545,10 @@ func TestParseQueryParams_EmptyQuery(t *testing.T) {
 
 	c := newTestContext("")
 	h := &Handler{}
-	req := h.parseQueryParams(c)
+	req, err := h.parseQueryParams(c)
+	if err != nil {
+		t.Fatalf("parseQueryParams: %v", err)
+	}
 
 	if req.Query != "" {
 		t.Errorf("expected empty query, got %q", req.Query)
@@ -532,6 +567,65 @@ func TestParseQueryParams_EmptyQuery(t *testing.T) {
 	}
 }
 
+func TestParseQueryParams_DateRanges(t *testing.T) {
+	t.Helper()
+
+	c := newTestContext(
+		"published_from=2025-03-01&published_to=2025-03-31" +
+			"&crawled_from=2025-03-10T08:00:00Z&crawled_to=2025-03-10T12:00:00-04:00",
+	)
+	h := &Handler{}
+	req, err := h.parseQueryParams(c)
+	if err != nil {
+		t.Fatalf("parseQueryParams: %v", err)
+	}
+
+	f := req.Filters
+	if f.PublishedFrom == nil || !f.PublishedFrom.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("published_from = %v, want start of 2025-03-01", f.PublishedFrom)
+	}
+	wantEnd := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC).Add(-time.Millisecond)
+	if f.PublishedTo == nil || !f.PublishedTo.Equal(wantEnd) {
+		t.Errorf("published_to = %v, want end of 2025-03-31", f.PublishedTo)
+	}
+	if f.CrawledFrom == nil || !f.CrawledFrom.Equal(time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("crawled_from = %v, want 2025-03-10T08:00:00Z", f.CrawledFrom)
+	}
+	if f.CrawledTo == nil || !f.CrawledTo.Equal(time.Date(2025, 3, 10, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("crawled_to = %v, want 2025-03-10T16:00:00Z", f.CrawledTo)
+	}
+}
+
+func TestParseQueryParams_InvalidDateRange(t *testing.T) {
+	t.Helper()
+
+	c := newTestContext("published_from=last-week")
+	h := &Handler{}
+	if _, err := h.parseQueryParams(c); err == nil {
+		t.Fatal("expected an error for an unparseable published_from")
+	}
+}
+
+func TestParseFilters_Locations(t *testing.T) {
+	t.Helper()
+
+	c := newTestContext("cities=Sudbury,+Thunder+Bay&city=toronto&province=on&provinces=QC,")
+	filters := parseFilters(c)
+
+	wantCities := []string{"Sudbury", "Thunder Bay", "toronto"}
+	if len(filters.Cities) != len(wantCities) {
+		t.Fatalf("cities = %v, want %v", filters.Cities, wantCities)
+	}
+	for i, city := range wantCities {
+		if filters.Cities[i] != city {
+			t.Errorf("cities[%d] = %q, want %q", i, filters.Cities[i], city)
+		}
+	}
+	if len(filters.Provinces) != 2 || filters.Provinces[0] != "QC" || filters.Provinces[1] != "on" {
+		t.Errorf("provinces = %v, want [QC on]", filters.Provinces)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Combined filter test (all filter fields in one query)
 // ---------------------------------------------------------------------------
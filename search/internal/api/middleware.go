@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/search/internal/config"
 )
@@ -76,13 +77,12 @@ func CORSMiddleware(cfg *config.CORSConfig) gin.HandlerFunc {
 	}
 }
 
-// RecoveryMiddleware handles panics
+// RecoveryMiddleware handles panics, logging and reporting them via the crash package
 func RecoveryMiddleware(log infralogger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Error("Panic recovered",
-					infralogger.Any("error", err),
+				crash.Handle(err, log,
 					infralogger.String("path", c.Request.URL.Path),
 					infralogger.String("method", c.Request.Method),
 				)
@@ -23,15 +23,43 @@ func SetupRoutes(router *gin.Engine, handler *Handler) {
 		v1.GET("/health", handler.HealthCheck)
 		v1.GET("/ready", handler.ReadinessCheck)
 
+		// API keys, when enabled, guard everything that searches the index on request
+		apiKey := handler.APIKeyMiddleware()
+
 		// Search endpoints
-		search := v1.Group("/search")
+		search := v1.Group("/search", apiKey)
 		search.GET("/suggest", handler.Suggest)
 		search.POST("", handler.Search)
 		search.GET("", handler.Search)
 
+		// Related coverage for article pages
+		v1.GET("/articles/:id/related", apiKey, handler.RelatedArticles)
+
+		// Coverage report
+		v1.GET("/coverage/sources", apiKey, handler.SourceCoverage)
+
+		// Trending topics and cities
+		v1.GET("/trending", apiKey, handler.TrendingTerms)
+
 		// Feed endpoints (public, no auth)
 		feeds := v1.Group("/feeds")
 		feeds.GET("/latest", handler.PublicFeed)
 		feeds.GET("/:slug", handler.TopicFeed)
+
+		// Partner widget API
+		widget := v1.Group("/widget", handler.WidgetAuthMiddleware())
+		widget.GET("/search", handler.WidgetSearch)
+
+		// Query analytics
+		analytics := v1.Group("/analytics", handler.AnalyticsAuthMiddleware())
+		analytics.GET("/queries/top", handler.TopQueries)
+		analytics.GET("/queries/zero-results", handler.ZeroResultQueries)
+		analytics.GET("/latency", handler.QueryLatency)
+
+		// Search synonyms; read-scoped tokens can only list them
+		synonyms := v1.Group("/synonyms", handler.SynonymsAuthMiddleware())
+		synonyms.GET("", handler.ListSynonyms)
+		synonyms.PUT("/:id", handler.PutSynonym)
+		synonyms.DELETE("/:id", handler.DeleteSynonym)
 	}
 }
@@ -16,16 +16,25 @@ func TestSetupRoutes_RegistersExpectedPaths(t *testing.T) {
 	SetupRoutes(router, handler)
 
 	expectedRoutes := map[string]bool{
-		"GET /health":                false,
-		"GET /ready":                 false,
-		"GET /health/memory":         false,
-		"GET /api/v1/health":         false,
-		"GET /api/v1/ready":          false,
-		"GET /api/v1/search":         false,
-		"POST /api/v1/search":        false,
-		"GET /api/v1/search/suggest": false,
-		"GET /api/v1/feeds/latest":   false,
-		"GET /api/v1/feeds/:slug":    false,
+		"GET /health":                       false,
+		"GET /ready":                        false,
+		"GET /health/memory":                false,
+		"GET /api/v1/health":                false,
+		"GET /api/v1/ready":                 false,
+		"GET /api/v1/search":                false,
+		"POST /api/v1/search":               false,
+		"GET /api/v1/search/suggest":        false,
+		"GET /api/v1/feeds/latest":          false,
+		"GET /api/v1/feeds/:slug":           false,
+		"GET /api/v1/coverage/sources":      false,
+		"GET /api/v1/trending":              false,
+		"GET /api/v1/widget/search":         false,
+		"GET /api/v1/articles/:id/related":  false,
+		"GET /api/v1/analytics/queries/top": false,
+		"GET /api/v1/analytics/latency":     false,
+		"GET /api/v1/synonyms":              false,
+		"PUT /api/v1/synonyms/:id":          false,
+		"DELETE /api/v1/synonyms/:id":       false,
 	}
 
 	for _, route := range router.Routes() {
@@ -51,14 +60,22 @@ func TestSetupServiceRoutes_RegistersExpectedPaths(t *testing.T) {
 	SetupServiceRoutes(router, handler)
 
 	expectedRoutes := map[string]bool{
-		"GET /ready":                  false,
-		"GET /feed.json":              false,
-		"GET /api/communities/search": false,
-		"GET /api/v1/health":          false,
-		"GET /api/v1/ready":           false,
-		"GET /api/v1/search":          false,
-		"POST /api/v1/search":         false,
-		"GET /api/v1/feeds/:slug":     false,
+		"GET /ready":                                 false,
+		"GET /feed.json":                             false,
+		"GET /api/communities/search":                false,
+		"GET /api/v1/health":                         false,
+		"GET /api/v1/ready":                          false,
+		"GET /api/v1/search":                         false,
+		"POST /api/v1/search":                        false,
+		"GET /api/v1/feeds/:slug":                    false,
+		"GET /api/v1/coverage/sources":               false,
+		"GET /api/v1/trending":                       false,
+		"GET /api/v1/articles/:id/related":           false,
+		"GET /api/v1/widget/search":                  false,
+		"GET /api/v1/analytics/queries/zero-results": false,
+		"GET /api/v1/synonyms":                       false,
+		"PUT /api/v1/synonyms/:id":                   false,
+		"DELETE /api/v1/synonyms/:id":                false,
 	}
 
 	for _, route := range router.Routes() {
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// Search response formats. Feed formats turn any query and filter
+// combination into a standing feed of its newest matches.
+const (
+	formatJSON          = "json"
+	formatRSS           = "rss"
+	formatJSONFeed      = "jsonfeed"
+	maxFeedItems        = 50
+	rssContentType      = "application/rss+xml; charset=utf-8"
+	jsonFeedContentType = "application/feed+json; charset=utf-8"
+	jsonFeedVersion     = "https://jsonfeed.org/version/1.1"
+)
+
+// rssFeed is a minimal RSS 2.0 document.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link,omitempty"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// jsonFeed is a JSON Feed 1.1 document.
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string          `json:"id"`
+	URL           string          `json:"url"`
+	ExternalURL   string          `json:"external_url,omitempty"`
+	Title         string          `json:"title"`
+	ContentText   string          `json:"content_text"`
+	Image         string          `json:"image,omitempty"`
+	DatePublished string          `json:"date_published,omitempty"`
+	Authors       []jsonFeedActor `json:"authors,omitempty"`
+	Tags          []string        `json:"tags,omitempty"`
+}
+
+type jsonFeedActor struct {
+	Name string `json:"name"`
+}
+
+// validSearchFormat reports whether format is a known search response format.
+func validSearchFormat(format string) bool {
+	return format == formatJSON || format == formatRSS || format == formatJSONFeed
+}
+
+// prepareFeedRequest shapes a search into a feed: the first limit items
+// (default 10, max 50), newest first unless a sort was asked for, without
+// facets. Feed readers poll, so feed searches are not counted as queries.
+func prepareFeedRequest(c *gin.Context, req *domain.SearchRequest) {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = defaultFeedLimitParam
+	}
+	req.Pagination = &domain.Pagination{Page: 1, Size: min(limit, maxFeedItems)}
+
+	if req.Sort == nil || req.Sort.Field == "" {
+		req.Sort = &domain.Sort{Field: "published_date", Order: "desc"}
+	}
+	if req.Options == nil {
+		req.Options = &domain.Options{}
+	}
+	req.Options.IncludeFacets = false
+	req.Options.Feed = true
+}
+
+// writeSearchFeed renders a search response as RSS 2.0 or JSON Feed. Item
+// links are the click-tracked URLs when click tracking is enabled.
+func writeSearchFeed(c *gin.Context, format string, req *domain.SearchRequest, result *domain.SearchResponse) error {
+	self := requestURL(c.Request)
+	title, description := searchFeedTitle(req)
+
+	var body []byte
+	var err error
+	contentType := rssContentType
+	if format == formatJSONFeed {
+		contentType = jsonFeedContentType
+		body, err = json.Marshal(buildJSONFeed(result, title, description, self))
+	} else {
+		body, err = xml.MarshalIndent(buildSearchRSS(result, title, description, self), "", "  ")
+		body = append([]byte(xml.Header), body...)
+	}
+	if err != nil {
+		return fmt.Errorf("render %s feed: %w", format, err)
+	}
+
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(publicFeedCacheMaxAge))
+	c.Data(http.StatusOK, contentType, body)
+	return nil
+}
+
+// searchFeedTitle names a feed after its query.
+func searchFeedTitle(req *domain.SearchRequest) (title, description string) {
+	if req.Query == "" || req.Query == "*" {
+		return "Latest content", "Newest content matching the feed's filters"
+	}
+	return "Search: " + req.Query, fmt.Sprintf("Newest content matching %q", req.Query)
+}
+
+func buildSearchRSS(result *domain.SearchResponse, title, description, self string) rssFeed {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         title,
+			Link:          self,
+			Description:   description,
+			LastBuildDate: feedUpdated(result.Hits).Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(result.Hits)),
+		},
+	}
+
+	for _, hit := range result.Hits {
+		item := rssItem{
+			Title:       hit.Title,
+			Link:        feedItemLink(hit),
+			GUID:        rssGUID{Value: hit.ID},
+			Description: hit.Snippet,
+			Categories:  hit.Topics,
+		}
+		if published := hitTime(hit); !published.IsZero() {
+			item.PubDate = published.Format(time.RFC1123Z)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+	return feed
+}
+
+func buildJSONFeed(result *domain.SearchResponse, title, description, self string) jsonFeed {
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       title,
+		FeedURL:     self,
+		Description: description,
+		Items:       make([]jsonFeedItem, 0, len(result.Hits)),
+	}
+
+	for _, hit := range result.Hits {
+		item := jsonFeedItem{
+			ID:          hit.ID,
+			URL:         feedItemLink(hit),
+			Title:       hit.Title,
+			ContentText: hit.Snippet,
+			Image:       hit.OGImage,
+			Tags:        hit.Topics,
+		}
+		if item.URL != hit.URL {
+			item.ExternalURL = hit.URL
+		}
+		if published := hitTime(hit); !published.IsZero() {
+			item.DatePublished = published.Format(time.RFC3339)
+		}
+		if hit.SourceName != "" {
+			item.Authors = []jsonFeedActor{{Name: hit.SourceName}}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	return feed
+}
+
+// feedItemLink is the click-tracked URL of a hit, or its URL when click
+// tracking is disabled.
+func feedItemLink(hit *domain.SearchHit) string {
+	if hit.ClickURL != "" {
+		return hit.ClickURL
+	}
+	return hit.URL
+}
+
+// hitTime is when a hit was published, or crawled when it has no published date.
+func hitTime(hit *domain.SearchHit) time.Time {
+	if hit.PublishedDate != nil && !hit.PublishedDate.IsZero() {
+		return hit.PublishedDate.UTC()
+	}
+	if hit.CrawledAt != nil {
+		return hit.CrawledAt.UTC()
+	}
+	return time.Time{}
+}
+
+// feedUpdated is the time of the newest hit, or now for an empty feed.
+func feedUpdated(hits []*domain.SearchHit) time.Time {
+	var newest time.Time
+	for _, hit := range hits {
+		if t := hitTime(hit); t.After(newest) {
+			newest = t
+		}
+	}
+	if newest.IsZero() {
+		return time.Now().UTC()
+	}
+	return newest
+}
+
+// requestURL is the absolute URL the feed was requested at, honouring the
+// scheme set by the reverse proxy.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
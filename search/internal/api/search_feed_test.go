@@ -0,0 +1,144 @@
+//nolint:testpackage // tests unexported feed rendering helpers
+package api
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+func feedTestResponse() *domain.SearchResponse {
+	published := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	crawled := time.Date(2026, 10, 2, 8, 0, 0, 0, time.UTC)
+	return &domain.SearchResponse{
+		Query: "wildfire",
+		Hits: []*domain.SearchHit{
+			{
+				ID: "doc-1", Title: "Wildfire near Sudbury", URL: "https://news.example.com/fire",
+				ClickURL: "https://click.example.com/click?id=doc-1", SourceName: "sudbury_star",
+				PublishedDate: &published, Snippet: "Crews are battling", Topics: []string{"environment"},
+			},
+			{ID: "doc-2", Title: "Smoke advisory", URL: "https://news.example.com/smoke", CrawledAt: &crawled},
+		},
+	}
+}
+
+func newFeedContext(rawQuery string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/search?"+rawQuery, http.NoBody)
+	c.Request.Host = "search.example.com"
+	c.Request.Header.Set("X-Forwarded-Proto", "https")
+	return c, w
+}
+
+func TestPrepareFeedRequest(t *testing.T) {
+	t.Helper()
+
+	c, _ := newFeedContext("q=fire&format=rss&limit=500")
+	req := &domain.SearchRequest{Query: "fire", Sort: &domain.Sort{}, Options: &domain.Options{IncludeFacets: true}}
+	prepareFeedRequest(c, req)
+
+	if req.Pagination.Page != 1 || req.Pagination.Size != maxFeedItems {
+		t.Errorf("expected the first page capped at %d items, got %+v", maxFeedItems, req.Pagination)
+	}
+	if req.Sort.Field != "published_date" || req.Sort.Order != "desc" {
+		t.Errorf("expected newest first by default, got %+v", req.Sort)
+	}
+	if req.Options.IncludeFacets || !req.Options.Feed {
+		t.Errorf("expected a feed search without facets, got %+v", req.Options)
+	}
+
+	c, _ = newFeedContext("q=fire&format=rss&sort=relevance")
+	req = &domain.SearchRequest{Query: "fire", Sort: &domain.Sort{Field: "relevance"}}
+	prepareFeedRequest(c, req)
+	if req.Sort.Field != "relevance" || req.Pagination.Size != defaultFeedLimitParam {
+		t.Errorf("expected the requested sort and default limit to be kept, got %+v %+v", req.Sort, req.Pagination)
+	}
+}
+
+func TestWriteSearchFeed_RSS(t *testing.T) {
+	t.Helper()
+
+	c, w := newFeedContext("q=wildfire&format=rss")
+	if err := writeSearchFeed(c, formatRSS, &domain.SearchRequest{Query: "wildfire"}, feedTestResponse()); err != nil {
+		t.Fatalf("writeSearchFeed: %v", err)
+	}
+
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/rss+xml") {
+		t.Errorf("Content-Type = %q", got)
+	}
+	var feed rssFeed
+	if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("decode RSS: %v", err)
+	}
+	if feed.Channel.Title != "Search: wildfire" || feed.Channel.Link != "https://search.example.com/api/v1/search?q=wildfire&format=rss" {
+		t.Errorf("unexpected channel %q %q", feed.Channel.Title, feed.Channel.Link)
+	}
+	if len(feed.Channel.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(feed.Channel.Items))
+	}
+	first := feed.Channel.Items[0]
+	if first.Link != "https://click.example.com/click?id=doc-1" {
+		t.Errorf("expected the click-tracked link, got %q", first.Link)
+	}
+	if first.PubDate != "Thu, 01 Oct 2026 12:00:00 +0000" || first.GUID.Value != "doc-1" {
+		t.Errorf("unexpected first item %+v", first)
+	}
+	if second := feed.Channel.Items[1]; second.Link != "https://news.example.com/smoke" || second.PubDate == "" {
+		t.Errorf("expected the article URL and crawl date without click tracking, got %+v", second)
+	}
+	if feed.Channel.LastBuildDate != "Fri, 02 Oct 2026 08:00:00 +0000" {
+		t.Errorf("lastBuildDate = %q, want the newest item", feed.Channel.LastBuildDate)
+	}
+}
+
+func TestWriteSearchFeed_JSONFeed(t *testing.T) {
+	t.Helper()
+
+	c, w := newFeedContext("format=jsonfeed&topics=environment")
+	if err := writeSearchFeed(c, formatJSONFeed, &domain.SearchRequest{}, feedTestResponse()); err != nil {
+		t.Fatalf("writeSearchFeed: %v", err)
+	}
+
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/feed+json") {
+		t.Errorf("Content-Type = %q", got)
+	}
+	var feed jsonFeed
+	if err := json.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("decode JSON Feed: %v", err)
+	}
+	if feed.Version != jsonFeedVersion || feed.Title != "Latest content" {
+		t.Errorf("unexpected feed %q %q", feed.Version, feed.Title)
+	}
+	first := feed.Items[0]
+	if first.URL != "https://click.example.com/click?id=doc-1" || first.ExternalURL != "https://news.example.com/fire" {
+		t.Errorf("expected the click-tracked URL with the article as external_url, got %+v", first)
+	}
+	if first.DatePublished != "2026-10-01T12:00:00Z" || first.Authors[0].Name != "sudbury_star" {
+		t.Errorf("unexpected first item %+v", first)
+	}
+	if feed.Items[1].ExternalURL != "" {
+		t.Errorf("expected no external_url without click tracking, got %q", feed.Items[1].ExternalURL)
+	}
+}
+
+func TestValidSearchFormat(t *testing.T) {
+	t.Helper()
+
+	for _, format := range []string{formatJSON, formatRSS, formatJSONFeed} {
+		if !validSearchFormat(format) {
+			t.Errorf("expected %q to be valid", format)
+		}
+	}
+	if validSearchFormat("atom") {
+		t.Error("expected atom to be rejected")
+	}
+}
@@ -77,12 +77,41 @@ func SetupServiceRoutes(router *gin.Engine, handler *Handler) {
 		v1.GET("/ready", handler.ReadinessCheck)
 
 		// Search endpoints
-		search := v1.Group("/search")
+		// API keys, when enabled, guard everything that searches the index on request
+		apiKey := handler.APIKeyMiddleware()
+
+		search := v1.Group("/search", apiKey)
 		search.POST("", handler.Search) // POST for complex searches
 		search.GET("", handler.Search)  // GET for simple searches
 
+		// Related coverage for article pages
+		v1.GET("/articles/:id/related", apiKey, handler.RelatedArticles)
+
+		// Per-source freshness and coverage report
+		v1.GET("/coverage/sources", apiKey, handler.SourceCoverage)
+
+		// Topics and cities spiking against their baseline
+		v1.GET("/trending", apiKey, handler.TrendingTerms)
+
 		// Topic-filtered feeds (no auth): /api/v1/feeds/{slug}
 		feeds := v1.Group("/feeds")
 		feeds.GET("/:slug", handler.TopicFeed)
+
+		// Partner widget API: key-scoped and origin-restricted
+		widget := v1.Group("/widget", handler.WidgetAuthMiddleware())
+		widget.GET("/search", handler.WidgetSearch)
+
+		// Query analytics: JWT-protected, disabled without a secret
+		analytics := v1.Group("/analytics", handler.AnalyticsAuthMiddleware())
+		analytics.GET("/queries/top", handler.TopQueries)
+		analytics.GET("/queries/zero-results", handler.ZeroResultQueries)
+		analytics.GET("/latency", handler.QueryLatency)
+
+		// Search synonyms: JWT-protected, disabled without a secret; read-scoped
+		// tokens can only list them
+		synonyms := v1.Group("/synonyms", handler.SynonymsAuthMiddleware())
+		synonyms.GET("", handler.ListSynonyms)
+		synonyms.PUT("/:id", handler.PutSynonym)
+		synonyms.DELETE("/:id", handler.DeleteSynonym)
 	}
 }
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// synonymRuleBody is the body of a PUT synonym rule request.
+type synonymRuleBody struct {
+	Synonyms string `json:"synonyms" binding:"required"`
+}
+
+// WithSynonyms enables the synonyms API behind JWTs signed with jwtSecret.
+func (h *Handler) WithSynonyms(jwtSecret string) *Handler {
+	h.synonymsSecret = jwtSecret
+	return h
+}
+
+// SynonymsAuthMiddleware requires a valid JWT for the synonyms API. Without
+// a secret the API is disabled and answers 404.
+func (h *Handler) SynonymsAuthMiddleware() gin.HandlerFunc {
+	if h.synonymsSecret == "" {
+		return func(c *gin.Context) {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrorResponse{
+				Error:     "Synonym management is disabled",
+				Code:      "SYNONYMS_DISABLED",
+				Timestamp: time.Now(),
+			})
+		}
+	}
+	return infrajwt.Middleware(h.synonymsSecret)
+}
+
+// ListSynonyms handles listing the search synonym rules.
+// Query params: from (offset, default 0), size (default 100, max 1000).
+func (h *Handler) ListSynonyms(c *gin.Context) {
+	req := &domain.SynonymsRequest{}
+	if from, err := strconv.Atoi(c.Query("from")); err == nil {
+		req.From = from
+	}
+	if size, err := strconv.Atoi(c.Query("size")); err == nil {
+		req.Size = size
+	}
+
+	result, err := h.searchService.SynonymRules(c.Request.Context(), req)
+	if err != nil {
+		h.synonymsError(c, "Listing synonyms failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// PutSynonym handles creating or replacing the synonym rule :id with a body
+// of {"synonyms": "opp, ontario provincial police"}. The response lists the
+// indexes whose search analyzers were reloaded with it.
+func (h *Handler) PutSynonym(c *gin.Context) {
+	var body synonymRuleBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:     "Invalid request body: " + err.Error(),
+			Code:      "INVALID_REQUEST",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	rule := &domain.SynonymRule{ID: c.Param("id"), Synonyms: body.Synonyms}
+	result, err := h.searchService.PutSynonymRule(c.Request.Context(), rule)
+	if err != nil {
+		h.synonymsError(c, "Updating synonym rule failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteSynonym handles removing the synonym rule :id.
+func (h *Handler) DeleteSynonym(c *gin.Context) {
+	result, err := h.searchService.DeleteSynonymRule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.synonymsError(c, "Deleting synonym rule failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) synonymsError(c *gin.Context, failure string, err error) {
+	statusCode := http.StatusInternalServerError
+	errorCode := "SYNONYMS_ERROR"
+	message := failure
+	switch {
+	case errors.Is(err, domain.ErrSynonymRuleNotFound):
+		statusCode, errorCode, message = http.StatusNotFound, "SYNONYM_NOT_FOUND", err.Error()
+	case strings.Contains(err.Error(), "validation"):
+		statusCode, errorCode, message = http.StatusBadRequest, "VALIDATION_ERROR", err.Error()
+	default:
+		h.logger.Error(failure, infralogger.Error(err))
+	}
+
+	c.JSON(statusCode, ErrorResponse{Error: message, Code: errorCode, Timestamp: time.Now()})
+}
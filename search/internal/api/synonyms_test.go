@@ -0,0 +1,101 @@
+//nolint:testpackage // tests the synonyms API against the unexported handler state
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+const synonymsTestSecret = "test-secret-key-32-chars-minimum"
+
+// newSynonymsRouter serves the synonyms API from a service without
+// Elasticsearch, so only requests rejected before reaching it succeed.
+func newSynonymsRouter(secret string) *gin.Engine {
+	searchService := service.NewSearchService(nil, &config.Config{}, newTestLogger(), nil)
+	handler := (&Handler{searchService: searchService, logger: newTestLogger()}).WithSynonyms(secret)
+
+	router := gin.New()
+	synonyms := router.Group("/api/v1/synonyms", handler.SynonymsAuthMiddleware())
+	synonyms.GET("", handler.ListSynonyms)
+	synonyms.PUT("/:id", handler.PutSynonym)
+	synonyms.DELETE("/:id", handler.DeleteSynonym)
+	return router
+}
+
+func serveSynonyms(t *testing.T, router *gin.Engine, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func newSynonymsToken(t *testing.T, scope string) string {
+	t.Helper()
+
+	token, err := infrajwt.NewServiceToken(synonymsTestSecret, "dashboard", scope, time.Hour)
+	if err != nil {
+		t.Fatalf("NewServiceToken: %v", err)
+	}
+	return token
+}
+
+func TestSynonymsAPI_Disabled(t *testing.T) {
+	t.Helper()
+
+	w := serveSynonyms(t, newSynonymsRouter(""), http.MethodGet, "/api/v1/synonyms", "", "")
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "SYNONYMS_DISABLED") {
+		t.Errorf("disabled API: got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSynonymsAPI_Auth(t *testing.T) {
+	t.Helper()
+
+	router := newSynonymsRouter(synonymsTestSecret)
+	body := `{"synonyms": "opp, ontario provincial police"}`
+
+	if w := serveSynonyms(t, router, http.MethodPut, "/api/v1/synonyms/opp", "", body); w.Code != http.StatusUnauthorized {
+		t.Errorf("without a token: got %d, want 401", w.Code)
+	}
+	readToken := newSynonymsToken(t, infrajwt.ScopeRead)
+	if w := serveSynonyms(t, router, http.MethodPut, "/api/v1/synonyms/opp", readToken, body); w.Code != http.StatusForbidden {
+		t.Errorf("read-scoped token: got %d, want 403", w.Code)
+	}
+}
+
+func TestSynonymsAPI_Validation(t *testing.T) {
+	t.Helper()
+
+	router := newSynonymsRouter(synonymsTestSecret)
+	token := newSynonymsToken(t, infrajwt.ScopeAdmin)
+
+	tests := []struct {
+		name, method, path, body, wantCode string
+	}{
+		{"missing synonyms", http.MethodPut, "/api/v1/synonyms/opp", `{}`, "INVALID_REQUEST"},
+		{"single term", http.MethodPut, "/api/v1/synonyms/opp", `{"synonyms": "opp"}`, "VALIDATION_ERROR"},
+		{"bad id", http.MethodPut, "/api/v1/synonyms/OPP", `{"synonyms": "opp, police"}`, "VALIDATION_ERROR"},
+		{"bad delete id", http.MethodDelete, "/api/v1/synonyms/OPP", "", "VALIDATION_ERROR"},
+	}
+
+	for _, tt := range tests {
+		w := serveSynonyms(t, router, tt.method, tt.path, token, tt.body)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tt.wantCode) {
+			t.Errorf("%s: got %d %s, want 400 %s", tt.name, w.Code, w.Body.String(), tt.wantCode)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+const (
+	// widgetKeyHeader carries the widget key for server-side callers; browsers
+	// pass ?key= so the request stays a simple CORS request without a preflight.
+	widgetKeyHeader = "X-Widget-Key"
+	// widgetKeyContextKey holds the resolved *config.WidgetKey in the gin context.
+	widgetKeyContextKey = "widget_key"
+)
+
+// WithWidgetKeys enables the widget API for the given keys.
+func (h *Handler) WithWidgetKeys(keys []config.WidgetKey) *Handler {
+	h.widgetKeys = make(map[string]*config.WidgetKey, len(keys))
+	for i := range keys {
+		h.widgetKeys[keys[i].Key] = &keys[i]
+	}
+	return h
+}
+
+// WidgetAuthMiddleware resolves the widget key and checks the caller's origin
+// against the key's allowed origins. The origin comes from the Origin header
+// (fetch/XHR) or, for iframe page loads, the Referer. Matching responses get
+// CORS headers for that origin only.
+func (h *Handler) WidgetAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keyValue := c.GetHeader(widgetKeyHeader)
+		if keyValue == "" {
+			keyValue = c.Query("key")
+		}
+
+		key, ok := h.widgetKeys[keyValue]
+		if keyValue == "" || !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+				Error:     "Missing or unknown widget key",
+				Code:      "INVALID_WIDGET_KEY",
+				Timestamp: time.Now(),
+			})
+			return
+		}
+
+		origin := requestOrigin(c.Request)
+		if !slices.Contains(key.AllowedOrigins, origin) {
+			h.logger.Warn("Widget request from disallowed origin",
+				infralogger.String("widget", key.Name),
+				infralogger.String("origin", origin),
+			)
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+				Error:     "Origin is not allowed for this widget key",
+				Code:      "ORIGIN_NOT_ALLOWED",
+				Timestamp: time.Now(),
+			})
+			return
+		}
+
+		c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+		c.Writer.Header().Set("Access-Control-Allow-Credentials", "false")
+		c.Writer.Header().Add("Vary", "Origin")
+		c.Set(widgetKeyContextKey, key)
+		c.Next()
+	}
+}
+
+// requestOrigin returns the calling page's origin from the Origin header, or
+// the scheme and host of the Referer when there is no Origin.
+func requestOrigin(r *http.Request) string {
+	if origin := r.Header.Get("Origin"); origin != "" && origin != "null" {
+		return origin
+	}
+
+	referer, err := url.Parse(r.Referer())
+	if err != nil || referer.Scheme == "" || referer.Host == "" {
+		return ""
+	}
+	return referer.Scheme + "://" + referer.Host
+}
+
+// WidgetSearch handles GET /api/v1/widget/search for embedded partner widgets.
+// Query params: key, q (empty returns the newest items), topics (comma-separated,
+// within the key's topics), page, size (capped by the key).
+func (h *Handler) WidgetSearch(c *gin.Context) {
+	key, _ := c.Get(widgetKeyContextKey)
+	widgetKey, _ := key.(*config.WidgetKey)
+
+	query := service.WidgetQuery{Query: strings.TrimSpace(c.Query("q"))}
+	if topics := c.Query("topics"); topics != "" {
+		query.Topics = strings.Split(topics, ",")
+	}
+	query.Page, _ = strconv.Atoi(c.Query("page"))
+	query.Size, _ = strconv.Atoi(c.Query("size"))
+
+	result, err := h.searchService.WidgetSearch(c.Request.Context(), widgetKey, query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorCode := "SEARCH_ERROR"
+		message := "Search failed"
+		switch {
+		case errors.Is(err, service.ErrWidgetTopicNotAllowed):
+			statusCode, errorCode, message = http.StatusForbidden, "TOPIC_NOT_ALLOWED", err.Error()
+		case strings.Contains(err.Error(), "validation"):
+			statusCode, errorCode, message = http.StatusBadRequest, "VALIDATION_ERROR", err.Error()
+		default:
+			h.logger.Error("Widget search failed",
+				infralogger.String("widget", widgetKey.Name),
+				infralogger.Error(err),
+			)
+		}
+
+		c.JSON(statusCode, ErrorResponse{Error: message, Code: errorCode, Timestamp: time.Now()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,69 @@
+//nolint:testpackage // tests the widget middleware against the unexported handler state
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+)
+
+func newWidgetRouter() *gin.Engine {
+	handler := (&Handler{logger: newTestLogger()}).WithWidgetKeys([]config.WidgetKey{{
+		Name:           "sudbury",
+		Key:            "wk_sudbury_community_2f9c41",
+		AllowedOrigins: []string{"https://community.example.com"},
+		MaxResults:     10,
+	}})
+
+	router := gin.New()
+	router.GET("/widget", handler.WidgetAuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestWidgetAuthMiddleware(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name       string
+		query      string
+		headers    map[string]string
+		wantStatus int
+		wantOrigin string
+	}{
+		{"missing key", "", map[string]string{"Origin": "https://community.example.com"}, http.StatusUnauthorized, ""},
+		{"unknown key", "key=nope", map[string]string{"Origin": "https://community.example.com"}, http.StatusUnauthorized, ""},
+		{"allowed origin", "key=wk_sudbury_community_2f9c41",
+			map[string]string{"Origin": "https://community.example.com"}, http.StatusOK, "https://community.example.com"},
+		{"iframe referer", "key=wk_sudbury_community_2f9c41",
+			map[string]string{"Referer": "https://community.example.com/news?page=2"}, http.StatusOK, "https://community.example.com"},
+		{"header key", "",
+			map[string]string{widgetKeyHeader: "wk_sudbury_community_2f9c41", "Origin": "https://community.example.com"},
+			http.StatusOK, "https://community.example.com"},
+		{"other origin", "key=wk_sudbury_community_2f9c41",
+			map[string]string{"Origin": "https://evil.example.net"}, http.StatusForbidden, ""},
+		{"no origin", "key=wk_sudbury_community_2f9c41", nil, http.StatusForbidden, ""},
+	}
+
+	router := newWidgetRouter()
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/widget?"+tt.query, http.NoBody)
+		for name, value := range tt.headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.wantStatus, w.Code)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+			t.Errorf("%s: expected allow-origin %q, got %q", tt.name, tt.wantOrigin, got)
+		}
+	}
+}
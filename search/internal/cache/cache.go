@@ -0,0 +1,173 @@
+// Package cache keeps search responses in Redis for a short TTL, so the
+// queries dashboards repeat many times a minute are answered without
+// Elasticsearch. Entries are dropped early when the classifier announces new
+// documents from a source they could include.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// anySource is the generation scope of searches not filtered by source:
+// every classified batch can change their results.
+const anySource = "*"
+
+// Cache stores search responses keyed by the normalized request and the
+// generations of the sources the request can match. Invalidating a source
+// bumps its generation, so older entries are never read again and expire
+// with their TTL.
+type Cache struct {
+	client *redis.Client
+	cfg    config.CacheConfig
+	logger infralogger.Logger
+}
+
+// New creates a cache on client. Call Run to apply invalidations.
+func New(client *redis.Client, cfg config.CacheConfig, log infralogger.Logger) *Cache {
+	return &Cache{client: client, cfg: cfg, logger: log}
+}
+
+// Get returns the cached response to req. Redis errors are logged and
+// reported as a miss, so the cache never fails a search.
+func (c *Cache) Get(ctx context.Context, req *domain.SearchRequest) (*domain.SearchResponse, bool) {
+	key, err := c.key(ctx, req)
+	if err != nil {
+		c.logger.Warn("Search cache lookup failed", infralogger.Error(err))
+		return nil, false
+	}
+
+	raw, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false
+	}
+	if err != nil {
+		c.logger.Warn("Search cache lookup failed", infralogger.Error(err))
+		return nil, false
+	}
+
+	var resp domain.SearchResponse
+	if unmarshalErr := json.Unmarshal(raw, &resp); unmarshalErr != nil {
+		c.logger.Warn("Discarding unreadable search cache entry", infralogger.Error(unmarshalErr))
+		return nil, false
+	}
+	return &resp, true
+}
+
+// Set caches resp as the response to req for the configured TTL.
+func (c *Cache) Set(ctx context.Context, req *domain.SearchRequest, resp *domain.SearchResponse) {
+	key, err := c.key(ctx, req)
+	if err != nil {
+		c.logger.Warn("Search cache store failed", infralogger.Error(err))
+		return
+	}
+
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		c.logger.Warn("Search cache store failed", infralogger.Error(err))
+		return
+	}
+	if setErr := c.client.Set(ctx, key, raw, c.cfg.TTL).Err(); setErr != nil {
+		c.logger.Warn("Search cache store failed", infralogger.Error(setErr))
+	}
+}
+
+// Invalidate drops the cached responses that could include new documents
+// from sources: those filtered to one of them and those not filtered by source.
+func (c *Cache) Invalidate(ctx context.Context, sources []string) error {
+	pipe := c.client.TxPipeline()
+	pipe.Incr(ctx, c.generationKey(anySource))
+	for _, source := range sources {
+		if source != "" {
+			pipe.Incr(ctx, c.generationKey(source))
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("bump cache generations: %w", err)
+	}
+	return nil
+}
+
+// key hashes the request together with the current generations of its sources.
+func (c *Cache) key(ctx context.Context, req *domain.SearchRequest) (string, error) {
+	scopes := requestScopes(req)
+	generationKeys := make([]string, len(scopes))
+	for i, scope := range scopes {
+		generationKeys[i] = c.generationKey(scope)
+	}
+
+	generations, err := c.client.MGet(ctx, generationKeys...).Result()
+	if err != nil {
+		return "", fmt.Errorf("read cache generations: %w", err)
+	}
+
+	normalized, err := json.Marshal(cacheableRequest(req))
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	hash := sha256.New()
+	hash.Write(normalized)
+	// Searches by an API key limited to some indexes see only those
+	if patterns := domain.IndexPatterns(ctx); len(patterns) > 0 {
+		_, _ = fmt.Fprintf(hash, "|indexes=%s", strings.Join(sortedCopy(patterns), ","))
+	}
+	for _, generation := range generations {
+		// A missing counter reads as nil: the source has never been invalidated
+		_, _ = fmt.Fprintf(hash, "|%v", generation)
+	}
+	return c.cfg.KeyPrefix + ":search:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (c *Cache) generationKey(scope string) string {
+	return c.cfg.KeyPrefix + ":gen:" + scope
+}
+
+// requestScopes are the generations a request's results depend on: its
+// source filter, or every source when it has none.
+func requestScopes(req *domain.SearchRequest) []string {
+	if req.Filters == nil || len(req.Filters.SourceNames) == 0 {
+		return []string{anySource}
+	}
+	scopes := slices.Clone(req.Filters.SourceNames)
+	slices.Sort(scopes)
+	return slices.Compact(scopes)
+}
+
+// cacheableRequest is req without what does not change the response: the
+// session only appears in click URLs, which are signed per response.
+func cacheableRequest(req *domain.SearchRequest) domain.SearchRequest {
+	normalized := *req
+	if req.Options != nil {
+		options := *req.Options
+		options.SessionID = ""
+		normalized.Options = &options
+	}
+	if req.Filters != nil {
+		filters := *req.Filters
+		filters.SourceNames = sortedCopy(req.Filters.SourceNames)
+		filters.Topics = sortedCopy(req.Filters.Topics)
+		normalized.Filters = &filters
+	}
+	return normalized
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return values
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted
+}
@@ -0,0 +1,154 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/cache"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestCache(t *testing.T) (*cache.Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	cfg := config.CacheConfig{Enabled: true, TTL: 30 * time.Second, KeyPrefix: "search:cache"}
+	return cache.New(client, cfg, infralogger.NewNop()), mr
+}
+
+func searchRequest(query string, page int, sources ...string) *domain.SearchRequest {
+	return &domain.SearchRequest{
+		Query:      query,
+		Filters:    &domain.Filters{SourceNames: sources},
+		Pagination: &domain.Pagination{Page: page, Size: 20},
+		Options:    &domain.Options{},
+	}
+}
+
+func TestCache_SetAndGet(t *testing.T) {
+	t.Helper()
+
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	req := searchRequest("mining", 1)
+
+	if _, ok := c.Get(ctx, req); ok {
+		t.Fatal("expected a miss before the response is cached")
+	}
+
+	c.Set(ctx, req, &domain.SearchResponse{Query: "mining", TotalHits: 42})
+	got, ok := c.Get(ctx, req)
+	if !ok {
+		t.Fatal("expected a hit after the response is cached")
+	}
+	if got.TotalHits != 42 {
+		t.Errorf("TotalHits: want 42, got %d", got.TotalHits)
+	}
+
+	if _, ok = c.Get(ctx, searchRequest("mining", 2)); ok {
+		t.Error("expected another page to miss")
+	}
+
+	mr.FastForward(31 * time.Second)
+	if _, ok = c.Get(ctx, req); ok {
+		t.Error("expected the entry to expire after the TTL")
+	}
+}
+
+func TestCache_KeyIgnoresSessionAndFilterOrder(t *testing.T) {
+	t.Helper()
+
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+
+	req := searchRequest("fire", 1, "cbc", "ctv")
+	req.Options.SessionID = "visitor-a"
+	c.Set(ctx, req, &domain.SearchResponse{Query: "fire"})
+
+	other := searchRequest("fire", 1, "ctv", "cbc")
+	other.Options.SessionID = "visitor-b"
+	if _, ok := c.Get(ctx, other); !ok {
+		t.Error("expected the same search from another session to hit")
+	}
+}
+
+func TestCache_InvalidateBySource(t *testing.T) {
+	t.Helper()
+
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+
+	unfiltered := searchRequest("crime", 1)
+	fromCBC := searchRequest("crime", 1, "cbc")
+	fromCTV := searchRequest("crime", 1, "ctv")
+	for _, req := range []*domain.SearchRequest{unfiltered, fromCBC, fromCTV} {
+		c.Set(ctx, req, &domain.SearchResponse{Query: "crime"})
+	}
+
+	if err := c.HandleEvent(ctx, `{"sources":["cbc"],"count":3}`); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+
+	if _, ok := c.Get(ctx, fromCBC); ok {
+		t.Error("expected the search filtered to the classified source to be invalidated")
+	}
+	if _, ok := c.Get(ctx, unfiltered); ok {
+		t.Error("expected the search over every source to be invalidated")
+	}
+	if _, ok := c.Get(ctx, fromCTV); !ok {
+		t.Error("expected the search filtered to another source to stay cached")
+	}
+}
+
+func TestCache_HandleEventRejectsMalformedPayload(t *testing.T) {
+	t.Helper()
+
+	c, _ := newTestCache(t)
+	if err := c.HandleEvent(context.Background(), "not json"); err == nil {
+		t.Error("expected an error for a malformed event")
+	}
+}
+
+func TestCache_RedisDownIsAMiss(t *testing.T) {
+	t.Helper()
+
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	req := searchRequest("flood", 1)
+	c.Set(ctx, req, &domain.SearchResponse{Query: "flood"})
+
+	mr.Close()
+	if _, ok := c.Get(ctx, req); ok {
+		t.Error("expected a miss while Redis is unreachable")
+	}
+}
+
+func TestCache_KeyedByIndexPatterns(t *testing.T) {
+	t.Helper()
+
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+	req := searchRequest("mining", 1)
+	c.Set(ctx, req, &domain.SearchResponse{Query: "mining", TotalHits: 42})
+
+	scoped := domain.WithIndexPatterns(ctx, []string{"sudbury_*_classified_content"})
+	if _, ok := c.Get(scoped, req); ok {
+		t.Fatal("expected a search scoped to some indexes to miss an unscoped response")
+	}
+
+	c.Set(scoped, req, &domain.SearchResponse{Query: "mining", TotalHits: 3})
+	if got, ok := c.Get(scoped, req); !ok || got.TotalHits != 3 {
+		t.Errorf("expected the scoped response, got %+v", got)
+	}
+	if got, ok := c.Get(ctx, req); !ok || got.TotalHits != 42 {
+		t.Errorf("expected the unscoped response to be kept, got %+v", got)
+	}
+}
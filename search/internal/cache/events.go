@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// classifiedEvent is the message the classifier publishes after indexing a
+// batch of classified documents.
+type classifiedEvent struct {
+	Sources []string `json:"sources"`
+	Count   int      `json:"count"`
+}
+
+// Run applies the classifier's classified-batch announcements until ctx is
+// done. go-redis resubscribes after a dropped connection; batches announced
+// in between are only reflected once the TTL expires.
+func (c *Cache) Run(ctx context.Context) {
+	sub := c.client.Subscribe(ctx, c.cfg.InvalidationChannel)
+	defer func() { _ = sub.Close() }()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if err := c.HandleEvent(ctx, msg.Payload); err != nil {
+				c.logger.Warn("Search cache invalidation failed",
+					infralogger.Error(err),
+					infralogger.String("channel", msg.Channel),
+				)
+			}
+		}
+	}
+}
+
+// HandleEvent invalidates the sources of one classified-batch announcement.
+func (c *Cache) HandleEvent(ctx context.Context, payload string) error {
+	var event classifiedEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return fmt.Errorf("decode classified event: %w", err)
+	}
+	if err := c.Invalidate(ctx, event.Sources); err != nil {
+		return err
+	}
+
+	c.logger.Debug("Search cache invalidated",
+		infralogger.Any("sources", event.Sources),
+		infralogger.Int("documents", event.Count),
+	)
+	return nil
+}
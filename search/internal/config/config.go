@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"time"
 
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
@@ -16,6 +18,7 @@ const (
 	defaultPageSize          = 20
 	defaultMaxQueryLength    = 500
 	defaultSearchTimeoutSec  = 5
+	defaultCursorKeepAlive   = 5 * time.Minute
 	defaultESURL             = "http://localhost:9200"
 	defaultESMaxRetries      = 3
 	defaultESTimeoutSec      = 30
@@ -25,21 +28,66 @@ const (
 	defaultBoostRawText      = 1.0
 	defaultHighlightFragment = 150
 	defaultHighlightMax      = 3
+	defaultHighlightTag      = "em"
+	minHighlightFragment     = 20
+	maxHighlightFragment     = 1000
 	defaultMaxTopics         = 20
 	defaultMaxSources        = 20
 	defaultMaxContentTypes   = 10
 	defaultLogLevel          = "info"
 	defaultLogFormat         = "json"
+	defaultReputationRefresh = 10 * time.Minute
+	defaultClassifierTimeout = 10 * time.Second
+	defaultIntervalRefresh   = 10 * time.Minute
+	defaultCrawlerTimeout    = 10 * time.Second
+	defaultWidgetMaxResults  = 10
+	maxWidgetMaxResults      = 20
+	minWidgetKeyLength       = 16
+	maxQualityScore          = 100
+	defaultPersonalizeWindow = 30 * 24 * time.Hour
+	maxPersonalizeWindow     = 93 * 24 * time.Hour
+	defaultProfileCacheTTL   = 5 * time.Minute
+	defaultProfileCacheSize  = 10000
+	defaultProfileTimeout    = 500 * time.Millisecond
+	defaultSourceBoost       = 0.5
+	defaultTopicBoost        = 0.3
+	defaultCacheAddress      = "localhost:6379"
+	defaultCacheTTL          = 30 * time.Second
+	maxCacheTTL              = 10 * time.Minute
+	defaultCacheKeyPrefix    = "search:cache"
+	defaultCacheChannel      = "classifier:classified"
+	defaultQualityWeight     = 0.5
+	defaultReputationWeight  = 0.5
+	defaultFreshnessWeight   = 1.0
+	defaultFreshnessScale    = 7 * 24 * time.Hour
+	defaultFreshnessOffset   = 24 * time.Hour
+	defaultFreshnessDecay    = 0.5
+	defaultAnalyticsRetain   = 30 * 24 * time.Hour
+	maxAnalyticsRetain       = 90 * 24 * time.Hour
+	defaultAnalyticsMinCount = 2
+	defaultAnalyticsPrefix   = "search:analytics"
+	defaultAnalyticsBuffer   = 1000
+	defaultAPIKeysPrefix     = "search:apikeys"
+	defaultAPIKeyRateLimit   = 60
+	defaultAPIKeyDailyQuota  = 10000
 )
 
 // Config holds all configuration for the search service.
 type Config struct {
-	Service       ServiceConfig       `yaml:"service"`
-	Elasticsearch ElasticsearchConfig `yaml:"elasticsearch"`
-	Facets        FacetsConfig        `yaml:"facets"`
-	Logging       LoggingConfig       `yaml:"logging"`
-	CORS          CORSConfig          `yaml:"cors"`
-	ClickTracker  ClickTrackerConfig  `yaml:"click_tracker"`
+	Service         ServiceConfig         `yaml:"service"`
+	Elasticsearch   ElasticsearchConfig   `yaml:"elasticsearch"`
+	Facets          FacetsConfig          `yaml:"facets"`
+	Logging         LoggingConfig         `yaml:"logging"`
+	CORS            CORSConfig            `yaml:"cors"`
+	ClickTracker    ClickTrackerConfig    `yaml:"click_tracker"`
+	Classifier      ClassifierConfig      `yaml:"classifier"`
+	Crawler         CrawlerConfig         `yaml:"crawler"`
+	Widget          WidgetConfig          `yaml:"widget"`
+	Personalization PersonalizationConfig `yaml:"personalization"`
+	Cache           CacheConfig           `yaml:"cache"`
+	Analytics       AnalyticsConfig       `yaml:"analytics"`
+	APIKeys         APIKeysConfig         `yaml:"api_keys"`
+	Synonyms        SynonymsConfig        `yaml:"synonyms"`
 }
 
 // ServiceConfig holds service-level configuration.
@@ -52,6 +100,9 @@ type ServiceConfig struct {
 	DefaultPageSize int           `env:"SEARCH_DEFAULT_PAGE_SIZE" yaml:"default_page_size"`
 	MaxQueryLength  int           `yaml:"max_query_length"`
 	SearchTimeout   time.Duration `yaml:"search_timeout"`
+	// CursorKeepAlive is how long a cursor stays usable after its page was
+	// served; each page extends it
+	CursorKeepAlive time.Duration `yaml:"cursor_keep_alive"`
 }
 
 // ElasticsearchConfig holds Elasticsearch connection and search configuration.
@@ -64,8 +115,32 @@ type ElasticsearchConfig struct {
 	ClassifiedContentPattern string        `yaml:"classified_content_pattern"`
 	DefaultBoost             BoostConfig   `yaml:"default_boost"`
 	HighlightEnabled         bool          `yaml:"highlight_enabled"`
-	HighlightFragmentSize    int           `yaml:"highlight_fragment_size"`
+	HighlightFragmentSize    int           `env:"SEARCH_HIGHLIGHT_FRAGMENT_SIZE" yaml:"highlight_fragment_size"`
 	HighlightMaxFragments    int           `yaml:"highlight_max_fragments"`
+	// HighlightTag is the element matches are wrapped in, e.g. "em" or "mark"
+	HighlightTag string `yaml:"highlight_tag"`
+	// SpellCheckEnabled adds the did_you_mean phrase suggester, which needs
+	// the title.suggest subfield: enable it once every classified index has it
+	SpellCheckEnabled bool `env:"SEARCH_SPELL_CHECK_ENABLED" yaml:"spell_check_enabled"`
+	// Ranking multiplies relevance by quality, reputation, and freshness
+	Ranking RankingConfig `yaml:"ranking"`
+}
+
+// RankingConfig weighs what relevance-sorted searches favour besides the text
+// match. A hit scores its relevance times 1 plus each weight scaled by the
+// hit's standing (0-1): quality_score/100, its source's reputation/100, and
+// the freshness decay of crawled_at. With no weight set the defaults apply;
+// once one is set, the others default to 0 so a deployment can drop a factor.
+type RankingConfig struct {
+	Enabled          bool    `env:"SEARCH_RANKING_ENABLED" yaml:"enabled"`
+	QualityWeight    float64 `yaml:"quality_weight"`
+	ReputationWeight float64 `yaml:"reputation_weight"` // needs classifier.url
+	FreshnessWeight  float64 `yaml:"freshness_weight"`
+	// Freshness is 1 within FreshnessOffset of now and FreshnessDecay
+	// FreshnessScale further back, falling along a gaussian curve
+	FreshnessScale  time.Duration `yaml:"freshness_scale"`  // default 7 days
+	FreshnessOffset time.Duration `yaml:"freshness_offset"` // default 1 day
+	FreshnessDecay  float64       `yaml:"freshness_decay"`  // default 0.5
 }
 
 // BoostConfig holds field boosting values.
@@ -102,13 +177,131 @@ type CORSConfig struct {
 	MaxAge           int      `yaml:"max_age"`
 }
 
-// ClickTrackerConfig holds click tracking URL generation config.
+// ClickTrackerConfig holds click tracking URL generation config. KeyID names
+// Secret in signatures so the click-tracker can verify links across a key
+// rotation; empty signs without a key ID.
 type ClickTrackerConfig struct {
 	Enabled bool   `env:"CLICK_TRACKER_ENABLED"  yaml:"enabled"`
 	Secret  string `env:"CLICK_TRACKER_SECRET"   yaml:"secret"`
+	KeyID   string `env:"CLICK_TRACKER_KEY_ID"   yaml:"key_id"`
 	BaseURL string `env:"CLICK_TRACKER_BASE_URL" yaml:"base_url"`
 }
 
+// ClassifierConfig points at the classifier whose source reputation scores back
+// the min_reputation filter and source_reputation sort. An empty URL disables both.
+type ClassifierConfig struct {
+	URL             string        `env:"CLASSIFIER_URL"       yaml:"url"`
+	InternalSecret  string        `env:"AUTH_INTERNAL_SECRET" yaml:"internal_secret"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	Timeout         time.Duration `yaml:"timeout"`
+}
+
+// CrawlerConfig points at the crawler whose job intervals set each source's
+// gap threshold in the coverage report. An empty URL uses gap_hours or a day.
+type CrawlerConfig struct {
+	URL             string        `env:"CRAWLER_URL"     yaml:"url"`
+	JWTSecret       string        `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	Timeout         time.Duration `yaml:"timeout"`
+}
+
+// PersonalizationConfig points at the click-tracker whose per-session click
+// stats re-rank searches that ask for personalization. An empty URL disables it.
+type PersonalizationConfig struct {
+	ClickTrackerURL string        `env:"CLICK_TRACKER_URL" yaml:"click_tracker_url"`
+	JWTSecret       string        `env:"AUTH_JWT_SECRET"   yaml:"jwt_secret"`
+	Window          time.Duration `yaml:"window"`     // how far back clicks count (default 30 days, max 93)
+	CacheTTL        time.Duration `yaml:"cache_ttl"`  // how long a session's profile is reused
+	CacheSize       int           `yaml:"cache_size"` // profiles kept in memory
+	Timeout         time.Duration `yaml:"timeout"`    // budget for building a profile during a search
+	// SourceBoost and TopicBoost are the extra weight of results from the
+	// session's most-clicked source and topic (0.5 scores them 1.5x)
+	SourceBoost float64 `yaml:"source_boost"`
+	TopicBoost  float64 `yaml:"topic_boost"`
+}
+
+// CacheConfig enables caching search responses in Redis. Entries live for
+// TTL at most and are dropped early when the classifier announces new
+// documents from a source they could include.
+type CacheConfig struct {
+	Enabled   bool          `env:"SEARCH_CACHE_ENABLED" yaml:"enabled"`
+	Address   string        `env:"REDIS_ADDRESS"        yaml:"address"`
+	Password  string        `env:"REDIS_PASSWORD"       yaml:"password"`
+	DB        int           `yaml:"db"`
+	TTL       time.Duration `env:"SEARCH_CACHE_TTL"     yaml:"ttl"` // default 30s, max 10m
+	KeyPrefix string        `yaml:"key_prefix"`
+	// InvalidationChannel is the Redis channel the classifier announces
+	// classified batches on (its redis.channel_classified)
+	InvalidationChannel string `yaml:"invalidation_channel"`
+}
+
+// AnalyticsConfig enables recording searches in Redis for the query
+// analytics API. Queries are stored normalized and anonymized, never with the
+// session, and only queries searched at least MinCount times are reported.
+type AnalyticsConfig struct {
+	Enabled   bool          `env:"SEARCH_ANALYTICS_ENABLED" yaml:"enabled"`
+	Address   string        `env:"REDIS_ADDRESS"            yaml:"address"`
+	Password  string        `env:"REDIS_PASSWORD"           yaml:"password"`
+	DB        int           `yaml:"db"`
+	Retention time.Duration `yaml:"retention"` // default 30 days, max 90
+	MinCount  int           `yaml:"min_count"` // default 2
+	KeyPrefix string        `yaml:"key_prefix"`
+	// BufferSize bounds the searches waiting to be recorded; more are dropped
+	BufferSize int `yaml:"buffer_size"`
+	// JWTSecret protects the analytics API; it is required when enabled
+	JWTSecret string `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
+}
+
+// APIKeysConfig requires an API key issued by the auth service on the public
+// search endpoints. Keys are JWTs carrying their own rate limit, daily quota,
+// and index patterns; the counters live in Redis.
+type APIKeysConfig struct {
+	Enabled bool `env:"SEARCH_API_KEYS_ENABLED" yaml:"enabled"`
+	// JWTSecret verifies keys; it is the secret the auth service signs with
+	JWTSecret string `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
+	Address   string `env:"REDIS_ADDRESS"   yaml:"address"`
+	Password  string `env:"REDIS_PASSWORD"  yaml:"password"`
+	DB        int    `yaml:"db"`
+	KeyPrefix string `yaml:"key_prefix"`
+	// DefaultRateLimit (requests per minute) and DefaultDailyQuota apply to
+	// keys issued without their own
+	DefaultRateLimit  int `yaml:"default_rate_limit"`  // default 60
+	DefaultDailyQuota int `yaml:"default_daily_quota"` // default 10000
+	// Revoked lists the IDs of keys refused before they expire
+	Revoked []string `env:"SEARCH_REVOKED_API_KEYS" yaml:"revoked"`
+}
+
+// SynonymsConfig enables the API managing the synonyms set the classified
+// indexes expand queries with. Rules change search results everywhere, so
+// the API requires a JWT.
+type SynonymsConfig struct {
+	Enabled bool `env:"SEARCH_SYNONYMS_ENABLED" yaml:"enabled"`
+	// JWTSecret protects the synonyms API; it is required when enabled
+	JWTSecret string `env:"AUTH_JWT_SECRET" yaml:"jwt_secret"`
+}
+
+// WidgetConfig lists the API keys partner sites use to embed the search/news
+// widget. No keys disables the widget API.
+type WidgetConfig struct {
+	Keys []WidgetKey `yaml:"keys"`
+}
+
+// WidgetKey scopes one partner site's widget: the origins allowed to call it
+// and the content it can see. Keys ship in the partner's page, so they are
+// identifiers, not secrets; the scope is what protects the rest of the index.
+type WidgetKey struct {
+	Name           string   `yaml:"name"`
+	Key            string   `yaml:"key"`
+	AllowedOrigins []string `yaml:"allowed_origins"` // e.g. "https://partner.example.com"
+	Sources        []string `yaml:"sources"`         // source_name values; empty allows every source
+	Topics         []string `yaml:"topics"`          // empty allows every topic
+	MinQuality     int      `yaml:"min_quality"`
+	MaxResults     int      `yaml:"max_results"` // page size cap (default 10, max 20)
+	// Personalize re-ranks the widget's searches by what its readers click.
+	// Readers are not told apart: the key is the session.
+	Personalize bool `yaml:"personalize"`
+}
+
 // Load loads configuration from file and environment variables.
 func Load(path string) (*Config, error) {
 	cfg, err := infraconfig.LoadWithDefaults[Config](path, setDefaults)
@@ -130,6 +323,108 @@ func setDefaults(cfg *Config) {
 	setFacetsDefaults(&cfg.Facets)
 	setLoggingDefaults(&cfg.Logging)
 	setCORSDefaults(&cfg.CORS)
+	setClassifierDefaults(&cfg.Classifier)
+	setCrawlerDefaults(&cfg.Crawler)
+	setWidgetDefaults(&cfg.Widget)
+	setPersonalizationDefaults(&cfg.Personalization)
+	setCacheDefaults(&cfg.Cache)
+	setAnalyticsDefaults(&cfg.Analytics)
+	setAPIKeysDefaults(&cfg.APIKeys)
+}
+
+func setAPIKeysDefaults(k *APIKeysConfig) {
+	if k.Address == "" {
+		k.Address = defaultCacheAddress
+	}
+	if k.KeyPrefix == "" {
+		k.KeyPrefix = defaultAPIKeysPrefix
+	}
+	if k.DefaultRateLimit == 0 {
+		k.DefaultRateLimit = defaultAPIKeyRateLimit
+	}
+	if k.DefaultDailyQuota == 0 {
+		k.DefaultDailyQuota = defaultAPIKeyDailyQuota
+	}
+}
+
+func setAnalyticsDefaults(a *AnalyticsConfig) {
+	if a.Address == "" {
+		a.Address = defaultCacheAddress
+	}
+	if a.Retention == 0 {
+		a.Retention = defaultAnalyticsRetain
+	}
+	if a.MinCount == 0 {
+		a.MinCount = defaultAnalyticsMinCount
+	}
+	if a.KeyPrefix == "" {
+		a.KeyPrefix = defaultAnalyticsPrefix
+	}
+	if a.BufferSize == 0 {
+		a.BufferSize = defaultAnalyticsBuffer
+	}
+}
+
+func setCacheDefaults(c *CacheConfig) {
+	if c.Address == "" {
+		c.Address = defaultCacheAddress
+	}
+	if c.TTL == 0 {
+		c.TTL = defaultCacheTTL
+	}
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = defaultCacheKeyPrefix
+	}
+	if c.InvalidationChannel == "" {
+		c.InvalidationChannel = defaultCacheChannel
+	}
+}
+
+func setPersonalizationDefaults(p *PersonalizationConfig) {
+	if p.Window == 0 {
+		p.Window = defaultPersonalizeWindow
+	}
+	if p.CacheTTL == 0 {
+		p.CacheTTL = defaultProfileCacheTTL
+	}
+	if p.CacheSize == 0 {
+		p.CacheSize = defaultProfileCacheSize
+	}
+	if p.Timeout == 0 {
+		p.Timeout = defaultProfileTimeout
+	}
+	if p.SourceBoost == 0 {
+		p.SourceBoost = defaultSourceBoost
+	}
+	if p.TopicBoost == 0 {
+		p.TopicBoost = defaultTopicBoost
+	}
+}
+
+func setWidgetDefaults(w *WidgetConfig) {
+	for i := range w.Keys {
+		if w.Keys[i].MaxResults == 0 {
+			w.Keys[i].MaxResults = defaultWidgetMaxResults
+		}
+	}
+}
+
+func setClassifierDefaults(c *ClassifierConfig) {
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = defaultReputationRefresh
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultClassifierTimeout
+	}
+}
+
+func setCrawlerDefaults(c *CrawlerConfig) {
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = defaultIntervalRefresh
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultCrawlerTimeout
+	}
 }
 
 func setServiceDefaults(s *ServiceConfig) {
@@ -154,6 +449,9 @@ func setServiceDefaults(s *ServiceConfig) {
 	if s.SearchTimeout == 0 {
 		s.SearchTimeout = defaultSearchTimeoutSec * time.Second
 	}
+	if s.CursorKeepAlive == 0 {
+		s.CursorKeepAlive = defaultCursorKeepAlive
+	}
 }
 
 func setElasticsearchDefaults(e *ElasticsearchConfig) {
@@ -184,6 +482,27 @@ func setElasticsearchDefaults(e *ElasticsearchConfig) {
 	if e.HighlightMaxFragments == 0 {
 		e.HighlightMaxFragments = defaultHighlightMax
 	}
+	if e.HighlightTag == "" {
+		e.HighlightTag = defaultHighlightTag
+	}
+	setRankingDefaults(&e.Ranking)
+}
+
+func setRankingDefaults(r *RankingConfig) {
+	if r.QualityWeight == 0 && r.ReputationWeight == 0 && r.FreshnessWeight == 0 {
+		r.QualityWeight = defaultQualityWeight
+		r.ReputationWeight = defaultReputationWeight
+		r.FreshnessWeight = defaultFreshnessWeight
+	}
+	if r.FreshnessScale == 0 {
+		r.FreshnessScale = defaultFreshnessScale
+	}
+	if r.FreshnessOffset == 0 {
+		r.FreshnessOffset = defaultFreshnessOffset
+	}
+	if r.FreshnessDecay == 0 {
+		r.FreshnessDecay = defaultFreshnessDecay
+	}
 }
 
 func setFacetsDefaults(f *FacetsConfig) {
@@ -215,7 +534,7 @@ func setCORSDefaults(c *CORSConfig) {
 		c.AllowedMethods = []string{"GET", "POST", "OPTIONS"}
 	}
 	if len(c.AllowedHeaders) == 0 {
-		c.AllowedHeaders = []string{"Content-Type", "Authorization"}
+		c.AllowedHeaders = []string{"Content-Type", "Authorization", "X-API-Key"}
 	}
 }
 
@@ -233,17 +552,213 @@ func (c *Config) Validate() error {
 			Message: fmt.Sprintf("must be between 1 and %d", c.Service.MaxPageSize),
 		}
 	}
+	if c.Service.CursorKeepAlive < time.Second {
+		return &infraconfig.ValidationError{Field: "service.cursor_keep_alive", Message: "must be at least 1s"}
+	}
 	if c.Elasticsearch.URL == "" {
 		return &infraconfig.ValidationError{Field: "elasticsearch.url", Message: "is required"}
 	}
 	if c.Elasticsearch.ClassifiedContentPattern == "" {
 		return &infraconfig.ValidationError{Field: "elasticsearch.classified_content_pattern", Message: "is required"}
 	}
+	if err := c.Elasticsearch.validateHighlight(); err != nil {
+		return err
+	}
+	if err := c.Elasticsearch.Ranking.Validate(); err != nil {
+		return err
+	}
 	if err := infraconfig.ValidateLogLevel(c.Logging.Level); err != nil {
 		return err
 	}
 	if err := infraconfig.ValidateLogFormat(c.Logging.Format); err != nil {
 		return err
 	}
+	if err := c.Personalization.Validate(); err != nil {
+		return err
+	}
+	if err := c.Cache.Validate(); err != nil {
+		return err
+	}
+	if err := c.Analytics.Validate(); err != nil {
+		return err
+	}
+	if err := c.APIKeys.Validate(); err != nil {
+		return err
+	}
+	if err := c.Synonyms.Validate(); err != nil {
+		return err
+	}
+	return c.Widget.Validate()
+}
+
+// highlightTagPattern allows a bare element name, so the tag cannot carry attributes or markup.
+var highlightTagPattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// validateHighlight checks the fragment size and that the highlight tag is a plain element name.
+func (e *ElasticsearchConfig) validateHighlight() error {
+	if e.HighlightFragmentSize < minHighlightFragment || e.HighlightFragmentSize > maxHighlightFragment {
+		return &infraconfig.ValidationError{
+			Field:   "elasticsearch.highlight_fragment_size",
+			Message: fmt.Sprintf("must be between %d and %d", minHighlightFragment, maxHighlightFragment),
+		}
+	}
+	if e.HighlightMaxFragments < 1 {
+		return &infraconfig.ValidationError{Field: "elasticsearch.highlight_max_fragments", Message: "must be greater than 0"}
+	}
+	if !highlightTagPattern.MatchString(e.HighlightTag) {
+		return &infraconfig.ValidationError{
+			Field: "elasticsearch.highlight_tag", Message: fmt.Sprintf("%q is not a lowercase element name", e.HighlightTag),
+		}
+	}
+	return nil
+}
+
+// Validate checks the weights and freshness curve when ranking is enabled.
+func (r *RankingConfig) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+	if r.QualityWeight < 0 || r.ReputationWeight < 0 || r.FreshnessWeight < 0 {
+		return &infraconfig.ValidationError{Field: "elasticsearch.ranking", Message: "weights cannot be negative"}
+	}
+	if r.FreshnessScale < time.Hour {
+		return &infraconfig.ValidationError{Field: "elasticsearch.ranking.freshness_scale", Message: "must be at least 1h"}
+	}
+	if r.FreshnessOffset < 0 {
+		return &infraconfig.ValidationError{Field: "elasticsearch.ranking.freshness_offset", Message: "cannot be negative"}
+	}
+	if r.FreshnessDecay <= 0 || r.FreshnessDecay >= 1 {
+		return &infraconfig.ValidationError{
+			Field: "elasticsearch.ranking.freshness_decay", Message: "must be between 0 and 1 (exclusive)",
+		}
+	}
+	return nil
+}
+
+// Validate checks the click window and boosts when personalization is enabled.
+func (p *PersonalizationConfig) Validate() error {
+	if p.ClickTrackerURL == "" {
+		return nil
+	}
+	if p.Window < 24*time.Hour || p.Window > maxPersonalizeWindow {
+		return &infraconfig.ValidationError{Field: "personalization.window", Message: "must be between 24h and 93 days"}
+	}
+	if p.SourceBoost < 0 || p.TopicBoost < 0 {
+		return &infraconfig.ValidationError{Field: "personalization", Message: "boosts cannot be negative"}
+	}
+	if p.CacheSize < 1 {
+		return &infraconfig.ValidationError{Field: "personalization.cache_size", Message: "must be greater than 0"}
+	}
+	return nil
+}
+
+// Validate checks the TTL when the cache is enabled. The TTL bounds how stale
+// a response can be when an invalidation is missed, so it stays short.
+func (c *CacheConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.TTL < time.Second || c.TTL > maxCacheTTL {
+		return &infraconfig.ValidationError{Field: "cache.ttl", Message: "must be between 1s and 10m"}
+	}
+	return nil
+}
+
+// Validate checks retention and that the analytics API is protected when
+// analytics is enabled: the queries people type are not public.
+func (a *AnalyticsConfig) Validate() error {
+	if !a.Enabled {
+		return nil
+	}
+	if a.JWTSecret == "" {
+		return &infraconfig.ValidationError{Field: "analytics.jwt_secret", Message: "is required when analytics is enabled"}
+	}
+	if a.Retention < 24*time.Hour || a.Retention > maxAnalyticsRetain {
+		return &infraconfig.ValidationError{Field: "analytics.retention", Message: "must be between 24h and 90 days"}
+	}
+	if a.MinCount < 1 {
+		return &infraconfig.ValidationError{Field: "analytics.min_count", Message: "must be greater than 0"}
+	}
+	if a.BufferSize < 1 {
+		return &infraconfig.ValidationError{Field: "analytics.buffer_size", Message: "must be greater than 0"}
+	}
 	return nil
 }
+
+// Validate checks that keys can be verified and the default limits allow
+// requests when API keys are enabled.
+func (k *APIKeysConfig) Validate() error {
+	if !k.Enabled {
+		return nil
+	}
+	if k.JWTSecret == "" {
+		return &infraconfig.ValidationError{Field: "api_keys.jwt_secret", Message: "is required when API keys are enabled"}
+	}
+	if k.DefaultRateLimit < 1 {
+		return &infraconfig.ValidationError{Field: "api_keys.default_rate_limit", Message: "must be greater than 0"}
+	}
+	if k.DefaultDailyQuota < 1 {
+		return &infraconfig.ValidationError{Field: "api_keys.default_daily_quota", Message: "must be greater than 0"}
+	}
+	return nil
+}
+
+// Validate checks that the synonyms API is protected when enabled.
+func (s *SynonymsConfig) Validate() error {
+	if s.Enabled && s.JWTSecret == "" {
+		return &infraconfig.ValidationError{Field: "synonyms.jwt_secret", Message: "is required when synonyms are enabled"}
+	}
+	return nil
+}
+
+// Validate checks that every widget key is unique, long enough to be
+// unguessable, origin-restricted, and within the result limits.
+func (w *WidgetConfig) Validate() error {
+	seen := make(map[string]bool, len(w.Keys))
+	for i := range w.Keys {
+		key := &w.Keys[i]
+		field := fmt.Sprintf("widget.keys[%d]", i)
+
+		if len(key.Key) < minWidgetKeyLength {
+			return &infraconfig.ValidationError{
+				Field: field + ".key", Message: fmt.Sprintf("must be at least %d characters", minWidgetKeyLength),
+			}
+		}
+		if seen[key.Key] {
+			return &infraconfig.ValidationError{Field: field + ".key", Message: "is used by another widget key"}
+		}
+		seen[key.Key] = true
+
+		if len(key.AllowedOrigins) == 0 {
+			return &infraconfig.ValidationError{Field: field + ".allowed_origins", Message: "is required"}
+		}
+		for _, origin := range key.AllowedOrigins {
+			if !isOrigin(origin) {
+				return &infraconfig.ValidationError{
+					Field: field + ".allowed_origins", Message: fmt.Sprintf("%q is not an origin (scheme://host[:port])", origin),
+				}
+			}
+		}
+		if key.MinQuality < 0 || key.MinQuality > maxQualityScore {
+			return &infraconfig.ValidationError{
+				Field: field + ".min_quality", Message: fmt.Sprintf("must be between 0 and %d", maxQualityScore),
+			}
+		}
+		if key.MaxResults < 1 || key.MaxResults > maxWidgetMaxResults {
+			return &infraconfig.ValidationError{
+				Field: field + ".max_results", Message: fmt.Sprintf("must be between 1 and %d", maxWidgetMaxResults),
+			}
+		}
+	}
+	return nil
+}
+
+// isOrigin reports whether s is a bare http(s) origin without path, query, or trailing slash.
+func isOrigin(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
@@ -0,0 +1,263 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+)
+
+func TestWidgetConfigValidate(t *testing.T) {
+	t.Helper()
+
+	valid := config.WidgetKey{
+		Key:            "wk_sudbury_community_2f9c41",
+		AllowedOrigins: []string{"https://community.example.com", "http://localhost:3000"},
+		MaxResults:     10,
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(k *config.WidgetKey)
+		wantErr bool
+	}{
+		{"valid", func(*config.WidgetKey) {}, false},
+		{"short key", func(k *config.WidgetKey) { k.Key = "short" }, true},
+		{"no origins", func(k *config.WidgetKey) { k.AllowedOrigins = nil }, true},
+		{"origin with path", func(k *config.WidgetKey) { k.AllowedOrigins = []string{"https://community.example.com/"} }, true},
+		{"wildcard origin", func(k *config.WidgetKey) { k.AllowedOrigins = []string{"*"} }, true},
+		{"too many results", func(k *config.WidgetKey) { k.MaxResults = 50 }, true},
+	}
+
+	for _, tt := range tests {
+		key := valid
+		tt.mutate(&key)
+		cfg := config.WidgetConfig{Keys: []config.WidgetKey{key}}
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+
+	duplicate := config.WidgetConfig{Keys: []config.WidgetKey{valid, valid}}
+	if err := duplicate.Validate(); err == nil {
+		t.Error("expected duplicate keys to be rejected")
+	}
+}
+
+func TestPersonalizationConfigValidate(t *testing.T) {
+	t.Helper()
+
+	valid := config.PersonalizationConfig{
+		ClickTrackerURL: "http://click-tracker:8093",
+		Window:          30 * 24 * time.Hour,
+		CacheSize:       100,
+		SourceBoost:     0.5,
+		TopicBoost:      0.3,
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(p *config.PersonalizationConfig)
+		wantErr bool
+	}{
+		{"valid", func(*config.PersonalizationConfig) {}, false},
+		{"disabled ignores values", func(p *config.PersonalizationConfig) { p.ClickTrackerURL = ""; p.Window = time.Hour }, false},
+		{"window too short", func(p *config.PersonalizationConfig) { p.Window = time.Hour }, true},
+		{"window too long", func(p *config.PersonalizationConfig) { p.Window = 100 * 24 * time.Hour }, true},
+		{"negative boost", func(p *config.PersonalizationConfig) { p.TopicBoost = -1 }, true},
+	}
+
+	for _, tt := range tests {
+		cfg := valid
+		tt.mutate(&cfg)
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestCacheConfigValidate(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name    string
+		cfg     config.CacheConfig
+		wantErr bool
+	}{
+		{"valid", config.CacheConfig{Enabled: true, TTL: 30 * time.Second}, false},
+		{"disabled ignores ttl", config.CacheConfig{TTL: time.Hour}, false},
+		{"ttl too short", config.CacheConfig{Enabled: true, TTL: time.Millisecond}, true},
+		{"ttl too long", config.CacheConfig{Enabled: true, TTL: time.Hour}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestAnalyticsConfigValidate(t *testing.T) {
+	t.Helper()
+
+	valid := config.AnalyticsConfig{
+		Enabled: true, JWTSecret: "secret", Retention: 30 * 24 * time.Hour, MinCount: 2, BufferSize: 100,
+	}
+	withoutSecret := valid
+	withoutSecret.JWTSecret = ""
+	withLongRetention := valid
+	withLongRetention.Retention = 365 * 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		cfg     config.AnalyticsConfig
+		wantErr bool
+	}{
+		{"valid", valid, false},
+		{"disabled needs no secret", config.AnalyticsConfig{}, false},
+		{"unprotected API", withoutSecret, true},
+		{"retention too long", withLongRetention, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestAPIKeysConfigValidate(t *testing.T) {
+	t.Helper()
+
+	valid := config.APIKeysConfig{Enabled: true, JWTSecret: "secret", DefaultRateLimit: 60, DefaultDailyQuota: 10000}
+	withoutSecret := valid
+	withoutSecret.JWTSecret = ""
+	withoutQuota := valid
+	withoutQuota.DefaultDailyQuota = 0
+
+	tests := []struct {
+		name    string
+		cfg     config.APIKeysConfig
+		wantErr bool
+	}{
+		{"valid", valid, false},
+		{"disabled needs no secret", config.APIKeysConfig{}, false},
+		{"keys cannot be verified", withoutSecret, true},
+		{"no default quota", withoutQuota, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestSynonymsConfigValidate(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name    string
+		cfg     config.SynonymsConfig
+		wantErr bool
+	}{
+		{"valid", config.SynonymsConfig{Enabled: true, JWTSecret: "secret"}, false},
+		{"disabled needs no secret", config.SynonymsConfig{}, false},
+		{"unprotected", config.SynonymsConfig{Enabled: true}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestRankingConfigValidate(t *testing.T) {
+	t.Helper()
+
+	valid := config.RankingConfig{
+		Enabled: true, QualityWeight: 0.5, ReputationWeight: 0.5, FreshnessWeight: 1,
+		FreshnessScale: 7 * 24 * time.Hour, FreshnessOffset: 24 * time.Hour, FreshnessDecay: 0.5,
+	}
+	withNegativeWeight := valid
+	withNegativeWeight.QualityWeight = -1
+	withShortScale := valid
+	withShortScale.FreshnessScale = time.Minute
+	withFullDecay := valid
+	withFullDecay.FreshnessDecay = 1
+
+	tests := []struct {
+		name    string
+		cfg     config.RankingConfig
+		wantErr bool
+	}{
+		{"valid", valid, false},
+		{"disabled ignores weights", config.RankingConfig{QualityWeight: -1}, false},
+		{"negative weight", withNegativeWeight, true},
+		{"scale too short", withShortScale, true},
+		{"decay of 1 never decays", withFullDecay, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestConfigValidate_Highlight(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name    string
+		mutate  func(e *config.ElasticsearchConfig)
+		wantErr bool
+	}{
+		{"defaults", func(*config.ElasticsearchConfig) {}, false},
+		{"mark tag", func(e *config.ElasticsearchConfig) { e.HighlightTag = "mark" }, false},
+		{"tag with attributes", func(e *config.ElasticsearchConfig) { e.HighlightTag = `em class="x"` }, true},
+		{"tag with brackets", func(e *config.ElasticsearchConfig) { e.HighlightTag = "<em>" }, true},
+		{"fragment too small", func(e *config.ElasticsearchConfig) { e.HighlightFragmentSize = 5 }, true},
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("service:\n  port: 8092\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	for _, tt := range tests {
+		cfg, err := config.Load(path)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		tt.mutate(&cfg.Elasticsearch)
+		if validateErr := cfg.Validate(); (validateErr != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, validateErr)
+		}
+	}
+}
+
+func TestConfigValidate_CursorKeepAlive(t *testing.T) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("service:\n  port: 8092\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Service.CursorKeepAlive != 5*time.Minute {
+		t.Errorf("default cursor_keep_alive = %v, want 5m", cfg.Service.CursorKeepAlive)
+	}
+
+	cfg.Service.CursorKeepAlive = 500 * time.Millisecond
+	if validateErr := cfg.Validate(); validateErr == nil {
+		t.Error("expected error for a keep-alive under 1s")
+	}
+}
@@ -0,0 +1,158 @@
+// Package crawlinterval keeps a periodically refreshed copy of each source's
+// crawl interval, taken from the crawler's recurring jobs, so coverage
+// reports judge gaps against how often a source is crawled.
+package crawlinterval
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	// jobsPath is the crawler's job list endpoint.
+	jobsPath = "/api/v1/jobs"
+	// pageSize is the crawler's maximum job list page.
+	pageSize = 250
+	// serviceSubject names search in the tokens it sends the crawler.
+	serviceSubject = "search"
+	hoursPerDay    = 24
+)
+
+// job is the part of a crawler job the cache reads.
+type job struct {
+	SourceName      *string `json:"source_name"`
+	IntervalMinutes *int    `json:"interval_minutes"`
+	IntervalType    string  `json:"interval_type"`
+}
+
+// Cache holds the latest crawl interval per source. A failed refresh keeps the
+// previous intervals, so a crawler outage serves stale rather than no data.
+type Cache struct {
+	service  *infrahttp.ServiceClient
+	url      string
+	interval time.Duration
+	logger   infralogger.Logger
+
+	mu        sync.RWMutex
+	intervals domain.CrawlIntervals
+	loadedAt  time.Time
+}
+
+// NewCache creates a cache for the crawler at cfg.URL. Call Run to load it.
+func NewCache(cfg config.CrawlerConfig, log infralogger.Logger) *Cache {
+	return &Cache{
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:   cfg.Timeout,
+			JWTSecret: cfg.JWTSecret,
+			Subject:   serviceSubject,
+		}),
+		url:      strings.TrimRight(cfg.URL, "/") + jobsPath,
+		interval: cfg.RefreshInterval,
+		logger:   log,
+	}
+}
+
+// Run loads the intervals immediately, then refreshes them every interval until ctx is done.
+func (c *Cache) Run(ctx context.Context) {
+	c.refreshAndLog(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refreshAndLog(ctx)
+		}
+	}
+}
+
+// Snapshot returns the current intervals and whether they have loaded at least once.
+// The returned map must not be modified.
+func (c *Cache) Snapshot() (domain.CrawlIntervals, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.intervals, !c.loadedAt.IsZero()
+}
+
+// Refresh pages through the crawler's jobs and replaces the cached intervals.
+// A source crawled by several recurring jobs gets the shortest interval;
+// run-once jobs are ignored.
+func (c *Cache) Refresh(ctx context.Context) error {
+	intervals := domain.CrawlIntervals{}
+	// Advance by the jobs received, as the crawler may clamp the page size
+	offset := 0
+	for {
+		query := url.Values{"limit": {strconv.Itoa(pageSize)}, "offset": {strconv.Itoa(offset)}}
+		var page struct {
+			Jobs  []job `json:"jobs"`
+			Total int   `json:"total"`
+		}
+		if err := c.service.Do(ctx, http.MethodGet, c.url+"?"+query.Encode(), nil, &page); err != nil {
+			return fmt.Errorf("fetch crawl jobs: %w", err)
+		}
+		for i := range page.Jobs {
+			addInterval(intervals, &page.Jobs[i])
+		}
+		offset += len(page.Jobs)
+		if len(page.Jobs) == 0 || offset >= page.Total {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	c.intervals = intervals
+	c.loadedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// addInterval records j's interval for its source unless a shorter one is known.
+func addInterval(intervals domain.CrawlIntervals, j *job) {
+	if j.SourceName == nil || *j.SourceName == "" || j.IntervalMinutes == nil || *j.IntervalMinutes <= 0 {
+		return
+	}
+	// interval_minutes counts interval_type units, as in the crawler's scheduler
+	var every time.Duration
+	switch j.IntervalType {
+	case "hours":
+		every = time.Duration(*j.IntervalMinutes) * time.Hour
+	case "days":
+		every = time.Duration(*j.IntervalMinutes) * hoursPerDay * time.Hour
+	default:
+		every = time.Duration(*j.IntervalMinutes) * time.Minute
+	}
+	if known, ok := intervals[*j.SourceName]; !ok || every < known {
+		intervals[*j.SourceName] = every
+	}
+}
+
+// refreshAndLog refreshes the cache, logging failures instead of returning them.
+func (c *Cache) refreshAndLog(ctx context.Context) {
+	if err := c.Refresh(ctx); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		c.mu.RLock()
+		loadedAt := c.loadedAt
+		c.mu.RUnlock()
+		c.logger.Warn("Crawl interval refresh failed, keeping previous intervals",
+			infralogger.Error(err),
+			infralogger.Time("loaded_at", loadedAt),
+		)
+		return
+	}
+	c.logger.Debug("Crawl intervals refreshed")
+}
@@ -0,0 +1,68 @@
+package crawlinterval_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/crawlinterval"
+)
+
+func newTestCache(url string) *crawlinterval.Cache {
+	return crawlinterval.NewCache(config.CrawlerConfig{
+		URL:             url,
+		JWTSecret:       "test-secret",
+		RefreshInterval: time.Minute,
+		Timeout:         time.Second,
+	}, infralogger.NewNop())
+}
+
+func TestCache_Refresh(t *testing.T) {
+	t.Parallel()
+
+	jobs := []string{
+		`{"source_name":"cbc","interval_minutes":30,"interval_type":"minutes"}`,
+		`{"source_name":"cbc","interval_minutes":2,"interval_type":"hours"}`,
+		`{"source_name":"weekly","interval_minutes":7,"interval_type":"days"}`,
+		`{"source_name":"once"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Errorf("expected a service token, got %q", r.Header.Get("Authorization"))
+		}
+		// Two jobs per page, to exercise paging
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := min(offset+2, len(jobs))
+		_, _ = fmt.Fprintf(w, `{"jobs":[%s],"total":%d}`, strings.Join(jobs[offset:end], ","), len(jobs))
+	}))
+	defer server.Close()
+
+	cache := newTestCache(server.URL + "/")
+	if _, loaded := cache.Snapshot(); loaded {
+		t.Fatal("cache should not report loaded before the first refresh")
+	}
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	intervals, loaded := cache.Snapshot()
+	if !loaded {
+		t.Fatal("cache should report loaded after a refresh")
+	}
+	if intervals["cbc"] != 30*time.Minute {
+		t.Errorf("cbc interval = %v, want the shorter 30m", intervals["cbc"])
+	}
+	if intervals["weekly"] != 7*24*time.Hour {
+		t.Errorf("weekly interval = %v, want 168h", intervals["weekly"])
+	}
+	if _, ok := intervals["once"]; ok {
+		t.Error("run-once jobs should not set an interval")
+	}
+}
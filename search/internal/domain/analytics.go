@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxAnalyticsQueryLength is the number of runes of a query kept for analytics.
+const maxAnalyticsQueryLength = 100
+
+// queryHashLength is the number of hex characters of a query's hash in logs.
+const queryHashLength = 16
+
+var (
+	// emailPattern and longNumberPattern match what can identify the person
+	// searching: addresses, phone and account numbers. Years stay readable.
+	emailPattern      = regexp.MustCompile(`\S+@\S+`)
+	longNumberPattern = regexp.MustCompile(`\d{5,}`)
+)
+
+// AnonymizeQuery reduces a query to the form analytics counts: lowercased,
+// single-spaced, with email addresses and numbers of five or more digits
+// masked, cut to 100 characters. Different spellings of one search count once.
+func AnonymizeQuery(query string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	normalized = emailPattern.ReplaceAllString(normalized, "<email>")
+	normalized = longNumberPattern.ReplaceAllString(normalized, "<number>")
+	if runes := []rune(normalized); len(runes) > maxAnalyticsQueryLength {
+		normalized = strings.TrimSpace(string(runes[:maxAnalyticsQueryLength]))
+	}
+	return normalized
+}
+
+// QueryHash identifies a query in logs without revealing it. Equal anonymized
+// queries hash alike, so log lines can be grouped by query.
+func QueryHash(query string) string {
+	sum := sha256.Sum256([]byte(AnonymizeQuery(query)))
+	return hex.EncodeToString(sum[:])[:queryHashLength]
+}
+
+// QueryEvent is one executed search as analytics records it.
+type QueryEvent struct {
+	// Query is the anonymized query, or empty when the search is not counted
+	// as a query (browsing without text, or a later page of results)
+	Query   string
+	Hits    int64
+	Latency time.Duration
+	At      time.Time
+}
+
+// AnalyticsRequest holds the window and size of an analytics report.
+type AnalyticsRequest struct {
+	Days  int
+	Limit int
+}
+
+// QueryCount is how often a query was searched in a report's window.
+type QueryCount struct {
+	Query    string `json:"query"`
+	Searches int64  `json:"searches"`
+}
+
+// QueriesReport lists the most searched queries, or the most searched ones
+// that found nothing.
+type QueriesReport struct {
+	WindowDays int          `json:"window_days"`
+	MinCount   int          `json:"min_count"`
+	Queries    []QueryCount `json:"queries"`
+}
+
+// LatencyReport summarizes how many searches ran in a window and how long
+// they took. Percentiles are interpolated within latency histogram buckets.
+type LatencyReport struct {
+	WindowDays     int     `json:"window_days"`
+	Searches       int64   `json:"searches"`
+	ZeroResults    int64   `json:"zero_results"`
+	ZeroResultRate float64 `json:"zero_result_rate"`
+	P50Ms          float64 `json:"p50_ms"`
+	P90Ms          float64 `json:"p90_ms"`
+	P95Ms          float64 `json:"p95_ms"`
+	P99Ms          float64 `json:"p99_ms"`
+}
@@ -0,0 +1,40 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+func TestAnonymizeQuery(t *testing.T) {
+	t.Helper()
+
+	for _, tt := range []struct {
+		query string
+		want  string
+	}{
+		{"  Sudbury   Mining ", "sudbury mining"},
+		{"jane.doe@example.com complaint", "<email> complaint"},
+		{"call 7055551234 about 2024 fire", "call <number> about 2024 fire"},
+	} {
+		if got := domain.AnonymizeQuery(tt.query); got != tt.want {
+			t.Errorf("AnonymizeQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+
+	if got := domain.AnonymizeQuery(strings.Repeat("word ", 50)); len([]rune(got)) > 100 {
+		t.Errorf("expected the query cut to 100 characters, got %d", len([]rune(got)))
+	}
+}
+
+func TestQueryHash(t *testing.T) {
+	t.Helper()
+
+	if domain.QueryHash("Forest Fire") != domain.QueryHash("forest  fire") {
+		t.Error("expected queries that anonymize alike to hash alike")
+	}
+	if got := domain.QueryHash("forest fire"); len(got) != 16 || strings.Contains(got, "fire") {
+		t.Errorf("unexpected hash %q", got)
+	}
+}
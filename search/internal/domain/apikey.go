@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Limits an API key can exceed.
+const (
+	LimitRate  = "rate_limit"
+	LimitQuota = "daily_quota"
+)
+
+// APIKey is an authenticated search API key with its limits resolved: keys
+// issued without a rate limit or quota get the service defaults.
+type APIKey struct {
+	ID         string
+	Name       string
+	RateLimit  int      // requests per minute
+	DailyQuota int      // requests per UTC day
+	Indexes    []string // index patterns the key may search; empty for every classified index
+}
+
+// QuotaUsage is where an API key stands against its limits after a request.
+type QuotaUsage struct {
+	// Exceeded is the limit the request went over (LimitRate or LimitQuota),
+	// or empty when the request is allowed
+	Exceeded       string
+	RateLimit      int
+	RateRemaining  int
+	RateReset      time.Time // start of the next minute
+	DailyQuota     int
+	QuotaRemaining int
+	QuotaReset     time.Time // next UTC midnight
+}
+
+// Allowed reports whether the request is within the key's limits.
+func (u *QuotaUsage) Allowed() bool {
+	return u.Exceeded == ""
+}
+
+// indexPatternsKey holds the index patterns searches are restricted to.
+type indexPatternsKey struct{}
+
+// WithIndexPatterns restricts the searches run with the returned context to
+// patterns, the indexes an API key may search. Empty patterns leave ctx as is.
+func WithIndexPatterns(ctx context.Context, patterns []string) context.Context {
+	if len(patterns) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, indexPatternsKey{}, patterns)
+}
+
+// IndexPatterns returns the index patterns searches with ctx are restricted
+// to, or nil when they may search every classified index.
+func IndexPatterns(ctx context.Context) []string {
+	patterns, _ := ctx.Value(indexPatternsKey{}).([]string)
+	return patterns
+}
@@ -1,6 +1,25 @@
 package domain
 
-import "time"
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// snippetLength is the length in characters of snippets taken from the start of the text.
+	snippetLength = 150
+	// fragmentSeparator joins highlight fragments into one snippet.
+	fragmentSeparator = " … "
+)
+
+// highlightTagPattern matches the highlight tags Elasticsearch wraps matches
+// in. The html encoder escapes everything else, so these are the only tags.
+var highlightTagPattern = regexp.MustCompile(`</?[A-Za-z][A-Za-z0-9]*>`)
+
+// snippetFields are the highlighted fields a snippet is taken from, in order of preference.
+var snippetFields = []string{"body", "raw_text"}
 
 // SearchCrimeInfo contains crime classification data from the crime nested object
 type SearchCrimeInfo struct {
@@ -43,6 +62,7 @@ type ClassifiedContent struct {
 	OGDescription    string           `json:"og_description,omitempty"`
 	OGImage          string           `json:"og_image,omitempty"`
 	MetaDescription  string           `json:"meta_description,omitempty"`
+	Language         string           `json:"language,omitempty"`
 	CrawledAt        *time.Time       `json:"crawled_at,omitempty"`
 	PublishedDate    *time.Time       `json:"published_date,omitempty"`
 	ContentType      string           `json:"content_type"`
@@ -67,29 +87,69 @@ func (c *ClassifiedContent) GetCrimeRelevance() string {
 	return ""
 }
 
-// ToSearchHit converts ClassifiedContent to SearchHit
+// ToSearchHit converts ClassifiedContent to SearchHit. The snippet comes
+// from the highlighted body fragments when there are any, so it shows where
+// the query matched; otherwise from the start of the text.
 func (c *ClassifiedContent) ToSearchHit(score float64, highlight map[string][]string) *SearchHit {
-	// Generate snippet from raw_text if no highlight available
-	snippet := ""
-	if len(highlight) == 0 && len(c.RawText) > 150 {
-		snippet = c.RawText[:150] + "..."
+	highlighted := highlightedSnippet(highlight)
+	snippet := plainSnippet(highlighted)
+	if snippet == "" {
+		text := c.RawText
+		if text == "" {
+			text = c.Body
+		}
+		snippet = truncateText(text, snippetLength)
 	}
 
 	return &SearchHit{
-		ID:             c.ID,
-		Title:          c.Title,
-		URL:            c.URL,
-		SourceName:     c.SourceName,
-		PublishedDate:  c.PublishedDate,
-		CrawledAt:      c.CrawledAt,
-		QualityScore:   c.QualityScore,
-		ContentType:    c.ContentType,
-		Topics:         c.Topics,
-		CrimeRelevance: c.GetCrimeRelevance(),
-		OGImage:        c.OGImage,
-		RFP:            c.RFP,
-		Score:          score,
-		Highlight:      highlight,
-		Snippet:        snippet,
+		ID:                 c.ID,
+		Title:              c.Title,
+		URL:                c.URL,
+		SourceName:         c.SourceName,
+		PublishedDate:      c.PublishedDate,
+		CrawledAt:          c.CrawledAt,
+		QualityScore:       c.QualityScore,
+		ContentType:        c.ContentType,
+		Topics:             c.Topics,
+		CrimeRelevance:     c.GetCrimeRelevance(),
+		Language:           c.Language,
+		OGImage:            c.OGImage,
+		RFP:                c.RFP,
+		Score:              score,
+		Highlight:          highlight,
+		Snippet:            snippet,
+		HighlightedSnippet: highlighted,
+	}
+}
+
+// highlightedSnippet joins the fragments of the first highlighted snippet field.
+func highlightedSnippet(highlight map[string][]string) string {
+	for _, field := range snippetFields {
+		if fragments := highlight[field]; len(fragments) > 0 {
+			return strings.Join(fragments, fragmentSeparator)
+		}
+	}
+	return ""
+}
+
+// plainSnippet strips the highlight tags and HTML escaping from a highlighted snippet.
+func plainSnippet(highlighted string) string {
+	if highlighted == "" {
+		return ""
+	}
+	return html.UnescapeString(highlightTagPattern.ReplaceAllString(highlighted, ""))
+}
+
+// truncateText shortens text to at most limit characters, cutting at the
+// last space when there is one, and marks the cut with "...".
+func truncateText(text string, limit int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= limit {
+		return string(runes)
+	}
+	cut := string(runes[:limit])
+	if space := strings.LastIndex(cut, " "); space > 0 {
+		cut = cut[:space]
 	}
+	return cut + "..."
 }
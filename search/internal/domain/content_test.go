@@ -1,7 +1,9 @@
 package domain_test
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/jonesrussell/north-cloud/search/internal/domain"
 )
@@ -43,3 +45,51 @@ func TestToSearchHit_NilRFPPassesThrough(t *testing.T) {
 		t.Errorf("expected nil RFP for non-RFP content, got %+v", hit.RFP)
 	}
 }
+
+func TestToSearchHit_SnippetFromHighlight(t *testing.T) {
+	content := &domain.ClassifiedContent{
+		ID:      "article-002",
+		RawText: "Opening paragraph that does not mention the query at all.",
+	}
+	highlight := map[string][]string{
+		"title": {"Downtown <em>fire</em>"},
+		"body": {
+			"Crews fought the <em>fire</em> for &quot;hours&quot;",
+			"a second <em>fire</em> &lt;script&gt;",
+		},
+	}
+
+	hit := content.ToSearchHit(1.0, highlight)
+
+	wantHighlighted := "Crews fought the <em>fire</em> for &quot;hours&quot; … a second <em>fire</em> &lt;script&gt;"
+	if hit.HighlightedSnippet != wantHighlighted {
+		t.Errorf("HighlightedSnippet = %q, want %q", hit.HighlightedSnippet, wantHighlighted)
+	}
+	wantPlain := `Crews fought the fire for "hours" … a second fire <script>`
+	if hit.Snippet != wantPlain {
+		t.Errorf("Snippet = %q, want %q", hit.Snippet, wantPlain)
+	}
+}
+
+func TestToSearchHit_SnippetFallsBackToText(t *testing.T) {
+	text := strings.Repeat("é word ", 40)
+	content := &domain.ClassifiedContent{ID: "article-003", RawText: text}
+
+	hit := content.ToSearchHit(1.0, map[string][]string{"title": {"<em>word</em>"}})
+
+	if hit.HighlightedSnippet != "" {
+		t.Errorf("HighlightedSnippet = %q, want empty without body fragments", hit.HighlightedSnippet)
+	}
+	cut := strings.TrimSuffix(hit.Snippet, "...")
+	if cut == hit.Snippet || !strings.HasPrefix(text, cut+" ") || utf8.RuneCountInString(cut) > 150 {
+		t.Errorf("Snippet = %q, want the text cut at a word within 150 characters", hit.Snippet)
+	}
+	if !utf8.ValidString(hit.Snippet) {
+		t.Errorf("Snippet is not valid UTF-8: %q", hit.Snippet)
+	}
+
+	short := (&domain.ClassifiedContent{Body: "Short body."}).ToSearchHit(1.0, nil)
+	if short.Snippet != "Short body." {
+		t.Errorf("Snippet = %q, want the whole short body", short.Snippet)
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// CoverageRequest holds parameters for the per-source coverage report.
+type CoverageRequest struct {
+	// Source restricts the report to a single source_name (optional).
+	Source string
+	// Days is the size of the docs-per-day window.
+	Days int
+	// GapThreshold, when set, is the minimum empty span reported as a gap for
+	// every source. Otherwise each source's crawl interval is used.
+	GapThreshold time.Duration
+}
+
+// CrawlIntervals maps source names to how often the crawler crawls them.
+type CrawlIntervals map[string]time.Duration
+
+// CoverageResponse is the per-source freshness and coverage report.
+type CoverageResponse struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	WindowDays  int       `json:"window_days"`
+	// GapThresholdHour is the gap_hours override; without it each source
+	// reports its own threshold.
+	GapThresholdHour float64           `json:"gap_threshold_hours,omitempty"`
+	Sources          []*SourceCoverage `json:"sources"`
+}
+
+// SourceCoverage describes how well a single source is covered.
+type SourceCoverage struct {
+	SourceName     string       `json:"source_name"`
+	TotalDocuments int64        `json:"total_documents"`
+	OldestIndexed  *time.Time   `json:"oldest_indexed,omitempty"`
+	NewestIndexed  *time.Time   `json:"newest_indexed,omitempty"`
+	DocsPerDay     []DailyCount `json:"docs_per_day"`
+	// GapThresholdHours is the gap_hours override, else the source's crawl
+	// interval, else 24.
+	GapThresholdHours float64       `json:"gap_threshold_hours"`
+	Gaps              []CoverageGap `json:"gaps"`
+	Stale             bool          `json:"stale"`
+}
+
+// DailyCount is the number of documents indexed on a calendar day (UTC).
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// CoverageGap is a span of consecutive days with no indexed documents.
+type CoverageGap struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Days int    `json:"days"`
+}
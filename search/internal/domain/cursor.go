@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// CursorStart is the cursor that begins a cursor walk through a search's
+// results. Each page of the walk returns the cursor of the next.
+const CursorStart = "*"
+
+// MaxResultWindow is how deep page-numbered searches can go: Elasticsearch
+// refuses from+size past index.max_result_window. Cursors have no limit.
+const MaxResultWindow = 10000
+
+// cursorSearchBytes is the length of the search hash a cursor carries.
+const cursorSearchBytes = 8
+
+// ErrInvalidCursor is returned for cursor tokens that cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of a cursor walk: the point in time it reads from,
+// the sort values of the last hit returned, and the number of pages so far.
+// Clients see it as an opaque token.
+type Cursor struct {
+	PIT   string            `json:"pit"`
+	After []json.RawMessage `json:"after"`
+	Page  int               `json:"page"`
+	// Search identifies the search the cursor walks, see CursorSearch
+	Search string `json:"search"`
+}
+
+// Encode returns the cursor's token.
+func (c *Cursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor parses a cursor token.
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if json.Unmarshal(data, &c) != nil || c.PIT == "" || len(c.After) == 0 || c.Page < 1 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// CursorSearch identifies what a cursor walk of req against index returns:
+// its query, language, filters, and sort. A cursor only continues the search
+// it was issued for.
+func (req *SearchRequest) CursorSearch(index string) string {
+	data, err := json.Marshal(struct {
+		Query   string   `json:"q"`
+		Lang    string   `json:"lang"`
+		Filters *Filters `json:"filters"`
+		Sort    *Sort    `json:"sort"`
+		Index   string   `json:"index"`
+	}{req.Query, req.Lang, req.Filters, req.Sort, index})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:cursorSearchBytes])
+}
@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	t.Helper()
+
+	cursor := &domain.Cursor{
+		PIT:    "46ToAwMDaWR5BXV1aWQy",
+		After:  []json.RawMessage{json.RawMessage(`1.25`), json.RawMessage(`9223372036854775807`)},
+		Page:   3,
+		Search: "abc123",
+	}
+	token, err := cursor.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	decoded, err := domain.DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if decoded.PIT != cursor.PIT || decoded.Page != cursor.Page || decoded.Search != cursor.Search {
+		t.Errorf("decoded %+v, want %+v", decoded, cursor)
+	}
+	// Shard doc tiebreakers are longs that must survive without float rounding
+	if string(decoded.After[1]) != "9223372036854775807" {
+		t.Errorf("After[1] = %s, want 9223372036854775807", decoded.After[1])
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	t.Helper()
+
+	incomplete, err := (&domain.Cursor{PIT: "pit", Page: 1}).Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	for name, token := range map[string]string{
+		"not base64":     "%%%",
+		"not json":       "bm90IGpzb24",
+		"no sort values": incomplete,
+		"start marker":   domain.CursorStart,
+	} {
+		if _, decodeErr := domain.DecodeCursor(token); !errors.Is(decodeErr, domain.ErrInvalidCursor) {
+			t.Errorf("%s: want ErrInvalidCursor, got %v", name, decodeErr)
+		}
+	}
+}
+
+func TestSearchRequest_CursorSearch(t *testing.T) {
+	t.Helper()
+
+	base := func() *domain.SearchRequest {
+		return &domain.SearchRequest{
+			Query:   "wildfire",
+			Filters: &domain.Filters{Topics: []string{"environment"}},
+			Sort:    &domain.Sort{Field: "relevance", Order: "desc"},
+		}
+	}
+	want := base().CursorSearch("*_classified_content")
+
+	paged := base()
+	paged.Pagination = &domain.Pagination{Page: 4, Size: 50}
+	if got := paged.CursorSearch("*_classified_content"); got != want {
+		t.Errorf("pagination changed the search: %s != %s", got, want)
+	}
+
+	otherFilter := base()
+	otherFilter.Filters.Topics = []string{"crime"}
+	if otherFilter.CursorSearch("*_classified_content") == want {
+		t.Error("different filters should be a different search")
+	}
+	if base().CursorSearch("news_classified_content") == want {
+		t.Error("different indexes should be a different search")
+	}
+}
+
+func TestSearchRequest_Validate_Cursor(t *testing.T) {
+	t.Helper()
+
+	req := &domain.SearchRequest{
+		Query:      "test",
+		Pagination: &domain.Pagination{Page: 900, Size: 50, Cursor: domain.CursorStart},
+	}
+	if err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if req.Pagination.Page != 1 {
+		t.Errorf("cursor searches ignore page: got %d, want 1", req.Pagination.Page)
+	}
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Fields of the query syntax: source:naiz.eus topic:crime title:"armed robbery".
+const (
+	QueryFieldSource   = "source"
+	QueryFieldTopic    = "topic"
+	QueryFieldType     = "type"
+	QueryFieldTitle    = "title"
+	QueryFieldCity     = "city"
+	QueryFieldProvince = "province"
+)
+
+// queryFields lists the query syntax fields in the order errors name them.
+var queryFields = []string{
+	QueryFieldSource, QueryFieldTopic, QueryFieldType, QueryFieldTitle, QueryFieldCity, QueryFieldProvince,
+}
+
+// fieldNamePattern matches what reads as a field name before a colon. Other
+// words with colons, such as times (10:30) and URLs, stay query text.
+var fieldNamePattern = regexp.MustCompile(`^[A-Za-z_]+$`)
+
+// QueryClause is one field term, quoted phrase or exclusion of a query.
+type QueryClause struct {
+	// Field is one of the QueryField* names, or empty for the text fields
+	// the query words are matched against
+	Field  string
+	Value  string
+	Phrase bool
+	// Negate excludes documents matching the clause (a leading -)
+	Negate bool
+}
+
+// FieldQuery is a query written in the field syntax: its plain words, still
+// matched like any query, and the clauses around them.
+type FieldQuery struct {
+	Text    string
+	Clauses []QueryClause
+}
+
+// ParseFieldQuery parses the query syntax: field:value and field:"a phrase"
+// for the QueryField* fields, "a phrase" to match words in order, and a
+// leading - to exclude a word, phrase or field value. Other words are the
+// query text. It returns nil for a query without any syntax, which is
+// searched exactly as before.
+func ParseFieldQuery(query string) (*FieldQuery, error) {
+	var words []string
+	var clauses []QueryClause
+
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		var clause QueryClause
+		// A lone dash ("Sudbury - Timmins") is punctuation, not an exclusion
+		if rest[0] == '-' && len(rest) > 1 && !isQuerySpace(rest[1]) {
+			clause.Negate = true
+			rest = rest[1:]
+		}
+
+		var token string
+		var err error
+		if rest[0] == '"' {
+			if clause.Value, rest, err = readQueryPhrase(rest); err != nil {
+				return nil, err
+			}
+			clause.Phrase = true
+			clauses = append(clauses, clause)
+			rest = strings.TrimLeft(rest, " \t\r\n")
+			continue
+		}
+
+		token, rest = readQueryWord(rest)
+		name, value, isField := strings.Cut(token, ":")
+		if !isField || !fieldNamePattern.MatchString(name) || strings.HasPrefix(value, "//") {
+			if clause.Negate {
+				clause.Value = token
+				clauses = append(clauses, clause)
+			} else {
+				words = append(words, token)
+			}
+			rest = strings.TrimLeft(rest, " \t\r\n")
+			continue
+		}
+
+		clause.Field = strings.ToLower(name)
+		if !validQueryField(clause.Field) {
+			return nil, fmt.Errorf("query syntax: unknown field %q, use one of %s", name, strings.Join(queryFields, ", "))
+		}
+		if value == "" && strings.HasPrefix(rest, `"`) {
+			if value, rest, err = readQueryPhrase(rest); err != nil {
+				return nil, err
+			}
+			clause.Phrase = true
+		}
+		if clause.Value, err = normalizeQueryValue(clause.Field, value); err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+		rest = strings.TrimLeft(rest, " \t\r\n")
+	}
+
+	if len(clauses) == 0 {
+		return nil, nil //nolint:nilnil // A plain query has no field query
+	}
+	return &FieldQuery{Text: strings.Join(words, " "), Clauses: clauses}, nil
+}
+
+// readQueryWord reads up to the next space. A field:"phrase" word stops at
+// the opening quote so the phrase is read whole.
+func readQueryWord(s string) (word, rest string) {
+	for i := 0; i < len(s); i++ {
+		if isQuerySpace(s[i]) {
+			return s[:i], s[i:]
+		}
+		if s[i] == '"' && i > 0 && s[i-1] == ':' {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// readQueryPhrase reads the quoted phrase s starts with.
+func readQueryPhrase(s string) (phrase, rest string, err error) {
+	end := strings.IndexByte(s[1:], '"')
+	if end < 0 {
+		return "", "", errors.New("query syntax: unterminated quote")
+	}
+	phrase = strings.Join(strings.Fields(s[1:end+1]), " ")
+	if phrase == "" {
+		return "", "", errors.New("query syntax: empty quoted phrase")
+	}
+	return phrase, s[end+2:], nil
+}
+
+// normalizeQueryValue rewrites a field value into the form the classifier
+// indexes, like the equivalent filters.
+func normalizeQueryValue(field, value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("query syntax: %s: needs a value", field)
+	}
+	switch field {
+	case QueryFieldCity:
+		return citySlug(value), nil
+	case QueryFieldProvince:
+		code := strings.ToUpper(value)
+		if !canadianProvinces[code] {
+			return "", fmt.Errorf("query syntax: unknown province %q, use a two-letter code such as ON", value)
+		}
+		return code, nil
+	default:
+		return value, nil
+	}
+}
+
+func validQueryField(field string) bool {
+	for _, known := range queryFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+func TestParseFieldQuery(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name  string
+		query string
+		want  *domain.FieldQuery
+	}{
+		{"plain query", "armed robbery sudbury", nil},
+		{"times and urls stay text", "council 10:30 https://example.com", nil},
+		{"lone dash stays text", "Sudbury - Timmins", nil},
+		{
+			"fields, phrase and exclusion",
+			`source:naiz.eus topic:crime title:"armed robbery" -sports`,
+			&domain.FieldQuery{Clauses: []domain.QueryClause{
+				{Field: domain.QueryFieldSource, Value: "naiz.eus"},
+				{Field: domain.QueryFieldTopic, Value: "crime"},
+				{Field: domain.QueryFieldTitle, Value: "armed robbery", Phrase: true},
+				{Value: "sports", Negate: true},
+			}},
+		},
+		{
+			"text around clauses",
+			`mine  closure province:on -"open pit" -city:Thunder Bay`,
+			&domain.FieldQuery{Text: "mine closure Bay", Clauses: []domain.QueryClause{
+				{Field: domain.QueryFieldProvince, Value: "ON"},
+				{Value: "open pit", Phrase: true, Negate: true},
+				{Field: domain.QueryFieldCity, Value: "thunder", Negate: true},
+			}},
+		},
+		{
+			"quoted city",
+			`City:"Thunder  Bay" fire`,
+			&domain.FieldQuery{Text: "fire", Clauses: []domain.QueryClause{
+				{Field: domain.QueryFieldCity, Value: "thunder-bay", Phrase: true},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := domain.ParseFieldQuery(tt.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseFieldQuery_Errors(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{"site:example.com", `unknown field "site"`},
+		{"source:", "source: needs a value"},
+		{`title:"armed robbery`, "unterminated quote"},
+		{`fire ""`, "empty quoted phrase"},
+		{"province:zz", `unknown province "zz"`},
+	}
+
+	for _, tt := range tests {
+		_, err := domain.ParseFieldQuery(tt.query)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%q: want error containing %q, got %v", tt.query, tt.wantErr, err)
+		}
+	}
+}
+
+func TestSearchRequest_Validate_FieldQuery(t *testing.T) {
+	t.Helper()
+
+	req := &domain.SearchRequest{Query: "topic:crime robbery"}
+	if err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if req.FieldQuery == nil || req.FieldQuery.Text != "robbery" {
+		t.Errorf("FieldQuery = %+v, want text robbery", req.FieldQuery)
+	}
+
+	bad := &domain.SearchRequest{Query: "tpoic:crime"}
+	if err := bad.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err == nil {
+		t.Error("Validate() should reject an unknown field")
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+)
+
+// maxAffinities bounds how many sources and topics one profile boosts.
+const maxAffinities = 10
+
+// widgetSessionHashLength is the number of hex characters of a widget key's
+// hash used in its session ID.
+const widgetSessionHashLength = 16
+
+// sessionIDPattern matches the session IDs the click-tracker accepts.
+var sessionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// ValidSessionID reports whether id can be attached to click URLs.
+func ValidSessionID(id string) bool {
+	return sessionIDPattern.MatchString(id)
+}
+
+// WidgetSessionID is the session widget searches are attributed to, so a
+// partner site's readers share one engagement profile per widget key. The
+// key is hashed so it never appears in click URLs or the click-tracker.
+func WidgetSessionID(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return "key-" + hex.EncodeToString(sum[:])[:widgetSessionHashLength]
+}
+
+// ResultTags are the fields of a clicked result that personalization boosts.
+type ResultTags struct {
+	Source string
+	Topics []string
+}
+
+// Affinity is a source or topic a session engages with and the extra weight
+// its results get: a matching hit scores (1 + Boost) times its relevance.
+type Affinity struct {
+	Value string  `json:"value"`
+	Boost float64 `json:"boost"`
+}
+
+// EngagementProfile is what a session has clicked, reduced to the sources
+// and topics it prefers. An empty profile leaves ranking unchanged.
+type EngagementProfile struct {
+	Clicks  int64      `json:"clicks"`
+	Sources []Affinity `json:"sources,omitempty"`
+	Topics  []Affinity `json:"topics,omitempty"`
+}
+
+// Empty reports whether the profile boosts nothing.
+func (p *EngagementProfile) Empty() bool {
+	return p == nil || (len(p.Sources) == 0 && len(p.Topics) == 0)
+}
+
+// NewEngagementProfile weighs each source and topic by the clicks on results
+// carrying it. The most-clicked source gets sourceBoost and the most-clicked
+// topic topicBoost; the others scale down in proportion to their clicks.
+// Clicks on results missing from tags are counted but boost nothing.
+func NewEngagementProfile(
+	clicks map[string]int64, tags map[string]ResultTags, sourceBoost, topicBoost float64,
+) *EngagementProfile {
+	profile := &EngagementProfile{}
+	sources := make(map[string]int64)
+	topics := make(map[string]int64)
+	for resultID, count := range clicks {
+		profile.Clicks += count
+		tag, ok := tags[resultID]
+		if !ok {
+			continue
+		}
+		if tag.Source != "" {
+			sources[tag.Source] += count
+		}
+		for _, topic := range tag.Topics {
+			topics[topic] += count
+		}
+	}
+
+	profile.Sources = topAffinities(sources, sourceBoost)
+	profile.Topics = topAffinities(topics, topicBoost)
+	return profile
+}
+
+// topAffinities keeps the maxAffinities most-clicked values, strongest first.
+func topAffinities(counts map[string]int64, boost float64) []Affinity {
+	if len(counts) == 0 || boost <= 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	if len(values) > maxAffinities {
+		values = values[:maxAffinities]
+	}
+
+	top := float64(counts[values[0]])
+	affinities := make([]Affinity, 0, len(values))
+	for _, value := range values {
+		affinities = append(affinities, Affinity{Value: value, Boost: boost * float64(counts[value]) / top})
+	}
+	return affinities
+}
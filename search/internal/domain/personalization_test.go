@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+func TestSearchRequest_Validate_Session(t *testing.T) {
+	t.Helper()
+
+	for _, tt := range []struct {
+		sessionID   string
+		personalize bool
+		wantErr     bool
+	}{
+		{"", false, false},
+		{"s_a1b2-c3", true, false},
+		{"s_a1b2-c3", false, false},
+		{"", true, true},
+		{"has space", false, true},
+		{"abcdefghijklmnopqrstuvwxyz0123456", false, true},
+	} {
+		req := &domain.SearchRequest{
+			Query:   "test",
+			Options: &domain.Options{SessionID: tt.sessionID, Personalize: tt.personalize},
+		}
+		err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("session %q personalize %v: Validate() error = %v, wantError %v",
+				tt.sessionID, tt.personalize, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNewEngagementProfile(t *testing.T) {
+	t.Helper()
+
+	clicks := map[string]int64{"doc-1": 4, "doc-2": 2, "doc-3": 1, "gone": 5}
+	tags := map[string]domain.ResultTags{
+		"doc-1": {Source: "sudbury_star", Topics: []string{"crime", "local_news"}},
+		"doc-2": {Source: "cbc", Topics: []string{"crime"}},
+		"doc-3": {Source: "sudbury_star"},
+	}
+
+	profile := domain.NewEngagementProfile(clicks, tags, 0.5, 0.3)
+
+	if profile.Clicks != 12 {
+		t.Errorf("Clicks = %d, want 12", profile.Clicks)
+	}
+	wantSources := []domain.Affinity{{Value: "sudbury_star", Boost: 0.5}, {Value: "cbc", Boost: 0.2}}
+	if len(profile.Sources) != len(wantSources) {
+		t.Fatalf("Sources = %+v, want %+v", profile.Sources, wantSources)
+	}
+	for i, want := range wantSources {
+		if got := profile.Sources[i]; got.Value != want.Value || !nearlyEqual(got.Boost, want.Boost) {
+			t.Errorf("Sources[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+	if len(profile.Topics) != 2 || profile.Topics[0].Value != "crime" || !nearlyEqual(profile.Topics[0].Boost, 0.3) {
+		t.Errorf("Topics = %+v, want crime first with the full topic boost", profile.Topics)
+	}
+}
+
+func TestNewEngagementProfile_Empty(t *testing.T) {
+	t.Helper()
+
+	profile := domain.NewEngagementProfile(map[string]int64{"gone": 3}, nil, 0.5, 0.3)
+	if !profile.Empty() {
+		t.Errorf("profile without resolvable clicks should be empty, got %+v", profile)
+	}
+	if !(*domain.EngagementProfile)(nil).Empty() {
+		t.Error("nil profile should be empty")
+	}
+}
+
+func TestWidgetSessionID(t *testing.T) {
+	t.Helper()
+
+	id := domain.WidgetSessionID("wk_sudbury_community_2f9c41")
+	if !domain.ValidSessionID(id) {
+		t.Errorf("WidgetSessionID() = %q is not a valid session ID", id)
+	}
+	if id != domain.WidgetSessionID("wk_sudbury_community_2f9c41") || id == domain.WidgetSessionID("wk_timmins_news_7d01e2") {
+		t.Errorf("WidgetSessionID() should be stable per key name, got %q", id)
+	}
+}
+
+func nearlyEqual(a, b float64) bool {
+	const epsilon = 1e-9
+	return a-b < epsilon && b-a < epsilon
+}
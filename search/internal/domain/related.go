@@ -0,0 +1,28 @@
+package domain
+
+// RelatedRequest holds parameters for the related-articles lookup.
+type RelatedRequest struct {
+	// ArticleID is the document related coverage is found for.
+	ArticleID string
+	// Days bounds how old a related article may be.
+	Days int
+	// Limit is the number of related articles returned.
+	Limit int
+	// SessionID is attached to click URLs like a search's (optional).
+	SessionID string
+}
+
+// RelatedArticle is the document a related-articles lookup starts from.
+type RelatedArticle struct {
+	ID    string
+	Index string
+	Title string
+}
+
+// RelatedResponse lists articles covering the same story, most similar first.
+type RelatedResponse struct {
+	ArticleID  string       `json:"article_id"`
+	WindowDays int          `json:"window_days"`
+	Hits       []*SearchHit `json:"hits"`
+	TookMs     int64        `json:"took_ms"`
+}
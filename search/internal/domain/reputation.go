@@ -0,0 +1,25 @@
+package domain
+
+import "sort"
+
+// SourceReputations maps source names to the classifier's reputation score (0-100).
+type SourceReputations map[string]int
+
+// SourcesAtLeast returns the sources scoring minScore or more, sorted by name.
+// Sources the classifier has not scored are excluded.
+func (r SourceReputations) SourcesAtLeast(minScore int) []string {
+	names := make([]string, 0, len(r))
+	for name, score := range r {
+		if score >= minScore {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Score returns the source's reputation and whether it is known.
+func (r SourceReputations) Score(source string) (int, bool) {
+	score, ok := r[source]
+	return score, ok
+}
@@ -3,30 +3,65 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
-const maxQualityScore = 100
+const (
+	maxQualityScore    = 100
+	maxReputationScore = 100
+)
+
+// SortSourceReputation sorts by the source's current reputation score
+const SortSourceReputation = "source_reputation"
+
+// Query languages. A search with a lang only returns documents in that
+// language and matches them with its analyzer; documents without a language
+// count as English. A search without one uses the English analyzer throughout.
+const (
+	LanguageEnglish = "en"
+	LanguageFrench  = "fr"
+)
 
 // SearchRequest represents a search query request
 type SearchRequest struct {
 	Query      string      `json:"query"`
+	Lang       string      `json:"lang,omitempty"` // LanguageEnglish, LanguageFrench, or empty for all
 	Filters    *Filters    `json:"filters,omitempty"`
 	Pagination *Pagination `json:"pagination,omitempty"`
 	Sort       *Sort       `json:"sort,omitempty"`
 	Options    *Options    `json:"options,omitempty"`
+	// FieldQuery is Query parsed by Validate when it uses the field syntax
+	FieldQuery *FieldQuery `json:"-"`
 }
 
 // Filters holds search filter criteria
 type Filters struct {
-	Topics          []string   `json:"topics,omitempty"`
-	ContentType     string     `json:"content_type,omitempty"`
-	MinQualityScore int        `json:"min_quality_score,omitempty"`
-	MaxQualityScore int        `json:"max_quality_score,omitempty"`
-	CrimeRelevance  []string   `json:"crime_relevance,omitempty"`
-	SourceNames     []string   `json:"source_names,omitempty"`
-	FromDate        *time.Time `json:"from_date,omitempty"`
-	ToDate          *time.Time `json:"to_date,omitempty"`
+	Topics          []string `json:"topics,omitempty"`
+	ContentType     string   `json:"content_type,omitempty"`
+	MinQualityScore int      `json:"min_quality_score,omitempty"`
+	MaxQualityScore int      `json:"max_quality_score,omitempty"`
+	CrimeRelevance  []string `json:"crime_relevance,omitempty"`
+	SourceNames     []string `json:"source_names,omitempty"`
+	MinReputation   int      `json:"min_reputation,omitempty"` // classifier source reputation, 0-100
+
+	// Date range filters, inclusive at both ends. published_date is missing
+	// on some documents and a published range excludes them; crawled_at is
+	// always set.
+	PublishedFrom *time.Time `json:"published_from,omitempty"`
+	PublishedTo   *time.Time `json:"published_to,omitempty"`
+	CrawledFrom   *time.Time `json:"crawled_from,omitempty"`
+	CrawledTo     *time.Time `json:"crawled_to,omitempty"`
+
+	// Deprecated: FromDate and ToDate filter on crawled_at. Validate folds
+	// them into CrawledFrom and CrawledTo when those are unset.
+	FromDate *time.Time `json:"from_date,omitempty"`
+	ToDate   *time.Time `json:"to_date,omitempty"`
+
+	// Location filters on the classifier's detected location. Cities are
+	// matched as slugs ("thunder-bay"), provinces as two-letter codes ("ON").
+	Cities    []string `json:"cities,omitempty"`
+	Provinces []string `json:"provinces,omitempty"`
 
 	// Recipe filters
 	RecipeCuisine  []string `json:"recipe_cuisine,omitempty"`
@@ -52,11 +87,15 @@ type Filters struct {
 type Pagination struct {
 	Page int `json:"page"`
 	Size int `json:"size"`
+	// Cursor pages with search_after instead of page numbers, past the
+	// 10000 results pages can reach: "*" starts a walk, and each response's
+	// next_cursor continues it. Page is ignored when set.
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // Sort holds sorting parameters
 type Sort struct {
-	Field string `json:"field"` // relevance, published_date, quality_score, crawled_at
+	Field string `json:"field"` // relevance, published_date, quality_score, crawled_at, source_reputation
 	Order string `json:"order"` // asc, desc
 }
 
@@ -65,6 +104,18 @@ type Options struct {
 	IncludeHighlights bool     `json:"include_highlights,omitempty"`
 	IncludeFacets     bool     `json:"include_facets,omitempty"`
 	SourceFields      []string `json:"source_fields,omitempty"`
+	// SessionID identifies an anonymous visitor (up to 32 of [A-Za-z0-9_-]).
+	// It is attached to click URLs so the click-tracker records the session.
+	SessionID string `json:"session_id,omitempty"`
+	// Personalize boosts the sources and topics SessionID has clicked.
+	// Only applies to relevance-sorted searches.
+	Personalize bool `json:"personalize,omitempty"`
+	// AutoCorrect re-runs a search that finds nothing with its did_you_mean
+	// suggestion, when the spelling suggester is enabled.
+	AutoCorrect bool `json:"auto_correct,omitempty"`
+	// Feed marks a search rendered as an RSS or JSON feed. Feed readers poll
+	// the same search, so query analytics does not count it.
+	Feed bool `json:"-"`
 }
 
 // SearchResponse represents a search result response
@@ -77,26 +128,45 @@ type SearchResponse struct {
 	TookMs      int64        `json:"took_ms"`
 	Hits        []*SearchHit `json:"hits"`
 	Facets      *Facets      `json:"facets,omitempty"`
+	// Personalized is true when the session's engagement profile re-ranked the hits
+	Personalized bool `json:"personalized,omitempty"`
+	// Cached is true when the response was served from the search cache
+	Cached bool `json:"cached,omitempty"`
+	// DidYouMean is a spelling correction of the query with matching documents
+	DidYouMean string `json:"did_you_mean,omitempty"`
+	// CorrectedFrom is the original query when AutoCorrect replaced it
+	CorrectedFrom string `json:"corrected_from,omitempty"`
+	// NextCursor continues a cursor walk; it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // SearchHit represents a single search result
 type SearchHit struct {
-	ID             string              `json:"id"`
-	Title          string              `json:"title"`
-	URL            string              `json:"url"`
-	SourceName     string              `json:"source_name"`
-	PublishedDate  *time.Time          `json:"published_date,omitempty"`
-	CrawledAt      *time.Time          `json:"crawled_at,omitempty"`
-	QualityScore   int                 `json:"quality_score"`
-	ContentType    string              `json:"content_type"`
-	Topics         []string            `json:"topics,omitempty"`
-	CrimeRelevance string              `json:"crime_relevance,omitempty"`
-	Score          float64             `json:"score"` // Relevance score
-	Highlight      map[string][]string `json:"highlight,omitempty"`
-	Snippet        string              `json:"snippet,omitempty"`
-	ClickURL       string              `json:"click_url,omitempty"`
-	OGImage        string              `json:"og_image,omitempty"`
-	RFP            *RFPData            `json:"rfp,omitempty"`
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	URL            string     `json:"url"`
+	SourceName     string     `json:"source_name"`
+	PublishedDate  *time.Time `json:"published_date,omitempty"`
+	CrawledAt      *time.Time `json:"crawled_at,omitempty"`
+	QualityScore   int        `json:"quality_score"`
+	ContentType    string     `json:"content_type"`
+	Topics         []string   `json:"topics,omitempty"`
+	CrimeRelevance string     `json:"crime_relevance,omitempty"`
+	Language       string     `json:"language,omitempty"`
+	Score          float64    `json:"score"` // Relevance score
+	// Highlight holds matched fragments per field (title, body, raw_text). They
+	// are HTML-escaped, with matches wrapped in the configured highlight tag.
+	Highlight map[string][]string `json:"highlight,omitempty"`
+	// Snippet is plain text: the matched body fragments, or the start of the text
+	Snippet string `json:"snippet,omitempty"`
+	// HighlightedSnippet is the matched fragments as HTML-safe markup with
+	// matches tagged; empty when nothing in the body was highlighted
+	HighlightedSnippet string   `json:"highlighted_snippet,omitempty"`
+	ClickURL           string   `json:"click_url,omitempty"`
+	OGImage            string   `json:"og_image,omitempty"`
+	RFP                *RFPData `json:"rfp,omitempty"`
+	// SourceReputation is the source's current reputation from the classifier (nil when unknown)
+	SourceReputation *int `json:"source_reputation,omitempty"`
 }
 
 // Facets holds faceted search aggregations
@@ -131,11 +201,21 @@ func (req *SearchRequest) Validate(maxPageSize, defaultPageSize, maxQueryLength
 		return fmt.Errorf("query length exceeds maximum of %d characters", maxQueryLength)
 	}
 
+	fieldQuery, err := ParseFieldQuery(req.Query)
+	if err != nil {
+		return err
+	}
+	req.FieldQuery = fieldQuery
+
 	// Set defaults and validate pagination
 	if err := validatePagination(req, maxPageSize, defaultPageSize); err != nil {
 		return err
 	}
 
+	if err := validateLang(req); err != nil {
+		return err
+	}
+
 	// Set default filters and validate
 	if err := initializeAndValidateFilters(req); err != nil {
 		return err
@@ -147,7 +227,18 @@ func (req *SearchRequest) Validate(maxPageSize, defaultPageSize, maxQueryLength
 	// Set default options
 	initializeOptions(req)
 
-	return nil
+	return validateOptions(req.Options)
+}
+
+// validateLang normalizes the query language and rejects unsupported ones
+func validateLang(req *SearchRequest) error {
+	req.Lang = strings.ToLower(strings.TrimSpace(req.Lang))
+	switch req.Lang {
+	case "", LanguageEnglish, LanguageFrench:
+		return nil
+	default:
+		return fmt.Errorf("lang must be %s or %s", LanguageEnglish, LanguageFrench)
+	}
 }
 
 // validatePagination validates and sets defaults for pagination
@@ -170,6 +261,15 @@ func validatePagination(req *SearchRequest, maxPageSize, defaultPageSize int) er
 		return fmt.Errorf("page size exceeds maximum of %d", maxPageSize)
 	}
 
+	// The service sets the page a cursor continues at
+	if req.Pagination.Cursor != "" {
+		req.Pagination.Page = 1
+		return nil
+	}
+	if req.Pagination.Page*req.Pagination.Size > MaxResultWindow {
+		return fmt.Errorf("pages only reach the first %d results, use cursor pagination to go deeper", MaxResultWindow)
+	}
+
 	return nil
 }
 
@@ -203,12 +303,15 @@ func validateFilterValues(filters *Filters) error {
 	if filters.MinQualityScore > filters.MaxQualityScore {
 		return errors.New("min_quality_score cannot exceed max_quality_score")
 	}
+	if filters.MinReputation < 0 || filters.MinReputation > maxReputationScore {
+		return fmt.Errorf("min_reputation must be between 0 and %d", maxReputationScore)
+	}
 
-	// Validate date range
-	if filters.FromDate != nil && filters.ToDate != nil {
-		if filters.FromDate.After(*filters.ToDate) {
-			return errors.New("from_date cannot be after to_date")
-		}
+	if err := validateDateRanges(filters); err != nil {
+		return err
+	}
+	if err := normalizeLocations(filters); err != nil {
+		return err
 	}
 
 	// Recipe/job filter constraints
@@ -225,6 +328,69 @@ func validateFilterValues(filters *Filters) error {
 	return nil
 }
 
+// validateDateRanges folds the deprecated from_date/to_date into the crawled
+// range and checks that no range ends before it starts.
+func validateDateRanges(filters *Filters) error {
+	if filters.CrawledFrom == nil {
+		filters.CrawledFrom = filters.FromDate
+	}
+	if filters.CrawledTo == nil {
+		filters.CrawledTo = filters.ToDate
+	}
+	filters.FromDate, filters.ToDate = nil, nil
+
+	if filters.PublishedFrom != nil && filters.PublishedTo != nil &&
+		filters.PublishedFrom.After(*filters.PublishedTo) {
+		return errors.New("published_from cannot be after published_to")
+	}
+	if filters.CrawledFrom != nil && filters.CrawledTo != nil &&
+		filters.CrawledFrom.After(*filters.CrawledTo) {
+		return errors.New("crawled_from cannot be after crawled_to")
+	}
+
+	return nil
+}
+
+// canadianProvinces are the province and territory codes the classifier
+// stores in location.province.
+var canadianProvinces = map[string]bool{
+	"AB": true, "BC": true, "MB": true, "NB": true, "NL": true, "NS": true, "NT": true,
+	"NU": true, "ON": true, "PE": true, "QC": true, "SK": true, "YT": true,
+}
+
+// normalizeLocations rewrites city and province filters into the form the
+// classifier indexes and rejects unknown province codes.
+func normalizeLocations(filters *Filters) error {
+	cities := make([]string, 0, len(filters.Cities))
+	for _, city := range filters.Cities {
+		if slug := citySlug(city); slug != "" {
+			cities = append(cities, slug)
+		}
+	}
+	filters.Cities = cities
+
+	provinces := make([]string, 0, len(filters.Provinces))
+	for _, province := range filters.Provinces {
+		code := strings.ToUpper(strings.TrimSpace(province))
+		if code == "" {
+			continue
+		}
+		if !canadianProvinces[code] {
+			return fmt.Errorf("unknown province %q: use a two-letter code such as ON", province)
+		}
+		provinces = append(provinces, code)
+	}
+	filters.Provinces = provinces
+
+	return nil
+}
+
+// citySlug converts a city name to the slug stored in location.city,
+// e.g. "Thunder Bay" to "thunder-bay".
+func citySlug(city string) string {
+	return strings.ToLower(strings.Join(strings.Fields(city), "-"))
+}
+
 // validateSort validates and sets defaults for sort
 func validateSort(req *SearchRequest) {
 	if req.Sort == nil {
@@ -237,10 +403,11 @@ func validateSort(req *SearchRequest) {
 
 	// Validate sort field
 	validFields := map[string]bool{
-		"relevance":      true,
-		"published_date": true,
-		"quality_score":  true,
-		"crawled_at":     true,
+		"relevance":          true,
+		"published_date":     true,
+		"quality_score":      true,
+		"crawled_at":         true,
+		SortSourceReputation: true,
 	}
 	if !validFields[req.Sort.Field] {
 		// Reset to default if invalid
@@ -263,6 +430,17 @@ func initializeOptions(req *SearchRequest) {
 	}
 }
 
+// validateOptions checks the session a search is attributed to
+func validateOptions(options *Options) error {
+	if options.SessionID != "" && !ValidSessionID(options.SessionID) {
+		return errors.New("session_id must be 1-32 letters, digits, '_' or '-'")
+	}
+	if options.Personalize && options.SessionID == "" {
+		return errors.New("personalize requires session_id")
+	}
+	return nil
+}
+
 // HealthStatus represents the health status of the service
 type HealthStatus struct {
 	Status       string            `json:"status"`
@@ -117,6 +117,8 @@ func TestSearchRequest_Validate_Pagination(t *testing.T) {
 		{"zero size (corrected)", 1, 0, 1, testDefaultPageSize, false},
 		{"size at limit", 1, testMaxPageSize, 1, testMaxPageSize, false},
 		{"size exceeds limit", 1, testMaxPageSize + 1, 0, 0, true},
+		{"last page in result window", domain.MaxResultWindow / 20, 20, domain.MaxResultWindow / 20, 20, false},
+		{"page past result window", domain.MaxResultWindow/20 + 1, 20, 0, 0, true},
 	}
 
 	for _, tt := range tests {
@@ -191,6 +193,41 @@ func TestSearchRequest_Validate_QualityScoreFilters(t *testing.T) {
 	}
 }
 
+func TestSearchRequest_Validate_MinReputation(t *testing.T) {
+	t.Helper()
+
+	for _, tt := range []struct {
+		minReputation int
+		wantErr       bool
+	}{
+		{0, false},
+		{70, false},
+		{100, false},
+		{-1, true},
+		{101, true},
+	} {
+		req := &domain.SearchRequest{Query: "test", Filters: &domain.Filters{MinReputation: tt.minReputation}}
+		err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("min_reputation %d: Validate() error = %v, wantError %v", tt.minReputation, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSourceReputations_SourcesAtLeast(t *testing.T) {
+	t.Helper()
+
+	scores := domain.SourceReputations{"cbc": 82, "sudbury_star": 70, "spamblog": 12}
+
+	got := scores.SourcesAtLeast(70)
+	if len(got) != 2 || got[0] != "cbc" || got[1] != "sudbury_star" {
+		t.Errorf("SourcesAtLeast(70) = %v, want [cbc sudbury_star]", got)
+	}
+	if got := domain.SourceReputations(nil).SourcesAtLeast(1); got == nil || len(got) != 0 {
+		t.Errorf("nil scores should yield an empty, non-nil slice, got %#v", got)
+	}
+}
+
 func TestSearchRequest_Validate_DateFilters(t *testing.T) {
 	t.Helper()
 
@@ -231,10 +268,106 @@ func TestSearchRequest_Validate_DateFilters(t *testing.T) {
 	}
 }
 
+func TestSearchRequest_Validate_DateRanges(t *testing.T) {
+	t.Helper()
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+
+	t.Run("published range reversed", func(t *testing.T) {
+		req := &domain.SearchRequest{
+			Filters: &domain.Filters{PublishedFrom: &now, PublishedTo: &yesterday},
+		}
+		if err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err == nil {
+			t.Error("expected an error when published_from is after published_to")
+		}
+	})
+
+	t.Run("deprecated dates fold into crawled range", func(t *testing.T) {
+		req := &domain.SearchRequest{
+			Filters: &domain.Filters{FromDate: &yesterday, ToDate: &now},
+		}
+		if err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if req.Filters.CrawledFrom != &yesterday || req.Filters.CrawledTo != &now {
+			t.Errorf("crawled range = %v..%v, want from_date..to_date", req.Filters.CrawledFrom, req.Filters.CrawledTo)
+		}
+		if req.Filters.FromDate != nil || req.Filters.ToDate != nil {
+			t.Error("from_date/to_date should be cleared once folded")
+		}
+	})
+
+	t.Run("crawled range wins over deprecated dates", func(t *testing.T) {
+		req := &domain.SearchRequest{
+			Filters: &domain.Filters{FromDate: &yesterday, CrawledFrom: &now},
+		}
+		if err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if req.Filters.CrawledFrom != &now {
+			t.Errorf("crawled_from = %v, want %v", req.Filters.CrawledFrom, now)
+		}
+	})
+}
+
+func TestSearchRequest_Validate_Locations(t *testing.T) {
+	t.Helper()
+
+	req := &domain.SearchRequest{
+		Filters: &domain.Filters{
+			Cities:    []string{" Thunder  Bay ", "sudbury", ""},
+			Provinces: []string{"on", " qc"},
+		},
+	}
+	if err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := req.Filters.Cities; len(got) != 2 || got[0] != "thunder-bay" || got[1] != "sudbury" {
+		t.Errorf("cities = %v, want [thunder-bay sudbury]", got)
+	}
+	if got := req.Filters.Provinces; len(got) != 2 || got[0] != "ON" || got[1] != "QC" {
+		t.Errorf("provinces = %v, want [ON QC]", got)
+	}
+
+	req = &domain.SearchRequest{Filters: &domain.Filters{Provinces: []string{"Ontario"}}}
+	if err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength); err == nil {
+		t.Error("expected an error for a province name instead of a code")
+	}
+}
+
+func TestSearchRequest_Validate_Lang(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		lang    string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"en", "en", false},
+		{" FR ", "fr", false},
+		{"de", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			req := &domain.SearchRequest{Query: "test", Lang: tt.lang}
+			err := req.Validate(testMaxPageSize, testDefaultPageSize, testMaxQueryLength)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && req.Lang != tt.want {
+				t.Errorf("Lang = %q, want %q", req.Lang, tt.want)
+			}
+		})
+	}
+}
+
 func TestSearchRequest_Validate_SortField(t *testing.T) {
 	t.Helper()
 
-	validFields := []string{"relevance", "published_date", "quality_score", "crawled_at"}
+	validFields := []string{"relevance", "published_date", "quality_score", "crawled_at", "source_reputation"}
 
 	for _, field := range validFields {
 		t.Run(field, func(t *testing.T) {
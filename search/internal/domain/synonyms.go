@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxSynonymRuleLength bounds a rule's synonyms, well above any real rule.
+const maxSynonymRuleLength = 1024
+
+// ErrSynonymRuleNotFound is returned for a rule ID not in the synonyms set.
+var ErrSynonymRuleNotFound = errors.New("synonym rule not found")
+
+// synonymRuleIDPattern keeps rule IDs readable and safe in a URL path.
+var synonymRuleIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// SynonymRule is one rule of the search synonyms set, in the Solr format
+// Elasticsearch uses: "opp, ontario provincial police" makes the terms
+// equivalent, "sault => sault ste marie" expands the left side into the right.
+type SynonymRule struct {
+	ID       string `json:"id"`
+	Synonyms string `json:"synonyms"`
+}
+
+// SynonymRules is a page of the search synonyms set.
+type SynonymRules struct {
+	Set   string         `json:"set"`
+	Total int            `json:"total"`
+	Rules []*SynonymRule `json:"rules"`
+}
+
+// SynonymsRequest holds the paging of a synonyms set listing.
+type SynonymsRequest struct {
+	From int
+	Size int
+}
+
+// SynonymUpdate reports a rule change and the indexes whose search analyzers
+// were reloaded with it. Searches use the change once reloaded; no reindex
+// is needed, since synonyms apply at query time only.
+type SynonymUpdate struct {
+	Rule            *SynonymRule `json:"rule"`
+	Result          string       `json:"result"`
+	ReloadedIndexes []string     `json:"reloaded_indexes"`
+	// ReloadFailedShards counts shards whose analyzers failed to reload;
+	// they keep the previous synonyms until the next change or restart
+	ReloadFailedShards int `json:"reload_failed_shards"`
+}
+
+// ValidateSynonymRuleID checks that id is a valid rule ID.
+func ValidateSynonymRuleID(id string) error {
+	if !synonymRuleIDPattern.MatchString(id) {
+		return errors.New("synonym rule id must be 1-64 lowercase letters, digits, - or _, starting with a letter or digit")
+	}
+	return nil
+}
+
+// Validate checks the rule ID and that the synonyms form one equivalence
+// ("a, b") or explicit mapping ("a => b") rule.
+func (r *SynonymRule) Validate() error {
+	if err := ValidateSynonymRuleID(r.ID); err != nil {
+		return err
+	}
+
+	r.Synonyms = strings.TrimSpace(r.Synonyms)
+	switch {
+	case r.Synonyms == "":
+		return errors.New("synonyms are required")
+	case len(r.Synonyms) > maxSynonymRuleLength:
+		return fmt.Errorf("synonyms must be at most %d characters", maxSynonymRuleLength)
+	case strings.ContainsAny(r.Synonyms, "\r\n"):
+		return errors.New("synonyms must be a single rule, on one line")
+	}
+
+	if left, right, explicit := strings.Cut(r.Synonyms, "=>"); explicit {
+		if strings.Contains(right, "=>") {
+			return errors.New(`synonyms may contain "=>" only once`)
+		}
+		if !hasSynonymTerms(left) || !hasSynonymTerms(right) {
+			return errors.New(`synonyms need terms on both sides of "=>"`)
+		}
+		return nil
+	}
+
+	terms := 0
+	for _, term := range strings.Split(r.Synonyms, ",") {
+		if strings.TrimSpace(term) != "" {
+			terms++
+		}
+	}
+	if terms < 2 {
+		return errors.New(`synonyms need at least two comma-separated terms, or "a => b"`)
+	}
+	return nil
+}
+
+// hasSynonymTerms reports whether a comma-separated side of a rule names a term.
+func hasSynonymTerms(side string) bool {
+	for _, term := range strings.Split(side, ",") {
+		if strings.TrimSpace(term) != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+func TestSynonymRule_Validate(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name    string
+		rule    domain.SynonymRule
+		wantErr string
+	}{
+		{"equivalence", domain.SynonymRule{ID: "opp", Synonyms: "OPP, Ontario Provincial Police"}, ""},
+		{"explicit mapping", domain.SynonymRule{ID: "sault", Synonyms: " Sault => Sault Ste. Marie "}, ""},
+		{"uppercase id", domain.SynonymRule{ID: "OPP", Synonyms: "a, b"}, "rule id"},
+		{"id with slash", domain.SynonymRule{ID: "a/b", Synonyms: "a, b"}, "rule id"},
+		{"empty", domain.SynonymRule{ID: "x", Synonyms: "  "}, "required"},
+		{"single term", domain.SynonymRule{ID: "x", Synonyms: "opp"}, "at least two"},
+		{"empty terms", domain.SynonymRule{ID: "x", Synonyms: "opp, ,"}, "at least two"},
+		{"empty side", domain.SynonymRule{ID: "x", Synonyms: "opp =>"}, "both sides"},
+		{"two arrows", domain.SynonymRule{ID: "x", Synonyms: "a => b => c"}, "only once"},
+		{"several rules", domain.SynonymRule{ID: "x", Synonyms: "a, b\nc, d"}, "one line"},
+		{"too long", domain.SynonymRule{ID: "x", Synonyms: "a, " + strings.Repeat("b", 1100)}, "at most"},
+	}
+
+	for _, tt := range tests {
+		rule := tt.rule
+		err := rule.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want one containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSynonymRule_ValidateTrims(t *testing.T) {
+	t.Helper()
+
+	rule := domain.SynonymRule{ID: "sault", Synonyms: "  sault => sault ste marie "}
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if rule.Synonyms != "sault => sault ste marie" {
+		t.Errorf("Synonyms = %q, want it trimmed", rule.Synonyms)
+	}
+}
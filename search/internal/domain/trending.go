@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Kinds of trending terms. The classifier stores no named entities, so the
+// detected city stands in for them.
+const (
+	TrendingTopic = "topic"
+	TrendingCity  = "city"
+)
+
+// TrendingRequest holds parameters for the trending terms report.
+type TrendingRequest struct {
+	// Hours is the recent window terms are counted in.
+	Hours int
+	// BaselineDays is the window before it that sets each term's usual rate.
+	BaselineDays int
+	// Limit is the number of terms returned.
+	Limit int
+}
+
+// TrendingResponse lists the terms spiking in the recent window, fastest first.
+type TrendingResponse struct {
+	GeneratedAt  time.Time       `json:"generated_at"`
+	WindowHours  int             `json:"window_hours"`
+	BaselineDays int             `json:"baseline_days"`
+	Terms        []*TrendingTerm `json:"terms"`
+}
+
+// TrendingTerm is a topic or city indexed more often than usual. Expected is
+// the number of documents its baseline rate predicts for the window; Velocity
+// is how far Count exceeds it, in standard deviations of a Poisson count, so
+// a rare term going from 0 to 3 does not outrank a common one doubling.
+type TrendingTerm struct {
+	Term          string  `json:"term"`
+	Type          string  `json:"type"`
+	Count         int64   `json:"count"`
+	BaselineCount int64   `json:"baseline_count"`
+	Expected      float64 `json:"expected"`
+	Velocity      float64 `json:"velocity"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// WidgetItem is the simplified result shape returned to embedded partner widgets.
+// URL is the click-tracked link when click tracking is enabled.
+type WidgetItem struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Source      string     `json:"source"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Snippet     string     `json:"snippet,omitempty"`
+	Image       string     `json:"image,omitempty"`
+	Topics      []string   `json:"topics,omitempty"`
+}
+
+// WidgetResponse is the response of GET /api/v1/widget/search.
+type WidgetResponse struct {
+	Query      string       `json:"query"`
+	Items      []WidgetItem `json:"items"`
+	Total      int64        `json:"total"`
+	Page       int          `json:"page"`
+	TotalPages int          `json:"total_pages"`
+}
@@ -0,0 +1,7 @@
+package elasticsearch
+
+// ParseSynonymRules exposes parseSynonymRules for external tests.
+var ParseSynonymRules = parseSynonymRules
+
+// ParseSynonymUpdate exposes parseSynonymUpdate for external tests.
+var ParseSynonymUpdate = parseSynonymUpdate
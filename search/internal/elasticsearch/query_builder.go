@@ -3,13 +3,16 @@ package elasticsearch
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/esmapping"
 	"github.com/jonesrussell/north-cloud/search/internal/config"
 	"github.com/jonesrussell/north-cloud/search/internal/domain"
 )
 
 const (
 	maxQualityScoreValue = 100
+	maxReputationScore   = 100
 	recencyDecayFactor   = 0.5
 	qualityBoostFactor   = 0.01
 	topicsAggSize        = 20
@@ -21,8 +24,23 @@ const (
 	qualityRangeMax      = 101
 	recipeFacetSize      = 20
 	jobFacetSize         = 20
+
+	// Phrase suggester: corrections up to two misspelled words, from
+	// title words of at least three letters
+	suggestGramSize      = 3
+	suggestMaxErrors     = 2
+	suggestMinWordLength = 3
 )
 
+// SpellSuggestion names the phrase suggester whose best correction a search
+// response offers as did_you_mean.
+const SpellSuggestion = "did_you_mean"
+
+// reputationSortScript scores a hit by its source's reputation; sources the
+// classifier has not scored sort with params.missing.
+const reputationSortScript = "if (doc['source_name.keyword'].size() == 0) { return params.missing; } " +
+	"return params.scores.getOrDefault(doc['source_name.keyword'].value, params.missing);"
+
 // QueryBuilder builds Elasticsearch queries from search requests
 type QueryBuilder struct {
 	config *config.ElasticsearchConfig
@@ -37,11 +55,22 @@ func NewQueryBuilder(cfg *config.ElasticsearchConfig) *QueryBuilder {
 
 // Build constructs the complete Elasticsearch query
 func (qb *QueryBuilder) Build(req *domain.SearchRequest) map[string]any {
+	return qb.BuildWithReputations(req, nil)
+}
+
+// BuildWithReputations constructs the query, resolving the min_reputation
+// filter and source_reputation sort against scores.
+func (qb *QueryBuilder) BuildWithReputations(req *domain.SearchRequest, scores domain.SourceReputations) map[string]any {
 	query := map[string]any{
-		"query": qb.buildBoolQuery(req),
+		"query": qb.buildBoolQuery(req, scores),
 		"from":  (req.Pagination.Page - 1) * req.Pagination.Size,
 		"size":  req.Pagination.Size,
-		"sort":  qb.buildSort(req),
+		"sort":  qb.buildSort(req, scores),
+	}
+
+	// Favour quality, trusted, fresh documents among equally relevant ones
+	if qb.config.Ranking.Enabled && req.Sort != nil && req.Sort.Field == "relevance" {
+		query["query"] = qb.rank(query["query"], scores)
 	}
 
 	// Collapse on title to deduplicate syndicated wire stories
@@ -52,7 +81,7 @@ func (qb *QueryBuilder) Build(req *domain.SearchRequest) map[string]any {
 
 	// Add highlighting if enabled
 	if req.Options.IncludeHighlights && qb.config.HighlightEnabled {
-		query["highlight"] = qb.buildHighlight()
+		query["highlight"] = qb.buildHighlight(req.Lang)
 	}
 
 	// Add aggregations if enabled
@@ -71,18 +100,126 @@ func (qb *QueryBuilder) Build(req *domain.SearchRequest) map[string]any {
 			"published_date", "crawled_at",
 			"quality_score", "content_type", "topics",
 			"crime", "body", "raw_text", "og_image",
-			"rfp",
+			"rfp", "language",
 		}
 	}
 
+	// Spelling corrections for queries that may be misspelled
+	// Field queries are left out: a correction could not keep their syntax
+	if qb.config.SpellCheckEnabled && strings.TrimSpace(req.Query) != "" && req.FieldQuery == nil {
+		query["suggest"] = buildSpellSuggest(req.Query)
+	}
+
 	// Enable total hits tracking
 	query["track_total_hits"] = true
 
 	return query
 }
 
+// Personalize re-ranks a built query by the session's engagement: the score of
+// a hit from an affine source or topic is multiplied by 1 plus the boosts it
+// matches, capped at the strongest source and topic boost combined. Only
+// relevance-sorted queries are changed, since other sorts ignore the score.
+func (qb *QueryBuilder) Personalize(query map[string]any, req *domain.SearchRequest, profile *domain.EngagementProfile) bool {
+	if profile.Empty() || req.Sort == nil || req.Sort.Field != "relevance" {
+		return false
+	}
+
+	functions := []any{map[string]any{"weight": 1}}
+	maxBoost := 1.0
+	for i, affinity := range profile.Sources {
+		functions = append(functions, affinityFunction("source_name.keyword", affinity))
+		if i == 0 {
+			maxBoost += affinity.Boost
+		}
+	}
+	for i, affinity := range profile.Topics {
+		functions = append(functions, affinityFunction("topics.keyword", affinity))
+		if i == 0 {
+			maxBoost += affinity.Boost
+		}
+	}
+
+	query["query"] = map[string]any{
+		"function_score": map[string]any{
+			"query":      query["query"],
+			"functions":  functions,
+			"score_mode": "sum",
+			"boost_mode": "multiply",
+			"max_boost":  maxBoost,
+		},
+	}
+	return true
+}
+
+// rank multiplies the relevance of query's hits by 1 plus the configured
+// weights of their quality, source reputation, and freshness, each scaled to
+// 0-1. Reputation is skipped until the classifier's scores have loaded.
+func (qb *QueryBuilder) rank(query any, scores domain.SourceReputations) map[string]any {
+	ranking := qb.config.Ranking
+	functions := []any{map[string]any{"weight": 1}}
+
+	if ranking.QualityWeight > 0 {
+		functions = append(functions, map[string]any{
+			"field_value_factor": map[string]any{
+				"field":   "quality_score",
+				"factor":  1.0 / maxQualityScoreValue,
+				"missing": 0,
+			},
+			"weight": ranking.QualityWeight,
+		})
+	}
+	if ranking.ReputationWeight > 0 && len(scores) > 0 {
+		functions = append(functions, map[string]any{
+			"script_score": map[string]any{
+				"script": map[string]any{
+					"lang":   "painless",
+					"source": reputationSortScript,
+					"params": map[string]any{"scores": scores, "missing": 0},
+				},
+			},
+			"weight": ranking.ReputationWeight / maxReputationScore,
+		})
+	}
+	if ranking.FreshnessWeight > 0 {
+		functions = append(functions, map[string]any{
+			"gauss": map[string]any{
+				"crawled_at": map[string]any{
+					"origin": "now",
+					"scale":  esMinutes(ranking.FreshnessScale),
+					"offset": esMinutes(ranking.FreshnessOffset),
+					"decay":  ranking.FreshnessDecay,
+				},
+			},
+			"weight": ranking.FreshnessWeight,
+		})
+	}
+
+	return map[string]any{
+		"function_score": map[string]any{
+			"query":      query,
+			"functions":  functions,
+			"score_mode": "sum",
+			"boost_mode": "multiply",
+		},
+	}
+}
+
+// esMinutes formats d as an Elasticsearch time unit in whole minutes.
+func esMinutes(d time.Duration) string {
+	return fmt.Sprintf("%dm", int64(d/time.Minute))
+}
+
+// affinityFunction adds an affinity's boost to hits whose field holds its value.
+func affinityFunction(field string, affinity domain.Affinity) map[string]any {
+	return map[string]any{
+		"filter": map[string]any{"term": map[string]any{field: affinity.Value}},
+		"weight": affinity.Boost,
+	}
+}
+
 // buildBoolQuery constructs the bool query with must, filter, and should clauses
-func (qb *QueryBuilder) buildBoolQuery(req *domain.SearchRequest) map[string]any {
+func (qb *QueryBuilder) buildBoolQuery(req *domain.SearchRequest, scores domain.SourceReputations) map[string]any {
 	boolQuery := map[string]any{
 		"must":   []any{},
 		"filter": []any{},
@@ -91,46 +228,60 @@ func (qb *QueryBuilder) buildBoolQuery(req *domain.SearchRequest) map[string]any
 
 	// Multi-match query for full-text search.
 	// Treat "*" as match-all (empty must clause); multi-match doesn't interpret "*" as wildcard.
-	if req.Query != "" && req.Query != "*" {
-		boolQuery["must"] = []any{
-			qb.buildMultiMatchQuery(req.Query),
-		}
+	text := req.Query
+	if req.FieldQuery != nil {
+		text = req.FieldQuery.Text
+	}
+	must := []any{}
+	if text != "" && text != "*" {
+		must = append(must, qb.buildMultiMatchQuery(text, req.Lang))
 	}
 
 	// Add filters
 	filters := qb.buildFilters(req.Filters)
+	if req.FieldQuery != nil {
+		fieldMust, fieldFilters, mustNot := qb.buildFieldClauses(req.FieldQuery.Clauses, req.Lang)
+		must = append(must, fieldMust...)
+		filters = append(filters, fieldFilters...)
+		if len(mustNot) > 0 {
+			boolQuery["must_not"] = mustNot
+		}
+	}
+	boolQuery["must"] = must
+	if langFilter := buildLanguageFilter(req.Lang); langFilter != nil {
+		filters = append(filters, langFilter)
+	}
+	if req.Filters != nil && req.Filters.MinReputation > 0 {
+		// Resolved to source names here so Elasticsearch needs no reputation field
+		filters = append(filters, map[string]any{
+			"terms": map[string]any{
+				"source_name.keyword": scores.SourcesAtLeast(req.Filters.MinReputation),
+			},
+		})
+	}
 	if len(filters) > 0 {
 		boolQuery["filter"] = filters
 	}
 
-	// Add boosting for recency and quality
-	shouldClauses := qb.buildBoosts()
-	if len(shouldClauses) > 0 {
-		boolQuery["should"] = shouldClauses
+	// Add boosting for recency and quality, unless ranking scores them instead
+	if !qb.config.Ranking.Enabled {
+		boolQuery["should"] = qb.buildBoosts()
 	}
 
 	return map[string]any{"bool": boolQuery}
 }
 
-// buildMultiMatchQuery creates a multi-match query with field boosting
-func (qb *QueryBuilder) buildMultiMatchQuery(query string) map[string]any {
-	boost := qb.config.DefaultBoost
-
+// buildMultiMatchQuery creates a multi-match query with field boosting.
+// French queries match the French-analyzed subfields of title and body text.
+func (qb *QueryBuilder) buildMultiMatchQuery(query, lang string) map[string]any {
 	// Count words in query to adjust minimum_should_match
 	words := len(strings.Fields(query))
 
 	// For single-word queries, don't use minimum_should_match
 	// For multi-word queries, use a more lenient setting
 	multiMatch := map[string]any{
-		"query": query,
-		"fields": []string{
-			"title^" + floatToString(boost.Title),
-			"og_title^" + floatToString(boost.OGTitle),
-			"body^" + floatToString(boost.RawText),
-			"raw_text^" + floatToString(boost.RawText),
-			"og_description^" + floatToString(boost.OGDescription),
-			"meta_description^" + floatToString(boost.MetaDescription),
-		},
+		"query":     query,
+		"fields":    qb.textFields(lang),
 		"type":      "best_fields",
 		"operator":  "or",
 		"fuzziness": "AUTO",
@@ -146,6 +297,115 @@ func (qb *QueryBuilder) buildMultiMatchQuery(query string) map[string]any {
 	}
 }
 
+// textFields are the boosted fields queries are matched against.
+func (qb *QueryBuilder) textFields(lang string) []string {
+	boost := qb.config.DefaultBoost
+	title, body, rawText := "title", "body", "raw_text"
+	if lang == domain.LanguageFrench {
+		title, body, rawText = frenchField(title), frenchField(body), frenchField(rawText)
+	}
+	return []string{
+		title + "^" + floatToString(boost.Title),
+		"og_title^" + floatToString(boost.OGTitle),
+		body + "^" + floatToString(boost.RawText),
+		rawText + "^" + floatToString(boost.RawText),
+		"og_description^" + floatToString(boost.OGDescription),
+		"meta_description^" + floatToString(boost.MetaDescription),
+	}
+}
+
+// queryFieldKeywords are the keyword fields of the query syntax fields
+// matched exactly, like the equivalent filters.
+var queryFieldKeywords = map[string]string{
+	domain.QueryFieldSource:   "source_name.keyword",
+	domain.QueryFieldTopic:    "topics.keyword",
+	domain.QueryFieldType:     "content_type.keyword",
+	domain.QueryFieldCity:     "location.city",
+	domain.QueryFieldProvince: "location.province",
+}
+
+// buildFieldClauses turns the clauses of a field query into bool clauses:
+// exact field values filter, title and text terms score, and negated clauses
+// exclude. Text clauses match the fields plain queries do, without fuzziness
+// so an exclusion does not remove near-misses of the word.
+func (qb *QueryBuilder) buildFieldClauses(clauses []domain.QueryClause, lang string) (must, filter, mustNot []any) {
+	for _, clause := range clauses {
+		var query map[string]any
+		if field, ok := queryFieldKeywords[clause.Field]; ok {
+			query = map[string]any{"term": map[string]any{field: clause.Value}}
+			if !clause.Negate {
+				filter = append(filter, query)
+				continue
+			}
+		} else {
+			query = qb.buildTextClause(clause, lang)
+		}
+
+		if clause.Negate {
+			mustNot = append(mustNot, query)
+		} else {
+			must = append(must, query)
+		}
+	}
+	return must, filter, mustNot
+}
+
+// buildTextClause matches a title: clause against the title, or a bare
+// phrase or excluded word against the text fields of plain queries.
+func (qb *QueryBuilder) buildTextClause(clause domain.QueryClause, lang string) map[string]any {
+	if clause.Field == domain.QueryFieldTitle {
+		title := "title"
+		if lang == domain.LanguageFrench {
+			title = frenchField(title)
+		}
+		if clause.Phrase {
+			return map[string]any{"match_phrase": map[string]any{title: clause.Value}}
+		}
+		return map[string]any{"match": map[string]any{title: map[string]any{"query": clause.Value, "operator": "and"}}}
+	}
+
+	matchType := "best_fields"
+	if clause.Phrase {
+		matchType = "phrase"
+	}
+	return map[string]any{
+		"multi_match": map[string]any{
+			"query":    clause.Value,
+			"fields":   qb.textFields(lang),
+			"type":     matchType,
+			"operator": "and",
+		},
+	}
+}
+
+// frenchField names the French-analyzed subfield of a text field.
+func frenchField(field string) string {
+	return field + "." + esmapping.FrenchSubfield
+}
+
+// buildLanguageFilter restricts a search to documents in lang. Documents
+// indexed before language detection have no language and count as English.
+func buildLanguageFilter(lang string) map[string]any {
+	switch lang {
+	case domain.LanguageFrench:
+		return map[string]any{"term": map[string]any{"language": lang}}
+	case domain.LanguageEnglish:
+		return map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{"term": map[string]any{"language": lang}},
+					map[string]any{"bool": map[string]any{
+						"must_not": map[string]any{"exists": map[string]any{"field": "language"}},
+					}},
+				},
+				"minimum_should_match": 1,
+			},
+		}
+	default:
+		return nil
+	}
+}
+
 // buildFilters constructs filter clauses.
 // Validate() initializes req.Filters so Build() is safe; nil filters return no clauses.
 func (qb *QueryBuilder) buildFilters(filters *domain.Filters) []any {
@@ -214,22 +474,9 @@ func (qb *QueryBuilder) buildFilters(filters *domain.Filters) []any {
 		})
 	}
 
-	// Date range filter - use crawled_at since published_date may not exist in all documents
-	if filters.FromDate != nil || filters.ToDate != nil {
-		dateRange := map[string]any{}
-		if filters.FromDate != nil {
-			dateRange["gte"] = filters.FromDate.Format("2006-01-02T15:04:05Z07:00")
-		}
-		if filters.ToDate != nil {
-			dateRange["lte"] = filters.ToDate.Format("2006-01-02T15:04:05Z07:00")
-		}
-		// Use crawled_at as it's more reliable (always exists)
-		result = append(result, map[string]any{
-			"range": map[string]any{
-				"crawled_at": dateRange,
-			},
-		})
-	}
+	// Date and location filters (extracted to stay under funlen limit)
+	result = append(result, buildDateRangeFilters(filters)...)
+	result = append(result, buildLocationFilters(filters)...)
 
 	// Recipe and job filters (extracted to stay under funlen limit)
 	result = append(result, qb.buildRecipeFilters(filters)...)
@@ -239,6 +486,54 @@ func (qb *QueryBuilder) buildFilters(filters *domain.Filters) []any {
 	return result
 }
 
+// buildDateRangeFilters constructs range clauses for published_date and crawled_at.
+// The deprecated from_date/to_date are folded into the crawled range by Validate().
+func buildDateRangeFilters(filters *domain.Filters) []any {
+	var result []any
+	if dateRange := rangeBounds(filters.PublishedFrom, filters.PublishedTo); dateRange != nil {
+		result = append(result, map[string]any{
+			"range": map[string]any{"published_date": dateRange},
+		})
+	}
+	if dateRange := rangeBounds(filters.CrawledFrom, filters.CrawledTo); dateRange != nil {
+		result = append(result, map[string]any{
+			"range": map[string]any{"crawled_at": dateRange},
+		})
+	}
+	return result
+}
+
+// rangeBounds returns the gte/lte bounds of an inclusive date range, or nil when both ends are open.
+func rangeBounds(from, to *time.Time) map[string]any {
+	if from == nil && to == nil {
+		return nil
+	}
+	bounds := map[string]any{}
+	if from != nil {
+		bounds["gte"] = from.Format(time.RFC3339Nano)
+	}
+	if to != nil {
+		bounds["lte"] = to.Format(time.RFC3339Nano)
+	}
+	return bounds
+}
+
+// buildLocationFilters constructs filter clauses for the classifier's location fields
+func buildLocationFilters(filters *domain.Filters) []any {
+	var result []any
+	if len(filters.Cities) > 0 {
+		result = append(result, map[string]any{
+			"terms": map[string]any{"location.city": filters.Cities},
+		})
+	}
+	if len(filters.Provinces) > 0 {
+		result = append(result, map[string]any{
+			"terms": map[string]any{"location.province": filters.Provinces},
+		})
+	}
+	return result
+}
+
 // buildRecipeFilters constructs filter clauses for recipe fields
 func (qb *QueryBuilder) buildRecipeFilters(filters *domain.Filters) []any {
 	var result []any
@@ -386,7 +681,7 @@ func (qb *QueryBuilder) buildBoosts() []any {
 }
 
 // buildSort constructs sort criteria
-func (qb *QueryBuilder) buildSort(req *domain.SearchRequest) []any {
+func (qb *QueryBuilder) buildSort(req *domain.SearchRequest, scores domain.SourceReputations) []any {
 	var sortCriteria []any
 
 	switch req.Sort.Field {
@@ -416,6 +711,21 @@ func (qb *QueryBuilder) buildSort(req *domain.SearchRequest) []any {
 				"order": req.Sort.Order,
 			},
 		})
+	case domain.SortSourceReputation:
+		if scores == nil {
+			scores = domain.SourceReputations{}
+		}
+		sortCriteria = append(sortCriteria, map[string]any{
+			"_script": map[string]any{
+				"type": "number",
+				"script": map[string]any{
+					"lang":   "painless",
+					"source": reputationSortScript,
+					"params": map[string]any{"scores": scores, "missing": 0},
+				},
+				"order": req.Sort.Order,
+			},
+		})
 	default:
 		// Default to relevance if field is unknown
 		sortCriteria = append(sortCriteria, map[string]any{
@@ -437,24 +747,75 @@ func (qb *QueryBuilder) buildSort(req *domain.SearchRequest) []any {
 	return sortCriteria
 }
 
-// buildHighlight constructs highlight configuration
-func (qb *QueryBuilder) buildHighlight() map[string]any {
+// buildHighlight constructs highlight configuration. The html encoder escapes
+// the document text, so the highlight tags are the only markup in fragments.
+// French searches highlight the parent fields with the matches of their
+// French subfields, so fragments stay keyed by title, body and raw_text.
+func (qb *QueryBuilder) buildHighlight(lang string) map[string]any {
+	tag := qb.config.HighlightTag
+	if tag == "" {
+		tag = "em"
+	}
+
+	fields := map[string]any{
+		"title": map[string]any{
+			"number_of_fragments": 1,
+		},
+		"body": map[string]any{
+			"fragment_size":       qb.config.HighlightFragmentSize,
+			"number_of_fragments": qb.config.HighlightMaxFragments,
+		},
+		"raw_text": map[string]any{
+			"fragment_size":       qb.config.HighlightFragmentSize,
+			"number_of_fragments": qb.config.HighlightMaxFragments,
+		},
+	}
+	if lang == domain.LanguageFrench {
+		for field, settings := range fields {
+			settings.(map[string]any)["matched_fields"] = []string{field, frenchField(field)}
+		}
+	}
+
 	return map[string]any{
-		"fields": map[string]any{
-			"title": map[string]any{
-				"number_of_fragments": 1,
-			},
-			"body": map[string]any{
-				"fragment_size":       qb.config.HighlightFragmentSize,
-				"number_of_fragments": qb.config.HighlightMaxFragments,
-			},
-			"raw_text": map[string]any{
-				"fragment_size":       qb.config.HighlightFragmentSize,
-				"number_of_fragments": qb.config.HighlightMaxFragments,
+		"encoder":   "html",
+		"fields":    fields,
+		"pre_tags":  []string{"<" + tag + ">"},
+		"post_tags": []string{"</" + tag + ">"},
+	}
+}
+
+// buildSpellSuggest corrects the query against the unstemmed title shingles.
+// The collate query keeps only corrections whose words all appear in some
+// title, so a suggestion always finds documents.
+func buildSpellSuggest(query string) map[string]any {
+	field := "title." + esmapping.SuggestSubfield
+	return map[string]any{
+		"text": query,
+		SpellSuggestion: map[string]any{
+			"phrase": map[string]any{
+				"field":      field,
+				"size":       1,
+				"gram_size":  suggestGramSize,
+				"max_errors": suggestMaxErrors,
+				"direct_generator": []any{
+					map[string]any{
+						"field":           field,
+						"suggest_mode":    "always",
+						"min_word_length": suggestMinWordLength,
+					},
+				},
+				"collate": map[string]any{
+					"query": map[string]any{
+						"source": map[string]any{
+							"match": map[string]any{
+								"title": map[string]any{"query": "{{suggestion}}", "operator": "and"},
+							},
+						},
+					},
+					"prune": false,
+				},
 			},
 		},
-		"pre_tags":  []string{"<em>"},
-		"post_tags": []string{"</em>"},
 	}
 }
 
@@ -1,7 +1,11 @@
 package elasticsearch_test
 
 import (
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jonesrussell/north-cloud/search/internal/config"
 	"github.com/jonesrussell/north-cloud/search/internal/domain"
@@ -206,8 +210,120 @@ func TestQueryBuilder_Build_WithHighlights(t *testing.T) {
 	}
 
 	// Should have highlight configuration
-	if _, ok := query["highlight"]; !ok {
-		t.Error("Build() with highlights enabled should have 'highlight' field")
+	highlight, ok := query["highlight"].(map[string]any)
+	if !ok {
+		t.Fatal("Build() with highlights enabled should have 'highlight' field")
+	}
+	if highlight["encoder"] != "html" {
+		t.Errorf("expected the html encoder to escape document text, got %v", highlight["encoder"])
+	}
+}
+
+func TestQueryBuilder_Build_HighlightTag(t *testing.T) {
+	t.Helper()
+
+	cfg := getTestConfig()
+	cfg.HighlightTag = "mark"
+	qb := elasticsearch.NewQueryBuilder(cfg)
+
+	req := getDefaultSearchRequest("test")
+	req.Options = &domain.Options{IncludeHighlights: true}
+
+	highlight := qb.Build(req)["highlight"].(map[string]any)
+	if pre := highlight["pre_tags"].([]string); len(pre) != 1 || pre[0] != "<mark>" {
+		t.Errorf("pre_tags = %v, want [<mark>]", pre)
+	}
+	if post := highlight["post_tags"].([]string); len(post) != 1 || post[0] != "</mark>" {
+		t.Errorf("post_tags = %v, want [</mark>]", post)
+	}
+}
+
+func TestQueryBuilder_Build_SpellSuggest(t *testing.T) {
+	t.Helper()
+
+	cfg := getTestConfig()
+	if _, ok := elasticsearch.NewQueryBuilder(cfg).Build(getDefaultSearchRequest("sudbry"))["suggest"]; ok {
+		t.Error("expected no suggester while spell check is disabled")
+	}
+
+	cfg.SpellCheckEnabled = true
+	qb := elasticsearch.NewQueryBuilder(cfg)
+	suggest, ok := qb.Build(getDefaultSearchRequest("sudbry"))["suggest"].(map[string]any)
+	if !ok {
+		t.Fatal("expected a suggester when spell check is enabled")
+	}
+	if suggest["text"] != "sudbry" {
+		t.Errorf("suggest text = %v, want sudbry", suggest["text"])
+	}
+	phrase := suggest[elasticsearch.SpellSuggestion].(map[string]any)["phrase"].(map[string]any)
+	if phrase["field"] != "title.suggest" {
+		t.Errorf("phrase field = %v, want title.suggest", phrase["field"])
+	}
+	if _, ok = phrase["collate"]; !ok {
+		t.Error("expected suggestions to be collated against titles")
+	}
+
+	if _, ok = qb.Build(getDefaultSearchRequest("  "))["suggest"]; ok {
+		t.Error("expected no suggester for an empty query")
+	}
+}
+
+func TestQueryBuilder_Build_French(t *testing.T) {
+	t.Helper()
+
+	cfg := getTestConfig()
+	qb := elasticsearch.NewQueryBuilder(cfg)
+
+	req := getDefaultSearchRequest("élections municipales")
+	req.Lang = domain.LanguageFrench
+	req.Options = &domain.Options{IncludeHighlights: true}
+	query := qb.Build(req)
+
+	boolQuery := getBoolQuery(t, query)
+	must := boolQuery["must"].([]any)
+	fields := must[0].(map[string]any)["multi_match"].(map[string]any)["fields"].([]string)
+	for _, want := range []string{"title.fr^", "body.fr^", "raw_text.fr^"} {
+		if !slices.ContainsFunc(fields, func(f string) bool { return strings.HasPrefix(f, want) }) {
+			t.Errorf("fields %v missing %s", fields, want)
+		}
+	}
+
+	filters := getFilterSlice(t, boolQuery)
+	if !slices.ContainsFunc(filters, func(clause any) bool {
+		return reflect.DeepEqual(clause, map[string]any{"term": map[string]any{"language": "fr"}})
+	}) {
+		t.Errorf("filters %v missing language term", filters)
+	}
+
+	highlightFields := query["highlight"].(map[string]any)["fields"].(map[string]any)
+	body := highlightFields["body"].(map[string]any)
+	if got := body["matched_fields"]; !reflect.DeepEqual(got, []string{"body", "body.fr"}) {
+		t.Errorf("body matched_fields = %v, want [body body.fr]", got)
+	}
+}
+
+func TestQueryBuilder_Build_English(t *testing.T) {
+	t.Helper()
+
+	cfg := getTestConfig()
+	qb := elasticsearch.NewQueryBuilder(cfg)
+
+	req := getDefaultSearchRequest("council elections")
+	req.Lang = domain.LanguageEnglish
+	boolQuery := getBoolQuery(t, qb.Build(req))
+
+	fields := boolQuery["must"].([]any)[0].(map[string]any)["multi_match"].(map[string]any)["fields"].([]string)
+	if slices.ContainsFunc(fields, func(f string) bool { return strings.Contains(f, ".fr") }) {
+		t.Errorf("English search should not query French subfields: %v", fields)
+	}
+
+	// English also matches documents indexed before language detection
+	filters := getFilterSlice(t, boolQuery)
+	if !slices.ContainsFunc(filters, func(clause any) bool {
+		boolClause, ok := clause.(map[string]any)["bool"].(map[string]any)
+		return ok && len(boolClause["should"].([]any)) == 2
+	}) {
+		t.Errorf("filters %v missing the language term-or-missing clause", filters)
 	}
 }
 
@@ -349,6 +465,89 @@ func TestQueryBuilder_Build_JobFilters(t *testing.T) {
 	assertFilterRangeHasOp(t, filters, "job.salary_min", "gte")
 }
 
+func TestQueryBuilder_Build_DateAndLocationFilters(t *testing.T) {
+	t.Helper()
+
+	cfg := getTestConfig()
+	qb := elasticsearch.NewQueryBuilder(cfg)
+
+	publishedFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	publishedTo := time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)
+	crawledFrom := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	req := getDefaultSearchRequest("mine expansion")
+	req.Filters = &domain.Filters{
+		PublishedFrom: &publishedFrom,
+		PublishedTo:   &publishedTo,
+		CrawledFrom:   &crawledFrom,
+		Cities:        []string{"sudbury", "thunder-bay"},
+		Provinces:     []string{"ON"},
+	}
+
+	boolQuery := getBoolQuery(t, qb.Build(req))
+	if _, hasMust := boolQuery["must"]; !hasMust {
+		t.Error("text query should stay in the must clause alongside the filters")
+	}
+	filters := getFilterSlice(t, boolQuery)
+
+	assertFilterRangeHasOp(t, filters, "published_date", "gte")
+	assertFilterRangeHasOp(t, filters, "published_date", "lte")
+	assertFilterRangeHasOp(t, filters, "crawled_at", "gte")
+	assertFilterTerms(t, filters, "location.city", []string{"sudbury", "thunder-bay"})
+	assertFilterTerms(t, filters, "location.province", []string{"ON"})
+
+	for _, clause := range filters {
+		r, ok := clause.(map[string]any)["range"].(map[string]any)
+		if !ok {
+			continue
+		}
+		if crawled, isCrawled := r["crawled_at"].(map[string]any); isCrawled {
+			if _, hasLte := crawled["lte"]; hasLte {
+				t.Error("open-ended crawled range should not set lte")
+			}
+		}
+	}
+}
+
+func TestQueryBuilder_BuildWithReputations_MinReputation(t *testing.T) {
+	t.Helper()
+
+	qb := elasticsearch.NewQueryBuilder(getTestConfig())
+	req := getDefaultSearchRequest("test")
+	req.Filters = &domain.Filters{MinReputation: 60}
+
+	query := qb.BuildWithReputations(req, domain.SourceReputations{"cbc": 82, "spamblog": 12})
+
+	filters := getFilterSlice(t, getBoolQuery(t, query))
+	assertFilterTerms(t, filters, "source_name.keyword", []string{"cbc"})
+}
+
+func TestQueryBuilder_BuildWithReputations_Sort(t *testing.T) {
+	t.Helper()
+
+	qb := elasticsearch.NewQueryBuilder(getTestConfig())
+	req := getDefaultSearchRequest("test")
+	req.Sort = &domain.Sort{Field: domain.SortSourceReputation, Order: "desc"}
+	scores := domain.SourceReputations{"cbc": 82}
+
+	query := qb.BuildWithReputations(req, scores)
+
+	sortCriteria, ok := query["sort"].([]any)
+	if !ok || len(sortCriteria) != 2 {
+		t.Fatalf("expected script sort plus _score tiebreak, got %v", query["sort"])
+	}
+	scriptSort, ok := sortCriteria[0].(map[string]any)["_script"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected _script sort, got %v", sortCriteria[0])
+	}
+	if scriptSort["order"] != "desc" || scriptSort["type"] != "number" {
+		t.Errorf("unexpected script sort %v", scriptSort)
+	}
+	params := scriptSort["script"].(map[string]any)["params"].(map[string]any)
+	if got, _ := params["scores"].(domain.SourceReputations); got["cbc"] != 82 {
+		t.Errorf("expected scores in script params, got %v", params["scores"])
+	}
+}
+
 func getBoolQuery(t *testing.T, query map[string]any) map[string]any {
 	t.Helper()
 	queryField, ok := query["query"].(map[string]any)
@@ -566,3 +765,201 @@ func TestQueryBuilder_Build_WithFacets_IncludesRecipeAndJobAggs(t *testing.T) {
 		}
 	}
 }
+
+func getRankingConfig() *config.ElasticsearchConfig {
+	cfg := getTestConfig()
+	cfg.Ranking = config.RankingConfig{
+		Enabled:          true,
+		QualityWeight:    0.5,
+		ReputationWeight: 0.4,
+		FreshnessWeight:  1,
+		FreshnessScale:   7 * 24 * time.Hour,
+		FreshnessOffset:  24 * time.Hour,
+		FreshnessDecay:   0.5,
+	}
+	return cfg
+}
+
+func TestQueryBuilder_Build_Ranking(t *testing.T) {
+	t.Helper()
+
+	qb := elasticsearch.NewQueryBuilder(getRankingConfig())
+	scores := domain.SourceReputations{"cbc": 90}
+	query := qb.BuildWithReputations(getDefaultSearchRequest("wildfire"), scores)
+
+	functionScore, ok := query["query"].(map[string]any)["function_score"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected function_score query, got %v", query["query"])
+	}
+	if functionScore["boost_mode"] != "multiply" || functionScore["score_mode"] != "sum" {
+		t.Errorf("unexpected modes %v / %v", functionScore["boost_mode"], functionScore["score_mode"])
+	}
+	wrapped, ok := functionScore["query"].(map[string]any)["bool"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected the bool query to be wrapped, got %v", functionScore["query"])
+	}
+	if should := wrapped["should"].([]any); len(should) != 0 {
+		t.Errorf("ranking should replace the recency and quality should clauses, got %v", should)
+	}
+
+	functions := functionScore["functions"].([]any)
+	if len(functions) != 4 {
+		t.Fatalf("expected base weight plus quality, reputation, and freshness, got %d", len(functions))
+	}
+	reputation := functions[2].(map[string]any)
+	if weight, _ := reputation["weight"].(float64); weight < 0.0039 || weight > 0.0041 {
+		t.Errorf("reputation weight = %v, want 0.4 per 100 points", reputation["weight"])
+	}
+	freshness := functions[3].(map[string]any)["gauss"].(map[string]any)["crawled_at"].(map[string]any)
+	if freshness["scale"] != "10080m" || freshness["offset"] != "1440m" {
+		t.Errorf("unexpected freshness curve %v", freshness)
+	}
+}
+
+func TestQueryBuilder_Build_RankingSkipped(t *testing.T) {
+	t.Helper()
+
+	cfg := getRankingConfig()
+	cfg.Ranking.QualityWeight = 0
+	qb := elasticsearch.NewQueryBuilder(cfg)
+
+	// Without reputations or a quality weight only freshness is left
+	query := qb.Build(getDefaultSearchRequest("wildfire"))
+	functionScore := query["query"].(map[string]any)["function_score"].(map[string]any)
+	if functions := functionScore["functions"].([]any); len(functions) != 2 {
+		t.Errorf("expected base weight plus freshness, got %v", functions)
+	}
+
+	req := getDefaultSearchRequest("wildfire")
+	req.Sort = &domain.Sort{Field: "crawled_at", Order: "desc"}
+	if _, ok := qb.Build(req)["query"].(map[string]any)["bool"]; !ok {
+		t.Error("date-sorted queries should not be ranked")
+	}
+}
+
+func TestQueryBuilder_Personalize(t *testing.T) {
+	t.Helper()
+
+	qb := elasticsearch.NewQueryBuilder(getTestConfig())
+	req := getDefaultSearchRequest("test")
+	query := qb.Build(req)
+	original := query["query"]
+
+	profile := &domain.EngagementProfile{
+		Sources: []domain.Affinity{{Value: "sudbury_star", Boost: 0.5}, {Value: "cbc", Boost: 0.25}},
+		Topics:  []domain.Affinity{{Value: "crime", Boost: 0.3}},
+	}
+	if !qb.Personalize(query, req, profile) {
+		t.Fatal("expected a relevance-sorted query to be personalized")
+	}
+
+	functionScore, ok := query["query"].(map[string]any)["function_score"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected function_score query, got %v", query["query"])
+	}
+	if functionScore["boost_mode"] != "multiply" || functionScore["score_mode"] != "sum" {
+		t.Errorf("unexpected modes %v / %v", functionScore["boost_mode"], functionScore["score_mode"])
+	}
+	if maxBoost, _ := functionScore["max_boost"].(float64); maxBoost < 1.79 || maxBoost > 1.81 {
+		t.Errorf("max_boost = %v, want 1.8", functionScore["max_boost"])
+	}
+	functions, _ := functionScore["functions"].([]any)
+	if len(functions) != 4 {
+		t.Fatalf("expected base weight plus 3 affinity functions, got %d", len(functions))
+	}
+	topic := functions[3].(map[string]any)
+	term := topic["filter"].(map[string]any)["term"].(map[string]any)
+	if term["topics.keyword"] != "crime" || topic["weight"] != 0.3 {
+		t.Errorf("unexpected topic function %v", topic)
+	}
+	if !reflect.DeepEqual(functionScore["query"], original) {
+		t.Errorf("expected the original query to be wrapped, got %v", functionScore["query"])
+	}
+}
+
+func TestQueryBuilder_Personalize_Skipped(t *testing.T) {
+	t.Helper()
+
+	qb := elasticsearch.NewQueryBuilder(getTestConfig())
+	profile := &domain.EngagementProfile{Sources: []domain.Affinity{{Value: "cbc", Boost: 0.5}}}
+
+	req := getDefaultSearchRequest("test")
+	req.Sort = &domain.Sort{Field: "published_date", Order: "desc"}
+	query := qb.Build(req)
+	if qb.Personalize(query, req, profile) {
+		t.Error("date-sorted queries should not be personalized")
+	}
+
+	req = getDefaultSearchRequest("test")
+	query = qb.Build(req)
+	if qb.Personalize(query, req, &domain.EngagementProfile{Clicks: 3}) {
+		t.Error("an empty profile should leave the query unchanged")
+	}
+	if _, ok := query["query"].(map[string]any)["bool"]; !ok {
+		t.Errorf("expected the bool query to be untouched, got %v", query["query"])
+	}
+}
+
+func TestQueryBuilder_Build_FieldQuery(t *testing.T) {
+	t.Helper()
+
+	req := getDefaultSearchRequest(`source:naiz.eus topic:crime title:"armed robbery" police -sports`)
+	fieldQuery, err := domain.ParseFieldQuery(req.Query)
+	if err != nil {
+		t.Fatalf("ParseFieldQuery: %v", err)
+	}
+	req.FieldQuery = fieldQuery
+	req.Filters = nil
+
+	boolQuery := elasticsearch.NewQueryBuilder(getTestConfig()).Build(req)["query"].(map[string]any)["bool"].(map[string]any)
+
+	must := boolQuery["must"].([]any)
+	if len(must) != 2 {
+		t.Fatalf("must = %v, want the text match and the title phrase", must)
+	}
+	if text := must[0].(map[string]any)["multi_match"].(map[string]any)["query"]; text != "police" {
+		t.Errorf("text query = %v, want police", text)
+	}
+	phrase := must[1].(map[string]any)["match_phrase"].(map[string]any)
+	if phrase["title"] != "armed robbery" {
+		t.Errorf("title phrase = %v, want armed robbery", phrase)
+	}
+
+	filters := boolQuery["filter"].([]any)
+	wantFilters := []any{
+		map[string]any{"term": map[string]any{"source_name.keyword": "naiz.eus"}},
+		map[string]any{"term": map[string]any{"topics.keyword": "crime"}},
+	}
+	if !reflect.DeepEqual(filters, wantFilters) {
+		t.Errorf("filters = %v, want %v", filters, wantFilters)
+	}
+
+	mustNot := boolQuery["must_not"].([]any)
+	if len(mustNot) != 1 {
+		t.Fatalf("must_not = %v, want the excluded word", mustNot)
+	}
+	excluded := mustNot[0].(map[string]any)["multi_match"].(map[string]any)
+	if excluded["query"] != "sports" {
+		t.Errorf("excluded query = %v, want sports", excluded["query"])
+	}
+	if _, fuzzy := excluded["fuzziness"]; fuzzy {
+		t.Error("exclusions should not be fuzzy")
+	}
+}
+
+func TestQueryBuilder_Build_FieldQueryNoSpellSuggest(t *testing.T) {
+	t.Helper()
+
+	cfg := getTestConfig()
+	cfg.SpellCheckEnabled = true
+	req := getDefaultSearchRequest("topic:crime sudbry")
+	fieldQuery, err := domain.ParseFieldQuery(req.Query)
+	if err != nil {
+		t.Fatalf("ParseFieldQuery: %v", err)
+	}
+	req.FieldQuery = fieldQuery
+
+	if _, ok := elasticsearch.NewQueryBuilder(cfg).Build(req)["suggest"]; ok {
+		t.Error("expected no suggester for a field query")
+	}
+}
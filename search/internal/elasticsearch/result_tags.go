@@ -0,0 +1,66 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// ResultTags returns the source and topics of the given documents, keyed by
+// document ID. Documents no longer in the index are left out.
+func (c *Client) ResultTags(ctx context.Context, indexPattern string, ids []string) (map[string]domain.ResultTags, error) {
+	tags := make(map[string]domain.ResultTags, len(ids))
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]any{
+		"query":   map[string]any{"ids": map[string]any{"values": ids}},
+		"size":    len(ids),
+		"_source": []string{"source_name", "topics"},
+	}); err != nil {
+		return nil, fmt.Errorf("encode result tags query: %w", err)
+	}
+
+	res, err := c.esClient.Search(
+		c.esClient.Search.WithContext(ctx),
+		c.esClient.Search.WithIndex(indexPattern),
+		c.esClient.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("result tags request failed: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("result tags returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	var body struct {
+		Hits struct {
+			Hits []struct {
+				ID     string `json:"_id"`
+				Source struct {
+					SourceName string   `json:"source_name"`
+					Topics     []string `json:"topics"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&body); decodeErr != nil {
+		return nil, fmt.Errorf("decode result tags: %w", decodeErr)
+	}
+
+	for _, hit := range body.Hits.Hits {
+		tags[hit.ID] = domain.ResultTags{Source: hit.Source.SourceName, Topics: hit.Source.Topics}
+	}
+	return tags, nil
+}
@@ -0,0 +1,176 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// EnsureSynonymsSet creates the synonyms set setID, empty, unless it exists.
+// Existing rules are kept.
+func (c *Client) EnsureSynonymsSet(ctx context.Context, setID string) error {
+	res, err := c.esClient.SynonymsGetSynonym(setID,
+		c.esClient.SynonymsGetSynonym.WithContext(ctx),
+		c.esClient.SynonymsGetSynonym.WithSize(1),
+	)
+	if err != nil {
+		return fmt.Errorf("get synonyms set request failed: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if !res.IsError() {
+		return nil
+	}
+	if res.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("get synonyms set returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	put, err := c.esClient.SynonymsPutSynonym(setID, strings.NewReader(`{"synonyms_set":[]}`),
+		c.esClient.SynonymsPutSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("create synonyms set request failed: %w", err)
+	}
+	defer func() {
+		_ = put.Body.Close()
+	}()
+
+	if put.IsError() {
+		body, _ := io.ReadAll(put.Body)
+		return fmt.Errorf("create synonyms set returned error [%d]: %s", put.StatusCode, string(body))
+	}
+	return nil
+}
+
+// SynonymRules returns a page of the rules in the synonyms set setID. A set
+// that does not exist yet has no rules.
+func (c *Client) SynonymRules(ctx context.Context, setID string, req *domain.SynonymsRequest) (*domain.SynonymRules, error) {
+	res, err := c.esClient.SynonymsGetSynonym(setID,
+		c.esClient.SynonymsGetSynonym.WithContext(ctx),
+		c.esClient.SynonymsGetSynonym.WithFrom(req.From),
+		c.esClient.SynonymsGetSynonym.WithSize(req.Size),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get synonyms request failed: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode == http.StatusNotFound {
+		return &domain.SynonymRules{Set: setID, Rules: []*domain.SynonymRule{}}, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("get synonyms returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	return parseSynonymRules(res.Body, setID)
+}
+
+// PutSynonymRule creates or replaces a rule in the synonyms set setID.
+// Elasticsearch reloads the search analyzers of every index using the set
+// before it answers.
+func (c *Client) PutSynonymRule(ctx context.Context, setID string, rule *domain.SynonymRule) (*domain.SynonymUpdate, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]any{"synonyms": rule.Synonyms}); err != nil {
+		return nil, fmt.Errorf("encode synonym rule: %w", err)
+	}
+
+	res, err := c.esClient.SynonymsPutSynonymRule(&buf, rule.ID, setID,
+		c.esClient.SynonymsPutSynonymRule.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("put synonym rule request failed: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("put synonym rule returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	return parseSynonymUpdate(res.Body, rule)
+}
+
+// DeleteSynonymRule removes a rule from the synonyms set setID, reloading
+// the search analyzers like PutSynonymRule.
+func (c *Client) DeleteSynonymRule(ctx context.Context, setID, ruleID string) (*domain.SynonymUpdate, error) {
+	res, err := c.esClient.SynonymsDeleteSynonymRule(ruleID, setID,
+		c.esClient.SynonymsDeleteSynonymRule.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("delete synonym rule request failed: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrSynonymRuleNotFound
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("delete synonym rule returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	return parseSynonymUpdate(res.Body, &domain.SynonymRule{ID: ruleID})
+}
+
+// parseSynonymRules decodes a get synonyms set response.
+func parseSynonymRules(body io.Reader, setID string) (*domain.SynonymRules, error) {
+	var esResponse struct {
+		Count       int                   `json:"count"`
+		SynonymsSet []*domain.SynonymRule `json:"synonyms_set"`
+	}
+	if err := json.NewDecoder(body).Decode(&esResponse); err != nil {
+		return nil, fmt.Errorf("decode synonyms: %w", err)
+	}
+
+	rules := esResponse.SynonymsSet
+	if rules == nil {
+		rules = []*domain.SynonymRule{}
+	}
+	return &domain.SynonymRules{Set: setID, Total: esResponse.Count, Rules: rules}, nil
+}
+
+// parseSynonymUpdate decodes a put or delete synonym rule response and its
+// analyzer reload details.
+func parseSynonymUpdate(body io.Reader, rule *domain.SynonymRule) (*domain.SynonymUpdate, error) {
+	var esResponse struct {
+		Result                 string `json:"result"`
+		ReloadAnalyzersDetails struct {
+			Shards struct {
+				Failed int `json:"failed"`
+			} `json:"_shards"`
+			ReloadDetails []struct {
+				Index string `json:"index"`
+			} `json:"reload_details"`
+		} `json:"reload_analyzers_details"`
+	}
+	if err := json.NewDecoder(body).Decode(&esResponse); err != nil {
+		return nil, fmt.Errorf("decode synonym rule update: %w", err)
+	}
+
+	update := &domain.SynonymUpdate{
+		Rule:               rule,
+		Result:             esResponse.Result,
+		ReloadedIndexes:    make([]string, 0, len(esResponse.ReloadAnalyzersDetails.ReloadDetails)),
+		ReloadFailedShards: esResponse.ReloadAnalyzersDetails.Shards.Failed,
+	}
+	for _, detail := range esResponse.ReloadAnalyzersDetails.ReloadDetails {
+		update.ReloadedIndexes = append(update.ReloadedIndexes, detail.Index)
+	}
+	return update, nil
+}
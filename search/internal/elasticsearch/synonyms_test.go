@@ -0,0 +1,62 @@
+package elasticsearch_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/elasticsearch"
+)
+
+func TestParseSynonymRules(t *testing.T) {
+	t.Helper()
+
+	body := `{"count": 2, "synonyms_set": [
+		{"id": "opp", "synonyms": "opp, ontario provincial police"},
+		{"id": "sault", "synonyms": "sault => sault ste marie"}
+	]}`
+	rules, err := elasticsearch.ParseSynonymRules(strings.NewReader(body), "north-cloud-search")
+	if err != nil {
+		t.Fatalf("ParseSynonymRules: %v", err)
+	}
+	if rules.Set != "north-cloud-search" || rules.Total != 2 || len(rules.Rules) != 2 {
+		t.Fatalf("rules = %+v", rules)
+	}
+	if rules.Rules[1].ID != "sault" || rules.Rules[1].Synonyms != "sault => sault ste marie" {
+		t.Errorf("second rule = %+v", rules.Rules[1])
+	}
+
+	empty, err := elasticsearch.ParseSynonymRules(strings.NewReader(`{"count": 0}`), "north-cloud-search")
+	if err != nil {
+		t.Fatalf("ParseSynonymRules: %v", err)
+	}
+	if empty.Rules == nil {
+		t.Error("an empty set should list no rules, not null")
+	}
+}
+
+func TestParseSynonymUpdate(t *testing.T) {
+	t.Helper()
+
+	body := `{
+		"result": "updated",
+		"reload_analyzers_details": {
+			"_shards": {"total": 4, "successful": 3, "failed": 1},
+			"reload_details": [
+				{"index": "naiz_eus_classified_content", "reloaded_analyzers": ["english_search", "french_search"]},
+				{"index": "sudbury_com_classified_content", "reloaded_analyzers": ["english_search", "french_search"]}
+			]
+		}
+	}`
+	rule := &domain.SynonymRule{ID: "opp", Synonyms: "opp, ontario provincial police"}
+	update, err := elasticsearch.ParseSynonymUpdate(strings.NewReader(body), rule)
+	if err != nil {
+		t.Fatalf("ParseSynonymUpdate: %v", err)
+	}
+	if update.Result != "updated" || update.Rule != rule || update.ReloadFailedShards != 1 {
+		t.Errorf("update = %+v", update)
+	}
+	if len(update.ReloadedIndexes) != 2 || update.ReloadedIndexes[1] != "sudbury_com_classified_content" {
+		t.Errorf("ReloadedIndexes = %v", update.ReloadedIndexes)
+	}
+}
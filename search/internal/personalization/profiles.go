@@ -0,0 +1,156 @@
+// Package personalization builds engagement profiles from the click-tracker's
+// per-session click stats, so searches can boost the sources and topics a
+// visitor or widget audience keeps clicking.
+package personalization
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	// sessionStatsPath is the click-tracker's per-session stats endpoint.
+	sessionStatsPath = "/api/v1/stats/sessions/"
+	// sessionResultLimit is the most clicked results read per session (the click-tracker's cap).
+	sessionResultLimit = 100
+	// hoursPerDay converts the click window to the days the click-tracker expects.
+	hoursPerDay = 24
+)
+
+// TagResolver looks up the source and topics of clicked results.
+type TagResolver interface {
+	ResultTags(ctx context.Context, indexPattern string, ids []string) (map[string]domain.ResultTags, error)
+}
+
+// cachedProfile is a profile and when it stops being reused.
+type cachedProfile struct {
+	profile   *domain.EngagementProfile
+	expiresAt time.Time
+}
+
+// Profiles builds and caches engagement profiles. Profiles are rebuilt after
+// cfg.CacheTTL, so new clicks change ranking within minutes, not per search.
+type Profiles struct {
+	service      *infrahttp.ServiceClient
+	baseURL      string
+	cfg          config.PersonalizationConfig
+	resolver     TagResolver
+	indexPattern string
+
+	mu    sync.Mutex
+	cache map[string]cachedProfile
+}
+
+// NewProfiles creates profiles read from the click-tracker at
+// cfg.ClickTrackerURL, with clicked results resolved in indexPattern.
+func NewProfiles(cfg config.PersonalizationConfig, resolver TagResolver, indexPattern string) *Profiles {
+	return &Profiles{
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:   cfg.Timeout,
+			JWTSecret: cfg.JWTSecret,
+			Subject:   "search",
+		}),
+		baseURL:      strings.TrimRight(cfg.ClickTrackerURL, "/"),
+		cfg:          cfg,
+		resolver:     resolver,
+		indexPattern: indexPattern,
+		cache:        make(map[string]cachedProfile),
+	}
+}
+
+// Profile returns the session's engagement profile, building it when it is
+// not cached. Building is bounded by cfg.Timeout; failures are not cached.
+func (p *Profiles) Profile(ctx context.Context, sessionID string) (*domain.EngagementProfile, error) {
+	if profile, ok := p.cached(sessionID); ok {
+		return profile, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+
+	clicks, err := p.sessionClicks(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(clicks))
+	for id := range clicks {
+		ids = append(ids, id)
+	}
+	tags, err := p.resolver.ResultTags(ctx, p.indexPattern, ids)
+	if err != nil {
+		return nil, fmt.Errorf("resolve clicked results: %w", err)
+	}
+
+	profile := domain.NewEngagementProfile(clicks, tags, p.cfg.SourceBoost, p.cfg.TopicBoost)
+	p.store(sessionID, profile)
+	return profile, nil
+}
+
+// sessionClicks reads the clicks per result of one session from the click-tracker.
+func (p *Profiles) sessionClicks(ctx context.Context, sessionID string) (map[string]int64, error) {
+	query := url.Values{}
+	query.Set("days", strconv.Itoa(int(p.cfg.Window.Hours())/hoursPerDay))
+	query.Set("limit", strconv.Itoa(sessionResultLimit))
+	endpoint := p.baseURL + sessionStatsPath + url.PathEscape(sessionID) + "?" + query.Encode()
+
+	var body struct {
+		Results []struct {
+			ResultID string `json:"result_id"`
+			Clicks   int64  `json:"clicks"`
+		} `json:"results"`
+	}
+	if err := p.service.Do(ctx, http.MethodGet, endpoint, nil, &body); err != nil {
+		return nil, fmt.Errorf("fetch session clicks: %w", err)
+	}
+
+	clicks := make(map[string]int64, len(body.Results))
+	for _, result := range body.Results {
+		clicks[result.ResultID] += result.Clicks
+	}
+	return clicks, nil
+}
+
+// cached returns the session's profile while it is fresh.
+func (p *Profiles) cached(sessionID string) (*domain.EngagementProfile, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.cache[sessionID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.profile, true
+}
+
+// store caches a profile. A full cache first drops expired profiles, then
+// arbitrary ones, so memory stays bounded by cfg.CacheSize.
+func (p *Profiles) store(sessionID string, profile *domain.EngagementProfile) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if len(p.cache) >= p.cfg.CacheSize {
+		for id, entry := range p.cache {
+			if now.After(entry.expiresAt) {
+				delete(p.cache, id)
+			}
+		}
+	}
+	for id := range p.cache {
+		if len(p.cache) < p.cfg.CacheSize {
+			break
+		}
+		delete(p.cache, id)
+	}
+	p.cache[sessionID] = cachedProfile{profile: profile, expiresAt: now.Add(p.cfg.CacheTTL)}
+}
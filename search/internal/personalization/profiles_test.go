@@ -0,0 +1,102 @@
+package personalization_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/personalization"
+)
+
+type fakeResolver struct {
+	gotIDs []string
+}
+
+func (f *fakeResolver) ResultTags(_ context.Context, _ string, ids []string) (map[string]domain.ResultTags, error) {
+	f.gotIDs = ids
+	return map[string]domain.ResultTags{
+		"doc-1": {Source: "sudbury_star", Topics: []string{"crime"}},
+	}, nil
+}
+
+func newTestProfiles(url string, resolver personalization.TagResolver) *personalization.Profiles {
+	return personalization.NewProfiles(config.PersonalizationConfig{
+		ClickTrackerURL: url,
+		JWTSecret:       "jwt-secret",
+		Window:          7 * 24 * time.Hour,
+		CacheTTL:        time.Minute,
+		CacheSize:       10,
+		Timeout:         time.Second,
+		SourceBoost:     0.5,
+		TopicBoost:      0.3,
+	}, resolver, "*_classified_content")
+}
+
+func TestProfiles_Profile(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var gotPath, gotQuery, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"session_id":"sess_1","results":[{"result_id":"doc-1","clicks":3}],"count":1}`))
+	}))
+	defer server.Close()
+
+	resolver := &fakeResolver{}
+	profiles := newTestProfiles(server.URL+"/", resolver)
+
+	profile, err := profiles.Profile(context.Background(), "sess_1")
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if gotPath != "/api/v1/stats/sessions/sess_1" || gotQuery != "days=7&limit=100" {
+		t.Errorf("unexpected request %s?%s", gotPath, gotQuery)
+	}
+	if !strings.HasPrefix(gotAuth, "Bearer ") {
+		t.Errorf("expected a bearer token, got %q", gotAuth)
+	}
+	if len(resolver.gotIDs) != 1 || resolver.gotIDs[0] != "doc-1" {
+		t.Errorf("unexpected resolved IDs %v", resolver.gotIDs)
+	}
+	if profile.Clicks != 3 || len(profile.Sources) != 1 || profile.Sources[0].Value != "sudbury_star" {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+
+	if _, err = profiles.Profile(context.Background(), "sess_1"); err != nil {
+		t.Fatalf("cached Profile() error = %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the cached profile to be reused, got %d calls", calls.Load())
+	}
+}
+
+func TestProfiles_ClickTrackerError(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	profiles := newTestProfiles(server.URL, &fakeResolver{})
+	for range 2 {
+		if _, err := profiles.Profile(context.Background(), "sess_1"); err == nil {
+			t.Fatal("expected an error from a failing click-tracker")
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("failures should not be cached, got %d calls", calls.Load())
+	}
+}
@@ -0,0 +1,86 @@
+// Package ratelimit enforces the per-minute rate limit and daily quota of
+// search API keys with counters in Redis, so every search instance shares them.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	dayLayout = "2006-01-02"
+	// rateKeyTTL keeps a minute's counter until the minute is over
+	rateKeyTTL = 2 * time.Minute
+	// quotaKeyTTL keeps a day's counter until the UTC day is over everywhere
+	quotaKeyTTL = 48 * time.Hour
+)
+
+// consumeScript counts a request against its key's minute and day. A
+// request over the rate limit does not count towards the daily quota, so a
+// client retrying too fast does not use up its day.
+var consumeScript = redis.NewScript(`
+local minute = redis.call('INCR', KEYS[1])
+if minute == 1 then redis.call('EXPIRE', KEYS[1], ARGV[2]) end
+if minute > tonumber(ARGV[1]) then
+	return {minute, tonumber(redis.call('GET', KEYS[2]) or '0')}
+end
+local day = redis.call('INCR', KEYS[2])
+if day == 1 then redis.call('EXPIRE', KEYS[2], ARGV[3]) end
+return {minute, day}
+`)
+
+// Limiter counts API key requests in fixed windows: the current minute for
+// the rate limit and the current UTC day for the quota.
+type Limiter struct {
+	client *redis.Client
+	cfg    config.APIKeysConfig
+}
+
+// New creates a limiter on client.
+func New(client *redis.Client, cfg config.APIKeysConfig) *Limiter {
+	return &Limiter{client: client, cfg: cfg}
+}
+
+// Consume counts one request by key at now and reports whether it is within
+// the key's rate limit and daily quota.
+func (l *Limiter) Consume(ctx context.Context, key *domain.APIKey, now time.Time) (*domain.QuotaUsage, error) {
+	now = now.UTC()
+	minute := now.Truncate(time.Minute)
+	day := now.Format(dayLayout)
+
+	rateKey := l.cfg.KeyPrefix + ":rate:" + key.ID + ":" + strconv.FormatInt(minute.Unix(), 10)
+	quotaKey := l.cfg.KeyPrefix + ":quota:" + key.ID + ":" + day
+	counts, err := consumeScript.Run(ctx, l.client, []string{rateKey, quotaKey},
+		key.RateLimit, int(rateKeyTTL.Seconds()), int(quotaKeyTTL.Seconds()),
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("count api key request: %w", err)
+	}
+	requests, daily := counts[0], counts[1]
+
+	usage := &domain.QuotaUsage{
+		RateLimit:      key.RateLimit,
+		RateRemaining:  remaining(key.RateLimit, requests),
+		RateReset:      minute.Add(time.Minute),
+		DailyQuota:     key.DailyQuota,
+		QuotaRemaining: remaining(key.DailyQuota, daily),
+		QuotaReset:     time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC),
+	}
+	switch {
+	case requests > int64(key.RateLimit):
+		usage.Exceeded = domain.LimitRate
+	case daily > int64(key.DailyQuota):
+		usage.Exceeded = domain.LimitQuota
+	}
+	return usage, nil
+}
+
+func remaining(limit int, used int64) int {
+	return int(max(0, int64(limit)-used))
+}
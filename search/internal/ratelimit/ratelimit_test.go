@@ -0,0 +1,91 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestLimiter(t *testing.T) (*ratelimit.Limiter, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return ratelimit.New(client, config.APIKeysConfig{KeyPrefix: "search:apikeys"}), mr
+}
+
+func TestLimiter_RateLimit(t *testing.T) {
+	t.Helper()
+
+	limiter, mr := newTestLimiter(t)
+	ctx := context.Background()
+	key := &domain.APIKey{ID: "k1", RateLimit: 2, DailyQuota: 100}
+	now := time.Date(2026, 10, 16, 12, 30, 15, 0, time.UTC)
+
+	for i := range 2 {
+		usage, err := limiter.Consume(ctx, key, now)
+		if err != nil {
+			t.Fatalf("Consume: %v", err)
+		}
+		if !usage.Allowed() || usage.RateRemaining != 1-i {
+			t.Errorf("request %d: unexpected usage %+v", i+1, usage)
+		}
+	}
+
+	usage, err := limiter.Consume(ctx, key, now)
+	if err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if usage.Exceeded != domain.LimitRate {
+		t.Fatalf("expected the third request in a minute to be rate limited, got %+v", usage)
+	}
+	if want := time.Date(2026, 10, 16, 12, 31, 0, 0, time.UTC); !usage.RateReset.Equal(want) {
+		t.Errorf("RateReset = %v, want %v", usage.RateReset, want)
+	}
+	if usage.QuotaRemaining != 98 {
+		t.Errorf("a rate-limited request should not use the quota, %d remaining", usage.QuotaRemaining)
+	}
+	if got := mr.TTL("search:apikeys:quota:k1:2026-10-16"); got != 48*time.Hour {
+		t.Errorf("quota counter TTL = %v", got)
+	}
+
+	if usage, err = limiter.Consume(ctx, key, now.Add(time.Minute)); err != nil || !usage.Allowed() {
+		t.Errorf("expected the next minute to be allowed, got %+v (%v)", usage, err)
+	}
+}
+
+func TestLimiter_DailyQuota(t *testing.T) {
+	t.Helper()
+
+	limiter, _ := newTestLimiter(t)
+	ctx := context.Background()
+	key := &domain.APIKey{ID: "k2", RateLimit: 100, DailyQuota: 1}
+	now := time.Date(2026, 10, 16, 23, 59, 0, 0, time.UTC)
+
+	if usage, err := limiter.Consume(ctx, key, now); err != nil || !usage.Allowed() {
+		t.Fatalf("expected the first request to be allowed, got %+v (%v)", usage, err)
+	}
+
+	usage, err := limiter.Consume(ctx, key, now.Add(time.Second))
+	if err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if usage.Exceeded != domain.LimitQuota || usage.QuotaRemaining != 0 {
+		t.Fatalf("expected the daily quota to be exceeded, got %+v", usage)
+	}
+	if want := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC); !usage.QuotaReset.Equal(want) {
+		t.Errorf("QuotaReset = %v, want %v", usage.QuotaReset, want)
+	}
+
+	if usage, err = limiter.Consume(ctx, key, now.Add(2*time.Minute)); err != nil || !usage.Allowed() {
+		t.Errorf("expected a new UTC day to be allowed, got %+v (%v)", usage, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package reputation keeps a periodically refreshed copy of the classifier's
+// source reputation scores so searches never call the classifier synchronously.
+package reputation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// reputationPath is the classifier's internal reputation endpoint.
+const reputationPath = "/api/internal/v1/sources/reputation"
+
+// Cache holds the latest source reputation scores. A failed refresh keeps the
+// previous scores, so a classifier outage serves stale rather than no data.
+type Cache struct {
+	service  *infrahttp.ServiceClient
+	url      string
+	interval time.Duration
+	logger   infralogger.Logger
+
+	mu       sync.RWMutex
+	scores   domain.SourceReputations
+	loadedAt time.Time
+}
+
+// NewCache creates a cache for the classifier at cfg.URL. Call Run to load it.
+func NewCache(cfg config.ClassifierConfig, log infralogger.Logger) *Cache {
+	return &Cache{
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:        cfg.Timeout,
+			InternalSecret: cfg.InternalSecret,
+		}),
+		url:      strings.TrimRight(cfg.URL, "/") + reputationPath,
+		interval: cfg.RefreshInterval,
+		logger:   log,
+	}
+}
+
+// Run loads the scores immediately, then refreshes them every interval until ctx is done.
+func (c *Cache) Run(ctx context.Context) {
+	c.refreshAndLog(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refreshAndLog(ctx)
+		}
+	}
+}
+
+// Snapshot returns the current scores and whether they have loaded at least once.
+// The returned map must not be modified.
+func (c *Cache) Snapshot() (domain.SourceReputations, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.scores, !c.loadedAt.IsZero()
+}
+
+// Refresh fetches the scores from the classifier and replaces the cached copy.
+func (c *Cache) Refresh(ctx context.Context) error {
+	var body struct {
+		Sources map[string]int `json:"sources"`
+	}
+	if err := c.service.Do(ctx, http.MethodGet, c.url, nil, &body); err != nil {
+		return fmt.Errorf("fetch source reputations: %w", err)
+	}
+	if body.Sources == nil {
+		body.Sources = map[string]int{}
+	}
+
+	c.mu.Lock()
+	c.scores = body.Sources
+	c.loadedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// refreshAndLog refreshes the cache, logging failures instead of returning them.
+func (c *Cache) refreshAndLog(ctx context.Context) {
+	if err := c.Refresh(ctx); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		c.mu.RLock()
+		loadedAt := c.loadedAt
+		c.mu.RUnlock()
+		c.logger.Warn("Source reputation refresh failed, keeping previous scores",
+			infralogger.Error(err),
+			infralogger.Time("loaded_at", loadedAt),
+		)
+		return
+	}
+	c.logger.Debug("Source reputations refreshed")
+}
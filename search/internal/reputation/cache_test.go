@@ -0,0 +1,82 @@
+package reputation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/reputation"
+)
+
+func newTestCache(url string) *reputation.Cache {
+	return reputation.NewCache(config.ClassifierConfig{
+		URL:             url,
+		InternalSecret:  "internal-secret",
+		RefreshInterval: time.Minute,
+		Timeout:         time.Second,
+	}, infralogger.NewNop())
+}
+
+func TestCache_Refresh(t *testing.T) {
+	t.Parallel()
+
+	var gotPath, gotSecret string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotSecret = r.Header.Get("X-Internal-Secret")
+		_, _ = w.Write([]byte(`{"sources":{"cbc":82,"spamblog":12},"generated_at":"2026-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	cache := newTestCache(server.URL + "/")
+	if _, loaded := cache.Snapshot(); loaded {
+		t.Fatal("cache should not report loaded before the first refresh")
+	}
+
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if gotPath != "/api/internal/v1/sources/reputation" {
+		t.Errorf("unexpected path %q", gotPath)
+	}
+	if gotSecret != "internal-secret" {
+		t.Errorf("expected internal secret header, got %q", gotSecret)
+	}
+	scores, loaded := cache.Snapshot()
+	if !loaded || scores["cbc"] != 82 || scores["spamblog"] != 12 {
+		t.Errorf("unexpected snapshot %v (loaded=%v)", scores, loaded)
+	}
+}
+
+func TestCache_FailedRefreshKeepsScores(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) > 1 {
+			http.Error(w, "database down", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"sources":{"cbc":82}}`))
+	}))
+	defer server.Close()
+
+	cache := newTestCache(server.URL)
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("first Refresh() error = %v", err)
+	}
+	if err := cache.Refresh(context.Background()); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+
+	scores, loaded := cache.Snapshot()
+	if !loaded || scores["cbc"] != 82 {
+		t.Errorf("expected previous scores to be kept, got %v (loaded=%v)", scores, loaded)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	analyticsDefaultDays  = 7
+	analyticsDefaultLimit = 20
+	analyticsMaxLimit     = 100
+)
+
+// ErrAnalyticsUnavailable is returned for analytics reports when query
+// analytics is disabled.
+var ErrAnalyticsUnavailable = errors.New("query analytics is unavailable")
+
+// QueryAnalytics records executed searches and reports on them.
+type QueryAnalytics interface {
+	Track(event domain.QueryEvent)
+	TopQueries(ctx context.Context, days, limit int) ([]domain.QueryCount, error)
+	ZeroResultQueries(ctx context.Context, days, limit int) ([]domain.QueryCount, error)
+	Latency(ctx context.Context, days int) (*domain.LatencyReport, error)
+}
+
+// WithAnalytics records every search for the analytics reports.
+func (s *SearchService) WithAnalytics(a QueryAnalytics) *SearchService {
+	s.analytics = a
+	return s
+}
+
+// trackQuery hands a completed search to analytics, unless a feed reader made
+// it. Only first pages of text searches count as queries; every search counts
+// towards volume and latency.
+// A search answered by auto-correction counts as finding nothing, since the
+// query as typed did not.
+func (s *SearchService) trackQuery(req *domain.SearchRequest, response *domain.SearchResponse) {
+	if s.analytics == nil || req.Options.Feed {
+		return
+	}
+
+	event := domain.QueryEvent{
+		Hits:    response.TotalHits,
+		Latency: time.Duration(response.TookMs) * time.Millisecond,
+		At:      time.Now(),
+	}
+	if req.Pagination.Page == 1 && req.Query != "*" {
+		event.Query = domain.AnonymizeQuery(req.Query)
+	}
+	if response.CorrectedFrom != "" {
+		event.Hits = 0
+	}
+	s.analytics.Track(event)
+}
+
+// TopQueries reports the most searched queries.
+func (s *SearchService) TopQueries(ctx context.Context, req *domain.AnalyticsRequest) (*domain.QueriesReport, error) {
+	if s.analytics == nil {
+		return nil, ErrAnalyticsUnavailable
+	}
+	s.normalizeAnalyticsRequest(req)
+
+	queries, err := s.analytics.TopQueries(ctx, req.Days, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.QueriesReport{WindowDays: req.Days, MinCount: s.config.Analytics.MinCount, Queries: queries}, nil
+}
+
+// ZeroResultQueries reports the most searched queries that found nothing.
+func (s *SearchService) ZeroResultQueries(ctx context.Context, req *domain.AnalyticsRequest) (*domain.QueriesReport, error) {
+	if s.analytics == nil {
+		return nil, ErrAnalyticsUnavailable
+	}
+	s.normalizeAnalyticsRequest(req)
+
+	queries, err := s.analytics.ZeroResultQueries(ctx, req.Days, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.QueriesReport{WindowDays: req.Days, MinCount: s.config.Analytics.MinCount, Queries: queries}, nil
+}
+
+// QueryLatency reports search volume, the zero-result rate, and latency percentiles.
+func (s *SearchService) QueryLatency(ctx context.Context, req *domain.AnalyticsRequest) (*domain.LatencyReport, error) {
+	if s.analytics == nil {
+		return nil, ErrAnalyticsUnavailable
+	}
+	s.normalizeAnalyticsRequest(req)
+	return s.analytics.Latency(ctx, req.Days)
+}
+
+// normalizeAnalyticsRequest applies defaults and bounds the window by retention.
+func (s *SearchService) normalizeAnalyticsRequest(req *domain.AnalyticsRequest) {
+	maxDays := max(1, int(s.config.Analytics.Retention.Hours()/hoursPerDay))
+	if req.Days <= 0 {
+		req.Days = analyticsDefaultDays
+	}
+	if req.Days > maxDays {
+		req.Days = maxDays
+	}
+	if req.Limit <= 0 {
+		req.Limit = analyticsDefaultLimit
+	}
+	if req.Limit > analyticsMaxLimit {
+		req.Limit = analyticsMaxLimit
+	}
+}
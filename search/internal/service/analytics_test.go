@@ -0,0 +1,121 @@
+//nolint:testpackage // White-box test: searches are answered by the fake cache, not Elasticsearch
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+type fakeAnalytics struct {
+	events []domain.QueryEvent
+	days   int
+	limit  int
+}
+
+func (f *fakeAnalytics) Track(event domain.QueryEvent) {
+	f.events = append(f.events, event)
+}
+
+func (f *fakeAnalytics) TopQueries(_ context.Context, days, limit int) ([]domain.QueryCount, error) {
+	f.days, f.limit = days, limit
+	return []domain.QueryCount{{Query: "fire", Searches: 3}}, nil
+}
+
+func (f *fakeAnalytics) ZeroResultQueries(_ context.Context, days, limit int) ([]domain.QueryCount, error) {
+	f.days, f.limit = days, limit
+	return nil, nil
+}
+
+func (f *fakeAnalytics) Latency(_ context.Context, days int) (*domain.LatencyReport, error) {
+	f.days = days
+	return &domain.LatencyReport{WindowDays: days}, nil
+}
+
+func TestSearch_TracksQuery(t *testing.T) {
+	t.Helper()
+
+	tracked := &fakeAnalytics{}
+	cached := &fakeCache{resp: &domain.SearchResponse{TotalHits: 4}}
+	s := NewSearchService(nil, cacheTestConfig(), infralogger.NewNop(), nil).
+		WithCache(cached).
+		WithAnalytics(tracked)
+
+	req := personalizeRequest(false)
+	req.Query = "  Forest FIRE  "
+	if _, err := s.Search(context.Background(), req); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	req = personalizeRequest(false)
+	req.Pagination.Page = 2
+	if _, err := s.Search(context.Background(), req); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	if len(tracked.events) != 2 {
+		t.Fatalf("expected both searches tracked, got %d", len(tracked.events))
+	}
+	if got := tracked.events[0]; got.Query != "forest fire" || got.Hits != 4 {
+		t.Errorf("unexpected first event %+v", got)
+	}
+	if got := tracked.events[1].Query; got != "" {
+		t.Errorf("a later page should not count as a query, got %q", got)
+	}
+}
+
+func TestTrackQuery_AutoCorrectedCountsAsZeroResults(t *testing.T) {
+	t.Helper()
+
+	tracked := &fakeAnalytics{}
+	s := NewSearchService(nil, cacheTestConfig(), infralogger.NewNop(), nil).WithAnalytics(tracked)
+
+	s.trackQuery(personalizeRequest(false), &domain.SearchResponse{TotalHits: 12, CorrectedFrom: "fier"})
+	if tracked.events[0].Hits != 0 {
+		t.Errorf("expected the query as typed to count as finding nothing, got %d hits", tracked.events[0].Hits)
+	}
+}
+
+func TestTrackQuery_SkipsFeeds(t *testing.T) {
+	t.Helper()
+
+	tracked := &fakeAnalytics{}
+	s := NewSearchService(nil, cacheTestConfig(), infralogger.NewNop(), nil).WithAnalytics(tracked)
+
+	req := personalizeRequest(false)
+	req.Options.Feed = true
+	s.trackQuery(req, &domain.SearchResponse{TotalHits: 3})
+	if len(tracked.events) != 0 {
+		t.Errorf("expected feed polls not to be tracked, got %+v", tracked.events)
+	}
+}
+
+func TestTopQueries_Bounds(t *testing.T) {
+	t.Helper()
+
+	tracked := &fakeAnalytics{}
+	cfg := cacheTestConfig()
+	cfg.Analytics.Retention = 30 * 24 * time.Hour
+	cfg.Analytics.MinCount = 2
+	s := NewSearchService(nil, cfg, infralogger.NewNop(), nil).WithAnalytics(tracked)
+
+	report, err := s.TopQueries(context.Background(), &domain.AnalyticsRequest{Days: 365, Limit: 1000})
+	if err != nil {
+		t.Fatalf("TopQueries: %v", err)
+	}
+	if tracked.days != 30 || tracked.limit != 100 {
+		t.Errorf("expected the window capped at retention and limit at 100, got %d days, limit %d", tracked.days, tracked.limit)
+	}
+	if report.MinCount != 2 || len(report.Queries) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	disabled := NewSearchService(nil, cfg, infralogger.NewNop(), nil)
+	if _, err = disabled.QueryLatency(context.Background(), &domain.AnalyticsRequest{}); !errors.Is(err, ErrAnalyticsUnavailable) {
+		t.Errorf("expected ErrAnalyticsUnavailable, got %v", err)
+	}
+}
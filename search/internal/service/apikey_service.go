@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// QuotaLimiter counts API key requests against their rate limits and quotas.
+type QuotaLimiter interface {
+	Consume(ctx context.Context, key *domain.APIKey, now time.Time) (*domain.QuotaUsage, error)
+}
+
+// WithQuotas enforces the rate limit and daily quota of API keys.
+func (s *SearchService) WithQuotas(l QuotaLimiter) *SearchService {
+	s.quotas = l
+	return s
+}
+
+// ConsumeQuota counts one request by key and returns where the key stands.
+// It returns nil, allowing the request, when no limiter is configured or
+// the counters cannot be reached: keys are still verified, and an outage of
+// the counters does not take search down.
+func (s *SearchService) ConsumeQuota(ctx context.Context, key *domain.APIKey) *domain.QuotaUsage {
+	if s.quotas == nil {
+		return nil
+	}
+
+	usage, err := s.quotas.Consume(ctx, key, time.Now())
+	if err != nil {
+		s.logger.Warn("API key limits unavailable, allowing request",
+			infralogger.String("key_id", key.ID),
+			infralogger.Error(err),
+		)
+		return nil
+	}
+	return usage
+}
+
+// searchIndex is the index pattern searches with ctx run against: the
+// patterns of the caller's API key, or every classified content index.
+func (s *SearchService) searchIndex(ctx context.Context) string {
+	if patterns := domain.IndexPatterns(ctx); len(patterns) > 0 {
+		return strings.Join(patterns, ",")
+	}
+	return s.config.Elasticsearch.ClassifiedContentPattern
+}
@@ -0,0 +1,72 @@
+//nolint:testpackage // White-box test: a cache hit must not reach the (nil) Elasticsearch client
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+type fakeCache struct {
+	resp *domain.SearchResponse
+}
+
+func (f *fakeCache) Get(context.Context, *domain.SearchRequest) (*domain.SearchResponse, bool) {
+	return f.resp, f.resp != nil
+}
+
+func (f *fakeCache) Set(_ context.Context, _ *domain.SearchRequest, resp *domain.SearchResponse) {
+	f.resp = resp
+}
+
+func cacheTestConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Service.MaxPageSize = 100
+	cfg.Service.DefaultPageSize = 20
+	cfg.Service.MaxQueryLength = 500
+	cfg.ClickTracker.BaseURL = "https://click.example.com"
+	return cfg
+}
+
+func TestSearch_ServedFromCache(t *testing.T) {
+	t.Helper()
+
+	cached := &fakeCache{resp: &domain.SearchResponse{
+		Query:     "fire",
+		TotalHits: 1,
+		Hits:      []*domain.SearchHit{{ID: "doc-1", URL: "https://news.example.com/fire"}},
+	}}
+	s := NewSearchService(nil, cacheTestConfig(), infralogger.NewNop(), clickurl.NewSigner("secret")).WithCache(cached)
+
+	resp, err := s.Search(context.Background(), personalizeRequest(false))
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if !resp.Cached {
+		t.Error("expected the response to be marked cached")
+	}
+	if !strings.HasPrefix(resp.Hits[0].ClickURL, "https://click.example.com") {
+		t.Errorf("expected a click URL signed for this response, got %q", resp.Hits[0].ClickURL)
+	}
+}
+
+func TestSearch_PersonalizedSkipsCache(t *testing.T) {
+	t.Helper()
+
+	cached := &fakeCache{}
+	s := NewSearchService(nil, cacheTestConfig(), infralogger.NewNop(), nil).
+		WithPersonalization(&fakeProfiles{}).
+		WithCache(cached)
+
+	if s.cacheable(personalizeRequest(true)) {
+		t.Error("personalized searches should not be cached")
+	}
+	if !s.cacheable(personalizeRequest(false)) {
+		t.Error("unpersonalized searches should be cached")
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	coverageMaxSources       = 500
+	coverageDefaultDays      = 30
+	coverageMaxDays          = 90
+	coverageDefaultGap       = 24 * time.Hour
+	coverageDateLayout       = "2006-01-02"
+	coverageIndexedDateField = "crawled_at"
+	hoursPerDay              = 24
+)
+
+// CrawlIntervalSource provides cached per-source crawl intervals.
+type CrawlIntervalSource interface {
+	Snapshot() (domain.CrawlIntervals, bool)
+}
+
+// WithCrawlIntervals judges coverage gaps against each source's crawl interval.
+func (s *SearchService) WithCrawlIntervals(src CrawlIntervalSource) *SearchService {
+	s.intervals = src
+	return s
+}
+
+// crawlIntervalSnapshot returns the cached intervals, or nil before they have loaded.
+func (s *SearchService) crawlIntervalSnapshot() domain.CrawlIntervals {
+	if s.intervals == nil {
+		return nil
+	}
+	intervals, loaded := s.intervals.Snapshot()
+	if !loaded {
+		return nil
+	}
+	return intervals
+}
+
+// SourceCoverage reports, per source, the oldest and newest indexed document,
+// docs per day over the requested window, and gaps longer than the source's
+// crawl interval (or the request's gap threshold, when set).
+func (s *SearchService) SourceCoverage(
+	ctx context.Context,
+	req *domain.CoverageRequest,
+) (*domain.CoverageResponse, error) {
+	normalizeCoverageRequest(req)
+	now := time.Now().UTC()
+
+	res, err := s.executeSearch(ctx, buildCoverageQuery(req, now))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	sources, err := parseCoverageResponse(res.Body, req, s.crawlIntervalSnapshot(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CoverageResponse{
+		GeneratedAt:      now,
+		WindowDays:       req.Days,
+		GapThresholdHour: req.GapThreshold.Hours(),
+		Sources:          sources,
+	}, nil
+}
+
+// normalizeCoverageRequest applies defaults and bounds to a coverage request.
+func normalizeCoverageRequest(req *domain.CoverageRequest) {
+	if req.Days <= 0 {
+		req.Days = coverageDefaultDays
+	}
+	if req.Days > coverageMaxDays {
+		req.Days = coverageMaxDays
+	}
+	if req.GapThreshold < 0 {
+		req.GapThreshold = 0
+	}
+}
+
+// coverageGapThreshold returns the request's gap threshold if set, else the
+// source's crawl interval, else a day.
+func coverageGapThreshold(req *domain.CoverageRequest, intervals domain.CrawlIntervals, source string) time.Duration {
+	if req.GapThreshold > 0 {
+		return req.GapThreshold
+	}
+	if every, ok := intervals[source]; ok && every > 0 {
+		return every
+	}
+	return coverageDefaultGap
+}
+
+// buildCoverageQuery builds the per-source min/max + daily histogram aggregation.
+func buildCoverageQuery(req *domain.CoverageRequest, now time.Time) map[string]any {
+	windowStart := now.AddDate(0, 0, -(req.Days - 1)).Format(coverageDateLayout)
+	windowEnd := now.Format(coverageDateLayout)
+
+	query := map[string]any{"match_all": map[string]any{}}
+	if req.Source != "" {
+		query = map[string]any{
+			"term": map[string]any{"source_name.keyword": req.Source},
+		}
+	}
+
+	return map[string]any{
+		"size":  0,
+		"query": query,
+		"aggs": map[string]any{
+			"sources": map[string]any{
+				"terms": map[string]any{
+					"field": "source_name.keyword",
+					"size":  coverageMaxSources,
+				},
+				"aggs": map[string]any{
+					"oldest": map[string]any{"min": map[string]any{"field": coverageIndexedDateField}},
+					"newest": map[string]any{"max": map[string]any{"field": coverageIndexedDateField}},
+					"window": map[string]any{
+						"filter": map[string]any{
+							"range": map[string]any{
+								coverageIndexedDateField: map[string]any{
+									"gte":    windowStart,
+									"format": "yyyy-MM-dd",
+								},
+							},
+						},
+						"aggs": map[string]any{
+							"per_day": map[string]any{
+								"date_histogram": map[string]any{
+									"field":             coverageIndexedDateField,
+									"calendar_interval": "day",
+									"format":            "yyyy-MM-dd",
+									"min_doc_count":     0,
+									"extended_bounds": map[string]any{
+										"min": windowStart,
+										"max": windowEnd,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// coverageAggResponse mirrors the aggregation shape produced by buildCoverageQuery.
+type coverageAggResponse struct {
+	Aggregations struct {
+		Sources struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount int64  `json:"doc_count"`
+				Oldest   struct {
+					ValueAsString string `json:"value_as_string"`
+				} `json:"oldest"`
+				Newest struct {
+					ValueAsString string `json:"value_as_string"`
+				} `json:"newest"`
+				Window struct {
+					PerDay struct {
+						Buckets []struct {
+							KeyAsString string `json:"key_as_string"`
+							DocCount    int64  `json:"doc_count"`
+						} `json:"buckets"`
+					} `json:"per_day"`
+				} `json:"window"`
+			} `json:"buckets"`
+		} `json:"sources"`
+	} `json:"aggregations"`
+}
+
+// parseCoverageResponse converts the aggregation response into per-source coverage.
+func parseCoverageResponse(
+	body io.Reader,
+	req *domain.CoverageRequest,
+	intervals domain.CrawlIntervals,
+	now time.Time,
+) ([]*domain.SourceCoverage, error) {
+	var esResponse coverageAggResponse
+	if err := json.NewDecoder(body).Decode(&esResponse); err != nil {
+		return nil, fmt.Errorf("decode coverage response: %w", err)
+	}
+
+	out := make([]*domain.SourceCoverage, 0, len(esResponse.Aggregations.Sources.Buckets))
+	for _, bucket := range esResponse.Aggregations.Sources.Buckets {
+		cov := &domain.SourceCoverage{
+			SourceName:     bucket.Key,
+			TotalDocuments: bucket.DocCount,
+			OldestIndexed:  parseAggTime(bucket.Oldest.ValueAsString),
+			NewestIndexed:  parseAggTime(bucket.Newest.ValueAsString),
+			DocsPerDay:     make([]domain.DailyCount, 0, len(bucket.Window.PerDay.Buckets)),
+		}
+		for _, day := range bucket.Window.PerDay.Buckets {
+			cov.DocsPerDay = append(cov.DocsPerDay, domain.DailyCount{Date: day.KeyAsString, Count: day.DocCount})
+		}
+		threshold := coverageGapThreshold(req, intervals, bucket.Key)
+		cov.GapThresholdHours = threshold.Hours()
+		cov.Gaps = findCoverageGaps(cov.DocsPerDay, threshold)
+		cov.Stale = cov.NewestIndexed == nil || now.Sub(*cov.NewestIndexed) > threshold
+		out = append(out, cov)
+	}
+
+	// Stale sources first, then alphabetical, so editors see problems at the top.
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Stale != out[j].Stale {
+			return out[i].Stale
+		}
+		return out[i].SourceName < out[j].SourceName
+	})
+
+	return out, nil
+}
+
+// findCoverageGaps returns runs of empty days whose span exceeds threshold.
+func findCoverageGaps(days []domain.DailyCount, threshold time.Duration) []domain.CoverageGap {
+	gaps := make([]domain.CoverageGap, 0)
+	runStart := -1
+
+	flush := func(end int) {
+		if runStart < 0 {
+			return
+		}
+		length := end - runStart
+		if time.Duration(length*hoursPerDay)*time.Hour > threshold {
+			gaps = append(gaps, domain.CoverageGap{
+				From: days[runStart].Date,
+				To:   days[end-1].Date,
+				Days: length,
+			})
+		}
+		runStart = -1
+	}
+
+	for i, day := range days {
+		if day.Count == 0 {
+			if runStart < 0 {
+				runStart = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(days))
+
+	return gaps
+}
+
+// parseAggTime parses a min/max aggregation value_as_string; returns nil when absent.
+func parseAggTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+func TestFindCoverageGaps(t *testing.T) {
+	days := []domain.DailyCount{
+		{Date: "2026-10-01", Count: 5},
+		{Date: "2026-10-02", Count: 0},
+		{Date: "2026-10-03", Count: 4},
+		{Date: "2026-10-04", Count: 0},
+		{Date: "2026-10-05", Count: 0},
+		{Date: "2026-10-06", Count: 0},
+		{Date: "2026-10-07", Count: 2},
+		{Date: "2026-10-08", Count: 0},
+		{Date: "2026-10-09", Count: 0},
+	}
+
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		wantGaps  []domain.CoverageGap
+	}{
+		{
+			name:      "daily interval ignores single empty day",
+			threshold: 24 * time.Hour,
+			wantGaps: []domain.CoverageGap{
+				{From: "2026-10-04", To: "2026-10-06", Days: 3},
+				{From: "2026-10-08", To: "2026-10-09", Days: 2},
+			},
+		},
+		{
+			name:      "hourly interval reports every empty day",
+			threshold: time.Hour,
+			wantGaps: []domain.CoverageGap{
+				{From: "2026-10-02", To: "2026-10-02", Days: 1},
+				{From: "2026-10-04", To: "2026-10-06", Days: 3},
+				{From: "2026-10-08", To: "2026-10-09", Days: 2},
+			},
+		},
+		{
+			name:      "weekly interval reports nothing",
+			threshold: 7 * 24 * time.Hour,
+			wantGaps:  []domain.CoverageGap{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.FindCoverageGaps(days, tt.threshold)
+			if len(got) != len(tt.wantGaps) {
+				t.Fatalf("got %d gaps (%+v), want %d", len(got), got, len(tt.wantGaps))
+			}
+			for i := range got {
+				if got[i] != tt.wantGaps[i] {
+					t.Errorf("gap %d = %+v, want %+v", i, got[i], tt.wantGaps[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCoverageResponse_StaleSourcesFirst(t *testing.T) {
+	body := `{"aggregations":{"sources":{"buckets":[
+		{"key":"fresh_news","doc_count":10,
+		 "oldest":{"value_as_string":"2026-09-01T00:00:00.000Z"},
+		 "newest":{"value_as_string":"2026-10-09T20:00:00.000Z"},
+		 "window":{"per_day":{"buckets":[
+			{"key_as_string":"2026-10-08","doc_count":4},
+			{"key_as_string":"2026-10-09","doc_count":6}]}}},
+		{"key":"quiet_times","doc_count":3,
+		 "oldest":{"value_as_string":"2026-08-01T00:00:00.000Z"},
+		 "newest":{"value_as_string":"2026-10-01T00:00:00.000Z"},
+		 "window":{"per_day":{"buckets":[
+			{"key_as_string":"2026-10-08","doc_count":0},
+			{"key_as_string":"2026-10-09","doc_count":0}]}}}
+	]}}}`
+
+	req := &domain.CoverageRequest{Days: 30, GapThreshold: 24 * time.Hour}
+	now := time.Date(2026, 10, 9, 23, 0, 0, 0, time.UTC)
+
+	sources, err := service.ParseCoverageResponse(strings.NewReader(body), req, nil, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sources) != 2 {
+		t.Fatalf("got %d sources, want 2", len(sources))
+	}
+
+	if sources[0].SourceName != "quiet_times" || !sources[0].Stale {
+		t.Errorf("expected stale quiet_times first, got %+v", sources[0])
+	}
+	if len(sources[0].Gaps) != 1 {
+		t.Errorf("quiet_times gaps = %+v, want 1", sources[0].Gaps)
+	}
+	if sources[1].Stale {
+		t.Error("fresh_news should not be stale")
+	}
+	if sources[1].OldestIndexed == nil || sources[1].OldestIndexed.Month() != time.September {
+		t.Errorf("fresh_news oldest = %v", sources[1].OldestIndexed)
+	}
+}
+
+func TestParseCoverageResponse_ThresholdFollowsCrawlInterval(t *testing.T) {
+	bucket := func(name string) string {
+		return `{"key":"` + name + `","doc_count":6,
+		 "oldest":{"value_as_string":"2026-09-01T00:00:00.000Z"},
+		 "newest":{"value_as_string":"2026-10-06T12:00:00.000Z"},
+		 "window":{"per_day":{"buckets":[
+			{"key_as_string":"2026-10-06","doc_count":6},
+			{"key_as_string":"2026-10-07","doc_count":0},
+			{"key_as_string":"2026-10-08","doc_count":0},
+			{"key_as_string":"2026-10-09","doc_count":0}]}}}`
+	}
+	body := `{"aggregations":{"sources":{"buckets":[` +
+		bucket("hourly_news") + `,` + bucket("weekly_digest") + `,` + bucket("unscheduled") + `]}}}`
+	intervals := domain.CrawlIntervals{
+		"hourly_news":   time.Hour,
+		"weekly_digest": 7 * 24 * time.Hour,
+	}
+	now := time.Date(2026, 10, 9, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		want      map[string]struct {
+			hours float64
+			gaps  int
+			stale bool
+		}
+	}{
+		{
+			name: "each source uses its crawl interval",
+			want: map[string]struct {
+				hours float64
+				gaps  int
+				stale bool
+			}{
+				"hourly_news":   {hours: 1, gaps: 1, stale: true},
+				"weekly_digest": {hours: 168, gaps: 0, stale: false},
+				"unscheduled":   {hours: 24, gaps: 1, stale: true},
+			},
+		},
+		{
+			name:      "gap_hours overrides every interval",
+			threshold: 96 * time.Hour,
+			want: map[string]struct {
+				hours float64
+				gaps  int
+				stale bool
+			}{
+				"hourly_news":   {hours: 96, gaps: 0, stale: false},
+				"weekly_digest": {hours: 96, gaps: 0, stale: false},
+				"unscheduled":   {hours: 96, gaps: 0, stale: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &domain.CoverageRequest{Days: 30, GapThreshold: tt.threshold}
+			sources, err := service.ParseCoverageResponse(strings.NewReader(body), req, intervals, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(sources) != len(tt.want) {
+				t.Fatalf("got %d sources, want %d", len(sources), len(tt.want))
+			}
+			for _, src := range sources {
+				want := tt.want[src.SourceName]
+				if src.GapThresholdHours != want.hours || len(src.Gaps) != want.gaps || src.Stale != want.stale {
+					t.Errorf("%s: threshold %vh, %d gaps, stale %v; want %vh, %d gaps, stale %v",
+						src.SourceName, src.GapThresholdHours, len(src.Gaps), src.Stale,
+						want.hours, want.gaps, want.stale)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// ErrCursorExpired is returned for cursors whose point in time has closed:
+// the walk went unused for longer than the cursor keep-alive, or ended.
+var ErrCursorExpired = errors.New("cursor has expired, start a new cursor walk")
+
+// errCursorMismatch rejects a cursor presented with a different search.
+var errCursorMismatch = errors.New("cursor belongs to a different search")
+
+// openCursor resolves the cursor of req: "*" opens a point in time on the
+// caller's indexes, any other token continues the walk it was issued for.
+// Continued pages leave out facets, which the first page already counted.
+func (s *SearchService) openCursor(ctx context.Context, req *domain.SearchRequest) (*domain.Cursor, error) {
+	search := req.CursorSearch(s.searchIndex(ctx))
+
+	if req.Pagination.Cursor == domain.CursorStart {
+		pit, err := s.openPointInTime(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.Cursor{PIT: pit, Search: search}, nil
+	}
+
+	cursor, err := domain.DecodeCursor(req.Pagination.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+	if cursor.Search != search {
+		return nil, fmt.Errorf("validation error: %w", errCursorMismatch)
+	}
+	req.Pagination.Page = cursor.Page + 1
+	req.Options.IncludeFacets = false
+	return cursor, nil
+}
+
+// applyCursor makes esQuery read the page after cursor from its point in
+// time. A point in time names its indexes, and search_after cannot be
+// combined with from or with collapsing on another field than the sort, so
+// cursor pages are not deduplicated by title.
+func (s *SearchService) applyCursor(esQuery map[string]any, cursor *domain.Cursor) {
+	delete(esQuery, "from")
+	delete(esQuery, "collapse")
+	esQuery["pit"] = map[string]any{
+		"id":         cursor.PIT,
+		"keep_alive": esDuration(s.config.Service.CursorKeepAlive),
+	}
+	if len(cursor.After) > 0 {
+		esQuery["search_after"] = cursor.After
+	}
+}
+
+// nextCursor returns the token of the page after response, or "" after the
+// last page, when the point in time is closed.
+func (s *SearchService) nextCursor(ctx context.Context, cursor *domain.Cursor, response *domain.SearchResponse) string {
+	cursor.Page = response.CurrentPage
+	last := len(response.Hits) < response.PageSize ||
+		int64(cursor.Page)*int64(response.PageSize) >= response.TotalHits
+	if last || len(cursor.After) == 0 {
+		s.closePointInTime(ctx, cursor.PIT)
+		return ""
+	}
+
+	token, err := cursor.Encode()
+	if err != nil {
+		s.logger.Warn("Failed to encode search cursor", infralogger.Error(err))
+		return ""
+	}
+	return token
+}
+
+// cursorError reports a failed continuation of a walk whose point in time
+// Elasticsearch no longer has as ErrCursorExpired.
+func cursorError(cursor *domain.Cursor, err error) error {
+	if cursor != nil && cursor.Page > 0 && strings.Contains(err.Error(), "search_context_missing_exception") {
+		return ErrCursorExpired
+	}
+	return err
+}
+
+// openPointInTime opens a point in time on the indexes searches with ctx run against.
+func (s *SearchService) openPointInTime(ctx context.Context) (string, error) {
+	esClient := s.esClient.GetESClient()
+	res, err := esClient.OpenPointInTime(
+		[]string{s.searchIndex(ctx)},
+		esDuration(s.config.Service.CursorKeepAlive),
+		esClient.OpenPointInTime.WithContext(ctx),
+	)
+	if err != nil {
+		return "", fmt.Errorf("open point in time: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return "", fmt.Errorf("open point in time returned error [%d]: %s", res.StatusCode, string(body))
+	}
+
+	var pit struct {
+		ID string `json:"id"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&pit); decodeErr != nil {
+		return "", fmt.Errorf("decode point in time: %w", decodeErr)
+	}
+	return pit.ID, nil
+}
+
+// closePointInTime releases a finished walk's point in time. Failures are
+// only logged: the point in time expires after the keep-alive anyway.
+func (s *SearchService) closePointInTime(ctx context.Context, pit string) {
+	body, err := json.Marshal(map[string]string{"id": pit})
+	if err != nil {
+		return
+	}
+
+	esClient := s.esClient.GetESClient()
+	res, err := esClient.ClosePointInTime(
+		esClient.ClosePointInTime.WithContext(ctx),
+		esClient.ClosePointInTime.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		s.logger.Warn("Failed to close point in time", infralogger.Error(err))
+		return
+	}
+	_ = res.Body.Close()
+}
+
+// esDuration formats d in Elasticsearch time units.
+func esDuration(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+}
@@ -0,0 +1,155 @@
+//nolint:testpackage // White-box test for cursor pagination helpers
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+func newCursorTestService() *SearchService {
+	return &SearchService{
+		config: &config.Config{
+			Service:       config.ServiceConfig{CursorKeepAlive: 5 * time.Minute},
+			Elasticsearch: config.ElasticsearchConfig{ClassifiedContentPattern: "*_classified_content"},
+		},
+		logger: infralogger.NewNop(),
+	}
+}
+
+func newCursorRequest(cursor string) *domain.SearchRequest {
+	return &domain.SearchRequest{
+		Query:      "wildfire",
+		Pagination: &domain.Pagination{Page: 1, Size: 2, Cursor: cursor},
+		Sort:       &domain.Sort{Field: "relevance", Order: "desc"},
+		Options:    &domain.Options{IncludeFacets: true},
+	}
+}
+
+func TestOpenCursor_Continues(t *testing.T) {
+	t.Helper()
+
+	s := newCursorTestService()
+	req := newCursorRequest("")
+	token, err := (&domain.Cursor{
+		PIT:    "pit-1",
+		After:  []json.RawMessage{json.RawMessage(`2.5`), json.RawMessage(`17`)},
+		Page:   3,
+		Search: req.CursorSearch("*_classified_content"),
+	}).Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	req.Pagination.Cursor = token
+
+	cursor, err := s.openCursor(context.Background(), req)
+	if err != nil {
+		t.Fatalf("openCursor: %v", err)
+	}
+	if cursor.PIT != "pit-1" || req.Pagination.Page != 4 {
+		t.Errorf("pit %q page %d, want pit-1 page 4", cursor.PIT, req.Pagination.Page)
+	}
+	if req.Options.IncludeFacets {
+		t.Error("continued pages should not count facets")
+	}
+}
+
+func TestOpenCursor_Rejects(t *testing.T) {
+	t.Helper()
+
+	s := newCursorTestService()
+	other := newCursorRequest("")
+	other.Query = "flood"
+	token, err := (&domain.Cursor{
+		PIT:    "pit-1",
+		After:  []json.RawMessage{json.RawMessage(`1`)},
+		Page:   1,
+		Search: other.CursorSearch("*_classified_content"),
+	}).Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	for name, cursor := range map[string]string{"other search": token, "garbage": "not-a-cursor"} {
+		_, openErr := s.openCursor(context.Background(), newCursorRequest(cursor))
+		if openErr == nil || !strings.Contains(openErr.Error(), "validation") {
+			t.Errorf("%s: want validation error, got %v", name, openErr)
+		}
+	}
+}
+
+func TestApplyCursor(t *testing.T) {
+	t.Helper()
+
+	esQuery := map[string]any{"from": 0, "size": 2, "collapse": map[string]any{"field": "title.keyword"}}
+	cursor := &domain.Cursor{PIT: "pit-1", After: []json.RawMessage{json.RawMessage(`1`)}}
+	newCursorTestService().applyCursor(esQuery, cursor)
+
+	if _, ok := esQuery["from"]; ok {
+		t.Error("cursor queries must not set from")
+	}
+	if _, ok := esQuery["collapse"]; ok {
+		t.Error("cursor queries must not collapse")
+	}
+	pit, ok := esQuery["pit"].(map[string]any)
+	if !ok || pit["id"] != "pit-1" || pit["keep_alive"] != "300s" {
+		t.Errorf("pit = %v", esQuery["pit"])
+	}
+	if _, ok := esQuery["search_after"]; !ok {
+		t.Error("continued cursor should set search_after")
+	}
+}
+
+func TestParseSearchResponse_AdvancesCursor(t *testing.T) {
+	t.Helper()
+
+	body := `{
+		"pit_id": "pit-2",
+		"hits": {"total": {"value": 5}, "hits": [
+			{"_id": "a", "_score": 3.1, "_source": {"title": "A"}, "sort": [3.1, 10]},
+			{"_id": "b", "_score": 2.5, "_source": {"title": "B"}, "sort": [2.5, 9223372036854775807]}
+		]}
+	}`
+	s := newCursorTestService()
+	req := newCursorRequest(domain.CursorStart)
+	cursor := &domain.Cursor{PIT: "pit-1", Search: "s"}
+
+	resp, err := s.parseSearchResponse(strings.NewReader(body), req, nil, cursor)
+	if err != nil {
+		t.Fatalf("parseSearchResponse: %v", err)
+	}
+	if cursor.PIT != "pit-2" {
+		t.Errorf("PIT = %q, want the refreshed pit-2", cursor.PIT)
+	}
+	if len(cursor.After) != 2 || string(cursor.After[1]) != "9223372036854775807" {
+		t.Errorf("After = %s, want the last hit's sort values", cursor.After)
+	}
+
+	next := s.nextCursor(context.Background(), cursor, resp)
+	decoded, err := domain.DecodeCursor(next)
+	if err != nil {
+		t.Fatalf("next cursor: %v", err)
+	}
+	if decoded.Page != 1 || decoded.PIT != "pit-2" {
+		t.Errorf("next cursor page %d pit %q, want 1 pit-2", decoded.Page, decoded.PIT)
+	}
+}
+
+func TestCursorError(t *testing.T) {
+	t.Helper()
+
+	missing := errors.New(`elasticsearch returned error [404]: {"error":{"type":"search_context_missing_exception"}}`)
+	if got := cursorError(&domain.Cursor{Page: 2}, missing); !errors.Is(got, ErrCursorExpired) {
+		t.Errorf("continued walk: want ErrCursorExpired, got %v", got)
+	}
+	if got := cursorError(nil, missing); errors.Is(got, ErrCursorExpired) {
+		t.Error("page-numbered searches should keep the original error")
+	}
+}
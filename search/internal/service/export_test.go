@@ -8,3 +8,36 @@ const (
 	ExportedPipelineFeedMinQuality = pipelineFeedMinQuality
 	ExportedTopicFeedMinQuality    = topicFeedMinQuality
 )
+
+// FindCoverageGaps exposes findCoverageGaps for external tests.
+var FindCoverageGaps = findCoverageGaps
+
+// ParseCoverageResponse exposes parseCoverageResponse for external tests.
+var ParseCoverageResponse = parseCoverageResponse
+
+// NormalizeTrendingRequest exposes normalizeTrendingRequest for external tests.
+var NormalizeTrendingRequest = normalizeTrendingRequest
+
+// BuildTrendingQuery exposes buildTrendingQuery for external tests.
+var BuildTrendingQuery = buildTrendingQuery
+
+// ParseTrendingResponse exposes parseTrendingResponse for external tests.
+var ParseTrendingResponse = parseTrendingResponse
+
+// BuildWidgetRequest exposes buildWidgetRequest for external tests.
+var BuildWidgetRequest = buildWidgetRequest
+
+// ToWidgetItems exposes toWidgetItems for external tests.
+var ToWidgetItems = toWidgetItems
+
+// NormalizeRelatedRequest exposes normalizeRelatedRequest for external tests.
+var NormalizeRelatedRequest = normalizeRelatedRequest
+
+// BuildRelatedQuery exposes buildRelatedQuery for external tests.
+var BuildRelatedQuery = buildRelatedQuery
+
+// ParseRelatedResponse exposes parseRelatedResponse for external tests.
+var ParseRelatedResponse = parseRelatedResponse
+
+// NormalizeSynonymsRequest exposes normalizeSynonymsRequest for external tests.
+var NormalizeSynonymsRequest = normalizeSynonymsRequest
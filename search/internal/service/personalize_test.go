@@ -0,0 +1,72 @@
+//nolint:testpackage // White-box test for personalize, which mutates the built query
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+type fakeProfiles struct {
+	profile *domain.EngagementProfile
+	err     error
+	calls   int
+}
+
+func (f *fakeProfiles) Profile(context.Context, string) (*domain.EngagementProfile, error) {
+	f.calls++
+	return f.profile, f.err
+}
+
+func personalizeRequest(personalize bool) *domain.SearchRequest {
+	return &domain.SearchRequest{
+		Query:      "fire",
+		Filters:    &domain.Filters{},
+		Pagination: &domain.Pagination{Page: 1, Size: 10},
+		Sort:       &domain.Sort{Field: "relevance", Order: "desc"},
+		Options:    &domain.Options{SessionID: "sess_1", Personalize: personalize},
+	}
+}
+
+func TestPersonalize(t *testing.T) {
+	t.Helper()
+
+	profiles := &fakeProfiles{profile: &domain.EngagementProfile{
+		Sources: []domain.Affinity{{Value: "sudbury_star", Boost: 0.5}},
+	}}
+	s := NewSearchService(nil, &config.Config{}, infralogger.NewNop(), nil).WithPersonalization(profiles)
+
+	req := personalizeRequest(true)
+	query := s.queryBuilder.Build(req)
+	if !s.personalize(context.Background(), req, query) {
+		t.Fatal("expected the search to be personalized")
+	}
+	if _, ok := query["query"].(map[string]any)["function_score"]; !ok {
+		t.Errorf("expected a function_score query, got %v", query["query"])
+	}
+
+	req = personalizeRequest(false)
+	if s.personalize(context.Background(), req, s.queryBuilder.Build(req)) || profiles.calls != 1 {
+		t.Errorf("searches without personalize should not read a profile (%d calls)", profiles.calls)
+	}
+}
+
+func TestPersonalize_ProfileErrorDoesNotFailSearch(t *testing.T) {
+	t.Helper()
+
+	profiles := &fakeProfiles{err: errors.New("click-tracker unavailable")}
+	s := NewSearchService(nil, &config.Config{}, infralogger.NewNop(), nil).WithPersonalization(profiles)
+
+	req := personalizeRequest(true)
+	query := s.queryBuilder.Build(req)
+	if s.personalize(context.Background(), req, query) {
+		t.Error("a failed profile should leave the search unpersonalized")
+	}
+	if _, ok := query["query"].(map[string]any)["bool"]; !ok {
+		t.Errorf("expected the query to be untouched, got %v", query["query"])
+	}
+}
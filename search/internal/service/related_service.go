@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	relatedDefaultDays  = 30
+	relatedMaxDays      = 365
+	relatedDefaultLimit = 5
+	relatedMaxLimit     = 20
+
+	// More-like-this tuning: terms must appear in at least two documents,
+	// and a related article must share 30% of the selected terms.
+	relatedMaxQueryTerms      = 25
+	relatedMinDocFreq         = 2
+	relatedMinimumShouldMatch = "30%"
+)
+
+// relatedFields are compared by more-like-this. The classifier stores no
+// named entities, so topics stand in for them.
+var relatedFields = []string{"title", "body", "topics"}
+
+// ErrArticleNotFound is returned when the article a lookup starts from is not indexed.
+var ErrArticleNotFound = errors.New("article not found")
+
+// RelatedArticles returns recent articles similar to the given one: a
+// more-like-this query over title, body and topics, limited to the last
+// req.Days days and collapsed by title so syndicated copies appear once.
+func (s *SearchService) RelatedArticles(
+	ctx context.Context,
+	req *domain.RelatedRequest,
+) (*domain.RelatedResponse, error) {
+	startTime := time.Now()
+	if err := normalizeRelatedRequest(req); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	article, err := s.findArticle(ctx, req.ArticleID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.executeSearch(ctx, buildRelatedQuery(article, req))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	hits, err := parseRelatedResponse(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := s.reputationSnapshot()
+	for _, hit := range hits {
+		if score, ok := scores.Score(hit.SourceName); ok {
+			hit.SourceReputation = &score
+		}
+	}
+	if s.clickSigner != nil {
+		s.addClickURLs(hits, generateQueryID(), 1, req.SessionID)
+	}
+
+	return &domain.RelatedResponse{
+		ArticleID:  req.ArticleID,
+		WindowDays: req.Days,
+		Hits:       hits,
+		TookMs:     time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// normalizeRelatedRequest applies defaults and bounds to a related-articles request.
+func normalizeRelatedRequest(req *domain.RelatedRequest) error {
+	if req.ArticleID == "" {
+		return errors.New("article id is required")
+	}
+	if req.SessionID != "" && !domain.ValidSessionID(req.SessionID) {
+		return errors.New("session_id must be 1-32 characters of [A-Za-z0-9_-]")
+	}
+	if req.Days <= 0 {
+		req.Days = relatedDefaultDays
+	}
+	if req.Days > relatedMaxDays {
+		req.Days = relatedMaxDays
+	}
+	if req.Limit <= 0 {
+		req.Limit = relatedDefaultLimit
+	}
+	if req.Limit > relatedMaxLimit {
+		req.Limit = relatedMaxLimit
+	}
+	return nil
+}
+
+// findArticle looks up the index and title of an article by document ID.
+func (s *SearchService) findArticle(ctx context.Context, id string) (*domain.RelatedArticle, error) {
+	res, err := s.executeSearch(ctx, map[string]any{
+		"query":   map[string]any{"ids": map[string]any{"values": []string{id}}},
+		"size":    1,
+		"_source": []string{"title"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	var body struct {
+		Hits struct {
+			Hits []struct {
+				ID     string `json:"_id"`
+				Index  string `json:"_index"`
+				Source struct {
+					Title string `json:"title"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if decodeErr := json.NewDecoder(res.Body).Decode(&body); decodeErr != nil {
+		return nil, fmt.Errorf("decode article lookup: %w", decodeErr)
+	}
+	if len(body.Hits.Hits) == 0 {
+		return nil, ErrArticleNotFound
+	}
+
+	hit := body.Hits.Hits[0]
+	return &domain.RelatedArticle{ID: hit.ID, Index: hit.Index, Title: hit.Source.Title}, nil
+}
+
+// buildRelatedQuery builds the more-like-this query for an article. Copies
+// of the article under the same title (wire stories) are excluded, and the
+// rest are collapsed by title. Articles without a published date are dated
+// by when they were crawled.
+func buildRelatedQuery(article *domain.RelatedArticle, req *domain.RelatedRequest) map[string]any {
+	since := "now-" + strconv.Itoa(req.Days) + "d/d"
+
+	mustNot := []any{
+		map[string]any{"ids": map[string]any{"values": []string{article.ID}}},
+	}
+	if article.Title != "" {
+		mustNot = append(mustNot, map[string]any{"term": map[string]any{"title.keyword": article.Title}})
+	}
+
+	return map[string]any{
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": []any{
+					map[string]any{
+						"more_like_this": map[string]any{
+							"fields":               relatedFields,
+							"like":                 []any{map[string]any{"_index": article.Index, "_id": article.ID}},
+							"min_term_freq":        1,
+							"min_doc_freq":         relatedMinDocFreq,
+							"max_query_terms":      relatedMaxQueryTerms,
+							"minimum_should_match": relatedMinimumShouldMatch,
+						},
+					},
+				},
+				"filter": []any{
+					map[string]any{
+						"bool": map[string]any{
+							"should": []any{
+								map[string]any{"range": map[string]any{"published_date": map[string]any{"gte": since}}},
+								map[string]any{"bool": map[string]any{
+									"must_not": map[string]any{"exists": map[string]any{"field": "published_date"}},
+									"filter":   map[string]any{"range": map[string]any{"crawled_at": map[string]any{"gte": since}}},
+								}},
+							},
+							"minimum_should_match": 1,
+						},
+					},
+				},
+				"must_not": mustNot,
+			},
+		},
+		"collapse": map[string]any{"field": "title.keyword"},
+		"size":     req.Limit,
+		"_source": []string{
+			"id", "title", "url", "source_name",
+			"published_date", "crawled_at",
+			"quality_score", "content_type", "topics",
+			"raw_text", "body", "og_image", "language",
+		},
+	}
+}
+
+// parseRelatedResponse converts related-article hits to search hits.
+func parseRelatedResponse(body io.Reader) ([]*domain.SearchHit, error) {
+	var esResponse struct {
+		Hits struct {
+			Hits []struct {
+				ID     string                   `json:"_id"`
+				Score  float64                  `json:"_score"`
+				Source domain.ClassifiedContent `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(body).Decode(&esResponse); err != nil {
+		return nil, fmt.Errorf("decode related articles response: %w", err)
+	}
+
+	hits := make([]*domain.SearchHit, 0, len(esResponse.Hits.Hits))
+	for i := range esResponse.Hits.Hits {
+		hit := &esResponse.Hits.Hits[i]
+		if hit.Source.ID == "" {
+			hit.Source.ID = hit.ID
+		}
+		hits = append(hits, hit.Source.ToSearchHit(hit.Score, nil))
+	}
+	return hits, nil
+}
@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+func TestNormalizeRelatedRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       domain.RelatedRequest
+		wantDays  int
+		wantLimit int
+		wantErr   bool
+	}{
+		{"defaults", domain.RelatedRequest{ArticleID: "a1"}, 30, 5, false},
+		{"caps", domain.RelatedRequest{ArticleID: "a1", Days: 1000, Limit: 500}, 365, 20, false},
+		{"keeps valid values", domain.RelatedRequest{ArticleID: "a1", Days: 7, Limit: 3}, 7, 3, false},
+		{"requires article", domain.RelatedRequest{}, 0, 0, true},
+		{"rejects bad session", domain.RelatedRequest{ArticleID: "a1", SessionID: "not a session!"}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			err := service.NormalizeRelatedRequest(&req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.Days != tt.wantDays || req.Limit != tt.wantLimit {
+				t.Errorf("days, limit = %d, %d; want %d, %d", req.Days, req.Limit, tt.wantDays, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestBuildRelatedQuery(t *testing.T) {
+	article := &domain.RelatedArticle{ID: "a1", Index: "cbc_classified_content", Title: "Mine expansion approved"}
+	req := &domain.RelatedRequest{ArticleID: "a1", Days: 14, Limit: 5}
+
+	query := service.BuildRelatedQuery(article, req)
+	boolQuery := query["query"].(map[string]any)["bool"].(map[string]any)
+
+	mlt := boolQuery["must"].([]any)[0].(map[string]any)["more_like_this"].(map[string]any)
+	like := mlt["like"].([]any)[0].(map[string]any)
+	if like["_index"] != article.Index || like["_id"] != article.ID {
+		t.Errorf("like = %v, want the article's index and id", like)
+	}
+
+	mustNot := boolQuery["must_not"].([]any)
+	if len(mustNot) != 2 {
+		t.Fatalf("must_not = %v, want the article id and its title", mustNot)
+	}
+	titleTerm := mustNot[1].(map[string]any)["term"].(map[string]any)
+	if titleTerm["title.keyword"] != article.Title {
+		t.Errorf("must_not title = %v, want %q", titleTerm, article.Title)
+	}
+
+	window := boolQuery["filter"].([]any)[0].(map[string]any)["bool"].(map[string]any)["should"].([]any)
+	published := window[0].(map[string]any)["range"].(map[string]any)["published_date"].(map[string]any)
+	if published["gte"] != "now-14d/d" {
+		t.Errorf("published_date gte = %v, want now-14d/d", published["gte"])
+	}
+
+	if collapse := query["collapse"].(map[string]any); collapse["field"] != "title.keyword" {
+		t.Errorf("collapse = %v, want title.keyword", collapse)
+	}
+	if query["size"] != 5 {
+		t.Errorf("size = %v, want 5", query["size"])
+	}
+}
+
+func TestBuildRelatedQuery_UntitledArticle(t *testing.T) {
+	article := &domain.RelatedArticle{ID: "a1", Index: "cbc_classified_content"}
+	query := service.BuildRelatedQuery(article, &domain.RelatedRequest{ArticleID: "a1", Days: 30, Limit: 5})
+
+	mustNot := query["query"].(map[string]any)["bool"].(map[string]any)["must_not"].([]any)
+	if len(mustNot) != 1 {
+		t.Errorf("must_not = %v, want only the article id", mustNot)
+	}
+}
+
+func TestParseRelatedResponse(t *testing.T) {
+	body := `{"hits":{"hits":[
+		{"_id":"b2","_score":4.2,"_source":{"title":"Mine expansion opposed","url":"https://example.com/b2","source_name":"ctv","raw_text":"Residents oppose the expansion."}},
+		{"_id":"c3","_score":2.1,"_source":{"id":"c3-doc","title":"Council votes","url":"https://example.com/c3","source_name":"cbc"}}
+	]}}`
+
+	hits, err := service.ParseRelatedResponse(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("ParseRelatedResponse: %v", err)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("got %d hits, want 2", len(hits))
+	}
+	if hits[0].ID != "b2" || hits[0].Score != 4.2 || hits[0].Snippet == "" {
+		t.Errorf("first hit = %+v, want id b2 with score and snippet", hits[0])
+	}
+	if hits[1].ID != "c3-doc" {
+		t.Errorf("second hit id = %q, want the source id c3-doc", hits[1].ID)
+	}
+}
@@ -6,10 +6,10 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
-	"net/url"
 	"strings"
 	"time"
 
@@ -21,13 +21,40 @@ import (
 	"github.com/jonesrussell/north-cloud/search/internal/elasticsearch"
 )
 
+// ErrReputationUnavailable is returned for min_reputation searches before
+// source reputations have loaded, or when no classifier is configured.
+var ErrReputationUnavailable = errors.New("source reputation is unavailable")
+
+// ReputationSource provides cached source reputation scores.
+type ReputationSource interface {
+	Snapshot() (domain.SourceReputations, bool)
+}
+
+// ProfileSource provides the engagement profiles of search sessions.
+type ProfileSource interface {
+	Profile(ctx context.Context, sessionID string) (*domain.EngagementProfile, error)
+}
+
+// ResponseCache keeps search responses between identical requests. Lookups
+// and stores never fail a search: errors are handled as misses.
+type ResponseCache interface {
+	Get(ctx context.Context, req *domain.SearchRequest) (*domain.SearchResponse, bool)
+	Set(ctx context.Context, req *domain.SearchRequest, resp *domain.SearchResponse)
+}
+
 // SearchService orchestrates search operations
 type SearchService struct {
 	esClient     *elasticsearch.Client
 	queryBuilder *elasticsearch.QueryBuilder
 	config       *config.Config
 	logger       infralogger.Logger
-	clickSigner  *clickurl.Signer // nil if disabled
+	clickSigner  *clickurl.Signer    // nil if disabled
+	reputations  ReputationSource    // nil if no classifier is configured
+	intervals    CrawlIntervalSource // nil if no crawler is configured
+	profiles     ProfileSource       // nil if personalization is disabled
+	cache        ResponseCache       // nil if the search cache is disabled
+	analytics    QueryAnalytics      // nil if query analytics is disabled
+	quotas       QuotaLimiter        // nil if API keys are disabled
 }
 
 // NewSearchService creates a new search service
@@ -46,6 +73,62 @@ func NewSearchService(
 	}
 }
 
+// WithReputations enables the min_reputation filter and source_reputation sort.
+func (s *SearchService) WithReputations(src ReputationSource) *SearchService {
+	s.reputations = src
+	return s
+}
+
+// WithPersonalization enables re-ranking searches that ask for personalize.
+func (s *SearchService) WithPersonalization(src ProfileSource) *SearchService {
+	s.profiles = src
+	return s
+}
+
+// WithCache serves repeated searches from cache. Personalized searches are
+// ranked per session and always go to Elasticsearch.
+func (s *SearchService) WithCache(c ResponseCache) *SearchService {
+	s.cache = c
+	return s
+}
+
+// cacheable reports whether req's response can be shared with other sessions.
+// Cursor pages read a point in time only their walk can use.
+func (s *SearchService) cacheable(req *domain.SearchRequest) bool {
+	return s.cache != nil && req.Pagination.Cursor == "" && !(req.Options.Personalize && s.profiles != nil)
+}
+
+// personalize re-ranks esQuery by the session's engagement profile. Without
+// a profile source, or when the profile cannot be built, the search runs
+// unpersonalized rather than failing.
+func (s *SearchService) personalize(ctx context.Context, req *domain.SearchRequest, esQuery map[string]any) bool {
+	if !req.Options.Personalize || s.profiles == nil {
+		return false
+	}
+
+	profile, err := s.profiles.Profile(ctx, req.Options.SessionID)
+	if err != nil {
+		s.logger.Warn("Personalization unavailable, ranking without it",
+			infralogger.Error(err),
+			infralogger.String("session_id", req.Options.SessionID),
+		)
+		return false
+	}
+	return s.queryBuilder.Personalize(esQuery, req, profile)
+}
+
+// reputationSnapshot returns the cached scores, or nil before they have loaded.
+func (s *SearchService) reputationSnapshot() domain.SourceReputations {
+	if s.reputations == nil {
+		return nil
+	}
+	scores, loaded := s.reputations.Snapshot()
+	if !loaded {
+		return nil
+	}
+	return scores
+}
+
 // Search executes a search query
 func (s *SearchService) Search(ctx context.Context, req *domain.SearchRequest) (*domain.SearchResponse, error) {
 	startTime := time.Now()
@@ -59,46 +142,136 @@ func (s *SearchService) Search(ctx context.Context, req *domain.SearchRequest) (
 	}
 
 	s.logger.Info("Executing search",
-		infralogger.String("query", req.Query),
+		infralogger.String("query_hash", domain.QueryHash(req.Query)),
 		infralogger.Int("page", req.Pagination.Page),
 		infralogger.Int("size", req.Pagination.Size),
 	)
 
-	// Build Elasticsearch query
-	esQuery := s.queryBuilder.Build(req)
+	// Reputation filtering needs scores; sorting falls back to relevance order without them
+	scores := s.reputationSnapshot()
+	if req.Filters.MinReputation > 0 && scores == nil {
+		return nil, ErrReputationUnavailable
+	}
+
+	if s.cacheable(req) {
+		if cached, ok := s.cache.Get(ctx, req); ok {
+			cached.TookMs = time.Since(startTime).Milliseconds()
+			cached.Cached = true
+			s.addSearchClickURLs(cached, req)
+			s.logger.Info("Search served from cache",
+				infralogger.String("query_hash", domain.QueryHash(req.Query)),
+				infralogger.Int64("total_hits", cached.TotalHits),
+			)
+			s.trackQuery(req, cached)
+			return cached, nil
+		}
+	}
+
+	response, err := s.runSearch(ctx, req, scores)
+	if err != nil {
+		return nil, err
+	}
+	if response.TotalHits == 0 && req.Options.AutoCorrect && response.DidYouMean != "" && req.Pagination.Cursor == "" {
+		response = s.autoCorrect(ctx, req, scores, response)
+	}
+
+	// Calculate execution time
+	response.TookMs = time.Since(startTime).Milliseconds()
+
+	// Cache before click URLs are added: they are signed per response
+	if s.cacheable(req) {
+		s.cache.Set(ctx, req, response)
+	}
+	s.addSearchClickURLs(response, req)
+
+	s.logger.Info("Search completed",
+		infralogger.String("query_hash", domain.QueryHash(req.Query)),
+		infralogger.Int64("total_hits", response.TotalHits),
+		infralogger.Int64("took_ms", response.TookMs),
+	)
+	s.trackQuery(req, response)
+
+	return response, nil
+}
+
+// runSearch builds, executes and parses the Elasticsearch query for req.
+// Cursor searches read their point in time and are not personalized: a
+// profile changing mid-walk would reorder pages already served.
+func (s *SearchService) runSearch(
+	ctx context.Context, req *domain.SearchRequest, scores domain.SourceReputations,
+) (*domain.SearchResponse, error) {
+	var cursor *domain.Cursor
+	if req.Pagination.Cursor != "" {
+		var err error
+		if cursor, err = s.openCursor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	esQuery := s.queryBuilder.BuildWithReputations(req, scores)
+	personalized := false
+	if cursor != nil {
+		s.applyCursor(esQuery, cursor)
+	} else {
+		personalized = s.personalize(ctx, req, esQuery)
+	}
 
-	// Execute search
 	res, err := s.executeSearch(ctx, esQuery)
 	if err != nil {
 		s.logger.Error("Search execution failed",
 			infralogger.Error(err),
 			infralogger.String("query", req.Query),
 		)
-		return nil, err
+		return nil, cursorError(cursor, err)
 	}
 	defer func() {
 		_ = res.Body.Close()
 	}()
 
-	// Parse response
-	response, err := s.parseSearchResponse(res.Body, req)
+	response, err := s.parseSearchResponse(res.Body, req, scores, cursor)
 	if err != nil {
 		s.logger.Error("Failed to parse search response",
 			infralogger.Error(err),
 		)
 		return nil, err
 	}
+	response.Personalized = personalized
+	if cursor != nil {
+		response.NextCursor = s.nextCursor(ctx, cursor, response)
+	}
 
-	// Calculate execution time
-	response.TookMs = time.Since(startTime).Milliseconds()
+	return response, nil
+}
 
-	s.logger.Info("Search completed",
+// autoCorrect re-runs a search that found nothing with its spelling
+// suggestion. The original response is kept when the corrected search fails
+// or finds nothing either.
+func (s *SearchService) autoCorrect(
+	ctx context.Context, req *domain.SearchRequest, scores domain.SourceReputations, original *domain.SearchResponse,
+) *domain.SearchResponse {
+	corrected := *req
+	corrected.Query = original.DidYouMean
+
+	response, err := s.runSearch(ctx, &corrected, scores)
+	if err != nil {
+		s.logger.Warn("Corrected search failed, returning the original results",
+			infralogger.Error(err),
+			infralogger.String("query", req.Query),
+			infralogger.String("corrected_query", corrected.Query),
+		)
+		return original
+	}
+	if response.TotalHits == 0 {
+		return original
+	}
+
+	s.logger.Info("Search auto-corrected",
 		infralogger.String("query", req.Query),
-		infralogger.Int64("total_hits", response.TotalHits),
-		infralogger.Int64("took_ms", response.TookMs),
+		infralogger.String("corrected_query", corrected.Query),
 	)
-
-	return response, nil
+	response.CorrectedFrom = req.Query
+	response.DidYouMean = ""
+	return response
 }
 
 const (
@@ -203,15 +376,18 @@ func (s *SearchService) executeSearch(ctx context.Context, query map[string]any)
 		)
 	}
 
-	// Execute search
+	// Execute search; a point in time names its own indexes
 	esClient := s.esClient.GetESClient()
-	res, err := esClient.Search(
+	opts := []func(*esapi.SearchRequest){
 		esClient.Search.WithContext(ctx),
-		esClient.Search.WithIndex(s.config.Elasticsearch.ClassifiedContentPattern),
 		esClient.Search.WithBody(&buf),
 		esClient.Search.WithTimeout(s.config.Service.SearchTimeout),
 		esClient.Search.WithTrackTotalHits(true),
-	)
+	}
+	if _, pit := query["pit"]; !pit {
+		opts = append(opts, esClient.Search.WithIndex(s.searchIndex(ctx)))
+	}
+	res, err := esClient.Search(opts...)
 
 	if err != nil {
 		return nil, fmt.Errorf("elasticsearch search failed: %w", err)
@@ -237,11 +413,44 @@ type aggregation struct {
 	Buckets []aggregationBucket `json:"buckets"`
 }
 
-// parseSearchResponse parses the Elasticsearch response
-func (s *SearchService) parseSearchResponse(body io.Reader, req *domain.SearchRequest) (*domain.SearchResponse, error) {
+// suggestion is one entry of a phrase suggester result: the suggester
+// returns an entry per query text with its candidate corrections, best first.
+type suggestion struct {
+	Options []suggestionOption `json:"options"`
+}
+
+type suggestionOption struct {
+	Text string `json:"text"`
+}
+
+// didYouMean returns the best correction of query, or "" when there is none
+// or it only differs from the query in case or spacing.
+func didYouMean(suggestions []suggestion, query string) string {
+	for _, entry := range suggestions {
+		if len(entry.Options) == 0 {
+			continue
+		}
+		text := entry.Options[0].Text
+		if strings.EqualFold(strings.Join(strings.Fields(text), " "), strings.Join(strings.Fields(query), " ")) {
+			return ""
+		}
+		return text
+	}
+	return ""
+}
+
+// parseSearchResponse parses the Elasticsearch response. A non-nil cursor is
+// moved past the parsed hits, onto the point in time the response returned.
+func (s *SearchService) parseSearchResponse(
+	body io.Reader,
+	req *domain.SearchRequest,
+	scores domain.SourceReputations,
+	cursor *domain.Cursor,
+) (*domain.SearchResponse, error) {
 	var esResponse struct {
-		Took int64 `json:"took"`
-		Hits struct {
+		Took  int64  `json:"took"`
+		PitID string `json:"pit_id,omitempty"`
+		Hits  struct {
 			Total struct {
 				Value int64 `json:"value"`
 			} `json:"total"`
@@ -250,9 +459,11 @@ func (s *SearchService) parseSearchResponse(body io.Reader, req *domain.SearchRe
 				Score     float64                  `json:"_score"`
 				Source    domain.ClassifiedContent `json:"_source"`
 				Highlight map[string][]string      `json:"highlight,omitempty"`
+				Sort      []json.RawMessage        `json:"sort,omitempty"`
 			} `json:"hits"`
 		} `json:"hits"`
-		Aggregations map[string]aggregation `json:"aggregations,omitempty"`
+		Aggregations map[string]aggregation  `json:"aggregations,omitempty"`
+		Suggest      map[string][]suggestion `json:"suggest,omitempty"`
 	}
 
 	if err := json.NewDecoder(body).Decode(&esResponse); err != nil {
@@ -266,6 +477,7 @@ func (s *SearchService) parseSearchResponse(body io.Reader, req *domain.SearchRe
 		CurrentPage: req.Pagination.Page,
 		PageSize:    req.Pagination.Size,
 		Hits:        make([]*domain.SearchHit, 0, len(esResponse.Hits.Hits)),
+		DidYouMean:  didYouMean(esResponse.Suggest[elasticsearch.SpellSuggestion], req.Query),
 	}
 
 	// Calculate total pages
@@ -280,13 +492,19 @@ func (s *SearchService) parseSearchResponse(body io.Reader, req *domain.SearchRe
 		}
 
 		searchHit := hit.Source.ToSearchHit(hit.Score, hit.Highlight)
+		if score, ok := scores.Score(searchHit.SourceName); ok {
+			searchHit.SourceReputation = &score
+		}
 		response.Hits = append(response.Hits, searchHit)
 	}
 
-	// Add click URLs if signer is configured
-	if s.clickSigner != nil {
-		queryID := generateQueryID()
-		s.addClickURLs(response.Hits, queryID, req.Pagination.Page)
+	if cursor != nil {
+		if esResponse.PitID != "" {
+			cursor.PIT = esResponse.PitID
+		}
+		if n := len(esResponse.Hits.Hits); n > 0 {
+			cursor.After = esResponse.Hits.Hits[n-1].Sort
+		}
 	}
 
 	// Parse facets if requested
@@ -374,30 +592,30 @@ func formatFacetLabel(key string) string {
 
 const queryIDLength = 8
 
-func (s *SearchService) addClickURLs(hits []*domain.SearchHit, queryID string, page int) {
-	baseURL := strings.TrimRight(s.config.ClickTracker.BaseURL, "/")
+// addSearchClickURLs signs click URLs for a search response under a new query ID.
+func (s *SearchService) addSearchClickURLs(response *domain.SearchResponse, req *domain.SearchRequest) {
+	if s.clickSigner == nil {
+		return
+	}
+	s.addClickURLs(response.Hits, generateQueryID(), req.Pagination.Page, req.Options.SessionID)
+}
+
+func (s *SearchService) addClickURLs(hits []*domain.SearchHit, queryID string, page int, sessionID string) {
 	now := time.Now().Unix()
 
 	for i, hit := range hits {
 		if hit.URL == "" {
 			continue
 		}
-		position := i + 1
-		params := clickurl.ClickParams{
+		hit.ClickURL = s.clickSigner.URL(s.config.ClickTracker.BaseURL, clickurl.ClickParams{
 			QueryID:        queryID,
 			ResultID:       hit.ID,
-			Position:       position,
+			Position:       i + 1,
 			Page:           page,
 			Timestamp:      now,
 			DestinationURL: hit.URL,
-		}
-		sig := s.clickSigner.Sign(params.Message())
-		hit.ClickURL = fmt.Sprintf(
-			"%s/click?q=%s&r=%s&p=%d&pg=%d&t=%d&u=%s&sig=%s",
-			baseURL, url.QueryEscape(queryID), url.QueryEscape(hit.ID),
-			position, page, now,
-			url.QueryEscape(hit.URL), sig,
-		)
+			SessionID:      sessionID,
+		})
 	}
 }
 
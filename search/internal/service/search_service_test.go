@@ -2,6 +2,7 @@
 package service
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jonesrussell/north-cloud/search/internal/domain"
@@ -146,3 +147,51 @@ func TestParseFacets_MissingRecipeJobAggsLeaveEmpty(t *testing.T) {
 		t.Error("JobTypes should be nil when agg missing")
 	}
 }
+
+func TestParseSearchResponse_DidYouMean(t *testing.T) {
+	t.Helper()
+
+	body := `{
+		"hits": {"total": {"value": 0}, "hits": []},
+		"suggest": {"did_you_mean": [{"text": "sudbry fire", "options": [{"text": "sudbury fire", "score": 0.4}]}]}
+	}`
+	req := &domain.SearchRequest{
+		Query:      "sudbry fire",
+		Pagination: &domain.Pagination{Page: 1, Size: 10},
+		Options:    &domain.Options{},
+	}
+
+	resp, err := (&SearchService{}).parseSearchResponse(strings.NewReader(body), req, nil, nil)
+	if err != nil {
+		t.Fatalf("parseSearchResponse: %v", err)
+	}
+	if resp.DidYouMean != "sudbury fire" {
+		t.Errorf("DidYouMean: want %q, got %q", "sudbury fire", resp.DidYouMean)
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	t.Helper()
+
+	withOption := func(text string) []suggestion {
+		return []suggestion{{Options: []suggestionOption{{Text: text}}}}
+	}
+
+	tests := []struct {
+		name        string
+		suggestions []suggestion
+		query       string
+		want        string
+	}{
+		{"correction", withOption("timmins"), "timins", "timmins"},
+		{"same words", withOption("north bay"), "North  Bay", ""},
+		{"no options", []suggestion{{}}, "timins", ""},
+		{"no suggester", nil, "timins", ""},
+	}
+
+	for _, tt := range tests {
+		if got := didYouMean(tt.suggestions, tt.query); got != tt.want {
+			t.Errorf("%s: want %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
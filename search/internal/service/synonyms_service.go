@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/esmapping"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	synonymsDefaultSize = 100
+	synonymsMaxSize     = 1000
+)
+
+// EnsureSynonymsSet creates the search synonyms set, empty, if it does not
+// exist yet, so rules can be added and new classified indexes created.
+func (s *SearchService) EnsureSynonymsSet(ctx context.Context) error {
+	return s.esClient.EnsureSynonymsSet(ctx, esmapping.SearchSynonymsSet)
+}
+
+// SynonymRules lists the rules of the search synonyms set.
+func (s *SearchService) SynonymRules(ctx context.Context, req *domain.SynonymsRequest) (*domain.SynonymRules, error) {
+	normalizeSynonymsRequest(req)
+	return s.esClient.SynonymRules(ctx, esmapping.SearchSynonymsSet, req)
+}
+
+// PutSynonymRule creates or replaces a synonym rule. Searches on every
+// classified index use it once the response reports the analyzers reloaded.
+func (s *SearchService) PutSynonymRule(ctx context.Context, rule *domain.SynonymRule) (*domain.SynonymUpdate, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	update, err := s.esClient.PutSynonymRule(ctx, esmapping.SearchSynonymsSet, rule)
+	if err != nil {
+		return nil, err
+	}
+	s.logSynonymUpdate(update)
+	return update, nil
+}
+
+// DeleteSynonymRule removes a synonym rule, reloading the analyzers like PutSynonymRule.
+func (s *SearchService) DeleteSynonymRule(ctx context.Context, id string) (*domain.SynonymUpdate, error) {
+	if err := domain.ValidateSynonymRuleID(id); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	update, err := s.esClient.DeleteSynonymRule(ctx, esmapping.SearchSynonymsSet, id)
+	if err != nil {
+		return nil, err
+	}
+	s.logSynonymUpdate(update)
+	return update, nil
+}
+
+// logSynonymUpdate records a rule change; shards that failed to reload keep
+// serving the old synonyms, which is worth a warning.
+func (s *SearchService) logSynonymUpdate(update *domain.SynonymUpdate) {
+	fields := []infralogger.Field{
+		infralogger.String("rule_id", update.Rule.ID),
+		infralogger.String("result", update.Result),
+		infralogger.Int("reloaded_indexes", len(update.ReloadedIndexes)),
+	}
+	if update.ReloadFailedShards > 0 {
+		s.logger.Warn("Synonym rule changed, some analyzers failed to reload",
+			append(fields, infralogger.Int("failed_shards", update.ReloadFailedShards))...)
+		return
+	}
+	s.logger.Info("Synonym rule changed", fields...)
+}
+
+// normalizeSynonymsRequest applies defaults and bounds to a listing's paging.
+func normalizeSynonymsRequest(req *domain.SynonymsRequest) {
+	if req.From < 0 {
+		req.From = 0
+	}
+	if req.Size <= 0 {
+		req.Size = synonymsDefaultSize
+	}
+	if req.Size > synonymsMaxSize {
+		req.Size = synonymsMaxSize
+	}
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+func TestNormalizeSynonymsRequest(t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		req  domain.SynonymsRequest
+		want domain.SynonymsRequest
+	}{
+		{"defaults", domain.SynonymsRequest{}, domain.SynonymsRequest{From: 0, Size: 100}},
+		{"bounds", domain.SynonymsRequest{From: -5, Size: 5000}, domain.SynonymsRequest{From: 0, Size: 1000}},
+		{"keeps valid values", domain.SynonymsRequest{From: 200, Size: 50}, domain.SynonymsRequest{From: 200, Size: 50}},
+	}
+
+	for _, tt := range tests {
+		req := tt.req
+		service.NormalizeSynonymsRequest(&req)
+		if req != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, req, tt.want)
+		}
+	}
+}
+
+func TestSynonymRules_ValidateBeforeElasticsearch(t *testing.T) {
+	t.Helper()
+
+	// Without an Elasticsearch client, only validation can answer
+	s := service.NewSearchService(nil, &config.Config{}, infralogger.NewNop(), nil)
+
+	_, err := s.PutSynonymRule(context.Background(), &domain.SynonymRule{ID: "opp", Synonyms: "opp"})
+	if err == nil || !strings.Contains(err.Error(), "validation error") {
+		t.Errorf("PutSynonymRule: want validation error, got %v", err)
+	}
+	_, err = s.DeleteSynonymRule(context.Background(), "../opp")
+	if err == nil || !strings.Contains(err.Error(), "validation error") {
+		t.Errorf("DeleteSynonymRule: want validation error, got %v", err)
+	}
+}
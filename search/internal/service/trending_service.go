@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+const (
+	trendingDefaultHours        = 24
+	trendingMaxHours            = 168
+	trendingDefaultBaselineDays = 7
+	trendingMaxBaselineDays     = 30
+	trendingDefaultLimit        = 10
+	trendingMaxLimit            = 50
+	// trendingCandidates is how many of each kind of term, by recent
+	// count, are scored against their baseline
+	trendingCandidates = 200
+	// trendingMinCount keeps terms seen only once or twice out of the report
+	trendingMinCount = 3
+	// trendingPrecision rounds expected counts and velocities to hundredths
+	trendingPrecision = 100
+)
+
+// trendingFields are the fields trending terms are counted on, by kind.
+var trendingFields = map[string]string{
+	domain.TrendingTopic: "topics.keyword",
+	domain.TrendingCity:  "location.city",
+}
+
+// TrendingTerms reports the topics and cities indexed more often in the last
+// req.Hours than their rate over the req.BaselineDays before predicts.
+func (s *SearchService) TrendingTerms(ctx context.Context, req *domain.TrendingRequest) (*domain.TrendingResponse, error) {
+	normalizeTrendingRequest(req)
+	now := time.Now().UTC()
+
+	res, err := s.executeSearch(ctx, buildTrendingQuery(req, now))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	terms, err := parseTrendingResponse(res.Body, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TrendingResponse{
+		GeneratedAt:  now,
+		WindowHours:  req.Hours,
+		BaselineDays: req.BaselineDays,
+		Terms:        terms,
+	}, nil
+}
+
+// normalizeTrendingRequest applies defaults and bounds to a trending request.
+func normalizeTrendingRequest(req *domain.TrendingRequest) {
+	if req.Hours <= 0 {
+		req.Hours = trendingDefaultHours
+	}
+	if req.Hours > trendingMaxHours {
+		req.Hours = trendingMaxHours
+	}
+	if req.BaselineDays <= 0 {
+		req.BaselineDays = trendingDefaultBaselineDays
+	}
+	if req.BaselineDays > trendingMaxBaselineDays {
+		req.BaselineDays = trendingMaxBaselineDays
+	}
+	if req.Limit <= 0 {
+		req.Limit = trendingDefaultLimit
+	}
+	if req.Limit > trendingMaxLimit {
+		req.Limit = trendingMaxLimit
+	}
+}
+
+// buildTrendingQuery counts each kind of term over the window and its
+// baseline together, with the window's share as a sub-aggregation, so a
+// term's baseline count is exact rather than limited to the baseline's top terms.
+func buildTrendingQuery(req *domain.TrendingRequest, now time.Time) map[string]any {
+	windowStart := now.Add(-time.Duration(req.Hours) * time.Hour)
+	baselineStart := windowStart.AddDate(0, 0, -req.BaselineDays)
+
+	aggs := make(map[string]any, len(trendingFields))
+	for kind, field := range trendingFields {
+		aggs[kind] = map[string]any{
+			"terms": map[string]any{
+				"field": field,
+				"size":  trendingCandidates,
+				"order": map[string]any{"window": "desc"},
+			},
+			"aggs": map[string]any{
+				"window": map[string]any{
+					"filter": map[string]any{
+						"range": map[string]any{
+							coverageIndexedDateField: map[string]any{"gte": windowStart.Format(time.RFC3339)},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return map[string]any{
+		"size": 0,
+		"query": map[string]any{
+			"range": map[string]any{
+				coverageIndexedDateField: map[string]any{
+					"gte": baselineStart.Format(time.RFC3339),
+					"lte": now.Format(time.RFC3339),
+				},
+			},
+		},
+		"aggs": aggs,
+	}
+}
+
+// trendingAggResponse mirrors the aggregation shape produced by buildTrendingQuery.
+type trendingAggResponse struct {
+	Aggregations map[string]struct {
+		Buckets []struct {
+			Key      string `json:"key"`
+			DocCount int64  `json:"doc_count"`
+			Window   struct {
+				DocCount int64 `json:"doc_count"`
+			} `json:"window"`
+		} `json:"buckets"`
+	} `json:"aggregations"`
+}
+
+// parseTrendingResponse scores every term against its baseline and returns
+// the req.Limit fastest rising ones.
+func parseTrendingResponse(body io.Reader, req *domain.TrendingRequest) ([]*domain.TrendingTerm, error) {
+	var esResponse trendingAggResponse
+	if err := json.NewDecoder(body).Decode(&esResponse); err != nil {
+		return nil, fmt.Errorf("decode trending response: %w", err)
+	}
+
+	windowShare := float64(req.Hours) / float64(req.BaselineDays*hoursPerDay)
+	terms := make([]*domain.TrendingTerm, 0)
+	for kind := range trendingFields {
+		for _, bucket := range esResponse.Aggregations[kind].Buckets {
+			count := bucket.Window.DocCount
+			if count < trendingMinCount {
+				continue
+			}
+			baseline := bucket.DocCount - count
+			expected := float64(baseline) * windowShare
+			velocity := (float64(count) - expected) / math.Sqrt(expected+1)
+			if velocity <= 0 {
+				continue
+			}
+			terms = append(terms, &domain.TrendingTerm{
+				Term:          bucket.Key,
+				Type:          kind,
+				Count:         count,
+				BaselineCount: baseline,
+				Expected:      math.Round(expected*trendingPrecision) / trendingPrecision,
+				Velocity:      math.Round(velocity*trendingPrecision) / trendingPrecision,
+			})
+		}
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Velocity != terms[j].Velocity {
+			return terms[i].Velocity > terms[j].Velocity
+		}
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Type+terms[i].Term < terms[j].Type+terms[j].Term
+	})
+	if len(terms) > req.Limit {
+		terms = terms[:req.Limit]
+	}
+	return terms, nil
+}
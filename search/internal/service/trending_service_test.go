@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+func TestNormalizeTrendingRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  domain.TrendingRequest
+		want domain.TrendingRequest
+	}{
+		{"defaults", domain.TrendingRequest{}, domain.TrendingRequest{Hours: 24, BaselineDays: 7, Limit: 10}},
+		{"caps", domain.TrendingRequest{Hours: 1000, BaselineDays: 365, Limit: 500}, domain.TrendingRequest{Hours: 168, BaselineDays: 30, Limit: 50}},
+		{"keeps valid values", domain.TrendingRequest{Hours: 6, BaselineDays: 14, Limit: 5}, domain.TrendingRequest{Hours: 6, BaselineDays: 14, Limit: 5}},
+	}
+
+	for _, tt := range tests {
+		req := tt.req
+		service.NormalizeTrendingRequest(&req)
+		if req != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, req, tt.want)
+		}
+	}
+}
+
+func TestBuildTrendingQuery(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	query := service.BuildTrendingQuery(&domain.TrendingRequest{Hours: 24, BaselineDays: 7, Limit: 10}, now)
+
+	rangeQuery := query["query"].(map[string]any)["range"].(map[string]any)["crawled_at"].(map[string]any)
+	if rangeQuery["gte"] != "2026-10-08T12:00:00Z" {
+		t.Errorf("baseline start = %v, want 2026-10-08T12:00:00Z", rangeQuery["gte"])
+	}
+
+	aggs := query["aggs"].(map[string]any)
+	for _, kind := range []string{domain.TrendingTopic, domain.TrendingCity} {
+		agg, ok := aggs[kind].(map[string]any)
+		if !ok {
+			t.Fatalf("missing %s aggregation", kind)
+		}
+		window := agg["aggs"].(map[string]any)["window"].(map[string]any)
+		windowRange := window["filter"].(map[string]any)["range"].(map[string]any)["crawled_at"].(map[string]any)
+		if windowRange["gte"] != "2026-10-15T12:00:00Z" {
+			t.Errorf("%s window start = %v, want 2026-10-15T12:00:00Z", kind, windowRange["gte"])
+		}
+	}
+}
+
+func TestParseTrendingResponse(t *testing.T) {
+	// Over a 24h window and 7 day baseline, a term's expected window count is
+	// a seventh of its baseline count.
+	body := `{"aggregations": {
+		"topic": {"buckets": [
+			{"key": "wildfire", "doc_count": 184, "window": {"doc_count": 100}},
+			{"key": "politics", "doc_count": 770, "window": {"doc_count": 70}},
+			{"key": "mining", "doc_count": 2, "window": {"doc_count": 2}}
+		]},
+		"city": {"buckets": [
+			{"key": "timmins", "doc_count": 5, "window": {"doc_count": 5}}
+		]}
+	}}`
+
+	terms, err := service.ParseTrendingResponse(strings.NewReader(body), &domain.TrendingRequest{Hours: 24, BaselineDays: 7, Limit: 10})
+	if err != nil {
+		t.Fatalf("ParseTrendingResponse: %v", err)
+	}
+
+	// politics runs below its usual 100 a day; mining is under the minimum count
+	if len(terms) != 2 {
+		t.Fatalf("got %d terms, want 2: %+v", len(terms), terms)
+	}
+	if terms[0].Term != "wildfire" || terms[0].Type != domain.TrendingTopic {
+		t.Errorf("first term = %s %s, want topic wildfire", terms[0].Type, terms[0].Term)
+	}
+	if terms[0].BaselineCount != 84 || terms[0].Expected != 12 {
+		t.Errorf("wildfire baseline %d expected %v, want 84 and 12", terms[0].BaselineCount, terms[0].Expected)
+	}
+	if terms[0].Velocity != 24.41 {
+		t.Errorf("wildfire velocity = %v, want 24.41", terms[0].Velocity)
+	}
+	if terms[1].Term != "timmins" || terms[1].Type != domain.TrendingCity || terms[1].Velocity != 5 {
+		t.Errorf("second term = %+v, want city timmins with velocity 5", terms[1])
+	}
+
+	limited, err := service.ParseTrendingResponse(strings.NewReader(body), &domain.TrendingRequest{Hours: 24, BaselineDays: 7, Limit: 1})
+	if err != nil {
+		t.Fatalf("ParseTrendingResponse: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("limit 1: got %d terms", len(limited))
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+)
+
+// widgetMaxPage bounds widget pagination; widgets show recent or top results only.
+const widgetMaxPage = 10
+
+// ErrWidgetTopicNotAllowed is returned when a widget asks for a topic outside its key's scope.
+var ErrWidgetTopicNotAllowed = errors.New("topic is outside the widget's scope")
+
+// WidgetQuery is what an embedded widget may ask for; everything else comes from its key.
+type WidgetQuery struct {
+	Query  string
+	Topics []string
+	Page   int
+	Size   int
+}
+
+// WidgetSearch runs a search restricted to the key's sources, topics, and
+// minimum quality, and returns the simplified widget shape. An empty query
+// returns the newest items, for news widgets.
+func (s *SearchService) WidgetSearch(
+	ctx context.Context,
+	key *config.WidgetKey,
+	q WidgetQuery,
+) (*domain.WidgetResponse, error) {
+	req, err := buildWidgetRequest(key, q)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.Search(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WidgetResponse{
+		Query:      result.Query,
+		Items:      toWidgetItems(result.Hits),
+		Total:      result.TotalHits,
+		Page:       result.CurrentPage,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// buildWidgetRequest scopes a widget query to its key. Requested topics must
+// be within the key's topics; without any, the key's topics apply.
+func buildWidgetRequest(key *config.WidgetKey, q WidgetQuery) (*domain.SearchRequest, error) {
+	topics := q.Topics
+	if len(key.Topics) > 0 {
+		for _, topic := range topics {
+			if !slices.Contains(key.Topics, topic) {
+				return nil, fmt.Errorf("%w: %s", ErrWidgetTopicNotAllowed, topic)
+			}
+		}
+		if len(topics) == 0 {
+			topics = key.Topics
+		}
+	}
+
+	if q.Page > widgetMaxPage {
+		return nil, fmt.Errorf("validation error: page cannot exceed %d", widgetMaxPage)
+	}
+
+	size := q.Size
+	if size < 1 || size > key.MaxResults {
+		size = key.MaxResults
+	}
+
+	sort := &domain.Sort{Field: "relevance", Order: "desc"}
+	if q.Query == "" {
+		sort = &domain.Sort{Field: "published_date", Order: "desc"}
+	}
+
+	return &domain.SearchRequest{
+		Query: q.Query,
+		Filters: &domain.Filters{
+			Topics:          topics,
+			SourceNames:     key.Sources,
+			MinQualityScore: key.MinQuality,
+		},
+		Pagination: &domain.Pagination{Page: q.Page, Size: size},
+		Sort:       sort,
+		Options: &domain.Options{
+			SessionID:   domain.WidgetSessionID(key.Key),
+			Personalize: key.Personalize,
+		},
+	}, nil
+}
+
+// toWidgetItems maps search hits to widget items, preferring click-tracked URLs.
+func toWidgetItems(hits []*domain.SearchHit) []domain.WidgetItem {
+	items := make([]domain.WidgetItem, 0, len(hits))
+	for _, hit := range hits {
+		link := hit.URL
+		if hit.ClickURL != "" {
+			link = hit.ClickURL
+		}
+		published := hit.PublishedDate
+		if published == nil {
+			published = hit.CrawledAt
+		}
+		items = append(items, domain.WidgetItem{
+			ID:          hit.ID,
+			Title:       hit.Title,
+			URL:         link,
+			Source:      hit.SourceName,
+			PublishedAt: published,
+			Snippet:     hit.Snippet,
+			Image:       hit.OGImage,
+			Topics:      hit.Topics,
+		})
+	}
+	return items
+}
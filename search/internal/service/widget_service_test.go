@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/domain"
+	"github.com/jonesrussell/north-cloud/search/internal/service"
+)
+
+func widgetKey() *config.WidgetKey {
+	return &config.WidgetKey{
+		Name:       "sudbury",
+		Sources:    []string{"sudbury_com"},
+		Topics:     []string{"local_news", "crime"},
+		MinQuality: 50,
+		MaxResults: 10,
+	}
+}
+
+func TestBuildWidgetRequest_AppliesKeyScope(t *testing.T) {
+	t.Helper()
+
+	req, err := service.BuildWidgetRequest(widgetKey(), service.WidgetQuery{Size: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(req.Filters.SourceNames) != 1 || req.Filters.SourceNames[0] != "sudbury_com" {
+		t.Errorf("expected the key's sources, got %v", req.Filters.SourceNames)
+	}
+	if len(req.Filters.Topics) != 2 {
+		t.Errorf("expected the key's topics without requested topics, got %v", req.Filters.Topics)
+	}
+	if req.Filters.MinQualityScore != 50 {
+		t.Errorf("expected min quality 50, got %d", req.Filters.MinQualityScore)
+	}
+	if req.Pagination.Size != 10 {
+		t.Errorf("expected size capped at 10, got %d", req.Pagination.Size)
+	}
+	if req.Sort.Field != "published_date" {
+		t.Errorf("empty query should list newest first, got %s", req.Sort.Field)
+	}
+	if req.Options.IncludeFacets || req.Options.IncludeHighlights {
+		t.Error("widgets should not request facets or highlights")
+	}
+}
+
+func TestBuildWidgetRequest_RejectsTopicOutsideScope(t *testing.T) {
+	t.Helper()
+
+	req, err := service.BuildWidgetRequest(widgetKey(), service.WidgetQuery{Query: "fire", Topics: []string{"crime"}})
+	if err != nil || req.Sort.Field != "relevance" || len(req.Filters.Topics) != 1 {
+		t.Fatalf("expected a relevance search for crime, got %+v (%v)", req, err)
+	}
+
+	_, err = service.BuildWidgetRequest(widgetKey(), service.WidgetQuery{Topics: []string{"mining"}})
+	if !errors.Is(err, service.ErrWidgetTopicNotAllowed) {
+		t.Errorf("expected ErrWidgetTopicNotAllowed, got %v", err)
+	}
+
+	if _, err = service.BuildWidgetRequest(widgetKey(), service.WidgetQuery{Page: 11}); err == nil {
+		t.Error("expected deep pages to be rejected")
+	}
+}
+
+func TestToWidgetItems_PrefersClickURL(t *testing.T) {
+	t.Helper()
+
+	crawled := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	items := service.ToWidgetItems([]*domain.SearchHit{
+		{ID: "a", URL: "https://news.example/a", ClickURL: "https://nc.example/click?id=a", CrawledAt: &crawled},
+		{ID: "b", URL: "https://news.example/b", OGImage: "https://news.example/b.jpg"},
+	})
+
+	if items[0].URL != "https://nc.example/click?id=a" {
+		t.Errorf("expected the click-tracked URL, got %s", items[0].URL)
+	}
+	if items[0].PublishedAt == nil || !items[0].PublishedAt.Equal(crawled) {
+		t.Errorf("expected crawled_at when there is no published date, got %v", items[0].PublishedAt)
+	}
+	if items[1].URL != "https://news.example/b" || items[1].Image != "https://news.example/b.jpg" {
+		t.Errorf("unexpected item %+v", items[1])
+	}
+}
+
+func TestBuildWidgetRequest_AttributesSessionToKey(t *testing.T) {
+	t.Helper()
+
+	key := widgetKey()
+	key.Key = "wk_sudbury_community_2f9c41"
+	key.Personalize = true
+
+	req, err := service.BuildWidgetRequest(key, service.WidgetQuery{Query: "fire"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Options.SessionID != domain.WidgetSessionID(key.Key) || !req.Options.Personalize {
+		t.Errorf("expected the key's session with personalization, got %+v", req.Options)
+	}
+}
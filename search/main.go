@@ -4,22 +4,36 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jonesrussell/north-cloud/infrastructure/clickurl"
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
+	infraredis "github.com/jonesrussell/north-cloud/infrastructure/redis"
+	"github.com/jonesrussell/north-cloud/search/internal/analytics"
 	"github.com/jonesrussell/north-cloud/search/internal/api"
+	"github.com/jonesrussell/north-cloud/search/internal/cache"
 	"github.com/jonesrussell/north-cloud/search/internal/config"
+	"github.com/jonesrussell/north-cloud/search/internal/crawlinterval"
 	"github.com/jonesrussell/north-cloud/search/internal/elasticsearch"
+	"github.com/jonesrussell/north-cloud/search/internal/personalization"
+	"github.com/jonesrussell/north-cloud/search/internal/ratelimit"
+	"github.com/jonesrussell/north-cloud/search/internal/reputation"
 	"github.com/jonesrussell/north-cloud/search/internal/service"
 )
 
+// synonymsSetupTimeout bounds the synonyms set check at startup.
+const synonymsSetupTimeout = 10 * time.Second
+
 func main() {
 	os.Exit(run())
 }
 
 func run() int {
+	defer crash.Start("search")()
+
 	// Start profiling server (if enabled)
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("search"); pyroErr != nil {
@@ -95,21 +109,192 @@ func setupElasticsearch(cfg *config.Config, log infralogger.Logger) (*elasticsea
 	return esClient, nil
 }
 
+// setupCache connects the response cache and starts applying invalidations.
+// Without Redis the service runs uncached rather than failing to start.
+func setupCache(cfg *config.Config, searchService *service.SearchService, log infralogger.Logger) func() {
+	redisClient, err := infraredis.NewClient(infraredis.Config{
+		Address:  cfg.Cache.Address,
+		Password: cfg.Cache.Password,
+		DB:       cfg.Cache.DB,
+	})
+	if err != nil {
+		log.Warn("Search cache disabled: Redis unavailable", infralogger.Error(err))
+		return func() {}
+	}
+
+	responseCache := cache.New(redisClient, cfg.Cache, log)
+	cacheCtx, stopInvalidations := context.WithCancel(context.Background())
+	go responseCache.Run(cacheCtx)
+	searchService.WithCache(responseCache)
+	log.Info("Search cache enabled",
+		infralogger.String("redis_address", cfg.Cache.Address),
+		infralogger.Duration("ttl", cfg.Cache.TTL),
+		infralogger.String("invalidation_channel", cfg.Cache.InvalidationChannel),
+	)
+
+	return func() {
+		stopInvalidations()
+		_ = redisClient.Close()
+	}
+}
+
+// setupAnalytics connects query analytics and starts recording searches. It
+// reports whether analytics is running; without Redis the service runs
+// without it rather than failing to start.
+func setupAnalytics(cfg *config.Config, searchService *service.SearchService, log infralogger.Logger) (func(), bool) {
+	redisClient, err := infraredis.NewClient(infraredis.Config{
+		Address:  cfg.Analytics.Address,
+		Password: cfg.Analytics.Password,
+		DB:       cfg.Analytics.DB,
+	})
+	if err != nil {
+		log.Warn("Query analytics disabled: Redis unavailable", infralogger.Error(err))
+		return func() {}, false
+	}
+
+	queryAnalytics := analytics.New(redisClient, cfg.Analytics, log)
+	analyticsCtx, stopRecording := context.WithCancel(context.Background())
+	go queryAnalytics.Run(analyticsCtx)
+	searchService.WithAnalytics(queryAnalytics)
+	log.Info("Query analytics enabled",
+		infralogger.String("redis_address", cfg.Analytics.Address),
+		infralogger.Duration("retention", cfg.Analytics.Retention),
+	)
+
+	return func() {
+		stopRecording()
+		_ = redisClient.Close()
+	}, true
+}
+
+// setupAPIKeyLimits connects the counters behind API key rate limits and
+// quotas. Without Redis keys are still required but their limits are not
+// enforced, rather than the service failing to start.
+func setupAPIKeyLimits(cfg *config.Config, searchService *service.SearchService, log infralogger.Logger) func() {
+	redisClient, err := infraredis.NewClient(infraredis.Config{
+		Address:  cfg.APIKeys.Address,
+		Password: cfg.APIKeys.Password,
+		DB:       cfg.APIKeys.DB,
+	})
+	if err != nil {
+		log.Warn("API key limits not enforced: Redis unavailable", infralogger.Error(err))
+		return func() {}
+	}
+
+	searchService.WithQuotas(ratelimit.New(redisClient, cfg.APIKeys))
+	log.Info("API keys required",
+		infralogger.String("redis_address", cfg.APIKeys.Address),
+		infralogger.Int("default_rate_limit", cfg.APIKeys.DefaultRateLimit),
+		infralogger.Int("default_daily_quota", cfg.APIKeys.DefaultDailyQuota),
+		infralogger.Int("revoked_keys", len(cfg.APIKeys.Revoked)),
+	)
+
+	return func() {
+		_ = redisClient.Close()
+	}
+}
+
+// setupSynonyms creates the search synonyms set if it is missing. The API
+// stays enabled when this fails: the set is also created by the
+// index-manager, and listing a missing set returns no rules.
+func setupSynonyms(searchService *service.SearchService, log infralogger.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), synonymsSetupTimeout)
+	defer cancel()
+
+	if err := searchService.EnsureSynonymsSet(ctx); err != nil {
+		log.Warn("Failed to ensure search synonyms set", infralogger.Error(err))
+		return
+	}
+	log.Info("Synonym management enabled")
+}
+
 // runServer creates the search service, handler, and HTTP server, then runs with graceful shutdown.
 func runServer(cfg *config.Config, esClient *elasticsearch.Client, log infralogger.Logger) int {
 	// Create click URL signer if enabled
 	var clickSigner *clickurl.Signer
 	if cfg.ClickTracker.Enabled && cfg.ClickTracker.Secret != "" {
-		clickSigner = clickurl.NewSigner(cfg.ClickTracker.Secret)
+		signer, err := clickurl.NewKeyedSigner(clickurl.Key{ID: cfg.ClickTracker.KeyID, Secret: cfg.ClickTracker.Secret})
+		if err != nil {
+			log.Error("Invalid click tracker key", infralogger.Error(err))
+			return 1
+		}
+		clickSigner = signer
 		log.Info("Click tracking enabled",
 			infralogger.String("base_url", cfg.ClickTracker.BaseURL),
+			infralogger.String("key_id", cfg.ClickTracker.KeyID),
 		)
 	}
 
 	searchService := service.NewSearchService(esClient, cfg, log, clickSigner)
+
+	// Source reputation cache backs min_reputation and the source_reputation sort
+	if cfg.Classifier.URL != "" {
+		reputations := reputation.NewCache(cfg.Classifier, log)
+		reputationCtx, stopReputations := context.WithCancel(context.Background())
+		defer stopReputations()
+		go reputations.Run(reputationCtx)
+		searchService.WithReputations(reputations)
+		log.Info("Source reputation cache enabled",
+			infralogger.String("classifier_url", cfg.Classifier.URL),
+			infralogger.Duration("refresh_interval", cfg.Classifier.RefreshInterval),
+		)
+	}
+
+	// Crawl intervals from the crawler set each source's coverage gap threshold
+	if cfg.Crawler.URL != "" {
+		intervals := crawlinterval.NewCache(cfg.Crawler, log)
+		intervalCtx, stopIntervals := context.WithCancel(context.Background())
+		defer stopIntervals()
+		go intervals.Run(intervalCtx)
+		searchService.WithCrawlIntervals(intervals)
+		log.Info("Crawl interval cache enabled",
+			infralogger.String("crawler_url", cfg.Crawler.URL),
+			infralogger.Duration("refresh_interval", cfg.Crawler.RefreshInterval),
+		)
+	}
+
+	// Engagement profiles from the click-tracker back personalize=true searches
+	if cfg.Personalization.ClickTrackerURL != "" {
+		profiles := personalization.NewProfiles(cfg.Personalization, esClient, cfg.Elasticsearch.ClassifiedContentPattern)
+		searchService.WithPersonalization(profiles)
+		log.Info("Personalization enabled",
+			infralogger.String("click_tracker_url", cfg.Personalization.ClickTrackerURL),
+			infralogger.Duration("window", cfg.Personalization.Window),
+		)
+	}
+	// Redis response cache for repeated searches, invalidated by classified batches
+	if cfg.Cache.Enabled {
+		stopCache := setupCache(cfg, searchService, log)
+		defer stopCache()
+	}
+	// Query analytics: searches recorded in Redis, reported behind JWT auth
+	analyticsRunning := false
+	if cfg.Analytics.Enabled {
+		var stopAnalytics func()
+		stopAnalytics, analyticsRunning = setupAnalytics(cfg, searchService, log)
+		defer stopAnalytics()
+	}
+	// API keys from the auth service, rate-limited and metered in Redis
+	if cfg.APIKeys.Enabled {
+		stopLimits := setupAPIKeyLimits(cfg, searchService, log)
+		defer stopLimits()
+	}
+	// Synonym management: rules in the Elasticsearch synonyms set, behind JWT auth
+	if cfg.Synonyms.Enabled {
+		setupSynonyms(searchService, log)
+	}
 	log.Info("Search service initialized")
 
-	handler := api.NewHandler(searchService, log)
+	handler := api.NewHandler(searchService, log).WithWidgetKeys(cfg.Widget.Keys)
+	if analyticsRunning {
+		handler.WithAnalytics(cfg.Analytics.JWTSecret)
+	}
+	if cfg.APIKeys.Enabled {
+		handler.WithAPIKeys(&cfg.APIKeys)
+	}
+	if cfg.Synonyms.Enabled {
+		handler.WithSynonyms(cfg.Synonyms.JWTSecret)
+	}
 	server := api.NewServer(handler, cfg, log, &api.ServerDeps{
 		ESPing: func() error {
 			return esClient.Ping(context.Background())
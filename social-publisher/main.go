@@ -14,6 +14,7 @@ import (
 	_ "github.com/lib/pq"
 	goredis "github.com/redis/go-redis/v9"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
 	infraredis "github.com/jonesrussell/north-cloud/infrastructure/redis"
@@ -45,6 +46,8 @@ func main() {
 }
 
 func run() int {
+	defer crash.Start("social-publisher")()
+
 	profiling.StartPprofServer()
 
 	cfg, err := config.Load("config.yml")
@@ -1,19 +1,26 @@
 package api
 
 import (
+	"net/url"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	infragin "github.com/jonesrussell/north-cloud/infrastructure/gin"
+	infrajwt "github.com/jonesrussell/north-cloud/infrastructure/jwt"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/config"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/crawlersync"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/credentials"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/events"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/icpstore"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/services"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/sourcehealth"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testcrawl"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/webhooks"
 )
 
 // Constants for router configuration.
@@ -82,6 +89,8 @@ func contains(slice []string, str string) bool {
 // NewServer creates a new HTTP server using the infrastructure gin package.
 func NewServer(
 	db *repository.SourceRepository,
+	sourceNoteRepo *repository.SourceNoteRepository,
+	sourceVersionRepo *repository.SourceVersionRepository,
 	communityRepo *repository.CommunityRepository,
 	personRepo *repository.PersonRepository,
 	bandOfficeRepo *repository.BandOfficeRepository,
@@ -92,8 +101,26 @@ func NewServer(
 	infraLog infralogger.Logger,
 	publisher *events.Publisher,
 	icpStore *icpstore.Store,
+	healthCache *sourcehealth.Cache,
+	crawlerSync *crawlersync.Client,
+	credentialStore *credentials.Store,
+	webhookDispatcher *webhooks.Dispatcher,
 ) *infragin.Server {
-	sourceHandler := handlers.NewSourceHandler(db, infraLog, publisher)
+	sourceHandler := handlers.NewSourceHandler(db, infraLog, publisher).
+		WithVersions(sourceVersionRepo).
+		WithCrawlExtractor(newCrawlExtractor(cfg, infraLog))
+	if healthCache != nil {
+		sourceHandler.WithHealth(healthCache)
+	}
+	if crawlerSync != nil {
+		sourceHandler.WithCrawlerSync(crawlerSync)
+	}
+	if webhookDispatcher.Enabled() {
+		sourceHandler.WithWebhooks(webhookDispatcher)
+	}
+	sourceHandler.WithArchiveRetention(cfg.Lifecycle.ArchiveRetention)
+	sourceNoteHandler := handlers.NewSourceNoteHandler(sourceNoteRepo, infraLog)
+	sourceCredentialHandler := handlers.NewSourceCredentialHandler(credentialStore, infraLog)
 	communityHandler := handlers.NewCommunityHandler(communityRepo, infraLog)
 	personHandler := handlers.NewPersonHandler(personRepo, infraLog)
 	bandOfficeHandler := handlers.NewBandOfficeHandler(bandOfficeRepo, infraLog)
@@ -121,7 +148,7 @@ func NewServer(
 		WithRoutes(func(router *gin.Engine) {
 			// Setup service-specific routes (health routes added by builder)
 			setupServiceRoutes(
-				router, sourceHandler, communityHandler, personHandler,
+				router, sourceHandler, sourceNoteHandler, sourceCredentialHandler, communityHandler, personHandler,
 				bandOfficeHandler, verificationHandler, linkerHandler,
 				dictionaryHandler, travelTimeHandler, icpHandler, cfg,
 			)
@@ -131,11 +158,24 @@ func NewServer(
 	return server
 }
 
+// newCrawlExtractor builds the test-crawl extractor, fetching through the configured
+// proxy (validated by config.Validate) when one is set.
+func newCrawlExtractor(cfg *config.Config, infraLog infralogger.Logger) *testcrawl.Extractor {
+	if cfg.TestCrawl.ProxyURL == "" {
+		return testcrawl.NewExtractor(infraLog)
+	}
+	proxy, _ := url.Parse(cfg.TestCrawl.ProxyURL)
+	infraLog.Info("Test crawls fetch through proxy", infralogger.String("proxy_url", cfg.TestCrawl.ProxyURL))
+	return testcrawl.NewExtractor(infraLog, testcrawl.WithProxy(proxy, cfg.TestCrawl.InsecureSkipVerify))
+}
+
 // setupServiceRoutes configures service-specific API routes (not health routes).
 // Health routes are handled by the infrastructure gin package.
 func setupServiceRoutes(
 	router *gin.Engine,
 	sourceHandler *handlers.SourceHandler,
+	sourceNoteHandler *handlers.SourceNoteHandler,
+	sourceCredentialHandler *handlers.SourceCredentialHandler,
 	communityHandler *handlers.CommunityHandler,
 	personHandler *handlers.PersonHandler,
 	bandOfficeHandler *handlers.BandOfficeHandler,
@@ -180,8 +220,20 @@ func setupServiceRoutes(
 	sources := v1.Group("/sources")
 	sources.POST("", sourceHandler.Create)
 	sources.POST("/batch", sourceHandler.BatchCreate)
+	sources.POST("/bulk", sourceHandler.Bulk)
+	sources.POST("/bulk/update", sourceHandler.BulkUpdateFromFile)
+	sources.GET("/crawler-sync", sourceHandler.GetCrawlerSyncReport)
+	sources.POST("/crawler-sync", sourceHandler.SyncCrawler)
 	sources.POST("/fetch-metadata", sourceHandler.FetchMetadata)
 	sources.POST("/test-crawl", sourceHandler.TestCrawl)
+	sources.POST("/test", sourceHandler.TestDraft)
+	sources.POST("/suggest-selectors", sourceHandler.SuggestSelectors)
+	sources.POST("/credentials/rewrap", sourceCredentialHandler.Rewrap)
+	sources.GET("/export", sourceHandler.Export)
+	sources.GET("/retention", sourceHandler.ListRetention)
+	sources.GET("/duplicates", sourceHandler.ListDuplicates)
+	sources.GET("/webhooks", sourceHandler.GetWebhookStatus)
+	sources.POST("/import", sourceHandler.ImportSources)
 	sources.POST("/import-excel", sourceHandler.ImportExcel)
 	sources.POST("/import-indigenous", sourceHandler.ImportIndigenous)
 	sources.GET("/by-identity", sourceHandler.GetByIdentityKey)
@@ -192,6 +244,21 @@ func setupServiceRoutes(
 	sources.PATCH("/:id/feed-enable", sourceHandler.EnableFeed)
 	sources.PATCH("/:id/disable", sourceHandler.DisableSource)
 	sources.PATCH("/:id/enable", sourceHandler.EnableSource)
+	sources.PATCH("/:id/lifecycle", sourceHandler.SetLifecycle)
+	sources.POST("/:id/test", sourceHandler.TestSource)
+	sources.GET("/:id/notes", sourceNoteHandler.List)
+	sources.POST("/:id/notes", sourceNoteHandler.Create)
+	sources.PATCH("/:id/notes/:note_id/resolve", sourceNoteHandler.Resolve)
+	sources.GET("/:id/credentials", sourceCredentialHandler.List)
+	// The crawler reads decrypted credentials with a sources:credentials:read token
+	resolve := infragin.ProtectedGroup(router, "/api/v1/sources", cfg.Auth.JWTSecret,
+		infrajwt.WithRequiredScope(handlers.CredentialResolveScope))
+	resolve.GET("/:id/credentials/resolved", sourceCredentialHandler.Resolve)
+	sources.PUT("/:id/credentials/:kind", sourceCredentialHandler.Put)
+	sources.DELETE("/:id/credentials/:kind", sourceCredentialHandler.Delete)
+	sources.GET("/:id/versions", sourceHandler.ListVersions)
+	sources.GET("/:id/versions/:version", sourceHandler.GetVersion)
+	sources.POST("/:id/versions/:version/rollback", sourceHandler.RollbackVersion)
 
 	// Communities endpoints (protected - requires JWT for mutations)
 	communities := v1.Group("/communities")
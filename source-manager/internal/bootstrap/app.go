@@ -7,18 +7,25 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jonesrussell/north-cloud/infrastructure/crash"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/profiling"
 	"github.com/jonesrussell/north-cloud/infrastructure/provider/anthropic"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/aiverify"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/crawlersync"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/credentials"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/icpstore"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/sourcehealth"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/webhooks"
 )
 
 const version = "dev"
 
 // Start initializes and starts the source-manager application.
 func Start() error {
+	defer crash.Start("source-manager")()
+
 	// Phase 0: Start profiling server (if enabled)
 	profiling.StartPprofServer()
 	if pyroProfiler, pyroErr := profiling.StartPyroscope("source-manager"); pyroErr != nil {
@@ -63,10 +70,46 @@ func Start() error {
 	}
 	icpCtx, icpCancel := context.WithCancel(context.Background())
 	defer icpCancel()
-	go icpStore.Run(icpCtx)
+	crash.Go(log, "icp-store", func() { icpStore.Run(icpCtx) })
+
+	// Phase 3.5: Source health cache (optional, on when the crawler or index-manager is configured)
+	var healthCache *sourcehealth.Cache
+	if cfg.SourceHealth.Enabled() {
+		healthCache = sourcehealth.NewCache(cfg.SourceHealth, cfg.Auth.JWTSecret, log)
+		healthCtx, healthCancel := context.WithCancel(context.Background())
+		defer healthCancel()
+		crash.Go(log, "source-health", func() { healthCache.Run(healthCtx) })
+	}
+
+	// Phase 3.6: Crawler job sync (optional, pushes source changes to the crawler's jobs)
+	var crawlerSync *crawlersync.Client
+	if cfg.CrawlerSync.Enabled {
+		crawlerSync = crawlersync.NewClient(cfg.SourceHealth.CrawlerURL, cfg.Auth.JWTSecret, cfg.SourceHealth.Timeout, log)
+	}
+
+	// Phase 3.7: Source credential store (saving and resolving need SOURCE_CREDENTIALS_KEY)
+	credentialStore, err := credentials.NewStore(
+		repository.NewSourceCredentialRepository(db.DB(), log), cfg.Credentials.Key, cfg.Credentials.PreviousKey,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set up credential store: %w", err)
+	}
+	if !credentialStore.Enabled() {
+		log.Warn("Source credentials disabled: SOURCE_CREDENTIALS_KEY is not set")
+	}
+
+	// Phase 3.8: Change webhooks (optional, on when SOURCE_WEBHOOK_URLS is set)
+	webhookDispatcher := webhooks.NewDispatcher(
+		cfg.Webhooks.URLs, cfg.Webhooks.Secret, cfg.Webhooks.Timeout, cfg.Webhooks.MaxAttempts, log,
+	)
+	if webhookDispatcher.Enabled() && cfg.Webhooks.Secret == "" {
+		log.Warn("Source webhooks are unsigned: SOURCE_WEBHOOK_SECRET is not set")
+	}
 
 	// Phase 4: Setup and run HTTP server
-	server := SetupHTTPServer(cfg, db, publisher, icpStore, log)
+	server := SetupHTTPServer(
+		cfg, db, publisher, icpStore, healthCache, crawlerSync, credentialStore, webhookDispatcher, log,
+	)
 
 	// Phase 4.5: Verification worker (optional, disabled by default)
 	if cfg.Verification.AIEnabled {
@@ -82,7 +125,7 @@ func Start() error {
 
 		verifyCtx, verifyCancel := context.WithCancel(context.Background())
 		defer verifyCancel()
-		go worker.Run(verifyCtx)
+		crash.Go(log, "verification-worker", func() { worker.Run(verifyCtx) })
 	}
 
 	log.Info("Starting HTTP server",
@@ -5,12 +5,16 @@ import (
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/api"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/config"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/crawlersync"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/credentials"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/database"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/events"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/icpstore"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/services"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/services/osrm"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/sourcehealth"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/webhooks"
 )
 
 // SetupHTTPServer creates and configures the HTTP server.
@@ -19,9 +23,15 @@ func SetupHTTPServer(
 	db *database.DB,
 	publisher *events.Publisher,
 	icpStore *icpstore.Store,
+	healthCache *sourcehealth.Cache,
+	crawlerSync *crawlersync.Client,
+	credentialStore *credentials.Store,
+	webhookDispatcher *webhooks.Dispatcher,
 	log infralogger.Logger,
 ) *infragin.Server {
 	sourceRepo := repository.NewSourceRepository(db.DB(), log)
+	sourceNoteRepo := repository.NewSourceNoteRepository(db.DB(), log)
+	sourceVersionRepo := repository.NewSourceVersionRepository(db.DB(), log)
 	communityRepo := repository.NewCommunityRepository(db.DB(), log)
 	personRepo := repository.NewPersonRepository(db.DB(), log)
 	bandOfficeRepo := repository.NewBandOfficeRepository(db.DB(), log)
@@ -33,7 +43,8 @@ func SetupHTTPServer(
 	travelTimeSvc := services.NewTravelTimeService(osrmClient, travelTimeRepo, communityRepo, log)
 
 	return api.NewServer(
-		sourceRepo, communityRepo, personRepo, bandOfficeRepo,
-		verificationRepo, dictionaryRepo, travelTimeSvc, cfg, log, publisher, icpStore,
+		sourceRepo, sourceNoteRepo, sourceVersionRepo, communityRepo, personRepo, bandOfficeRepo,
+		verificationRepo, dictionaryRepo, travelTimeSvc, cfg, log, publisher, icpStore, healthCache, crawlerSync,
+		credentialStore, webhookDispatcher,
 	)
 }
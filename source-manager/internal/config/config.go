@@ -1,8 +1,10 @@
 package config
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	infraconfig "github.com/jonesrussell/north-cloud/infrastructure/config"
@@ -26,6 +28,13 @@ const (
 	defaultOSRMBaseURL           = "http://router.project-osrm.org"
 	defaultICPSegmentsPath       = "data/icp-segments.yml"
 	defaultICPReloadInterval     = 30 * time.Second
+	defaultHealthRefresh         = time.Minute
+	defaultHealthTimeout         = 10 * time.Second
+	defaultArchiveRetention      = 90 * 24 * time.Hour
+	defaultWebhookTimeout        = 10 * time.Second
+	defaultWebhookMaxAttempts    = 3
+	// credentialsKeyBytes is the AES-256 master key size.
+	credentialsKeyBytes = 32
 )
 
 type Config struct {
@@ -37,6 +46,83 @@ type Config struct {
 	Verification VerificationConfig `yaml:"verification"`
 	OSRM         OSRMConfig         `yaml:"osrm"`
 	ICP          ICPConfig          `yaml:"icp"`
+	TestCrawl    TestCrawlConfig    `yaml:"test_crawl"`
+	SourceHealth SourceHealthConfig `yaml:"source_health"`
+	CrawlerSync  CrawlerSyncConfig  `yaml:"crawler_sync"`
+	Credentials  CredentialsConfig  `yaml:"credentials"`
+	Lifecycle    LifecycleConfig    `yaml:"lifecycle"`
+	Webhooks     WebhooksConfig     `yaml:"webhooks"`
+}
+
+// WebhooksConfig lists the endpoints that receive a signed POST for every
+// source change. Webhooks are off while URLs is empty.
+type WebhooksConfig struct {
+	URLs []string `env:"SOURCE_WEBHOOK_URLS" yaml:"urls"`
+	// Secret signs deliveries (X-North-Cloud-Signature); they are unsigned when it is empty.
+	Secret      string        `env:"SOURCE_WEBHOOK_SECRET" yaml:"secret"`
+	Timeout     time.Duration `yaml:"timeout"`
+	MaxAttempts int           `yaml:"max_attempts"`
+}
+
+// LifecycleConfig controls what happens to sources by lifecycle state.
+type LifecycleConfig struct {
+	// ArchiveRetention is how long an archived source's indexes are kept after
+	// it was archived; GET /api/v1/sources/retention reports the expired ones.
+	ArchiveRetention time.Duration `env:"SOURCE_ARCHIVE_RETENTION" yaml:"archive_retention"`
+}
+
+// SourceHealthConfig points at the crawler and index-manager whose per-source
+// crawl state and document counts are embedded in source listings. A service
+// with an empty URL is left out; both empty disables it.
+type SourceHealthConfig struct {
+	CrawlerURL      string        `env:"CRAWLER_URL"                    yaml:"crawler_url"`
+	IndexManagerURL string        `env:"INDEX_MANAGER_URL"              yaml:"index_manager_url"`
+	RefreshInterval time.Duration `env:"SOURCE_HEALTH_REFRESH_INTERVAL" yaml:"refresh_interval"`
+	Timeout         time.Duration `yaml:"timeout"`
+}
+
+// Enabled reports whether either service is configured.
+func (c SourceHealthConfig) Enabled() bool {
+	return c.CrawlerURL != "" || c.IndexManagerURL != ""
+}
+
+// CrawlerSyncConfig controls pushing source changes to the crawler's scheduled
+// jobs. It reuses source_health.crawler_url and timeout to reach the crawler.
+type CrawlerSyncConfig struct {
+	Enabled bool `env:"CRAWLER_SYNC_ENABLED" yaml:"enabled"`
+}
+
+// CredentialsConfig holds the AES-256 master keys that wrap the data keys of
+// source credentials, as 64 hex characters. Credentials cannot be stored while Key is
+// empty. During a key change the old key goes in PreviousKey until
+// POST /api/v1/sources/credentials/rewrap has moved every data key to Key.
+type CredentialsConfig struct {
+	Key         string `env:"SOURCE_CREDENTIALS_KEY"          yaml:"key"`
+	PreviousKey string `env:"SOURCE_CREDENTIALS_PREVIOUS_KEY" yaml:"previous_key"`
+}
+
+// Validate checks that configured keys are 32 bytes of hex.
+func (c *CredentialsConfig) Validate() error {
+	for name, key := range map[string]string{"key": c.Key, "previous_key": c.PreviousKey} {
+		if key == "" {
+			continue
+		}
+		if decoded, err := hex.DecodeString(key); err != nil || len(decoded) != credentialsKeyBytes {
+			return fmt.Errorf("credentials.%s must be %d hex characters", name, 2*credentialsKeyBytes)
+		}
+	}
+	if c.Key == "" && c.PreviousKey != "" {
+		return errors.New("credentials.previous_key requires credentials.key")
+	}
+	return nil
+}
+
+// TestCrawlConfig configures how test crawls and source validation fetch pages.
+type TestCrawlConfig struct {
+	// ProxyURL routes fetches through an HTTP proxy, e.g. nc-http-proxy to replay fixtures.
+	ProxyURL string `env:"TEST_CRAWL_PROXY_URL" yaml:"proxy_url"`
+	// InsecureSkipVerify accepts the proxy's re-signed HTTPS certificates.
+	InsecureSkipVerify bool `env:"TEST_CRAWL_INSECURE_SKIP_VERIFY" yaml:"insecure_skip_verify"`
 }
 
 // OSRMConfig holds OSRM routing engine configuration.
@@ -112,7 +198,22 @@ func (c *Config) Validate() error {
 	if c.Database.DBName == "" {
 		return errors.New("database.dbname is required")
 	}
-	return nil
+	if c.TestCrawl.ProxyURL != "" {
+		proxy, err := url.Parse(c.TestCrawl.ProxyURL)
+		if err != nil || (proxy.Scheme != "http" && proxy.Scheme != "https") || proxy.Host == "" {
+			return errors.New("test_crawl.proxy_url must be an http(s) URL")
+		}
+	}
+	if c.CrawlerSync.Enabled && c.SourceHealth.CrawlerURL == "" {
+		return errors.New("crawler_sync requires source_health.crawler_url")
+	}
+	for _, endpoint := range c.Webhooks.URLs {
+		parsed, err := url.Parse(endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errors.New("webhooks.urls must be http(s) URLs")
+		}
+	}
+	return c.Credentials.Validate()
 }
 
 func Load(path string) (*Config, error) {
@@ -201,4 +302,19 @@ func setDefaults(cfg *Config) {
 	if cfg.ICP.ReloadInterval == 0 {
 		cfg.ICP.ReloadInterval = defaultICPReloadInterval
 	}
+	if cfg.SourceHealth.RefreshInterval == 0 {
+		cfg.SourceHealth.RefreshInterval = defaultHealthRefresh
+	}
+	if cfg.SourceHealth.Timeout == 0 {
+		cfg.SourceHealth.Timeout = defaultHealthTimeout
+	}
+	if cfg.Lifecycle.ArchiveRetention == 0 {
+		cfg.Lifecycle.ArchiveRetention = defaultArchiveRetention
+	}
+	if cfg.Webhooks.Timeout == 0 {
+		cfg.Webhooks.Timeout = defaultWebhookTimeout
+	}
+	if cfg.Webhooks.MaxAttempts == 0 {
+		cfg.Webhooks.MaxAttempts = defaultWebhookMaxAttempts
+	}
 }
@@ -199,6 +199,33 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid test crawl proxy",
+			config: Config{
+				Server:    ServerConfig{Host: "0.0.0.0", Port: 8050},
+				Database:  DatabaseConfig{Host: "localhost", Port: 5432, User: "user", DBName: "db"},
+				TestCrawl: TestCrawlConfig{ProxyURL: "nc-http-proxy:8055"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "short credentials key",
+			config: Config{
+				Server:      ServerConfig{Host: "0.0.0.0", Port: 8050},
+				Database:    DatabaseConfig{Host: "localhost", Port: 5432, User: "user", DBName: "db"},
+				Credentials: CredentialsConfig{Key: "abcd"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "crawler sync without crawler URL",
+			config: Config{
+				Server:      ServerConfig{Host: "0.0.0.0", Port: 8050},
+				Database:    DatabaseConfig{Host: "localhost", Port: 5432, User: "user", DBName: "db"},
+				CrawlerSync: CrawlerSyncConfig{Enabled: true},
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty database name",
 			config: Config{
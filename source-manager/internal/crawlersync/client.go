@@ -0,0 +1,145 @@
+// Package crawlersync pushes source changes to the crawler's scheduled jobs
+// through the crawler's reconcile endpoint, which creates, updates, pauses or
+// resumes each source's job to match it and reports what it did.
+package crawlersync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+const (
+	// reconcilePath is the crawler's source/job reconciliation endpoint.
+	reconcilePath = "/api/v1/admin/reconcile-sources"
+	// serviceSubject identifies source-manager in the service token.
+	serviceSubject = "source-manager"
+	// serviceScope lets the token read and write crawler jobs.
+	serviceScope = "crawler:jobs:write"
+)
+
+// Actions the crawler reports for a source's job.
+const (
+	ActionInSync   = "in_sync"
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionPause    = "pause"
+	ActionResume   = "resume"
+	ActionDeferred = "deferred"
+)
+
+// Item is one source whose job is (or was) out of sync.
+type Item struct {
+	SourceID   string   `json:"source_id"`
+	SourceName string   `json:"source_name,omitempty"`
+	JobID      string   `json:"job_id,omitempty"`
+	Action     string   `json:"action"`
+	Changes    []string `json:"changes"`
+	Applied    bool     `json:"applied"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Report is the crawler's reconciliation report. Items lists only sources that
+// were out of sync; Summary counts every checked source by action.
+type Report struct {
+	DryRun         bool           `json:"dry_run"`
+	SourcesChecked int            `json:"sources_checked"`
+	Summary        map[string]int `json:"summary"`
+	Items          []Item         `json:"items"`
+	Errors         int            `json:"errors"`
+}
+
+// Client calls the crawler's reconcile endpoint.
+type Client struct {
+	service  *infrahttp.ServiceClient
+	endpoint string
+	timeout  time.Duration
+	logger   infralogger.Logger
+}
+
+// NewClient creates a client for the crawler at crawlerURL, authenticating with
+// service tokens signed by jwtSecret.
+func NewClient(crawlerURL, jwtSecret string, timeout time.Duration, log infralogger.Logger) *Client {
+	return &Client{
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:   timeout,
+			JWTSecret: jwtSecret,
+			Subject:   serviceSubject,
+			Scope:     serviceScope,
+		}),
+		endpoint: strings.TrimRight(crawlerURL, "/") + reconcilePath,
+		timeout:  timeout,
+		logger:   log,
+	}
+}
+
+// Report returns what reconciling sourceIDs (all sources when empty) would
+// change, without changing anything.
+func (c *Client) Report(ctx context.Context, sourceIDs []string) (*Report, error) {
+	query := url.Values{}
+	for _, id := range sourceIDs {
+		query.Add("source_id", id)
+	}
+	endpoint := c.endpoint
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	return c.do(ctx, http.MethodGet, endpoint, nil)
+}
+
+// Reconcile brings the jobs of sourceIDs (all sources when empty) in line with
+// the sources and reports what changed.
+func (c *Client) Reconcile(ctx context.Context, sourceIDs []string) (*Report, error) {
+	return c.do(ctx, http.MethodPost, c.endpoint, map[string][]string{"source_ids": sourceIDs})
+}
+
+// SyncAsync reconciles the jobs of sourceIDs in the background, logging the
+// outcome. It is a no-op on a nil client or without IDs.
+func (c *Client) SyncAsync(sourceIDs ...string) {
+	if c == nil || len(sourceIDs) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		defer cancel()
+
+		report, err := c.Reconcile(ctx, sourceIDs)
+		if err != nil {
+			c.logger.Warn("Crawler job sync failed",
+				infralogger.Int("source_count", len(sourceIDs)),
+				infralogger.Error(err),
+			)
+			return
+		}
+		for _, item := range report.Items {
+			if item.Error != "" {
+				c.logger.Warn("Crawler job sync item failed",
+					infralogger.String("source_id", item.SourceID),
+					infralogger.String("action", item.Action),
+					infralogger.String("error", item.Error),
+				)
+			}
+		}
+		c.logger.Info("Crawler jobs synced",
+			infralogger.Int("sources_checked", report.SourcesChecked),
+			infralogger.Int("changed", len(report.Items)),
+			infralogger.Int("errors", report.Errors),
+		)
+	}()
+}
+
+// do calls the crawler with a service token and decodes the report.
+func (c *Client) do(ctx context.Context, method, endpoint string, body any) (*Report, error) {
+	var report Report
+	if err := c.service.Do(ctx, method, endpoint, body, &report); err != nil {
+		return nil, fmt.Errorf("call crawler: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,105 @@
+package crawlersync_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/crawlersync"
+)
+
+const reportBody = `{"dry_run":%t,"sources_checked":1,"summary":{"pause":1},` +
+	`"items":[{"source_id":"src-1","job_id":"job-1","action":"pause","changes":["enabled"],"applied":%t}],"errors":0}`
+
+// newReconcileServer serves the crawler's reconcile endpoint, recording the
+// source IDs of each call.
+func newReconcileServer(t *testing.T, gotIDs *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/admin/reconcile-sources" {
+			http.NotFound(w, r)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			http.Error(w, "missing token", http.StatusUnauthorized)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			*gotIDs = r.URL.Query()["source_id"]
+			_, _ = fmt.Fprintf(w, reportBody, true, false)
+		case http.MethodPost:
+			var body struct {
+				SourceIDs []string `json:"source_ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			*gotIDs = body.SourceIDs
+			_, _ = fmt.Fprintf(w, reportBody, false, true)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Report(t *testing.T) {
+	var gotIDs []string
+	server := newReconcileServer(t, &gotIDs)
+	client := crawlersync.NewClient(server.URL+"/", "test-secret", time.Second, infralogger.NewNop())
+
+	report, err := client.Report(context.Background(), []string{"src-1", "src-2"})
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if !report.DryRun || report.Summary[crawlersync.ActionPause] != 1 || report.Items[0].Applied {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if strings.Join(gotIDs, ",") != "src-1,src-2" {
+		t.Errorf("expected both source IDs in the query, got %v", gotIDs)
+	}
+}
+
+func TestClient_Reconcile(t *testing.T) {
+	var gotIDs []string
+	server := newReconcileServer(t, &gotIDs)
+	client := crawlersync.NewClient(server.URL, "test-secret", time.Second, infralogger.NewNop())
+
+	report, err := client.Reconcile(context.Background(), []string{"src-1"})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if report.DryRun || !report.Items[0].Applied || report.Items[0].Action != crawlersync.ActionPause {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if strings.Join(gotIDs, ",") != "src-1" {
+		t.Errorf("expected src-1 in the body, got %v", gotIDs)
+	}
+}
+
+func TestClient_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+	client := crawlersync.NewClient(server.URL, "test-secret", time.Second, infralogger.NewNop())
+
+	_, err := client.Reconcile(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected a 403 error, got %v", err)
+	}
+}
+
+func TestClient_SyncAsyncNil(t *testing.T) {
+	var client *crawlersync.Client
+	client.SyncAsync("src-1") // must not panic
+}
@@ -0,0 +1,223 @@
+// Package credentials stores the secrets sources need to be crawled, encrypted
+// at rest with envelope encryption: each value is sealed with its own random
+// AES-256-GCM data key, and the data key is sealed (wrapped) with the master
+// key. Changing the master key only re-wraps the data keys.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jonesrussell/north-cloud/infrastructure/secretbox"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+)
+
+var (
+	// ErrNotConfigured is returned when no master key is configured.
+	ErrNotConfigured = errors.New("credential encryption key is not configured")
+	// ErrUnknownKey is returned for a credential whose data key was wrapped with a
+	// master key that is neither the current nor the previous key.
+	ErrUnknownKey = secretbox.ErrUnknownKey
+)
+
+// Store saves, lists, resolves and re-wraps source credentials. The value and
+// its data key are both bound to the credential's source and kind, so a
+// ciphertext copied onto another row does not decrypt. A previous master key
+// can be configured while a key change is rolled out; Rewrap moves data keys
+// still wrapped with it onto the current key.
+type Store struct {
+	repo *repository.SourceCredentialRepository
+	keys *secretbox.Keyring
+}
+
+// NewStore creates a store. An empty key leaves the store unconfigured: saving
+// and resolving return ErrNotConfigured. Keys are 64 hex characters (32 bytes).
+func NewStore(repo *repository.SourceCredentialRepository, keyHex, previousKeyHex string) (*Store, error) {
+	keys, err := secretbox.NewKeyring(keyHex, previousKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("credentials %w", err)
+	}
+	return &Store{repo: repo, keys: keys}, nil
+}
+
+// Enabled reports whether a master key is configured.
+func (s *Store) Enabled() bool {
+	return s.keys != nil
+}
+
+// Put encrypts req and stores it as the source's credential of kind, replacing
+// any existing one. The returned credential is masked. req must be validated.
+func (s *Store) Put(
+	ctx context.Context, sourceID, kind string, req *models.SourceCredentialRequest, actor string,
+) (*models.SourceCredential, error) {
+	if !s.Enabled() {
+		return nil, ErrNotConfigured
+	}
+
+	master := s.keys.Current()
+	ciphertext, wrappedKey, err := sealEnvelope(master, associatedData(sourceID, kind), req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &models.SourceCredential{
+		SourceID:   sourceID,
+		Kind:       kind,
+		Username:   req.Username,
+		HeaderName: req.HeaderName,
+		Ciphertext: ciphertext,
+		WrappedKey: wrappedKey,
+		KeyID:      master.ID(),
+		Hint:       models.CredentialHint(req.Value),
+		CreatedBy:  actor,
+	}
+	if upsertErr := s.repo.Upsert(ctx, cred); upsertErr != nil {
+		return nil, upsertErr
+	}
+	cred.Mask()
+	return cred, nil
+}
+
+// List returns a source's credentials with their values masked. It works
+// without a master key, since nothing is decrypted.
+func (s *Store) List(ctx context.Context, sourceID string) ([]models.SourceCredential, error) {
+	creds, err := s.repo.ListBySource(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range creds {
+		creds[i].Mask()
+	}
+	return creds, nil
+}
+
+// Delete removes a source's credential of kind.
+func (s *Store) Delete(ctx context.Context, sourceID, kind string) error {
+	return s.repo.Delete(ctx, sourceID, kind)
+}
+
+// Resolve decrypts a source's credentials for the crawler. A source without
+// credentials resolves to an empty list even when no master key is configured.
+func (s *Store) Resolve(ctx context.Context, sourceID string) ([]models.ResolvedCredential, error) {
+	creds, err := s.repo.ListBySource(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make([]models.ResolvedCredential, 0, len(creds))
+	if len(creds) == 0 {
+		return resolved, nil
+	}
+	if !s.Enabled() {
+		return nil, ErrNotConfigured
+	}
+
+	for i := range creds {
+		value, openErr := s.open(&creds[i])
+		if openErr != nil {
+			return nil, fmt.Errorf("%s: %w", creds[i].Kind, openErr)
+		}
+		resolved = append(resolved, models.ResolvedCredential{
+			Kind:       creds[i].Kind,
+			Username:   creds[i].Username,
+			HeaderName: creds[i].HeaderName,
+			Value:      value,
+		})
+	}
+	return resolved, nil
+}
+
+// Rewrap re-wraps every data key still wrapped with the previous master key
+// with the current key, and returns how many were moved. Values are not
+// re-encrypted. Credentials wrapped with an unknown key are left alone and
+// reported in the error.
+func (s *Store) Rewrap(ctx context.Context) (int, error) {
+	if !s.Enabled() {
+		return 0, ErrNotConfigured
+	}
+	creds, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	master := s.keys.Current()
+	moved := 0
+	var errs []error
+	for i := range creds {
+		cred := &creds[i]
+		if cred.KeyID == master.ID() {
+			continue
+		}
+		aad := associatedData(cred.SourceID, cred.Kind)
+		dataKey, unwrapErr := s.unwrap(cred, aad)
+		if unwrapErr != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", cred.SourceID, cred.Kind, unwrapErr))
+			continue
+		}
+		wrappedKey, wrapErr := master.Seal(dataKey, aad)
+		clear(dataKey)
+		if wrapErr != nil {
+			return moved, wrapErr
+		}
+		if updateErr := s.repo.Rewrap(ctx, cred.ID, wrappedKey, master.ID()); updateErr != nil {
+			return moved, updateErr
+		}
+		moved++
+	}
+	return moved, errors.Join(errs...)
+}
+
+// open decrypts a credential's value.
+func (s *Store) open(cred *models.SourceCredential) (string, error) {
+	aad := associatedData(cred.SourceID, cred.Kind)
+	rawKey, err := s.unwrap(cred, aad)
+	if err != nil {
+		return "", err
+	}
+	defer clear(rawKey)
+
+	dataKey, err := secretbox.NewKey(rawKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := dataKey.Open(cred.Ciphertext, aad)
+	if err != nil {
+		return "", fmt.Errorf("decrypt credential: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// unwrap decrypts a credential's data key with the master key it was wrapped with.
+func (s *Store) unwrap(cred *models.SourceCredential, aad []byte) ([]byte, error) {
+	dataKey, err := s.keys.Open(cred.KeyID, cred.WrappedKey, aad)
+	if errors.Is(err, ErrUnknownKey) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// associatedData binds a ciphertext to its source and kind.
+func associatedData(sourceID, kind string) []byte {
+	return []byte(sourceID + "/" + kind)
+}
+
+// sealEnvelope encrypts value with a new data key and wraps the data key with master.
+func sealEnvelope(master *secretbox.Key, aad []byte, value string) (ciphertext, wrappedKey []byte, err error) {
+	dataKey, rawKey, err := secretbox.GenerateKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate data key: %w", err)
+	}
+	defer clear(rawKey)
+
+	if ciphertext, err = dataKey.Seal([]byte(value), aad); err != nil {
+		return nil, nil, err
+	}
+	if wrappedKey, err = master.Seal(rawKey, aad); err != nil {
+		return nil, nil, err
+	}
+	return ciphertext, wrappedKey, nil
+}
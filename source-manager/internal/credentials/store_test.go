@@ -0,0 +1,194 @@
+//nolint:testpackage // Testing unexported envelopes requires same package access
+package credentials
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jonesrussell/north-cloud/infrastructure/secretbox"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSourceID = "11111111-1111-1111-1111-111111111111"
+	gcmNonceSize = 12
+	gcmTagSize   = 16
+)
+
+var (
+	testKey    = strings.Repeat("ab", secretbox.KeyLength)
+	testOldKey = strings.Repeat("cd", secretbox.KeyLength)
+)
+
+// sealedCredential returns a credential for value sealed by store.
+func sealedCredential(t *testing.T, store *Store, kind, value string) *models.SourceCredential {
+	t.Helper()
+	master := store.keys.Current()
+	ciphertext, wrappedKey, err := sealEnvelope(master, associatedData(testSourceID, kind), value)
+	require.NoError(t, err)
+	return &models.SourceCredential{
+		ID: "cred-" + kind, SourceID: testSourceID, Kind: kind,
+		Ciphertext: ciphertext, WrappedKey: wrappedKey, KeyID: master.ID(),
+	}
+}
+
+// captureBytes is a sqlmock argument that records the []byte it matches.
+type captureBytes struct{ got *[]byte }
+
+func (c captureBytes) Match(v driver.Value) bool {
+	b, ok := v.([]byte)
+	*c.got = b
+	return ok
+}
+
+func newMockRepo(t *testing.T) (*repository.SourceCredentialRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return repository.NewSourceCredentialRepository(db, testhelpers.NewTestLogger()), mock
+}
+
+func credentialRows(creds ...*models.SourceCredential) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "source_id", "kind", "username", "header_name", "ciphertext", "wrapped_key",
+		"key_id", "hint", "created_by", "created_at", "updated_at", "rotated_at",
+	})
+	now := time.Now()
+	for _, c := range creds {
+		rows.AddRow(c.ID, c.SourceID, c.Kind, c.Username, c.HeaderName, c.Ciphertext, c.WrappedKey,
+			c.KeyID, "", "operator", now, now, nil)
+	}
+	return rows
+}
+
+func TestNewStore_Keys(t *testing.T) {
+	store, err := NewStore(nil, "", "")
+	require.NoError(t, err)
+	assert.False(t, store.Enabled())
+
+	_, err = NewStore(nil, "abcd", "")
+	require.Error(t, err, "keys must be 32 bytes")
+	_, err = NewStore(nil, strings.Repeat("zz", secretbox.KeyLength), "")
+	require.Error(t, err, "keys must be hex")
+	_, err = NewStore(nil, testKey, "abcd")
+	require.Error(t, err, "the previous key is checked too")
+}
+
+func TestEnvelope_BindsValueToSourceAndKind(t *testing.T) {
+	store, err := NewStore(nil, testKey, "")
+	require.NoError(t, err)
+
+	cred := sealedCredential(t, store, models.CredentialKindCookie, "session=abc123")
+	assert.NotContains(t, string(cred.Ciphertext), "session=abc123")
+	// A wrapped data key is a GCM nonce, the key and a GCM tag
+	assert.Len(t, cred.WrappedKey, gcmNonceSize+secretbox.KeyLength+gcmTagSize)
+
+	value, err := store.open(cred)
+	require.NoError(t, err)
+	assert.Equal(t, "session=abc123", value)
+
+	moved := *cred
+	moved.Kind = models.CredentialKindAPIKey
+	_, err = store.open(&moved)
+	require.Error(t, err, "a ciphertext copied to another credential must not decrypt")
+
+	other := sealedCredential(t, store, models.CredentialKindCookie, "session=other")
+	swapped := *cred
+	swapped.WrappedKey = other.WrappedKey
+	_, err = store.open(&swapped)
+	require.Error(t, err, "each value has its own data key")
+}
+
+func TestStore_OpensWithPreviousKey(t *testing.T) {
+	old, err := NewStore(nil, testOldKey, "")
+	require.NoError(t, err)
+	cred := sealedCredential(t, old, models.CredentialKindBasicAuth, "hunter2")
+
+	rotated, err := NewStore(nil, testKey, testOldKey)
+	require.NoError(t, err)
+	value, err := rotated.open(cred)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	current, err := NewStore(nil, testKey, "")
+	require.NoError(t, err)
+	_, err = current.open(cred)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestStore_Rewrap(t *testing.T) {
+	old, err := NewStore(nil, testOldKey, "")
+	require.NoError(t, err)
+	stale := sealedCredential(t, old, models.CredentialKindBasicAuth, "hunter2")
+
+	repo, mock := newMockRepo(t)
+	store, err := NewStore(repo, testKey, testOldKey)
+	require.NoError(t, err)
+	fresh := sealedCredential(t, store, models.CredentialKindCookie, "session=abc123")
+
+	var wrapped []byte
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_credentials ORDER BY")).
+		WillReturnRows(credentialRows(stale, fresh))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE source_credentials SET wrapped_key")).
+		WithArgs(stale.ID, captureBytes{&wrapped}, store.keys.Current().ID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	moved, err := store.Rewrap(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The rewrapped credential opens with the current key alone; its ciphertext is unchanged
+	current, err := NewStore(nil, testKey, "")
+	require.NoError(t, err)
+	stale.WrappedKey, stale.KeyID = wrapped, current.keys.Current().ID()
+	value, err := current.open(stale)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+}
+
+func TestStore_Resolve(t *testing.T) {
+	repo, mock := newMockRepo(t)
+	store, err := NewStore(repo, testKey, "")
+	require.NoError(t, err)
+	cred := sealedCredential(t, store, models.CredentialKindAPIKey, "key-1234567890")
+	cred.HeaderName = "X-Api-Key"
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE source_id = $1")).
+		WithArgs(testSourceID).
+		WillReturnRows(credentialRows(cred))
+
+	resolved, err := store.Resolve(t.Context(), testSourceID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ResolvedCredential{
+		{Kind: models.CredentialKindAPIKey, HeaderName: "X-Api-Key", Value: "key-1234567890"},
+	}, resolved)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_ResolveWithoutKey(t *testing.T) {
+	repo, mock := newMockRepo(t)
+	store, err := NewStore(repo, "", "")
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE source_id = $1")).WillReturnRows(credentialRows())
+	resolved, err := store.Resolve(t.Context(), testSourceID)
+	require.NoError(t, err)
+	assert.Empty(t, resolved, "a source without credentials resolves without a key")
+
+	keyed, err := NewStore(nil, testKey, "")
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE source_id = $1")).
+		WillReturnRows(credentialRows(sealedCredential(t, keyed, models.CredentialKindCookie, "session=abc123")))
+	_, err = store.Resolve(t.Context(), testSourceID)
+	require.ErrorIs(t, err, ErrNotConfigured)
+}
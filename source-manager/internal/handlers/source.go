@@ -4,21 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	infraevents "github.com/jonesrussell/north-cloud/infrastructure/events"
 	"github.com/jonesrussell/north-cloud/infrastructure/indigenous"
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/crawlersync"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/events"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/metadata"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/sourcehealth"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/testcrawl"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/webhooks"
 	"github.com/lib/pq"
 )
 
@@ -27,11 +32,14 @@ const (
 	pqUniqueViolation = "23505"
 )
 
-// ImportResult is the response for the import-excel endpoint.
+// ImportResult is the response for the import endpoints.
 type ImportResult struct {
-	Created int                    `json:"created"`
-	Updated int                    `json:"updated"`
-	Errors  []importer.ImportError `json:"errors"`
+	Created int  `json:"created"`
+	Updated int  `json:"updated"`
+	DryRun  bool `json:"dry_run"`
+	// Valid is the number of rows that passed validation.
+	Valid  int                    `json:"valid"`
+	Errors []importer.ImportError `json:"errors"`
 }
 
 type SourceHandler struct {
@@ -40,6 +48,16 @@ type SourceHandler struct {
 	extractor      *metadata.Extractor
 	crawlExtractor *testcrawl.Extractor
 	publisher      *events.Publisher
+	// versions is nil unless version history is enabled (WithVersions).
+	versions *repository.SourceVersionRepository
+	// health is nil unless source health is enabled (WithHealth).
+	health *sourcehealth.Cache
+	// crawlerSync is nil unless crawler sync is enabled (WithCrawlerSync).
+	crawlerSync *crawlersync.Client
+	// archiveRetention is how long archived sources keep their indexes (WithArchiveRetention).
+	archiveRetention time.Duration
+	// webhooks is nil unless change webhooks are enabled (WithWebhooks).
+	webhooks *webhooks.Dispatcher
 }
 
 func NewSourceHandler(repo *repository.SourceRepository, log infralogger.Logger, publisher *events.Publisher) *SourceHandler {
@@ -84,6 +102,12 @@ func (h *SourceHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if source.Lifecycle != "" && source.Lifecycle != models.LifecycleDraft && source.Lifecycle != models.LifecycleActive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a new source's lifecycle must be draft or active"})
+		return
+	}
+
+	source.URL = models.NormalizeSourceURL(source.URL)
 	source.RateLimit = models.NormalizeRateLimit(source.RateLimit)
 
 	if err := h.validateIndigenousRegion(&source); err != nil {
@@ -91,6 +115,27 @@ func (h *SourceHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if c.Query("force") != trueString {
+		idx, err := h.loadDuplicateIndex(c.Request.Context())
+		if err != nil {
+			h.logger.Error("Failed to check for duplicate sources",
+				infralogger.String("source_name", source.Name),
+				infralogger.Error(err),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create source"})
+			return
+		}
+		if duplicates := idx.find(source.URL, source.Name); len(duplicates) > 0 {
+			h.logger.Warn("Duplicate source URL",
+				infralogger.String("source_name", source.Name),
+				infralogger.String("url", source.URL),
+				infralogger.Int("duplicates", len(duplicates)),
+			)
+			c.JSON(http.StatusConflict, gin.H{"error": errDuplicateSource, "duplicates": duplicates})
+			return
+		}
+	}
+
 	if err := h.repo.Create(c.Request.Context(), &source); err != nil {
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
@@ -113,6 +158,12 @@ func (h *SourceHandler) Create(c *gin.Context) {
 		infralogger.String("source_name", source.Name),
 	)
 
+	if h.versions != nil {
+		if created, err := h.repo.GetByID(c.Request.Context(), source.ID); err == nil {
+			h.recordVersion(c, nil, created, models.VersionActionCreate)
+		}
+	}
+
 	// Publish event asynchronously
 	if h.publisher != nil {
 		sourceID, _ := uuid.Parse(source.ID)
@@ -129,6 +180,8 @@ func (h *SourceHandler) Create(c *gin.Context) {
 			},
 		})
 	}
+	h.webhooks.Notify(webhooks.EventCreated, &source, nil)
+	h.syncCrawler(source.ID)
 
 	c.JSON(http.StatusCreated, source)
 }
@@ -175,6 +228,8 @@ func (h *SourceHandler) GetByIdentityKey(c *gin.Context) {
 	c.JSON(http.StatusOK, source)
 }
 
+// List returns a page of sources. With source health enabled, each source
+// carries a health object: its crawl job state and indexed document counts.
 func (h *SourceHandler) List(c *gin.Context) {
 	filter := parseListQuery(c)
 
@@ -200,7 +255,7 @@ func (h *SourceHandler) List(c *gin.Context) {
 	totalPages := (total + filter.Limit - 1) / filter.Limit
 
 	c.JSON(http.StatusOK, gin.H{
-		"sources":     sources,
+		"sources":     h.withHealth(sources),
 		"total":       total,
 		"page":        page,
 		"per_page":    filter.Limit,
@@ -208,7 +263,8 @@ func (h *SourceHandler) List(c *gin.Context) {
 	})
 }
 
-// parseListQuery parses limit, offset, sort_by, sort_order, search, enabled from query params.
+// parseListQuery parses limit, offset, sort_by, sort_order, search and the
+// enabled, lifecycle, type, region and domain filters from query params.
 func parseListQuery(c *gin.Context) repository.ListFilter {
 	const defaultLimit = 100
 	const maxLimit = 500
@@ -233,7 +289,7 @@ func parseListQuery(c *gin.Context) repository.ListFilter {
 
 	sortBy := c.DefaultQuery("sort_by", "name")
 	validSort := map[string]bool{
-		"name": true, "url": true, "enabled": true, "created_at": true,
+		"name": true, "url": true, "enabled": true, "created_at": true, "updated_at": true,
 	}
 	if !validSort[sortBy] {
 		sortBy = "name"
@@ -264,6 +320,11 @@ func parseListQuery(c *gin.Context) repository.ListFilter {
 		feedActive = &t
 	}
 
+	lifecycle := c.Query("lifecycle")
+	if !models.IsValidLifecycle(lifecycle) {
+		lifecycle = ""
+	}
+
 	return repository.ListFilter{
 		Limit:      limit,
 		Offset:     offset,
@@ -272,9 +333,28 @@ func parseListQuery(c *gin.Context) repository.ListFilter {
 		Search:     search,
 		Enabled:    enabled,
 		FeedActive: feedActive,
+		Lifecycle:  lifecycle,
+		Type:       strings.TrimSpace(c.Query("type")),
+		Region:     strings.TrimSpace(c.Query("region")),
+		Domain:     listDomain(c.Query("domain")),
 	}
 }
 
+// listDomain reads the domain filter the way duplicate detection keys URLs:
+// "www.cbc.ca", "cbc.ca" and "https://www.cbc.ca/news" all filter on cbc.ca.
+// A value that is not a host is kept as typed and simply matches nothing.
+func listDomain(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	key, ok := models.URLKeyOf(raw)
+	if !ok {
+		return strings.ToLower(raw)
+	}
+	return key.Domain
+}
+
 func (h *SourceHandler) Update(c *gin.Context) {
 	id := c.Param("id")
 
@@ -294,6 +374,7 @@ func (h *SourceHandler) Update(c *gin.Context) {
 	}
 
 	source.ID = id
+	source.URL = models.NormalizeSourceURL(source.URL)
 	source.RateLimit = models.NormalizeRateLimit(source.RateLimit)
 
 	if err := h.validateIndigenousRegion(&source); err != nil {
@@ -301,11 +382,17 @@ func (h *SourceHandler) Update(c *gin.Context) {
 		return
 	}
 
+	before := h.currentForVersion(c, id)
+
 	if err := h.repo.Update(c.Request.Context(), &source); err != nil {
 		if errors.Is(err, repository.ErrDisableReasonRequired) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "disable_reason is required when disabling a source"})
 			return
 		}
+		if errors.Is(err, repository.ErrSourceArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": errArchivedEnable})
+			return
+		}
 		if errors.Is(err, repository.ErrSourceNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
 			return
@@ -323,37 +410,59 @@ func (h *SourceHandler) Update(c *gin.Context) {
 		infralogger.String("source_name", source.Name),
 	)
 
-	// Publish event asynchronously
-	if h.publisher != nil {
-		sourceID, _ := uuid.Parse(id)
-		h.publisher.PublishAsync(infraevents.SourceEvent{
-			EventType: infraevents.SourceUpdated,
-			SourceID:  sourceID,
-			Payload: infraevents.SourceUpdatedPayload{
-				ChangedFields: []string{}, // TODO: Track changed fields
-				Current: map[string]any{
-					"name":       source.Name,
-					"rate_limit": source.RateLimit,
-					"max_depth":  source.MaxDepth,
-					"enabled":    source.Enabled,
-				},
-			},
-		})
-	}
-
 	// Fetch updated source
 	updated, err := h.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
+		h.publishSourceUpdated(&source, nil)
+		h.syncCrawler(id)
 		c.JSON(http.StatusOK, source)
 		return
 	}
 
+	version := h.recordVersion(c, before, updated, models.VersionActionUpdate)
+	h.publishSourceUpdated(updated, changedFields(version))
+	h.syncCrawlerOnChange(id, changedFields(version))
+
 	c.JSON(http.StatusOK, updated)
 }
 
+// publishSourceUpdated publishes a source.updated event asynchronously and
+// sends the matching webhooks. changed lists the top-level fields that changed,
+// when version history knows them.
+func (h *SourceHandler) publishSourceUpdated(source *models.Source, changed []string) {
+	h.notifyUpdated(source, changed)
+	if h.publisher == nil {
+		return
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	sourceID, _ := uuid.Parse(source.ID)
+	h.publisher.PublishAsync(infraevents.SourceEvent{
+		EventType: infraevents.SourceUpdated,
+		SourceID:  sourceID,
+		Payload: infraevents.SourceUpdatedPayload{
+			ChangedFields: changed,
+			Current: map[string]any{
+				"name":       source.Name,
+				"rate_limit": source.RateLimit,
+				"max_depth":  source.MaxDepth,
+				"enabled":    source.Enabled,
+			},
+		},
+	})
+}
+
 func (h *SourceHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
+	deleted := &models.Source{ID: id}
+	if h.webhooks.Enabled() {
+		if current, err := h.repo.GetByID(c.Request.Context(), id); err == nil {
+			deleted = current
+		}
+	}
+
 	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
 		h.logger.Error("Failed to delete source",
 			infralogger.String("source_id", id),
@@ -367,21 +476,29 @@ func (h *SourceHandler) Delete(c *gin.Context) {
 		infralogger.String("source_id", id),
 	)
 
-	// Publish event asynchronously
-	if h.publisher != nil {
-		sourceID, _ := uuid.Parse(id)
-		h.publisher.PublishAsync(infraevents.SourceEvent{
-			EventType: infraevents.SourceDeleted,
-			SourceID:  sourceID,
-			Payload: infraevents.SourceDeletedPayload{
-				DeletionReason: "user_requested",
-			},
-		})
-	}
+	h.publishSourceDeleted(deleted)
+	h.syncCrawler(id)
 
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// publishSourceDeleted publishes a source.deleted event asynchronously and
+// sends the source.deleted webhook with the source as it was.
+func (h *SourceHandler) publishSourceDeleted(source *models.Source) {
+	h.webhooks.Notify(webhooks.EventDeleted, source, nil)
+	if h.publisher == nil {
+		return
+	}
+	sourceID, _ := uuid.Parse(source.ID)
+	h.publisher.PublishAsync(infraevents.SourceEvent{
+		EventType: infraevents.SourceDeleted,
+		SourceID:  sourceID,
+		Payload: infraevents.SourceDeletedPayload{
+			DeletionReason: "user_requested",
+		},
+	})
+}
+
 func (h *SourceHandler) GetCities(c *gin.Context) {
 	cities, err := h.repo.GetCities(c.Request.Context())
 	if err != nil {
@@ -551,6 +668,10 @@ func (h *SourceHandler) handleEnable(
 			c.JSON(http.StatusNotFound, gin.H{"error": entityLabel + " not found"})
 			return
 		}
+		if errors.Is(err, repository.ErrSourceArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": errArchivedEnable})
+			return
+		}
 		h.logger.Error("Failed to enable "+entityLabel,
 			infralogger.String("source_id", id),
 			infralogger.Error(err),
@@ -577,16 +698,30 @@ func (h *SourceHandler) EnableFeed(c *gin.Context) {
 // DisableSource marks a source as disabled with a reason.
 func (h *SourceHandler) DisableSource(c *gin.Context) {
 	h.handleDisable(c, h.repo.DisableSource, "Source")
+	if c.Writer.Status() == http.StatusOK {
+		h.notifyToggledByID(c, c.Param("id"))
+		h.syncCrawler(c.Param("id"))
+	}
 }
 
 // EnableSource clears a source's disabled state.
 func (h *SourceHandler) EnableSource(c *gin.Context) {
 	h.handleEnable(c, h.repo.EnableSource, "Source")
+	if c.Writer.Status() == http.StatusOK {
+		h.notifyToggledByID(c, c.Param("id"))
+		h.syncCrawler(c.Param("id"))
+	}
 }
 
 // publishImportEvents publishes SourceCreated for created sources and SourceUpdated for updated sources.
 // Created events are published first so the crawler creates jobs before rescheduling.
 func (h *SourceHandler) publishImportEvents(createdList, updatedList []*models.Source) {
+	for _, s := range createdList {
+		h.webhooks.Notify(webhooks.EventCreated, s, nil)
+	}
+	for _, s := range updatedList {
+		h.webhooks.Notify(webhooks.EventUpdated, s, nil)
+	}
 	if h.publisher == nil {
 		return
 	}
@@ -627,20 +762,26 @@ func (h *SourceHandler) publishImportEvents(createdList, updatedList []*models.S
 	)
 }
 
+// sourceIDs returns the IDs of the sources in lists.
+func sourceIDs(lists ...[]*models.Source) []string {
+	var ids []string
+	for _, list := range lists {
+		for _, s := range list {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
+
 // ImportExcel handles bulk import of sources from an Excel file.
+// POST /api/v1/sources/import-excel[?dry_run=true]
 func (h *SourceHandler) ImportExcel(c *gin.Context) {
-	// 1. Extract file from multipart form
-	file, header, err := c.Request.FormFile("file")
-	if err != nil {
-		h.logger.Debug("No file in request",
-			infralogger.Error(err),
-		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+	file, header, ok := h.importUpload(c)
+	if !ok {
 		return
 	}
 	defer file.Close()
 
-	// 2. Validate file extension
 	if !strings.HasSuffix(strings.ToLower(header.Filename), ".xlsx") {
 		h.logger.Debug("Invalid file extension",
 			infralogger.String("filename", header.Filename),
@@ -649,40 +790,104 @@ func (h *SourceHandler) ImportExcel(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Processing Excel import",
-		infralogger.String("filename", header.Filename),
-		infralogger.Int64("size", header.Size),
-	)
+	h.importSources(c, file, header, importer.ParseExcelFile)
+}
 
-	// 3. Parse and validate all rows
-	rows, validationErrors := importer.ParseExcelFile(file)
-	if len(validationErrors) > 0 {
-		h.logger.Debug("Validation errors in Excel file",
-			infralogger.Int("error_count", len(validationErrors)),
+// ImportSources handles bulk import of sources from an Excel, CSV or YAML file, parsed
+// by its extension with the same validation for every format.
+// POST /api/v1/sources/import[?dry_run=true]
+func (h *SourceHandler) ImportSources(c *gin.Context) {
+	file, header, ok := h.importUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	parse, supported := importer.ParserFor(header.Filename)
+	if !supported {
+		h.logger.Debug("Invalid file extension",
+			infralogger.String("filename", header.Filename),
 		)
-		c.JSON(http.StatusBadRequest, ImportResult{Errors: validationErrors})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File must be one of: " + importer.SupportedExtensions})
 		return
 	}
 
-	// 4. Convert to models
+	h.importSources(c, file, header, parse)
+}
+
+// importUpload extracts the uploaded file from the multipart form.
+func (h *SourceHandler) importUpload(c *gin.Context) (multipart.File, *multipart.FileHeader, bool) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		h.logger.Debug("No file in request",
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return nil, nil, false
+	}
+	return file, header, true
+}
+
+// importSources parses, validates and upserts the sources in an import file. With
+// ?dry_run=true nothing is written: the response reports how many rows are valid and
+// every row-level error.
+func (h *SourceHandler) importSources(
+	c *gin.Context, file multipart.File, header *multipart.FileHeader, parse importer.ParseFunc,
+) {
+	dryRun := c.Query("dry_run") == trueString
+	force := c.Query("force") == trueString
+
+	h.logger.Info("Processing source import",
+		infralogger.String("filename", header.Filename),
+		infralogger.Int64("size", header.Size),
+		infralogger.Bool("dry_run", dryRun),
+		infralogger.Bool("force", force),
+	)
+
+	// 1. Parse and validate all rows
+	rows, importErrors := parse(file)
+
+	// 2. Convert to models
 	sources := make([]*models.Source, 0, len(rows))
+	sourceRows := make([]int, 0, len(rows))
 	for _, row := range rows {
 		source, convErr := importer.ToSource(row)
 		if convErr != nil {
 			// This shouldn't happen if validation passed, but handle it
-			h.logger.Error("Failed to convert row to source",
-				infralogger.Int("row", row.Row),
-				infralogger.Error(convErr),
-			)
-			c.JSON(http.StatusBadRequest, ImportResult{
-				Errors: []importer.ImportError{{Row: row.Row, Error: convErr.Error()}},
-			})
-			return
+			importErrors = append(importErrors, importer.ImportError{Row: row.Row, Error: convErr.Error()})
+			continue
 		}
 		sources = append(sources, source)
+		sourceRows = append(sourceRows, row.Row)
+	}
+
+	// 3. Reject new sources that duplicate another source's site, unless forced
+	valid := len(sources)
+	if !force {
+		duplicateErrors, dupErr := h.importDuplicateErrors(c.Request.Context(), sources, sourceRows)
+		if dupErr != nil {
+			h.logger.Error("Failed to check import for duplicate sources", infralogger.Error(dupErr))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import sources"})
+			return
+		}
+		importErrors = append(importErrors, duplicateErrors...)
+		valid -= len(duplicateErrors)
 	}
 
-	// 5. Upsert in transaction
+	if dryRun {
+		c.JSON(http.StatusOK, ImportResult{DryRun: true, Valid: valid, Errors: importErrors})
+		return
+	}
+	if len(importErrors) > 0 {
+		h.logger.Debug("Validation errors in import file",
+			infralogger.String("filename", header.Filename),
+			infralogger.Int("error_count", len(importErrors)),
+		)
+		c.JSON(http.StatusBadRequest, ImportResult{Valid: valid, Errors: importErrors})
+		return
+	}
+
+	// 4. Upsert in transaction
 	createdList, updatedList, err := h.repo.UpsertSourcesTx(c.Request.Context(), sources)
 	if err != nil {
 		h.logger.Error("Failed to import sources",
@@ -692,10 +897,11 @@ func (h *SourceHandler) ImportExcel(c *gin.Context) {
 		return
 	}
 
-	// 6. Publish events: created first, then updated (ordering for crawler job creation before reschedule)
+	// 5. Publish events: created first, then updated (ordering for crawler job creation before reschedule)
 	h.publishImportEvents(createdList, updatedList)
+	h.syncCrawler(sourceIDs(createdList, updatedList)...)
 
-	// 7. Log success and return
+	// 6. Log success and return
 	h.logger.Info("Sources imported successfully",
 		infralogger.Int("created", len(createdList)),
 		infralogger.Int("updated", len(updatedList)),
@@ -705,6 +911,7 @@ func (h *SourceHandler) ImportExcel(c *gin.Context) {
 	c.JSON(http.StatusOK, ImportResult{
 		Created: len(createdList),
 		Updated: len(updatedList),
+		Valid:   len(sources),
 		Errors:  []importer.ImportError{},
 	})
 }
@@ -732,6 +939,7 @@ func (h *SourceHandler) ImportIndigenous(c *gin.Context) {
 
 	// Convert to models.
 	sources := make([]*models.Source, 0, len(indigenousSources))
+	sourceRows := make([]int, 0, len(indigenousSources))
 	for i, src := range indigenousSources {
 		model, convErr := importer.IndigenousSourceToModel(src)
 		if convErr != nil {
@@ -741,6 +949,21 @@ func (h *SourceHandler) ImportIndigenous(c *gin.Context) {
 			return
 		}
 		sources = append(sources, model)
+		sourceRows = append(sourceRows, i+1)
+	}
+
+	// Reject new sources that duplicate another source's site, unless forced.
+	if c.Query("force") != trueString {
+		duplicateErrors, dupErr := h.importDuplicateErrors(c.Request.Context(), sources, sourceRows)
+		if dupErr != nil {
+			h.logger.Error("Failed to check import for duplicate sources", infralogger.Error(dupErr))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import sources"})
+			return
+		}
+		if len(duplicateErrors) > 0 {
+			c.JSON(http.StatusBadRequest, ImportResult{Valid: len(sources) - len(duplicateErrors), Errors: duplicateErrors})
+			return
+		}
 	}
 
 	// Upsert in transaction.
@@ -752,6 +975,7 @@ func (h *SourceHandler) ImportIndigenous(c *gin.Context) {
 	}
 
 	h.publishImportEvents(createdList, updatedList)
+	h.syncCrawler(sourceIDs(createdList, updatedList)...)
 
 	h.logger.Info("Indigenous sources imported",
 		infralogger.Int("created", len(createdList)),
@@ -761,6 +985,7 @@ func (h *SourceHandler) ImportIndigenous(c *gin.Context) {
 	c.JSON(http.StatusOK, ImportResult{
 		Created: len(createdList),
 		Updated: len(updatedList),
+		Valid:   len(sources),
 		Errors:  []importer.ImportError{},
 	})
 }
@@ -840,6 +1065,17 @@ func (h *SourceHandler) BatchCreate(c *gin.Context) {
 		return
 	}
 
+	var duplicates *duplicateIndex
+	if c.Query("force") != trueString {
+		idx, err := h.loadDuplicateIndex(c.Request.Context())
+		if err != nil {
+			h.logger.Error("Failed to check batch for duplicate sources", infralogger.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create sources"})
+			return
+		}
+		duplicates = idx
+	}
+
 	resp := BatchResponse{
 		Created: make([]models.Source, 0, len(req.Sources)),
 		Skipped: make([]string, 0),
@@ -848,6 +1084,7 @@ func (h *SourceHandler) BatchCreate(c *gin.Context) {
 
 	for i := range req.Sources {
 		source := &req.Sources[i]
+		source.URL = models.NormalizeSourceURL(source.URL)
 		source.RateLimit = models.NormalizeRateLimit(source.RateLimit)
 
 		if err := h.validateIndigenousRegion(source); err != nil {
@@ -855,6 +1092,13 @@ func (h *SourceHandler) BatchCreate(c *gin.Context) {
 			continue
 		}
 
+		if duplicates != nil {
+			if found := duplicates.find(source.URL, source.Name); len(found) > 0 {
+				resp.Failed = append(resp.Failed, BatchFailure{Name: source.Name, Error: describeDuplicates(found)})
+				continue
+			}
+		}
+
 		if err := h.repo.Create(c.Request.Context(), source); err != nil {
 			var pqErr *pq.Error
 			if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
@@ -881,8 +1125,17 @@ func (h *SourceHandler) BatchCreate(c *gin.Context) {
 			})
 		}
 
+		if duplicates != nil {
+			duplicates.add(source)
+		}
+		h.webhooks.Notify(webhooks.EventCreated, source, nil)
 		resp.Created = append(resp.Created, *source)
 	}
+	createdIDs := make([]string, 0, len(resp.Created))
+	for i := range resp.Created {
+		createdIDs = append(createdIDs, resp.Created[i].ID)
+	}
+	h.syncCrawler(createdIDs...)
 
 	h.logger.Info("Batch import completed",
 		infralogger.Int("created", len(resp.Created)),
@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+)
+
+// maxBulkSize is the most sources one bulk request may touch.
+const maxBulkSize = 500
+
+// Bulk actions.
+const (
+	BulkActionEnable    = "enable"
+	BulkActionDisable   = "disable"
+	BulkActionRetag     = "retag"
+	BulkActionRateLimit = "rate_limit"
+	BulkActionDelete    = "delete"
+	// BulkActionUpdate is the action reported for bulk updates from a file.
+	BulkActionUpdate = "update"
+)
+
+// Per-item bulk statuses.
+const (
+	BulkStatusUpdated  = "updated"
+	BulkStatusDeleted  = "deleted"
+	BulkStatusValid    = "valid"
+	BulkStatusNotFound = "not_found"
+	BulkStatusFailed   = "failed"
+)
+
+// BulkRequest applies one action to many sources. Reason is required for disable;
+// retag sets Type, IndigenousRegion or both; rate_limit sets RateLimit.
+type BulkRequest struct {
+	Action           string   `binding:"required" json:"action"`
+	IDs              []string `json:"ids"`
+	Reason           string   `json:"reason,omitempty"`
+	Type             string   `json:"type,omitempty"`
+	IndigenousRegion *string  `json:"indigenous_region,omitempty"`
+	RateLimit        string   `json:"rate_limit,omitempty"`
+}
+
+// BulkItemResult is the outcome for one source. Row is set for file updates.
+type BulkItemResult struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Row    int    `json:"row,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkResponse is the response for the bulk endpoints. Succeeded counts updated,
+// deleted and (on a dry run) valid items; every other item counts as failed.
+type BulkResponse struct {
+	Action    string           `json:"action"`
+	DryRun    bool             `json:"dry_run"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+func (r *BulkResponse) add(result BulkItemResult) {
+	switch result.Status {
+	case BulkStatusUpdated, BulkStatusDeleted, BulkStatusValid:
+		r.Succeeded++
+	default:
+		r.Failed++
+	}
+	r.Results = append(r.Results, result)
+}
+
+// changedIDs returns the IDs of the sources that were updated or deleted.
+func (r *BulkResponse) changedIDs() []string {
+	ids := make([]string, 0, r.Succeeded)
+	for i := range r.Results {
+		if status := r.Results[i].Status; status == BulkStatusUpdated || status == BulkStatusDeleted {
+			ids = append(ids, r.Results[i].ID)
+		}
+	}
+	return ids
+}
+
+// validateBulkRequest checks the request as a whole, so a malformed request
+// fails before any source is touched.
+func (h *SourceHandler) validateBulkRequest(req *BulkRequest) error {
+	if len(req.IDs) == 0 {
+		return errors.New("ids array is empty")
+	}
+	if len(req.IDs) > maxBulkSize {
+		return fmt.Errorf("bulk size exceeds maximum of %d", maxBulkSize)
+	}
+
+	switch req.Action {
+	case BulkActionEnable, BulkActionDelete:
+		return nil
+	case BulkActionDisable:
+		if strings.TrimSpace(req.Reason) == "" {
+			return errors.New("reason is required when disabling sources")
+		}
+		return nil
+	case BulkActionRetag:
+		if req.Type == "" && req.IndigenousRegion == nil {
+			return errors.New("retag requires type or indigenous_region")
+		}
+		if req.Type != "" && !models.IsValidSourceType(req.Type) {
+			return fmt.Errorf("invalid source type: %s", req.Type)
+		}
+		if req.IndigenousRegion == nil {
+			return nil
+		}
+		probe := models.Source{IndigenousRegion: req.IndigenousRegion}
+		if err := h.validateIndigenousRegion(&probe); err != nil {
+			return err
+		}
+		if probe.IndigenousRegion == nil {
+			// An explicit empty region clears it.
+			empty := ""
+			probe.IndigenousRegion = &empty
+		}
+		req.IndigenousRegion = probe.IndigenousRegion
+		return nil
+	case BulkActionRateLimit:
+		if !models.IsValidRateLimit(req.RateLimit) {
+			return fmt.Errorf("invalid rate_limit: %q", req.RateLimit)
+		}
+		req.RateLimit = models.NormalizeRateLimit(req.RateLimit)
+		return nil
+	default:
+		return fmt.Errorf("unknown action %q: must be one of %s, %s, %s, %s, %s", req.Action,
+			BulkActionEnable, BulkActionDisable, BulkActionRetag, BulkActionRateLimit, BulkActionDelete)
+	}
+}
+
+// applyBulkAction changes source as req asks. It is not called for deletes.
+func applyBulkAction(source *models.Source, req *BulkRequest) {
+	switch req.Action {
+	case BulkActionEnable:
+		source.Enabled = true
+	case BulkActionDisable:
+		source.Enabled = false
+		reason := req.Reason
+		source.DisableReason = &reason
+	case BulkActionRetag:
+		if req.Type != "" {
+			source.Type = req.Type
+		}
+		if req.IndigenousRegion != nil {
+			source.IndigenousRegion = req.IndigenousRegion
+			if *req.IndigenousRegion == "" {
+				source.IndigenousRegion = nil
+			}
+		}
+	case BulkActionRateLimit:
+		source.RateLimit = req.RateLimit
+	}
+}
+
+// Bulk enables, disables, retags, changes the rate limit of, or deletes many
+// sources in one request. Each source succeeds or fails on its own, and changes
+// record versions and publish events exactly as the single-source endpoints do.
+// POST /api/v1/sources/bulk
+func (h *SourceHandler) Bulk(c *gin.Context) {
+	var req BulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if err := h.validateBulkRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := BulkResponse{Action: req.Action, Results: make([]BulkItemResult, 0, len(req.IDs))}
+	seen := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		resp.add(h.bulkItem(c, id, &req))
+	}
+	// Retagging changes nothing a crawler job depends on
+	if req.Action != BulkActionRetag {
+		h.syncCrawler(resp.changedIDs()...)
+	}
+
+	h.logger.Info("Bulk source operation completed",
+		infralogger.String("action", req.Action),
+		infralogger.Int("succeeded", resp.Succeeded),
+		infralogger.Int("failed", resp.Failed),
+	)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// bulkItem applies req to the source with the given ID.
+func (h *SourceHandler) bulkItem(c *gin.Context, id string, req *BulkRequest) BulkItemResult {
+	if _, err := uuid.Parse(id); err != nil {
+		return BulkItemResult{ID: id, Status: BulkStatusFailed, Error: "invalid source ID"}
+	}
+
+	before, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		return BulkItemResult{ID: id, Status: BulkStatusNotFound, Error: "source not found"}
+	}
+
+	if req.Action == BulkActionDelete {
+		if deleteErr := h.repo.Delete(c.Request.Context(), id); deleteErr != nil {
+			if errors.Is(deleteErr, repository.ErrSourceNotFound) {
+				return BulkItemResult{ID: id, Name: before.Name, Status: BulkStatusNotFound, Error: "source not found"}
+			}
+			h.logger.Error("Failed to delete source",
+				infralogger.String("source_id", id),
+				infralogger.Error(deleteErr),
+			)
+			return BulkItemResult{ID: id, Name: before.Name, Status: BulkStatusFailed, Error: "database error"}
+		}
+		h.publishSourceDeleted(before)
+		return BulkItemResult{ID: id, Name: before.Name, Status: BulkStatusDeleted}
+	}
+
+	source := *before
+	applyBulkAction(&source, req)
+	return h.bulkUpdate(c, before, &source)
+}
+
+// bulkUpdate saves source over before, recording a version and publishing
+// source.updated as Update does.
+func (h *SourceHandler) bulkUpdate(c *gin.Context, before, source *models.Source) BulkItemResult {
+	result := BulkItemResult{ID: source.ID, Name: source.Name}
+
+	if err := h.repo.Update(c.Request.Context(), source); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDisableReasonRequired):
+			result.Status, result.Error = BulkStatusFailed, "disable reason is required when disabling a source"
+		case errors.Is(err, repository.ErrSourceNotFound):
+			result.Status, result.Error = BulkStatusNotFound, "source not found"
+		case errors.Is(err, repository.ErrSourceArchived):
+			result.Status, result.Error = BulkStatusFailed, errArchivedEnable
+		default:
+			h.logger.Error("Failed to update source",
+				infralogger.String("source_id", source.ID),
+				infralogger.Error(err),
+			)
+			result.Status, result.Error = BulkStatusFailed, "database error"
+		}
+		return result
+	}
+
+	updated, err := h.repo.GetByID(c.Request.Context(), source.ID)
+	if err != nil {
+		h.publishSourceUpdated(source, nil)
+		result.Status = BulkStatusUpdated
+		return result
+	}
+
+	version := h.recordVersion(c, before, updated, models.VersionActionUpdate)
+	h.publishSourceUpdated(updated, changedFields(version))
+
+	result.Status = BulkStatusUpdated
+	return result
+}
+
+// BulkUpdateFromFile updates existing sources from an Excel, CSV or YAML file in
+// the import format, typically an edited export. Rows are matched to sources by
+// name; a row with no matching source is reported as not_found and never created.
+// Every column is applied, except that empty rate_limit, time and selectors cells
+// keep the current value. The "reason" form field is the disable reason for rows
+// that disable a source. With ?dry_run=true nothing is written.
+// POST /api/v1/sources/bulk/update[?dry_run=true]
+func (h *SourceHandler) BulkUpdateFromFile(c *gin.Context) {
+	file, header, ok := h.importUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	parse, supported := importer.ParserFor(header.Filename)
+	if !supported {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File must be one of: " + importer.SupportedExtensions})
+		return
+	}
+
+	dryRun := c.Query("dry_run") == trueString
+	reason := strings.TrimSpace(c.PostForm("reason"))
+
+	rows, rowErrors := parse(file)
+	if len(rows)+len(rowErrors) > maxBulkSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("bulk size exceeds maximum of %d", maxBulkSize)})
+		return
+	}
+
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Name)
+	}
+	existing, err := h.repo.GetByNames(c.Request.Context(), names)
+	if err != nil {
+		h.logger.Error("Failed to look up sources for bulk update", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up sources"})
+		return
+	}
+
+	resp := BulkResponse{
+		Action:  BulkActionUpdate,
+		DryRun:  dryRun,
+		Results: make([]BulkItemResult, 0, len(rows)+len(rowErrors)),
+	}
+	for _, rowErr := range rowErrors {
+		resp.add(BulkItemResult{Row: rowErr.Row, Status: BulkStatusFailed, Error: rowErr.Error})
+	}
+	for _, row := range rows {
+		before, found := existing[row.Name]
+		if !found {
+			resp.add(BulkItemResult{Name: row.Name, Row: row.Row, Status: BulkStatusNotFound, Error: "source not found"})
+			continue
+		}
+
+		source, applyErr := applyFileRow(before, row, reason)
+		if applyErr != nil {
+			resp.add(BulkItemResult{ID: before.ID, Name: row.Name, Row: row.Row, Status: BulkStatusFailed, Error: applyErr.Error()})
+			continue
+		}
+		if dryRun {
+			resp.add(BulkItemResult{ID: before.ID, Name: row.Name, Row: row.Row, Status: BulkStatusValid})
+			continue
+		}
+
+		result := h.bulkUpdate(c, before, source)
+		result.Row = row.Row
+		resp.add(result)
+	}
+	h.syncCrawler(resp.changedIDs()...)
+
+	h.logger.Info("Bulk update from file completed",
+		infralogger.String("filename", header.Filename),
+		infralogger.Bool("dry_run", dryRun),
+		infralogger.Int("succeeded", resp.Succeeded),
+		infralogger.Int("failed", resp.Failed),
+	)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// applyFileRow returns before with a file row's fields applied.
+func applyFileRow(before *models.Source, row importer.SourceRow, reason string) (*models.Source, error) {
+	parsed, err := importer.ToSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	source := *before
+	source.URL = parsed.URL
+	source.Enabled = parsed.Enabled
+	source.MaxDepth = parsed.MaxDepth
+	if strings.TrimSpace(row.RateLimit) != "" {
+		source.RateLimit = parsed.RateLimit
+	}
+	if strings.TrimSpace(row.Time) != "" {
+		source.Time = parsed.Time
+	}
+	if strings.TrimSpace(row.Selectors) != "" {
+		source.Selectors = parsed.Selectors.MergeWithDefaults()
+	}
+	if before.Enabled && !source.Enabled {
+		if reason == "" {
+			return nil, errors.New("reason is required to disable a source")
+		}
+		source.DisableReason = &reason
+	}
+	return &source, nil
+}
@@ -0,0 +1,183 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	bulkSourceA = "11111111-1111-1111-1111-111111111111"
+	bulkSourceB = "22222222-2222-2222-2222-222222222222"
+)
+
+func newBulkRouter(t *testing.T) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	t.Cleanup(cleanup)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/sources/bulk", handler.Bulk)
+	router.POST("/api/v1/sources/bulk/update", handler.BulkUpdateFromFile)
+	return router, mock
+}
+
+func expectBulkGet(mock sqlmock.Sqlmock, id, name string) {
+	rows := sqlmock.NewRows(sourceListCols())
+	addSourceRowExtra(rows, id, name)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, url")).WithArgs(id).WillReturnRows(rows)
+}
+
+func postBulk(t *testing.T, router *gin.Engine, body string) (*httptest.ResponseRecorder, handlers.BulkResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sources/bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp handlers.BulkResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w, resp
+}
+
+func TestSourceHandler_Bulk_Disable(t *testing.T) {
+	router, mock := newBulkRouter(t)
+
+	expectBulkGet(mock, bulkSourceA, "Example News")
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).
+		WithArgs(bulkSourceA, "Example News", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), false,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "off season", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectBulkGet(mock, bulkSourceA, "Example News")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, url")).
+		WithArgs(bulkSourceB).
+		WillReturnRows(sqlmock.NewRows(sourceListCols()))
+
+	body := `{"action":"disable","reason":"off season","ids":["` + bulkSourceA + `","` + bulkSourceB + `","` +
+		bulkSourceA + `","not-a-uuid"]}`
+	w, resp := postBulk(t, router, body)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, handlers.BulkActionDisable, resp.Action)
+	assert.Equal(t, 1, resp.Succeeded)
+	assert.Equal(t, 2, resp.Failed)
+	assert.Equal(t, []handlers.BulkItemResult{
+		{ID: bulkSourceA, Name: "Example News", Status: handlers.BulkStatusUpdated},
+		{ID: bulkSourceB, Status: handlers.BulkStatusNotFound, Error: "source not found"},
+		{ID: "not-a-uuid", Status: handlers.BulkStatusFailed, Error: "invalid source ID"},
+	}, resp.Results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_Bulk_Delete(t *testing.T) {
+	router, mock := newBulkRouter(t)
+
+	expectBulkGet(mock, bulkSourceA, "Example News")
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sources")).
+		WithArgs(bulkSourceA).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	w, resp := postBulk(t, router, `{"action":"delete","ids":["`+bulkSourceA+`"]}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []handlers.BulkItemResult{
+		{ID: bulkSourceA, Name: "Example News", Status: handlers.BulkStatusDeleted},
+	}, resp.Results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_Bulk_InvalidRequests(t *testing.T) {
+	router, mock := newBulkRouter(t)
+	ids := `"ids":["` + bulkSourceA + `"]`
+
+	bodies := map[string]string{
+		"no ids":             `{"action":"enable","ids":[]}`,
+		"unknown action":     `{"action":"archive",` + ids + `}`,
+		"disable w/o reason": `{"action":"disable",` + ids + `}`,
+		"retag w/o tags":     `{"action":"retag",` + ids + `}`,
+		"invalid type":       `{"action":"retag","type":"gossip",` + ids + `}`,
+		"invalid region":     `{"action":"retag","indigenous_region":"atlantis",` + ids + `}`,
+		"invalid rate limit": `{"action":"rate_limit","rate_limit":"fast",` + ids + `}`,
+		"too many ids":       `{"action":"enable","ids":[` + strings.Repeat(`"x",`, 500) + `"x"]}`,
+	}
+	for name, body := range bodies {
+		w, _ := postBulk(t, router, body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+	// Nothing was read or written
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_BulkUpdateFromFile_DryRun(t *testing.T) {
+	router, mock := newBulkRouter(t)
+
+	rows := sqlmock.NewRows(sourceListCols())
+	addSourceRowExtra(rows, bulkSourceA, "Example News")
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE name = ANY($1)")).WillReturnRows(rows)
+
+	csv := "name,url,enabled,rate_limit\n" +
+		"Example News,https://example.com,false,5s\n" +
+		"Missing News,https://missing.example.com,true,5s\n" +
+		"Bad,example.com,true,5s\n"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "/api/v1/sources/bulk/update?dry_run=true", "sources.csv", csv))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp handlers.BulkResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, handlers.BulkActionUpdate, resp.Action)
+	assert.Equal(t, []handlers.BulkItemResult{
+		{Row: 4, Status: handlers.BulkStatusFailed, Error: "url must start with http:// or https://"},
+		// Disabling needs the reason form field
+		{ID: bulkSourceA, Name: "Example News", Row: 2, Status: handlers.BulkStatusFailed,
+			Error: "reason is required to disable a source"},
+		{Name: "Missing News", Row: 3, Status: handlers.BulkStatusNotFound, Error: "source not found"},
+	}, resp.Results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_BulkUpdateFromFile(t *testing.T) {
+	router, mock := newBulkRouter(t)
+
+	rows := sqlmock.NewRows(sourceListCols())
+	addSourceRowExtra(rows, bulkSourceA, "Example News")
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE name = ANY($1)")).WillReturnRows(rows)
+	// Only the file's rate limit and depth change; time and selectors are kept
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).
+		WithArgs(bulkSourceA, "Example News", "https://example.com", "5s", 3,
+			[]byte(`["09:00"]`), sqlmock.AnyArg(), true,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectBulkGet(mock, bulkSourceA, "Example News")
+
+	yaml := "- name: Example News\n  url: https://example.com\n  rate_limit: 5s\n  max_depth: 3\n"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "/api/v1/sources/bulk/update", "sources.yaml", yaml))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp handlers.BulkResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Succeeded)
+	assert.Equal(t, []handlers.BulkItemResult{
+		{ID: bulkSourceA, Name: "Example News", Row: 1, Status: handlers.BulkStatusUpdated},
+	}, resp.Results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/crawlersync"
+)
+
+// crawlerSyncFields are the source fields a crawler job mirrors or is scheduled by.
+var crawlerSyncFields = []string{"name", "url", "rate_limit", "max_depth", "time", "enabled"}
+
+// WithCrawlerSync enables pushing source changes to the crawler's jobs.
+func (h *SourceHandler) WithCrawlerSync(client *crawlersync.Client) *SourceHandler {
+	h.crawlerSync = client
+	return h
+}
+
+// syncCrawler reconciles the crawler jobs of the given sources in the background.
+// It is a no-op unless crawler sync is enabled.
+func (h *SourceHandler) syncCrawler(ids ...string) {
+	h.crawlerSync.SyncAsync(ids...)
+}
+
+// syncCrawlerOnChange syncs a source's job unless changed (when known) lists
+// only fields the job does not depend on, such as type or notes.
+func (h *SourceHandler) syncCrawlerOnChange(id string, changed []string) {
+	if len(changed) > 0 && !slices.ContainsFunc(changed, func(field string) bool {
+		return slices.Contains(crawlerSyncFields, field)
+	}) {
+		return
+	}
+	h.syncCrawler(id)
+}
+
+// crawlerSyncRequest optionally limits a sync to some sources.
+type crawlerSyncRequest struct {
+	SourceIDs []string `json:"source_ids"`
+}
+
+// GetCrawlerSyncReport reports how the crawler's jobs differ from the sources,
+// without changing anything.
+// GET /api/v1/sources/crawler-sync[?source_id=...]
+func (h *SourceHandler) GetCrawlerSyncReport(c *gin.Context) {
+	if h.crawlerSync == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "crawler sync is not enabled"})
+		return
+	}
+	ids := c.QueryArray("source_id")
+	if !validSourceIDs(c, ids) {
+		return
+	}
+
+	report, err := h.crawlerSync.Report(c.Request.Context(), ids)
+	if err != nil {
+		h.logger.Error("Failed to get crawler sync report", infralogger.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to reach crawler"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// SyncCrawler creates, updates, pauses or resumes the crawler's jobs to match
+// the sources (all of them unless source_ids is given) and returns the
+// reconciliation report.
+// POST /api/v1/sources/crawler-sync
+func (h *SourceHandler) SyncCrawler(c *gin.Context) {
+	if h.crawlerSync == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "crawler sync is not enabled"})
+		return
+	}
+	var req crawlerSyncRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+			return
+		}
+	}
+	if !validSourceIDs(c, req.SourceIDs) {
+		return
+	}
+
+	report, err := h.crawlerSync.Reconcile(c.Request.Context(), req.SourceIDs)
+	if err != nil {
+		h.logger.Error("Failed to sync crawler jobs", infralogger.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to reach crawler"})
+		return
+	}
+
+	h.logger.Info("Crawler jobs synced",
+		infralogger.Int("sources_checked", report.SourcesChecked),
+		infralogger.Int("changed", len(report.Items)),
+		infralogger.Int("errors", report.Errors),
+	)
+	c.JSON(http.StatusOK, report)
+}
+
+// validSourceIDs writes a 400 and returns false if any ID is not a UUID.
+func validSourceIDs(c *gin.Context, ids []string) bool {
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid source ID: " + id})
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/crawlersync"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const syncSourceID = "11111111-1111-1111-1111-111111111111"
+
+// newFakeCrawler serves the crawler's reconcile endpoint, sending the source IDs
+// of each POST on synced.
+func newFakeCrawler(t *testing.T, synced chan<- []string) *httptest.Server {
+	t.Helper()
+	crawler := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/admin/reconcile-sources" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			var body struct {
+				SourceIDs []string `json:"source_ids"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			synced <- body.SourceIDs
+		}
+		_, _ = fmt.Fprintf(w, `{"dry_run":%t,"sources_checked":1,"summary":{"resume":1},"items":[{"source_id":%q,`+
+			`"job_id":"job-1","action":"resume","changes":["enabled"],"applied":false}],"errors":0}`,
+			r.Method == http.MethodGet, syncSourceID)
+	}))
+	t.Cleanup(crawler.Close)
+	return crawler
+}
+
+func TestSourceHandler_CrawlerSyncReport(t *testing.T) {
+	crawler := newFakeCrawler(t, make(chan []string, 1))
+	handler, _, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	handler.WithCrawlerSync(crawlersync.NewClient(crawler.URL, "", time.Second, testhelpers.NewTestLogger()))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/sources/crawler-sync", handler.GetCrawlerSyncReport)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v1/sources/crawler-sync?source_id="+syncSourceID, http.NoBody))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var report crawlersync.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Items, 1)
+	assert.Equal(t, crawlersync.ActionResume, report.Items[0].Action)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/sources/crawler-sync?source_id=nope", http.NoBody))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSourceHandler_CrawlerSyncDisabled(t *testing.T) {
+	handler, _, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/sources/crawler-sync", handler.SyncCrawler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/sources/crawler-sync", http.NoBody))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestSourceHandler_EnableSource_SyncsCrawler(t *testing.T) {
+	synced := make(chan []string, 1)
+	crawler := newFakeCrawler(t, synced)
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	handler.WithCrawlerSync(crawlersync.NewClient(crawler.URL, "", time.Second, testhelpers.NewTestLogger()))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PATCH("/api/v1/sources/:id/enable", handler.EnableSource)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).
+		WithArgs(syncSourceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/api/v1/sources/"+syncSourceID+"/enable", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	select {
+	case ids := <-synced:
+		assert.Equal(t, []string{syncSourceID}, ids)
+	case <-time.After(time.Second):
+		t.Fatal("expected the source's crawler job to be synced")
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/credentials"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+)
+
+// CredentialResolveScope is the scope a token needs to resolve decrypted
+// credentials. It must be granted explicitly: full-access, "read" and
+// "sources:read" tokens are refused, so only the crawler sees plaintext.
+const CredentialResolveScope = "sources:credentials:read"
+
+// SourceCredentialHandler provides HTTP handlers for the credentials sources
+// need to be crawled. Values are write-only: every response masks them except
+// Resolve, which is restricted to CredentialResolveScope.
+type SourceCredentialHandler struct {
+	store  *credentials.Store
+	logger infralogger.Logger
+}
+
+// NewSourceCredentialHandler creates a new SourceCredentialHandler.
+func NewSourceCredentialHandler(store *credentials.Store, log infralogger.Logger) *SourceCredentialHandler {
+	return &SourceCredentialHandler{
+		store:  store,
+		logger: log,
+	}
+}
+
+// List returns a source's credentials with masked values.
+// GET /api/v1/sources/:id/credentials
+func (h *SourceCredentialHandler) List(c *gin.Context) {
+	sourceID := c.Param("id")
+
+	creds, err := h.store.List(c.Request.Context(), sourceID)
+	if err != nil {
+		h.logger.Error("Failed to list source credentials",
+			infralogger.String("source_id", sourceID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"credentials": creds,
+		"count":       len(creds),
+		"enabled":     h.store.Enabled(),
+	})
+}
+
+// Put stores or rotates a source's credential of one kind.
+// PUT /api/v1/sources/:id/credentials/:kind
+func (h *SourceCredentialHandler) Put(c *gin.Context) {
+	sourceID := c.Param("id")
+	kind := c.Param("kind")
+
+	var req models.SourceCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if err := req.Validate(kind); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cred, err := h.store.Put(c.Request.Context(), sourceID, kind, &req, versionAuthor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, credentials.ErrNotConfigured):
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Credential storage is disabled: set SOURCE_CREDENTIALS_KEY",
+			})
+		case errors.Is(err, repository.ErrSourceNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		default:
+			h.logger.Error("Failed to store source credential",
+				infralogger.String("source_id", sourceID),
+				infralogger.String("kind", kind),
+				infralogger.Error(err),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store credential"})
+		}
+		return
+	}
+
+	h.logger.Info("Source credential stored",
+		infralogger.String("source_id", sourceID),
+		infralogger.String("kind", kind),
+		infralogger.String("actor", cred.CreatedBy),
+		infralogger.Bool("rotated", cred.RotatedAt != nil),
+	)
+	c.JSON(http.StatusOK, gin.H{"credential": cred})
+}
+
+// Delete removes a source's credential of one kind.
+// DELETE /api/v1/sources/:id/credentials/:kind
+func (h *SourceCredentialHandler) Delete(c *gin.Context) {
+	sourceID := c.Param("id")
+	kind := c.Param("kind")
+
+	if err := h.store.Delete(c.Request.Context(), sourceID, kind); err != nil {
+		if errors.Is(err, repository.ErrCredentialNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Credential not found"})
+			return
+		}
+		h.logger.Error("Failed to delete source credential",
+			infralogger.String("source_id", sourceID),
+			infralogger.String("kind", kind),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete credential"})
+		return
+	}
+
+	h.logger.Info("Source credential deleted",
+		infralogger.String("source_id", sourceID),
+		infralogger.String("kind", kind),
+		infralogger.String("actor", versionAuthor(c)),
+	)
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
+// Resolve returns a source's decrypted credentials for the crawler to apply at
+// fetch time. The token must carry CredentialResolveScope explicitly.
+// GET /api/v1/sources/:id/credentials/resolved
+func (h *SourceCredentialHandler) Resolve(c *gin.Context) {
+	sourceID := c.Param("id")
+
+	if claims, ok := jwt.GetClaims(c); ok && !slices.Contains(claims.Scopes(), CredentialResolveScope) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":          "token scope does not allow this operation",
+			"required_scope": CredentialResolveScope,
+		})
+		return
+	}
+
+	resolved, err := h.store.Resolve(c.Request.Context(), sourceID)
+	if err != nil {
+		if errors.Is(err, credentials.ErrNotConfigured) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Credential storage is disabled: set SOURCE_CREDENTIALS_KEY",
+			})
+			return
+		}
+		h.logger.Error("Failed to resolve source credentials",
+			infralogger.String("source_id", sourceID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"credentials": resolved})
+}
+
+// Rewrap moves data keys still wrapped with the previous master key onto the
+// current one. Run it after rotating SOURCE_CREDENTIALS_KEY, before dropping
+// SOURCE_CREDENTIALS_PREVIOUS_KEY.
+// POST /api/v1/sources/credentials/rewrap
+func (h *SourceCredentialHandler) Rewrap(c *gin.Context) {
+	moved, err := h.store.Rewrap(c.Request.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, credentials.ErrNotConfigured):
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Credential storage is disabled: set SOURCE_CREDENTIALS_KEY",
+			})
+		case errors.Is(err, credentials.ErrUnknownKey):
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     "Some credentials are wrapped with an unknown key",
+				"details":   err.Error(),
+				"rewrapped": moved,
+			})
+		default:
+			h.logger.Error("Failed to rewrap source credentials", infralogger.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rewrap credentials", "rewrapped": moved})
+		}
+		return
+	}
+
+	h.logger.Info("Source credentials rewrapped",
+		infralogger.Int("rewrapped", moved),
+		infralogger.String("actor", versionAuthor(c)),
+	)
+	c.JSON(http.StatusOK, gin.H{"rewrapped": moved})
+}
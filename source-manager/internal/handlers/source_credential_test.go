@@ -0,0 +1,144 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/credentials"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	credentialSecret   = "test-secret"
+	credentialSourceID = "11111111-1111-1111-1111-111111111111"
+)
+
+// newCredentialRouter serves the credential endpoints behind JWT auth, with a
+// store over a mock database. An empty key leaves the store unconfigured.
+func newCredentialRouter(t *testing.T, key string) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	log := testhelpers.NewTestLogger()
+	store, err := credentials.NewStore(repository.NewSourceCredentialRepository(db, log), key, "")
+	require.NoError(t, err)
+	handler := handlers.NewSourceCredentialHandler(store, log)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	sources := router.Group("/api/v1/sources", jwt.Middleware(credentialSecret))
+	sources.GET("/:id/credentials", handler.List)
+	sources.PUT("/:id/credentials/:kind", handler.Put)
+	router.GET("/api/v1/sources/:id/credentials/resolved",
+		jwt.Middleware(credentialSecret, jwt.WithRequiredScope(handlers.CredentialResolveScope)), handler.Resolve)
+	return router, mock
+}
+
+func credentialRequest(t *testing.T, method, path, scope string, body any) *http.Request {
+	t.Helper()
+	token, err := jwt.NewServiceToken(credentialSecret, "tester", scope, time.Minute)
+	require.NoError(t, err)
+
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestSourceCredentialHandler_PutMasksValue(t *testing.T) {
+	router, mock := newCredentialRouter(t, strings.Repeat("ab", 32))
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO source_credentials")).
+		WithArgs(sqlmock.AnyArg(), credentialSourceID, "basic_auth", "crawler", "",
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "7890", "tester").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_by", "created_at", "updated_at", "rotated_at"}).
+			AddRow("cred-1", "tester", now, now, nil))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, credentialRequest(t, http.MethodPut,
+		"/api/v1/sources/"+credentialSourceID+"/credentials/basic_auth", "",
+		map[string]string{"username": "crawler", "value": "secret-123456-7890"}))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "secret-123456")
+	assert.Contains(t, w.Body.String(), `"masked_value":"****7890"`)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceCredentialHandler_PutValidation(t *testing.T) {
+	router, _ := newCredentialRouter(t, strings.Repeat("ab", 32))
+
+	tests := []struct {
+		name string
+		kind string
+		body map[string]string
+	}{
+		{"unknown kind", "oauth", map[string]string{"value": "x"}},
+		{"missing value", "cookie", map[string]string{}},
+		{"basic auth without username", "basic_auth", map[string]string{"value": "x"}},
+		{"api key with reserved header", "api_key", map[string]string{"header_name": "host", "value": "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, credentialRequest(t, http.MethodPut,
+				"/api/v1/sources/"+credentialSourceID+"/credentials/"+tt.kind, "", tt.body))
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestSourceCredentialHandler_PutWithoutKey(t *testing.T) {
+	router, _ := newCredentialRouter(t, "")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, credentialRequest(t, http.MethodPut,
+		"/api/v1/sources/"+credentialSourceID+"/credentials/cookie", "",
+		map[string]string{"value": "session=abc"}))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestSourceCredentialHandler_ResolveRequiresExplicitScope(t *testing.T) {
+	router, mock := newCredentialRouter(t, strings.Repeat("ab", 32))
+	path := "/api/v1/sources/" + credentialSourceID + "/credentials/resolved"
+
+	for _, scope := range []string{"", jwt.ScopeAdmin, jwt.ScopeRead, "sources:read"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, credentialRequest(t, http.MethodGet, path, scope, nil))
+		assert.Equal(t, http.StatusForbidden, w.Code, "scope %q must not resolve credentials", scope)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_credentials WHERE source_id = $1")).
+		WithArgs(credentialSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "source_id", "kind", "username", "header_name", "ciphertext", "wrapped_key",
+			"key_id", "hint", "created_by", "created_at", "updated_at", "rotated_at",
+		}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, credentialRequest(t, http.MethodGet, path, handlers.CredentialResolveScope, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"credentials":[]}`, w.Body.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
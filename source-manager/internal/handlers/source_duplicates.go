@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+// errDuplicateSource is returned when a new source's URL duplicates another's.
+const errDuplicateSource = "source URL duplicates an existing source; retry with ?force=true to create it anyway"
+
+// indexedSource is a source with its URL key.
+type indexedSource struct {
+	source models.Source
+	key    models.SourceURLKey
+}
+
+// duplicateIndex finds the sources that crawl the same site as a URL.
+type duplicateIndex struct {
+	byDomain map[string][]indexedSource
+	names    map[string]bool
+}
+
+func newDuplicateIndex(sources []models.Source) *duplicateIndex {
+	idx := &duplicateIndex{byDomain: make(map[string][]indexedSource), names: make(map[string]bool)}
+	for i := range sources {
+		idx.add(&sources[i])
+	}
+	return idx
+}
+
+// loadDuplicateIndex indexes every stored source by URL.
+func (h *SourceHandler) loadDuplicateIndex(ctx context.Context) (*duplicateIndex, error) {
+	sources, err := h.repo.ListURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newDuplicateIndex(sources), nil
+}
+
+// add indexes source, so sources added later in the same request are checked against it.
+func (idx *duplicateIndex) add(source *models.Source) {
+	idx.names[source.Name] = true
+	key, ok := models.URLKeyOf(source.URL)
+	if !ok {
+		return
+	}
+	idx.byDomain[key.Domain] = append(idx.byDomain[key.Domain], indexedSource{source: *source, key: key})
+}
+
+// hasName reports whether a source with this name is indexed.
+func (idx *duplicateIndex) hasName(name string) bool {
+	return idx.names[name]
+}
+
+// find returns the indexed sources on rawURL's site other than one named
+// exceptName, those with the same path first.
+func (idx *duplicateIndex) find(rawURL, exceptName string) []models.SourceDuplicate {
+	key, ok := models.URLKeyOf(rawURL)
+	if !ok {
+		return nil
+	}
+
+	var duplicates []models.SourceDuplicate
+	for _, indexed := range idx.byDomain[key.Domain] {
+		if indexed.source.Name == exceptName {
+			continue
+		}
+		match := models.DuplicateMatchDomain
+		if indexed.key.Path == key.Path {
+			match = models.DuplicateMatchURL
+		}
+		duplicates = append(duplicates, models.SourceDuplicate{
+			ID:        indexed.source.ID,
+			Name:      indexed.source.Name,
+			URL:       indexed.source.URL,
+			Lifecycle: indexed.source.Lifecycle,
+			Match:     match,
+		})
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Match == models.DuplicateMatchURL && duplicates[j].Match != models.DuplicateMatchURL
+	})
+	return duplicates
+}
+
+// describeDuplicates summarizes duplicates for a row error.
+func describeDuplicates(duplicates []models.SourceDuplicate) string {
+	first := duplicates[0]
+	msg := fmt.Sprintf("URL duplicates source %q (%s, same %s)", first.Name, first.URL, first.Match)
+	if len(duplicates) > 1 {
+		msg += fmt.Sprintf(" and %d more", len(duplicates)-1)
+	}
+	return msg + "; use force=true to import it anyway"
+}
+
+// importDuplicateErrors reports the new sources in an import whose URL
+// duplicates a stored source or an earlier row. Sources whose name already
+// exists are updates and are not checked. rows holds each source's row number.
+func (h *SourceHandler) importDuplicateErrors(
+	ctx context.Context, sources []*models.Source, rows []int,
+) ([]importer.ImportError, error) {
+	idx, err := h.loadDuplicateIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []importer.ImportError
+	for i, source := range sources {
+		if idx.hasName(source.Name) {
+			continue
+		}
+		if duplicates := idx.find(source.URL, source.Name); len(duplicates) > 0 {
+			errs = append(errs, importer.ImportError{Row: rows[i], Error: describeDuplicates(duplicates)})
+			continue
+		}
+		idx.add(source)
+	}
+	return errs, nil
+}
+
+// duplicateGroup is a set of stored sources on the same site.
+type duplicateGroup struct {
+	Domain  string                   `json:"domain"`
+	Sources []models.SourceDuplicate `json:"sources"`
+}
+
+// ListDuplicates reports the stored sources that share a site, grouped by
+// domain. Within a group, match is "url" for sources that share a path with
+// another source in the group and "domain" otherwise.
+// GET /api/v1/sources/duplicates
+func (h *SourceHandler) ListDuplicates(c *gin.Context) {
+	idx, err := h.loadDuplicateIndex(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to load sources for duplicate detection", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list duplicate sources"})
+		return
+	}
+
+	groups := make([]duplicateGroup, 0)
+	for domain, indexed := range idx.byDomain {
+		if len(indexed) < 2 {
+			continue
+		}
+		group := duplicateGroup{Domain: domain, Sources: make([]models.SourceDuplicate, 0, len(indexed))}
+		for i, entry := range indexed {
+			match := models.DuplicateMatchDomain
+			for j, other := range indexed {
+				if i != j && other.key.Path == entry.key.Path {
+					match = models.DuplicateMatchURL
+					break
+				}
+			}
+			group.Sources = append(group.Sources, models.SourceDuplicate{
+				ID:        entry.source.ID,
+				Name:      entry.source.Name,
+				URL:       entry.source.URL,
+				Lifecycle: entry.source.Lifecycle,
+				Match:     match,
+			})
+		}
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Domain < groups[j].Domain })
+
+	c.JSON(http.StatusOK, gin.H{"groups": groups, "count": len(groups)})
+}
@@ -0,0 +1,135 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectSourceURLs expects the duplicate-detection query, returning sources
+// given as id, name, url triples.
+func expectSourceURLs(mock sqlmock.Sqlmock, sources ...[3]string) {
+	rows := sqlmock.NewRows([]string{"id", "name", "url", "lifecycle"})
+	for _, source := range sources {
+		rows.AddRow(source[0], source[1], source[2], "active")
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, url, lifecycle FROM sources")).WillReturnRows(rows)
+}
+
+func TestSourceHandler_Create_RejectsDuplicateURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.POST("/api/v1/sources", handler.Create)
+
+	expectSourceURLs(mock,
+		[3]string{"id-1", "Example Sports", "https://example.com/sports"},
+		[3]string{"id-2", "Example News", "https://www.example.com/news/"},
+		[3]string{"id-3", "Other", "https://other.com"},
+	)
+
+	body := `{"name":"Example News 2","url":"http://EXAMPLE.com/news","rate_limit":"10"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sources", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	var resp struct {
+		Duplicates []struct {
+			ID    string `json:"id"`
+			Match string `json:"match"`
+		} `json:"duplicates"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Duplicates, 2)
+	assert.Equal(t, "id-2", resp.Duplicates[0].ID)
+	assert.Equal(t, "url", resp.Duplicates[0].Match)
+	assert.Equal(t, "domain", resp.Duplicates[1].Match)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_ImportSources_DuplicateRows(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.POST("/api/v1/sources/import", handler.ImportSources)
+
+	expectSourceURLs(mock, [3]string{"id-1", "Example News", "https://example.com/"})
+
+	csv := "name,url\n" +
+		"Example News,https://example.com/\n" + // an update of the existing source
+		"Example Copy,https://www.example.com\n" +
+		"Fresh,https://fresh.org/a\n" +
+		"Fresh Again,https://fresh.org/a/\n"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "/api/v1/sources/import?dry_run=true", "sources.csv", csv))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result handlers.ImportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, 2, result.Valid)
+	rows := make([]int, 0, len(result.Errors))
+	for _, importErr := range result.Errors {
+		rows = append(rows, importErr.Row)
+	}
+	assert.Equal(t, []int{3, 5}, rows)
+	assert.Contains(t, result.Errors[0].Error, `"Example News"`)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_ImportSources_ForceSkipsDuplicateCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.POST("/api/v1/sources/import", handler.ImportSources)
+
+	csv := "name,url\nA,https://example.com\nB,https://example.com\n"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "/api/v1/sources/import?dry_run=true&force=true", "sources.csv", csv))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result handlers.ImportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, 2, result.Valid)
+	assert.Empty(t, result.Errors)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_ListDuplicates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.GET("/api/v1/sources/duplicates", handler.ListDuplicates)
+
+	expectSourceURLs(mock,
+		[3]string{"id-1", "Site", "https://site.com"},
+		[3]string{"id-2", "Site (www)", "https://www.site.com/"},
+		[3]string{"id-3", "Site News", "https://site.com/news"},
+		[3]string{"id-4", "Alone", "https://alone.org"},
+	)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/sources/duplicates", http.NoBody))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"count":1,"groups":[{"domain":"site.com","sources":[
+		{"id":"id-1","name":"Site","url":"https://site.com","lifecycle":"active","match":"url"},
+		{"id":"id-2","name":"Site (www)","url":"https://www.site.com/","lifecycle":"active","match":"url"},
+		{"id":"id-3","name":"Site News","url":"https://site.com/news","lifecycle":"active","match":"domain"}
+	]}]}`, w.Body.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+// exportPageSize is the number of sources read per query while exporting.
+const exportPageSize = 500
+
+// exportContentTypes maps export formats to their response content types.
+var exportContentTypes = map[string]string{
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"csv":  "text/csv; charset=utf-8",
+	"yaml": "application/yaml",
+}
+
+// Export downloads sources in an import format (?format=xlsx, csv or yaml; default xlsx),
+// sorted by name, so they can be reviewed offline, diffed in git, and re-imported through
+// POST /api/v1/sources/import. The search, enabled and feed_active filters of the list
+// endpoint select a subset; pagination is ignored.
+// GET /api/v1/sources/export
+func (h *SourceHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", "xlsx")
+	if format == "yml" {
+		format = "yaml"
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of: " + importer.ExportFormats})
+		return
+	}
+
+	filter := parseListQuery(c)
+	filter.SortBy = "name"
+	filter.SortOrder = "asc"
+	filter.Limit = exportPageSize
+
+	sources := make([]models.Source, 0)
+	for filter.Offset = 0; ; filter.Offset += exportPageSize {
+		page, err := h.repo.ListPaginated(c.Request.Context(), filter)
+		if err != nil {
+			h.logger.Error("Failed to list sources for export",
+				infralogger.Error(err),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export sources"})
+			return
+		}
+		sources = append(sources, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := importer.WriteExport(&buf, format, sources); err != nil {
+		h.logger.Error("Failed to write source export",
+			infralogger.String("format", format),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export sources"})
+		return
+	}
+
+	h.logger.Info("Sources exported",
+		infralogger.String("format", format),
+		infralogger.Int("count", len(sources)),
+	)
+
+	c.Header("Content-Disposition", `attachment; filename="sources.`+format+`"`)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
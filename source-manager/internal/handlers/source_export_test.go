@@ -0,0 +1,63 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceHandler_Export_YAML(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	router.GET("/api/v1/sources/export", handler.Export)
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, url")).
+		WithArgs("%example%", true, 500, 0).
+		WillReturnRows(sqlmock.NewRows(sourceListCols()).AddRow(
+			"src-1", "Example News", "https://example.com", "10s", 2,
+			[]byte(`["09:00"]`), []byte(`{"article":{"title":"h1.headline"}}`), true,
+			nil, nil, "", 0,
+			nil, nil,
+			false, nil, nil, nil,
+			"static", "news", nil,
+			nil, nil,
+			"active", nil,
+			now, now,
+		))
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/sources/export?format=yml&search=example&enabled=true&page=3", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `attachment; filename="sources.yaml"`, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "  - name: Example News\n    url: https://example.com\n    enabled: true\n")
+	assert.Contains(t, w.Body.String(), "title: h1.headline")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_Export_UnsupportedFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, _, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	router.GET("/api/v1/sources/export", handler.Export)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/sources/export?format=json", http.NoBody))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
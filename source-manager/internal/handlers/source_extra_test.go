@@ -45,6 +45,7 @@ func sourceListCols() []string {
 		"allow_source_discovery", "identity_key", "extraction_profile", "template_hint",
 		"render_mode", "type", "indigenous_region",
 		"disabled_at", "disable_reason",
+		"lifecycle", "lifecycle_changed_at",
 		"created_at", "updated_at",
 	}
 }
@@ -61,6 +62,7 @@ func addSourceRowExtra(rows *sqlmock.Rows, id, name string) {
 		false, nil, nil, nil,
 		"static", "news", nil,
 		nil, nil,
+		"active", nil,
 		now, now,
 	)
 }
@@ -156,6 +158,36 @@ func TestSourceHandler_List_WithEnabledFilter(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestSourceHandler_List_WithTypeRegionAndDomainFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandlerExtra(t)
+	defer cleanup()
+
+	router.GET("/api/v1/sources", handler.List)
+
+	rows := sqlmock.NewRows(sourceListCols())
+	addSourceRowExtra(rows, "id-1", "CBC North")
+
+	// The domain filter is keyed like duplicate detection: scheme, www. and path are dropped.
+	domainPattern := `^[a-z][a-z0-9+.-]*://([^/?#@]*@)?([^/?#@]*\.)?cbc\.ca(:[0-9]+)?([/?#]|$)`
+	mock.ExpectQuery("SELECT id, name, url").
+		WithArgs("%cbc%", "%north%", "news", "canada", domainPattern, 100, 0).
+		WillReturnRows(rows)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).
+		WithArgs("%cbc%", "%north%", "news", "canada", domainPattern).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/sources?search=cbc+north&type=news&region=canada&domain=https://www.cbc.ca/news", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestSourceHandler_List_DBError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -221,6 +253,7 @@ func TestSourceHandler_Update_Success(t *testing.T) {
 				false, nil, nil, nil,
 				"static", "news", nil,
 				nil, nil,
+				"active", nil,
 				now, now,
 			),
 		)
@@ -256,9 +289,9 @@ func TestSourceHandler_Update_DisableWithoutReasonReturnsBadRequest(t *testing.T
 			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM sources WHERE id = $1)")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT lifecycle FROM sources WHERE id = $1")).
 		WithArgs("upd-id").
-		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		WillReturnRows(sqlmock.NewRows([]string{"lifecycle"}).AddRow("active"))
 
 	body := `{"name":"Updated Source","url":"https://updated.com","rate_limit":"5","max_depth":3,"enabled":false}`
 	req := httptest.NewRequest(http.MethodPut, "/api/v1/sources/upd-id", strings.NewReader(body))
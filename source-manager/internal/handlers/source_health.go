@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/sourcehealth"
+)
+
+// WithHealth embeds each source's crawl and index health, from cache, in source listings.
+func (h *SourceHandler) WithHealth(health *sourcehealth.Cache) *SourceHandler {
+	h.health = health
+	return h
+}
+
+// sourceWithHealth is a listed source with its end-to-end health; Health is
+// null until the cache has loaded.
+type sourceWithHealth struct {
+	models.Source
+	Health *sourcehealth.Health `json:"health"`
+}
+
+// withHealth pairs each source with its cached health. Without a health cache
+// the sources are returned unchanged.
+func (h *SourceHandler) withHealth(sources []models.Source) any {
+	if h.health == nil {
+		return sources
+	}
+
+	listed := make([]sourceWithHealth, len(sources))
+	for i := range sources {
+		listed[i] = sourceWithHealth{Source: sources[i], Health: h.health.Lookup(&sources[i])}
+	}
+	return listed
+}
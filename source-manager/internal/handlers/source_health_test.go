@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/config"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/sourcehealth"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceHandler_List_WithHealth(t *testing.T) {
+	services := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/jobs/source-health":
+			_, _ = w.Write([]byte(`{"sources":[{"source_id":"id-1","job_id":"job-1","job_status":"failed",` +
+				`"failure_count":3,"last_success_at":"2026-10-15T09:00:00Z"}],"total":1}`))
+		case "/api/v1/aggregations/source-health":
+			_, _ = w.Write([]byte(`{"sources":[{"source":"source_1","raw_count":50,"classified_count":30,` +
+				`"backlog":20}],"total":1}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer services.Close()
+
+	cache := sourcehealth.NewCache(config.SourceHealthConfig{
+		CrawlerURL:      services.URL,
+		IndexManagerURL: services.URL,
+		Timeout:         time.Second,
+	}, "", testhelpers.NewTestLogger())
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.GET("/api/v1/sources", handler.WithHealth(cache).List)
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT id, name, url").
+		WillReturnRows(sqlmock.NewRows(sourceListCols()).AddRow(
+			"id-1", "Source 1", "https://example.com", "1s", 2,
+			[]byte(`[]`), []byte(`{"article":{},"list":{},"page":{}}`), true,
+			nil, nil, "", 0,
+			nil, nil,
+			false, nil, nil, nil,
+			"", "news", nil,
+			nil, nil,
+			"active", nil,
+			now, now,
+		))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM sources WHERE 1=1")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/sources", http.NoBody))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Sources []struct {
+			ID     string               `json:"id"`
+			Name   string               `json:"name"`
+			Health *sourcehealth.Health `json:"health"`
+		} `json:"sources"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Sources, 1)
+	source := resp.Sources[0]
+	assert.Equal(t, "Source 1", source.Name)
+	require.NotNil(t, source.Health)
+	require.NotNil(t, source.Health.Crawl)
+	assert.Equal(t, "failed", source.Health.Crawl.JobStatus)
+	assert.Equal(t, 3, source.Health.Crawl.FailureCount)
+	require.NotNil(t, source.Health.Index)
+	assert.Equal(t, int64(20), source.Health.Index.Backlog)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
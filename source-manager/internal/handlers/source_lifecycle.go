@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/naming"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+)
+
+// errArchivedEnable is returned when an archived source is enabled directly.
+const errArchivedEnable = "archived sources must be moved back to draft and reviewed before they are enabled"
+
+// WithArchiveRetention sets how long archived sources keep their indexes.
+// Zero disables retention reporting.
+func (h *SourceHandler) WithArchiveRetention(retention time.Duration) *SourceHandler {
+	h.archiveRetention = retention
+	return h
+}
+
+// lifecycleRequest is the body of PATCH /api/v1/sources/:id/lifecycle.
+type lifecycleRequest struct {
+	State  string `binding:"required" json:"state"`
+	Reason string `json:"reason"`
+}
+
+// SetLifecycle moves a source to another lifecycle state. Only the transitions
+// in models.LifecycleTransitions are allowed; pausing and archiving need a
+// reason. The crawler's job follows: only active sources are scheduled.
+func (h *SourceHandler) SetLifecycle(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid source ID"})
+		return
+	}
+
+	var req lifecycleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if !models.IsValidLifecycle(req.State) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "state must be one of draft, active, paused, archived"})
+		return
+	}
+	if models.LifecycleNeedsReason(req.State) && req.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required when pausing or archiving a source"})
+		return
+	}
+
+	before, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+	if before.Lifecycle == req.State {
+		c.JSON(http.StatusOK, before)
+		return
+	}
+	if !models.CanTransitionLifecycle(before.Lifecycle, req.State) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "cannot move a " + before.Lifecycle + " source to " + req.State,
+			"allowed": models.LifecycleTransitions(before.Lifecycle),
+		})
+		return
+	}
+
+	if err = h.repo.SetLifecycle(c.Request.Context(), id, before.Lifecycle, req.State, req.Reason); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSourceNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		case errors.Is(err, repository.ErrLifecycleConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "source lifecycle changed concurrently; reload and retry"})
+		default:
+			h.logger.Error("Failed to set source lifecycle",
+				infralogger.String("source_id", id),
+				infralogger.String("state", req.State),
+				infralogger.Error(err),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set source lifecycle"})
+		}
+		return
+	}
+
+	h.logger.Info("Source lifecycle changed",
+		infralogger.String("source_id", id),
+		infralogger.String("from", before.Lifecycle),
+		infralogger.String("to", req.State),
+		infralogger.String("reason", req.Reason),
+	)
+
+	updated, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		h.syncCrawler(id)
+		c.JSON(http.StatusOK, gin.H{"id": id, "lifecycle": req.State})
+		return
+	}
+
+	version := h.recordVersion(c, before, updated, models.VersionActionUpdate)
+	h.publishSourceUpdated(updated, changedFields(version))
+	h.syncCrawler(id)
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// retentionEntry is an archived source and when its indexes may be deleted.
+type retentionEntry struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	ArchivedAt      *time.Time `json:"archived_at"`
+	RetainUntil     *time.Time `json:"retain_until"`
+	Expired         bool       `json:"expired"`
+	RawIndex        string     `json:"raw_index"`
+	ClassifiedIndex string     `json:"classified_index"`
+}
+
+// ListRetention lists archived sources with the date their indexes stop being
+// retained. With ?expired=true only sources past retention are listed. Nothing
+// is deleted here; index cleanup acts on this list.
+func (h *SourceHandler) ListRetention(c *gin.Context) {
+	sources, err := h.repo.ListByLifecycle(c.Request.Context(), models.LifecycleArchived)
+	if err != nil {
+		h.logger.Error("Failed to list archived sources", infralogger.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list archived sources"})
+		return
+	}
+
+	onlyExpired := c.Query("expired") == "true"
+	now := time.Now()
+	entries := make([]retentionEntry, 0, len(sources))
+	for i := range sources {
+		entry := h.retentionFor(&sources[i], now)
+		if onlyExpired && !entry.Expired {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sources":   entries,
+		"count":     len(entries),
+		"retention": h.archiveRetention.String(),
+	})
+}
+
+// retentionFor computes an archived source's retention. A source archived
+// before lifecycle tracking, or with retention disabled, never expires.
+func (h *SourceHandler) retentionFor(source *models.Source, now time.Time) retentionEntry {
+	entry := retentionEntry{
+		ID:              source.ID,
+		Name:            source.Name,
+		ArchivedAt:      source.LifecycleChangedAt,
+		RawIndex:        naming.RawContentIndex(source.Name),
+		ClassifiedIndex: naming.ClassifiedContentIndex(source.Name),
+	}
+	if source.LifecycleChangedAt != nil && h.archiveRetention > 0 {
+		until := source.LifecycleChangedAt.Add(h.archiveRetention)
+		entry.RetainUntil = &until
+		entry.Expired = now.After(until)
+	}
+	return entry
+}
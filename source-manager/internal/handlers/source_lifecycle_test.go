@@ -0,0 +1,154 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lifecycleSourceID = "33333333-3333-3333-3333-333333333333"
+
+// lifecycleSourceRows returns one source row in the given lifecycle state.
+func lifecycleSourceRows(state string, changedAt any) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows(sourceListCols()).AddRow(
+		lifecycleSourceID, "Example News", "https://example.com", "1s", 2,
+		[]byte(`["09:00"]`), []byte(`{"article":{"title":"h1"}}`), state == "active",
+		nil, nil, "", 0,
+		nil, nil,
+		false, nil, nil, nil,
+		"static", "news", nil,
+		nil, nil,
+		state, changedAt,
+		now, now,
+	)
+}
+
+func expectLifecycleSource(mock sqlmock.Sqlmock, state string, changedAt any) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, url")).
+		WithArgs(lifecycleSourceID).
+		WillReturnRows(lifecycleSourceRows(state, changedAt))
+}
+
+func patchLifecycle(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/sources/"+lifecycleSourceID+"/lifecycle",
+		strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSourceHandler_SetLifecycle_Archive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.PATCH("/api/v1/sources/:id/lifecycle", handler.SetLifecycle)
+
+	expectLifecycleSource(mock, "active", nil)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).
+		WithArgs(lifecycleSourceID, "active", "archived", "site closed").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectLifecycleSource(mock, "archived", time.Now())
+
+	w := patchLifecycle(router, `{"state":"archived","reason":" site closed "}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"lifecycle":"archived"`)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_SetLifecycle_RejectsInvalidTransition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.PATCH("/api/v1/sources/:id/lifecycle", handler.SetLifecycle)
+
+	expectLifecycleSource(mock, "archived", time.Now())
+
+	w := patchLifecycle(router, `{"state":"active"}`)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	var resp struct {
+		Allowed []string `json:"allowed"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"draft"}, resp.Allowed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_SetLifecycle_PauseNeedsReason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.PATCH("/api/v1/sources/:id/lifecycle", handler.SetLifecycle)
+
+	w := patchLifecycle(router, `{"state":"paused"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_EnableSource_ArchivedConflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	router.PATCH("/api/v1/sources/:id/enable", handler.EnableSource)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).
+		WithArgs(lifecycleSourceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT lifecycle FROM sources WHERE id = $1")).
+		WithArgs(lifecycleSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"lifecycle"}).AddRow("archived"))
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/sources/"+lifecycleSourceID+"/enable", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_ListRetention(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+	handler.WithArchiveRetention(30 * 24 * time.Hour)
+	router.GET("/api/v1/sources/retention", handler.ListRetention)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE lifecycle = $1")).
+		WithArgs("archived").
+		WillReturnRows(lifecycleSourceRows("archived", time.Now().Add(-60*24*time.Hour)))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sources/retention?expired=true", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Sources []struct {
+			Expired  bool   `json:"expired"`
+			RawIndex string `json:"raw_index"`
+		} `json:"sources"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Sources, 1)
+	assert.True(t, resp.Sources[0].Expired)
+	assert.Equal(t, "example_news_raw_content", resp.Sources[0].RawIndex)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
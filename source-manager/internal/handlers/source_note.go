@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+)
+
+const (
+	// defaultNoteAuthor is used when a note is created without an author.
+	defaultNoteAuthor = "operator"
+	// maxNoteBodyLength bounds the free-text body of a note.
+	maxNoteBodyLength = 4000
+	// maxDedupeKeyLength matches source_notes.dedupe_key.
+	maxDedupeKeyLength = 255
+)
+
+// SourceNoteHandler provides HTTP handlers for notes attached to sources.
+type SourceNoteHandler struct {
+	repo   *repository.SourceNoteRepository
+	logger infralogger.Logger
+}
+
+// NewSourceNoteHandler creates a new SourceNoteHandler.
+func NewSourceNoteHandler(repo *repository.SourceNoteRepository, log infralogger.Logger) *SourceNoteHandler {
+	return &SourceNoteHandler{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+// createNoteRequest is the request body for creating a source note.
+type createNoteRequest struct {
+	Kind      string          `json:"kind"`
+	Author    string          `json:"author"`
+	Body      string          `json:"body"`
+	Details   json.RawMessage `json:"details"`
+	DedupeKey string          `json:"dedupe_key"`
+}
+
+// validate normalizes the request and returns a user-facing error message, or "" when valid.
+func (r *createNoteRequest) validate() string {
+	r.Body = strings.TrimSpace(r.Body)
+	if r.Kind == "" {
+		r.Kind = models.NoteKindNote
+	}
+	if r.Author == "" {
+		r.Author = defaultNoteAuthor
+	}
+
+	switch {
+	case r.Body == "":
+		return "body is required"
+	case len(r.Body) > maxNoteBodyLength:
+		return "body is too long"
+	case !models.IsValidNoteKind(r.Kind):
+		return "invalid note kind: " + r.Kind
+	case len(r.DedupeKey) > maxDedupeKeyLength:
+		return "dedupe_key is too long"
+	case len(r.Details) > 0 && !json.Valid(r.Details):
+		return "details must be valid JSON"
+	}
+	return ""
+}
+
+// Create attaches a note to a source. A note whose dedupe_key already exists on
+// the source is ignored and reported with created=false.
+// POST /api/v1/sources/:id/notes
+func (h *SourceNoteHandler) Create(c *gin.Context) {
+	sourceID := c.Param("id")
+
+	var req createNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	note := &models.SourceNote{
+		SourceID: sourceID,
+		Kind:     req.Kind,
+		Author:   req.Author,
+		Body:     req.Body,
+		Details:  req.Details,
+	}
+	if req.DedupeKey != "" {
+		note.DedupeKey = &req.DedupeKey
+	}
+
+	created, err := h.repo.Create(c.Request.Context(), note)
+	if err != nil {
+		if errors.Is(err, repository.ErrSourceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+			return
+		}
+		h.logger.Error("Failed to create source note",
+			infralogger.String("source_id", sourceID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create note"})
+		return
+	}
+
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"created": false})
+		return
+	}
+
+	h.logger.Info("Source note created",
+		infralogger.String("source_id", sourceID),
+		infralogger.String("kind", note.Kind),
+		infralogger.String("author", note.Author),
+	)
+	c.JSON(http.StatusCreated, gin.H{"created": true, "note": note})
+}
+
+// List returns a source's open notes, or all notes with ?include_resolved=true.
+// GET /api/v1/sources/:id/notes
+func (h *SourceNoteHandler) List(c *gin.Context) {
+	sourceID := c.Param("id")
+	includeResolved := c.Query("include_resolved") == "true"
+
+	notes, err := h.repo.ListBySource(c.Request.Context(), sourceID, includeResolved)
+	if err != nil {
+		h.logger.Error("Failed to list source notes",
+			infralogger.String("source_id", sourceID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list notes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notes": notes,
+		"count": len(notes),
+	})
+}
+
+// Resolve marks a note as resolved.
+// PATCH /api/v1/sources/:id/notes/:note_id/resolve
+func (h *SourceNoteHandler) Resolve(c *gin.Context) {
+	sourceID := c.Param("id")
+	noteID := c.Param("note_id")
+
+	if err := h.repo.Resolve(c.Request.Context(), sourceID, noteID); err != nil {
+		if errors.Is(err, repository.ErrNoteNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Note not found or already resolved"})
+			return
+		}
+		h.logger.Error("Failed to resolve source note",
+			infralogger.String("source_id", sourceID),
+			infralogger.String("note_id", noteID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve note"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"resolved": true})
+}
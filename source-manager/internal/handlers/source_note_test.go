@@ -0,0 +1,39 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+)
+
+func TestSourceNoteHandler_Create_RejectsInvalidBodies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler := handlers.NewSourceNoteHandler(nil, testhelpers.NewTestLogger())
+	router.POST("/api/v1/sources/:id/notes", handler.Create)
+
+	bodies := map[string]string{
+		"missing body":    `{"kind":"note"}`,
+		"blank body":      `{"body":"   "}`,
+		"unknown kind":    `{"kind":"gossip","body":"hello"}`,
+		"invalid details": `{"body":"hello","details":"not-json`,
+	}
+
+	for name, body := range bodies {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/sources/abc/notes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d: %s", name, w.Code, w.Body.String())
+		}
+	}
+}
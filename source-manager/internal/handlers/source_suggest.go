@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testcrawl"
+)
+
+// selectorSuggestionResponse is the suggestion with its selectors in source form,
+// ready to edit and send as a source's selectors.
+type selectorSuggestionResponse struct {
+	*testcrawl.Suggestions
+	Selectors models.SelectorConfig `json:"selectors"`
+}
+
+// suggestedSelectors maps suggestions onto a source's article selectors.
+func suggestedSelectors(fields *testcrawl.SuggestedFields) models.SelectorConfig {
+	var selectors models.SelectorConfig
+	for _, field := range []struct {
+		suggestion *testcrawl.SelectorSuggestion
+		target     *string
+	}{
+		{fields.Title, &selectors.Article.Title},
+		{fields.Body, &selectors.Article.Body},
+		{fields.Container, &selectors.Article.Container},
+		{fields.Author, &selectors.Article.Author},
+		{fields.PublishedTime, &selectors.Article.PublishedTime},
+	} {
+		if field.suggestion != nil {
+			*field.target = field.suggestion.Selector
+		}
+	}
+	return selectors
+}
+
+// SuggestSelectors fetches a sample article URL and proposes title, body,
+// container, author and date selectors from its JSON-LD, OpenGraph tags and DOM,
+// with a sample and confidence for each. Nothing is saved.
+// POST /api/v1/sources/suggest-selectors
+func (h *SourceHandler) SuggestSelectors(c *gin.Context) {
+	var request struct {
+		URL string `binding:"required" json:"url"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "URL is required", "details": err.Error()})
+		return
+	}
+
+	suggestions, err := h.crawlExtractor.Suggest(c.Request.Context(), request.URL)
+	if err != nil {
+		h.logger.Warn("Selector suggestion failed",
+			infralogger.String("url", request.URL),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Suggestion failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, selectorSuggestionResponse{
+		Suggestions: suggestions,
+		Selectors:   suggestedSelectors(&suggestions.Fields),
+	})
+}
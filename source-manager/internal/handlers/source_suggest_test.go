@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testcrawl"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// suggestArticle is an article page whose JSON-LD agrees with its DOM, except
+// that the headline carries a site suffix.
+const suggestArticle = `<html><head>
+<meta property="og:type" content="article">
+<meta property="og:title" content="Council approves budget | Example News">
+<meta property="article:published_time" content="2026-01-02T09:00:00Z">
+<script type="application/ld+json">{"@context":"https://schema.org","@graph":[
+  {"@type":"WebSite","name":"Example News"},
+  {"@type":"NewsArticle","headline":"Council approves budget | Example News",
+   "datePublished":"2026-01-02T09:00:00Z","author":[{"@type":"Person","name":"Ana Lee"}]}]}</script>
+</head><body>
+<nav class="menu"><p>Home News Sports Weather Contact Subscribe Login</p></nav>
+<article class="story">
+  <h1 class="story-title">Council approves budget</h1>
+  <span class="byline">By Ana Lee</span>
+  <div class="story-text">
+    <p>Council voted on Tuesday to approve the new municipal budget after a long debate.</p>
+    <p>The budget funds road repairs, a new library branch and more transit service.</p>
+  </div>
+</article>
+<footer><p>Copyright Example News</p></footer>
+</body></html>`
+
+func newSuggestRouter(t *testing.T, pages map[string]string) *gin.Engine {
+	t.Helper()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if r.URL.Host != "news.example.com" || !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(page))
+	}))
+	t.Cleanup(proxy.Close)
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	log := testhelpers.NewTestLogger()
+	handler := handlers.NewSourceHandler(repository.NewSourceRepository(nil, log), log, nil).
+		WithCrawlExtractor(testcrawl.NewExtractor(log, testcrawl.WithProxy(proxyURL, false)))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/sources/suggest-selectors", handler.SuggestSelectors)
+	return router
+}
+
+func postSuggest(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sources/suggest-selectors", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSourceHandler_SuggestSelectors(t *testing.T) {
+	router := newSuggestRouter(t, map[string]string{"/news/budget": suggestArticle})
+
+	w := postSuggest(router, `{"url":"http://news.example.com/news/budget"}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		testcrawl.Suggestions
+		Selectors struct {
+			Article map[string]string `json:"article"`
+		} `json:"selectors"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, "NewsArticle", resp.StructuredData.JSONLDType)
+	assert.Equal(t, "Ana Lee", resp.StructuredData.Author)
+	assert.Equal(t, map[string]string{
+		"title":          "article h1",
+		"body":           "div.story-text",
+		"container":      "article.story",
+		"author":         ".byline",
+		"published_time": "meta[property='article:published_time']",
+	}, resp.Selectors.Article)
+
+	require.NotNil(t, resp.Fields.Title)
+	assert.Equal(t, "Council approves budget", resp.Fields.Title.Sample)
+	assert.Equal(t, testcrawl.ConfidenceHigh, resp.Fields.Title.Confidence)
+	require.NotNil(t, resp.Fields.PublishedTime)
+	assert.Equal(t, testcrawl.ConfidenceHigh, resp.Fields.PublishedTime.Confidence)
+	require.NotNil(t, resp.Fields.Author)
+	assert.Equal(t, testcrawl.ConfidenceHigh, resp.Fields.Author.Confidence)
+	require.NotNil(t, resp.Fields.Body)
+	assert.Equal(t, testcrawl.SuggestionSourceDensity, resp.Fields.Body.Source)
+	assert.Contains(t, resp.Fields.Body.Sample, "new library branch")
+	assert.Empty(t, resp.Warnings)
+}
+
+func TestSourceHandler_SuggestSelectors_Errors(t *testing.T) {
+	router := newSuggestRouter(t, map[string]string{})
+
+	assert.Equal(t, http.StatusBadRequest, postSuggest(router, `{}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity,
+		postSuggest(router, `{"url":"http://news.example.com/missing"}`).Code)
+}
@@ -1,7 +1,9 @@
 package handlers_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -12,6 +14,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
@@ -79,6 +82,7 @@ func TestSourceHandler_Create_Success(t *testing.T) {
 
 	router.POST("/api/v1/sources", handler.Create)
 
+	expectSourceURLs(mock)
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sources")).
 		WithArgs(
 			sqlmock.AnyArg(), // id
@@ -99,6 +103,7 @@ func TestSourceHandler_Create_Success(t *testing.T) {
 			sqlmock.AnyArg(), // indigenous_region
 			sqlmock.AnyArg(), // created_at
 			sqlmock.AnyArg(), // updated_at
+			"draft",          // lifecycle
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -161,6 +166,7 @@ func TestSourceHandler_GetByID_Success(t *testing.T) {
 				"allow_source_discovery", "identity_key", "extraction_profile", "template_hint",
 				"render_mode", "type", "indigenous_region",
 				"disabled_at", "disable_reason",
+				"lifecycle", "lifecycle_changed_at",
 				"created_at", "updated_at",
 			}).AddRow(
 				"src-123", "My Source", "https://example.com", "5s", 3,
@@ -170,6 +176,7 @@ func TestSourceHandler_GetByID_Success(t *testing.T) {
 				false, nil, nil, nil,
 				"static", "news", nil,
 				nil, nil,
+				"active", nil,
 				now, now,
 			),
 		)
@@ -470,6 +477,84 @@ func TestSourceHandler_ImportExcel_NoFile(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+// newImportRequest builds a multipart upload of content as filename.
+func newImportRequest(t *testing.T, target, filename, content string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestSourceHandler_ImportSources_DryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	router.POST("/api/v1/sources/import", handler.ImportSources)
+
+	expectSourceURLs(mock)
+
+	csv := "name,url,max_depth\nGood,https://example.com,2\nBad,example.com,2\nDeep,https://test.com,-1\n"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "/api/v1/sources/import?dry_run=true", "sources.csv", csv))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result handlers.ImportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.Valid)
+	assert.Zero(t, result.Created)
+	assert.Equal(t, []importer.ImportError{
+		{Row: 3, Error: "url must start with http:// or https://"},
+		{Row: 4, Error: "max_depth must be non-negative"},
+	}, result.Errors)
+	// Nothing was written
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_ImportSources_RowErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	router.POST("/api/v1/sources/import", handler.ImportSources)
+	expectSourceURLs(mock)
+
+	yaml := "- name: Good\n  url: https://example.com\n- name: Bad\n  url: example.com\n"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "/api/v1/sources/import", "sources.yaml", yaml))
+
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	var result handlers.ImportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, []importer.ImportError{{Row: 3, Error: "url must start with http:// or https://"}}, result.Errors)
+}
+
+func TestSourceHandler_ImportSources_UnsupportedFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, _, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	router.POST("/api/v1/sources/import", handler.ImportSources)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "/api/v1/sources/import", "sources.json", "[]"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ".csv")
+}
+
 func TestSourceHandler_ImportIndigenous_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -538,6 +623,7 @@ func TestSourceHandler_List_Success(t *testing.T) {
 				"allow_source_discovery", "identity_key", "extraction_profile", "template_hint",
 				"render_mode", "type", "indigenous_region",
 				"disabled_at", "disable_reason",
+				"lifecycle", "lifecycle_changed_at",
 				"created_at", "updated_at",
 			}).AddRow(
 				"id-1", "Source 1", "https://example.com", "1s", 2,
@@ -547,6 +633,7 @@ func TestSourceHandler_List_Success(t *testing.T) {
 				false, nil, nil, nil,
 				"", "news", nil,
 				nil, nil,
+				"active", nil,
 				now, now,
 			),
 		)
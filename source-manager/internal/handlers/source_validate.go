@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testcrawl"
+)
+
+// WithCrawlExtractor replaces the test-crawl extractor, e.g. with one that fetches
+// through nc-http-proxy.
+func (h *SourceHandler) WithCrawlExtractor(extractor *testcrawl.Extractor) *SourceHandler {
+	h.crawlExtractor = extractor
+	return h
+}
+
+// validationSelectors maps a source's selectors onto the ones validation applies.
+func validationSelectors(selectors *models.SelectorConfig) testcrawl.Selectors {
+	return testcrawl.Selectors{
+		ListContainer:   selectors.List.Container,
+		ArticleCards:    selectors.List.ArticleCards,
+		ArticleLink:     selectors.Article.Link,
+		Title:           selectors.Article.Title,
+		Body:            selectors.Article.Body,
+		Container:       selectors.Article.Container,
+		PublishedTime:   selectors.Article.PublishedTime,
+		ExcludeFromList: selectors.List.ExcludeFromList,
+	}
+}
+
+// validateSource runs validation and writes the result, or 422 when the page cannot be fetched.
+func (h *SourceHandler) validateSource(c *gin.Context, sourceURL string, selectors *models.SelectorConfig) {
+	result, err := h.crawlExtractor.Validate(c.Request.Context(), sourceURL, validationSelectors(selectors))
+	if err != nil {
+		h.logger.Warn("Source validation failed",
+			infralogger.String("url", sourceURL),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	h.logger.Info("Source validated",
+		infralogger.String("url", sourceURL),
+		infralogger.Int("article_count", result.ArticleCount),
+		infralogger.Int("warning_count", len(result.Warnings)),
+	)
+	c.JSON(http.StatusOK, result)
+}
+
+// TestSource fetches a saved source's URL, applies its stored selectors to up to three
+// article pages, and returns title/body/date samples with warnings for empty fields.
+// Nothing is saved.
+// POST /api/v1/sources/:id/test
+func (h *SourceHandler) TestSource(c *gin.Context) {
+	id := c.Param("id")
+
+	source, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+
+	h.validateSource(c, source.URL, &source.Selectors)
+}
+
+// TestDraft validates a source that has not been saved: the request's selectors,
+// merged with the defaults a saved source would get, applied as TestSource does.
+// POST /api/v1/sources/test
+func (h *SourceHandler) TestDraft(c *gin.Context) {
+	var request testCrawlRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	var selectors models.SelectorConfig
+	if request.Selectors != nil {
+		selectors = *request.Selectors
+	}
+	selectors = selectors.MergeWithDefaults()
+
+	h.validateSource(c, request.URL, &selectors)
+}
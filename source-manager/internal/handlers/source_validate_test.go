@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testcrawl"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newValidationProxy serves news.example.com as a forward proxy would: a listing
+// page with two on-site article cards, one of whose articles has no date.
+func newValidationProxy(t *testing.T) *url.URL {
+	t.Helper()
+	pages := map[string]string{
+		"/news": `<html><body><a href="/about">About</a><div class="feed">
+			<article><a href="/news/one">One</a></article>
+			<article><a href="https://other.example.com/x">Off-site</a></article>
+			<article><a href="/news/two#comments">Two</a></article>
+		</div></body></html>`,
+		"/news/one": `<html><body><h1>First story</h1><time datetime="2026-01-02">Jan 2</time>
+			<div class="story">Council approves the new budget.</div></body></html>`,
+		"/news/two": `<html><body><h1>Second story</h1>
+			<div class="story">Road work begins next week.</div></body></html>`,
+	}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if r.URL.Host != "news.example.com" || !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(page))
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	return proxyURL
+}
+
+func newValidationRouter(t *testing.T, proxyURL *url.URL) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	log := testhelpers.NewTestLogger()
+	handler := handlers.NewSourceHandler(repository.NewSourceRepository(db, log), log, nil).
+		WithCrawlExtractor(testcrawl.NewExtractor(log, testcrawl.WithProxy(proxyURL, false)))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/sources/test", handler.TestDraft)
+	router.POST("/api/v1/sources/:id/test", handler.TestSource)
+	return router, mock
+}
+
+func TestSourceHandler_TestDraft(t *testing.T) {
+	router, _ := newValidationRouter(t, newValidationProxy(t))
+
+	body := `{"url":"http://news.example.com/news","selectors":{` +
+		`"list":{"container":".feed"},` +
+		`"article":{"title":"h1","body":".story","published_time":"time"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sources/test", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result testcrawl.ValidationResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+
+	assert.True(t, result.Success)
+	require.Len(t, result.Articles, 2)
+	assert.Equal(t, "http://news.example.com/news/one", result.Articles[0].URL)
+	assert.Equal(t, "First story", result.Articles[0].Title)
+	assert.Equal(t, "2026-01-02", result.Articles[0].PublishedDate)
+	assert.Empty(t, result.Articles[0].Warnings)
+	assert.Equal(t, []string{`published time selector "time" matched nothing`}, result.Articles[1].Warnings)
+	assert.InDelta(t, 0.5, result.SuccessRate, 0.001)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestSourceHandler_TestSource_NotFound(t *testing.T) {
+	router, mock := newValidationRouter(t, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, url")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(sourceListCols()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/sources/missing/test", http.NoBody))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+)
+
+// defaultVersionAuthor is recorded when a change is made without a token
+// (JWT auth disabled).
+const defaultVersionAuthor = "operator"
+
+// WithVersions enables version history: creates, updates and rollbacks record
+// a version of the source.
+func (h *SourceHandler) WithVersions(versions *repository.SourceVersionRepository) *SourceHandler {
+	h.versions = versions
+	return h
+}
+
+// versionAuthor returns the subject of the request's token.
+func versionAuthor(c *gin.Context) string {
+	if claims, ok := jwt.GetClaims(c); ok && claims.Sub != "" {
+		return claims.Sub
+	}
+	return defaultVersionAuthor
+}
+
+// currentForVersion returns the source as it is before a change, or nil when
+// version history is off or the source cannot be read.
+func (h *SourceHandler) currentForVersion(c *gin.Context, id string) *models.Source {
+	if h.versions == nil {
+		return nil
+	}
+	source, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		return nil
+	}
+	return source
+}
+
+// recordVersion stores after as the source's next version. Failures are
+// logged, not returned: the change itself has already been saved.
+func (h *SourceHandler) recordVersion(c *gin.Context, before, after *models.Source, action string) *models.SourceVersion {
+	if h.versions == nil {
+		return nil
+	}
+	version, err := h.versions.Record(c.Request.Context(), before, after, action, versionAuthor(c))
+	if err != nil {
+		h.logger.Error("Failed to record source version",
+			infralogger.String("source_id", after.ID),
+			infralogger.String("action", action),
+			infralogger.Error(err),
+		)
+		return nil
+	}
+	return version
+}
+
+// changedFields returns the top-level fields a version changed, for events.
+func changedFields(version *models.SourceVersion) []string {
+	fields := make([]string, 0)
+	if version == nil {
+		return fields
+	}
+	seen := make(map[string]bool)
+	for _, change := range version.Changes {
+		field, _, _ := strings.Cut(change.Field, ".")
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ListVersions returns a source's version history, newest first, without snapshots.
+// GET /api/v1/sources/:id/versions
+func (h *SourceHandler) ListVersions(c *gin.Context) {
+	sourceID := c.Param("id")
+
+	versions, err := h.versions.ListBySource(c.Request.Context(), sourceID)
+	if err != nil {
+		h.logger.Error("Failed to list source versions",
+			infralogger.String("source_id", sourceID),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list versions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"versions": versions,
+		"count":    len(versions),
+	})
+}
+
+// parseVersionNumber reads the :version path parameter.
+func parseVersionNumber(c *gin.Context) (int, bool) {
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version must be a positive integer"})
+		return 0, false
+	}
+	return number, true
+}
+
+// GetVersion returns one version of a source with its full snapshot.
+// GET /api/v1/sources/:id/versions/:version
+func (h *SourceHandler) GetVersion(c *gin.Context) {
+	sourceID := c.Param("id")
+	number, ok := parseVersionNumber(c)
+	if !ok {
+		return
+	}
+
+	version, err := h.versions.Get(c.Request.Context(), sourceID, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Version not found"})
+			return
+		}
+		h.logger.Error("Failed to get source version",
+			infralogger.String("source_id", sourceID),
+			infralogger.Int("version", number),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get version"})
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// RollbackVersion restores a source's configuration to an earlier version and
+// records the rollback as a new version. Whether the source is enabled, and
+// why it was disabled, are kept: those belong to the enable/disable endpoints.
+// POST /api/v1/sources/:id/versions/:version/rollback
+func (h *SourceHandler) RollbackVersion(c *gin.Context) {
+	sourceID := c.Param("id")
+	number, ok := parseVersionNumber(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	target, err := h.versions.Get(ctx, sourceID, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Version not found"})
+			return
+		}
+		h.logger.Error("Failed to get source version",
+			infralogger.String("source_id", sourceID),
+			infralogger.Int("version", number),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get version"})
+		return
+	}
+
+	current, err := h.repo.GetByID(ctx, sourceID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+
+	var restored models.Source
+	if err = json.Unmarshal(target.Snapshot, &restored); err != nil {
+		h.logger.Error("Failed to decode source version",
+			infralogger.String("source_id", sourceID),
+			infralogger.Int("version", number),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to roll back source"})
+		return
+	}
+	restored.ID = sourceID
+	restored.Enabled = current.Enabled
+	restored.DisableReason = current.DisableReason
+
+	if err = h.repo.Update(ctx, &restored); err != nil {
+		if errors.Is(err, repository.ErrSourceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+			return
+		}
+		h.logger.Error("Failed to roll back source",
+			infralogger.String("source_id", sourceID),
+			infralogger.Int("version", number),
+			infralogger.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to roll back source"})
+		return
+	}
+
+	updated, err := h.repo.GetByID(ctx, sourceID)
+	if err != nil {
+		updated = &restored
+	}
+	version := h.recordVersion(c, current, updated, models.VersionActionRollback)
+
+	h.logger.Info("Source rolled back",
+		infralogger.String("source_id", sourceID),
+		infralogger.Int("to_version", number),
+		infralogger.String("author", versionAuthor(c)),
+	)
+	h.publishSourceUpdated(updated, changedFields(version))
+	h.syncCrawlerOnChange(sourceID, changedFields(version))
+
+	c.JSON(http.StatusOK, gin.H{"source": updated, "version": version})
+}
@@ -0,0 +1,201 @@
+package handlers_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/infrastructure/jwt"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/handlers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/repository"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const versionedSourceID = "ver-id"
+
+// newVersionedRouter serves the source update and version routes with version
+// history on, as ana@example.com.
+func newVersionedRouter(t *testing.T) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	log := testhelpers.NewTestLogger()
+	handler := handlers.NewSourceHandler(repository.NewSourceRepository(db, log), log, nil).
+		WithVersions(repository.NewSourceVersionRepository(db, log))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("claims", &jwt.Claims{Sub: "ana@example.com"})
+	})
+	router.PUT("/api/v1/sources/:id", handler.Update)
+	router.GET("/api/v1/sources/:id/versions/:version", handler.GetVersion)
+	router.POST("/api/v1/sources/:id/versions/:version/rollback", handler.RollbackVersion)
+	return router, mock
+}
+
+// versionedSource is the source GetByID returns for a row from expectGetSource.
+func versionedSource(title string) *models.Source {
+	source := &models.Source{
+		ID:         versionedSourceID,
+		Name:       "Example News",
+		URL:        "https://example.com",
+		RateLimit:  "1s",
+		MaxDepth:   2,
+		Time:       models.StringArray{"09:00"},
+		Enabled:    true,
+		Lifecycle:  models.LifecycleActive,
+		RenderMode: "static",
+		Type:       "news",
+	}
+	source.Selectors.Article.Title = title
+	source.Selectors = source.Selectors.MergeWithDefaults()
+	return source
+}
+
+func expectGetSource(mock sqlmock.Sqlmock, title string) {
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, url")).
+		WithArgs(versionedSourceID).
+		WillReturnRows(sqlmock.NewRows(sourceListCols()).AddRow(
+			versionedSourceID, "Example News", "https://example.com", "1s", 2,
+			[]byte(`["09:00"]`),
+			[]byte(`{"article":{"title":"`+title+`"},"list":{},"page":{}}`),
+			true,
+			nil, nil, "", 0,
+			nil, nil,
+			false, nil, nil, nil,
+			"static", "news", nil,
+			nil, nil,
+			"active", nil,
+			now, now,
+		))
+}
+
+// changeOf matches a JSON changes argument holding one change to field.
+type changeOf string
+
+func (m changeOf) Match(v driver.Value) bool {
+	data, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+	var changes []models.FieldChange
+	if json.Unmarshal(data, &changes) != nil {
+		return false
+	}
+	return len(changes) == 1 && changes[0].Field == string(m)
+}
+
+func TestSourceHandler_Update_RecordsVersion(t *testing.T) {
+	router, mock := newVersionedRouter(t)
+
+	expectGetSource(mock, "h1")
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectGetSource(mock, "h2")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM sources WHERE id = $1 FOR UPDATE")).
+		WithArgs(versionedSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(versionedSourceID))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, snapshot FROM source_versions")).
+		WithArgs(versionedSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "snapshot"}))
+	// No history yet: the pre-edit state becomes the baseline
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO source_versions")).
+		WithArgs(sqlmock.AnyArg(), versionedSourceID, 1, models.VersionActionBaseline, "system",
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO source_versions")).
+		WithArgs(sqlmock.AnyArg(), versionedSourceID, 2, models.VersionActionUpdate, "ana@example.com",
+			changeOf("selectors.article.title"), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"name":"Example News","url":"https://example.com","rate_limit":"1s","max_depth":2,` +
+		`"enabled":true,"selectors":{"article":{"title":"h2"}}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/sources/"+versionedSourceID, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_RollbackVersion(t *testing.T) {
+	router, mock := newVersionedRouter(t)
+
+	good, err := json.Marshal(versionedSource("h1"))
+	require.NoError(t, err)
+	bad, err := json.Marshal(versionedSource("h2"))
+	require.NoError(t, err)
+	versionCols := []string{"id", "source_id", "version", "action", "author", "changes", "snapshot", "created_at"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_versions")).
+		WithArgs(versionedSourceID, 1).
+		WillReturnRows(sqlmock.NewRows(versionCols).
+			AddRow("v1", versionedSourceID, 1, models.VersionActionCreate, "ana@example.com", []byte(`[]`), good, time.Now()))
+	expectGetSource(mock, "h2")
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectGetSource(mock, "h1")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM sources WHERE id = $1 FOR UPDATE")).
+		WithArgs(versionedSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(versionedSourceID))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, snapshot FROM source_versions")).
+		WithArgs(versionedSourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "snapshot"}).AddRow(2, bad))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO source_versions")).
+		WithArgs(sqlmock.AnyArg(), versionedSourceID, 3, models.VersionActionRollback, "ana@example.com",
+			changeOf("selectors.article.title"), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sources/"+versionedSourceID+"/versions/1/rollback", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Source  models.Source        `json:"source"`
+		Version models.SourceVersion `json:"version"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "h1", resp.Source.Selectors.Article.Title)
+	assert.Equal(t, 3, resp.Version.Version)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_GetVersion_Errors(t *testing.T) {
+	router, mock := newVersionedRouter(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_versions")).
+		WithArgs(versionedSourceID, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	paths := map[string]int{
+		"/api/v1/sources/" + versionedSourceID + "/versions/0":    http.StatusBadRequest,
+		"/api/v1/sources/" + versionedSourceID + "/versions/next": http.StatusBadRequest,
+		"/api/v1/sources/" + versionedSourceID + "/versions/7":    http.StatusNotFound,
+	}
+	for path, want := range paths {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		assert.Equal(t, want, w.Code, path)
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/webhooks"
+)
+
+// WithWebhooks enables change webhooks for source creates, updates, deletes and enable/disable.
+func (h *SourceHandler) WithWebhooks(dispatcher *webhooks.Dispatcher) *SourceHandler {
+	h.webhooks = dispatcher
+	return h
+}
+
+// notifyUpdated sends source.updated and, when enabled changed, source.enabled
+// or source.disabled. changed is nil when the changed fields are unknown.
+func (h *SourceHandler) notifyUpdated(source *models.Source, changed []string) {
+	h.webhooks.Notify(webhooks.EventUpdated, source, changed)
+	if slices.Contains(changed, "enabled") {
+		h.notifyToggled(source)
+	}
+}
+
+// notifyToggled sends source.enabled or source.disabled for source's current state.
+func (h *SourceHandler) notifyToggled(source *models.Source) {
+	if source.Enabled {
+		h.webhooks.Notify(webhooks.EventEnabled, source, nil)
+		return
+	}
+	h.webhooks.Notify(webhooks.EventDisabled, source, nil)
+}
+
+// notifyToggledByID sends source.enabled or source.disabled after the enable or
+// disable endpoint changed the source with the given ID.
+func (h *SourceHandler) notifyToggledByID(c *gin.Context, id string) {
+	if !h.webhooks.Enabled() {
+		return
+	}
+	if source, err := h.repo.GetByID(c.Request.Context(), id); err == nil {
+		h.notifyToggled(source)
+	}
+}
+
+// GetWebhookStatus reports each webhook endpoint's deliveries since startup.
+// GET /api/v1/sources/webhooks
+func (h *SourceHandler) GetWebhookStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"enabled":   h.webhooks.Enabled(),
+		"endpoints": h.webhooks.Status(),
+	})
+}
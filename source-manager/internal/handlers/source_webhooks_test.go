@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records the events posted to it.
+func webhookReceiver(t *testing.T) (*httptest.Server, <-chan webhooks.Event) {
+	t.Helper()
+	events := make(chan webhooks.Event, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event webhooks.Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events <- event
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, events
+}
+
+func TestSourceHandler_Delete_SendsWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	receiver, events := webhookReceiver(t)
+	handler.WithWebhooks(webhooks.NewDispatcher([]string{receiver.URL}, "", time.Second, 1, testhelpers.NewTestLogger()))
+	router.DELETE("/api/v1/sources/:id", handler.Delete)
+
+	expectLifecycleSource(mock, "active", nil)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sources WHERE id = $1")).
+		WithArgs(lifecycleSourceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/sources/"+lifecycleSourceID, http.NoBody))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, webhooks.EventDeleted, event.Type)
+		assert.Equal(t, lifecycleSourceID, event.SourceID)
+		require.NotNil(t, event.Source)
+		assert.Equal(t, "Example News", event.Source.Name)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook delivered")
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceHandler_SetLifecycle_SendsUpdatedWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, mock, cleanup := newMockSourceHandler(t)
+	defer cleanup()
+
+	receiver, events := webhookReceiver(t)
+	handler.WithWebhooks(webhooks.NewDispatcher([]string{receiver.URL}, "", time.Second, 1, testhelpers.NewTestLogger()))
+	router.PATCH("/api/v1/sources/:id/lifecycle", handler.SetLifecycle)
+
+	expectLifecycleSource(mock, "active", nil)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources")).
+		WithArgs(lifecycleSourceID, "active", "paused", "maintenance").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectLifecycleSource(mock, "paused", time.Now())
+
+	w := patchLifecycle(router, `{"state":"paused","reason":"maintenance"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	select {
+	case event := <-events:
+		assert.Equal(t, webhooks.EventUpdated, event.Type)
+		assert.Equal(t, "paused", event.Source.Lifecycle)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook delivered")
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
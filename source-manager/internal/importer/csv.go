@@ -0,0 +1,34 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// utf8BOM is written at the start of CSV files exported by Excel.
+const utf8BOM = "\ufeff"
+
+// ParseCSVFile parses a CSV file with the same headers and validation as ParseExcelFile.
+// Rows that fail validation are reported in the errors and left out of the rows.
+func ParseCSVFile(reader io.Reader) ([]SourceRow, []ImportError) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1 // Short rows are padded like Excel rows
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, []ImportError{{Row: parseErr.Line, Error: "invalid CSV: " + parseErr.Err.Error()}}
+		}
+		return nil, []ImportError{{Row: 0, Error: "failed to read CSV file: " + err.Error()}}
+	}
+
+	if len(records) > 0 && len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], utf8BOM)
+	}
+
+	return parseSheetRows(records)
+}
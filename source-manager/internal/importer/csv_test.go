@@ -0,0 +1,105 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
+)
+
+func TestParseCSVFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		csv           string
+		wantRowCount  int
+		wantErrors    []importer.ImportError
+		wantFirstName string
+	}{
+		{
+			name: "valid file with alternate headers and BOM",
+			csv: "\ufeffNews Site Name,Website,Status,Selectors\n" +
+				`Example News,https://example.com,Active,"{""article"":{""title"":""h1""}}"` + "\n" +
+				"Test News,https://test.com,inactive,\n",
+			wantRowCount:  2,
+			wantFirstName: "Example News",
+		},
+		{
+			name: "invalid rows are reported by row number",
+			csv: "name,url,max_depth,time\n" +
+				"Good,https://example.com,2,\n" +
+				"No Scheme,example.com,2,\n" +
+				"\n" +
+				`Bad Time,https://test.com,2,not-json` + "\n",
+			wantRowCount: 1,
+			wantErrors: []importer.ImportError{
+				{Row: 3, Error: "url must start with http:// or https://"},
+				{Row: 4, Error: "time must be a valid JSON array"},
+			},
+		},
+		{
+			name:       "missing url column",
+			csv:        "name,enabled\nExample,true\n",
+			wantErrors: []importer.ImportError{{Row: 1, Error: "missing required column: 'URL' (or 'Website', 'Link')"}},
+		},
+		{
+			name:       "malformed CSV",
+			csv:        "name,url\n\"Example,https://example.com\n",
+			wantErrors: []importer.ImportError{{Row: 2, Error: "invalid CSV: extraneous or missing \" in quoted-field"}},
+		},
+		{
+			name: "empty file",
+			csv:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, errs := importer.ParseCSVFile(strings.NewReader(tt.csv))
+
+			if len(rows) != tt.wantRowCount {
+				t.Errorf("ParseCSVFile() got %d rows, want %d", len(rows), tt.wantRowCount)
+			}
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("ParseCSVFile() errors = %v, want %v", errs, tt.wantErrors)
+			}
+			for i, want := range tt.wantErrors {
+				if errs[i] != want {
+					t.Errorf("ParseCSVFile() error[%d] = %+v, want %+v", i, errs[i], want)
+				}
+			}
+			if tt.wantFirstName != "" && rows[0].Name != tt.wantFirstName {
+				t.Errorf("ParseCSVFile() first name = %q, want %q", rows[0].Name, tt.wantFirstName)
+			}
+		})
+	}
+}
+
+func TestParseCSVFile_RowConversion(t *testing.T) {
+	csv := "name,url,status,rate limit,depth,time,selectors\n" +
+		`Example News,https://example.com,no,10,3,"[""09:00""]","{""article"":{""body"":""article""}}"` + "\n"
+
+	rows, errs := importer.ParseCSVFile(strings.NewReader(csv))
+	if len(errs) != 0 || len(rows) != 1 {
+		t.Fatalf("ParseCSVFile() = %v rows, %v errors", rows, errs)
+	}
+
+	source, err := importer.ToSource(rows[0])
+	if err != nil {
+		t.Fatalf("ToSource() error = %v", err)
+	}
+	if source.Enabled {
+		t.Error("expected source to be disabled")
+	}
+	if source.RateLimit != "10s" {
+		t.Errorf("RateLimit = %q, want %q", source.RateLimit, "10s")
+	}
+	if source.MaxDepth != 3 {
+		t.Errorf("MaxDepth = %d, want 3", source.MaxDepth)
+	}
+	if len(source.Time) != 1 || source.Time[0] != "09:00" {
+		t.Errorf("Time = %v, want [09:00]", source.Time)
+	}
+	if source.Selectors.Article.Body != "article" {
+		t.Errorf("Selectors.Article.Body = %q, want %q", source.Selectors.Article.Body, "article")
+	}
+}
@@ -65,9 +65,9 @@ var headerAliases = map[string]string{
 	"selector":  "selectors",
 }
 
-// SourceRow represents a parsed row from the Excel spreadsheet.
+// SourceRow represents a parsed source definition from an import file.
 type SourceRow struct {
-	Row       int // Excel row number (for error reporting)
+	Row       int // Row (Excel, CSV) or line (YAML) number, for error reporting
 	Name      string
 	URL       string
 	Enabled   bool
@@ -186,57 +186,43 @@ func parseHeaders(headerRow []string) columnMap {
 }
 
 // ParseExcelFile parses an Excel file from an io.Reader and returns parsed rows and any validation errors.
-// If any validation errors occur, returns nil rows with all errors.
+// Rows that fail validation are reported in the errors and left out of the rows.
 func ParseExcelFile(reader io.Reader) ([]SourceRow, []ImportError) {
-	f, err := excelize.OpenReader(reader)
-	if err != nil {
-		return nil, []ImportError{{Row: 0, Error: "failed to open Excel file: " + err.Error()}}
-	}
-	defer f.Close()
-
-	// Get the first sheet
-	sheetName := f.GetSheetName(0)
-	if sheetName == "" {
-		return nil, []ImportError{{Row: 0, Error: "no sheets found in Excel file"}}
-	}
-
-	// Get all rows
-	excelRows, err := f.GetRows(sheetName)
+	excelRows, err := openExcelRows(reader)
 	if err != nil {
-		return nil, []ImportError{{Row: 0, Error: "failed to read rows: " + err.Error()}}
+		return nil, []ImportError{{Row: 0, Error: err.Error()}}
 	}
+	return parseSheetRows(excelRows)
+}
 
+// parseSheetRows parses spreadsheet rows (Excel or CSV) whose first row is the header.
+// Rows that fail validation are reported in the errors and left out of the rows.
+func parseSheetRows(sheetRows [][]string) ([]SourceRow, []ImportError) {
 	// Need at least a header row
-	if len(excelRows) == 0 {
+	if len(sheetRows) == 0 {
 		return []SourceRow{}, []ImportError{}
 	}
 
 	// Parse headers to build column map
-	colMap := parseHeaders(excelRows[0])
+	colMap := parseHeaders(sheetRows[0])
 
 	// Validate required columns exist
-	if colMap.name == -1 && colMap.url == -1 {
-		return nil, []ImportError{{Row: 1, Error: "missing required columns: need 'Name' (or 'News Site Name') and 'URL' headers"}}
-	}
-	if colMap.name == -1 {
-		return nil, []ImportError{{Row: 1, Error: "missing required column: 'Name' (or 'News Site Name', 'Site Name', 'Source')"}}
-	}
-	if colMap.url == -1 {
-		return nil, []ImportError{{Row: 1, Error: "missing required column: 'URL' (or 'Website', 'Link')"}}
+	if headerErr := validateRequiredColumns(colMap); headerErr != nil {
+		return nil, []ImportError{*headerErr}
 	}
 
 	// Skip header row, check if there's any data
-	if len(excelRows) <= headerRowIndex {
+	if len(sheetRows) <= headerRowIndex {
 		return []SourceRow{}, []ImportError{}
 	}
 
-	var rows []SourceRow
-	var errs []ImportError
+	rows := make([]SourceRow, 0, len(sheetRows)-headerRowIndex)
+	errs := make([]ImportError, 0)
 
 	// Parse data rows (skip header at index 0)
-	for i := headerRowIndex; i < len(excelRows); i++ {
-		cells := excelRows[i]
-		rowNum := i + 1 // Excel rows are 1-based
+	for i := headerRowIndex; i < len(sheetRows); i++ {
+		cells := sheetRows[i]
+		rowNum := i + 1 // Spreadsheet rows are 1-based
 
 		// Skip empty rows
 		if isEmptyRow(cells) {
@@ -260,15 +246,10 @@ func ParseExcelFile(reader io.Reader) ([]SourceRow, []ImportError) {
 		rows = append(rows, sourceRow)
 	}
 
-	// If any validation errors, return nil rows with all errors
-	if len(errs) > 0 {
-		return nil, errs
-	}
-
 	return rows, errs
 }
 
-// parseRowWithMap converts Excel row cells to a SourceRow using the column map.
+// parseRowWithMap converts spreadsheet row cells to a SourceRow using the column map.
 func parseRowWithMap(cells []string, rowNum int, colMap columnMap) SourceRow {
 	row := SourceRow{Row: rowNum}
 
@@ -360,7 +341,7 @@ func ValidateRow(row SourceRow) string {
 func ToSource(row SourceRow) (*models.Source, error) {
 	source := &models.Source{
 		Name:      row.Name,
-		URL:       row.URL,
+		URL:       models.NormalizeSourceURL(row.URL),
 		Enabled:   row.Enabled,
 		RateLimit: models.NormalizeRateLimit(row.RateLimit),
 		MaxDepth:  row.MaxDepth,
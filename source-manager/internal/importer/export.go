@@ -0,0 +1,174 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/xuri/excelize/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// exportSheetName is the sheet sources are written to; ParseExcelFile reads the first sheet.
+const exportSheetName = "Sources"
+
+// yamlIndent matches the two-space indentation of the YAML import examples.
+const yamlIndent = 2
+
+// exportHeaders is the header row of Excel and CSV exports, in the import template's column order.
+var exportHeaders = []string{"Name", "URL", "Enabled", "Rate Limit", "Max Depth", "Time", "Selectors"}
+
+// ExportFormats lists the formats WriteExport accepts.
+const ExportFormats = "xlsx, csv, yaml"
+
+// exportSource is one source in a YAML export; its fields match yamlSource.
+type exportSource struct {
+	Name      string         `yaml:"name"`
+	URL       string         `yaml:"url"`
+	Enabled   bool           `yaml:"enabled"`
+	RateLimit string         `yaml:"rate_limit,omitempty"`
+	MaxDepth  int            `yaml:"max_depth"`
+	Time      []string       `yaml:"time,omitempty"`
+	Selectors map[string]any `yaml:"selectors,omitempty"`
+}
+
+// WriteExport writes sources in an import format ("xlsx", "csv" or "yaml"), with the
+// fields the import template carries, so the output can be re-imported as is.
+// It returns an error for any other format.
+func WriteExport(w io.Writer, format string, sources []models.Source) error {
+	switch format {
+	case "xlsx":
+		return writeExcelExport(w, sources)
+	case "csv":
+		return writeCSVExport(w, sources)
+	case "yaml":
+		return writeYAMLExport(w, sources)
+	default:
+		return fmt.Errorf("unsupported export format %q (want one of: %s)", format, ExportFormats)
+	}
+}
+
+// exportRows converts sources to spreadsheet rows, header first.
+func exportRows(sources []models.Source) ([][]string, error) {
+	rows := make([][]string, 0, len(sources)+1)
+	rows = append(rows, exportHeaders)
+	for i := range sources {
+		source := &sources[i]
+
+		timeJSON := ""
+		if len(source.Time) > 0 {
+			data, err := json.Marshal(source.Time)
+			if err != nil {
+				return nil, fmt.Errorf("encode time for %q: %w", source.Name, err)
+			}
+			timeJSON = string(data)
+		}
+		selectorsJSON, err := json.Marshal(source.Selectors)
+		if err != nil {
+			return nil, fmt.Errorf("encode selectors for %q: %w", source.Name, err)
+		}
+
+		rows = append(rows, []string{
+			source.Name,
+			source.URL,
+			strconv.FormatBool(source.Enabled),
+			source.RateLimit,
+			strconv.Itoa(source.MaxDepth),
+			timeJSON,
+			string(selectorsJSON),
+		})
+	}
+	return rows, nil
+}
+
+func writeExcelExport(w io.Writer, sources []models.Source) error {
+	rows, err := exportRows(sources)
+	if err != nil {
+		return err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err = f.SetSheetName(f.GetSheetName(0), exportSheetName); err != nil {
+		return fmt.Errorf("name sheet: %w", err)
+	}
+	for i, row := range rows {
+		cell, cellErr := excelize.CoordinatesToCellName(1, i+1)
+		if cellErr != nil {
+			return fmt.Errorf("cell name: %w", cellErr)
+		}
+		if err = f.SetSheetRow(exportSheetName, cell, &row); err != nil {
+			return fmt.Errorf("write row %d: %w", i+1, err)
+		}
+	}
+
+	if _, err = f.WriteTo(w); err != nil {
+		return fmt.Errorf("write Excel file: %w", err)
+	}
+	return nil
+}
+
+func writeCSVExport(w io.Writer, sources []models.Source) error {
+	rows, err := exportRows(sources)
+	if err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(w)
+	if err = csvWriter.WriteAll(rows); err != nil {
+		return fmt.Errorf("write CSV file: %w", err)
+	}
+	return nil
+}
+
+func writeYAMLExport(w io.Writer, sources []models.Source) error {
+	exported := make([]exportSource, 0, len(sources))
+	for i := range sources {
+		source := &sources[i]
+
+		// Round-trip through JSON so selectors keep their JSON names and omit empty fields.
+		var selectors map[string]any
+		data, err := json.Marshal(source.Selectors)
+		if err != nil {
+			return fmt.Errorf("encode selectors for %q: %w", source.Name, err)
+		}
+		if err = json.Unmarshal(data, &selectors); err != nil {
+			return fmt.Errorf("decode selectors for %q: %w", source.Name, err)
+		}
+		for group, groupSelectors := range selectors {
+			if fields, ok := groupSelectors.(map[string]any); ok && len(fields) == 0 {
+				delete(selectors, group)
+			}
+		}
+
+		exported = append(exported, exportSource{
+			Name:      source.Name,
+			URL:       source.URL,
+			Enabled:   source.Enabled,
+			RateLimit: source.RateLimit,
+			MaxDepth:  source.MaxDepth,
+			Time:      source.Time,
+			Selectors: selectors,
+		})
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+	if err := encoder.Encode(map[string][]exportSource{"sources": exported}); err != nil {
+		return fmt.Errorf("encode YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encode YAML: %w", err)
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write YAML file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package importer_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+func exportTestSources() []models.Source {
+	first := models.Source{
+		Name:      "Example News",
+		URL:       "https://example.com",
+		Enabled:   true,
+		RateLimit: "10s",
+		MaxDepth:  2,
+		Time:      models.StringArray{"09:00", "17:00"},
+	}
+	first.Selectors.Article.Title = "h1.headline"
+	first.Selectors.List.ExcludeFromList = []string{".ad"}
+
+	second := models.Source{
+		Name:      "Quiet, \"Quoted\" News",
+		URL:       "https://test.com/news",
+		Enabled:   false,
+		RateLimit: "1s",
+	}
+	return []models.Source{first, second}
+}
+
+func TestWriteExport_RoundTrip(t *testing.T) {
+	parsers := map[string]importer.ParseFunc{
+		"xlsx": importer.ParseExcelFile,
+		"csv":  importer.ParseCSVFile,
+		"yaml": importer.ParseYAMLFile,
+	}
+	want := exportTestSources()
+
+	for format, parse := range parsers {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := importer.WriteExport(&buf, format, want); err != nil {
+				t.Fatalf("WriteExport() error = %v", err)
+			}
+
+			rows, errs := parse(&buf)
+			if len(errs) != 0 {
+				t.Fatalf("parse exported file: errors = %v", errs)
+			}
+			if len(rows) != len(want) {
+				t.Fatalf("parse exported file: got %d rows, want %d", len(rows), len(want))
+			}
+
+			for i, row := range rows {
+				got, err := importer.ToSource(row)
+				if err != nil {
+					t.Fatalf("ToSource() error = %v", err)
+				}
+				if got.Name != want[i].Name || got.URL != want[i].URL || got.Enabled != want[i].Enabled ||
+					got.RateLimit != want[i].RateLimit || got.MaxDepth != want[i].MaxDepth {
+					t.Errorf("row %d = %+v, want %+v", i, got, want[i])
+				}
+				if len(got.Time) != len(want[i].Time) || (len(want[i].Time) > 0 && !reflect.DeepEqual(got.Time, want[i].Time)) {
+					t.Errorf("row %d time = %v, want %v", i, got.Time, want[i].Time)
+				}
+				if !reflect.DeepEqual(got.Selectors, want[i].Selectors) {
+					t.Errorf("row %d selectors = %+v, want %+v", i, got.Selectors, want[i].Selectors)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteExport_YAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := importer.WriteExport(&buf, "yaml", exportTestSources()[:1]); err != nil {
+		t.Fatalf("WriteExport() error = %v", err)
+	}
+
+	want := `sources:
+  - name: Example News
+    url: https://example.com
+    enabled: true
+    rate_limit: 10s
+    max_depth: 2
+    time:
+      - "09:00"
+      - "17:00"
+    selectors:
+      article:
+        title: h1.headline
+      list:
+        exclude_from_list:
+          - .ad
+`
+	if buf.String() != want {
+		t.Errorf("WriteExport() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteExport_UnsupportedFormat(t *testing.T) {
+	err := importer.WriteExport(&bytes.Buffer{}, "json", nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported export format") {
+		t.Errorf("WriteExport() error = %v, want unsupported export format", err)
+	}
+}
@@ -0,0 +1,27 @@
+package importer
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ParseFunc parses an import file into source rows and row-level validation errors.
+type ParseFunc func(reader io.Reader) ([]SourceRow, []ImportError)
+
+// parsersByExtension maps supported import file extensions to their parsers.
+var parsersByExtension = map[string]ParseFunc{
+	".xlsx": ParseExcelFile,
+	".csv":  ParseCSVFile,
+	".yaml": ParseYAMLFile,
+	".yml":  ParseYAMLFile,
+}
+
+// SupportedExtensions lists the import file extensions ParserFor accepts.
+const SupportedExtensions = ".xlsx, .csv, .yaml, .yml"
+
+// ParserFor returns the parser for an import file by its extension.
+func ParserFor(filename string) (ParseFunc, bool) {
+	parse, ok := parsersByExtension[strings.ToLower(filepath.Ext(filename))]
+	return parse, ok
+}
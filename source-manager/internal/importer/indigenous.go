@@ -89,7 +89,7 @@ func IndigenousSourceToModel(src IndigenousSource) (*models.Source, error) {
 
 	return &models.Source{
 		Name:                    src.Name,
-		URL:                     src.Homepage,
+		URL:                     models.NormalizeSourceURL(src.Homepage),
 		RateLimit:               rateLimit,
 		MaxDepth:                maxDepth,
 		Enabled:                 true,
@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlSource is one source definition in a YAML import file.
+type yamlSource struct {
+	Name      string         `yaml:"name"`
+	URL       string         `yaml:"url"`
+	Enabled   *bool          `yaml:"enabled"`
+	RateLimit string         `yaml:"rate_limit"`
+	MaxDepth  int            `yaml:"max_depth"`
+	Time      []string       `yaml:"time"`
+	Selectors map[string]any `yaml:"selectors"`
+}
+
+// ParseYAMLFile parses a YAML list of source definitions, either at the top level or under
+// a "sources" key:
+//
+//	sources:
+//	  - name: Example News
+//	    url: https://example.com
+//	    rate_limit: 10
+//	    selectors:
+//	      article:
+//	        title: h1
+//
+// Each source gets the same validation as an Excel row; errors report the line the source
+// starts on. Sources that fail validation are reported in the errors and left out of the rows.
+func ParseYAMLFile(reader io.Reader) ([]SourceRow, []ImportError) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(reader).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return []SourceRow{}, []ImportError{}
+		}
+		return nil, []ImportError{{Row: 0, Error: "invalid YAML: " + err.Error()}}
+	}
+
+	list, listErr := yamlSourceList(&doc)
+	if listErr != nil {
+		return nil, []ImportError{*listErr}
+	}
+
+	rows := make([]SourceRow, 0, len(list.Content))
+	errs := make([]ImportError, 0)
+	for _, item := range list.Content {
+		sourceRow, err := yamlSourceRow(item)
+		if err != nil {
+			errs = append(errs, ImportError{Row: item.Line, Error: err.Error()})
+			continue
+		}
+
+		// Skip sources without URL (don't treat as error), as Excel rows are
+		if strings.TrimSpace(sourceRow.URL) == "" {
+			continue
+		}
+
+		if errMsg := ValidateRow(sourceRow); errMsg != "" {
+			errs = append(errs, ImportError{Row: item.Line, Error: errMsg})
+			continue
+		}
+
+		rows = append(rows, sourceRow)
+	}
+
+	return rows, errs
+}
+
+// yamlSourceList returns the sequence of source definitions in a YAML document.
+func yamlSourceList(doc *yaml.Node) (*yaml.Node, *ImportError) {
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.SequenceNode}, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "sources" {
+				root = root.Content[i+1]
+				break
+			}
+		}
+	}
+	if root.Kind != yaml.SequenceNode {
+		return nil, &ImportError{Row: root.Line, Error: "expected a list of sources (or a 'sources' key holding one)"}
+	}
+	return root, nil
+}
+
+// yamlSourceRow decodes one source definition into a SourceRow, encoding time and
+// selectors as JSON so they are validated like Excel cells.
+func yamlSourceRow(item *yaml.Node) (SourceRow, error) {
+	var source yamlSource
+	if err := item.Decode(&source); err != nil {
+		return SourceRow{}, fmt.Errorf("invalid source definition: %w", err)
+	}
+
+	sourceRow := SourceRow{
+		Row:       item.Line,
+		Name:      strings.TrimSpace(source.Name),
+		URL:       strings.TrimSpace(source.URL),
+		Enabled:   source.Enabled == nil || *source.Enabled, // Default to enabled, as Excel rows are
+		RateLimit: strings.TrimSpace(source.RateLimit),
+		MaxDepth:  source.MaxDepth,
+	}
+
+	if source.Time != nil {
+		timeJSON, err := json.Marshal(source.Time)
+		if err != nil {
+			return SourceRow{}, fmt.Errorf("encode time: %w", err)
+		}
+		sourceRow.Time = string(timeJSON)
+	}
+	if source.Selectors != nil {
+		selectorsJSON, err := json.Marshal(source.Selectors)
+		if err != nil {
+			return SourceRow{}, fmt.Errorf("encode selectors: %w", err)
+		}
+		sourceRow.Selectors = string(selectorsJSON)
+	}
+
+	return sourceRow, nil
+}
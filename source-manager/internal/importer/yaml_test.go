@@ -0,0 +1,126 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/importer"
+)
+
+func TestParseYAMLFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		wantRowCount int
+		wantErrors   []importer.ImportError
+	}{
+		{
+			name: "top-level list",
+			yaml: `
+- name: Example News
+  url: https://example.com
+- name: Test News
+  url: https://test.com
+  enabled: false
+`,
+			wantRowCount: 2,
+		},
+		{
+			name: "sources key with invalid entries reported by line",
+			yaml: `sources:
+  - name: Good
+    url: https://example.com
+  - name: No Scheme
+    url: example.com
+  - name: Negative Depth
+    url: https://test.com
+    max_depth: -1
+  - name: Bad Time
+    url: https://test.com
+    time: morning
+  - name: Without URL is skipped
+`,
+			wantRowCount: 1,
+			wantErrors: []importer.ImportError{
+				{Row: 4, Error: "url must start with http:// or https://"},
+				{Row: 6, Error: "max_depth must be non-negative"},
+				{Row: 9, Error: "invalid source definition: yaml: unmarshal errors:\n  line 11: cannot unmarshal !!str `morning` into []string"},
+			},
+		},
+		{
+			name:       "not a list",
+			yaml:       "name: Example News\nurl: https://example.com\n",
+			wantErrors: []importer.ImportError{{Row: 1, Error: "expected a list of sources (or a 'sources' key holding one)"}},
+		},
+		{
+			name:       "invalid YAML",
+			yaml:       "- name: [unclosed\n",
+			wantErrors: []importer.ImportError{{Row: 0, Error: "invalid YAML: yaml: line 1: did not find expected ',' or ']'"}},
+		},
+		{
+			name: "empty file",
+			yaml: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, errs := importer.ParseYAMLFile(strings.NewReader(tt.yaml))
+
+			if len(rows) != tt.wantRowCount {
+				t.Errorf("ParseYAMLFile() got %d rows, want %d", len(rows), tt.wantRowCount)
+			}
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("ParseYAMLFile() errors = %v, want %v", errs, tt.wantErrors)
+			}
+			for i, want := range tt.wantErrors {
+				if errs[i] != want {
+					t.Errorf("ParseYAMLFile() error[%d] = %+v, want %+v", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseYAMLFile_RowConversion(t *testing.T) {
+	yaml := `
+- name: Example News
+  url: https://example.com
+  rate_limit: 10
+  max_depth: 3
+  time: ["09:00"]
+  selectors:
+    article:
+      title: h1.headline
+    list:
+      article_cards: .card
+`
+
+	rows, errs := importer.ParseYAMLFile(strings.NewReader(yaml))
+	if len(errs) != 0 || len(rows) != 1 {
+		t.Fatalf("ParseYAMLFile() = %v rows, %v errors", rows, errs)
+	}
+
+	source, err := importer.ToSource(rows[0])
+	if err != nil {
+		t.Fatalf("ToSource() error = %v", err)
+	}
+	if !source.Enabled {
+		t.Error("expected source to default to enabled")
+	}
+	if source.RateLimit != "10s" {
+		t.Errorf("RateLimit = %q, want %q", source.RateLimit, "10s")
+	}
+	if source.MaxDepth != 3 {
+		t.Errorf("MaxDepth = %d, want 3", source.MaxDepth)
+	}
+	if len(source.Time) != 1 || source.Time[0] != "09:00" {
+		t.Errorf("Time = %v, want [09:00]", source.Time)
+	}
+	if source.Selectors.Article.Title != "h1.headline" {
+		t.Errorf("Selectors.Article.Title = %q, want %q", source.Selectors.Article.Title, "h1.headline")
+	}
+	if source.Selectors.List.ArticleCards != ".card" {
+		t.Errorf("Selectors.List.ArticleCards = %q, want %q", source.Selectors.List.ArticleCards, ".card")
+	}
+}
@@ -28,3 +28,14 @@ func NormalizeRateLimit(s string) string {
 	}
 	return DefaultRateLimit
 }
+
+// IsValidRateLimit reports whether s is a positive duration or a positive number of
+// seconds, i.e. whether NormalizeRateLimit keeps it rather than falling back to the default.
+func IsValidRateLimit(s string) bool {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return d > 0
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
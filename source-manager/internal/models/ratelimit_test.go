@@ -33,3 +33,19 @@ func TestNormalizeRateLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidRateLimit(t *testing.T) {
+	t.Helper()
+	valid := []string{"10", " 5 ", "10s", "1m", "500ms"}
+	invalid := []string{"", "abc", "0", "-1", "0s", "-5s"}
+	for _, in := range valid {
+		if !models.IsValidRateLimit(in) {
+			t.Errorf("IsValidRateLimit(%q) = false, want true", in)
+		}
+	}
+	for _, in := range invalid {
+		if models.IsValidRateLimit(in) {
+			t.Errorf("IsValidRateLimit(%q) = true, want false", in)
+		}
+	}
+}
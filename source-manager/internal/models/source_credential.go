@@ -0,0 +1,146 @@
+package models
+
+import (
+	"errors"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Source credential kinds. A source has at most one credential of each kind.
+const (
+	// CredentialKindBasicAuth is HTTP basic auth; the value is the password.
+	CredentialKindBasicAuth = "basic_auth"
+	// CredentialKindAPIKey is sent as the value of HeaderName.
+	CredentialKindAPIKey = "api_key"
+	// CredentialKindCookie is a login cookie string, sent as the Cookie header.
+	CredentialKindCookie = "cookie"
+)
+
+const (
+	// credentialHintLength is how many trailing characters of a value are kept in
+	// clear for masking; values shorter than credentialMinHintValue keep none.
+	credentialHintLength   = 4
+	credentialMinHintValue = 12
+	credentialMask         = "****"
+	// maxCredentialValueLength bounds a secret, enough for a long cookie string.
+	maxCredentialValueLength = 8192
+	// maxCredentialFieldLength matches source_credentials.username.
+	maxCredentialFieldLength = 255
+	// maxHeaderNameLength matches source_credentials.header_name.
+	maxHeaderNameLength = 100
+)
+
+// SourceCredential is a secret a source needs to be crawled, stored encrypted at
+// rest. Its value is never returned by the API: responses carry MaskedValue, and
+// only the crawler can resolve the value at fetch time.
+type SourceCredential struct {
+	ID       string `json:"id"`
+	SourceID string `json:"source_id"`
+	Kind     string `json:"kind"`
+	// Username is the basic auth user name (not secret).
+	Username string `json:"username,omitempty"`
+	// HeaderName is the request header an API key is sent in.
+	HeaderName string `json:"header_name,omitempty"`
+	// Ciphertext is the value sealed with the credential's data key.
+	Ciphertext []byte `json:"-"`
+	// WrappedKey is the data key sealed with the master key KeyID.
+	WrappedKey []byte `json:"-"`
+	KeyID      string `json:"key_id"`
+	// Hint is the clear tail of the value used to build MaskedValue.
+	Hint        string     `json:"-"`
+	MaskedValue string     `json:"masked_value"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	RotatedAt   *time.Time `json:"rotated_at,omitempty"`
+}
+
+// Mask sets MaskedValue from the stored hint.
+func (c *SourceCredential) Mask() {
+	c.MaskedValue = credentialMask + c.Hint
+}
+
+// CredentialHint returns the clear tail kept for masking a value. Short values
+// keep nothing so the hint cannot give most of a secret away.
+func CredentialHint(value string) string {
+	if len(value) < credentialMinHintValue {
+		return ""
+	}
+	return value[len(value)-credentialHintLength:]
+}
+
+// IsValidCredentialKind reports whether kind is a known source credential kind.
+func IsValidCredentialKind(kind string) bool {
+	return kind == CredentialKindBasicAuth || kind == CredentialKindAPIKey || kind == CredentialKindCookie
+}
+
+// SourceCredentialRequest stores or replaces a source's credential of one kind.
+type SourceCredentialRequest struct {
+	Username   string `json:"username"`
+	HeaderName string `json:"header_name"`
+	Value      string `json:"value"`
+}
+
+// Validate checks the request for a credential of kind and canonicalizes the header name.
+func (r *SourceCredentialRequest) Validate(kind string) error {
+	r.Username = strings.TrimSpace(r.Username)
+	r.HeaderName = strings.TrimSpace(r.HeaderName)
+
+	switch {
+	case !IsValidCredentialKind(kind):
+		return errors.New("kind must be basic_auth, api_key or cookie")
+	case r.Value == "":
+		return errors.New("value is required")
+	case len(r.Value) > maxCredentialValueLength:
+		return errors.New("value is too long")
+	case strings.ContainsAny(r.Value, "\r\n"):
+		return errors.New("value must not contain line breaks")
+	case len(r.Username) > maxCredentialFieldLength:
+		return errors.New("username is too long")
+	}
+
+	switch kind {
+	case CredentialKindBasicAuth:
+		if r.Username == "" {
+			return errors.New("username is required for basic_auth")
+		}
+		r.HeaderName = ""
+	case CredentialKindAPIKey:
+		if !validHeaderName(r.HeaderName) {
+			return errors.New("header_name is required for api_key and must be a valid header name")
+		}
+		r.HeaderName = textproto.CanonicalMIMEHeaderKey(r.HeaderName)
+		r.Username = ""
+	case CredentialKindCookie:
+		r.Username, r.HeaderName = "", ""
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is a non-empty HTTP header token that is
+// not one the crawler manages itself.
+func validHeaderName(name string) bool {
+	if name == "" || len(name) > maxHeaderNameLength {
+		return false
+	}
+	for _, ch := range name {
+		isAlnum := (ch >= '0' && ch <= '9') || (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z')
+		if !isAlnum && ch != '-' && ch != '_' {
+			return false
+		}
+	}
+	switch textproto.CanonicalMIMEHeaderKey(name) {
+	case "Host", "Cookie", "Content-Length", "Transfer-Encoding", "Connection":
+		return false
+	}
+	return true
+}
+
+// ResolvedCredential is a decrypted credential as the crawler applies it to requests.
+type ResolvedCredential struct {
+	Kind       string `json:"kind"`
+	Username   string `json:"username,omitempty"`
+	HeaderName string `json:"header_name,omitempty"`
+	Value      string `json:"value"`
+}
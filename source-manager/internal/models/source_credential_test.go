@@ -0,0 +1,30 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceCredentialRequest_Validate(t *testing.T) {
+	req := models.SourceCredentialRequest{Username: "ignored", HeaderName: " x-api-key ", Value: "key"}
+	require.NoError(t, req.Validate(models.CredentialKindAPIKey))
+	assert.Equal(t, "X-Api-Key", req.HeaderName)
+	assert.Empty(t, req.Username, "fields the kind does not use are cleared")
+
+	req = models.SourceCredentialRequest{Value: "session=abc\r\nX-Injected: 1"}
+	require.Error(t, req.Validate(models.CredentialKindCookie))
+
+	req = models.SourceCredentialRequest{HeaderName: "Cookie", Value: "key"}
+	require.Error(t, req.Validate(models.CredentialKindAPIKey), "the crawler manages the Cookie header")
+
+	req = models.SourceCredentialRequest{HeaderName: "X Api Key", Value: "key"}
+	require.Error(t, req.Validate(models.CredentialKindAPIKey))
+}
+
+func TestCredentialHint(t *testing.T) {
+	assert.Empty(t, models.CredentialHint("hunter2"), "short values keep no hint")
+	assert.Equal(t, "7890", models.CredentialHint("secret-123456-7890"))
+}
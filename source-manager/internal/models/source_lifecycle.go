@@ -0,0 +1,60 @@
+package models
+
+import "slices"
+
+// Source lifecycle states. Only active sources are crawled; Source.Enabled is
+// kept equal to Lifecycle == LifecycleActive for the services that read it.
+const (
+	// LifecycleDraft is a source being onboarded: saved but never crawled yet.
+	LifecycleDraft = "draft"
+	// LifecycleActive is a source that is crawled.
+	LifecycleActive = "active"
+	// LifecyclePaused is a source temporarily not crawled, with a reason.
+	LifecyclePaused = "paused"
+	// LifecycleArchived is a retired source. It is not crawled and its indexes
+	// are kept only for the retention period. It must go back through draft
+	// (review) before it can be active again.
+	LifecycleArchived = "archived"
+)
+
+// lifecycleTransitions lists the states each state may move to.
+var lifecycleTransitions = map[string][]string{
+	LifecycleDraft:    {LifecycleActive, LifecycleArchived},
+	LifecycleActive:   {LifecyclePaused, LifecycleArchived},
+	LifecyclePaused:   {LifecycleActive, LifecycleArchived},
+	LifecycleArchived: {LifecycleDraft},
+}
+
+// IsValidLifecycle reports whether state is a known lifecycle state.
+func IsValidLifecycle(state string) bool {
+	_, ok := lifecycleTransitions[state]
+	return ok
+}
+
+// LifecycleTransitions returns the states a source in state may move to.
+func LifecycleTransitions(state string) []string {
+	return slices.Clone(lifecycleTransitions[state])
+}
+
+// CanTransitionLifecycle reports whether a source may move from one state to another.
+func CanTransitionLifecycle(from, to string) bool {
+	return slices.Contains(lifecycleTransitions[from], to)
+}
+
+// LifecycleNeedsReason reports whether moving to state requires a reason.
+func LifecycleNeedsReason(state string) bool {
+	return state == LifecyclePaused || state == LifecycleArchived
+}
+
+// InitialLifecycle returns the state of a new source: the requested one, or
+// active when it is enabled and draft when it is not.
+func InitialLifecycle(requested string, enabled bool) string {
+	switch {
+	case requested != "":
+		return requested
+	case enabled:
+		return LifecycleActive
+	default:
+		return LifecycleDraft
+	}
+}
@@ -0,0 +1,26 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionLifecycle(t *testing.T) {
+	assert.True(t, models.CanTransitionLifecycle(models.LifecycleDraft, models.LifecycleActive))
+	assert.True(t, models.CanTransitionLifecycle(models.LifecycleActive, models.LifecyclePaused))
+	assert.True(t, models.CanTransitionLifecycle(models.LifecyclePaused, models.LifecycleArchived))
+	assert.True(t, models.CanTransitionLifecycle(models.LifecycleArchived, models.LifecycleDraft))
+
+	assert.False(t, models.CanTransitionLifecycle(models.LifecycleArchived, models.LifecycleActive),
+		"archived sources go back through draft")
+	assert.False(t, models.CanTransitionLifecycle(models.LifecycleDraft, models.LifecyclePaused))
+	assert.False(t, models.CanTransitionLifecycle("retired", models.LifecycleActive))
+}
+
+func TestInitialLifecycle(t *testing.T) {
+	assert.Equal(t, models.LifecycleActive, models.InitialLifecycle("", true))
+	assert.Equal(t, models.LifecycleDraft, models.InitialLifecycle("", false))
+	assert.Equal(t, models.LifecycleDraft, models.InitialLifecycle(models.LifecycleDraft, true))
+}
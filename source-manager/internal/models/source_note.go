@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Source note kinds.
+const (
+	// NoteKindNote is a free-form operator note.
+	NoteKindNote = "note"
+	// NoteKindSectionSuggestion is filed by the crawler when it finds a section
+	// or URL pattern on a source that the current crawl filters do not cover.
+	NoteKindSectionSuggestion = "section_suggestion"
+)
+
+// SourceNote is a note attached to a source by an operator or another service.
+type SourceNote struct {
+	ID       string `json:"id"`
+	SourceID string `json:"source_id"`
+	Kind     string `json:"kind"`
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	// Details: optional structured payload (e.g. sample URLs and a suggested pattern).
+	Details json.RawMessage `json:"details,omitempty"`
+	// DedupeKey: when set, a second note with the same key on the same source is ignored.
+	DedupeKey  *string    `json:"dedupe_key,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// IsValidNoteKind reports whether kind is a known source note kind.
+func IsValidNoteKind(kind string) bool {
+	return kind == NoteKindNote || kind == NoteKindSectionSuggestion
+}
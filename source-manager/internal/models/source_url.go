@@ -0,0 +1,77 @@
+package models
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Duplicate match kinds: a source with the same site and path, or only the same site.
+const (
+	DuplicateMatchURL    = "url"
+	DuplicateMatchDomain = "domain"
+)
+
+// SourceDuplicate is an existing source that a new source's URL duplicates.
+type SourceDuplicate struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Lifecycle string `json:"lifecycle,omitempty"`
+	Match     string `json:"match"`
+}
+
+// SourceURLKey is what identifies the site a source crawls: the host without
+// "www." or a default port, and the path without a trailing slash. Scheme,
+// query and fragment are ignored, as is case.
+type SourceURLKey struct {
+	Domain string
+	Path   string
+}
+
+// NormalizeSourceURL tidies a source URL for storage: surrounding space, the
+// fragment and a default port are dropped and the scheme and host lowercased.
+// A value that is not an absolute URL is only trimmed.
+func NormalizeSourceURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return raw
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = hostWithoutDefaultPort(parsed.Scheme, strings.ToLower(parsed.Host))
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	return parsed.String()
+}
+
+// URLKeyOf returns the duplicate-detection key of a source URL. A URL without
+// a scheme is read as https. ok is false when there is no host.
+func URLKeyOf(raw string) (key SourceURLKey, ok bool) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" {
+		return SourceURLKey{}, false
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	domain := hostWithoutDefaultPort(scheme, strings.ToLower(parsed.Host))
+	domain = strings.TrimPrefix(domain, "www.")
+	path := strings.TrimRight(strings.ToLower(parsed.EscapedPath()), "/")
+	return SourceURLKey{Domain: domain, Path: path}, true
+}
+
+// hostWithoutDefaultPort drops :80 from http and :443 from https hosts.
+func hostWithoutDefaultPort(scheme, host string) string {
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return host
+	}
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		return hostname
+	}
+	return host
+}
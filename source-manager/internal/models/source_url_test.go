@@ -0,0 +1,35 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSourceURL(t *testing.T) {
+	assert.Equal(t, "https://example.com/news?page=1",
+		models.NormalizeSourceURL("  HTTPS://Example.COM:443/news?page=1#top "))
+	assert.Equal(t, "http://example.com:8080/", models.NormalizeSourceURL("http://example.com:8080/"))
+	assert.Equal(t, "example.com/news", models.NormalizeSourceURL(" example.com/news"))
+}
+
+func TestURLKeyOf(t *testing.T) {
+	want := models.SourceURLKey{Domain: "example.com", Path: "/news"}
+	for _, raw := range []string{
+		"https://www.example.com/news/",
+		"http://EXAMPLE.com:80/News?utm_source=x",
+		"example.com/news#latest",
+	} {
+		key, ok := models.URLKeyOf(raw)
+		assert.True(t, ok, raw)
+		assert.Equal(t, want, key, raw)
+	}
+
+	key, ok := models.URLKeyOf("https://example.com/")
+	assert.True(t, ok)
+	assert.Empty(t, key.Path)
+
+	_, ok = models.URLKeyOf("https://")
+	assert.False(t, ok)
+}
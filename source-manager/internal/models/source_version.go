@@ -0,0 +1,125 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Source version actions.
+const (
+	// VersionActionBaseline records a source's state as found before a tracked
+	// change, when it predates version history or changed without a version
+	// (imports, the enable/disable endpoints).
+	VersionActionBaseline = "baseline"
+	// VersionActionCreate records a newly created source.
+	VersionActionCreate = "create"
+	// VersionActionUpdate records an edit through the update endpoint.
+	VersionActionUpdate = "update"
+	// VersionActionRollback records a source restored to an earlier version.
+	VersionActionRollback = "rollback"
+)
+
+// unversionedSourceFields are bookkeeping fields left out of version diffs.
+var unversionedSourceFields = map[string]bool{
+	"id":               true,
+	"created_at":       true,
+	"updated_at":       true,
+	"disabled_at":      true,
+	"feed_disabled_at": true,
+	// lifecycle_changed_at is bookkeeping; lifecycle itself is versioned.
+	"lifecycle_changed_at": true,
+}
+
+// SourceVersion is a source's configuration as it was after one change.
+type SourceVersion struct {
+	ID       string `json:"id"`
+	SourceID string `json:"source_id"`
+	// Version numbers a source's history from 1, in order.
+	Version int    `json:"version"`
+	Action  string `json:"action"`
+	// Author is the subject of the token that made the change.
+	Author string `json:"author"`
+	// Changes lists the fields that differ from the previous version.
+	Changes []FieldChange `json:"changes"`
+	// Snapshot is the full source; omitted from version lists.
+	Snapshot  json.RawMessage `json:"snapshot,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// FieldChange is one changed field, named by its JSON path (e.g. "selectors.article.title").
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// DiffSources returns the fields that differ between two sources, sorted by
+// path. Selector and other nested objects are compared field by field; arrays
+// are compared whole. A nil before diffs against an empty source.
+func DiffSources(before, after *Source) ([]FieldChange, error) {
+	oldFields, err := flattenSource(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := flattenSource(after)
+	if err != nil {
+		return nil, err
+	}
+
+	null := json.RawMessage("null")
+	changes := make([]FieldChange, 0)
+	for field, newValue := range newFields {
+		if oldValue, ok := oldFields[field]; !ok || !bytes.Equal(oldValue, newValue) {
+			if !ok {
+				oldValue = null
+			}
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	for field, oldValue := range oldFields {
+		if _, ok := newFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: null})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// flattenSource maps each versioned leaf field of source to its JSON value.
+func flattenSource(source *Source) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if source == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(source)
+	if err != nil {
+		return nil, fmt.Errorf("marshal source: %w", err)
+	}
+	var top map[string]json.RawMessage
+	if err = json.Unmarshal(data, &top); err != nil {
+		return nil, fmt.Errorf("unmarshal source: %w", err)
+	}
+	for name, value := range top {
+		if !unversionedSourceFields[name] {
+			flattenJSON(name, value, fields)
+		}
+	}
+	return fields, nil
+}
+
+// flattenJSON adds value to fields under path, descending into objects.
+func flattenJSON(path string, value json.RawMessage, fields map[string]json.RawMessage) {
+	var object map[string]json.RawMessage
+	if bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) && json.Unmarshal(value, &object) == nil {
+		for name, child := range object {
+			flattenJSON(path+"."+name, child, fields)
+		}
+		return
+	}
+	fields[path] = value
+}
@@ -0,0 +1,45 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+func TestDiffSources(t *testing.T) {
+	feedURL := "https://example.com/feed"
+	before := &models.Source{ID: "a", Name: "Example", MaxDepth: 2, UpdatedAt: time.Now()}
+	before.Selectors.Article.Title = "h1"
+	before.Selectors.Article.Exclude = []string{".ad"}
+
+	after := *before
+	after.UpdatedAt = before.UpdatedAt.Add(time.Hour)
+	after.FeedURL = &feedURL
+	after.Selectors.Article.Title = "h2"
+	after.Selectors.Article.Exclude = []string{".ad", ".promo"}
+
+	changes, err := models.DiffSources(before, &after)
+	if err != nil {
+		t.Fatalf("DiffSources() error = %v", err)
+	}
+
+	want := []struct{ field, old, new string }{
+		{"feed_url", "null", `"https://example.com/feed"`},
+		{"selectors.article.exclude", `[".ad"]`, `[".ad",".promo"]`},
+		{"selectors.article.title", `"h1"`, `"h2"`},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("DiffSources() = %+v, want %d changes", changes, len(want))
+	}
+	for i, w := range want {
+		if changes[i].Field != w.field || string(changes[i].Old) != w.old || string(changes[i].New) != w.new {
+			t.Errorf("change %d = %s: %s -> %s, want %s: %s -> %s",
+				i, changes[i].Field, changes[i].Old, changes[i].New, w.field, w.old, w.new)
+		}
+	}
+
+	if changes, err = models.DiffSources(&after, &after); err != nil || len(changes) != 0 {
+		t.Errorf("DiffSources(same) = %+v, %v, want no changes", changes, err)
+	}
+}
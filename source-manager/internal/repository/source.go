@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
 	"github.com/jonesrussell/north-cloud/infrastructure/naming"
 	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/lib/pq"
 )
 
 // ErrSourceNotFound is returned when a source operation targets a non-existent ID.
@@ -22,6 +24,10 @@ var ErrSourceNotFound = errors.New("source not found")
 // ErrDisableReasonRequired is returned when disabling a source without an audit reason.
 var ErrDisableReasonRequired = errors.New("disable reason required")
 
+// ErrSourceArchived is returned when enabling an archived source: it has to be
+// moved back to draft (reviewed) first.
+var ErrSourceArchived = errors.New("archived source must be moved to draft before it is enabled")
+
 type SourceRepository struct {
 	db     *sql.DB
 	logger infralogger.Logger
@@ -42,6 +48,9 @@ func (r *SourceRepository) Create(ctx context.Context, source *models.Source) er
 	if source.Type == "" {
 		source.Type = models.DefaultSourceType
 	}
+	source.Lifecycle = models.InitialLifecycle(source.Lifecycle, source.Enabled)
+	source.Enabled = source.Lifecycle == models.LifecycleActive
+	source.LifecycleChangedAt = &source.CreatedAt
 
 	selectorsJSON, err := json.Marshal(source.Selectors)
 	if err != nil {
@@ -61,8 +70,10 @@ func (r *SourceRepository) Create(ctx context.Context, source *models.Source) er
 			time, selectors, enabled,
 			feed_url, sitemap_url, ingestion_mode, feed_poll_interval_minutes,
 			allow_source_discovery, identity_key, extraction_profile, template_hint,
-			render_mode, type, indigenous_region, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+			render_mode, type, indigenous_region, created_at, updated_at,
+			lifecycle, lifecycle_changed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21,
+			$22, $20)
 	`
 
 	_, err = r.db.ExecContext(ctx,
@@ -88,6 +99,7 @@ func (r *SourceRepository) Create(ctx context.Context, source *models.Source) er
 		source.IndigenousRegion,
 		source.CreatedAt,
 		source.UpdatedAt,
+		source.Lifecycle,
 	)
 
 	if err != nil {
@@ -117,6 +129,7 @@ func (r *SourceRepository) GetByID(ctx context.Context, id string) (*models.Sour
 		       allow_source_discovery, identity_key, extraction_profile, template_hint,
 		       render_mode, type, indigenous_region,
 		       disabled_at, disable_reason,
+		       lifecycle, lifecycle_changed_at,
 		       created_at, updated_at
 		FROM sources
 		WHERE id = $1
@@ -146,6 +159,8 @@ func (r *SourceRepository) GetByID(ctx context.Context, id string) (*models.Sour
 		&source.IndigenousRegion,
 		&source.DisabledAt,
 		&source.DisableReason,
+		&source.Lifecycle,
+		&source.LifecycleChangedAt,
 		&source.CreatedAt,
 		&source.UpdatedAt,
 	)
@@ -188,6 +203,7 @@ func (r *SourceRepository) GetByIdentityKey(ctx context.Context, identityKey str
 		       allow_source_discovery, identity_key, extraction_profile, template_hint,
 		       render_mode, type, indigenous_region,
 		       disabled_at, disable_reason,
+		       lifecycle, lifecycle_changed_at,
 		       created_at, updated_at
 		FROM sources
 		WHERE identity_key = $1
@@ -212,16 +228,56 @@ func (r *SourceRepository) GetByIdentityKey(ctx context.Context, identityKey str
 	return source, nil
 }
 
+// GetByNames returns the sources with the given names, keyed by name. Names with
+// no source are absent from the map.
+func (r *SourceRepository) GetByNames(ctx context.Context, names []string) (map[string]*models.Source, error) {
+	found := make(map[string]*models.Source, len(names))
+	if len(names) == 0 {
+		return found, nil
+	}
+	query := `
+		SELECT id, name, url, rate_limit, max_depth,
+		       time, selectors, enabled,
+		       feed_url, sitemap_url, ingestion_mode, feed_poll_interval_minutes,
+		       feed_disabled_at, feed_disable_reason,
+		       allow_source_discovery, identity_key, extraction_profile, template_hint,
+		       render_mode, type, indigenous_region,
+		       disabled_at, disable_reason,
+		       lifecycle, lifecycle_changed_at,
+		       created_at, updated_at
+		FROM sources
+		WHERE name = ANY($1)
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("query sources by name: %w", err)
+	}
+	defer rows.Close()
+
+	sources, scanErr := scanSourceRows(rows)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	for i := range sources {
+		found[sources[i].Name] = &sources[i]
+	}
+	return found, nil
+}
+
 // ListFilter holds pagination and filter params for ListPaginated.
 type ListFilter struct {
 	Limit          int
 	Offset         int
-	SortBy         string // name, url, enabled, created_at
+	SortBy         string // name, url, enabled, created_at, updated_at
 	SortOrder      string // asc, desc
-	Search         string // ILIKE on name or url
+	Search         string // whitespace-separated terms, each matched (ILIKE) against name or url
 	Enabled        *bool  // nil = all, true = enabled only, false = disabled only
 	FeedActive     *bool  // nil = all, true = feeds that are active or past cooldown
 	IndigenousOnly bool   // true = only sources with indigenous_region IS NOT NULL
+	Lifecycle      string // "" = all, else only sources in this lifecycle state
+	Type           string // "" = all, else only sources of this type
+	Region         string // "" = all, else only sources tagged with this indigenous_region
+	Domain         string // "" = all, else only sources whose URL host is this domain or a subdomain of it
 }
 
 // Count returns the total number of sources matching the filter (ignores Limit/Offset/Sort).
@@ -259,6 +315,7 @@ func (r *SourceRepository) ListPaginated(ctx context.Context, filter ListFilter)
 		       allow_source_discovery, identity_key, extraction_profile, template_hint,
 		       render_mode, type, indigenous_region,
 		       disabled_at, disable_reason,
+		       lifecycle, lifecycle_changed_at,
 		       created_at, updated_at
 		FROM sources
 		WHERE 1=1` + whereClause + orderClause + `
@@ -323,6 +380,8 @@ func scanSourceRow(rows *sql.Rows) (*models.Source, error) {
 		&source.IndigenousRegion,
 		&source.DisabledAt,
 		&source.DisableReason,
+		&source.Lifecycle,
+		&source.LifecycleChangedAt,
 		&source.CreatedAt,
 		&source.UpdatedAt,
 	); err != nil {
@@ -343,10 +402,10 @@ func buildListWhere(filter ListFilter) (whereClause string, args []any) {
 	// Derive placeholder position from args length to avoid manual pos tracking.
 	nextPos := func() int { return len(args) + 1 }
 
-	if filter.Search != "" {
+	for _, term := range strings.Fields(filter.Search) {
 		pos := nextPos()
 		clauses = append(clauses, fmt.Sprintf("(name ILIKE $%d OR url ILIKE $%d)", pos, pos))
-		args = append(args, "%"+filter.Search+"%")
+		args = append(args, "%"+likeEscaper.Replace(term)+"%")
 	}
 	if filter.Enabled != nil {
 		clauses = append(clauses, fmt.Sprintf("enabled = $%d", nextPos()))
@@ -375,6 +434,26 @@ func buildListWhere(filter ListFilter) (whereClause string, args []any) {
 		clauses = append(clauses, "indigenous_region IS NOT NULL")
 	}
 
+	if filter.Lifecycle != "" {
+		clauses = append(clauses, fmt.Sprintf("lifecycle = $%d", nextPos()))
+		args = append(args, filter.Lifecycle)
+	}
+
+	if filter.Type != "" {
+		clauses = append(clauses, fmt.Sprintf("type = $%d", nextPos()))
+		args = append(args, filter.Type)
+	}
+
+	if filter.Region != "" {
+		clauses = append(clauses, fmt.Sprintf("indigenous_region = $%d", nextPos()))
+		args = append(args, filter.Region)
+	}
+
+	if filter.Domain != "" {
+		clauses = append(clauses, fmt.Sprintf("url ~* $%d", nextPos()))
+		args = append(args, domainURLPattern(filter.Domain))
+	}
+
 	if len(clauses) == 0 {
 		return "", args
 	}
@@ -388,7 +467,7 @@ func buildListOrder(filter ListFilter) string {
 		sortBy = "name"
 	}
 	validSort := map[string]bool{
-		"name": true, "url": true, "enabled": true, "created_at": true,
+		"name": true, "url": true, "enabled": true, "created_at": true, "updated_at": true,
 	}
 	if !validSort[sortBy] {
 		sortBy = "name"
@@ -397,7 +476,18 @@ func buildListOrder(filter ListFilter) string {
 	if order != "ASC" && order != "DESC" {
 		order = "ASC"
 	}
-	return fmt.Sprintf(" ORDER BY %s %s", sortBy, order)
+	// id breaks ties so that pages do not overlap or skip rows with equal sort values.
+	return fmt.Sprintf(" ORDER BY %s %s, id", sortBy, order)
+}
+
+// likeEscaper escapes the ILIKE wildcards in a search term so that it matches
+// literally; backslash is PostgreSQL's default LIKE escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// domainURLPattern returns a case-insensitive regular expression matching
+// URLs whose host is domain or one of its subdomains, on any port.
+func domainURLPattern(domain string) string {
+	return `^[a-z][a-z0-9+.-]*://([^/?#@]*@)?([^/?#@]*\.)?` + regexp.QuoteMeta(domain) + `(:[0-9]+)?([/?#]|$)`
 }
 
 func (r *SourceRepository) List(ctx context.Context) ([]models.Source, error) {
@@ -409,6 +499,7 @@ func (r *SourceRepository) List(ctx context.Context) ([]models.Source, error) {
 		       allow_source_discovery, identity_key, extraction_profile, template_hint,
 		       render_mode, type, indigenous_region,
 		       disabled_at, disable_reason,
+		       lifecycle, lifecycle_changed_at,
 		       created_at, updated_at
 		FROM sources
 		ORDER BY name
@@ -446,17 +537,23 @@ func (r *SourceRepository) Update(ctx context.Context, source *models.Source) er
 		    feed_url = $9, sitemap_url = $10, ingestion_mode = $11, feed_poll_interval_minutes = $12,
 		    allow_source_discovery = $13, identity_key = $14, extraction_profile = $15, template_hint = $16,
 		    render_mode = $17, type = $18, indigenous_region = $19,
+		    lifecycle = ` + updateLifecycleExpr + `,
+		    lifecycle_changed_at = CASE
+		        WHEN ` + updateLifecycleExpr + ` = lifecycle THEN lifecycle_changed_at
+		        ELSE NOW()
+		    END,
 		    disabled_at = CASE
-		        WHEN $8 THEN NULL
+		        WHEN $8 OR lifecycle = 'draft' THEN NULL
 		        ELSE COALESCE(disabled_at, NOW())
 		    END,
 		    disable_reason = CASE
-		        WHEN $8 THEN NULL
+		        WHEN $8 OR lifecycle = 'draft' THEN NULL
 		        ELSE COALESCE($20, disable_reason)
 		    END,
 		    updated_at = $21
 		WHERE id = $1
-		  AND ($8 OR COALESCE($20, disable_reason) IS NOT NULL)
+		  AND NOT ($8 AND lifecycle = 'archived')
+		  AND ($8 OR lifecycle = 'draft' OR COALESCE($20, disable_reason) IS NOT NULL)
 	`
 
 	result, err := r.db.ExecContext(ctx,
@@ -494,11 +591,15 @@ func (r *SourceRepository) Update(ctx context.Context, source *models.Source) er
 	}
 
 	if rowsAffected == 0 {
-		exists, existsErr := r.sourceExists(ctx, source.ID)
-		if existsErr != nil {
-			return existsErr
-		}
-		if exists && !source.Enabled {
+		state, stateErr := r.sourceLifecycle(ctx, source.ID)
+		switch {
+		case stateErr != nil:
+			return stateErr
+		case state == "":
+			return ErrSourceNotFound
+		case source.Enabled && state == models.LifecycleArchived:
+			return ErrSourceArchived
+		case !source.Enabled:
 			return ErrDisableReasonRequired
 		}
 		return ErrSourceNotFound
@@ -507,6 +608,15 @@ func (r *SourceRepository) Update(ctx context.Context, source *models.Source) er
 	return nil
 }
 
+// updateLifecycleExpr is the lifecycle an update leaves a source in: enabling
+// makes it active and disabling an active source pauses it. Drafts, paused and
+// archived sources keep their state when they stay disabled.
+const updateLifecycleExpr = `(CASE
+		        WHEN $8 THEN 'active'
+		        WHEN lifecycle = 'active' THEN 'paused'
+		        ELSE lifecycle
+		    END)`
+
 func trimmedStringPtr(value *string) *string {
 	if value == nil {
 		return nil
@@ -518,14 +628,6 @@ func trimmedStringPtr(value *string) *string {
 	return &trimmed
 }
 
-func (r *SourceRepository) sourceExists(ctx context.Context, id string) (bool, error) {
-	var exists bool
-	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM sources WHERE id = $1)`, id).Scan(&exists); err != nil {
-		return false, fmt.Errorf("check source exists: %w", err)
-	}
-	return exists, nil
-}
-
 func (r *SourceRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM sources WHERE id = $1`
 
@@ -625,15 +727,22 @@ func (r *SourceRepository) UpsertSource(ctx context.Context, tx *sql.Tx, source
 			id, name, url, rate_limit, max_depth,
 			time, selectors, enabled,
 			feed_url, sitemap_url, ingestion_mode, feed_poll_interval_minutes,
-			render_mode, type, indigenous_region, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+			render_mode, type, indigenous_region, created_at, updated_at,
+			lifecycle, lifecycle_changed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+			CASE WHEN $8 THEN 'active' ELSE 'draft' END, $16)
 		ON CONFLICT (name) DO UPDATE SET
 			url = EXCLUDED.url,
 			rate_limit = EXCLUDED.rate_limit,
 			max_depth = EXCLUDED.max_depth,
 			time = EXCLUDED.time,
 			selectors = EXCLUDED.selectors,
-			enabled = EXCLUDED.enabled,
+			enabled = EXCLUDED.enabled AND sources.lifecycle <> 'archived',
+			lifecycle = ` + upsertLifecycleExpr + `,
+			lifecycle_changed_at = CASE
+				WHEN ` + upsertLifecycleExpr + ` = sources.lifecycle THEN sources.lifecycle_changed_at
+				ELSE NOW()
+			END,
 			feed_url = EXCLUDED.feed_url,
 			sitemap_url = EXCLUDED.sitemap_url,
 			ingestion_mode = EXCLUDED.ingestion_mode,
@@ -678,6 +787,15 @@ func (r *SourceRepository) UpsertSource(ctx context.Context, tx *sql.Tx, source
 	return isInsert, nil
 }
 
+// upsertLifecycleExpr is the lifecycle an import leaves an existing source in.
+// Imports never revive an archived source.
+const upsertLifecycleExpr = `(CASE
+				WHEN sources.lifecycle = 'archived' THEN 'archived'
+				WHEN EXCLUDED.enabled THEN 'active'
+				WHEN sources.lifecycle = 'active' THEN 'paused'
+				ELSE sources.lifecycle
+			END)`
+
 // DisableFeed marks a source's feed as disabled with a reason.
 func (r *SourceRepository) DisableFeed(ctx context.Context, id, reason string) error {
 	query := `
@@ -724,11 +842,15 @@ func (r *SourceRepository) EnableFeed(ctx context.Context, id string) error {
 	return nil
 }
 
-// DisableSource marks a source as disabled with a reason.
+// DisableSource marks a source as disabled with a reason. An active source is
+// paused; drafts and archived sources keep their state.
 func (r *SourceRepository) DisableSource(ctx context.Context, id, reason string) error {
 	query := `
 		UPDATE sources
-		SET disabled_at = NOW(), disable_reason = $2, updated_at = NOW()
+		SET disabled_at = NOW(), disable_reason = $2, enabled = false,
+		    lifecycle = CASE WHEN lifecycle = 'active' THEN 'paused' ELSE lifecycle END,
+		    lifecycle_changed_at = CASE WHEN lifecycle = 'active' THEN NOW() ELSE lifecycle_changed_at END,
+		    updated_at = NOW()
 		WHERE id = $1
 	`
 
@@ -747,12 +869,16 @@ func (r *SourceRepository) DisableSource(ctx context.Context, id, reason string)
 	return nil
 }
 
-// EnableSource clears a source's disabled state.
+// EnableSource clears a source's disabled state and makes it active. Archived
+// sources return ErrSourceArchived.
 func (r *SourceRepository) EnableSource(ctx context.Context, id string) error {
 	query := `
 		UPDATE sources
-		SET disabled_at = NULL, disable_reason = NULL, updated_at = NOW()
-		WHERE id = $1
+		SET disabled_at = NULL, disable_reason = NULL, enabled = true,
+		    lifecycle = 'active',
+		    lifecycle_changed_at = CASE WHEN lifecycle = 'active' THEN lifecycle_changed_at ELSE NOW() END,
+		    updated_at = NOW()
+		WHERE id = $1 AND lifecycle <> 'archived'
 	`
 
 	result, err := r.db.ExecContext(ctx, query, id)
@@ -765,6 +891,13 @@ func (r *SourceRepository) EnableSource(ctx context.Context, id string) error {
 		return fmt.Errorf("get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
+		state, stateErr := r.sourceLifecycle(ctx, id)
+		if stateErr != nil {
+			return stateErr
+		}
+		if state == models.LifecycleArchived {
+			return ErrSourceArchived
+		}
 		return ErrSourceNotFound
 	}
 	return nil
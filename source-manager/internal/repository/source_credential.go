@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+// ErrCredentialNotFound is returned when a source has no credential of the requested kind.
+var ErrCredentialNotFound = errors.New("source credential not found")
+
+// credentialColumns are the source_credentials columns scanCredentialRows reads.
+const credentialColumns = `id, source_id, kind, COALESCE(username, ''), COALESCE(header_name, ''),
+	ciphertext, wrapped_key, key_id, hint, created_by, created_at, updated_at, rotated_at`
+
+// SourceCredentialRepository provides persistence for the source_credentials table.
+// It stores values exactly as given; encryption is the credentials store's job.
+type SourceCredentialRepository struct {
+	db     *sql.DB
+	logger infralogger.Logger
+}
+
+// NewSourceCredentialRepository creates a new SourceCredentialRepository.
+func NewSourceCredentialRepository(db *sql.DB, log infralogger.Logger) *SourceCredentialRepository {
+	return &SourceCredentialRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Upsert stores cred, replacing the source's existing credential of the same
+// kind (which marks it rotated). It fills in the stored ID and timestamps, and
+// returns ErrSourceNotFound when the source does not exist.
+func (r *SourceCredentialRepository) Upsert(ctx context.Context, cred *models.SourceCredential) error {
+	query := `
+		INSERT INTO source_credentials
+			(id, source_id, kind, username, header_name, ciphertext, wrapped_key, key_id, hint, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9, $10)
+		ON CONFLICT (source_id, kind) DO UPDATE SET
+			username = EXCLUDED.username,
+			header_name = EXCLUDED.header_name,
+			ciphertext = EXCLUDED.ciphertext,
+			wrapped_key = EXCLUDED.wrapped_key,
+			key_id = EXCLUDED.key_id,
+			hint = EXCLUDED.hint,
+			updated_at = NOW(),
+			rotated_at = NOW()
+		RETURNING id, created_by, created_at, updated_at, rotated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		uuid.New().String(), cred.SourceID, cred.Kind, cred.Username, cred.HeaderName,
+		cred.Ciphertext, cred.WrappedKey, cred.KeyID, cred.Hint, cred.CreatedBy,
+	).Scan(&cred.ID, &cred.CreatedBy, &cred.CreatedAt, &cred.UpdatedAt, &cred.RotatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqForeignKeyViolation {
+			return ErrSourceNotFound
+		}
+		return fmt.Errorf("upsert source credential: %w", err)
+	}
+	return nil
+}
+
+// ListBySource returns a source's credentials ordered by kind.
+func (r *SourceCredentialRepository) ListBySource(
+	ctx context.Context, sourceID string,
+) ([]models.SourceCredential, error) {
+	query := `SELECT ` + credentialColumns + ` FROM source_credentials WHERE source_id = $1 ORDER BY kind`
+
+	rows, err := r.db.QueryContext(ctx, query, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("list source credentials: %w", err)
+	}
+	return scanCredentialRows(rows)
+}
+
+// ListAll returns every stored credential, for re-wrapping data keys.
+func (r *SourceCredentialRepository) ListAll(ctx context.Context) ([]models.SourceCredential, error) {
+	query := `SELECT ` + credentialColumns + ` FROM source_credentials ORDER BY source_id, kind`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list source credentials: %w", err)
+	}
+	return scanCredentialRows(rows)
+}
+
+// Delete removes a source's credential of kind.
+func (r *SourceCredentialRepository) Delete(ctx context.Context, sourceID, kind string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM source_credentials WHERE source_id = $1 AND kind = $2`, sourceID, kind)
+	if err != nil {
+		return fmt.Errorf("delete source credential: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrCredentialNotFound
+	}
+	return nil
+}
+
+// Rewrap replaces a credential's wrapped data key after a master key change.
+// The ciphertext is untouched.
+func (r *SourceCredentialRepository) Rewrap(ctx context.Context, id string, wrappedKey []byte, keyID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE source_credentials SET wrapped_key = $2, key_id = $3, updated_at = NOW() WHERE id = $1`,
+		id, wrappedKey, keyID)
+	if err != nil {
+		return fmt.Errorf("rewrap source credential: %w", err)
+	}
+	return nil
+}
+
+// scanCredentialRows reads credentialColumns rows and closes rows.
+func scanCredentialRows(rows *sql.Rows) ([]models.SourceCredential, error) {
+	defer func() { _ = rows.Close() }()
+
+	creds := make([]models.SourceCredential, 0)
+	for rows.Next() {
+		var cred models.SourceCredential
+		if err := rows.Scan(
+			&cred.ID, &cred.SourceID, &cred.Kind, &cred.Username, &cred.HeaderName,
+			&cred.Ciphertext, &cred.WrappedKey, &cred.KeyID, &cred.Hint,
+			&cred.CreatedBy, &cred.CreatedAt, &cred.UpdatedAt, &cred.RotatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan source credential: %w", err)
+		}
+		creds = append(creds, cred)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate source credentials: %w", err)
+	}
+	return creds, nil
+}
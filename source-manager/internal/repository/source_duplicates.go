@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+// ListURLs returns the ID, name, URL and lifecycle of every source, for
+// duplicate detection. Other fields are left empty.
+func (r *SourceRepository) ListURLs(ctx context.Context) ([]models.Source, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, url, lifecycle FROM sources ORDER BY created_at, name`)
+	if err != nil {
+		return nil, fmt.Errorf("query source urls: %w", err)
+	}
+	defer rows.Close()
+
+	sources := make([]models.Source, 0)
+	for rows.Next() {
+		var source models.Source
+		if scanErr := rows.Scan(&source.ID, &source.Name, &source.URL, &source.Lifecycle); scanErr != nil {
+			return nil, fmt.Errorf("scan source url: %w", scanErr)
+		}
+		sources = append(sources, source)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate source urls: %w", err)
+	}
+	return sources, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+// ErrLifecycleConflict is returned when a source's lifecycle changed between
+// reading it and applying a transition.
+var ErrLifecycleConflict = errors.New("source lifecycle changed concurrently")
+
+// SetLifecycle moves a source from one lifecycle state to another, keeping
+// enabled, disabled_at and disable_reason consistent with it: active clears
+// them, paused and archived record reason. The caller validates the transition;
+// it is applied only while the source is still in from.
+func (r *SourceRepository) SetLifecycle(ctx context.Context, id, from, to, reason string) error {
+	query := `
+		UPDATE sources
+		SET lifecycle = $3,
+		    enabled = ($3 = 'active'),
+		    lifecycle_changed_at = NOW(),
+		    disabled_at = CASE
+		        WHEN $3 IN ('paused', 'archived') THEN COALESCE(disabled_at, NOW())
+		        ELSE NULL
+		    END,
+		    disable_reason = CASE
+		        WHEN $3 IN ('paused', 'archived') THEN NULLIF($4, '')
+		        ELSE NULL
+		    END,
+		    updated_at = NOW()
+		WHERE id = $1 AND lifecycle = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, from, to, reason)
+	if err != nil {
+		return fmt.Errorf("set source lifecycle: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		state, stateErr := r.sourceLifecycle(ctx, id)
+		if stateErr != nil {
+			return stateErr
+		}
+		if state == "" {
+			return ErrSourceNotFound
+		}
+		return ErrLifecycleConflict
+	}
+	return nil
+}
+
+// ListByLifecycle returns the sources in a lifecycle state, longest in it first.
+func (r *SourceRepository) ListByLifecycle(ctx context.Context, state string) ([]models.Source, error) {
+	query := `
+		SELECT id, name, url, rate_limit, max_depth,
+		       time, selectors, enabled,
+		       feed_url, sitemap_url, ingestion_mode, feed_poll_interval_minutes,
+		       feed_disabled_at, feed_disable_reason,
+		       allow_source_discovery, identity_key, extraction_profile, template_hint,
+		       render_mode, type, indigenous_region,
+		       disabled_at, disable_reason,
+		       lifecycle, lifecycle_changed_at,
+		       created_at, updated_at
+		FROM sources
+		WHERE lifecycle = $1
+		ORDER BY lifecycle_changed_at ASC NULLS FIRST, name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, state)
+	if err != nil {
+		return nil, fmt.Errorf("query sources by lifecycle: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSourceRows(rows)
+}
+
+// sourceLifecycle returns a source's lifecycle state, or "" when the source does not exist.
+func (r *SourceRepository) sourceLifecycle(ctx context.Context, id string) (string, error) {
+	var state string
+	err := r.db.QueryRowContext(ctx, `SELECT lifecycle FROM sources WHERE id = $1`, id).Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get source lifecycle: %w", err)
+	}
+	return state, nil
+}
@@ -22,6 +22,7 @@ func sourceListColumns() []string {
 		"allow_source_discovery", "identity_key", "extraction_profile", "template_hint",
 		"render_mode", "type", "indigenous_region",
 		"disabled_at", "disable_reason",
+		"lifecycle", "lifecycle_changed_at",
 		"created_at", "updated_at",
 	}
 }
@@ -39,6 +40,7 @@ func addSourceRow(rows *sqlmock.Rows, id, name string) {
 		false, nil, nil, nil,
 		"static", "news", nil,
 		nil, nil,
+		"active", nil,
 		now, now,
 	)
 }
@@ -76,6 +76,7 @@ func TestSourceRepository_Create_Mock(t *testing.T) {
 			sqlmock.AnyArg(), // indigenous_region
 			sqlmock.AnyArg(), // created_at
 			sqlmock.AnyArg(), // updated_at
+			models.LifecycleActive,
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -83,6 +84,7 @@ func TestSourceRepository_Create_Mock(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, source.ID)
 	assert.False(t, source.CreatedAt.IsZero())
+	assert.Equal(t, models.LifecycleActive, source.Lifecycle)
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
@@ -108,6 +110,7 @@ func TestSourceRepository_GetByID_Mock(t *testing.T) {
 				"allow_source_discovery", "identity_key", "extraction_profile", "template_hint",
 				"render_mode", "type", "indigenous_region",
 				"disabled_at", "disable_reason",
+				"lifecycle", "lifecycle_changed_at",
 				"created_at", "updated_at",
 			}).AddRow(
 				"test-id", "Test Source", "https://example.com", "1s", 2,
@@ -117,6 +120,7 @@ func TestSourceRepository_GetByID_Mock(t *testing.T) {
 				false, nil, nil, nil,
 				"static", "news", nil,
 				nil, nil,
+				"active", nil,
 				now, now,
 			),
 		)
@@ -213,9 +217,9 @@ func TestSourceRepository_Update_NotFound_Mock(t *testing.T) {
 			sqlmock.AnyArg(), // updated_at
 		).
 		WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM sources WHERE id = $1)")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT lifecycle FROM sources WHERE id = $1")).
 		WithArgs("missing-id").
-		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		WillReturnRows(sqlmock.NewRows([]string{"lifecycle"}))
 
 	err := repo.Update(ctx, source)
 	require.ErrorIs(t, err, ErrSourceNotFound)
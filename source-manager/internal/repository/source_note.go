@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+// pqForeignKeyViolation is PostgreSQL error code 23503 (foreign_key_violation).
+const pqForeignKeyViolation = "23503"
+
+// ErrNoteNotFound is returned when a note operation targets a non-existent note.
+var ErrNoteNotFound = errors.New("source note not found")
+
+// SourceNoteRepository provides persistence for the source_notes table.
+type SourceNoteRepository struct {
+	db     *sql.DB
+	logger infralogger.Logger
+}
+
+// NewSourceNoteRepository creates a new SourceNoteRepository.
+func NewSourceNoteRepository(db *sql.DB, log infralogger.Logger) *SourceNoteRepository {
+	return &SourceNoteRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Create inserts a note. It returns false without an error when a note with the
+// same dedupe key already exists on the source, and ErrSourceNotFound when the
+// source does not exist.
+func (r *SourceNoteRepository) Create(ctx context.Context, note *models.SourceNote) (bool, error) {
+	note.ID = uuid.New().String()
+	note.CreatedAt = time.Now()
+
+	var details any
+	if len(note.Details) > 0 {
+		details = []byte(note.Details)
+	}
+
+	query := `
+		INSERT INTO source_notes (id, source_id, kind, author, body, details, dedupe_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (source_id, dedupe_key) WHERE dedupe_key IS NOT NULL DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		note.ID, note.SourceID, note.Kind, note.Author, note.Body,
+		details, note.DedupeKey, note.CreatedAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqForeignKeyViolation {
+			return false, ErrSourceNotFound
+		}
+		return false, fmt.Errorf("create source note: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ListBySource returns a source's notes, newest first. Resolved notes are
+// included only when includeResolved is true.
+func (r *SourceNoteRepository) ListBySource(
+	ctx context.Context, sourceID string, includeResolved bool,
+) ([]models.SourceNote, error) {
+	query := `
+		SELECT id, source_id, kind, author, body, details, dedupe_key, created_at, resolved_at
+		FROM source_notes
+		WHERE source_id = $1 AND ($2 OR resolved_at IS NULL)
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, sourceID, includeResolved)
+	if err != nil {
+		return nil, fmt.Errorf("list source notes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	notes := make([]models.SourceNote, 0)
+	for rows.Next() {
+		var (
+			note    models.SourceNote
+			details []byte
+		)
+		if scanErr := rows.Scan(
+			&note.ID, &note.SourceID, &note.Kind, &note.Author, &note.Body,
+			&details, &note.DedupeKey, &note.CreatedAt, &note.ResolvedAt,
+		); scanErr != nil {
+			return nil, fmt.Errorf("scan source note: %w", scanErr)
+		}
+		if len(details) > 0 {
+			note.Details = details
+		}
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate source notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// Resolve marks a note as resolved.
+func (r *SourceNoteRepository) Resolve(ctx context.Context, sourceID, noteID string) error {
+	query := `
+		UPDATE source_notes
+		SET resolved_at = NOW()
+		WHERE id = $1 AND source_id = $2 AND resolved_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, noteID, sourceID)
+	if err != nil {
+		return fmt.Errorf("resolve source note: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNoteNotFound
+	}
+	return nil
+}
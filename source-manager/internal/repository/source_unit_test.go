@@ -2,6 +2,7 @@
 package repository
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
@@ -70,6 +71,44 @@ func TestBuildListWhere_IndigenousOnlyFilter(t *testing.T) {
 	assert.Empty(t, args)
 }
 
+func TestBuildListWhere_SearchTerms(t *testing.T) {
+	t.Helper()
+	filter := ListFilter{Search: "cbc  100%_news"}
+
+	whereClause, args := buildListWhere(filter)
+	assert.Contains(t, whereClause, "name ILIKE $1 OR url ILIKE $1")
+	assert.Contains(t, whereClause, "name ILIKE $2 OR url ILIKE $2")
+	assert.Equal(t, []any{"%cbc%", `%100\%\_news%`}, args, "each term must match and wildcards match literally")
+}
+
+func TestBuildListWhere_TypeRegionDomainFilters(t *testing.T) {
+	t.Helper()
+	filter := ListFilter{Type: "news", Region: "canada", Domain: "cbc.ca"}
+
+	whereClause, args := buildListWhere(filter)
+	assert.Contains(t, whereClause, "type = $1")
+	assert.Contains(t, whereClause, "indigenous_region = $2")
+	assert.Contains(t, whereClause, "url ~* $3")
+	require.Len(t, args, 3)
+	assert.Equal(t, domainURLPattern("cbc.ca"), args[2])
+}
+
+func TestDomainURLPattern(t *testing.T) {
+	t.Helper()
+	pattern := regexp.MustCompile("(?i)" + domainURLPattern("cbc.ca"))
+
+	for _, u := range []string{
+		"https://cbc.ca", "https://www.cbc.ca/news", "http://news.CBC.ca:8080/x", "https://cbc.ca?page=1",
+	} {
+		assert.True(t, pattern.MatchString(u), u)
+	}
+	for _, u := range []string{
+		"https://notcbc.ca/", "https://cbcxca/", "https://cbc.ca.evil.com/", "https://example.com/cbc.ca",
+	} {
+		assert.False(t, pattern.MatchString(u), u)
+	}
+}
+
 func TestBuildListWhere_CombinedFilters(t *testing.T) {
 	t.Helper()
 	enabled := true
@@ -91,7 +130,7 @@ func TestBuildListOrder_DefaultValues(t *testing.T) {
 	filter := ListFilter{}
 
 	orderClause := buildListOrder(filter)
-	assert.Equal(t, " ORDER BY name ASC", orderClause)
+	assert.Equal(t, " ORDER BY name ASC, id", orderClause)
 }
 
 func TestBuildListOrder_ValidSortBy(t *testing.T) {
@@ -102,10 +141,10 @@ func TestBuildListOrder_ValidSortBy(t *testing.T) {
 		sortOrder string
 		expected  string
 	}{
-		{"sort by name asc", "name", "asc", " ORDER BY name ASC"},
-		{"sort by url desc", "url", "desc", " ORDER BY url DESC"},
-		{"sort by enabled asc", "enabled", "asc", " ORDER BY enabled ASC"},
-		{"sort by created_at desc", "created_at", "desc", " ORDER BY created_at DESC"},
+		{"sort by name asc", "name", "asc", " ORDER BY name ASC, id"},
+		{"sort by url desc", "url", "desc", " ORDER BY url DESC, id"},
+		{"sort by enabled asc", "enabled", "asc", " ORDER BY enabled ASC, id"},
+		{"sort by created_at desc", "created_at", "desc", " ORDER BY created_at DESC, id"},
 	}
 
 	for _, tt := range tests {
@@ -121,7 +160,7 @@ func TestBuildListOrder_InvalidSortBy(t *testing.T) {
 	filter := ListFilter{SortBy: "DROP TABLE sources;", SortOrder: "asc"}
 
 	orderClause := buildListOrder(filter)
-	assert.Equal(t, " ORDER BY name ASC", orderClause)
+	assert.Equal(t, " ORDER BY name ASC, id", orderClause)
 }
 
 func TestBuildListOrder_InvalidSortOrder(t *testing.T) {
@@ -129,7 +168,7 @@ func TestBuildListOrder_InvalidSortOrder(t *testing.T) {
 	filter := ListFilter{SortBy: "name", SortOrder: "invalid"}
 
 	orderClause := buildListOrder(filter)
-	assert.Equal(t, " ORDER BY name ASC", orderClause)
+	assert.Equal(t, " ORDER BY name ASC, id", orderClause)
 }
 
 func TestDeriveClassifiedContentIndex(t *testing.T) {
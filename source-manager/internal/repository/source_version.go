@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+// baselineVersionAuthor is the author of a baseline version: the change that
+// produced the state was not recorded.
+const baselineVersionAuthor = "system"
+
+// ErrVersionNotFound is returned when a source has no version with the given number.
+var ErrVersionNotFound = errors.New("source version not found")
+
+// SourceVersionRepository provides persistence for the source_versions table.
+type SourceVersionRepository struct {
+	db     *sql.DB
+	logger infralogger.Logger
+}
+
+// NewSourceVersionRepository creates a new SourceVersionRepository.
+func NewSourceVersionRepository(db *sql.DB, log infralogger.Logger) *SourceVersionRepository {
+	return &SourceVersionRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Record stores after as the source's next version, with its diff from before.
+// When before is set and differs from the latest version (the source predates
+// version history, or changed through an import or the enable/disable
+// endpoints), before is stored first as a baseline, so the pre-change state can
+// always be restored. It returns nil without an error when nothing changed, and
+// ErrSourceNotFound when the source does not exist.
+func (r *SourceVersionRepository) Record(
+	ctx context.Context, before, after *models.Source, action, author string,
+) (*models.SourceVersion, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Locking the source row serializes version numbering per source.
+	var sourceID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM sources WHERE id = $1 FOR UPDATE`, after.ID).Scan(&sourceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock source: %w", err)
+	}
+
+	latest, previous, err := latestSnapshot(ctx, tx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if before != nil {
+		drift, diffErr := models.DiffSources(previous, before)
+		if diffErr != nil {
+			return nil, diffErr
+		}
+		if len(drift) > 0 {
+			latest++
+			if _, err = insertVersion(ctx, tx, before, latest, models.VersionActionBaseline, baselineVersionAuthor, drift); err != nil {
+				return nil, err
+			}
+		}
+		previous = before
+	}
+
+	changes, err := models.DiffSources(previous, after)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("commit transaction: %w", err)
+		}
+		return nil, nil //nolint:nilnil // nothing changed, so no version
+	}
+
+	version, err := insertVersion(ctx, tx, after, latest+1, action, author, changes)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return version, nil
+}
+
+// latestSnapshot returns the number and source of a source's latest version,
+// or 0 and nil when it has none.
+func latestSnapshot(ctx context.Context, tx *sql.Tx, sourceID string) (int, *models.Source, error) {
+	var (
+		version  int
+		snapshot []byte
+	)
+	err := tx.QueryRowContext(ctx, `
+		SELECT version, snapshot FROM source_versions
+		WHERE source_id = $1
+		ORDER BY version DESC
+		LIMIT 1
+	`, sourceID).Scan(&version, &snapshot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("query latest source version: %w", err)
+	}
+
+	var source models.Source
+	if err = json.Unmarshal(snapshot, &source); err != nil {
+		return 0, nil, fmt.Errorf("unmarshal source snapshot: %w", err)
+	}
+	return version, &source, nil
+}
+
+// insertVersion stores source as version number of its history.
+func insertVersion(
+	ctx context.Context, tx *sql.Tx, source *models.Source, number int, action, author string, changes []models.FieldChange,
+) (*models.SourceVersion, error) {
+	snapshot, err := json.Marshal(source)
+	if err != nil {
+		return nil, fmt.Errorf("marshal source snapshot: %w", err)
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("marshal source changes: %w", err)
+	}
+
+	version := &models.SourceVersion{
+		ID:        uuid.New().String(),
+		SourceID:  source.ID,
+		Version:   number,
+		Action:    action,
+		Author:    author,
+		Changes:   changes,
+		Snapshot:  snapshot,
+		CreatedAt: time.Now(),
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO source_versions (id, source_id, version, action, author, changes, snapshot, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		version.ID, version.SourceID, version.Version, version.Action, version.Author,
+		changesJSON, snapshot, version.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert source version: %w", err)
+	}
+	return version, nil
+}
+
+// ListBySource returns a source's versions, newest first, without snapshots.
+func (r *SourceVersionRepository) ListBySource(ctx context.Context, sourceID string) ([]models.SourceVersion, error) {
+	query := `
+		SELECT id, source_id, version, action, author, changes, created_at
+		FROM source_versions
+		WHERE source_id = $1
+		ORDER BY version DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("list source versions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	versions := make([]models.SourceVersion, 0)
+	for rows.Next() {
+		var (
+			version models.SourceVersion
+			changes []byte
+		)
+		if scanErr := rows.Scan(
+			&version.ID, &version.SourceID, &version.Version, &version.Action,
+			&version.Author, &changes, &version.CreatedAt,
+		); scanErr != nil {
+			return nil, fmt.Errorf("scan source version: %w", scanErr)
+		}
+		if unmarshalErr := json.Unmarshal(changes, &version.Changes); unmarshalErr != nil {
+			return nil, fmt.Errorf("unmarshal source changes: %w", unmarshalErr)
+		}
+		versions = append(versions, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate source versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// Get returns one version of a source with its snapshot.
+func (r *SourceVersionRepository) Get(ctx context.Context, sourceID string, number int) (*models.SourceVersion, error) {
+	query := `
+		SELECT id, source_id, version, action, author, changes, snapshot, created_at
+		FROM source_versions
+		WHERE source_id = $1 AND version = $2
+	`
+
+	var (
+		version  models.SourceVersion
+		changes  []byte
+		snapshot []byte
+	)
+	err := r.db.QueryRowContext(ctx, query, sourceID, number).Scan(
+		&version.ID, &version.SourceID, &version.Version, &version.Action,
+		&version.Author, &changes, &snapshot, &version.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query source version: %w", err)
+	}
+
+	if err = json.Unmarshal(changes, &version.Changes); err != nil {
+		return nil, fmt.Errorf("unmarshal source changes: %w", err)
+	}
+	version.Snapshot = snapshot
+	return &version, nil
+}
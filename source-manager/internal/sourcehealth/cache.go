@@ -0,0 +1,211 @@
+// Package sourcehealth keeps a periodically refreshed copy of every source's
+// crawl state (from the crawler) and document counts (from index-manager), so
+// source listings show end-to-end health without calling either service per request.
+package sourcehealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/naming"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/config"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+const (
+	// crawlHealthPath is the crawler's per-source job and execution summary.
+	crawlHealthPath = "/api/v1/jobs/source-health"
+	// indexHealthPath is index-manager's per-source document counts.
+	indexHealthPath = "/api/v1/aggregations/source-health"
+	// serviceSubject identifies source-manager in the service token.
+	serviceSubject = "source-manager"
+)
+
+// Health is a source's end-to-end health. Crawl is nil when the crawler has no
+// job for the source; Index is nil when index-manager has no documents for it.
+type Health struct {
+	Crawl *CrawlHealth `json:"crawl"`
+	Index *IndexHealth `json:"index"`
+	// RefreshedAt is when the older of the two summaries was fetched.
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+// CrawlHealth is the state of a source's current crawl job.
+type CrawlHealth struct {
+	JobID         string     `json:"job_id"`
+	JobStatus     string     `json:"job_status"`
+	IsPaused      bool       `json:"is_paused"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	FailureCount  int        `json:"failure_count"`
+}
+
+// IndexHealth is a source's document counts: Backlog is raw content not yet classified.
+type IndexHealth struct {
+	RawCount        int64   `json:"raw_count"`
+	ClassifiedCount int64   `json:"classified_count"`
+	Backlog         int64   `json:"backlog"`
+	Delta24h        int64   `json:"delta_24h"`
+	AvgQuality      float64 `json:"avg_quality"`
+}
+
+// Cache holds the latest crawl and index summaries. Each is refreshed on its
+// own, and a failed refresh keeps the previous copy, so an outage of either
+// service serves stale rather than no data.
+type Cache struct {
+	service  *infrahttp.ServiceClient
+	crawlURL string
+	indexURL string
+	interval time.Duration
+	logger   infralogger.Logger
+
+	mu            sync.RWMutex
+	crawl         map[string]*CrawlHealth // by source ID
+	index         map[string]*IndexHealth // by index prefix (sanitized source name)
+	crawlLoadedAt time.Time
+	indexLoadedAt time.Time
+}
+
+// NewCache creates a cache reading from the services in cfg, authenticating with
+// service tokens signed by jwtSecret. A service with an empty URL is skipped.
+// Call Run to load it.
+func NewCache(cfg config.SourceHealthConfig, jwtSecret string, log infralogger.Logger) *Cache {
+	c := &Cache{
+		service: infrahttp.NewServiceClient(infrahttp.ServiceClientConfig{
+			Timeout:   cfg.Timeout,
+			JWTSecret: jwtSecret,
+			Subject:   serviceSubject,
+		}),
+		interval: cfg.RefreshInterval,
+		logger:   log,
+	}
+	if cfg.CrawlerURL != "" {
+		c.crawlURL = strings.TrimRight(cfg.CrawlerURL, "/") + crawlHealthPath
+	}
+	if cfg.IndexManagerURL != "" {
+		c.indexURL = strings.TrimRight(cfg.IndexManagerURL, "/") + indexHealthPath
+	}
+	return c
+}
+
+// Run loads both summaries immediately, then refreshes them every interval until ctx is done.
+func (c *Cache) Run(ctx context.Context) {
+	c.refreshAndLog(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refreshAndLog(ctx)
+		}
+	}
+}
+
+// Lookup returns the health of source, or nil when neither summary has loaded yet.
+func (c *Cache) Lookup(source *models.Source) *Health {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.crawlLoadedAt.IsZero() && c.indexLoadedAt.IsZero() {
+		return nil
+	}
+
+	health := &Health{
+		Crawl:       c.crawl[source.ID],
+		Index:       c.index[naming.SanitizeSourceName(source.Name)],
+		RefreshedAt: c.crawlLoadedAt,
+	}
+	if c.crawlLoadedAt.IsZero() || (!c.indexLoadedAt.IsZero() && c.indexLoadedAt.Before(c.crawlLoadedAt)) {
+		health.RefreshedAt = c.indexLoadedAt
+	}
+	return health
+}
+
+// Refresh fetches both summaries and replaces the cached copies that were fetched.
+func (c *Cache) Refresh(ctx context.Context) error {
+	var errs []error
+
+	if c.crawlURL != "" {
+		if err := c.refreshCrawl(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.indexURL != "" {
+		if err := c.refreshIndex(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// refreshCrawl replaces the crawl summary with the crawler's.
+func (c *Cache) refreshCrawl(ctx context.Context) error {
+	var body struct {
+		Sources []struct {
+			SourceID string `json:"source_id"`
+			CrawlHealth
+		} `json:"sources"`
+	}
+	if err := c.service.Do(ctx, http.MethodGet, c.crawlURL, nil, &body); err != nil {
+		return fmt.Errorf("crawler: %w", err)
+	}
+
+	crawl := make(map[string]*CrawlHealth, len(body.Sources))
+	for i := range body.Sources {
+		crawl[body.Sources[i].SourceID] = &body.Sources[i].CrawlHealth
+	}
+
+	c.mu.Lock()
+	c.crawl = crawl
+	c.crawlLoadedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// refreshIndex replaces the index summary with index-manager's.
+func (c *Cache) refreshIndex(ctx context.Context) error {
+	var body struct {
+		Sources []struct {
+			Source string `json:"source"`
+			IndexHealth
+		} `json:"sources"`
+	}
+	if err := c.service.Do(ctx, http.MethodGet, c.indexURL, nil, &body); err != nil {
+		return fmt.Errorf("index-manager: %w", err)
+	}
+
+	index := make(map[string]*IndexHealth, len(body.Sources))
+	for i := range body.Sources {
+		index[body.Sources[i].Source] = &body.Sources[i].IndexHealth
+	}
+
+	c.mu.Lock()
+	c.index = index
+	c.indexLoadedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// refreshAndLog refreshes the cache, logging failures instead of returning them.
+func (c *Cache) refreshAndLog(ctx context.Context) {
+	if err := c.Refresh(ctx); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		c.logger.Warn("Source health refresh failed, keeping previous data", infralogger.Error(err))
+		return
+	}
+	c.logger.Debug("Source health refreshed")
+}
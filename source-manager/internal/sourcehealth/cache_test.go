@@ -0,0 +1,128 @@
+package sourcehealth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/config"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/sourcehealth"
+)
+
+const (
+	crawlBody = `{"sources":[{"source_id":"src-1","job_id":"job-1","job_status":"scheduled",` +
+		`"is_paused":false,"last_success_at":"2026-10-15T09:00:00Z","failure_count":0}],"total":1}`
+	indexBody = `{"sources":[{"source":"example_news","raw_count":120,"classified_count":100,` +
+		`"backlog":20,"delta_24h":15,"avg_quality":61.5}],"total":1}`
+)
+
+// newHealthServer serves the crawler and index-manager health endpoints, failing
+// the index-manager one once failIndexAfter calls have been served.
+func newHealthServer(t *testing.T, failIndexAfter int32) *httptest.Server {
+	t.Helper()
+
+	var indexCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			http.Error(w, "missing token", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/jobs/source-health":
+			_, _ = w.Write([]byte(crawlBody))
+		case "/api/v1/aggregations/source-health":
+			if indexCalls.Add(1) > failIndexAfter {
+				http.Error(w, "elasticsearch down", http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(indexBody))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestCache(crawlerURL, indexManagerURL string) *sourcehealth.Cache {
+	return sourcehealth.NewCache(config.SourceHealthConfig{
+		CrawlerURL:      crawlerURL,
+		IndexManagerURL: indexManagerURL,
+		RefreshInterval: time.Minute,
+		Timeout:         time.Second,
+	}, "test-secret", infralogger.NewNop())
+}
+
+func TestCache_Lookup(t *testing.T) {
+	t.Parallel()
+
+	server := newHealthServer(t, 1)
+	cache := newTestCache(server.URL+"/", server.URL)
+	source := &models.Source{ID: "src-1", Name: "Example News"}
+
+	if health := cache.Lookup(source); health != nil {
+		t.Fatalf("expected no health before the first refresh, got %+v", health)
+	}
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	health := cache.Lookup(source)
+	if health == nil || health.Crawl == nil || health.Index == nil {
+		t.Fatalf("expected crawl and index health, got %+v", health)
+	}
+	if health.Crawl.JobStatus != "scheduled" || health.Crawl.LastSuccessAt == nil {
+		t.Errorf("unexpected crawl health %+v", health.Crawl)
+	}
+	if health.Index.RawCount != 120 || health.Index.ClassifiedCount != 100 || health.Index.Backlog != 20 {
+		t.Errorf("unexpected index health %+v", health.Index)
+	}
+
+	other := cache.Lookup(&models.Source{ID: "src-2", Name: "Other"})
+	if other == nil || other.Crawl != nil || other.Index != nil {
+		t.Errorf("expected empty health for an unknown source, got %+v", other)
+	}
+}
+
+func TestCache_FailedRefreshKeepsData(t *testing.T) {
+	t.Parallel()
+
+	server := newHealthServer(t, 1)
+	cache := newTestCache(server.URL, server.URL)
+	source := &models.Source{ID: "src-1", Name: "Example News"}
+
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("first Refresh() error = %v", err)
+	}
+	err := cache.Refresh(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "index-manager") {
+		t.Fatalf("expected index-manager error, got %v", err)
+	}
+
+	health := cache.Lookup(source)
+	if health == nil || health.Index == nil || health.Index.Backlog != 20 {
+		t.Errorf("expected previous index health to be kept, got %+v", health)
+	}
+}
+
+func TestCache_SkipsUnconfiguredService(t *testing.T) {
+	t.Parallel()
+
+	server := newHealthServer(t, 0)
+	cache := newTestCache(server.URL, "")
+
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	health := cache.Lookup(&models.Source{ID: "src-1", Name: "Example News"})
+	if health == nil || health.Crawl == nil || health.Index != nil {
+		t.Errorf("expected crawl health only, got %+v", health)
+	}
+}
@@ -54,11 +54,34 @@ type Extractor struct {
 	httpFetcher *httpFetcher
 }
 
+// Option configures an Extractor.
+type Option func(*extractorOptions)
+
+// extractorOptions collects Option values before the HTTP client is built.
+type extractorOptions struct {
+	proxy              *url.URL
+	insecureSkipVerify bool
+}
+
+// WithProxy fetches every page through the HTTP proxy at proxyURL (e.g. nc-http-proxy,
+// so test crawls replay recorded fixtures). insecureSkipVerify accepts the proxy's
+// re-signed HTTPS certificates. A nil proxyURL leaves fetches direct.
+func WithProxy(proxyURL *url.URL, insecureSkipVerify bool) Option {
+	return func(o *extractorOptions) {
+		o.proxy = proxyURL
+		o.insecureSkipVerify = insecureSkipVerify
+	}
+}
+
 // NewExtractor creates a new testcrawl Extractor with an SSRF-safe HTTP client.
-func NewExtractor(log infralogger.Logger) *Extractor {
+func NewExtractor(log infralogger.Logger, opts ...Option) *Extractor {
+	var options extractorOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	return &Extractor{
 		logger:      log,
-		httpFetcher: newHTTPFetcher(),
+		httpFetcher: newHTTPFetcher(options.proxy, options.insecureSkipVerify),
 	}
 }
 
@@ -69,32 +92,10 @@ func (e *Extractor) Extract(
 	rawURL string,
 	sourceTitle, sourceBody, sourceContainer string,
 ) (*ExtractionResult, error) {
-	// 1. URL pre-filter.
-	filterResult := FilterURL(rawURL)
-	if !filterResult.Allowed {
-		return nil, fmt.Errorf("URL rejected by pre-filter: %s", filterResult.Reason)
-	}
-
-	// 2. Validate URL scheme and block dangerous hosts (reuses metadata package helper).
-	if err := validateURL(rawURL); err != nil {
-		return nil, fmt.Errorf("URL validation failed: %w", err)
-	}
-
-	// 3. Fetch the page.
-	body, err := e.httpFetcher.Fetch(ctx, rawURL)
+	// 1-4. Pre-filter, validate, fetch and parse the page.
+	doc, body, err := e.fetchPage(ctx, rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
-	}
-
-	e.logger.Info("Fetched URL for test-crawl extraction",
-		infralogger.String("url", rawURL),
-		infralogger.Int("body_bytes", len(body)),
-	)
-
-	// 4. Parse HTML.
-	doc, parseErr := goquery.NewDocumentFromReader(strings.NewReader(body))
-	if parseErr != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", parseErr)
+		return nil, err
 	}
 
 	// 5. Detect CMS template.
@@ -145,6 +146,35 @@ func (e *Extractor) Extract(
 	}, nil
 }
 
+// fetchPage runs the URL pre-filter and scheme/host validation, fetches the page,
+// and parses it.
+func (e *Extractor) fetchPage(ctx context.Context, rawURL string) (*goquery.Document, string, error) {
+	filterResult := FilterURL(rawURL)
+	if !filterResult.Allowed {
+		return nil, "", fmt.Errorf("URL rejected by pre-filter: %s", filterResult.Reason)
+	}
+
+	if err := validateURL(rawURL); err != nil {
+		return nil, "", fmt.Errorf("URL validation failed: %w", err)
+	}
+
+	body, err := e.httpFetcher.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch URL: %w", err)
+	}
+
+	e.logger.Info("Fetched URL for test-crawl extraction",
+		infralogger.String("url", rawURL),
+		infralogger.Int("body_bytes", len(body)),
+	)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+	return doc, body, nil
+}
+
 // runExtractionPipeline tries selectors in priority order and returns:
 // selectorsTried (comma-separated), matchedSelector (label), rawText.
 func runExtractionPipeline(
@@ -2,10 +2,12 @@ package testcrawl
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -27,7 +29,10 @@ type httpFetcher struct {
 }
 
 // newHTTPFetcher creates an httpFetcher with SSRF protection (blocks private IPs at dial time).
-func newHTTPFetcher() *httpFetcher {
+// With a proxy, every request goes through it (e.g. nc-http-proxy): the proxy is trusted, so
+// only its own address may be private, and insecureSkipVerify accepts the certificates it
+// re-signs HTTPS with.
+func newHTTPFetcher(proxy *url.URL, insecureSkipVerify bool) *httpFetcher {
 	transport := &http.Transport{
 		DialContext:           safeDialContext,
 		ForceAttemptHTTP2:     true,
@@ -36,6 +41,12 @@ func newHTTPFetcher() *httpFetcher {
 		TLSHandshakeTimeout:   httpDialTimeout,
 		ExpectContinueTimeout: 1 * time.Second,
 	}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+		transport.DialContext = proxyDialContext(proxyAddress(proxy))
+		//nolint:gosec // G402: opt-in for a local MITM proxy such as nc-http-proxy
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	}
 	client := &http.Client{
 		Transport: transport,
 		Timeout:   httpFetchTimeout,
@@ -114,6 +125,28 @@ func safeDialContext(ctx context.Context, network, addr string) (net.Conn, error
 	return dialer.DialContext(ctx, network, target)
 }
 
+// proxyDialContext dials proxyAddr directly, and any other address through safeDialContext.
+func proxyDialContext(proxyAddr string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: httpDialTimeout}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == proxyAddr {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		return safeDialContext(ctx, network, addr)
+	}
+}
+
+// proxyAddress returns the host:port a proxy URL dials.
+func proxyAddress(proxy *url.URL) string {
+	if port := proxy.Port(); port != "" {
+		return net.JoinHostPort(proxy.Hostname(), port)
+	}
+	if proxy.Scheme == "https" {
+		return net.JoinHostPort(proxy.Hostname(), "443")
+	}
+	return net.JoinHostPort(proxy.Hostname(), "80")
+}
+
 // isPrivateOrReservedIP returns true for IPs that should never be dialled from a web service.
 func isPrivateOrReservedIP(ip net.IP) bool {
 	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
@@ -0,0 +1,509 @@
+package testcrawl
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// Where a suggested selector came from.
+const (
+	SuggestionSourceDOM      = "dom"
+	SuggestionSourceMeta     = "meta"
+	SuggestionSourceTemplate = "template"
+	SuggestionSourceDensity  = "density"
+)
+
+// How far a suggestion can be trusted.
+const (
+	// ConfidenceHigh means the element agrees with the page's JSON-LD or OpenGraph metadata.
+	ConfidenceHigh = "high"
+	// ConfidenceMedium means a common selector matched, with nothing to confirm it.
+	ConfidenceMedium = "medium"
+	// ConfidenceLow means only a fallback matched; check the sample before saving.
+	ConfidenceLow = "low"
+)
+
+// minSuggestedBodyWords is the paragraph word count below which a body suggestion is low confidence.
+const minSuggestedBodyWords = 50
+
+// bodyCoverage is the share of the densest paragraph block a known body selector
+// must cover to be preferred over the density-derived selector.
+const bodyCoverage = 0.8
+
+// isoDateLen is the length of a YYYY-MM-DD date prefix.
+const isoDateLen = 10
+
+// titleCandidates are tried in order for the article title.
+var titleCandidates = []string{
+	"h1[itemprop='headline']",
+	"[itemprop='headline']",
+	"h1.entry-title",
+	"h1.article-title",
+	"h1.headline",
+	"article h1",
+	"h1",
+}
+
+// bodyCandidates are tried in order for the article body. Broad containers
+// (article, main) are left to the density heuristic, which finds the block inside.
+var bodyCandidates = []string{
+	"[itemprop='articleBody']",
+	".article-body",
+	".entry-content",
+	".post-content",
+	".article-content",
+	".story-body",
+}
+
+// dateCandidates are tried in order for the published date.
+var dateCandidates = []string{
+	"meta[property='article:published_time']",
+	"[itemprop='datePublished']",
+	"article time[datetime]",
+	"time[datetime]",
+}
+
+// authorCandidates are tried in order for the author.
+var authorCandidates = []string{
+	"meta[name='author']",
+	"[itemprop='author']",
+	"[rel='author']",
+	".byline",
+	".author",
+}
+
+// cssIdentifier matches ids and classes usable in a selector without escaping.
+var cssIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// SelectorSuggestion is a proposed selector for one article field.
+type SelectorSuggestion struct {
+	Selector string `json:"selector"`
+	// Sample is what the selector extracts from the page.
+	Sample string `json:"sample"`
+	// Source is how the selector was found: dom, meta, template or density.
+	Source     string `json:"source"`
+	Confidence string `json:"confidence"`
+}
+
+// SuggestedFields are the proposed article selectors; a field is nil when nothing matched.
+type SuggestedFields struct {
+	Title         *SelectorSuggestion `json:"title,omitempty"`
+	Body          *SelectorSuggestion `json:"body,omitempty"`
+	Container     *SelectorSuggestion `json:"container,omitempty"`
+	Author        *SelectorSuggestion `json:"author,omitempty"`
+	PublishedTime *SelectorSuggestion `json:"published_time,omitempty"`
+}
+
+// StructuredData is the article metadata a page declares in JSON-LD and OpenGraph
+// tags, used to confirm what the DOM selectors extract.
+type StructuredData struct {
+	JSONLDType    string `json:"json_ld_type,omitempty"`
+	Headline      string `json:"headline,omitempty"`
+	Author        string `json:"author,omitempty"`
+	DatePublished string `json:"date_published,omitempty"`
+	OGType        string `json:"og_type,omitempty"`
+	OGTitle       string `json:"og_title,omitempty"`
+}
+
+// Suggestions are proposed selectors for an article page.
+type Suggestions struct {
+	URL            string          `json:"url"`
+	Template       string          `json:"template,omitempty"`
+	Fields         SuggestedFields `json:"fields"`
+	StructuredData StructuredData  `json:"structured_data"`
+	Warnings       []string        `json:"warnings"`
+}
+
+// Suggest fetches a sample article and proposes title, body, container, author
+// and date selectors. Each DOM match is checked against the page's JSON-LD and
+// OpenGraph metadata: agreement makes it high confidence. An error means the
+// page could not be fetched.
+func (e *Extractor) Suggest(ctx context.Context, articleURL string) (*Suggestions, error) {
+	doc, body, err := e.fetchPage(ctx, articleURL)
+	if err != nil {
+		return nil, err
+	}
+
+	templateName, templateSel := DetectTemplate(extractHostname(articleURL), body)
+	data := readStructuredData(doc)
+	result := &Suggestions{
+		URL:            articleURL,
+		Template:       templateName,
+		StructuredData: data,
+		Warnings:       make([]string, 0),
+	}
+
+	if data.JSONLDType == "" && data.OGType != "article" {
+		result.Warnings = append(result.Warnings,
+			"page declares no article JSON-LD or og:type article; suggest from an article page, not a listing")
+	}
+
+	result.Fields.Title = suggestTitle(doc, templateSel.Title, firstNonEmpty(data.Headline, data.OGTitle))
+	result.Fields.Body, result.Fields.Container = suggestBody(doc, templateSel)
+	result.Fields.PublishedTime = suggestDate(doc, data.DatePublished)
+	result.Fields.Author = suggestAuthor(doc, data.Author)
+
+	for _, field := range []struct {
+		name       string
+		suggestion *SelectorSuggestion
+	}{
+		{"title", result.Fields.Title},
+		{"body", result.Fields.Body},
+		{"published time", result.Fields.PublishedTime},
+		{"author", result.Fields.Author},
+	} {
+		if field.suggestion == nil {
+			result.Warnings = append(result.Warnings, "no "+field.name+" selector found")
+		}
+	}
+
+	e.logger.Info("Selectors suggested",
+		infralogger.String("url", articleURL),
+		infralogger.String("template", templateName),
+		infralogger.Int("warning_count", len(result.Warnings)),
+	)
+	return result, nil
+}
+
+// suggestTitle returns the first title candidate whose text matches the declared
+// headline, or else the first candidate with text.
+func suggestTitle(doc *goquery.Document, templateTitle, headline string) *SelectorSuggestion {
+	candidates := titleCandidates
+	if templateTitle != "" {
+		candidates = append([]string{templateTitle}, titleCandidates...)
+	}
+
+	var fallback *SelectorSuggestion
+	for i, selector := range candidates {
+		text := strings.TrimSpace(doc.Find(selector).First().Text())
+		if text == "" {
+			continue
+		}
+		source := SuggestionSourceDOM
+		if templateTitle != "" && i == 0 {
+			source = SuggestionSourceTemplate
+		}
+		if headline != "" && sameText(text, headline) {
+			return &SelectorSuggestion{Selector: selector, Sample: text, Source: source, Confidence: ConfidenceHigh}
+		}
+		if fallback == nil {
+			fallback = &SelectorSuggestion{Selector: selector, Sample: text, Source: source, Confidence: ConfidenceMedium}
+		}
+	}
+	return fallback
+}
+
+// suggestBody picks the body selector: a template or common selector when it
+// covers most of the page's densest paragraph block, otherwise a selector built
+// for that block. The container is the article element around the body.
+func suggestBody(doc *goquery.Document, templateSel ExtractionSelectors) (body, container *SelectorSuggestion) {
+	densest, densestWords := densestParagraphBlock(doc)
+
+	candidates := bodyCandidates
+	if templateSel.Body != "" {
+		candidates = append([]string{templateSel.Body}, bodyCandidates...)
+	}
+	for i, selector := range candidates {
+		node := doc.Find(selector).First()
+		words := paragraphWords(node)
+		if words == 0 || float64(words) < bodyCoverage*float64(densestWords) {
+			continue
+		}
+		source := SuggestionSourceDOM
+		if templateSel.Body != "" && i == 0 {
+			source = SuggestionSourceTemplate
+		}
+		body = &SelectorSuggestion{
+			Selector:   selector,
+			Sample:     paragraphText(node),
+			Source:     source,
+			Confidence: bodyConfidence(words, ConfidenceMedium),
+		}
+		if source == SuggestionSourceTemplate || strings.Contains(selector, "articleBody") {
+			body.Confidence = bodyConfidence(words, ConfidenceHigh)
+		}
+		return body, suggestContainer(doc, node)
+	}
+
+	if densest == nil {
+		return nil, nil
+	}
+	body = &SelectorSuggestion{
+		Selector:   selectorFor(doc, densest),
+		Sample:     paragraphText(densest),
+		Source:     SuggestionSourceDensity,
+		Confidence: bodyConfidence(densestWords, ConfidenceMedium),
+	}
+	return body, suggestContainer(doc, densest)
+}
+
+// bodyConfidence downgrades a body match with little text to low confidence.
+func bodyConfidence(words int, confidence string) string {
+	if words < minSuggestedBodyWords {
+		return ConfidenceLow
+	}
+	return confidence
+}
+
+// suggestContainer returns the article element enclosing the body, if any.
+func suggestContainer(doc *goquery.Document, body *goquery.Selection) *SelectorSuggestion {
+	article := body.Closest("article")
+	if article.Length() == 0 {
+		return nil
+	}
+	return &SelectorSuggestion{
+		Selector:   selectorFor(doc, article),
+		Sample:     truncate(strings.TrimSpace(article.Text())),
+		Source:     SuggestionSourceDOM,
+		Confidence: ConfidenceMedium,
+	}
+}
+
+// suggestDate returns the first date candidate with a value, high confidence
+// when it matches the declared publication date.
+func suggestDate(doc *goquery.Document, declared string) *SelectorSuggestion {
+	var fallback *SelectorSuggestion
+	for _, selector := range dateCandidates {
+		value := extractDate(doc.Find(selector).First())
+		if value == "" {
+			continue
+		}
+		source := SuggestionSourceDOM
+		if strings.HasPrefix(selector, "meta") {
+			source = SuggestionSourceMeta
+		}
+		if declared != "" && sameDate(value, declared) {
+			return &SelectorSuggestion{Selector: selector, Sample: value, Source: source, Confidence: ConfidenceHigh}
+		}
+		if fallback == nil {
+			fallback = &SelectorSuggestion{Selector: selector, Sample: value, Source: source, Confidence: ConfidenceMedium}
+		}
+	}
+	return fallback
+}
+
+// suggestAuthor returns the first author candidate with a value, high confidence
+// when it names the declared author.
+func suggestAuthor(doc *goquery.Document, declared string) *SelectorSuggestion {
+	var fallback *SelectorSuggestion
+	for _, selector := range authorCandidates {
+		node := doc.Find(selector).First()
+		value := strings.TrimSpace(node.AttrOr("content", ""))
+		source := SuggestionSourceMeta
+		if !strings.HasPrefix(selector, "meta") {
+			value = strings.Join(strings.Fields(node.Text()), " ")
+			source = SuggestionSourceDOM
+		}
+		if value == "" {
+			continue
+		}
+		if declared != "" && strings.Contains(strings.ToLower(value), strings.ToLower(declared)) {
+			return &SelectorSuggestion{Selector: selector, Sample: value, Source: source, Confidence: ConfidenceHigh}
+		}
+		if fallback == nil {
+			fallback = &SelectorSuggestion{Selector: selector, Sample: value, Source: source, Confidence: ConfidenceMedium}
+		}
+	}
+	return fallback
+}
+
+// densestParagraphBlock returns the element with the most words in its own
+// paragraphs (not nested ones), skipping navigation and other noise.
+func densestParagraphBlock(doc *goquery.Document) (*goquery.Selection, int) {
+	var best *goquery.Selection
+	bestWords := 0
+	doc.Find("div, section, article, main").Each(func(_ int, s *goquery.Selection) {
+		if isDensityNoise(s) {
+			return
+		}
+		words := 0
+		s.ChildrenFiltered("p").Each(func(_ int, p *goquery.Selection) {
+			words += len(strings.Fields(p.Text()))
+		})
+		if words > bestWords {
+			best, bestWords = s, words
+		}
+	})
+	return best, bestWords
+}
+
+// paragraphWords counts the words in a node's paragraphs.
+func paragraphWords(node *goquery.Selection) int {
+	return len(strings.Fields(node.Find("p").Text()))
+}
+
+// paragraphText joins a node's paragraphs, truncated for the sample.
+func paragraphText(node *goquery.Selection) string {
+	parts := make([]string, 0, node.Find("p").Length())
+	node.Find("p").Each(func(_ int, p *goquery.Selection) {
+		if text := strings.TrimSpace(p.Text()); text != "" {
+			parts = append(parts, text)
+		}
+	})
+	return truncate(strings.Join(parts, "\n\n"))
+}
+
+// selectorFor builds a selector for node: its id, or its tag with the class
+// that identifies it on the page, or its tag under its parent's selector.
+func selectorFor(doc *goquery.Document, node *goquery.Selection) string {
+	tag := goquery.NodeName(node)
+	if id := node.AttrOr("id", ""); cssIdentifier.MatchString(id) {
+		return "#" + id
+	}
+
+	var first string
+	for _, class := range strings.Fields(node.AttrOr("class", "")) {
+		if !cssIdentifier.MatchString(class) {
+			continue
+		}
+		selector := tag + "." + class
+		if doc.Find(selector).Length() == 1 {
+			return selector
+		}
+		if first == "" {
+			first = selector
+		}
+	}
+	if first != "" {
+		return first
+	}
+	if doc.Find(tag).Length() == 1 {
+		return tag
+	}
+
+	parent := node.Parent()
+	if parent.Length() == 0 || goquery.NodeName(parent) == "body" {
+		return tag
+	}
+	return selectorFor(doc, parent) + " > " + tag
+}
+
+// readStructuredData reads the article's JSON-LD and OpenGraph metadata.
+func readStructuredData(doc *goquery.Document) StructuredData {
+	data := StructuredData{
+		OGType:  strings.TrimSpace(doc.Find("meta[property='og:type']").AttrOr("content", "")),
+		OGTitle: strings.TrimSpace(doc.Find("meta[property='og:title']").AttrOr("content", "")),
+	}
+	doc.Find("script[type='application/ld+json']").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var parsed any
+		if json.Unmarshal([]byte(s.Text()), &parsed) != nil {
+			return true
+		}
+		article := findJSONLDArticle(parsed)
+		if article == nil {
+			return true
+		}
+		data.JSONLDType = jsonLDType(article)
+		data.Headline = strings.TrimSpace(stringValue(article["headline"]))
+		data.Author = jsonLDAuthor(article["author"])
+		data.DatePublished = strings.TrimSpace(stringValue(article["datePublished"]))
+		return false
+	})
+	if data.Author == "" {
+		data.Author = strings.TrimSpace(doc.Find("meta[property='article:author']").AttrOr("content", ""))
+	}
+	return data
+}
+
+// findJSONLDArticle returns the first article object in a JSON-LD document,
+// searching arrays and @graph.
+func findJSONLDArticle(value any) map[string]any {
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			if article := findJSONLDArticle(item); article != nil {
+				return article
+			}
+		}
+	case map[string]any:
+		switch jsonLDType(v) {
+		case "NewsArticle", "Article", "BlogPosting", "ReportageNewsArticle", "PressRelease":
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJSONLDArticle(graph)
+		}
+	}
+	return nil
+}
+
+// jsonLDType returns an object's @type, or its first type when it has several.
+func jsonLDType(object map[string]any) string {
+	switch t := object["@type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// jsonLDAuthor returns the first author name: a string, a Person, or a list of either.
+func jsonLDAuthor(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		return strings.TrimSpace(stringValue(v["name"]))
+	case []any:
+		for _, item := range v {
+			if name := jsonLDAuthor(item); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// stringValue returns value when it is a string.
+func stringValue(value any) string {
+	s, _ := value.(string)
+	return s
+}
+
+// sameText reports whether a page element shows the declared text. Declared
+// titles often carry a site suffix ("Headline | Site"), so the shorter text may
+// be contained in the longer, as long as it is at least half of it.
+func sameText(text, declared string) bool {
+	shorter := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	longer := strings.ToLower(strings.Join(strings.Fields(declared), " "))
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	return shorter != "" && 2*len(shorter) >= len(longer) && strings.Contains(longer, shorter)
+}
+
+// sameDate reports whether two dates fall on the same day.
+func sameDate(value, declared string) bool {
+	if len(value) < isoDateLen || len(declared) < isoDateLen {
+		return value == declared
+	}
+	return value[:isoDateLen] == declared[:isoDateLen]
+}
+
+// truncate shortens a sample to the preview length.
+func truncate(text string) string {
+	if len(text) > rawTextPreviewLen {
+		return text[:rawTextPreviewLen]
+	}
+	return text
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,275 @@
+package testcrawl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+)
+
+// maxValidationSamples is the number of article pages fetched from a source page.
+const maxValidationSamples = 3
+
+// fallbackArticleLinkSelectors find article links when the source has no list or link selector.
+const fallbackArticleLinkSelectors = "article a[href], h2 a[href], h3 a[href]"
+
+// Selectors are the source selectors validation applies: list selectors find article
+// links on the source page, article selectors extract each sample.
+type Selectors struct {
+	ListContainer   string
+	ArticleCards    string
+	ArticleLink     string
+	Title           string
+	Body            string
+	Container       string
+	PublishedTime   string
+	ExcludeFromList []string
+}
+
+// ArticleSample is what a source's selectors extracted from one article page.
+type ArticleSample struct {
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	Body          string   `json:"body"`
+	PublishedDate string   `json:"published_date"`
+	WordCount     int      `json:"word_count"`
+	Warnings      []string `json:"warnings"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// complete reports whether every field was extracted.
+func (s *ArticleSample) complete() bool {
+	return s.Error == "" && s.Title != "" && s.Body != "" && s.PublishedDate != ""
+}
+
+// ValidationResult is the outcome of validating a source's selectors.
+type ValidationResult struct {
+	URL string `json:"url"`
+	// Success is true when at least one sample has a title and a body.
+	Success bool `json:"success"`
+	// ArticleCount is the number of samples checked.
+	ArticleCount int `json:"article_count"`
+	// SuccessRate is the share of samples with a title, a body and a date.
+	SuccessRate float64         `json:"success_rate"`
+	Warnings    []string        `json:"warnings"`
+	Articles    []ArticleSample `json:"articles"`
+}
+
+// Validate fetches a source page, follows up to three article links found with the
+// list selectors, and applies the article selectors to each, warning about every
+// field that comes back empty. When no article links are found, the source page
+// itself is checked. An error means the source page could not be fetched.
+func (e *Extractor) Validate(ctx context.Context, sourceURL string, selectors Selectors) (*ValidationResult, error) {
+	doc, _, err := e.fetchPage(ctx, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ValidationResult{
+		URL:      sourceURL,
+		Warnings: make([]string, 0),
+		Articles: make([]ArticleSample, 0, maxValidationSamples),
+	}
+
+	links, linkWarning := findArticleLinks(doc, sourceURL, selectors)
+	if linkWarning != "" {
+		result.Warnings = append(result.Warnings, linkWarning)
+	}
+
+	if len(links) == 0 {
+		result.Warnings = append(result.Warnings, "no article links found on the source page; checked the page itself")
+		result.Articles = append(result.Articles, extractSample(doc, sourceURL, selectors))
+	}
+	for _, link := range links {
+		articleDoc, _, fetchErr := e.fetchPage(ctx, link)
+		if fetchErr != nil {
+			e.logger.Debug("Validation sample fetch failed",
+				infralogger.String("url", link),
+				infralogger.Error(fetchErr),
+			)
+			result.Articles = append(result.Articles, ArticleSample{URL: link, Warnings: []string{}, Error: fetchErr.Error()})
+			continue
+		}
+		result.Articles = append(result.Articles, extractSample(articleDoc, link, selectors))
+	}
+
+	complete := 0
+	for i := range result.Articles {
+		sample := &result.Articles[i]
+		if sample.complete() {
+			complete++
+		}
+		if sample.Error == "" && sample.Title != "" && sample.Body != "" {
+			result.Success = true
+		}
+	}
+	result.ArticleCount = len(result.Articles)
+	result.SuccessRate = float64(complete) / float64(result.ArticleCount)
+	result.Warnings = append(result.Warnings, fieldWarnings(result.Articles)...)
+
+	return result, nil
+}
+
+// findArticleLinks returns up to maxValidationSamples same-host article URLs from the
+// source page, and a warning when the configured list selectors matched nothing.
+func findArticleLinks(doc *goquery.Document, sourceURL string, selectors Selectors) ([]string, string) {
+	base, err := url.Parse(sourceURL)
+	if err != nil {
+		return nil, ""
+	}
+
+	scope := doc.Selection
+	var warning string
+	if selectors.ListContainer != "" {
+		if container := doc.Find(selectors.ListContainer); container.Length() > 0 {
+			scope = container
+		} else {
+			warning = fmt.Sprintf("list container selector %q matched nothing", selectors.ListContainer)
+		}
+	}
+	for _, exclude := range selectors.ExcludeFromList {
+		if exclude != "" {
+			scope.Find(exclude).Remove()
+		}
+	}
+
+	var anchors *goquery.Selection
+	switch {
+	case selectors.ArticleCards != "":
+		anchors = scope.Find(selectors.ArticleCards).Find("a[href]")
+		if anchors.Length() == 0 {
+			warning = fmt.Sprintf("article cards selector %q matched no links", selectors.ArticleCards)
+		}
+	case selectors.ArticleLink != "":
+		anchors = scope.Find(selectors.ArticleLink)
+		if anchors.Length() == 0 {
+			warning = fmt.Sprintf("article link selector %q matched nothing", selectors.ArticleLink)
+		}
+	}
+	if anchors == nil || anchors.Length() == 0 {
+		anchors = scope.Find(fallbackArticleLinkSelectors)
+	}
+
+	links := make([]string, 0, maxValidationSamples)
+	seen := map[string]bool{strings.TrimRight(base.String(), "/"): true}
+	anchors.EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		href, ok := a.Attr("href")
+		if !ok {
+			href, _ = a.Find("a[href]").First().Attr("href")
+		}
+		link, resolveErr := base.Parse(strings.TrimSpace(href))
+		if href == "" || resolveErr != nil || link.Hostname() != base.Hostname() {
+			return true
+		}
+		link.Fragment = ""
+		key := strings.TrimRight(link.String(), "/")
+		if seen[key] || !FilterURL(link.String()).Allowed {
+			return true
+		}
+		seen[key] = true
+		links = append(links, link.String())
+		return len(links) < maxValidationSamples
+	})
+	return links, warning
+}
+
+// extractSample applies the article selectors to one page. Only the configured
+// selectors are used, so an empty field points at the selector to fix.
+func extractSample(doc *goquery.Document, pageURL string, selectors Selectors) ArticleSample {
+	sample := ArticleSample{URL: pageURL, Warnings: make([]string, 0)}
+
+	switch {
+	case selectors.Title == "":
+		sample.Warnings = append(sample.Warnings, "no title selector configured")
+	default:
+		sample.Title = strings.TrimSpace(doc.Find(selectors.Title).First().Text())
+		if sample.Title == "" {
+			sample.Warnings = append(sample.Warnings, fmt.Sprintf("title selector %q matched nothing", selectors.Title))
+		}
+	}
+
+	bodySelector := selectors.Body
+	if bodySelector == "" {
+		bodySelector = selectors.Container
+	}
+	switch {
+	case bodySelector == "":
+		sample.Warnings = append(sample.Warnings, "no body selector configured")
+	default:
+		text := extractTextFromSelector(doc, bodySelector)
+		if text == "" && selectors.Container != "" && selectors.Container != bodySelector {
+			text = extractTextFromSelector(doc, selectors.Container)
+		}
+		sample.WordCount = len(strings.Fields(text))
+		if len(text) > rawTextPreviewLen {
+			text = text[:rawTextPreviewLen]
+		}
+		sample.Body = text
+		if sample.Body == "" {
+			sample.Warnings = append(sample.Warnings, fmt.Sprintf("body selector %q matched nothing", bodySelector))
+		}
+	}
+
+	switch {
+	case selectors.PublishedTime == "":
+		sample.Warnings = append(sample.Warnings, "no published time selector configured")
+	default:
+		sample.PublishedDate = extractDate(doc.Find(selectors.PublishedTime).First())
+		if sample.PublishedDate == "" {
+			sample.Warnings = append(sample.Warnings,
+				fmt.Sprintf("published time selector %q matched nothing", selectors.PublishedTime))
+		}
+	}
+
+	return sample
+}
+
+// extractDate reads a date from an element's datetime or content attribute, or its text.
+func extractDate(node *goquery.Selection) string {
+	if node.Length() == 0 {
+		return ""
+	}
+	for _, attr := range []string{"datetime", "content"} {
+		if value, ok := node.Attr(attr); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return strings.TrimSpace(node.Text())
+}
+
+// fieldWarnings summarizes fields that came back empty on every fetched sample.
+func fieldWarnings(samples []ArticleSample) []string {
+	var fetched, titles, bodies, dates int
+	for i := range samples {
+		if samples[i].Error != "" {
+			continue
+		}
+		fetched++
+		if samples[i].Title != "" {
+			titles++
+		}
+		if samples[i].Body != "" {
+			bodies++
+		}
+		if samples[i].PublishedDate != "" {
+			dates++
+		}
+	}
+
+	warnings := make([]string, 0)
+	if fetched == 0 {
+		return append(warnings, "no article page could be fetched")
+	}
+	for _, field := range []struct {
+		name  string
+		count int
+	}{{"title", titles}, {"body", bodies}, {"published date", dates}} {
+		if field.count == 0 {
+			warnings = append(warnings, field.name+" is empty on every sample")
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,277 @@
+// Package webhooks tells subscribers about source changes with signed JSON
+// POSTs carrying the changed source, so other services can react to changes
+// instead of polling the source list.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	infrahttp "github.com/jonesrussell/north-cloud/infrastructure/http"
+	infralogger "github.com/jonesrussell/north-cloud/infrastructure/logger"
+	"github.com/jonesrussell/north-cloud/infrastructure/retry"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+)
+
+// Request headers set on every delivery. The signature scheme is the
+// publisher's, so consumers can verify both with the same code.
+const (
+	// SignatureHeader carries "sha256=<hex HMAC of timestamp + "." + body>"; only sent with a secret.
+	SignatureHeader = "X-North-Cloud-Signature"
+	// TimestampHeader carries the Unix time the request was signed.
+	TimestampHeader = "X-North-Cloud-Timestamp"
+	// EventHeader carries the event type.
+	EventHeader = "X-North-Cloud-Event"
+	// DeliveryHeader carries the event ID, the same on every retry and endpoint.
+	DeliveryHeader = "X-North-Cloud-Delivery"
+)
+
+// Event types.
+const (
+	EventCreated  = "source.created"
+	EventUpdated  = "source.updated"
+	EventDeleted  = "source.deleted"
+	EventEnabled  = "source.enabled"
+	EventDisabled = "source.disabled"
+)
+
+const (
+	// retryDelay is the first backoff delay between attempts.
+	retryDelay = 500 * time.Millisecond
+	// maxRetryDelay caps the backoff delay.
+	maxRetryDelay = 10 * time.Second
+)
+
+// Event is the body of a delivery. Source is the source after the change;
+// for source.deleted it is the source as it was before deletion.
+type Event struct {
+	ID            string         `json:"id"`
+	Type          string         `json:"type"`
+	SourceID      string         `json:"source_id"`
+	OccurredAt    time.Time      `json:"occurred_at"`
+	ChangedFields []string       `json:"changed_fields,omitempty"`
+	Source        *models.Source `json:"source"`
+}
+
+// EndpointStatus is the delivery record of one endpoint since startup.
+type EndpointStatus struct {
+	URL            string     `json:"url"`
+	Delivered      int        `json:"delivered"`
+	Failed         int        `json:"failed"`
+	LastDeliveryAt *time.Time `json:"last_delivery_at,omitempty"`
+	LastFailureAt  *time.Time `json:"last_failure_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// Dispatcher delivers events to every configured endpoint.
+type Dispatcher struct {
+	httpClient  *http.Client
+	urls        []string
+	secret      string
+	timeout     time.Duration
+	maxAttempts int
+	retryDelay  time.Duration
+	now         func() time.Time
+	logger      infralogger.Logger
+
+	mu     sync.Mutex
+	status map[string]*EndpointStatus
+}
+
+// NewDispatcher creates a dispatcher for urls. Deliveries are signed when
+// secret is set; each attempt is bounded by timeout and failed deliveries are
+// retried up to maxAttempts in all.
+func NewDispatcher(urls []string, secret string, timeout time.Duration, maxAttempts int, log infralogger.Logger) *Dispatcher {
+	status := make(map[string]*EndpointStatus, len(urls))
+	for _, endpoint := range urls {
+		status[endpoint] = &EndpointStatus{URL: redact(endpoint)}
+	}
+	return &Dispatcher{
+		httpClient:  infrahttp.NewClient(&infrahttp.ClientConfig{Timeout: timeout}),
+		urls:        urls,
+		secret:      secret,
+		timeout:     timeout,
+		maxAttempts: max(maxAttempts, 1),
+		retryDelay:  retryDelay,
+		now:         time.Now,
+		logger:      log,
+		status:      status,
+	}
+}
+
+// Enabled reports whether any endpoint is configured. A nil dispatcher is disabled.
+func (d *Dispatcher) Enabled() bool {
+	return d != nil && len(d.urls) > 0
+}
+
+// Notify delivers an event about source in the background. changed lists the
+// top-level fields an update changed, when known. It is a no-op on a disabled
+// dispatcher.
+func (d *Dispatcher) Notify(eventType string, source *models.Source, changed []string) {
+	if !d.Enabled() || source == nil {
+		return
+	}
+
+	event := Event{
+		ID:            uuid.NewString(),
+		Type:          eventType,
+		SourceID:      source.ID,
+		OccurredAt:    d.now().UTC(),
+		ChangedFields: changed,
+		Source:        source,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), d.timeout*time.Duration(d.maxAttempts)+maxRetryDelay)
+		defer cancel()
+		_ = d.Deliver(ctx, event)
+	}()
+}
+
+// Deliver sends event to every endpoint, retrying 5xx, 429 and network errors,
+// and returns the endpoints' errors joined.
+func (d *Dispatcher) Deliver(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal webhook event: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(d.urls))
+	for i, endpoint := range d.urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.deliverTo(ctx, endpoint, event, body)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// deliverTo sends one event to one endpoint with retries and records the outcome.
+func (d *Dispatcher) deliverTo(ctx context.Context, endpoint string, event Event, body []byte) error {
+	retryCfg := retry.Config{
+		MaxAttempts:  d.maxAttempts,
+		InitialDelay: d.retryDelay,
+		MaxDelay:     maxRetryDelay,
+		Multiplier:   2.0,
+		IsRetryable:  isRetryable,
+	}
+	err := retry.Retry(ctx, retryCfg, func() error {
+		return d.post(ctx, endpoint, event, body)
+	})
+	d.record(endpoint, err)
+
+	if err != nil {
+		d.logger.Warn("Source webhook delivery failed",
+			infralogger.String("url", redact(endpoint)),
+			infralogger.String("event", event.Type),
+			infralogger.String("source_id", event.SourceID),
+			infralogger.Error(err),
+		)
+		return fmt.Errorf("deliver to %s: %w", redact(endpoint), err)
+	}
+	return nil
+}
+
+// post makes one delivery attempt.
+func (d *Dispatcher) post(ctx context.Context, endpoint string, event Event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.ID)
+	if d.secret != "" {
+		timestamp := strconv.FormatInt(d.now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(d.secret, timestamp, body))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if statusErr := infrahttp.CheckResponse(resp); statusErr != nil {
+		return statusErr
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// record updates an endpoint's delivery status.
+func (d *Dispatcher) record(endpoint string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status := d.status[endpoint]
+	now := d.now().UTC()
+	if err != nil {
+		status.Failed++
+		status.LastFailureAt = &now
+		status.LastError = err.Error()
+		return
+	}
+	status.Delivered++
+	status.LastDeliveryAt = &now
+}
+
+// Status returns each endpoint's delivery record, in configuration order.
+func (d *Dispatcher) Status() []EndpointStatus {
+	if d == nil {
+		return []EndpointStatus{}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	statuses := make([]EndpointStatus, 0, len(d.urls))
+	for _, endpoint := range d.urls {
+		statuses = append(statuses, *d.status[endpoint])
+	}
+	return statuses
+}
+
+// Sign returns the signature header value for a body. Consumers recompute it
+// over "<timestamp>.<raw body>" to verify a request.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// isRetryable retries server errors, rate limiting and transport failures.
+func isRetryable(err error) bool {
+	var statusErr *infrahttp.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return retry.DefaultIsRetryable(err) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// redact hides credentials in an endpoint URL for logs and status.
+func redact(endpoint string) string {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	return parsed.Redacted()
+}
@@ -0,0 +1,91 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonesrussell/north-cloud/source-manager/internal/models"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/testhelpers"
+	"github.com/jonesrussell/north-cloud/source-manager/internal/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_DeliverSignsEvent(t *testing.T) {
+	var received webhooks.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		timestamp := r.Header.Get(webhooks.TimestampHeader)
+		assert.Equal(t, webhooks.Sign("s3cret", timestamp, body), r.Header.Get(webhooks.SignatureHeader))
+		assert.Equal(t, webhooks.EventUpdated, r.Header.Get(webhooks.EventHeader))
+		assert.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher := webhooks.NewDispatcher([]string{server.URL}, "s3cret", time.Second, 1, testhelpers.NewTestLogger())
+	event := webhooks.Event{
+		ID:            "evt-1",
+		Type:          webhooks.EventUpdated,
+		SourceID:      "src-1",
+		ChangedFields: []string{"url"},
+		Source:        &models.Source{ID: "src-1", Name: "Example News", URL: "https://example.com"},
+	}
+	require.NoError(t, dispatcher.Deliver(context.Background(), event))
+
+	assert.Equal(t, "https://example.com", received.Source.URL)
+	assert.Equal(t, []string{"url"}, received.ChangedFields)
+	status := dispatcher.Status()
+	require.Len(t, status, 1)
+	assert.Equal(t, 1, status[0].Delivered)
+}
+
+func TestDispatcher_DeliverRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dispatcher := webhooks.NewDispatcher([]string{server.URL}, "", time.Second, 2, testhelpers.NewTestLogger())
+	event := webhooks.Event{ID: "evt-1", Type: webhooks.EventCreated, Source: &models.Source{ID: "src-1"}}
+
+	require.NoError(t, dispatcher.Deliver(context.Background(), event))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestDispatcher_DeliverRecordsClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	dispatcher := webhooks.NewDispatcher([]string{server.URL}, "", time.Second, 3, testhelpers.NewTestLogger())
+	event := webhooks.Event{ID: "evt-1", Type: webhooks.EventDeleted, Source: &models.Source{ID: "src-1"}}
+
+	require.Error(t, dispatcher.Deliver(context.Background(), event))
+	assert.Equal(t, int32(1), calls.Load(), "4xx responses are not retried")
+	status := dispatcher.Status()
+	assert.Equal(t, 1, status[0].Failed)
+	assert.Contains(t, status[0].LastError, "400")
+}
+
+func TestDispatcher_Disabled(t *testing.T) {
+	var dispatcher *webhooks.Dispatcher
+	assert.False(t, dispatcher.Enabled())
+	dispatcher.Notify(webhooks.EventCreated, &models.Source{ID: "src-1"}, nil)
+	assert.Empty(t, dispatcher.Status())
+}
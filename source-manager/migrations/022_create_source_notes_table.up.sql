@@ -0,0 +1,16 @@
+-- Notes attached to a source by operators or services (e.g. crawler section suggestions).
+-- dedupe_key lets services re-file the same finding without creating duplicates.
+CREATE TABLE IF NOT EXISTS source_notes (
+    id VARCHAR(36) PRIMARY KEY,
+    source_id VARCHAR(36) NOT NULL REFERENCES sources(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL DEFAULT 'note',
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    details JSONB,
+    dedupe_key VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_source_notes_source ON source_notes(source_id, created_at DESC);
+CREATE UNIQUE INDEX idx_source_notes_dedupe ON source_notes(source_id, dedupe_key) WHERE dedupe_key IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Versioned history of source configuration. Each row is the source as it was
+-- after a change, with the field-level diff from the previous version, so a bad
+-- selector edit can be found and rolled back.
+CREATE TABLE IF NOT EXISTS source_versions (
+    id VARCHAR(36) PRIMARY KEY,
+    source_id VARCHAR(36) NOT NULL REFERENCES sources(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (source_id, version)
+);
+
+CREATE INDEX idx_source_versions_source ON source_versions(source_id, version DESC);
@@ -0,0 +1,20 @@
+-- Credentials a source needs to be crawled (HTTP basic auth, API key header, login cookie).
+-- Each value is encrypted with its own data key (ciphertext); the data key is encrypted
+-- with the master key identified by key_id (wrapped_key). hint is the clear tail used
+-- to mask the value in API responses.
+CREATE TABLE IF NOT EXISTS source_credentials (
+    id VARCHAR(36) PRIMARY KEY,
+    source_id VARCHAR(36) NOT NULL REFERENCES sources(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('basic_auth', 'api_key', 'cookie')),
+    username VARCHAR(255),
+    header_name VARCHAR(100),
+    ciphertext BYTEA NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    key_id VARCHAR(16) NOT NULL,
+    hint VARCHAR(4) NOT NULL DEFAULT '',
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    rotated_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (source_id, kind)
+);
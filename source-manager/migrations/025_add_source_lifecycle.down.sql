@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sources_lifecycle;
+ALTER TABLE sources DROP COLUMN IF EXISTS lifecycle_changed_at;
+ALTER TABLE sources DROP COLUMN IF EXISTS lifecycle;
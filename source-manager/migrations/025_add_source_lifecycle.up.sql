@@ -0,0 +1,16 @@
+-- Source lifecycle: draft (onboarding, never crawled), active (crawled), paused
+-- (temporarily not crawled) and archived (retired; its indexes age out).
+-- enabled stays as the flag other services read and is kept equal to
+-- lifecycle = 'active'. lifecycle_changed_at is when the state last changed,
+-- which for archived sources starts the index retention period.
+ALTER TABLE sources ADD COLUMN lifecycle VARCHAR(20) NOT NULL DEFAULT 'active'
+    CHECK (lifecycle IN ('draft', 'active', 'paused', 'archived'));
+ALTER TABLE sources ADD COLUMN lifecycle_changed_at TIMESTAMP WITH TIME ZONE;
+
+-- Backfill: disabled sources are paused
+UPDATE sources
+SET lifecycle = 'paused',
+    lifecycle_changed_at = COALESCE(disabled_at, updated_at, NOW())
+WHERE enabled = false;
+
+CREATE INDEX idx_sources_lifecycle ON sources(lifecycle);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sources_type;
+DROP INDEX IF EXISTS idx_sources_url_trgm;
+DROP INDEX IF EXISTS idx_sources_name_trgm;
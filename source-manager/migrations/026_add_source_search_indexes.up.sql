@@ -0,0 +1,8 @@
+-- Indexes for the source list filters: trigram indexes let the name/url
+-- search (ILIKE '%term%') and the domain filter (url ~* pattern) use an index
+-- instead of scanning every source; type is filtered by equality.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_sources_name_trgm ON sources USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_sources_url_trgm ON sources USING gin (url gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_sources_type ON sources(type);